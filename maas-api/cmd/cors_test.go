@@ -8,6 +8,8 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
 )
 
 func TestIsLocalhostOrigin(t *testing.T) {
@@ -173,3 +175,90 @@ func TestNoCORS_WhenDebugModeDisabled(t *testing.T) {
 	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"),
 		"CORS headers should not be present when debug mode is off")
 }
+
+func newConfiguredCORSTestRouter(cfg *config.Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(cors.New(corsConfig(cfg)))
+	router.OPTIONS("/*path", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/test", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return router
+}
+
+func corsRequest(router *gin.Engine, method, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/test", nil)
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization")
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_AllowsConfiguredOrigins(t *testing.T) {
+	cfg := &config.Config{
+		CORSAllowedOrigins: []string{"https://dashboard.example.com", "https://*.apps.example.com"},
+		CORSAllowedMethods: []string{"GET", "DELETE"},
+		CORSAllowedHeaders: []string{"Authorization"},
+	}
+	router := newConfiguredCORSTestRouter(cfg)
+
+	for _, origin := range []string{"https://dashboard.example.com", "https://spa.apps.example.com"} {
+		t.Run(origin, func(t *testing.T) {
+			w := corsRequest(router, http.MethodGet, origin)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+
+			w = corsRequest(router, http.MethodOptions, origin)
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "GET,DELETE", w.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, "Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+		})
+	}
+
+	for _, origin := range []string{"https://attacker.example.org", "http://dashboard.example.com", "http://localhost:3000"} {
+		t.Run(origin, func(t *testing.T) {
+			w := corsRequest(router, http.MethodGet, origin)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestCORS_DebugModeAlsoAllowsLocalhost(t *testing.T) {
+	router := newConfiguredCORSTestRouter(&config.Config{
+		DebugMode:          true,
+		CORSAllowedOrigins: []string{"https://dashboard.example.com"},
+		CORSAllowedMethods: []string{"GET"},
+	})
+
+	assert.Equal(t, "http://localhost:3000", corsRequest(router, http.MethodGet, "http://localhost:3000").Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "https://dashboard.example.com", corsRequest(router, http.MethodGet, "https://dashboard.example.com").Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	router := newConfiguredCORSTestRouter(&config.Config{
+		CORSAllowedOrigins: []string{"*"},
+		CORSAllowedMethods: []string{"GET"},
+	})
+
+	w := corsRequest(router, http.MethodGet, "https://anywhere.example.org")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	router := newConfiguredCORSTestRouter(&config.Config{
+		CORSAllowedOrigins:   []string{"https://dashboard.example.com"},
+		CORSAllowedMethods:   []string{"GET"},
+		CORSAllowCredentials: true,
+	})
+
+	w := corsRequest(router, http.MethodGet, "https://dashboard.example.com")
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+}
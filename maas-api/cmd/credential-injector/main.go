@@ -0,0 +1,160 @@
+// Command credential-injector runs the credential injector, the Envoy external processor
+// that sets the provider API key of an ExternalModel, read from its credentialRef Secret,
+// on the requests the gateway forwards to the provider. maas-controller deploys it in
+// the gateway namespace.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/env"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/credinject"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+const (
+	defaultPort = 9004
+
+	// defaultSecretTTL is how long a credentialRef Secret is used before it is read again.
+	defaultSecretTTL = 30 * time.Second
+)
+
+// externalModelRoutes selects the routes the ExternalModel reconciler creates.
+var externalModelRoutes = credinject.ManagedByLabel + "=" + credinject.ManagedByValue
+
+// routeResources are the kinds of the routes of ExternalModels. Those not served by the
+// cluster are skipped. The VirtualServices of the istio routing provider of maas-controller
+// are not served: no AuthPolicy authenticates the requests they route.
+var routeResources = []schema.GroupVersionResource{
+	{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
+}
+
+func main() {
+	if err := serve(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func serve() error {
+	debugMode, _ := env.GetBool("DEBUG_MODE", false)
+	port, _ := env.GetInt("PORT", defaultPort)
+
+	log := logger.New(debugMode)
+	defer func() {
+		if err := log.Sync(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to sync logger: %v\n", err)
+		}
+	}()
+
+	restConfig, err := config.LoadRestConfig()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes config: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+	metadataClient, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata client: %w", err)
+	}
+
+	// Only the metadata of the routes is cached, for the routes of ExternalModels.
+	routeFactory := metadatainformer.NewFilteredSharedInformerFactory(metadataClient, constant.DefaultResyncPeriod, metav1.NamespaceAll,
+		func(o *metav1.ListOptions) { o.LabelSelector = externalModelRoutes })
+	var routeListers []cache.GenericLister
+	var synced []cache.InformerSynced
+	for _, gvr := range routeResources {
+		served, err := isServed(clientset, gvr)
+		if err != nil {
+			return err
+		}
+		if !served {
+			log.Info("Skipping routes not served by the cluster", "gvr", gvr.String())
+			continue
+		}
+		informer := routeFactory.ForResource(gvr)
+		routeListers = append(routeListers, informer.Lister())
+		synced = append(synced, informer.Informer().HasSynced)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	routeFactory.Start(stopCh)
+	// Requests are rejected by the gateway until the injector serves, rather than sent
+	// without the provider key.
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		return errors.New("failed to sync the route cache")
+	}
+
+	// Secrets are not watched: the injector may only get the credentialRef Secrets, through
+	// the Roles the ExternalModel reconciler creates in the ExternalModel namespaces.
+	secrets := credinject.NewCachingSecretGetter(clientset.CoreV1(), defaultSecretTTL)
+	injector := credinject.NewServer(log, credinject.NewListerResolver(routeListers, secrets))
+
+	grpcSrv := grpc.NewServer()
+	extprocv3.RegisterExternalProcessorServer(grpcSrv, injector)
+	healthpb.RegisterHealthServer(grpcSrv, health.NewServer())
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info("Credential injector starting", "port", port)
+		serverErr <- grpcSrv.Serve(listener)
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("credential injector failed: %w", err)
+	case <-quit:
+		log.Info("Shutdown signal received, shutting down credential injector...")
+	}
+
+	grpcSrv.GracefulStop()
+	log.Info("Credential injector exited gracefully")
+	return nil
+}
+
+// isServed reports whether the cluster serves the resource gvr.
+func isServed(clientset kubernetes.Interface, gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to discover %s: %w", gvr.GroupVersion().String(), err)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,15 +12,21 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/admin"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/auth"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/authpolicy"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/billing"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
@@ -26,8 +34,11 @@ import (
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/openapi"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tracing"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
 )
 
 func main() {
@@ -55,6 +66,21 @@ func serve() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if cfg.TracingEnabled {
+		shutdownTracing, err := tracing.Setup(ctx, log)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFlush()
+			if err := shutdownTracing(flushCtx); err != nil {
+				log.Error("Failed to flush traces", "error", err)
+			}
+		}()
+		log.Info("OpenTelemetry tracing enabled")
+	}
+
 	metricsRegistry := prometheus.NewRegistry()
 
 	cluster, err := config.NewClusterConfig(cfg.Namespace, cfg.MaaSSubscriptionNamespace, constant.DefaultResyncPeriod, cfg.SARCacheMaxSize, metricsRegistry, log)
@@ -83,7 +109,12 @@ func serve() error {
 	// Recovery must be first to catch panics from subsequent middleware
 	router.Use(gin.Recovery())
 	router.Use(middleware.RequestID())
-	router.Use(middleware.AccessLogger())
+	router.Use(tracing.Middleware("/health", "/healthz", "/readyz"))
+	if cfg.AccessLogFormat == config.AccessLogText {
+		router.Use(middleware.AccessLogger())
+	} else {
+		router.Use(middleware.StructuredAccessLogger(log, "/health", "/healthz", "/readyz"))
+	}
 
 	// Add metrics middleware
 	metricsRecorder, err := metrics.NewPrometheusRecorder(metricsRegistry)
@@ -91,6 +122,11 @@ func serve() error {
 		return fmt.Errorf("failed to create metrics recorder: %w", err)
 	}
 	router.Use(metrics.NewMiddleware(metricsRecorder))
+	sloRoutes := make(map[string]metrics.SLO, len(cfg.SLORoutes))
+	for route, objective := range cfg.SLORoutes {
+		sloRoutes[route] = toSLO(objective)
+	}
+	router.Use(metrics.NewSLOMiddleware(metricsRecorder, toSLO(cfg.SLO), sloRoutes, "/health", "/healthz", "/readyz"))
 
 	// Start metrics server
 	metricsSrv, err := metrics.NewMetricsServer(cfg.MetricsAddress(), metricsRegistry)
@@ -103,11 +139,19 @@ func serve() error {
 		metricsErr <- metricsSrv.ListenAndServe()
 	}()
 
-	if cfg.DebugMode {
+	if len(cfg.CORSAllowedOrigins) > 0 {
+		log.Info("CORS policy active", "origins", cfg.CORSAllowedOrigins, "credentials", cfg.CORSAllowCredentials)
+		router.Use(cors.New(corsConfig(cfg)))
+	} else if cfg.DebugMode {
 		log.Warn("Debug CORS policy active: allowing localhost origins only")
 		router.Use(cors.New(debugCORSConfig()))
 	}
 
+	// The request rules are those of the routes documented by registerRoutes, and must
+	// apply to the routes registered from now on.
+	apiDoc := newAPIDocument()
+	router.Use(apiDoc.ValidateRequests(cfg.MaxRequestBodyBytes))
+
 	router.OPTIONS("/*path", func(c *gin.Context) { c.Status(204) })
 
 	store, err := initStore(ctx, log, cfg)
@@ -120,10 +164,30 @@ func serve() error {
 		}
 	}()
 
-	if err = registerHandlers(ctx, log, router, cfg, cluster, store); err != nil {
+	if err := metricsRegistry.Register(collectors.NewDBStatsCollector(store.DB(), "maas_api")); err != nil {
+		return fmt.Errorf("failed to register database pool metrics: %w", err)
+	}
+
+	router.GET("/health", handlers.NewHealthHandler().HealthCheck)
+	healthHandler := handlers.NewHealthHandler(healthDependencies(cfg, cluster, store)...)
+	router.GET("/healthz", healthHandler.Liveness)
+	router.GET("/readyz", healthHandler.Readiness)
+
+	usageStore := usage.NewPostgresStore(store.DB(), log, cfg.TenantName)
+	auditStore := audit.NewPostgresStore(store.DB(), log, cfg.TenantName)
+
+	if err = registerHandlers(ctx, log, router, apiDoc, cfg, cluster, store, usageStore, auditStore, metricsRecorder); err != nil {
 		return fmt.Errorf("failed to register handlers: %w", err)
 	}
 
+	if cfg.BillingExportPeriod != "" {
+		exporter, err := newBillingExporter(log, cfg, cluster, usageStore, billing.NewPostgresStateStore(store.DB(), cfg.TenantName))
+		if err != nil {
+			return fmt.Errorf("failed to configure billing exports: %w", err)
+		}
+		exporter.Start(ctx)
+	}
+
 	srv, err := newServer(cfg, router)
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
@@ -149,44 +213,63 @@ func serve() error {
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			return fmt.Errorf("metrics server failed: %w", err)
 		}
-	case <-quit:
-		log.Info("Shutdown signal received, shutting down server...")
+	case sig := <-quit:
+		// A second signal terminates the process without waiting for the drain.
+		signal.Stop(quit)
+		delay := time.Duration(cfg.ShutdownDelaySeconds) * time.Second
+		log.Info("Shutdown signal received, failing readiness before draining the server", "signal", sig.String(), "delay", delay)
+		healthHandler.StartShutdown()
+		time.Sleep(delay)
 	}
 
-	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancelShutdown()
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
-	}
+	drain(log, srv, time.Duration(cfg.ShutdownTimeoutSeconds)*time.Second)
 
-	if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
+	metricsCtx, cancelMetrics := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelMetrics()
+	if err := metricsSrv.Shutdown(metricsCtx); err != nil {
 		log.Error("Metrics server forced to shutdown", "error", err)
 	}
 
+	// Stop the informers and background workers before the deferred close of the
+	// database they write to.
+	cancel()
+
 	log.Info("Server exited gracefully")
 	return nil
 }
 
 // initStore creates the PostgreSQL store for API key management.
 // DBConnectionURL is validated in cfg.Validate() before this is called.
-func initStore(ctx context.Context, log *logger.Logger, cfg *config.Config) (api_keys.MetadataStore, error) { //nolint:ireturn // Returns MetadataStore interface by design.
+// The usage store shares its database connection.
+func initStore(ctx context.Context, log *logger.Logger, cfg *config.Config) (*api_keys.PostgresStore, error) {
 	log.Info("Connecting to PostgreSQL database...", "tenant", cfg.TenantName)
 	return api_keys.NewPostgresStoreFromURL(ctx, log, cfg.DBConnectionURL, cfg.TenantName)
 }
 
-func registerHandlers(ctx context.Context, log *logger.Logger, router *gin.Engine, cfg *config.Config, cluster *config.ClusterConfig, store api_keys.MetadataStore) error {
-	router.GET("/health", handlers.NewHealthHandler().HealthCheck)
+// healthDependencies are the dependencies checked by /readyz: the database, the informer
+// caches and, when KEYCLOAK_READINESS_URL is set, Keycloak. A stopped informer also fails
+// /healthz, since only a restart starts it again.
+func healthDependencies(cfg *config.Config, cluster *config.ClusterConfig, store *api_keys.PostgresStore) []handlers.Dependency {
+	dependencies := []handlers.Dependency{
+		{Name: "database", Check: store.DB().PingContext},
+		{Name: "informers", Liveness: true, Check: cluster.CachesSynced},
+	}
+	if cfg.KeycloakReadinessURL != "" {
+		client := &http.Client{Timeout: handlers.DefaultDependencyTimeout}
+		dependencies = append(dependencies, handlers.Dependency{Name: "keycloak", Check: handlers.HTTPCheck(client, cfg.KeycloakReadinessURL)})
+	}
+	return dependencies
+}
 
+func registerHandlers(ctx context.Context, log *logger.Logger, router *gin.Engine, apiDoc *openapi.Document, cfg *config.Config, cluster *config.ClusterConfig, store api_keys.MetadataStore, usageStore usage.Store, auditStore audit.Store, metricsRecorder *metrics.PrometheusRecorder) error {
 	log.Info("Starting informers and waiting for cache sync...")
 	if !cluster.StartAndWaitForSync(ctx.Done()) {
 		return errors.New("failed to sync informer caches")
 	}
 	log.Info("Informer caches synced successfully")
 
-	v1Routes := router.Group("/v1")
-
 	authPolicyChecker := authpolicy.NewChecker(log, cluster.MaaSAuthPolicyLister)
-	subscriptionSelector := subscription.NewSelector(log, cluster.MaaSSubscriptionLister, cluster.MaaSModelRefLister, authPolicyChecker)
+	subscriptionSelector := subscription.NewSelector(log, cluster.MaaSSubscriptionLister, cluster.MaaSModelRefLister, authPolicyChecker, cluster.GroupMapper)
 
 	resolveCtx, resolveCancel := context.WithTimeout(ctx, time.Duration(cfg.AccessCheckTimeoutSeconds)*time.Second)
 	gatewayInternalHost, err := config.ResolveGatewayInternalHost(resolveCtx, cluster.ClientSet, cfg.GatewayName, cfg.GatewayNamespace)
@@ -206,39 +289,213 @@ func registerHandlers(ctx context.Context, log *logger.Logger, router *gin.Engin
 	if err != nil {
 		log.Fatal("Failed to create model manager", "error", err)
 	}
+	modelManager.SetProbeMetrics(metricsRecorder)
 
 	tokenHandler := token.NewHandler(log, cfg.TenantName)
 	modelsHandler := handlers.NewModelsHandler(log, modelManager, subscriptionSelector, cluster.MaaSModelRefLister)
+	if cfg.PerformanceWindowMinutes > 0 {
+		window := time.Duration(cfg.PerformanceWindowMinutes) * time.Minute
+		modelsHandler.SetPerformanceSource(usage.NewPerformanceTracker(log, usageStore, window))
+	}
 	subscriptionHandler := subscription.NewHandler(log, subscriptionSelector)
 
 	apiKeyService := api_keys.NewServiceWithLogger(store, cfg, subscriptionSelector, log)
+	apiKeyService.SetValidationMetrics(metricsRecorder)
 	apiKeyService.StartDebounceCleanup(ctx)
+	auditLog := audit.NewLog(log, auditStore)
+	auditLog.SetMisuseDetection(cfg.KeyMisuseThreshold, time.Duration(cfg.KeyMisuseWindowSeconds)*time.Second)
+	if cfg.SecurityEventsAddress != "" {
+		forwarder, err := newSecurityEventForwarder(log, cfg)
+		if err != nil {
+			return err
+		}
+		forwarder.SetMetrics(metricsRecorder)
+		forwarder.Start(ctx)
+		auditLog.SetForwarder(forwarder)
+		log.Info("Forwarding security events", "address", cfg.SecurityEventsAddress,
+			"protocol", cfg.SecurityEventsProtocol, "format", cfg.SecurityEventsFormat)
+	}
+	auditHandler := audit.NewHandler(log, auditStore, auditLog, cluster.AdminChecker)
 	apiKeyHandler := api_keys.NewHandler(log, apiKeyService, cluster.AdminChecker)
+	apiKeyHandler.SetAuditLog(auditLog)
+	usageHandler := usage.NewHandler(log, usageStore, cluster.AdminChecker)
+	usageHandler.SetAuditLog(auditLog)
+	usageHandler.SetKeyOwners(apiKeyService)
+	if cfg.AccessLogShipperServiceAccount != "" {
+		namespace, name, err := cfg.AccessLogShipper()
+		if err != nil {
+			return err
+		}
+		usageHandler.SetShipperAuthenticator(auth.NewServiceAccountAuthenticator(cluster.ClientSet, namespace, name))
+		log.Info("Accepting access logs from the log shipper", "serviceAccount", namespace+"/"+name)
+	}
+	chargebackHandler := billing.NewChargebackHandler(log, usageStore, cluster.MaaSSubscriptionLister, cluster.AdminChecker)
+	chargebackHandler.SetAuditLog(auditLog)
+	adminHandler := admin.NewHandler(log, apiKeyService, usageStore, subscriptionSelector, cluster.AdminChecker)
+	adminHandler.SetAuditLog(auditLog)
+	estimateHandler := billing.NewEstimateHandler(log, subscriptionSelector)
+	var inferenceHandler *handlers.InferenceHandler
+	if cfg.InferenceProxy {
+		tlsConfig, err := models.BuildClusterTLSConfig(log)
+		if err != nil {
+			return fmt.Errorf("failed to build the inference proxy TLS config: %w", err)
+		}
+		gatewayTransport := models.NewGatewayTransport(tlsConfig, gatewayInternalHost)
+		// The Accept-Encoding of the client is forwarded, rather than gzip responses being
+		// decompressed by the proxy, which holds back streamed events.
+		gatewayTransport.DisableCompression = true
+		// Requests carry the trace context to the models.
+		transport := tracing.Transport(gatewayTransport)
+		inferenceHandler = handlers.NewInferenceHandler(log, apiKeyService, subscriptionSelector, cluster.MaaSModelRefLister, transport)
+		log.Info("Proxying inference requests to the models", "gatewayInternalHost", gatewayInternalHost)
+	}
 
-	v1Routes.GET("/models", tokenHandler.ExtractUserInfo(), modelsHandler.ListLLMs)
+	if cfg.LimitadorURL == "" {
+		log.Info("LIMITADOR_URL not set - token usage will not be collected")
+	} else {
+		limitador := usage.NewLimitadorClient(cfg.LimitadorURL, 10*time.Second)
+		usage.NewCollector(log, usageStore, limitador, cluster.MaaSModelRefLister, cluster.MaaSSubscriptionLister,
+			time.Duration(cfg.UsageScrapeIntervalSeconds)*time.Second).Start(ctx)
+		log.Info("Collecting token usage from Limitador", "url", cfg.LimitadorURL, "intervalSeconds", cfg.UsageScrapeIntervalSeconds)
+	}
 
-	// Subscription listing routes
-	v1Routes.GET("/subscriptions", tokenHandler.ExtractUserInfo(), subscriptionHandler.ListSubscriptions)
-	v1Routes.GET("/model/:model-id/subscriptions", tokenHandler.ExtractUserInfo(), subscriptionHandler.ListSubscriptionsForModel)
+	retention := usage.RetentionPolicy{HourlyDays: cfg.UsageHourlyRetentionDays, DailyMonths: cfg.UsageDailyRetentionMonths}
+	if retention != (usage.RetentionPolicy{}) {
+		usage.NewCompactor(log, usageStore, retention).Start(ctx)
+		log.Info("Compacting usage", "hourlyRetentionDays", retention.HourlyDays, "dailyRetentionMonths", retention.DailyMonths)
+	}
 
-	// API Key routes - Complete CRUD for hash-based key architecture
-	apiKeyRoutes := v1Routes.Group("/api-keys", tokenHandler.ExtractUserInfo())
-	apiKeyRoutes.GET("/config", apiKeyHandler.GetAPIKeyConfig)         // Get API key limits
-	apiKeyRoutes.POST("", apiKeyHandler.CreateAPIKey)                  // Create hash-based key
-	apiKeyRoutes.POST("/search", apiKeyHandler.SearchAPIKeys)          // Search keys with filtering, sorting, and pagination
-	apiKeyRoutes.POST("/bulk-revoke", apiKeyHandler.BulkRevokeAPIKeys) // Bulk revoke keys
-	apiKeyRoutes.GET("/:id", apiKeyHandler.GetAPIKey)                  // Get specific key
-	apiKeyRoutes.DELETE("/:id", apiKeyHandler.RevokeAPIKey)            // Revoke specific key
+	var eventSinks []usage.EventSink
+	if cfg.UsageEventsHTTPURL != "" {
+		eventSinks = append(eventSinks, usage.NewHTTPSink(cfg.UsageEventsHTTPURL, cfg.UsageEventsHTTPBatch, 10*time.Second))
+		log.Info("Sending usage events to HTTP sink", "url", cfg.UsageEventsHTTPURL, "batch", cfg.UsageEventsHTTPBatch)
+	}
+	if cfg.UsageEventsKafkaBridgeURL != "" {
+		eventSinks = append(eventSinks, usage.NewKafkaBridgeSink(cfg.UsageEventsKafkaBridgeURL, cfg.UsageEventsKafkaTopic, 10*time.Second))
+		log.Info("Sending usage events to Kafka", "bridgeUrl", cfg.UsageEventsKafkaBridgeURL, "topic", cfg.UsageEventsKafkaTopic)
+	}
+	if len(eventSinks) > 0 {
+		eventPublisher := usage.NewEventPublisher(log, "/maas-api/"+cfg.TenantName, eventSinks...)
+		eventPublisher.SetMetrics(metricsRecorder)
+		eventPublisher.Start(ctx)
+		usageHandler.SetEventPublisher(eventPublisher)
+	}
+	if cfg.UsageAnomalySpikeFactor > 0 || cfg.UsageAnomalyNewNetwork || cfg.UsageAnomalyBusinessHours != "" {
+		anomalyDetector, err := newAnomalyDetector(log, cfg, usageStore)
+		if err != nil {
+			return err
+		}
+		usageHandler.SetAnomalyDetector(anomalyDetector)
+		log.Info("Detecting API key usage anomalies", "spikeFactor", cfg.UsageAnomalySpikeFactor,
+			"newNetwork", cfg.UsageAnomalyNewNetwork, "businessHours", cfg.UsageAnomalyBusinessHours,
+			"webhookUrl", cfg.UsageAnomalyWebhookURL)
+	}
 
-	// Internal routes (no auth required - called by Authorino / CronJob)
-	internalRoutes := router.Group("/internal/v1")
-	internalRoutes.POST("/api-keys/validate", apiKeyHandler.ValidateAPIKeyHandler)
-	internalRoutes.POST("/api-keys/cleanup", apiKeyHandler.CleanupExpiredEphemeralKeys)
-	internalRoutes.POST("/subscriptions/select", subscriptionHandler.SelectSubscription)
+	registerRoutes(apiDoc, router, routeHandlers{
+		token:        tokenHandler,
+		models:       modelsHandler,
+		subscription: subscriptionHandler,
+		apiKey:       apiKeyHandler,
+		usage:        usageHandler,
+		chargeback:   chargebackHandler,
+		estimate:     estimateHandler,
+		audit:        auditHandler,
+		admin:        adminHandler,
+		inference:    inferenceHandler,
+	})
+	router.GET("/openapi.json", apiDoc.ServeJSON)
+	if cfg.SwaggerUI {
+		router.GET("/swagger", openapi.SwaggerUI("Models as a Service API", "openapi.json", cfg.SwaggerUIAssetsURL))
+	}
 
 	return nil
 }
 
+// newBillingExporter creates the exporter of the usage per period to the configured
+// directory and S3 bucket. It needs the informer caches, synced by registerHandlers.
+func newBillingExporter(log *logger.Logger, cfg *config.Config, cluster *config.ClusterConfig, usageStore usage.Store, state billing.StateStore) (*billing.Exporter, error) {
+	var destinations []billing.Destination
+	if cfg.BillingExportDir != "" {
+		destinations = append(destinations, billing.NewDirDestination(cfg.BillingExportDir))
+	}
+	if cfg.BillingExportS3Bucket != "" {
+		s3, err := billing.NewS3Destination(cfg.BillingExportS3Bucket, cfg.BillingExportS3Prefix, cfg.BillingExportS3Region, cfg.BillingExportS3Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, s3)
+	}
+	formats := make([]billing.Format, 0, len(cfg.BillingExportFormats))
+	for _, f := range cfg.BillingExportFormats {
+		formats = append(formats, billing.Format(f))
+	}
+	for _, d := range destinations {
+		log.Info("Exporting billing periods", "period", cfg.BillingExportPeriod, "formats", cfg.BillingExportFormats, "destination", d.Name())
+	}
+	return billing.NewExporter(log, cfg.TenantName, billing.Period(cfg.BillingExportPeriod), formats,
+		usageStore, cluster.MaaSSubscriptionLister, state, destinations...), nil
+}
+
+// toSLO returns the metrics SLO of a configured objective.
+func toSLO(o config.SLOObjective) metrics.SLO {
+	return metrics.SLO{
+		Availability:     o.Availability,
+		LatencyThreshold: time.Duration(o.LatencyThresholdMs) * time.Millisecond,
+		LatencyTarget:    o.LatencyTarget,
+	}
+}
+
+// newAnomalyDetector creates the detector of the API key anomalies of the ingested access
+// logs, with the configured networks and business hours.
+func newAnomalyDetector(log *logger.Logger, cfg *config.Config, usageStore usage.Store) (*usage.AnomalyDetector, error) {
+	detectorCfg := usage.AnomalyConfig{
+		SpikeFactor: cfg.UsageAnomalySpikeFactor,
+		MinRequests: int64(cfg.UsageAnomalyMinRequests),
+		NewNetwork:  cfg.UsageAnomalyNewNetwork,
+		WebhookURL:  cfg.UsageAnomalyWebhookURL,
+	}
+	if cfg.UsageAnomalyASNFile != "" {
+		networks, err := usage.LoadNetworkTable(cfg.UsageAnomalyASNFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read USAGE_ANOMALY_ASN_FILE: %w", err)
+		}
+		detectorCfg.Networks = networks
+	}
+	if cfg.UsageAnomalyBusinessHours != "" {
+		hours, err := usage.ParseBusinessHours(cfg.UsageAnomalyBusinessHours, cfg.UsageAnomalyTimezone)
+		if err != nil {
+			return nil, fmt.Errorf("USAGE_ANOMALY_BUSINESS_HOURS: %w", err)
+		}
+		detectorCfg.BusinessHours = hours
+	}
+	return usage.NewAnomalyDetector(log, usageStore, "/maas-api/"+cfg.TenantName, detectorCfg), nil
+}
+
+// newSecurityEventForwarder creates the forwarder of the audit events to the configured SIEM.
+func newSecurityEventForwarder(log *logger.Logger, cfg *config.Config) (*audit.Forwarder, error) {
+	forwarderCfg := audit.ForwarderConfig{
+		Protocol: cfg.SecurityEventsProtocol,
+		Address:  cfg.SecurityEventsAddress,
+		Format:   audit.Format(cfg.SecurityEventsFormat),
+		Tenant:   cfg.TenantName,
+	}
+	if cfg.SecurityEventsProtocol == "tls" {
+		forwarderCfg.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.SecurityEventsCAFile != "" {
+			pem, err := os.ReadFile(cfg.SecurityEventsCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read SECURITY_EVENTS_CA_FILE: %w", err)
+			}
+			roots := x509.NewCertPool()
+			if !roots.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("SECURITY_EVENTS_CA_FILE %q contains no PEM certificate", cfg.SecurityEventsCAFile)
+			}
+			forwarderCfg.TLSConfig.RootCAs = roots
+		}
+	}
+	return audit.NewForwarder(log, forwarderCfg), nil
+}
+
 // isLocalhostOrigin reports whether the origin is a localhost address,
 // used by the debug-mode CORS policy to restrict cross-origin access to
 // local development only. Accepts both ported (http://localhost:3000)
@@ -258,6 +515,28 @@ func isLocalhostOrigin(origin string) bool {
 	return ip != nil && ip.IsLoopback()
 }
 
+// corsConfig is the CORS policy of CORS_ALLOWED_ORIGINS. In debug mode, it also allows
+// the localhost origins.
+func corsConfig(cfg *config.Config) cors.Config {
+	corsCfg := cors.Config{
+		AllowMethods:     cfg.CORSAllowedMethods,
+		AllowHeaders:     cfg.CORSAllowedHeaders,
+		ExposeHeaders:    []string{"Content-Type"},
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           12 * time.Hour,
+	}
+	if slices.Equal(cfg.CORSAllowedOrigins, []string{"*"}) {
+		corsCfg.AllowAllOrigins = true
+		return corsCfg
+	}
+	corsCfg.AllowOrigins = cfg.CORSAllowedOrigins
+	corsCfg.AllowWildcard = true
+	if cfg.DebugMode {
+		corsCfg.AllowOriginFunc = isLocalhostOrigin
+	}
+	return corsCfg
+}
+
 func debugCORSConfig() cors.Config {
 	return cors.Config{
 		AllowMethods:    []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
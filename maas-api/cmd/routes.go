@@ -0,0 +1,283 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openai/openai-go/v2/packages/pagination"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/admin"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/billing"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/openapi"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+// routeHandlers are the handlers of the documented maas-api routes.
+type routeHandlers struct {
+	token        *token.Handler
+	models       *handlers.ModelsHandler
+	subscription *subscription.Handler
+	apiKey       *api_keys.Handler
+	usage        *usage.Handler
+	chargeback   *billing.ChargebackHandler
+	estimate     *billing.EstimateHandler
+	audit        *audit.Handler
+	admin        *admin.Handler
+	// inference is only set when INFERENCE_PROXY is enabled.
+	inference *handlers.InferenceHandler
+}
+
+// newAPIDocument returns the OpenAPI document the routes are registered in.
+func newAPIDocument() *openapi.Document {
+	return openapi.New(openapi.Info{
+		Title:       "Models as a Service API",
+		Description: "Models as a Service Billing and Management API",
+		Version:     "1.0",
+	})
+}
+
+var (
+	usageRangeParameters = []openapi.Parameter{
+		{Name: "from", Format: "date-time", Description: "Start of the range (RFC 3339), rounded down to its window. Default: to minus 24 hours"},
+		{Name: "to", Format: "date-time", Description: "End of the range (RFC 3339). Default: now"},
+		{Name: "granularity", Enum: []string{"hour", "day"}, Description: "Window of the records. Default: hour"},
+	}
+	usageFilterParameters = append(append([]openapi.Parameter{}, usageRangeParameters...),
+		openapi.Parameter{Name: "subscription", Description: "Only the usage of this subscription"},
+		openapi.Parameter{Name: "model", Description: "Only the usage of this model (namespace/name)"},
+	)
+)
+
+// registerRoutes registers the routes of the API on the router and documents them.
+func registerRoutes(doc *openapi.Document, router *gin.Engine, h routeHandlers) {
+	v1Routes := router.Group("/v1")
+	auth := h.token.ExtractUserInfo()
+
+	doc.Handle(v1Routes, http.MethodGet, "/models", openapi.Route{
+		OperationID: "listModels",
+		Summary:     "List the models the user can access",
+		Tags:        []string{"models"},
+		Responses:   map[int]any{http.StatusOK: pagination.Page[models.Model]{}},
+	}, auth, h.models.ListLLMs)
+
+	// Subscription listing routes
+	doc.Handle(v1Routes, http.MethodGet, "/subscriptions", openapi.Route{
+		OperationID: "listSubscriptions",
+		Summary:     "List the subscriptions the user can use",
+		Tags:        []string{"subscriptions"},
+		Responses:   map[int]any{http.StatusOK: []subscription.SubscriptionInfo{}},
+	}, auth, h.subscription.ListSubscriptions)
+	doc.Handle(v1Routes, http.MethodGet, "/model/:model-id/subscriptions", openapi.Route{
+		OperationID: "listModelSubscriptions",
+		Summary:     "List the subscriptions of the user that include a model",
+		Tags:        []string{"subscriptions"},
+		Responses:   map[int]any{http.StatusOK: []subscription.SubscriptionInfo{}},
+	}, auth, h.subscription.ListSubscriptionsForModel)
+
+	// API Key routes - Complete CRUD for hash-based key architecture
+	apiKeyRoutes := v1Routes.Group("/api-keys", auth)
+	apiKeyTags := []string{"api-keys"}
+	doc.Handle(apiKeyRoutes, http.MethodGet, "/config", openapi.Route{
+		OperationID: "getAPIKeyConfig",
+		Summary:     "Get the API key limits",
+		Tags:        apiKeyTags,
+		Responses:   map[int]any{http.StatusOK: api_keys.ConfigResponse{}},
+	}, h.apiKey.GetAPIKeyConfig)
+	doc.Handle(apiKeyRoutes, http.MethodPost, "", openapi.Route{
+		OperationID: "createAPIKey",
+		Summary:     "Create an API key",
+		Description: "The key is only returned in this response.",
+		Tags:        apiKeyTags,
+		Request:     api_keys.CreateAPIKeyRequest{},
+		Responses:   map[int]any{http.StatusCreated: api_keys.CreateAPIKeyResponse{}},
+	}, h.apiKey.CreateAPIKey)
+	doc.Handle(apiKeyRoutes, http.MethodPost, "/search", openapi.Route{
+		OperationID: "searchAPIKeys",
+		Summary:     "Search API keys with filtering, sorting, and pagination",
+		Tags:        apiKeyTags,
+		Request:     api_keys.SearchAPIKeysRequest{},
+		Responses:   map[int]any{http.StatusOK: api_keys.SearchAPIKeysResponse{}},
+	}, h.apiKey.SearchAPIKeys)
+	doc.Handle(apiKeyRoutes, http.MethodPost, "/bulk-revoke", openapi.Route{
+		OperationID: "bulkRevokeAPIKeys",
+		Summary:     "Revoke all API keys of a user",
+		Tags:        apiKeyTags,
+		Request:     api_keys.BulkRevokeRequest{},
+		Responses:   map[int]any{http.StatusOK: api_keys.BulkRevokeResponse{}},
+	}, h.apiKey.BulkRevokeAPIKeys)
+	doc.Handle(apiKeyRoutes, http.MethodGet, "/:id", openapi.Route{
+		OperationID: "getAPIKey",
+		Summary:     "Get an API key",
+		Tags:        apiKeyTags,
+		Responses:   map[int]any{http.StatusOK: api_keys.ApiKey{}},
+	}, h.apiKey.GetAPIKey)
+	doc.Handle(apiKeyRoutes, http.MethodGet, "/:id/usage", openapi.Route{
+		OperationID: "getAPIKeyUsage",
+		Summary:     "Get the usage of an API key",
+		Tags:        apiKeyTags,
+		Parameters:  usageRangeParameters,
+		Responses:   map[int]any{http.StatusOK: usage.KeyUsageResponse{}},
+	}, h.usage.GetKeyUsage)
+	doc.Handle(apiKeyRoutes, http.MethodDelete, "/:id", openapi.Route{
+		OperationID: "revokeAPIKey",
+		Summary:     "Revoke an API key",
+		Tags:        apiKeyTags,
+		Responses:   map[int]any{http.StatusOK: api_keys.ApiKey{}},
+	}, h.apiKey.RevokeAPIKey)
+
+	// Usage routes
+	usageTags := []string{"usage"}
+	doc.Handle(v1Routes, http.MethodGet, "/usage", openapi.Route{
+		OperationID: "getUsage",
+		Summary:     "Get the usage of the user",
+		Tags:        usageTags,
+		Parameters:  usageFilterParameters,
+		Responses:   map[int]any{http.StatusOK: usage.Response{}},
+	}, auth, h.usage.GetUsage)
+	doc.Handle(v1Routes, http.MethodGet, "/admin/usage", openapi.Route{
+		OperationID: "getAdminUsage",
+		Summary:     "Get the usage of all users, or of one",
+		Tags:        usageTags,
+		Parameters: append(append([]openapi.Parameter{}, usageFilterParameters...),
+			openapi.Parameter{Name: "user", Description: "Only the usage of this user"}),
+		Responses: map[int]any{http.StatusOK: usage.Response{}},
+	}, auth, h.usage.GetAdminUsage)
+	doc.Handle(v1Routes, http.MethodGet, "/admin/usage/top", openapi.Route{
+		OperationID: "getTopUsage",
+		Summary:     "Get the top consumers of tokens",
+		Tags:        usageTags,
+		Parameters: []openapi.Parameter{
+			{Name: "window", Description: "Duration such as 24h, or a number of days such as 7d. Default: 24h"},
+			{Name: "by", Enum: []string{"user", "model", "key"}, Description: "Dimension of the consumers. Default: user"},
+			{Name: "limit", Type: "integer", Description: "Number of consumers. Default: 10"},
+		},
+		Responses: map[int]any{http.StatusOK: usage.TopResponse{}},
+	}, auth, h.usage.GetTopUsage)
+	doc.Handle(v1Routes, http.MethodGet, "/admin/usage/anomalies", openapi.Route{
+		OperationID: "listUsageAnomalies",
+		Summary:     "List the API keys flagged for anomalous usage",
+		Tags:        usageTags,
+		Parameters:  usageRangeParameters[:2],
+		Responses:   map[int]any{http.StatusOK: usage.AnomaliesResponse{}},
+	}, auth, h.usage.GetAnomalies)
+	doc.Handle(v1Routes, http.MethodGet, "/admin/chargeback", openapi.Route{
+		OperationID: "getChargeback",
+		Summary:     "Get the cost of the token usage by cost center, organization, subscription or model",
+		Tags:        []string{"billing"},
+		Parameters: []openapi.Parameter{
+			{Name: "from", Format: "date-time", Description: "Start of the range (RFC 3339). Default: start of the month of to"},
+			{Name: "to", Format: "date-time", Description: "End of the range (RFC 3339). Default: now"},
+			{Name: "groupBy", Enum: []string{"costCenter", "organization", "subscription", "model"}, Description: "Default: costCenter"},
+		},
+		Responses: map[int]any{http.StatusOK: billing.ChargebackResponse{}},
+	}, auth, h.chargeback.GetChargeback)
+	doc.Handle(v1Routes, http.MethodPost, "/cost/estimate", openapi.Route{
+		OperationID:     "estimateCost",
+		Summary:         "Estimate the cost of a request with the billing rate of a subscription",
+		Tags:            []string{"billing"},
+		Request:         billing.EstimateRequest{},
+		MaxRequestBytes: billing.MaxEstimateBodyBytes,
+		Responses:       map[int]any{http.StatusOK: billing.EstimateResponse{}},
+	}, auth, h.estimate.EstimateCost)
+
+	// Audit log routes
+	doc.Handle(v1Routes, http.MethodGet, "/admin/audit", openapi.Route{
+		OperationID: "listAuditEvents",
+		Summary:     "List the audit log events",
+		Tags:        []string{"audit"},
+		Parameters: []openapi.Parameter{
+			{Name: "from", Format: "date-time"},
+			{Name: "to", Format: "date-time"},
+			{Name: "actor", Description: "Only the events of this actor"},
+			{Name: "user", Description: "Only the events targeting this user"},
+			{Name: "action", Description: "Only the events of this action"},
+			{Name: "after", Type: "integer", Description: "Only the events after this sequence"},
+			{Name: "limit", Type: "integer", Description: "Maximum number of events"},
+		},
+		Responses: map[int]any{http.StatusOK: audit.Response{}},
+	}, auth, h.audit.GetEvents)
+	doc.Handle(v1Routes, http.MethodGet, "/admin/audit/verify", openapi.Route{
+		OperationID: "verifyAuditLog",
+		Summary:     "Verify the hash chain of the audit log",
+		Tags:        []string{"audit"},
+		Responses:   map[int]any{http.StatusOK: audit.VerifyResult{}},
+	}, auth, h.audit.VerifyChain)
+
+	// Admin routes
+	doc.Handle(v1Routes, http.MethodGet, "/admin/users", openapi.Route{
+		OperationID: "listUsers",
+		Summary:     "List the users with their API keys, usage and subscriptions",
+		Tags:        []string{"admin"},
+		Parameters: []openapi.Parameter{
+			{Name: "limit", Type: "integer", Description: "Number of users. Default: 50, maximum: 100"},
+			{Name: "offset", Type: "integer", Description: "Number of users to skip. Default: 0"},
+			{Name: "from", Format: "date-time", Description: "Start of the usage range (RFC 3339). Default: to minus 30 days"},
+			{Name: "to", Format: "date-time", Description: "End of the usage range (RFC 3339). Default: now"},
+		},
+		Responses: map[int]any{http.StatusOK: admin.UsersResponse{}},
+	}, auth, h.admin.ListUsers)
+
+	// Inference proxy routes - authenticated with API keys by the handler
+	if h.inference != nil {
+		for _, route := range []struct{ path, operationID, summary string }{
+			{"/chat/completions", "createChatCompletion", "Create a chat completion with a model"},
+			{"/completions", "createCompletion", "Create a completion with a model"},
+		} {
+			doc.Handle(v1Routes, http.MethodPost, route.path, openapi.Route{
+				OperationID: route.operationID,
+				Summary:     route.summary,
+				Description: "OpenAI-compatible request, forwarded to the model of its `model` field with the subscription of the API key. " +
+					"Only served when INFERENCE_PROXY is enabled.",
+				Tags:            []string{"inference"},
+				Request:         handlers.InferenceRequest{},
+				MaxRequestBytes: handlers.MaxInferenceRequestBytes,
+				RequestError:    handlers.InferenceRequestError,
+				Responses:       map[int]any{http.StatusOK: nil},
+			}, h.inference.Proxy)
+		}
+	}
+
+	// Internal routes (no user auth - called by Authorino / CronJob / log shipper)
+	internalRoutes := router.Group("/internal/v1")
+	internalTags := []string{"internal"}
+	doc.Handle(internalRoutes, http.MethodPost, "/api-keys/validate", openapi.Route{
+		OperationID: "validateAPIKey",
+		Summary:     "Validate an API key",
+		Tags:        internalTags,
+		Public:      true,
+		Request:     api_keys.ValidateAPIKeyRequest{},
+		Responses:   map[int]any{http.StatusOK: api_keys.ValidationResult{}},
+	}, h.apiKey.ValidateAPIKeyHandler)
+	doc.Handle(internalRoutes, http.MethodPost, "/api-keys/cleanup", openapi.Route{
+		OperationID: "cleanupEphemeralAPIKeys",
+		Summary:     "Delete the expired ephemeral API keys",
+		Tags:        internalTags,
+		Public:      true,
+		Responses:   map[int]any{http.StatusOK: api_keys.CleanupResponse{}},
+	}, h.apiKey.CleanupExpiredEphemeralKeys)
+	doc.Handle(internalRoutes, http.MethodPost, "/subscriptions/select", openapi.Route{
+		OperationID:  "selectSubscription",
+		Summary:      "Select the subscription of a request",
+		Tags:         internalTags,
+		Public:       true,
+		Request:      subscription.SelectRequest{},
+		RequestError: subscription.SelectRequestError,
+		Responses:    map[int]any{http.StatusOK: subscription.SelectResponse{}},
+	}, h.subscription.SelectSubscription)
+	doc.Handle(internalRoutes, http.MethodPost, "/usage/access-logs", openapi.Route{
+		OperationID:        "ingestAccessLogs",
+		Summary:            "Ingest a batch of gateway access log lines",
+		Description:        "The body is newline-delimited JSON, one access log entry per line, posted with a token of the log shipper ServiceAccount.",
+		Tags:               internalTags,
+		Request:            usage.AccessLogEntry{},
+		RequestContentType: "application/x-ndjson",
+		MaxRequestBytes:    usage.MaxAccessLogBatchBytes,
+		Responses:          map[int]any{http.StatusOK: usage.AccessLogResult{}},
+	}, h.usage.AuthenticateShipper, h.usage.IngestAccessLogs)
+}
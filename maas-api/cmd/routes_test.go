@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
+)
+
+func TestRegisterRoutesDocumentsEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	doc := newAPIDocument()
+	registerRoutes(doc, router, routeHandlers{inference: &handlers.InferenceHandler{}})
+
+	body, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var rendered struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Security    []any  `json:"security"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(body, &rendered))
+
+	operationIDs := map[string]bool{}
+	for _, route := range router.Routes() {
+		path := route.Path
+		for _, segment := range strings.Split(path, "/") {
+			if strings.HasPrefix(segment, ":") {
+				path = strings.Replace(path, segment, "{"+segment[1:]+"}", 1)
+			}
+		}
+		operation, ok := rendered.Paths[path][strings.ToLower(route.Method)]
+		require.True(t, ok, "%s %s is not documented", route.Method, route.Path)
+		assert.NotEmpty(t, operation.OperationID, "%s %s", route.Method, route.Path)
+		assert.False(t, operationIDs[operation.OperationID], "duplicate operationId %s", operation.OperationID)
+		operationIDs[operation.OperationID] = true
+
+		// The log shipper authenticates with its ServiceAccount token.
+		if strings.HasPrefix(route.Path, "/internal/") && route.Path != "/internal/v1/usage/access-logs" {
+			assert.NotNil(t, operation.Security, "%s %s must not require a bearer token", route.Method, route.Path)
+		} else {
+			assert.Nil(t, operation.Security, "%s %s must require a bearer token", route.Method, route.Path)
+		}
+	}
+	assert.Len(t, doc.Paths(), len(rendered.Paths))
+	assert.Contains(t, rendered.Paths, "/v1/chat/completions")
+}
+
+func TestRegisterRoutesWithoutInferenceProxy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := newAPIDocument()
+	registerRoutes(doc, gin.New(), routeHandlers{})
+
+	assert.NotContains(t, doc.Paths(), "/v1/chat/completions")
+	assert.NotContains(t, doc.Paths(), "/v1/completions")
+}
+
+func TestRegisterRoutesRejectsInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	doc := newAPIDocument()
+	router.Use(doc.ValidateRequests(config.DefaultMaxRequestBodyBytes))
+	registerRoutes(doc, router, routeHandlers{inference: &handlers.InferenceHandler{}})
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "api error",
+			path:       "/v1/api-keys/bulk-revoke",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error": "username is required"}`,
+		},
+		{
+			name:       "OpenAI error of the inference proxy",
+			path:       "/v1/chat/completions",
+			body:       `{"model": 1}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error": {"message": "model must be a string", "type": "invalid_request_error"}}`,
+		},
+		{
+			name:       "selection error read by Authorino",
+			path:       "/internal/v1/subscriptions/select",
+			body:       `{"groups": ["a"]}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"error": "bad_request", "message": "invalid request body: username is required", "phase": "", "ready": false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(`{"model": "granite"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = handlers.MaxInferenceRequestBytes
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.NotEqual(t, http.StatusRequestEntityTooLarge, w.Code, "the inference proxy accepts bodies above the default limit")
+}
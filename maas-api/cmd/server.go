@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/cert"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 )
 
 func newServer(cfg *config.Config, handler http.Handler) (*http.Server, error) {
@@ -61,3 +63,21 @@ func listenAndServe(srv *http.Server) error {
 	}
 	return srv.ListenAndServe()
 }
+
+// drain stops srv accepting connections and waits up to timeout for its in-flight
+// requests, streamed responses included, to complete. The connections of the requests
+// still running then are closed.
+func drain(log *logger.Logger, srv *http.Server, timeout time.Duration) {
+	srv.SetKeepAlivesEnabled(false)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Warn("In-flight requests did not complete within the shutdown timeout, closing their connections", "timeout", timeout, "error", err)
+		if err := srv.Close(); err != nil {
+			log.Error("Failed to close the server connections", "error", err)
+		}
+		return
+	}
+	log.Info("Drained in-flight requests", "duration", time.Since(start))
+}
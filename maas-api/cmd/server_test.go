@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// startStreamingServer serves a response that sends "data: 1" and then waits for
+// release or the end of the request before it sends "data: 2".
+func startStreamingServer(t *testing.T, release <-chan struct{}) (*http.Server, string) {
+	t.Helper()
+	srv := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "data: 1\n\n")
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			_, _ = io.WriteString(w, "data: 2\n\n")
+		}),
+	}
+	ln, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return srv, "http://" + ln.Addr().String()
+}
+
+func startStream(t *testing.T, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	first := make([]byte, len("data: 1\n\n"))
+	_, err = io.ReadFull(resp.Body, first)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestDrainWaitsForStreams(t *testing.T) {
+	release := make(chan struct{})
+	srv, url := startStreamingServer(t, release)
+	resp := startStream(t, url)
+
+	drained := make(chan struct{})
+	go func() {
+		drain(logger.Development(), srv, 10*time.Second)
+		close(drained)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := (&net.Dialer{}).DialContext(t.Context(), "tcp", url[len("http://"):])
+		if err == nil {
+			conn.Close()
+		}
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond, "the server must stop accepting connections")
+	select {
+	case <-drained:
+		t.Fatal("drain returned before the stream completed")
+	default:
+	}
+
+	close(release)
+	rest, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "data: 2\n\n", string(rest))
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain did not return after the stream completed")
+	}
+}
+
+func TestDrainClosesStreamsAfterTimeout(t *testing.T) {
+	srv, url := startStreamingServer(t, make(chan struct{}))
+	resp := startStream(t, url)
+
+	start := time.Now()
+	drain(logger.Development(), srv, 100*time.Millisecond)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	_, err := io.ReadAll(resp.Body)
+	assert.Error(t, err, "the stream still running after the timeout must be cut")
+}
@@ -0,0 +1,107 @@
+// Command token-counter runs the token counter, the Envoy external processor that adds
+// the estimated usage to the streamed completions of backends that do not report it.
+// The controller deploys it in the gateway namespace when a Tenant enables
+// spec.tokenCounting.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"k8s.io/utils/env"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tokencount"
+)
+
+const defaultPort = 9004
+
+func main() {
+	if err := serve(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func serve() error {
+	debugMode, _ := env.GetBool("DEBUG_MODE", false)
+	port, _ := env.GetInt("PORT", defaultPort)
+	metricsPort, _ := env.GetInt("METRICS_PORT", constant.DefaultMetricsPort)
+	charsPerToken, _ := env.GetFloat64("CHARS_PER_TOKEN", tokencount.DefaultCharsPerToken)
+	if charsPerToken <= 0 {
+		return fmt.Errorf("CHARS_PER_TOKEN must be positive, got %v", charsPerToken)
+	}
+
+	log := logger.New(debugMode)
+	defer func() {
+		if err := log.Sync(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to sync logger: %v\n", err)
+		}
+	}()
+
+	metricsRegistry := prometheus.NewRegistry()
+	metricsRecorder, err := metrics.NewPrometheusRecorder(metricsRegistry)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics recorder: %w", err)
+	}
+	metricsAddress := fmt.Sprintf(":%d", metricsPort)
+	metricsSrv, err := metrics.NewMetricsServer(metricsAddress, metricsRegistry)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics server: %w", err)
+	}
+	metricsErr := make(chan error, 1)
+	go func() {
+		log.Info("Metrics server starting", "address", metricsAddress)
+		metricsErr <- metricsSrv.ListenAndServe()
+	}()
+
+	counter := tokencount.NewServer(log, tokencount.Estimator{CharsPerToken: charsPerToken})
+	counter.SetMetrics(metricsRecorder)
+
+	grpcSrv := grpc.NewServer()
+	extprocv3.RegisterExternalProcessorServer(grpcSrv, counter)
+	healthpb.RegisterHealthServer(grpcSrv, health.NewServer())
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info("Token counter starting", "port", port, "charsPerToken", charsPerToken)
+		serverErr <- grpcSrv.Serve(listener)
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("token counter failed: %w", err)
+	case err := <-metricsErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("metrics server failed: %w", err)
+		}
+	case <-quit:
+		log.Info("Shutdown signal received, shutting down token counter...")
+	}
+
+	grpcSrv.GracefulStop()
+	if err := metricsSrv.Close(); err != nil {
+		log.Error("Failed to close metrics server", "error", err)
+	}
+	log.Info("Token counter exited gracefully")
+	return nil
+}
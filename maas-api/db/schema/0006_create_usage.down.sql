@@ -0,0 +1,4 @@
+-- Rollback for 0006_create_usage
+DROP TABLE IF EXISTS usage_counters;
+DROP INDEX IF EXISTS idx_usage_records_tenant_window;
+DROP TABLE IF EXISTS usage_records;
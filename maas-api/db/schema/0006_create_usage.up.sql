@@ -0,0 +1,33 @@
+-- Schema for Usage Metering: 0006_create_usage.up.sql
+-- Description: Per-user token and request usage, aggregated into hourly windows
+
+-- One row per tenant, user, subscription, model and hour. Writers add to the counts
+-- (INSERT ... ON CONFLICT DO UPDATE), so replicas can record concurrently.
+CREATE TABLE IF NOT EXISTS usage_records (
+    tenant        TEXT        NOT NULL,
+    username      TEXT        NOT NULL,
+    subscription  TEXT        NOT NULL,
+    model         TEXT        NOT NULL,
+    window_start  TIMESTAMPTZ NOT NULL,
+    requests      BIGINT      NOT NULL DEFAULT 0,
+    input_tokens  BIGINT      NOT NULL DEFAULT 0,
+    output_tokens BIGINT      NOT NULL DEFAULT 0,
+    total_tokens  BIGINT      NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant, username, subscription, model, window_start)
+);
+
+-- Admin queries: SELECT ... FROM usage_records WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+CREATE INDEX IF NOT EXISTS idx_usage_records_tenant_window
+    ON usage_records(tenant, window_start);
+
+-- Last value seen of each Limitador counter, so that each scrape only records what was
+-- consumed since the previous one, whichever replica made it.
+CREATE TABLE IF NOT EXISTS usage_counters (
+    tenant      TEXT        NOT NULL,
+    counter_key TEXT        NOT NULL,
+    consumed    BIGINT      NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant, counter_key)
+);
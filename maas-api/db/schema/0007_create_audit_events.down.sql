@@ -0,0 +1,3 @@
+-- Rollback for 0007_create_audit_events
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
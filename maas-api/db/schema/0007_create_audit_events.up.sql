@@ -0,0 +1,43 @@
+-- Schema for the Audit Log: 0007_create_audit_events.up.sql
+-- Description: Append-only, hash-chained log of credential operations
+
+-- One row per event. The events of a tenant form a chain: sequence increments by one
+-- and hash = SHA-256(prev_hash || event), so that an edited, removed or reordered row
+-- breaks the chain (GET /v1/admin/audit/verify).
+CREATE TABLE IF NOT EXISTS audit_events (
+    tenant      TEXT        NOT NULL,
+    sequence    BIGINT      NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    actor       TEXT        NOT NULL,
+    action      TEXT        NOT NULL,
+    outcome     TEXT        NOT NULL,
+    target_user TEXT        NOT NULL DEFAULT '',
+    key_id      TEXT        NOT NULL DEFAULT '',
+    reason      TEXT        NOT NULL DEFAULT '',
+    request_id  TEXT        NOT NULL DEFAULT '',
+    details     JSONB       NOT NULL DEFAULT '{}',
+    prev_hash   TEXT        NOT NULL,
+    hash        TEXT        NOT NULL,
+    PRIMARY KEY (tenant, sequence)
+);
+
+-- Admin queries: SELECT ... FROM audit_events WHERE tenant = $1 AND occurred_at >= $2 ...
+CREATE INDEX IF NOT EXISTS idx_audit_events_tenant_time
+    ON audit_events(tenant, occurred_at);
+
+-- Rows are never updated or deleted by maas-api; refuse it from any client.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_no_update_delete ON audit_events;
+CREATE TRIGGER audit_events_no_update_delete
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
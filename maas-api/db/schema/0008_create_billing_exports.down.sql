@@ -0,0 +1,2 @@
+-- Rollback for 0008_create_billing_exports
+DROP TABLE IF EXISTS billing_exports;
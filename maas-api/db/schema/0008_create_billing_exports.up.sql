@@ -0,0 +1,12 @@
+-- Schema for Billing Exports: 0008_create_billing_exports.up.sql
+-- Description: Periods of usage already exported for chargeback
+
+-- One row per tenant and exported period, written once all the export's files are.
+-- Replicas exporting the same period write the same files and insert the same row.
+CREATE TABLE IF NOT EXISTS billing_exports (
+    tenant       TEXT        NOT NULL,
+    period       TEXT        NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    exported_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant, period, period_start)
+);
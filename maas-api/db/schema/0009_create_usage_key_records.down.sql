@@ -0,0 +1,3 @@
+-- Rollback for 0009_create_usage_key_records
+DROP INDEX IF EXISTS idx_usage_key_records_tenant_window;
+DROP TABLE IF EXISTS usage_key_records;
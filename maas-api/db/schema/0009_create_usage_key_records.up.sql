@@ -0,0 +1,21 @@
+-- Schema for Usage Metering: 0009_create_usage_key_records.up.sql
+-- Description: Per-API-key request and token usage from the gateway access logs, aggregated into hourly windows
+
+-- One row per tenant, API key and hour, for the leaderboard of the heaviest keys. The
+-- tokens are those the access logs report. Writers add to the counts.
+CREATE TABLE IF NOT EXISTS usage_key_records (
+    tenant        TEXT        NOT NULL,
+    key_id        TEXT        NOT NULL,
+    username      TEXT        NOT NULL,
+    window_start  TIMESTAMPTZ NOT NULL,
+    requests      BIGINT      NOT NULL DEFAULT 0,
+    input_tokens  BIGINT      NOT NULL DEFAULT 0,
+    output_tokens BIGINT      NOT NULL DEFAULT 0,
+    total_tokens  BIGINT      NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant, key_id, window_start)
+);
+
+-- Leaderboard queries: SELECT ... FROM usage_key_records WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+CREATE INDEX IF NOT EXISTS idx_usage_key_records_tenant_window
+    ON usage_key_records(tenant, window_start);
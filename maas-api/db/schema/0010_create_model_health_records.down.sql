@@ -0,0 +1,3 @@
+-- Rollback for 0010_create_model_health_records
+DROP INDEX IF EXISTS idx_model_health_records_tenant_window;
+DROP TABLE IF EXISTS model_health_records;
@@ -0,0 +1,21 @@
+-- Schema for Usage Metering: 0010_create_model_health_records.up.sql
+-- Description: Per-model latency histogram and 5xx errors from the gateway access logs, aggregated into 5-minute windows
+
+-- One row per tenant, model, 5-minute window and latency bucket, for the rolling p95
+-- latency and error rate of the models in GET /v1/models. le_ms is the upper bound of
+-- the bucket in milliseconds (9223372036854775807 for the overflow bucket). Writers add
+-- to the counts; rows older than a day are deleted.
+CREATE TABLE IF NOT EXISTS model_health_records (
+    tenant        TEXT        NOT NULL,
+    model         TEXT        NOT NULL,
+    window_start  TIMESTAMPTZ NOT NULL,
+    le_ms         BIGINT      NOT NULL,
+    requests      BIGINT      NOT NULL DEFAULT 0,
+    server_errors BIGINT      NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant, model, window_start, le_ms)
+);
+
+-- Performance queries: SELECT ... FROM model_health_records WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+CREATE INDEX IF NOT EXISTS idx_model_health_records_tenant_window
+    ON model_health_records(tenant, window_start);
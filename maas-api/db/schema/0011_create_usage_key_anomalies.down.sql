@@ -0,0 +1,4 @@
+-- Rollback for 0011_create_usage_key_anomalies
+DROP TABLE IF EXISTS usage_key_networks;
+DROP INDEX IF EXISTS idx_usage_key_anomalies_tenant_window;
+DROP TABLE IF EXISTS usage_key_anomalies;
@@ -0,0 +1,31 @@
+-- Schema for Usage Metering: 0011_create_usage_key_anomalies.up.sql
+-- Description: API keys flagged by the anomaly detection of the gateway access logs, and the networks the keys were used from
+
+-- One row per tenant, API key, rule, hour and network (empty but for new_network). The
+-- primary key makes a key flagged once per rule and hour across replicas.
+CREATE TABLE IF NOT EXISTS usage_key_anomalies (
+    tenant       TEXT        NOT NULL,
+    key_id       TEXT        NOT NULL,
+    rule         TEXT        NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    network      TEXT        NOT NULL DEFAULT '',
+    username     TEXT        NOT NULL,
+    requests     BIGINT      NOT NULL DEFAULT 0,
+    detail       TEXT        NOT NULL DEFAULT '',
+    detected_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant, key_id, rule, window_start, network)
+);
+
+-- Admin queries: SELECT ... FROM usage_key_anomalies WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+CREATE INDEX IF NOT EXISTS idx_usage_key_anomalies_tenant_window
+    ON usage_key_anomalies(tenant, window_start);
+
+-- One row per tenant, API key and network ("AS<number>" or an address prefix) the key
+-- was used from, for the new_network rule.
+CREATE TABLE IF NOT EXISTS usage_key_networks (
+    tenant     TEXT        NOT NULL,
+    key_id     TEXT        NOT NULL,
+    network    TEXT        NOT NULL,
+    first_seen TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant, key_id, network)
+);
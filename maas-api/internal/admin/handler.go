@@ -0,0 +1,281 @@
+// Package admin serves the admin views of a tenant's users, which combine their API keys,
+// usage and subscriptions.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+const (
+	// DefaultUsageRange is the usage range of a listing without "from".
+	DefaultUsageRange = 30 * 24 * time.Hour
+
+	maxUsageRange = 366 * 24 * time.Hour
+)
+
+var ErrInvalidQuery = errors.New("invalid users query")
+
+// AdminChecker reports whether a user may list the users.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, user *token.UserContext) (bool, error)
+}
+
+// UserLister lists the users with API keys.
+type UserLister interface {
+	ListUsers(ctx context.Context, tenant string, pagination *api_keys.PaginationParams) (*api_keys.UserListResult, error)
+}
+
+// Handler serves the admin users API.
+type Handler struct {
+	users        UserLister
+	usage        usage.Store
+	selector     *subscription.Selector
+	adminChecker AdminChecker
+	logger       *logger.Logger
+	audit        *audit.Log
+	now          func() time.Time
+}
+
+// NewHandler creates an admin users handler.
+func NewHandler(log *logger.Logger, users UserLister, usageStore usage.Store, selector *subscription.Selector, adminChecker AdminChecker) *Handler {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Handler{
+		users:        users,
+		usage:        usageStore,
+		selector:     selector,
+		adminChecker: adminChecker,
+		logger:       log,
+		now:          time.Now,
+	}
+}
+
+// SetAuditLog sets the audit log of the listings.
+func (h *Handler) SetAuditLog(auditLog *audit.Log) {
+	h.audit = auditLog
+}
+
+// UsersResponse is the body of GET /v1/admin/users.
+type UsersResponse struct {
+	Object string `json:"object"` // Always "list"
+	Data   []User `json:"data"`
+	// HasMore is true when more users follow; pass offset+limit as offset to read them.
+	HasMore bool `json:"has_more"`
+	// From and To are the range of the usage of the users.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// User is a user with API keys, their usage and the subscriptions they can use.
+type User struct {
+	Username string `json:"username"`
+	// Groups are those of the user's most recent API key, which the subscriptions are
+	// matched with.
+	Groups        []string       `json:"groups"`
+	Keys          KeyCounts      `json:"keys"`
+	LastUsedAt    string         `json:"lastUsedAt,omitempty"`
+	Usage         usage.Totals   `json:"usage"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// KeyCounts are the numbers of API keys of a user per status, ephemeral keys excluded.
+type KeyCounts struct {
+	Active  int `json:"active"`
+	Revoked int `json:"revoked"`
+	Expired int `json:"expired"`
+}
+
+// Subscription is a MaaSSubscription a user can use, with the models it gives access to
+// as "namespace/name".
+type Subscription struct {
+	Name        string   `json:"name"`
+	Namespace   string   `json:"namespace"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Priority    int32    `json:"priority"`
+	Models      []string `json:"models"`
+}
+
+type usersQuery struct {
+	pagination api_keys.PaginationParams
+	from, to   time.Time
+}
+
+// parseQuery reads the query parameters limit, offset, from and to. from and to are RFC
+// 3339 times; from defaults to DefaultUsageRange before to and is rounded down to its hour.
+func (h *Handler) parseQuery(c *gin.Context) (usersQuery, error) {
+	q := usersQuery{
+		pagination: api_keys.PaginationParams{Limit: api_keys.DefaultLimit},
+		to:         h.now().UTC(),
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > api_keys.MaxLimit {
+			return q, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, api_keys.MaxLimit)
+		}
+		q.pagination.Limit = limit
+	}
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return q, fmt.Errorf("%w: offset must be a non-negative integer", ErrInvalidQuery)
+		}
+		q.pagination.Offset = offset
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return q, fmt.Errorf("%w: to must be an RFC 3339 time", ErrInvalidQuery)
+		}
+		q.to = t.UTC()
+	}
+	q.from = q.to.Add(-DefaultUsageRange)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return q, fmt.Errorf("%w: from must be an RFC 3339 time", ErrInvalidQuery)
+		}
+		q.from = t.UTC()
+	}
+	q.from = q.from.Truncate(usage.WindowSize)
+	if !q.from.Before(q.to) {
+		return q, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	if q.to.Sub(q.from) > maxUsageRange {
+		return q, fmt.Errorf("%w: range must not exceed %d days", ErrInvalidQuery, int(maxUsageRange.Hours()/24))
+	}
+	return q, nil
+}
+
+// ListUsers handles GET /v1/admin/users: a page of the users with API keys in the
+// tenant, ordered by username, with the counts of their keys, their usage of the range
+// and the subscriptions their groups match. Only admins may call it.
+func (h *Handler) ListUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	isAdmin, err := h.adminChecker.IsAdmin(ctx, user)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check admin status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
+	if !isAdmin {
+		h.audit.Record(ctx, audit.NewEvent(c, user.Username, audit.ActionUserList, audit.OutcomeDenied))
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can list the users"})
+		return
+	}
+	q, err := h.parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.audit.Record(ctx, audit.NewEvent(c, user.Username, audit.ActionUserList, audit.OutcomeSuccess))
+
+	page, err := h.users.ListUsers(ctx, user.Tenant, &q.pagination)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list users", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list users"})
+		return
+	}
+	usernames := make([]string, len(page.Users))
+	for i, u := range page.Users {
+		usernames[i] = u.Username
+	}
+	totals, err := h.usage.UserTotals(ctx, usernames, q.from, q.to)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to query usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query usage"})
+		return
+	}
+
+	users := make([]User, 0, len(page.Users))
+	for _, u := range page.Users {
+		subscriptions, err := h.subscriptions(u)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to match subscriptions", "user", u.Username, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+			return
+		}
+		groups := u.Groups
+		if groups == nil {
+			groups = []string{}
+		}
+		users = append(users, User{
+			Username: u.Username,
+			Groups:   groups,
+			Keys: KeyCounts{
+				Active:  u.ActiveKeys,
+				Revoked: u.RevokedKeys,
+				Expired: u.ExpiredKeys,
+			},
+			LastUsedAt:    u.LastUsedAt,
+			Usage:         totals[u.Username],
+			Subscriptions: subscriptions,
+		})
+	}
+
+	c.JSON(http.StatusOK, UsersResponse{
+		Object:  "list",
+		Data:    users,
+		HasMore: page.HasMore,
+		From:    q.from,
+		To:      q.to,
+	})
+}
+
+// subscriptions returns the subscriptions the groups of the user's most recent key
+// match, like those the user could bind a new key to.
+func (h *Handler) subscriptions(u api_keys.UserKeys) ([]Subscription, error) {
+	accessible, err := h.selector.GetAllAccessible(u.Groups, u.Username)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions := make([]Subscription, 0, len(accessible))
+	for _, sub := range accessible {
+		models := make([]string, 0, len(sub.ModelRefs))
+		for _, ref := range sub.ModelRefs {
+			models = append(models, ref.Namespace+"/"+ref.Name)
+		}
+		subscriptions = append(subscriptions, Subscription{
+			Name:        sub.Name,
+			Namespace:   sub.Namespace,
+			DisplayName: sub.DisplayName,
+			Priority:    sub.Priority,
+			Models:      models,
+		})
+	}
+	return subscriptions, nil
+}
+
+func (h *Handler) getUserContext(c *gin.Context) *token.UserContext {
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		return nil
+	}
+
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		return nil
+	}
+
+	return user
+}
@@ -0,0 +1,185 @@
+package admin //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+type groupAdminChecker struct{}
+
+func (groupAdminChecker) IsAdmin(_ context.Context, user *token.UserContext) (bool, error) {
+	return slices.Contains(user.Groups, "admin-users"), nil
+}
+
+type staticLister []*unstructured.Unstructured
+
+func (l staticLister) List() ([]*unstructured.Unstructured, error) {
+	return l, nil
+}
+
+func userSubscription(name, group string, priority int64, models ...string) *unstructured.Unstructured {
+	refs := make([]any, 0, len(models))
+	for _, m := range models {
+		refs = append(refs, map[string]any{"namespace": "llm", "name": m})
+	}
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"owner":     map[string]any{"groups": []any{map[string]any{"name": group}}},
+			"priority":  priority,
+			"modelRefs": refs,
+		},
+		"status": map[string]any{"phase": "Active"},
+	}}
+	u.SetName(name)
+	u.SetNamespace("models-as-a-service")
+	return u
+}
+
+var testNow = time.Date(2026, 10, 14, 12, 15, 0, 0, time.UTC)
+
+func setupHandler(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctx := t.Context()
+
+	keys := api_keys.NewMockStore()
+	require.NoError(t, keys.AddKey(ctx, "alice", "a-1", "ah1", "laptop", "", []string{"premium-users"}, "premium", "tenant-a", nil, false))
+	require.NoError(t, keys.AddKey(ctx, "alice", "a-2", "ah2", "ci", "", []string{"premium-users"}, "premium", "tenant-a", nil, false))
+	require.NoError(t, keys.Revoke(ctx, "a-2"))
+	require.NoError(t, keys.AddKey(ctx, "bob", "b-1", "bh1", "laptop", "", []string{"team-users"}, "team", "tenant-a", nil, false))
+	require.NoError(t, keys.AddKey(ctx, "carol", "c-1", "ch1", "laptop", "", []string{"team-users"}, "team", "tenant-b", nil, false))
+
+	usageStore := usage.NewMockStore()
+	require.NoError(t, usageStore.AddRecords(ctx, []usage.Record{
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: testNow.Add(-48 * time.Hour).Truncate(time.Hour), Requests: 3, TotalTokens: 3000},
+		{Username: "alice", Subscription: "premium", Model: "llm/llama", WindowStart: testNow.Add(-2 * time.Hour).Truncate(time.Hour), Requests: 1, TotalTokens: 200},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: testNow.Add(-60 * 24 * time.Hour).Truncate(time.Hour), Requests: 9, TotalTokens: 9000},
+	}))
+	subscriptions := staticLister{
+		userSubscription("premium", "premium-users", 10, "granite", "llama"),
+		userSubscription("team", "team-users", 1, "granite"),
+	}
+	selector := subscription.NewSelector(logger.Development(), subscriptions, nil, nil, nil)
+
+	h := NewHandler(logger.Development(), keys, usageStore, selector, groupAdminChecker{})
+	h.now = func() time.Time { return testNow }
+
+	router := gin.New()
+	router.GET("/v1/admin/users", func(c *gin.Context) {
+		user := &token.UserContext{Username: c.GetHeader("X-Test-User"), Tenant: "tenant-a"}
+		if user.Username == "admin" {
+			user.Groups = []string{"admin-users"}
+		}
+		c.Set("user", user)
+	}, h.ListUsers)
+	return router
+}
+
+func listUsers(t *testing.T, router *gin.Engine, user, target string) (int, UsersResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp UsersResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func TestListUsers(t *testing.T) {
+	router := setupHandler(t)
+
+	code, resp := listUsers(t, router, "admin", "/v1/admin/users")
+
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "list", resp.Object)
+	assert.False(t, resp.HasMore)
+	assert.Equal(t, testNow.Add(-DefaultUsageRange).Truncate(time.Hour), resp.From)
+	assert.Equal(t, testNow, resp.To)
+	assert.Equal(t, []User{
+		{
+			Username: "alice",
+			Groups:   []string{"premium-users"},
+			Keys:     KeyCounts{Active: 1, Revoked: 1},
+			Usage:    usage.Totals{Requests: 4, TotalTokens: 3200},
+			Subscriptions: []Subscription{
+				{Name: "premium", Namespace: "models-as-a-service", Priority: 10, Models: []string{"llm/granite", "llm/llama"}},
+			},
+		},
+		{
+			Username: "bob",
+			Groups:   []string{"team-users"},
+			Keys:     KeyCounts{Active: 1},
+			Subscriptions: []Subscription{
+				{Name: "team", Namespace: "models-as-a-service", Priority: 1, Models: []string{"llm/granite"}},
+			},
+		},
+	}, resp.Data, "carol is in another tenant")
+}
+
+func TestListUsers_Pagination(t *testing.T) {
+	router := setupHandler(t)
+
+	code, resp := listUsers(t, router, "admin", "/v1/admin/users?limit=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "alice", resp.Data[0].Username)
+	assert.True(t, resp.HasMore)
+
+	code, resp = listUsers(t, router, "admin", "/v1/admin/users?limit=1&offset=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "bob", resp.Data[0].Username)
+	assert.False(t, resp.HasMore)
+}
+
+func TestListUsers_UsageRange(t *testing.T) {
+	router := setupHandler(t)
+
+	code, resp := listUsers(t, router, "admin", "/v1/admin/users?from=2026-10-14T00:00:00Z")
+
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, usage.Totals{Requests: 1, TotalTokens: 200}, resp.Data[0].Usage)
+}
+
+func TestListUsers_Rejections(t *testing.T) {
+	router := setupHandler(t)
+
+	tests := []struct {
+		name   string
+		user   string
+		target string
+		want   int
+	}{
+		{"not an admin", "alice", "/v1/admin/users", http.StatusForbidden},
+		{"limit too large", "admin", "/v1/admin/users?limit=101", http.StatusBadRequest},
+		{"negative offset", "admin", "/v1/admin/users?offset=-1", http.StatusBadRequest},
+		{"invalid from", "admin", "/v1/admin/users?from=yesterday", http.StatusBadRequest},
+		{"from after to", "admin", "/v1/admin/users?from=2026-10-14T00:00:00Z&to=2026-10-13T00:00:00Z", http.StatusBadRequest},
+		{"range too long", "admin", "/v1/admin/users?from=2025-01-01T00:00:00Z", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := listUsers(t, router, tt.user, tt.target)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
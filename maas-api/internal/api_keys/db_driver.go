@@ -8,10 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/jackc/pgx/v5/stdlib" // PostgreSQL driver
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 	"k8s.io/utils/env"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/db/schema"
@@ -37,7 +39,9 @@ func NewPostgresStoreFromURL(ctx context.Context, log *logger.Logger, databaseUR
 			databaseURL)
 	}
 
-	db, err := sql.Open("pgx", databaseURL)
+	db, err := otelsql.Open("pgx", databaseURL,
+		otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open PostgreSQL connection: %w", err)
 	}
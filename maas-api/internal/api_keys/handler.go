@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
 )
@@ -38,12 +41,31 @@ type Handler struct {
 	service      *Service
 	logger       *logger.Logger
 	adminChecker AdminChecker
+	audit        *audit.Log
+}
+
+// SetAuditLog sets the audit log of the key operations.
+func (h *Handler) SetAuditLog(auditLog *audit.Log) {
+	h.audit = auditLog
+}
+
+// recordAudit records an event of the request in the audit log.
+func (h *Handler) recordAudit(c *gin.Context, user *token.UserContext, action audit.Action, outcome audit.Outcome, edit func(*audit.Event)) {
+	actor := audit.SystemActor
+	if user != nil {
+		actor = user.Username
+	}
+	event := audit.NewEvent(c, actor, action, outcome)
+	if edit != nil {
+		edit(&event)
+	}
+	h.audit.Record(c.Request.Context(), event)
 }
 
 func (h *Handler) GetAPIKeyConfig(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"max_expiration_days":      h.service.GetMaxExpirationDays(),
-		"ephemeral_max_expiration": constant.DefaultEphemeralKeyMaxExpiration.String(),
+	c.JSON(http.StatusOK, ConfigResponse{
+		MaxExpirationDays:      h.service.GetMaxExpirationDays(),
+		EphemeralMaxExpiration: constant.DefaultEphemeralKeyMaxExpiration.String(),
 	})
 }
 
@@ -108,6 +130,7 @@ func (h *Handler) GetAPIKey(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Token ID required"})
 		return
 	}
+	middleware.Attribute(c, middleware.Attribution{KeyID: tokenID})
 
 	// Extract user context for authorization
 	user := h.getUserContext(c)
@@ -122,7 +145,7 @@ func (h *Handler) GetAPIKey(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 			return
 		}
-		h.logger.Error("Failed to get API key",
+		h.logger.ErrorContext(c.Request.Context(), "Failed to get API key",
 			"error", err,
 		)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API key"})
@@ -132,21 +155,31 @@ func (h *Handler) GetAPIKey(c *gin.Context) {
 	// Check authorization - user must be in same tenant and own the key or be admin
 	authorized, authErr := h.isAuthorizedForKey(c.Request.Context(), user, tok.Username, tok.Tenant)
 	if authErr != nil {
-		h.logger.Error("Failed to check admin status", "error", authErr)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", authErr)
 		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 		return
 	}
 	if !authorized {
-		h.logger.Warn("Unauthorized API key access attempt",
+		h.logger.WarnContext(c.Request.Context(), "Unauthorized API key access attempt",
 			"requestingUser", user.Username,
 			"keyOwner", tok.Username,
 			"keyId", tokenID,
 		)
+		h.recordAudit(c, user, audit.ActionAPIKeyRead, audit.OutcomeDenied, func(e *audit.Event) {
+			e.TargetUser, e.KeyID = tok.Username, tokenID
+		})
 		// Return 404 instead of 403 to prevent key enumeration (IDOR protection)
 		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 		return
 	}
 
+	if tok.Username != user.Username {
+		// An admin reading the key of another user.
+		h.recordAudit(c, user, audit.ActionAPIKeyRead, audit.OutcomeSuccess, func(e *audit.Event) {
+			e.TargetUser, e.KeyID = tok.Username, tokenID
+		})
+	}
+
 	c.JSON(http.StatusOK, tok)
 }
 
@@ -228,7 +261,11 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 		strings.TrimSpace(req.Subscription),
 		user.Tenant)
 	if err != nil {
-		h.logger.Error("Failed to create API key", "error", err)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to create API key", "error", err)
+		h.recordAudit(c, user, audit.ActionAPIKeyCreate, audit.OutcomeError, func(e *audit.Event) {
+			e.TargetUser, e.Reason = user.Username, err.Error()
+			e.Details = map[string]string{"name": name, "subscription": strings.TrimSpace(req.Subscription), "ephemeral": strconv.FormatBool(req.Ephemeral)}
+		})
 		if errors.Is(err, ErrExpirationNotPositive) || errors.Is(err, ErrExpirationExceedsMax) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -264,15 +301,28 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("Created API key",
+	h.logger.InfoContext(c.Request.Context(), "Created API key",
 		"keyId", result.ID,
 		"keyPrefix", result.KeyPrefix,
 		"username", user.Username,
 		"groups", user.Groups,
 		"ephemeral", req.Ephemeral,
 	)
+	h.recordAudit(c, user, audit.ActionAPIKeyCreate, audit.OutcomeSuccess, func(e *audit.Event) {
+		e.TargetUser, e.KeyID = user.Username, result.ID
+		e.Details = map[string]string{
+			"name":         result.Name,
+			"keyPrefix":    result.KeyPrefix,
+			"subscription": result.Subscription,
+			"ephemeral":    strconv.FormatBool(result.Ephemeral),
+		}
+		if result.ExpiresAt != nil {
+			e.Details["expiresAt"] = *result.ExpiresAt
+		}
+	})
 
 	// Return the key - THIS IS THE ONLY TIME THE PLAINTEXT IS SHOWN
+	middleware.Attribute(c, middleware.Attribution{KeyID: result.ID, Subscription: result.Subscription})
 	c.JSON(http.StatusCreated, result)
 }
 
@@ -293,12 +343,14 @@ func (h *Handler) ValidateAPIKeyHandler(c *gin.Context) {
 
 	result, err := h.service.ValidateAPIKey(c.Request.Context(), req.Key)
 	if err != nil {
-		h.logger.Error("API key validation failed", "error", err)
+		h.logger.ErrorContext(c.Request.Context(), "API key validation failed", "error", err)
+		h.recordValidationFailure(c, req.Key, audit.OutcomeError, "validation failed")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "validation failed"})
 		return
 	}
 
 	if !result.Valid {
+		h.recordValidationFailure(c, req.Key, audit.OutcomeDenied, result.Reason)
 		// Return 200 with validation result for Authorino
 		// Per design doc section 7.7: invalid keys should return 200 with valid:false
 		c.JSON(http.StatusOK, result)
@@ -306,9 +358,24 @@ func (h *Handler) ValidateAPIKeyHandler(c *gin.Context) {
 	}
 
 	// Valid key - return user identity for Authorino to use
+	middleware.Attribute(c, middleware.Attribution{User: result.Username, KeyID: result.KeyID, Subscription: result.Subscription})
 	c.JSON(http.StatusOK, result)
 }
 
+// recordValidationFailure records a rejected or failed key validation. Strings that are
+// not API keys, e.g. OpenShift tokens sent to a model, are not recorded.
+func (h *Handler) recordValidationFailure(c *gin.Context, key string, outcome audit.Outcome, reason string) {
+	prefix := DisplayPrefix(key)
+	if prefix == "" {
+		return
+	}
+	h.recordAudit(c, nil, audit.ActionAPIKeyValidate, outcome, func(e *audit.Event) {
+		e.Actor = audit.UnauthenticatedActor
+		e.Reason = reason
+		e.Details = map[string]string{"keyPrefix": prefix}
+	})
+}
+
 // RevokeAPIKey handles DELETE /v1/api-keys/:id
 // Revokes a specific API key by changing its status to 'revoked'.
 func (h *Handler) RevokeAPIKey(c *gin.Context) {
@@ -317,6 +384,7 @@ func (h *Handler) RevokeAPIKey(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "API key ID required"})
 		return
 	}
+	middleware.Attribute(c, middleware.Attribution{KeyID: keyID})
 
 	// Extract user context for authorization
 	user := h.getUserContext(c)
@@ -331,7 +399,7 @@ func (h *Handler) RevokeAPIKey(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 			return
 		}
-		h.logger.Error("Failed to get API key for authorization check", "error", err, "keyId", keyID)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to get API key for authorization check", "error", err, "keyId", keyID)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API key"})
 		return
 	}
@@ -339,16 +407,19 @@ func (h *Handler) RevokeAPIKey(c *gin.Context) {
 	// Check authorization - user must be in same tenant and own the key or be admin
 	authorized, authErr := h.isAuthorizedForKey(c.Request.Context(), user, keyMetadata.Username, keyMetadata.Tenant)
 	if authErr != nil {
-		h.logger.Error("Failed to check admin status", "error", authErr)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", authErr)
 		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 		return
 	}
 	if !authorized {
-		h.logger.Warn("Unauthorized API key revocation attempt",
+		h.logger.WarnContext(c.Request.Context(), "Unauthorized API key revocation attempt",
 			"requestingUser", user.Username,
 			"keyOwner", keyMetadata.Username,
 			"keyId", keyID,
 		)
+		h.recordAudit(c, user, audit.ActionAPIKeyRevoke, audit.OutcomeDenied, func(e *audit.Event) {
+			e.TargetUser, e.KeyID = keyMetadata.Username, keyID
+		})
 		// Return 404 instead of 403 to prevent key enumeration (IDOR protection)
 		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 		return
@@ -360,17 +431,23 @@ func (h *Handler) RevokeAPIKey(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
 			return
 		}
-		h.logger.Error("Failed to revoke API key", "error", err, "keyId", keyID)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to revoke API key", "error", err, "keyId", keyID)
+		h.recordAudit(c, user, audit.ActionAPIKeyRevoke, audit.OutcomeError, func(e *audit.Event) {
+			e.TargetUser, e.KeyID, e.Reason = keyMetadata.Username, keyID, err.Error()
+		})
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
 		return
 	}
 
-	h.logger.Info("Revoked API key", "keyId", keyID, "revokedBy", user.Username)
+	h.logger.InfoContext(c.Request.Context(), "Revoked API key", "keyId", keyID, "revokedBy", user.Username)
+	h.recordAudit(c, user, audit.ActionAPIKeyRevoke, audit.OutcomeSuccess, func(e *audit.Event) {
+		e.TargetUser, e.KeyID = keyMetadata.Username, keyID
+	})
 
 	// Return the revoked key metadata (per OpenAPI spec)
 	revokedKey, err := h.service.GetAPIKey(c.Request.Context(), keyID)
 	if err != nil {
-		h.logger.Error("Failed to retrieve revoked key", "error", err, "keyId", keyID)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to retrieve revoked key", "error", err, "keyId", keyID)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Key revoked but failed to retrieve metadata"})
 		return
 	}
@@ -423,7 +500,7 @@ func (h *Handler) SearchAPIKeys(c *gin.Context) {
 	// Determine target username for filtering
 	isAdmin, adminErr := h.isAdmin(c.Request.Context(), user)
 	if adminErr != nil {
-		h.logger.Error("Failed to check admin status", "error", adminErr)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", adminErr)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
 		return
 	}
@@ -432,6 +509,9 @@ func (h *Handler) SearchAPIKeys(c *gin.Context) {
 	if !isAdmin {
 		// Regular user: can only search own keys
 		if targetUsername != "" && targetUsername != user.Username {
+			h.recordAudit(c, user, audit.ActionAPIKeySearch, audit.OutcomeDenied, func(e *audit.Event) {
+				e.TargetUser = targetUsername
+			})
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "non-admin users can only search their own API keys",
 			})
@@ -490,7 +570,7 @@ func (h *Handler) SearchAPIKeys(c *gin.Context) {
 		req.Pagination,
 	)
 	if err != nil {
-		h.logger.Error("Failed to search API keys",
+		h.logger.ErrorContext(c.Request.Context(), "Failed to search API keys",
 			"error", err,
 			"username", targetUsername,
 		)
@@ -498,6 +578,16 @@ func (h *Handler) SearchAPIKeys(c *gin.Context) {
 		return
 	}
 
+	if targetUsername != user.Username {
+		// An admin searching the keys of another user, or of all users.
+		h.recordAudit(c, user, audit.ActionAPIKeySearch, audit.OutcomeSuccess, func(e *audit.Event) {
+			e.TargetUser = targetUsername
+			if targetUsername == "" {
+				e.Details = map[string]string{"scope": "all users"}
+			}
+		})
+	}
+
 	// Build response
 	response := SearchAPIKeysResponse{
 		Object:  "list",
@@ -514,11 +604,20 @@ func (h *Handler) SearchAPIKeys(c *gin.Context) {
 func (h *Handler) CleanupExpiredEphemeralKeys(c *gin.Context) {
 	count, err := h.service.CleanupExpiredEphemeral(c.Request.Context())
 	if err != nil {
-		h.logger.Error("Failed to cleanup expired ephemeral keys", "error", err)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to cleanup expired ephemeral keys", "error", err)
+		h.recordAudit(c, nil, audit.ActionAPIKeyCleanup, audit.OutcomeError, func(e *audit.Event) {
+			e.Reason = err.Error()
+		})
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cleanup expired ephemeral keys"})
 		return
 	}
 
+	if count > 0 {
+		h.recordAudit(c, nil, audit.ActionAPIKeyCleanup, audit.OutcomeSuccess, func(e *audit.Event) {
+			e.Details = map[string]string{"deletedCount": strconv.FormatInt(count, 10)}
+		})
+	}
+
 	c.JSON(http.StatusOK, CleanupResponse{
 		DeletedCount: count,
 		Message:      fmt.Sprintf("Successfully deleted %d expired ephemeral key(s)", count),
@@ -543,15 +642,18 @@ func (h *Handler) BulkRevokeAPIKeys(c *gin.Context) {
 	if req.Username != user.Username {
 		isAdmin, adminErr := h.isAdmin(c.Request.Context(), user)
 		if adminErr != nil {
-			h.logger.Error("Failed to check admin status", "error", adminErr)
+			h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", adminErr)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
 			return
 		}
 		if !isAdmin {
-			h.logger.Warn("Unauthorized bulk revoke attempt",
+			h.logger.WarnContext(c.Request.Context(), "Unauthorized bulk revoke attempt",
 				"requestingUser", user.Username,
 				"targetUser", req.Username,
 			)
+			h.recordAudit(c, user, audit.ActionAPIKeyBulkRevoke, audit.OutcomeDenied, func(e *audit.Event) {
+				e.TargetUser = req.Username
+			})
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "Access denied: you can only bulk revoke your own API keys",
 			})
@@ -562,20 +664,27 @@ func (h *Handler) BulkRevokeAPIKeys(c *gin.Context) {
 	// Perform bulk revocation (scoped to caller's tenant)
 	count, err := h.service.BulkRevokeAPIKeys(c.Request.Context(), req.Username, user.Tenant)
 	if err != nil {
-		h.logger.Error("Failed to bulk revoke API keys",
+		h.logger.ErrorContext(c.Request.Context(), "Failed to bulk revoke API keys",
 			"error", err,
 			"targetUser", req.Username,
 			"requestingUser", user.Username,
 		)
+		h.recordAudit(c, user, audit.ActionAPIKeyBulkRevoke, audit.OutcomeError, func(e *audit.Event) {
+			e.TargetUser, e.Reason = req.Username, err.Error()
+		})
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API keys"})
 		return
 	}
 
-	h.logger.Info("Bulk revoked API keys",
+	h.logger.InfoContext(c.Request.Context(), "Bulk revoked API keys",
 		"count", count,
 		"targetUser", req.Username,
 		"revokedBy", user.Username,
 	)
+	h.recordAudit(c, user, audit.ActionAPIKeyBulkRevoke, audit.OutcomeSuccess, func(e *audit.Event) {
+		e.TargetUser = req.Username
+		e.Details = map[string]string{"revokedCount": strconv.Itoa(count)}
+	})
 
 	response := BulkRevokeResponse{
 		RevokedCount: count,
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
@@ -2212,3 +2213,68 @@ func TestCreateAPIKey_NameValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditLogOfKeyOperations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewMockStore()
+	service := NewServiceWithLogger(store, &config.Config{}, fixedSubSelector{}, logger.Development())
+	handler := NewHandler(logger.Development(), service, newMockAdminChecker())
+	auditStore := audit.NewMockStore()
+	handler.SetAuditLog(audit.NewLog(logger.Development(), auditStore))
+
+	alice := &token.UserContext{Username: "alice", Groups: []string{"tier-free"}, Tenant: "test-tenant"}
+	bob := &token.UserContext{Username: "bob", Groups: []string{"tier-free"}, Tenant: "test-tenant"}
+	admin := &token.UserContext{Username: "admin", Groups: []string{"admin-users"}, Tenant: "test-tenant"}
+
+	serve := func(user *token.UserContext, method, target, body string, params gin.Params, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("request_id", "req-"+method)
+		if user != nil {
+			c.Set("user", user)
+		}
+		c.Params = params
+		handle(c)
+		return w
+	}
+
+	w := serve(alice, http.MethodPost, "/v1/api-keys", `{"name": "my-key"}`, nil, handler.CreateAPIKey)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created CreateAPIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	keyParams := gin.Params{{Key: "id", Value: created.ID}}
+	require.Equal(t, http.StatusOK, serve(alice, http.MethodGet, "/v1/api-keys/"+created.ID, "", keyParams, handler.GetAPIKey).Code)
+	require.Equal(t, http.StatusForbidden, serve(bob, http.MethodPost, "/v1/api-keys/bulk-revoke", testBulkRevokeAliceJSON, nil, handler.BulkRevokeAPIKeys).Code)
+	require.Equal(t, http.StatusOK, serve(admin, http.MethodDelete, "/v1/api-keys/"+created.ID, "", keyParams, handler.RevokeAPIKey).Code)
+	require.Equal(t, http.StatusOK, serve(nil, http.MethodPost, "/internal/v1/api-keys/validate", fmt.Sprintf(`{"key": %q}`, created.Key), nil, handler.ValidateAPIKeyHandler).Code)
+	require.Equal(t, http.StatusOK, serve(nil, http.MethodPost, "/internal/v1/api-keys/validate", `{"key": "not-an-api-key"}`, nil, handler.ValidateAPIKeyHandler).Code)
+
+	events, _, err := auditStore.Query(context.Background(), audit.Query{})
+	require.NoError(t, err)
+	type summary struct {
+		Actor      string
+		Action     audit.Action
+		Outcome    audit.Outcome
+		TargetUser string
+	}
+	var got []summary
+	for _, e := range events {
+		got = append(got, summary{e.Actor, e.Action, e.Outcome, e.TargetUser})
+	}
+	assert.Equal(t, []summary{
+		{"alice", audit.ActionAPIKeyCreate, audit.OutcomeSuccess, "alice"},
+		{"bob", audit.ActionAPIKeyBulkRevoke, audit.OutcomeDenied, "alice"},
+		{"admin", audit.ActionAPIKeyRevoke, audit.OutcomeSuccess, "alice"},
+		{audit.UnauthenticatedActor, audit.ActionAPIKeyValidate, audit.OutcomeDenied, ""},
+	}, got, "reading one's own key and non-key strings are not audited")
+
+	assert.Equal(t, created.ID, events[0].KeyID)
+	assert.Equal(t, created.KeyPrefix, events[0].Details["keyPrefix"])
+	assert.Equal(t, "req-POST", events[0].RequestID)
+	assert.Equal(t, "key revoked or expired", events[3].Reason)
+	assert.Equal(t, created.KeyPrefix, events[3].Details["keyPrefix"])
+	assert.NotContains(t, fmt.Sprint(events), created.Key, "the plaintext key is never recorded")
+}
@@ -65,13 +65,28 @@ func GenerateAPIKey() (plaintext, hash, prefix string, err error) {
 	hash = hashWithSalt(keyID, secret)
 
 	// 5. Create display prefix (first 12 chars of key_id + ellipsis)
+	prefix = displayPrefix(keyID)
+
+	return plaintext, hash, prefix, nil
+}
+
+// displayPrefix returns the display prefix of a key_id: the key prefix and its first
+// 12 chars, followed by an ellipsis.
+func displayPrefix(keyID string) string {
 	if len(keyID) >= displayPrefixLength {
-		prefix = KeyPrefix + keyID[:displayPrefixLength] + "..."
-	} else {
-		prefix = KeyPrefix + keyID + "..."
+		return KeyPrefix + keyID[:displayPrefixLength] + "..."
 	}
+	return KeyPrefix + keyID + "..."
+}
 
-	return plaintext, hash, prefix, nil
+// DisplayPrefix returns the display prefix of an API key, as shown in key listings, or
+// "" if key is not an API key. It reveals nothing of the secret.
+func DisplayPrefix(key string) string {
+	keyID, _, err := ParseAPIKey(key)
+	if err != nil {
+		return ""
+	}
+	return displayPrefix(keyID)
 }
 
 // hashWithSalt computes SHA-256(keyID + "\x00" + secret) for storage.
@@ -14,6 +14,7 @@ import (
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/config"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
 )
 
@@ -40,6 +41,14 @@ type Service struct {
 	// Prevents Postgres row-lock storms when many requests share one key.
 	lastUsedDebounce    sync.Map
 	lastUsedDebounceTTL time.Duration
+
+	// validationMetrics, when set, records the latency and outcome of key validations.
+	validationMetrics metrics.KeyValidationRecorder
+}
+
+// SetValidationMetrics sets the recorder of the key validations.
+func (s *Service) SetValidationMetrics(recorder metrics.KeyValidationRecorder) {
+	s.validationMetrics = recorder
 }
 
 func (s *Service) GetMaxExpirationDays() int {
@@ -158,7 +167,7 @@ func (s *Service) CreateAPIKey(
 		subResp, selectErr = s.subSelector.SelectHighestPriority(userGroups, username)
 	}
 	if selectErr != nil {
-		s.logger.Warn("Subscription selection failed when creating API key",
+		s.logger.WarnContext(ctx, "Subscription selection failed when creating API key",
 			"user", username,
 			"requestedSubscription", requestedSubscription,
 			"error", selectErr,
@@ -178,7 +187,7 @@ func (s *Service) CreateAPIKey(
 		return nil, fmt.Errorf("failed to store API key: %w", err)
 	}
 
-	s.logger.Info("Created API key", "user", username, "groups", userGroups, "id", keyID, "ephemeral", ephemeral)
+	s.logger.InfoContext(ctx, "Created API key", "user", username, "groups", userGroups, "id", keyID, "ephemeral", ephemeral)
 
 	// Return plaintext to user - THIS IS THE ONLY TIME IT'S AVAILABLE
 	formatted := expiresAt.Format(time.RFC3339)
@@ -200,6 +209,19 @@ func (s *Service) GetAPIKey(ctx context.Context, id string) (*ApiKey, error) {
 	return s.store.Get(ctx, id)
 }
 
+// KeyOwner returns the user and tenant of an API key, for the usage of the key.
+// found is false when the key does not exist.
+func (s *Service) KeyOwner(ctx context.Context, id string) (string, string, bool, error) {
+	key, err := s.store.Get(ctx, id)
+	if errors.Is(err, ErrKeyNotFound) {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, err
+	}
+	return key.Username, key.Tenant, true, nil
+}
+
 // ValidateAPIKey validates an API key (called by Authorino HTTP callback).
 // Per Feature Refinement "Gateway Integration (Inference Flow)":
 // - Parses the key to extract key_id and secret
@@ -207,6 +229,37 @@ func (s *Service) GetAPIKey(ctx context.Context, id string) (*ApiKey, error) {
 // - Looks up by hash (O(1) indexed lookup)
 // - Returns user identity if valid, rejection reason if invalid.
 func (s *Service) ValidateAPIKey(ctx context.Context, key string) (*ValidationResult, error) {
+	start := time.Now()
+	result, err := s.validateAPIKey(ctx, key)
+	if s.validationMetrics != nil {
+		s.validationMetrics.RecordKeyValidation(validationOutcome(result, err), time.Since(start))
+	}
+	return result, err
+}
+
+// validationOutcomes are the metric labels of the rejection reasons.
+var validationOutcomes = map[string]string{
+	"invalid key format":            "invalid_format",
+	"key not found":                 "not_found",
+	"key revoked or expired":        "revoked_or_expired",
+	"key has no subscription bound": "no_subscription",
+}
+
+// validationOutcome returns the metric label of a validation result.
+func validationOutcome(result *ValidationResult, err error) string {
+	switch {
+	case err != nil || result == nil:
+		return "error"
+	case result.Valid:
+		return "valid"
+	}
+	if outcome, ok := validationOutcomes[result.Reason]; ok {
+		return outcome
+	}
+	return "invalid"
+}
+
+func (s *Service) validateAPIKey(ctx context.Context, key string) (*ValidationResult, error) {
 	// Check key format
 	if !IsValidKeyFormat(key) {
 		return &ValidationResult{
@@ -254,7 +307,7 @@ func (s *Service) ValidateAPIKey(ctx context.Context, key string) (*ValidationRe
 			// Recover from panics to prevent crashing the entire process
 			defer func() {
 				if r := recover(); r != nil {
-					s.logger.Error("Panic in UpdateLastUsed goroutine", "panic", r, "key_id", metadata.ID)
+					s.logger.ErrorContext(ctx, "Panic in UpdateLastUsed goroutine", "panic", r, "key_id", metadata.ID)
 				}
 			}()
 
@@ -268,7 +321,7 @@ func (s *Service) ValidateAPIKey(ctx context.Context, key string) (*ValidationRe
 				// if this goroutine was delayed by the scheduler after its context expired.
 				s.clearDebounceSlot(metadata.ID, slot)
 				// Log warning but don't fail validation - this is best-effort tracking
-				s.logger.Warn("Failed to update last_used_at", "key_id", metadata.ID, "error", err)
+				s.logger.WarnContext(ctx, "Failed to update last_used_at", "key_id", metadata.ID, "error", err)
 			}
 		}()
 	}
@@ -285,7 +338,7 @@ func (s *Service) ValidateAPIKey(ctx context.Context, key string) (*ValidationRe
 	// This prevents legacy keys, bad migrations, or manual writes with empty subscription
 	// from bypassing the "subscription bound at mint" access control invariant
 	if strings.TrimSpace(metadata.Subscription) == "" {
-		s.logger.Warn("API key missing bound subscription", "key_id", metadata.ID)
+		s.logger.WarnContext(ctx, "API key missing bound subscription", "key_id", metadata.ID)
 		return &ValidationResult{
 			Valid:  false,
 			Reason: "key has no subscription bound",
@@ -296,7 +349,7 @@ func (s *Service) ValidateAPIKey(ctx context.Context, key string) (*ValidationRe
 	// Database should always return valid UUIDs, but verify to prevent malformed IDs
 	// from being used in cache keys or authorization decisions
 	if _, err := uuid.Parse(metadata.ID); err != nil {
-		s.logger.Error("API key has invalid UUID format", "key_id", metadata.ID, "error", err)
+		s.logger.ErrorContext(ctx, "API key has invalid UUID format", "key_id", metadata.ID, "error", err)
 		return nil, fmt.Errorf("database integrity error: invalid key ID format: %w", err)
 	}
 
@@ -330,6 +383,12 @@ func (s *Service) Search(
 	return s.store.Search(ctx, username, tenant, filters, sort, pagination)
 }
 
+// ListUsers returns a page of the users with API keys in the tenant and the counts of
+// their keys.
+func (s *Service) ListUsers(ctx context.Context, tenant string, pagination *PaginationParams) (*UserListResult, error) {
+	return s.store.ListUsers(ctx, tenant, pagination)
+}
+
 // BulkRevokeAPIKeys revokes all active keys for a user
 // Returns count of revoked keys.
 func (s *Service) BulkRevokeAPIKeys(ctx context.Context, username string, tenant string) (int, error) {
@@ -422,6 +481,6 @@ func (s *Service) CleanupExpiredEphemeral(ctx context.Context) (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("cleanup failed: %w", err)
 	}
-	s.logger.Info("Ephemeral key cleanup completed", "deletedCount", count)
+	s.logger.InfoContext(ctx, "Ephemeral key cleanup completed", "deletedCount", count)
 	return count, nil
 }
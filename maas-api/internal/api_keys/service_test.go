@@ -68,6 +68,32 @@ func TestValidateAPIKey_ValidKey(t *testing.T) {
 	assert.Equal(t, "default-sub", result.Subscription)
 }
 
+type validationRecorder struct {
+	outcomes []string
+}
+
+func (r *validationRecorder) RecordKeyValidation(outcome string, _ time.Duration) {
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+func TestValidateAPIKey_RecordsMetrics(t *testing.T) {
+	ctx := context.Background()
+	svc, store := createTestService(t)
+	recorder := &validationRecorder{}
+	svc.SetValidationMetrics(recorder)
+
+	plainKey, hash := createTestAPIKey(t)
+	require.NoError(t, store.AddKey(ctx, "alice", "550e8400-e29b-41d4-a716-446655440000", hash, "Test Key", "", nil, "default-sub", "", nil, false))
+	unknownKey, _ := createTestAPIKey(t)
+
+	for _, key := range []string{plainKey, unknownKey, "invalid-key"} {
+		_, err := svc.ValidateAPIKey(ctx, key)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"valid", "not_found", "invalid_format"}, recorder.outcomes)
+}
+
 func TestValidateAPIKey_InvalidFormat(t *testing.T) {
 	ctx := context.Background()
 	svc, _ := createTestService(t)
@@ -464,6 +490,27 @@ func TestGetAPIKey_NotFound(t *testing.T) {
 	assert.Equal(t, api_keys.ErrKeyNotFound, err)
 }
 
+func TestKeyOwner(t *testing.T) {
+	ctx := context.Background()
+	svc, store := createTestService(t)
+
+	keyID := "550e8400-e29b-41d4-a716-446655440015"
+	_, hash := createTestAPIKey(t)
+	require.NoError(t, store.AddKey(ctx, "alice", keyID, hash, "Alice's Key", "", nil, "default-sub", "", nil, false))
+	meta, err := svc.GetAPIKey(ctx, keyID)
+	require.NoError(t, err)
+
+	owner, tenant, found, err := svc.KeyOwner(ctx, keyID)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "alice", owner)
+	assert.Equal(t, meta.Tenant, tenant)
+
+	_, _, found, err = svc.KeyOwner(ctx, "nonexistent-key")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
 func TestRevokeAPIKey(t *testing.T) {
 	ctx := context.Background()
 	svc, store := createTestService(t)
@@ -62,6 +62,10 @@ type MetadataStore interface {
 		pagination *PaginationParams,
 	) (*PaginatedResult, error)
 
+	// ListUsers returns the users with API keys and the counts of their keys per status,
+	// ordered by username. Tenant scoping is mandatory.
+	ListUsers(ctx context.Context, tenant string, pagination *PaginationParams) (*UserListResult, error)
+
 	Get(ctx context.Context, jti string) (*ApiKey, error)
 
 	// GetByHash looks up an API key by its SHA-256 hash (for Authorino validation).
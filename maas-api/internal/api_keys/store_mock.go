@@ -371,6 +371,61 @@ func (m *MockStore) Search(
 	}, nil
 }
 
+// ListUsers returns the users with API keys in the tenant, with the counts of their
+// keys per effective status and the groups of their most recent key.
+func (m *MockStore) ListUsers(_ context.Context, tenant string, pagination *PaginationParams) (*UserListResult, error) {
+	if pagination.Limit < 1 || pagination.Limit > MaxLimit {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.New("offset must be non-negative")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	byUser := map[string]*UserKeys{}
+	latest := map[string]string{}
+	for _, k := range m.filterKeys("", tenant, nil, false, time.Now().UTC()) {
+		u, ok := byUser[k.Username]
+		if !ok {
+			u = &UserKeys{Username: k.Username}
+			byUser[k.Username] = u
+		}
+		switch k.Status {
+		case StatusActive:
+			u.ActiveKeys++
+		case StatusRevoked:
+			u.RevokedKeys++
+		case StatusExpired:
+			u.ExpiredKeys++
+		}
+		if k.LastUsedAt > u.LastUsedAt {
+			u.LastUsedAt = k.LastUsedAt
+		}
+		if k.CreationDate >= latest[k.Username] {
+			latest[k.Username] = k.CreationDate
+			u.Groups = k.Groups
+		}
+	}
+
+	users := make([]UserKeys, 0, len(byUser))
+	for _, u := range byUser {
+		users = append(users, *u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	if pagination.Offset >= len(users) {
+		return &UserListResult{Users: []UserKeys{}}, nil
+	}
+	users = users[pagination.Offset:]
+	hasMore := len(users) > pagination.Limit
+	if hasMore {
+		users = users[:pagination.Limit]
+	}
+	return &UserListResult{Users: users, HasMore: hasMore}, nil
+}
+
 func (m *MockStore) Get(ctx context.Context, keyID string) (*ApiKey, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -38,6 +38,11 @@ func NewPostgresStore(db *sql.DB, log *logger.Logger, tenantName string) *Postgr
 	}
 }
 
+// DB returns the database connection of the store, shared with the usage store.
+func (s *PostgresStore) DB() *sql.DB {
+	return s.db
+}
+
 // AddKey stores an API key with hash-only storage (no plaintext).
 // Keys can be permanent (expiresAt=nil) or expiring (expiresAt set).
 // ephemeral marks the key as short-lived for programmatic use.
@@ -84,7 +89,7 @@ func (s *PostgresStore) AddKey(
 		return fmt.Errorf("failed to insert API key: %w", err)
 	}
 
-	s.logger.Debug("Stored API key", "id", keyID, "user", username, "ephemeral", ephemeral)
+	s.logger.DebugContext(ctx, "Stored API key", "id", keyID, "user", username, "ephemeral", ephemeral)
 	return nil
 }
 
@@ -357,6 +362,70 @@ func (s *PostgresStore) Search(
 	}, nil
 }
 
+// ListUsers returns the users with API keys in the tenant, with the counts of their
+// keys per effective status and the groups of their most recent key.
+func (s *PostgresStore) ListUsers(ctx context.Context, _ string, pagination *PaginationParams) (*UserListResult, error) {
+	if pagination.Limit < 1 || pagination.Limit > MaxLimit {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+	if pagination.Offset < 0 {
+		return nil, errors.New("offset must be non-negative")
+	}
+
+	// Tenant scoping is mandatory - use store's tenant for isolation
+	query := `
+		WITH keys AS (
+			SELECT username, created_at, last_used_at, user_groups,
+				CASE WHEN status = 'active' AND expires_at IS NOT NULL AND expires_at < NOW() THEN 'expired' ELSE status END AS status
+			FROM api_keys
+			WHERE tenant = $1 AND ephemeral = FALSE
+		), latest AS (
+			SELECT DISTINCT ON (username) username, user_groups
+			FROM keys
+			ORDER BY username, created_at DESC
+		)
+		SELECT keys.username, latest.user_groups,
+			COUNT(*) FILTER (WHERE keys.status = 'active'),
+			COUNT(*) FILTER (WHERE keys.status = 'revoked'),
+			COUNT(*) FILTER (WHERE keys.status = 'expired'),
+			MAX(keys.last_used_at)
+		FROM keys
+		JOIN latest ON latest.username = keys.username
+		GROUP BY keys.username, latest.user_groups
+		ORDER BY keys.username
+		LIMIT $2 OFFSET $3
+	`
+
+	// Fetch one extra to determine hasMore
+	rows, err := s.db.QueryContext(ctx, query, s.tenantName, pagination.Limit+1, pagination.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []UserKeys{}
+	for rows.Next() {
+		var u UserKeys
+		var lastUsedAt sql.NullTime
+		if err := rows.Scan(&u.Username, pq.Array(&u.Groups), &u.ActiveKeys, &u.RevokedKeys, &u.ExpiredKeys, &lastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		if lastUsedAt.Valid {
+			u.LastUsedAt = lastUsedAt.Time.UTC().Format(time.RFC3339)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	hasMore := len(users) > pagination.Limit
+	if hasMore {
+		users = users[:pagination.Limit]
+	}
+	return &UserListResult{Users: users, HasMore: hasMore}, nil
+}
+
 // Get retrieves a single API key by ID.
 func (s *PostgresStore) Get(ctx context.Context, keyID string) (*ApiKey, error) {
 	// Use effective status to return 'expired' for keys past expiration date
@@ -433,7 +502,7 @@ func (s *PostgresStore) GetByHash(ctx context.Context, keyHash string) (*ApiKey,
 			// Auto-update status to expired
 			updateQuery := `UPDATE api_keys SET status = 'expired' WHERE id = $1 AND tenant = $2 AND status = 'active'`
 			if _, err := s.db.ExecContext(ctx, updateQuery, k.ID, s.tenantName); err != nil {
-				s.logger.Warn("Failed to update expired key status", "key_id", k.ID, "error", err)
+				s.logger.WarnContext(ctx, "Failed to update expired key status", "key_id", k.ID, "error", err)
 			}
 			k.Status = StatusExpired
 		}
@@ -464,7 +533,7 @@ func (s *PostgresStore) InvalidateAll(ctx context.Context, username string, tena
 	}
 
 	count := int(rows)
-	s.logger.Info("Revoked all keys for user", "count", count, "user", username)
+	s.logger.InfoContext(ctx, "Revoked all keys for user", "count", count, "user", username)
 	return count, nil
 }
 
@@ -484,7 +553,7 @@ func (s *PostgresStore) Revoke(ctx context.Context, keyID string) error {
 		return ErrKeyNotFound
 	}
 
-	s.logger.Info("Revoked API key", "id", keyID)
+	s.logger.InfoContext(ctx, "Revoked API key", "id", keyID)
 	return nil
 }
 
@@ -516,7 +585,7 @@ func (s *PostgresStore) DeleteExpiredEphemeral(ctx context.Context) (int64, erro
 	}
 
 	if rows > 0 {
-		s.logger.Info("Deleted expired ephemeral keys", "count", rows)
+		s.logger.InfoContext(ctx, "Deleted expired ephemeral keys", "count", rows)
 	}
 
 	return rows, nil
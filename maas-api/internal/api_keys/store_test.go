@@ -3,6 +3,7 @@ package api_keys_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -320,3 +321,39 @@ func TestInvalidateAll_TenantScoped(t *testing.T) {
 		assert.Equal(t, api_keys.StatusActive, key.Status, "tenant-b key %s should remain active", id)
 	}
 }
+
+func TestListUsers(t *testing.T) {
+	ctx := t.Context()
+	store := createTestStore(t)
+	defer store.Close()
+
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, store.AddKey(ctx, "bob", "b-1", "bh1", "key-b1", "", []string{"dev"}, "sub-1", "tenant-a", nil, false))
+	require.NoError(t, store.AddKey(ctx, "alice", "a-1", "ah1", "key-a1", "", []string{"premium"}, "sub-1", "tenant-a", nil, false))
+	require.NoError(t, store.AddKey(ctx, "alice", "a-2", "ah2", "key-a2", "", []string{"premium"}, "sub-1", "tenant-a", &past, false))
+	require.NoError(t, store.AddKey(ctx, "alice", "a-3", "ah3", "key-a3", "", []string{"premium"}, "sub-1", "tenant-a", nil, false))
+	require.NoError(t, store.Revoke(ctx, "a-3"))
+	require.NoError(t, store.AddKey(ctx, "alice", "a-eph", "aheph", "key-eph", "", []string{"premium"}, "sub-1", "tenant-a", nil, true))
+	require.NoError(t, store.AddKey(ctx, "carol", "c-1", "ch1", "key-c1", "", nil, "sub-1", "tenant-b", nil, false))
+
+	result, err := store.ListUsers(ctx, "tenant-a", &api_keys.PaginationParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.True(t, result.HasMore)
+	assert.Equal(t, api_keys.UserKeys{
+		Username:    "alice",
+		Groups:      []string{"premium"},
+		ActiveKeys:  1,
+		RevokedKeys: 1,
+		ExpiredKeys: 1,
+	}, result.Users[0])
+
+	result, err = store.ListUsers(ctx, "tenant-a", &api_keys.PaginationParams{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.False(t, result.HasMore)
+	assert.Equal(t, "bob", result.Users[0].Username)
+
+	_, err = store.ListUsers(ctx, "tenant-a", &api_keys.PaginationParams{Limit: 0})
+	require.Error(t, err)
+}
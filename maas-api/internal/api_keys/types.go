@@ -59,6 +59,22 @@ type PaginatedResult struct {
 	HasMore bool
 }
 
+// UserKeys summarizes the API keys of a user. Ephemeral keys are not counted.
+type UserKeys struct {
+	Username    string   `json:"username"`
+	Groups      []string `json:"groups,omitempty"` // Groups snapshot of the user's most recent key
+	ActiveKeys  int      `json:"active"`
+	RevokedKeys int      `json:"revoked"`
+	ExpiredKeys int      `json:"expired"`
+	LastUsedAt  string   `json:"lastUsedAt,omitempty"` // Most recent use of any of the keys
+}
+
+// UserListResult holds a page of the users with API keys, ordered by username.
+type UserListResult struct {
+	Users   []UserKeys
+	HasMore bool
+}
+
 // ============================================================
 // SEARCH REQUEST/RESPONSE TYPES
 // ============================================================
@@ -166,3 +182,13 @@ type CleanupResponse struct {
 	DeletedCount int64  `json:"deletedCount"`
 	Message      string `json:"message"`
 }
+
+// ============================================================
+// CONFIG TYPES
+// ============================================================
+
+// ConfigResponse is the body of GET /v1/api-keys/config.
+type ConfigResponse struct {
+	MaxExpirationDays      int    `json:"max_expiration_days"`
+	EphemeralMaxExpiration string `json:"ephemeral_max_expiration"` // Go duration, e.g. "1h0m0s"
+}
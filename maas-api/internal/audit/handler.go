@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+// AdminChecker reports whether a user may read the audit log.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, user *token.UserContext) (bool, error)
+}
+
+// Handler serves the audit log API.
+type Handler struct {
+	store        Store
+	audit        *Log
+	adminChecker AdminChecker
+	logger       *logger.Logger
+}
+
+// NewHandler creates an audit handler. Reads of the log are recorded in auditLog.
+func NewHandler(log *logger.Logger, store Store, auditLog *Log, adminChecker AdminChecker) *Handler {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Handler{store: store, audit: auditLog, adminChecker: adminChecker, logger: log}
+}
+
+// Response is the body of GET /v1/admin/audit.
+type Response struct {
+	Events []Event `json:"events"`
+	// HasMore is true when more events matched; pass the last sequence as "after" to
+	// read them.
+	HasMore bool `json:"hasMore"`
+}
+
+// requireAdmin returns the user of the request when they are an admin, or responds with
+// an error, recording the refusal, and returns nil.
+func (h *Handler) requireAdmin(c *gin.Context, action string) *token.UserContext {
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		return nil
+	}
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		return nil
+	}
+
+	isAdmin, err := h.adminChecker.IsAdmin(c.Request.Context(), user)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return nil
+	}
+	if !isAdmin {
+		event := NewEvent(c, user.Username, ActionAuditRead, OutcomeDenied)
+		event.Details = map[string]string{"operation": action}
+		h.audit.Record(c.Request.Context(), event)
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can read the audit log"})
+		return nil
+	}
+	return user
+}
+
+// parseQuery reads the query parameters from, to (RFC 3339), actor, user, action,
+// after and limit.
+func parseQuery(c *gin.Context) (Query, error) {
+	q := Query{
+		Actor:      c.Query("actor"),
+		TargetUser: c.Query("user"),
+		Action:     Action(c.Query("action")),
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &q.From}, {"to", &q.To}} {
+		if v := c.Query(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return q, fmt.Errorf("%w: %s must be an RFC 3339 time", ErrInvalidQuery, p.name)
+			}
+			*p.dst = t.UTC()
+		}
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return q, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	if v := c.Query("after"); v != "" {
+		after, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || after < 0 {
+			return q, fmt.Errorf("%w: after must be a non-negative sequence", ErrInvalidQuery)
+		}
+		q.AfterSequence = after
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return q, fmt.Errorf("%w: limit must be a positive integer", ErrInvalidQuery)
+		}
+		q.Limit = min(limit, MaxQueryLimit)
+	}
+	return q, nil
+}
+
+// GetEvents handles GET /v1/admin/audit: the events of the tenant's audit log, in
+// sequence order. Only admins may call it.
+func (h *Handler) GetEvents(c *gin.Context) {
+	user := h.requireAdmin(c, "query")
+	if user == nil {
+		return
+	}
+	q, err := parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event := NewEvent(c, user.Username, ActionAuditRead, OutcomeSuccess)
+	event.TargetUser = q.TargetUser
+	event.Details = map[string]string{"operation": "query"}
+	h.audit.Record(c.Request.Context(), event)
+
+	events, more, err := h.store.Query(c.Request.Context(), q)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to query audit events", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query audit events"})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Events: events, HasMore: more})
+}
+
+// VerifyChain handles GET /v1/admin/audit/verify: it walks the tenant's audit log and
+// reports whether the hash chain is intact. Only admins may call it.
+func (h *Handler) VerifyChain(c *gin.Context) {
+	user := h.requireAdmin(c, "verify")
+	if user == nil {
+		return
+	}
+	result, err := Verify(c.Request.Context(), h.store)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to verify audit log", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify audit log"})
+		return
+	}
+	if !result.Valid {
+		h.logger.ErrorContext(c.Request.Context(), "Audit log chain is broken", "sequence", result.FirstInvalidSequence, "reason", result.Error)
+	}
+
+	event := NewEvent(c, user.Username, ActionAuditRead, OutcomeSuccess)
+	event.Details = map[string]string{"operation": "verify", "valid": strconv.FormatBool(result.Valid)}
+	h.audit.Record(c.Request.Context(), event)
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,122 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+type groupAdminChecker struct{}
+
+func (groupAdminChecker) IsAdmin(_ context.Context, user *token.UserContext) (bool, error) {
+	return slices.Contains(user.Groups, "admin-users"), nil
+}
+
+func setupAuditHandler(t *testing.T) (*gin.Engine, *audit.MockStore) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := audit.NewMockStore()
+	appendEvents(t, store, 3)
+	require.NoError(t, store.Append(t.Context(), &audit.Event{Actor: "bob", Action: audit.ActionAPIKeyCreate, Outcome: audit.OutcomeSuccess, TargetUser: "bob"}))
+	h := audit.NewHandler(logger.Development(), store, audit.NewLog(logger.Development(), store), groupAdminChecker{})
+
+	router := gin.New()
+	withUser := func(c *gin.Context) {
+		user := &token.UserContext{Username: c.GetHeader("X-Test-User")}
+		if user.Username == "admin" {
+			user.Groups = []string{"admin-users"}
+		}
+		c.Set("user", user)
+	}
+	router.GET("/v1/admin/audit", withUser, h.GetEvents)
+	router.GET("/v1/admin/audit/verify", withUser, h.VerifyChain)
+	return router, store
+}
+
+func getAudit(t *testing.T, router *gin.Engine, user, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetEvents(t *testing.T) {
+	router, store := setupAuditHandler(t)
+
+	w := getAudit(t, router, "admin", "/v1/admin/audit?action=api_key.revoke&limit=2")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp audit.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Events, 2)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, int64(1), resp.Events[0].Sequence)
+
+	w = getAudit(t, router, "admin", "/v1/admin/audit?action=api_key.revoke&after=2")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, int64(3), resp.Events[0].Sequence)
+	assert.False(t, resp.HasMore)
+
+	events, _, err := store.Query(t.Context(), audit.Query{Action: audit.ActionAuditRead})
+	require.NoError(t, err)
+	require.Len(t, events, 2, "reads of the audit log are audited")
+	assert.Equal(t, "admin", events[0].Actor)
+}
+
+func TestGetEvents_Forbidden(t *testing.T) {
+	router, store := setupAuditHandler(t)
+
+	w := getAudit(t, router, "alice", "/v1/admin/audit")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	events, _, err := store.Query(t.Context(), audit.Query{Actor: "alice"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, audit.OutcomeDenied, events[0].Outcome)
+}
+
+func TestGetEvents_InvalidQuery(t *testing.T) {
+	router, _ := setupAuditHandler(t)
+
+	for _, target := range []string{
+		"/v1/admin/audit?from=yesterday",
+		"/v1/admin/audit?from=2026-10-14T12:00:00Z&to=2026-10-14T11:00:00Z",
+		"/v1/admin/audit?after=-1",
+		"/v1/admin/audit?limit=0",
+	} {
+		w := getAudit(t, router, "admin", target)
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	router, store := setupAuditHandler(t)
+
+	w := getAudit(t, router, "admin", "/v1/admin/audit/verify")
+	require.Equal(t, http.StatusOK, w.Code)
+	var result audit.VerifyResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, audit.VerifyResult{Valid: true, Events: 4}, result)
+
+	store.Tamper(2, func(e *audit.Event) { e.TargetUser = "mallory" })
+	w = getAudit(t, router, "admin", "/v1/admin/audit/verify")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(2), result.FirstInvalidSequence)
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+)
+
+// appendTimeout bounds the write of an event, which outlives a cancelled request.
+const appendTimeout = 5 * time.Second
+
+// Log records events in the audit store. Each event is also written to the structured
+// log with the message "audit", so a log shipper can forward the trail to an external
+// sink, and to the Forwarder, if any. A nil *Log records nothing.
+type Log struct {
+	store     Store
+	logger    *logger.Logger
+	now       func() time.Time
+	forwarder *Forwarder
+	misuse    *misuseDetector
+}
+
+// NewLog creates an audit log appending to store.
+func NewLog(log *logger.Logger, store Store) *Log {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Log{store: store, logger: log, now: time.Now}
+}
+
+// SetForwarder sets the forwarder of the events to a SIEM.
+func (l *Log) SetForwarder(forwarder *Forwarder) {
+	l.forwarder = forwarder
+}
+
+// SetMisuseDetection records an api_key.misuse event when the validations of a key prefix
+// are rejected threshold times within window. A threshold below 1 disables the detection.
+func (l *Log) SetMisuseDetection(threshold int, window time.Duration) {
+	l.misuse = nil
+	if threshold > 0 {
+		l.misuse = newMisuseDetector(threshold, window)
+	}
+}
+
+// NewEvent returns an event of the request: its actor, action and outcome, and the
+// request ID set by middleware.RequestID.
+func NewEvent(c *gin.Context, actor string, action Action, outcome Outcome) Event {
+	return Event{
+		Actor:     actor,
+		Action:    action,
+		Outcome:   outcome,
+		RequestID: c.GetString(middleware.RequestIDKey),
+	}
+}
+
+// Record appends the event and forwards it. The operation it records has already been
+// made, so a failure to append is logged rather than returned; the event is still
+// forwarded.
+func (l *Log) Record(ctx context.Context, event Event) {
+	if l == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = l.now()
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), appendTimeout)
+	defer cancel()
+	defer l.detectMisuse(ctx, event)
+	if err := l.store.Append(ctx, &event); err != nil {
+		l.logger.ErrorContext(ctx, "Failed to append audit event", "error", err,
+			"action", event.Action, "actor", event.Actor, "outcome", event.Outcome, "requestId", event.RequestID)
+		l.forwarder.Forward(event)
+		return
+	}
+	l.forwarder.Forward(event)
+	l.logger.InfoContext(ctx, "audit",
+		"sequence", event.Sequence,
+		"action", event.Action,
+		"actor", event.Actor,
+		"outcome", event.Outcome,
+		"targetUser", event.TargetUser,
+		"keyId", event.KeyID,
+		"reason", event.Reason,
+		"requestId", event.RequestID,
+		"details", event.Details,
+		"hash", event.Hash,
+	)
+}
+
+// detectMisuse records the misuse event of a rejected key validation reaching the
+// threshold.
+func (l *Log) detectMisuse(ctx context.Context, event Event) {
+	if l.misuse == nil || event.Action != ActionAPIKeyValidate || event.Outcome != OutcomeDenied {
+		return
+	}
+	if misuse := l.misuse.observe(event); misuse != nil {
+		l.Record(ctx, *misuse)
+	}
+}
@@ -0,0 +1,156 @@
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+func appendEvents(t *testing.T, store *audit.MockStore, n int) {
+	t.Helper()
+	for i := range n {
+		require.NoError(t, store.Append(t.Context(), &audit.Event{
+			Time:       time.Date(2026, 10, 14, 12, 0, i, 123456789, time.UTC),
+			Actor:      "admin",
+			Action:     audit.ActionAPIKeyRevoke,
+			Outcome:    audit.OutcomeSuccess,
+			TargetUser: "alice",
+			KeyID:      "key-1",
+			Details:    map[string]string{"b": "2", "a": "1"},
+		}))
+	}
+}
+
+func TestRecordChainsEvents(t *testing.T) {
+	store := audit.NewMockStore()
+	log := audit.NewLog(logger.Development(), store)
+	for i := range 3 {
+		log.Record(t.Context(), audit.Event{
+			Time:       time.Date(2026, 10, 14, 12, 0, i, 123456789, time.UTC),
+			Actor:      "admin",
+			Action:     audit.ActionAPIKeyRevoke,
+			Outcome:    audit.OutcomeSuccess,
+			TargetUser: "alice",
+		})
+	}
+
+	events, more, err := store.Query(t.Context(), audit.Query{})
+	require.NoError(t, err)
+	assert.False(t, more)
+	require.Len(t, events, 3)
+	assert.Equal(t, int64(1), events[0].Sequence)
+	assert.Empty(t, events[0].PrevHash, "the first event has no predecessor")
+	assert.Equal(t, events[0].Hash, events[1].PrevHash)
+	assert.Equal(t, events[1].Hash, events[2].PrevHash)
+	assert.Equal(t, time.Date(2026, 10, 14, 12, 0, 0, 123456000, time.UTC), events[0].Time, "times are stored with microsecond precision")
+	assert.Equal(t, events[2].Hash, events[2].ComputeHash(events[1].Hash))
+
+	result, err := audit.Verify(t.Context(), store)
+	require.NoError(t, err)
+	assert.Equal(t, audit.VerifyResult{Valid: true, Events: 3}, result)
+}
+
+func TestRecordNilLog(t *testing.T) {
+	var log *audit.Log
+	assert.NotPanics(t, func() { log.Record(t.Context(), audit.Event{Action: audit.ActionAPIKeyCreate}) })
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		edit   func(*audit.Event)
+		reason string
+	}{
+		{
+			name:   "edited field",
+			edit:   func(e *audit.Event) { e.TargetUser = "bob" },
+			reason: "hash does not match the event",
+		},
+		{
+			name:   "edited details",
+			edit:   func(e *audit.Event) { e.Details["a"] = "3" },
+			reason: "hash does not match the event",
+		},
+		{
+			name: "rehashed event",
+			edit: func(e *audit.Event) {
+				e.Actor = "mallory"
+				e.Hash = e.ComputeHash(e.PrevHash)
+			},
+			reason: "previous hash does not match the previous event",
+		},
+		{
+			name:   "renumbered event",
+			edit:   func(e *audit.Event) { e.Sequence = 10 },
+			reason: "expected sequence 2, found 10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := audit.NewMockStore()
+			appendEvents(t, store, 3)
+			store.Tamper(2, tt.edit)
+
+			result, err := audit.Verify(t.Context(), store)
+			require.NoError(t, err)
+			assert.False(t, result.Valid)
+			assert.Equal(t, tt.reason, result.Error)
+			assert.Positive(t, result.FirstInvalidSequence)
+		})
+	}
+}
+
+func TestVerifyPagesThroughLog(t *testing.T) {
+	store := audit.NewMockStore()
+	appendEvents(t, store, audit.MaxQueryLimit+5)
+
+	result, err := audit.Verify(t.Context(), store)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(audit.MaxQueryLimit+5), result.Events)
+}
+
+func TestRecordDetectsKeyMisuse(t *testing.T) {
+	store := audit.NewMockStore()
+	log := audit.NewLog(logger.Development(), store)
+	log.SetMisuseDetection(3, time.Minute)
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	reject := func(prefix string, at time.Duration) {
+		log.Record(t.Context(), audit.Event{
+			Time:    start.Add(at),
+			Actor:   audit.UnauthenticatedActor,
+			Action:  audit.ActionAPIKeyValidate,
+			Outcome: audit.OutcomeDenied,
+			Reason:  "key revoked or expired",
+			Details: map[string]string{"keyPrefix": prefix},
+		})
+	}
+
+	reject("sk-oai-aaa", 0)
+	reject("sk-oai-aaa", 2*time.Minute) // the first rejection is out of the window
+	reject("sk-oai-bbb", 2*time.Minute)
+	reject("sk-oai-aaa", 2*time.Minute+10*time.Second)
+	reject("sk-oai-aaa", 2*time.Minute+20*time.Second)
+	reject("sk-oai-aaa", 2*time.Minute+30*time.Second) // the count restarts after a report
+
+	events, _, err := store.Query(t.Context(), audit.Query{Action: audit.ActionAPIKeyMisuse})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	misuse := events[0]
+	assert.Equal(t, audit.UnauthenticatedActor, misuse.Actor)
+	assert.Equal(t, audit.OutcomeDenied, misuse.Outcome)
+	assert.Equal(t, start.Add(2*time.Minute+20*time.Second), misuse.Time)
+	assert.Equal(t, "3 rejected validations in 1m0s", misuse.Reason)
+	assert.Equal(t, map[string]string{
+		"keyPrefix":     "sk-oai-aaa",
+		"rejections":    "3",
+		"windowSeconds": "60",
+		"lastReason":    "key revoked or expired",
+	}, misuse.Details)
+	assert.Equal(t, int64(6), misuse.Sequence, "the misuse event follows the rejection that triggered it")
+}
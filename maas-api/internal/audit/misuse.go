@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxTrackedPrefixes bounds the key prefixes whose rejections are counted, so that
+// random keys cannot grow the detector without bound.
+const maxTrackedPrefixes = 10000
+
+// misuseDetector reports a key prefix once it has been rejected threshold times within
+// window, e.g. a leaked revoked key still in use or a guessing attempt.
+type misuseDetector struct {
+	threshold int
+	window    time.Duration
+
+	mu         sync.Mutex
+	rejections map[string][]time.Time
+}
+
+func newMisuseDetector(threshold int, window time.Duration) *misuseDetector {
+	return &misuseDetector{threshold: threshold, window: window, rejections: map[string][]time.Time{}}
+}
+
+// observe counts a rejection of a key prefix and returns the misuse event to record
+// when it reaches the threshold. The count then restarts, so that a key rejected
+// continuously is reported once per threshold rejections.
+func (d *misuseDetector) observe(rejection Event) *Event {
+	prefix := rejection.Details["keyPrefix"]
+	if prefix == "" {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	since := rejection.Time.Add(-d.window)
+	times := d.rejections[prefix]
+	if times == nil && len(d.rejections) >= maxTrackedPrefixes {
+		d.prune(since)
+		if len(d.rejections) >= maxTrackedPrefixes {
+			return nil
+		}
+	}
+	recent := times[:0]
+	for _, t := range times {
+		if t.After(since) {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, rejection.Time)
+	if len(recent) < d.threshold {
+		d.rejections[prefix] = recent
+		return nil
+	}
+	delete(d.rejections, prefix)
+
+	return &Event{
+		Time:      rejection.Time,
+		Actor:     UnauthenticatedActor,
+		Action:    ActionAPIKeyMisuse,
+		Outcome:   OutcomeDenied,
+		Reason:    fmt.Sprintf("%d rejected validations in %s", len(recent), d.window),
+		RequestID: rejection.RequestID,
+		Details: map[string]string{
+			"keyPrefix":     prefix,
+			"rejections":    strconv.Itoa(len(recent)),
+			"windowSeconds": strconv.Itoa(int(d.window.Seconds())),
+			"lastReason":    rejection.Reason,
+		},
+	}
+}
+
+// prune forgets the prefixes without a rejection since the given time.
+func (d *misuseDetector) prune(since time.Time) {
+	for prefix, times := range d.rejections {
+		if !times[len(times)-1].After(since) {
+			delete(d.rejections, prefix)
+		}
+	}
+}
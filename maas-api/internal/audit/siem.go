@@ -0,0 +1,333 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+)
+
+// Format is the encoding of the events sent to a SIEM.
+type Format string
+
+const (
+	// FormatCEF sends RFC 5424 syslog messages whose payload is an ArcSight Common Event
+	// Format (CEF) record.
+	FormatCEF Format = "cef"
+	// FormatJSON sends a JSON object per event.
+	FormatJSON Format = "json"
+)
+
+// Categories of the forwarded events.
+const (
+	CategoryKeyMisuse      = "key-misuse"
+	CategoryAuthentication = "authentication"
+	CategoryAdmin          = "admin"
+	CategoryCredential     = "credential"
+)
+
+const (
+	// forwardQueueSize bounds the events waiting to be sent; newer events are dropped
+	// when it is full.
+	forwardQueueSize = 1000
+	// forwardAttempts is the number of times an event is sent to a failing SIEM.
+	forwardAttempts = 3
+	// forwardTimeout bounds the connection to the SIEM and the write of an event.
+	forwardTimeout = 5 * time.Second
+
+	cefVendor  = "Open Data Hub"
+	cefProduct = "MaaS API"
+	// cefVersion is the version of the CEF records, bumped when their fields change.
+	cefVersion = "1.0"
+	appName    = "maas-api"
+	// syslogFacility is authpriv, the facility of security and authorization messages.
+	syslogFacility = 10
+)
+
+// ForwarderConfig is the connection to the SIEM of a Forwarder.
+type ForwarderConfig struct {
+	// Protocol is "tcp", "tls" or "udp". Over TCP and TLS, the events are separated by
+	// newlines; over UDP, each event is a datagram.
+	Protocol string
+	// Address is the host:port of the SIEM's receiver.
+	Address string
+	Format  Format
+	// TLSConfig is the configuration of the "tls" protocol.
+	TLSConfig *tls.Config
+	// Tenant identifies this maas-api instance in the events.
+	Tenant string
+}
+
+// Forwarder sends the audit events to a SIEM, such as Splunk or QRadar, in the
+// background: API key validation failures and denied operations, admin actions on other
+// users and detected key misuse. Events that a full queue cannot take, or that cannot be
+// sent, are dropped and counted. A nil *Forwarder forwards nothing.
+type Forwarder struct {
+	cfg        ForwarderConfig
+	hostname   string
+	queue      chan Event
+	conn       net.Conn
+	logger     *logger.Logger
+	metrics    metrics.SecurityEventRecorder
+	retryDelay time.Duration
+}
+
+// NewForwarder creates a forwarder of the events to the SIEM of cfg.
+func NewForwarder(log *logger.Logger, cfg ForwarderConfig) *Forwarder {
+	if log == nil {
+		log = logger.Production()
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &Forwarder{
+		cfg:        cfg,
+		hostname:   hostname,
+		queue:      make(chan Event, forwardQueueSize),
+		logger:     log,
+		retryDelay: time.Second,
+	}
+}
+
+// SetMetrics sets the recorder of the delivered, failed and dropped events.
+func (f *Forwarder) SetMetrics(recorder metrics.SecurityEventRecorder) {
+	f.metrics = recorder
+}
+
+// Start sends the forwarded events until ctx is done.
+func (f *Forwarder) Start(ctx context.Context) {
+	go f.run(ctx)
+}
+
+// Forward queues the event. It does not block: the event is dropped if the queue is full.
+func (f *Forwarder) Forward(event Event) {
+	if f == nil {
+		return
+	}
+	select {
+	case f.queue <- event:
+	default:
+		f.logger.Warn("Security event queue is full, dropping event", "action", event.Action, "requestId", event.RequestID)
+		f.record("dropped")
+	}
+}
+
+func (f *Forwarder) run(ctx context.Context) {
+	defer f.disconnect()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-f.queue:
+			f.send(ctx, event)
+		}
+	}
+}
+
+// send writes an event, reconnecting to a failing SIEM with an increasing delay.
+func (f *Forwarder) send(ctx context.Context, event Event) {
+	message, err := f.encode(event)
+	if err != nil {
+		f.logger.Error("Failed to encode security event", "action", event.Action, "error", err)
+		f.record("failed")
+		return
+	}
+	delay := f.retryDelay
+	for attempt := 1; attempt <= forwardAttempts; attempt++ {
+		if err = f.write(message); err == nil {
+			f.record("delivered")
+			return
+		}
+		f.disconnect()
+		if attempt == forwardAttempts || ctx.Err() != nil {
+			break
+		}
+		f.logger.Debug("Failed to send security event, retrying", "address", f.cfg.Address, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	f.logger.Error("Failed to send security event", "address", f.cfg.Address, "action", event.Action, "requestId", event.RequestID, "error", err)
+	f.record("failed")
+}
+
+func (f *Forwarder) write(message []byte) error {
+	if f.conn == nil {
+		conn, err := f.dial()
+		if err != nil {
+			return err
+		}
+		f.conn = conn
+	}
+	if err := f.conn.SetWriteDeadline(time.Now().Add(forwardTimeout)); err != nil {
+		return err
+	}
+	_, err := f.conn.Write(message)
+	return err
+}
+
+func (f *Forwarder) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: forwardTimeout}
+	if f.cfg.Protocol == "tls" {
+		return tls.DialWithDialer(dialer, "tcp", f.cfg.Address, f.cfg.TLSConfig)
+	}
+	return dialer.Dial(f.cfg.Protocol, f.cfg.Address)
+}
+
+func (f *Forwarder) disconnect() {
+	if f.conn != nil {
+		_ = f.conn.Close()
+		f.conn = nil
+	}
+}
+
+func (f *Forwarder) record(outcome string) {
+	if f.metrics != nil {
+		f.metrics.RecordSecurityEvents(outcome, 1)
+	}
+}
+
+// encode returns the message of an event, framed for the protocol.
+func (f *Forwarder) encode(event Event) ([]byte, error) {
+	var message []byte
+	switch f.cfg.Format {
+	case FormatJSON:
+		category, severity := classify(event)
+		var err error
+		message, err = json.Marshal(securityEvent{
+			Event:    event,
+			Product:  appName,
+			Tenant:   f.cfg.Tenant,
+			Host:     f.hostname,
+			Category: category,
+			Severity: severity,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case FormatCEF:
+		message = []byte(f.syslogCEF(event))
+	default:
+		return nil, fmt.Errorf("unsupported security event format %q", f.cfg.Format)
+	}
+	if f.cfg.Protocol != "udp" {
+		message = append(message, '\n')
+	}
+	return message, nil
+}
+
+// securityEvent is an event in the JSON format, with the fields a SIEM indexes it by.
+type securityEvent struct {
+	Event
+
+	Product  string `json:"product"`
+	Tenant   string `json:"tenant"`
+	Host     string `json:"host"`
+	Category string `json:"category"`
+	// Severity is the CEF severity of the event, from 0 to 10.
+	Severity int `json:"severity"`
+}
+
+// classify returns the category of an event and its CEF severity.
+func classify(e Event) (string, int) {
+	category := CategoryCredential
+	switch {
+	case e.Action == ActionAPIKeyMisuse:
+		return CategoryKeyMisuse, 8
+	case e.Action == ActionAPIKeyValidate:
+		category = CategoryAuthentication
+	case e.TargetUser != "" && e.TargetUser != e.Actor:
+		category = CategoryAdmin
+	}
+	switch {
+	case e.Outcome == OutcomeDenied || category == CategoryAuthentication:
+		return category, 5
+	case e.Outcome == OutcomeError:
+		return category, 4
+	case category == CategoryAdmin:
+		return category, 3
+	default:
+		return category, 2
+	}
+}
+
+// syslogCEF returns the RFC 5424 syslog message of an event, with a CEF payload.
+func (f *Forwarder) syslogCEF(e Event) string {
+	category, severity := classify(e)
+	syslogSeverity := 5 // notice
+	switch {
+	case severity >= 8:
+		syslogSeverity = 2 // critical
+	case severity >= 5:
+		syslogSeverity = 4 // warning
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 %s %s %s - - - ", syslogFacility*8+syslogSeverity,
+		e.Time.UTC().Format("2006-01-02T15:04:05.000Z"), f.hostname, appName)
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeader(cefVendor), cefHeader(cefProduct), cefVersion,
+		cefHeader(string(e.Action)), cefHeader(string(e.Action)+" "+string(e.Outcome)), severity)
+
+	extension := []cefField{
+		{"rt", strconv.FormatInt(e.Time.UnixMilli(), 10)},
+		{"cat", category},
+		{"act", string(e.Action)},
+		{"outcome", string(e.Outcome)},
+		{"suser", e.Actor},
+		{"duser", e.TargetUser},
+		{"reason", e.Reason},
+		{"dvchost", f.hostname},
+		{"cs1Label", "keyId"}, {"cs1", e.KeyID},
+		{"cs2Label", "requestId"}, {"cs2", e.RequestID},
+		{"cs3Label", "tenant"}, {"cs3", f.cfg.Tenant},
+		{"cs4Label", "hash"}, {"cs4", e.Hash},
+	}
+	if len(e.Details) > 0 {
+		// Marshalling a map of strings cannot fail.
+		details, _ := json.Marshal(e.Details)
+		extension = append(extension, cefField{"cs5Label", "details"}, cefField{"cs5", string(details)})
+	}
+	if e.Sequence > 0 {
+		extension = append(extension, cefField{"cn1Label", "sequence"}, cefField{"cn1", strconv.FormatInt(e.Sequence, 10)})
+	}
+	sep := ""
+	for _, kv := range extension {
+		if kv.value == "" {
+			continue
+		}
+		b.WriteString(sep)
+		b.WriteString(kv.key)
+		b.WriteByte('=')
+		b.WriteString(cefExtension(kv.value))
+		sep = " "
+	}
+	return b.String()
+}
+
+// cefField is a key=value pair of the extension of a CEF record.
+type cefField struct{ key, value string }
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func cefHeader(s string) string {
+	return cefHeaderEscaper.Replace(s)
+}
+
+func cefExtension(s string) string {
+	return cefExtensionEscaper.Replace(s)
+}
@@ -0,0 +1,118 @@
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+func TestForwarderSendsCEFOverTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	forwarder := audit.NewForwarder(logger.Development(), audit.ForwarderConfig{
+		Protocol: "tcp",
+		Address:  listener.Addr().String(),
+		Format:   audit.FormatCEF,
+		Tenant:   "models-as-a-service",
+	})
+	forwarder.Start(t.Context())
+	forwarder.Forward(audit.Event{
+		Sequence:   42,
+		Time:       time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+		Actor:      "admin",
+		Action:     audit.ActionAPIKeyRevoke,
+		Outcome:    audit.OutcomeSuccess,
+		TargetUser: "alice",
+		KeyID:      "key-1",
+		Reason:     "a=b\nc",
+		RequestID:  "req-1",
+	})
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+
+	line = strings.TrimSuffix(line, "\n")
+	assert.Regexp(t, `^<85>1 2026-10-14T12:00:00\.000Z \S+ maas-api - - - `, line, "authpriv notice")
+	assert.Contains(t, line, "CEF:0|Open Data Hub|MaaS API|1.0|api_key.revoke|api_key.revoke success|3|rt=1791979200000 cat=admin ")
+	for _, field := range []string{"suser=admin", "duser=alice", `reason=a\=b\nc`, "cs1=key-1", "cs2=req-1", "cs3=models-as-a-service", "cn1=42"} {
+		assert.Contains(t, line, field)
+	}
+}
+
+func TestForwarderSendsJSONOverUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	forwarder := audit.NewForwarder(logger.Development(), audit.ForwarderConfig{
+		Protocol: "udp",
+		Address:  conn.LocalAddr().String(),
+		Format:   audit.FormatJSON,
+		Tenant:   "redteam",
+	})
+	forwarder.Start(t.Context())
+	forwarder.Forward(audit.Event{
+		Time:    time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+		Actor:   audit.UnauthenticatedActor,
+		Action:  audit.ActionAPIKeyMisuse,
+		Outcome: audit.OutcomeDenied,
+		Details: map[string]string{"keyPrefix": "sk-oai-abc"},
+	})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 64<<10)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(buf[:n], &event))
+	assert.Equal(t, "api_key.misuse", event["action"])
+	assert.Equal(t, "key-misuse", event["category"])
+	assert.InDelta(t, 8, event["severity"], 0)
+	assert.Equal(t, "redteam", event["tenant"])
+	assert.Equal(t, "maas-api", event["product"])
+	assert.Equal(t, map[string]any{"keyPrefix": "sk-oai-abc"}, event["details"])
+}
+
+func TestRecordForwardsEvents(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	forwarder := audit.NewForwarder(logger.Development(), audit.ForwarderConfig{
+		Protocol: "udp",
+		Address:  conn.LocalAddr().String(),
+		Format:   audit.FormatJSON,
+	})
+	forwarder.Start(t.Context())
+	log := audit.NewLog(logger.Development(), audit.NewMockStore())
+	log.SetForwarder(forwarder)
+	log.Record(t.Context(), audit.Event{Actor: "alice", Action: audit.ActionAPIKeyCreate, Outcome: audit.OutcomeSuccess})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 64<<10)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(buf[:n], &event))
+	assert.Equal(t, "api_key.create", event["action"])
+	assert.InDelta(t, 1, event["sequence"], 0, "the event is forwarded once stored")
+	assert.NotEmpty(t, event["hash"])
+	assert.Equal(t, "credential", event["category"])
+}
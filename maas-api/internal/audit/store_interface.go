@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+)
+
+// Store is the append-only storage of the audit log of a tenant.
+type Store interface {
+	// Append chains the event after the last one of the log and stores it, setting its
+	// Sequence, PrevHash and Hash.
+	Append(ctx context.Context, event *Event) error
+
+	// Query returns the events matching q in sequence order, and whether more matched.
+	Query(ctx context.Context, q Query) ([]Event, bool, error)
+}
+
+// verifyBatchSize is the number of events read at a time by Verify.
+const verifyBatchSize = MaxQueryLimit
+
+// Verify walks the whole log and checks that each event follows the previous one and
+// that its hash matches its fields.
+func Verify(ctx context.Context, store Store) (VerifyResult, error) {
+	var result VerifyResult
+	var prevSequence int64
+	var prevHash string
+	for {
+		events, more, err := store.Query(ctx, Query{AfterSequence: prevSequence, Limit: verifyBatchSize})
+		if err != nil {
+			return result, err
+		}
+		for i := range events {
+			e := &events[i]
+			switch {
+			case e.Sequence != prevSequence+1:
+				result.FirstInvalidSequence = e.Sequence
+				result.Error = fmt.Sprintf("expected sequence %d, found %d", prevSequence+1, e.Sequence)
+			case e.PrevHash != prevHash:
+				result.FirstInvalidSequence = e.Sequence
+				result.Error = "previous hash does not match the previous event"
+			case e.ComputeHash(prevHash) != e.Hash:
+				result.FirstInvalidSequence = e.Sequence
+				result.Error = "hash does not match the event"
+			}
+			if result.Error != "" {
+				return result, nil
+			}
+			result.Events++
+			prevSequence, prevHash = e.Sequence, e.Hash
+		}
+		if !more || len(events) == 0 {
+			result.Valid = true
+			return result, nil
+		}
+	}
+}
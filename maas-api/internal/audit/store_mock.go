@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// MockStore implements Store for testing purposes.
+// It stores data in memory and is safe for concurrent use.
+type MockStore struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewMockStore creates a new in-memory mock store for testing.
+func NewMockStore() *MockStore {
+	return &MockStore{}
+}
+
+// Compile-time check that MockStore implements Store.
+var _ Store = (*MockStore)(nil)
+
+// Append chains the event after the last one of the log.
+func (m *MockStore) Append(_ context.Context, event *Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var prevSequence int64
+	var prevHash string
+	if n := len(m.events); n > 0 {
+		prevSequence, prevHash = m.events[n-1].Sequence, m.events[n-1].Hash
+	}
+	event.chain(prevSequence, prevHash)
+	stored := *event
+	stored.Details = maps.Clone(event.Details)
+	m.events = append(m.events, stored)
+	return nil
+}
+
+// Query returns the events matching q in sequence order.
+func (m *MockStore) Query(_ context.Context, q Query) ([]Event, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	limit := queryLimit(q.Limit)
+	out := []Event{}
+	for _, e := range m.events {
+		switch {
+		case e.Sequence <= q.AfterSequence,
+			!q.From.IsZero() && e.Time.Before(q.From),
+			!q.To.IsZero() && !e.Time.Before(q.To),
+			q.Actor != "" && e.Actor != q.Actor,
+			q.TargetUser != "" && e.TargetUser != q.TargetUser,
+			q.Action != "" && e.Action != q.Action:
+			continue
+		}
+		if len(out) == limit {
+			return out, true, nil
+		}
+		e.Details = maps.Clone(e.Details)
+		out = append(out, e)
+	}
+	return out, false, nil
+}
+
+// Tamper replaces the stored event of the given sequence, for tests of Verify.
+func (m *MockStore) Tamper(sequence int64, edit func(*Event)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.events {
+		if m.events[i].Sequence == sequence {
+			edit(&m.events[i])
+		}
+	}
+}
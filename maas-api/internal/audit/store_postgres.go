@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// PostgresStore implements Store using the PostgreSQL database of the API keys.
+// The schema is managed by golang-migrate (see db/schema); triggers refuse updates and
+// deletes of the audit_events rows.
+type PostgresStore struct {
+	db         *sql.DB
+	logger     *logger.Logger
+	tenantName string // Tenant identifier for filtering queries
+}
+
+// Compile-time check that PostgresStore implements Store.
+var _ Store = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a PostgreSQL-backed audit store.
+// tenantName is used to filter all database queries to enforce tenant isolation.
+func NewPostgresStore(db *sql.DB, log *logger.Logger, tenantName string) *PostgresStore {
+	return &PostgresStore{
+		db:         db,
+		logger:     log,
+		tenantName: tenantName,
+	}
+}
+
+// Append chains the event after the last one of the tenant. The tenant's log is locked
+// for the transaction so that replicas appending at the same time do not fork the chain.
+func (s *PostgresStore) Append(ctx context.Context, event *Event) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "maas-audit/"+s.tenantName); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+
+	var prevSequence int64
+	var prevHash string
+	err = tx.QueryRowContext(ctx,
+		`SELECT sequence, hash FROM audit_events WHERE tenant = $1 ORDER BY sequence DESC LIMIT 1`,
+		s.tenantName).Scan(&prevSequence, &prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to read the last audit event: %w", err)
+	}
+	event.chain(prevSequence, prevHash)
+
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit details: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_events (tenant, sequence, occurred_at, actor, action, outcome, target_user, key_id, reason, request_id, details, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, s.tenantName, event.Sequence, event.Time, event.Actor, string(event.Action), string(event.Outcome),
+		event.TargetUser, event.KeyID, event.Reason, event.RequestID, detailsJSON, event.PrevHash, event.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to append audit event: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit audit event: %w", err)
+	}
+	return nil
+}
+
+// Query returns the events matching q in sequence order.
+func (s *PostgresStore) Query(ctx context.Context, q Query) ([]Event, bool, error) {
+	whereClauses := []string{"tenant = $1", "sequence > $2"}
+	args := []any{s.tenantName, q.AfterSequence}
+	if !q.From.IsZero() {
+		args = append(args, q.From.UTC())
+		whereClauses = append(whereClauses, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if !q.To.IsZero() {
+		args = append(args, q.To.UTC())
+		whereClauses = append(whereClauses, fmt.Sprintf("occurred_at < $%d", len(args)))
+	}
+	for column, value := range map[string]string{"actor": q.Actor, "target_user": q.TargetUser, "action": string(q.Action)} {
+		if value != "" {
+			args = append(args, value)
+			whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+	}
+	limit := queryLimit(q.Limit)
+	args = append(args, limit+1)
+
+	//nolint:gosec // G201: the WHERE clause only holds fixed column names and placeholders.
+	query := fmt.Sprintf(`
+		SELECT sequence, occurred_at, actor, action, outcome, target_user, key_id, reason, request_id, details, prev_hash, hash
+		FROM audit_events
+		WHERE %s
+		ORDER BY sequence
+		LIMIT $%d
+	`, strings.Join(whereClauses, " AND "), len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		var e Event
+		var action, outcome string
+		var detailsJSON []byte
+		if err := rows.Scan(&e.Sequence, &e.Time, &e.Actor, &action, &outcome, &e.TargetUser,
+			&e.KeyID, &e.Reason, &e.RequestID, &detailsJSON, &e.PrevHash, &e.Hash); err != nil {
+			return nil, false, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		e.Time = normalizeTime(e.Time)
+		e.Action, e.Outcome = Action(action), Outcome(outcome)
+		if err := json.Unmarshal(detailsJSON, &e.Details); err != nil {
+			return nil, false, fmt.Errorf("failed to decode audit details of event %d: %w", e.Sequence, err)
+		}
+		if len(e.Details) == 0 {
+			e.Details = nil
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	if len(events) > limit {
+		return events[:limit], true, nil
+	}
+	return events, false, nil
+}
+
+// queryLimit returns the limit of a query, DefaultQueryLimit when unset and at most
+// MaxQueryLimit.
+func queryLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return min(limit, MaxQueryLimit)
+}
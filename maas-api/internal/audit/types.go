@@ -0,0 +1,157 @@
+// Package audit records the credential operations of maas-api in an append-only,
+// hash-chained log: API key creation and revocation, admin actions on other users, and
+// API key validation failures and misuse. The events can also be forwarded to a SIEM.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Action is the operation of an event.
+type Action string
+
+const (
+	ActionAPIKeyCreate     Action = "api_key.create"
+	ActionAPIKeyRevoke     Action = "api_key.revoke"
+	ActionAPIKeyBulkRevoke Action = "api_key.bulk_revoke"
+	ActionAPIKeyRead       Action = "api_key.read"
+	ActionAPIKeySearch     Action = "api_key.search"
+	ActionAPIKeyValidate   Action = "api_key.validate"
+	ActionAPIKeyMisuse     Action = "api_key.misuse"
+	ActionAPIKeyCleanup    Action = "api_key.cleanup"
+	ActionUsageRead        Action = "usage.read"
+	ActionAuditRead        Action = "audit.read"
+	ActionUserList         Action = "user.list"
+)
+
+// Outcome is the result of an event's operation.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	// OutcomeDenied is an operation refused for lack of permission, or a rejected key.
+	OutcomeDenied Outcome = "denied"
+	// OutcomeError is an operation that failed.
+	OutcomeError Outcome = "error"
+)
+
+const (
+	// SystemActor is the actor of the operations maas-api makes for an internal caller,
+	// such as the ephemeral key cleanup.
+	SystemActor = "system:maas-api"
+	// UnauthenticatedActor is the actor of a rejected API key: whoever presented it.
+	UnauthenticatedActor = "system:unauthenticated"
+)
+
+// Event is an entry of the audit log.
+type Event struct {
+	// Sequence is the position of the event in the tenant's chain, starting at 1.
+	Sequence int64     `json:"sequence"`
+	Time     time.Time `json:"time"`
+	// Actor is the user who made the operation, SystemActor or UnauthenticatedActor.
+	Actor   string  `json:"actor"`
+	Action  Action  `json:"action"`
+	Outcome Outcome `json:"outcome"`
+	// TargetUser is the user whose keys or usage the operation was about.
+	TargetUser string `json:"targetUser,omitempty"`
+	KeyID      string `json:"keyId,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	RequestID  string `json:"requestId,omitempty"`
+	// Details holds action-specific values, e.g. the subscription of a created key.
+	Details map[string]string `json:"details,omitempty"`
+	// PrevHash is the Hash of the previous event, "" for the first one.
+	PrevHash string `json:"prevHash"`
+	// Hash is the hex SHA-256 of PrevHash and the event's fields.
+	Hash string `json:"hash"`
+}
+
+// hashedEvent is the canonical form of the fields covered by an event's hash.
+type hashedEvent struct {
+	Sequence   int64             `json:"sequence"`
+	Time       string            `json:"time"`
+	Actor      string            `json:"actor"`
+	Action     Action            `json:"action"`
+	Outcome    Outcome           `json:"outcome"`
+	TargetUser string            `json:"targetUser"`
+	KeyID      string            `json:"keyId"`
+	Reason     string            `json:"reason"`
+	RequestID  string            `json:"requestId"`
+	Details    map[string]string `json:"details"`
+}
+
+// normalizeTime returns t as stored: UTC, with the microsecond precision of PostgreSQL.
+func normalizeTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// ComputeHash returns the hash of the event given the hash of the previous one.
+func (e *Event) ComputeHash(prevHash string) string {
+	details := e.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	// Marshalling a struct of strings and a map (whose keys are sorted) cannot fail.
+	canonical, _ := json.Marshal(hashedEvent{
+		Sequence:   e.Sequence,
+		Time:       normalizeTime(e.Time).Format(time.RFC3339Nano),
+		Actor:      e.Actor,
+		Action:     e.Action,
+		Outcome:    e.Outcome,
+		TargetUser: e.TargetUser,
+		KeyID:      e.KeyID,
+		Reason:     e.Reason,
+		RequestID:  e.RequestID,
+		Details:    details,
+	})
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(canonical)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// chain sets the sequence and hashes of e as the successor of the event with sequence
+// prevSequence and hash prevHash.
+func (e *Event) chain(prevSequence int64, prevHash string) {
+	e.Time = normalizeTime(e.Time)
+	e.Sequence = prevSequence + 1
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash(prevHash)
+}
+
+// Query selects events. Zero fields do not filter.
+type Query struct {
+	From       time.Time
+	To         time.Time
+	Actor      string
+	TargetUser string
+	Action     Action
+	// AfterSequence selects the events after this sequence, to page through the log.
+	AfterSequence int64
+	// Limit is the maximum number of events returned, at most MaxQueryLimit.
+	Limit int
+}
+
+const (
+	// DefaultQueryLimit is the number of events returned when a query has no limit.
+	DefaultQueryLimit = 100
+	// MaxQueryLimit is the maximum number of events a query returns.
+	MaxQueryLimit = 1000
+)
+
+// ErrInvalidQuery is returned for malformed query parameters.
+var ErrInvalidQuery = errors.New("invalid audit query")
+
+// VerifyResult is the outcome of a verification of the chain.
+type VerifyResult struct {
+	Valid bool `json:"valid"`
+	// Events is the number of events verified.
+	Events int64 `json:"events"`
+	// FirstInvalidSequence is the sequence of the first event that breaks the chain.
+	FirstInvalidSequence int64  `json:"firstInvalidSequence,omitempty"`
+	Error                string `json:"error,omitempty"`
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ServiceAccountAuthenticator authenticates the bearer tokens of a single
+// ServiceAccount via Kubernetes TokenReview. It lets internal callers, such as the
+// access log shipper, prove who they are without a gateway in front of maas-api.
+type ServiceAccountAuthenticator struct {
+	client   kubernetes.Interface
+	username string
+}
+
+// NewServiceAccountAuthenticator creates an authenticator accepting only the tokens
+// of the ServiceAccount name in namespace.
+func NewServiceAccountAuthenticator(client kubernetes.Interface, namespace, name string) *ServiceAccountAuthenticator {
+	if client == nil {
+		panic("client cannot be nil for ServiceAccountAuthenticator")
+	}
+	if namespace == "" || name == "" {
+		panic("namespace and name cannot be empty for ServiceAccountAuthenticator")
+	}
+	return &ServiceAccountAuthenticator{
+		client:   client,
+		username: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+	}
+}
+
+// Authenticate reports whether token is a valid token of the ServiceAccount.
+func (a *ServiceAccountAuthenticator) Authenticate(ctx context.Context, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	review := &authnv1.TokenReview{Spec: authnv1.TokenReviewSpec{Token: token}}
+	result, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("token review: %w", err)
+	}
+	return result.Status.Authenticated && result.Status.User.Username == a.username, nil
+}
@@ -0,0 +1,68 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/auth"
+)
+
+func TestServiceAccountAuthenticator_Authenticate(t *testing.T) {
+	// The fake API server authenticates "shipper-token" as the log shipper and
+	// "other-token" as another ServiceAccount.
+	newClient := func() *fake.Clientset {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review, ok := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+			require.True(t, ok)
+			switch review.Spec.Token {
+			case "shipper-token":
+				review.Status.Authenticated = true
+				review.Status.User.Username = "system:serviceaccount:openshift-logging:maas-log-shipper"
+			case "other-token":
+				review.Status.Authenticated = true
+				review.Status.User.Username = "system:serviceaccount:openshift-logging:collector"
+			}
+			return true, review, nil
+		})
+		return client
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{name: "ShipperTokenAccepted", token: "shipper-token", want: true},
+		{name: "OtherServiceAccountRejected", token: "other-token", want: false},
+		{name: "InvalidTokenRejected", token: "forged", want: false},
+		{name: "EmptyTokenRejected", token: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authn := auth.NewServiceAccountAuthenticator(newClient(), "openshift-logging", "maas-log-shipper")
+			ok, err := authn.Authenticate(context.Background(), tt.token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+
+	t.Run("ReviewErrorReturned", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("create", "tokenreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("api server unavailable")
+		})
+		authn := auth.NewServiceAccountAuthenticator(client, "openshift-logging", "maas-log-shipper")
+		ok, err := authn.Authenticate(context.Background(), "shipper-token")
+		require.Error(t, err)
+		assert.False(t, ok)
+	})
+}
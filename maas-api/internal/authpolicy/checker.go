@@ -63,12 +63,17 @@ func (c *Checker) AuthorizedModels(groups []string, username string) map[ModelKe
 	}
 
 	authorized := make(map[ModelKey]bool)
+	denied := make(map[ModelKey]bool)
 	for _, policy := range policies {
 		spec, ok := policy.Object["spec"].(map[string]any)
 		if !ok {
 			continue
 		}
-		if !policyMatchesSubject(spec, groups, username) {
+		target := authorized
+		switch {
+		case policyDeniesUser(spec, username):
+			target = denied
+		case !policyMatchesSubject(spec, groups, username):
 			continue
 		}
 		modelRefs, ok := spec["modelRefs"].([]any)
@@ -83,10 +88,14 @@ func (c *Checker) AuthorizedModels(groups []string, username string) map[ModelKe
 			name, _ := refMap["name"].(string)
 			ns, _ := refMap["namespace"].(string)
 			if name != "" {
-				authorized[ModelKey{Namespace: ns, Name: name}] = true
+				target[ModelKey{Namespace: ns, Name: name}] = true
 			}
 		}
 	}
+	// A user denied by any policy of a model has no access to it, whatever grants it.
+	for key := range denied {
+		delete(authorized, key)
+	}
 	return authorized
 }
 
@@ -100,6 +109,21 @@ func (c *Checker) IsModelAccessible(groups []string, username string, modelName,
 	return authorized[ModelKey{Namespace: modelNamespace, Name: modelName}]
 }
 
+// policyDeniesUser reports whether the user is in the policy's subjects.deniedUsers.
+func policyDeniesUser(spec map[string]any, username string) bool {
+	subjects, ok := spec["subjects"].(map[string]any)
+	if !ok || username == "" {
+		return false
+	}
+	deniedUsers, _ := subjects["deniedUsers"].([]any)
+	for _, u := range deniedUsers {
+		if s, ok := u.(string); ok && strings.TrimSpace(s) == strings.TrimSpace(username) {
+			return true
+		}
+	}
+	return false
+}
+
 func policyMatchesSubject(spec map[string]any, groups []string, username string) bool {
 	subjects, ok := spec["subjects"].(map[string]any)
 	if !ok {
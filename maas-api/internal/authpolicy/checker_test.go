@@ -188,6 +188,31 @@ func TestAuthorizedModels(t *testing.T) {
 	}
 }
 
+func TestAuthorizedModels_DeniedUsers(t *testing.T) {
+	log := logger.New(false)
+	lockout := createPolicy("lockout", nil, nil, []map[string]string{{"name": "model-a", "namespace": "llm"}})
+	lockout.Object["spec"].(map[string]any)["subjects"].(map[string]any)["deniedUsers"] = []any{"mallory"}
+	policies := []*unstructured.Unstructured{
+		createPolicy("policy-1", []string{"mallory"}, []string{"team-a"}, []map[string]string{
+			{"name": "model-a", "namespace": "llm"},
+			{"name": "model-b", "namespace": "llm"},
+		}),
+		lockout,
+	}
+	checker := authpolicy.NewChecker(log, &fakeLister{policies: policies})
+
+	result := checker.AuthorizedModels([]string{"team-a"}, "mallory")
+	if result[authpolicy.ModelKey{Namespace: "llm", Name: "model-a"}] {
+		t.Error("expected model-a to be denied to mallory despite the group grant")
+	}
+	if !result[authpolicy.ModelKey{Namespace: "llm", Name: "model-b"}] {
+		t.Error("expected model-b to stay accessible to mallory")
+	}
+	if result := checker.AuthorizedModels([]string{"team-a"}, "alice"); !result[authpolicy.ModelKey{Namespace: "llm", Name: "model-a"}] {
+		t.Error("expected model-a to stay accessible to other members of team-a")
+	}
+}
+
 func TestAuthorizedModels_NilLister(t *testing.T) {
 	log := logger.New(false)
 	checker := authpolicy.NewChecker(log, nil)
@@ -0,0 +1,292 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+// maxChargebackRange bounds the range of a chargeback query.
+const maxChargebackRange = 366 * 24 * time.Hour
+
+// GroupBy is what a chargeback breakdown is grouped by.
+type GroupBy string
+
+const (
+	// GroupByCostCenter groups by organization and cost center.
+	GroupByCostCenter   GroupBy = "costCenter"
+	GroupByOrganization GroupBy = "organization"
+	GroupBySubscription GroupBy = "subscription"
+	GroupByModel        GroupBy = "model"
+)
+
+var ErrInvalidChargebackQuery = errors.New("invalid chargeback query")
+
+func (g GroupBy) validate() error {
+	switch g {
+	case GroupByCostCenter, GroupByOrganization, GroupBySubscription, GroupByModel:
+		return nil
+	default:
+		return fmt.Errorf("%w: groupBy must be costCenter, organization, subscription or model", ErrInvalidChargebackQuery)
+	}
+}
+
+// Spend is the usage and cost of a set of lines.
+type Spend struct {
+	Requests       int64 `json:"requests"`
+	InputTokens    int64 `json:"inputTokens"`
+	OutputTokens   int64 `json:"outputTokens"`
+	TotalTokens    int64 `json:"totalTokens"`
+	BillableTokens int64 `json:"billableTokens"`
+	// UnpricedTokens are the billable tokens of the lines without a billingRate, which
+	// Cost does not cover.
+	UnpricedTokens int64  `json:"unpricedTokens"`
+	Cost           string `json:"cost"`
+
+	cost *big.Rat
+}
+
+func (s *Spend) add(l Line) {
+	s.Requests += l.Requests
+	s.InputTokens += l.InputTokens
+	s.OutputTokens += l.OutputTokens
+	s.TotalTokens += l.TotalTokens
+	s.BillableTokens += l.BillableTokens
+	if s.cost == nil {
+		s.cost = new(big.Rat)
+	}
+	cost, ok := l.cost()
+	if !ok {
+		s.UnpricedTokens += l.BillableTokens
+		return
+	}
+	s.cost.Add(s.cost, cost)
+}
+
+func (s *Spend) round() {
+	if s.cost == nil {
+		s.cost = new(big.Rat)
+	}
+	s.Cost = s.cost.FloatString(costScale)
+}
+
+// ChargebackGroup is the spend of a group of a breakdown. Only the fields of the
+// grouping are set: an empty organization or cost center is omitted.
+type ChargebackGroup struct {
+	OrganizationID string `json:"organizationId,omitempty"`
+	CostCenter     string `json:"costCenter,omitempty"`
+	Subscription   string `json:"subscription,omitempty"`
+	Model          string `json:"model,omitempty"`
+	Spend
+}
+
+// ChargebackResponse is the body of GET /v1/admin/chargeback.
+type ChargebackResponse struct {
+	From      time.Time         `json:"from"`
+	To        time.Time         `json:"to"`
+	GroupBy   GroupBy           `json:"groupBy"`
+	Breakdown []ChargebackGroup `json:"breakdown"`
+	Totals    Spend             `json:"totals"`
+}
+
+// Breakdown sums the lines per group, sorted by the grouping's fields.
+func Breakdown(lines []Line, groupBy GroupBy) ([]ChargebackGroup, error) {
+	if err := groupBy.validate(); err != nil {
+		return nil, err
+	}
+	var key func(Line) ChargebackGroup
+	switch groupBy {
+	case GroupByCostCenter:
+		key = func(l Line) ChargebackGroup {
+			return ChargebackGroup{OrganizationID: l.OrganizationID, CostCenter: l.CostCenter}
+		}
+	case GroupByOrganization:
+		key = func(l Line) ChargebackGroup { return ChargebackGroup{OrganizationID: l.OrganizationID} }
+	case GroupBySubscription:
+		key = func(l Line) ChargebackGroup {
+			return ChargebackGroup{OrganizationID: l.OrganizationID, CostCenter: l.CostCenter, Subscription: l.Subscription}
+		}
+	default:
+		key = func(l Line) ChargebackGroup { return ChargebackGroup{Model: l.Model} }
+	}
+
+	type groupKey struct{ organization, costCenter, subscription, model string }
+	groups := map[groupKey]*ChargebackGroup{}
+	for _, l := range lines {
+		g := key(l)
+		k := groupKey{g.OrganizationID, g.CostCenter, g.Subscription, g.Model}
+		existing, ok := groups[k]
+		if !ok {
+			existing = &g
+			groups[k] = existing
+		}
+		existing.add(l)
+	}
+	out := make([]ChargebackGroup, 0, len(groups))
+	for _, g := range groups {
+		g.round()
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.OrganizationID != b.OrganizationID {
+			return a.OrganizationID < b.OrganizationID
+		}
+		if a.CostCenter != b.CostCenter {
+			return a.CostCenter < b.CostCenter
+		}
+		if a.Subscription != b.Subscription {
+			return a.Subscription < b.Subscription
+		}
+		return a.Model < b.Model
+	})
+	return out, nil
+}
+
+// ChargebackHandler serves the spend of the tenant's cost centers.
+type ChargebackHandler struct {
+	usage              usage.Store
+	subscriptionLister subscription.Lister
+	adminChecker       usage.AdminChecker
+	logger             *logger.Logger
+	audit              *audit.Log
+	now                func() time.Time
+}
+
+// NewChargebackHandler creates a chargeback handler.
+func NewChargebackHandler(log *logger.Logger, usageStore usage.Store, subscriptionLister subscription.Lister, adminChecker usage.AdminChecker) *ChargebackHandler {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &ChargebackHandler{
+		usage:              usageStore,
+		subscriptionLister: subscriptionLister,
+		adminChecker:       adminChecker,
+		logger:             log,
+		now:                time.Now,
+	}
+}
+
+// SetAuditLog sets the audit log of the chargeback reads.
+func (h *ChargebackHandler) SetAuditLog(auditLog *audit.Log) {
+	h.audit = auditLog
+}
+
+// parseQuery reads the query parameters from, to and groupBy. from and to are RFC 3339
+// times; from defaults to the start of the month of to and is rounded down to its hour.
+// groupBy defaults to costCenter.
+func (h *ChargebackHandler) parseQuery(c *gin.Context) (time.Time, time.Time, GroupBy, error) {
+	groupBy := GroupBy(c.DefaultQuery("groupBy", string(GroupByCostCenter)))
+	if err := groupBy.validate(); err != nil {
+		return time.Time{}, time.Time{}, groupBy, err
+	}
+	to := h.now().UTC()
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, groupBy, fmt.Errorf("%w: to must be an RFC 3339 time", ErrInvalidChargebackQuery)
+		}
+		to = t.UTC()
+	}
+	from, _, _ := PeriodMonth.Bounds(to.Add(-time.Nanosecond))
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, groupBy, fmt.Errorf("%w: from must be an RFC 3339 time", ErrInvalidChargebackQuery)
+		}
+		from = t.UTC().Truncate(usage.WindowSize)
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, groupBy, fmt.Errorf("%w: from must be before to", ErrInvalidChargebackQuery)
+	}
+	if to.Sub(from) > maxChargebackRange {
+		return time.Time{}, time.Time{}, groupBy, fmt.Errorf("%w: range must not exceed %d days", ErrInvalidChargebackQuery, int(maxChargebackRange.Hours()/24))
+	}
+	return from, to, groupBy, nil
+}
+
+// GetChargeback handles GET /v1/admin/chargeback: the usage of the range priced with
+// the billingRate of the subscriptions and grouped per the "groupBy" parameter. Only
+// admins may call it.
+func (h *ChargebackHandler) GetChargeback(c *gin.Context) {
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	isAdmin, err := h.adminChecker.IsAdmin(c.Request.Context(), user)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
+	if !isAdmin {
+		h.audit.Record(c.Request.Context(), audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeDenied))
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can read the chargeback"})
+		return
+	}
+	from, to, groupBy, err := h.parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.audit.Record(c.Request.Context(), audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeSuccess))
+
+	totals, err := h.usage.SubscriptionTotals(c.Request.Context(), from, to)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to query usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query usage"})
+		return
+	}
+	subscriptions, err := h.subscriptionLister.List()
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to list MaaSSubscriptions", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+		return
+	}
+	lines := BuildReport("", "", from, to, totals, subscriptions, h.now()).Lines
+	breakdown, err := Breakdown(lines, groupBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var sum Spend
+	for _, l := range lines {
+		sum.add(l)
+	}
+	sum.round()
+
+	c.JSON(http.StatusOK, ChargebackResponse{
+		From:      from,
+		To:        to,
+		GroupBy:   groupBy,
+		Breakdown: breakdown,
+		Totals:    sum,
+	})
+}
+
+func (h *ChargebackHandler) getUserContext(c *gin.Context) *token.UserContext {
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		return nil
+	}
+
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		return nil
+	}
+
+	return user
+}
@@ -0,0 +1,141 @@
+package billing //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+type groupAdminChecker struct{}
+
+func (groupAdminChecker) IsAdmin(_ context.Context, user *token.UserContext) (bool, error) {
+	return slices.Contains(user.Groups, "admin-users"), nil
+}
+
+func chargebackSubscription(name, organization, costCenter, perToken string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{
+		"tokenMetadata": map[string]any{"organizationId": organization, "costCenter": costCenter},
+		"modelRefs": []any{map[string]any{
+			"namespace": "llm", "name": "granite", "billingRate": map[string]any{"perToken": perToken},
+		}},
+	}}}
+	u.SetName(name)
+	return u
+}
+
+func setupChargebackHandler(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := usage.NewMockStore()
+	require.NoError(t, store.AddRecords(t.Context(), []usage.Record{
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC), Requests: 3, TotalTokens: 3000},
+		{Username: "bob", Subscription: "team", Model: "llm/granite", WindowStart: time.Date(2026, 10, 3, 11, 0, 0, 0, time.UTC), Requests: 2, TotalTokens: 1000},
+		{Username: "bob", Subscription: "team", Model: "llm/llama", WindowStart: time.Date(2026, 10, 3, 11, 0, 0, 0, time.UTC), Requests: 1, TotalTokens: 200},
+		{Username: "carol", Subscription: "research", Model: "llm/granite", WindowStart: time.Date(2026, 10, 4, 9, 0, 0, 0, time.UTC), Requests: 1, TotalTokens: 500},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: time.Date(2026, 9, 30, 9, 0, 0, 0, time.UTC), Requests: 9, TotalTokens: 9000},
+	}))
+	subscriptions := staticLister{
+		chargebackSubscription("premium", "acme", "cc-42", "0.00002"),
+		chargebackSubscription("team", "acme", "cc-42", "0.00001"),
+		chargebackSubscription("research", "acme", "cc-7", "0.00001"),
+	}
+	h := NewChargebackHandler(logger.Development(), store, subscriptions, groupAdminChecker{})
+	h.now = func() time.Time { return time.Date(2026, 10, 14, 12, 15, 0, 0, time.UTC) }
+
+	router := gin.New()
+	router.GET("/v1/admin/chargeback", func(c *gin.Context) {
+		user := &token.UserContext{Username: c.GetHeader("X-Test-User")}
+		if user.Username == "admin" {
+			user.Groups = []string{"admin-users"}
+		}
+		c.Set("user", user)
+	}, h.GetChargeback)
+	return router
+}
+
+func getChargeback(t *testing.T, router *gin.Engine, user, target string) (int, ChargebackResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp ChargebackResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func TestGetChargeback_DefaultsToMonthPerCostCenter(t *testing.T) {
+	router := setupChargebackHandler(t)
+
+	code, resp := getChargeback(t, router, "admin", "/v1/admin/chargeback")
+
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), resp.From)
+	assert.Equal(t, GroupByCostCenter, resp.GroupBy)
+	require.Len(t, resp.Breakdown, 2)
+	assert.Equal(t, "cc-42", resp.Breakdown[0].CostCenter)
+	assert.Equal(t, int64(6), resp.Breakdown[0].Requests)
+	assert.Equal(t, "0.070000", resp.Breakdown[0].Cost, "3000 x 0.00002 + 1000 x 0.00001")
+	assert.Equal(t, int64(200), resp.Breakdown[0].UnpricedTokens, "llama has no billingRate")
+	assert.Equal(t, "cc-7", resp.Breakdown[1].CostCenter)
+	assert.Equal(t, "0.005000", resp.Breakdown[1].Cost)
+	assert.Equal(t, int64(7), resp.Totals.Requests)
+	assert.Equal(t, "0.075000", resp.Totals.Cost)
+}
+
+func TestGetChargeback_GroupBy(t *testing.T) {
+	router := setupChargebackHandler(t)
+
+	code, resp := getChargeback(t, router, "admin", "/v1/admin/chargeback?groupBy=model")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Breakdown, 2)
+	assert.Equal(t, "llm/granite", resp.Breakdown[0].Model)
+	assert.Empty(t, resp.Breakdown[0].CostCenter)
+	assert.Equal(t, "0.075000", resp.Breakdown[0].Cost)
+	assert.Equal(t, "0.000000", resp.Breakdown[1].Cost)
+
+	code, resp = getChargeback(t, router, "admin", "/v1/admin/chargeback?groupBy=organization")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Breakdown, 1)
+	assert.Equal(t, "acme", resp.Breakdown[0].OrganizationID)
+
+	code, resp = getChargeback(t, router, "admin", "/v1/admin/chargeback?groupBy=subscription&from=2026-09-30T00:00:00Z&to=2026-10-01T00:00:00Z")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Breakdown, 1)
+	assert.Equal(t, "premium", resp.Breakdown[0].Subscription)
+	assert.Equal(t, "cc-42", resp.Breakdown[0].CostCenter)
+	assert.Equal(t, "0.180000", resp.Breakdown[0].Cost)
+}
+
+func TestGetChargeback_Errors(t *testing.T) {
+	router := setupChargebackHandler(t)
+
+	code, _ := getChargeback(t, router, "alice", "/v1/admin/chargeback")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	for _, target := range []string{
+		"/v1/admin/chargeback?groupBy=user",
+		"/v1/admin/chargeback?from=yesterday",
+		"/v1/admin/chargeback?from=2026-10-14T00:00:00Z&to=2026-10-13T00:00:00Z",
+		"/v1/admin/chargeback?from=2025-01-01T00:00:00Z",
+	} {
+		code, _ := getChargeback(t, router, "admin", target)
+		assert.Equal(t, http.StatusBadRequest, code, target)
+	}
+}
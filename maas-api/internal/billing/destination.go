@@ -0,0 +1,101 @@
+package billing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Destination stores export files.
+type Destination interface {
+	// Name identifies the destination in logs.
+	Name() string
+	// Write stores data as the file name, replacing an existing one.
+	Write(ctx context.Context, name string, data []byte, contentType string) error
+}
+
+// DirDestination writes the exports to a directory, such as a mounted PersistentVolumeClaim.
+type DirDestination struct {
+	dir string
+}
+
+// NewDirDestination returns a destination writing to dir.
+func NewDirDestination(dir string) *DirDestination {
+	return &DirDestination{dir: dir}
+}
+
+func (d *DirDestination) Name() string {
+	return "dir:" + d.dir
+}
+
+// Write writes the file through a temporary file, so that readers never see a partial one.
+func (d *DirDestination) Write(_ context.Context, name string, data []byte, _ string) error {
+	target := filepath.Join(d.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// S3Destination uploads the exports to an S3 bucket. The credentials are read by the
+// AWS SDK from the standard AWS_* environment variables, shared files or the pod's
+// web identity.
+type S3Destination struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3Destination returns a destination uploading to bucket, under prefix. endpoint
+// is the URL of an S3-compatible service, such as OpenShift Data Foundation or MinIO,
+// whose buckets are addressed by path; empty for AWS.
+func NewS3Destination(bucket, prefix, region, endpoint string) (*S3Destination, error) {
+	cfg := aws.NewConfig().WithRegion(region)
+	if endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+	return &S3Destination{client: s3.New(sess), bucket: bucket, prefix: prefix}, nil
+}
+
+func (d *S3Destination) Name() string {
+	return "s3://" + path.Join(d.bucket, d.prefix)
+}
+
+func (d *S3Destination) Write(ctx context.Context, name string, data []byte, contentType string) error {
+	_, err := d.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(d.bucket),
+		Key:         aws.String(path.Join(d.prefix, name)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to bucket %s: %w", name, d.bucket, err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package billing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tokencount"
+)
+
+const (
+	// MaxEstimateBodyBytes bounds the body of an estimate, prompt included.
+	MaxEstimateBodyBytes = 4 << 20
+	// maxEstimateTokens bounds the input and output tokens of a request of an estimate.
+	maxEstimateTokens = 1_000_000_000
+	// maxEstimateRequests bounds the requests of an estimate.
+	maxEstimateRequests = 1_000_000
+)
+
+var ErrInvalidEstimate = errors.New("invalid cost estimate")
+
+// SubscriptionSelector selects the subscription a user's requests to a model are
+// charged to, as the gateway does.
+type SubscriptionSelector interface {
+	Select(groups []string, username string, requestedSubscription string, requestedModel string) (*subscription.SelectResponse, error)
+}
+
+// EstimateRequest is the body of POST /v1/cost/estimate. The input tokens are either
+// given or estimated from the prompt or messages of the request.
+type EstimateRequest struct {
+	// Model is the MaaSModelRef as "namespace/name", the owned_by of GET /v1/models.
+	Model string `json:"model"`
+	// Subscription is the MaaSSubscription; it may be omitted when the user has a
+	// single subscription to the model.
+	Subscription string          `json:"subscription,omitempty"`
+	InputTokens  int64           `json:"inputTokens,omitempty"`
+	OutputTokens int64           `json:"outputTokens,omitempty"`
+	Prompt       json.RawMessage `json:"prompt,omitempty"`
+	Messages     json.RawMessage `json:"messages,omitempty"`
+	// Requests is the number of such requests, e.g. of a batch job; 1 when omitted.
+	Requests int64 `json:"requests,omitempty"`
+}
+
+// EstimateResponse is the body of POST /v1/cost/estimate.
+type EstimateResponse struct {
+	Model        string `json:"model"`
+	Subscription string `json:"subscription"`
+	Requests     int64  `json:"requests"`
+	InputTokens  int64  `json:"inputTokens"`
+	OutputTokens int64  `json:"outputTokens"`
+	// InputTokensEstimated is true when the input tokens were estimated from the prompt.
+	InputTokensEstimated bool `json:"inputTokensEstimated"`
+	// BillableTokens is the input plus output tokens of all the requests.
+	BillableTokens int64 `json:"billableTokens"`
+	// PerToken is the billingRate of the subscription's model entry, "" when it has none.
+	PerToken string `json:"perToken,omitempty"`
+	// Cost is BillableTokens times PerToken, "" without a rate.
+	Cost string `json:"cost,omitempty"`
+}
+
+// validate checks the request and sets its defaults.
+func (r *EstimateRequest) validate() error {
+	namespace, name, ok := strings.Cut(r.Model, "/")
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf("%w: model must be a MaaSModelRef as namespace/name", ErrInvalidEstimate)
+	}
+	hasPrompt := len(r.Prompt) > 0 || len(r.Messages) > 0
+	if r.InputTokens != 0 && hasPrompt {
+		return fmt.Errorf("%w: set inputTokens or prompt and messages, not both", ErrInvalidEstimate)
+	}
+	if r.InputTokens < 0 || r.InputTokens > maxEstimateTokens || r.OutputTokens < 0 || r.OutputTokens > maxEstimateTokens {
+		return fmt.Errorf("%w: inputTokens and outputTokens must be between 0 and %d", ErrInvalidEstimate, maxEstimateTokens)
+	}
+	if r.Requests == 0 {
+		r.Requests = 1
+	}
+	if r.Requests < 1 || r.Requests > maxEstimateRequests {
+		return fmt.Errorf("%w: requests must be between 1 and %d", ErrInvalidEstimate, maxEstimateRequests)
+	}
+	return nil
+}
+
+// Estimate prices the request with the billingRate of the model entry of the selected
+// subscription, like the billing exports do. The input tokens of a prompt are estimated
+// like the token counter does.
+func Estimate(req EstimateRequest, sub *subscription.SelectResponse, estimator tokencount.Estimator) EstimateResponse {
+	resp := EstimateResponse{
+		Model:        req.Model,
+		Subscription: sub.Name,
+		Requests:     req.Requests,
+		InputTokens:  req.InputTokens,
+		OutputTokens: req.OutputTokens,
+	}
+	if len(req.Prompt) > 0 || len(req.Messages) > 0 {
+		body, _ := json.Marshal(struct {
+			Prompt   json.RawMessage `json:"prompt,omitempty"`
+			Messages json.RawMessage `json:"messages,omitempty"`
+		}{req.Prompt, req.Messages})
+		resp.InputTokens = int64(estimator.PromptTokens(body))
+		resp.InputTokensEstimated = true
+	}
+	resp.BillableTokens = (resp.InputTokens + resp.OutputTokens) * resp.Requests
+
+	b := subscriptionBilling{Rates: map[string]string{}}
+	for _, ref := range sub.ModelRefs {
+		if ref.BillingRate != nil && ref.BillingRate.PerToken != "" {
+			b.Rates[ref.Namespace+"/"+ref.Name] = ref.BillingRate.PerToken
+		}
+	}
+	line := Line{BillableTokens: resp.BillableTokens, PerToken: b.rate(req.Model)}
+	if cost, ok := line.cost(); ok {
+		resp.PerToken = line.PerToken
+		resp.Cost = cost.FloatString(costScale)
+	}
+	return resp
+}
+
+// EstimateHandler serves the cost estimates of requests before they are made.
+type EstimateHandler struct {
+	selector  SubscriptionSelector
+	estimator tokencount.Estimator
+	logger    *logger.Logger
+}
+
+// NewEstimateHandler creates a cost estimate handler.
+func NewEstimateHandler(log *logger.Logger, selector SubscriptionSelector) *EstimateHandler {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &EstimateHandler{selector: selector, logger: log}
+}
+
+// EstimateCost handles POST /v1/cost/estimate: the cost of requests to a model with
+// the subscription the user's requests are charged to.
+func (h *EstimateHandler) EstimateCost(c *gin.Context) {
+	userCtx, _ := c.Get("user")
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		return
+	}
+
+	var req EstimateRequest
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, MaxEstimateBodyBytes)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body exceeds 4MiB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	if err := req.validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	middleware.Attribute(c, middleware.Attribution{Subscription: req.Subscription, Model: req.Model})
+
+	//nolint:unqueryvet,nolintlint // Select is subscription resolution, not a SQL query
+	sub, err := h.selector.Select(user.Groups, user.Username, req.Subscription, req.Model)
+	if err != nil {
+		h.respondSelectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Estimate(req, sub, h.estimator))
+}
+
+// respondSelectError responds with the status of a failed subscription selection.
+func (h *EstimateHandler) respondSelectError(c *gin.Context, err error) {
+	var (
+		multipleErr  *subscription.MultipleSubscriptionsError
+		deniedErr    *subscription.AccessDeniedError
+		noSubErr     *subscription.NoSubscriptionError
+		notFoundErr  *subscription.SubscriptionNotFoundError
+		notInSubErr  *subscription.ModelNotInSubscriptionError
+		unhealthyErr *subscription.ModelUnhealthyError
+	)
+	switch {
+	case errors.As(err, &multipleErr):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "subscription is required: the model is available in several subscriptions",
+			"subscriptions": multipleErr.Subscriptions,
+		})
+	case errors.As(err, &deniedErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &noSubErr), errors.As(err, &notFoundErr), errors.As(err, &notInSubErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": "no subscription of the user includes the model"})
+	case errors.As(err, &unhealthyErr):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(c.Request.Context(), "Failed to select subscription", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to select subscription"})
+	}
+}
@@ -0,0 +1,113 @@
+package billing //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tokencount"
+)
+
+// fakeSelector selects premium for llm/granite and llm/llama, which has no rate, and
+// requires a subscription for llm/shared.
+type fakeSelector struct{}
+
+func (fakeSelector) Select(_ []string, _ string, requestedSubscription string, requestedModel string) (*subscription.SelectResponse, error) {
+	switch {
+	case requestedModel == "llm/shared" && requestedSubscription == "":
+		return nil, &subscription.MultipleSubscriptionsError{Subscriptions: []string{"premium", "team"}}
+	case requestedSubscription != "" && requestedSubscription != "premium":
+		return nil, &subscription.SubscriptionNotFoundError{Subscription: requestedSubscription}
+	case requestedModel == "llm/other":
+		return nil, &subscription.NoSubscriptionError{}
+	}
+	return &subscription.SelectResponse{Name: "premium", ModelRefs: []subscription.ModelRefInfo{
+		{Namespace: "llm", Name: "granite", BillingRate: &subscription.BillingRate{PerToken: "0.00002"}},
+		{Namespace: "llm", Name: "shared", BillingRate: &subscription.BillingRate{PerToken: "0.00001"}},
+		{Namespace: "llm", Name: "llama"},
+	}}, nil
+}
+
+func TestEstimate(t *testing.T) {
+	sub, err := fakeSelector{}.Select(nil, "alice", "", "llm/granite")
+	require.NoError(t, err)
+
+	resp := Estimate(EstimateRequest{Model: "llm/granite", InputTokens: 1200, OutputTokens: 300, Requests: 1000}, sub, tokencount.Estimator{})
+	assert.Equal(t, EstimateResponse{
+		Model:          "llm/granite",
+		Subscription:   "premium",
+		Requests:       1000,
+		InputTokens:    1200,
+		OutputTokens:   300,
+		BillableTokens: 1500000,
+		PerToken:       "0.00002",
+		Cost:           "30.000000",
+	}, resp)
+
+	resp = Estimate(EstimateRequest{
+		Model: "llm/granite", OutputTokens: 10, Requests: 1,
+		Messages: json.RawMessage(`[{"role": "user", "content": "Summarize this document"}]`),
+	}, sub, tokencount.Estimator{})
+	assert.True(t, resp.InputTokensEstimated)
+	assert.Equal(t, int64(4+6), resp.InputTokens, "4 tokens of chat template and 23 characters")
+	assert.Equal(t, "0.000400", resp.Cost)
+
+	resp = Estimate(EstimateRequest{Model: "llm/llama", InputTokens: 100, Requests: 1}, sub, tokencount.Estimator{})
+	assert.Equal(t, int64(100), resp.BillableTokens)
+	assert.Empty(t, resp.PerToken)
+	assert.Empty(t, resp.Cost, "models without a billingRate are not priced")
+}
+
+func TestEstimateCost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewEstimateHandler(logger.Development(), fakeSelector{})
+	router := gin.New()
+	router.POST("/v1/cost/estimate", func(c *gin.Context) {
+		c.Set("user", &token.UserContext{Username: "alice", Groups: []string{"premium-users"}})
+	}, h.EstimateCost)
+
+	estimate := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/cost/estimate", strings.NewReader(body)))
+		return w
+	}
+
+	w := estimate(`{"model": "llm/granite", "prompt": "Hello world!", "outputTokens": 97}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp EstimateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(3), resp.InputTokens)
+	assert.Equal(t, int64(1), resp.Requests)
+	assert.Equal(t, "0.002000", resp.Cost)
+
+	w = estimate(`{"model": "llm/shared", "inputTokens": 10}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"subscriptions":["premium","team"]`)
+
+	w = estimate(`{"model": "llm/shared", "subscription": "premium", "inputTokens": 10}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, tt := range []struct {
+		body     string
+		wantCode int
+	}{
+		{`not json`, http.StatusBadRequest},
+		{`{"model": "granite", "inputTokens": 10}`, http.StatusBadRequest},
+		{`{"model": "llm/granite", "inputTokens": 10, "prompt": "Hello"}`, http.StatusBadRequest},
+		{`{"model": "llm/granite", "inputTokens": -1}`, http.StatusBadRequest},
+		{`{"model": "llm/granite", "inputTokens": 10, "requests": 2000000}`, http.StatusBadRequest},
+		{`{"model": "llm/granite", "subscription": "team", "inputTokens": 10}`, http.StatusNotFound},
+		{`{"model": "llm/other", "inputTokens": 10}`, http.StatusNotFound},
+	} {
+		assert.Equal(t, tt.wantCode, estimate(tt.body).Code, tt.body)
+	}
+}
@@ -0,0 +1,145 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+const (
+	// exportDelay is how long after the end of a period it is exported, so that the
+	// last Limitador scrapes and shipped access logs of the period are recorded.
+	exportDelay = time.Hour
+	// checkInterval is the interval between two checks for a period to export.
+	checkInterval = 10 * time.Minute
+	// maxBackfill bounds the missed periods exported at once, after a long outage.
+	maxBackfill = 62
+)
+
+// Exporter writes the report of each completed period to its destinations, as
+// "<tenant>/billing-<period>.<format>", e.g. "models-as-a-service/billing-2026-10.csv".
+type Exporter struct {
+	tenant             string
+	period             Period
+	formats            []Format
+	usage              usage.Store
+	subscriptionLister subscription.Lister
+	state              StateStore
+	destinations       []Destination
+	logger             *logger.Logger
+	now                func() time.Time
+}
+
+// NewExporter creates an exporter of the tenant's usage per period.
+func NewExporter(log *logger.Logger, tenant string, period Period, formats []Format, usageStore usage.Store, subscriptionLister subscription.Lister, state StateStore, destinations ...Destination) *Exporter {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Exporter{
+		tenant:             tenant,
+		period:             period,
+		formats:            formats,
+		usage:              usageStore,
+		subscriptionLister: subscriptionLister,
+		state:              state,
+		destinations:       destinations,
+		logger:             log,
+		now:                time.Now,
+	}
+}
+
+// Start exports the completed periods every checkInterval until ctx is done.
+func (e *Exporter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			if err := e.ExportPending(ctx); err != nil {
+				e.logger.Error("Failed to export billing period", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ExportPending exports the periods completed since the last exported one, or the
+// last completed period when none was exported yet.
+func (e *Exporter) ExportPending(ctx context.Context) error {
+	current, _, err := e.period.Bounds(e.now().Add(-exportDelay))
+	if err != nil {
+		return err
+	}
+	lastComplete, _, _ := e.period.Bounds(current.Add(-time.Nanosecond))
+
+	next := lastComplete
+	last, found, err := e.state.LastExported(ctx, e.period)
+	if err != nil {
+		return err
+	}
+	if found {
+		_, next, _ = e.period.Bounds(last)
+	}
+	for i := 0; !next.After(lastComplete); i++ {
+		if i == maxBackfill {
+			e.logger.Warn("Too many billing periods to export, the next check continues", "period", e.period, "next", e.period.Label(next))
+			return nil
+		}
+		if err := e.Export(ctx, next); err != nil {
+			return fmt.Errorf("period %s: %w", e.period.Label(next), err)
+		}
+		if err := e.state.MarkExported(ctx, e.period, next); err != nil {
+			return err
+		}
+		_, next, _ = e.period.Bounds(next)
+	}
+	return nil
+}
+
+// Export writes the report of the period starting at start to every destination.
+// Exporting a period again replaces its files.
+func (e *Exporter) Export(ctx context.Context, start time.Time) error {
+	start, end, err := e.period.Bounds(start)
+	if err != nil {
+		return err
+	}
+	totals, err := e.usage.SubscriptionTotals(ctx, start, end)
+	if err != nil {
+		return err
+	}
+	subscriptions, err := e.subscriptionLister.List()
+	if err != nil {
+		return fmt.Errorf("failed to list MaaSSubscriptions: %w", err)
+	}
+	report := BuildReport(e.tenant, e.period, start, end, totals, subscriptions, e.now())
+
+	for _, format := range e.formats {
+		data, err := report.Encode(format)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s/billing-%s.%s", e.tenant, e.period.Label(start), format)
+		for _, d := range e.destinations {
+			if err := d.Write(ctx, name, data, contentType(format)); err != nil {
+				return fmt.Errorf("%s: %w", d.Name(), err)
+			}
+		}
+	}
+	e.logger.Info("Exported billing period", "period", e.period, "start", e.period.Label(start),
+		"lines", len(report.Lines), "costCenters", len(report.CostCenters))
+	return nil
+}
+
+func contentType(format Format) string {
+	if format == FormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
@@ -0,0 +1,139 @@
+package billing //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+type staticLister []*unstructured.Unstructured
+
+func (l staticLister) List() ([]*unstructured.Unstructured, error) { return l, nil }
+
+// memoryDestination keeps the files written, failing while failing is set.
+type memoryDestination struct {
+	mu      sync.Mutex
+	files   map[string][]byte
+	failing bool
+}
+
+func (d *memoryDestination) Name() string { return "memory" }
+
+func (d *memoryDestination) Write(_ context.Context, name string, data []byte, _ string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.failing {
+		return errors.New("bucket unavailable")
+	}
+	if d.files == nil {
+		d.files = map[string][]byte{}
+	}
+	d.files[name] = data
+	return nil
+}
+
+func newTestExporter(t *testing.T, period Period, now time.Time, destinations ...Destination) (*Exporter, *MockStateStore) {
+	t.Helper()
+	store := usage.NewMockStore()
+	require.NoError(t, store.AddRecords(t.Context(), []usage.Record{
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC), Requests: 3, TotalTokens: 300},
+		{Username: "bob", Subscription: "premium", Model: "llm/granite", WindowStart: time.Date(2026, 10, 13, 23, 0, 0, 0, time.UTC), Requests: 1, TotalTokens: 100},
+	}))
+	sub := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{
+		"tokenMetadata": map[string]any{"organizationId": "acme", "costCenter": "cc-42"},
+	}}}
+	sub.SetName("premium")
+	state := NewMockStateStore()
+	e := NewExporter(logger.Development(), "models-as-a-service", period, []Format{FormatCSV, FormatJSON},
+		store, staticLister{sub}, state, destinations...)
+	e.now = func() time.Time { return now }
+	return e, state
+}
+
+func TestExportPending_FirstRunExportsLastPeriod(t *testing.T) {
+	dest := &memoryDestination{}
+	e, state := newTestExporter(t, PeriodDay, time.Date(2026, 10, 14, 1, 30, 0, 0, time.UTC), dest)
+
+	require.NoError(t, e.ExportPending(t.Context()))
+
+	assert.Equal(t, []time.Time{time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)}, state.Exported(PeriodDay))
+	assert.Contains(t, dest.files, "models-as-a-service/billing-2026-10-13.csv")
+	assert.Contains(t, dest.files, "models-as-a-service/billing-2026-10-13.json")
+	assert.Contains(t, string(dest.files["models-as-a-service/billing-2026-10-13.csv"]), "acme,cc-42,premium,llm/granite,1,0,0,100,100")
+
+	// Nothing new to export.
+	require.NoError(t, e.ExportPending(t.Context()))
+	assert.Len(t, state.Exported(PeriodDay), 1)
+}
+
+func TestExportPending_WaitsForDelay(t *testing.T) {
+	dest := &memoryDestination{}
+	e, state := newTestExporter(t, PeriodDay, time.Date(2026, 10, 14, 0, 30, 0, 0, time.UTC), dest)
+
+	require.NoError(t, e.ExportPending(t.Context()))
+
+	assert.Equal(t, []time.Time{time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)}, state.Exported(PeriodDay),
+		"2026-10-13 ended less than exportDelay ago")
+}
+
+func TestExportPending_Backfills(t *testing.T) {
+	dest := &memoryDestination{}
+	e, state := newTestExporter(t, PeriodDay, time.Date(2026, 10, 14, 1, 30, 0, 0, time.UTC), dest)
+	require.NoError(t, state.MarkExported(t.Context(), PeriodDay, time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, e.ExportPending(t.Context()))
+
+	assert.Len(t, state.Exported(PeriodDay), 4)
+	assert.Len(t, dest.files, 6)
+	assert.Contains(t, string(dest.files["models-as-a-service/billing-2026-10-12.csv"]), "premium,llm/granite,3,0,0,300")
+}
+
+func TestExportPending_Monthly(t *testing.T) {
+	dest := &memoryDestination{}
+	e, state := newTestExporter(t, PeriodMonth, time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC), dest)
+
+	require.NoError(t, e.ExportPending(t.Context()))
+
+	assert.Equal(t, []time.Time{time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}, state.Exported(PeriodMonth))
+	assert.Contains(t, string(dest.files["models-as-a-service/billing-2026-10.csv"]), "premium,llm/granite,4,0,0,400")
+}
+
+func TestExportPending_FailureIsRetried(t *testing.T) {
+	dest := &memoryDestination{failing: true}
+	e, state := newTestExporter(t, PeriodDay, time.Date(2026, 10, 14, 1, 30, 0, 0, time.UTC), dest)
+
+	err := e.ExportPending(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bucket unavailable")
+	assert.Empty(t, state.Exported(PeriodDay))
+
+	dest.failing = false
+	require.NoError(t, e.ExportPending(t.Context()))
+	assert.Len(t, state.Exported(PeriodDay), 1)
+}
+
+func TestDirDestination(t *testing.T) {
+	dir := t.TempDir()
+	d := NewDirDestination(dir)
+
+	require.NoError(t, d.Write(t.Context(), "models-as-a-service/billing-2026-10.csv", []byte("v1"), "text/csv"))
+	require.NoError(t, d.Write(t.Context(), "models-as-a-service/billing-2026-10.csv", []byte("v2"), "text/csv"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "models-as-a-service", "billing-2026-10.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(data))
+	entries, err := os.ReadDir(filepath.Join(dir, "models-as-a-service"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
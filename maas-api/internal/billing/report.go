@@ -0,0 +1,286 @@
+// Package billing exports the usage of each period, grouped by the organization and
+// cost center of the subscriptions' tokenMetadata, for finance chargeback.
+package billing
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+// Period is the length of the periods exported.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodMonth Period = "month"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// costScale is the number of decimals of the exported costs.
+const costScale = 6
+
+// ErrInvalidPeriod is returned for a period other than day or month.
+var ErrInvalidPeriod = errors.New("billing period must be day or month")
+
+// Bounds returns the start and end of the period t falls into, in UTC.
+func (p Period) Bounds(t time.Time) (time.Time, time.Time, error) {
+	t = t.UTC()
+	switch p {
+	case PeriodDay:
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1), nil
+	case PeriodMonth:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0), nil
+	default:
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+}
+
+// Label returns the name of the period starting at start, e.g. 2026-10-14 or 2026-10.
+func (p Period) Label(start time.Time) string {
+	if p == PeriodMonth {
+		return start.UTC().Format("2006-01")
+	}
+	return start.UTC().Format(time.DateOnly)
+}
+
+// Line is the usage of a subscription for a model in the period.
+type Line struct {
+	OrganizationID string `json:"organizationId"`
+	CostCenter     string `json:"costCenter"`
+	Subscription   string `json:"subscription"`
+	Model          string `json:"model"`
+	Requests       int64  `json:"requests"`
+	InputTokens    int64  `json:"inputTokens"`
+	OutputTokens   int64  `json:"outputTokens"`
+	TotalTokens    int64  `json:"totalTokens"`
+	// BillableTokens is TotalTokens, or InputTokens plus OutputTokens for subscriptions
+	// that only count tokens per direction.
+	BillableTokens int64 `json:"billableTokens"`
+	// PerToken is the billingRate of the subscription's model entry, "" when it has none.
+	PerToken string `json:"perToken,omitempty"`
+	// Cost is BillableTokens times PerToken, "" without a rate.
+	Cost string `json:"cost,omitempty"`
+}
+
+// cost returns BillableTokens times PerToken, unrounded; false without a rate.
+func (l Line) cost() (*big.Rat, bool) {
+	rate, ok := new(big.Rat).SetString(l.PerToken)
+	if !ok {
+		return nil, false
+	}
+	return rate.Mul(rate, new(big.Rat).SetInt64(l.BillableTokens)), true
+}
+
+// CostCenterTotals is the usage of the subscriptions of a cost center in the period.
+type CostCenterTotals struct {
+	OrganizationID string `json:"organizationId"`
+	CostCenter     string `json:"costCenter"`
+	Requests       int64  `json:"requests"`
+	InputTokens    int64  `json:"inputTokens"`
+	OutputTokens   int64  `json:"outputTokens"`
+	TotalTokens    int64  `json:"totalTokens"`
+	BillableTokens int64  `json:"billableTokens"`
+	// Cost is the sum of the costs of the lines with a rate.
+	Cost string `json:"cost"`
+}
+
+// Report is the export of a period.
+type Report struct {
+	Tenant      string             `json:"tenant"`
+	Period      Period             `json:"period"`
+	PeriodStart time.Time          `json:"periodStart"`
+	PeriodEnd   time.Time          `json:"periodEnd"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+	CostCenters []CostCenterTotals `json:"costCenters"`
+	Lines       []Line             `json:"lines"`
+}
+
+// subscriptionBilling is the billing metadata of a MaaSSubscription.
+type subscriptionBilling struct {
+	OrganizationID string
+	CostCenter     string
+	// Rates is the billingRate.perToken of the modelRefs, by "namespace/name"; wildcard
+	// entries are keyed "namespace/*" and "*/*".
+	Rates map[string]string
+}
+
+// rate returns the rate of a model, "namespace/name", falling back to the wildcard
+// entries of its namespace and of all namespaces.
+func (b subscriptionBilling) rate(model string) string {
+	if r, ok := b.Rates[model]; ok {
+		return r
+	}
+	namespace, _, _ := strings.Cut(model, "/")
+	if r, ok := b.Rates[namespace+"/*"]; ok {
+		return r
+	}
+	return b.Rates["*/*"]
+}
+
+// parseSubscriptions returns the billing metadata of the MaaSSubscriptions by name.
+func parseSubscriptions(subscriptions []*unstructured.Unstructured) map[string]subscriptionBilling {
+	out := make(map[string]subscriptionBilling, len(subscriptions))
+	for _, sub := range subscriptions {
+		b := subscriptionBilling{Rates: map[string]string{}}
+		b.OrganizationID, _, _ = unstructured.NestedString(sub.Object, "spec", "tokenMetadata", "organizationId")
+		b.CostCenter, _, _ = unstructured.NestedString(sub.Object, "spec", "tokenMetadata", "costCenter")
+		refs, _, _ := unstructured.NestedSlice(sub.Object, "spec", "modelRefs")
+		for _, ref := range refs {
+			m, ok := ref.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := m["name"].(string)
+			namespace, _ := m["namespace"].(string)
+			perToken, _, _ := unstructured.NestedString(m, "billingRate", "perToken")
+			if name != "" && namespace != "" && perToken != "" {
+				b.Rates[namespace+"/"+name] = perToken
+			}
+		}
+		out[sub.GetName()] = b
+	}
+	return out
+}
+
+// BuildReport attributes the usage totals of a period to the organization and cost
+// center of their subscription, as currently set in its spec.tokenMetadata, and prices
+// them with the billingRate of the subscription's model entry. Usage of subscriptions
+// that no longer exist or set no cost center is reported with an empty one.
+func BuildReport(tenant string, period Period, start, end time.Time, totals []usage.Record, subscriptions []*unstructured.Unstructured, now time.Time) Report {
+	billing := parseSubscriptions(subscriptions)
+
+	type costCenterKey struct{ organization, costCenter string }
+	byCostCenter := map[costCenterKey]*CostCenterTotals{}
+	costs := map[costCenterKey]*big.Rat{}
+	lines := make([]Line, 0, len(totals))
+	for _, r := range totals {
+		b := billing[r.Subscription]
+		line := Line{
+			OrganizationID: b.OrganizationID,
+			CostCenter:     b.CostCenter,
+			Subscription:   r.Subscription,
+			Model:          r.Model,
+			Requests:       r.Requests,
+			InputTokens:    r.InputTokens,
+			OutputTokens:   r.OutputTokens,
+			TotalTokens:    r.TotalTokens,
+			BillableTokens: r.TotalTokens,
+			PerToken:       b.rate(r.Model),
+		}
+		if line.BillableTokens == 0 {
+			line.BillableTokens = r.InputTokens + r.OutputTokens
+		}
+
+		key := costCenterKey{b.OrganizationID, b.CostCenter}
+		cc, ok := byCostCenter[key]
+		if !ok {
+			cc = &CostCenterTotals{OrganizationID: b.OrganizationID, CostCenter: b.CostCenter}
+			byCostCenter[key] = cc
+			costs[key] = new(big.Rat)
+		}
+		if cost, ok := line.cost(); ok {
+			line.Cost = cost.FloatString(costScale)
+			costs[key].Add(costs[key], cost)
+		}
+		cc.Requests += line.Requests
+		cc.InputTokens += line.InputTokens
+		cc.OutputTokens += line.OutputTokens
+		cc.TotalTokens += line.TotalTokens
+		cc.BillableTokens += line.BillableTokens
+		lines = append(lines, line)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if a.OrganizationID != b.OrganizationID {
+			return a.OrganizationID < b.OrganizationID
+		}
+		if a.CostCenter != b.CostCenter {
+			return a.CostCenter < b.CostCenter
+		}
+		if a.Subscription != b.Subscription {
+			return a.Subscription < b.Subscription
+		}
+		return a.Model < b.Model
+	})
+	costCenters := make([]CostCenterTotals, 0, len(byCostCenter))
+	for key, cc := range byCostCenter {
+		cc.Cost = costs[key].FloatString(costScale)
+		costCenters = append(costCenters, *cc)
+	}
+	sort.Slice(costCenters, func(i, j int) bool {
+		if costCenters[i].OrganizationID != costCenters[j].OrganizationID {
+			return costCenters[i].OrganizationID < costCenters[j].OrganizationID
+		}
+		return costCenters[i].CostCenter < costCenters[j].CostCenter
+	})
+
+	return Report{
+		Tenant:      tenant,
+		Period:      period,
+		PeriodStart: start.UTC(),
+		PeriodEnd:   end.UTC(),
+		GeneratedAt: now.UTC(),
+		CostCenters: costCenters,
+		Lines:       lines,
+	}
+}
+
+// csvHeader is the header of a CSV export, one row per line of the report.
+var csvHeader = []string{
+	"period_start", "period_end", "organization_id", "cost_center", "subscription", "model",
+	"requests", "input_tokens", "output_tokens", "total_tokens", "billable_tokens", "per_token", "cost",
+}
+
+// Encode returns the report in the format.
+func (r Report) Encode(format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write(csvHeader)
+		start, end := r.PeriodStart.Format(time.RFC3339), r.PeriodEnd.Format(time.RFC3339)
+		for _, l := range r.Lines {
+			_ = w.Write([]string{
+				start, end, l.OrganizationID, l.CostCenter, l.Subscription, l.Model,
+				strconv.FormatInt(l.Requests, 10), strconv.FormatInt(l.InputTokens, 10),
+				strconv.FormatInt(l.OutputTokens, 10), strconv.FormatInt(l.TotalTokens, 10),
+				strconv.FormatInt(l.BillableTokens, 10), l.PerToken, l.Cost,
+			})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported billing export format %q", format)
+	}
+}
@@ -0,0 +1,119 @@
+package billing_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/billing"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+func testSubscription(name, organization, costCenter string, modelRefs ...map[string]any) *unstructured.Unstructured {
+	refs := make([]any, 0, len(modelRefs))
+	for _, r := range modelRefs {
+		refs = append(refs, r)
+	}
+	spec := map[string]any{"modelRefs": refs}
+	if organization != "" || costCenter != "" {
+		spec["tokenMetadata"] = map[string]any{"organizationId": organization, "costCenter": costCenter}
+	}
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetName(name)
+	u.SetNamespace("models-as-a-service")
+	return u
+}
+
+func modelRef(namespace, name, perToken string) map[string]any {
+	ref := map[string]any{"namespace": namespace, "name": name}
+	if perToken != "" {
+		ref["billingRate"] = map[string]any{"perToken": perToken}
+	}
+	return ref
+}
+
+func TestPeriodBounds(t *testing.T) {
+	at := time.Date(2026, 12, 31, 23, 30, 0, 0, time.UTC)
+
+	start, end, err := billing.PeriodDay.Bounds(at)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "2026-12-31", billing.PeriodDay.Label(start))
+
+	start, end, err = billing.PeriodMonth.Bounds(at)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "2026-12", billing.PeriodMonth.Label(start))
+
+	_, _, err = billing.Period("week").Bounds(at)
+	require.ErrorIs(t, err, billing.ErrInvalidPeriod)
+}
+
+func TestBuildReport(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	totals := []usage.Record{
+		{Subscription: "premium", Model: "llm/granite", Requests: 10, TotalTokens: 1000},
+		{Subscription: "premium", Model: "llm/llama", Requests: 2, TotalTokens: 300},
+		{Subscription: "research", Model: "llm/granite", Requests: 5, InputTokens: 400, OutputTokens: 100},
+		{Subscription: "deleted", Model: "llm/granite", Requests: 1, TotalTokens: 50},
+	}
+	subscriptions := []*unstructured.Unstructured{
+		testSubscription("premium", "acme", "cc-42", modelRef("llm", "granite", "0.00002"), modelRef("llm", "*", "0.00001")),
+		testSubscription("research", "acme", "cc-7", modelRef("llm", "granite", "")),
+	}
+
+	report := billing.BuildReport("models-as-a-service", billing.PeriodMonth, start, end, totals, subscriptions, end)
+
+	require.Len(t, report.Lines, 4)
+	assert.Equal(t, billing.Line{Subscription: "deleted", Model: "llm/granite", Requests: 1, TotalTokens: 50, BillableTokens: 50}, report.Lines[0],
+		"usage of a deleted subscription is not attributed")
+	assert.Equal(t, "cc-42", report.Lines[1].CostCenter)
+	assert.Equal(t, "0.020000", report.Lines[1].Cost)
+	assert.Equal(t, "0.00001", report.Lines[2].PerToken, "the namespace wildcard provides the rate of llama")
+	assert.Equal(t, "0.003000", report.Lines[2].Cost)
+	assert.Equal(t, int64(500), report.Lines[3].BillableTokens, "tokens counted per direction are billed")
+	assert.Empty(t, report.Lines[3].Cost)
+
+	require.Len(t, report.CostCenters, 3)
+	assert.Equal(t, billing.CostCenterTotals{
+		OrganizationID: "acme", CostCenter: "cc-42", Requests: 12, TotalTokens: 1300, BillableTokens: 1300, Cost: "0.023000",
+	}, report.CostCenters[1])
+	assert.Equal(t, "0.000000", report.CostCenters[2].Cost)
+}
+
+func TestReportEncode(t *testing.T) {
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	report := billing.BuildReport("models-as-a-service", billing.PeriodDay, start, start.AddDate(0, 0, 1),
+		[]usage.Record{{Subscription: "premium", Model: "llm/granite", Requests: 3, TotalTokens: 30}},
+		[]*unstructured.Unstructured{testSubscription("premium", "acme", "cc-42", modelRef("llm", "granite", "0.5"))},
+		start.AddDate(0, 0, 1))
+
+	data, err := report.Encode(billing.FormatCSV)
+	require.NoError(t, err)
+	rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "cost_center", rows[0][3])
+	assert.Equal(t, []string{
+		"2026-10-14T00:00:00Z", "2026-10-15T00:00:00Z", "acme", "cc-42", "premium", "llm/granite",
+		"3", "0", "0", "30", "30", "0.5", "15.000000",
+	}, rows[1])
+
+	data, err = report.Encode(billing.FormatJSON)
+	require.NoError(t, err)
+	var decoded billing.Report
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report, decoded)
+
+	_, err = report.Encode("xml")
+	require.Error(t, err)
+}
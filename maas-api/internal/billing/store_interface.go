@@ -0,0 +1,14 @@
+package billing
+
+import (
+	"context"
+	"time"
+)
+
+// StateStore keeps the periods already exported, so that each is exported once.
+type StateStore interface {
+	// LastExported returns the start of the last exported period, false when none was.
+	LastExported(ctx context.Context, period Period) (time.Time, bool, error)
+	// MarkExported records the period starting at start as exported.
+	MarkExported(ctx context.Context, period Period, start time.Time) error
+}
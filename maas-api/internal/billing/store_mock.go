@@ -0,0 +1,48 @@
+package billing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MockStateStore implements StateStore for testing purposes.
+// It stores data in memory and is safe for concurrent use.
+type MockStateStore struct {
+	mu       sync.Mutex
+	exported map[Period][]time.Time
+}
+
+// Compile-time check that MockStateStore implements StateStore.
+var _ StateStore = (*MockStateStore)(nil)
+
+// NewMockStateStore creates a new in-memory export state store for testing.
+func NewMockStateStore() *MockStateStore {
+	return &MockStateStore{exported: map[Period][]time.Time{}}
+}
+
+func (m *MockStateStore) LastExported(_ context.Context, period Period) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var last time.Time
+	for _, t := range m.exported[period] {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last, !last.IsZero(), nil
+}
+
+func (m *MockStateStore) MarkExported(_ context.Context, period Period, start time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exported[period] = append(m.exported[period], start.UTC())
+	return nil
+}
+
+// Exported returns the starts of the exported periods, in the order they were recorded.
+func (m *MockStateStore) Exported(period Period) []time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]time.Time(nil), m.exported[period]...)
+}
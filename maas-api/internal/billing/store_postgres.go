@@ -0,0 +1,50 @@
+package billing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PostgresStateStore implements StateStore using the PostgreSQL database of the API keys.
+// The schema is managed by golang-migrate (see db/schema).
+type PostgresStateStore struct {
+	db         *sql.DB
+	tenantName string // Tenant identifier for filtering queries
+}
+
+// Compile-time check that PostgresStateStore implements StateStore.
+var _ StateStore = (*PostgresStateStore)(nil)
+
+// NewPostgresStateStore creates a PostgreSQL-backed export state store.
+// tenantName is used to filter all database queries to enforce tenant isolation.
+func NewPostgresStateStore(db *sql.DB, tenantName string) *PostgresStateStore {
+	return &PostgresStateStore{db: db, tenantName: tenantName}
+}
+
+func (s *PostgresStateStore) LastExported(ctx context.Context, period Period) (time.Time, bool, error) {
+	var start sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		`SELECT MAX(period_start) FROM billing_exports WHERE tenant = $1 AND period = $2`,
+		s.tenantName, string(period)).Scan(&start)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, fmt.Errorf("failed to read the last billing export: %w", err)
+	}
+	if !start.Valid {
+		return time.Time{}, false, nil
+	}
+	return start.Time.UTC(), true, nil
+}
+
+func (s *PostgresStateStore) MarkExported(ctx context.Context, period Period, start time.Time) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO billing_exports (tenant, period, period_start) VALUES ($1, $2, $3)
+		ON CONFLICT (tenant, period, period_start) DO NOTHING
+	`, s.tenantName, string(period), start.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record billing export: %w", err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/auth"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/authpolicy"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/groupmapping"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
 )
@@ -40,14 +42,24 @@ type ClusterConfig struct {
 	// MaaSAuthPolicyLister lists MaaSAuthPolicy CRs from the informer cache for model access checks.
 	MaaSAuthPolicyLister authpolicy.Lister
 
+	// GroupMapper expands users' groups with the roles of the group mapping ConfigMap
+	// of the subscription namespace.
+	GroupMapper *groupmapping.Mapper
+
 	// AdminChecker uses SubjectAccessReview to check if a user is an admin.
 	// Admin is determined by RBAC: can user create maasauthpolicies in the configured MaaS namespace?
 	// Results are cached with a TTL to reduce Kubernetes API server load.
 	AdminChecker *auth.CachedAdminChecker
 
-	informersSynced []cache.InformerSynced
-	startFuncs      []func(<-chan struct{})
-	log             infoLogger
+	informers  []namedInformer
+	startFuncs []func(<-chan struct{})
+	log        infoLogger
+}
+
+// namedInformer is an informer of the cluster config and the resource it caches.
+type namedInformer struct {
+	resource string
+	informer cache.SharedIndexInformer
 }
 
 // unstructuredLister wraps a cache.GenericLister and implements the List() method
@@ -115,6 +127,15 @@ func NewClusterConfig(
 	authPolicyInformer := authPolicyDynamicFactory.ForResource(authPolicyGVR)
 	authPolicyListerVal := &unstructuredLister{lister: authPolicyInformer.Lister(), log: log}
 
+	// Group mapping ConfigMap informer (cached); watches only the mapping ConfigMap of the subscription namespace.
+	configMapFactory := informers.NewSharedInformerFactoryWithOptions(clientset, resyncPeriod,
+		informers.WithNamespace(subscriptionNamespace),
+		informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.FieldSelector = "metadata.name=" + groupmapping.ConfigMapName
+		}))
+	configMapInformer := configMapFactory.Core().V1().ConfigMaps()
+	groupMapperVal := groupmapping.NewMapper(nil, configMapInformer.Lister().ConfigMaps(subscriptionNamespace))
+
 	// SAR-based admin checker: uses SubjectAccessReview to check RBAC permissions.
 	// Admin is determined by: can user create maasauthpolicies in the MaaS namespace?
 	// This aligns with RBAC from opendatahub-operator#3301 which grants admin groups CRUD access to MaaS resources.
@@ -128,17 +149,20 @@ func NewClusterConfig(
 		MaaSModelRefLister:     maasModelRefListerVal,
 		MaaSSubscriptionLister: maasSubscriptionListerVal,
 		MaaSAuthPolicyLister:   authPolicyListerVal,
+		GroupMapper:            groupMapperVal,
 		AdminChecker:           adminCheckerVal,
 
-		informersSynced: []cache.InformerSynced{
-			maasInformer.Informer().HasSynced,
-			subscriptionInformer.Informer().HasSynced,
-			authPolicyInformer.Informer().HasSynced,
+		informers: []namedInformer{
+			{resource: maasGVR.Resource, informer: maasInformer.Informer()},
+			{resource: subscriptionGVR.Resource, informer: subscriptionInformer.Informer()},
+			{resource: authPolicyGVR.Resource, informer: authPolicyInformer.Informer()},
+			{resource: "configmaps", informer: configMapInformer.Informer()},
 		},
 		startFuncs: []func(<-chan struct{}){
 			maasDynamicFactory.Start,
 			subscriptionDynamicFactory.Start,
 			authPolicyDynamicFactory.Start,
+			configMapFactory.Start,
 		},
 		log: log,
 	}, nil
@@ -148,7 +172,29 @@ func (c *ClusterConfig) StartAndWaitForSync(stopCh <-chan struct{}) bool {
 	for _, start := range c.startFuncs {
 		start(stopCh)
 	}
-	return cache.WaitForCacheSync(stopCh, c.informersSynced...)
+	synced := make([]cache.InformerSynced, 0, len(c.informers))
+	for _, i := range c.informers {
+		synced = append(synced, i.informer.HasSynced)
+	}
+	return cache.WaitForCacheSync(stopCh, synced...)
+}
+
+// CachesSynced fails if an informer has not synced its cache or has stopped, in which
+// case the listers serve stale or no resources.
+func (c *ClusterConfig) CachesSynced(context.Context) error {
+	var failing []string
+	for _, i := range c.informers {
+		switch {
+		case i.informer.IsStopped():
+			failing = append(failing, i.resource+" informer stopped")
+		case !i.informer.HasSynced():
+			failing = append(failing, i.resource+" not synced")
+		}
+	}
+	if len(failing) > 0 {
+		return fmt.Errorf("informer caches unavailable: %s", strings.Join(failing, ", "))
+	}
+	return nil
 }
 
 // ResolveGatewayInternalHost finds the cluster-internal DNS name of the gateway's
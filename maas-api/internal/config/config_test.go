@@ -49,6 +49,36 @@ func TestLoad_EnvironmentVariables(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "shutdown delay and timeout default to 5s and 30s",
+			envVars: map[string]string{},
+			check: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.ShutdownDelaySeconds != 5 || cfg.ShutdownTimeoutSeconds != 30 {
+					t.Errorf("expected shutdown delay 5 and timeout 30, got %d and %d", cfg.ShutdownDelaySeconds, cfg.ShutdownTimeoutSeconds)
+				}
+			},
+		},
+		{
+			name:    "MAX_REQUEST_BODY_BYTES defaults to 1 MiB",
+			envVars: map[string]string{},
+			check: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.MaxRequestBodyBytes != DefaultMaxRequestBodyBytes {
+					t.Errorf("expected MaxRequestBodyBytes %d, got %d", DefaultMaxRequestBodyBytes, cfg.MaxRequestBodyBytes)
+				}
+			},
+		},
+		{
+			name:    "SHUTDOWN_DELAY_SECONDS and SHUTDOWN_TIMEOUT_SECONDS override the defaults",
+			envVars: map[string]string{"SHUTDOWN_DELAY_SECONDS": "0", "SHUTDOWN_TIMEOUT_SECONDS": "120"},
+			check: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				if cfg.ShutdownDelaySeconds != 0 || cfg.ShutdownTimeoutSeconds != 120 {
+					t.Errorf("expected shutdown delay 0 and timeout 120, got %d and %d", cfg.ShutdownDelaySeconds, cfg.ShutdownTimeoutSeconds)
+				}
+			},
+		},
 	}
 
 	// All env vars that Load() reads, to be cleared before each subtest.
@@ -57,6 +87,7 @@ func TestLoad_EnvironmentVariables(t *testing.T) {
 		"NAMESPACE", "GATEWAY_NAMESPACE", "ADDRESS",
 		"PORT",
 		"TLS_CERT", "TLS_KEY", "TLS_SELF_SIGNED",
+		"SHUTDOWN_DELAY_SECONDS", "SHUTDOWN_TIMEOUT_SECONDS", "MAX_REQUEST_BODY_BYTES",
 	}
 
 	for _, tt := range tests {
@@ -242,6 +273,310 @@ func TestValidate(t *testing.T) {
 			},
 			expectError: "METRICS_PORT must be between 1 and 65535",
 		},
+		{
+			name: "ShutdownDelaySeconds negative returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				ShutdownDelaySeconds:      -1,
+			},
+			expectError: "SHUTDOWN_DELAY_SECONDS must be greater than or equal to 0",
+		},
+		{
+			name: "ShutdownTimeoutSeconds negative returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				ShutdownTimeoutSeconds:    -1,
+			},
+			expectError: "SHUTDOWN_TIMEOUT_SECONDS must be greater than or equal to 0",
+		},
+		{
+			name: "MaxRequestBodyBytes negative returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				MaxRequestBodyBytes:       -1,
+			},
+			expectError: "MAX_REQUEST_BODY_BYTES must be at least 1",
+		},
+		{
+			name: "LimitadorURL without scheme returns error",
+			cfg: Config{
+				DBConnectionURL:            "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:    30,
+				AccessCheckTimeoutSeconds:  15,
+				SARCacheMaxSize:            8192,
+				MetricsPort:                9090,
+				MaaSSubscriptionNamespace:  "models-as-a-service",
+				TenantName:                 "test-tenant",
+				LimitadorURL:               "limitador:8080",
+				UsageScrapeIntervalSeconds: 60,
+			},
+			expectError: "LIMITADOR_URL",
+		},
+		{
+			name: "UsageScrapeIntervalSeconds too short with LimitadorURL returns error",
+			cfg: Config{
+				DBConnectionURL:            "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:    30,
+				AccessCheckTimeoutSeconds:  15,
+				SARCacheMaxSize:            8192,
+				MetricsPort:                9090,
+				MaaSSubscriptionNamespace:  "models-as-a-service",
+				TenantName:                 "test-tenant",
+				LimitadorURL:               "http://limitador-limitador.kuadrant-system.svc:8080",
+				UsageScrapeIntervalSeconds: 1,
+			},
+			expectError: "USAGE_SCRAPE_INTERVAL_SECONDS must be at least 5",
+		},
+		{
+			name: "UsageEventsHTTPURL without scheme returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				UsageEventsHTTPURL:        "broker-ingress.knative-eventing.svc/maas/default",
+			},
+			expectError: "USAGE_EVENTS_HTTP_URL",
+		},
+		{
+			name: "unknown AccessLogFormat returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				AccessLogFormat:           "logfmt",
+			},
+			expectError: "ACCESS_LOG_FORMAT",
+		},
+		{
+			name: "unknown SecurityEventsProtocol returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				SecurityEventsAddress:     "splunk.example.com:514",
+				SecurityEventsProtocol:    "http",
+				SecurityEventsFormat:      "cef",
+			},
+			expectError: "SECURITY_EVENTS_PROTOCOL",
+		},
+		{
+			name: "SecurityEventsAddress without port returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				SecurityEventsAddress:     "splunk.example.com",
+				SecurityEventsProtocol:    "tcp",
+				SecurityEventsFormat:      "json",
+			},
+			expectError: "SECURITY_EVENTS_ADDRESS",
+		},
+		{
+			name: "KeyMisuseThreshold without window returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				KeyMisuseThreshold:        10,
+			},
+			expectError: "KEY_MISUSE_WINDOW_SECONDS",
+		},
+		{
+			name: "KeycloakReadinessURL without scheme returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				KeycloakReadinessURL:      "keycloak.keycloak.svc:9000/health/ready",
+			},
+			expectError: "KEYCLOAK_READINESS_URL",
+		},
+		{
+			name: "UsageEventsKafkaBridgeURL without topic returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				UsageEventsKafkaBridgeURL: "http://maas-bridge-bridge-service.kafka.svc:8080",
+			},
+			expectError: "USAGE_EVENTS_KAFKA_TOPIC must be set",
+		},
+		{
+			name: "PerformanceWindowMinutes out of range returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				PerformanceWindowMinutes:  1441,
+			},
+			expectError: "MODEL_PERFORMANCE_WINDOW_MINUTES must be between 0 and 1440",
+		},
+		{
+			name: "UsageDailyRetentionMonths not longer than the hourly retention returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				UsageHourlyRetentionDays:  90,
+				UsageDailyRetentionMonths: 3,
+			},
+			expectError: "USAGE_DAILY_RETENTION_MONTHS must be longer than USAGE_HOURLY_RETENTION_DAYS",
+		},
+		{
+			name: "AccessLogShipperServiceAccount with an empty name returns error",
+			cfg: Config{
+				DBConnectionURL:                "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:        30,
+				AccessCheckTimeoutSeconds:      15,
+				SARCacheMaxSize:                8192,
+				MetricsPort:                    9090,
+				MaaSSubscriptionNamespace:      "models-as-a-service",
+				TenantName:                     "test-tenant",
+				AccessLogShipperServiceAccount: "openshift-logging/",
+			},
+			expectError: `ACCESS_LOG_SHIPPER_SERVICE_ACCOUNT "openshift-logging/" must be namespace/name or a name`,
+		},
+		{
+			name: "UsageAnomalySpikeFactor below 1 returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				UsageAnomalySpikeFactor:   0.5,
+			},
+			expectError: "USAGE_ANOMALY_SPIKE_FACTOR must be 0 or at least 1",
+		},
+		{
+			name: "UsageAnomalyWebhookURL without scheme returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				UsageAnomalyWebhookURL:    "alerts.example.com/maas",
+			},
+			expectError: "USAGE_ANOMALY_WEBHOOK_URL",
+		},
+		{
+			name: "SwaggerUIAssetsURL without scheme returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				SwaggerUI:                 true,
+				SwaggerUIAssetsURL:        "cdn.example.com/swagger-ui",
+			},
+			expectError: "SWAGGER_UI_ASSETS_URL",
+		},
+		{
+			name: "BillingExportPeriod invalid returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				BillingExportPeriod:       "week",
+				BillingExportFormats:      []string{"csv"},
+				BillingExportDir:          "/exports",
+			},
+			expectError: "BILLING_EXPORT_PERIOD \"week\" must be day or month",
+		},
+		{
+			name: "BillingExportFormats unsupported returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				BillingExportPeriod:       "month",
+				BillingExportFormats:      []string{"csv", "xlsx"},
+				BillingExportDir:          "/exports",
+			},
+			expectError: "unsupported format \"xlsx\"",
+		},
+		{
+			name: "BillingExportPeriod without destination returns error",
+			cfg: Config{
+				DBConnectionURL:           "postgresql://localhost/test",
+				APIKeyMaxExpirationDays:   30,
+				AccessCheckTimeoutSeconds: 15,
+				SARCacheMaxSize:           8192,
+				MetricsPort:               9090,
+				MaaSSubscriptionNamespace: "models-as-a-service",
+				TenantName:                "test-tenant",
+				BillingExportPeriod:       "day",
+				BillingExportFormats:      []string{"json"},
+			},
+			expectError: "requires BILLING_EXPORT_DIR or BILLING_EXPORT_S3_BUCKET",
+		},
 	}
 
 	for _, tt := range tests {
@@ -280,6 +615,118 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidate_SLO(t *testing.T) {
+	valid := func(routes string) *Config {
+		return &Config{
+			DBConnectionURL:           "postgresql://localhost/test",
+			APIKeyMaxExpirationDays:   30,
+			AccessCheckTimeoutSeconds: 15,
+			SARCacheMaxSize:           8192,
+			MetricsPort:               9090,
+			MaaSSubscriptionNamespace: "models-as-a-service",
+			TenantName:                "test-tenant",
+			SLO:                       SLOObjective{LatencyThresholdMs: 2000},
+			sloRoutesJSON:             routes,
+		}
+	}
+
+	cfg := valid(`{"POST /internal/v1/api-keys/validate": {"availability": 0.9995, "latencyThresholdMs": 100}}`)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (SLOObjective{Availability: DefaultSLOAvailability, LatencyThresholdMs: 2000, LatencyTarget: DefaultSLOLatencyTarget}); cfg.SLO != want {
+		t.Errorf("SLO = %+v, want %+v", cfg.SLO, want)
+	}
+	want := SLOObjective{Availability: 0.9995, LatencyThresholdMs: 100, LatencyTarget: DefaultSLOLatencyTarget}
+	if got := cfg.SLORoutes["POST /internal/v1/api-keys/validate"]; got != want {
+		t.Errorf("route SLO = %+v, want %+v", got, want)
+	}
+
+	for routes, expectError := range map[string]string{
+		`[]`:                                   "SLO_ROUTES must be a JSON object",
+		`{"/v1/models": {"availability": 1}}`:  `SLO_ROUTES "/v1/models": availability`,
+		`{"/v1/models": {"latencyTarget": 2}}`: `SLO_ROUTES "/v1/models": latency target`,
+		`{"": {}}`:                             "empty route",
+	} {
+		err := valid(routes).Validate()
+		if err == nil || !strings.Contains(err.Error(), expectError) {
+			t.Errorf("SLO_ROUTES %s: expected error containing %q, got %v", routes, expectError, err)
+		}
+	}
+}
+
+func TestValidate_CORS(t *testing.T) {
+	valid := func(credentials bool, origins ...string) *Config {
+		return &Config{
+			DBConnectionURL:           "postgresql://localhost/test",
+			APIKeyMaxExpirationDays:   30,
+			AccessCheckTimeoutSeconds: 15,
+			SARCacheMaxSize:           8192,
+			MetricsPort:               9090,
+			MaaSSubscriptionNamespace: "models-as-a-service",
+			TenantName:                "test-tenant",
+			CORSAllowedOrigins:        origins,
+			CORSAllowedMethods:        []string{"get", "POST"},
+			CORSAllowCredentials:      credentials,
+		}
+	}
+
+	cfg := valid(true, "https://dashboard.example.com", "https://*.apps.example.com", "http://localhost:3000")
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(cfg.CORSAllowedMethods, ","); got != "GET,POST" {
+		t.Errorf("CORSAllowedMethods = %q, want GET,POST", got)
+	}
+	if err := valid(false, "*").Validate(); err != nil {
+		t.Errorf("any origin: unexpected error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		cfg         *Config
+		expectError string
+	}{
+		{valid(true, "*"), "CORS_ALLOW_CREDENTIALS cannot be combined"},
+		{valid(false, "*", "https://dashboard.example.com"), "cannot list other origins"},
+		{valid(false, "https://*.*.example.com"), "at most one *"},
+		{valid(false, "dashboard.example.com"), "must be an http or https origin"},
+		{valid(false, "https://dashboard.example.com/"), "without a path"},
+		{valid(false, "ftp://dashboard.example.com"), "must be an http or https origin"},
+		{&Config{
+			DBConnectionURL:           "postgresql://localhost/test",
+			APIKeyMaxExpirationDays:   30,
+			AccessCheckTimeoutSeconds: 15,
+			MetricsPort:               9090,
+			MaaSSubscriptionNamespace: "models-as-a-service",
+			TenantName:                "test-tenant",
+			CORSAllowedOrigins:        []string{"https://dashboard.example.com"},
+		}, "CORS_ALLOWED_METHODS must not be empty"},
+	} {
+		err := tt.cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+			t.Errorf("CORS_ALLOWED_ORIGINS %q: expected error containing %q, got %v", tt.cfg.CORSAllowedOrigins, tt.expectError, err)
+		}
+	}
+}
+
+func TestAccessLogShipper(t *testing.T) {
+	for _, tt := range []struct {
+		serviceAccount, namespace, name string
+	}{
+		{"maas-log-shipper", "opendatahub", "maas-log-shipper"},
+		{"openshift-logging/collector", "openshift-logging", "collector"},
+	} {
+		cfg := &Config{Namespace: "opendatahub", AccessLogShipperServiceAccount: tt.serviceAccount}
+		namespace, name, err := cfg.AccessLogShipper()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.serviceAccount, err)
+		}
+		if namespace != tt.namespace || name != tt.name {
+			t.Errorf("%s: got %s/%s, want %s/%s", tt.serviceAccount, namespace, name, tt.namespace, tt.name)
+		}
+	}
+}
+
 func TestHandleDeprecatedFlags(t *testing.T) {
 	t.Run("deprecated port sets Address and clears Secure", func(t *testing.T) {
 		cfg := &Config{
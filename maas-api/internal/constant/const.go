@@ -32,4 +32,11 @@ const (
 	AnnotationDisplayName       = "openshift.io/display-name"
 	AnnotationContextWindow     = "opendatahub.io/context-window"
 	AnnotationModelCapabilities = "opendatahub.io/model-capabilities"
+
+	// LabelModelVisibility mirrors MaaSModelRef spec.visibility (set by maas-controller).
+	LabelModelVisibility = "maas.opendatahub.io/visibility"
+	// Model visibility values.
+	VisibilityPublic   = "public"
+	VisibilityInternal = "internal"
+	VisibilityHidden   = "hidden"
 )
@@ -0,0 +1,186 @@
+package credinject
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Annotations the ExternalModel reconciler of maas-controller sets on the HTTPRoute of an
+// ExternalModel. They name the provider key; the key itself never
+// leaves its Secret.
+const (
+	// AnnotationCredentialSecret is the name of the credentialRef Secret, in the namespace
+	// of the route.
+	AnnotationCredentialSecret = "maas.opendatahub.io/credential-secret"
+	// AnnotationCredentialHeader is the header the provider reads its key from.
+	AnnotationCredentialHeader = "maas.opendatahub.io/credential-header"
+)
+
+// SecretKey is the Secret data key holding the provider API key.
+const SecretKey = "api-key"
+
+const (
+	// ManagedByLabel and ManagedByValue select the routes the ExternalModel reconciler
+	// creates.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "maas-external-model-reconciler"
+
+	// annotationManaged opts a resource out of management by maas-controller when "false".
+	annotationManaged = "opendatahub.io/managed"
+
+	// routeNamePrefix prefixes the name of the ExternalModel in the names of its routes.
+	routeNamePrefix = "maas-"
+	// routeNameMaxLength and routeNameHashLength bound the names of the routes.
+	routeNameMaxLength  = 253
+	routeNameHashLength = 8
+
+	// externalModelGroup is the API group of ExternalModels.
+	externalModelGroup = "maas.opendatahub.io"
+)
+
+// Credential is the provider API key of a route, set in Header of the upstream request.
+type Credential struct {
+	Header string
+	Value  string
+}
+
+// Resolver finds the provider credential of an Envoy route.
+type Resolver interface {
+	// Resolve returns the credential of the route named routeName; found is false for
+	// routes that are not those of an ExternalModel. An error is returned, with found
+	// true, when the credential of an ExternalModel route cannot be read, or when a route
+	// the ExternalModel reconciler does not manage names a credential Secret.
+	Resolve(ctx context.Context, routeName string) (cred Credential, found bool, err error)
+}
+
+// ListerResolver resolves credentials from the informer caches of the routes, and gets
+// the Secrets they name.
+type ListerResolver struct {
+	routes  []cache.GenericLister
+	secrets SecretGetter
+}
+
+// NewListerResolver creates a resolver reading the annotations of the routes from the
+// routes listers and the provider keys from secrets.
+func NewListerResolver(routes []cache.GenericLister, secrets SecretGetter) *ListerResolver {
+	return &ListerResolver{routes: routes, secrets: secrets}
+}
+
+// Resolve implements Resolver.
+func (r *ListerResolver) Resolve(ctx context.Context, routeName string) (Credential, bool, error) {
+	namespace, name, ok := ParseRouteName(routeName)
+	if !ok {
+		return Credential{}, false, nil
+	}
+	route, err := r.route(namespace, name)
+	if err != nil || route == nil {
+		return Credential{}, false, err
+	}
+	annotations := route.GetAnnotations()
+	secretName := annotations[AnnotationCredentialSecret]
+	if secretName == "" {
+		return Credential{}, false, nil
+	}
+	// Anyone allowed to create routes can annotate them; only the routes of an
+	// ExternalModel, created by its reconciler, may send its provider key.
+	if !isExternalModelRoute(route) {
+		return Credential{}, true, fmt.Errorf("route %s/%s names credential Secret %q but is not managed by the ExternalModel reconciler", namespace, name, secretName)
+	}
+
+	secret, err := r.secrets.GetSecret(ctx, namespace, secretName)
+	if err != nil {
+		return Credential{}, true, fmt.Errorf("failed to get credential Secret %s/%s: %w", namespace, secretName, err)
+	}
+	apiKey := strings.TrimSpace(string(secret.Data[SecretKey]))
+	if apiKey == "" {
+		return Credential{}, true, fmt.Errorf("credential Secret %s/%s has no %q key", namespace, secretName, SecretKey)
+	}
+	return newCredential(annotations[AnnotationCredentialHeader], apiKey), true, nil
+}
+
+// route returns the HTTPRoute namespace/name, or nil if there is none.
+func (r *ListerResolver) route(namespace, name string) (metav1.Object, error) {
+	for _, lister := range r.routes {
+		obj, err := lister.ByNamespace(namespace).Get(name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get route %s/%s: %w", namespace, name, err)
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read route %s/%s: %w", namespace, name, err)
+		}
+		return accessor, nil
+	}
+	return nil, nil
+}
+
+// isExternalModelRoute reports whether route is the route of an ExternalModel managed by
+// its reconciler: labeled as such, controlled by the ExternalModel and named after it.
+func isExternalModelRoute(route metav1.Object) bool {
+	if route.GetLabels()[ManagedByLabel] != ManagedByValue || route.GetAnnotations()[annotationManaged] == "false" {
+		return false
+	}
+	owner := metav1.GetControllerOf(route)
+	if owner == nil || owner.Kind != "ExternalModel" {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil || gv.Group != externalModelGroup {
+		return false
+	}
+	return route.GetName() == externalModelRouteName(owner.Name)
+}
+
+// externalModelRouteName returns the name of the route of the ExternalModel modelName,
+// as modelnaming.ExternalModelResourceName of maas-controller names it.
+func externalModelRouteName(modelName string) string {
+	name := routeNamePrefix + modelName
+	if len(name) <= routeNameMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(modelName))
+	hash := hex.EncodeToString(sum[:])[:routeNameHashLength]
+	budget := routeNameMaxLength - len(routeNamePrefix) - len(hash) - 1
+	trimmed := strings.Trim(modelName[:budget], "-.")
+	if trimmed == "" {
+		trimmed = hash
+	}
+	return routeNamePrefix + trimmed + "-" + hash
+}
+
+// newCredential returns the credential of apiKey in header; the Authorization header,
+// also the default, carries it as a bearer token.
+func newCredential(header, apiKey string) Credential {
+	header = strings.ToLower(strings.TrimSpace(header))
+	if header == "" || header == "authorization" {
+		return Credential{Header: "authorization", Value: "Bearer " + apiKey}
+	}
+	return Credential{Header: header, Value: apiKey}
+}
+
+// ParseRouteName returns the namespace and name of the HTTPRoute of an Envoy route, named
+// <namespace>.<name>.<rule-index> by Istio for Gateway API routes.
+func ParseRouteName(routeName string) (namespace, name string, ok bool) {
+	first := strings.IndexByte(routeName, '.')
+	last := strings.LastIndexByte(routeName, '.')
+	if first <= 0 || last <= first+1 {
+		return "", "", false
+	}
+	if _, err := strconv.Atoi(routeName[last+1:]); err != nil {
+		return "", "", false
+	}
+	return routeName[:first], routeName[first+1 : last], true
+}
@@ -0,0 +1,69 @@
+package credinject
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// SecretGetter reads the credentialRef Secrets.
+type SecretGetter interface {
+	GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+}
+
+// CachingSecretGetter gets Secrets from the API server and keeps them for a TTL. The
+// credential injector is only granted get on the credentialRef Secrets, by a Role the
+// ExternalModel reconciler creates next to each ExternalModel, so it can neither list
+// nor watch Secrets. A rotated provider key is used once its cache entry expires.
+type CachingSecretGetter struct {
+	client corev1client.SecretsGetter
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[types.NamespacedName]cachedSecret
+}
+
+type cachedSecret struct {
+	secret  *corev1.Secret
+	expires time.Time
+}
+
+// NewCachingSecretGetter creates a SecretGetter keeping the Secrets it got from client
+// for ttl. Failed gets are not cached.
+func NewCachingSecretGetter(client corev1client.SecretsGetter, ttl time.Duration) *CachingSecretGetter {
+	return &CachingSecretGetter{
+		client:  client,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[types.NamespacedName]cachedSecret{},
+	}
+}
+
+// GetSecret implements SecretGetter.
+func (g *CachingSecretGetter) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	g.mu.Lock()
+	entry, ok := g.entries[key]
+	g.mu.Unlock()
+	if ok && g.now().Before(entry.expires) {
+		return entry.secret, nil
+	}
+
+	secret, err := g.client.Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		g.mu.Lock()
+		delete(g.entries, key)
+		g.mu.Unlock()
+		return nil, err
+	}
+	g.mu.Lock()
+	g.entries[key] = cachedSecret{secret: secret, expires: g.now().Add(g.ttl)}
+	g.mu.Unlock()
+	return secret, nil
+}
@@ -0,0 +1,47 @@
+package credinject //nolint:testpackage // sets the clock of the cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCachingSecretGetter(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai-key", Namespace: "llm"},
+		Data:       map[string][]byte{SecretKey: []byte("sk-old")},
+	})
+	now := time.Unix(0, 0)
+	getter := NewCachingSecretGetter(clientset.CoreV1(), time.Minute)
+	getter.now = func() time.Time { return now }
+
+	secret, err := getter.GetSecret(ctx, "llm", "openai-key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-old", string(secret.Data[SecretKey]))
+
+	_, err = clientset.CoreV1().Secrets("llm").Update(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai-key", Namespace: "llm"},
+		Data:       map[string][]byte{SecretKey: []byte("sk-new")},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	gets := len(clientset.Actions())
+
+	_, err = getter.GetSecret(ctx, "llm", "openai-key")
+	require.NoError(t, err)
+	assert.Len(t, clientset.Actions(), gets, "the Secret is served from the cache until it expires")
+
+	now = now.Add(2 * time.Minute)
+	secret, err = getter.GetSecret(ctx, "llm", "openai-key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-new", string(secret.Data[SecretKey]), "the rotated key is read once the entry expires")
+
+	_, err = getter.GetSecret(ctx, "llm", "missing")
+	require.Error(t, err)
+}
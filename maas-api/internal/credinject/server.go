@@ -0,0 +1,145 @@
+package credinject
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// RouteNameAttribute is the Envoy attribute of the route name, which the EnvoyFilter of
+// the credential injector lists in request_attributes.
+const RouteNameAttribute = "xds.route_name"
+
+// consumerCredentialHeaders are the headers a consumer may send its MaaS API key in.
+// They are removed from the requests to a provider, apart from the header the provider
+// key is set in.
+var consumerCredentialHeaders = []string{"authorization", "x-api-key", "api-key"}
+
+// Server is the credential injector: an Envoy external processor (ext_proc) placed after
+// the Kuadrant Wasm plugin of the gateway, once the consumer's MaaS API key is validated.
+// On the routes of an ExternalModel, it replaces the consumer's key with the provider API
+// key of the credentialRef Secret, which the gateway reads at request time, so that the
+// provider key is never copied into a route and the MaaS key never reaches the provider.
+// Requests whose provider key cannot be read are rejected rather than forwarded with the
+// consumer's key. The requests of other routes are passed through unchanged.
+type Server struct {
+	extprocv3.UnimplementedExternalProcessorServer
+
+	resolver Resolver
+	logger   *logger.Logger
+}
+
+// NewServer creates a credential injector resolving the provider keys with resolver.
+func NewServer(log *logger.Logger, resolver Resolver) *Server {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Server{resolver: resolver, logger: log}
+}
+
+// Process handles the messages Envoy sends for a request. Only the request headers are
+// sent to the credential injector.
+func (s *Server) Process(srv extprocv3.ExternalProcessor_ProcessServer) error {
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(s.handle(srv.Context(), req)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, req *extprocv3.ProcessingRequest) *extprocv3.ProcessingResponse {
+	switch r := req.GetRequest().(type) {
+	case *extprocv3.ProcessingRequest_RequestHeaders:
+		return s.requestHeaders(ctx, routeName(req))
+	case *extprocv3.ProcessingRequest_RequestBody:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestBody{
+			RequestBody: &extprocv3.BodyResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_RequestTrailers:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestTrailers{
+			RequestTrailers: &extprocv3.TrailersResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_ResponseHeaders:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: &extprocv3.HeadersResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_ResponseBody:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: &extprocv3.BodyResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_ResponseTrailers:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseTrailers{
+			ResponseTrailers: &extprocv3.TrailersResponse{},
+		}}
+	default:
+		s.logger.Debug("Ignoring unexpected ext_proc message", "type", r)
+		return &extprocv3.ProcessingResponse{}
+	}
+}
+
+// requestHeaders sets the provider key on the requests of an ExternalModel route.
+func (s *Server) requestHeaders(ctx context.Context, route string) *extprocv3.ProcessingResponse {
+	cred, found, err := s.resolver.Resolve(ctx, route)
+	if err != nil {
+		s.logger.Error("Failed to read the provider credential", "route", route, "error", err)
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extprocv3.ImmediateResponse{
+				Status:  &typev3.HttpStatus{Code: typev3.StatusCode_ServiceUnavailable},
+				Body:    []byte("provider credential unavailable"),
+				Details: "credential_injector_secret_unavailable",
+			},
+		}}
+	}
+
+	headers := &extprocv3.HeadersResponse{}
+	resp := &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestHeaders{
+		RequestHeaders: headers,
+	}}
+	if !found {
+		return resp
+	}
+
+	remove := make([]string, 0, len(consumerCredentialHeaders))
+	for _, header := range consumerCredentialHeaders {
+		if header != cred.Header {
+			remove = append(remove, header)
+		}
+	}
+	headers.Response = &extprocv3.CommonResponse{
+		HeaderMutation: &extprocv3.HeaderMutation{
+			SetHeaders: []*corev3.HeaderValueOption{{
+				Header:       &corev3.HeaderValue{Key: cred.Header, RawValue: []byte(cred.Value)},
+				AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			}},
+			RemoveHeaders: remove,
+		},
+	}
+	s.logger.Debug("Injected provider credential", "route", route, "header", cred.Header)
+	return resp
+}
+
+// routeName returns the RouteNameAttribute of a request, sent in the attributes of the
+// ext_proc filter.
+func routeName(req *extprocv3.ProcessingRequest) string {
+	for _, attributes := range req.GetAttributes() {
+		if value, ok := attributes.GetFields()[RouteNameAttribute]; ok {
+			return value.GetStringValue()
+		}
+	}
+	return ""
+}
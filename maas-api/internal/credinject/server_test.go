@@ -0,0 +1,219 @@
+package credinject_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/credinject"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// fakeProcessStream replays the messages Envoy would send for a request.
+type fakeProcessStream struct {
+	grpc.ServerStream
+
+	requests  []*extprocv3.ProcessingRequest
+	responses []*extprocv3.ProcessingResponse
+}
+
+func (f *fakeProcessStream) Context() context.Context { return context.Background() }
+
+func (f *fakeProcessStream) Recv() (*extprocv3.ProcessingRequest, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakeProcessStream) Send(resp *extprocv3.ProcessingResponse) error {
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+// externalModelRoute returns the metadata of the route the ExternalModel reconciler
+// creates for the ExternalModel model in llm.
+func externalModelRoute(model string, annotations map[string]string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+		Name:        "maas-" + model,
+		Namespace:   "llm",
+		Labels:      map[string]string{credinject.ManagedByLabel: credinject.ManagedByValue},
+		Annotations: annotations,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "maas.opendatahub.io/v1alpha1",
+			Kind:       "ExternalModel",
+			Name:       model,
+			UID:        "uid-" + types.UID(model),
+			Controller: ptr.To(true),
+		}},
+	}}
+}
+
+// newResolver resolves the routes of the ExternalModels gpt-4o (openai), claude
+// (anthropic) and broken (Secret without key) in llm, of maas-api, which is not an
+// ExternalModel, and of routes naming the openai Secret without being managed by the
+// ExternalModel reconciler.
+func newResolver(t *testing.T) *credinject.ListerResolver {
+	t.Helper()
+	openAI := map[string]string{
+		credinject.AnnotationCredentialSecret: "openai-key",
+		credinject.AnnotationCredentialHeader: "Authorization",
+	}
+	unlabeled := externalModelRoute("unlabeled", openAI)
+	unlabeled.Labels = nil
+	unowned := externalModelRoute("unowned", openAI)
+	unowned.OwnerReferences = nil
+	renamed := externalModelRoute("gpt-4o", openAI)
+	renamed.Name = "exfiltrate"
+	optedOut := externalModelRoute("opted-out", openAI)
+	optedOut.Annotations = map[string]string{
+		credinject.AnnotationCredentialSecret: "openai-key",
+		"opendatahub.io/managed":              "false",
+	}
+
+	routes := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, route := range []*metav1.PartialObjectMetadata{
+		externalModelRoute("gpt-4o", openAI),
+		externalModelRoute("claude", map[string]string{
+			credinject.AnnotationCredentialSecret: "anthropic-key",
+			credinject.AnnotationCredentialHeader: "x-api-key",
+		}),
+		externalModelRoute("broken", map[string]string{
+			credinject.AnnotationCredentialSecret: "empty-key",
+		}),
+		{ObjectMeta: metav1.ObjectMeta{Name: "maas-api-route", Namespace: "opendatahub"}},
+		unlabeled, unowned, renamed, optedOut,
+	} {
+		require.NoError(t, routes.Add(route))
+	}
+
+	clientset := fake.NewClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "openai-key", Namespace: "llm"}, Data: map[string][]byte{credinject.SecretKey: []byte("sk-openai\n")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "anthropic-key", Namespace: "llm"}, Data: map[string][]byte{credinject.SecretKey: []byte("sk-ant")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "empty-key", Namespace: "llm"}, Data: map[string][]byte{"token": []byte("sk-other")}},
+	)
+
+	httpRoutes := cache.NewGenericLister(routes, schema.GroupResource{Group: "gateway.networking.k8s.io", Resource: "httproutes"})
+	return credinject.NewListerResolver([]cache.GenericLister{httpRoutes}, credinject.NewCachingSecretGetter(clientset.CoreV1(), time.Minute))
+}
+
+func requestHeaders(t *testing.T, route string) *extprocv3.ProcessingRequest {
+	t.Helper()
+	attributes, err := structpb.NewStruct(map[string]any{credinject.RouteNameAttribute: route})
+	require.NoError(t, err)
+	return &extprocv3.ProcessingRequest{
+		Request:    &extprocv3.ProcessingRequest_RequestHeaders{RequestHeaders: &extprocv3.HttpHeaders{}},
+		Attributes: map[string]*structpb.Struct{"envoy.filters.http.ext_proc": attributes},
+	}
+}
+
+func process(t *testing.T, route string) *extprocv3.ProcessingResponse {
+	t.Helper()
+	server := credinject.NewServer(logger.Development(), newResolver(t))
+	stream := &fakeProcessStream{requests: []*extprocv3.ProcessingRequest{requestHeaders(t, route)}}
+	require.NoError(t, server.Process(stream))
+	require.Len(t, stream.responses, 1)
+	return stream.responses[0]
+}
+
+func TestProcess_InjectsProviderCredential(t *testing.T) {
+	tests := []struct {
+		name       string
+		route      string
+		wantHeader string
+		wantValue  string
+		wantRemove []string
+	}{
+		{
+			name:       "bearer provider replaces the consumer key",
+			route:      "llm.maas-gpt-4o.0",
+			wantHeader: "authorization",
+			wantValue:  "Bearer sk-openai",
+			wantRemove: []string{"x-api-key", "api-key"},
+		},
+		{
+			name:       "header provider removes the consumer key",
+			route:      "llm.maas-claude.1",
+			wantHeader: "x-api-key",
+			wantValue:  "sk-ant",
+			wantRemove: []string{"authorization", "api-key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mutation := process(t, tt.route).GetRequestHeaders().GetResponse().GetHeaderMutation()
+			require.NotNil(t, mutation)
+			require.Len(t, mutation.GetSetHeaders(), 1)
+			set := mutation.GetSetHeaders()[0]
+			assert.Equal(t, tt.wantHeader, set.GetHeader().GetKey())
+			assert.Equal(t, tt.wantValue, string(set.GetHeader().GetRawValue()))
+			assert.Equal(t, tt.wantRemove, mutation.GetRemoveHeaders())
+		})
+	}
+}
+
+func TestProcess_PassesOtherRoutes(t *testing.T) {
+	for _, route := range []string{"opendatahub.maas-api-route.0", "llm.unknown.0", "not-a-route", ""} {
+		t.Run(route, func(t *testing.T) {
+			resp := process(t, route)
+			require.NotNil(t, resp.GetRequestHeaders(), "the request is continued")
+			assert.Nil(t, resp.GetRequestHeaders().GetResponse(), "the headers are unchanged")
+		})
+	}
+}
+
+func TestProcess_RejectsUnreadableCredential(t *testing.T) {
+	immediate := process(t, "llm.maas-broken.0").GetImmediateResponse()
+	require.NotNil(t, immediate, "the request must not reach the provider with the consumer key")
+	assert.Equal(t, typev3.StatusCode_ServiceUnavailable, immediate.GetStatus().GetCode())
+	assert.NotContains(t, string(immediate.GetBody()), "sk-other")
+}
+
+func TestProcess_RejectsRoutesNotManagedByTheReconciler(t *testing.T) {
+	for _, route := range []string{"llm.maas-unlabeled.0", "llm.maas-unowned.0", "llm.exfiltrate.0", "llm.maas-opted-out.0"} {
+		t.Run(route, func(t *testing.T) {
+			resp := process(t, route)
+			require.NotNil(t, resp.GetImmediateResponse(), "the provider key must not be sent to a route the reconciler does not manage")
+			assert.Equal(t, typev3.StatusCode_ServiceUnavailable, resp.GetImmediateResponse().GetStatus().GetCode())
+		})
+	}
+}
+
+func TestParseRouteName(t *testing.T) {
+	tests := []struct {
+		route               string
+		wantNamespace, name string
+		wantOK              bool
+	}{
+		{route: "llm.maas-gpt-4o.0", wantNamespace: "llm", name: "maas-gpt-4o", wantOK: true},
+		{route: "llm.maas-gpt-4.1.1", wantNamespace: "llm", name: "maas-gpt-4.1", wantOK: true},
+		{route: "llm.maas-gpt-4o.path"},
+		{route: "llm..0"},
+		{route: "maas-gpt-4o"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			namespace, name, ok := credinject.ParseRouteName(tt.route)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Package groupmapping maps identity provider groups to the role names that
+// MaaSAuthPolicies and MaaSSubscriptions refer to, so that identity provider group
+// names do not have to be repeated in every CR.
+package groupmapping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+const (
+	// ConfigMapName is the ConfigMap of the subscription namespace holding the mapping.
+	// The maas-controller reads the same ConfigMap, of the same namespace, for the gateway
+	// AuthPolicy.
+	ConfigMapName = "maas-group-mapping"
+	// DataKey is the data key of the mapping: a YAML map from an identity provider group
+	// to the list of roles its members have.
+	DataKey = "mapping.yaml"
+)
+
+// Mapping maps an identity provider group to the roles of its members.
+type Mapping map[string][]string
+
+// Parse parses the mapping.yaml of the group mapping ConfigMap. It applies the rules of
+// the maas-controller, so that both reject the same mappings.
+func Parse(data string) (Mapping, error) {
+	mapping := Mapping{}
+	if err := yaml.UnmarshalStrict([]byte(data), &mapping); err != nil {
+		return nil, fmt.Errorf("%s must map group names to lists of roles: %w", DataKey, err)
+	}
+	for group, roles := range mapping {
+		if group == "" {
+			return nil, errors.New("group names must not be empty")
+		}
+		if strings.ContainsAny(group, `"\`) {
+			return nil, fmt.Errorf("group name %q contains a double quote or a backslash", group)
+		}
+		if len(roles) == 0 {
+			return nil, fmt.Errorf("group %q maps to no roles", group)
+		}
+		for _, role := range roles {
+			if role == "" {
+				return nil, fmt.Errorf("group %q maps to an empty role", group)
+			}
+			if strings.ContainsAny(role, `"\`) {
+				return nil, fmt.Errorf("role %q contains a double quote or a backslash", role)
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// Expand returns the groups followed by the roles they map to that are not already among
+// them.
+func (m Mapping) Expand(groups []string) []string {
+	if len(m) == 0 {
+		return groups
+	}
+	seen := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		seen[strings.TrimSpace(g)] = true
+	}
+	expanded := append([]string(nil), groups...)
+	for _, g := range groups {
+		for _, role := range m[strings.TrimSpace(g)] {
+			if !seen[role] {
+				seen[role] = true
+				expanded = append(expanded, role)
+			}
+		}
+	}
+	return expanded
+}
+
+// Mapper expands groups with the mapping of the ConfigMap in the informer cache. The
+// mapping is parsed again only when the ConfigMap changes. Without the ConfigMap groups
+// are not expanded. While it is invalid the last valid mapping is kept, like the gateway
+// AuthPolicy, which the maas-controller does not update from an invalid mapping.
+type Mapper struct {
+	lister corelisters.ConfigMapNamespaceLister
+	logger *logger.Logger
+
+	mu              sync.Mutex
+	resourceVersion string
+	mapping         Mapping
+}
+
+// NewMapper creates a Mapper reading the group mapping ConfigMap from the lister of the
+// subscription namespace.
+func NewMapper(log *logger.Logger, lister corelisters.ConfigMapNamespaceLister) *Mapper {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Mapper{lister: lister, logger: log}
+}
+
+// ExpandGroups returns the groups followed by the roles they map to.
+func (m *Mapper) ExpandGroups(groups []string) []string {
+	return m.current().Expand(groups)
+}
+
+func (m *Mapper) current() Mapping {
+	cm, err := m.lister.Get(ConfigMapName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			m.logger.Error("failed to get group mapping ConfigMap", "name", ConfigMapName, "error", err)
+		}
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cm.ResourceVersion == m.resourceVersion {
+		return m.mapping
+	}
+	m.resourceVersion = cm.ResourceVersion
+	mapping, err := Parse(cm.Data[DataKey])
+	if err != nil {
+		m.logger.Error("invalid group mapping ConfigMap, keeping the last valid mapping", "name", ConfigMapName, "error", err)
+		return m.mapping
+	}
+	m.mapping = mapping
+	return mapping
+}
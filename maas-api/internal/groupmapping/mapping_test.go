@@ -0,0 +1,80 @@
+package groupmapping_test
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/groupmapping"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+func TestParse(t *testing.T) {
+	mapping, err := groupmapping.Parse("ldap-premium: [maas-premium]\nldap-staff:\n  - maas-premium\n  - maas-standard\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := groupmapping.Mapping{"ldap-premium": {"maas-premium"}, "ldap-staff": {"maas-premium", "maas-standard"}}
+	if !reflect.DeepEqual(mapping, want) {
+		t.Errorf("mapping = %v, want %v", mapping, want)
+	}
+
+	for _, data := range []string{
+		"ldap-premium: maas-premium",
+		"ldap-premium: []",
+		`ldap-premium: ["maas\"premium"]`,
+		`"": [maas-premium]`,
+		"- ldap-premium",
+	} {
+		if _, err := groupmapping.Parse(data); err == nil {
+			t.Errorf("Parse(%q) expected an error", data)
+		}
+	}
+}
+
+func TestMapping_Expand(t *testing.T) {
+	mapping := groupmapping.Mapping{"ldap-premium": {"maas-premium"}, "ldap-staff": {"maas-premium", "maas-standard"}}
+
+	got := mapping.Expand([]string{"system:authenticated", "ldap-staff", "ldap-premium"})
+	want := []string{"system:authenticated", "ldap-staff", "ldap-premium", "maas-premium", "maas-standard"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand = %v, want %v", got, want)
+	}
+	if got := mapping.Expand([]string{"other"}); !reflect.DeepEqual(got, []string{"other"}) {
+		t.Errorf("Expand = %v, want unmapped groups unchanged", got)
+	}
+}
+
+func TestMapper_ExpandGroups(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	mapper := groupmapping.NewMapper(logger.New(false), corelisters.NewConfigMapLister(indexer).ConfigMaps("models-as-a-service"))
+
+	if got := mapper.ExpandGroups([]string{"ldap-premium"}); !reflect.DeepEqual(got, []string{"ldap-premium"}) {
+		t.Errorf("ExpandGroups = %v, want groups unchanged without the ConfigMap", got)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: groupmapping.ConfigMapName, Namespace: "models-as-a-service", ResourceVersion: "1"},
+		Data:       map[string]string{groupmapping.DataKey: "ldap-premium: [maas-premium]"},
+	}
+	if err := indexer.Add(cm); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if got := mapper.ExpandGroups([]string{"ldap-premium"}); !reflect.DeepEqual(got, []string{"ldap-premium", "maas-premium"}) {
+		t.Errorf("ExpandGroups = %v, want maas-premium added", got)
+	}
+
+	invalid := cm.DeepCopy()
+	invalid.ResourceVersion = "2"
+	invalid.Data[groupmapping.DataKey] = "ldap-premium: maas-premium"
+	if err := indexer.Update(invalid); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := mapper.ExpandGroups([]string{"ldap-premium"}); !reflect.DeepEqual(got, []string{"ldap-premium", "maas-premium"}) {
+		t.Errorf("ExpandGroups = %v, want the last valid mapping kept", got)
+	}
+}
@@ -1,20 +1,143 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// DefaultDependencyTimeout bounds a single dependency check, below the timeouts of the
+// Kubernetes probes.
+const DefaultDependencyTimeout = 2 * time.Second
+
+// Statuses of the health endpoints and their dependencies.
+const (
+	StatusOK           = "ok"
+	StatusUnavailable  = "unavailable"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Dependency is a dependency checked by /readyz.
+type Dependency struct {
+	Name string
+	// Liveness also checks the dependency in /healthz. Only set it for failures that
+	// restarting the replica fixes: a replica that fails /healthz is restarted.
+	Liveness bool
+	Check    func(ctx context.Context) error
+}
+
+// DependencyStatus is the result of a dependency check.
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthResponse is the body of /healthz and /readyz.
+type HealthResponse struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyStatus `json:"checks,omitempty"`
+}
+
 // HealthHandler handles health check endpoints.
-type HealthHandler struct{}
+type HealthHandler struct {
+	dependencies []Dependency
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
 
-// NewHealthHandler creates a new health handler.
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler checking the dependencies.
+func NewHealthHandler(dependencies ...Dependency) *HealthHandler {
+	return &HealthHandler{dependencies: dependencies, timeout: DefaultDependencyTimeout}
 }
 
 // HealthCheck handles GET /health.
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 }
+
+// Liveness handles GET /healthz: 503 when a liveness dependency fails.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	var dependencies []Dependency
+	for _, d := range h.dependencies {
+		if d.Liveness {
+			dependencies = append(dependencies, d)
+		}
+	}
+	h.respond(c, dependencies)
+}
+
+// Readiness handles GET /readyz: 503 when any dependency fails or the server is shutting
+// down, so that the replica is taken out of the Service endpoints.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	if h.shuttingDown.Load() {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: StatusShuttingDown})
+		return
+	}
+	h.respond(c, h.dependencies)
+}
+
+// StartShutdown makes /readyz fail from now on, while /healthz keeps answering so that
+// the replica is not restarted while it drains its requests.
+func (h *HealthHandler) StartShutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// respond checks the dependencies concurrently and writes their statuses.
+func (h *HealthHandler) respond(c *gin.Context, dependencies []Dependency) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	resp := HealthResponse{Status: StatusOK, Checks: make(map[string]DependencyStatus, len(dependencies))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, d := range dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := DependencyStatus{Status: StatusOK}
+			if err := d.Check(ctx); err != nil {
+				status = DependencyStatus{Status: StatusUnavailable, Error: err.Error()}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[d.Name] = status
+			if status.Status != StatusOK {
+				resp.Status = StatusUnavailable
+			}
+		}()
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	if resp.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, resp)
+}
+
+// HTTPCheck returns a check that fails unless a GET of url answers with a 2xx status.
+func HTTPCheck(client *http.Client, url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		// #nosec G704 -- The URL is configured by the operator of the deployment
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("GET %s returned HTTP %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
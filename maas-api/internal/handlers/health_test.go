@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
+)
+
+func getHealth(t *testing.T, router *gin.Engine, path string) (int, handlers.HealthResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	var resp handlers.HealthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestHealthHandler_LivenessAndReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var dbErr error
+	h := handlers.NewHealthHandler(
+		handlers.Dependency{Name: "database", Check: func(context.Context) error { return dbErr }},
+		handlers.Dependency{Name: "informers", Liveness: true, Check: func(context.Context) error { return nil }},
+	)
+	router := gin.New()
+	router.GET("/healthz", h.Liveness)
+	router.GET("/readyz", h.Readiness)
+
+	code, resp := getHealth(t, router, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, handlers.HealthResponse{Status: handlers.StatusOK, Checks: map[string]handlers.DependencyStatus{
+		"database":  {Status: handlers.StatusOK},
+		"informers": {Status: handlers.StatusOK},
+	}}, resp)
+
+	dbErr = errors.New("connection refused")
+	code, resp = getHealth(t, router, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, handlers.StatusUnavailable, resp.Status)
+	assert.Equal(t, handlers.DependencyStatus{Status: handlers.StatusUnavailable, Error: "connection refused"}, resp.Checks["database"])
+	assert.Equal(t, handlers.StatusOK, resp.Checks["informers"].Status)
+
+	code, resp = getHealth(t, router, "/healthz")
+	assert.Equal(t, http.StatusOK, code, "a database outage must not restart the replica")
+	assert.Equal(t, map[string]handlers.DependencyStatus{"informers": {Status: handlers.StatusOK}}, resp.Checks)
+}
+
+func TestHealthHandler_CheckTimesOut(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHealthHandler(handlers.Dependency{Name: "database", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	router := gin.New()
+	router.GET("/readyz", h.Readiness)
+
+	code, resp := getHealth(t, router, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, context.DeadlineExceeded.Error(), resp.Checks["database"].Error)
+}
+
+func TestHealthHandler_ShuttingDown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHealthHandler(
+		handlers.Dependency{Name: "informers", Liveness: true, Check: func(context.Context) error { return nil }},
+	)
+	router := gin.New()
+	router.GET("/healthz", h.Liveness)
+	router.GET("/readyz", h.Readiness)
+
+	h.StartShutdown()
+
+	code, resp := getHealth(t, router, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, handlers.HealthResponse{Status: handlers.StatusShuttingDown}, resp)
+
+	code, _ = getHealth(t, router, "/healthz")
+	assert.Equal(t, http.StatusOK, code, "a draining replica must not be restarted")
+}
+
+func TestHTTPCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	require.NoError(t, handlers.HTTPCheck(srv.Client(), srv.URL+"/health/ready")(t.Context()))
+	err := handlers.HTTPCheck(srv.Client(), srv.URL+"/down")(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
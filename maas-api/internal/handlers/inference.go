@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/constant"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+)
+
+const (
+	// MaxInferenceRequestBytes bounds the request bodies buffered to read their model.
+	MaxInferenceRequestBytes int64 = 16 << 20 // 16 MiB
+
+	// inferenceWriteTimeout replaces the server write timeout for proxied responses,
+	// since completions, streamed or not, often take longer.
+	inferenceWriteTimeout = 10 * time.Minute
+)
+
+// strippedInferenceHeaders are the request headers the proxy does not forward to the
+// models: the API key, and the identity and metering headers that model servers trust
+// because the gateway sets them.
+var strippedInferenceHeaders = []string{
+	"Authorization",
+	"X-Api-Key",
+	constant.HeaderUsername,
+	constant.HeaderGroup,
+	"X-MaaS-User",
+	"X-MaaS-Groups",
+	"X-MaaS-Organization-ID",
+	"X-MaaS-Cost-Center",
+}
+
+// KeyValidator validates the API keys of inference requests.
+type KeyValidator interface {
+	ValidateAPIKey(ctx context.Context, key string) (*api_keys.ValidationResult, error)
+}
+
+// InferenceRequest is the part of an OpenAI completion or chat completion request the
+// proxy reads. The other fields are forwarded to the model unchanged.
+type InferenceRequest struct {
+	// Model is the ID of the model, as listed by GET /v1/models, or the namespace/name
+	// of its MaaSModelRef.
+	Model  string `json:"model"`
+	Stream bool   `json:"stream,omitempty"`
+}
+
+// InferenceHandler proxies the OpenAI inference endpoints to the models, for deployments
+// without a gateway that authorizes inference requests. It authenticates the API key,
+// resolves the model of the request body, selects the subscription and forwards the
+// request to the model endpoint.
+type InferenceHandler struct {
+	logger               *logger.Logger
+	keys                 KeyValidator
+	subscriptionSelector *subscription.Selector
+	maasModelRefLister   models.MaaSModelRefLister
+	transport            http.RoundTripper
+}
+
+// NewInferenceHandler creates an inference proxy forwarding requests with transport.
+func NewInferenceHandler(
+	log *logger.Logger,
+	keys KeyValidator,
+	subscriptionSelector *subscription.Selector,
+	maasModelRefLister models.MaaSModelRefLister,
+	transport http.RoundTripper,
+) *InferenceHandler {
+	if log == nil {
+		log = logger.Production()
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &InferenceHandler{
+		logger:               log,
+		keys:                 keys,
+		subscriptionSelector: subscriptionSelector,
+		maasModelRefLister:   maasModelRefLister,
+		transport:            transport,
+	}
+}
+
+func inferenceError(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"message": message,
+			"type":    errType,
+		}})
+}
+
+// InferenceRequestError writes the OpenAI error of an invalid inference request, for
+// the requests rejected before they reach the proxy.
+func InferenceRequestError(c *gin.Context, status int, message string) {
+	inferenceError(c, status, "invalid_request_error", message)
+}
+
+// Proxy handles POST /v1/chat/completions and POST /v1/completions.
+func (h *InferenceHandler) Proxy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	key, ok := strings.CutPrefix(strings.TrimSpace(c.GetHeader("Authorization")), "Bearer ")
+	if !ok || !strings.HasPrefix(key, api_keys.KeyPrefix) {
+		inferenceError(c, http.StatusUnauthorized, "authentication_error", "An API key is required: Authorization: Bearer "+api_keys.KeyPrefix+"...")
+		return
+	}
+	identity, err := h.keys.ValidateAPIKey(ctx, key)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "API key validation failed", "error", err)
+		inferenceError(c, http.StatusInternalServerError, "server_error", "Failed to validate the API key")
+		return
+	}
+	if !identity.Valid {
+		h.logger.DebugContext(ctx, "Invalid API key", "reason", identity.Reason)
+		inferenceError(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+	middleware.Attribute(c, middleware.Attribution{User: identity.Username, KeyID: identity.KeyID})
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxInferenceRequestBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			inferenceError(c, http.StatusRequestEntityTooLarge, "invalid_request_error", "Request body too large")
+			return
+		}
+		inferenceError(c, http.StatusBadRequest, "invalid_request_error", "Failed to read the request body")
+		return
+	}
+	var req InferenceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		inferenceError(c, http.StatusBadRequest, "invalid_request_error", "The request body must be a JSON object")
+		return
+	}
+	if strings.TrimSpace(req.Model) == "" {
+		inferenceError(c, http.StatusBadRequest, "invalid_request_error", "model is required")
+		return
+	}
+
+	model, err := h.resolveModel(req.Model)
+	if err != nil {
+		var notFound *modelNotFoundError
+		var ambiguous *ambiguousModelError
+		switch {
+		case errors.As(err, &notFound):
+			inferenceError(c, http.StatusNotFound, "invalid_request_error", err.Error())
+		case errors.As(err, &ambiguous):
+			inferenceError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		default:
+			h.logger.ErrorContext(ctx, "Failed to list models", "error", err)
+			inferenceError(c, http.StatusInternalServerError, "server_error", "Failed to resolve the model")
+		}
+		return
+	}
+	// OwnedBy is the namespace/name of the MaaSModelRef.
+	modelRef := model.OwnedBy
+	namespace, name, _ := strings.Cut(modelRef, "/")
+	middleware.Attribute(c, middleware.Attribution{Model: modelRef})
+
+	//nolint:unqueryvet,nolintlint // Select is a method, not a SQL query
+	sub, err := h.subscriptionSelector.Select(identity.Groups, identity.Username, identity.Subscription, modelRef)
+	if err != nil {
+		h.handleSelectionError(c, err)
+		return
+	}
+	middleware.Attribute(c, middleware.Attribution{Subscription: sub.Name})
+	if !h.subscriptionSelector.IsModelAuthorized(identity.Groups, identity.Username, namespace, name) {
+		inferenceError(c, http.StatusForbidden, "permission_error", "Access to model "+req.Model+" is not granted by any MaaSAuthPolicy")
+		return
+	}
+	if !model.Ready || model.URL == nil {
+		inferenceError(c, http.StatusServiceUnavailable, "server_error", "Model "+req.Model+" is not ready")
+		return
+	}
+
+	target, err := url.JoinPath(model.URL.String(), c.Request.URL.Path)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Invalid model endpoint", "model", modelRef, "error", err)
+		inferenceError(c, http.StatusInternalServerError, "server_error", "Invalid model endpoint")
+		return
+	}
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Invalid model endpoint", "model", modelRef, "error", err)
+		inferenceError(c, http.StatusInternalServerError, "server_error", "Invalid model endpoint")
+		return
+	}
+	// A namespace/name model is sent to the backend by its ID.
+	if req.Model != model.ID {
+		if body, err = rewriteModel(body, model.ID); err != nil {
+			inferenceError(c, http.StatusBadRequest, "invalid_request_error", "The request body must be a JSON object")
+			return
+		}
+	}
+
+	h.logger.DebugContext(ctx, "Proxying inference request",
+		"model", modelRef,
+		"subscription", sub.Name,
+		"stream", req.Stream,
+	)
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(inferenceWriteTimeout)); err != nil {
+		h.logger.DebugContext(ctx, "Failed to extend the write deadline", "error", err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			targetURL.RawQuery = r.In.URL.RawQuery
+			r.Out.URL = targetURL
+			r.Out.Host = ""
+			r.SetXForwarded()
+			// The MaaS API key is not a credential of the model, and the identity and
+			// metering headers are only set by the gateway: none of them are forwarded.
+			// The subscription is the one selected here.
+			for _, header := range strippedInferenceHeaders {
+				r.Out.Header.Del(header)
+			}
+			r.Out.Header.Set("X-Maas-Subscription", sub.Namespace+"/"+sub.Name)
+		},
+		Transport: h.transport,
+		// Streamed completions are flushed event by event.
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			h.logger.ErrorContext(r.Context(), "Failed to reach the model", "model", modelRef, "error", err)
+			inferenceError(c, http.StatusBadGateway, "server_error", "Failed to reach the model")
+		},
+	}
+	defer h.recoverAbortedStream(c, modelRef)
+	proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// recoverAbortedStream handles the http.ErrAbortHandler panic of httputil.ReverseProxy
+// when a response is interrupted, before gin.Recovery logs it as a crash. A client that
+// disconnects has already cancelled the request to the model; the connection of a
+// response the model broke off is closed, so that the client does not take it for a
+// complete one.
+func (h *InferenceHandler) recoverAbortedStream(c *gin.Context, modelRef string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if r != http.ErrAbortHandler { //nolint:errorlint // ReverseProxy panics with the sentinel itself
+		panic(r)
+	}
+	ctx := c.Request.Context()
+	c.Abort()
+	if ctx.Err() != nil {
+		h.logger.DebugContext(ctx, "Client disconnected from the model response", "model", modelRef)
+		return
+	}
+	h.logger.ErrorContext(ctx, "Model response interrupted", "model", modelRef)
+	if conn, _, err := http.NewResponseController(c.Writer).Hijack(); err == nil {
+		_ = conn.Close()
+	}
+}
+
+// handleSelectionError responds to a failed subscription selection. Like the gateway,
+// subscription errors are 403 Forbidden.
+func (h *InferenceHandler) handleSelectionError(c *gin.Context, err error) {
+	var noSubErr *subscription.NoSubscriptionError
+	var notFoundErr *subscription.SubscriptionNotFoundError
+	var accessDeniedErr *subscription.AccessDeniedError
+	var multipleSubsErr *subscription.MultipleSubscriptionsError
+	var modelNotInSubErr *subscription.ModelNotInSubscriptionError
+	var modelUnhealthyErr *subscription.ModelUnhealthyError
+
+	switch {
+	case errors.As(err, &modelUnhealthyErr):
+		inferenceError(c, http.StatusServiceUnavailable, "server_error", modelUnhealthyErr.Message)
+	case errors.As(err, &noSubErr), errors.As(err, &notFoundErr), errors.As(err, &accessDeniedErr),
+		errors.As(err, &multipleSubsErr), errors.As(err, &modelNotInSubErr):
+		h.logger.DebugContext(c.Request.Context(), "Subscription selection denied", "error", err)
+		inferenceError(c, http.StatusForbidden, "permission_error", err.Error())
+	default:
+		h.logger.ErrorContext(c.Request.Context(), "Subscription selection failed", "error", err)
+		inferenceError(c, http.StatusInternalServerError, "server_error", "Failed to select subscription")
+	}
+}
+
+type modelNotFoundError struct {
+	model string
+}
+
+func (e *modelNotFoundError) Error() string {
+	return "The model " + e.model + " does not exist"
+}
+
+type ambiguousModelError struct {
+	model string
+	refs  []string
+}
+
+func (e *ambiguousModelError) Error() string {
+	return "The model " + e.model + " is served by " + strings.Join(e.refs, ", ") + "; use its namespace/name"
+}
+
+// resolveModel returns the model of a request: the MaaSModelRef whose model ID, or
+// namespace/name, is requested.
+func (h *InferenceHandler) resolveModel(requested string) (*models.Model, error) {
+	list, err := models.ListFromMaaSModelRefLister(h.maasModelRefLister)
+	if err != nil {
+		return nil, err
+	}
+	var matches []models.Model
+	for _, m := range list {
+		if m.OwnedBy == requested {
+			return &m, nil
+		}
+		if m.ID == requested {
+			matches = append(matches, m)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, &modelNotFoundError{model: requested}
+	case 1:
+		return &matches[0], nil
+	}
+	refs := make([]string, len(matches))
+	for i, m := range matches {
+		refs[i] = m.OwnedBy
+	}
+	return nil, &ambiguousModelError{model: requested, refs: refs}
+}
+
+// rewriteModel sets the model of a JSON request body.
+func rewriteModel(body []byte, model string) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	fields["model"] = encoded
+	return json.Marshal(fields)
+}
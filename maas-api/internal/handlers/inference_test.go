@@ -0,0 +1,373 @@
+package handlers_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/api_keys"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/authpolicy"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/handlers"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+)
+
+const testAPIKey = "sk-oai-testkey_secret"
+
+// fakeKeyValidator accepts testAPIKey for alice, bound to the premium subscription.
+type fakeKeyValidator struct {
+	err error
+}
+
+func (f fakeKeyValidator) ValidateAPIKey(_ context.Context, key string) (*api_keys.ValidationResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if key != testAPIKey {
+		return &api_keys.ValidationResult{Valid: false, Reason: "key not found"}, nil
+	}
+	return &api_keys.ValidationResult{
+		Valid:        true,
+		Username:     "alice",
+		KeyID:        "0b9f5c52-3c43-4c45-9f6e-1f3a1e0c9d11",
+		Groups:       []string{"premium-users"},
+		Subscription: "premium",
+	}, nil
+}
+
+// inferenceSubscriptionLister returns the premium subscription, which includes the
+// models of modelRefs ("namespace/name").
+type inferenceSubscriptionLister []string
+
+func (f inferenceSubscriptionLister) List() ([]*unstructured.Unstructured, error) {
+	sub := &unstructured.Unstructured{}
+	sub.SetName("premium")
+	sub.SetNamespace("models-as-a-service")
+	_ = unstructured.SetNestedSlice(sub.Object, []any{map[string]any{"name": "premium-users"}}, "spec", "owner", "groups")
+	refs := make([]any, 0, len(f))
+	for _, ref := range f {
+		namespace, name, _ := strings.Cut(ref, "/")
+		refs = append(refs, map[string]any{"name": name, "namespace": namespace})
+	}
+	_ = unstructured.SetNestedSlice(sub.Object, refs, "spec", "modelRefs")
+	_ = unstructured.SetNestedField(sub.Object, "Active", "status", "phase")
+	_ = unstructured.SetNestedSlice(sub.Object, []any{
+		map[string]any{"type": "Ready", "status": "True"},
+	}, "status", "conditions")
+	return []*unstructured.Unstructured{sub}, nil
+}
+
+// authorizedModels implements subscription.ModelAccessChecker for tests.
+type authorizedModels map[authpolicy.ModelKey]bool
+
+func (a authorizedModels) AuthorizedModels(_ []string, _ string) map[authpolicy.ModelKey]bool {
+	return a
+}
+
+type proxiedRequest struct {
+	path         string
+	body         map[string]any
+	subscription string
+	header       http.Header
+}
+
+// spoofedHeaders are sent by the client of every proxied request; the gateway or the
+// proxy sets them, so none may reach the model.
+var spoofedHeaders = map[string]string{
+	"X-Api-Key":              testAPIKey,
+	"X-Maas-Username":        "mallory",
+	"X-Maas-Group":           "cluster-admins",
+	"X-MaaS-User":            "mallory",
+	"X-MaaS-Groups":          "cluster-admins",
+	"X-MaaS-Organization-ID": "org-victim",
+	"X-MaaS-Cost-Center":     "cc-victim",
+}
+
+func newInferenceRouter(t *testing.T, refs fakeMaaSModelRefLister, subscribed []string, authorized authorizedModels, keys handlers.KeyValidator, middleware ...gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	log := logger.New(false)
+	selector := subscription.NewSelector(log, inferenceSubscriptionLister(subscribed), refs, authorized, nil)
+	handler := handlers.NewInferenceHandler(log, keys, selector, refs, nil)
+	router := gin.New()
+	router.Use(middleware...)
+	router.POST("/v1/chat/completions", handler.Proxy)
+	router.POST("/v1/completions", handler.Proxy)
+	return router
+}
+
+func newModelBackend(t *testing.T, received chan<- proxiedRequest) *httptest.Server {
+	t.Helper()
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		received <- proxiedRequest{
+			path:         r.URL.Path,
+			body:         body,
+			subscription: r.Header.Get("X-Maas-Subscription"),
+			header:       r.Header.Clone(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"object":"chat.completion","choices":[]}`)
+	}))
+	t.Cleanup(backend.Close)
+	return backend
+}
+
+// sendInference sends a request through a server: httputil.ReverseProxy needs the
+// http.CloseNotifier of gin's writer, which httptest.ResponseRecorder does not implement.
+func sendInference(t *testing.T, router *gin.Engine, path, auth, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	server := httptest.NewServer(router)
+	defer server.Close()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range spoofedHeaders {
+		req.Header.Set(name, value)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	w := httptest.NewRecorder()
+	w.Code = resp.StatusCode
+	_, err = io.Copy(w.Body, resp.Body)
+	require.NoError(t, err)
+	return w
+}
+
+func errorType(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body struct {
+		Error struct {
+			Type string `json:"type"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return body.Error.Type
+}
+
+func TestInferenceProxy_ForwardsToModel(t *testing.T) {
+	received := make(chan proxiedRequest, 1)
+	backend := newModelBackend(t, received)
+	refs := fakeMaaSModelRefLister{"llm": {maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil)}}
+	router := newInferenceRouter(t, refs, []string{"llm/granite"}, authorizedModels{{Namespace: "llm", Name: "granite"}: true}, fakeKeyValidator{})
+
+	for _, path := range []string{"/v1/chat/completions", "/v1/completions"} {
+		t.Run(path, func(t *testing.T) {
+			w := sendInference(t, router, path, "Bearer "+testAPIKey, `{"model":"granite","messages":[{"role":"user","content":"hi"}]}`)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.JSONEq(t, `{"object":"chat.completion","choices":[]}`, w.Body.String())
+			got := <-received
+			assert.Equal(t, "/llm/granite"+path, got.path)
+			assert.Equal(t, "granite", got.body["model"])
+			assert.NotNil(t, got.body["messages"])
+			assert.Equal(t, "models-as-a-service/premium", got.subscription)
+			assert.Empty(t, got.header.Values("Authorization"), "the API key must not be forwarded")
+		})
+	}
+}
+
+func TestInferenceProxy_StripsClientHeaders(t *testing.T) {
+	received := make(chan proxiedRequest, 1)
+	backend := newModelBackend(t, received)
+	refs := fakeMaaSModelRefLister{"llm": {maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil)}}
+	router := newInferenceRouter(t, refs, []string{"llm/granite"}, authorizedModels{{Namespace: "llm", Name: "granite"}: true}, fakeKeyValidator{})
+
+	w := sendInference(t, router, "/v1/chat/completions", "Bearer "+testAPIKey, `{"model":"granite"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	got := <-received
+
+	for name := range spoofedHeaders {
+		t.Run(name, func(t *testing.T) {
+			assert.Empty(t, got.header.Values(name), "%s must not be forwarded", name)
+		})
+	}
+}
+
+func TestInferenceProxy_NamespacedModel(t *testing.T) {
+	received := make(chan proxiedRequest, 1)
+	backend := newModelBackend(t, received)
+	refs := fakeMaaSModelRefLister{
+		"llm":  {maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil)},
+		"team": {maasModelRefUnstructured("granite", "team", backend.URL+"/team/granite", true, nil)},
+	}
+	authorized := authorizedModels{{Namespace: "llm", Name: "granite"}: true, {Namespace: "team", Name: "granite"}: true}
+	router := newInferenceRouter(t, refs, []string{"llm/granite", "team/granite"}, authorized, fakeKeyValidator{})
+
+	w := sendInference(t, router, "/v1/chat/completions", "Bearer "+testAPIKey, `{"model":"granite"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "use its namespace/name")
+
+	w = sendInference(t, router, "/v1/chat/completions", "Bearer "+testAPIKey, `{"model":"team/granite"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	got := <-received
+	assert.Equal(t, "/team/granite/v1/chat/completions", got.path)
+	assert.Equal(t, "granite", got.body["model"], "the backend gets the model ID")
+}
+
+func TestInferenceProxy_Rejections(t *testing.T) {
+	backend := newModelBackend(t, make(chan proxiedRequest, 1))
+	refs := fakeMaaSModelRefLister{"llm": {
+		maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil),
+		maasModelRefUnstructured("llama", "llm", backend.URL+"/llm/llama", true, nil),
+		maasModelRefUnstructured("mistral", "llm", backend.URL+"/llm/mistral", false, nil),
+		maasModelRefUnstructured("phi", "llm", backend.URL+"/llm/phi", true, nil),
+	}}
+	authorized := authorizedModels{
+		{Namespace: "llm", Name: "granite"}: true,
+		{Namespace: "llm", Name: "llama"}:   true,
+		{Namespace: "llm", Name: "mistral"}: true,
+	}
+
+	tests := []struct {
+		name     string
+		keys     handlers.KeyValidator
+		auth     string
+		body     string
+		wantCode int
+		wantType string
+	}{
+		{name: "no credentials", auth: "", body: `{"model":"granite"}`, wantCode: http.StatusUnauthorized, wantType: "authentication_error"},
+		{name: "OpenShift token", auth: "Bearer sha256~token", body: `{"model":"granite"}`, wantCode: http.StatusUnauthorized, wantType: "authentication_error"},
+		{name: "unknown API key", auth: "Bearer sk-oai-unknown_secret", body: `{"model":"granite"}`, wantCode: http.StatusUnauthorized, wantType: "authentication_error"},
+		{name: "validation failure", keys: fakeKeyValidator{err: errors.New("database down")}, auth: "Bearer " + testAPIKey, body: `{"model":"granite"}`, wantCode: http.StatusInternalServerError, wantType: "server_error"},
+		{name: "invalid body", auth: "Bearer " + testAPIKey, body: `not json`, wantCode: http.StatusBadRequest, wantType: "invalid_request_error"},
+		{name: "missing model", auth: "Bearer " + testAPIKey, body: `{"prompt":"hi"}`, wantCode: http.StatusBadRequest, wantType: "invalid_request_error"},
+		{name: "unknown model", auth: "Bearer " + testAPIKey, body: `{"model":"gpt-4"}`, wantCode: http.StatusNotFound, wantType: "invalid_request_error"},
+		{name: "model not in subscription", auth: "Bearer " + testAPIKey, body: `{"model":"llama"}`, wantCode: http.StatusForbidden, wantType: "permission_error"},
+		{name: "model not authorized", auth: "Bearer " + testAPIKey, body: `{"model":"phi"}`, wantCode: http.StatusForbidden, wantType: "permission_error"},
+		{name: "model not ready", auth: "Bearer " + testAPIKey, body: `{"model":"mistral"}`, wantCode: http.StatusServiceUnavailable, wantType: "server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := tt.keys
+			if keys == nil {
+				keys = fakeKeyValidator{}
+			}
+			router := newInferenceRouter(t, refs, []string{"llm/granite", "llm/mistral", "llm/phi"}, authorized, keys)
+
+			w := sendInference(t, router, "/v1/chat/completions", tt.auth, tt.body)
+
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			assert.Equal(t, tt.wantType, errorType(t, w))
+		})
+	}
+}
+
+func TestInferenceProxy_UnreachableModel(t *testing.T) {
+	backend := httptest.NewServer(http.NotFoundHandler())
+	endpoint := backend.URL + "/llm/granite"
+	backend.Close()
+	refs := fakeMaaSModelRefLister{"llm": {maasModelRefUnstructured("granite", "llm", endpoint, true, nil)}}
+	router := newInferenceRouter(t, refs, []string{"llm/granite"}, authorizedModels{{Namespace: "llm", Name: "granite"}: true}, fakeKeyValidator{})
+
+	w := sendInference(t, router, "/v1/chat/completions", "Bearer "+testAPIKey, `{"model":"granite"}`)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, "server_error", errorType(t, w))
+}
+
+func TestInferenceProxy_StreamsEvents(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{`{"choices":[{"delta":{"content":"Hel"}}]}`, `{"choices":[{"delta":{"content":"lo"}}]}`, "[DONE]"} {
+			_, _ = io.WriteString(w, "data: "+event+"\n\n")
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(backend.Close)
+	refs := fakeMaaSModelRefLister{"llm": {maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil)}}
+	router := newInferenceRouter(t, refs, []string{"llm/granite"}, authorizedModels{{Namespace: "llm", Name: "granite"}: true}, fakeKeyValidator{})
+
+	w := sendInference(t, router, "/v1/chat/completions", "Bearer "+testAPIKey, `{"model":"granite","stream":true}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 3, strings.Count(w.Body.String(), "data: "))
+	assert.True(t, strings.HasSuffix(w.Body.String(), "data: [DONE]\n\n"))
+}
+
+func TestInferenceProxy_ClientDisconnectCancelsModelRequest(t *testing.T) {
+	cancelled := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	t.Cleanup(backend.Close)
+	refs := fakeMaaSModelRefLister{"llm": {maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil)}}
+	var panicked any
+	router := newInferenceRouter(t, refs, []string{"llm/granite"}, authorizedModels{{Namespace: "llm", Name: "granite"}: true}, fakeKeyValidator{},
+		gin.CustomRecovery(func(_ *gin.Context, err any) { panicked = err }))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/chat/completions", strings.NewReader(`{"model":"granite","stream":true}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "data: {}\n", line)
+	cancel()
+	_ = resp.Body.Close()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the model request was not cancelled")
+	}
+	server.Close()
+	assert.Nil(t, panicked)
+}
+
+func TestInferenceProxy_InterruptedModelResponse(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {}\n\n")
+		w.(http.Flusher).Flush()
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	t.Cleanup(backend.Close)
+	refs := fakeMaaSModelRefLister{"llm": {maasModelRefUnstructured("granite", "llm", backend.URL+"/llm/granite", true, nil)}}
+	// Like main, with gin.Recovery, which would otherwise end the response cleanly.
+	router := newInferenceRouter(t, refs, []string{"llm/granite"}, authorizedModels{{Namespace: "llm", Name: "granite"}: true}, fakeKeyValidator{},
+		gin.RecoveryWithWriter(io.Discard))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+"/v1/chat/completions", strings.NewReader(`{"model":"granite","stream":true}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF, "the client must see the response is incomplete")
+	assert.Equal(t, "data: {}\n\n", string(body))
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"sort"
@@ -11,17 +12,31 @@ import (
 	"github.com/openai/openai-go/v2/packages/pagination"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
 )
 
+// PerformanceSource provides the recent performance of the models, keyed by MaaSModelRef
+// "namespace/name".
+type PerformanceSource interface {
+	ModelPerformance(ctx context.Context) map[string]models.Performance
+}
+
 // ModelsHandler handles model-related endpoints.
 type ModelsHandler struct {
 	modelMgr             *models.Manager
 	subscriptionSelector *subscription.Selector
 	logger               *logger.Logger
 	maasModelRefLister   models.MaaSModelRefLister
+	performance          PerformanceSource
+}
+
+// SetPerformanceSource sets the source of the latency and error rate reported with the
+// models of GET /v1/models. Without one, the models have no performance.
+func (h *ModelsHandler) SetPerformanceSource(source PerformanceSource) {
+	h.performance = source
 }
 
 // NewModelsHandler creates a new models handler.
@@ -56,7 +71,7 @@ func (h *ModelsHandler) selectSubscriptionsForListing(
 		if h.subscriptionSelector != nil {
 			allSubs, err := h.subscriptionSelector.GetAllAccessible(userContext.Groups, userContext.Username)
 			if err != nil {
-				h.logger.Error("Failed to get all accessible subscriptions", "error", err)
+				h.logger.ErrorContext(c.Request.Context(), "Failed to get all accessible subscriptions", "error", err)
 				c.JSON(http.StatusInternalServerError, gin.H{
 					"error": gin.H{
 						"message": "Failed to get subscriptions",
@@ -64,11 +79,11 @@ func (h *ModelsHandler) selectSubscriptionsForListing(
 					}})
 				return nil, true
 			}
-			h.logger.Debug("User token - returning models from all accessible subscriptions", "subscriptionCount", len(allSubs))
+			h.logger.DebugContext(c.Request.Context(), "User token - returning models from all accessible subscriptions", "subscriptionCount", len(allSubs))
 			return allSubs, false
 		}
 		// No selector configured - cannot return all models
-		h.logger.Debug("Subscription selector not configured")
+		h.logger.DebugContext(c.Request.Context(), "Subscription selector not configured")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Subscription system not configured",
@@ -85,7 +100,7 @@ func (h *ModelsHandler) selectSubscriptionsForListing(
 			h.handleSubscriptionSelectionError(c, err)
 			return nil, true
 		}
-		h.logger.Debug("API key - filtering by subscription", "subscription", result.Name)
+		h.logger.DebugContext(c.Request.Context(), "API key - filtering by subscription", "subscription", result.Name)
 		return []*subscription.SelectResponse{result}, false
 	}
 
@@ -111,7 +126,7 @@ func (h *ModelsHandler) handleSubscriptionSelectionError(c *gin.Context, err err
 	if errors.As(err, &multipleSubsErr) {
 		// This should not happen with API keys (subscription is bound at mint time)
 		// If it does, it indicates the API key was minted without a subscription
-		h.logger.Debug("API key has no subscription bound - invalid state",
+		h.logger.DebugContext(c.Request.Context(), "API key has no subscription bound - invalid state",
 			"subscriptionCount", len(multipleSubsErr.Subscriptions),
 		)
 		c.JSON(http.StatusForbidden, gin.H{
@@ -123,7 +138,7 @@ func (h *ModelsHandler) handleSubscriptionSelectionError(c *gin.Context, err err
 	}
 
 	if errors.As(err, &accessDeniedErr) {
-		h.logger.Debug("Access denied to subscription")
+		h.logger.DebugContext(c.Request.Context(), "Access denied to subscription")
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": gin.H{
 				"message": err.Error(),
@@ -133,7 +148,7 @@ func (h *ModelsHandler) handleSubscriptionSelectionError(c *gin.Context, err err
 	}
 
 	if errors.As(err, &notFoundErr) {
-		h.logger.Debug("Subscription not found")
+		h.logger.DebugContext(c.Request.Context(), "Subscription not found")
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": gin.H{
 				"message": err.Error(),
@@ -143,7 +158,7 @@ func (h *ModelsHandler) handleSubscriptionSelectionError(c *gin.Context, err err
 	}
 
 	if errors.As(err, &noSubErr) {
-		h.logger.Debug("No subscription found for user")
+		h.logger.DebugContext(c.Request.Context(), "No subscription found for user")
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": gin.H{
 				"message": err.Error(),
@@ -153,7 +168,7 @@ func (h *ModelsHandler) handleSubscriptionSelectionError(c *gin.Context, err err
 	}
 
 	// Other errors are internal server errors
-	h.logger.Error("Subscription selection failed", "error", err)
+	h.logger.ErrorContext(c.Request.Context(), "Subscription selection failed", "error", err)
 	c.JSON(http.StatusInternalServerError, gin.H{
 		"error": gin.H{
 			"message": "Failed to select subscription",
@@ -176,7 +191,7 @@ func (h *ModelsHandler) addSubscriptionIfNew(model *models.Model, subInfo models
 func (h *ModelsHandler) extractAndValidateAuth(c *gin.Context) (string, string, bool, error) {
 	authHeader := strings.TrimSpace(c.GetHeader("Authorization"))
 	if authHeader == "" {
-		h.logger.Debug("Authorization header missing") // SAFE: Logging that header is missing, not the value itself
+		h.logger.DebugContext(c.Request.Context(), "Authorization header missing") // SAFE: Logging that header is missing, not the value itself
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": gin.H{
 				"message": "Authorization required",
@@ -199,7 +214,7 @@ func (h *ModelsHandler) extractAndValidateAuth(c *gin.Context) (string, string,
 
 	// Fail closed: API keys without a bound subscription must be rejected
 	if isAPIKeyRequest && requestedSubscription == "" {
-		h.logger.Debug("API key request missing bound subscription header")
+		h.logger.DebugContext(c.Request.Context(), "API key request missing bound subscription header")
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": gin.H{
 				"message": "API key has no subscription bound",
@@ -219,7 +234,7 @@ func (h *ModelsHandler) getUserContextIfNeeded(c *gin.Context) (*token.UserConte
 
 	userContextVal, exists := c.Get("user")
 	if !exists {
-		h.logger.Error("User context not found - ExtractUserInfo middleware not called")
+		h.logger.ErrorContext(c.Request.Context(), "User context not found - ExtractUserInfo middleware not called")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Internal server error",
@@ -230,7 +245,7 @@ func (h *ModelsHandler) getUserContextIfNeeded(c *gin.Context) (*token.UserConte
 
 	userContext, ok := userContextVal.(*token.UserContext)
 	if !ok {
-		h.logger.Error("Invalid user context type")
+		h.logger.ErrorContext(c.Request.Context(), "Invalid user context type")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Internal server error",
@@ -284,17 +299,17 @@ func (h *ModelsHandler) aggregateModelsFromSubscriptions(
 			// Pre-filter by modelRefs if available (optimization to reduce HTTP calls)
 			modelsToCheck := list
 			if len(sub.ModelRefs) > 0 {
-				h.logger.Debug("Pre-filtering models by subscription modelRefs",
+				h.logger.DebugContext(ctx, "Pre-filtering models by subscription modelRefs",
 					"subscription", sub.Name,
 					"totalModels", len(list),
 					"modelRefsCount", len(sub.ModelRefs),
 				)
 				modelsToCheck = filterModelsBySubscription(list, sub.ModelRefs)
-				h.logger.Debug("After modelRef filtering", "modelsToCheck", len(modelsToCheck))
+				h.logger.DebugContext(ctx, "After modelRef filtering", "modelsToCheck", len(modelsToCheck))
 			}
 
 			probeSubscriptionHeader := sub.Name
-			h.logger.Debug("Filtering models by subscription", "subscription", sub.Name, "modelCount", len(modelsToCheck), "probeWithSubscriptionHeader", probeSubscriptionHeader != "")
+			h.logger.DebugContext(ctx, "Filtering models by subscription", "subscription", sub.Name, "modelCount", len(modelsToCheck), "probeWithSubscriptionHeader", probeSubscriptionHeader != "")
 			filteredModels := h.modelMgr.FilterModelsByAccess(ctx, modelsToCheck, authHeader, probeSubscriptionHeader)
 
 			resultChan <- probeResult{
@@ -363,6 +378,8 @@ func (h *ModelsHandler) ListLLMs(c *gin.Context) {
 		return
 	}
 
+	middleware.Attribute(c, middleware.Attribution{Subscription: requestedSubscription})
+
 	// Determine behavior based on auth method
 	returnAllModels := !isAPIKeyRequest && requestedSubscription == ""
 
@@ -374,11 +391,11 @@ func (h *ModelsHandler) ListLLMs(c *gin.Context) {
 
 	// Log the authentication method and filtering behavior
 	if requestedSubscription != "" {
-		h.logger.Debug("API key request - filtering models by subscription",
+		h.logger.DebugContext(c.Request.Context(), "API key request - filtering models by subscription",
 			"subscription", requestedSubscription,
 		)
 	} else {
-		h.logger.Debug("User token request - returning all accessible models")
+		h.logger.DebugContext(c.Request.Context(), "User token request - returning all accessible models")
 	}
 
 	// Determine which subscriptions to use for model filtering
@@ -391,10 +408,10 @@ func (h *ModelsHandler) ListLLMs(c *gin.Context) {
 	modelList := []models.Model{}
 	accessCheckedAt := time.Now().UTC()
 	if h.maasModelRefLister != nil {
-		h.logger.Debug("Listing models from MaaSModelRef cache (all namespaces)")
+		h.logger.DebugContext(c.Request.Context(), "Listing models from MaaSModelRef cache (all namespaces)")
 		list, err := models.ListFromMaaSModelRefLister(h.maasModelRefLister)
 		if err != nil {
-			h.logger.Error("Listing from MaaSModelRef failed", "error", err)
+			h.logger.ErrorContext(c.Request.Context(), "Listing from MaaSModelRef failed", "error", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": gin.H{
 					"message": "Failed to list models",
@@ -402,16 +419,17 @@ func (h *ModelsHandler) ListLLMs(c *gin.Context) {
 				}})
 			return
 		}
+		list = models.FilterByVisibility(list, isAPIKeyRequest)
 
 		// Distinguish between "no subscription system" and "user has zero subscriptions"
 		if len(subscriptionsToUse) == 0 {
 			if h.subscriptionSelector == nil {
 				// Legacy case: no subscription system configured
-				h.logger.Debug("No subscription system configured, filtering models without subscription header")
+				h.logger.DebugContext(c.Request.Context(), "No subscription system configured, filtering models without subscription header")
 				modelList = h.modelMgr.FilterModelsByAccess(c.Request.Context(), list, authHeader, "")
 			} else {
 				// User has zero accessible subscriptions - return empty list
-				h.logger.Debug("User has zero accessible subscriptions, returning empty model list")
+				h.logger.DebugContext(c.Request.Context(), "User has zero accessible subscriptions, returning empty model list")
 				// modelList is already initialized to empty slice above
 			}
 		} else {
@@ -420,24 +438,40 @@ func (h *ModelsHandler) ListLLMs(c *gin.Context) {
 		}
 
 		accessCheckedAt = time.Now().UTC()
-		h.logger.Debug("Access validation complete", "listed", len(list), "accessible", len(modelList), "subscriptions", len(subscriptionsToUse))
+		h.logger.DebugContext(c.Request.Context(), "Access validation complete", "listed", len(list), "accessible", len(modelList), "subscriptions", len(subscriptionsToUse))
 	} else {
-		h.logger.Debug("MaaSModelRef lister not configured, returning empty model list")
+		h.logger.DebugContext(c.Request.Context(), "MaaSModelRef lister not configured, returning empty model list")
 	}
 
+	h.addPerformance(c.Request.Context(), modelList)
+
 	// Prevent clients and proxies from caching authorization-checked model listings.
 	// The access check is a point-in-time snapshot; auth policies may change at any moment.
 	// X-Access-Checked-At lets clients assess the freshness of the authorization decision.
 	c.Header("Cache-Control", "no-store")
 	c.Header("X-Access-Checked-At", accessCheckedAt.Format(time.RFC3339))
 
-	h.logger.Debug("GET /v1/models returning models", "count", len(modelList))
+	h.logger.DebugContext(c.Request.Context(), "GET /v1/models returning models", "count", len(modelList))
 	c.JSON(http.StatusOK, pagination.Page[models.Model]{
 		Object: "list",
 		Data:   modelList,
 	})
 }
 
+// addPerformance sets the performance of the models that served requests recently.
+func (h *ModelsHandler) addPerformance(ctx context.Context, modelList []models.Model) {
+	if h.performance == nil || len(modelList) == 0 {
+		return
+	}
+	performance := h.performance.ModelPerformance(ctx)
+	for i := range modelList {
+		// Models from MaaSModelRefLister have OwnedBy set to namespace/name
+		if p, ok := performance[modelList[i].OwnedBy]; ok {
+			modelList[i].Performance = &p
+		}
+	}
+}
+
 // filterModelsBySubscription filters models to only those matching the subscription's modelRefs.
 func filterModelsBySubscription(modelList []models.Model, modelRefs []subscription.ModelRefInfo) []models.Model {
 	if len(modelRefs) == 0 {
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -357,7 +358,7 @@ func TestListingModels(t *testing.T) { //nolint:maintidx // table-driven test wi
 	defer cleanup()
 
 	// Create a mock subscription selector that auto-selects for single subscription users
-	subscriptionSelector := subscription.NewSelector(testLogger, &fakeSubscriptionLister{}, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, &fakeSubscriptionLister{}, nil, nil, nil)
 
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, maasModelRefLister)
 
@@ -475,7 +476,7 @@ func TestListingModelsWithSubscriptionHeader(t *testing.T) {
 		"premium": []string{"premium-users"},
 		"free":    []string{"free-users"},
 	}
-	subscriptionSelector := subscription.NewSelector(testLogger, multiSubLister, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, multiSubLister, nil, nil, nil)
 
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, maasModelRefLister)
 	tokenHandler := token.NewHandler(testLogger, fixtures.TestTenant)
@@ -695,7 +696,7 @@ func TestListModels_ReturnAllModels(t *testing.T) {
 	modelMgr, err := models.NewManager(testLogger, 15, "")
 	require.NoError(t, err)
 
-	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil, nil)
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -748,7 +749,7 @@ func TestListModels_ReturnAllModels(t *testing.T) {
 			},
 		}
 
-		subscriptionSelector := subscription.NewSelector(testLogger, emptySubscriptionLister, nil, nil)
+		subscriptionSelector := subscription.NewSelector(testLogger, emptySubscriptionLister, nil, nil, nil)
 		emptyHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 		config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -884,7 +885,7 @@ func TestListModels_DeduplicationBySubscription(t *testing.T) {
 	modelMgr, err := models.NewManager(testLogger, 15, "")
 	require.NoError(t, err)
 
-	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil, nil)
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -1002,7 +1003,7 @@ func TestListModels_DifferentModelRefsWithSameModelID(t *testing.T) {
 	modelMgr, err := models.NewManager(testLogger, 15, "")
 	require.NoError(t, err)
 
-	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil, nil)
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -1109,7 +1110,7 @@ func TestListModels_DifferentModelRefsWithSameURLAndModelID(t *testing.T) {
 	modelMgr, err := models.NewManager(testLogger, 15, "")
 	require.NoError(t, err)
 
-	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil, nil)
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -1215,7 +1216,7 @@ func TestListModels_DifferentModelRefsWithSameModelIDAndDifferentSubscriptions(t
 	modelMgr, err := models.NewManager(testLogger, 15, "")
 	require.NoError(t, err)
 
-	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, subscriptionLister, nil, nil, nil)
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -1308,7 +1309,7 @@ func TestListModels_ExternalModelUsesModelRefName(t *testing.T) {
 	modelMgr, err := models.NewManager(testLogger, 15, "")
 	require.NoError(t, err)
 
-	subscriptionSelector := subscription.NewSelector(testLogger, &fakeSubscriptionLister{}, lister, nil)
+	subscriptionSelector := subscription.NewSelector(testLogger, &fakeSubscriptionLister{}, lister, nil, nil)
 	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
 
 	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
@@ -1343,3 +1344,142 @@ func TestListModels_ExternalModelUsesModelRefName(t *testing.T) {
 	assert.Equal(t, fixtures.TestNamespace+"/"+maasModelRefName, response.Data[0].OwnedBy,
 		"OwnedBy should still reference the MaaSModelRef for dashboard display")
 }
+
+type fakePerformanceSource map[string]models.Performance
+
+func (f fakePerformanceSource) ModelPerformance(context.Context) map[string]models.Performance {
+	return f
+}
+
+func TestListModels_Performance(t *testing.T) {
+	testLogger := logger.Development()
+
+	lister := fakeMaaSModelRefLister{
+		fixtures.TestNamespace: []*unstructured.Unstructured{
+			maasModelRefExternalModelUnstructured("gpt-4o", fixtures.TestNamespace, "gpt-4o-external", true, nil),
+			maasModelRefExternalModelUnstructured("claude", fixtures.TestNamespace, "claude-external", true, nil),
+		},
+	}
+
+	modelMgr, err := models.NewManager(testLogger, 15, "")
+	require.NoError(t, err)
+
+	subscriptionSelector := subscription.NewSelector(testLogger, &fakeSubscriptionLister{}, lister, nil, nil)
+	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
+	p95 := int64(420)
+	modelsHandler.SetPerformanceSource(fakePerformanceSource{
+		fixtures.TestNamespace + "/gpt-4o": {Window: "15m", Requests: 200, LatencyP95Ms: &p95, ErrorRate: 0.005},
+	})
+
+	config := fixtures.TestServerConfig{Objects: []runtime.Object{}}
+	router, _ := fixtures.SetupTestServer(t, config)
+
+	_, cleanup := fixtures.StubTokenProviderAPIs(t)
+	defer cleanup()
+
+	tokenHandler := token.NewHandler(testLogger, fixtures.TestTenant)
+	v1 := router.Group("/v1")
+	v1.GET("/models", tokenHandler.ExtractUserInfo(), modelsHandler.ListLLMs)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/models", nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set(constant.HeaderUsername, "test-user@example.com")
+	req.Header.Set(constant.HeaderGroup, `["free-users"]`)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response pagination.Page[models.Model]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+
+	performance := map[string]*models.Performance{}
+	for _, m := range response.Data {
+		performance[m.ID] = m.Performance
+	}
+	require.NotNil(t, performance["gpt-4o-external"])
+	assert.Equal(t, models.Performance{Window: "15m", Requests: 200, LatencyP95Ms: &p95, ErrorRate: 0.005},
+		*performance["gpt-4o-external"])
+	assert.Nil(t, performance["claude-external"], "models without requests in the window have no performance")
+	assert.NotContains(t, w.Body.String(), `"performance":null`)
+}
+
+func TestListModels_Visibility(t *testing.T) {
+	testLogger := logger.Development()
+
+	publicServer := createMockModelServerWithSubscriptionCheck(t, "public-model", "free")
+	internalServer := createMockModelServerWithSubscriptionCheck(t, "internal-model", "free")
+	hiddenServer := createMockModelServerWithSubscriptionCheck(t, "hidden-model", "free")
+
+	withVisibility := func(u *unstructured.Unstructured, visibility string) *unstructured.Unstructured {
+		u.SetLabels(map[string]string{constant.LabelModelVisibility: visibility})
+		return u
+	}
+	// spec.visibility wins over a label the controller has not updated yet; the label
+	// alone (internal-model) is honored for CRs without spec.visibility.
+	hidden := withVisibility(maasModelRefUnstructured("hidden-model", fixtures.TestNamespace, hiddenServer.URL, true, nil), constant.VisibilityPublic)
+	_ = unstructured.SetNestedField(hidden.Object, constant.VisibilityHidden, "spec", "visibility")
+
+	lister := fakeMaaSModelRefLister{fixtures.TestNamespace: []*unstructured.Unstructured{
+		maasModelRefUnstructured("public-model", fixtures.TestNamespace, publicServer.URL, true, nil),
+		withVisibility(maasModelRefUnstructured("internal-model", fixtures.TestNamespace, internalServer.URL, true, nil), constant.VisibilityInternal),
+		hidden,
+	}}
+
+	modelMgr, err := models.NewManager(testLogger, 15, "")
+	require.NoError(t, err)
+	subscriptionSelector := subscription.NewSelector(testLogger, fakeMultiSubscriptionLister{"free": []string{"free-users"}}, nil, nil, nil)
+	modelsHandler := handlers.NewModelsHandler(testLogger, modelMgr, subscriptionSelector, lister)
+
+	router, _ := fixtures.SetupTestServer(t, fixtures.TestServerConfig{Objects: []runtime.Object{}})
+	_, cleanup := fixtures.StubTokenProviderAPIs(t)
+	defer cleanup()
+
+	tokenHandler := token.NewHandler(testLogger, fixtures.TestTenant)
+	v1 := router.Group("/v1")
+	v1.GET("/models", tokenHandler.ExtractUserInfo(), modelsHandler.ListLLMs)
+
+	tests := []struct {
+		name        string
+		auth        string
+		expectedIDs []string
+	}{
+		{
+			name:        "user token - public and internal models listed",
+			auth:        "Bearer valid-token",
+			expectedIDs: []string{"internal-model", "public-model"},
+		},
+		{
+			name:        "API key - only public models listed",
+			auth:        "Bearer sk-oai-test-key",
+			expectedIDs: []string{"public-model"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/models", nil)
+			require.NoError(t, err)
+
+			req.Header.Set("Authorization", tt.auth)
+			req.Header.Set("X-Maas-Subscription", "free")
+			req.Header.Set(constant.HeaderUsername, "test-user@example.com")
+			req.Header.Set(constant.HeaderGroup, `["free-users"]`)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response pagination.Page[models.Model]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			ids := make([]string, 0, len(response.Data))
+			for _, m := range response.Data {
+				ids = append(ids, m.ID)
+			}
+			assert.ElementsMatch(t, tt.expectedIDs, ids)
+		})
+	}
+}
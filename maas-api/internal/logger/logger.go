@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"os"
 
 	"go.uber.org/zap"
@@ -115,6 +116,31 @@ func (l *Logger) WithRequestID(requestID string) *Logger {
 	}
 }
 
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, which the
+// *Context logging methods attach to their log lines.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID of ctx, or "" if it has none.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// withContext returns the logger with the request_id of ctx attached.
+func (l *Logger) withContext(ctx context.Context) *zap.SugaredLogger {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return l.With("request_id", requestID)
+	}
+	return l.SugaredLogger
+}
+
 // Debug logs a debug-level message with optional fields.
 // Only logged when debug mode is enabled.
 func (l *Logger) Debug(msg string, fields ...any) {
@@ -145,6 +171,34 @@ func (l *Logger) Error(msg string, fields ...any) {
 	}
 }
 
+// DebugContext is Debug with the request ID of ctx attached.
+func (l *Logger) DebugContext(ctx context.Context, msg string, fields ...any) {
+	if l.level <= zapcore.DebugLevel {
+		l.withContext(ctx).Debugw(msg, fields...)
+	}
+}
+
+// InfoContext is Info with the request ID of ctx attached.
+func (l *Logger) InfoContext(ctx context.Context, msg string, fields ...any) {
+	if l.level <= zapcore.InfoLevel {
+		l.withContext(ctx).Infow(msg, fields...)
+	}
+}
+
+// WarnContext is Warn with the request ID of ctx attached.
+func (l *Logger) WarnContext(ctx context.Context, msg string, fields ...any) {
+	if l.level <= zapcore.WarnLevel {
+		l.withContext(ctx).Warnw(msg, fields...)
+	}
+}
+
+// ErrorContext is Error with the request ID of ctx attached.
+func (l *Logger) ErrorContext(ctx context.Context, msg string, fields ...any) {
+	if l.level <= zapcore.ErrorLevel {
+		l.withContext(ctx).Errorw(msg, fields...)
+	}
+}
+
 // Fatal logs a fatal-level message and exits the program.
 func (l *Logger) Fatal(msg string, fields ...any) {
 	l.Fatalw(msg, fields...)
@@ -11,6 +11,18 @@ type PrometheusRecorder struct {
 	requestsTotal   *prometheus.CounterVec
 	requestDuration *prometheus.HistogramVec
 	inFlight        *prometheus.GaugeVec
+
+	keyValidationsTotal   *prometheus.CounterVec
+	keyValidationDuration *prometheus.HistogramVec
+	probesTotal           *prometheus.CounterVec
+	probeDuration         *prometheus.HistogramVec
+	usageEventsTotal      *prometheus.CounterVec
+	securityEventsTotal   *prometheus.CounterVec
+	sloRequestsTotal      *prometheus.CounterVec
+	sloErrorsTotal        *prometheus.CounterVec
+	sloObjective          *prometheus.GaugeVec
+	tokenCountsTotal      *prometheus.CounterVec
+	countedTokensTotal    *prometheus.CounterVec
 }
 
 func NewPrometheusRecorder(reg prometheus.Registerer) (*PrometheusRecorder, error) {
@@ -33,7 +45,69 @@ func NewPrometheusRecorder(reg prometheus.Registerer) (*PrometheusRecorder, erro
 		Help: "Number of HTTP requests currently being served.",
 	}, []string{"method"})
 
-	for _, c := range []prometheus.Collector{requestsTotal, requestDuration, inFlight} {
+	keyValidationsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_key_validations_total",
+		Help: "Total number of API key validations, by outcome.",
+	}, []string{"outcome"})
+
+	keyValidationDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "maas_api_key_validation_duration_seconds",
+		Help:    "API key validation latency in seconds.",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"outcome"})
+
+	probesTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_model_probes_total",
+		Help: "Total number of model access probes, by model kind and outcome.",
+	}, []string{"kind", "outcome"})
+
+	probeDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "maas_api_model_probe_duration_seconds",
+		Help:    "Model access probe latency in seconds, retries included.",
+		Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 30},
+	}, []string{"kind", "outcome"})
+
+	usageEventsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_usage_events_total",
+		Help: "Total number of usage events, by sink and outcome (delivered, failed or dropped).",
+	}, []string{"sink", "outcome"})
+
+	securityEventsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_security_events_total",
+		Help: "Total number of security events forwarded to the SIEM, by outcome (delivered, failed or dropped).",
+	}, []string{"outcome"})
+
+	sloRequestsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_slo_requests_total",
+		Help: "Total number of requests counted against the SLO of their route, by SLI (availability or latency).",
+	}, []string{"method", "route", "sli"})
+
+	sloErrorsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_slo_errors_total",
+		Help: "Total number of requests that missed the SLO of their route: 5xx responses for availability, responses slower than the threshold for latency.",
+	}, []string{"method", "route", "sli"})
+
+	sloObjective := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "maas_api_slo_objective",
+		Help: "Target ratio of the requests of the route that must meet the SLI.",
+	}, []string{"method", "route", "sli"})
+
+	tokenCountsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_token_counter_responses_total",
+		Help: "Total number of responses seen by the token counter, by outcome (reported, counted or not_streamed).",
+	}, []string{"outcome"})
+
+	countedTokensTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "maas_api_token_counter_tokens_total",
+		Help: "Total number of tokens estimated by the token counter for streamed responses without usage, by direction (input or output).",
+	}, []string{"direction"})
+
+	for _, c := range []prometheus.Collector{
+		requestsTotal, requestDuration, inFlight,
+		keyValidationsTotal, keyValidationDuration, probesTotal, probeDuration, usageEventsTotal,
+		securityEventsTotal, sloRequestsTotal, sloErrorsTotal, sloObjective,
+		tokenCountsTotal, countedTokensTotal,
+	} {
 		if err := reg.Register(c); err != nil {
 			return nil, err
 		}
@@ -43,6 +117,18 @@ func NewPrometheusRecorder(reg prometheus.Registerer) (*PrometheusRecorder, erro
 		requestsTotal:   requestsTotal,
 		requestDuration: requestDuration,
 		inFlight:        inFlight,
+
+		keyValidationsTotal:   keyValidationsTotal,
+		keyValidationDuration: keyValidationDuration,
+		probesTotal:           probesTotal,
+		probeDuration:         probeDuration,
+		usageEventsTotal:      usageEventsTotal,
+		securityEventsTotal:   securityEventsTotal,
+		sloRequestsTotal:      sloRequestsTotal,
+		sloErrorsTotal:        sloErrorsTotal,
+		sloObjective:          sloObjective,
+		tokenCountsTotal:      tokenCountsTotal,
+		countedTokensTotal:    countedTokensTotal,
 	}, nil
 }
 
@@ -58,3 +144,51 @@ func (r *PrometheusRecorder) IncrementInFlight(method string) {
 func (r *PrometheusRecorder) DecrementInFlight(method string) {
 	r.inFlight.WithLabelValues(method).Dec()
 }
+
+func (r *PrometheusRecorder) RecordKeyValidation(outcome string, duration time.Duration) {
+	r.keyValidationsTotal.WithLabelValues(outcome).Inc()
+	r.keyValidationDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+func (r *PrometheusRecorder) RecordProbe(kind, outcome string, duration time.Duration) {
+	r.probesTotal.WithLabelValues(kind, outcome).Inc()
+	r.probeDuration.WithLabelValues(kind, outcome).Observe(duration.Seconds())
+}
+
+func (r *PrometheusRecorder) RecordUsageEvents(sink, outcome string, count int) {
+	r.usageEventsTotal.WithLabelValues(sink, outcome).Add(float64(count))
+}
+
+func (r *PrometheusRecorder) RecordSecurityEvents(outcome string, count int) {
+	r.securityEventsTotal.WithLabelValues(outcome).Add(float64(count))
+}
+
+// RecordSLO counts a request against the availability SLI and, unless it failed, the
+// latency SLI of its route. The objective gauges let the burn rate be computed from the
+// counters alone.
+func (r *PrometheusRecorder) RecordSLO(method, route string, slo SLO, failed, slow bool) {
+	r.observeSLI(method, route, SLIAvailability, slo.Availability, failed)
+	if !failed {
+		r.observeSLI(method, route, SLILatency, slo.LatencyTarget, slow)
+	}
+}
+
+func (r *PrometheusRecorder) observeSLI(method, route, sli string, objective float64, missed bool) {
+	r.sloObjective.WithLabelValues(method, route, sli).Set(objective)
+	r.sloRequestsTotal.WithLabelValues(method, route, sli).Inc()
+	if missed {
+		r.sloErrorsTotal.WithLabelValues(method, route, sli).Inc()
+	}
+}
+
+// RecordTokenCount counts a streamed response of the token counter and, when it counted
+// them, its estimated tokens.
+func (r *PrometheusRecorder) RecordTokenCount(outcome string, inputTokens, outputTokens int) {
+	r.tokenCountsTotal.WithLabelValues(outcome).Inc()
+	if inputTokens > 0 {
+		r.countedTokensTotal.WithLabelValues("input").Add(float64(inputTokens))
+	}
+	if outputTokens > 0 {
+		r.countedTokensTotal.WithLabelValues("output").Add(float64(outputTokens))
+	}
+}
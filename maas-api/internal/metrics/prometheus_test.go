@@ -80,6 +80,63 @@ func TestInFlightGauge(t *testing.T) {
 	assert.InDelta(t, float64(1), gatherMetricValue(t, reg, "maas_api_http_requests_in_flight", map[string]string{"method": "GET"}), 0)
 }
 
+func TestRecordKeyValidation(t *testing.T) {
+	r, reg := newTestRecorder(t)
+
+	r.RecordKeyValidation("valid", 2*time.Millisecond)
+	r.RecordKeyValidation("valid", 3*time.Millisecond)
+	r.RecordKeyValidation("not_found", time.Millisecond)
+
+	assert.InDelta(t, float64(2), gatherMetricValue(t, reg, "maas_api_key_validations_total", map[string]string{"outcome": "valid"}), 0)
+	assert.InDelta(t, float64(1), gatherMetricValue(t, reg, "maas_api_key_validations_total", map[string]string{"outcome": "not_found"}), 0)
+}
+
+func TestRecordProbe(t *testing.T) {
+	r, reg := newTestRecorder(t)
+
+	r.RecordProbe("llmisvc", "granted", 40*time.Millisecond)
+	r.RecordProbe("llmisvc", "denied", 20*time.Millisecond)
+	r.RecordProbe("ExternalModel", "timeout", 15*time.Second)
+
+	assert.InDelta(t, float64(1), gatherMetricValue(t, reg, "maas_api_model_probes_total", map[string]string{"kind": "llmisvc", "outcome": "granted"}), 0)
+	assert.InDelta(t, float64(1), gatherMetricValue(t, reg, "maas_api_model_probes_total", map[string]string{"kind": "ExternalModel", "outcome": "timeout"}), 0)
+}
+
+func TestRecordUsageEvents(t *testing.T) {
+	r, reg := newTestRecorder(t)
+
+	r.RecordUsageEvents("kafka", "delivered", 100)
+	r.RecordUsageEvents("kafka", "delivered", 20)
+	r.RecordUsageEvents("http", "failed", 3)
+
+	assert.InDelta(t, float64(120), gatherMetricValue(t, reg, "maas_api_usage_events_total", map[string]string{"sink": "kafka", "outcome": "delivered"}), 0)
+	assert.InDelta(t, float64(3), gatherMetricValue(t, reg, "maas_api_usage_events_total", map[string]string{"sink": "http", "outcome": "failed"}), 0)
+}
+
+func TestRecordSecurityEvents(t *testing.T) {
+	r, reg := newTestRecorder(t)
+
+	r.RecordSecurityEvents("delivered", 1)
+	r.RecordSecurityEvents("delivered", 1)
+	r.RecordSecurityEvents("dropped", 1)
+
+	assert.InDelta(t, float64(2), gatherMetricValue(t, reg, "maas_api_security_events_total", map[string]string{"outcome": "delivered"}), 0)
+	assert.InDelta(t, float64(1), gatherMetricValue(t, reg, "maas_api_security_events_total", map[string]string{"outcome": "dropped"}), 0)
+}
+
+func TestRecordTokenCount(t *testing.T) {
+	r, reg := newTestRecorder(t)
+
+	r.RecordTokenCount("counted", 12, 30)
+	r.RecordTokenCount("counted", 8, 10)
+	r.RecordTokenCount("reported", 0, 0)
+
+	assert.InDelta(t, float64(2), gatherMetricValue(t, reg, "maas_api_token_counter_responses_total", map[string]string{"outcome": "counted"}), 0)
+	assert.InDelta(t, float64(1), gatherMetricValue(t, reg, "maas_api_token_counter_responses_total", map[string]string{"outcome": "reported"}), 0)
+	assert.InDelta(t, float64(20), gatherMetricValue(t, reg, "maas_api_token_counter_tokens_total", map[string]string{"direction": "input"}), 0)
+	assert.InDelta(t, float64(40), gatherMetricValue(t, reg, "maas_api_token_counter_tokens_total", map[string]string{"direction": "output"}), 0)
+}
+
 func TestNewPrometheusRecorderNilRegistry(t *testing.T) {
 	r, err := metrics.NewPrometheusRecorder(nil)
 	assert.Nil(t, r)
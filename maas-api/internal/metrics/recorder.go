@@ -7,3 +7,29 @@ type MetricsRecorder interface {
 	IncrementInFlight(method string)
 	DecrementInFlight(method string)
 }
+
+// KeyValidationRecorder records the API key validations of the Authorino callback.
+type KeyValidationRecorder interface {
+	RecordKeyValidation(outcome string, duration time.Duration)
+}
+
+// ProbeRecorder records the model access probes of GET /v1/models.
+type ProbeRecorder interface {
+	RecordProbe(kind, outcome string, duration time.Duration)
+}
+
+// UsageEventRecorder records the usage events sent to the event sinks.
+type UsageEventRecorder interface {
+	RecordUsageEvents(sink, outcome string, count int)
+}
+
+// SecurityEventRecorder records the security events forwarded to the SIEM.
+type SecurityEventRecorder interface {
+	RecordSecurityEvents(outcome string, count int)
+}
+
+// TokenCountRecorder records the streamed responses of the token counter and the tokens
+// it estimated for those without usage.
+type TokenCountRecorder interface {
+	RecordTokenCount(outcome string, inputTokens, outputTokens int)
+}
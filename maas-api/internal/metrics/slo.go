@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SLI names of the SLO metrics.
+const (
+	SLIAvailability = "availability"
+	SLILatency      = "latency"
+)
+
+// SLO is the service level objective of a route: the ratio of its requests that must
+// not fail with a 5xx status, and the ratio of those that must be served within
+// LatencyThreshold.
+type SLO struct {
+	Availability     float64
+	LatencyThreshold time.Duration
+	LatencyTarget    float64
+}
+
+// SLORecorder records the requests of a route against its SLO.
+type SLORecorder interface {
+	RecordSLO(method, route string, slo SLO, failed, slow bool)
+}
+
+// NewSLOMiddleware records each request of a matched route against its SLO: the one of
+// "METHOD /route" in routes, else of "/route", else defaultSLO. Requests of unmatched
+// routes and of skipPaths are not recorded.
+func NewSLOMiddleware(recorder SLORecorder, defaultSLO SLO, routes map[string]SLO, skipPaths ...string) gin.HandlerFunc {
+	if recorder == nil {
+		panic("metrics.NewSLOMiddleware: nil SLORecorder")
+	}
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		duration := time.Since(start)
+
+		route := c.FullPath()
+		if route == "" || slices.Contains(skipPaths, route) {
+			return
+		}
+		method := c.Request.Method
+		slo, ok := routes[method+" "+route]
+		if !ok {
+			if slo, ok = routes[route]; !ok {
+				slo = defaultSLO
+			}
+		}
+		failed := c.Writer.Status() >= http.StatusInternalServerError
+		recorder.RecordSLO(method, route, slo, failed, !failed && duration > slo.LatencyThreshold)
+	}
+}
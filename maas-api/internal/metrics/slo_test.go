@@ -0,0 +1,67 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+)
+
+func TestSLOMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec, reg := newTestRecorder(t)
+	defaultSLO := metrics.SLO{Availability: 0.999, LatencyThreshold: time.Second, LatencyTarget: 0.99}
+	router := gin.New()
+	router.Use(metrics.NewSLOMiddleware(rec, defaultSLO, map[string]metrics.SLO{
+		"POST /internal/v1/api-keys/validate": {Availability: 0.9995, LatencyThreshold: time.Millisecond, LatencyTarget: 0.999},
+	}, "/healthz"))
+	status := http.StatusOK
+	router.GET("/v1/models", func(c *gin.Context) { c.Status(status) })
+	router.POST("/internal/v1/api-keys/validate", func(c *gin.Context) {
+		time.Sleep(5 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	router.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	serve := func(method, path string) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, nil))
+	}
+
+	serve(http.MethodGet, "/v1/models")
+	status = http.StatusServiceUnavailable
+	serve(http.MethodGet, "/v1/models")
+	status = http.StatusNotFound
+	serve(http.MethodGet, "/v1/models")
+	serve(http.MethodPost, "/internal/v1/api-keys/validate")
+	serve(http.MethodGet, "/healthz")
+	serve(http.MethodGet, "/no/such/path")
+
+	models := func(sli string) map[string]string {
+		return map[string]string{"method": "GET", "route": "/v1/models", "sli": sli}
+	}
+	assert.InDelta(t, 3, gatherMetricValue(t, reg, "maas_api_slo_requests_total", models(metrics.SLIAvailability)), 0)
+	assert.InDelta(t, 1, gatherMetricValue(t, reg, "maas_api_slo_errors_total", models(metrics.SLIAvailability)), 0, "only 5xx responses miss availability")
+	assert.InDelta(t, 2, gatherMetricValue(t, reg, "maas_api_slo_requests_total", models(metrics.SLILatency)), 0, "failed requests are not counted for latency")
+	assert.InDelta(t, 0.999, gatherMetricValue(t, reg, "maas_api_slo_objective", models(metrics.SLIAvailability)), 1e-9)
+
+	validate := map[string]string{"method": "POST", "route": "/internal/v1/api-keys/validate", "sli": metrics.SLILatency}
+	assert.InDelta(t, 1, gatherMetricValue(t, reg, "maas_api_slo_errors_total", validate), 0, "the route's threshold applies")
+	assert.InDelta(t, 0.999, gatherMetricValue(t, reg, "maas_api_slo_objective", validate), 1e-9)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "route" {
+					assert.NotContains(t, []string{"/healthz", "unmatched", ""}, l.GetValue())
+				}
+			}
+		}
+	}
+}
@@ -11,7 +11,8 @@ import (
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 )
 
-// AccessLogger is like gin.Logger() but appends a redacted sensitive-header summary.
+// AccessLogger is like gin.Logger() but appends the request ID and a redacted
+// sensitive-header summary.
 func AccessLogger() gin.HandlerFunc {
 	return gin.LoggerWithConfig(gin.LoggerConfig{
 		Formatter: accessLogFormatter,
@@ -30,13 +31,19 @@ func accessLogFormatter(param gin.LogFormatterParams) string {
 		param.Latency = param.Latency.Truncate(time.Second)
 	}
 
-	line := fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+	var requestID string
+	if id, ok := param.Keys[RequestIDKey].(string); ok && id != "" {
+		requestID = " | request_id=" + id
+	}
+
+	line := fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v%s\n%s",
 		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
 		statusColor, param.StatusCode, resetColor,
 		param.Latency,
 		param.ClientIP,
 		methodColor, param.Method, resetColor,
 		param.Path,
+		requestID,
 		param.ErrorMessage,
 	)
 
@@ -1,10 +1,16 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 )
 
 const (
@@ -34,6 +40,10 @@ func isValidRequestID(id string) bool {
 // If the request has a valid X-Request-ID header (e.g., from the gateway),
 // it uses that value. Otherwise, it generates a new UUID.
 // Client-supplied values are validated to prevent log injection attacks.
+//
+// The ID is also stored in the request's context.Context, where the logger's *Context
+// methods and outgoing requests such as the model access probes pick it up, and is added
+// as "requestId" to JSON object bodies of error responses.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Check if request ID already exists (from gateway or client)
@@ -51,11 +61,84 @@ func RequestID() gin.HandlerFunc {
 		// Store in context for handlers
 		c.Set(RequestIDKey, requestID)
 
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
+
 		// Add to response headers for client correlation
 		c.Header(RequestIDHeader, requestID)
 
+		w := &errorBodyWriter{ResponseWriter: c.Writer}
+		c.Writer = w
 		c.Next()
+		c.Writer = w.ResponseWriter
+		if w.body.Len() > 0 {
+			w.ResponseWriter.Header().Del("Content-Length")
+			_, _ = w.ResponseWriter.Write(withRequestID(w.body.Bytes(), requestID))
+		}
+	}
+}
+
+// errorBodyWriter holds back the body of error responses so that the request ID can be
+// added once the handler is done. Event streams are written as they come, whatever their
+// status.
+type errorBodyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *errorBodyWriter) holdsBack() bool {
+	return w.Status() >= http.StatusBadRequest &&
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream")
+}
+
+func (w *errorBodyWriter) Write(b []byte) (int, error) {
+	if w.holdsBack() {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush does not send the headers of a held back body, whose Content-Length changes.
+func (w *errorBodyWriter) Flush() {
+	if w.holdsBack() {
+		return
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. to extend the write
+// deadline of streamed responses.
+func (w *errorBodyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *errorBodyWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// withRequestID adds a "requestId" field to a JSON object body that does not have one.
+// Other bodies are returned unchanged.
+func withRequestID(body []byte, requestID string) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return body
+	}
+	if _, exists := fields["requestId"]; exists {
+		return body
+	}
+	value, err := json.Marshal(requestID)
+	if err != nil {
+		return body
+	}
+	trimmed := bytes.TrimRight(body, " \t\r\n")
+	closing := len(trimmed) - 1
+	field := append([]byte(`"requestId":`), value...)
+	if len(fields) > 0 {
+		field = append([]byte(","), field...)
 	}
+	out := make([]byte, 0, len(body)+len(field))
+	out = append(out, trimmed[:closing]...)
+	out = append(out, field...)
+	return append(out, body[closing:]...)
 }
 
 // GetRequestID retrieves the request ID from the gin context.
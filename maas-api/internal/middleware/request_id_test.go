@@ -1,14 +1,20 @@
 package middleware_test
 
 import (
+	"bufio"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 )
 
@@ -194,3 +200,113 @@ func TestGetRequestID_ReturnsEmptyWhenWrongType(t *testing.T) {
 	requestID := middleware.GetRequestID(c)
 	assert.Empty(t, requestID, "Should return empty string for wrong type")
 }
+
+func TestRequestID_SetsRequestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID())
+
+	var fromContext string
+	router.GET("/test", func(c *gin.Context) {
+		fromContext = logger.RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-ID", "client-request-id-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "client-request-id-123", fromContext)
+}
+
+func TestRequestID_AddsRequestIDToErrorBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "healthy"}) })
+	router.GET("/error", func(c *gin.Context) { c.JSON(http.StatusForbidden, gin.H{"error": "denied"}) })
+	router.GET("/nested", func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": gin.H{"message": "failed", "type": "server_error"}})
+	})
+	router.GET("/empty", func(c *gin.Context) { c.JSON(http.StatusBadRequest, gin.H{}) })
+	router.GET("/has-id", func(c *gin.Context) { c.JSON(http.StatusBadRequest, gin.H{"requestId": "own"}) })
+	router.GET("/text", func(c *gin.Context) { c.String(http.StatusBadGateway, "upstream failed") })
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/ok", `{"status":"healthy"}`},
+		{"/error", `{"error":"denied","requestId":"req-1"}`},
+		{"/nested", `{"error":{"message":"failed","type":"server_error"},"requestId":"req-1"}`},
+		{"/empty", `{"requestId":"req-1"}`},
+		{"/has-id", `{"requestId":"own"}`},
+		{"/text", "upstream failed"},
+		{"/missing", "404 page not found"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("X-Request-ID", "req-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tt.want, w.Body.String(), tt.path)
+	}
+}
+
+func TestRequestID_StreamsResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.GET("/flushed-error", func(c *gin.Context) {
+		body := `{"error":"denied"}`
+		c.Header("Content-Type", "application/json")
+		c.Header("Content-Length", strconv.Itoa(len(body)))
+		c.Status(http.StatusForbidden)
+		_, _ = c.Writer.WriteString(body)
+		c.Writer.Flush()
+	})
+	released := make(chan struct{})
+	router.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusTooManyRequests)
+		_, _ = c.Writer.WriteString("data: first\n\n")
+		c.Writer.Flush()
+		<-released
+	})
+	router.GET("/deadline", func(c *gin.Context) {
+		if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(time.Minute)); err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	get := func(path string) *http.Response {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Request-ID", "req-1")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	resp := get("/flushed-error")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"error":"denied","requestId":"req-1"}`, string(body))
+
+	// The event is received while the handler still runs.
+	resp = get("/events")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	close(released)
+	require.NoError(t, err)
+	assert.Equal(t, "data: first\n", line)
+
+	resp = get("/deadline")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
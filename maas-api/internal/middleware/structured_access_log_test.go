@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+func observedLogger() (*logger.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	return &logger.Logger{SugaredLogger: zap.New(core).Sugar()}, logs
+}
+
+func TestStructuredAccessLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, logs := observedLogger()
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.StructuredAccessLogger(log, "/health"))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/v1/api-keys/:id", func(c *gin.Context) {
+		c.Set("user", &token.UserContext{Username: "alice"})
+		middleware.Attribute(c, middleware.Attribution{KeyID: c.Param("id")})
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+	})
+	router.POST("/internal/v1/api-keys/validate", func(c *gin.Context) {
+		middleware.Attribute(c, middleware.Attribution{User: "bob", KeyID: "key-2"})
+		middleware.Attribute(c, middleware.Attribution{Subscription: "premium", Model: "llm/granite"})
+		c.Status(http.StatusOK)
+	})
+
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/health", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/api-keys/key-1?verbose=true", nil),
+		httptest.NewRequest(http.MethodPost, "/internal/v1/api-keys/validate", nil),
+	} {
+		r.Header.Set("X-Request-ID", "req-1")
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	entries := logs.FilterMessage("access").All()
+	require.Len(t, entries, 2, "health checks are not logged")
+
+	first := entries[0].ContextMap()
+	assert.Equal(t, "GET", first["method"])
+	assert.Equal(t, "/v1/api-keys/key-1", first["path"], "query strings are not logged")
+	assert.Equal(t, "/v1/api-keys/:id", first["route"])
+	assert.EqualValues(t, http.StatusNotFound, first["status"])
+	assert.Equal(t, "alice", first["user"])
+	assert.Equal(t, "key-1", first["key_id"])
+	assert.Equal(t, "req-1", first["request_id"])
+	assert.Contains(t, first, "latency_ms")
+	assert.NotContains(t, first, "subscription")
+
+	second := entries[1].ContextMap()
+	assert.Equal(t, "bob", second["user"])
+	assert.Equal(t, "key-2", second["key_id"])
+	assert.Equal(t, "premium", second["subscription"])
+	assert.Equal(t, "llm/granite", second["model"])
+}
@@ -16,11 +16,15 @@ import (
 	"time"
 
 	"github.com/openai/openai-go/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/pkg/apis"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tracing"
 )
 
 type authResult int
@@ -56,6 +60,14 @@ type Manager struct {
 	httpClient          *http.Client
 	accessCheckTimeout  time.Duration
 	gatewayInternalHost string
+
+	// probeMetrics, when set, records the latency and outcome of the access probes.
+	probeMetrics metrics.ProbeRecorder
+}
+
+// SetProbeMetrics sets the recorder of the access probes.
+func (m *Manager) SetProbeMetrics(recorder metrics.ProbeRecorder) {
+	m.probeMetrics = recorder
 }
 
 // NewManager creates a Manager for filtering models by access.
@@ -83,13 +95,29 @@ func NewManager(log *logger.Logger, accessCheckTimeoutSeconds int, gatewayIntern
 	// No per-client Timeout — each request inherits the accessCheckTimeout
 	// deadline via its context. This ensures that configuring a longer
 	// ACCESS_CHECK_TIMEOUT_SECONDS actually allows slower backends to respond.
+	transport := NewGatewayTransport(tlsConfig, gatewayInternalHost)
+	transport.MaxIdleConnsPerHost = maxDiscoveryConcurrency
+
+	return &Manager{
+		logger:              log,
+		accessCheckTimeout:  timeout,
+		gatewayInternalHost: gatewayInternalHost,
+		httpClient: &http.Client{
+			// Probes carry the trace context to the model backends.
+			Transport: tracing.Transport(transport),
+		},
+	}, nil
+}
+
+// NewGatewayTransport returns a transport for the model endpoints. When
+// gatewayInternalHost is non-empty, TCP connections go to this cluster-internal address
+// while the URL hostname is kept for TLS SNI and the Host header.
+func NewGatewayTransport(tlsConfig *tls.Config, gatewayInternalHost string) *http.Transport {
 	transport := &http.Transport{
-		TLSClientConfig:     tlsConfig,
-		MaxIdleConns:        httpMaxIdleConns,
-		MaxIdleConnsPerHost: maxDiscoveryConcurrency,
-		IdleConnTimeout:     httpIdleConnTimeout,
+		TLSClientConfig: tlsConfig,
+		MaxIdleConns:    httpMaxIdleConns,
+		IdleConnTimeout: httpIdleConnTimeout,
 	}
-
 	if gatewayInternalHost != "" {
 		dialer := &net.Dialer{}
 		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
@@ -100,15 +128,7 @@ func NewManager(log *logger.Logger, accessCheckTimeoutSeconds int, gatewayIntern
 			return dialer.DialContext(ctx, network, net.JoinHostPort(gatewayInternalHost, port))
 		}
 	}
-
-	return &Manager{
-		logger:              log,
-		accessCheckTimeout:  timeout,
-		gatewayInternalHost: gatewayInternalHost,
-		httpClient: &http.Client{
-			Transport: transport,
-		},
-	}, nil
+	return transport
 }
 
 // BuildClusterTLSConfig creates a TLS config for cluster-internal communication using
@@ -175,11 +195,14 @@ func (m *Manager) FilterModelsByAccess(ctx context.Context, models []Model, auth
 		return models
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "models.FilterModelsByAccess", trace.WithAttributes(attribute.Int("maas.models.count", len(models))))
+	defer span.End()
+
 	// Bound the total access-check duration to limit the staleness window.
 	ctx, cancel := context.WithTimeout(ctx, m.accessCheckTimeout)
 	defer cancel()
 
-	m.logger.Debug("FilterModelsByAccess: validating access for models", "count", len(models), "subscriptionHeaderProvided", subscriptionHeader != "")
+	m.logger.DebugContext(ctx, "FilterModelsByAccess: validating access for models", "count", len(models), "subscriptionHeaderProvided", subscriptionHeader != "")
 	// Initialize to empty slice (not nil) so JSON marshals as [] instead of null when no models are accessible
 	out := []Model{}
 	var mu sync.Mutex
@@ -193,22 +216,22 @@ func (m *Manager) FilterModelsByAccess(ctx context.Context, models []Model, auth
 		// gateway auth policy at inference time.
 		if model.Kind == "ExternalModel" {
 			if model.Ready {
-				m.logger.Debug("FilterModelsByAccess: including external model (no probe)", "id", model.ID)
+				m.logger.DebugContext(ctx, "FilterModelsByAccess: including external model (no probe)", "id", model.ID)
 				mu.Lock()
 				out = append(out, model)
 				mu.Unlock()
 			} else {
-				m.logger.Debug("FilterModelsByAccess: skipping external model (not ready)", "id", model.ID)
+				m.logger.DebugContext(ctx, "FilterModelsByAccess: skipping external model (not ready)", "id", model.ID)
 			}
 			continue
 		}
 		if model.URL == nil {
-			m.logger.Debug("FilterModelsByAccess: skipping model with no URL", "id", model.ID)
+			m.logger.DebugContext(ctx, "FilterModelsByAccess: skipping model with no URL", "id", model.ID)
 			continue
 		}
 		modelsEndpoint, err := url.JoinPath(model.URL.String(), "v1", "models")
 		if err != nil {
-			m.logger.Debug("FilterModelsByAccess: failed to build endpoint", "id", model.ID, "error", err)
+			m.logger.DebugContext(ctx, "FilterModelsByAccess: failed to build endpoint", "id", model.ID, "error", err)
 			continue
 		}
 		kind := model.Kind
@@ -233,16 +256,17 @@ func (m *Manager) FilterModelsByAccess(ctx context.Context, models []Model, auth
 				out = append(out, converted...)
 				mu.Unlock()
 				for _, c := range converted {
-					m.logger.Debug("FilterModelsByAccess: access granted", "model", c.ID, "endpoint", modelsEndpoint)
+					m.logger.DebugContext(ctx, "FilterModelsByAccess: access granted", "model", c.ID, "endpoint", modelsEndpoint)
 				}
 			} else {
-				m.logger.Debug("FilterModelsByAccess: access denied or unreachable", "model", model.ID, "endpoint", modelsEndpoint)
+				m.logger.DebugContext(ctx, "FilterModelsByAccess: access denied or unreachable", "model", model.ID, "endpoint", modelsEndpoint)
 			}
 			return nil
 		})
 	}
 	_ = g.Wait()
-	m.logger.Debug("FilterModelsByAccess: complete", "input", len(models), "accessible", len(out))
+	span.SetAttributes(attribute.Int("maas.models.accessible", len(out)))
+	m.logger.DebugContext(ctx, "FilterModelsByAccess: complete", "input", len(models), "accessible", len(out))
 	return out
 }
 
@@ -304,7 +328,19 @@ type modelMetadata struct {
 }
 
 func (m *Manager) fetchModelsWithRetry(ctx context.Context, authHeader string, subscriptionHeader string, meta modelMetadata) []openai.Model {
-	m.logger.Debug("Validating access: probing model endpoint",
+	ctx, span := tracing.Tracer().Start(ctx, "models.probe", trace.WithAttributes(
+		attribute.String("maas.model.id", meta.ServiceName),
+		attribute.String("maas.model.kind", meta.Kind),
+	))
+	defer span.End()
+
+	outcome := "error"
+	if m.probeMetrics != nil {
+		start := time.Now()
+		defer func() { m.probeMetrics.RecordProbe(meta.Kind, outcome, time.Since(start)) }()
+	}
+
+	m.logger.DebugContext(ctx, "Validating access: probing model endpoint",
 		"service", meta.ServiceName,
 		"endpoint", meta.Endpoint,
 		"kind", meta.Kind,
@@ -331,25 +367,29 @@ func (m *Manager) fetchModelsWithRetry(ctx context.Context, authHeader string, s
 		return lastResult != authRetry, nil
 	}); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded {
-			m.logger.Debug("Access validation failed: context deadline exceeded", "service", meta.ServiceName, "endpoint", meta.Endpoint, "timeout", m.accessCheckTimeout)
+			outcome = "timeout"
+			m.logger.DebugContext(ctx, "Access validation failed: context deadline exceeded", "service", meta.ServiceName, "endpoint", meta.Endpoint, "timeout", m.accessCheckTimeout)
 		} else {
-			m.logger.Debug("Access validation failed: model fetch backoff exhausted", "service", meta.ServiceName, "endpoint", meta.Endpoint, "error", err)
+			m.logger.DebugContext(ctx, "Access validation failed: model fetch backoff exhausted", "service", meta.ServiceName, "endpoint", meta.Endpoint, "error", err)
 		}
 		return nil // explicit fail-closed on error
 	}
 
+	span.SetAttributes(attribute.Bool("maas.model.access_granted", lastResult == authGranted))
 	if lastResult != authGranted {
-		m.logger.Debug("Access validation denied for model", "service", meta.ServiceName, "endpoint", meta.Endpoint)
+		outcome = "denied"
+		m.logger.DebugContext(ctx, "Access validation denied for model", "service", meta.ServiceName, "endpoint", meta.Endpoint)
 		return nil
 	}
-	m.logger.Debug("Access validation granted for model", "service", meta.ServiceName, "endpoint", meta.Endpoint)
+	outcome = "granted"
+	m.logger.DebugContext(ctx, "Access validation granted for model", "service", meta.ServiceName, "endpoint", meta.Endpoint)
 	return result
 }
 
 func (m *Manager) fetchModels(ctx context.Context, authHeader string, subscriptionHeader string, meta modelMetadata) ([]openai.Model, authResult) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.Endpoint, nil)
 	if err != nil {
-		m.logger.Debug("Access validation: failed to create GET request", "service", meta.ServiceName, "endpoint", meta.Endpoint, "error", err)
+		m.logger.DebugContext(ctx, "Access validation: failed to create GET request", "service", meta.ServiceName, "endpoint", meta.Endpoint, "error", err)
 		return nil, authRetry
 	}
 
@@ -357,20 +397,23 @@ func (m *Manager) fetchModels(ctx context.Context, authHeader string, subscripti
 	if subscriptionHeader != "" {
 		req.Header.Set("X-Maas-Subscription", subscriptionHeader)
 	}
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set("X-Request-ID", requestID)
+	}
 
 	// #nosec G704 -- Intentional HTTP request to probe model endpoint for authorization check
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded {
-			m.logger.Debug("Access validation: request timed out (context deadline exceeded)", "service", meta.ServiceName, "endpoint", meta.Endpoint)
+			m.logger.DebugContext(ctx, "Access validation: request timed out (context deadline exceeded)", "service", meta.ServiceName, "endpoint", meta.Endpoint)
 			return nil, authDenied // fail-closed, no point retrying a deadline
 		}
-		m.logger.Debug("Access validation: GET request failed", "service", meta.ServiceName, "endpoint", meta.Endpoint, "error", err)
+		m.logger.DebugContext(ctx, "Access validation: GET request failed", "service", meta.ServiceName, "endpoint", meta.Endpoint, "error", err)
 		return nil, authRetry
 	}
 	defer resp.Body.Close()
 
-	m.logger.Debug("Access validation: model endpoint response",
+	m.logger.DebugContext(ctx, "Access validation: model endpoint response",
 		"service", meta.ServiceName,
 		"endpoint", meta.Endpoint,
 		"statusCode", resp.StatusCode,
@@ -380,7 +423,7 @@ func (m *Manager) fetchModels(ctx context.Context, authHeader string, subscripti
 	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
 		if len(body) > 0 {
-			m.logger.Debug("Access validation: auth failure response body", "service", meta.ServiceName, "endpoint", meta.Endpoint, "bodyPreview", string(body))
+			m.logger.DebugContext(ctx, "Access validation: auth failure response body", "service", meta.ServiceName, "endpoint", meta.Endpoint, "bodyPreview", string(body))
 		}
 	}
 
@@ -388,19 +431,19 @@ func (m *Manager) fetchModels(ctx context.Context, authHeader string, subscripti
 	case resp.StatusCode >= 200 && resp.StatusCode < 300:
 		models, parseErr := m.parseModelsResponse(resp.Body, meta)
 		if parseErr != nil {
-			m.logger.Debug("Failed to parse models response", "service", meta.ServiceName, "error", parseErr)
+			m.logger.DebugContext(ctx, "Failed to parse models response", "service", meta.ServiceName, "error", parseErr)
 			return nil, authRetry
 		}
 		return models, authGranted
 
 	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
-		m.logger.Debug("Access validation: endpoint returned auth failure", "service", meta.ServiceName, "endpoint", meta.Endpoint, "statusCode", resp.StatusCode)
+		m.logger.DebugContext(ctx, "Access validation: endpoint returned auth failure", "service", meta.ServiceName, "endpoint", meta.Endpoint, "statusCode", resp.StatusCode)
 		return nil, authDenied
 
 	case resp.StatusCode == http.StatusNotFound:
 		// 404 means we cannot verify authorization - deny access (fail-closed)
 		// See: https://issues.redhat.com/browse/RHOAIENG-45883
-		m.logger.Debug("Access validation: endpoint returned 404, denying access (cannot verify authorization)", "service", meta.ServiceName, "endpoint", meta.Endpoint)
+		m.logger.DebugContext(ctx, "Access validation: endpoint returned 404, denying access (cannot verify authorization)", "service", meta.ServiceName, "endpoint", meta.Endpoint)
 		return nil, authDenied
 
 	case resp.StatusCode == http.StatusMethodNotAllowed:
@@ -408,7 +451,7 @@ func (m *Manager) fetchModels(ctx context.Context, authHeader string, subscripti
 		// proving it passed AuthorizationPolicies (which would return 401/403).
 		// The 405 indicates the HTTP method isn't enabled on this route/endpoint,
 		// not an authorization failure.
-		m.logger.Debug("Model endpoint returned 405 - auth succeeded, using model name as fallback ID",
+		m.logger.DebugContext(ctx, "Model endpoint returned 405 - auth succeeded, using model name as fallback ID",
 			"service", meta.ServiceName,
 			"modelName", meta.ModelName,
 			"endpoint", meta.Endpoint,
@@ -420,7 +463,7 @@ func (m *Manager) fetchModels(ctx context.Context, authHeader string, subscripti
 
 	default:
 		// Retry on server errors (5xx) or other unexpected codes
-		m.logger.Debug("Access validation: unexpected status code, will retry",
+		m.logger.DebugContext(ctx, "Access validation: unexpected status code, will retry",
 			"service", meta.ServiceName,
 			"endpoint", meta.Endpoint,
 			"statusCode", resp.StatusCode,
@@ -8,13 +8,17 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"knative.dev/pkg/apis"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
@@ -37,6 +41,47 @@ func TestNewManager(t *testing.T) {
 	})
 }
 
+type probeRecorder struct {
+	mu       sync.Mutex
+	outcomes map[string]string
+}
+
+func (r *probeRecorder) RecordProbe(kind, outcome string, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outcomes[kind] = outcome
+}
+
+func TestFilterModelsByAccessRecordsProbes(t *testing.T) {
+	granted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"granite","object":"model"}]}`))
+	}))
+	defer granted.Close()
+	denied := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer denied.Close()
+
+	manager, err := models.NewManager(logger.Development(), 15, "")
+	require.NoError(t, err)
+	recorder := &probeRecorder{outcomes: map[string]string{}}
+	manager.SetProbeMetrics(recorder)
+
+	mustURL := func(raw string) *apis.URL {
+		u, err := apis.ParseURL(raw)
+		require.NoError(t, err)
+		return u
+	}
+	out := manager.FilterModelsByAccess(t.Context(), []models.Model{
+		{Kind: "llmisvc", URL: mustURL(granted.URL), Ready: true},
+		{Kind: "InferenceService", URL: mustURL(denied.URL), Ready: true},
+	}, "Bearer token", "")
+
+	require.Len(t, out, 1)
+	assert.Equal(t, map[string]string{"llmisvc": "granted", "InferenceService": "denied"}, recorder.outcomes)
+}
+
 func TestBuildClusterTLSConfig(t *testing.T) {
 	t.Run("returns error when logger is nil", func(t *testing.T) {
 		tlsConfig, err := models.BuildClusterTLSConfig(nil)
@@ -148,3 +193,24 @@ func selfSignedCertPEM(t *testing.T) []byte {
 
 	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
 }
+
+func TestFilterModelsByAccessPropagatesRequestID(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Request-ID")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"granite","object":"model"}]}`))
+	}))
+	defer server.Close()
+
+	manager, err := models.NewManager(logger.Development(), 15, "")
+	require.NoError(t, err)
+	u, err := apis.ParseURL(server.URL)
+	require.NoError(t, err)
+
+	ctx := logger.ContextWithRequestID(t.Context(), "req-123")
+	out := manager.FilterModelsByAccess(ctx, []models.Model{{Kind: "llmisvc", URL: u, Ready: true}}, "Bearer token", "")
+
+	require.Len(t, out, 1)
+	assert.Equal(t, "req-123", got)
+}
@@ -93,6 +93,15 @@ func maasModelRefToModel(u *unstructured.Unstructured) *Model {
 		}
 	}
 
+	visibility, _, _ := unstructured.NestedString(u.Object, "spec", "visibility")
+	if visibility == "" {
+		// Fall back to the label for CRs created before spec.visibility existed.
+		visibility = u.GetLabels()[constant.LabelModelVisibility]
+	}
+	if visibility == "" {
+		visibility = constant.VisibilityPublic
+	}
+
 	var urlPtr *apis.URL
 	if endpoint != "" {
 		parsed, err := url.Parse(endpoint)
@@ -116,9 +125,28 @@ func maasModelRefToModel(u *unstructured.Unstructured) *Model {
 			Created: created,
 			OwnedBy: ownedBy,
 		},
-		Kind:    kind,
-		URL:     urlPtr,
-		Ready:   ready,
-		Details: details,
+		Kind:       kind,
+		URL:        urlPtr,
+		Ready:      ready,
+		Details:    details,
+		Visibility: visibility,
+	}
+}
+
+// FilterByVisibility drops models the caller should not see in the catalog: hidden models
+// are never listed, and internal models are only listed for user-token (non API key) requests.
+func FilterByVisibility(list []Model, isAPIKeyRequest bool) []Model {
+	out := make([]Model, 0, len(list))
+	for _, m := range list {
+		switch m.Visibility {
+		case constant.VisibilityHidden:
+			continue
+		case constant.VisibilityInternal:
+			if isAPIKeyRequest {
+				continue
+			}
+		}
+		out = append(out, m)
 	}
+	return out
 }
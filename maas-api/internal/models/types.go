@@ -26,6 +26,20 @@ type SubscriptionInfo struct {
 	Description string `json:"description,omitempty"`
 }
 
+// Performance is the recent latency and error rate of a model, from the gateway access
+// logs, so that clients can fall back to another model.
+type Performance struct {
+	// Window is the rolling window the performance covers, e.g. "15m".
+	Window string `json:"window"`
+	// Requests is the number of requests of the window: served, or failed with a 5xx status.
+	Requests int64 `json:"requests"`
+	// LatencyP95Ms is the 95th percentile latency of the requests that did not fail, in
+	// milliseconds. It is unset when all of them failed.
+	LatencyP95Ms *int64 `json:"latencyP95Ms,omitempty"`
+	// ErrorRate is the ratio of the requests that failed with a 5xx status.
+	ErrorRate float64 `json:"errorRate"`
+}
+
 // Model extends openai.Model with additional fields.
 //
 // The ID field contains the canonical model identifier, which is used for metrics,
@@ -43,6 +57,13 @@ type Model struct {
 	Details       *Details           `json:"modelDetails,omitempty"`
 	Aliases       []string           `json:"aliases,omitempty"`
 	Subscriptions []SubscriptionInfo `json:"subscriptions,omitempty"` // Subscriptions providing access to this model
+	// Performance is the recent latency and error rate of the model, when the gateway
+	// access logs are ingested and the model served requests.
+	Performance *Performance `json:"performance,omitempty"`
+
+	// Visibility is the catalog visibility declared on the MaaSModelRef (public, internal, or hidden).
+	// Used only for filtering GET /v1/models; not serialized.
+	Visibility string `json:"-"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshalling to work around openai.Model's
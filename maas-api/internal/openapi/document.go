@@ -0,0 +1,284 @@
+// Package openapi generates the OpenAPI 3.1 document of maas-api from annotated gin
+// routes. The schemas of the request and response bodies are derived from their Go
+// types, so the document follows the handlers as they change.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.1.0"
+
+// Info is the info object of the document.
+type Info struct {
+	Title       string
+	Description string
+	Version     string
+}
+
+// Parameter is a query or header parameter of a route. Path parameters are derived from
+// the route path.
+type Parameter struct {
+	Name string
+	// In is "query" (the default) or "header".
+	In          string
+	Description string
+	// Type is the JSON Schema type of the value, "string" by default.
+	Type     string
+	Format   string
+	Enum     []string
+	Required bool
+}
+
+// Route annotates a gin route.
+type Route struct {
+	// OperationID names the operation in generated clients.
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Parameters  []Parameter
+	// Request is a value of the request body type, nil for none.
+	Request any
+	// RequestContentType is the media type of the request body, application/json by
+	// default.
+	RequestContentType string
+	// Responses are values of the JSON response body types by status code. A nil value
+	// documents a response without body.
+	Responses map[int]any
+	// Public routes are not authenticated with a bearer token.
+	Public bool
+	// MaxRequestBytes bounds the request body, in place of the limit of ValidateRequests.
+	MaxRequestBytes int64
+	// RequestError writes the responses of the requests ValidateRequests rejects,
+	// {"error": message} by default.
+	RequestError ErrorWriter
+}
+
+// Document is the OpenAPI document of the routes handled through it.
+type Document struct {
+	info Info
+
+	mu       sync.Mutex
+	registry *schemaRegistry
+	paths    map[string]map[string]any
+	// requests are the rules of ValidateRequests by "METHOD /gin/path".
+	requests map[string]requestRule
+}
+
+// New returns an empty document.
+func New(info Info) *Document {
+	return &Document{
+		info:     info,
+		registry: newSchemaRegistry(),
+		paths:    map[string]map[string]any{},
+		requests: map[string]requestRule{},
+	}
+}
+
+// Handle registers the handlers of a route on the router group and documents it.
+func (d *Document) Handle(group *gin.RouterGroup, method, path string, route Route, handlers ...gin.HandlerFunc) {
+	group.Handle(method, path, handlers...)
+	d.Add(method, joinPaths(group.BasePath(), path), route)
+}
+
+// Add documents a route with the gin path, e.g. /v1/api-keys/:id.
+func (d *Document) Add(method, path string, route Route) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	openAPIPath, pathParameters := convertPath(path)
+	operation := map[string]any{
+		"operationId": route.OperationID,
+		"responses":   d.responses(route.Responses),
+	}
+	if route.Summary != "" {
+		operation["summary"] = route.Summary
+	}
+	if route.Description != "" {
+		operation["description"] = route.Description
+	}
+	if len(route.Tags) > 0 {
+		operation["tags"] = route.Tags
+	}
+	if route.Public {
+		operation["security"] = []any{}
+	}
+
+	parameters := make([]any, 0, len(pathParameters)+len(route.Parameters))
+	for _, name := range pathParameters {
+		parameters = append(parameters, map[string]any{
+			"name": name, "in": "path", "required": true, "schema": Schema{"type": "string"},
+		})
+	}
+	for _, p := range route.Parameters {
+		parameters = append(parameters, parameterObject(p))
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	rule := requestRule{maxBytes: route.MaxRequestBytes, writeError: route.RequestError}
+	if rule.writeError == nil {
+		rule.writeError = writeError
+	}
+	if route.Request != nil {
+		contentType := route.RequestContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		if contentType == "application/json" {
+			rule.bodyType = reflect.TypeOf(route.Request)
+		}
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				contentType: map[string]any{"schema": d.registry.schemaOf(reflect.TypeOf(route.Request))},
+			},
+		}
+	}
+
+	d.requests[method+" "+path] = rule
+
+	if d.paths[openAPIPath] == nil {
+		d.paths[openAPIPath] = map[string]any{}
+	}
+	d.paths[openAPIPath][strings.ToLower(method)] = operation
+}
+
+func (d *Document) responses(bodies map[int]any) map[string]any {
+	responses := map[string]any{
+		"default": map[string]any{
+			"description": "Error",
+			"content":     jsonContent(Schema{"$ref": "#/components/schemas/Error"}),
+		},
+	}
+	for status, body := range bodies {
+		response := map[string]any{"description": http.StatusText(status)}
+		if body != nil {
+			response["content"] = jsonContent(d.registry.schemaOf(reflect.TypeOf(body)))
+		}
+		responses[strconv.Itoa(status)] = response
+	}
+	return responses
+}
+
+// MarshalJSON renders the document.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	schemas := map[string]any{
+		"Error": Schema{
+			"type":       "object",
+			"properties": Schema{"error": Schema{"type": "string"}},
+			"required":   []string{"error"},
+		},
+	}
+	for name, schema := range d.registry.schemas {
+		schemas[name] = schema
+	}
+	info := map[string]any{"title": d.info.Title, "version": d.info.Version}
+	if d.info.Description != "" {
+		info["description"] = d.info.Description
+	}
+	return json.Marshal(map[string]any{
+		"openapi": Version,
+		"info":    info,
+		// Relative to the document, so that it applies behind the /maas-api prefix of
+		// the gateway as well as in the cluster.
+		"servers":  []any{map[string]any{"url": "."}},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+		"paths":    d.paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "An API key (sk-oai-...) or an OpenShift token",
+				},
+			},
+		},
+	})
+}
+
+// Paths returns the documented paths, sorted.
+func (d *Document) Paths() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	paths := make([]string, 0, len(d.paths))
+	for path := range d.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ServeJSON serves the document.
+func (d *Document) ServeJSON(c *gin.Context) {
+	body, err := d.MarshalJSON()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render the OpenAPI document"})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func parameterObject(p Parameter) map[string]any {
+	in := p.In
+	if in == "" {
+		in = "query"
+	}
+	schema := Schema{"type": "string"}
+	if p.Type != "" {
+		schema["type"] = p.Type
+	}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+	parameter := map[string]any{"name": p.Name, "in": in, "schema": schema}
+	if p.Description != "" {
+		parameter["description"] = p.Description
+	}
+	if p.Required {
+		parameter["required"] = true
+	}
+	return parameter
+}
+
+func jsonContent(schema Schema) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// convertPath turns a gin path into an OpenAPI path and returns its parameters, e.g.
+// /v1/api-keys/:id into /v1/api-keys/{id}.
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var parameters []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			parameters = append(parameters, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), parameters
+}
+
+func joinPaths(base, path string) string {
+	if path == "" {
+		return base
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,189 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/openapi"
+)
+
+type page[T any] struct {
+	Data []T `json:"data"`
+}
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type item struct {
+	base
+
+	Name      string            `json:"name"`
+	Count     int64             `json:"count,omitempty"`
+	Created   time.Time         `json:"created"`
+	Expires   *time.Time        `json:"expires,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Parent    *item             `json:"parent,omitempty"`
+	Secret    string            `json:"-"`
+	Untagged  bool
+	unexposed string
+}
+
+type createRequest struct {
+	Name string `json:"name"`
+}
+
+func render(t *testing.T, doc *openapi.Document) map[string]any {
+	t.Helper()
+	body, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var rendered map[string]any
+	require.NoError(t, json.Unmarshal(body, &rendered))
+	return rendered
+}
+
+func lookup(t *testing.T, v any, keys ...string) any {
+	t.Helper()
+	for _, key := range keys {
+		m, ok := v.(map[string]any)
+		require.True(t, ok, "%q is not an object", key)
+		v, ok = m[key]
+		require.True(t, ok, "%q not found", key)
+	}
+	return v
+}
+
+func newDocument(t *testing.T) (*openapi.Document, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	doc := openapi.New(openapi.Info{Title: "Test API", Version: "1.0"})
+	items := router.Group("/v1/items")
+	doc.Handle(items, http.MethodGet, "", openapi.Route{
+		OperationID: "listItems",
+		Tags:        []string{"items"},
+		Parameters:  []openapi.Parameter{{Name: "limit", Type: "integer"}, {Name: "sort", Enum: []string{"asc", "desc"}}},
+		Responses:   map[int]any{http.StatusOK: page[item]{}},
+	}, func(c *gin.Context) { c.Status(http.StatusOK) })
+	doc.Handle(items, http.MethodPost, "", openapi.Route{
+		OperationID: "createItem",
+		Request:     createRequest{},
+		Responses:   map[int]any{http.StatusCreated: item{}},
+	}, func(c *gin.Context) { c.Status(http.StatusCreated) })
+	doc.Handle(items, http.MethodDelete, "/:id", openapi.Route{
+		OperationID: "deleteItem",
+		Responses:   map[int]any{http.StatusNoContent: nil},
+	}, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	doc.Handle(router.Group("/internal"), http.MethodPost, "/items/ingest", openapi.Route{
+		OperationID:        "ingestItems",
+		Public:             true,
+		Request:            item{},
+		RequestContentType: "application/x-ndjson",
+		Responses:          map[int]any{http.StatusOK: nil},
+	}, func(c *gin.Context) { c.Status(http.StatusOK) })
+	return doc, router
+}
+
+func TestHandleRegistersRoutes(t *testing.T) {
+	_, router := newDocument(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/items/abc", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDocumentPaths(t *testing.T) {
+	doc, _ := newDocument(t)
+	assert.Equal(t, []string{"/internal/items/ingest", "/v1/items", "/v1/items/{id}"}, doc.Paths())
+
+	rendered := render(t, doc)
+	assert.Equal(t, openapi.Version, rendered["openapi"])
+	assert.Equal(t, "Test API", lookup(t, rendered, "info", "title"))
+
+	parameters := lookup(t, rendered, "paths", "/v1/items/{id}", "delete", "parameters")
+	assert.Equal(t, []any{map[string]any{
+		"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+	}}, parameters)
+	assert.NotContains(t, lookup(t, rendered, "paths", "/v1/items/{id}", "delete", "responses", "204"), "content")
+
+	parameters = lookup(t, rendered, "paths", "/v1/items", "get", "parameters")
+	assert.Equal(t, []any{
+		map[string]any{"name": "limit", "in": "query", "schema": map[string]any{"type": "integer"}},
+		map[string]any{"name": "sort", "in": "query", "schema": map[string]any{"type": "string", "enum": []any{"asc", "desc"}}},
+	}, parameters)
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Error"},
+		lookup(t, rendered, "paths", "/v1/items", "get", "responses", "default", "content", "application/json", "schema"))
+}
+
+func TestDocumentSecurity(t *testing.T) {
+	doc, _ := newDocument(t)
+	rendered := render(t, doc)
+
+	assert.Equal(t, []any{map[string]any{"bearerAuth": []any{}}}, rendered["security"])
+	assert.Equal(t, "bearer", lookup(t, rendered, "components", "securitySchemes", "bearerAuth", "scheme"))
+	assert.NotContains(t, lookup(t, rendered, "paths", "/v1/items", "get"), "security")
+	assert.Equal(t, []any{}, lookup(t, rendered, "paths", "/internal/items/ingest", "post", "security"))
+}
+
+func TestDocumentSchemas(t *testing.T) {
+	doc, _ := newDocument(t)
+	rendered := render(t, doc)
+
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/createRequest"},
+		lookup(t, rendered, "paths", "/v1/items", "post", "requestBody", "content", "application/json", "schema"))
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/item"},
+		lookup(t, rendered, "paths", "/internal/items/ingest", "post", "requestBody", "content", "application/x-ndjson", "schema"))
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/pageitem"},
+		lookup(t, rendered, "paths", "/v1/items", "get", "responses", "200", "content", "application/json", "schema"))
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"$ref": "#/components/schemas/item"}},
+		lookup(t, rendered, "components", "schemas", "pageitem", "properties", "data"))
+
+	itemSchema := lookup(t, rendered, "components", "schemas", "item")
+	assert.Equal(t, map[string]any{
+		"id":       map[string]any{"type": "string"},
+		"name":     map[string]any{"type": "string"},
+		"count":    map[string]any{"type": "integer", "format": "int64"},
+		"created":  map[string]any{"type": "string", "format": "date-time"},
+		"expires":  map[string]any{"type": "string", "format": "date-time"},
+		"labels":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+		"parent":   map[string]any{"$ref": "#/components/schemas/item"},
+		"Untagged": map[string]any{"type": "boolean"},
+	}, lookup(t, itemSchema, "properties"))
+	assert.Equal(t, []any{"id", "name", "created", "Untagged"}, lookup(t, itemSchema, "required"))
+}
+
+func TestServeJSON(t *testing.T) {
+	doc, router := newDocument(t)
+	router.GET("/openapi.json", doc.ServeJSON)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	var rendered map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rendered))
+	assert.Contains(t, rendered["paths"], "/v1/items/{id}")
+}
+
+func TestSwaggerUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/swagger", openapi.SwaggerUI("Test API", "openapi.json", ""))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<title>Test API</title>")
+	assert.Contains(t, w.Body.String(), openapi.DefaultSwaggerUIAssetsURL+"/swagger-ui-bundle.js")
+	assert.Contains(t, w.Body.String(), `url: "openapi.json"`)
+}
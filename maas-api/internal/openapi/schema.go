@@ -0,0 +1,159 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+	// typeArgumentPackage matches the package path of a type argument of a generic type name,
+	// e.g. "github.com/openai/openai-go/v2/packages/pagination." in
+	// "Page[github.com/openai/openai-go/v2/packages/pagination.Model]".
+	typeArgumentPackage = regexp.MustCompile(`[\w./-]*\.`)
+)
+
+// Schema is a JSON Schema of the document.
+type Schema = map[string]any
+
+// schemaRegistry generates the schemas of Go types, and names the schemas of structs in
+// components.schemas.
+type schemaRegistry struct {
+	schemas map[string]Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: map[string]Schema{}, names: map[reflect.Type]string{}}
+}
+
+// schemaOf returns the schema of the JSON encoding of t. Structs are referenced from
+// components.schemas. Types that marshal themselves are documented as strings.
+func (r *schemaRegistry) schemaOf(t reflect.Type) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType), reflect.PointerTo(t).Implements(jsonMarshalerType),
+		t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		return Schema{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s := Schema{"type": "integer"}
+		switch t.Kind() { //nolint:exhaustive // Only the fixed-size integers have a format.
+		case reflect.Int32, reflect.Uint32:
+			s["format"] = "int32"
+		case reflect.Int64, reflect.Uint64:
+			s["format"] = "int64"
+		}
+		return s
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.objectSchema(t)
+		}
+		return Schema{"$ref": "#/components/schemas/" + r.define(t)}
+	default:
+		// Interfaces, e.g. gin.H values, can hold anything.
+		return Schema{}
+	}
+}
+
+// define adds the schema of the named struct t to components.schemas and returns its
+// name: the type name, prefixed with its package name if another type has it.
+func (r *schemaRegistry) define(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := schemaName(t.Name())
+	if _, taken := r.schemas[name]; taken {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = schemaName(strings.ToUpper(pkg[:1])+pkg[1:]) + name
+	}
+	r.names[t] = name
+	// Reserve the name first, so that recursive types reference it.
+	r.schemas[name] = Schema{}
+	r.schemas[name] = r.objectSchema(t)
+	return name
+}
+
+// schemaName turns a Go type name into a schema name, e.g. "Page[...models.Model]"
+// into "PageModel".
+func schemaName(typeName string) string {
+	name := typeArgumentPackage.ReplaceAllString(typeName, "")
+	return strings.Map(func(c rune) rune {
+		if c == '_' || ('0' <= c && c <= '9') || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') {
+			return c
+		}
+		return -1
+	}, name)
+}
+
+// objectSchema returns the object schema of the fields encoding/json marshals of the
+// struct t. Fields without omitempty are always marshaled, so they are required.
+func (r *schemaRegistry) objectSchema(t reflect.Type) Schema {
+	properties := Schema{}
+	var required []string
+	r.addFields(t, properties, &required)
+	s := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (r *schemaRegistry) addFields(t reflect.Type, properties Schema, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			// The fields of embedded structs are promoted.
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = r.schemaOf(field.Type)
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			*required = append(*required, name)
+		}
+	}
+}
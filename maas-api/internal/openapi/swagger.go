@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultSwaggerUIAssetsURL is where the Swagger UI page loads its scripts and styles.
+const DefaultSwaggerUIAssetsURL = "https://unpkg.com/swagger-ui-dist@5"
+
+var swaggerUIPage = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.AssetsURL}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: {{.SpecURL}}, dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`))
+
+// SwaggerUI serves a Swagger UI page for the document at specURL, a URL relative to
+// the page, loading Swagger UI from assetsURL.
+func SwaggerUI(title, specURL, assetsURL string) gin.HandlerFunc {
+	if assetsURL == "" {
+		assetsURL = DefaultSwaggerUIAssetsURL
+	}
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+		data := struct{ Title, SpecURL, AssetsURL string }{title, specURL, assetsURL}
+		if err := swaggerUIPage.Execute(c.Writer, data); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrorWriter writes the response of a request rejected by ValidateRequests.
+type ErrorWriter func(c *gin.Context, status int, message string)
+
+// requestRule is what ValidateRequests checks of the requests of a route.
+type requestRule struct {
+	// bodyType is the Go type of a JSON request body, nil for other bodies and none.
+	bodyType   reflect.Type
+	maxBytes   int64
+	writeError ErrorWriter
+}
+
+func writeError(c *gin.Context, status int, message string) {
+	c.JSON(status, gin.H{"error": message})
+}
+
+// requestRule returns the rule of the route, registered by Add.
+func (d *Document) requestRule(method, path string) (requestRule, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rule, ok := d.requests[method+" "+path]
+	return rule, ok
+}
+
+// ValidateRequests returns the middleware that rejects requests before their handlers
+// read them:
+//
+//   - 413 when the body exceeds the MaxRequestBytes of the route, or maxBytes for the
+//     routes without it and those not documented. Bodies without Content-Length are cut
+//     at the limit.
+//   - 415 when the Content-Type of a route with a JSON request body is not JSON.
+//   - 400 when a JSON body is not a single JSON value of the schema of the Request type,
+//     or fails its binding tags. Unknown fields are not rejected.
+//
+// It must be used before the routes are registered. The bodies it validates are buffered
+// and handed to the handlers unchanged.
+func (d *Document) ValidateRequests(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, ok := d.requestRule(c.Request.Method, c.FullPath())
+		if !ok {
+			rule = requestRule{writeError: writeError}
+		}
+		if rule.maxBytes == 0 {
+			rule.maxBytes = maxBytes
+		}
+		if c.Request.ContentLength > rule.maxBytes {
+			rule.reject(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", rule.maxBytes))
+			return
+		}
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			if rule.bodyType != nil {
+				rule.reject(c, http.StatusBadRequest, "request body is required")
+				return
+			}
+			c.Next()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, rule.maxBytes)
+		if rule.bodyType == nil {
+			c.Next()
+			return
+		}
+
+		if !isJSON(c.GetHeader("Content-Type")) {
+			rule.reject(c, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				rule.reject(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", rule.maxBytes))
+				return
+			}
+			rule.reject(c, http.StatusBadRequest, "failed to read the request body")
+			return
+		}
+		if err := validateJSON(body, rule.bodyType); err != nil {
+			rule.reject(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
+
+func (r requestRule) reject(c *gin.Context, status int, message string) {
+	r.writeError(c, status, message)
+	c.Abort()
+}
+
+// isJSON reports whether the Content-Type is application/json or a +json media type.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// validateJSON decodes the body into a value of type t and checks its binding tags.
+func validateJSON(body []byte, t reflect.Type) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errors.New("request body is required")
+	}
+	value := reflect.New(t)
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(value.Interface()); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+			return fmt.Errorf("request body is not valid JSON: %w", err)
+		case errors.As(err, &typeErr) && typeErr.Field == "":
+			return fmt.Errorf("request body must be %s", jsonType(t))
+		case errors.As(err, &typeErr):
+			return fmt.Errorf("%s must be %s", typeErr.Field, jsonType(typeErr.Type))
+		default:
+			return fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("request body must be a single JSON value")
+	}
+
+	if err := binding.Validator.ValidateStruct(value.Interface()); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if errors.As(err, &fieldErrs) && len(fieldErrs) > 0 {
+			name := jsonFieldName(t, fieldErrs[0])
+			if fieldErrs[0].Tag() == "required" {
+				return fmt.Errorf("%s is required", name)
+			}
+			return fmt.Errorf("%s fails the %s check", name, fieldErrs[0].Tag())
+		}
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// jsonType describes the JSON values of the Go type t, e.g. "an integer".
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType,
+		t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		return "a string"
+	}
+	switch t.Kind() { //nolint:exhaustive // The other kinds are not decoded from JSON.
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "a JSON object"
+	default:
+		return "a JSON value"
+	}
+}
+
+// jsonFieldName returns the JSON name of the field of a failed binding tag, e.g.
+// "filters.status" for SearchAPIKeysRequest.Filters.Status.
+func jsonFieldName(t reflect.Type, fieldErr validator.FieldError) string {
+	parts := strings.Split(fieldErr.StructNamespace(), ".")[1:]
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fieldErr.Field()
+		}
+		fieldName, _, _ := strings.Cut(part, "[")
+		field, ok := t.FieldByName(fieldName)
+		if !ok {
+			return fieldErr.Field()
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name+part[len(fieldName):])
+		t = field.Type
+	}
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,155 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/openapi"
+)
+
+type searchRequest struct {
+	Filters *struct {
+		Owner string   `binding:"required" json:"owner"`
+		Tags  []string `json:"tags,omitempty"`
+	} `json:"filters,omitempty"`
+	Limit int `json:"limit,omitempty"`
+}
+
+type revokeRequest struct {
+	Username string `binding:"required" json:"username"`
+}
+
+// newValidatedRouter serves routes whose handlers echo the body they read.
+func newValidatedRouter(maxBytes int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1"})
+	router := gin.New()
+	router.Use(doc.ValidateRequests(maxBytes))
+	echo := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, string(body))
+	}
+	group := router.Group("/v1")
+	doc.Handle(group, http.MethodPost, "/search", openapi.Route{OperationID: "search", Request: searchRequest{}}, echo)
+	doc.Handle(group, http.MethodPost, "/revoke", openapi.Route{
+		OperationID:  "revoke",
+		Request:      revokeRequest{},
+		RequestError: func(c *gin.Context, _ int, message string) { c.JSON(http.StatusOK, gin.H{"rejected": message}) },
+	}, echo)
+	doc.Handle(group, http.MethodPost, "/logs", openapi.Route{
+		OperationID:        "logs",
+		Request:            revokeRequest{},
+		RequestContentType: "application/x-ndjson",
+		MaxRequestBytes:    64,
+	}, echo)
+	router.POST("/undocumented", echo)
+	return router
+}
+
+func post(router *gin.Engine, path, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestValidateRequests_PassesValidBodies(t *testing.T) {
+	router := newValidatedRouter(1024)
+
+	body := `{"filters": {"owner": "alice", "unknown": true}, "limit": 10}`
+	w := post(router, "/v1/search", "application/json; charset=utf-8", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String(), "the handler must read the body unchanged")
+
+	w = post(router, "/v1/logs", "text/plain", "a\nb\n")
+	assert.Equal(t, http.StatusOK, w.Code, "only JSON bodies are checked")
+}
+
+func TestValidateRequests_RejectsInvalidBodies(t *testing.T) {
+	router := newValidatedRouter(1024)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantError   string
+	}{
+		{"no content type", "", `{}`, http.StatusUnsupportedMediaType, "Content-Type must be application/json"},
+		{"form", "application/x-www-form-urlencoded", `limit=1`, http.StatusUnsupportedMediaType, "Content-Type must be application/json"},
+		{"empty", "application/json", ``, http.StatusBadRequest, "request body is required"},
+		{"malformed", "application/json", `{"limit": 1`, http.StatusBadRequest, "request body is not valid JSON"},
+		{"array", "application/json", `[]`, http.StatusBadRequest, "request body must be a JSON object"},
+		{"wrong field type", "application/json", `{"limit": "ten"}`, http.StatusBadRequest, "limit must be an integer"},
+		{"wrong nested type", "application/json", `{"filters": {"owner": "a", "tags": "x"}}`, http.StatusBadRequest, "filters.tags must be an array"},
+		{"missing required", "application/json", `{"filters": {}}`, http.StatusBadRequest, "filters.owner is required"},
+		{"trailing data", "application/json", `{} {}`, http.StatusBadRequest, "request body must be a single JSON value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(router, "/v1/search", tt.contentType, tt.body)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
+
+func TestValidateRequests_LimitsBodySize(t *testing.T) {
+	router := newValidatedRouter(32)
+
+	w := post(router, "/v1/search", "application/json", `{"filters": {"owner": "`+strings.Repeat("a", 32)+`"}}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(t, `{"error": "request body exceeds 32 bytes"}`, w.Body.String())
+
+	w = post(router, "/v1/logs", "application/x-ndjson", strings.Repeat("a", 48))
+	assert.Equal(t, http.StatusOK, w.Code, "the route limit replaces the default")
+	w = post(router, "/v1/logs", "application/x-ndjson", strings.Repeat("a", 65))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = post(router, "/undocumented", "text/plain", strings.Repeat("a", 33))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, "undocumented routes take the default")
+}
+
+func TestValidateRequests_LimitsBodiesWithoutContentLength(t *testing.T) {
+	router := newValidatedRouter(32)
+
+	for _, path := range []string{"/v1/search", "/undocumented"} {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"limit": `+strings.Repeat("1", 40)+`}`))
+			req.ContentLength = -1
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if path == "/v1/search" {
+				assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+			} else {
+				assert.Equal(t, http.StatusInternalServerError, w.Code)
+				assert.Contains(t, w.Body.String(), "request body too large", "handlers reading past the limit get an error")
+			}
+		})
+	}
+}
+
+func TestValidateRequests_RouteErrorWriter(t *testing.T) {
+	router := newValidatedRouter(1024)
+
+	w := post(router, "/v1/revoke", "application/json", `{}`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"rejected": "username is required"}`, w.Body.String())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
 	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
 )
 
@@ -27,6 +28,16 @@ func NewHandler(log *logger.Logger, selector *Selector) *Handler {
 	}
 }
 
+// SelectRequestError writes the response of a selection request rejected before it
+// reaches SelectSubscription. Like the other selection errors, it is a 200 response,
+// which Authorino reads the error of.
+func SelectRequestError(c *gin.Context, _ int, message string) {
+	c.JSON(http.StatusOK, SelectResponse{
+		Error:   "bad_request",
+		Message: "invalid request body: " + message,
+	})
+}
+
 // SelectSubscription handles POST /internal/v1/subscriptions/select requests.
 //
 // This endpoint is called by Authorino during AuthPolicy evaluation to determine
@@ -43,14 +54,14 @@ func NewHandler(log *logger.Logger, selector *Selector) *Handler {
 // Authorino pods. No additional authentication is needed as the groups/username
 // come from an already-authenticated auth.identity object.
 func (h *Handler) SelectSubscription(c *gin.Context) {
-	h.logger.Debug("Subscription selection request received",
+	h.logger.DebugContext(c.Request.Context(), "Subscription selection request received",
 		"path", c.Request.URL.Path,
 		"method", c.Request.Method,
 	)
 
 	var req SelectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Invalid request body",
+		h.logger.WarnContext(c.Request.Context(), "Invalid request body",
 			"error", err.Error(),
 		)
 		c.JSON(http.StatusOK, SelectResponse{
@@ -60,7 +71,8 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		return
 	}
 
-	h.logger.Debug("Processing subscription selection",
+	middleware.Attribute(c, middleware.Attribution{User: req.Username, Subscription: req.RequestedSubscription, Model: req.RequestedModel})
+	h.logger.DebugContext(c.Request.Context(), "Processing subscription selection",
 		"username", req.Username,
 		"groups", req.Groups,
 		"requestedSubscription", req.RequestedSubscription,
@@ -80,7 +92,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		var modelUnhealthyErr *ModelUnhealthyError
 
 		if errors.As(err, &noSubErr) {
-			h.logger.Debug("No subscription found for user",
+			h.logger.DebugContext(c.Request.Context(), "No subscription found for user",
 				"username", req.Username,
 				"groups", req.Groups,
 			)
@@ -92,7 +104,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		}
 
 		if errors.As(err, &notFoundErr) {
-			h.logger.Debug("Requested subscription not found",
+			h.logger.DebugContext(c.Request.Context(), "Requested subscription not found",
 				"subscription", req.RequestedSubscription,
 			)
 			c.JSON(http.StatusOK, SelectResponse{
@@ -103,7 +115,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		}
 
 		if errors.As(err, &accessDeniedErr) {
-			h.logger.Debug("Access denied to subscription",
+			h.logger.DebugContext(c.Request.Context(), "Access denied to subscription",
 				"username", req.Username,
 				"subscription", req.RequestedSubscription,
 			)
@@ -115,7 +127,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		}
 
 		if errors.As(err, &multipleSubsErr) {
-			h.logger.Debug("Multiple subscriptions found, explicit selection required",
+			h.logger.DebugContext(c.Request.Context(), "Multiple subscriptions found, explicit selection required",
 				"username", req.Username,
 				"subscriptions", multipleSubsErr.Subscriptions,
 			)
@@ -127,7 +139,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		}
 
 		if errors.As(err, &modelNotInSubErr) {
-			h.logger.Debug("Model not included in subscription",
+			h.logger.DebugContext(c.Request.Context(), "Model not included in subscription",
 				"subscription", modelNotInSubErr.Subscription,
 				"model", modelNotInSubErr.Model,
 			)
@@ -139,7 +151,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		}
 
 		if errors.As(err, &modelUnhealthyErr) {
-			h.logger.Debug("Requested model is unhealthy",
+			h.logger.DebugContext(c.Request.Context(), "Requested model is unhealthy",
 				"subscription", modelUnhealthyErr.Subscription,
 				"phase", modelUnhealthyErr.Phase,
 				"reason", modelUnhealthyErr.Reason,
@@ -154,7 +166,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		}
 
 		// All other errors are internal server errors
-		h.logger.Error("Subscription selection failed",
+		h.logger.ErrorContext(c.Request.Context(), "Subscription selection failed",
 			"error", err.Error(),
 			"username", req.Username,
 		)
@@ -165,7 +177,8 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 		return
 	}
 
-	h.logger.Debug("Subscription selected successfully",
+	middleware.Attribute(c, middleware.Attribution{Subscription: response.Name})
+	h.logger.DebugContext(c.Request.Context(), "Subscription selected successfully",
 		"username", req.Username,
 		"subscription", response.Name,
 		"organizationId", response.OrganizationID,
@@ -178,7 +191,7 @@ func (h *Handler) SelectSubscription(c *gin.Context) {
 func (h *Handler) ListSubscriptions(c *gin.Context) {
 	userContextVal, exists := c.Get("user")
 	if !exists {
-		h.logger.Error("User context not found - ExtractUserInfo middleware not called")
+		h.logger.ErrorContext(c.Request.Context(), "User context not found - ExtractUserInfo middleware not called")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Internal server error",
@@ -188,7 +201,7 @@ func (h *Handler) ListSubscriptions(c *gin.Context) {
 	}
 	userContext, ok := userContextVal.(*token.UserContext)
 	if !ok {
-		h.logger.Error("Invalid user context type")
+		h.logger.ErrorContext(c.Request.Context(), "Invalid user context type")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Internal server error",
@@ -199,7 +212,7 @@ func (h *Handler) ListSubscriptions(c *gin.Context) {
 
 	accessible, err := h.selector.GetAllAccessible(userContext.Groups, userContext.Username)
 	if err != nil {
-		h.logger.Error("Failed to list subscriptions", "error", err)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to list subscriptions", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Failed to list subscriptions",
@@ -219,9 +232,10 @@ func (h *Handler) ListSubscriptions(c *gin.Context) {
 // ListSubscriptionsForModel handles GET /v1/model/:model-id/subscriptions.
 // Returns subscriptions the user has access to that include the specified model.
 func (h *Handler) ListSubscriptionsForModel(c *gin.Context) {
+	middleware.Attribute(c, middleware.Attribution{Model: c.Param("model-id")})
 	userContextVal, exists := c.Get("user")
 	if !exists {
-		h.logger.Error("User context not found - ExtractUserInfo middleware not called")
+		h.logger.ErrorContext(c.Request.Context(), "User context not found - ExtractUserInfo middleware not called")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Internal server error",
@@ -231,7 +245,7 @@ func (h *Handler) ListSubscriptionsForModel(c *gin.Context) {
 	}
 	userContext, ok := userContextVal.(*token.UserContext)
 	if !ok {
-		h.logger.Error("Invalid user context type")
+		h.logger.ErrorContext(c.Request.Context(), "Invalid user context type")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Internal server error",
@@ -252,7 +266,7 @@ func (h *Handler) ListSubscriptionsForModel(c *gin.Context) {
 
 	subs, err := h.selector.ListAccessibleForModel(userContext.Username, userContext.Groups, modelID)
 	if err != nil {
-		h.logger.Error("Failed to list subscriptions for model", "error", err, "model", modelID)
+		h.logger.ErrorContext(c.Request.Context(), "Failed to list subscriptions for model", "error", err, "model", modelID)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"message": "Failed to list subscriptions",
@@ -34,17 +34,25 @@ type ModelAccessChecker interface {
 	AuthorizedModels(groups []string, username string) map[authpolicy.ModelKey]bool
 }
 
+// GroupMapper adds the roles that identity provider groups map to to a user's groups.
+type GroupMapper interface {
+	ExpandGroups(groups []string) []string
+}
+
 // Selector handles subscription selection logic.
 type Selector struct {
 	lister        Lister
 	modelLister   models.MaaSModelRefLister
 	accessChecker ModelAccessChecker
+	groupMapper   GroupMapper
 	logger        *logger.Logger
 }
 
 // NewSelector creates a new subscription selector.
 // modelLister is optional; when provided, model refs in list responses are enriched with displayName and description.
-func NewSelector(log *logger.Logger, lister Lister, modelLister models.MaaSModelRefLister, accessChecker ModelAccessChecker) *Selector {
+// groupMapper is optional; when provided, the user's groups are expanded with the roles they map to
+// before they are matched against subscription owners and MaaSAuthPolicy subjects.
+func NewSelector(log *logger.Logger, lister Lister, modelLister models.MaaSModelRefLister, accessChecker ModelAccessChecker, groupMapper GroupMapper) *Selector {
 	if log == nil {
 		log = logger.Production()
 	}
@@ -52,10 +60,19 @@ func NewSelector(log *logger.Logger, lister Lister, modelLister models.MaaSModel
 		lister:        lister,
 		modelLister:   modelLister,
 		accessChecker: accessChecker,
+		groupMapper:   groupMapper,
 		logger:        log,
 	}
 }
 
+// mapGroups returns the user's groups followed by the roles they map to.
+func (s *Selector) mapGroups(groups []string) []string {
+	if s.groupMapper == nil {
+		return groups
+	}
+	return s.groupMapper.ExpandGroups(groups)
+}
+
 // buildModelIndex builds a lookup map keyed by "namespace/name" from the MaaSModelRef cache.
 // Called once per loadSubscriptions to avoid repeated List() calls for every model ref.
 // Returns nil when the lister is nil or the List() call fails.
@@ -101,6 +118,7 @@ func (s *Selector) GetAllAccessible(groups []string, username string) ([]*Select
 	if len(groups) == 0 && username == "" {
 		return nil, errors.New("either groups or username must be provided")
 	}
+	groups = s.mapGroups(groups)
 
 	subscriptions, err := s.loadSubscriptions()
 	if err != nil {
@@ -165,6 +183,7 @@ func (s *Selector) Select(groups []string, username string, requestedSubscriptio
 	if len(groups) == 0 && username == "" {
 		return nil, errors.New("either groups or username must be provided")
 	}
+	groups = s.mapGroups(groups)
 
 	subscriptions, err := s.loadSubscriptions()
 	if err != nil {
@@ -262,6 +281,7 @@ func (s *Selector) SelectHighestPriority(groups []string, username string) (*Sel
 	if len(groups) == 0 && username == "" {
 		return nil, errors.New("either groups or username must be provided")
 	}
+	groups = s.mapGroups(groups)
 
 	subscriptions, err := s.loadSubscriptions()
 	if err != nil {
@@ -287,6 +307,16 @@ func (s *Selector) SelectHighestPriority(groups []string, username string) (*Sel
 	return toResponse(&accessible[0]), nil
 }
 
+// IsModelAuthorized reports whether a MaaSAuthPolicy grants the user access to the
+// MaaSModelRef namespace/name. Without an access checker every model is authorized.
+func (s *Selector) IsModelAuthorized(groups []string, username string, namespace, name string) bool {
+	if s.accessChecker == nil {
+		return true
+	}
+	authorized := s.accessChecker.AuthorizedModels(s.mapGroups(groups), username)
+	return authorized[authpolicy.ModelKey{Namespace: namespace, Name: name}]
+}
+
 // loadSubscriptions fetches and parses MaaSSubscription resources.
 func (s *Selector) loadSubscriptions() ([]subscription, error) {
 	objects, err := s.lister.List()
@@ -358,23 +388,16 @@ func parseSubscription(obj *unstructured.Unstructured) (subscription, error) {
 		sub.Description = annotations[constant.AnnotationDescription]
 	}
 
-	// Parse owner
+	// Parse owner; the groups and users of spec.owners entries have access too
 	if owner, found, _ := unstructured.NestedMap(spec, "owner"); found {
-		// Parse groups
-		if groupsRaw, found, _ := unstructured.NestedSlice(owner, "groups"); found {
-			for _, g := range groupsRaw {
-				if groupMap, ok := g.(map[string]any); ok {
-					if name, ok := groupMap["name"].(string); ok {
-						sub.Groups = append(sub.Groups, name)
-					}
-				}
+		parseOwner(owner, &sub)
+	}
+	if owners, found, _ := unstructured.NestedSlice(spec, "owners"); found {
+		for _, o := range owners {
+			if owner, ok := o.(map[string]any); ok {
+				parseOwner(owner, &sub)
 			}
 		}
-
-		// Parse users
-		if users, found, _ := unstructured.NestedStringSlice(owner, "users"); found {
-			sub.Users = users
-		}
 	}
 
 	// Parse priority
@@ -384,11 +407,16 @@ func parseSubscription(obj *unstructured.Unstructured) (subscription, error) {
 		}
 	}
 
-	// Parse modelRefs
+	// Parse modelRefs; wildcard entries only provide limits for the selected models
+	wildcards := map[string][]TokenRateLimit{}
 	if modelRefs, found, _ := unstructured.NestedSlice(spec, "modelRefs"); found {
 		for _, modelRef := range modelRefs {
 			if modelMap, ok := modelRef.(map[string]any); ok {
 				ref := parseModelRef(modelMap)
+				if ref.Name == modelRefWildcard {
+					wildcards[ref.Namespace] = ref.TokenRateLimits
+					continue
+				}
 				for _, trl := range ref.TokenRateLimits {
 					if trl.Limit > sub.MaxLimit {
 						sub.MaxLimit = trl.Limit
@@ -399,6 +427,9 @@ func parseSubscription(obj *unstructured.Unstructured) (subscription, error) {
 		}
 	}
 
+	// Parse models selected by wildcard modelRefs and spec.modelSelector
+	parseSelectedModelRefs(obj, spec, wildcards, &sub)
+
 	// Parse tokenMetadata
 	parseTokenMetadata(spec, &sub)
 
@@ -471,6 +502,10 @@ func parseSubscription(obj *unstructured.Unstructured) (subscription, error) {
 	return sub, nil
 }
 
+// modelRefWildcard as a modelRef name covers every model in the entry's namespace, and
+// as its namespace as well every model in every namespace.
+const modelRefWildcard = "*"
+
 // parseModelRef extracts a ModelRefInfo from an unstructured model ref map.
 func parseModelRef(modelMap map[string]any) ModelRefInfo {
 	ref := ModelRefInfo{}
@@ -490,6 +525,9 @@ func parseModelRef(modelMap map[string]any) ModelRefInfo {
 				if window, ok := limitMap["window"].(string); ok {
 					trl.Window = window
 				}
+				if direction, ok := limitMap["direction"].(string); ok {
+					trl.Direction = direction
+				}
 				ref.TokenRateLimits = append(ref.TokenRateLimits, trl)
 			}
 		}
@@ -504,6 +542,47 @@ func parseModelRef(modelMap map[string]any) ModelRefInfo {
 	return ref
 }
 
+// parseSelectedModelRefs adds the models the controller resolved from wildcard modelRefs
+// and spec.modelSelector (status.selectedModelRefs) to the subscription. Each gets the
+// limits of the "<namespace>/*" entry, else the "*/*" entry, else the selector. Models
+// already listed in spec.modelRefs keep their own entry.
+func parseSelectedModelRefs(obj *unstructured.Unstructured, spec map[string]any, wildcards map[string][]TokenRateLimit, sub *subscription) {
+	selected, found, _ := unstructured.NestedSlice(obj.Object, "status", "selectedModelRefs")
+	if !found {
+		return
+	}
+	var selectorLimits []TokenRateLimit
+	if selector, found, _ := unstructured.NestedMap(spec, "modelSelector"); found {
+		selectorLimits = parseModelRef(selector).TokenRateLimits
+	}
+	for _, raw := range selected {
+		modelMap, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		ref := parseModelRef(modelMap)
+		if slices.ContainsFunc(sub.ModelRefs, func(r ModelRefInfo) bool {
+			return r.Name == ref.Name && r.Namespace == ref.Namespace
+		}) {
+			continue
+		}
+		limits, ok := wildcards[ref.Namespace]
+		if !ok {
+			limits, ok = wildcards[modelRefWildcard]
+		}
+		if !ok {
+			limits = selectorLimits
+		}
+		ref.TokenRateLimits = limits
+		for _, trl := range limits {
+			if trl.Limit > sub.MaxLimit {
+				sub.MaxLimit = trl.Limit
+			}
+		}
+		sub.ModelRefs = append(sub.ModelRefs, ref)
+	}
+}
+
 // parseTokenMetadata extracts tokenMetadata fields from the spec into the subscription.
 func parseTokenMetadata(spec map[string]any, sub *subscription) {
 	metadata, found, _ := unstructured.NestedMap(spec, "tokenMetadata")
@@ -526,6 +605,41 @@ func parseTokenMetadata(spec map[string]any, sub *subscription) {
 	}
 }
 
+// parseOwner adds the groups, users and service accounts of an owner to the subscription.
+func parseOwner(owner map[string]any, sub *subscription) {
+	if groupsRaw, found, _ := unstructured.NestedSlice(owner, "groups"); found {
+		for _, g := range groupsRaw {
+			if groupMap, ok := g.(map[string]any); ok {
+				if name, ok := groupMap["name"].(string); ok {
+					sub.Groups = append(sub.Groups, name)
+				}
+			}
+		}
+	}
+	if users, found, _ := unstructured.NestedStringSlice(owner, "users"); found {
+		sub.Users = append(sub.Users, users...)
+	}
+	// Service accounts authenticate as system:serviceaccount:<namespace>:<name>, and all of
+	// a namespace's service accounts are in the system:serviceaccounts:<namespace> group.
+	if sas, found, _ := unstructured.NestedSlice(owner, "serviceAccounts"); found {
+		for _, raw := range sas {
+			sa, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			namespace, _ := sa["namespace"].(string)
+			if namespace == "" {
+				continue
+			}
+			if name, _ := sa["name"].(string); name != "" {
+				sub.Users = append(sub.Users, "system:serviceaccount:"+namespace+":"+name)
+			} else {
+				sub.Groups = append(sub.Groups, "system:serviceaccounts:"+namespace)
+			}
+		}
+	}
+}
+
 // userHasAccess checks if user/groups match subscription owner.
 func userHasAccess(sub *subscription, username string, groups []string) bool {
 	// Check username match
@@ -706,6 +820,7 @@ func sortSubscriptionsByPriority(subs []subscription) {
 // ListAccessibleForModel returns subscriptions the user has access to
 // that include the specified model in their modelRefs.
 func (s *Selector) ListAccessibleForModel(username string, groups []string, modelID string) ([]SubscriptionInfo, error) {
+	groups = s.mapGroups(groups)
 	subscriptions, err := s.loadSubscriptions()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load subscriptions: %w", err)
@@ -274,7 +274,7 @@ func TestGetAllAccessible(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lister := &fakeLister{subscriptions: tt.subscriptions}
-			selector := subscription.NewSelector(log, lister, nil, nil)
+			selector := subscription.NewSelector(log, lister, nil, nil, nil)
 
 			result, err := selector.GetAllAccessible(tt.groups, tt.username)
 
@@ -334,7 +334,7 @@ func TestGetAllAccessible_ErrorHandling(t *testing.T) {
 
 	t.Run("requires groups or username", func(t *testing.T) {
 		lister := &fakeLister{subscriptions: []*unstructured.Unstructured{}}
-		selector := subscription.NewSelector(log, lister, nil, nil)
+		selector := subscription.NewSelector(log, lister, nil, nil, nil)
 
 		_, err := selector.GetAllAccessible(nil, "")
 		if err == nil {
@@ -354,7 +354,7 @@ func TestSelectHighestPriority(t *testing.T) {
 			createSubscription("low-sub", []string{"g1"}, nil, 10, defaultTestTokenRateLimit, "L", "d1"),
 			createSubscription("high-sub", []string{"g1"}, nil, 50, defaultTestTokenRateLimit, "H", "d2"),
 		}}
-		sel := subscription.NewSelector(log, lister, nil, nil)
+		sel := subscription.NewSelector(log, lister, nil, nil, nil)
 		got, err := sel.SelectHighestPriority([]string{"g1"}, "")
 		if err != nil {
 			t.Fatalf("SelectHighestPriority: %v", err)
@@ -369,7 +369,7 @@ func TestSelectHighestPriority(t *testing.T) {
 			createSubscription("sub-a", []string{"g1"}, nil, 10, 10, "", ""),
 			createSubscription("sub-b", []string{"g1"}, nil, 10, 20, "", ""),
 		}}
-		sel := subscription.NewSelector(log, lister, nil, nil)
+		sel := subscription.NewSelector(log, lister, nil, nil, nil)
 		got, err := sel.SelectHighestPriority([]string{"g1"}, "")
 		if err != nil {
 			t.Fatalf("SelectHighestPriority: %v", err)
@@ -384,7 +384,7 @@ func TestSelectHighestPriority(t *testing.T) {
 			createSubscription("zebra", []string{"g1"}, nil, 5, defaultTestTokenRateLimit, "", ""),
 			createSubscription("alpha", []string{"g1"}, nil, 5, defaultTestTokenRateLimit, "", ""),
 		}}
-		sel := subscription.NewSelector(log, lister, nil, nil)
+		sel := subscription.NewSelector(log, lister, nil, nil, nil)
 		got, err := sel.SelectHighestPriority([]string{"g1"}, "")
 		if err != nil {
 			t.Fatalf("SelectHighestPriority: %v", err)
@@ -398,7 +398,7 @@ func TestSelectHighestPriority(t *testing.T) {
 		lister := &fakeLister{subscriptions: []*unstructured.Unstructured{
 			createSubscription("other", []string{"other-group"}, nil, 10, defaultTestTokenRateLimit, "", ""),
 		}}
-		sel := subscription.NewSelector(log, lister, nil, nil)
+		sel := subscription.NewSelector(log, lister, nil, nil, nil)
 		_, err := sel.SelectHighestPriority([]string{"g1"}, "")
 		if err == nil {
 			t.Fatal("expected error")
@@ -554,7 +554,7 @@ func TestSelector_HealthFieldParsing(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lister := &fakeLister{subscriptions: []*unstructured.Unstructured{tt.subscription}}
-			selector := subscription.NewSelector(log, lister, nil, nil)
+			selector := subscription.NewSelector(log, lister, nil, nil, nil)
 
 			//nolint:unqueryvet,nolintlint // False positive - not a SQL query
 			result, err := selector.Select([]string{"g1"}, "", "", "")
@@ -598,7 +598,7 @@ func TestSelector_ListAccessibleWithHealth(t *testing.T) {
 	}
 
 	lister := &fakeLister{subscriptions: subscriptions}
-	selector := subscription.NewSelector(log, lister, nil, nil)
+	selector := subscription.NewSelector(log, lister, nil, nil, nil)
 
 	results, err := selector.GetAllAccessible([]string{"g1"}, "")
 	if err != nil {
@@ -789,7 +789,7 @@ func TestSelector_DegradedSubscriptionTRLPFiltering(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lister := &fakeLister{subscriptions: []*unstructured.Unstructured{tt.subscription}}
-			selector := subscription.NewSelector(log, lister, nil, nil)
+			selector := subscription.NewSelector(log, lister, nil, nil, nil)
 
 			//nolint:unqueryvet,nolintlint // False positive - not a SQL query
 			result, err := selector.Select([]string{"g1"}, "", "", tt.requestedModel)
@@ -942,7 +942,7 @@ func TestEnrichModelRefsSource(t *testing.T) {
 			modelLister := &fakeModelLister{items: []*unstructured.Unstructured{modelRef}}
 
 			lister := &fakeLister{subscriptions: []*unstructured.Unstructured{sub}}
-			selector := subscription.NewSelector(log, lister, modelLister, nil)
+			selector := subscription.NewSelector(log, lister, modelLister, nil, nil)
 
 			accessible, err := selector.GetAllAccessible([]string{"g1"}, "")
 			if err != nil {
@@ -1194,7 +1194,7 @@ func TestListAccessibleForModel_MultiNamespace(t *testing.T) {
 				accessChecker = &fakeAccessChecker{authorized: tt.authorized}
 			}
 
-			selector := subscription.NewSelector(log, lister, nil, accessChecker)
+			selector := subscription.NewSelector(log, lister, nil, accessChecker, nil)
 			result, err := selector.ListAccessibleForModel(tt.username, tt.groups, tt.modelID)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -1218,3 +1218,215 @@ func TestListAccessibleForModel_MultiNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestListAccessibleForModel_ModelSelector(t *testing.T) {
+	log := logger.New(false)
+
+	sub := createSubscriptionWithModelRefs("sub1", []string{"g1"}, []map[string]any{
+		{"name": "listed", "namespace": "tenant-a", "tokenRateLimits": []any{
+			map[string]any{"limit": int64(50), "window": "1m"},
+		}},
+	})
+	sub.Object["spec"].(map[string]any)["modelSelector"] = map[string]any{
+		"selector": map[string]any{"matchLabels": map[string]any{"family": "granite"}},
+		"tokenRateLimits": []any{
+			map[string]any{"limit": int64(500), "window": "1h"},
+		},
+	}
+	sub.Object["status"].(map[string]any)["selectedModelRefs"] = []any{
+		map[string]any{"name": "selected", "namespace": "tenant-a"},
+		map[string]any{"name": "listed", "namespace": "tenant-a"},
+	}
+
+	selector := subscription.NewSelector(log, &fakeLister{subscriptions: []*unstructured.Unstructured{sub}}, nil, nil, nil)
+	result, err := selector.ListAccessibleForModel("", []string{"g1"}, "selected")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected the selected model to be accessible, got %d subscriptions", len(result))
+	}
+
+	refs := map[string]subscription.ModelRefInfo{}
+	for _, ref := range result[0].ModelRefs {
+		if _, dup := refs[ref.Name]; dup {
+			t.Errorf("Model %q listed twice", ref.Name)
+		}
+		refs[ref.Name] = ref
+	}
+	if got := refs["selected"].TokenRateLimits; len(got) != 1 || got[0].Limit != 500 {
+		t.Errorf("Expected the selector's limits on the selected model, got %v", got)
+	}
+	if got := refs["listed"].TokenRateLimits; len(got) != 1 || got[0].Limit != 50 {
+		t.Errorf("Expected the listed model to keep its own limits, got %v", got)
+	}
+}
+
+func TestListAccessibleForModel_WildcardModelRefs(t *testing.T) {
+	log := logger.New(false)
+
+	sub := createSubscriptionWithModelRefs("default", []string{"g1"}, []map[string]any{
+		{"name": "*", "namespace": "*", "tokenRateLimits": []any{
+			map[string]any{"limit": int64(10), "window": "1m"},
+		}},
+		{"name": "*", "namespace": "tenant-b", "tokenRateLimits": []any{
+			map[string]any{"limit": int64(20), "window": "1m"},
+		}},
+	})
+	sub.Object["status"].(map[string]any)["selectedModelRefs"] = []any{
+		map[string]any{"name": "model-x", "namespace": "tenant-a"},
+		map[string]any{"name": "model-y", "namespace": "tenant-b"},
+	}
+
+	selector := subscription.NewSelector(log, &fakeLister{subscriptions: []*unstructured.Unstructured{sub}}, nil, nil, nil)
+	if result, err := selector.ListAccessibleForModel("", []string{"g1"}, "*"); err != nil || len(result) != 0 {
+		t.Errorf("Expected a wildcard entry not to act as a model, got %v, %v", result, err)
+	}
+	result, err := selector.ListAccessibleForModel("", []string{"g1"}, "model-y")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected the wildcard-selected model to be accessible, got %d subscriptions", len(result))
+	}
+	limits := map[string]int64{}
+	for _, ref := range result[0].ModelRefs {
+		limits[ref.Namespace+"/"+ref.Name] = ref.TokenRateLimits[0].Limit
+	}
+	if limits["tenant-a/model-x"] != 10 || limits["tenant-b/model-y"] != 20 {
+		t.Errorf("Expected the global and the namespace wildcard's limits, got %v", limits)
+	}
+}
+
+func TestListAccessibleForModel_Owners(t *testing.T) {
+	log := logger.New(false)
+
+	sub := createSubscriptionWithModelRefs("tiers", nil, []map[string]any{
+		{"name": "model-x", "namespace": "tenant-a", "tokenRateLimits": []any{
+			map[string]any{"limit": int64(100), "window": "1m"},
+		}},
+	})
+	sub.Object["spec"].(map[string]any)["owners"] = []any{
+		map[string]any{
+			"name":            "gold",
+			"groups":          []any{map[string]any{"name": "gold-team"}},
+			"tokenRateLimits": []any{map[string]any{"limit": int64(5000), "window": "1m"}},
+		},
+		map[string]any{
+			"name":            "silver",
+			"users":           []any{"bob"},
+			"tokenRateLimits": []any{map[string]any{"limit": int64(1000), "window": "1m"}},
+		},
+	}
+
+	selector := subscription.NewSelector(log, &fakeLister{subscriptions: []*unstructured.Unstructured{sub}}, nil, nil, nil)
+	for _, tc := range []struct {
+		username string
+		groups   []string
+		want     int
+	}{
+		{username: "alice", groups: []string{"gold-team"}, want: 1},
+		{username: "bob", want: 1},
+		{username: "carol", groups: []string{"other"}, want: 0},
+	} {
+		result, err := selector.ListAccessibleForModel(tc.username, tc.groups, "model-x")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result) != tc.want {
+			t.Errorf("%s: expected %d accessible subscriptions, got %d", tc.username, tc.want, len(result))
+		}
+	}
+}
+
+func TestListAccessibleForModel_ServiceAccountOwners(t *testing.T) {
+	log := logger.New(false)
+
+	sub := createSubscriptionWithModelRefs("batch", nil, []map[string]any{
+		{"name": "model-x", "namespace": "tenant-a", "tokenRateLimits": []any{
+			map[string]any{"limit": int64(100), "window": "1m"},
+		}},
+	})
+	sub.Object["spec"].(map[string]any)["owner"] = map[string]any{
+		"serviceAccounts": []any{
+			map[string]any{"namespace": "pipelines", "name": "nightly"},
+			map[string]any{"namespace": "etl"},
+		},
+	}
+
+	selector := subscription.NewSelector(log, &fakeLister{subscriptions: []*unstructured.Unstructured{sub}}, nil, nil, nil)
+	for _, tc := range []struct {
+		username string
+		groups   []string
+		want     int
+	}{
+		{username: "system:serviceaccount:pipelines:nightly", groups: []string{"system:serviceaccounts", "system:serviceaccounts:pipelines"}, want: 1},
+		{username: "system:serviceaccount:etl:loader", groups: []string{"system:serviceaccounts", "system:serviceaccounts:etl"}, want: 1},
+		{username: "system:serviceaccount:pipelines:other", groups: []string{"system:serviceaccounts", "system:serviceaccounts:pipelines"}, want: 0},
+	} {
+		result, err := selector.ListAccessibleForModel(tc.username, tc.groups, "model-x")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result) != tc.want {
+			t.Errorf("%s: expected %d accessible subscriptions, got %d", tc.username, tc.want, len(result))
+		}
+	}
+}
+
+// fakeGroupMapper implements subscription.GroupMapper for testing.
+type fakeGroupMapper map[string][]string
+
+func (f fakeGroupMapper) ExpandGroups(groups []string) []string {
+	expanded := append([]string(nil), groups...)
+	for _, g := range groups {
+		expanded = append(expanded, f[g]...)
+	}
+	return expanded
+}
+
+func TestSelector_GroupMapping(t *testing.T) {
+	log := logger.New(false)
+
+	lister := &fakeLister{subscriptions: []*unstructured.Unstructured{
+		createSubscription("premium", []string{"maas-premium"}, nil, 10, defaultTestTokenRateLimit, "", ""),
+	}}
+	selector := subscription.NewSelector(log, lister, nil, nil, fakeGroupMapper{"ldap-premium": {"maas-premium"}})
+
+	got, err := selector.Select([]string{"ldap-premium"}, "alice", "", "")
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if got.Name != "premium" {
+		t.Errorf("expected premium for a member of the mapped ldap-premium group, got %q", got.Name)
+	}
+	accessible, err := selector.GetAllAccessible([]string{"ldap-premium"}, "alice")
+	if err != nil {
+		t.Fatalf("GetAllAccessible: %v", err)
+	}
+	if len(accessible) != 1 {
+		t.Errorf("expected 1 accessible subscription, got %d", len(accessible))
+	}
+
+	if _, err := selector.Select([]string{"ldap-basic"}, "bob", "", ""); err == nil {
+		t.Error("expected no subscription for an unmapped group")
+	}
+}
+
+func TestSelector_IsModelAuthorized(t *testing.T) {
+	log := logger.New(false)
+	lister := &fakeLister{}
+
+	if !subscription.NewSelector(log, lister, nil, nil, nil).IsModelAuthorized(nil, "alice", "llm", "granite") {
+		t.Error("expected every model to be authorized without an access checker")
+	}
+
+	checker := &fakeAccessChecker{authorized: map[authpolicy.ModelKey]bool{{Namespace: "llm", Name: "granite"}: true}}
+	selector := subscription.NewSelector(log, lister, nil, checker, nil)
+	if !selector.IsModelAuthorized([]string{"users"}, "alice", "llm", "granite") {
+		t.Error("expected llm/granite to be authorized")
+	}
+	if selector.IsModelAuthorized([]string{"users"}, "alice", "other", "granite") {
+		t.Error("expected other/granite not to be authorized")
+	}
+}
@@ -66,6 +66,8 @@ type ModelRefInfo struct {
 type TokenRateLimit struct {
 	Limit  int64  `json:"limit"`
 	Window string `json:"window"`
+	// Direction is Input, Output or Total; empty means Total.
+	Direction string `json:"direction,omitempty"`
 }
 
 // TokenRateLimitStatus represents the status of a TokenRateLimitPolicy for a model.
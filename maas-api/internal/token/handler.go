@@ -62,7 +62,7 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 		// Validate required headers exist and are not empty
 		// Missing headers indicate a configuration issue with the auth policy (internal error)
 		if username == "" {
-			h.logger.Error("Missing or empty username header",
+			h.logger.ErrorContext(c.Request.Context(), "Missing or empty username header",
 				"header", constant.HeaderUsername,
 			)
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -75,7 +75,7 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 		}
 
 		if groupHeader == "" {
-			h.logger.Error("Missing group header",
+			h.logger.ErrorContext(c.Request.Context(), "Missing group header",
 				"header", constant.HeaderGroup,
 				"username", username,
 			)
@@ -92,7 +92,7 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 		// Parsing errors also indicate configuration issues
 		groups, err := parseGroupsHeader(groupHeader)
 		if err != nil {
-			h.logger.Error("Failed to parse group header",
+			h.logger.ErrorContext(c.Request.Context(), "Failed to parse group header",
 				"header", constant.HeaderGroup,
 				"header_value", groupHeader,
 				"error", err,
@@ -114,7 +114,7 @@ func (h *Handler) ExtractUserInfo() gin.HandlerFunc {
 			Tenant:   h.tenantName,
 		}
 
-		h.logger.Debug("Extracted user info from headers",
+		h.logger.DebugContext(c.Request.Context(), "Extracted user info from headers",
 			"username", username,
 			"groups", groups,
 		)
@@ -0,0 +1,88 @@
+// Package tokencount implements the token counter, an Envoy external processor that
+// counts the tokens of the streamed completions of OpenAI-compatible backends that do not
+// report usage, so that the token rate limits and metering of the gateway still see them.
+package tokencount
+
+import (
+	"encoding/json"
+	"math"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultCharsPerToken is the average number of characters of a token of English text
+	// for the BPE tokenizers of common models.
+	DefaultCharsPerToken = 4.0
+	// messageOverhead approximates the tokens a chat template adds around each message.
+	messageOverhead = 4
+)
+
+// Estimator estimates token counts from the length of a text. Without the tokenizer of
+// the model, the counts are approximations: they are meant to keep the rate limits of a
+// backend that reports no usage close to its consumption, not to bill it exactly.
+type Estimator struct {
+	// CharsPerToken is the average number of characters of a token; DefaultCharsPerToken
+	// when not positive.
+	CharsPerToken float64
+}
+
+// Tokens returns the estimated tokens of a text of the given number of characters.
+func (e Estimator) Tokens(chars int) int {
+	if chars <= 0 {
+		return 0
+	}
+	charsPerToken := e.CharsPerToken
+	if charsPerToken <= 0 {
+		charsPerToken = DefaultCharsPerToken
+	}
+	return int(math.Ceil(float64(chars) / charsPerToken))
+}
+
+// PromptTokens estimates the input tokens of an OpenAI-compatible request body: the
+// messages of a chat completion or the prompt of a completion. A body that is not JSON
+// is estimated from its length.
+func (e Estimator) PromptTokens(body []byte) int {
+	var request struct {
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+		Prompt json.RawMessage `json:"prompt"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return e.Tokens(utf8.RuneCount(body))
+	}
+	tokens := e.Tokens(textLength(request.Prompt))
+	for _, message := range request.Messages {
+		tokens += messageOverhead + e.Tokens(textLength(message.Content))
+	}
+	return tokens
+}
+
+// textLength returns the number of characters of a content value: a string, or an array
+// of strings or of content parts with a "text" field. Other parts, such as images, are
+// not counted.
+func textLength(raw json.RawMessage) int {
+	if len(raw) == 0 {
+		return 0
+	}
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return utf8.RuneCountInString(text)
+	}
+	var parts []json.RawMessage
+	if json.Unmarshal(raw, &parts) != nil {
+		return 0
+	}
+	length := 0
+	for _, raw := range parts {
+		var part struct {
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(raw, &text) == nil {
+			length += utf8.RuneCountInString(text)
+		} else if json.Unmarshal(raw, &part) == nil {
+			length += utf8.RuneCountInString(part.Text)
+		}
+	}
+	return length
+}
@@ -0,0 +1,205 @@
+package tokencount
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+)
+
+// MetadataNamespace is the Envoy dynamic metadata namespace of the counted usage, e.g.
+// %DYNAMIC_METADATA(maas.token_counter:total_tokens)% in the gateway access log.
+const MetadataNamespace = "maas.token_counter"
+
+// Outcomes of the responses of the token counter.
+const (
+	// OutcomeReported is a streamed response whose backend reported the usage.
+	OutcomeReported = "reported"
+	// OutcomeCounted is a streamed response whose usage the counter estimated and added.
+	OutcomeCounted = "counted"
+	// OutcomeNotStreamed is a response that is not a stream of server-sent events.
+	OutcomeNotStreamed = "not_streamed"
+)
+
+// maxRequestBody bounds the request body buffered to estimate the prompt tokens; the
+// prompt of a larger body is estimated from its size.
+const maxRequestBody = 4 << 20
+
+// Server is the token counter: an Envoy external processor (ext_proc) placed after the
+// Kuadrant Wasm plugin of the gateway. It estimates the prompt tokens of each inference
+// request and, when a streamed response (text/event-stream) ends without a chunk with
+// usage, adds one with the estimated usage before its final event. The Kuadrant Wasm
+// plugin then charges the token rate limits with it as if the backend had reported it.
+// Responses that are not streamed, or that report usage, are passed through unchanged.
+type Server struct {
+	extprocv3.UnimplementedExternalProcessorServer
+
+	estimator Estimator
+	logger    *logger.Logger
+	metrics   metrics.TokenCountRecorder
+}
+
+// NewServer creates a token counter estimating tokens with estimator.
+func NewServer(log *logger.Logger, estimator Estimator) *Server {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Server{estimator: estimator, logger: log}
+}
+
+// SetMetrics sets the recorder of the responses and the counted tokens.
+func (s *Server) SetMetrics(recorder metrics.TokenCountRecorder) {
+	s.metrics = recorder
+}
+
+// exchange is the state of a request and its response.
+type exchange struct {
+	requestBody []byte
+	requestSize int
+	stream      *stream
+}
+
+// Process handles the messages Envoy sends for a request and its response.
+func (s *Server) Process(srv extprocv3.ExternalProcessor_ProcessServer) error {
+	ex := &exchange{}
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(s.handle(ex, req)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ex *exchange, req *extprocv3.ProcessingRequest) *extprocv3.ProcessingResponse {
+	switch r := req.GetRequest().(type) {
+	case *extprocv3.ProcessingRequest_RequestHeaders:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestHeaders{
+			RequestHeaders: &extprocv3.HeadersResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_RequestBody:
+		body := r.RequestBody.GetBody()
+		ex.requestSize += len(body)
+		if ex.requestSize <= maxRequestBody {
+			ex.requestBody = append(ex.requestBody, body...)
+		}
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestBody{
+			RequestBody: &extprocv3.BodyResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_RequestTrailers:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestTrailers{
+			RequestTrailers: &extprocv3.TrailersResponse{},
+		}}
+	case *extprocv3.ProcessingRequest_ResponseHeaders:
+		return s.responseHeaders(ex, r.ResponseHeaders)
+	case *extprocv3.ProcessingRequest_ResponseBody:
+		return s.responseBody(ex, r.ResponseBody)
+	case *extprocv3.ProcessingRequest_ResponseTrailers:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseTrailers{
+			ResponseTrailers: &extprocv3.TrailersResponse{},
+		}}
+	default:
+		s.logger.Debug("Ignoring unexpected ext_proc message", "type", r)
+		return &extprocv3.ProcessingResponse{}
+	}
+}
+
+// responseHeaders starts counting a streamed response. Envoy is told not to send the
+// body of other responses.
+func (s *Server) responseHeaders(ex *exchange, headers *extprocv3.HttpHeaders) *extprocv3.ProcessingResponse {
+	resp := &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+		ResponseHeaders: &extprocv3.HeadersResponse{},
+	}}
+	mediaType, _, _ := mime.ParseMediaType(headerValue(headers.GetHeaders(), "content-type"))
+	if mediaType != "text/event-stream" || headers.GetEndOfStream() {
+		s.record(OutcomeNotStreamed, Usage{})
+		resp.ModeOverride = &extprocfilterv3.ProcessingMode{
+			ResponseBodyMode:    extprocfilterv3.ProcessingMode_NONE,
+			ResponseTrailerMode: extprocfilterv3.ProcessingMode_SKIP,
+		}
+		return resp
+	}
+
+	var promptTokens int
+	if ex.requestSize > maxRequestBody {
+		promptTokens = s.estimator.Tokens(ex.requestSize)
+	} else {
+		promptTokens = s.estimator.PromptTokens(ex.requestBody)
+	}
+	ex.requestBody = nil
+	ex.stream = newStream(s.estimator, promptTokens)
+	return resp
+}
+
+func (s *Server) responseBody(ex *exchange, body *extprocv3.HttpBody) *extprocv3.ProcessingResponse {
+	bodyResponse := &extprocv3.BodyResponse{}
+	resp := &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseBody{
+		ResponseBody: bodyResponse,
+	}}
+	if ex.stream == nil || ex.stream.finished {
+		return resp
+	}
+
+	if mutated := ex.stream.process(body.GetBody(), body.GetEndOfStream()); mutated != nil {
+		bodyResponse.Response = &extprocv3.CommonResponse{
+			BodyMutation: &extprocv3.BodyMutation{Mutation: &extprocv3.BodyMutation_Body{Body: mutated}},
+		}
+	}
+	switch {
+	case ex.stream.counted():
+		usage := ex.stream.usage
+		s.logger.Debug("Added estimated usage to streamed response",
+			"model", ex.stream.model, "promptTokens", usage.PromptTokens, "completionTokens", usage.CompletionTokens)
+		s.record(OutcomeCounted, usage)
+		resp.DynamicMetadata = usageMetadata(usage)
+	case ex.stream.finished:
+		s.record(OutcomeReported, Usage{})
+	}
+	return resp
+}
+
+func (s *Server) record(outcome string, usage Usage) {
+	if s.metrics != nil {
+		s.metrics.RecordTokenCount(outcome, usage.PromptTokens, usage.CompletionTokens)
+	}
+}
+
+// usageMetadata returns the dynamic metadata of an estimated usage.
+func usageMetadata(usage Usage) *structpb.Struct {
+	return &structpb.Struct{Fields: map[string]*structpb.Value{
+		MetadataNamespace: structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"input_tokens":  structpb.NewNumberValue(float64(usage.PromptTokens)),
+			"output_tokens": structpb.NewNumberValue(float64(usage.CompletionTokens)),
+			"total_tokens":  structpb.NewNumberValue(float64(usage.TotalTokens)),
+			"estimated":     structpb.NewBoolValue(true),
+		}}),
+	}}
+}
+
+// headerValue returns the value of a header; Envoy sends it as value or raw value.
+func headerValue(headers *corev3.HeaderMap, name string) string {
+	for _, header := range headers.GetHeaders() {
+		if strings.EqualFold(header.GetKey(), name) {
+			if header.GetValue() != "" {
+				return header.GetValue()
+			}
+			return string(header.GetRawValue())
+		}
+	}
+	return ""
+}
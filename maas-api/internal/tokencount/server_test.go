@@ -0,0 +1,143 @@
+package tokencount_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tokencount"
+)
+
+// fakeProcessStream replays the messages Envoy would send for an exchange.
+type fakeProcessStream struct {
+	grpc.ServerStream
+
+	requests  []*extprocv3.ProcessingRequest
+	responses []*extprocv3.ProcessingResponse
+}
+
+func (f *fakeProcessStream) Context() context.Context { return context.Background() }
+
+func (f *fakeProcessStream) Recv() (*extprocv3.ProcessingRequest, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakeProcessStream) Send(resp *extprocv3.ProcessingResponse) error {
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+type recordedCount struct {
+	outcome       string
+	input, output int
+}
+
+type fakeRecorder struct {
+	counts []recordedCount
+}
+
+func (f *fakeRecorder) RecordTokenCount(outcome string, input, output int) {
+	f.counts = append(f.counts, recordedCount{outcome, input, output})
+}
+
+func requestBody(body string) *extprocv3.ProcessingRequest {
+	return &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestBody{
+		RequestBody: &extprocv3.HttpBody{Body: []byte(body), EndOfStream: true},
+	}}
+}
+
+func responseHeaders(contentType string) *extprocv3.ProcessingRequest {
+	return &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseHeaders{
+		ResponseHeaders: &extprocv3.HttpHeaders{Headers: &corev3.HeaderMap{Headers: []*corev3.HeaderValue{
+			{Key: ":status", RawValue: []byte("200")},
+			{Key: "content-type", RawValue: []byte(contentType)},
+		}}},
+	}}
+}
+
+func responseBody(body string, endOfStream bool) *extprocv3.ProcessingRequest {
+	return &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseBody{
+		ResponseBody: &extprocv3.HttpBody{Body: []byte(body), EndOfStream: endOfStream},
+	}}
+}
+
+func TestProcess_AddsUsageToStreamWithoutUsage(t *testing.T) {
+	server := tokencount.NewServer(logger.Development(), tokencount.Estimator{})
+	recorder := &fakeRecorder{}
+	server.SetMetrics(recorder)
+
+	stream := &fakeProcessStream{requests: []*extprocv3.ProcessingRequest{
+		{Request: &extprocv3.ProcessingRequest_RequestHeaders{RequestHeaders: &extprocv3.HttpHeaders{}}},
+		requestBody(`{"model":"granite","stream":true,"messages":[{"role":"user","content":"Tell me a joke"}]}`),
+		responseHeaders("text/event-stream; charset=utf-8"),
+		responseBody(`data: {"id":"cmpl-1","object":"chat.completion.chunk","model":"granite","choices":[{"delta":{"content":"Why did the"}}]}`+"\n\n", false),
+		responseBody("data: [DONE]\n\n", true),
+	}}
+	require.NoError(t, server.Process(stream))
+	require.Len(t, stream.responses, 5)
+
+	assert.Nil(t, stream.responses[2].GetModeOverride(), "streamed responses are processed")
+	assert.Nil(t, stream.responses[3].GetResponseBody().GetResponse(), "chunks before [DONE] are unchanged")
+
+	last := stream.responses[4]
+	body := string(last.GetResponseBody().GetResponse().GetBodyMutation().GetBody())
+	assert.Equal(t, `data: {"id":"cmpl-1","object":"chat.completion.chunk","model":"granite","choices":[],"usage":{"prompt_tokens":8,"completion_tokens":3,"total_tokens":11}}`+"\n\ndata: [DONE]\n\n", body)
+
+	metadata := last.GetDynamicMetadata().GetFields()[tokencount.MetadataNamespace].GetStructValue().GetFields()
+	assert.InDelta(t, float64(8), metadata["input_tokens"].GetNumberValue(), 0)
+	assert.InDelta(t, float64(3), metadata["output_tokens"].GetNumberValue(), 0)
+	assert.InDelta(t, float64(11), metadata["total_tokens"].GetNumberValue(), 0)
+	assert.True(t, metadata["estimated"].GetBoolValue())
+
+	assert.Equal(t, []recordedCount{{tokencount.OutcomeCounted, 8, 3}}, recorder.counts)
+}
+
+func TestProcess_PassesStreamWithUsage(t *testing.T) {
+	server := tokencount.NewServer(logger.Development(), tokencount.Estimator{})
+	recorder := &fakeRecorder{}
+	server.SetMetrics(recorder)
+
+	stream := &fakeProcessStream{requests: []*extprocv3.ProcessingRequest{
+		requestBody(`{"model":"granite","stream":true,"messages":[{"role":"user","content":"Hi"}]}`),
+		responseHeaders("text/event-stream"),
+		responseBody(`data: {"choices":[{"delta":{"content":"Hello"}}],"usage":null}`+"\n\n", false),
+		responseBody(`data: {"choices":[],"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`+"\n\ndata: [DONE]\n\n", true),
+	}}
+	require.NoError(t, server.Process(stream))
+
+	for _, resp := range stream.responses[2:] {
+		assert.Nil(t, resp.GetResponseBody().GetResponse())
+		assert.Nil(t, resp.GetDynamicMetadata())
+	}
+	assert.Equal(t, []recordedCount{{tokencount.OutcomeReported, 0, 0}}, recorder.counts)
+}
+
+func TestProcess_SkipsResponsesThatAreNotStreamed(t *testing.T) {
+	server := tokencount.NewServer(logger.Development(), tokencount.Estimator{})
+	recorder := &fakeRecorder{}
+	server.SetMetrics(recorder)
+
+	stream := &fakeProcessStream{requests: []*extprocv3.ProcessingRequest{
+		requestBody(`{"model":"granite","messages":[{"role":"user","content":"Hi"}]}`),
+		responseHeaders("application/json"),
+	}}
+	require.NoError(t, server.Process(stream))
+
+	override := stream.responses[1].GetModeOverride()
+	require.NotNil(t, override)
+	assert.Equal(t, extprocfilterv3.ProcessingMode_NONE, override.GetResponseBodyMode())
+	assert.Equal(t, []recordedCount{{tokencount.OutcomeNotStreamed, 0, 0}}, recorder.counts)
+}
@@ -0,0 +1,207 @@
+package tokencount
+
+import (
+	"bytes"
+	"encoding/json"
+	"unicode/utf8"
+)
+
+// maxLineLength bounds the incomplete line held back between the body chunks of a
+// stream; a longer line is sent without waiting for its end.
+const maxLineLength = 1 << 20
+
+var doneMarker = []byte("[DONE]")
+
+// Usage is the token usage of a completion, in the format of the OpenAI API.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// completionChunk is the part of a chunk of a streamed chat completion or completion the
+// counter reads.
+type completionChunk struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Text  string `json:"text"`
+		Delta struct {
+			Content          string `json:"content"`
+			ReasoningContent string `json:"reasoning_content"`
+			ToolCalls        []struct {
+				Function struct {
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage json.RawMessage `json:"usage"`
+}
+
+// usageChunk is the chunk added to a stream without usage, like the final chunk OpenAI
+// sends with stream_options.include_usage.
+type usageChunk struct {
+	ID      string     `json:"id,omitempty"`
+	Object  string     `json:"object"`
+	Created int64      `json:"created,omitempty"`
+	Model   string     `json:"model,omitempty"`
+	Choices []struct{} `json:"choices"`
+	Usage   Usage      `json:"usage"`
+}
+
+// stream follows the server-sent events of a streamed completion. It counts the text of
+// its chunks and, unless one of them reports usage, adds a chunk with the estimated usage
+// before the final "data: [DONE]" event, or at the end of the stream without one.
+type stream struct {
+	estimator    Estimator
+	promptTokens int
+
+	// pending is the incomplete last line of the chunks processed so far. It is held back
+	// until its end, so that the usage can be inserted before the [DONE] event even when
+	// the event is split across chunks.
+	pending []byte
+
+	id      string
+	object  string
+	created int64
+	model   string
+	// textChunks is the number of chunks with completion text, chars their characters.
+	textChunks int
+	chars      int
+
+	// reported is set when a chunk of the stream has usage.
+	reported bool
+	// finished is set once the usage has been added or the stream has ended.
+	finished bool
+	usage    Usage
+}
+
+func newStream(estimator Estimator, promptTokens int) *stream {
+	return &stream{estimator: estimator, promptTokens: promptTokens}
+}
+
+// process reads a chunk of the response body and returns the chunk to send in its place,
+// or nil to send it unchanged.
+func (s *stream) process(body []byte, endOfStream bool) []byte {
+	if s.finished {
+		return nil
+	}
+	held := len(s.pending)
+	data := append(s.pending, body...)
+	s.pending = nil
+	start := 0
+	for {
+		end := bytes.IndexByte(data[start:], '\n')
+		if end < 0 {
+			break
+		}
+		end += start
+		if s.observe(bytes.TrimRight(data[start:end], "\r")) && !s.reported {
+			return s.finish(data, start)
+		}
+		start = end + 1
+	}
+
+	if endOfStream && start < len(data) {
+		s.observe(bytes.TrimRight(data[start:], "\r"))
+	}
+	switch {
+	case s.reported:
+		// The backend reports usage: the rest of the stream is sent as is.
+		s.finished = true
+		start = len(data)
+	case endOfStream:
+		return s.finish(data, len(data))
+	case len(data)-start > maxLineLength:
+		start = len(data)
+	}
+	s.pending = append([]byte(nil), data[start:]...)
+	if held == 0 && start == len(data) {
+		return nil
+	}
+	return data[:start]
+}
+
+// observe reads a line of the stream and reports whether it is the [DONE] event.
+func (s *stream) observe(line []byte) bool {
+	payload, ok := bytes.CutPrefix(line, []byte("data:"))
+	if !ok {
+		return false
+	}
+	payload = bytes.TrimSpace(payload)
+	if bytes.Equal(payload, doneMarker) {
+		return true
+	}
+	var chunk completionChunk
+	if json.Unmarshal(payload, &chunk) != nil {
+		return false
+	}
+	if len(chunk.Usage) > 0 && !bytes.Equal(chunk.Usage, []byte("null")) {
+		s.reported = true
+	}
+	if s.id == "" {
+		s.id, s.object, s.created, s.model = chunk.ID, chunk.Object, chunk.Created, chunk.Model
+	}
+	for _, choice := range chunk.Choices {
+		chars := utf8.RuneCountInString(choice.Text) +
+			utf8.RuneCountInString(choice.Delta.Content) +
+			utf8.RuneCountInString(choice.Delta.ReasoningContent)
+		for _, call := range choice.Delta.ToolCalls {
+			chars += utf8.RuneCountInString(call.Function.Arguments)
+		}
+		if chars > 0 {
+			s.textChunks++
+			s.chars += chars
+		}
+	}
+	return false
+}
+
+// finish estimates the usage of the stream and returns data with the usage chunk
+// inserted at the given position.
+func (s *stream) finish(data []byte, at int) []byte {
+	s.finished = true
+	// Streaming servers send about a token per chunk: a chunk with text counts as at
+	// least one token.
+	completionTokens := max(s.textChunks, s.estimator.Tokens(s.chars))
+	s.usage = Usage{
+		PromptTokens:     s.promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      s.promptTokens + completionTokens,
+	}
+	object := s.object
+	if object == "" {
+		object = "chat.completion.chunk"
+	}
+	// Marshalling the chunk cannot fail.
+	payload, _ := json.Marshal(usageChunk{
+		ID:      s.id,
+		Object:  object,
+		Created: s.created,
+		Model:   s.model,
+		Choices: []struct{}{},
+		Usage:   s.usage,
+	})
+
+	event := make([]byte, 0, len(payload)+10)
+	if at == len(data) {
+		// The stream may not end with a blank line; extra blank lines are ignored.
+		event = append(event, '\n')
+	}
+	event = append(event, "data: "...)
+	event = append(event, payload...)
+	event = append(event, "\n\n"...)
+
+	out := make([]byte, 0, len(data)+len(event))
+	out = append(out, data[:at]...)
+	out = append(out, event...)
+	return append(out, data[at:]...)
+}
+
+// counted reports whether the stream has ended with an estimated usage.
+func (s *stream) counted() bool {
+	return s.finished && !s.reported
+}
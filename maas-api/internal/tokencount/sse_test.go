@@ -0,0 +1,135 @@
+package tokencount //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chunkHello = `data: {"id":"chatcmpl-1","object":"chat.completion.chunk","created":1760000000,"model":"granite","choices":[{"index":0,"delta":{"content":"Hello"}}]}` + "\n\n"
+	chunkWorld = `data: {"id":"chatcmpl-1","object":"chat.completion.chunk","created":1760000000,"model":"granite","choices":[{"index":0,"delta":{"content":" world, how are you?"}}]}` + "\n\n"
+	chunkUsage = `data: {"id":"chatcmpl-1","object":"chat.completion.chunk","created":1760000000,"model":"granite","choices":[],"usage":{"prompt_tokens":9,"completion_tokens":6,"total_tokens":15}}` + "\n\n"
+	chunkDone  = "data: [DONE]\n\n"
+)
+
+// usageEvents returns the usage of the data events of a stream that have one.
+func usageEvents(t *testing.T, body string) []Usage {
+	t.Helper()
+	var usages []Usage
+	for _, line := range strings.Split(body, "\n") {
+		payload, ok := strings.CutPrefix(line, "data: ")
+		if !ok || payload == "[DONE]" {
+			continue
+		}
+		var chunk struct {
+			Usage *Usage `json:"usage"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(payload), &chunk))
+		if chunk.Usage != nil {
+			usages = append(usages, *chunk.Usage)
+		}
+	}
+	return usages
+}
+
+func TestStream_AddsUsageBeforeDone(t *testing.T) {
+	s := newStream(Estimator{}, 12)
+
+	assert.Nil(t, s.process([]byte(chunkHello), false))
+	assert.Nil(t, s.process([]byte(chunkWorld), false))
+	out := s.process([]byte(chunkDone), true)
+	require.NotNil(t, out)
+
+	assert.True(t, strings.HasSuffix(string(out), "\n\n"+chunkDone), "usage must precede [DONE]: %q", out)
+	// "Hello" and " world, how are you?" are 25 characters: 7 tokens.
+	assert.Equal(t, []Usage{{PromptTokens: 12, CompletionTokens: 7, TotalTokens: 19}}, usageEvents(t, string(out)))
+	assert.Contains(t, string(out), `"id":"chatcmpl-1","object":"chat.completion.chunk","created":1760000000,"model":"granite","choices":[]`)
+	assert.True(t, s.counted())
+}
+
+func TestStream_PassesReportedUsage(t *testing.T) {
+	s := newStream(Estimator{}, 12)
+
+	for _, chunk := range []string{chunkHello, chunkUsage, chunkDone} {
+		assert.Nil(t, s.process([]byte(chunk), chunk == chunkDone))
+	}
+	assert.True(t, s.finished)
+	assert.False(t, s.counted())
+}
+
+func TestStream_LinesSplitAcrossChunks(t *testing.T) {
+	s := newStream(Estimator{}, 0)
+	stream := chunkHello + chunkWorld + chunkDone
+
+	var out strings.Builder
+	for i := 0; i < len(stream); i += 7 {
+		end := min(i+7, len(stream))
+		chunk := []byte(stream[i:end])
+		if mutated := s.process(chunk, end == len(stream)); mutated != nil {
+			chunk = mutated
+		}
+		out.Write(chunk)
+	}
+
+	usages := usageEvents(t, out.String())
+	require.Len(t, usages, 1)
+	assert.Equal(t, 7, usages[0].CompletionTokens)
+	assert.True(t, strings.HasPrefix(out.String(), chunkHello+chunkWorld+"data: {"))
+	assert.True(t, strings.HasSuffix(out.String(), "\n\n"+chunkDone))
+}
+
+func TestStream_EndsWithoutDone(t *testing.T) {
+	s := newStream(Estimator{}, 3)
+
+	assert.Nil(t, s.process([]byte(chunkHello), false))
+	out := s.process([]byte(strings.TrimSuffix(chunkWorld, "\n\n")), true)
+	require.NotNil(t, out)
+
+	assert.True(t, strings.HasPrefix(string(out), strings.TrimSuffix(chunkWorld, "\n\n")+"\n"))
+	assert.Equal(t, []Usage{{PromptTokens: 3, CompletionTokens: 7, TotalTokens: 10}}, usageEvents(t, string(out)))
+}
+
+func TestStream_CountsCompletionsAndToolCalls(t *testing.T) {
+	s := newStream(Estimator{}, 0)
+	body := `data: {"object":"text_completion","choices":[{"text":"abcdefgh"}]}` + "\n\n" +
+		`data: {"choices":[{"delta":{"tool_calls":[{"function":{"arguments":"{\"city\":\"Rome\"}"}}]}}]}` + "\n\n" +
+		`data: {"choices":[{"delta":{}}]}` + "\n\n" +
+		chunkDone
+
+	out := s.process([]byte(body), true)
+
+	// 8 characters and 15 characters: 2 and 4 tokens; the empty delta is not counted.
+	assert.Equal(t, []Usage{{CompletionTokens: 6, TotalTokens: 6}}, usageEvents(t, string(out)))
+	assert.Contains(t, string(out), `"object":"text_completion"`)
+}
+
+func TestEstimator_PromptTokens(t *testing.T) {
+	e := Estimator{}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"chat messages", `{"messages":[{"role":"system","content":"Be brief."},{"role":"user","content":"What is MaaS?"}]}`, 4 + 3 + 4 + 4},
+		{"content parts", `{"messages":[{"role":"user","content":[{"type":"text","text":"Describe"},{"type":"image_url","image_url":{"url":"https://x"}}]}]}`, 4 + 2},
+		{"completion prompt", `{"prompt":"Once upon a time"}`, 4},
+		{"prompt array", `{"prompt":["abcd","efgh"]}`, 2},
+		{"not JSON", `0123456789`, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, e.PromptTokens([]byte(tt.body)))
+		})
+	}
+}
+
+func TestEstimator_CharsPerToken(t *testing.T) {
+	assert.Equal(t, 0, Estimator{}.Tokens(0))
+	assert.Equal(t, 1, Estimator{}.Tokens(1))
+	assert.Equal(t, 4, Estimator{CharsPerToken: 2.5}.Tokens(10))
+}
@@ -0,0 +1,241 @@
+package usage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxAccessLogLine bounds a line of an access log batch.
+const maxAccessLogLine = 64 << 10
+
+// AccessLogEntry is a gateway access log line, in the JSON format of the usage
+// documentation: the identity dynamic metadata of the gateway AuthPolicy and the
+// start time and response code of the request. The other fields are optional and only
+// reported in the usage events.
+type AccessLogEntry struct {
+	StartTime string `json:"start_time"`
+	User      string `json:"user"`
+	// SubscriptionKey is the identity's selected_subscription_key,
+	// "<subscription namespace>/<subscription>@<model namespace>/<model>".
+	SubscriptionKey string `json:"subscription_key"`
+	ResponseCode    int    `json:"response_code"`
+
+	RequestID      string `json:"request_id"`
+	KeyID          string `json:"key_id"`
+	CostCenter     string `json:"cost_center"`
+	OrganizationID string `json:"organization_id"`
+	// Duration is the duration of the request in milliseconds, Envoy's %DURATION%.
+	Duration     logInt `json:"duration"`
+	InputTokens  logInt `json:"input_tokens"`
+	OutputTokens logInt `json:"output_tokens"`
+	TotalTokens  logInt `json:"total_tokens"`
+	// ClientAddress is the address of the client, Envoy's %DOWNSTREAM_REMOTE_ADDRESS% or
+	// the X-Forwarded-For header, for the anomaly detection. Only the first address of a
+	// list is used, and a port is ignored.
+	ClientAddress string `json:"client_address"`
+}
+
+// logInt is an optional integer of an access log line. Envoy writes the values of
+// headers and dynamic metadata as strings, and null or "-" when they are missing.
+type logInt int64
+
+func (n *logInt) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "-" || s == "null" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+	*n = logInt(max(v, 0))
+	return nil
+}
+
+// AccessLogResult is the outcome of ingesting a batch of access log lines.
+type AccessLogResult struct {
+	Accepted int `json:"accepted"`
+	Skipped  int `json:"skipped"`
+}
+
+// parseSubscriptionKey splits a selected_subscription_key into the subscription name
+// and the model as "namespace/name".
+func parseSubscriptionKey(key string) (string, string, bool) {
+	sub, model, ok := strings.Cut(key, "@")
+	if !ok {
+		return "", "", false
+	}
+	_, subName, ok := strings.Cut(sub, "/")
+	if !ok || subName == "" || strings.Count(model, "/") != 1 || strings.HasPrefix(model, "/") || strings.HasSuffix(model, "/") {
+		return "", "", false
+	}
+	return subName, model, true
+}
+
+// ParseAccessLogs reads newline-delimited JSON access log entries and counts the
+// requests the models served, per user, subscription, model and window. Lines that
+// are not JSON, lack the identity or were not served (a response code of 400 or
+// more) are skipped.
+func ParseAccessLogs(r io.Reader) ([]Record, AccessLogResult, error) {
+	requests, result, err := ParseAccessLogRequests(r)
+	if err != nil {
+		return nil, result, err
+	}
+	return CountRequests(requests), result, nil
+}
+
+// ParseAccessLogRequests reads newline-delimited JSON access log entries and returns
+// the requests the models served, skipping the same lines as ParseAccessLogs.
+func ParseAccessLogRequests(r io.Reader) ([]RequestUsage, AccessLogResult, error) {
+	batch, err := ParseAccessLogBatch(r)
+	return batch.Requests, batch.Result, err
+}
+
+// AccessLogBatch is what a batch of access log lines holds.
+type AccessLogBatch struct {
+	// Requests are the requests the models served.
+	Requests []RequestUsage
+	// Health are the requests that count towards the health of their model: those
+	// served, and those that failed with a 5xx status, with or without a user.
+	Health []HealthObservation
+	Result AccessLogResult
+}
+
+// ParseAccessLogBatch reads newline-delimited JSON access log entries and returns the
+// requests the models served, skipping the same lines as ParseAccessLogs, and those
+// that count towards the health of their model.
+func ParseAccessLogBatch(r io.Reader) (AccessLogBatch, error) {
+	var batch AccessLogBatch
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxAccessLogLine)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry AccessLogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			batch.Result.Skipped++
+			continue
+		}
+		subName, model, ok := parseSubscriptionKey(entry.SubscriptionKey)
+		start, err := time.Parse(time.RFC3339Nano, entry.StartTime)
+		if ok && err == nil && entry.ResponseCode > 0 && (entry.ResponseCode < 400 || entry.ResponseCode >= 500) {
+			batch.Health = append(batch.Health, HealthObservation{
+				Model:        model,
+				StartTime:    start.UTC(),
+				ResponseCode: entry.ResponseCode,
+				LatencyMs:    int64(entry.Duration),
+			})
+		}
+		if !ok || err != nil || entry.User == "" || entry.ResponseCode <= 0 || entry.ResponseCode >= 400 {
+			batch.Result.Skipped++
+			continue
+		}
+
+		request := RequestUsage{
+			RequestID:      entry.RequestID,
+			StartTime:      start.UTC(),
+			Username:       entry.User,
+			KeyID:          entry.KeyID,
+			Subscription:   subName,
+			Model:          model,
+			CostCenter:     entry.CostCenter,
+			OrganizationID: entry.OrganizationID,
+			ResponseCode:   entry.ResponseCode,
+			LatencyMs:      int64(entry.Duration),
+			InputTokens:    int64(entry.InputTokens),
+			OutputTokens:   int64(entry.OutputTokens),
+			TotalTokens:    int64(entry.TotalTokens),
+			lineHash:       lineHash(line),
+			clientAddress:  entry.ClientAddress,
+		}
+		if request.TotalTokens == 0 {
+			request.TotalTokens = request.InputTokens + request.OutputTokens
+		}
+		batch.Requests = append(batch.Requests, request)
+		batch.Result.Accepted++
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return AccessLogBatch{Result: batch.Result}, errors.New("access log line exceeds 64KiB")
+		}
+		return AccessLogBatch{Result: batch.Result}, err
+	}
+	return batch, nil
+}
+
+// CountRequests returns the number of requests per user, subscription, model and
+// window, in the order of their first request.
+func CountRequests(requests []RequestUsage) []Record {
+	counts := map[recordKey]*Record{}
+	var order []recordKey
+	for _, r := range requests {
+		window := windowStart(r.StartTime)
+		key := recordKey{r.Username, r.Subscription, r.Model, window.Unix()}
+		record, seen := counts[key]
+		if !seen {
+			record = &Record{Username: r.Username, Subscription: r.Subscription, Model: r.Model, WindowStart: window}
+			counts[key] = record
+			order = append(order, key)
+		}
+		record.Requests++
+	}
+
+	records := make([]Record, 0, len(order))
+	for _, key := range order {
+		records = append(records, *counts[key])
+	}
+	return records
+}
+
+// CountKeyRequests returns the number of requests and the tokens reported per API key
+// and window, in the order they first appear. Requests without a key ID, e.g. made with
+// an OpenShift token, are not counted.
+func CountKeyRequests(requests []RequestUsage) []KeyRecord {
+	type keyWindow struct {
+		keyID      string
+		windowUnix int64
+	}
+	counts := map[keyWindow]*KeyRecord{}
+	var order []keyWindow
+	for _, r := range requests {
+		if r.KeyID == "" {
+			continue
+		}
+		window := windowStart(r.StartTime)
+		key := keyWindow{r.KeyID, window.Unix()}
+		record, seen := counts[key]
+		if !seen {
+			record = &KeyRecord{KeyID: r.KeyID, Username: r.Username, WindowStart: window}
+			counts[key] = record
+			order = append(order, key)
+		}
+		record.Requests++
+		record.InputTokens += r.InputTokens
+		record.OutputTokens += r.OutputTokens
+		record.TotalTokens += r.TotalTokens
+	}
+
+	records := make([]KeyRecord, 0, len(order))
+	for _, key := range order {
+		records = append(records, *counts[key])
+	}
+	return records
+}
+
+// lineHash identifies an access log line, for the events of requests without an ID.
+func lineHash(line string) string {
+	sum := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(sum[:16])
+}
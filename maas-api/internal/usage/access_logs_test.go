@@ -0,0 +1,122 @@
+package usage_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+func TestParseAccessLogs(t *testing.T) {
+	lines := strings.Join([]string{
+		`{"start_time": "2026-10-14T12:01:00.123Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200}`,
+		`{"start_time": "2026-10-14T12:59:59Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200}`,
+		`{"start_time": "2026-10-14T13:00:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200}`,
+		``,
+		`{"start_time": "2026-10-14T12:05:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 429}`,
+		`{"start_time": "2026-10-14T12:05:00Z", "user": null, "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200}`,
+		`{"start_time": "2026-10-14T12:05:00Z", "user": "bob", "subscription_key": "", "response_code": 200}`,
+		`not json`,
+	}, "\n")
+
+	records, result, err := usage.ParseAccessLogs(strings.NewReader(lines))
+	require.NoError(t, err)
+	assert.Equal(t, usage.AccessLogResult{Accepted: 3, Skipped: 4}, result)
+	require.Len(t, records, 2)
+	assert.Equal(t, usage.Record{
+		Username:     "alice",
+		Subscription: "premium",
+		Model:        "llm/granite",
+		WindowStart:  time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+		Requests:     2,
+	}, records[0])
+	assert.Equal(t, time.Date(2026, 10, 14, 13, 0, 0, 0, time.UTC), records[1].WindowStart)
+}
+
+func TestParseAccessLogRequests(t *testing.T) {
+	lines := strings.Join([]string{
+		`{"start_time": "2026-10-14T12:01:00.123Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200, ` +
+			`"request_id": "6f1c2d6e", "key_id": "k1", "cost_center": "cc-42", "organization_id": "acme", "duration": 850, "input_tokens": "120", "output_tokens": "30"}`,
+		`{"start_time": "2026-10-14T12:02:00Z", "user": "bob", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 200, ` +
+			`"key_id": null, "duration": 40, "input_tokens": "-", "output_tokens": null, "total_tokens": 7}`,
+		`{"start_time": "2026-10-14T12:03:00Z", "user": "bob", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 200, "input_tokens": "many"}`,
+	}, "\n")
+
+	requests, result, err := usage.ParseAccessLogRequests(strings.NewReader(lines))
+	require.NoError(t, err)
+	assert.Equal(t, usage.AccessLogResult{Accepted: 2, Skipped: 1}, result)
+	require.Len(t, requests, 2)
+
+	alice := requests[0]
+	assert.Equal(t, "6f1c2d6e", alice.RequestID)
+	assert.Equal(t, "k1", alice.KeyID)
+	assert.Equal(t, "premium", alice.Subscription)
+	assert.Equal(t, "llm/granite", alice.Model)
+	assert.Equal(t, "cc-42", alice.CostCenter)
+	assert.Equal(t, "acme", alice.OrganizationID)
+	assert.Equal(t, int64(850), alice.LatencyMs)
+	assert.Equal(t, int64(120), alice.InputTokens)
+	assert.Equal(t, int64(30), alice.OutputTokens)
+	assert.Equal(t, int64(150), alice.TotalTokens, "total defaults to input plus output")
+
+	bob := requests[1]
+	assert.Empty(t, bob.KeyID)
+	assert.Zero(t, bob.InputTokens)
+	assert.Equal(t, int64(7), bob.TotalTokens)
+
+	event := usage.NewRequestEvent("/maas-api/test", alice)
+	assert.Equal(t, "6f1c2d6e", event.ID)
+	assert.Equal(t, usage.RequestEventType, event.Type)
+	assert.Equal(t, "1.0", event.SpecVersion)
+	assert.Equal(t, "llm/granite", event.Subject)
+
+	// Without a request ID, the event ID is derived from the line, so a line sent twice
+	// has the same ID.
+	again, _, err := usage.ParseAccessLogRequests(strings.NewReader(strings.Split(lines, "\n")[1]))
+	require.NoError(t, err)
+	bobEvent := usage.NewRequestEvent("/maas-api/test", bob)
+	assert.NotEmpty(t, bobEvent.ID)
+	assert.Equal(t, bobEvent.ID, usage.NewRequestEvent("/maas-api/test", again[0]).ID)
+}
+
+func TestParseAccessLogBatch_Health(t *testing.T) {
+	lines := strings.Join([]string{
+		`{"start_time": "2026-10-14T12:01:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200, "duration": 850}`,
+		`{"start_time": "2026-10-14T12:02:00Z", "user": null, "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 503, "duration": 30}`,
+		`{"start_time": "2026-10-14T12:03:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 429, "duration": 2}`,
+		`{"start_time": "2026-10-14T12:04:00Z", "user": "alice", "subscription_key": "", "response_code": 500}`,
+	}, "\n")
+
+	batch, err := usage.ParseAccessLogBatch(strings.NewReader(lines))
+	require.NoError(t, err)
+	assert.Equal(t, usage.AccessLogResult{Accepted: 1, Skipped: 3}, batch.Result)
+	require.Len(t, batch.Requests, 1)
+	assert.Equal(t, []usage.HealthObservation{
+		{Model: "llm/granite", StartTime: time.Date(2026, 10, 14, 12, 1, 0, 0, time.UTC), ResponseCode: 200, LatencyMs: 850},
+		{Model: "llm/granite", StartTime: time.Date(2026, 10, 14, 12, 2, 0, 0, time.UTC), ResponseCode: 503, LatencyMs: 30},
+	}, batch.Health, "server errors count towards health, client errors do not")
+}
+
+func TestParseAccessLogs_LineTooLong(t *testing.T) {
+	_, _, err := usage.ParseAccessLogs(strings.NewReader(strings.Repeat("x", 65<<10)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds 64KiB")
+}
+
+func TestCountKeyRequests(t *testing.T) {
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	records := usage.CountKeyRequests([]usage.RequestUsage{
+		{StartTime: start.Add(time.Minute), Username: "alice", KeyID: "key-a", TotalTokens: 10},
+		{StartTime: start.Add(2 * time.Minute), Username: "alice", KeyID: "key-a", InputTokens: 5, OutputTokens: 1, TotalTokens: 6},
+		{StartTime: start.Add(time.Hour), Username: "alice", KeyID: "key-a", TotalTokens: 1},
+		{StartTime: start, Username: "bob", TotalTokens: 100},
+	})
+	assert.Equal(t, []usage.KeyRecord{
+		{KeyID: "key-a", Username: "alice", WindowStart: start, Requests: 2, InputTokens: 5, OutputTokens: 1, TotalTokens: 16},
+		{KeyID: "key-a", Username: "alice", WindowStart: start.Add(time.Hour), Requests: 1, TotalTokens: 1},
+	}, records)
+}
@@ -0,0 +1,489 @@
+package usage
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tracing"
+)
+
+const (
+	// AnomalyEventType is the CloudEvents type of the alert of a flagged API key.
+	AnomalyEventType = "io.opendatahub.maas.usage.anomaly.v1"
+
+	// anomalyBaseline is the range before an hour whose mean the requests of the hour are
+	// compared to.
+	anomalyBaseline = 24 * time.Hour
+	// anomalyCheckInterval is the minimum interval between two checks of the requests of
+	// a key in an hour, which each query the store.
+	anomalyCheckInterval = time.Minute
+	// maxTrackedChecks bounds the keys and hours whose last check is remembered.
+	maxTrackedChecks = 10000
+	// maxTrackedNetworks bounds the networks of the keys known to be in the store.
+	maxTrackedNetworks = 100000
+)
+
+// AnomalyRule is the rule that flagged an API key.
+type AnomalyRule string
+
+const (
+	// AnomalySpike is an hour with SpikeFactor times the hourly mean of the requests of
+	// the key over the previous 24 hours.
+	AnomalySpike AnomalyRule = "spike"
+	// AnomalyNewNetwork is a request from a network the key was not used from before.
+	AnomalyNewNetwork AnomalyRule = "new_network"
+	// AnomalyOffHours is an hour outside business hours with MinRequests requests.
+	AnomalyOffHours AnomalyRule = "off_hours"
+)
+
+// Anomaly is an unusual use of an API key, such as that of a leaked key.
+type Anomaly struct {
+	KeyID    string      `json:"keyId"`
+	Username string      `json:"user"`
+	Rule     AnomalyRule `json:"rule"`
+	// WindowStart is the hour of the requests. A key is flagged once per rule and hour,
+	// and per network for new_network.
+	WindowStart time.Time `json:"windowStart"`
+	// Network is the new network of a new_network anomaly: "AS<number>", or the /24
+	// (IPv4) or /48 (IPv6) prefix of addresses without a known ASN.
+	Network string `json:"network,omitempty"`
+	// Requests is the requests of the key in the hour, or from the new network in the
+	// ingested access log batch.
+	Requests   int64     `json:"requests"`
+	Detail     string    `json:"detail"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// AnomalyEvent is the alert of a flagged API key in the structured JSON format of
+// CloudEvents 1.0.
+type AnomalyEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Anomaly   `json:"data"`
+}
+
+// NewAnomalyEvent returns the alert of an anomaly, with the key ID as subject. Its ID is
+// derived from the key, rule, hour and network, so that receivers can drop duplicates.
+func NewAnomalyEvent(source string, a Anomaly) AnomalyEvent {
+	id := fmt.Sprintf("%s-%s-%d", a.KeyID, a.Rule, a.WindowStart.Unix())
+	if a.Network != "" {
+		id += "-" + a.Network
+	}
+	return AnomalyEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            AnomalyEventType,
+		Subject:         a.KeyID,
+		Time:            a.DetectedAt,
+		DataContentType: "application/json",
+		Data:            a,
+	}
+}
+
+// BusinessHours are the hours of Monday to Friday the API keys are expected to be used.
+type BusinessHours struct {
+	// Start is the first hour and End the hour after the last, between 0 and 24.
+	Start, End int
+	Location   *time.Location
+}
+
+// ParseBusinessHours parses business hours such as "8-18" in an IANA time zone, UTC
+// when empty.
+func ParseBusinessHours(hours, timezone string) (*BusinessHours, error) {
+	first, last, ok := strings.Cut(hours, "-")
+	start, startErr := strconv.Atoi(strings.TrimSpace(first))
+	end, endErr := strconv.Atoi(strings.TrimSpace(last))
+	if !ok || startErr != nil || endErr != nil || start < 0 || end > 24 || start >= end {
+		return nil, fmt.Errorf("business hours %q must be <start>-<end> with 0 <= start < end <= 24", hours)
+	}
+	location := time.UTC
+	if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+		}
+	}
+	return &BusinessHours{Start: start, End: end, Location: location}, nil
+}
+
+// contains reports whether the hour starting at t is a business hour.
+func (b *BusinessHours) contains(t time.Time) bool {
+	local := t.In(b.Location)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	return local.Hour() >= b.Start && local.Hour() < b.End
+}
+
+// NetworkTable maps the IP addresses to the autonomous systems announcing them.
+type NetworkTable struct {
+	// bits are the prefix lengths of the table, longest first.
+	bits     []int
+	prefixes map[netip.Prefix]string
+}
+
+// LoadNetworkTable reads a network table file, see ParseNetworkTable.
+func LoadNetworkTable(path string) (*NetworkTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseNetworkTable(f)
+}
+
+// ParseNetworkTable reads lines of a prefix and the number of the autonomous system
+// announcing it, such as "203.0.113.0/24 64500" or "2001:db8::/32 AS64501". Blank lines
+// and lines starting with # are ignored.
+func ParseNetworkTable(r io.Reader) (*NetworkTable, error) {
+	t := &NetworkTable{prefixes: map[netip.Prefix]string{}}
+	lengths := map[int]bool{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: want a prefix and an ASN", n)
+		}
+		prefix, err := netip.ParsePrefix(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid ASN %q", n, fields[1])
+		}
+		prefix = prefix.Masked()
+		t.prefixes[prefix] = "AS" + strconv.FormatUint(asn, 10)
+		lengths[prefix.Bits()] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for bits := range lengths {
+		t.bits = append(t.bits, bits)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(t.bits)))
+	return t, nil
+}
+
+// network returns the network of a client address: the ASN of the longest prefix of the
+// table it falls into, or its /24 (IPv4) or /48 (IPv6) prefix. ok is false for an
+// address that does not parse.
+func (t *NetworkTable) network(address string) (string, bool) {
+	address, _, _ = strings.Cut(address, ",")
+	address = strings.TrimSpace(address)
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		addrPort, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return "", false
+		}
+		addr = addrPort.Addr()
+	}
+	addr = addr.Unmap().WithZone("")
+	if t != nil {
+		for _, bits := range t.bits {
+			if bits > addr.BitLen() {
+				continue
+			}
+			prefix, _ := addr.Prefix(bits)
+			if asn, ok := t.prefixes[prefix]; ok {
+				return asn, true
+			}
+		}
+	}
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, _ := addr.Prefix(bits)
+	return prefix.String(), true
+}
+
+// AnomalyConfig are the rules of an AnomalyDetector.
+type AnomalyConfig struct {
+	// SpikeFactor flags a key whose requests in an hour reach SpikeFactor times their
+	// hourly mean over the previous 24 hours. Keys without requests in the previous 24
+	// hours are not flagged. 0 disables the rule.
+	SpikeFactor float64
+	// MinRequests is the requests of a key in an hour below which it is not flagged as a
+	// spike or an off-hours burst.
+	MinRequests int64
+	// NewNetwork flags a key used from a network it was not used from before. The
+	// networks of the first access log batch with client addresses of a key are learned.
+	NewNetwork bool
+	// Networks maps the client addresses to their ASN; nil uses their prefix.
+	Networks *NetworkTable
+	// BusinessHours flags a key used outside of them; nil disables the rule.
+	BusinessHours *BusinessHours
+	// WebhookURL is the endpoint the alerts of the flagged keys are posted to. Empty
+	// disables the alerts; the keys are still flagged in the store.
+	WebhookURL string
+}
+
+// AnomalyDetector flags the API keys whose usage in the ingested access logs looks like
+// that of a leaked credential, and alerts a webhook. A nil *AnomalyDetector detects
+// nothing.
+type AnomalyDetector struct {
+	store  Store
+	config AnomalyConfig
+	source string
+	client *http.Client
+	logger *logger.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	checked  map[string]time.Time
+	networks map[string]struct{}
+}
+
+// NewAnomalyDetector creates a detector flagging keys in store, whose alerts have the
+// given CloudEvents source.
+func NewAnomalyDetector(log *logger.Logger, store Store, source string, config AnomalyConfig) *AnomalyDetector {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &AnomalyDetector{
+		store:    store,
+		config:   config,
+		source:   source,
+		client:   &http.Client{Timeout: eventSendTimeout, Transport: tracing.Transport(nil)},
+		logger:   log,
+		now:      time.Now,
+		checked:  map[string]time.Time{},
+		networks: map[string]struct{}{},
+	}
+}
+
+// Observe checks the requests of an access log batch, whose API key records are stored,
+// and flags the keys matching a rule. The detection is best effort: a failure is logged.
+func (d *AnomalyDetector) Observe(ctx context.Context, requests []RequestUsage) {
+	if d == nil {
+		return
+	}
+	var anomalies []Anomaly
+	if d.config.SpikeFactor > 0 || d.config.BusinessHours != nil {
+		for _, r := range CountKeyRequests(requests) {
+			anomalies = append(anomalies, d.checkHour(ctx, r)...)
+		}
+	}
+	if d.config.NewNetwork {
+		anomalies = append(anomalies, d.checkNetworks(ctx, requests)...)
+	}
+	for _, a := range anomalies {
+		d.flag(ctx, a)
+	}
+}
+
+// checkHour checks the requests of the key in the hour of a record of the batch against
+// the spike and off-hours rules.
+func (d *AnomalyDetector) checkHour(ctx context.Context, r KeyRecord) []Anomaly {
+	if !d.due(r.KeyID, r.WindowStart) {
+		return nil
+	}
+	records, err := d.store.KeyUsage(ctx, r.KeyID, Query{
+		From:        r.WindowStart.Add(-anomalyBaseline),
+		To:          r.WindowStart.Add(WindowSize),
+		Granularity: GranularityHour,
+	})
+	if err != nil {
+		d.logger.ErrorContext(ctx, "Failed to query API key usage for anomaly detection", "error", err, "keyId", r.KeyID)
+		return nil
+	}
+	var current, previous int64
+	for _, record := range records {
+		if record.WindowStart.Equal(r.WindowStart) {
+			current += record.Requests
+		} else {
+			previous += record.Requests
+		}
+	}
+	if current < d.config.MinRequests {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	hour := Anomaly{KeyID: r.KeyID, Username: r.Username, WindowStart: r.WindowStart, Requests: current}
+	mean := float64(previous) / anomalyBaseline.Hours()
+	if d.config.SpikeFactor > 0 && previous > 0 && float64(current) >= d.config.SpikeFactor*mean {
+		spike := hour
+		spike.Rule = AnomalySpike
+		spike.Detail = fmt.Sprintf("%d requests in an hour, %.1fx the hourly mean of %.1f over the previous 24 hours",
+			current, float64(current)/mean, mean)
+		anomalies = append(anomalies, spike)
+	}
+	if hours := d.config.BusinessHours; hours != nil && !hours.contains(r.WindowStart) {
+		offHours := hour
+		offHours.Rule = AnomalyOffHours
+		offHours.Detail = fmt.Sprintf("%d requests in the hour starting %s, outside business hours",
+			current, r.WindowStart.In(hours.Location).Format("Mon 15:04 MST"))
+		anomalies = append(anomalies, offHours)
+	}
+	return anomalies
+}
+
+// due reports whether the requests of the key in the hour are to be checked, at most
+// once per anomalyCheckInterval.
+func (d *AnomalyDetector) due(keyID string, window time.Time) bool {
+	key := keyID + "@" + strconv.FormatInt(window.Unix(), 10)
+	now := d.now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if last, ok := d.checked[key]; ok && now.Sub(last) < anomalyCheckInterval {
+		return false
+	}
+	if len(d.checked) >= maxTrackedChecks {
+		for k, last := range d.checked {
+			if now.Sub(last) >= anomalyCheckInterval {
+				delete(d.checked, k)
+			}
+		}
+		if len(d.checked) >= maxTrackedChecks {
+			return true
+		}
+	}
+	d.checked[key] = now
+	return true
+}
+
+// keyNetworks are the requests of a key of a batch per network.
+type keyNetworks struct {
+	username string
+	networks []string
+	requests map[string]int64
+	last     time.Time
+}
+
+// checkNetworks records the networks the keys of the batch were used from and returns
+// the new networks of the keys that were used from others before.
+func (d *AnomalyDetector) checkNetworks(ctx context.Context, requests []RequestUsage) []Anomaly {
+	byKey := map[string]*keyNetworks{}
+	var order []string
+	for _, r := range requests {
+		if r.KeyID == "" || r.clientAddress == "" {
+			continue
+		}
+		network, ok := d.config.Networks.network(r.clientAddress)
+		if !ok {
+			continue
+		}
+		k, seen := byKey[r.KeyID]
+		if !seen {
+			k = &keyNetworks{username: r.Username, requests: map[string]int64{}}
+			byKey[r.KeyID] = k
+			order = append(order, r.KeyID)
+		}
+		if k.requests[network] == 0 {
+			k.networks = append(k.networks, network)
+		}
+		k.requests[network]++
+		if r.StartTime.After(k.last) {
+			k.last = r.StartTime
+		}
+	}
+
+	var anomalies []Anomaly
+	for _, keyID := range order {
+		k := byKey[keyID]
+		unknown := d.unknownNetworks(keyID, k.networks)
+		if len(unknown) == 0 {
+			continue
+		}
+		added, known, err := d.store.AddKeyNetworks(ctx, keyID, unknown, k.last)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "Failed to record API key networks", "error", err, "keyId", keyID)
+			continue
+		}
+		d.rememberNetworks(keyID, unknown)
+		if known == 0 {
+			continue
+		}
+		for _, network := range added {
+			anomalies = append(anomalies, Anomaly{
+				KeyID:       keyID,
+				Username:    k.username,
+				Rule:        AnomalyNewNetwork,
+				WindowStart: windowStart(k.last),
+				Network:     network,
+				Requests:    k.requests[network],
+				Detail:      fmt.Sprintf("%d requests from %s, a network the key was not used from before", k.requests[network], network),
+			})
+		}
+	}
+	return anomalies
+}
+
+// unknownNetworks returns the networks not known to be recorded for the key.
+func (d *AnomalyDetector) unknownNetworks(keyID string, networks []string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var unknown []string
+	for _, network := range networks {
+		if _, ok := d.networks[keyID+" "+network]; !ok {
+			unknown = append(unknown, network)
+		}
+	}
+	return unknown
+}
+
+// rememberNetworks remembers that the networks of the key are recorded, forgetting all
+// the networks when there are too many.
+func (d *AnomalyDetector) rememberNetworks(keyID string, networks []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.networks)+len(networks) > maxTrackedNetworks {
+		clear(d.networks)
+	}
+	for _, network := range networks {
+		d.networks[keyID+" "+network] = struct{}{}
+	}
+}
+
+// flag records the anomaly in the store and alerts the webhook in the background, unless
+// the key was already flagged for it.
+func (d *AnomalyDetector) flag(ctx context.Context, a Anomaly) {
+	a.DetectedAt = d.now().UTC()
+	flagged, err := d.store.FlagKey(ctx, a)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "Failed to flag API key", "error", err, "keyId", a.KeyID, "rule", a.Rule)
+		return
+	}
+	if !flagged {
+		return
+	}
+	d.logger.WarnContext(ctx, "API key usage anomaly", "keyId", a.KeyID, "user", a.Username,
+		"rule", a.Rule, "network", a.Network, "detail", a.Detail)
+	if d.config.WebhookURL != "" {
+		go d.alert(NewAnomalyEvent(d.source, a))
+	}
+}
+
+// alert posts the alert of an anomaly to the webhook.
+func (d *AnomalyDetector) alert(event AnomalyEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventSendTimeout)
+	defer cancel()
+	if _, err := postJSON(ctx, d.client, d.config.WebhookURL, cloudEventContentType, event); err != nil {
+		d.logger.Error("Failed to send usage anomaly alert", "keyId", event.Data.KeyID, "rule", event.Data.Rule, "error", err)
+	}
+}
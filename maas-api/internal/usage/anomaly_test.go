@@ -0,0 +1,280 @@
+package usage //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// alertReceiver is a webhook recording the alerts it is posted.
+type alertReceiver struct {
+	mu     sync.Mutex
+	alerts []AnomalyEvent
+}
+
+func (a *alertReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var event AnomalyEvent
+	if r.Header.Get("Content-Type") != cloudEventContentType || json.NewDecoder(r.Body).Decode(&event) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.alerts = append(a.alerts, event)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *alertReceiver) received() []AnomalyEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]AnomalyEvent(nil), a.alerts...)
+}
+
+// newTestDetector returns a detector alerting a test webhook, whose clock is set by the
+// returned function.
+func newTestDetector(t *testing.T, store Store, config AnomalyConfig) (*AnomalyDetector, *alertReceiver, func(time.Time)) {
+	t.Helper()
+	receiver := &alertReceiver{}
+	srv := httptest.NewServer(receiver)
+	t.Cleanup(srv.Close)
+	config.WebhookURL = srv.URL
+	d := NewAnomalyDetector(logger.Development(), store, "/maas-api/test", config)
+	var mu sync.Mutex
+	var now time.Time
+	d.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	return d, receiver, func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+}
+
+func TestParseBusinessHours(t *testing.T) {
+	hours, err := ParseBusinessHours("8-18", "Europe/Paris")
+	require.NoError(t, err)
+	// Wednesday 14 October 2026, 07:00 UTC is 09:00 in Paris.
+	assert.True(t, hours.contains(time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)))
+	assert.False(t, hours.contains(time.Date(2026, 10, 14, 16, 0, 0, 0, time.UTC)), "18:00 in Paris")
+	assert.False(t, hours.contains(time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)), "Saturday")
+
+	hours, err = ParseBusinessHours("0-24", "")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, hours.Location)
+
+	for _, spec := range []string{"", "8", "18-8", "8-25", "a-b"} {
+		_, err := ParseBusinessHours(spec, "")
+		assert.Error(t, err, spec)
+	}
+	_, err = ParseBusinessHours("8-18", "Mars/Olympus")
+	assert.Error(t, err)
+}
+
+func TestNetworkTable(t *testing.T) {
+	table, err := ParseNetworkTable(strings.NewReader(`
+# prefix ASN
+203.0.113.0/24 64500
+203.0.113.128/25 AS64501
+2001:db8::/32 64502
+`))
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		address string
+		want    string
+	}{
+		{"203.0.113.7", "AS64500"},
+		{"203.0.113.200:51234", "AS64501"},
+		{"::ffff:203.0.113.7", "AS64500"},
+		{"2001:db8:1::1", "AS64502"},
+		{"198.51.100.7, 10.0.0.1", "198.51.100.0/24"},
+		{"[2001:db9:1:2::1]:443", "2001:db9:1::/48"},
+	} {
+		got, ok := table.network(tt.address)
+		assert.True(t, ok, tt.address)
+		assert.Equal(t, tt.want, got, tt.address)
+	}
+	var none *NetworkTable
+	got, ok := none.network("203.0.113.7")
+	assert.True(t, ok)
+	assert.Equal(t, "203.0.113.0/24", got)
+	_, ok = none.network("-")
+	assert.False(t, ok)
+
+	_, err = ParseNetworkTable(strings.NewReader("203.0.113.0/24"))
+	require.Error(t, err)
+	_, err = ParseNetworkTable(strings.NewReader("203.0.113.0/24 ASX"))
+	require.Error(t, err)
+}
+
+func TestAnomalyDetector_Spike(t *testing.T) {
+	store := NewMockStore()
+	hour := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	var records []KeyRecord
+	for h := 1; h <= 24; h++ {
+		records = append(records, KeyRecord{KeyID: "k-alice", Username: "alice", WindowStart: hour.Add(-time.Duration(h) * time.Hour), Requests: 10})
+	}
+	records = append(records,
+		KeyRecord{KeyID: "k-alice", Username: "alice", WindowStart: hour, Requests: 150},
+		KeyRecord{KeyID: "k-new", Username: "bob", WindowStart: hour, Requests: 5000},
+	)
+	require.NoError(t, store.AddKeyRecords(t.Context(), records))
+
+	d, receiver, setNow := newTestDetector(t, store, AnomalyConfig{SpikeFactor: 10, MinRequests: 100})
+	setNow(hour.Add(30 * time.Minute))
+	batch := []RequestUsage{
+		{KeyID: "k-alice", Username: "alice", StartTime: hour.Add(29 * time.Minute)},
+		{KeyID: "k-new", Username: "bob", StartTime: hour.Add(29 * time.Minute)},
+	}
+	d.Observe(t.Context(), batch)
+
+	anomalies, err := store.Anomalies(t.Context(), hour, hour.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, anomalies, 1, "keys without requests in the previous 24 hours are not flagged")
+	assert.Equal(t, AnomalySpike, anomalies[0].Rule)
+	assert.Equal(t, "k-alice", anomalies[0].KeyID)
+	assert.Equal(t, int64(150), anomalies[0].Requests)
+	assert.Equal(t, "150 requests in an hour, 15.0x the hourly mean of 10.0 over the previous 24 hours", anomalies[0].Detail)
+
+	require.Eventually(t, func() bool { return len(receiver.received()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	alert := receiver.received()[0]
+	assert.Equal(t, AnomalyEventType, alert.Type)
+	assert.Equal(t, "/maas-api/test", alert.Source)
+	assert.Equal(t, "k-alice", alert.Subject)
+	assert.Equal(t, fmt.Sprintf("k-alice-spike-%d", hour.Unix()), alert.ID)
+
+	// The key is flagged once per hour, and checked at most once a minute.
+	setNow(hour.Add(40 * time.Minute))
+	d.Observe(t.Context(), batch)
+	anomalies, err = store.Anomalies(t.Context(), hour, hour.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, anomalies, 1)
+	assert.False(t, d.due("k-alice", hour))
+}
+
+func TestAnomalyDetector_OffHours(t *testing.T) {
+	store := NewMockStore()
+	night := time.Date(2026, 10, 14, 3, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.AddKeyRecords(t.Context(), []KeyRecord{
+		{KeyID: "k-alice", Username: "alice", WindowStart: night, Requests: 120},
+		{KeyID: "k-alice", Username: "alice", WindowStart: day, Requests: 500},
+		{KeyID: "k-bob", Username: "bob", WindowStart: night, Requests: 99},
+	}))
+	hours, err := ParseBusinessHours("8-18", "UTC")
+	require.NoError(t, err)
+	d, _, setNow := newTestDetector(t, store, AnomalyConfig{MinRequests: 100, BusinessHours: hours})
+	setNow(day.Add(time.Minute))
+
+	d.Observe(t.Context(), []RequestUsage{
+		{KeyID: "k-alice", Username: "alice", StartTime: night.Add(time.Minute)},
+		{KeyID: "k-alice", Username: "alice", StartTime: day.Add(time.Minute)},
+		{KeyID: "k-bob", Username: "bob", StartTime: night.Add(time.Minute)},
+	})
+
+	anomalies, err := store.Anomalies(t.Context(), night, day.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, AnomalyOffHours, anomalies[0].Rule)
+	assert.Equal(t, night, anomalies[0].WindowStart)
+	assert.Equal(t, "120 requests in the hour starting Wed 03:00 UTC, outside business hours", anomalies[0].Detail)
+}
+
+func TestAnomalyDetector_NewNetwork(t *testing.T) {
+	store := NewMockStore()
+	table, err := ParseNetworkTable(strings.NewReader("203.0.113.0/24 64500\n"))
+	require.NoError(t, err)
+	d, receiver, setNow := newTestDetector(t, store, AnomalyConfig{NewNetwork: true, Networks: table})
+	start := time.Date(2026, 10, 14, 12, 1, 0, 0, time.UTC)
+	setNow(start)
+
+	// The networks of the first batch of a key are learned.
+	d.Observe(t.Context(), []RequestUsage{
+		{KeyID: "k-alice", Username: "alice", StartTime: start, clientAddress: "203.0.113.7"},
+		{KeyID: "k-alice", Username: "alice", StartTime: start, clientAddress: "198.51.100.7:43122"},
+		{Username: "bob", StartTime: start, clientAddress: "192.0.2.1"},
+	})
+	anomalies, err := store.Anomalies(t.Context(), start.Add(-time.Hour), start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, anomalies)
+
+	d.Observe(t.Context(), []RequestUsage{
+		{KeyID: "k-alice", Username: "alice", StartTime: start.Add(time.Minute), clientAddress: "203.0.113.99"},
+		{KeyID: "k-alice", Username: "alice", StartTime: start.Add(time.Minute), clientAddress: "192.0.2.10"},
+		{KeyID: "k-alice", Username: "alice", StartTime: start.Add(2 * time.Minute), clientAddress: "192.0.2.11"},
+	})
+	anomalies, err = store.Anomalies(t.Context(), start.Add(-time.Hour), start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, AnomalyNewNetwork, anomalies[0].Rule)
+	assert.Equal(t, "192.0.2.0/24", anomalies[0].Network)
+	assert.Equal(t, int64(2), anomalies[0].Requests)
+	assert.Equal(t, "2 requests from 192.0.2.0/24, a network the key was not used from before", anomalies[0].Detail)
+
+	require.Eventually(t, func() bool { return len(receiver.received()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, "192.0.2.0/24", receiver.received()[0].Data.Network)
+}
+
+func TestAnomalyDetector_Nil(t *testing.T) {
+	var d *AnomalyDetector
+	d.Observe(t.Context(), []RequestUsage{{KeyID: "k1"}})
+}
+
+func TestGetAnomalies(t *testing.T) {
+	router, store := setupUsageHandler(t)
+	flagged, err := store.FlagKey(t.Context(), Anomaly{
+		KeyID: "k-alice", Username: "alice", Rule: AnomalySpike, Requests: 150,
+		WindowStart: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), DetectedAt: time.Date(2026, 10, 14, 11, 30, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.True(t, flagged)
+
+	get := func(user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/usage/anomalies", nil)
+		req.Header.Set("X-Test-User", user)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusForbidden, get("alice").Code)
+
+	w := get("admin")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp AnomaliesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Anomalies, 1)
+	assert.Equal(t, "k-alice", resp.Anomalies[0].KeyID)
+	assert.Equal(t, time.Date(2026, 10, 13, 12, 0, 0, 0, time.UTC), resp.From)
+}
+
+func TestIngestAccessLogs_DetectsNewNetwork(t *testing.T) {
+	router, store := setupUsageHandler(t)
+	line := func(address string) string {
+		return `{"start_time": "2026-10-14T12:01:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", ` +
+			`"response_code": 200, "key_id": "k-alice", "client_address": "` + address + `"}`
+	}
+	for _, body := range []string{line("203.0.113.7:40112"), line("198.51.100.7, 10.128.0.12")} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/internal/v1/usage/access-logs", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	anomalies, err := store.Anomalies(t.Context(), time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, "198.51.100.0/24", anomalies[0].Network)
+	assert.Equal(t, "alice", anomalies[0].Username)
+}
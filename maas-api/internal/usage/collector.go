@@ -0,0 +1,81 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/subscription"
+)
+
+// Collector periodically records the token usage counted by Limitador.
+type Collector struct {
+	store              Store
+	counters           CounterReader
+	modelLister        models.MaaSModelRefLister
+	subscriptionLister subscription.Lister
+	interval           time.Duration
+	logger             *logger.Logger
+	now                func() time.Time
+}
+
+// NewCollector creates a collector scraping the counters of the subscriptions' token
+// limits every interval.
+func NewCollector(log *logger.Logger, store Store, counters CounterReader, modelLister models.MaaSModelRefLister, subscriptionLister subscription.Lister, interval time.Duration) *Collector {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Collector{
+		store:              store,
+		counters:           counters,
+		modelLister:        modelLister,
+		subscriptionLister: subscriptionLister,
+		interval:           interval,
+		logger:             log,
+		now:                time.Now,
+	}
+}
+
+// Start scrapes the counters every interval until ctx is done.
+func (c *Collector) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			if err := c.Collect(ctx); err != nil {
+				c.logger.Error("Failed to collect usage", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Collect scrapes the counters of every model once and records what they consumed
+// since the previous scrape. Models whose counters cannot be read are skipped.
+func (c *Collector) Collect(ctx context.Context) error {
+	modelRefs, err := c.modelLister.List()
+	if err != nil {
+		return fmt.Errorf("failed to list MaaSModelRefs: %w", err)
+	}
+	subscriptions, err := c.subscriptionLister.List()
+	if err != nil {
+		return fmt.Errorf("failed to list MaaSSubscriptions: %w", err)
+	}
+
+	var samples []CounterSample
+	for _, limits := range buildModelLimits(modelRefs, subscriptions) {
+		counters, err := c.counters.Counters(ctx, limits.Namespace)
+		if err != nil {
+			c.logger.Warn("Failed to read Limitador counters", "model", limits.Model, "namespace", limits.Namespace, "error", err)
+			continue
+		}
+		samples = append(samples, counterSamples(limits, counters, c.now().UTC())...)
+	}
+	return c.store.RecordCounters(ctx, samples)
+}
@@ -0,0 +1,224 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/metrics"
+)
+
+const (
+	// CloudEventsSpecVersion is the CloudEvents version of the usage events.
+	CloudEventsSpecVersion = "1.0"
+	// RequestEventType is the CloudEvents type of the event of a metered request.
+	RequestEventType = "io.opendatahub.maas.usage.request.v1"
+
+	// eventQueueSize bounds the events waiting for a sink; newer events are dropped
+	// when it is full.
+	eventQueueSize = 10000
+	// maxEventBatch bounds the events a sink is sent at once.
+	maxEventBatch = 100
+	// eventSendAttempts is the number of times a batch is sent to a failing sink.
+	eventSendAttempts = 3
+	// eventSendTimeout bounds an attempt to send a batch.
+	eventSendTimeout = 10 * time.Second
+)
+
+// RequestUsage is what a request served by a model consumed, from its access log line.
+// The tokens are only known when the access log reports them.
+type RequestUsage struct {
+	RequestID      string    `json:"requestId,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	Username       string    `json:"user"`
+	KeyID          string    `json:"keyId,omitempty"`
+	Subscription   string    `json:"subscription"`
+	Model          string    `json:"model"`
+	CostCenter     string    `json:"costCenter,omitempty"`
+	OrganizationID string    `json:"organizationId,omitempty"`
+	ResponseCode   int       `json:"responseCode"`
+	LatencyMs      int64     `json:"latencyMs"`
+	InputTokens    int64     `json:"inputTokens"`
+	OutputTokens   int64     `json:"outputTokens"`
+	TotalTokens    int64     `json:"totalTokens"`
+
+	// lineHash identifies the request when the access log has no request ID.
+	lineHash string
+	// clientAddress is the client address of the access log, which the events do not
+	// report.
+	clientAddress string
+}
+
+// CloudEvent is a usage event in the structured JSON format of CloudEvents 1.0.
+type CloudEvent struct {
+	SpecVersion     string       `json:"specversion"`
+	ID              string       `json:"id"`
+	Source          string       `json:"source"`
+	Type            string       `json:"type"`
+	Subject         string       `json:"subject,omitempty"`
+	Time            time.Time    `json:"time"`
+	DataContentType string       `json:"datacontenttype"`
+	Data            RequestUsage `json:"data"`
+}
+
+// NewRequestEvent returns the event of a request. Its ID is the request ID, or a hash of
+// its access log line, so that consumers can drop the duplicates of a redelivery.
+func NewRequestEvent(source string, r RequestUsage) CloudEvent {
+	id := r.RequestID
+	if id == "" {
+		id = r.lineHash
+	}
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            RequestEventType,
+		Subject:         r.Model,
+		Time:            r.StartTime,
+		DataContentType: "application/json",
+		Data:            r,
+	}
+}
+
+// EventSink delivers usage events to an external system.
+type EventSink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	// Send delivers the events. A failed send may have delivered some of them.
+	Send(ctx context.Context, events []CloudEvent) error
+}
+
+// EventPublisher sends the events of the metered requests to sinks, in the background.
+// Each sink has its own queue, so a slow or failing sink does not hold the others back.
+// Delivery is at least once while maas-api runs; events queued at shutdown are lost.
+// A nil *EventPublisher publishes nothing.
+type EventPublisher struct {
+	source  string
+	workers []*sinkWorker
+}
+
+type sinkWorker struct {
+	sink          EventSink
+	queue         chan CloudEvent
+	metrics       metrics.UsageEventRecorder
+	logger        *logger.Logger
+	flushInterval time.Duration
+	retryDelay    time.Duration
+}
+
+// NewEventPublisher creates a publisher of events with the given CloudEvents source.
+func NewEventPublisher(log *logger.Logger, source string, sinks ...EventSink) *EventPublisher {
+	if log == nil {
+		log = logger.Production()
+	}
+	p := &EventPublisher{source: source}
+	for _, sink := range sinks {
+		p.workers = append(p.workers, &sinkWorker{
+			sink:          sink,
+			queue:         make(chan CloudEvent, eventQueueSize),
+			logger:        log,
+			flushInterval: time.Second,
+			retryDelay:    time.Second,
+		})
+	}
+	return p
+}
+
+// SetMetrics sets the recorder of the delivered, failed and dropped events.
+func (p *EventPublisher) SetMetrics(recorder metrics.UsageEventRecorder) {
+	for _, w := range p.workers {
+		w.metrics = recorder
+	}
+}
+
+// Start delivers the published events until ctx is done.
+func (p *EventPublisher) Start(ctx context.Context) {
+	for _, w := range p.workers {
+		go w.run(ctx)
+	}
+}
+
+// Publish queues the events of the requests for every sink. It does not block: the
+// events a full queue cannot take are dropped.
+func (p *EventPublisher) Publish(requests []RequestUsage) {
+	if p == nil {
+		return
+	}
+	for _, w := range p.workers {
+		dropped := 0
+		for _, r := range requests {
+			select {
+			case w.queue <- NewRequestEvent(p.source, r):
+			default:
+				dropped++
+			}
+		}
+		if dropped > 0 {
+			w.logger.Warn("Usage event queue is full, dropping events", "sink", w.sink.Name(), "dropped", dropped)
+			w.record("dropped", dropped)
+		}
+	}
+}
+
+// run sends the queued events in batches of up to maxEventBatch, at most flushInterval
+// after the first one was queued.
+func (w *sinkWorker) run(ctx context.Context) {
+	for {
+		var batch []CloudEvent
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-w.queue:
+			batch = append(batch, event)
+		}
+
+		flush := time.NewTimer(w.flushInterval)
+	fill:
+		for len(batch) < maxEventBatch {
+			select {
+			case event := <-w.queue:
+				batch = append(batch, event)
+			case <-flush.C:
+				break fill
+			case <-ctx.Done():
+				break fill
+			}
+		}
+		flush.Stop()
+		w.send(ctx, batch)
+	}
+}
+
+// send delivers a batch, retrying a failing sink with an increasing delay.
+func (w *sinkWorker) send(ctx context.Context, batch []CloudEvent) {
+	// The batch is still sent once when maas-api shuts down.
+	sendCtx := context.WithoutCancel(ctx)
+	delay := w.retryDelay
+	var err error
+	for attempt := 1; attempt <= eventSendAttempts; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(sendCtx, eventSendTimeout)
+		err = w.sink.Send(attemptCtx, batch)
+		cancel()
+		if err == nil {
+			w.record("delivered", len(batch))
+			return
+		}
+		if attempt == eventSendAttempts || ctx.Err() != nil {
+			break
+		}
+		w.logger.Debug("Failed to send usage events, retrying", "sink", w.sink.Name(), "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	w.logger.Error("Failed to send usage events", "sink", w.sink.Name(), "events", len(batch), "error", err)
+	w.record("failed", len(batch))
+}
+
+func (w *sinkWorker) record(outcome string, count int) {
+	if w.metrics != nil {
+		w.metrics.RecordUsageEvents(w.sink.Name(), outcome, count)
+	}
+}
@@ -0,0 +1,215 @@
+package usage //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// fakeSink records the batches it is sent, failing the first failures sends.
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]CloudEvent
+	attempts int
+}
+
+func (s *fakeSink) Name() string { return "fake" }
+
+func (s *fakeSink) Send(_ context.Context, events []CloudEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("sink unavailable")
+	}
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func (s *fakeSink) delivered() []CloudEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []CloudEvent
+	for _, b := range s.batches {
+		out = append(out, b...)
+	}
+	return out
+}
+
+type fakeEventRecorder struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (r *fakeEventRecorder) RecordUsageEvents(sink, outcome string, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counts == nil {
+		r.counts = map[string]int{}
+	}
+	r.counts[sink+"/"+outcome] += count
+}
+
+func (r *fakeEventRecorder) get(key string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counts[key]
+}
+
+func newTestPublisher(t *testing.T, sinks ...EventSink) (*EventPublisher, *fakeEventRecorder) {
+	t.Helper()
+	p := NewEventPublisher(logger.Development(), "/maas-api/test", sinks...)
+	for _, w := range p.workers {
+		w.flushInterval = 10 * time.Millisecond
+		w.retryDelay = time.Millisecond
+	}
+	recorder := &fakeEventRecorder{}
+	p.SetMetrics(recorder)
+	return p, recorder
+}
+
+func testRequests(n int) []RequestUsage {
+	requests := make([]RequestUsage, n)
+	for i := range requests {
+		requests[i] = RequestUsage{
+			RequestID:    "req-" + strings.Repeat("x", i),
+			StartTime:    time.Date(2026, 10, 14, 12, 0, i, 0, time.UTC),
+			Username:     "alice",
+			Subscription: "premium",
+			Model:        "llm/granite",
+			ResponseCode: 200,
+		}
+	}
+	return requests
+}
+
+func TestEventPublisher_DeliversInBatches(t *testing.T) {
+	sink := &fakeSink{}
+	p, recorder := newTestPublisher(t, sink)
+	p.Start(t.Context())
+
+	p.Publish(testRequests(maxEventBatch + 5))
+
+	require.Eventually(t, func() bool { return len(sink.delivered()) == maxEventBatch+5 }, 5*time.Second, 5*time.Millisecond)
+	sink.mu.Lock()
+	assert.Len(t, sink.batches[0], maxEventBatch)
+	sink.mu.Unlock()
+	events := sink.delivered()
+	assert.Equal(t, "req-", events[0].ID)
+	assert.Equal(t, "/maas-api/test", events[0].Source)
+	assert.Equal(t, "alice", events[0].Data.Username)
+	assert.Equal(t, maxEventBatch+5, recorder.get("fake/delivered"))
+}
+
+func TestEventPublisher_RetriesFailingSink(t *testing.T) {
+	sink := &fakeSink{failures: eventSendAttempts - 1}
+	p, recorder := newTestPublisher(t, sink)
+	p.Start(t.Context())
+
+	p.Publish(testRequests(2))
+
+	require.Eventually(t, func() bool { return len(sink.delivered()) == 2 }, 5*time.Second, 5*time.Millisecond)
+	assert.Zero(t, recorder.get("fake/failed"))
+}
+
+func TestEventPublisher_GivesUpAfterAttempts(t *testing.T) {
+	failing := &fakeSink{failures: eventSendAttempts}
+	healthy := &fakeSink{}
+	p, recorder := newTestPublisher(t, failing, healthy)
+	p.Start(t.Context())
+
+	p.Publish(testRequests(3))
+
+	require.Eventually(t, func() bool { return recorder.get("fake/failed") == 3 }, 5*time.Second, 5*time.Millisecond)
+	assert.Empty(t, failing.delivered())
+	require.Eventually(t, func() bool { return len(healthy.delivered()) == 3 }, 5*time.Second, 5*time.Millisecond,
+		"a failing sink does not hold the others back")
+}
+
+func TestEventPublisher_DropsWhenQueueIsFull(t *testing.T) {
+	sink := &fakeSink{}
+	p, recorder := newTestPublisher(t, sink)
+	p.workers[0].queue = make(chan CloudEvent, 2)
+
+	// Not started: nothing drains the queue.
+	p.Publish(testRequests(5))
+
+	assert.Equal(t, 3, recorder.get("fake/dropped"))
+	assert.Len(t, p.workers[0].queue, 2)
+}
+
+func TestEventPublisher_Nil(t *testing.T) {
+	var p *EventPublisher
+	assert.NotPanics(t, func() { p.Publish(testRequests(1)) })
+}
+
+func TestIngestAccessLogs_PublishesEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sink := &fakeSink{}
+	p, _ := newTestPublisher(t, sink)
+	p.Start(t.Context())
+	store := NewMockStore()
+	h := NewHandler(logger.Development(), store, groupAdminChecker{})
+	h.SetEventPublisher(p)
+	router := gin.New()
+	router.POST("/internal/v1/usage/access-logs", h.IngestAccessLogs)
+
+	body := strings.Join([]string{
+		`{"start_time": "2026-10-14T12:01:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200, "key_id": "k1", "cost_center": "cc-42", "duration": 12}`,
+		`{"start_time": "2026-10-14T12:01:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 429}`,
+	}, "\n")
+	req := httptest.NewRequest(http.MethodPost, "/internal/v1/usage/access-logs", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	records, _, err := store.Query(t.Context(), Query{
+		From: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), Granularity: GranularityDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(1), records[0].Requests)
+
+	require.Eventually(t, func() bool { return len(sink.delivered()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	event := sink.delivered()[0]
+	assert.Equal(t, RequestEventType, event.Type)
+	assert.Equal(t, "k1", event.Data.KeyID)
+	assert.Equal(t, "cc-42", event.Data.CostCenter)
+	assert.Equal(t, int64(12), event.Data.LatencyMs)
+	assert.Equal(t, "premium", event.Data.Subscription)
+}
+
+func TestIngestAccessLogs_StoreFailureDoesNotPublish(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sink := &fakeSink{}
+	p, _ := newTestPublisher(t, sink)
+	h := NewHandler(logger.Development(), failingStore{}, groupAdminChecker{})
+	h.SetEventPublisher(p)
+	router := gin.New()
+	router.POST("/internal/v1/usage/access-logs", h.IngestAccessLogs)
+
+	body := `{"start_time": "2026-10-14T12:01:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200}`
+	req := httptest.NewRequest(http.MethodPost, "/internal/v1/usage/access-logs", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, p.workers[0].queue)
+}
+
+type failingStore struct{ Store }
+
+func (failingStore) AddRecords(context.Context, []Record) error {
+	return errors.New("database unavailable")
+}
@@ -0,0 +1,490 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/middleware"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+const (
+	// DefaultQueryRange is the range of a query without "from".
+	DefaultQueryRange = 24 * time.Hour
+
+	maxHourlyRange = 31 * 24 * time.Hour
+	maxDailyRange  = 366 * 24 * time.Hour
+
+	// MaxAccessLogBatchBytes bounds the body of a batch of access log lines.
+	MaxAccessLogBatchBytes = 16 << 20
+
+	// defaultTopWindow is the window of a leaderboard without "window".
+	defaultTopWindow = 24 * time.Hour
+)
+
+// AdminChecker reports whether a user may read the usage of all users.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, user *token.UserContext) (bool, error)
+}
+
+// ShipperAuthenticator authenticates the log shipper posting the access logs.
+type ShipperAuthenticator interface {
+	// Authenticate reports whether token is a valid token of the log shipper.
+	Authenticate(ctx context.Context, token string) (bool, error)
+}
+
+// KeyOwners finds the owners of the API keys.
+type KeyOwners interface {
+	// KeyOwner returns the user and tenant of an API key; found is false when it does
+	// not exist.
+	KeyOwner(ctx context.Context, keyID string) (username, tenant string, found bool, err error)
+}
+
+// Handler serves the usage API.
+type Handler struct {
+	store        Store
+	adminChecker AdminChecker
+	keyOwners    KeyOwners
+	shippers     ShipperAuthenticator
+	logger       *logger.Logger
+	audit        *audit.Log
+	events       *EventPublisher
+	anomalies    *AnomalyDetector
+	now          func() time.Time
+}
+
+// SetAuditLog sets the audit log of the admin reads.
+func (h *Handler) SetAuditLog(auditLog *audit.Log) {
+	h.audit = auditLog
+}
+
+// SetEventPublisher sets the publisher of the events of the requests of the ingested
+// access logs.
+func (h *Handler) SetEventPublisher(publisher *EventPublisher) {
+	h.events = publisher
+}
+
+// SetAnomalyDetector sets the detector of the anomalies of the API keys of the ingested
+// access logs.
+func (h *Handler) SetAnomalyDetector(detector *AnomalyDetector) {
+	h.anomalies = detector
+}
+
+// SetKeyOwners sets the owners of the API keys whose usage GET /v1/api-keys/{id}/usage
+// reads.
+func (h *Handler) SetKeyOwners(owners KeyOwners) {
+	h.keyOwners = owners
+}
+
+// SetShipperAuthenticator sets the authenticator of the log shipper posting the access
+// logs. Without it, the access logs are rejected.
+func (h *Handler) SetShipperAuthenticator(shippers ShipperAuthenticator) {
+	h.shippers = shippers
+}
+
+// NewHandler creates a usage handler.
+func NewHandler(log *logger.Logger, store Store, adminChecker AdminChecker) *Handler {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Handler{store: store, adminChecker: adminChecker, logger: log, now: time.Now}
+}
+
+// Response is the body of GET /v1/usage and GET /v1/admin/usage.
+type Response struct {
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+	Granularity Granularity `json:"granularity"`
+	Usage       []Record    `json:"usage"`
+	Totals      Totals      `json:"totals"`
+	// Truncated is true when more records matched than were returned.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func (h *Handler) getUserContext(c *gin.Context) *token.UserContext {
+	userCtx, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User context not found"})
+		return nil
+	}
+
+	user, ok := userCtx.(*token.UserContext)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user context type"})
+		return nil
+	}
+
+	return user
+}
+
+// parseQuery reads the query parameters from, to, granularity, subscription and model.
+// from and to are RFC 3339 times; from is rounded down to its window.
+func (h *Handler) parseQuery(c *gin.Context) (Query, error) {
+	q := Query{
+		Granularity:  Granularity(c.DefaultQuery("granularity", string(GranularityHour))),
+		Subscription: c.Query("subscription"),
+		Model:        c.Query("model"),
+		To:           h.now().UTC(),
+	}
+	if q.Granularity != GranularityHour && q.Granularity != GranularityDay {
+		return q, fmt.Errorf("%w: granularity must be hour or day", ErrInvalidQuery)
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return q, fmt.Errorf("%w: to must be an RFC 3339 time", ErrInvalidQuery)
+		}
+		q.To = t.UTC()
+	}
+	q.From = q.To.Add(-DefaultQueryRange)
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return q, fmt.Errorf("%w: from must be an RFC 3339 time", ErrInvalidQuery)
+		}
+		q.From = t.UTC()
+	}
+	q.From = bucketStart(q.From, q.Granularity)
+	if !q.From.Before(q.To) {
+		return q, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	maxRange := maxHourlyRange
+	if q.Granularity == GranularityDay {
+		maxRange = maxDailyRange
+	}
+	if q.To.Sub(q.From) > maxRange {
+		return q, fmt.Errorf("%w: range must not exceed %d days for granularity %s", ErrInvalidQuery, int(maxRange.Hours()/24), q.Granularity)
+	}
+	return q, nil
+}
+
+func (h *Handler) respond(c *gin.Context, q Query) {
+	records, truncated, err := h.store.Query(c.Request.Context(), q)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to query usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query usage"})
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		From:        q.From,
+		To:          q.To,
+		Granularity: q.Granularity,
+		Usage:       records,
+		Totals:      Sum(records),
+		Truncated:   truncated,
+	})
+}
+
+// GetUsage handles GET /v1/usage: the usage of the authenticated user.
+func (h *Handler) GetUsage(c *gin.Context) {
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	q, err := h.parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Username = user.Username
+	h.respond(c, q)
+}
+
+// GetAdminUsage handles GET /v1/admin/usage: the usage of all users, or of the user
+// of the "user" query parameter. Only admins may call it.
+func (h *Handler) GetAdminUsage(c *gin.Context) {
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	isAdmin, err := h.adminChecker.IsAdmin(c.Request.Context(), user)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
+	if !isAdmin {
+		h.audit.Record(c.Request.Context(), audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeDenied))
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can read the usage of all users"})
+		return
+	}
+	q, err := h.parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Username = c.Query("user")
+	event := audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeSuccess)
+	event.TargetUser = q.Username
+	h.audit.Record(c.Request.Context(), event)
+	h.respond(c, q)
+}
+
+// KeyUsageResponse is the body of GET /v1/api-keys/{id}/usage.
+type KeyUsageResponse struct {
+	KeyID       string      `json:"keyId"`
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+	Granularity Granularity `json:"granularity"`
+	Usage       []KeyRecord `json:"usage"`
+	Totals      Totals      `json:"totals"`
+}
+
+// GetKeyUsage handles GET /v1/api-keys/{id}/usage: the usage of an API key, from the
+// access logs that report its ID. Users may read the usage of their own keys, admins
+// that of the keys of their tenant. Like GET /v1/api-keys/{id}, keys the user may not
+// read are not found.
+func (h *Handler) GetKeyUsage(c *gin.Context) {
+	keyID := c.Param("id")
+	middleware.Attribute(c, middleware.Attribution{KeyID: keyID})
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	if h.keyOwners == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+	owner, tenant, found, err := h.keyOwners.KeyOwner(c.Request.Context(), keyID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to get API key", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API key"})
+		return
+	}
+	if !found || tenant != user.Tenant {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+	if owner != user.Username {
+		isAdmin, err := h.adminChecker.IsAdmin(c.Request.Context(), user)
+		if err != nil {
+			h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		outcome := audit.OutcomeSuccess
+		if !isAdmin {
+			outcome = audit.OutcomeDenied
+		}
+		event := audit.NewEvent(c, user.Username, audit.ActionUsageRead, outcome)
+		event.TargetUser, event.KeyID = owner, keyID
+		h.audit.Record(c.Request.Context(), event)
+		if !isAdmin {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+	}
+
+	q, err := h.parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	records, err := h.store.KeyUsage(c.Request.Context(), keyID, q)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to query API key usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query usage"})
+		return
+	}
+	c.JSON(http.StatusOK, KeyUsageResponse{
+		KeyID:       keyID,
+		From:        q.From,
+		To:          q.To,
+		Granularity: q.Granularity,
+		Usage:       records,
+		Totals:      SumKeys(records),
+	})
+}
+
+// TopResponse is the body of GET /v1/admin/usage/top.
+type TopResponse struct {
+	From      time.Time  `json:"from"`
+	To        time.Time  `json:"to"`
+	By        Dimension  `json:"by"`
+	Consumers []Consumer `json:"consumers"`
+}
+
+// parseTopQuery reads the query parameters window, by and limit. window is a duration
+// such as 24h, or a number of days such as 7d; the leaderboard starts at the start of
+// the window now minus window falls into.
+func (h *Handler) parseTopQuery(c *gin.Context) (TopQuery, error) {
+	q := TopQuery{By: Dimension(c.DefaultQuery("by", string(DimensionUser))), To: h.now().UTC(), Limit: DefaultTopLimit}
+	if q.By != DimensionUser && q.By != DimensionModel && q.By != DimensionKey {
+		return q, fmt.Errorf("%w: by must be user, model or key", ErrInvalidQuery)
+	}
+	window := defaultTopWindow
+	if w := c.Query("window"); w != "" {
+		var err error
+		if days, ok := strings.CutSuffix(w, "d"); ok {
+			var n int
+			n, err = strconv.Atoi(days)
+			window = time.Duration(n) * 24 * time.Hour
+		} else {
+			window, err = time.ParseDuration(w)
+		}
+		if err != nil || window <= 0 {
+			return q, fmt.Errorf("%w: window must be a positive duration such as 24h or 7d", ErrInvalidQuery)
+		}
+	}
+	if window > maxDailyRange {
+		return q, fmt.Errorf("%w: window must not exceed %d days", ErrInvalidQuery, int(maxDailyRange.Hours()/24))
+	}
+	q.From = windowStart(q.To.Add(-window))
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > MaxTopLimit {
+			return q, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxTopLimit)
+		}
+		q.Limit = n
+	}
+	return q, nil
+}
+
+// GetTopUsage handles GET /v1/admin/usage/top: the users, models or API keys that
+// consumed the most tokens in the window. Only admins may call it.
+func (h *Handler) GetTopUsage(c *gin.Context) {
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	isAdmin, err := h.adminChecker.IsAdmin(c.Request.Context(), user)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
+	if !isAdmin {
+		h.audit.Record(c.Request.Context(), audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeDenied))
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can read the usage of all users"})
+		return
+	}
+	q, err := h.parseTopQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	event := audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeSuccess)
+	event.Details = map[string]string{"leaderboard": string(q.By)}
+	h.audit.Record(c.Request.Context(), event)
+
+	consumers, err := h.store.Top(c.Request.Context(), q)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to query top consumers", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query usage"})
+		return
+	}
+	c.JSON(http.StatusOK, TopResponse{From: q.From, To: q.To, By: q.By, Consumers: consumers})
+}
+
+// AnomaliesResponse is the body of GET /v1/admin/usage/anomalies.
+type AnomaliesResponse struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Anomalies []Anomaly `json:"anomalies"`
+}
+
+// GetAnomalies handles GET /v1/admin/usage/anomalies: the API keys flagged by the
+// anomaly detection between from and to. Only admins may call it.
+func (h *Handler) GetAnomalies(c *gin.Context) {
+	user := h.getUserContext(c)
+	if user == nil {
+		return
+	}
+	isAdmin, err := h.adminChecker.IsAdmin(c.Request.Context(), user)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to check admin status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
+	if !isAdmin {
+		h.audit.Record(c.Request.Context(), audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeDenied))
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can read the API key anomalies"})
+		return
+	}
+	q, err := h.parseQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.audit.Record(c.Request.Context(), audit.NewEvent(c, user.Username, audit.ActionUsageRead, audit.OutcomeSuccess))
+	anomalies, err := h.store.Anomalies(c.Request.Context(), q.From, q.To)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to query API key anomalies", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query anomalies"})
+		return
+	}
+	c.JSON(http.StatusOK, AnomaliesResponse{From: q.From, To: q.To, Anomalies: anomalies})
+}
+
+// AuthenticateShipper lets only the log shipper, authenticated with its bearer token,
+// through to IngestAccessLogs: the ingested requests are billed and alerted on, so
+// they must not be forged.
+func (h *Handler) AuthenticateShipper(c *gin.Context) {
+	if h.shippers == nil {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access log ingestion is disabled: ACCESS_LOG_SHIPPER_SERVICE_ACCOUNT is not set"})
+		return
+	}
+	bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found || strings.TrimSpace(bearer) == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing log shipper token"})
+		return
+	}
+	ok, err := h.shippers.Authenticate(c.Request.Context(), strings.TrimSpace(bearer))
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to authenticate the log shipper", "error", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate the log shipper"})
+		return
+	}
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid log shipper token"})
+		return
+	}
+	c.Next()
+}
+
+// IngestAccessLogs handles POST /internal/v1/usage/access-logs: a batch of
+// newline-delimited JSON gateway access log lines, whose served requests are added to
+// the request counts, and to those of their API keys, and published as usage events.
+// The requests of the API keys are checked for anomalies. The latency and 5xx errors of the requests are added to the health of their models.
+func (h *Handler) IngestAccessLogs(c *gin.Context) {
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, MaxAccessLogBatchBytes)
+	batch, err := ParseAccessLogBatch(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "access log batch exceeds 16MiB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	requests := batch.Requests
+	if err := h.store.AddRecords(c.Request.Context(), CountRequests(requests)); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to record access log usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record usage"})
+		return
+	}
+	// The batch is not failed, since sending it again would count its requests twice:
+	// the key counts and model health of a batch are lost instead.
+	if err := h.store.AddKeyRecords(c.Request.Context(), CountKeyRequests(requests)); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to record API key usage", "error", err)
+	} else {
+		h.anomalies.Observe(c.Request.Context(), requests)
+	}
+	if err := h.store.AddHealth(c.Request.Context(), CountHealth(batch.Health)); err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to record model health", "error", err)
+	}
+	// A batch that failed to be recorded is sent again by the log shipper, so its events
+	// are only published once it is.
+	h.events.Publish(requests)
+	c.JSON(http.StatusOK, batch.Result)
+}
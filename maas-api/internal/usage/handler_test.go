@@ -0,0 +1,397 @@
+package usage //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/audit"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/token"
+)
+
+type groupAdminChecker struct{}
+
+func (groupAdminChecker) IsAdmin(_ context.Context, user *token.UserContext) (bool, error) {
+	return slices.Contains(user.Groups, "admin-users"), nil
+}
+
+type keyOwner struct{ username, tenant string }
+
+type fakeKeyOwners map[string]keyOwner
+
+func (f fakeKeyOwners) KeyOwner(_ context.Context, keyID string) (string, string, bool, error) {
+	owner, ok := f[keyID]
+	return owner.username, owner.tenant, ok, nil
+}
+
+// fakeShippers accepts only the token "shipper-token".
+type fakeShippers struct{}
+
+func (fakeShippers) Authenticate(_ context.Context, token string) (bool, error) {
+	return token == "shipper-token", nil
+}
+
+func setupUsageHandler(t *testing.T) (*gin.Engine, *MockStore) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := NewMockStore()
+	require.NoError(t, store.AddRecords(t.Context(), []Record{
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC), Requests: 3, TotalTokens: 300},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), Requests: 1, TotalTokens: 50},
+		{Username: "bob", Subscription: "basic", Model: "llm/granite", WindowStart: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), Requests: 2, TotalTokens: 80},
+	}))
+	h := NewHandler(logger.Development(), store, groupAdminChecker{})
+	h.SetKeyOwners(fakeKeyOwners{
+		"k-alice":    {username: "alice"},
+		"k-alice-ci": {username: "alice"},
+		"k-other":    {username: "alice", tenant: "other-tenant"},
+	})
+	h.now = func() time.Time { return time.Date(2026, 10, 14, 12, 15, 0, 0, time.UTC) }
+	h.SetAnomalyDetector(NewAnomalyDetector(logger.Development(), store, "/maas-api/test", AnomalyConfig{NewNetwork: true}))
+
+	router := gin.New()
+	withUser := func(c *gin.Context) {
+		user := &token.UserContext{Username: c.GetHeader("X-Test-User")}
+		if user.Username == "admin" {
+			user.Groups = []string{"admin-users"}
+		}
+		c.Set("user", user)
+	}
+	router.GET("/v1/usage", withUser, h.GetUsage)
+	router.GET("/v1/admin/usage", withUser, h.GetAdminUsage)
+	router.GET("/v1/admin/usage/top", withUser, h.GetTopUsage)
+	router.GET("/v1/admin/usage/anomalies", withUser, h.GetAnomalies)
+	router.GET("/v1/api-keys/:id/usage", withUser, h.GetKeyUsage)
+	router.POST("/internal/v1/usage/access-logs", h.IngestAccessLogs)
+	return router, store
+}
+
+func getUsage(t *testing.T, router *gin.Engine, user, target string) (int, Response) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp Response
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func TestGetUsage_OwnUsageOnly(t *testing.T) {
+	router, _ := setupUsageHandler(t)
+
+	code, resp := getUsage(t, router, "alice", "/v1/usage?user=bob")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Usage, 2)
+	for _, r := range resp.Usage {
+		assert.Equal(t, "alice", r.Username)
+	}
+	assert.Equal(t, Totals{Requests: 4, TotalTokens: 350}, resp.Totals)
+	assert.Equal(t, time.Date(2026, 10, 13, 12, 0, 0, 0, time.UTC), resp.From, "from defaults to 24 hours before now, rounded down to the hour")
+}
+
+func TestGetUsage_DailyGranularity(t *testing.T) {
+	router, _ := setupUsageHandler(t)
+
+	code, resp := getUsage(t, router, "alice", "/v1/usage?granularity=day&from=2026-10-14T00:00:00Z")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Usage, 1)
+	assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), resp.Usage[0].WindowStart)
+	assert.Equal(t, int64(350), resp.Usage[0].TotalTokens)
+}
+
+func TestGetUsage_InvalidQuery(t *testing.T) {
+	router, _ := setupUsageHandler(t)
+
+	for _, target := range []string{
+		"/v1/usage?granularity=week",
+		"/v1/usage?from=yesterday",
+		"/v1/usage?from=2026-10-15T00:00:00Z",
+		"/v1/usage?from=2026-01-01T00:00:00Z",
+	} {
+		code, _ := getUsage(t, router, "alice", target)
+		assert.Equal(t, http.StatusBadRequest, code, target)
+	}
+}
+
+func TestGetAdminUsage(t *testing.T) {
+	router, _ := setupUsageHandler(t)
+
+	code, _ := getUsage(t, router, "alice", "/v1/admin/usage")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, resp := getUsage(t, router, "admin", "/v1/admin/usage")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, resp.Usage, 3)
+	assert.Equal(t, Totals{Requests: 6, TotalTokens: 430}, resp.Totals)
+
+	code, resp = getUsage(t, router, "admin", "/v1/admin/usage?user=bob&model=llm/granite")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Usage, 1)
+	assert.Equal(t, "basic", resp.Usage[0].Subscription)
+}
+
+func TestGetAdminUsage_Audited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auditStore := audit.NewMockStore()
+	h := NewHandler(logger.Development(), NewMockStore(), groupAdminChecker{})
+	h.SetAuditLog(audit.NewLog(logger.Development(), auditStore))
+	router := gin.New()
+	router.GET("/v1/admin/usage", func(c *gin.Context) {
+		user := &token.UserContext{Username: c.GetHeader("X-Test-User")}
+		if user.Username == "admin" {
+			user.Groups = []string{"admin-users"}
+		}
+		c.Set("user", user)
+	}, h.GetAdminUsage)
+
+	code, _ := getUsage(t, router, "alice", "/v1/admin/usage")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = getUsage(t, router, "admin", "/v1/admin/usage?user=bob")
+	assert.Equal(t, http.StatusOK, code)
+
+	events, _, err := auditStore.Query(t.Context(), audit.Query{Action: audit.ActionUsageRead})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "alice", events[0].Actor)
+	assert.Equal(t, audit.OutcomeDenied, events[0].Outcome)
+	assert.Equal(t, "admin", events[1].Actor)
+	assert.Equal(t, "bob", events[1].TargetUser)
+}
+
+func TestIngestAccessLogs(t *testing.T) {
+	router, store := setupUsageHandler(t)
+
+	body := `{"start_time": "2026-10-14T11:30:00Z", "user": "bob", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 200}
+{"start_time": "2026-10-14T11:31:00Z", "user": "bob", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 403}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/internal/v1/usage/access-logs", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"accepted": 1, "skipped": 1}`, w.Body.String())
+
+	records, _, err := store.Query(t.Context(), Query{
+		Username: "bob",
+		From:     time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC),
+		To:       time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(3), records[0].Requests)
+
+	health, err := store.ModelHealth(t.Context(),
+		time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, health, 1)
+	assert.Equal(t, int64(1), health[0].Requests, "the 403 does not count towards the health of the model")
+}
+
+func TestAuthenticateShipper(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"start_time": "2026-10-14T11:30:00Z", "user": "bob", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 200}`
+
+	tests := []struct {
+		name          string
+		shippers      ShipperAuthenticator
+		authorization string
+		wantCode      int
+	}{
+		{name: "ingestion disabled", authorization: "Bearer shipper-token", wantCode: http.StatusForbidden},
+		{name: "missing token", shippers: fakeShippers{}, wantCode: http.StatusUnauthorized},
+		{name: "forged token", shippers: fakeShippers{}, authorization: "Bearer forged", wantCode: http.StatusUnauthorized},
+		{name: "log shipper token", shippers: fakeShippers{}, authorization: "Bearer shipper-token", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMockStore()
+			h := NewHandler(logger.Development(), store, groupAdminChecker{})
+			if tt.shippers != nil {
+				h.SetShipperAuthenticator(tt.shippers)
+			}
+			router := gin.New()
+			router.POST("/internal/v1/usage/access-logs", h.AuthenticateShipper, h.IngestAccessLogs)
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/v1/usage/access-logs", strings.NewReader(body))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.wantCode, w.Code, w.Body.String())
+
+			records, _, err := store.Query(t.Context(), Query{
+				From: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+			})
+			require.NoError(t, err)
+			if tt.wantCode == http.StatusOK {
+				assert.Len(t, records, 1)
+			} else {
+				assert.Empty(t, records, "rejected access logs must not be recorded")
+			}
+		})
+	}
+}
+
+func getKeyUsage(t *testing.T, router *gin.Engine, user, target string) (int, KeyUsageResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp KeyUsageResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func TestGetKeyUsage(t *testing.T) {
+	router, store := setupUsageHandler(t)
+	require.NoError(t, store.AddKeyRecords(t.Context(), []KeyRecord{
+		{KeyID: "k-alice", Username: "alice", WindowStart: time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC), Requests: 2, InputTokens: 100, OutputTokens: 60, TotalTokens: 160},
+		{KeyID: "k-alice", Username: "alice", WindowStart: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), Requests: 1, InputTokens: 20, OutputTokens: 10, TotalTokens: 30},
+		{KeyID: "k-alice-ci", Username: "alice", WindowStart: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), Requests: 5},
+	}))
+
+	code, resp := getKeyUsage(t, router, "alice", "/v1/api-keys/k-alice/usage")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "k-alice", resp.KeyID)
+	require.Len(t, resp.Usage, 2)
+	assert.Equal(t, time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC), resp.Usage[0].WindowStart)
+	assert.Equal(t, Totals{Requests: 3, InputTokens: 120, OutputTokens: 70, TotalTokens: 190}, resp.Totals,
+		"only the usage of the key counts, not that of the other keys of its owner")
+
+	code, resp = getKeyUsage(t, router, "alice", "/v1/api-keys/k-alice/usage?granularity=day")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Usage, 1)
+	assert.Equal(t, int64(3), resp.Usage[0].Requests)
+
+	code, resp = getKeyUsage(t, router, "admin", "/v1/api-keys/k-alice-ci/usage")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(5), resp.Totals.Requests)
+
+	code, _ = getKeyUsage(t, router, "alice", "/v1/api-keys/k-alice/usage?granularity=week")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	for _, tt := range []struct{ user, keyID string }{
+		{"bob", "k-alice"},     // another user's key
+		{"alice", "k-other"},   // a key of another tenant
+		{"alice", "k-unknown"}, // a key that does not exist
+	} {
+		code, _ := getKeyUsage(t, router, tt.user, "/v1/api-keys/"+tt.keyID+"/usage")
+		assert.Equal(t, http.StatusNotFound, code, "%s reading %s", tt.user, tt.keyID)
+	}
+}
+
+func TestGetKeyUsage_Audited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auditStore := audit.NewMockStore()
+	h := NewHandler(logger.Development(), NewMockStore(), groupAdminChecker{})
+	h.SetAuditLog(audit.NewLog(logger.Development(), auditStore))
+	h.SetKeyOwners(fakeKeyOwners{"k-alice": {username: "alice"}})
+	router := gin.New()
+	router.GET("/v1/api-keys/:id/usage", func(c *gin.Context) {
+		user := &token.UserContext{Username: c.GetHeader("X-Test-User")}
+		if user.Username == "admin" {
+			user.Groups = []string{"admin-users"}
+		}
+		c.Set("user", user)
+	}, h.GetKeyUsage)
+
+	code, _ := getKeyUsage(t, router, "alice", "/v1/api-keys/k-alice/usage")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = getKeyUsage(t, router, "bob", "/v1/api-keys/k-alice/usage")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = getKeyUsage(t, router, "admin", "/v1/api-keys/k-alice/usage")
+	assert.Equal(t, http.StatusOK, code)
+
+	events, _, err := auditStore.Query(t.Context(), audit.Query{Action: audit.ActionUsageRead})
+	require.NoError(t, err)
+	require.Len(t, events, 2, "only the reads of the keys of another user are audited")
+	assert.Equal(t, "bob", events[0].Actor)
+	assert.Equal(t, audit.OutcomeDenied, events[0].Outcome)
+	assert.Equal(t, "admin", events[1].Actor)
+	assert.Equal(t, "alice", events[1].TargetUser)
+	assert.Equal(t, "k-alice", events[1].KeyID)
+}
+
+func getTopUsage(t *testing.T, router *gin.Engine, user, target string) (int, TopResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", user)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp TopResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func TestGetTopUsage(t *testing.T) {
+	router, store := setupUsageHandler(t)
+	require.NoError(t, store.AddRecords(t.Context(), []Record{
+		{Username: "carol", Subscription: "basic", Model: "llm/llama", WindowStart: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC), Requests: 1, InputTokens: 60, OutputTokens: 40},
+		{Username: "dave", Subscription: "basic", Model: "llm/llama", WindowStart: time.Date(2026, 10, 12, 11, 0, 0, 0, time.UTC), Requests: 9, TotalTokens: 9000},
+	}))
+
+	code, _ := getTopUsage(t, router, "alice", "/v1/admin/usage/top")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, resp := getTopUsage(t, router, "admin", "/v1/admin/usage/top?limit=2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, DimensionUser, resp.By)
+	assert.Equal(t, time.Date(2026, 10, 13, 12, 0, 0, 0, time.UTC), resp.From, "the window defaults to 24h, rounded down to the hour")
+	assert.Equal(t, []Consumer{
+		{Rank: 1, Username: "alice", Requests: 4, TotalTokens: 350, Tokens: 350},
+		{Rank: 2, Username: "carol", Requests: 1, InputTokens: 60, OutputTokens: 40, Tokens: 100},
+	}, resp.Consumers, "tokens counted per direction rank like total tokens")
+
+	code, resp = getTopUsage(t, router, "admin", "/v1/admin/usage/top?by=model&window=7d")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Consumers, 2)
+	assert.Equal(t, Consumer{Rank: 1, Model: "llm/llama", Requests: 10, InputTokens: 60, OutputTokens: 40, TotalTokens: 9000, Tokens: 9100}, resp.Consumers[0])
+	assert.Equal(t, "llm/granite", resp.Consumers[1].Model)
+
+	for _, target := range []string{
+		"/v1/admin/usage/top?by=subscription",
+		"/v1/admin/usage/top?window=-1h",
+		"/v1/admin/usage/top?window=400d",
+		"/v1/admin/usage/top?window=soon",
+		"/v1/admin/usage/top?limit=1000",
+	} {
+		code, _ := getTopUsage(t, router, "admin", target)
+		assert.Equal(t, http.StatusBadRequest, code, target)
+	}
+}
+
+func TestGetTopUsage_ByKey(t *testing.T) {
+	router, _ := setupUsageHandler(t)
+
+	body := `{"start_time": "2026-10-14T11:30:00Z", "user": "bob", "key_id": "key-b", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 200, "total_tokens": "20"}
+{"start_time": "2026-10-14T11:31:00Z", "user": "alice", "key_id": "key-a", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200, "input_tokens": "30", "output_tokens": "10"}
+{"start_time": "2026-10-14T11:32:00Z", "user": "bob", "key_id": "key-b", "subscription_key": "models-as-a-service/basic@llm/granite", "response_code": 200, "total_tokens": "25"}
+{"start_time": "2026-10-14T11:33:00Z", "user": "alice", "subscription_key": "models-as-a-service/premium@llm/granite", "response_code": 200, "total_tokens": "500"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/internal/v1/usage/access-logs", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	code, resp := getTopUsage(t, router, "admin", "/v1/admin/usage/top?by=key&window=1h")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []Consumer{
+		{Rank: 1, Username: "bob", KeyID: "key-b", Requests: 2, TotalTokens: 45, Tokens: 45},
+		{Rank: 2, Username: "alice", KeyID: "key-a", Requests: 1, InputTokens: 30, OutputTokens: 10, TotalTokens: 40, Tokens: 40},
+	}, resp.Consumers, "requests without a key are not ranked by key")
+}
@@ -0,0 +1,200 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+)
+
+const (
+	// HealthWindowSize is the length of the windows the latency and errors of the models
+	// are aggregated into.
+	HealthWindowSize = 5 * time.Minute
+	// HealthRetention is how long the latency and errors of the models are kept.
+	HealthRetention = 24 * time.Hour
+
+	// performanceCacheTTL is how long the performance of the models is reused before the
+	// store is read again.
+	performanceCacheTTL = 30 * time.Second
+)
+
+// latencyBucketsMs are the upper bounds, in milliseconds, of the latency histogram of
+// the models. Slower requests fall into the overflow bucket, infLatencyBucket.
+var latencyBucketsMs = []int64{50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 120000}
+
+const infLatencyBucket int64 = math.MaxInt64
+
+// HealthRecord counts the requests of a model in a window whose latency falls into a
+// bucket of the latency histogram, and those of them that failed with a 5xx status.
+// Model is the MaaSModelRef as "namespace/name".
+type HealthRecord struct {
+	Model       string
+	WindowStart time.Time
+	// LeMs is the upper bound of the latency bucket in milliseconds, math.MaxInt64 for
+	// the overflow bucket.
+	LeMs         int64
+	Requests     int64
+	ServerErrors int64
+}
+
+// HealthObservation is a request of the access logs that counts towards the health of
+// its model: served, or failed with a 5xx status.
+type HealthObservation struct {
+	Model        string
+	StartTime    time.Time
+	ResponseCode int
+	LatencyMs    int64
+}
+
+// healthWindowStart returns the start of the health window t falls into.
+func healthWindowStart(t time.Time) time.Time {
+	return t.UTC().Truncate(HealthWindowSize)
+}
+
+// latencyBucket returns the upper bound of the latency bucket of a duration.
+func latencyBucket(ms int64) int64 {
+	i := sort.Search(len(latencyBucketsMs), func(i int) bool { return latencyBucketsMs[i] >= ms })
+	if i == len(latencyBucketsMs) {
+		return infLatencyBucket
+	}
+	return latencyBucketsMs[i]
+}
+
+// CountHealth returns the requests and server errors per model, window and latency
+// bucket of the observed requests, in the order they first appear.
+func CountHealth(observations []HealthObservation) []HealthRecord {
+	type healthKey struct {
+		model      string
+		windowUnix int64
+		leMs       int64
+	}
+	counts := map[healthKey]*HealthRecord{}
+	var order []healthKey
+	for _, o := range observations {
+		window := healthWindowStart(o.StartTime)
+		key := healthKey{o.Model, window.Unix(), latencyBucket(o.LatencyMs)}
+		record, seen := counts[key]
+		if !seen {
+			record = &HealthRecord{Model: o.Model, WindowStart: window, LeMs: key.leMs}
+			counts[key] = record
+			order = append(order, key)
+		}
+		record.Requests++
+		if o.ResponseCode >= 500 {
+			record.ServerErrors++
+		}
+	}
+
+	records := make([]HealthRecord, 0, len(order))
+	for _, key := range order {
+		records = append(records, *counts[key])
+	}
+	return records
+}
+
+// ModelPerformance returns the performance of each model of the health records of a
+// window, keyed by model. The p95 latency is that of the requests that did not fail,
+// interpolated within its bucket of the histogram; it is the highest finite bound when
+// it falls into the overflow bucket.
+func ModelPerformance(records []HealthRecord, window time.Duration) map[string]models.Performance {
+	type histogram struct {
+		buckets      map[int64]int64 // successful requests per bucket
+		requests     int64
+		serverErrors int64
+	}
+	byModel := map[string]*histogram{}
+	for _, r := range records {
+		h, ok := byModel[r.Model]
+		if !ok {
+			h = &histogram{buckets: map[int64]int64{}}
+			byModel[r.Model] = h
+		}
+		h.buckets[r.LeMs] += r.Requests - r.ServerErrors
+		h.requests += r.Requests
+		h.serverErrors += r.ServerErrors
+	}
+
+	performance := make(map[string]models.Performance, len(byModel))
+	for model, h := range byModel {
+		if h.requests == 0 {
+			continue
+		}
+		p := models.Performance{
+			Window:    fmt.Sprintf("%dm", int(window.Minutes())),
+			Requests:  h.requests,
+			ErrorRate: math.Round(float64(h.serverErrors)/float64(h.requests)*1e4) / 1e4,
+		}
+		if succeeded := h.requests - h.serverErrors; succeeded > 0 {
+			p95 := quantileMs(0.95, h.buckets, succeeded)
+			p.LatencyP95Ms = &p95
+		}
+		performance[model] = p
+	}
+	return performance
+}
+
+// quantileMs returns the q-quantile of a latency histogram of total requests, like
+// Prometheus' histogram_quantile.
+func quantileMs(q float64, buckets map[int64]int64, total int64) int64 {
+	rank := q * float64(total)
+	var lower, cumulative int64
+	for _, upper := range latencyBucketsMs {
+		count := buckets[upper]
+		if count > 0 && float64(cumulative+count) >= rank {
+			return lower + int64(math.Ceil(float64(upper-lower)*(rank-float64(cumulative))/float64(count)))
+		}
+		cumulative += count
+		lower = upper
+	}
+	return latencyBucketsMs[len(latencyBucketsMs)-1]
+}
+
+// PerformanceTracker provides the performance of the models over a rolling window,
+// computed from the health records of the access logs. It reads the store at most once
+// per 30 seconds.
+type PerformanceTracker struct {
+	store  Store
+	window time.Duration
+	logger *logger.Logger
+	now    func() time.Time
+
+	mu          sync.Mutex
+	performance map[string]models.Performance
+	readAt      time.Time
+}
+
+// NewPerformanceTracker creates a tracker of the performance of the models over the
+// last window.
+func NewPerformanceTracker(log *logger.Logger, store Store, window time.Duration) *PerformanceTracker {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &PerformanceTracker{store: store, window: window, logger: log, now: time.Now}
+}
+
+// ModelPerformance returns the performance of the models that served requests in the
+// window, keyed by MaaSModelRef "namespace/name". When the store cannot be read, the
+// last performance read is returned until the next read.
+func (t *PerformanceTracker) ModelPerformance(ctx context.Context) map[string]models.Performance {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now().UTC()
+	if !t.readAt.IsZero() && now.Sub(t.readAt) < performanceCacheTTL {
+		return t.performance
+	}
+	records, err := t.store.ModelHealth(ctx, healthWindowStart(now.Add(-t.window)), now)
+	t.readAt = now
+	if err != nil {
+		t.logger.WarnContext(ctx, "Failed to read model performance", "error", err)
+		return t.performance
+	}
+	t.performance = ModelPerformance(records, t.window)
+	return t.performance
+}
@@ -0,0 +1,71 @@
+package usage_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/models"
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+func TestCountHealth(t *testing.T) {
+	start := time.Date(2026, 10, 14, 12, 1, 0, 0, time.UTC)
+	records := usage.CountHealth([]usage.HealthObservation{
+		{Model: "llm/granite", StartTime: start, ResponseCode: 200, LatencyMs: 80},
+		{Model: "llm/granite", StartTime: start.Add(time.Minute), ResponseCode: 503, LatencyMs: 100},
+		{Model: "llm/granite", StartTime: start.Add(5 * time.Minute), ResponseCode: 200, LatencyMs: 90},
+		{Model: "llm/granite", StartTime: start, ResponseCode: 200, LatencyMs: 200000},
+		{Model: "llm/llama", StartTime: start, ResponseCode: 200, LatencyMs: 0},
+	})
+
+	window := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []usage.HealthRecord{
+		{Model: "llm/granite", WindowStart: window, LeMs: 100, Requests: 2, ServerErrors: 1},
+		{Model: "llm/granite", WindowStart: window.Add(5 * time.Minute), LeMs: 100, Requests: 1},
+		{Model: "llm/granite", WindowStart: window, LeMs: math.MaxInt64, Requests: 1},
+		{Model: "llm/llama", WindowStart: window, LeMs: 50, Requests: 1},
+	}, records)
+}
+
+func TestModelPerformance(t *testing.T) {
+	performance := usage.ModelPerformance([]usage.HealthRecord{
+		{Model: "llm/granite", LeMs: 100, Requests: 10},
+		{Model: "llm/granite", LeMs: 250, Requests: 10, ServerErrors: 2},
+		{Model: "llm/slow", LeMs: math.MaxInt64, Requests: 5},
+		{Model: "llm/down", LeMs: 50, Requests: 3, ServerErrors: 3},
+	}, 15*time.Minute)
+
+	assert.Equal(t, map[string]models.Performance{
+		// 18 successful requests: the p95 is the 17.1th, in the 100-250ms bucket.
+		"llm/granite": {Window: "15m", Requests: 20, LatencyP95Ms: ptr.To(int64(234)), ErrorRate: 0.1},
+		"llm/slow":    {Window: "15m", Requests: 5, LatencyP95Ms: ptr.To(int64(120000))},
+		"llm/down":    {Window: "15m", Requests: 3, ErrorRate: 1},
+	}, performance)
+}
+
+func TestPerformanceTracker(t *testing.T) {
+	store := usage.NewMockStore()
+	now := time.Now()
+	require.NoError(t, store.AddHealth(t.Context(), []usage.HealthRecord{
+		{Model: "llm/granite", WindowStart: now, LeMs: 500, Requests: 4, ServerErrors: 1},
+		{Model: "llm/granite", WindowStart: now.Add(-2 * time.Hour), LeMs: 500, Requests: 100, ServerErrors: 100},
+	}))
+
+	tracker := usage.NewPerformanceTracker(logger.Development(), store, 15*time.Minute)
+	performance := tracker.ModelPerformance(t.Context())
+	require.Contains(t, performance, "llm/granite")
+	assert.Equal(t, int64(4), performance["llm/granite"].Requests, "only the records of the window count")
+	assert.InDelta(t, 0.25, performance["llm/granite"].ErrorRate, 0)
+
+	// The performance is cached between reads of the store.
+	require.NoError(t, store.AddHealth(t.Context(), []usage.HealthRecord{
+		{Model: "llm/granite", WindowStart: now, LeMs: 500, Requests: 4},
+	}))
+	assert.Equal(t, performance, tracker.ModelPerformance(t.Context()))
+}
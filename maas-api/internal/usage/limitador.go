@@ -0,0 +1,229 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tracing"
+)
+
+// userCounterVariable is the counter of the subscriptions' per-user token limits.
+const userCounterVariable = "auth.identity.userid"
+
+// LimitadorLimit is the limit of a Limitador counter.
+type LimitadorLimit struct {
+	Namespace  string   `json:"namespace"`
+	MaxValue   int64    `json:"max_value"`
+	Seconds    int64    `json:"seconds"`
+	Name       string   `json:"name,omitempty"`
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// LimitadorCounter is a counter as returned by Limitador's GET /counters/{namespace}.
+type LimitadorCounter struct {
+	Limit            LimitadorLimit    `json:"limit"`
+	SetVariables     map[string]string `json:"set_variables,omitempty"`
+	Remaining        int64             `json:"remaining"`
+	ExpiresInSeconds int64             `json:"expires_in_seconds"`
+}
+
+// CounterReader reads the active counters of a Limitador namespace.
+type CounterReader interface {
+	Counters(ctx context.Context, namespace string) ([]LimitadorCounter, error)
+}
+
+// LimitadorClient reads counters from Limitador's HTTP API.
+type LimitadorClient struct {
+	client *http.Client
+	url    string
+}
+
+// NewLimitadorClient returns a client of the Limitador HTTP API at limitadorURL, e.g.
+// http://limitador-limitador.kuadrant-system.svc:8080.
+func NewLimitadorClient(limitadorURL string, timeout time.Duration) *LimitadorClient {
+	return &LimitadorClient{client: &http.Client{Timeout: timeout, Transport: tracing.Transport(nil)}, url: limitadorURL}
+}
+
+// Counters sends GET <url>/counters/<namespace> and decodes the counters.
+func (c *LimitadorClient) Counters(ctx context.Context, namespace string) ([]LimitadorCounter, error) {
+	target, err := url.JoinPath(c.url, "counters", url.PathEscape(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Limitador URL %q: %w", c.url, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil, fmt.Errorf("GET %s returned HTTP %d", target, resp.StatusCode)
+	}
+	var counters []LimitadorCounter
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(&counters); err != nil {
+		return nil, fmt.Errorf("failed to decode Limitador counters: %w", err)
+	}
+	return counters, nil
+}
+
+// limitKeyPattern matches the TokenRateLimitPolicy limit key Kuadrant puts in the name
+// or conditions of a Limitador limit, "limit.<key>__<hash>".
+var limitKeyPattern = regexp.MustCompile(`limit\.([a-z0-9][a-z0-9.-]*)__`)
+
+// counterLimitKey returns the TokenRateLimitPolicy limit key of a counter, or "".
+func counterLimitKey(counter LimitadorCounter) string {
+	for _, s := range append([]string{counter.Limit.Name}, counter.Limit.Conditions...) {
+		if m := limitKeyPattern.FindStringSubmatch(s); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// limitKeyInfo is what a subscription's limit key counts.
+type limitKeyInfo struct {
+	Subscription string
+	Direction    Direction
+}
+
+// modelLimits are the subscriptions' token limit keys of a model.
+type modelLimits struct {
+	// Model is the MaaSModelRef as "namespace/name".
+	Model string
+	// Namespace is the Limitador namespace of the limits, that of the model's HTTPRoute.
+	Namespace string
+	Keys      map[string]limitKeyInfo
+}
+
+// subscriptionKeys returns the rolling token limit keys the maas-controller generates
+// for a subscription and a model: "<namespace>-<subscription>-<model>-tokens", its input
+// and output variants, and the same for each entry of spec.owners. The anchored keys of
+// a reset schedule count the same tokens and are left out.
+func subscriptionKeys(subNamespace, subName, modelName string, owners []string) map[string]limitKeyInfo {
+	prefixes := []string{fmt.Sprintf("%s-%s-%s-", subNamespace, subName, modelName)}
+	for _, owner := range owners {
+		prefixes = append(prefixes, prefixes[0]+"owner-"+owner+"-")
+	}
+	keys := map[string]limitKeyInfo{}
+	for _, p := range prefixes {
+		keys[p+"tokens"] = limitKeyInfo{Subscription: subName, Direction: DirectionTotal}
+		keys[p+"input-tokens"] = limitKeyInfo{Subscription: subName, Direction: DirectionInput}
+		keys[p+"output-tokens"] = limitKeyInfo{Subscription: subName, Direction: DirectionOutput}
+	}
+	return keys
+}
+
+// buildModelLimits returns the token limit keys of the models with an HTTPRoute, from
+// the MaaSModelRefs and MaaSSubscriptions.
+func buildModelLimits(modelRefs, subscriptions []*unstructured.Unstructured) []modelLimits {
+	byModel := map[string]*modelLimits{}
+	var order []string
+	for _, u := range modelRefs {
+		routeName, _, _ := unstructured.NestedString(u.Object, "status", "httpRouteName")
+		routeNamespace, _, _ := unstructured.NestedString(u.Object, "status", "httpRouteNamespace")
+		if routeName == "" {
+			continue
+		}
+		if routeNamespace == "" {
+			routeNamespace = u.GetNamespace()
+		}
+		model := u.GetNamespace() + "/" + u.GetName()
+		byModel[model] = &modelLimits{Model: model, Namespace: routeNamespace + "/" + routeName, Keys: map[string]limitKeyInfo{}}
+		order = append(order, model)
+	}
+
+	for _, sub := range subscriptions {
+		var owners []string
+		ownerList, _, _ := unstructured.NestedSlice(sub.Object, "spec", "owners")
+		for _, o := range ownerList {
+			if m, ok := o.(map[string]any); ok {
+				if name, _ := m["name"].(string); name != "" {
+					owners = append(owners, name)
+				}
+			}
+		}
+		refs, _, _ := unstructured.NestedSlice(sub.Object, "spec", "modelRefs")
+		for _, ref := range refs {
+			m, ok := ref.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := m["name"].(string)
+			namespace, _ := m["namespace"].(string)
+			limits, ok := byModel[namespace+"/"+name]
+			if !ok {
+				continue
+			}
+			for key, info := range subscriptionKeys(sub.GetNamespace(), sub.GetName(), name, owners) {
+				limits.Keys[key] = info
+			}
+		}
+	}
+
+	out := make([]modelLimits, 0, len(order))
+	for _, model := range order {
+		if limits := byModel[model]; len(limits.Keys) > 0 {
+			out = append(out, *limits)
+		}
+	}
+	return out
+}
+
+// counterSamples returns the samples of a model's per-user subscription counters. The
+// rates of a limit key each have their own counter of the same tokens; the one with the
+// longest window is kept for each key and user.
+func counterSamples(limits modelLimits, counters []LimitadorCounter, now time.Time) []CounterSample {
+	type keyUser struct{ key, user string }
+	longest := map[keyUser]LimitadorCounter{}
+	var order []keyUser
+	for _, counter := range counters {
+		key := counterLimitKey(counter)
+		if _, ok := limits.Keys[key]; !ok || counter.Limit.MaxValue <= 0 {
+			continue
+		}
+		user := counter.SetVariables[userCounterVariable]
+		if user == "" {
+			// Group and subscription wide counters are not attributed to a user.
+			continue
+		}
+		k := keyUser{key, user}
+		existing, seen := longest[k]
+		if !seen {
+			order = append(order, k)
+		}
+		if !seen || counter.Limit.Seconds > existing.Limit.Seconds {
+			longest[k] = counter
+		}
+	}
+
+	samples := make([]CounterSample, 0, len(order))
+	for _, k := range order {
+		counter := longest[k]
+		info := limits.Keys[k.key]
+		samples = append(samples, CounterSample{
+			Key:          strings.Join([]string{limits.Namespace, k.key, strconv.FormatInt(counter.Limit.Seconds, 10), k.user}, "|"),
+			Username:     k.user,
+			Subscription: info.Subscription,
+			Model:        limits.Model,
+			Direction:    info.Direction,
+			Consumed:     max(counter.Limit.MaxValue-counter.Remaining, 0),
+			ExpiresAt:    now.Add(time.Duration(counter.ExpiresInSeconds) * time.Second),
+			ObservedAt:   now,
+		})
+	}
+	return samples
+}
@@ -0,0 +1,152 @@
+package usage //nolint:testpackage // Testing private helper methods requires same package
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+type staticLister []*unstructured.Unstructured
+
+func (l staticLister) List() ([]*unstructured.Unstructured, error) { return l, nil }
+
+type fakeCounterReader map[string][]LimitadorCounter
+
+func (f fakeCounterReader) Counters(_ context.Context, namespace string) ([]LimitadorCounter, error) {
+	counters, ok := f[namespace]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return counters, nil
+}
+
+func testModelRef(namespace, name, routeName string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{"httpRouteName": routeName},
+	}}
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func testSubscription(name string, owners []string, models ...string) *unstructured.Unstructured {
+	var refs []any
+	for _, m := range models {
+		refs = append(refs, map[string]any{"namespace": "llm", "name": m})
+	}
+	var ownerList []any
+	for _, o := range owners {
+		ownerList = append(ownerList, map[string]any{"name": o})
+	}
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"modelRefs": refs, "owners": ownerList},
+	}}
+	u.SetNamespace("models-as-a-service")
+	u.SetName(name)
+	return u
+}
+
+func testCounter(key string, seconds, maxValue, remaining int64, user string) LimitadorCounter {
+	c := LimitadorCounter{
+		Limit:            LimitadorLimit{Name: "limit." + key + "__3f1a9c", MaxValue: maxValue, Seconds: seconds},
+		Remaining:        remaining,
+		ExpiresInSeconds: 30,
+	}
+	if user != "" {
+		c.SetVariables = map[string]string{userCounterVariable: user}
+	}
+	return c
+}
+
+func TestBuildModelLimits(t *testing.T) {
+	modelRefs := []*unstructured.Unstructured{
+		testModelRef("llm", "granite", "granite-route"),
+		testModelRef("llm", "pending", ""),
+	}
+	subscriptions := []*unstructured.Unstructured{
+		testSubscription("premium", []string{"research"}, "granite", "pending"),
+	}
+
+	got := buildModelLimits(modelRefs, subscriptions)
+	require.Len(t, got, 1, "models without an HTTPRoute have no counters")
+	assert.Equal(t, "llm/granite", got[0].Model)
+	assert.Equal(t, "llm/granite-route", got[0].Namespace)
+	assert.Equal(t, limitKeyInfo{Subscription: "premium", Direction: DirectionTotal}, got[0].Keys["models-as-a-service-premium-granite-tokens"])
+	assert.Equal(t, limitKeyInfo{Subscription: "premium", Direction: DirectionOutput}, got[0].Keys["models-as-a-service-premium-granite-owner-research-output-tokens"])
+}
+
+func TestCounterSamples(t *testing.T) {
+	limits := modelLimits{
+		Model:     "llm/granite",
+		Namespace: "llm/granite-route",
+		Keys:      subscriptionKeys("models-as-a-service", "premium", "granite", nil),
+	}
+	now := time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC)
+	counters := []LimitadorCounter{
+		testCounter("models-as-a-service-premium-granite-tokens", 60, 1000, 900, "alice"),
+		testCounter("models-as-a-service-premium-granite-tokens", 3600, 10000, 8000, "alice"),
+		testCounter("models-as-a-service-premium-granite-input-tokens", 3600, 5000, 4500, "alice"),
+		testCounter("models-as-a-service-premium-granite-tokens-daily", 86400, 50000, 40000, "alice"),
+		testCounter("models-as-a-service-premium-granite-tokens", 3600, 10000, 9000, ""),
+		testCounter("models-as-a-service-basic-granite-tokens", 3600, 10000, 9000, "bob"),
+	}
+
+	samples := counterSamples(limits, counters, now)
+	require.Len(t, samples, 2)
+	assert.Equal(t, CounterSample{
+		Key:          "llm/granite-route|models-as-a-service-premium-granite-tokens|3600|alice",
+		Username:     "alice",
+		Subscription: "premium",
+		Model:        "llm/granite",
+		Direction:    DirectionTotal,
+		Consumed:     2000,
+		ExpiresAt:    now.Add(30 * time.Second),
+		ObservedAt:   now,
+	}, samples[0], "the longest window of a key is kept")
+	assert.Equal(t, DirectionInput, samples[1].Direction)
+	assert.Equal(t, int64(500), samples[1].Consumed)
+}
+
+func TestCounterDelta(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC)
+	sample := CounterSample{Consumed: 700, ObservedAt: now}
+
+	assert.Equal(t, int64(700), counterDelta(nil, sample), "a new counter counts in full")
+	assert.Equal(t, int64(200), counterDelta(&counterState{Consumed: 500, ExpiresAt: now.Add(time.Minute)}, sample))
+	assert.Equal(t, int64(700), counterDelta(&counterState{Consumed: 500, ExpiresAt: now.Add(-time.Second)}, sample), "an expired window restarts")
+	assert.Equal(t, int64(700), counterDelta(&counterState{Consumed: 900, ExpiresAt: now.Add(time.Minute)}, sample), "a lower value restarts")
+}
+
+func TestCollectorCollect(t *testing.T) {
+	store := NewMockStore()
+	reader := fakeCounterReader{"llm/granite-route": {
+		testCounter("models-as-a-service-premium-granite-tokens", 3600, 10000, 9000, "alice"),
+	}}
+	c := NewCollector(logger.Development(), store,
+		reader, staticLister{testModelRef("llm", "granite", "granite-route")}, staticLister{testSubscription("premium", nil, "granite")}, time.Minute)
+	now := time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Collect(t.Context()))
+	reader["llm/granite-route"][0].Remaining = 8500
+	now = now.Add(10 * time.Second)
+	require.NoError(t, c.Collect(t.Context()))
+
+	records, _, err := store.Query(t.Context(), Query{From: now.Add(-time.Hour), To: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, Record{
+		Username:     "alice",
+		Subscription: "premium",
+		Model:        "llm/granite",
+		WindowStart:  time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+		TotalTokens:  1500,
+	}, records[0], "each scrape records what was consumed since the previous one")
+}
@@ -0,0 +1,102 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// compactionInterval is the interval between two compactions of the usage records.
+const compactionInterval = time.Hour
+
+// RetentionPolicy is how long the usage and API key usage records are kept.
+type RetentionPolicy struct {
+	// HourlyDays is the number of days the hourly windows are kept before they are
+	// rolled up into daily windows. 0 keeps them.
+	HourlyDays int
+	// DailyMonths is the number of months the daily windows are kept before they are
+	// deleted. 0 keeps them.
+	DailyMonths int
+}
+
+// Cutoffs returns the window starts before which the hourly windows are rolled up and
+// the windows are deleted at now, both at the start of a UTC day. A zero time disables
+// the stage.
+func (p RetentionPolicy) Cutoffs(now time.Time) (rollupBefore, deleteBefore time.Time) {
+	today := bucketStart(now, GranularityDay)
+	if p.HourlyDays > 0 {
+		rollupBefore = today.AddDate(0, 0, -p.HourlyDays)
+	}
+	if p.DailyMonths > 0 {
+		deleteBefore = today.AddDate(0, -p.DailyMonths, 0)
+	}
+	return rollupBefore, deleteBefore
+}
+
+// CompactionResult is what a compaction changed.
+type CompactionResult struct {
+	// RolledUp is the number of daily windows hourly windows were rolled up into.
+	RolledUp int64
+	// Deleted is the number of windows deleted.
+	Deleted int64
+}
+
+// Compactor periodically applies a retention policy to the usage records, so that the
+// tables do not grow unbounded.
+type Compactor struct {
+	store    Store
+	policy   RetentionPolicy
+	interval time.Duration
+	logger   *logger.Logger
+	now      func() time.Time
+}
+
+// NewCompactor creates a compactor applying the policy every hour.
+func NewCompactor(log *logger.Logger, store Store, policy RetentionPolicy) *Compactor {
+	if log == nil {
+		log = logger.Production()
+	}
+	return &Compactor{
+		store:    store,
+		policy:   policy,
+		interval: compactionInterval,
+		logger:   log,
+		now:      time.Now,
+	}
+}
+
+// Start compacts the records every interval until ctx is done.
+func (c *Compactor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := c.Compact(ctx); err != nil {
+				c.logger.Error("Failed to compact usage", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Compact applies the policy once.
+func (c *Compactor) Compact(ctx context.Context) (CompactionResult, error) {
+	rollupBefore, deleteBefore := c.policy.Cutoffs(c.now())
+	if rollupBefore.IsZero() && deleteBefore.IsZero() {
+		return CompactionResult{}, nil
+	}
+	result, err := c.store.Compact(ctx, rollupBefore, deleteBefore)
+	if err != nil {
+		return result, err
+	}
+	if result.RolledUp > 0 || result.Deleted > 0 {
+		c.logger.Info("Compacted usage", "rolledUp", result.RolledUp, "deleted", result.Deleted,
+			"rollupBefore", rollupBefore, "deleteBefore", deleteBefore)
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package usage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+func TestRetentionPolicy_Cutoffs(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 15, 0, 0, time.UTC)
+
+	rollupBefore, deleteBefore := usage.RetentionPolicy{HourlyDays: 90, DailyMonths: 13}.Cutoffs(now)
+	assert.Equal(t, time.Date(2026, 7, 16, 0, 0, 0, 0, time.UTC), rollupBefore)
+	assert.Equal(t, time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC), deleteBefore)
+
+	rollupBefore, deleteBefore = usage.RetentionPolicy{}.Cutoffs(now)
+	assert.True(t, rollupBefore.IsZero())
+	assert.True(t, deleteBefore.IsZero())
+}
+
+func TestMockStore_Compact(t *testing.T) {
+	ctx := t.Context()
+	store := usage.NewMockStore()
+	day := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, store.AddRecords(ctx, []usage.Record{
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: day, Requests: 1, TotalTokens: 10},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: day.Add(5 * time.Hour), Requests: 2, TotalTokens: 20},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: day.Add(23 * time.Hour), Requests: 3, TotalTokens: 30},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: day.AddDate(0, 0, 2).Add(time.Hour), Requests: 4, TotalTokens: 40},
+		{Username: "bob", Subscription: "premium", Model: "llm/granite", WindowStart: day.AddDate(-1, 0, 0).Add(time.Hour), Requests: 5, TotalTokens: 50},
+	}))
+	require.NoError(t, store.AddKeyRecords(ctx, []usage.KeyRecord{
+		{KeyID: "k-1", Username: "alice", WindowStart: day.Add(time.Hour), Requests: 1},
+		{KeyID: "k-1", Username: "alice", WindowStart: day.Add(2 * time.Hour), Requests: 2},
+		{KeyID: "k-2", Username: "bob", WindowStart: day.AddDate(-1, 0, 0), Requests: 3},
+	}))
+
+	result, err := store.Compact(ctx, day.AddDate(0, 0, 1), day.AddDate(0, -6, 0))
+	require.NoError(t, err)
+	assert.Equal(t, usage.CompactionResult{RolledUp: 2, Deleted: 2}, result)
+
+	records, _, err := store.Query(ctx, usage.Query{From: day.AddDate(-2, 0, 0), To: day.AddDate(0, 1, 0), Granularity: usage.GranularityHour})
+	require.NoError(t, err)
+	assert.Equal(t, []usage.Record{
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: day, Requests: 6, TotalTokens: 60},
+		{Username: "alice", Subscription: "premium", Model: "llm/granite", WindowStart: day.AddDate(0, 0, 2).Add(time.Hour), Requests: 4, TotalTokens: 40},
+	}, records, "the windows after the rollup cutoff stay hourly")
+
+	top, err := store.Top(ctx, usage.TopQuery{By: usage.DimensionKey, From: day.AddDate(-2, 0, 0), To: day.AddDate(0, 0, 1), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []usage.Consumer{{Rank: 1, KeyID: "k-1", Username: "alice", Requests: 3}}, top)
+
+	result, err = store.Compact(ctx, day.AddDate(0, 0, 1), day.AddDate(0, -6, 0))
+	require.NoError(t, err)
+	assert.Equal(t, usage.CompactionResult{}, result, "compacting again changes nothing")
+}
@@ -0,0 +1,148 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/tracing"
+)
+
+const (
+	cloudEventContentType      = "application/cloudevents+json"
+	cloudEventBatchContentType = "application/cloudevents-batch+json"
+	kafkaBridgeContentType     = "application/vnd.kafka.json.v2+json"
+)
+
+// HTTPSink posts the events to an HTTP endpoint in the structured content mode of the
+// CloudEvents HTTP binding, such as a Knative broker or a data platform's HTTP intake.
+type HTTPSink struct {
+	client *http.Client
+	url    string
+	batch  bool
+}
+
+// NewHTTPSink returns a sink posting each event to sinkURL, or each batch of events
+// when batch is set, in the batched content mode.
+func NewHTTPSink(sinkURL string, batch bool, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{client: &http.Client{Timeout: timeout, Transport: tracing.Transport(nil)}, url: sinkURL, batch: batch}
+}
+
+func (s *HTTPSink) Name() string {
+	return "http"
+}
+
+// Send posts the events, stopping at the first one refused.
+func (s *HTTPSink) Send(ctx context.Context, events []CloudEvent) error {
+	if s.batch {
+		return s.post(ctx, cloudEventBatchContentType, events)
+	}
+	for _, event := range events {
+		if err := s.post(ctx, cloudEventContentType, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *HTTPSink) post(ctx context.Context, contentType string, body any) error {
+	_, err := postJSON(ctx, s.client, s.url, contentType, body)
+	return err
+}
+
+// KafkaBridgeSink produces the events to a Kafka topic through the HTTP API of the
+// Strimzi Kafka Bridge. Each record is keyed by the user, so that the events of a user
+// stay in order within a partition, and its value is the structured event.
+type KafkaBridgeSink struct {
+	client *http.Client
+	url    string
+	topic  string
+}
+
+// NewKafkaBridgeSink returns a sink producing to topic through the Kafka Bridge at
+// bridgeURL, e.g. http://maas-bridge-bridge-service.kafka.svc:8080.
+func NewKafkaBridgeSink(bridgeURL, topic string, timeout time.Duration) *KafkaBridgeSink {
+	return &KafkaBridgeSink{client: &http.Client{Timeout: timeout, Transport: tracing.Transport(nil)}, url: bridgeURL, topic: topic}
+}
+
+func (s *KafkaBridgeSink) Name() string {
+	return "kafka"
+}
+
+type kafkaBridgeRecord struct {
+	Key   string     `json:"key"`
+	Value CloudEvent `json:"value"`
+}
+
+type kafkaBridgeOffset struct {
+	ErrorCode int    `json:"error_code,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Send sends POST <url>/topics/<topic> with a record per event. The bridge reports
+// the records it failed to produce in their offsets.
+func (s *KafkaBridgeSink) Send(ctx context.Context, events []CloudEvent) error {
+	target, err := url.JoinPath(s.url, "topics", url.PathEscape(s.topic))
+	if err != nil {
+		return fmt.Errorf("invalid Kafka Bridge URL %q: %w", s.url, err)
+	}
+	records := make([]kafkaBridgeRecord, 0, len(events))
+	for _, event := range events {
+		records = append(records, kafkaBridgeRecord{Key: event.Data.Username, Value: event})
+	}
+	body, err := postJSON(ctx, s.client, target, kafkaBridgeContentType, map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Offsets []kafkaBridgeOffset `json:"offsets"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to decode Kafka Bridge response: %w", err)
+	}
+	failed := 0
+	var first kafkaBridgeOffset
+	for _, o := range resp.Offsets {
+		if o.ErrorCode != 0 {
+			if failed == 0 {
+				first = o
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("kafka bridge failed to produce %d of %d records: %d %s", failed, len(records), first.ErrorCode, first.Message)
+	}
+	return nil
+}
+
+// postJSON posts body as JSON and returns the response body of a 2xx response.
+func postJSON(ctx context.Context, client *http.Client, target, contentType string, body any) ([]byte, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode usage events: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of POST %s: %w", target, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("POST %s returned HTTP %d", target, resp.StatusCode)
+	}
+	return respBody, nil
+}
@@ -0,0 +1,125 @@
+package usage_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/usage"
+)
+
+type capturedRequest struct {
+	path        string
+	contentType string
+	body        []byte
+}
+
+func captureServer(t *testing.T, status int, response string) (*httptest.Server, func() []capturedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var captured []capturedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		captured = append(captured, capturedRequest{path: r.URL.Path, contentType: r.Header.Get("Content-Type"), body: body})
+		mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []capturedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]capturedRequest(nil), captured...)
+	}
+}
+
+func testEvents() []usage.CloudEvent {
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	return []usage.CloudEvent{
+		usage.NewRequestEvent("/maas-api/test", usage.RequestUsage{RequestID: "r1", StartTime: start, Username: "alice", Model: "llm/granite", TotalTokens: 10}),
+		usage.NewRequestEvent("/maas-api/test", usage.RequestUsage{RequestID: "r2", StartTime: start, Username: "bob", Model: "llm/granite", TotalTokens: 20}),
+	}
+}
+
+func TestHTTPSink_Structured(t *testing.T) {
+	srv, captured := captureServer(t, http.StatusAccepted, "")
+	sink := usage.NewHTTPSink(srv.URL+"/maas/default", false, time.Second)
+
+	require.NoError(t, sink.Send(t.Context(), testEvents()))
+
+	requests := captured()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/maas/default", requests[0].path)
+	assert.Equal(t, "application/cloudevents+json", requests[0].contentType)
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(requests[0].body, &event))
+	assert.Equal(t, "1.0", event["specversion"])
+	assert.Equal(t, "r1", event["id"])
+	assert.Equal(t, usage.RequestEventType, event["type"])
+	assert.Equal(t, "alice", event["data"].(map[string]any)["user"])
+}
+
+func TestHTTPSink_Batch(t *testing.T) {
+	srv, captured := captureServer(t, http.StatusOK, "")
+	sink := usage.NewHTTPSink(srv.URL, true, time.Second)
+
+	require.NoError(t, sink.Send(t.Context(), testEvents()))
+
+	requests := captured()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "application/cloudevents-batch+json", requests[0].contentType)
+	var events []usage.CloudEvent
+	require.NoError(t, json.Unmarshal(requests[0].body, &events))
+	assert.Len(t, events, 2)
+}
+
+func TestHTTPSink_Refused(t *testing.T) {
+	srv, captured := captureServer(t, http.StatusServiceUnavailable, "")
+	sink := usage.NewHTTPSink(srv.URL, false, time.Second)
+
+	err := sink.Send(t.Context(), testEvents())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 503")
+	assert.Len(t, captured(), 1, "stops at the first refused event")
+}
+
+func TestKafkaBridgeSink(t *testing.T) {
+	srv, captured := captureServer(t, http.StatusOK, `{"offsets": [{"partition": 0, "offset": 7}, {"partition": 1, "offset": 3}]}`)
+	sink := usage.NewKafkaBridgeSink(srv.URL, "maas-usage-events", time.Second)
+
+	require.NoError(t, sink.Send(t.Context(), testEvents()))
+
+	requests := captured()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/topics/maas-usage-events", requests[0].path)
+	assert.Equal(t, "application/vnd.kafka.json.v2+json", requests[0].contentType)
+	var body struct {
+		Records []struct {
+			Key   string           `json:"key"`
+			Value usage.CloudEvent `json:"value"`
+		} `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal(requests[0].body, &body))
+	require.Len(t, body.Records, 2)
+	assert.Equal(t, "alice", body.Records[0].Key)
+	assert.Equal(t, "r1", body.Records[0].Value.ID)
+	assert.Equal(t, int64(20), body.Records[1].Value.Data.TotalTokens)
+}
+
+func TestKafkaBridgeSink_RecordErrors(t *testing.T) {
+	srv, _ := captureServer(t, http.StatusOK, `{"offsets": [{"partition": 0, "offset": 7}, {"error_code": 404, "message": "topic not found"}]}`)
+	sink := usage.NewKafkaBridgeSink(srv.URL, "maas-usage-events", time.Second)
+
+	err := sink.Send(t.Context(), testEvents())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 records")
+	assert.Contains(t, err.Error(), "topic not found")
+}
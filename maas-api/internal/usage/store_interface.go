@@ -0,0 +1,69 @@
+package usage
+
+import (
+	"context"
+	"time"
+)
+
+// MaxQueryRecords bounds the records a query returns.
+const MaxQueryRecords = 10000
+
+// Store keeps the usage records of a tenant.
+type Store interface {
+	// AddRecords adds the counts of the records to those of their window.
+	AddRecords(ctx context.Context, records []Record) error
+
+	// AddKeyRecords adds the counts of the API key records to those of their window.
+	AddKeyRecords(ctx context.Context, records []KeyRecord) error
+
+	// KeyUsage returns the records of the API key between q.From (inclusive) and q.To
+	// (exclusive) summed per q.Granularity window, ordered by window.
+	KeyUsage(ctx context.Context, keyID string, q Query) ([]KeyRecord, error)
+
+	// RecordCounters adds what each counter consumed since the previous scrape to the
+	// window of its scrape, and keeps the samples for the next one.
+	RecordCounters(ctx context.Context, samples []CounterSample) error
+
+	// Query returns the records matching q, summed per granularity window, ordered by
+	// window, user, subscription and model. truncated is true when more than
+	// MaxQueryRecords matched.
+	Query(ctx context.Context, q Query) (records []Record, truncated bool, err error)
+
+	// SubscriptionTotals returns the usage of the windows between from (inclusive) and
+	// to (exclusive) summed per subscription and model, whatever the user, ordered by
+	// subscription and model. The records have no user and start at from.
+	SubscriptionTotals(ctx context.Context, from, to time.Time) ([]Record, error)
+
+	// UserTotals returns the usage of the users between from (inclusive) and to
+	// (exclusive), whatever the subscription and model. Users without usage are absent.
+	UserTotals(ctx context.Context, usernames []string, from, to time.Time) (map[string]Totals, error)
+
+	// AddHealth adds the counts of the health records to those of their window and
+	// latency bucket, and deletes the records older than HealthRetention.
+	AddHealth(ctx context.Context, records []HealthRecord) error
+
+	// ModelHealth returns the health records of the windows between from (inclusive) and
+	// to (exclusive) summed per model and latency bucket, ordered by model and bucket.
+	// The records start at from.
+	ModelHealth(ctx context.Context, from, to time.Time) ([]HealthRecord, error)
+
+	// Top returns the consumers of q's dimension with the most tokens, then requests,
+	// ranked from 1. The usage of by=key comes from the key records.
+	Top(ctx context.Context, q TopQuery) ([]Consumer, error)
+
+	// Compact rolls the hourly windows of the usage and API key usage records before
+	// rollupBefore up into the window of their UTC day, and deletes the windows before
+	// deleteBefore. A zero time skips the stage.
+	Compact(ctx context.Context, rollupBefore, deleteBefore time.Time) (CompactionResult, error)
+	// FlagKey records an anomaly of an API key. flagged is false when the key was
+	// already flagged for the rule, hour and network of the anomaly.
+	FlagKey(ctx context.Context, a Anomaly) (flagged bool, err error)
+
+	// Anomalies returns the anomalies of the windows between from (inclusive) and to
+	// (exclusive), most recently detected first, at most MaxQueryRecords.
+	Anomalies(ctx context.Context, from, to time.Time) ([]Anomaly, error)
+
+	// AddKeyNetworks records the networks an API key was used from. It returns those
+	// it was not used from before, and the number of networks it was used from before.
+	AddKeyNetworks(ctx context.Context, keyID string, networks []string, seenAt time.Time) (added []string, known int, err error)
+}
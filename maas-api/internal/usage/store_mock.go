@@ -0,0 +1,458 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MockStore implements Store for testing purposes.
+// It stores data in memory and is safe for concurrent use.
+type MockStore struct {
+	mu         sync.Mutex
+	records    map[recordKey]*Record
+	keyRecords []KeyRecord
+	health     []HealthRecord
+	counters   map[string]counterState
+	anomalies  []Anomaly
+	networks   map[string]map[string]time.Time
+}
+
+type recordKey struct {
+	username, subscription, model string
+	windowUnix                    int64
+}
+
+// NewMockStore creates a new in-memory mock store for testing.
+func NewMockStore() *MockStore {
+	return &MockStore{
+		records:  make(map[recordKey]*Record),
+		counters: make(map[string]counterState),
+		networks: make(map[string]map[string]time.Time),
+	}
+}
+
+// Compile-time check that MockStore implements Store.
+var _ Store = (*MockStore)(nil)
+
+func (m *MockStore) addRecord(r Record) {
+	start := windowStart(r.WindowStart)
+	key := recordKey{r.Username, r.Subscription, r.Model, start.Unix()}
+	existing, ok := m.records[key]
+	if !ok {
+		existing = &Record{Username: r.Username, Subscription: r.Subscription, Model: r.Model, WindowStart: start}
+		m.records[key] = existing
+	}
+	existing.Requests += r.Requests
+	existing.InputTokens += r.InputTokens
+	existing.OutputTokens += r.OutputTokens
+	existing.TotalTokens += r.TotalTokens
+}
+
+// AddRecords adds the counts of the records to those of their window.
+func (m *MockStore) AddRecords(_ context.Context, records []Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range records {
+		m.addRecord(r)
+	}
+	return nil
+}
+
+// AddKeyRecords adds the counts of the API key records to those of their window.
+func (m *MockStore) AddKeyRecords(_ context.Context, records []KeyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range records {
+		r.WindowStart = windowStart(r.WindowStart)
+		m.keyRecords = append(m.keyRecords, r)
+	}
+	return nil
+}
+
+// KeyUsage returns the records of the API key between q.From and q.To summed per
+// granularity window.
+func (m *MockStore) KeyUsage(_ context.Context, keyID string, q Query) ([]KeyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	buckets := map[int64]*KeyRecord{}
+	for _, r := range m.keyRecords {
+		if r.KeyID != keyID || r.WindowStart.Before(q.From) || !r.WindowStart.Before(q.To) {
+			continue
+		}
+		start := bucketStart(r.WindowStart, q.Granularity)
+		b, ok := buckets[start.Unix()]
+		if !ok {
+			b = &KeyRecord{KeyID: keyID, Username: r.Username, WindowStart: start}
+			buckets[start.Unix()] = b
+		}
+		b.Requests += r.Requests
+		b.InputTokens += r.InputTokens
+		b.OutputTokens += r.OutputTokens
+		b.TotalTokens += r.TotalTokens
+	}
+
+	records := make([]KeyRecord, 0, len(buckets))
+	for _, b := range buckets {
+		records = append(records, *b)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].WindowStart.Before(records[j].WindowStart) })
+	return records, nil
+}
+
+// RecordCounters adds what each counter consumed since the previous scrape to the
+// window of its scrape.
+func (m *MockStore) RecordCounters(_ context.Context, samples []CounterSample) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range samples {
+		var prev *counterState
+		if state, ok := m.counters[s.Key]; ok {
+			prev = &state
+		}
+		m.counters[s.Key] = counterState{Consumed: s.Consumed, ExpiresAt: s.ExpiresAt}
+		if delta := counterDelta(prev, s); delta > 0 {
+			r := Record{Username: s.Username, Subscription: s.Subscription, Model: s.Model, WindowStart: s.ObservedAt}
+			r.addTokens(s.Direction, delta)
+			m.addRecord(r)
+		}
+	}
+	return nil
+}
+
+// Query returns the records matching q, summed per granularity window.
+func (m *MockStore) Query(_ context.Context, q Query) ([]Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	buckets := map[recordKey]*Record{}
+	for _, r := range m.records {
+		if r.WindowStart.Before(q.From) || !r.WindowStart.Before(q.To) ||
+			(q.Username != "" && r.Username != q.Username) ||
+			(q.Subscription != "" && r.Subscription != q.Subscription) ||
+			(q.Model != "" && r.Model != q.Model) {
+			continue
+		}
+		start := bucketStart(r.WindowStart, q.Granularity)
+		key := recordKey{r.Username, r.Subscription, r.Model, start.Unix()}
+		b, ok := buckets[key]
+		if !ok {
+			b = &Record{Username: r.Username, Subscription: r.Subscription, Model: r.Model, WindowStart: start}
+			buckets[key] = b
+		}
+		b.Requests += r.Requests
+		b.InputTokens += r.InputTokens
+		b.OutputTokens += r.OutputTokens
+		b.TotalTokens += r.TotalTokens
+	}
+
+	records := make([]Record, 0, len(buckets))
+	for _, b := range buckets {
+		records = append(records, *b)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if !a.WindowStart.Equal(b.WindowStart) {
+			return a.WindowStart.Before(b.WindowStart)
+		}
+		if a.Username != b.Username {
+			return a.Username < b.Username
+		}
+		if a.Subscription != b.Subscription {
+			return a.Subscription < b.Subscription
+		}
+		return a.Model < b.Model
+	})
+	if len(records) > MaxQueryRecords {
+		return records[:MaxQueryRecords], true, nil
+	}
+	return records, false, nil
+}
+
+// SubscriptionTotals returns the usage between from and to summed per subscription and
+// model.
+func (m *MockStore) SubscriptionTotals(_ context.Context, from, to time.Time) ([]Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type subscriptionModel struct{ subscription, model string }
+	totals := map[subscriptionModel]*Record{}
+	for _, r := range m.records {
+		if r.WindowStart.Before(from) || !r.WindowStart.Before(to) {
+			continue
+		}
+		key := subscriptionModel{r.Subscription, r.Model}
+		t, ok := totals[key]
+		if !ok {
+			t = &Record{Subscription: r.Subscription, Model: r.Model, WindowStart: from.UTC()}
+			totals[key] = t
+		}
+		t.Requests += r.Requests
+		t.InputTokens += r.InputTokens
+		t.OutputTokens += r.OutputTokens
+		t.TotalTokens += r.TotalTokens
+	}
+
+	records := make([]Record, 0, len(totals))
+	for _, t := range totals {
+		records = append(records, *t)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Subscription != records[j].Subscription {
+			return records[i].Subscription < records[j].Subscription
+		}
+		return records[i].Model < records[j].Model
+	})
+	return records, nil
+}
+
+// UserTotals returns the usage of the users between from and to.
+func (m *MockStore) UserTotals(_ context.Context, usernames []string, from, to time.Time) (map[string]Totals, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := make(map[string]Totals, len(usernames))
+	for _, r := range m.records {
+		if !slices.Contains(usernames, r.Username) || r.WindowStart.Before(from) || !r.WindowStart.Before(to) {
+			continue
+		}
+		t := totals[r.Username]
+		t.Requests += r.Requests
+		t.InputTokens += r.InputTokens
+		t.OutputTokens += r.OutputTokens
+		t.TotalTokens += r.TotalTokens
+		totals[r.Username] = t
+	}
+	return totals, nil
+}
+
+// AddHealth adds the health records.
+func (m *MockStore) AddHealth(_ context.Context, records []HealthRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range records {
+		r.WindowStart = healthWindowStart(r.WindowStart)
+		m.health = append(m.health, r)
+	}
+	return nil
+}
+
+// ModelHealth returns the health records between from and to summed per model and
+// latency bucket.
+func (m *MockStore) ModelHealth(_ context.Context, from, to time.Time) ([]HealthRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type modelBucket struct {
+		model string
+		leMs  int64
+	}
+	totals := map[modelBucket]*HealthRecord{}
+	for _, r := range m.health {
+		if r.WindowStart.Before(from) || !r.WindowStart.Before(to) {
+			continue
+		}
+		key := modelBucket{r.Model, r.LeMs}
+		t, ok := totals[key]
+		if !ok {
+			t = &HealthRecord{Model: r.Model, WindowStart: from.UTC(), LeMs: r.LeMs}
+			totals[key] = t
+		}
+		t.Requests += r.Requests
+		t.ServerErrors += r.ServerErrors
+	}
+
+	records := make([]HealthRecord, 0, len(totals))
+	for _, t := range totals {
+		records = append(records, *t)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Model != records[j].Model {
+			return records[i].Model < records[j].Model
+		}
+		return records[i].LeMs < records[j].LeMs
+	})
+	return records, nil
+}
+
+// Top returns the heaviest consumers of q's dimension.
+func (m *MockStore) Top(_ context.Context, q TopQuery) ([]Consumer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := map[string]*Consumer{}
+	add := func(key string, c Consumer, start time.Time, requests, input, output, total int64) {
+		if start.Before(q.From) || !start.Before(q.To) {
+			return
+		}
+		t, ok := totals[key]
+		if !ok {
+			t = &c
+			totals[key] = t
+		}
+		t.Requests += requests
+		t.InputTokens += input
+		t.OutputTokens += output
+		t.TotalTokens += total
+		t.Tokens += consumedTokens(input, output, total)
+	}
+	switch q.By {
+	case DimensionUser:
+		for _, r := range m.records {
+			add(r.Username, Consumer{Username: r.Username}, r.WindowStart, r.Requests, r.InputTokens, r.OutputTokens, r.TotalTokens)
+		}
+	case DimensionModel:
+		for _, r := range m.records {
+			add(r.Model, Consumer{Model: r.Model}, r.WindowStart, r.Requests, r.InputTokens, r.OutputTokens, r.TotalTokens)
+		}
+	case DimensionKey:
+		for _, r := range m.keyRecords {
+			add(r.KeyID, Consumer{KeyID: r.KeyID, Username: r.Username}, r.WindowStart, r.Requests, r.InputTokens, r.OutputTokens, r.TotalTokens)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown dimension %q", ErrInvalidQuery, q.By)
+	}
+
+	consumers := make([]Consumer, 0, len(totals))
+	for _, t := range totals {
+		consumers = append(consumers, *t)
+	}
+	name := func(c Consumer) string {
+		if q.By == DimensionKey {
+			return c.KeyID
+		}
+		return c.Username + c.Model
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		a, b := consumers[i], consumers[j]
+		if a.Tokens != b.Tokens {
+			return a.Tokens > b.Tokens
+		}
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return name(a) < name(b)
+	})
+	if len(consumers) > q.Limit {
+		consumers = consumers[:q.Limit]
+	}
+	for i := range consumers {
+		consumers[i].Rank = i + 1
+	}
+	return consumers, nil
+}
+
+// Compact rolls the hourly windows before rollupBefore up into daily windows and
+// deletes the windows before deleteBefore.
+func (m *MockStore) Compact(_ context.Context, rollupBefore, deleteBefore time.Time) (CompactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result CompactionResult
+	expired := func(start time.Time) bool {
+		return !deleteBefore.IsZero() && start.Before(deleteBefore)
+	}
+	rolledUp := func(start time.Time) bool {
+		return !rollupBefore.IsZero() && start.Before(rollupBefore) && !start.Equal(bucketStart(start, GranularityDay))
+	}
+
+	days := map[recordKey]bool{}
+	for key, r := range m.records {
+		switch {
+		case expired(r.WindowStart):
+			delete(m.records, key)
+			result.Deleted++
+		case rolledUp(r.WindowStart):
+			delete(m.records, key)
+			day := *r
+			day.WindowStart = bucketStart(r.WindowStart, GranularityDay)
+			m.addRecord(day)
+			days[recordKey{r.Username, r.Subscription, r.Model, day.WindowStart.Unix()}] = true
+		}
+	}
+	result.RolledUp += int64(len(days))
+
+	type keyDay struct {
+		keyID      string
+		windowUnix int64
+	}
+	keyRecords := m.keyRecords[:0]
+	keyDays := map[keyDay]int{}
+	for _, r := range m.keyRecords {
+		switch {
+		case expired(r.WindowStart):
+			result.Deleted++
+			continue
+		case rolledUp(r.WindowStart):
+			r.WindowStart = bucketStart(r.WindowStart, GranularityDay)
+			key := keyDay{r.KeyID, r.WindowStart.Unix()}
+			if i, ok := keyDays[key]; ok {
+				keyRecords[i].Requests += r.Requests
+				keyRecords[i].InputTokens += r.InputTokens
+				keyRecords[i].OutputTokens += r.OutputTokens
+				keyRecords[i].TotalTokens += r.TotalTokens
+				continue
+			}
+			keyDays[key] = len(keyRecords)
+		}
+		keyRecords = append(keyRecords, r)
+	}
+	m.keyRecords = keyRecords
+	result.RolledUp += int64(len(keyDays))
+	return result, nil
+}
+
+// FlagKey records the anomaly unless the key was already flagged for it.
+func (m *MockStore) FlagKey(_ context.Context, a Anomaly) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a.WindowStart = windowStart(a.WindowStart)
+	for _, existing := range m.anomalies {
+		if existing.KeyID == a.KeyID && existing.Rule == a.Rule && existing.WindowStart.Equal(a.WindowStart) && existing.Network == a.Network {
+			return false, nil
+		}
+	}
+	m.anomalies = append(m.anomalies, a)
+	return true, nil
+}
+
+// Anomalies returns the anomalies between from and to, most recently detected first.
+func (m *MockStore) Anomalies(_ context.Context, from, to time.Time) ([]Anomaly, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	anomalies := []Anomaly{}
+	for _, a := range m.anomalies {
+		if !a.WindowStart.Before(from) && a.WindowStart.Before(to) {
+			anomalies = append(anomalies, a)
+		}
+	}
+	sort.SliceStable(anomalies, func(i, j int) bool { return anomalies[i].DetectedAt.After(anomalies[j].DetectedAt) })
+	if len(anomalies) > MaxQueryRecords {
+		anomalies = anomalies[:MaxQueryRecords]
+	}
+	return anomalies, nil
+}
+
+// AddKeyNetworks records the networks of the API key.
+func (m *MockStore) AddKeyNetworks(_ context.Context, keyID string, networks []string, seenAt time.Time) ([]string, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	known, ok := m.networks[keyID]
+	if !ok {
+		known = map[string]time.Time{}
+		m.networks[keyID] = known
+	}
+	previous := len(known)
+	var added []string
+	for _, network := range networks {
+		if _, ok := known[network]; !ok {
+			known[network] = seenAt
+			added = append(added, network)
+		}
+	}
+	return added, previous, nil
+}
@@ -0,0 +1,575 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-api/internal/logger"
+)
+
+// counterRetention is how long the state of a counter is kept past the end of its window.
+const counterRetention = time.Hour
+
+// PostgresStore implements Store using the PostgreSQL database of the API keys.
+// The schema is managed by golang-migrate (see db/schema).
+type PostgresStore struct {
+	db         *sql.DB
+	logger     *logger.Logger
+	tenantName string // Tenant identifier for filtering queries
+}
+
+// Compile-time check that PostgresStore implements Store.
+var _ Store = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a PostgreSQL-backed usage store.
+// tenantName is used to filter all database queries to enforce tenant isolation.
+func NewPostgresStore(db *sql.DB, log *logger.Logger, tenantName string) *PostgresStore {
+	return &PostgresStore{
+		db:         db,
+		logger:     log,
+		tenantName: tenantName,
+	}
+}
+
+const addRecordQuery = `
+	INSERT INTO usage_records (tenant, username, subscription, model, window_start, requests, input_tokens, output_tokens, total_tokens, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (tenant, username, subscription, model, window_start) DO UPDATE SET
+		requests = usage_records.requests + EXCLUDED.requests,
+		input_tokens = usage_records.input_tokens + EXCLUDED.input_tokens,
+		output_tokens = usage_records.output_tokens + EXCLUDED.output_tokens,
+		total_tokens = usage_records.total_tokens + EXCLUDED.total_tokens,
+		updated_at = EXCLUDED.updated_at
+`
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (s *PostgresStore) addRecord(ctx context.Context, db execer, r Record, now time.Time) error {
+	_, err := db.ExecContext(ctx, addRecordQuery,
+		s.tenantName, r.Username, r.Subscription, r.Model, windowStart(r.WindowStart),
+		r.Requests, r.InputTokens, r.OutputTokens, r.TotalTokens, now)
+	if err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	return nil
+}
+
+// AddRecords adds the counts of the records to those of their window.
+func (s *PostgresStore) AddRecords(ctx context.Context, records []Record) error {
+	now := time.Now().UTC()
+	for _, r := range records {
+		if err := s.addRecord(ctx, s.db, r, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const addKeyRecordQuery = `
+	INSERT INTO usage_key_records (tenant, key_id, username, window_start, requests, input_tokens, output_tokens, total_tokens, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (tenant, key_id, window_start) DO UPDATE SET
+		requests = usage_key_records.requests + EXCLUDED.requests,
+		input_tokens = usage_key_records.input_tokens + EXCLUDED.input_tokens,
+		output_tokens = usage_key_records.output_tokens + EXCLUDED.output_tokens,
+		total_tokens = usage_key_records.total_tokens + EXCLUDED.total_tokens,
+		updated_at = EXCLUDED.updated_at
+`
+
+// AddKeyRecords adds the counts of the API key records to those of their window.
+func (s *PostgresStore) AddKeyRecords(ctx context.Context, records []KeyRecord) error {
+	now := time.Now().UTC()
+	for _, r := range records {
+		_, err := s.db.ExecContext(ctx, addKeyRecordQuery,
+			s.tenantName, r.KeyID, r.Username, windowStart(r.WindowStart),
+			r.Requests, r.InputTokens, r.OutputTokens, r.TotalTokens, now)
+		if err != nil {
+			return fmt.Errorf("failed to record API key usage: %w", err)
+		}
+	}
+	return nil
+}
+
+// KeyUsage returns the records of the API key between q.From and q.To summed per
+// granularity window.
+func (s *PostgresStore) KeyUsage(ctx context.Context, keyID string, q Query) ([]KeyRecord, error) {
+	granularity := GranularityHour
+	if q.Granularity == GranularityDay {
+		granularity = GranularityDay
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT username,
+			date_trunc($5, window_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket,
+			SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens)
+		FROM usage_key_records
+		WHERE tenant = $1 AND key_id = $2 AND window_start >= $3 AND window_start < $4
+		GROUP BY username, bucket
+		ORDER BY bucket, username
+	`, s.tenantName, keyID, q.From.UTC(), q.To.UTC(), string(granularity))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API key usage: %w", err)
+	}
+	defer rows.Close()
+
+	records := []KeyRecord{}
+	for rows.Next() {
+		r := KeyRecord{KeyID: keyID}
+		if err := rows.Scan(&r.Username, &r.WindowStart,
+			&r.Requests, &r.InputTokens, &r.OutputTokens, &r.TotalTokens); err != nil {
+			return nil, fmt.Errorf("failed to scan API key usage: %w", err)
+		}
+		r.WindowStart = r.WindowStart.UTC()
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query API key usage: %w", err)
+	}
+	return records, nil
+}
+
+// RecordCounters adds what each counter consumed since the previous scrape to the
+// window of its scrape. The tenant's counters are locked for the transaction so that
+// replicas scraping at the same time do not both record the same tokens.
+func (s *PostgresStore) RecordCounters(ctx context.Context, samples []CounterSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "maas-usage-counters/"+s.tenantName); err != nil {
+		return fmt.Errorf("failed to lock usage counters: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, sample := range samples {
+		var prev *counterState
+		var state counterState
+		err := tx.QueryRowContext(ctx,
+			`SELECT consumed, expires_at FROM usage_counters WHERE tenant = $1 AND counter_key = $2`,
+			s.tenantName, sample.Key).Scan(&state.Consumed, &state.ExpiresAt)
+		switch {
+		case err == nil:
+			prev = &state
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("failed to read usage counter: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO usage_counters (tenant, counter_key, consumed, expires_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (tenant, counter_key) DO UPDATE SET
+				consumed = EXCLUDED.consumed, expires_at = EXCLUDED.expires_at, updated_at = EXCLUDED.updated_at
+		`, s.tenantName, sample.Key, sample.Consumed, sample.ExpiresAt, now)
+		if err != nil {
+			return fmt.Errorf("failed to write usage counter: %w", err)
+		}
+
+		delta := counterDelta(prev, sample)
+		if delta == 0 {
+			continue
+		}
+		r := Record{Username: sample.Username, Subscription: sample.Subscription, Model: sample.Model, WindowStart: sample.ObservedAt}
+		r.addTokens(sample.Direction, delta)
+		if err := s.addRecord(ctx, tx, r, now); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM usage_counters WHERE tenant = $1 AND expires_at < $2`,
+		s.tenantName, now.Add(-counterRetention)); err != nil {
+		return fmt.Errorf("failed to prune usage counters: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit usage counters: %w", err)
+	}
+	return nil
+}
+
+// Query returns the records matching q, summed per granularity window.
+func (s *PostgresStore) Query(ctx context.Context, q Query) ([]Record, bool, error) {
+	whereClauses := []string{"tenant = $1", "window_start >= $2", "window_start < $3"}
+	args := []any{s.tenantName, q.From.UTC(), q.To.UTC()}
+	for column, value := range map[string]string{"username": q.Username, "subscription": q.Subscription, "model": q.Model} {
+		if value != "" {
+			args = append(args, value)
+			whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+	}
+	granularity := GranularityHour
+	if q.Granularity == GranularityDay {
+		granularity = GranularityDay
+	}
+	args = append(args, string(granularity), MaxQueryRecords+1)
+
+	//nolint:gosec // G201: the WHERE clause only holds fixed column names and placeholders.
+	query := fmt.Sprintf(`
+		SELECT username, subscription, model,
+			date_trunc($%d, window_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket,
+			SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens)
+		FROM usage_records
+		WHERE %s
+		GROUP BY username, subscription, model, bucket
+		ORDER BY bucket, username, subscription, model
+		LIMIT $%d
+	`, len(args)-1, strings.Join(whereClauses, " AND "), len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query usage: %w", err)
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		var r Record
+		if err := rows.Scan(&r.Username, &r.Subscription, &r.Model, &r.WindowStart,
+			&r.Requests, &r.InputTokens, &r.OutputTokens, &r.TotalTokens); err != nil {
+			return nil, false, fmt.Errorf("failed to scan usage: %w", err)
+		}
+		r.WindowStart = r.WindowStart.UTC()
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to query usage: %w", err)
+	}
+	if len(records) > MaxQueryRecords {
+		return records[:MaxQueryRecords], true, nil
+	}
+	return records, false, nil
+}
+
+// SubscriptionTotals returns the usage between from and to summed per subscription and
+// model.
+func (s *PostgresStore) SubscriptionTotals(ctx context.Context, from, to time.Time) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT subscription, model, SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens)
+		FROM usage_records
+		WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+		GROUP BY subscription, model
+		ORDER BY subscription, model
+	`, s.tenantName, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage totals: %w", err)
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		r := Record{WindowStart: from.UTC()}
+		if err := rows.Scan(&r.Subscription, &r.Model, &r.Requests, &r.InputTokens, &r.OutputTokens, &r.TotalTokens); err != nil {
+			return nil, fmt.Errorf("failed to scan usage totals: %w", err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query usage totals: %w", err)
+	}
+	return records, nil
+}
+
+// UserTotals returns the usage of the users between from and to.
+func (s *PostgresStore) UserTotals(ctx context.Context, usernames []string, from, to time.Time) (map[string]Totals, error) {
+	totals := make(map[string]Totals, len(usernames))
+	if len(usernames) == 0 {
+		return totals, nil
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT username, SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens)
+		FROM usage_records
+		WHERE tenant = $1 AND username = ANY($2) AND window_start >= $3 AND window_start < $4
+		GROUP BY username
+	`, s.tenantName, pq.Array(usernames), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user usage totals: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var username string
+		var t Totals
+		if err := rows.Scan(&username, &t.Requests, &t.InputTokens, &t.OutputTokens, &t.TotalTokens); err != nil {
+			return nil, fmt.Errorf("failed to scan user usage totals: %w", err)
+		}
+		totals[username] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query user usage totals: %w", err)
+	}
+	return totals, nil
+}
+
+const addHealthRecordQuery = `
+	INSERT INTO model_health_records (tenant, model, window_start, le_ms, requests, server_errors, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (tenant, model, window_start, le_ms) DO UPDATE SET
+		requests = model_health_records.requests + EXCLUDED.requests,
+		server_errors = model_health_records.server_errors + EXCLUDED.server_errors,
+		updated_at = EXCLUDED.updated_at
+`
+
+// AddHealth adds the counts of the health records to those of their window and latency
+// bucket, and deletes the records older than HealthRetention.
+func (s *PostgresStore) AddHealth(ctx context.Context, records []HealthRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	for _, r := range records {
+		_, err := s.db.ExecContext(ctx, addHealthRecordQuery,
+			s.tenantName, r.Model, healthWindowStart(r.WindowStart), r.LeMs, r.Requests, r.ServerErrors, now)
+		if err != nil {
+			return fmt.Errorf("failed to record model health: %w", err)
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM model_health_records WHERE tenant = $1 AND window_start < $2`,
+		s.tenantName, now.Add(-HealthRetention)); err != nil {
+		return fmt.Errorf("failed to prune model health: %w", err)
+	}
+	return nil
+}
+
+// ModelHealth returns the health records between from and to summed per model and
+// latency bucket.
+func (s *PostgresStore) ModelHealth(ctx context.Context, from, to time.Time) ([]HealthRecord, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT model, le_ms, SUM(requests), SUM(server_errors)
+		FROM model_health_records
+		WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+		GROUP BY model, le_ms
+		ORDER BY model, le_ms
+	`, s.tenantName, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query model health: %w", err)
+	}
+	defer rows.Close()
+
+	records := []HealthRecord{}
+	for rows.Next() {
+		r := HealthRecord{WindowStart: from.UTC()}
+		if err := rows.Scan(&r.Model, &r.LeMs, &r.Requests, &r.ServerErrors); err != nil {
+			return nil, fmt.Errorf("failed to scan model health: %w", err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query model health: %w", err)
+	}
+	return records, nil
+}
+
+// topQueries are the leaderboard queries per dimension. $1 is the tenant, $2 and $3 the
+// range and $4 the limit.
+var topQueries = map[Dimension]string{
+	DimensionUser: `
+		SELECT username, '', '', SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens),
+			SUM(GREATEST(total_tokens, input_tokens + output_tokens)) AS tokens
+		FROM usage_records
+		WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+		GROUP BY username
+		ORDER BY tokens DESC, SUM(requests) DESC, username
+		LIMIT $4`,
+	DimensionModel: `
+		SELECT '', model, '', SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens),
+			SUM(GREATEST(total_tokens, input_tokens + output_tokens)) AS tokens
+		FROM usage_records
+		WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+		GROUP BY model
+		ORDER BY tokens DESC, SUM(requests) DESC, model
+		LIMIT $4`,
+	DimensionKey: `
+		SELECT MIN(username), '', key_id, SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens),
+			SUM(GREATEST(total_tokens, input_tokens + output_tokens)) AS tokens
+		FROM usage_key_records
+		WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+		GROUP BY key_id
+		ORDER BY tokens DESC, SUM(requests) DESC, key_id
+		LIMIT $4`,
+}
+
+// Top returns the heaviest consumers of q's dimension.
+func (s *PostgresStore) Top(ctx context.Context, q TopQuery) ([]Consumer, error) {
+	query, ok := topQueries[q.By]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown dimension %q", ErrInvalidQuery, q.By)
+	}
+	rows, err := s.db.QueryContext(ctx, query, s.tenantName, q.From.UTC(), q.To.UTC(), q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top consumers: %w", err)
+	}
+	defer rows.Close()
+
+	consumers := []Consumer{}
+	for rows.Next() {
+		c := Consumer{Rank: len(consumers) + 1}
+		if err := rows.Scan(&c.Username, &c.Model, &c.KeyID,
+			&c.Requests, &c.InputTokens, &c.OutputTokens, &c.TotalTokens, &c.Tokens); err != nil {
+			return nil, fmt.Errorf("failed to scan top consumers: %w", err)
+		}
+		consumers = append(consumers, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query top consumers: %w", err)
+	}
+	return consumers, nil
+}
+
+// rollupQueries roll the hourly windows before $2 up into the window at the start of
+// their UTC day. The hourly rows are deleted and their sums added to the daily rows in
+// one statement, so that usage added concurrently is either rolled up or left alone.
+var rollupQueries = []string{`
+	WITH hourly AS (
+		DELETE FROM usage_records
+		WHERE tenant = $1 AND window_start < $2
+			AND window_start <> date_trunc('day', window_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+		RETURNING *
+	)
+	INSERT INTO usage_records (tenant, username, subscription, model, window_start, requests, input_tokens, output_tokens, total_tokens, updated_at)
+	SELECT tenant, username, subscription, model, date_trunc('day', window_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+		SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens), $3
+	FROM hourly
+	GROUP BY tenant, username, subscription, model, day
+	ON CONFLICT (tenant, username, subscription, model, window_start) DO UPDATE SET
+		requests = usage_records.requests + EXCLUDED.requests,
+		input_tokens = usage_records.input_tokens + EXCLUDED.input_tokens,
+		output_tokens = usage_records.output_tokens + EXCLUDED.output_tokens,
+		total_tokens = usage_records.total_tokens + EXCLUDED.total_tokens,
+		updated_at = EXCLUDED.updated_at`, `
+	WITH hourly AS (
+		DELETE FROM usage_key_records
+		WHERE tenant = $1 AND window_start < $2
+			AND window_start <> date_trunc('day', window_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+		RETURNING *
+	)
+	INSERT INTO usage_key_records (tenant, key_id, username, window_start, requests, input_tokens, output_tokens, total_tokens, updated_at)
+	SELECT tenant, key_id, MIN(username), date_trunc('day', window_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+		SUM(requests), SUM(input_tokens), SUM(output_tokens), SUM(total_tokens), $3
+	FROM hourly
+	GROUP BY tenant, key_id, day
+	ON CONFLICT (tenant, key_id, window_start) DO UPDATE SET
+		requests = usage_key_records.requests + EXCLUDED.requests,
+		input_tokens = usage_key_records.input_tokens + EXCLUDED.input_tokens,
+		output_tokens = usage_key_records.output_tokens + EXCLUDED.output_tokens,
+		total_tokens = usage_key_records.total_tokens + EXCLUDED.total_tokens,
+		updated_at = EXCLUDED.updated_at`,
+}
+
+// Compact rolls the hourly windows before rollupBefore up into daily windows and
+// deletes the windows before deleteBefore.
+func (s *PostgresStore) Compact(ctx context.Context, rollupBefore, deleteBefore time.Time) (CompactionResult, error) {
+	var result CompactionResult
+	if !rollupBefore.IsZero() {
+		now := time.Now().UTC()
+		for _, query := range rollupQueries {
+			res, err := s.db.ExecContext(ctx, query, s.tenantName, rollupBefore.UTC(), now)
+			if err != nil {
+				return result, fmt.Errorf("failed to roll up usage: %w", err)
+			}
+			n, _ := res.RowsAffected()
+			result.RolledUp += n
+		}
+	}
+	if !deleteBefore.IsZero() {
+		for _, table := range []string{"usage_records", "usage_key_records"} {
+			//nolint:gosec // G201: the table is one of fixed names.
+			res, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE tenant = $1 AND window_start < $2`, table),
+				s.tenantName, deleteBefore.UTC())
+			if err != nil {
+				return result, fmt.Errorf("failed to delete expired usage: %w", err)
+			}
+			n, _ := res.RowsAffected()
+			result.Deleted += n
+		}
+	}
+	return result, nil
+}
+
+const flagKeyQuery = `
+	INSERT INTO usage_key_anomalies (tenant, key_id, rule, window_start, network, username, requests, detail, detected_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (tenant, key_id, rule, window_start, network) DO NOTHING
+`
+
+// FlagKey records the anomaly unless the key was already flagged for it, by this or
+// another replica.
+func (s *PostgresStore) FlagKey(ctx context.Context, a Anomaly) (bool, error) {
+	result, err := s.db.ExecContext(ctx, flagKeyQuery,
+		s.tenantName, a.KeyID, string(a.Rule), windowStart(a.WindowStart), a.Network,
+		a.Username, a.Requests, a.Detail, a.DetectedAt.UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to flag API key: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to flag API key: %w", err)
+	}
+	return inserted == 1, nil
+}
+
+// Anomalies returns the anomalies between from and to, most recently detected first.
+func (s *PostgresStore) Anomalies(ctx context.Context, from, to time.Time) ([]Anomaly, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT key_id, username, rule, window_start, network, requests, detail, detected_at
+		FROM usage_key_anomalies
+		WHERE tenant = $1 AND window_start >= $2 AND window_start < $3
+		ORDER BY detected_at DESC, key_id
+		LIMIT $4
+	`, s.tenantName, from.UTC(), to.UTC(), MaxQueryRecords)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API key anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	anomalies := []Anomaly{}
+	for rows.Next() {
+		var a Anomaly
+		var rule string
+		if err := rows.Scan(&a.KeyID, &a.Username, &rule, &a.WindowStart, &a.Network,
+			&a.Requests, &a.Detail, &a.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API key anomalies: %w", err)
+		}
+		a.Rule = AnomalyRule(rule)
+		a.WindowStart, a.DetectedAt = a.WindowStart.UTC(), a.DetectedAt.UTC()
+		anomalies = append(anomalies, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query API key anomalies: %w", err)
+	}
+	return anomalies, nil
+}
+
+// AddKeyNetworks records the networks of the API key. Replicas recording the first
+// networks of a key at the same time may both count none before.
+func (s *PostgresStore) AddKeyNetworks(ctx context.Context, keyID string, networks []string, seenAt time.Time) ([]string, int, error) {
+	var known int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM usage_key_networks WHERE tenant = $1 AND key_id = $2`,
+		s.tenantName, keyID).Scan(&known); err != nil {
+		return nil, 0, fmt.Errorf("failed to query API key networks: %w", err)
+	}
+	var added []string
+	for _, network := range networks {
+		result, err := s.db.ExecContext(ctx, `
+			INSERT INTO usage_key_networks (tenant, key_id, network, first_seen)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant, key_id, network) DO NOTHING
+		`, s.tenantName, keyID, network, seenAt.UTC())
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to record API key networks: %w", err)
+		}
+		if inserted, err := result.RowsAffected(); err == nil && inserted == 1 {
+			added = append(added, network)
+		}
+	}
+	return added, known, nil
+}
@@ -0,0 +1,200 @@
+// Package usage meters what users consume: tokens from the Limitador counters of the
+// subscriptions' token rate limits and requests from the gateway access logs, aggregated
+// per user, subscription, model and hour.
+package usage
+
+import (
+	"errors"
+	"time"
+)
+
+// WindowSize is the length of the windows usage is aggregated into.
+const WindowSize = time.Hour
+
+// Direction is the kind of tokens a Limitador counter counts.
+type Direction string
+
+const (
+	DirectionTotal  Direction = "total"
+	DirectionInput  Direction = "input"
+	DirectionOutput Direction = "output"
+)
+
+// Granularity is the length of the windows a query returns.
+type Granularity string
+
+const (
+	GranularityHour Granularity = "hour"
+	GranularityDay  Granularity = "day"
+)
+
+var ErrInvalidQuery = errors.New("invalid usage query")
+
+// Record is the usage of a user of a subscription for a model in a window.
+// Model is the MaaSModelRef as "namespace/name".
+type Record struct {
+	Username     string    `json:"user"`
+	Subscription string    `json:"subscription"`
+	Model        string    `json:"model"`
+	WindowStart  time.Time `json:"windowStart"`
+	Requests     int64     `json:"requests"`
+	InputTokens  int64     `json:"inputTokens"`
+	OutputTokens int64     `json:"outputTokens"`
+	TotalTokens  int64     `json:"totalTokens"`
+}
+
+// KeyRecord is the usage of an API key in a window, from the gateway access logs. The
+// tokens are only known when the access logs report them.
+type KeyRecord struct {
+	KeyID        string    `json:"keyId"`
+	Username     string    `json:"user"`
+	WindowStart  time.Time `json:"windowStart"`
+	Requests     int64     `json:"requests"`
+	InputTokens  int64     `json:"inputTokens"`
+	OutputTokens int64     `json:"outputTokens"`
+	TotalTokens  int64     `json:"totalTokens"`
+}
+
+// Totals is the sum of the usage of records.
+type Totals struct {
+	Requests     int64 `json:"requests"`
+	InputTokens  int64 `json:"inputTokens"`
+	OutputTokens int64 `json:"outputTokens"`
+	TotalTokens  int64 `json:"totalTokens"`
+}
+
+// Query selects usage records. Empty filters match everything.
+type Query struct {
+	Username     string
+	Subscription string
+	Model        string
+	From         time.Time // inclusive
+	To           time.Time // exclusive
+	Granularity  Granularity
+}
+
+// Dimension is what the consumers of a leaderboard are.
+type Dimension string
+
+const (
+	DimensionUser  Dimension = "user"
+	DimensionModel Dimension = "model"
+	DimensionKey   Dimension = "key"
+)
+
+const (
+	// DefaultTopLimit is the number of consumers of a leaderboard without a limit.
+	DefaultTopLimit = 10
+	// MaxTopLimit is the maximum number of consumers of a leaderboard.
+	MaxTopLimit = 100
+)
+
+// TopQuery selects the heaviest consumers of the windows between From (inclusive) and
+// To (exclusive).
+type TopQuery struct {
+	By    Dimension
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+// Consumer is an entry of a leaderboard: a user, a model, or an API key and its owner.
+type Consumer struct {
+	Rank         int    `json:"rank"`
+	Username     string `json:"user,omitempty"`
+	Model        string `json:"model,omitempty"`
+	KeyID        string `json:"keyId,omitempty"`
+	Requests     int64  `json:"requests"`
+	InputTokens  int64  `json:"inputTokens"`
+	OutputTokens int64  `json:"outputTokens"`
+	TotalTokens  int64  `json:"totalTokens"`
+	// Tokens ranks the consumers: the total tokens of each window, or its input plus
+	// output tokens when the subscription only counts tokens per direction.
+	Tokens int64 `json:"tokens"`
+}
+
+// consumedTokens returns the tokens a window counts towards the ranking.
+func consumedTokens(input, output, total int64) int64 {
+	return max(total, input+output)
+}
+
+// CounterSample is the value of a Limitador counter at a scrape.
+type CounterSample struct {
+	// Key identifies the counter: its Limitador namespace, limit and counted user.
+	Key          string
+	Username     string
+	Subscription string
+	Model        string
+	Direction    Direction
+	// Consumed is what the counter has counted in its current window.
+	Consumed int64
+	// ExpiresAt is the end of the counter's current window.
+	ExpiresAt  time.Time
+	ObservedAt time.Time
+}
+
+// counterState is what a store keeps of a counter between scrapes.
+type counterState struct {
+	Consumed  int64
+	ExpiresAt time.Time
+}
+
+// counterDelta returns what a counter consumed since the state of the previous scrape.
+// A counter seen for the first time, past its previous window or lower than before has
+// started a new window, so all of its value is new.
+func counterDelta(prev *counterState, s CounterSample) int64 {
+	if prev == nil || s.ObservedAt.After(prev.ExpiresAt) || s.Consumed < prev.Consumed {
+		return max(s.Consumed, 0)
+	}
+	return s.Consumed - prev.Consumed
+}
+
+// windowStart returns the start of the window t falls into.
+func windowStart(t time.Time) time.Time {
+	return t.UTC().Truncate(WindowSize)
+}
+
+// bucketStart returns the start of the granularity's window t falls into.
+func bucketStart(t time.Time, g Granularity) time.Time {
+	t = t.UTC()
+	if g == GranularityDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(time.Hour)
+}
+
+// addTokens adds delta to the record's tokens of the direction.
+func (r *Record) addTokens(direction Direction, delta int64) {
+	switch direction {
+	case DirectionInput:
+		r.InputTokens += delta
+	case DirectionOutput:
+		r.OutputTokens += delta
+	default:
+		r.TotalTokens += delta
+	}
+}
+
+// Sum returns the totals of the records.
+func Sum(records []Record) Totals {
+	var t Totals
+	for _, r := range records {
+		t.Requests += r.Requests
+		t.InputTokens += r.InputTokens
+		t.OutputTokens += r.OutputTokens
+		t.TotalTokens += r.TotalTokens
+	}
+	return t
+}
+
+// SumKeys returns the totals of the API key records.
+func SumKeys(records []KeyRecord) Totals {
+	var t Totals
+	for _, r := range records {
+		t.Requests += r.Requests
+		t.InputTokens += r.InputTokens
+		t.OutputTokens += r.OutputTokens
+		t.TotalTokens += r.TotalTokens
+	}
+	return t
+}
@@ -38,10 +38,38 @@ const (
 	// ConditionRuntimeReady indicates whether the model's backend
 	// (routes, gateways, inference service) is healthy and serving.
 	ConditionRuntimeReady = "RuntimeReady"
+
+	// ConditionWaitingForRoute is True while the model's HTTPRoute does not exist yet
+	// and the controller is waiting for it to be created.
+	ConditionWaitingForRoute = "WaitingForRoute"
+
+	// ConditionPoliciesEnforced indicates whether at least one Kuadrant AuthPolicy
+	// targeting the model's HTTPRoute or Gateway is Accepted and Enforced.
+	ConditionPoliciesEnforced = "PoliciesEnforced"
+
+	// ConditionDegraded is True when a Ready model's endpoint fails the controller's
+	// periodic GET <endpoint>/v1/models probe; the message carries the observed status code.
+	ConditionDegraded = "Degraded"
+
+	// ConditionGatewayListenerCompatible indicates whether the Gateway the model's HTTPRoute
+	// attaches to has a listener whose protocol, allowedRoutes and hostname accept the route.
+	ConditionGatewayListenerCompatible = "GatewayListenerCompatible"
+
+	// ConditionFailoverActive is True while traffic for the model is routed to its
+	// spec.failover backend because the primary LLMInferenceService is not Ready.
+	ConditionFailoverActive = "FailoverActive"
+
+	// ConditionSessionAffinityConfigured indicates whether spec.routing.sessionAffinity
+	// has been translated into load-balancer configuration for the model's backends.
+	ConditionSessionAffinityConfigured = "SessionAffinityConfigured"
+
+	// ConditionRoutingPolicyApplied indicates whether spec.routing timeouts and retries
+	// have been applied to the model's traffic.
+	ConditionRoutingPolicyApplied = "RoutingPolicyApplied"
 )
 
 // ConditionReason represents a machine-readable reason for a status condition.
-// +kubebuilder:validation:Enum=Reconciled;ReconcileFailed;PartialFailure;Valid;NotFound;GetFailed;Accepted;AcceptedEnforced;NotAccepted;Enforced;NotEnforced;BackendNotReady;ConditionsNotFound;InvalidSpec;Unknown;NoPairingFound;GovernancePaired;GovernanceGap;RuntimeHealthy;RuntimeHealthFailure
+// +kubebuilder:validation:Enum=Reconciled;ReconcileFailed;PartialFailure;Valid;NotFound;GetFailed;Accepted;AcceptedEnforced;NotAccepted;Enforced;NotEnforced;BackendNotReady;ConditionsNotFound;InvalidSpec;Unknown;NoPairingFound;GovernancePaired;GovernanceGap;RuntimeHealthy;RuntimeHealthFailure;ConflictingPolicy;PolicyNotEnforced;NamespaceNotWatched;CleanupFailed;TemplateNotFound
 type ConditionReason string
 
 // Reason constants for status conditions and per-item statuses.
@@ -62,6 +90,9 @@ const (
 	// ReasonNotFound indicates a referenced resource was not found.
 	ReasonNotFound ConditionReason = "NotFound"
 
+	// ReasonInvalidRateLimits indicates a modelRef's rate limits cannot be enforced as written.
+	ReasonInvalidRateLimits ConditionReason = "InvalidRateLimits"
+
 	// ReasonGetFailed indicates a failure when fetching a resource.
 	ReasonGetFailed ConditionReason = "GetFailed"
 
@@ -83,9 +114,29 @@ const (
 	// ReasonBackendNotReady indicates the backend service is not ready.
 	ReasonBackendNotReady ConditionReason = "BackendNotReady"
 
+	// ReasonConflictingPolicy indicates a policy not created by MaaS targets the same
+	// resource, so the generated policy is not applied.
+	ReasonConflictingPolicy ConditionReason = "ConflictingPolicy"
+
+	// ReasonPolicyNotEnforced indicates a generated policy is not accepted or not
+	// enforced, so the limits it carries do not apply.
+	ReasonPolicyNotEnforced ConditionReason = "PolicyNotEnforced"
+
 	// ReasonConditionsNotFound indicates status conditions are not available.
 	ReasonConditionsNotFound ConditionReason = "ConditionsNotFound"
 
+	// ReasonNamespaceNotWatched indicates the resource is in a namespace the controller
+	// does not watch in namespace-scoped mode.
+	ReasonNamespaceNotWatched ConditionReason = "NamespaceNotWatched"
+
+	// ReasonCleanupFailed indicates the finalizer could not clean up some of the
+	// generated policies of a resource being deleted.
+	ReasonCleanupFailed ConditionReason = "CleanupFailed"
+
+	// ReasonTemplateNotFound indicates the MaaSSubscriptionTemplate or
+	// MaaSAuthPolicyTemplate named by a templateRef does not exist.
+	ReasonTemplateNotFound ConditionReason = "TemplateNotFound"
+
 	// ReasonInvalidSpec indicates the resource spec is missing or structurally invalid.
 	ReasonInvalidSpec ConditionReason = "InvalidSpec"
 
@@ -112,6 +163,18 @@ const (
 	ReasonRuntimeHealthFailure ConditionReason = "RuntimeHealthFailure"
 )
 
+// CleanupFailure records a model whose generated policies could not be cleaned up while
+// a resource was being deleted. The finalizer retries only the failed models.
+type CleanupFailure struct {
+	// Model is the namespace-qualified name of the model ("namespace/name"). Empty for
+	// cleanup that is not tied to a model, such as the gateway AuthPolicy.
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// Message describes the error of the last cleanup attempt.
+	Message string `json:"message"`
+}
+
 // ResourceRefStatus is the common status for any referenced Kubernetes resource.
 // Embedded by specific status types for type safety (follows metav1.Condition pattern).
 type ResourceRefStatus struct {
@@ -131,3 +194,25 @@ type ResourceRefStatus struct {
 	// +optional
 	Message string `json:"message,omitempty"`
 }
+
+// GeneratedResourcePreview is a rendered summary of a resource the controller would
+// generate for a MaaSSubscription or MaaSAuthPolicy in dry-run mode. Nothing described
+// here is applied to the cluster.
+type GeneratedResourcePreview struct {
+	// Kind of the generated resource (TokenRateLimitPolicy or AuthPolicy)
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind"`
+	// Name of the generated resource
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Namespace of the generated resource
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+	// Model is the namespace/name of the MaaSModelRef the preview applies to
+	// +kubebuilder:validation:MaxLength=317
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Rendered is the JSON rendering of what the controller would write
+	// +kubebuilder:validation:MaxLength=32768
+	Rendered string `json:"rendered"`
+}
@@ -21,18 +21,430 @@ import (
 )
 
 // MaaSAuthPolicySpec defines the desired state of MaaSAuthPolicy
+// +kubebuilder:validation:XValidation:rule="(has(self.authorization) && has(self.authorization.subjectAccessReview)) || (has(self.subjects.groups) && size(self.subjects.groups) > 0) || (has(self.subjects.users) && size(self.subjects.users) > 0) || (has(self.subjects.deniedUsers) && size(self.subjects.deniedUsers) > 0)",message="at least one group, user or denied user must be specified in subjects unless authorization.subjectAccessReview is set"
 type MaaSAuthPolicySpec struct {
 	// ModelRefs is a list of models (by name and namespace) that this policy grants access to
 	// +kubebuilder:validation:MinItems=1
 	ModelRefs []ModelRef `json:"modelRefs"`
 
 	// Subjects defines who has access (OR logic - any match grants access)
-	// +kubebuilder:validation:XValidation:rule="size(self.groups) > 0 || size(self.users) > 0",message="at least one group or user must be specified in subjects"
 	Subjects SubjectSpec `json:"subjects"`
 
+	// Priority decides between contradicting rules of the MaaSAuthPolicies of a model: a
+	// user one policy allows and another denies, or a path rule one policy allows and
+	// another denies. The rule of the policy with the higher priority wins; on a tie the
+	// denial is kept and the policies report a Conflict condition. Subjects and rules that
+	// do not contradict are merged across policies. Defaults to 0.
+	// +kubebuilder:default=0
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
 	// MeteringMetadata contains billing and tracking information
 	// +optional
 	MeteringMetadata *MeteringMetadata `json:"meteringMetadata,omitempty"`
+
+	// Authentication adds identity sources to the API keys and Kubernetes tokens the
+	// gateway accepts for the policy's models.
+	// +optional
+	Authentication *AuthenticationSpec `json:"authentication,omitempty"`
+
+	// Rules restrict the API paths of the policy's models that may be called, for every
+	// subject of the model. A request matching a Deny rule is refused; once a model has
+	// Allow rules, only requests matching one of them are allowed.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Rules []PathRule `json:"rules,omitempty"`
+
+	// Authorization adds access checks to the policy's models beyond its subjects, for
+	// rules too complex for group and user lists, e.g. export control or data residency.
+	// +optional
+	Authorization *AuthorizationSpec `json:"authorization,omitempty"`
+
+	// RequiredScopes restricts the JWTs accepted for the policy's models to those carrying
+	// model-specific scopes or a client role, so that model entitlements can be managed in
+	// the identity provider, e.g. Keycloak. API keys and Kubernetes tokens are not affected.
+	// +optional
+	RequiredScopes *RequiredScopes `json:"requiredScopes,omitempty"`
+
+	// AccessWindows restrict when the policy's models may be called, e.g. only during
+	// business hours or not during a maintenance freeze. Outside the Allow windows, or
+	// inside a Deny window, requests to the models are refused whoever makes them. A
+	// model whose policies set windows needs the windows of each policy to allow access.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	AccessWindows []AccessWindow `json:"accessWindows,omitempty"`
+
+	// AllowedCIDRs restrict the source addresses the policy's models may be called from,
+	// e.g. the office networks of an on-premises cluster. Requests from other addresses
+	// are refused whoever makes them. A modelRef's allowedCIDRs restrict its model further.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}/[0-9]{1,2}$`
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+
+	// IdentityHeaders adds headers identifying the caller to the requests the gateway
+	// forwards to the policy's models, so model servers and logging sidecars can attribute
+	// them. A header is added to a model's requests when any policy of the model enables it.
+	// +optional
+	IdentityHeaders *IdentityHeaders `json:"identityHeaders,omitempty"`
+
+	// DenyResponse customizes the responses the gateway sends when it refuses a request to
+	// the policy's models, e.g. a JSON error with a link to the access request docs instead
+	// of a plain 403. Of the policies of a model, the one with the highest priority sets its
+	// responses.
+	// +optional
+	DenyResponse *DenyResponses `json:"denyResponse,omitempty"`
+
+	// TemplateRef names a MaaSAuthPolicyTemplate in the same namespace whose rules are
+	// added to the gateway AuthPolicy, e.g. an audit callback or an extra authorization
+	// check, without opting the generated policy out of management.
+	// +optional
+	TemplateRef *AuthPolicyTemplateReference `json:"templateRef,omitempty"`
+}
+
+// DenyResponses are the responses to refused requests, by why they were refused.
+type DenyResponses struct {
+	// Unauthenticated is the response to requests without a valid credential.
+	// +optional
+	Unauthenticated *DenyResponse `json:"unauthenticated,omitempty"`
+
+	// Unauthorized is the response to requests whose caller may not use the model.
+	// +optional
+	Unauthorized *DenyResponse `json:"unauthorized,omitempty"`
+}
+
+// DenyResponse is the response to a refused request.
+type DenyResponse struct {
+	// StatusCode is the HTTP status of the response. The gateway answers the refused
+	// requests of all models of a tenant with one status per kind of denial, that of the
+	// policy with the highest priority setting one. Defaults to 401 for unauthenticated
+	// and 403 for unauthorized requests.
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	StatusCode int32 `json:"statusCode,omitempty"`
+
+	// Body is a JSON template of the response body. {{model}}, {{reason}}, {{message}}
+	// and {{docsUrl}} are replaced with the requested model, the reason and message of
+	// the denial, and DocsURL, escaped for a JSON string.
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Headers are added to the response, e.g. WWW-Authenticate.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Headers []DenyResponseHeader `json:"headers,omitempty"`
+
+	// DocsURL links the response to documentation, e.g. on how to request access, in a
+	// Link header with rel="help".
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://[^\s<>"]+$`
+	// +optional
+	DocsURL string `json:"docsUrl,omitempty"`
+}
+
+// DenyResponseHeader is a header of a deny response.
+type DenyResponseHeader struct {
+	// Name is the header name.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	Name string `json:"name"`
+
+	// Value is the header value.
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:Pattern=`^[^\r\n]*$`
+	Value string `json:"value"`
+}
+
+// PathRuleAction is what a PathRule does with the requests it matches.
+// +kubebuilder:validation:Enum=Allow;Deny
+type PathRuleAction string
+
+const (
+	// PathRuleAllow allows matching requests; requests matching no Allow rule are refused.
+	PathRuleAllow PathRuleAction = "Allow"
+	// PathRuleDeny refuses matching requests.
+	PathRuleDeny PathRuleAction = "Deny"
+)
+
+// HTTPMethod is the method of a request.
+// +kubebuilder:validation:Enum=GET;POST;PUT;PATCH;DELETE;HEAD;OPTIONS
+type HTTPMethod string
+
+// PathRule matches requests to a model by API path and method.
+type PathRule struct {
+	// Paths are API paths of the model, e.g. /v1/chat/completions, without the
+	// /<namespace>/<name> prefix of model routes. A trailing "*" matches any suffix,
+	// e.g. /v1/files*.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=256
+	// +kubebuilder:validation:items:Pattern=`^/[A-Za-z0-9._~/-]*\*?$`
+	Paths []string `json:"paths"`
+
+	// Methods limits the rule to these HTTP methods; empty matches every method.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Methods []HTTPMethod `json:"methods,omitempty"`
+
+	// Action allows or denies the matching requests.
+	Action PathRuleAction `json:"action"`
+}
+
+// Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// AccessWindowAction is what an AccessWindow does with the requests made inside it.
+// +kubebuilder:validation:Enum=Allow;Deny
+type AccessWindowAction string
+
+const (
+	// AccessWindowAllow allows requests inside the window; once a policy has Allow
+	// windows, requests outside all of them are refused.
+	AccessWindowAllow AccessWindowAction = "Allow"
+	// AccessWindowDeny refuses requests inside the window.
+	AccessWindowDeny AccessWindowAction = "Deny"
+)
+
+// AccessWindow is a recurring weekly time window.
+type AccessWindow struct {
+	// Days are the days the window starts on; empty means every day.
+	// +kubebuilder:validation:MaxItems=7
+	// +listType=set
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, as HH:MM.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the window closes, as HH:MM, excluded from the window. An
+	// end before the start closes the window the next day; an end equal to the start
+	// makes the window last the whole day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// TimeZone is the IANA time zone of Days, Start and End (e.g. "Europe/Berlin").
+	// Defaults to UTC.
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Action allows or denies the requests made inside the window. Defaults to Allow.
+	// +kubebuilder:default=Allow
+	// +optional
+	Action AccessWindowAction `json:"action,omitempty"`
+}
+
+// AuthorizationSpec configures additional access checks of a MaaSAuthPolicy.
+type AuthorizationSpec struct {
+	// OPA evaluates an Open Policy Agent Rego policy for each request to the policy's
+	// models. A request is refused unless the policy's allow rule is true, in addition to
+	// the subjects granting access.
+	// +optional
+	OPA *OPAPolicy `json:"opa,omitempty"`
+
+	// SubjectAccessReview authorizes the requests to the policy's models with a Kubernetes
+	// SubjectAccessReview of the caller on the virtual resource models.maas.opendatahub.io,
+	// named after the model in the model's namespace, so that access can be granted with
+	// standard Roles and RoleBindings. Once a policy of a model sets it, callers of the
+	// model need the permission: users and groups in subjects no longer grant access on
+	// their own, while subjects.deniedUsers are still refused.
+	// +optional
+	SubjectAccessReview *SubjectAccessReviewAuthorization `json:"subjectAccessReview,omitempty"`
+}
+
+// SubjectAccessReviewAuthorization checks the caller's RBAC permission on a model.
+type SubjectAccessReviewAuthorization struct {
+	// Verb is the verb the caller needs on the model. When the policies of a model set
+	// different verbs, the caller needs all of them.
+	// +kubebuilder:validation:Enum=get;use
+	// +kubebuilder:default=get
+	// +optional
+	Verb string `json:"verb,omitempty"`
+}
+
+// OPAPolicy is a Rego policy, inline or served at a URL. Its input is the authorization
+// JSON of the gateway: the caller under input.auth.identity and the request under
+// input.context.request.http.
+// +kubebuilder:validation:XValidation:rule="has(self.rego) != has(self.externalPolicyUrl)",message="exactly one of rego or externalPolicyUrl must be set"
+type OPAPolicy struct {
+	// Rego is the source of the policy, without a package declaration. It must define
+	// an allow rule.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=16384
+	// +optional
+	Rego string `json:"rego,omitempty"`
+
+	// ExternalPolicyURL serves the Rego source of the policy over HTTPS, so that it can be
+	// maintained outside the cluster, e.g. in a policy repository.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://\S+$`
+	// +optional
+	ExternalPolicyURL string `json:"externalPolicyUrl,omitempty"`
+
+	// RefreshSeconds is how often the policy at ExternalPolicyURL is fetched again.
+	// Defaults to 300.
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	RefreshSeconds int64 `json:"refreshSeconds,omitempty"`
+}
+
+// RequiredScopes lists the scopes or client roles a JWT needs for the policy's models. A
+// token is accepted when it carries all of Scopes, or any of ClientRoles.
+// +kubebuilder:validation:XValidation:rule="(has(self.scopes) && size(self.scopes) > 0) || (has(self.clientRoles) && size(self.clientRoles) > 0)",message="at least one scope or client role must be specified"
+type RequiredScopes struct {
+	// Scopes must all be granted to the token, in its space-separated scope claim or its
+	// scp claim.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +kubebuilder:validation:items:Pattern=`^[!#-\[\]-~]+$`
+	// +listType=set
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// ClientRoles are client roles in the token's resource_access claim, as issued by
+	// Keycloak; any of them grants access.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+}
+
+// ClientRole is a role of an OAuth client, found in the resource_access.<client>.roles
+// claim of a token.
+type ClientRole struct {
+	// Client is the client ID.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[^"\\]+$`
+	Client string `json:"client"`
+
+	// Role is the name of the client role.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[^"\\]+$`
+	Role string `json:"role"`
+}
+
+// IdentityHeaders selects the identity headers the gateway sets on upstream requests. The
+// gateway replaces any value the client sent for an enabled header.
+type IdentityHeaders struct {
+	// User sets X-MaaS-User to the caller's username.
+	// +optional
+	User bool `json:"user,omitempty"`
+
+	// Groups sets X-MaaS-Groups to the caller's groups, comma-separated.
+	// +optional
+	Groups bool `json:"groups,omitempty"`
+
+	// Subscription sets X-MaaS-Subscription to the name of the MaaSSubscription the
+	// request was admitted under.
+	// +optional
+	Subscription bool `json:"subscription,omitempty"`
+}
+
+// AuthenticationSpec configures additional identity sources of a MaaSAuthPolicy.
+type AuthenticationSpec struct {
+	// APIKey configures the sk-oai-* API keys minted by maas-api, which the gateway
+	// validates with maas-api's /internal/v1/api-keys/validate endpoint. API keys are
+	// accepted unless disabled.
+	// +optional
+	APIKey *APIKeyAuthentication `json:"apiKey,omitempty"`
+
+	// JWT accepts tokens of an OIDC provider, e.g. Keycloak, validated directly against
+	// the issuer's signing keys, so clients need no Kubernetes service account token.
+	// +optional
+	JWT *JWTAuthentication `json:"jwt,omitempty"`
+
+	// TokenReview configures the Kubernetes TokenReview of service account tokens.
+	// +optional
+	TokenReview *TokenReviewAuthentication `json:"tokenReview,omitempty"`
+
+	// X509 accepts TLS client certificates signed by a trusted CA, for clients that
+	// authenticate with certificates instead of bearer tokens. The gateway listener must
+	// request client certificates; the certificate's common name is the user and its
+	// organizations are the groups.
+	// +optional
+	X509 *X509Authentication `json:"x509,omitempty"`
+
+	// CacheTTL is how long the gateway reuses the TokenReview of a Kubernetes token, and
+	// caps how long it reuses API key validations and access decisions. A revoked
+	// credential or removed subject is refused at most this long after the change;
+	// shorter values cost more TokenReviews and maas-api calls. The shortest CacheTTL of
+	// the tenant's policies applies to its gateway; 0s disables caching.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s') && duration(self) <= duration('10m')",message="cacheTTL must be between 0s and 10m"
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+}
+
+// APIKeyAuthentication configures the API keys of a MaaSAuthPolicy.
+type APIKeyAuthentication struct {
+	// Enabled accepts API keys for the policy's models, with the username and groups
+	// maas-api returns for the key as the identity. Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// X509Authentication accepts the client certificates of trusted CAs.
+type X509Authentication struct {
+	// CASecretRef references the Secret holding the PEM-encoded CA certificates client
+	// certificates must be signed by.
+	CASecretRef CASecretReference `json:"caSecretRef"`
+}
+
+// CASecretReference references a Secret with CA certificates in the namespace of the
+// MaaSAuthPolicy.
+type CASecretReference struct {
+	// Name is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the data key of the PEM-encoded CA certificates. Defaults to ca.crt.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// TokenReviewAuthentication configures the Kubernetes TokenReview of the gateway.
+type TokenReviewAuthentication struct {
+	// Audiences are accepted in addition to the cluster's API server audience, e.g. the
+	// "<gateway-name>-sa" audience of tokens requested for the gateway's service account.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +kubebuilder:validation:items:Pattern=`^[^"\\]+$`
+	Audiences []string `json:"audiences"`
+}
+
+// JWTAuthentication accepts the JWTs of one issuer.
+type JWTAuthentication struct {
+	// IssuerURL is the issuer of the tokens; it must match their iss claim. The signing
+	// keys (JWKS) are discovered from its /.well-known/openid-configuration.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://[^"\\]+$`
+	IssuerURL string `json:"issuerUrl"`
+
+	// Audiences, when set, requires the aud claim of a token to contain one of them.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MaxLength=256
+	// +kubebuilder:validation:items:Pattern=`^[^"\\]+$`
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JWKSRefreshSeconds is how often the issuer's JWKS is fetched again, so that rotated
+	// signing keys are picked up. Defaults to 300.
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	JWKSRefreshSeconds int64 `json:"jwksRefreshSeconds,omitempty"`
 }
 
 // ModelRef references a MaaSModelRef by name and namespace.
@@ -46,6 +458,13 @@ type ModelRef struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=63
 	Namespace string `json:"namespace"`
+
+	// AllowedCIDRs restrict the source addresses the model may be called from under a
+	// MaaSAuthPolicy, in addition to the policy's spec.allowedCIDRs. Ignored elsewhere.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}/[0-9]{1,2}$`
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
 }
 
 // SubjectSpec defines the subjects that have access
@@ -57,6 +476,11 @@ type SubjectSpec struct {
 	// Users is a list of Kubernetes user names
 	// +optional
 	Users []string `json:"users,omitempty"`
+
+	// DeniedUsers are refused access to the policy's models, even when a group or another
+	// MaaSAuthPolicy grants it, e.g. to lock out a user without changing group membership.
+	// +optional
+	DeniedUsers []string `json:"deniedUsers,omitempty"`
 }
 
 // GroupReference references a Kubernetes group
@@ -106,11 +530,22 @@ type MaaSAuthPolicyStatus struct {
 	// AuthPolicies lists the underlying Kuadrant AuthPolicies and their status.
 	// +optional
 	AuthPolicies []AuthPolicyRefStatus `json:"authPolicies,omitempty"`
+
+	// DryRunPreview lists the gateway AuthPolicy access rules the controller would
+	// generate while the maas.opendatahub.io/dry-run annotation is set to "true"
+	// +optional
+	DryRunPreview []GeneratedResourcePreview `json:"dryRunPreview,omitempty"`
+
+	// CleanupFailures lists the models whose generated AuthPolicies could not be cleaned
+	// up while the policy is being deleted. The finalizer is kept until they are.
+	// +optional
+	CleanupFailures []CleanupFailure `json:"cleanupFailures,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 //+kubebuilder:printcolumn:name="AuthPolicies",type="string",JSONPath=".status.authPolicies[*].name",priority=1
 
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// MaaSAuthPolicyTemplateSpec defines Authorino rules that the controller adds to the
+// gateway AuthPolicy it generates for the MaaSAuthPolicies that reference the template
+// through spec.templateRef. Each entry is an Authorino rule of the kuadrant.io/v1
+// AuthPolicy, keyed by rule name, and is copied as written. Names of generated rules
+// cannot be reused.
+type MaaSAuthPolicyTemplateSpec struct {
+	// Authorization rules are evaluated in addition to the generated ones; a request must
+	// pass all of them, so they can only restrict access further.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Authorization map[string]runtime.RawExtension `json:"authorization,omitempty"`
+
+	// Callbacks are HTTP requests the gateway sends after a request has been authorized
+	// or denied, e.g. to notify an audit service.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Callbacks map[string]runtime.RawExtension `json:"callbacks,omitempty"`
+
+	// Response adds request headers and dynamic metadata to allowed requests.
+	// +optional
+	Response *AuthPolicyTemplateResponse `json:"response,omitempty"`
+}
+
+// AuthPolicyTemplateResponse holds the success response entries of a MaaSAuthPolicyTemplate.
+type AuthPolicyTemplateResponse struct {
+	// Headers are added to the requests forwarded to the model. Headers the controller
+	// sets itself, e.g. X-MaaS-Username, cannot be overridden.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Headers map[string]runtime.RawExtension `json:"headers,omitempty"`
+
+	// Filters are added to the dynamic metadata available to later filters, such as
+	// rate limiting, under auth.<name>.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Filters map[string]runtime.RawExtension `json:"filters,omitempty"`
+}
+
+// AuthPolicyTemplateReference names a MaaSAuthPolicyTemplate in the namespace of the
+// MaaSAuthPolicy.
+type AuthPolicyTemplateReference struct {
+	// Name of the MaaSAuthPolicyTemplate
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MaaSAuthPolicyTemplate is the Schema for the maasauthpolicytemplates API
+type MaaSAuthPolicyTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MaaSAuthPolicyTemplateSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// MaaSAuthPolicyTemplateList contains a list of MaaSAuthPolicyTemplate
+type MaaSAuthPolicyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaaSAuthPolicyTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaaSAuthPolicyTemplate{}, &MaaSAuthPolicyTemplateList{})
+}
@@ -38,6 +38,9 @@ type MaaSModelRef struct {
 }
 
 // MaaSModelSpec defines the desired state of MaaSModelRef
+// +kubebuilder:validation:XValidation:rule="!has(self.failover) || self.modelRef.kind == 'LLMInferenceService'",message="failover is only supported for modelRef.kind LLMInferenceService"
+// +kubebuilder:validation:XValidation:rule="!has(self.failover) || self.failover.modelRef.name != self.modelRef.name",message="failover.modelRef must differ from modelRef"
+// +kubebuilder:validation:XValidation:rule="!has(self.routing) || !has(self.routing.sessionAffinity) || self.modelRef.kind == 'LLMInferenceService'",message="routing.sessionAffinity is only supported for modelRef.kind LLMInferenceService"
 type MaaSModelSpec struct {
 	// ModelRef references the actual model endpoint
 	ModelRef ModelReference `json:"modelRef"`
@@ -46,8 +49,139 @@ type MaaSModelSpec struct {
 	// or Gateway/HTTPRoute).
 	// +optional
 	EndpointOverride string `json:"endpointOverride,omitempty"`
+	// RequirePolicies, when true, keeps the model out of Ready until at least one
+	// Kuadrant AuthPolicy targeting its HTTPRoute or Gateway is Accepted and Enforced,
+	// so the model is never advertised while reachable without authentication.
+	// When unset, the controller default (--require-policies-for-ready) applies.
+	// +optional
+	RequirePolicies *bool `json:"requirePolicies,omitempty"`
+	// Visibility controls how the model is exposed in the maas-api model catalog
+	// (GET /v1/models). It is mirrored to the maas.opendatahub.io/visibility label.
+	// public: listed for every caller with access.
+	// internal: listed only for requests authenticated with a cluster user token, not API keys.
+	// hidden: never listed; the model can still be invoked by callers with access.
+	// +kubebuilder:validation:Enum=public;internal;hidden
+	// +kubebuilder:default=public
+	// +optional
+	Visibility ModelVisibility `json:"visibility,omitempty"`
+	// Maintenance, when true, drains the model for upgrades: the gateway answers every
+	// request on the model's HTTPRoute with 503 and a Retry-After header, and the model
+	// is reported in phase Maintenance so the catalog does not list it as Ready.
+	// +optional
+	Maintenance bool `json:"maintenance,omitempty"`
+	// MaintenanceRetryAfterSeconds is the Retry-After value returned while in maintenance.
+	// Defaults to 300.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaintenanceRetryAfterSeconds int32 `json:"maintenanceRetryAfterSeconds,omitempty"`
+	// Routing configures how the gateway balances, times out, and retries requests to the model.
+	// +optional
+	Routing *RoutingSpec `json:"routing,omitempty"`
+	// GlobalTokenRateLimits caps the tokens all subscribers together may consume on this
+	// model per window, protecting capacity from aggregate overload even when every
+	// subscriber is within its own subscription limits.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	GlobalTokenRateLimits []TokenRateLimit `json:"globalTokenRateLimits,omitempty"`
+	// Failover names a backup model that serves this model's traffic while the primary
+	// LLMInferenceService is not Ready, so the catalog entry stays usable during restarts.
+	// +optional
+	Failover *FailoverSpec `json:"failover,omitempty"`
 }
 
+// RoutingSpec configures request routing for a MaaSModelRef.
+type RoutingSpec struct {
+	// SessionAffinity pins requests of the same session to the same replica, for model
+	// servers that keep per-session state such as a KV cache.
+	// +optional
+	SessionAffinity *SessionAffinity `json:"sessionAffinity,omitempty"`
+	// Timeouts overrides the gateway's request timeouts, e.g. so that long-running
+	// completions are not cut off.
+	// +optional
+	Timeouts *RouteTimeouts `json:"timeouts,omitempty"`
+	// Retries bounds how often the gateway retries a failed backend request.
+	// +optional
+	Retries *RouteRetries `json:"retries,omitempty"`
+}
+
+// RouteTimeouts maps to the Gateway API HTTPRoute rule timeouts.
+// +kubebuilder:validation:XValidation:rule="!has(self.request) || !has(self.backendRequest) || duration(self.backendRequest) <= duration(self.request)",message="backendRequest must not exceed request"
+type RouteTimeouts struct {
+	// Request is the maximum time the gateway has to answer a client request,
+	// including all retries. Zero disables the timeout.
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+	// BackendRequest is the maximum time of a single request from the gateway to the
+	// backend, so that a retry can start before Request expires.
+	// +optional
+	BackendRequest *metav1.Duration `json:"backendRequest,omitempty"`
+}
+
+// RouteRetries maps to the Gateway API HTTPRoute rule retry.
+type RouteRetries struct {
+	// Attempts is the maximum number of retries of a backend request.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Attempts int32 `json:"attempts"`
+	// Codes are the backend response codes that are retried. Defaults to 502, 503 and 504.
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Minimum=400
+	// +kubebuilder:validation:items:Maximum=599
+	// +optional
+	Codes []int32 `json:"codes,omitempty"`
+	// Backoff is the minimum wait between retry attempts.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// SessionAffinityType selects what identifies a session.
+// +kubebuilder:validation:Enum=Cookie;Header
+type SessionAffinityType string
+
+const (
+	SessionAffinityCookie SessionAffinityType = "Cookie"
+	SessionAffinityHeader SessionAffinityType = "Header"
+)
+
+// SessionAffinity configures sticky sessions for a model.
+type SessionAffinity struct {
+	// Type is Cookie (the gateway issues a cookie on the first response) or Header
+	// (clients send a session identifier header).
+	// +kubebuilder:default=Cookie
+	// +optional
+	Type SessionAffinityType `json:"type,omitempty"`
+	// Name is the cookie or header name. Defaults to maas-session (Cookie) or
+	// x-session-id (Header).
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	Name string `json:"name,omitempty"`
+	// CookieTTL is the lifetime of the issued cookie. Only used with type Cookie.
+	// Defaults to 1h.
+	// +optional
+	CookieTTL *metav1.Duration `json:"cookieTTL,omitempty"`
+}
+
+// FailoverSpec configures the backup backend of a MaaSModelRef.
+type FailoverSpec struct {
+	// ModelRef references the backup LLMInferenceService in the same namespace. It must
+	// serve the same model name as the primary, since requests are forwarded unchanged.
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'LLMInferenceService'",message="failover.modelRef.kind must be LLMInferenceService"
+	ModelRef ModelReference `json:"modelRef"`
+}
+
+// ModelVisibility declares how a model is exposed in the model catalog.
+type ModelVisibility string
+
+const (
+	VisibilityPublic   ModelVisibility = "public"
+	VisibilityInternal ModelVisibility = "internal"
+	VisibilityHidden   ModelVisibility = "hidden"
+)
+
+// LabelVisibility is set by the controller on every MaaSModelRef to its effective
+// spec.visibility so that consumers (maas-api) can filter without parsing the spec.
+const LabelVisibility = "maas.opendatahub.io/visibility"
+
 // ModelReference references a model endpoint in the same namespace.
 // For kind=ExternalModel, the Name field references an ExternalModel CR in the same namespace.
 type ModelReference struct {
@@ -76,6 +210,7 @@ type ModelReference struct {
 //     or inference service) has a runtime/health failure. GovernanceAttached remains
 //     True while RuntimeReady is False.
 //   - Failed: a non-recoverable reconciliation error occurred.
+//   - Maintenance: spec.maintenance is set and the gateway answers 503 for the model.
 //   - Invalid: the resource spec is missing or structurally invalid.
 //
 // Condition types:
@@ -85,14 +220,27 @@ type ModelReference struct {
 //     or UIDs appear in any status field.
 //   - RuntimeReady: whether the model backend is healthy and serving, independent
 //     of governance state.
+//   - PoliciesEnforced: whether an AuthPolicy protecting the model's route is
+//     Accepted and Enforced. Only set when policies are required.
+//   - Degraded: whether a Ready model's endpoint fails the controller's periodic
+//     /v1/models probe. Only set when endpoint probing is enabled.
+//   - GatewayListenerCompatible: whether a listener on the route's Gateway
+//     accepts the HTTPRoute (protocol, allowedRoutes, hostname).
+//   - FailoverActive: whether traffic is routed to spec.failover because the
+//     primary backend is not Ready. Only set when spec.failover is configured.
+//   - SessionAffinityConfigured: whether spec.routing.sessionAffinity is applied
+//     to the model's backends. Only set when session affinity is configured.
+//   - RoutingPolicyApplied: whether spec.routing timeouts and retries are applied
+//     to the model's traffic. Only set when timeouts or retries are configured.
 type MaaSModelStatus struct {
 	// Phase represents the current phase of the model.
 	// Pending = awaiting governance pairing or backend readiness.
 	// Ready = governed and runtime-healthy.
 	// Unhealthy = governed but runtime-failed.
 	// Failed = reconciliation error.
+	// Maintenance = drained via spec.maintenance.
 	// Invalid = bad spec.
-	// +kubebuilder:validation:Enum=Pending;Ready;Unhealthy;Failed;Invalid
+	// +kubebuilder:validation:Enum=Pending;Ready;Unhealthy;Failed;Maintenance;Invalid
 	Phase string `json:"phase,omitempty"`
 
 	// Endpoint is the endpoint URL for the model
@@ -119,11 +267,21 @@ type MaaSModelStatus struct {
 	// +optional
 	HTTPRouteHostnames []string `json:"httpRouteHostnames,omitempty"`
 
+	// GatewayListenerHostnames are the hostnames of the Gateway listeners that accept the HTTPRoute
+	// +optional
+	GatewayListenerHostnames []string `json:"gatewayListenerHostnames,omitempty"`
+
 	// Conditions represent the latest available observations of the model's state.
 	// Condition types include:
 	//   - Ready: overall readiness (governance + runtime).
 	//   - GovernanceAttached: active MaaSSubscription + MaaSAuthPolicy pairing exists.
 	//   - RuntimeReady: backend is healthy and serving.
+	//   - PoliciesEnforced: an AuthPolicy protecting the route is enforced (when required).
+	//   - Degraded: the Ready model's endpoint fails its /v1/models probe (when probing is enabled).
+	//   - GatewayListenerCompatible: a Gateway listener accepts the model's HTTPRoute.
+	//   - FailoverActive: traffic is served by the spec.failover backend (when configured).
+	//   - SessionAffinityConfigured: session affinity is applied to the backends (when configured).
+	//   - RoutingPolicyApplied: timeouts and retries are applied to the model route (when configured).
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -21,13 +21,39 @@ import (
 )
 
 // MaaSSubscriptionSpec defines the desired state of MaaSSubscription
+// +kubebuilder:validation:XValidation:rule="has(self.modelRefs) || has(self.modelSelector) || has(self.templateRef)",message="modelRefs, modelSelector or templateRef is required"
 type MaaSSubscriptionSpec struct {
-	// Owner defines who owns this subscription
-	Owner OwnerSpec `json:"owner"`
+	// Owner defines who owns this subscription. Its groups and users get the limits of
+	// the modelRefs. Optional when Owners is set.
+	// +optional
+	Owner OwnerSpec `json:"owner,omitempty"`
+
+	// Owners lists further owners, each with its own groups, users and token rate limits,
+	// so that tiers sharing the same models do not need one MaaSSubscription each. A
+	// request is limited by the first entry whose groups or users match, else by the
+	// limits of the modelRefs when it matches Owner.
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.name == a.name))",message="owners names must be unique"
+	// +optional
+	Owners []SubscriptionOwner `json:"owners,omitempty"`
+
+	// TemplateRef names a MaaSSubscriptionTemplate in the same namespace that defines
+	// the subscription's plan. The controller copies the template's models and limits
+	// into the fields of this spec that the subscription does not set itself, and keeps
+	// them in sync as the template changes.
+	// +optional
+	TemplateRef *SubscriptionTemplateReference `json:"templateRef,omitempty"`
 
 	// ModelRefs defines which models are included with per-model token rate limits
 	// +kubebuilder:validation:MinItems=1
-	ModelRefs []ModelSubscriptionRef `json:"modelRefs"`
+	// +optional
+	ModelRefs []ModelSubscriptionRef `json:"modelRefs,omitempty"`
+
+	// ModelSelector includes every MaaSModelRef whose labels match, all with the same
+	// limits, in addition to ModelRefs. Matching models are picked up or dropped as they
+	// are created, relabeled, or deleted. A model listed in ModelRefs keeps its own limits.
+	// +optional
+	ModelSelector *ModelSelector `json:"modelSelector,omitempty"`
 
 	// TokenMetadata contains metadata for token attribution and metering
 	// +optional
@@ -38,8 +64,155 @@ type MaaSSubscriptionSpec struct {
 	// +optional
 	// +kubebuilder:default=0
 	Priority int32 `json:"priority,omitempty"`
+
+	// CounterScope selects who shares a token rate limit counter.
+	// User (default): every user has their own counter.
+	// Group: users with the same groups share one counter, so a team draws from one pool.
+	// Subscription: all users of the subscription share one counter.
+	// +kubebuilder:validation:Enum=User;Group;Subscription
+	// +kubebuilder:default=User
+	// +optional
+	CounterScope CounterScope `json:"counterScope,omitempty"`
+
+	// CounterExpressions keys the rate limit counters on the given expressions instead
+	// of CounterScope, e.g. auth.identity.keyId for one counter per API key, or
+	// request.headers["x-org-id"] for one per organization header value. Each expression
+	// must be an auth.identity attribute or a request header lookup; requests with the
+	// same values share a counter.
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:MaxLength=256
+	// +optional
+	CounterExpressions []string `json:"counterExpressions,omitempty"`
+
+	// ResetSchedule anchors long-window rate limits to calendar periods, e.g. a monthly
+	// quota that resets on the 1st to match a billing cycle. Rates whose window is at
+	// least one period long reset at every period boundary instead of one window after
+	// their first request; shorter rates keep rolling.
+	// +optional
+	ResetSchedule *ResetSchedule `json:"resetSchedule,omitempty"`
+
+	// Suspended pauses the subscription without deleting it, e.g. during a billing
+	// dispute. The controller keeps its generated policies but replaces the
+	// subscription's rate limits with a zero rate, so its requests are denied until
+	// suspended is cleared.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Notifications posts to webhooks as the subscription's usage crosses thresholds of
+	// its token rate limits, so consumers hear about impending throttling before their
+	// requests are rejected. Requires the controller to run with usage collection enabled.
+	// +optional
+	Notifications *NotificationSpec `json:"notifications,omitempty"`
+
+	// LimitGroups lets several of the subscription's models draw from one shared token
+	// budget, e.g. all small models sharing 1M tokens per day, on top of each model's own
+	// tokenRateLimits. Each group becomes a single limit on the tenant Gateway instead of
+	// an independent pool per model.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.name == a.name))",message="limitGroups names must be unique"
+	// +optional
+	LimitGroups []LimitGroup `json:"limitGroups,omitempty"`
+}
+
+// LimitGroup is a token budget shared by several models of a subscription.
+type LimitGroup struct {
+	// Name identifies the group in the generated limit keys.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// ModelRefs lists the models that share the budget. Each must also be listed by name
+	// in spec.modelRefs.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	ModelRefs []ModelRef `json:"modelRefs"`
+
+	// TokenRateLimits are the rates of the shared budget. They follow the rules of a
+	// modelRef's tokenRateLimits, including direction and spec.resetSchedule.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.window == a.window && (has(b.direction) ? b.direction : 'Total') == (has(a.direction) ? a.direction : 'Total')))",message="tokenRateLimits windows must be unique per direction"
+	TokenRateLimits []TokenRateLimit `json:"tokenRateLimits"`
+}
+
+// NotificationSpec defines where and when usage notifications are sent.
+type NotificationSpec struct {
+	// Webhooks receive a JSON POST each time a model's usage crosses a threshold.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	Webhooks []NotificationWebhook `json:"webhooks"`
+
+	// Thresholds are the percentages of a token rate limit at which to notify, measured
+	// for the counter (e.g. user) closest to its limit. Defaults to 80, 90 and 100.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=100
+	// +optional
+	Thresholds []int32 `json:"thresholds,omitempty"`
+}
+
+// NotificationWebhook is an endpoint usage notifications are posted to.
+type NotificationWebhook struct {
+	// URL is the http:// or https:// endpoint to post notifications to.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// PayloadTemplate is a Go text/template that renders the request body from the
+	// notification, e.g. a chat message. Its fields are those of the default JSON body,
+	// capitalized ({{.Subscription}}, {{.Threshold}}, ...), and the json function quotes
+	// a value as JSON. range and template definitions are not allowed. Defaults to the
+	// JSON notification.
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	PayloadTemplate string `json:"payloadTemplate,omitempty"`
+
+	// ContentType is the Content-Type of a templated body. Defaults to application/json.
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
 }
 
+// ResetPeriod is the calendar period of a ResetSchedule.
+type ResetPeriod string
+
+const (
+	ResetPeriodDaily   ResetPeriod = "Daily"
+	ResetPeriodMonthly ResetPeriod = "Monthly"
+)
+
+// ResetSchedule defines when anchored quotas reset.
+// +kubebuilder:validation:XValidation:rule="!has(self.dayOfMonth) || self.period == 'Monthly'",message="dayOfMonth is only valid with the Monthly period"
+type ResetSchedule struct {
+	// Period is the calendar period after which quotas reset: Daily resets at midnight,
+	// Monthly at midnight on dayOfMonth.
+	// +kubebuilder:validation:Enum=Daily;Monthly
+	Period ResetPeriod `json:"period"`
+
+	// DayOfMonth is the day a Monthly period starts on. Limited to 28 so that every
+	// month has the day. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=28
+	// +optional
+	DayOfMonth *int32 `json:"dayOfMonth,omitempty"`
+
+	// TimeZone is the IANA time zone the period boundaries are computed in (e.g.
+	// "Europe/Berlin"). Defaults to UTC.
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CounterScope selects how token rate limit counters are keyed.
+type CounterScope string
+
+const (
+	CounterScopeUser         CounterScope = "User"
+	CounterScopeGroup        CounterScope = "Group"
+	CounterScopeSubscription CounterScope = "Subscription"
+)
+
 // OwnerSpec defines the owner of the subscription
 type OwnerSpec struct {
 	// Groups is a list of Kubernetes group names that own this subscription
@@ -49,27 +222,117 @@ type OwnerSpec struct {
 	// Users is a list of Kubernetes user names that own this subscription
 	// +optional
 	Users []string `json:"users,omitempty"`
+
+	// ServiceAccounts is a list of Kubernetes service accounts that own this subscription,
+	// for workloads such as batch pipelines that authenticate with service account tokens.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	ServiceAccounts []ServiceAccountReference `json:"serviceAccounts,omitempty"`
+}
+
+// ServiceAccountReference identifies a service account, or every service account of a namespace.
+type ServiceAccountReference struct {
+	// Namespace is the namespace of the service account.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the service account. When empty, every service account in
+	// Namespace matches through its system:serviceaccounts:<namespace> group.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// SubscriptionOwner is an owner of a subscription with its own token rate limits.
+type SubscriptionOwner struct {
+	// Name identifies the owner in the generated rate limit keys.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	OwnerSpec `json:",inline"`
+
+	// TokenRateLimits replaces the tokenRateLimits of every model of the subscription for
+	// this owner. The rules of ModelSubscriptionRef.TokenRateLimits apply.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.window == a.window && (has(b.direction) ? b.direction : 'Total') == (has(a.direction) ? a.direction : 'Total')))",message="tokenRateLimits windows must be unique per direction"
+	TokenRateLimits []TokenRateLimit `json:"tokenRateLimits"`
 }
 
 // ModelSubscriptionRef defines a model reference with rate limits
 type ModelSubscriptionRef struct {
-	// Name is the name of the MaaSModelRef
+	// Name is the name of the MaaSModelRef, or "*" for every model in Namespace. Models
+	// listed by name take precedence over a wildcard entry.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=63
 	Name string `json:"name"`
 
-	// Namespace is the namespace where the MaaSModelRef lives
+	// Namespace is the namespace where the MaaSModelRef lives. With Name "*", Namespace
+	// "*" covers every model in every namespace; "<namespace>/*" entries take precedence.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=63
 	Namespace string `json:"namespace"`
 
-	// TokenRateLimits defines token-based rate limits for this model
+	// TokenRateLimits defines token-based rate limits for this model. Several rates
+	// with different windows combine burst and sustained limits, e.g. 10000 tokens per
+	// 1m and 200000 tokens per 1h; a request must fit within every rate. Windows must be
+	// distinct, and a longer window must allow more tokens than a shorter one.
 	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.window == a.window && (has(b.direction) ? b.direction : 'Total') == (has(a.direction) ? a.direction : 'Total')))",message="tokenRateLimits windows must be unique per direction"
 	TokenRateLimits []TokenRateLimit `json:"tokenRateLimits"`
 
+	// RequestRateLimits defines request-count rate limits for this model, enforced in
+	// addition to the token limits. Useful for embeddings and small models where the
+	// number of requests matters more than their token count. Multiple windows follow
+	// the same rules as TokenRateLimits.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.window == a.window))",message="requestRateLimits windows must be unique"
+	// +optional
+	RequestRateLimits []RequestRateLimit `json:"requestRateLimits,omitempty"`
+
+	// MaxConcurrentRequests caps the requests of this subscription that each gateway
+	// replica forwards to the model at the same time, so one tenant cannot occupy all
+	// serving slots while it is within its token budget. Requests over the cap are
+	// rejected with 503 until one completes. Only applies to models routed to Services.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+
 	// BillingRate defines the cost per token
 	// +optional
 	BillingRate *BillingRate `json:"billingRate,omitempty"`
+
+	// CostBudget caps spend on this model per window. The controller converts it into
+	// a token limit using the price-per-token metadata of the MaaSModelRef and enforces
+	// it alongside TokenRateLimits; when both have the same window, the lower limit
+	// applies. The modelRef is invalid while the model has no price in the budget's currency.
+	// +optional
+	CostBudget *CostBudget `json:"costBudget,omitempty"`
+}
+
+// CostBudget defines a spending limit per window.
+type CostBudget struct {
+	// Amount is the maximum spend within the window as a decimal number, e.g. "25" or "12.50".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Amount string `json:"amount"`
+
+	// Currency is the ISO 4217 code of Amount. It must match the currency of the model's price.
+	// +kubebuilder:validation:Pattern=`^[A-Z]{3}$`
+	// +kubebuilder:default=USD
+	// +optional
+	Currency string `json:"currency,omitempty"`
+
+	// Window is the time window of the budget, in the same format as TokenRateLimit.Window.
+	// +kubebuilder:validation:MinLength=2
+	// +kubebuilder:validation:MaxLength=5
+	// +kubebuilder:validation:Pattern=`^[1-9]\d{0,3}(s|m|h)$`
+	Window string `json:"window"`
 }
 
 // TokenRateLimit defines a token rate limit
@@ -88,6 +351,70 @@ type TokenRateLimit struct {
 	// +kubebuilder:validation:MaxLength=5
 	// +kubebuilder:validation:Pattern=`^[1-9]\d{0,3}(s|m|h)$`
 	Window string `json:"window"`
+
+	// Direction selects the tokens the limit counts.
+	// Total (default): prompt and completion tokens together.
+	// Input: prompt tokens only. Output: completion tokens only.
+	// Rates of each direction get their own counters, so an output limit can be
+	// tighter than the input limit over the same window.
+	// +kubebuilder:validation:Enum=Input;Output;Total
+	// +kubebuilder:default=Total
+	// +optional
+	Direction TokenDirection `json:"direction,omitempty"`
+}
+
+// TokenDirection selects the tokens a TokenRateLimit counts.
+type TokenDirection string
+
+const (
+	TokenDirectionInput  TokenDirection = "Input"
+	TokenDirectionOutput TokenDirection = "Output"
+	TokenDirectionTotal  TokenDirection = "Total"
+)
+
+// RequestRateLimit defines a request-count rate limit
+type RequestRateLimit struct {
+	// Limit is the maximum number of requests allowed within the window.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000000
+	Limit int64 `json:"limit"`
+
+	// Window is the time window for rate limiting, in the same format as
+	// TokenRateLimit.Window (e.g., "1m", "1h").
+	// +kubebuilder:validation:MinLength=2
+	// +kubebuilder:validation:MaxLength=5
+	// +kubebuilder:validation:Pattern=`^[1-9]\d{0,3}(s|m|h)$`
+	Window string `json:"window"`
+}
+
+// ModelSelector selects MaaSModelRefs by label and sets the limits of every selected model.
+type ModelSelector struct {
+	// Selector matches MaaSModelRef labels. An empty selector matches every model.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Namespaces restricts the selection to MaaSModelRefs in these namespaces. Models in
+	// all namespaces are selected when it is empty.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// TokenRateLimits are the token rate limits of each selected model, with the same
+	// rules as in ModelRefs.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.window == a.window && (has(b.direction) ? b.direction : 'Total') == (has(a.direction) ? a.direction : 'Total')))",message="tokenRateLimits windows must be unique per direction"
+	TokenRateLimits []TokenRateLimit `json:"tokenRateLimits"`
+
+	// RequestRateLimits are the request rate limits of each selected model.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.window == a.window))",message="requestRateLimits windows must be unique"
+	// +optional
+	RequestRateLimits []RequestRateLimit `json:"requestRateLimits,omitempty"`
+
+	// MaxConcurrentRequests caps the in-flight requests to each selected model.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
 }
 
 // BillingRate defines billing information
@@ -116,9 +443,15 @@ type ModelRefStatus struct {
 	ResourceRefStatus `json:",inline"`
 }
 
-// TokenRateLimitStatus reports the status of a generated TokenRateLimitPolicy.
+// TokenRateLimitStatus reports the status of a policy generated for a model: its
+// TokenRateLimitPolicy, and any RateLimitPolicy or policy mirrored onto another of its
+// HTTPRoutes. Ready is true only when Kuadrant reports the policy Accepted and Enforced.
 type TokenRateLimitStatus struct {
 	ResourceRefStatus `json:",inline"`
+	// Kind of the generated policy: TokenRateLimitPolicy or RateLimitPolicy.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Kind string `json:"kind,omitempty"`
 	// Model is the MaaSModelRef name this TokenRateLimitPolicy targets
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=63
@@ -138,15 +471,112 @@ type MaaSSubscriptionStatus struct {
 	// +optional
 	ModelRefStatuses []ModelRefStatus `json:"modelRefStatuses,omitempty"`
 
+	// SelectedModelRefs lists the MaaSModelRefs that wildcard modelRefs and
+	// spec.modelSelector currently select, excluding models listed in spec.modelRefs
+	// by name.
+	// +optional
+	SelectedModelRefs []ModelRef `json:"selectedModelRefs,omitempty"`
+
 	// TokenRateLimitStatuses reports the status of each generated TokenRateLimitPolicy
 	// +optional
 	TokenRateLimitStatuses []TokenRateLimitStatus `json:"tokenRateLimitStatuses,omitempty"`
+
+	// DryRunPreview lists the TokenRateLimitPolicies the controller would generate
+	// while the maas.opendatahub.io/dry-run annotation is set to "true"
+	// +optional
+	DryRunPreview []GeneratedResourcePreview `json:"dryRunPreview,omitempty"`
+
+	// NextResetTime is when quotas anchored to spec.resetSchedule next reset.
+	// +optional
+	NextResetTime *metav1.Time `json:"nextResetTime,omitempty"`
+
+	// Usage reports, per model, what Limitador has counted against the subscription's
+	// token rate limits. Only set when the controller runs with usage collection enabled.
+	// +optional
+	Usage []ModelUsageStatus `json:"usage,omitempty"`
+
+	// Notifications records, per model, the highest threshold of spec.notifications that
+	// was notified for the current usage.
+	// +optional
+	Notifications []NotificationStatus `json:"notifications,omitempty"`
+
+	// CleanupFailures lists the models whose generated policies could not be cleaned up
+	// while the subscription is being deleted. The finalizer is kept until they are.
+	// +optional
+	CleanupFailures []CleanupFailure `json:"cleanupFailures,omitempty"`
+}
+
+// NotificationStatus is the notification state of one model.
+type NotificationStatus struct {
+	// Name of the MaaSModelRef
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Namespace of the MaaSModelRef
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+	// Threshold is the highest threshold notified; it is lowered as usage falls, e.g.
+	// when a window resets, so that crossing it again notifies again.
+	Threshold int32 `json:"threshold"`
+	// LastNotifiedTime is when the threshold was notified.
+	// +optional
+	LastNotifiedTime *metav1.Time `json:"lastNotifiedTime,omitempty"`
+}
+
+// ModelUsageStatus is the usage of one model's token rate limits, reported for the
+// counter (e.g. user) closest to its limit.
+type ModelUsageStatus struct {
+	// Name of the MaaSModelRef
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Namespace of the MaaSModelRef
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+	// Limit and Window identify the rate the most consumed counter is closest to.
+	// +optional
+	Limit int64 `json:"limit,omitempty"`
+	// +optional
+	Window string `json:"window,omitempty"`
+	// Consumed is how much of the rate the most consumed counter has used in the current window.
+	Consumed int64 `json:"consumed"`
+	// Remaining is what the most consumed counter has left in the current window.
+	Remaining int64 `json:"remaining"`
+	// Counter identifies the most consumed counter by its counter values, e.g. the user ID
+	// for the User counter scope. Empty when the subscription shares one counter.
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Counter string `json:"counter,omitempty"`
+	// ActiveCounters is the number of counters with usage in the current window.
+	ActiveCounters int32 `json:"activeCounters"`
+	// NearLimitCounters is the number of counters that have used the near-limit share of a rate.
+	NearLimitCounters int32 `json:"nearLimitCounters"`
+	// History holds hourly snapshots of the usage, oldest first. The controller keeps
+	// a bounded number, 24 by default.
+	// +kubebuilder:validation:MaxItems=744
+	// +optional
+	History []UsageSnapshot `json:"history,omitempty"`
+}
+
+// UsageSnapshot is the usage of a model at the first collection of an hour.
+type UsageSnapshot struct {
+	// Time is when the usage was collected.
+	Time metav1.Time `json:"time"`
+	// Consumed is how much of its rate the most consumed counter had used in its window.
+	Consumed int64 `json:"consumed"`
+	// Limit and Window identify that rate.
+	// +optional
+	Limit int64 `json:"limit,omitempty"`
+	// +optional
+	Window string `json:"window,omitempty"`
+	// ActiveCounters is the number of counters with usage in their window.
+	ActiveCounters int32 `json:"activeCounters"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 //+kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+//+kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspended"
+//+kubebuilder:printcolumn:name="NearLimit",type="string",JSONPath=`.status.conditions[?(@.type=="NearLimit")].status`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // MaaSSubscription is the Schema for the maassubscriptions API
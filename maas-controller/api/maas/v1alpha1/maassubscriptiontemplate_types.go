@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaaSSubscriptionTemplateSpec defines a plan, e.g. "free" or "pro": the models and
+// limits of the MaaSSubscriptions that reference it through spec.templateRef. Each field
+// has the meaning of the MaaSSubscription field of the same name.
+type MaaSSubscriptionTemplateSpec struct {
+	// ModelRefs defines which models are included with per-model token rate limits
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	ModelRefs []ModelSubscriptionRef `json:"modelRefs,omitempty"`
+
+	// ModelSelector includes every MaaSModelRef whose labels match, all with the same
+	// limits, in addition to ModelRefs.
+	// +optional
+	ModelSelector *ModelSelector `json:"modelSelector,omitempty"`
+
+	// TokenMetadata contains metadata for token attribution and metering
+	// +optional
+	TokenMetadata *TokenMetadata `json:"tokenMetadata,omitempty"`
+
+	// Priority determines subscription priority when user has multiple subscriptions
+	// Higher numbers have higher priority.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// CounterScope selects who shares a token rate limit counter.
+	// +kubebuilder:validation:Enum=User;Group;Subscription
+	// +optional
+	CounterScope CounterScope `json:"counterScope,omitempty"`
+
+	// CounterExpressions keys the rate limit counters on the given expressions instead
+	// of CounterScope.
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:MaxLength=256
+	// +optional
+	CounterExpressions []string `json:"counterExpressions,omitempty"`
+
+	// ResetSchedule anchors long-window rate limits to calendar periods.
+	// +optional
+	ResetSchedule *ResetSchedule `json:"resetSchedule,omitempty"`
+
+	// LimitGroups lets several of the plan's models draw from one shared token budget.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, b.name == a.name))",message="limitGroups names must be unique"
+	// +optional
+	LimitGroups []LimitGroup `json:"limitGroups,omitempty"`
+}
+
+// SubscriptionTemplateReference names a MaaSSubscriptionTemplate in the namespace of the
+// MaaSSubscription.
+type SubscriptionTemplateReference struct {
+	// Name of the MaaSSubscriptionTemplate
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MaaSSubscriptionTemplate is the Schema for the maassubscriptiontemplates API
+type MaaSSubscriptionTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MaaSSubscriptionTemplateSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// MaaSSubscriptionTemplateList contains a list of MaaSSubscriptionTemplate
+type MaaSSubscriptionTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaaSSubscriptionTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaaSSubscriptionTemplate{}, &MaaSSubscriptionTemplateList{})
+}
@@ -67,6 +67,16 @@ type TenantSpec struct {
 	// Telemetry contains configuration for telemetry and metrics collection.
 	// +kubebuilder:validation:Optional
 	Telemetry *TenantTelemetryConfig `json:"telemetry,omitempty"`
+
+	// TokenCounting deploys the token counter on the gateway, for backends that do not
+	// report usage in streamed responses.
+	// +kubebuilder:validation:Optional
+	TokenCounting *TenantTokenCountingConfig `json:"tokenCounting,omitempty"`
+
+	// RateLimitHeaders makes the gateway return X-RateLimit-* headers on inference
+	// responses, computed from the subscription's rate limit counters.
+	// +kubebuilder:validation:Optional
+	RateLimitHeaders *TenantRateLimitHeadersConfig `json:"rateLimitHeaders,omitempty"`
 }
 
 // TenantExternalOIDCConfig defines the external OIDC provider settings.
@@ -88,6 +98,47 @@ type TenantExternalOIDCConfig struct {
 	// +kubebuilder:default=300
 	// +kubebuilder:validation:Minimum=30
 	TTL int `json:"ttl,omitempty"`
+
+	// StepUp requires a recent token of this provider, optionally of a given
+	// authentication context class, for the key-management and admin routes of
+	// maas-api. API keys and Kubernetes tokens are refused on these routes; inference
+	// routes keep accepting them.
+	// +kubebuilder:validation:Optional
+	StepUp *TenantStepUpAuthConfig `json:"stepUp,omitempty"`
+
+	// ProvisionClient makes maas-controller create ClientID as a confidential client,
+	// with audience and groups mappers, in the Keycloak realm of IssuerURL. Requires
+	// maas-controller to run with --keycloak-admin-secret, and IssuerURL to be on the
+	// Keycloak server named in that Secret. The client secret is written to the Secret
+	// named in Tenant status.oidcClient.
+	// +kubebuilder:validation:Optional
+	ProvisionClient bool `json:"provisionClient,omitempty"`
+}
+
+// TenantStepUpAuthConfig defines the credential required for administrative routes.
+// +kubebuilder:validation:XValidation:rule="has(self.maxTokenAgeSeconds) || (has(self.acrValues) && size(self.acrValues) > 0)",message="at least one of maxTokenAgeSeconds or acrValues must be specified"
+type TenantStepUpAuthConfig struct {
+	// MaxTokenAgeSeconds is the longest time since the user authenticated, taken from
+	// the auth_time claim, or the iat claim of tokens without it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=30
+	MaxTokenAgeSeconds int32 `json:"maxTokenAgeSeconds,omitempty"`
+
+	// ACRValues lists the acr claim values accepted, e.g. a value the identity provider
+	// sets after multi-factor authentication. An empty list accepts any.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:Pattern=`^[^"\\]+$`
+	ACRValues []string `json:"acrValues,omitempty"`
+
+	// Paths are the gateway paths requiring step-up authentication. A trailing "*"
+	// matches any path with that prefix. Defaults to the maas-api key-management and admin
+	// routes.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^/[A-Za-z0-9._~/-]*\*?$`
+	Paths []string `json:"paths,omitempty"`
 }
 
 // TenantTelemetryConfig defines configuration for telemetry collection.
@@ -122,6 +173,29 @@ type TenantMetricsConfig struct {
 	CaptureModelUsage *bool `json:"captureModelUsage,omitempty"`
 }
 
+// TenantTokenCountingConfig configures the token counter: an Envoy ext_proc filter of the
+// gateway that estimates the tokens of streamed completions without usage chunk and adds
+// one, so that token rate limits and metering still count them.
+type TenantTokenCountingConfig struct {
+	// Enabled deploys the token counter in the gateway namespace. Disabling it removes
+	// the token counter this Tenant deployed.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// TenantRateLimitHeadersConfig configures the rate limit headers Limitador returns with
+// its rate limit decisions, and the Kuadrant Wasm plugin adds to the responses of the
+// gateway: X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset.
+type TenantRateLimitHeadersConfig struct {
+	// Enabled sets spec.rateLimitHeaders on the Limitador instances of the cluster.
+	// Disabling it unsets the value this Tenant set; a value set by an administrator
+	// is kept.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // TenantAPIKeysConfig defines configuration options for API key management.
 type TenantAPIKeysConfig struct {
 	// +kubebuilder:validation:Optional
@@ -154,6 +228,21 @@ type TenantStatus struct {
 	// DependenciesAvailable, MaaSPrerequisitesAvailable, DeploymentsAvailable, Degraded.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// OIDCClient is the OIDC client maas-controller provisioned for the tenant when
+	// the OIDC configuration sets provisionClient.
+	// +optional
+	OIDCClient *TenantOIDCClientStatus `json:"oidcClient,omitempty"`
+}
+
+// TenantOIDCClientStatus records a provisioned OIDC client.
+type TenantOIDCClientStatus struct {
+	// ClientID is the client ID in the identity provider.
+	ClientID string `json:"clientId"`
+
+	// SecretName is the Secret of the tenant namespace holding the client-id and
+	// client-secret of the client.
+	SecretName string `json:"secretName"`
 }
 
 // +kubebuilder:object:root=true
@@ -6,7 +6,7 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -129,7 +129,7 @@ func (in *AITenantSpec) DeepCopyInto(out *AITenantSpec) {
 	if in.OIDC != nil {
 		in, out := &in.OIDC, &out.OIDC
 		*out = new(TenantExternalOIDCConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RBAC != nil {
 		in, out := &in.RBAC, &out.RBAC
@@ -171,6 +171,46 @@ func (in *AITenantStatus) DeepCopy() *AITenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyAuthentication) DeepCopyInto(out *APIKeyAuthentication) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuthentication.
+func (in *APIKeyAuthentication) DeepCopy() *APIKeyAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessWindow) DeepCopyInto(out *AccessWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessWindow.
+func (in *AccessWindow) DeepCopy() *AccessWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthPolicyRefStatus) DeepCopyInto(out *AuthPolicyRefStatus) {
 	*out = *in
@@ -187,6 +227,115 @@ func (in *AuthPolicyRefStatus) DeepCopy() *AuthPolicyRefStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthPolicyTemplateReference) DeepCopyInto(out *AuthPolicyTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthPolicyTemplateReference.
+func (in *AuthPolicyTemplateReference) DeepCopy() *AuthPolicyTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthPolicyTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthPolicyTemplateResponse) DeepCopyInto(out *AuthPolicyTemplateResponse) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthPolicyTemplateResponse.
+func (in *AuthPolicyTemplateResponse) DeepCopy() *AuthPolicyTemplateResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthPolicyTemplateResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
+	*out = *in
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(APIKeyAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenReview != nil {
+		in, out := &in.TokenReview, &out.TokenReview
+		*out = new(TokenReviewAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.X509 != nil {
+		in, out := &in.X509, &out.X509
+		*out = new(X509Authentication)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSpec.
+func (in *AuthenticationSpec) DeepCopy() *AuthenticationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
+	*out = *in
+	if in.OPA != nil {
+		in, out := &in.OPA, &out.OPA
+		*out = new(OPAPolicy)
+		**out = **in
+	}
+	if in.SubjectAccessReview != nil {
+		in, out := &in.SubjectAccessReview, &out.SubjectAccessReview
+		*out = new(SubjectAccessReviewAuthorization)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
+func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BillingRate) DeepCopyInto(out *BillingRate) {
 	*out = *in
@@ -202,6 +351,51 @@ func (in *BillingRate) DeepCopy() *BillingRate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASecretReference) DeepCopyInto(out *CASecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASecretReference.
+func (in *CASecretReference) DeepCopy() *CASecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CASecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupFailure) DeepCopyInto(out *CleanupFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupFailure.
+func (in *CleanupFailure) DeepCopy() *CleanupFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientRole) DeepCopyInto(out *ClientRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRole.
+func (in *ClientRole) DeepCopy() *ClientRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -291,6 +485,21 @@ func (in *ConfigStatus) DeepCopy() *ConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostBudget) DeepCopyInto(out *CostBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostBudget.
+func (in *CostBudget) DeepCopy() *CostBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(CostBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialReference) DeepCopyInto(out *CredentialReference) {
 	*out = *in
@@ -306,6 +515,66 @@ func (in *CredentialReference) DeepCopy() *CredentialReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenyResponse) DeepCopyInto(out *DenyResponse) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]DenyResponseHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenyResponse.
+func (in *DenyResponse) DeepCopy() *DenyResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(DenyResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenyResponseHeader) DeepCopyInto(out *DenyResponseHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenyResponseHeader.
+func (in *DenyResponseHeader) DeepCopy() *DenyResponseHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(DenyResponseHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenyResponses) DeepCopyInto(out *DenyResponses) {
+	*out = *in
+	if in.Unauthenticated != nil {
+		in, out := &in.Unauthenticated, &out.Unauthenticated
+		*out = new(DenyResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Unauthorized != nil {
+		in, out := &in.Unauthorized, &out.Unauthorized
+		*out = new(DenyResponse)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenyResponses.
+func (in *DenyResponses) DeepCopy() *DenyResponses {
+	if in == nil {
+		return nil
+	}
+	out := new(DenyResponses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalModel) DeepCopyInto(out *ExternalModel) {
 	*out = *in
@@ -403,6 +672,37 @@ func (in *ExternalModelStatus) DeepCopy() *ExternalModelStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverSpec) DeepCopyInto(out *FailoverSpec) {
+	*out = *in
+	out.ModelRef = in.ModelRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverSpec.
+func (in *FailoverSpec) DeepCopy() *FailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedResourcePreview) DeepCopyInto(out *GeneratedResourcePreview) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedResourcePreview.
+func (in *GeneratedResourcePreview) DeepCopy() *GeneratedResourcePreview {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedResourcePreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupReference) DeepCopyInto(out *GroupReference) {
 	*out = *in
@@ -418,6 +718,68 @@ func (in *GroupReference) DeepCopy() *GroupReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityHeaders) DeepCopyInto(out *IdentityHeaders) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityHeaders.
+func (in *IdentityHeaders) DeepCopy() *IdentityHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuthentication) DeepCopyInto(out *JWTAuthentication) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthentication.
+func (in *JWTAuthentication) DeepCopy() *JWTAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitGroup) DeepCopyInto(out *LimitGroup) {
+	*out = *in
+	if in.ModelRefs != nil {
+		in, out := &in.ModelRefs, &out.ModelRefs
+		*out = make([]ModelRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TokenRateLimits != nil {
+		in, out := &in.TokenRateLimits, &out.TokenRateLimits
+		*out = make([]TokenRateLimit, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitGroup.
+func (in *LimitGroup) DeepCopy() *LimitGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaaSAuthPolicy) DeepCopyInto(out *MaaSAuthPolicy) {
 	*out = *in
@@ -483,7 +845,9 @@ func (in *MaaSAuthPolicySpec) DeepCopyInto(out *MaaSAuthPolicySpec) {
 	if in.ModelRefs != nil {
 		in, out := &in.ModelRefs, &out.ModelRefs
 		*out = make([]ModelRef, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Subjects.DeepCopyInto(&out.Subjects)
 	if in.MeteringMetadata != nil {
@@ -491,9 +855,58 @@ func (in *MaaSAuthPolicySpec) DeepCopyInto(out *MaaSAuthPolicySpec) {
 		*out = new(MeteringMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSAuthPolicySpec.
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthenticationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PathRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(AuthorizationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredScopes != nil {
+		in, out := &in.RequiredScopes, &out.RequiredScopes
+		*out = new(RequiredScopes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessWindows != nil {
+		in, out := &in.AccessWindows, &out.AccessWindows
+		*out = make([]AccessWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IdentityHeaders != nil {
+		in, out := &in.IdentityHeaders, &out.IdentityHeaders
+		*out = new(IdentityHeaders)
+		**out = **in
+	}
+	if in.DenyResponse != nil {
+		in, out := &in.DenyResponse, &out.DenyResponse
+		*out = new(DenyResponses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(AuthPolicyTemplateReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSAuthPolicySpec.
 func (in *MaaSAuthPolicySpec) DeepCopy() *MaaSAuthPolicySpec {
 	if in == nil {
 		return nil
@@ -518,6 +931,16 @@ func (in *MaaSAuthPolicyStatus) DeepCopyInto(out *MaaSAuthPolicyStatus) {
 		*out = make([]AuthPolicyRefStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.DryRunPreview != nil {
+		in, out := &in.DryRunPreview, &out.DryRunPreview
+		*out = make([]GeneratedResourcePreview, len(*in))
+		copy(*out, *in)
+	}
+	if in.CleanupFailures != nil {
+		in, out := &in.CleanupFailures, &out.CleanupFailures
+		*out = make([]CleanupFailure, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSAuthPolicyStatus.
@@ -530,12 +953,104 @@ func (in *MaaSAuthPolicyStatus) DeepCopy() *MaaSAuthPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSAuthPolicyTemplate) DeepCopyInto(out *MaaSAuthPolicyTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSAuthPolicyTemplate.
+func (in *MaaSAuthPolicyTemplate) DeepCopy() *MaaSAuthPolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSAuthPolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaaSAuthPolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSAuthPolicyTemplateList) DeepCopyInto(out *MaaSAuthPolicyTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaaSAuthPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSAuthPolicyTemplateList.
+func (in *MaaSAuthPolicyTemplateList) DeepCopy() *MaaSAuthPolicyTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSAuthPolicyTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaaSAuthPolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSAuthPolicyTemplateSpec) DeepCopyInto(out *MaaSAuthPolicyTemplateSpec) {
+	*out = *in
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Callbacks != nil {
+		in, out := &in.Callbacks, &out.Callbacks
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(AuthPolicyTemplateResponse)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSAuthPolicyTemplateSpec.
+func (in *MaaSAuthPolicyTemplateSpec) DeepCopy() *MaaSAuthPolicyTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSAuthPolicyTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaaSModelRef) DeepCopyInto(out *MaaSModelRef) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -593,6 +1108,26 @@ func (in *MaaSModelRefList) DeepCopyObject() runtime.Object {
 func (in *MaaSModelSpec) DeepCopyInto(out *MaaSModelSpec) {
 	*out = *in
 	out.ModelRef = in.ModelRef
+	if in.RequirePolicies != nil {
+		in, out := &in.RequirePolicies, &out.RequirePolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GlobalTokenRateLimits != nil {
+		in, out := &in.GlobalTokenRateLimits, &out.GlobalTokenRateLimits
+		*out = make([]TokenRateLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSModelSpec.
@@ -613,6 +1148,11 @@ func (in *MaaSModelStatus) DeepCopyInto(out *MaaSModelStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GatewayListenerHostnames != nil {
+		in, out := &in.GatewayListenerHostnames, &out.GatewayListenerHostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -656,196 +1196,789 @@ func (in *MaaSSubscription) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSSubscriptionList) DeepCopyInto(out *MaaSSubscriptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaaSSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionList.
+func (in *MaaSSubscriptionList) DeepCopy() *MaaSSubscriptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSSubscriptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaaSSubscriptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSSubscriptionSpec) DeepCopyInto(out *MaaSSubscriptionSpec) {
+	*out = *in
+	in.Owner.DeepCopyInto(&out.Owner)
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]SubscriptionOwner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(SubscriptionTemplateReference)
+		**out = **in
+	}
+	if in.ModelRefs != nil {
+		in, out := &in.ModelRefs, &out.ModelRefs
+		*out = make([]ModelSubscriptionRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ModelSelector != nil {
+		in, out := &in.ModelSelector, &out.ModelSelector
+		*out = new(ModelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenMetadata != nil {
+		in, out := &in.TokenMetadata, &out.TokenMetadata
+		*out = new(TokenMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CounterExpressions != nil {
+		in, out := &in.CounterExpressions, &out.CounterExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResetSchedule != nil {
+		in, out := &in.ResetSchedule, &out.ResetSchedule
+		*out = new(ResetSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitGroups != nil {
+		in, out := &in.LimitGroups, &out.LimitGroups
+		*out = make([]LimitGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionSpec.
+func (in *MaaSSubscriptionSpec) DeepCopy() *MaaSSubscriptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSSubscriptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSSubscriptionStatus) DeepCopyInto(out *MaaSSubscriptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ModelRefStatuses != nil {
+		in, out := &in.ModelRefStatuses, &out.ModelRefStatuses
+		*out = make([]ModelRefStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectedModelRefs != nil {
+		in, out := &in.SelectedModelRefs, &out.SelectedModelRefs
+		*out = make([]ModelRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TokenRateLimitStatuses != nil {
+		in, out := &in.TokenRateLimitStatuses, &out.TokenRateLimitStatuses
+		*out = make([]TokenRateLimitStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRunPreview != nil {
+		in, out := &in.DryRunPreview, &out.DryRunPreview
+		*out = make([]GeneratedResourcePreview, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextResetTime != nil {
+		in, out := &in.NextResetTime, &out.NextResetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make([]ModelUsageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CleanupFailures != nil {
+		in, out := &in.CleanupFailures, &out.CleanupFailures
+		*out = make([]CleanupFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionStatus.
+func (in *MaaSSubscriptionStatus) DeepCopy() *MaaSSubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSSubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSSubscriptionTemplate) DeepCopyInto(out *MaaSSubscriptionTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionTemplate.
+func (in *MaaSSubscriptionTemplate) DeepCopy() *MaaSSubscriptionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSSubscriptionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaaSSubscriptionTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSSubscriptionTemplateList) DeepCopyInto(out *MaaSSubscriptionTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaaSSubscriptionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionTemplateList.
+func (in *MaaSSubscriptionTemplateList) DeepCopy() *MaaSSubscriptionTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSSubscriptionTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaaSSubscriptionTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaaSSubscriptionTemplateSpec) DeepCopyInto(out *MaaSSubscriptionTemplateSpec) {
+	*out = *in
+	if in.ModelRefs != nil {
+		in, out := &in.ModelRefs, &out.ModelRefs
+		*out = make([]ModelSubscriptionRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ModelSelector != nil {
+		in, out := &in.ModelSelector, &out.ModelSelector
+		*out = new(ModelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenMetadata != nil {
+		in, out := &in.TokenMetadata, &out.TokenMetadata
+		*out = new(TokenMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CounterExpressions != nil {
+		in, out := &in.CounterExpressions, &out.CounterExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResetSchedule != nil {
+		in, out := &in.ResetSchedule, &out.ResetSchedule
+		*out = new(ResetSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitGroups != nil {
+		in, out := &in.LimitGroups, &out.LimitGroups
+		*out = make([]LimitGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionTemplateSpec.
+func (in *MaaSSubscriptionTemplateSpec) DeepCopy() *MaaSSubscriptionTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaaSSubscriptionTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringMetadata) DeepCopyInto(out *MeteringMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringMetadata.
+func (in *MeteringMetadata) DeepCopy() *MeteringMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRef) DeepCopyInto(out *ModelRef) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRef.
+func (in *ModelRef) DeepCopy() *ModelRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRefStatus) DeepCopyInto(out *ModelRefStatus) {
+	*out = *in
+	out.ResourceRefStatus = in.ResourceRefStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRefStatus.
+func (in *ModelRefStatus) DeepCopy() *ModelRefStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRefStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelReference) DeepCopyInto(out *ModelReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelReference.
+func (in *ModelReference) DeepCopy() *ModelReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSelector) DeepCopyInto(out *ModelSelector) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenRateLimits != nil {
+		in, out := &in.TokenRateLimits, &out.TokenRateLimits
+		*out = make([]TokenRateLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestRateLimits != nil {
+		in, out := &in.RequestRateLimits, &out.RequestRateLimits
+		*out = make([]RequestRateLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSelector.
+func (in *ModelSelector) DeepCopy() *ModelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSubscriptionRef) DeepCopyInto(out *ModelSubscriptionRef) {
+	*out = *in
+	if in.TokenRateLimits != nil {
+		in, out := &in.TokenRateLimits, &out.TokenRateLimits
+		*out = make([]TokenRateLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestRateLimits != nil {
+		in, out := &in.RequestRateLimits, &out.RequestRateLimits
+		*out = make([]RequestRateLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BillingRate != nil {
+		in, out := &in.BillingRate, &out.BillingRate
+		*out = new(BillingRate)
+		**out = **in
+	}
+	if in.CostBudget != nil {
+		in, out := &in.CostBudget, &out.CostBudget
+		*out = new(CostBudget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSubscriptionRef.
+func (in *ModelSubscriptionRef) DeepCopy() *ModelSubscriptionRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSubscriptionRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelUsageStatus) DeepCopyInto(out *ModelUsageStatus) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]UsageSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelUsageStatus.
+func (in *ModelUsageStatus) DeepCopy() *ModelUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSpec) DeepCopyInto(out *NotificationSpec) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]NotificationWebhook, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSpec.
+func (in *NotificationSpec) DeepCopy() *NotificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationStatus) DeepCopyInto(out *NotificationStatus) {
+	*out = *in
+	if in.LastNotifiedTime != nil {
+		in, out := &in.LastNotifiedTime, &out.LastNotifiedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationStatus.
+func (in *NotificationStatus) DeepCopy() *NotificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationWebhook) DeepCopyInto(out *NotificationWebhook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhook.
+func (in *NotificationWebhook) DeepCopy() *NotificationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPAPolicy) DeepCopyInto(out *OPAPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPAPolicy.
+func (in *OPAPolicy) DeepCopy() *OPAPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OPAPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerSpec) DeepCopyInto(out *OwnerSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]GroupReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerSpec.
+func (in *OwnerSpec) DeepCopy() *OwnerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PathRule) DeepCopyInto(out *PathRule) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]HTTPMethod, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathRule.
+func (in *PathRule) DeepCopy() *PathRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PathRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestRateLimit) DeepCopyInto(out *RequestRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestRateLimit.
+func (in *RequestRateLimit) DeepCopy() *RequestRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredScopes) DeepCopyInto(out *RequiredScopes) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredScopes.
+func (in *RequiredScopes) DeepCopy() *RequiredScopes {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredScopes)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MaaSSubscriptionList) DeepCopyInto(out *MaaSSubscriptionList) {
+func (in *ResetSchedule) DeepCopyInto(out *ResetSchedule) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]MaaSSubscription, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DayOfMonth != nil {
+		in, out := &in.DayOfMonth, &out.DayOfMonth
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionList.
-func (in *MaaSSubscriptionList) DeepCopy() *MaaSSubscriptionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResetSchedule.
+func (in *ResetSchedule) DeepCopy() *ResetSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(MaaSSubscriptionList)
+	out := new(ResetSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MaaSSubscriptionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MaaSSubscriptionSpec) DeepCopyInto(out *MaaSSubscriptionSpec) {
+func (in *ResourceRefStatus) DeepCopyInto(out *ResourceRefStatus) {
 	*out = *in
-	in.Owner.DeepCopyInto(&out.Owner)
-	if in.ModelRefs != nil {
-		in, out := &in.ModelRefs, &out.ModelRefs
-		*out = make([]ModelSubscriptionRef, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.TokenMetadata != nil {
-		in, out := &in.TokenMetadata, &out.TokenMetadata
-		*out = new(TokenMetadata)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionSpec.
-func (in *MaaSSubscriptionSpec) DeepCopy() *MaaSSubscriptionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRefStatus.
+func (in *ResourceRefStatus) DeepCopy() *ResourceRefStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MaaSSubscriptionSpec)
+	out := new(ResourceRefStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MaaSSubscriptionStatus) DeepCopyInto(out *MaaSSubscriptionStatus) {
+func (in *RouteRetries) DeepCopyInto(out *RouteRetries) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ModelRefStatuses != nil {
-		in, out := &in.ModelRefStatuses, &out.ModelRefStatuses
-		*out = make([]ModelRefStatus, len(*in))
+	if in.Codes != nil {
+		in, out := &in.Codes, &out.Codes
+		*out = make([]int32, len(*in))
 		copy(*out, *in)
 	}
-	if in.TokenRateLimitStatuses != nil {
-		in, out := &in.TokenRateLimitStatuses, &out.TokenRateLimitStatuses
-		*out = make([]TokenRateLimitStatus, len(*in))
-		copy(*out, *in)
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaaSSubscriptionStatus.
-func (in *MaaSSubscriptionStatus) DeepCopy() *MaaSSubscriptionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRetries.
+func (in *RouteRetries) DeepCopy() *RouteRetries {
 	if in == nil {
 		return nil
 	}
-	out := new(MaaSSubscriptionStatus)
+	out := new(RouteRetries)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MeteringMetadata) DeepCopyInto(out *MeteringMetadata) {
+func (in *RouteTimeouts) DeepCopyInto(out *RouteTimeouts) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BackendRequest != nil {
+		in, out := &in.BackendRequest, &out.BackendRequest
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringMetadata.
-func (in *MeteringMetadata) DeepCopy() *MeteringMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTimeouts.
+func (in *RouteTimeouts) DeepCopy() *RouteTimeouts {
 	if in == nil {
 		return nil
 	}
-	out := new(MeteringMetadata)
+	out := new(RouteTimeouts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRef) DeepCopyInto(out *ModelRef) {
+func (in *RoutingSpec) DeepCopyInto(out *RoutingSpec) {
 	*out = *in
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(SessionAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(RouteTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RouteRetries)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRef.
-func (in *ModelRef) DeepCopy() *ModelRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingSpec.
+func (in *RoutingSpec) DeepCopy() *RoutingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRef)
+	out := new(RoutingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRefStatus) DeepCopyInto(out *ModelRefStatus) {
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
 	*out = *in
-	out.ResourceRefStatus = in.ResourceRefStatus
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRefStatus.
-func (in *ModelRefStatus) DeepCopy() *ModelRefStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRefStatus)
+	out := new(ServiceAccountReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelReference) DeepCopyInto(out *ModelReference) {
+func (in *SessionAffinity) DeepCopyInto(out *SessionAffinity) {
 	*out = *in
+	if in.CookieTTL != nil {
+		in, out := &in.CookieTTL, &out.CookieTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelReference.
-func (in *ModelReference) DeepCopy() *ModelReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionAffinity.
+func (in *SessionAffinity) DeepCopy() *SessionAffinity {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelReference)
+	out := new(SessionAffinity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSubscriptionRef) DeepCopyInto(out *ModelSubscriptionRef) {
+func (in *SubjectAccessReviewAuthorization) DeepCopyInto(out *SubjectAccessReviewAuthorization) {
 	*out = *in
-	if in.TokenRateLimits != nil {
-		in, out := &in.TokenRateLimits, &out.TokenRateLimits
-		*out = make([]TokenRateLimit, len(*in))
-		copy(*out, *in)
-	}
-	if in.BillingRate != nil {
-		in, out := &in.BillingRate, &out.BillingRate
-		*out = new(BillingRate)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSubscriptionRef.
-func (in *ModelSubscriptionRef) DeepCopy() *ModelSubscriptionRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectAccessReviewAuthorization.
+func (in *SubjectAccessReviewAuthorization) DeepCopy() *SubjectAccessReviewAuthorization {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSubscriptionRef)
+	out := new(SubjectAccessReviewAuthorization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OwnerSpec) DeepCopyInto(out *OwnerSpec) {
+func (in *SubjectSpec) DeepCopyInto(out *SubjectSpec) {
 	*out = *in
 	if in.Groups != nil {
 		in, out := &in.Groups, &out.Groups
@@ -857,54 +1990,55 @@ func (in *OwnerSpec) DeepCopyInto(out *OwnerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DeniedUsers != nil {
+		in, out := &in.DeniedUsers, &out.DeniedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerSpec.
-func (in *OwnerSpec) DeepCopy() *OwnerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectSpec.
+func (in *SubjectSpec) DeepCopy() *SubjectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OwnerSpec)
+	out := new(SubjectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRefStatus) DeepCopyInto(out *ResourceRefStatus) {
+func (in *SubscriptionOwner) DeepCopyInto(out *SubscriptionOwner) {
 	*out = *in
+	in.OwnerSpec.DeepCopyInto(&out.OwnerSpec)
+	if in.TokenRateLimits != nil {
+		in, out := &in.TokenRateLimits, &out.TokenRateLimits
+		*out = make([]TokenRateLimit, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRefStatus.
-func (in *ResourceRefStatus) DeepCopy() *ResourceRefStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionOwner.
+func (in *SubscriptionOwner) DeepCopy() *SubscriptionOwner {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRefStatus)
+	out := new(SubscriptionOwner)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubjectSpec) DeepCopyInto(out *SubjectSpec) {
+func (in *SubscriptionTemplateReference) DeepCopyInto(out *SubscriptionTemplateReference) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]GroupReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectSpec.
-func (in *SubjectSpec) DeepCopy() *SubjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionTemplateReference.
+func (in *SubscriptionTemplateReference) DeepCopy() *SubscriptionTemplateReference {
 	if in == nil {
 		return nil
 	}
-	out := new(SubjectSpec)
+	out := new(SubscriptionTemplateReference)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -959,6 +2093,11 @@ func (in *TenantAPIKeysConfig) DeepCopy() *TenantAPIKeysConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantExternalOIDCConfig) DeepCopyInto(out *TenantExternalOIDCConfig) {
 	*out = *in
+	if in.StepUp != nil {
+		in, out := &in.StepUp, &out.StepUp
+		*out = new(TenantStepUpAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantExternalOIDCConfig.
@@ -1053,6 +2192,41 @@ func (in *TenantMetricsConfig) DeepCopy() *TenantMetricsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantOIDCClientStatus) DeepCopyInto(out *TenantOIDCClientStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantOIDCClientStatus.
+func (in *TenantOIDCClientStatus) DeepCopy() *TenantOIDCClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantOIDCClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRateLimitHeadersConfig) DeepCopyInto(out *TenantRateLimitHeadersConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRateLimitHeadersConfig.
+func (in *TenantRateLimitHeadersConfig) DeepCopy() *TenantRateLimitHeadersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRateLimitHeadersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	*out = *in
@@ -1065,13 +2239,23 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	if in.ExternalOIDC != nil {
 		in, out := &in.ExternalOIDC, &out.ExternalOIDC
 		*out = new(TenantExternalOIDCConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Telemetry != nil {
 		in, out := &in.Telemetry, &out.Telemetry
 		*out = new(TenantTelemetryConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TokenCounting != nil {
+		in, out := &in.TokenCounting, &out.TokenCounting
+		*out = new(TenantTokenCountingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimitHeaders != nil {
+		in, out := &in.RateLimitHeaders, &out.RateLimitHeaders
+		*out = new(TenantRateLimitHeadersConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSpec.
@@ -1094,6 +2278,11 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OIDCClient != nil {
+		in, out := &in.OIDCClient, &out.OIDCClient
+		*out = new(TenantOIDCClientStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
@@ -1106,6 +2295,31 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantStepUpAuthConfig) DeepCopyInto(out *TenantStepUpAuthConfig) {
+	*out = *in
+	if in.ACRValues != nil {
+		in, out := &in.ACRValues, &out.ACRValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStepUpAuthConfig.
+func (in *TenantStepUpAuthConfig) DeepCopy() *TenantStepUpAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantStepUpAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantTelemetryConfig) DeepCopyInto(out *TenantTelemetryConfig) {
 	*out = *in
@@ -1131,6 +2345,26 @@ func (in *TenantTelemetryConfig) DeepCopy() *TenantTelemetryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantTokenCountingConfig) DeepCopyInto(out *TenantTokenCountingConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantTokenCountingConfig.
+func (in *TenantTokenCountingConfig) DeepCopy() *TenantTokenCountingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantTokenCountingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenMetadata) DeepCopyInto(out *TokenMetadata) {
 	*out = *in
@@ -1183,3 +2417,55 @@ func (in *TokenRateLimitStatus) DeepCopy() *TokenRateLimitStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenReviewAuthentication) DeepCopyInto(out *TokenReviewAuthentication) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenReviewAuthentication.
+func (in *TokenReviewAuthentication) DeepCopy() *TokenReviewAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenReviewAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageSnapshot) DeepCopyInto(out *UsageSnapshot) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageSnapshot.
+func (in *UsageSnapshot) DeepCopy() *UsageSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *X509Authentication) DeepCopyInto(out *X509Authentication) {
+	*out = *in
+	out.CASecretRef = in.CASecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new X509Authentication.
+func (in *X509Authentication) DeepCopy() *X509Authentication {
+	if in == nil {
+		return nil
+	}
+	out := new(X509Authentication)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -50,11 +52,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/controller/maas"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/reconciler/externalmodel"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/tracing"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/webhook"
 )
 
@@ -70,6 +76,7 @@ func init() {
 	utilruntime.Must(extv1.AddToScheme(scheme))
 	utilruntime.Must(kservev1alpha1.AddToScheme(scheme))
 	utilruntime.Must(gatewayapiv1.Install(scheme))
+	utilruntime.Must(gatewayapiv1beta1.Install(scheme))
 	utilruntime.Must(maasv1alpha1.AddToScheme(scheme))
 }
 
@@ -500,6 +507,73 @@ func ensureClusterBootstrapRunnable(mgr ctrl.Manager, tenantNamespace, aitenantN
 	}
 }
 
+// splitCommaList returns the non-empty, trimmed elements of a comma-separated flag value.
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// watchNamespacesFromEnv returns WATCH_NAMESPACES, or the operator-SDK style
+// WATCH_NAMESPACE when it is unset.
+func watchNamespacesFromEnv() string {
+	if v, ok := os.LookupEnv("WATCH_NAMESPACES"); ok {
+		return v
+	}
+	return os.Getenv("WATCH_NAMESPACE")
+}
+
+// managerCacheOptions returns the cache options of the manager. MaaSSubscriptions and
+// MaaSAuthPolicies are read from the subscription namespace, or from every namespace with
+// tenant namespace discovery. In namespace-scoped mode (watched is non-nil), no object is
+// cached outside the watched namespaces, so the controller only needs RBAC in those.
+// Only the group mapping ConfigMaps of those namespaces are cached; the manager client
+// reads other ConfigMaps from the API server (see managerClientOptions).
+func managerCacheOptions(subscriptionNamespace string, tenantDiscovery bool, watched maas.WatchNamespaces) cache.Options {
+	crNamespaces := map[string]cache.Config{subscriptionNamespace: {}}
+	// Tenant CRs are watched cluster-wide to support AITenant-created tenants in any namespace.
+	// TODO: Replace with proper namespace discovery from S1 when merged.
+	var tenantNamespaces map[string]cache.Config
+	if tenantDiscovery {
+		crNamespaces = map[string]cache.Config{cache.AllNamespaces: {}}
+		tenantNamespaces = crNamespaces
+	}
+	opts := cache.Options{}
+	if watched != nil {
+		opts.DefaultNamespaces = map[string]cache.Config{}
+		for _, ns := range watched.List() {
+			opts.DefaultNamespaces[ns] = cache.Config{}
+		}
+		if tenantDiscovery {
+			crNamespaces = opts.DefaultNamespaces
+		}
+		// A nil Namespaces inherits DefaultNamespaces.
+		tenantNamespaces = nil
+	}
+	opts.ByObject = map[client.Object]cache.ByObject{
+		&maasv1alpha1.Tenant{}:           {Namespaces: tenantNamespaces},
+		&maasv1alpha1.MaaSAuthPolicy{}:   {Namespaces: crNamespaces},
+		&maasv1alpha1.MaaSSubscription{}: {Namespaces: crNamespaces},
+		&corev1.ConfigMap{}: {
+			Namespaces: crNamespaces,
+			Field:      fields.OneTermEqualSelector("metadata.name", maas.GroupMappingConfigMapName),
+		},
+	}
+	return opts
+}
+
+// managerClientOptions returns the client options of the manager. ConfigMaps are not read
+// from the cache, which only holds the group mapping ConfigMaps (see managerCacheOptions).
+func managerClientOptions() client.Options {
+	return client.Options{
+		Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.ConfigMap{}}},
+	}
+}
+
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
@@ -514,8 +588,30 @@ func main() {
 	var authzCacheTTL int64
 	var subscriptionNamespaceMaintainInterval time.Duration
 	var enableTenantNamespaceDiscovery bool
+	var routeRequeue maas.RequeueBackoff
+	var enforcementRequeue maas.RequeueBackoff
+	var enableLLMISvcAutoOnboarding bool
+	var manageGatewayBaselineAuth bool
+	var requirePoliciesForReady bool
+	var endpointProbeInterval time.Duration
+	var routingProvider string
+	var authProvider string
+	var endpointProbeTimeout time.Duration
+	var endpointProbeTokenFile string
+	var limitadorURL string
+	var keycloakAdminSecret string
+	var lifecycleSinkURL string
+	var usageCollectionInterval time.Duration
+	var usageNearLimitRatio float64
+	var usageHistoryLength int
+	var authFailureCollectionInterval time.Duration
+	var policyHealthInterval time.Duration
+	var refuseConflictingPolicies bool
 	var observabilityManifestsPath string
 	var monitoringNamespace string
+	var watchNamespaces string
+	var clusterAudienceOverride string
+	var tokenReviewAudiences string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -528,7 +624,17 @@ func main() {
 	flag.StringVar(&observabilityManifestsPath, "observability-manifests-path", "/deployment/components/observability/observability/dashboards", "Path to observability dashboard kustomize manifests.")
 	flag.StringVar(&monitoringNamespace, "monitoring-namespace", "opendatahub", "The namespace where the monitoring stack is deployed.")
 	flag.StringVar(&maasSubscriptionNamespace, "maas-subscription-namespace", "models-as-a-service", "The namespace to watch for MaaS CRs.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", watchNamespacesFromEnv(),
+		"Comma-separated namespaces to limit the controller to, so that it can run with namespace-scoped RBAC. "+
+			"The controller's own namespaces are always included. Defaults to the WATCH_NAMESPACES or WATCH_NAMESPACE "+
+			"environment variable; empty watches every namespace.")
 	flag.StringVar(&aitenantNamespace, "aitenant-namespace", tenantreconcile.DefaultAITenantNamespace, "The infrastructure namespace where AITenant CRs are accepted.")
+	flag.StringVar(&clusterAudienceOverride, "cluster-audience", "",
+		"Audience of the API server's service account tokens accepted by the gateway's Kubernetes TokenReview. "+
+			"Empty auto-detects the cluster's service account issuer and falls back to https://kubernetes.default.svc.")
+	flag.StringVar(&tokenReviewAudiences, "token-review-audiences", "",
+		"Comma-separated audiences the gateway's Kubernetes TokenReview accepts in addition to the cluster audience, "+
+			"e.g. <gateway-name>-sa. MaaSAuthPolicy spec.authentication.tokenReview.audiences adds to them per tenant.")
 	flag.Int64Var(&metadataCacheTTL, "metadata-cache-ttl", 60, "TTL in seconds for Authorino metadata HTTP caching (apiKeyValidation, subscription-info).")
 	flag.Int64Var(&authzCacheTTL, "authz-cache-ttl", 60, "TTL in seconds for Authorino OPA authorization caching (auth-valid, subscription-valid, require-group-membership).")
 	flag.DurationVar(&subscriptionNamespaceMaintainInterval, "subscription-namespace-maintain-interval", 30*time.Second,
@@ -537,6 +643,72 @@ func main() {
 	flag.BoolVar(&enableTenantNamespaceDiscovery, "enable-tenant-namespace-discovery", false,
 		"Discover AITenant-managed tenant namespaces labeled ai-gateway.opendatahub.io/tenant or maas.opendatahub.io/managed-by-aitenant=true and reconcile MaaS tenant CRs from them.")
 
+	flag.DurationVar(&routeRequeue.InitialDelay, "route-requeue-initial-delay", maas.DefaultRequeueInitialDelay,
+		"Initial delay before re-checking a MaaSModelRef whose HTTPRoute does not exist yet; doubles on each retry.")
+	flag.DurationVar(&routeRequeue.MaxDelay, "route-requeue-max-delay", maas.DefaultRequeueMaxDelay,
+		"Maximum delay between re-checks for a missing HTTPRoute.")
+	flag.DurationVar(&routeRequeue.MaxWait, "route-requeue-max-wait", maas.DefaultRequeueMaxWait,
+		"How long to keep re-checking for a missing HTTPRoute before marking the MaaSModelRef Failed. "+
+			"The HTTPRoute watch still reconciles the model once the route is created.")
+
+	flag.DurationVar(&enforcementRequeue.InitialDelay, "enforcement-requeue-initial-delay", maas.DefaultRequeueInitialDelay,
+		"Initial delay before re-checking a MaaSSubscription or MaaSAuthPolicy whose generated policies are not Enforced yet; doubles on each retry.")
+	flag.DurationVar(&enforcementRequeue.MaxDelay, "enforcement-requeue-max-delay", maas.DefaultRequeueMaxDelay,
+		"Maximum delay between re-checks for generated policies that are not Enforced.")
+	flag.DurationVar(&enforcementRequeue.MaxWait, "enforcement-requeue-max-wait", maas.DefaultRequeueMaxWait,
+		"How long to keep re-checking generated policies that are not Enforced. "+
+			"Policy status changes still reconcile the owning resource afterwards.")
+
+	flag.BoolVar(&requirePoliciesForReady, "require-policies-for-ready", false,
+		"Keep MaaSModelRefs out of Ready until an AuthPolicy targeting their HTTPRoute or Gateway is Accepted and Enforced. "+
+			"MaaSModelRef spec.requirePolicies overrides this per model.")
+	flag.StringVar(&routingProvider, "routing-provider", string(externalmodel.RoutingProviderGatewayAPI),
+		"How ExternalModel traffic is routed from the MaaS gateway: \"gateway-api\" emits HTTPRoutes; "+
+			"\"istio\" emits Istio VirtualServices bound to the Istio Gateway named by --gateway-name/--gateway-namespace, "+
+			"for clusters without the Gateway API CRDs. No AuthPolicy attaches to VirtualServices, so their MaaSModelRefs stay Pending.")
+	flag.DurationVar(&endpointProbeInterval, "endpoint-probe-interval", 0,
+		"How often to probe GET <status.endpoint>/v1/models for Ready MaaSModelRefs and report failures in the Degraded condition. "+
+			"Models with spec.endpointOverride are not probed. 0 disables probing.")
+	flag.DurationVar(&endpointProbeTimeout, "endpoint-probe-timeout", maas.DefaultEndpointProbeTimeout,
+		"Timeout for a single endpoint probe request.")
+	flag.StringVar(&endpointProbeTokenFile, "endpoint-probe-token-file", "",
+		"Optional file with a Bearer token sent with endpoint probes so they pass gateway authentication.")
+	flag.StringVar(&limitadorURL, "limitador-url", "",
+		"Base URL of the Limitador HTTP API (e.g. http://limitador-limitador.kuadrant-system.svc:8080) to read rate limit counters from "+
+			"into MaaSSubscription status.usage. Empty disables usage collection.")
+	flag.StringVar(&keycloakAdminSecret, "keycloak-admin-secret", "",
+		"Secret, as <namespace>/<name>, with the url of the Keycloak server and admin credentials (username and password, or "+
+			"client-id and client-secret, and an optional realm) used to create the OIDC clients of tenants whose OIDC configuration sets provisionClient. "+
+			"Empty disables provisioning.")
+	flag.StringVar(&lifecycleSinkURL, "lifecycle-sink-url", "",
+		"URL that MaaSModelRef lifecycle events (registered, ready, policy enforced, removed) are posted to as CloudEvents, "+
+			"in addition to the Kubernetes Events. Empty disables the sink.")
+	flag.DurationVar(&usageCollectionInterval, "usage-collection-interval", time.Minute,
+		"How often to refresh MaaSSubscription status.usage from Limitador when --limitador-url is set.")
+	flag.Float64Var(&usageNearLimitRatio, "usage-near-limit-ratio", maas.DefaultUsageNearLimitRatio,
+		"Share of a token rate limit a counter must have used for the MaaSSubscription NearLimit condition to become True.")
+	flag.IntVar(&usageHistoryLength, "usage-history-length", maas.DefaultUsageHistoryLength,
+		fmt.Sprintf("Number of hourly usage snapshots kept per model in MaaSSubscription status.usage[].history, at most %d. "+
+			"0 keeps no history.", maas.MaxUsageHistoryLength))
+	flag.DurationVar(&authFailureCollectionInterval, "auth-failure-collection-interval", 0,
+		"How often to read the 401 and 403 responses of the gateway's Envoy proxies into the maas_controller_model_auth_failures_total metric. "+
+			"0 disables collection.")
+	flag.DurationVar(&policyHealthInterval, "policy-health-interval", maas.DefaultPolicyHealthInterval,
+		"How often to count the generated Kuadrant policies that are not enforced or whose MaaSModelRef is gone into the "+
+			"maas_controller_policies_not_enforced and maas_controller_orphaned_resources metrics. 0 disables collection, as does --auth-provider=authorino.")
+	flag.BoolVar(&refuseConflictingPolicies, "refuse-conflicting-rate-limit-policies", false,
+		"Do not apply the generated TokenRateLimitPolicy and RateLimitPolicy of a model while a TokenRateLimitPolicy or RateLimitPolicy "+
+			"not created by maas-controller targets its HTTPRoute. Conflicts are reported in the MaaSSubscription ConflictingRateLimitPolicy condition either way.")
+	flag.BoolVar(&enableLLMISvcAutoOnboarding, "enable-llmisvc-auto-onboarding", false,
+		"Create a MaaSModelRef for every LLMInferenceService labeled "+maas.ExposeLabel+"=true and delete it when the label is removed.")
+	flag.StringVar(&authProvider, "auth-provider", string(maas.AuthProviderKuadrant),
+		"How MaaSAuthPolicies are enforced at the MaaS gateway: \"kuadrant\" emits Kuadrant AuthPolicies; "+
+			"\"authorino\" emits Authorino AuthConfigs for the gateway hostnames, for clusters running standalone Authorino "+
+			"without the Kuadrant operator.")
+	flag.BoolVar(&manageGatewayBaselineAuth, "manage-gateway-baseline-auth", true,
+		"Keep the deny-all gateway-default-auth AuthPolicy on the MaaS gateway while no MaaSAuthPolicy has created maas-gateway-auth, "+
+			"recreating it when deleted and reverting edits to it.")
+
 	opts := zap.Options{Development: false}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
@@ -554,6 +726,54 @@ func main() {
 			"gatewayName", gatewayName, "gatewayNamespace", gatewayNamespace)
 		os.Exit(1)
 	}
+	switch externalmodel.RoutingProvider(routingProvider) {
+	case externalmodel.RoutingProviderGatewayAPI, externalmodel.RoutingProviderIstio:
+	default:
+		setupLog.Error(stderrors.New("invalid routing provider"),
+			"--routing-provider must be \"gateway-api\" or \"istio\"",
+			"routingProvider", routingProvider)
+		os.Exit(1)
+	}
+	switch maas.AuthProvider(authProvider) {
+	case maas.AuthProviderKuadrant, maas.AuthProviderAuthorino:
+	default:
+		setupLog.Error(stderrors.New("invalid auth provider"),
+			"--auth-provider must be \"kuadrant\" or \"authorino\"",
+			"authProvider", authProvider)
+		os.Exit(1)
+	}
+	var keycloakAdminSecretRef types.NamespacedName
+	if keycloakAdminSecret != "" {
+		ns, name, ok := strings.Cut(keycloakAdminSecret, "/")
+		if !ok || validation.IsDNS1123Label(ns) != nil || validation.IsDNS1123Subdomain(name) != nil {
+			setupLog.Error(stderrors.New("invalid Keycloak admin Secret"),
+				"--keycloak-admin-secret must be <namespace>/<name>",
+				"keycloakAdminSecret", keycloakAdminSecret)
+			os.Exit(1)
+		}
+		keycloakAdminSecretRef = types.NamespacedName{Namespace: ns, Name: name}
+	}
+	var lifecycleSink maas.LifecycleSink
+	if lifecycleSinkURL != "" {
+		u, err := url.Parse(lifecycleSinkURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			setupLog.Error(stderrors.New("invalid lifecycle sink URL"),
+				"--lifecycle-sink-url must be an absolute http or https URL",
+				"lifecycleSinkURL", lifecycleSinkURL)
+			os.Exit(1)
+		}
+		lifecycleSink = maas.NewWebhookLifecycleSink(lifecycleSinkURL, maas.DefaultLifecycleSinkTimeout)
+	}
+	if usageHistoryLength < 0 || usageHistoryLength > maas.MaxUsageHistoryLength {
+		setupLog.Error(stderrors.New("invalid usage history length"),
+			fmt.Sprintf("--usage-history-length must be between 0 and %d", maas.MaxUsageHistoryLength),
+			"usageHistoryLength", usageHistoryLength)
+		os.Exit(1)
+	}
+	if usageHistoryLength == 0 {
+		// The reconciler defaults a zero length.
+		usageHistoryLength = -1
+	}
 	if strings.TrimSpace(controllerNamespace) == "" {
 		setupLog.Error(stderrors.New("invalid controller namespace configuration"),
 			"--controller-namespace must be non-empty")
@@ -573,6 +793,17 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	cfg := ctrl.GetConfigOrDie()
+	var shutdownTracing func(context.Context) error
+	if tracing.Enabled() {
+		shutdown, err := tracing.Setup(context.Background(), ctrl.Log.WithName("tracing"))
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		shutdownTracing = shutdown
+		cfg.Wrap(tracing.Transport)
+		setupLog.Info("OpenTelemetry tracing enabled")
+	}
 	clientset, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		setupLog.Error(err, "unable to create Kubernetes client for managed namespace setup")
@@ -587,27 +818,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	nsCfg := map[string]cache.Config{maasSubscriptionNamespace: {}}
-	cacheOpts := cache.Options{
-		ByObject: map[client.Object]cache.ByObject{
-			// Tenant CRs are watched cluster-wide to support AITenant-created tenants in any namespace.
-			// TODO: Replace with proper namespace discovery from S1 when merged.
-			&maasv1alpha1.Tenant{}:           {},
-			&maasv1alpha1.MaaSAuthPolicy{}:   {Namespaces: nsCfg},
-			&maasv1alpha1.MaaSSubscription{}: {Namespaces: nsCfg},
-		},
+	// The controller's own namespaces are always watched in namespace-scoped mode: it
+	// writes the gateway AuthPolicy, the maas-api configuration and its dashboards there.
+	watched := maas.ParseWatchNamespaces(watchNamespaces)
+	watched.Add(maasSubscriptionNamespace, gatewayNamespace, controllerNamespace, maasAPINamespace, aitenantNamespace, monitoringNamespace)
+	if watched != nil {
+		setupLog.Info("running in namespace-scoped mode", "namespaces", watched.List())
 	}
+	cacheOpts := managerCacheOptions(maasSubscriptionNamespace, enableTenantNamespaceDiscovery, watched)
 	setupLog.Info("watching namespace for MaaS CRs", "namespace", maasSubscriptionNamespace)
 	if enableTenantNamespaceDiscovery {
-		allNamespacesCfg := map[string]cache.Config{cache.AllNamespaces: {}}
-		cacheOpts = cache.Options{
-			ByObject: map[client.Object]cache.ByObject{
-				&maasv1alpha1.Tenant{}:           {Namespaces: allNamespacesCfg},
-				&maasv1alpha1.MaaSAuthPolicy{}:   {Namespaces: allNamespacesCfg},
-				&maasv1alpha1.MaaSSubscription{}: {Namespaces: allNamespacesCfg},
-			},
-		}
-		setupLog.Info("watching MaaS CRs across all namespaces for tenant discovery",
+		setupLog.Info("watching MaaS CRs across all watched namespaces for tenant discovery",
 			"defaultNamespace", maasSubscriptionNamespace,
 			"tenantNamespaceLabel", tenantreconcile.LabelAIGatewayTenant,
 			"compatTenantNamespaceLabel", tenantreconcile.LabelManagedByAITenant)
@@ -616,6 +837,7 @@ func main() {
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme:                 scheme,
 		Cache:                  cacheOpts,
+		Client:                 managerClientOptions(),
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -626,16 +848,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Auto-detect cluster audience from OpenShift/ROSA; fall back to the standard Kubernetes audience.
+	// Use --cluster-audience, else auto-detect it from OpenShift/ROSA; fall back to the standard Kubernetes audience.
 	// Use GetAPIReader() instead of GetClient() because the cache hasn't started yet.
 	clusterAudience := "https://kubernetes.default.svc"
-	if detectedAudience, err := getClusterServiceAccountIssuer(mgr.GetAPIReader()); err == nil && detectedAudience != "" {
+	if clusterAudienceOverride != "" {
+		clusterAudience = clusterAudienceOverride
+		setupLog.Info("using configured cluster audience", "audience", clusterAudience)
+	} else if detectedAudience, err := getClusterServiceAccountIssuer(mgr.GetAPIReader()); err == nil && detectedAudience != "" {
 		setupLog.Info("auto-detected cluster service account issuer", "audience", detectedAudience)
 		clusterAudience = detectedAudience
 	} else if err != nil {
 		setupLog.Error(err, "unable to auto-detect cluster service account issuer, using default", "default", clusterAudience)
 	}
 
+	// Generate the TokenRateLimitPolicy version the installed Kuadrant serves, so that a
+	// Kuadrant upgrade promoting the API to v1 does not break the controller.
+	trlpGVK, err := kuadrantv1alpha1.ServedTokenRateLimitPolicyGVK(mgr.GetRESTMapper())
+	if err != nil {
+		setupLog.Error(err, "unable to discover the served TokenRateLimitPolicy version, using default", "default", trlpGVK.GroupVersion().String())
+	} else {
+		setupLog.Info("discovered TokenRateLimitPolicy version", "apiVersion", trlpGVK.GroupVersion().String())
+	}
+
+	var endpointProbes *maas.EndpointProbeMonitor
+	if endpointProbeInterval > 0 {
+		endpointProbes = maas.NewEndpointProbeMonitor(maas.NewHTTPEndpointProber(endpointProbeTimeout, endpointProbeTokenFile), endpointProbeInterval)
+		if err := mgr.Add(endpointProbes); err != nil {
+			setupLog.Error(err, "unable to add endpoint probe monitor")
+			os.Exit(1)
+		}
+	}
 	if err := (&maas.MaaSModelRefReconciler{
 		Client:                          mgr.GetClient(),
 		Scheme:                          mgr.GetScheme(),
@@ -643,10 +885,39 @@ func main() {
 		GatewayNamespace:                gatewayNamespace,
 		DefaultTenantNamespace:          maasSubscriptionNamespace,
 		TenantNamespaceDiscoveryEnabled: enableTenantNamespaceDiscovery,
+		RouteRequeue:                    routeRequeue,
+		RequirePoliciesDefault:          requirePoliciesForReady,
+		EndpointProbes:                  endpointProbes,
+		RoutingProvider:                 externalmodel.RoutingProvider(routingProvider),
+		AuthProvider:                    maas.AuthProvider(authProvider),
+		TokenRateLimitPolicyGVK:         trlpGVK,
+		LifecycleSink:                   lifecycleSink,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MaaSModelRef")
 		os.Exit(1)
 	}
+	if enableLLMISvcAutoOnboarding {
+		if err := (&maas.LLMISvcOnboardingReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LLMISvcOnboarding")
+			os.Exit(1)
+		}
+		setupLog.Info("LLMInferenceService auto-onboarding enabled", "label", maas.ExposeLabel+"=true")
+	}
+	// The deny-all baseline is a Kuadrant AuthPolicy; standalone Authorino denies
+	// requests to hosts without an AuthConfig by itself.
+	if manageGatewayBaselineAuth && maas.AuthProvider(authProvider) == maas.AuthProviderKuadrant {
+		if err := (&maas.GatewayBaselineAuthReconciler{
+			Client:           mgr.GetClient(),
+			GatewayName:      gatewayName,
+			GatewayNamespace: gatewayNamespace,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GatewayBaselineAuth")
+			os.Exit(1)
+		}
+	}
 	if err := (&maas.MaaSAuthPolicyReconciler{
 		Client:                          mgr.GetClient(),
 		Scheme:                          mgr.GetScheme(),
@@ -655,13 +926,24 @@ func main() {
 		GatewayName:                     gatewayName,
 		GatewayNamespace:                gatewayNamespace,
 		ClusterAudience:                 clusterAudience,
+		TokenReviewAudiences:            splitCommaList(tokenReviewAudiences),
 		MetadataCacheTTL:                metadataCacheTTL,
 		AuthzCacheTTL:                   authzCacheTTL,
 		TenantNamespaceDiscoveryEnabled: enableTenantNamespaceDiscovery,
+		WatchNamespaces:                 watched,
+		EnforcementRequeue:              enforcementRequeue,
+		AuthProvider:                    maas.AuthProvider(authProvider),
+		RoutingProvider:                 externalmodel.RoutingProvider(routingProvider),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MaaSAuthPolicy")
 		os.Exit(1)
 	}
+	var usageCollector maas.UsageCollector
+	var usageNotifier maas.UsageNotifier
+	if limitadorURL != "" {
+		usageCollector = maas.NewLimitadorUsageCollector(limitadorURL, maas.DefaultUsageCollectionTimeout)
+		usageNotifier = maas.NewWebhookUsageNotifier(maas.DefaultNotificationTimeout)
+	}
 	if err := (&maas.MaaSSubscriptionReconciler{
 		Client:                          mgr.GetClient(),
 		Scheme:                          mgr.GetScheme(),
@@ -669,6 +951,17 @@ func main() {
 		TenantNamespaceDiscoveryEnabled: enableTenantNamespaceDiscovery,
 		GatewayName:                     gatewayName,
 		GatewayNamespace:                gatewayNamespace,
+		WatchNamespaces:                 watched,
+		UsageCollector:                  usageCollector,
+		UsageCollectionInterval:         usageCollectionInterval,
+		UsageNearLimitRatio:             usageNearLimitRatio,
+		UsageHistoryLength:              usageHistoryLength,
+		UsageNotifier:                   usageNotifier,
+		AuthProvider:                    maas.AuthProvider(authProvider),
+		RoutingProvider:                 externalmodel.RoutingProvider(routingProvider),
+		RefuseConflictingPolicies:       refuseConflictingPolicies,
+		EnforcementRequeue:              enforcementRequeue,
+		TokenRateLimitPolicyGVK:         trlpGVK,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MaaSSubscription")
 		os.Exit(1)
@@ -692,6 +985,7 @@ func main() {
 		Log:              ctrl.Log.WithName("controllers").WithName("ExternalModel"),
 		GatewayName:      gatewayName,
 		GatewayNamespace: gatewayNamespace,
+		RoutingProvider:  externalmodel.RoutingProvider(routingProvider),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ExternalModel")
 		os.Exit(1)
@@ -717,6 +1011,28 @@ func main() {
 		setupLog.Error(err, "unable to add AITenant namespace monitor")
 		os.Exit(1)
 	}
+	if authFailureCollectionInterval > 0 {
+		if err := mgr.Add(&maas.AuthFailureCollector{
+			Client:   mgr.GetClient(),
+			Source:   maas.NewEnvoyGatewayMetricsSource(mgr.GetAPIReader(), gatewayNamespace, gatewayName, maas.DefaultAuthFailureCollectionTimeout),
+			Interval: authFailureCollectionInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add gateway auth failure collector")
+			os.Exit(1)
+		}
+	}
+
+	// The collector reports Kuadrant policies, which standalone Authorino does not have.
+	if policyHealthInterval > 0 && maas.AuthProvider(authProvider) == maas.AuthProviderKuadrant {
+		if err := mgr.Add(&maas.PolicyHealthCollector{
+			Client:     mgr.GetClient(),
+			PolicyGVKs: []schema.GroupVersionKind{kuadrantv1.AuthPolicyGVK, trlpGVK, kuadrantv1.RateLimitPolicyGVK},
+			Interval:   policyHealthInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add generated policy health collector")
+			os.Exit(1)
+		}
+	}
 
 	// Startup ordering contract:
 	//   1. Managed namespace ensures run synchronously above, before the manager starts.
@@ -746,6 +1062,9 @@ func main() {
 		ClusterAudience:                 clusterAudience,
 		TenantNamespaceDiscoveryEnabled: enableTenantNamespaceDiscovery,
 		MetadataCacheTTL:                metadataCacheTTL,
+		KeycloakAdminSecret:             keycloakAdminSecretRef,
+		KeycloakAdmin:                   maas.NewKeycloakAdmin(maas.DefaultKeycloakAdminTimeout),
+		APIReader:                       mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tenant")
 		os.Exit(1)
@@ -817,7 +1136,15 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if shutdownTracing != nil {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := shutdownTracing(flushCtx); err != nil {
+			setupLog.Error(err, "failed to flush traces")
+		}
+		cancel()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
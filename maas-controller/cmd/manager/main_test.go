@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -15,6 +17,7 @@ import (
 	controllerfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/controller/maas"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
 )
 
@@ -370,3 +373,38 @@ func TestEnsureDefaultAITenantBootstrapDoesNotRecreateAfterBootstrapMarker(t *te
 		t.Fatalf("default AITenant was recreated after bootstrap marker")
 	}
 }
+
+func TestManagerCacheOptions(t *testing.T) {
+	clusterWide := managerCacheOptions("models-as-a-service", false, nil)
+	if clusterWide.DefaultNamespaces != nil {
+		t.Errorf("expected no default namespaces in cluster-wide mode, got %v", clusterWide.DefaultNamespaces)
+	}
+	for obj, byObject := range clusterWide.ByObject {
+		if _, ok := obj.(*maasv1alpha1.MaaSSubscription); ok {
+			if _, cached := byObject.Namespaces["models-as-a-service"]; !cached || len(byObject.Namespaces) != 1 {
+				t.Errorf("expected MaaSSubscriptions to be cached in the subscription namespace only, got %v", byObject.Namespaces)
+			}
+		}
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			if byObject.Field == nil || !byObject.Field.Matches(fields.Set{"metadata.name": maas.GroupMappingConfigMapName}) ||
+				byObject.Field.Matches(fields.Set{"metadata.name": "other"}) {
+				t.Errorf("expected only the group mapping ConfigMaps to be cached, got field selector %v", byObject.Field)
+			}
+		}
+	}
+
+	watched := maas.ParseWatchNamespaces("team-a,models-as-a-service")
+	for _, discovery := range []bool{false, true} {
+		opts := managerCacheOptions("models-as-a-service", discovery, watched)
+		if _, ok := opts.DefaultNamespaces["team-a"]; !ok || len(opts.DefaultNamespaces) != 2 {
+			t.Errorf("discovery=%v: DefaultNamespaces = %v, want the watched namespaces", discovery, opts.DefaultNamespaces)
+		}
+		for obj, byObject := range opts.ByObject {
+			for ns := range byObject.Namespaces {
+				if !watched.Contains(ns) {
+					t.Errorf("discovery=%v: %T is cached in unwatched namespace %q", discovery, obj, ns)
+				}
+			}
+		}
+	}
+}
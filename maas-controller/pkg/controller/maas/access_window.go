@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// celDayOfWeek numbers the days like CEL's getDayOfWeek, which starts the week on Sunday.
+var celDayOfWeek = map[maasv1alpha1.Weekday]int{
+	"Sunday": 0, "Monday": 1, "Tuesday": 2, "Wednesday": 3, "Thursday": 4, "Friday": 5, "Saturday": 6,
+}
+
+// accessWindowPolicy is the spec.accessWindows of a MaaSAuthPolicy with the models, as
+// "namespace/name", it applies to.
+type accessWindowPolicy struct {
+	PolicyName string
+	Models     []string
+	Windows    []maasv1alpha1.AccessWindow
+}
+
+// minuteOfDay parses an HH:MM time of day.
+func minuteOfDay(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, want HH:MM", hhmm)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateAccessWindows checks the parts of access windows the CRD schema cannot.
+func ValidateAccessWindows(windows []maasv1alpha1.AccessWindow) error {
+	for i, w := range windows {
+		if w.TimeZone != "" {
+			if _, err := time.LoadLocation(w.TimeZone); err != nil {
+				return fmt.Errorf("accessWindows[%d]: invalid timeZone %q: %w", i, w.TimeZone, err)
+			}
+		}
+		for _, hhmm := range []string{w.Start, w.End} {
+			if _, err := minuteOfDay(hhmm); err != nil {
+				return fmt.Errorf("accessWindows[%d]: %w", i, err)
+			}
+		}
+		for _, d := range w.Days {
+			if _, ok := celDayOfWeek[d]; !ok {
+				return fmt.Errorf("accessWindows[%d]: invalid day %q", i, d)
+			}
+		}
+	}
+	return nil
+}
+
+// aggregateAccessWindows returns the access windows of the policies, sorted by policy name.
+func aggregateAccessWindows(policies []maasv1alpha1.MaaSAuthPolicy) ([]accessWindowPolicy, error) {
+	var out []accessWindowPolicy
+	for _, p := range policies {
+		if len(p.Spec.AccessWindows) == 0 || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := ValidateAccessWindows(p.Spec.AccessWindows); err != nil {
+			return nil, fmt.Errorf("invalid MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+		}
+		entry := accessWindowPolicy{PolicyName: p.Name, Windows: p.Spec.AccessWindows}
+		for _, ref := range p.Spec.ModelRefs {
+			entry.Models = append(entry.Models, ref.Namespace+"/"+ref.Name)
+		}
+		entry.Models = deduplicateAndSort(entry.Models)
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PolicyName < out[j].PolicyName })
+	return out, nil
+}
+
+// celWindowDays is true when the day of week of expr is one of the days.
+func celWindowDays(expr string, days []maasv1alpha1.Weekday) string {
+	if len(days) == 0 {
+		return "true"
+	}
+	nums := make([]int, 0, len(days))
+	for _, d := range days {
+		nums = append(nums, celDayOfWeek[d])
+	}
+	sort.Ints(nums)
+	items := make([]string, 0, len(nums))
+	for _, n := range nums {
+		items = append(items, strconv.Itoa(n))
+	}
+	return expr + " in [" + strings.Join(items, ", ") + "]"
+}
+
+// celAccessWindow is true when request.time is inside the window. A window closing the
+// next day belongs, after midnight, to the day it opened on.
+func celAccessWindow(w maasv1alpha1.AccessWindow) string {
+	tz := w.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	dow := fmt.Sprintf("request.time.getDayOfWeek(%q)", tz)
+	minute := fmt.Sprintf("(request.time.getHours(%[1]q) * 60 + request.time.getMinutes(%[1]q))", tz)
+	start, _ := minuteOfDay(w.Start)
+	end, _ := minuteOfDay(w.End)
+	switch {
+	case start == end:
+		return "(" + celWindowDays(dow, w.Days) + ")"
+	case start < end:
+		return fmt.Sprintf("(%s && %s >= %d && %s < %d)", celWindowDays(dow, w.Days), minute, start, minute, end)
+	default:
+		return fmt.Sprintf("((%s && %s >= %d) || (%s && %s < %d))",
+			celWindowDays(dow, w.Days), minute, start, celWindowDays("(("+dow+" + 6) % 7)", w.Days), minute, end)
+	}
+}
+
+// celInsideAccessWindows is true when request.time is inside an Allow window, if there
+// are any, and outside every Deny window.
+func celInsideAccessWindows(windows []maasv1alpha1.AccessWindow) string {
+	var allow, deny []string
+	for _, w := range windows {
+		if w.Action == maasv1alpha1.AccessWindowDeny {
+			deny = append(deny, celAccessWindow(w))
+		} else {
+			allow = append(allow, celAccessWindow(w))
+		}
+	}
+	var parts []string
+	if len(allow) > 0 {
+		parts = append(parts, "("+strings.Join(allow, " || ")+")")
+	}
+	if len(deny) > 0 {
+		parts = append(parts, "!("+strings.Join(deny, " || ")+")")
+	}
+	return strings.Join(parts, " && ")
+}
+
+// accessWindowsRuleName is the gateway AuthPolicy rule of a MaaSAuthPolicy's access windows.
+func accessWindowsRuleName(policyName string) string {
+	return "access-windows-" + policyName
+}
+
+// addAccessWindowsRules adds an authorization rule per policy with spec.accessWindows that
+// refuses the requests to its models made outside the windows, for every credential.
+func addAccessWindowsRules(authorization map[string]kuadrantv1.AuthorizationRule, policies []accessWindowPolicy) {
+	for _, p := range policies {
+		authorization[accessWindowsRuleName(p.PolicyName)] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celRequestedModelIn(p.Models) + ` && !(` + celInsideAccessWindows(p.Windows) + `)`,
+				}},
+			},
+			// The rule only applies outside the windows, where it never matches.
+			PatternMatching: &kuadrantv1.PatternMatchingAuthz{
+				Patterns: []kuadrantv1.Pattern{{
+					Selector: "context.request.http.method",
+					Operator: "eq",
+					Value:    "__outside_access_window__",
+				}},
+			},
+		}
+	}
+}
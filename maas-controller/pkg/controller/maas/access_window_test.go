@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestCelAccessWindow(t *testing.T) {
+	const (
+		dow    = `request.time.getDayOfWeek("Europe/Berlin")`
+		minute = `(request.time.getHours("Europe/Berlin") * 60 + request.time.getMinutes("Europe/Berlin"))`
+	)
+	weekdays := []maasv1alpha1.Weekday{"Friday", "Monday", "Tuesday", "Wednesday", "Thursday"}
+	tests := []struct {
+		name   string
+		window maasv1alpha1.AccessWindow
+		want   string
+	}{
+		{
+			name:   "business hours",
+			window: maasv1alpha1.AccessWindow{Days: weekdays, Start: "09:00", End: "17:30", TimeZone: "Europe/Berlin"},
+			want:   `(` + dow + ` in [1, 2, 3, 4, 5] && ` + minute + ` >= 540 && ` + minute + ` < 1050)`,
+		},
+		{
+			name:   "overnight",
+			window: maasv1alpha1.AccessWindow{Days: []maasv1alpha1.Weekday{"Friday"}, Start: "22:00", End: "06:00", TimeZone: "Europe/Berlin"},
+			want:   `((` + dow + ` in [5] && ` + minute + ` >= 1320) || (((` + dow + ` + 6) % 7) in [5] && ` + minute + ` < 360))`,
+		},
+		{
+			name:   "whole day",
+			window: maasv1alpha1.AccessWindow{Days: []maasv1alpha1.Weekday{"Sunday"}, Start: "00:00", End: "00:00", TimeZone: "Europe/Berlin"},
+			want:   `(` + dow + ` in [0])`,
+		},
+		{
+			name:   "every day in UTC",
+			window: maasv1alpha1.AccessWindow{Start: "08:00", End: "20:00"},
+			want:   `(true && (request.time.getHours("UTC") * 60 + request.time.getMinutes("UTC")) >= 480 && (request.time.getHours("UTC") * 60 + request.time.getMinutes("UTC")) < 1200)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := celAccessWindow(tt.window); got != tt.want {
+				t.Errorf("celAccessWindow =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCelInsideAccessWindows(t *testing.T) {
+	hours := maasv1alpha1.AccessWindow{Start: "09:00", End: "17:00"}
+	freeze := maasv1alpha1.AccessWindow{Days: []maasv1alpha1.Weekday{"Saturday"}, Start: "00:00", End: "00:00", Action: maasv1alpha1.AccessWindowDeny}
+
+	if got, want := celInsideAccessWindows([]maasv1alpha1.AccessWindow{hours, freeze}), "("+celAccessWindow(hours)+") && !("+celAccessWindow(freeze)+")"; got != want {
+		t.Errorf("allow and deny = %s, want %s", got, want)
+	}
+	if got, want := celInsideAccessWindows([]maasv1alpha1.AccessWindow{freeze}), "!("+celAccessWindow(freeze)+")"; got != want {
+		t.Errorf("deny only = %s, want %s", got, want)
+	}
+}
+
+func TestAggregateAccessWindows(t *testing.T) {
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	hours := newMaaSAuthPolicy("business-hours", "default", "team-a", llm)
+	hours.Spec.AccessWindows = []maasv1alpha1.AccessWindow{{Start: "09:00", End: "17:00", TimeZone: "America/New_York"}}
+	plain := newMaaSAuthPolicy("plain", "default", "team-b", llm)
+
+	got, err := aggregateAccessWindows([]maasv1alpha1.MaaSAuthPolicy{*plain, *hours})
+	if err != nil {
+		t.Fatalf("aggregateAccessWindows: %v", err)
+	}
+	if len(got) != 1 || got[0].PolicyName != "business-hours" || strings.Join(got[0].Models, ",") != "default/llm" {
+		t.Errorf("got %+v, want the business-hours policy for default/llm", got)
+	}
+
+	hours.Spec.AccessWindows[0].TimeZone = "Mars/Olympus"
+	if _, err := aggregateAccessWindows([]maasv1alpha1.MaaSAuthPolicy{*hours}); err == nil {
+		t.Error("expected an unknown time zone to be rejected")
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_AccessWindows(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	windows := []maasv1alpha1.AccessWindow{{Start: "09:00", End: "17:00"}}
+	authz := gatewayAuthorization{AccessWindows: []accessWindowPolicy{{PolicyName: "business-hours", Models: []string{"llm/granite"}, Windows: windows}}}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, authz, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+
+	rule, ok := spec.Defaults.Rules.Authorization["access-windows-business-hours"]
+	if !ok || rule.PatternMatching == nil {
+		t.Fatalf("access-windows-business-hours rule missing: %+v", spec.Defaults.Rules.Authorization)
+	}
+	want := celRequestedModelIn([]string{"llm/granite"}) + ` && !(` + celInsideAccessWindows(windows) + `)`
+	if len(rule.When) != 1 || rule.When[0].Predicate != want {
+		t.Errorf("when = %+v, want the rule to apply to granite outside the windows", rule.When)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AdoptAnnotation marks a TokenRateLimitPolicy that was not created by maas-controller
+// for adoption: set to "true" on a policy targeting a model's HTTPRoute, the controller
+// takes it over as the model's TokenRateLimitPolicy instead of creating its own.
+const AdoptAnnotation = "maas.opendatahub.io/adopt"
+
+// adoptedTokenRateLimitPolicy returns the TokenRateLimitPolicy that serves as the model's
+// TokenRateLimitPolicy in place of the generated one, or nil if there is none. A policy
+// annotated for adoption is labeled as generated for the model when it is adopted. It
+// is only adopted while no policy exists under the generated name, so that a model
+// never has two.
+func (r *MaaSSubscriptionReconciler) adoptedTokenRateLimitPolicy(ctx context.Context, log logr.Logger, httpRouteName, httpRouteNS, modelNamespace, modelName string) (*unstructured.Unstructured, error) {
+	generated := &unstructured.Unstructured{}
+	generated.SetGroupVersionKind(r.trlpGVK())
+	err := r.Get(ctx, types.NamespacedName{Name: tokenRateLimitPolicyName(modelNamespace, modelName, ""), Namespace: httpRouteNS}, generated)
+	if err == nil {
+		return nil, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to check existing TokenRateLimitPolicy: %w", err)
+	}
+
+	policies := &unstructured.UnstructuredList{}
+	policies.SetGroupVersionKind(r.trlpGVK().GroupVersion().WithKind("TokenRateLimitPolicyList"))
+	if err := r.List(ctx, policies, client.InNamespace(httpRouteNS)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list TokenRateLimitPolicies in namespace %s: %w", httpRouteNS, err)
+	}
+	sort.Slice(policies.Items, func(i, j int) bool { return policies.Items[i].GetName() < policies.Items[j].GetName() })
+
+	var candidate *unstructured.Unstructured
+	for i := range policies.Items {
+		p := &policies.Items[i]
+		if p.GetAnnotations()[AdoptAnnotation] != "true" || !targetsHTTPRoute(p, httpRouteName) {
+			continue
+		}
+		labels := p.GetLabels()
+		if labels["app.kubernetes.io/managed-by"] == "maas-controller" {
+			if labels["maas.opendatahub.io/model"] == modelName && labels["maas.opendatahub.io/model-namespace"] == modelNamespace {
+				return p, nil
+			}
+			continue
+		}
+		if candidate == nil {
+			candidate = p
+		}
+	}
+	if candidate == nil || !isManaged(candidate) {
+		return candidate, nil
+	}
+
+	labels := candidate.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["maas.opendatahub.io/model"] = modelName
+	labels["maas.opendatahub.io/model-namespace"] = modelNamespace
+	labels["app.kubernetes.io/managed-by"] = "maas-controller"
+	labels["app.kubernetes.io/part-of"] = "maas-subscription"
+	labels["app.kubernetes.io/component"] = "token-rate-limit-policy"
+	candidate.SetLabels(labels)
+	if err := r.Update(ctx, candidate); err != nil {
+		return nil, fmt.Errorf("failed to adopt TokenRateLimitPolicy %s/%s: %w", candidate.GetNamespace(), candidate.GetName(), err)
+	}
+	log.Info("Adopted TokenRateLimitPolicy", "name", candidate.GetName(), "namespace", httpRouteNS, "model", modelNamespace+"/"+modelName)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(candidate, "Normal", "TokenRateLimitPolicyAdopted",
+			"Adopted as the TokenRateLimitPolicy of model %s/%s; its spec is now generated from MaaSSubscriptions", modelNamespace, modelName)
+	}
+	return candidate, nil
+}
+
+// modelTokenRateLimitPolicyName returns the name of the model's TokenRateLimitPolicy:
+// the adopted policy's when one was adopted, else the generated name.
+func (r *MaaSSubscriptionReconciler) modelTokenRateLimitPolicyName(ctx context.Context, httpRouteName, httpRouteNS, modelNamespace, modelName string) string {
+	policies := &unstructured.UnstructuredList{}
+	policies.SetGroupVersionKind(r.trlpGVK().GroupVersion().WithKind("TokenRateLimitPolicyList"))
+	if err := r.List(ctx, policies, client.InNamespace(httpRouteNS), client.MatchingLabels{
+		"maas.opendatahub.io/model":           modelName,
+		"maas.opendatahub.io/model-namespace": modelNamespace,
+		"app.kubernetes.io/managed-by":        "maas-controller",
+	}); err == nil {
+		for i := range policies.Items {
+			p := &policies.Items[i]
+			if p.GetAnnotations()[AdoptAnnotation] == "true" && targetsHTTPRoute(p, httpRouteName) {
+				return p.GetName()
+			}
+		}
+	}
+	return tokenRateLimitPolicyName(modelNamespace, modelName, "")
+}
+
+// targetsHTTPRoute reports whether a policy's targetRef is the named HTTPRoute.
+func targetsHTTPRoute(policy *unstructured.Unstructured, httpRouteName string) bool {
+	name, _, _ := unstructured.NestedString(policy.Object, "spec", "targetRef", "name")
+	kind, _, _ := unstructured.NestedString(policy.Object, "spec", "targetRef", "kind")
+	group, _, _ := unstructured.NestedString(policy.Object, "spec", "targetRef", "group")
+	return name == httpRouteName && kind == "HTTPRoute" && group == "gateway.networking.k8s.io"
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// newUserTRLP returns a TokenRateLimitPolicy created outside maas-controller that targets
+// the given HTTPRoute.
+func newUserTRLP(name, namespace, httpRouteName string, annotations map[string]string) *unstructured.Unstructured {
+	p := &unstructured.Unstructured{}
+	p.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	p.SetName(name)
+	p.SetNamespace(namespace)
+	p.SetLabels(map[string]string{"team": "platform"})
+	p.SetAnnotations(annotations)
+	_ = unstructured.SetNestedMap(p.Object, map[string]any{
+		"group": "gateway.networking.k8s.io",
+		"kind":  "HTTPRoute",
+		"name":  httpRouteName,
+	}, "spec", "targetRef")
+	_ = unstructured.SetNestedField(p.Object, map[string]any{}, "spec", "limits")
+	return p
+}
+
+// TestMaaSSubscriptionReconciler_AdoptTRLP verifies that a TokenRateLimitPolicy annotated
+// for adoption is taken over instead of a generated one being created next to it, and
+// that policies without the annotation are left alone.
+func TestMaaSSubscriptionReconciler_AdoptTRLP(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName     = "llm"
+		namespace     = "default"
+		httpRouteName = "maas-" + modelName
+	)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantAdopted bool
+	}{
+		{name: "annotated for adoption", annotations: map[string]string{AdoptAnnotation: "true"}, wantAdopted: true},
+		{name: "not annotated", wantAdopted: false},
+		{name: "annotated but opted out", annotations: map[string]string{AdoptAnnotation: "true", ManagedByODHOperator: "false"}, wantAdopted: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testRESTMapper()).
+				WithObjects(
+					newMaaSModelRef(modelName, namespace, "ExternalModel", modelName),
+					newHTTPRoute(httpRouteName, namespace),
+					newUserTRLP("brownfield-limits", namespace, httpRouteName, tc.annotations),
+					newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100),
+				).
+				WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+				WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+				Build()
+			r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+
+			user := &unstructured.Unstructured{}
+			user.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+			if err := c.Get(ctx, client.ObjectKey{Name: "brownfield-limits", Namespace: namespace}, user); err != nil {
+				t.Fatalf("Get user TokenRateLimitPolicy: %v", err)
+			}
+			generated := &unstructured.Unstructured{}
+			generated.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+			genErr := c.Get(ctx, client.ObjectKey{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}, generated)
+			limits, _, _ := unstructured.NestedMap(user.Object, "spec", "limits")
+			_, hasGeneratedLimit := limits[subscriptionLimitKey(namespace, "sub-a", modelName, "tokens")]
+
+			if !tc.wantAdopted {
+				if user.GetLabels()["app.kubernetes.io/managed-by"] == "maas-controller" || hasGeneratedLimit {
+					t.Errorf("expected the policy to be left alone, got labels %v and limits %v", user.GetLabels(), limits)
+				}
+				if tc.annotations[ManagedByODHOperator] != "false" && genErr != nil {
+					t.Errorf("expected the generated TokenRateLimitPolicy next to an unannotated policy: %v", genErr)
+				}
+				return
+			}
+			if !apierrors.IsNotFound(genErr) {
+				t.Errorf("expected no generated TokenRateLimitPolicy next to the adopted one, got %v", genErr)
+			}
+			labels := user.GetLabels()
+			if labels["app.kubernetes.io/managed-by"] != "maas-controller" || labels["maas.opendatahub.io/model"] != modelName || labels["team"] != "platform" {
+				t.Errorf("unexpected labels on adopted policy: %v", labels)
+			}
+			if len(user.GetOwnerReferences()) != 1 || user.GetOwnerReferences()[0].Name != httpRouteName {
+				t.Errorf("expected the HTTPRoute to own the adopted policy, got %+v", user.GetOwnerReferences())
+			}
+			if !hasGeneratedLimit {
+				t.Errorf("expected the adopted policy to carry the subscription's limits, got %v", limits)
+			}
+
+			got := &maasv1alpha1.MaaSSubscription{}
+			if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+				t.Fatalf("Get MaaSSubscription: %v", err)
+			}
+			if len(got.Status.TokenRateLimitStatuses) == 0 || got.Status.TokenRateLimitStatuses[0].Name != "brownfield-limits" {
+				t.Errorf("expected status to report the adopted policy, got %+v", got.Status.TokenRateLimitStatuses)
+			}
+
+			// The adopted policy is deleted with the model's last subscription.
+			if err := c.Delete(ctx, got); err != nil {
+				t.Fatalf("Delete MaaSSubscription: %v", err)
+			}
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			if err := c.Get(ctx, client.ObjectKey{Name: "brownfield-limits", Namespace: namespace}, user); !apierrors.IsNotFound(err) {
+				t.Errorf("expected the adopted policy to be deleted with the last subscription, got %v", err)
+			}
+		})
+	}
+}
@@ -175,7 +175,7 @@ func (r *AITenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&maasv1alpha1.AITenant{}, builder.WithPredicates(
 			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.Funcs{UpdateFunc: deletionTimestampSet}),
 		)).
-		Complete(r)
+		Complete(observeReconcile("AITenant", r))
 }
 
 func (r *AITenantReconciler) validateAITenantPlacement(aitenant *maasv1alpha1.AITenant) error {
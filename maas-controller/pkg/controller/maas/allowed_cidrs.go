@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// allowedCIDRsRule is a list of allowed source networks of a MaaSAuthPolicy, from its
+// spec.allowedCIDRs or from one of its modelRefs, with the models, as "namespace/name",
+// it applies to.
+type allowedCIDRsRule struct {
+	Name   string
+	Models []string
+	CIDRs  []*net.IPNet
+}
+
+// parseAllowedCIDRs parses IPv4 CIDRs, masking host bits.
+func parseAllowedCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(cidrs))
+	for i, c := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: invalid CIDR %q", i, c)
+		}
+		if ip.To4() == nil {
+			return nil, fmt.Errorf("[%d]: CIDR %q is not IPv4", i, c)
+		}
+		out = append(out, ipNet)
+	}
+	return out, nil
+}
+
+// ValidateAllowedCIDRs checks the parts of allowedCIDRs the CRD schema cannot.
+func ValidateAllowedCIDRs(cidrs []string) error {
+	if _, err := parseAllowedCIDRs(cidrs); err != nil {
+		return fmt.Errorf("allowedCIDRs%w", err)
+	}
+	return nil
+}
+
+// allowedCIDRsRuleName is the gateway AuthPolicy rule of the allowedCIDRs of a
+// MaaSAuthPolicy, or of one of its modelRefs when model is set.
+func allowedCIDRsRuleName(policyName, model string) string {
+	if model == "" {
+		return "allowed-cidrs-" + policyName
+	}
+	return "allowed-cidrs-" + policyName + "-" + strings.ReplaceAll(model, "/", "-")
+}
+
+// aggregateAllowedCIDRs returns the allowed source networks of the policies and of their
+// modelRefs, sorted by rule name.
+func aggregateAllowedCIDRs(policies []maasv1alpha1.MaaSAuthPolicy) ([]allowedCIDRsRule, error) {
+	var out []allowedCIDRsRule
+	for _, p := range policies {
+		if !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		invalid := func(err error) error {
+			return fmt.Errorf("invalid MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+		}
+		if len(p.Spec.AllowedCIDRs) > 0 {
+			cidrs, err := parseAllowedCIDRs(p.Spec.AllowedCIDRs)
+			if err != nil {
+				return nil, invalid(fmt.Errorf("allowedCIDRs%w", err))
+			}
+			entry := allowedCIDRsRule{Name: allowedCIDRsRuleName(p.Name, ""), CIDRs: cidrs}
+			for _, ref := range p.Spec.ModelRefs {
+				entry.Models = append(entry.Models, ref.Namespace+"/"+ref.Name)
+			}
+			entry.Models = deduplicateAndSort(entry.Models)
+			out = append(out, entry)
+		}
+		for i, ref := range p.Spec.ModelRefs {
+			if len(ref.AllowedCIDRs) == 0 {
+				continue
+			}
+			cidrs, err := parseAllowedCIDRs(ref.AllowedCIDRs)
+			if err != nil {
+				return nil, invalid(fmt.Errorf("modelRefs[%d].allowedCIDRs%w", i, err))
+			}
+			model := ref.Namespace + "/" + ref.Name
+			out = append(out, allowedCIDRsRule{Name: allowedCIDRsRuleName(p.Name, model), Models: []string{model}, CIDRs: cidrs})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// ipv4CIDRRegexp matches the dotted-quad IPv4 addresses of the network, and their
+// IPv4-mapped IPv6 form, for CEL's matches(). Octets the prefix covers are literal, the
+// octet it splits is an alternation of its values and the others match any number.
+func ipv4CIDRRegexp(n *net.IPNet) string {
+	ip := n.IP.To4()
+	ones, _ := n.Mask.Size()
+	octets := make([]string, 4)
+	for i := range octets {
+		bits := ones - 8*i
+		switch {
+		case bits >= 8:
+			octets[i] = strconv.Itoa(int(ip[i]))
+		case bits <= 0:
+			octets[i] = `\d{1,3}`
+		default:
+			lo := int(ip[i])
+			values := make([]string, 0, 1<<(8-bits))
+			for v := lo; v < lo+1<<(8-bits); v++ {
+				values = append(values, strconv.Itoa(v))
+			}
+			octets[i] = "(" + strings.Join(values, "|") + ")"
+		}
+	}
+	return `^(::ffff:)?` + strings.Join(octets, `\.`) + `$`
+}
+
+// celSourceInCIDRs is true when the source address of the request is in one of the networks.
+func celSourceInCIDRs(cidrs []*net.IPNet) string {
+	parts := make([]string, 0, len(cidrs))
+	for _, n := range cidrs {
+		parts = append(parts, "source.address.matches("+strconv.Quote(ipv4CIDRRegexp(n))+")")
+	}
+	return "(" + strings.Join(parts, " || ") + ")"
+}
+
+// addAllowedCIDRsRules adds an authorization rule per allowed source network list that
+// refuses the requests to its models from other addresses, for every credential.
+func addAllowedCIDRsRules(authorization map[string]kuadrantv1.AuthorizationRule, rules []allowedCIDRsRule) {
+	for _, r := range rules {
+		authorization[r.Name] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celRequestedModelIn(r.Models) + ` && !` + celSourceInCIDRs(r.CIDRs),
+				}},
+			},
+			// The rule only applies to requests from other addresses, where it never matches.
+			PatternMatching: &kuadrantv1.PatternMatchingAuthz{
+				Patterns: []kuadrantv1.Pattern{{
+					Selector: "context.request.http.method",
+					Operator: "eq",
+					Value:    "__source_not_allowed__",
+				}},
+			},
+		}
+	}
+}
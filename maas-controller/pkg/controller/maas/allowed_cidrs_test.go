@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestIPv4CIDRRegexp(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		want    string
+		match   []string
+		noMatch []string
+	}{
+		{
+			cidr:    "192.168.10.0/24",
+			want:    `^(::ffff:)?192\.168\.10\.\d{1,3}$`,
+			match:   []string{"192.168.10.1", "::ffff:192.168.10.254"},
+			noMatch: []string{"192.168.11.1", "192.168.100.1", "1192.168.10.1"},
+		},
+		{
+			cidr:    "10.0.0.0/14",
+			match:   []string{"10.0.0.1", "10.3.255.255"},
+			noMatch: []string{"10.4.0.1", "10.30.0.1"},
+		},
+		{
+			cidr:    "172.16.5.9/32",
+			want:    `^(::ffff:)?172\.16\.5\.9$`,
+			match:   []string{"172.16.5.9"},
+			noMatch: []string{"172.16.5.90"},
+		},
+		{
+			cidr:    "198.51.100.77/28",
+			match:   []string{"198.51.100.64", "198.51.100.79"},
+			noMatch: []string{"198.51.100.80", "198.51.100.6"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			cidrs, err := parseAllowedCIDRs([]string{tt.cidr})
+			if err != nil {
+				t.Fatalf("parseAllowedCIDRs: %v", err)
+			}
+			got := ipv4CIDRRegexp(cidrs[0])
+			if tt.want != "" && got != tt.want {
+				t.Errorf("regexp = %s, want %s", got, tt.want)
+			}
+			re := regexp.MustCompile(got)
+			for _, addr := range tt.match {
+				if !re.MatchString(addr) {
+					t.Errorf("%s should match %s", got, addr)
+				}
+			}
+			for _, addr := range tt.noMatch {
+				if re.MatchString(addr) {
+					t.Errorf("%s should not match %s", got, addr)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAllowedCIDRs(t *testing.T) {
+	if err := ValidateAllowedCIDRs([]string{"10.0.0.0/8", "192.168.1.7/24"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, cidr := range []string{"10.0.0.0/33", "300.0.0.0/8", "fd00::/8", "10.0.0.1"} {
+		if err := ValidateAllowedCIDRs([]string{cidr}); err == nil {
+			t.Errorf("expected %q to be rejected", cidr)
+		}
+	}
+}
+
+func TestAggregateAllowedCIDRs(t *testing.T) {
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	sensitive := maasv1alpha1.ModelRef{Name: "sensitive", Namespace: "default", AllowedCIDRs: []string{"10.1.0.0/16"}}
+	office := newMaaSAuthPolicy("office", "default", "team-a", llm, sensitive)
+	office.Spec.AllowedCIDRs = []string{"10.0.0.0/8"}
+	plain := newMaaSAuthPolicy("plain", "default", "team-b", llm)
+
+	got, err := aggregateAllowedCIDRs([]maasv1alpha1.MaaSAuthPolicy{*plain, *office})
+	if err != nil {
+		t.Fatalf("aggregateAllowedCIDRs: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %+v, want the policy rule and the sensitive model rule", got)
+	}
+	if got[0].Name != "allowed-cidrs-office" || strings.Join(got[0].Models, ",") != "default/llm,default/sensitive" || got[0].CIDRs[0].String() != "10.0.0.0/8" {
+		t.Errorf("policy rule = %+v, want 10.0.0.0/8 for both models", got[0])
+	}
+	if got[1].Name != "allowed-cidrs-office-default-sensitive" || strings.Join(got[1].Models, ",") != "default/sensitive" || got[1].CIDRs[0].String() != "10.1.0.0/16" {
+		t.Errorf("model rule = %+v, want 10.1.0.0/16 for default/sensitive", got[1])
+	}
+
+	office.Spec.ModelRefs[1].AllowedCIDRs = []string{"fd00::/8"}
+	if _, err := aggregateAllowedCIDRs([]maasv1alpha1.MaaSAuthPolicy{*office}); err == nil || !strings.Contains(err.Error(), "modelRefs[1].allowedCIDRs") {
+		t.Errorf("err = %v, want the IPv6 modelRef CIDR rejected", err)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_AllowedCIDRs(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	_, office, _ := net.ParseCIDR("10.0.0.0/8")
+	authz := gatewayAuthorization{AllowedCIDRs: []allowedCIDRsRule{{Name: "allowed-cidrs-office", Models: []string{"llm/granite"}, CIDRs: []*net.IPNet{office}}}}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, authz, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+
+	rule, ok := spec.Defaults.Rules.Authorization["allowed-cidrs-office"]
+	if !ok || rule.PatternMatching == nil {
+		t.Fatalf("allowed-cidrs-office rule missing: %+v", spec.Defaults.Rules.Authorization)
+	}
+	want := celRequestedModelIn([]string{"llm/granite"}) + ` && !(source.address.matches("^(::ffff:)?10\\.\\d{1,3}\\.\\d{1,3}\\.\\d{1,3}$"))`
+	if len(rule.When) != 1 || rule.When[0].Predicate != want {
+		t.Errorf("when = %+v, want the rule to apply to granite from outside 10.0.0.0/8", rule.When)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// policyAPIKeysEnabled reports whether a MaaSAuthPolicy accepts API keys, which it does
+// unless spec.authentication.apiKey.enabled is false.
+func policyAPIKeysEnabled(p *maasv1alpha1.MaaSAuthPolicy) bool {
+	if p.Spec.Authentication == nil || p.Spec.Authentication.APIKey == nil || p.Spec.Authentication.APIKey.Enabled == nil {
+		return true
+	}
+	return *p.Spec.Authentication.APIKey.Enabled
+}
+
+// apiKeyIdentityCacheKeySuffix extends the cache key of the model access check with
+// whether the request carries an API key when a policy disables them, so that an API key
+// does not reuse the decision cached for a token of the same username and groups.
+func apiKeyIdentityCacheKeySuffix(restricted bool, celIsAPIKey string) string {
+	if !restricted {
+		return ""
+	}
+	return ` + "|" + ((` + celIsAPIKey + `) ? "api-key" : "")`
+}
+
+// apiKeyIdentityRego only accepts API key identities for the models of the
+// MaaSAuthPolicies that do not disable spec.authentication.apiKey.
+const apiKeyIdentityRego = `api_key_identity {
+	object.get(input.auth, "metadata", {}).apiKeyValidation
+}
+
+api_key_allowed {
+	not api_key_identity
+}
+
+api_key_allowed {
+	not model_rules.apiKeysDisabled
+}`
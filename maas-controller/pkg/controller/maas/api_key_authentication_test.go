@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// newAPIKeyAuthPolicy returns a MaaSAuthPolicy for a model with API keys enabled or disabled.
+func newAPIKeyAuthPolicy(name, model string, enabled bool) maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-"+name, maasv1alpha1.ModelRef{Name: model, Namespace: "default"})
+	p.Spec.Authentication = &maasv1alpha1.AuthenticationSpec{APIKey: &maasv1alpha1.APIKeyAuthentication{Enabled: ptr.To(enabled)}}
+	return *p
+}
+
+func TestAggregateSubjectAllowlists_APIKeys(t *testing.T) {
+	aggregate, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{
+		newAPIKeyAuthPolicy("tokens-only", "internal", false),
+		newAPIKeyAuthPolicy("shared-off", "shared", false),
+		*newMaaSAuthPolicy("shared-on", "default", "team-b", maasv1alpha1.ModelRef{Name: "shared", Namespace: "default"}),
+	})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists: %v", err)
+	}
+	if !aggregate["default/internal"].APIKeysDisabled {
+		t.Error("expected API keys to be disabled for a model whose only policy disables them")
+	}
+	if aggregate["default/shared"].APIKeysDisabled {
+		t.Error("expected API keys to be accepted for a model of a policy that does not disable them")
+	}
+}
+
+func TestAggregateGatewayAuthentication_APIKeys(t *testing.T) {
+	authn, err := aggregateGatewayAuthentication([]maasv1alpha1.MaaSAuthPolicy{
+		newAPIKeyAuthPolicy("a", "internal", false),
+		newAPIKeyAuthPolicy("b", "llm", true),
+	})
+	if err != nil {
+		t.Fatalf("aggregateGatewayAuthentication: %v", err)
+	}
+	if !authn.APIKeysRestricted || authn.APIKeysDisabled {
+		t.Errorf("APIKeysRestricted=%v APIKeysDisabled=%v, want restricted only", authn.APIKeysRestricted, authn.APIKeysDisabled)
+	}
+
+	authn, err = aggregateGatewayAuthentication([]maasv1alpha1.MaaSAuthPolicy{newAPIKeyAuthPolicy("a", "internal", false)})
+	if err != nil {
+		t.Fatalf("aggregateGatewayAuthentication: %v", err)
+	}
+	if !authn.APIKeysDisabled {
+		t.Error("expected API keys to be disabled when no policy accepts them")
+	}
+}
+
+// TestBuildGatewayAuthPolicySpec_APIKeysDisabled verifies that the api-keys rules and the
+// maas-api validation callout are left out when no policy accepts API keys, and that the
+// model access check tells API keys apart when some policies do not accept them.
+func TestBuildGatewayAuthPolicySpec_APIKeysDisabled(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{
+		MaaSAPINamespace: "maas-system",
+		ClusterAudience:  "https://kubernetes.default.svc",
+		MetadataCacheTTL: 60,
+		AuthzCacheTTL:    60,
+	}
+
+	authn := gatewayAuthentication{APIKeysRestricted: true}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, authn, identityHeaderModels{}, gatewayAuthorization{}, true, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+	nestedStringRequired(t, obj, "spec", "defaults", "rules", "metadata", "apiKeyValidation", "http", "url")
+	rego := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "opa", "rego")
+	if !strings.Contains(rego, "not model_rules.apiKeysDisabled") {
+		t.Errorf("expected the access check to refuse API keys for models disabling them, got rego:\n%s", rego)
+	}
+	key := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "cache", "key", "selector")
+	if !strings.Contains(key, `"api-key"`) {
+		t.Errorf("expected the access check cache key to tell API keys apart, got %s", key)
+	}
+
+	authn = gatewayAuthentication{APIKeysRestricted: true, APIKeysDisabled: true}
+	spec = r.buildGatewayAuthPolicySpec("{}", nil, authn, identityHeaderModels{}, gatewayAuthorization{}, true, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	obj = &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+	for _, rule := range []string{"api-keys", "api-keys-x-api-key"} {
+		if _, found, _ := unstructured.NestedMap(obj.Object, "spec", "defaults", "rules", "authentication", rule); found {
+			t.Errorf("expected no %s authentication rule when no policy accepts API keys", rule)
+		}
+	}
+	if _, found, _ := unstructured.NestedMap(obj.Object, "spec", "defaults", "rules", "metadata", "apiKeyValidation"); found {
+		t.Error("expected no apiKeyValidation callout when no policy accepts API keys")
+	}
+}
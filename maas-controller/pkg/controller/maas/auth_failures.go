@@ -0,0 +1,324 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// DefaultAuthFailureCollectionTimeout bounds the scrape of a single gateway pod.
+	DefaultAuthFailureCollectionTimeout = 5 * time.Second
+
+	// gatewayMetricsPort and gatewayMetricsPath serve the Prometheus metrics of the Envoy
+	// proxy of an Istio gateway pod.
+	gatewayMetricsPort = 15090
+	gatewayMetricsPath = "/stats/prometheus"
+
+	// gatewayRequestsMetric counts the requests proxied by the gateway, labeled with the
+	// destination Service and the response code.
+	gatewayRequestsMetric = "istio_requests_total"
+)
+
+// modelAuthFailuresTotal counts the requests to a model the gateway refused with 401 or 403.
+var modelAuthFailuresTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "maas_controller_model_auth_failures_total",
+		Help: "Number of requests to a model refused by the gateway with 401 or 403, labeled with the model's MaaSAuthPolicies.",
+	},
+	[]string{"namespace", "model", "code", "auth_policies"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(modelAuthFailuresTotal)
+}
+
+// GatewayRequestCount is the number of requests an Envoy proxy of the gateway answered
+// with Code for the destination Service, since the proxy started.
+type GatewayRequestCount struct {
+	Pod              string
+	ServiceNamespace string
+	Service          string
+	Code             string
+	Value            float64
+}
+
+// GatewayMetricsSource reads the 401 and 403 request counts of the gateway's proxies.
+type GatewayMetricsSource interface {
+	AuthFailureCounts(ctx context.Context) ([]GatewayRequestCount, error)
+}
+
+// EnvoyGatewayMetricsSource scrapes the Envoy metrics of the gateway's pods.
+type EnvoyGatewayMetricsSource struct {
+	// Reader lists the gateway pods. An uncached reader avoids watching every pod of
+	// the gateway namespace.
+	Reader           client.Reader
+	Client           *http.Client
+	GatewayNamespace string
+	GatewayName      string
+}
+
+// NewEnvoyGatewayMetricsSource returns an EnvoyGatewayMetricsSource with the given
+// per-pod request timeout.
+func NewEnvoyGatewayMetricsSource(reader client.Reader, gatewayNamespace, gatewayName string, timeout time.Duration) *EnvoyGatewayMetricsSource {
+	if timeout <= 0 {
+		timeout = DefaultAuthFailureCollectionTimeout
+	}
+	return &EnvoyGatewayMetricsSource{
+		Reader:           reader,
+		Client:           &http.Client{Timeout: timeout},
+		GatewayNamespace: gatewayNamespace,
+		GatewayName:      gatewayName,
+	}
+}
+
+// AuthFailureCounts scrapes each running gateway pod. Pods that cannot be scraped are
+// left out; an error is returned only when none could be.
+func (s *EnvoyGatewayMetricsSource) AuthFailureCounts(ctx context.Context) ([]GatewayRequestCount, error) {
+	pods := &corev1.PodList{}
+	if err := s.Reader.List(ctx, pods, client.InNamespace(s.GatewayNamespace),
+		client.MatchingLabels{"gateway.networking.k8s.io/gateway-name": s.GatewayName}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of Gateway %s/%s: %w", s.GatewayNamespace, s.GatewayName, err)
+	}
+	var out []GatewayRequestCount
+	var failures []error
+	scraped := 0
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		counts, err := s.scrape(ctx, &pod)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("pod %s: %w", pod.Name, err))
+			continue
+		}
+		scraped++
+		out = append(out, counts...)
+	}
+	if scraped == 0 && len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+	return out, nil
+}
+
+func (s *EnvoyGatewayMetricsSource) scrape(ctx context.Context, pod *corev1.Pod) ([]GatewayRequestCount, error) {
+	target := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(gatewayMetricsPort)) + gatewayMetricsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil, fmt.Errorf("GET %s returned HTTP %d", target, resp.StatusCode)
+	}
+	return parseGatewayAuthFailureCounts(pod.Name, io.LimitReader(resp.Body, 64<<20))
+}
+
+// parseGatewayAuthFailureCounts sums the 401 and 403 samples of istio_requests_total in
+// the Prometheus text exposition of a gateway pod per destination Service and code.
+func parseGatewayAuthFailureCounts(pod string, in io.Reader) ([]GatewayRequestCount, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gateway metrics: %w", err)
+	}
+	family, ok := families[gatewayRequestsMetric]
+	if !ok {
+		return nil, nil
+	}
+	sums := map[GatewayRequestCount]float64{}
+	for _, m := range family.GetMetric() {
+		key := GatewayRequestCount{Pod: pod}
+		for _, label := range m.GetLabel() {
+			switch label.GetName() {
+			case "destination_service_namespace":
+				key.ServiceNamespace = label.GetValue()
+			case "destination_service_name":
+				key.Service = label.GetValue()
+			case "response_code":
+				key.Code = label.GetValue()
+			}
+		}
+		if key.Code != "401" && key.Code != "403" || key.Service == "" || key.Service == "unknown" {
+			continue
+		}
+		sums[key] += m.GetCounter().GetValue()
+	}
+	out := make([]GatewayRequestCount, 0, len(sums))
+	for key, value := range sums {
+		key.Value = value
+		out = append(out, key)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.ServiceNamespace+"/"+a.Service != b.ServiceNamespace+"/"+b.Service {
+			return a.ServiceNamespace+"/"+a.Service < b.ServiceNamespace+"/"+b.Service
+		}
+		return a.Code < b.Code
+	})
+	return out, nil
+}
+
+// AuthFailureCollector periodically adds the gateway's 401 and 403 responses to
+// maas_controller_model_auth_failures_total, attributed to the models behind the
+// destination Service. Only the leader collects, so that the counts are not exported twice.
+type AuthFailureCollector struct {
+	Client   client.Reader
+	Source   GatewayMetricsSource
+	Interval time.Duration
+
+	// previous holds the last count of each pod, Service and code, without its Value.
+	previous map[GatewayRequestCount]float64
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (c *AuthFailureCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start collects every Interval until ctx is done.
+func (c *AuthFailureCollector) Start(ctx context.Context) error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("auth failure collection interval must be positive, got %v", c.Interval)
+	}
+	log := ctrl.Log.WithName("auth-failure-collector")
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		if err := c.collect(ctx); err != nil {
+			log.Error(err, "failed to collect gateway auth failures")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect adds the counts since the previous collection. A count seen for the first
+// time only sets the baseline, so a controller restart does not re-export the history
+// of the gateway; a count lower than before means the proxy restarted.
+func (c *AuthFailureCollector) collect(ctx context.Context) error {
+	counts, err := c.Source.AuthFailureCounts(ctx)
+	if err != nil {
+		return err
+	}
+	modelsByService, err := c.modelsByService(ctx)
+	if err != nil {
+		return err
+	}
+	policiesByModel, err := c.policiesByModel(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[GatewayRequestCount]float64, len(counts))
+	for _, count := range counts {
+		value := count.Value
+		count.Value = 0
+		current[count] = value
+		previous, seen := c.previous[count]
+		if !seen {
+			continue
+		}
+		delta := value - previous
+		if delta < 0 {
+			delta = value
+		}
+		if delta == 0 {
+			continue
+		}
+		for _, m := range modelsByService[count.ServiceNamespace+"/"+count.Service] {
+			modelAuthFailuresTotal.WithLabelValues(m.Namespace, m.Name, count.Code, strings.Join(policiesByModel[m.Namespace+"/"+m.Name], ",")).Add(delta)
+		}
+	}
+	c.previous = current
+	return nil
+}
+
+// modelsByService maps the backend Services, as "namespace/name", of the models'
+// HTTPRoutes to the models. Models whose route cannot be resolved are left out.
+func (c *AuthFailureCollector) modelsByService(ctx context.Context) (map[string][]maasv1alpha1.ModelRef, error) {
+	models := &maasv1alpha1.MaaSModelRefList{}
+	if err := c.Client.List(ctx, models); err != nil {
+		return nil, fmt.Errorf("failed to list MaaSModelRefs: %w", err)
+	}
+	out := map[string][]maasv1alpha1.ModelRef{}
+	for _, m := range models.Items {
+		routeName, routeNS, err := findHTTPRouteForModel(ctx, c.Client, m.Namespace, m.Name)
+		if err != nil {
+			continue
+		}
+		route, err := getHTTPRoute(ctx, c.Client, routeName, routeNS)
+		if err != nil {
+			continue
+		}
+		for _, svc := range routeServiceBackends(route) {
+			key := routeNS + "/" + svc
+			out[key] = append(out[key], maasv1alpha1.ModelRef{Name: m.Name, Namespace: m.Namespace})
+		}
+	}
+	return out, nil
+}
+
+// policiesByModel maps the models, as "namespace/name", to the sorted
+// "namespace/name" of the MaaSAuthPolicies referencing them.
+func (c *AuthFailureCollector) policiesByModel(ctx context.Context) (map[string][]string, error) {
+	policies := &maasv1alpha1.MaaSAuthPolicyList{}
+	if err := c.Client.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list MaaSAuthPolicies: %w", err)
+	}
+	out := map[string][]string{}
+	for _, p := range policies.Items {
+		if !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		for _, ref := range p.Spec.ModelRefs {
+			key := ref.Namespace + "/" + ref.Name
+			out[key] = append(out[key], p.Namespace+"/"+p.Name)
+		}
+	}
+	for key, names := range out {
+		out[key] = deduplicateAndSort(names)
+	}
+	return out, nil
+}
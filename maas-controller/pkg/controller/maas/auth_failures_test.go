@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const gatewayMetricsSample = `# TYPE istio_requests_total counter
+istio_requests_total{reporter="source",destination_service_name="llm",destination_service_namespace="default",response_code="200",response_flags="-"} 120
+istio_requests_total{reporter="source",destination_service_name="llm",destination_service_namespace="default",response_code="401",response_flags="-"} 7
+istio_requests_total{reporter="source",destination_service_name="llm",destination_service_namespace="default",response_code="401",response_flags="UAEX"} 3
+istio_requests_total{reporter="source",destination_service_name="llm",destination_service_namespace="default",response_code="403",response_flags="UAEX"} 2
+istio_requests_total{reporter="source",destination_service_name="unknown",destination_service_namespace="unknown",response_code="401",response_flags="NR"} 9
+# TYPE envoy_server_uptime gauge
+envoy_server_uptime 42
+`
+
+func TestParseGatewayAuthFailureCounts(t *testing.T) {
+	got, err := parseGatewayAuthFailureCounts("gw-0", strings.NewReader(gatewayMetricsSample))
+	if err != nil {
+		t.Fatalf("parseGatewayAuthFailureCounts: %v", err)
+	}
+	want := []GatewayRequestCount{
+		{Pod: "gw-0", ServiceNamespace: "default", Service: "llm", Code: "401", Value: 10},
+		{Pod: "gw-0", ServiceNamespace: "default", Service: "llm", Code: "403", Value: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("counts = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseGatewayAuthFailureCounts("gw-0", strings.NewReader("not { metrics")); err == nil {
+		t.Error("expected malformed metrics to be rejected")
+	}
+}
+
+type fakeGatewayMetricsSource struct {
+	counts []GatewayRequestCount
+}
+
+func (s *fakeGatewayMetricsSource) AuthFailureCounts(context.Context) ([]GatewayRequestCount, error) {
+	return s.counts, nil
+}
+
+// TestAuthFailureCollector verifies that the 401 and 403 responses of a model's backend
+// Service are added to the model's counter, labeled with its MaaSAuthPolicies, once a
+// baseline has been taken.
+func TestAuthFailureCollector(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("llm", "default", "ExternalModel", "llm")
+	route := newHTTPRoute("maas-llm", "default")
+	route.Spec.Rules = []gatewayapiv1.HTTPRouteRule{{BackendRefs: []gatewayapiv1.HTTPBackendRef{
+		{BackendRef: gatewayapiv1.BackendRef{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "llm-backend"}}},
+	}}}
+	ref := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route,
+			newMaaSAuthPolicy("team-b", "models-as-a-service", "team-b", ref),
+			newMaaSAuthPolicy("team-a", "models-as-a-service", "team-a", ref)).
+		Build()
+
+	source := &fakeGatewayMetricsSource{}
+	collector := &AuthFailureCollector{Client: c, Source: source}
+	unauthorized := modelAuthFailuresTotal.WithLabelValues("default", "llm", "401", "models-as-a-service/team-a,models-as-a-service/team-b")
+	forbidden := modelAuthFailuresTotal.WithLabelValues("default", "llm", "403", "models-as-a-service/team-a,models-as-a-service/team-b")
+	before401, before403 := testutil.ToFloat64(unauthorized), testutil.ToFloat64(forbidden)
+
+	collect := func(code401, code403 float64) {
+		t.Helper()
+		source.counts = []GatewayRequestCount{
+			{Pod: "gw-0", ServiceNamespace: "default", Service: "llm-backend", Code: "401", Value: code401},
+			{Pod: "gw-0", ServiceNamespace: "default", Service: "llm-backend", Code: "403", Value: code403},
+			{Pod: "gw-0", ServiceNamespace: "default", Service: "other", Code: "401", Value: 1000},
+		}
+		if err := collector.collect(ctx); err != nil {
+			t.Fatalf("collect: %v", err)
+		}
+	}
+
+	collect(50, 5)
+	if got := testutil.ToFloat64(unauthorized) - before401; got != 0 {
+		t.Errorf("the first collection should only set the baseline, got %v", got)
+	}
+
+	collect(60, 7)
+	if got := testutil.ToFloat64(unauthorized) - before401; got != 10 {
+		t.Errorf("401 count = %v, want 10", got)
+	}
+	if got := testutil.ToFloat64(forbidden) - before403; got != 2 {
+		t.Errorf("403 count = %v, want 2", got)
+	}
+
+	// The proxy restarted: its counts start over.
+	collect(4, 7)
+	if got := testutil.ToFloat64(unauthorized) - before401; got != 14 {
+		t.Errorf("401 count after a proxy restart = %v, want 14", got)
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// authPolicyTemplateNotFoundError reports a templateRef naming a MaaSAuthPolicyTemplate
+// that does not exist.
+type authPolicyTemplateNotFoundError struct {
+	template string
+	policy   string
+}
+
+func (e *authPolicyTemplateNotFoundError) Error() string {
+	return fmt.Sprintf("MaaSAuthPolicyTemplate %s referenced by MaaSAuthPolicy %s not found; the gateway AuthPolicy is not updated until it exists",
+		e.template, e.policy)
+}
+
+// templateRule is a rule of a MaaSAuthPolicyTemplate in unstructured form, with the
+// models, as "namespace/name", of the MaaSAuthPolicies referencing the template.
+type templateRule struct {
+	template string
+	rule     map[string]any
+	models   []string
+}
+
+// scoped returns the rule with a when predicate limiting it to the requests to its
+// models, ahead of the predicates of the template.
+func (r templateRule) scoped() map[string]any {
+	rule := runtime.DeepCopyJSON(r.rule)
+	when := []any{map[string]any{"predicate": celRequestedModelIn(r.models)}}
+	if existing, ok := rule["when"].([]any); ok {
+		when = append(when, existing...)
+	}
+	rule["when"] = when
+	return rule
+}
+
+// authPolicyTemplateRules holds the rules of the MaaSAuthPolicyTemplates referenced by
+// the enforced MaaSAuthPolicies of a tenant, keyed by rule name.
+type authPolicyTemplateRules struct {
+	Authorization map[string]templateRule
+	Callbacks     map[string]templateRule
+	Headers       map[string]templateRule
+	Filters       map[string]templateRule
+}
+
+// add merges the rules of a template referenced by the policies of models. A rule name
+// defined differently by two templates is an error, since neither can be chosen over the
+// other.
+func (t *authPolicyTemplateRules) add(tmpl *maasv1alpha1.MaaSAuthPolicyTemplate, models []string) error {
+	var err error
+	if t.Authorization, err = addTemplateRules(t.Authorization, tmpl.Name, "authorization rule", tmpl.Spec.Authorization, models); err != nil {
+		return err
+	}
+	if t.Callbacks, err = addTemplateRules(t.Callbacks, tmpl.Name, "callback", tmpl.Spec.Callbacks, models); err != nil {
+		return err
+	}
+	if resp := tmpl.Spec.Response; resp != nil {
+		if t.Headers, err = addTemplateRules(t.Headers, tmpl.Name, "response header", resp.Headers, models); err != nil {
+			return err
+		}
+		if t.Filters, err = addTemplateRules(t.Filters, tmpl.Name, "response filter", resp.Filters, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addTemplateRules(dst map[string]templateRule, template, kind string, rules map[string]runtime.RawExtension, models []string) (map[string]templateRule, error) {
+	for _, name := range sortedKeys(rules) {
+		// util/json decodes integers as int64, as the API server does, so the merged
+		// spec compares equal to the stored AuthPolicy.
+		rule := map[string]any{}
+		if err := utiljson.Unmarshal(rules[name].Raw, &rule); err != nil {
+			return dst, fmt.Errorf("invalid %s %q in MaaSAuthPolicyTemplate %s: %w", kind, name, template, err)
+		}
+		if existing, ok := dst[name]; ok {
+			if !equality.Semantic.DeepEqual(existing.rule, rule) {
+				return dst, fmt.Errorf("%s %q is defined differently by MaaSAuthPolicyTemplates %s and %s", kind, name, existing.template, template)
+			}
+			existing.models = deduplicateAndSort(append(existing.models, models...))
+			dst[name] = existing
+			continue
+		}
+		if dst == nil {
+			dst = map[string]templateRule{}
+		}
+		dst[name] = templateRule{template: template, rule: rule, models: models}
+	}
+	return dst, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// apply adds the template rules to a generated gateway AuthPolicy spec, each limited to
+// the models of the policies referencing its template. The generated rules are left as
+// they are: a template rule reusing the name of one, or a template
+// header setting a header the controller sets, is an error.
+func (t authPolicyTemplateRules) apply(spec map[string]any) error {
+	if err := mergeTemplateSection(spec, t.Authorization, "authorization rule", "defaults", "rules", "authorization"); err != nil {
+		return err
+	}
+	if err := mergeTemplateSection(spec, t.Callbacks, "callback", "defaults", "rules", "callbacks"); err != nil {
+		return err
+	}
+	headersPath := []string{"defaults", "rules", "response", "success", "headers"}
+	generated, _, err := unstructured.NestedMap(spec, headersPath...)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(t.Headers) {
+		header := responseHeaderName(name, t.Headers[name].rule)
+		for generatedName, generatedHeader := range generated {
+			if strings.EqualFold(header, responseHeaderName(generatedName, generatedHeader)) {
+				return fmt.Errorf("response header %q of MaaSAuthPolicyTemplate %s sets the %s header, which the controller sets", name, t.Headers[name].template, header)
+			}
+		}
+	}
+	if err := mergeTemplateSection(spec, t.Headers, "response header", headersPath...); err != nil {
+		return err
+	}
+	return mergeTemplateSection(spec, t.Filters, "response filter", "defaults", "rules", "response", "success", "filters")
+}
+
+func mergeTemplateSection(spec map[string]any, rules map[string]templateRule, kind string, fields ...string) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	section, _, err := unstructured.NestedMap(spec, fields...)
+	if err != nil {
+		return err
+	}
+	if section == nil {
+		section = map[string]any{}
+	}
+	for _, name := range sortedKeys(rules) {
+		if _, ok := section[name]; ok {
+			return fmt.Errorf("%s %q of MaaSAuthPolicyTemplate %s conflicts with a generated %s", kind, name, rules[name].template, kind)
+		}
+		section[name] = rules[name].scoped()
+	}
+	return unstructured.SetNestedMap(spec, section, fields...)
+}
+
+// responseHeaderName returns the header a success header entry sets: its key, or the
+// entry name when it has none.
+func responseHeaderName(name string, entry any) string {
+	if m, ok := entry.(map[string]any); ok {
+		if key, ok := m["key"].(string); ok && key != "" {
+			return key
+		}
+	}
+	return name
+}
+
+// aggregateAuthPolicyTemplates merges the MaaSAuthPolicyTemplates referenced by the
+// enforced MaaSAuthPolicies in a namespace. A missing template is reported as an
+// authPolicyTemplateNotFoundError, which keeps the gateway AuthPolicy as it is rather
+// than dropping the template's rules.
+func (r *MaaSAuthPolicyReconciler) aggregateAuthPolicyTemplates(ctx context.Context, policyNamespace string) (authPolicyTemplateRules, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policyNamespace)
+	if err != nil {
+		return authPolicyTemplateRules{}, err
+	}
+	referencedBy := map[string]string{}
+	models := map[string][]string{}
+	for _, p := range policies {
+		if p.Spec.TemplateRef == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if _, ok := referencedBy[p.Spec.TemplateRef.Name]; !ok {
+			referencedBy[p.Spec.TemplateRef.Name] = p.Name
+		}
+		for _, ref := range p.Spec.ModelRefs {
+			models[p.Spec.TemplateRef.Name] = append(models[p.Spec.TemplateRef.Name], ref.Namespace+"/"+ref.Name)
+		}
+	}
+
+	var rules authPolicyTemplateRules
+	for _, name := range sortedKeys(referencedBy) {
+		tmpl := &maasv1alpha1.MaaSAuthPolicyTemplate{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: policyNamespace}, tmpl); err != nil {
+			if apierrors.IsNotFound(err) {
+				return authPolicyTemplateRules{}, &authPolicyTemplateNotFoundError{template: name, policy: referencedBy[name]}
+			}
+			return authPolicyTemplateRules{}, fmt.Errorf("failed to get MaaSAuthPolicyTemplate %s/%s: %w", policyNamespace, name, err)
+		}
+		if err := rules.add(tmpl, deduplicateAndSort(models[name])); err != nil {
+			return authPolicyTemplateRules{}, err
+		}
+	}
+	return rules, nil
+}
+
+// mapAuthPolicyTemplateToMaaSAuthPolicies returns the MaaSAuthPolicies referencing the
+// template, so that template changes reach the gateway AuthPolicy.
+func (r *MaaSAuthPolicyReconciler) mapAuthPolicyTemplateToMaaSAuthPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyList := &maasv1alpha1.MaaSAuthPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list MaaSAuthPolicy resources for MaaSAuthPolicyTemplate change",
+			"template", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range policyList.Items {
+		if ref := p.Spec.TemplateRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.Name, Namespace: p.Namespace}})
+		}
+	}
+	return requests
+}
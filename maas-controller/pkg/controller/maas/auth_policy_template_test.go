@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func rawRule(json string) runtime.RawExtension {
+	return runtime.RawExtension{Raw: []byte(json)}
+}
+
+func newMaaSAuthPolicyTemplate(name, namespace string) *maasv1alpha1.MaaSAuthPolicyTemplate {
+	return &maasv1alpha1.MaaSAuthPolicyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: maasv1alpha1.MaaSAuthPolicyTemplateSpec{
+			Authorization: map[string]runtime.RawExtension{
+				"eu-only": rawRule(`{"patternMatching":{"patterns":[{"selector":"request.headers.x-region","operator":"eq","value":"eu"}]},"priority":2}`),
+			},
+			Callbacks: map[string]runtime.RawExtension{
+				"audit": rawRule(`{"http":{"url":"http://audit.svc/events","method":"POST"}}`),
+			},
+			Response: &maasv1alpha1.AuthPolicyTemplateResponse{
+				Headers: map[string]runtime.RawExtension{
+					"x-cost-center": rawRule(`{"plain":{"value":"cc-42"}}`),
+				},
+			},
+		},
+	}
+}
+
+func generatedGatewaySpec(t *testing.T) map[string]any {
+	t.Helper()
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "maas-system", GatewayNamespace: "gateway-ns", GatewayName: "maas-default-gateway"}
+	spec, err := kuadrantv1.ToUnstructured(r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func TestAuthPolicyTemplateRulesApply(t *testing.T) {
+	models := []string{"llm/model-a"}
+	var rules authPolicyTemplateRules
+	tmpl := newMaaSAuthPolicyTemplate("audit", "models-as-a-service")
+	tmpl.Spec.Response.Filters = map[string]runtime.RawExtension{
+		"region": rawRule(`{"when":[{"predicate":"has(request.headers['x-region'])"}],"json":{"properties":{"region":{"expression":"request.headers['x-region']"}}}}`),
+	}
+	if err := rules.add(tmpl, models); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	spec := generatedGatewaySpec(t)
+	if err := rules.apply(spec); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	obj := &unstructured.Unstructured{Object: spec}
+
+	nestedMapRequired(t, obj, "defaults", "rules", "authorization", "require-group-membership")
+	euOnly := nestedMapRequired(t, obj, "defaults", "rules", "authorization", "eu-only")
+	if priority, ok := euOnly["priority"].(int64); !ok || priority != 2 {
+		t.Errorf("eu-only priority = %#v, want int64(2)", euOnly["priority"])
+	}
+	if got := nestedStringRequired(t, obj, "defaults", "rules", "callbacks", "audit", "http", "url"); got != "http://audit.svc/events" {
+		t.Errorf("audit callback url = %q", got)
+	}
+	nestedMapRequired(t, obj, "defaults", "rules", "response", "success", "headers", "x-cost-center")
+	nestedMapRequired(t, obj, "defaults", "rules", "response", "success", "headers", "X-MaaS-Username")
+
+	// Every template rule only applies to the models of the policies referencing it.
+	scope := map[string]any{"predicate": celRequestedModelIn(models)}
+	for _, path := range [][]string{
+		{"authorization", "eu-only"},
+		{"callbacks", "audit"},
+		{"response", "success", "headers", "x-cost-center"},
+	} {
+		rule := nestedMapRequired(t, obj, append([]string{"defaults", "rules"}, path...)...)
+		if !reflect.DeepEqual(rule["when"], []any{scope}) {
+			t.Errorf("%v when = %#v, want only the model predicate", path, rule["when"])
+		}
+	}
+	region := nestedMapRequired(t, obj, "defaults", "rules", "response", "success", "filters", "region")
+	if when, _ := region["when"].([]any); len(when) != 2 || !reflect.DeepEqual(when[0], scope) {
+		t.Errorf("region filter when = %#v, want the model predicate ahead of the template's", region["when"])
+	}
+
+	t.Run("rejects generated rule names", func(t *testing.T) {
+		tmpl := newMaaSAuthPolicyTemplate("override", "models-as-a-service")
+		tmpl.Spec.Authorization = map[string]runtime.RawExtension{"require-group-membership": rawRule(`{"opa":{"rego":"allow = true"}}`)}
+		var rules authPolicyTemplateRules
+		if err := rules.add(tmpl, models); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if err := rules.apply(generatedGatewaySpec(t)); err == nil || !strings.Contains(err.Error(), "require-group-membership") {
+			t.Errorf("apply error = %v, want a conflict with require-group-membership", err)
+		}
+	})
+
+	t.Run("rejects headers the controller sets", func(t *testing.T) {
+		tmpl := newMaaSAuthPolicyTemplate("spoof", "models-as-a-service")
+		tmpl.Spec.Response.Headers = map[string]runtime.RawExtension{"user": rawRule(`{"key":"x-maas-username","plain":{"value":"admin"}}`)}
+		var rules authPolicyTemplateRules
+		if err := rules.add(tmpl, models); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if err := rules.apply(generatedGatewaySpec(t)); err == nil || !strings.Contains(err.Error(), "x-maas-username") {
+			t.Errorf("apply error = %v, want the X-MaaS-Username header refused", err)
+		}
+	})
+
+	t.Run("rejects a rule defined differently by two templates", func(t *testing.T) {
+		other := newMaaSAuthPolicyTemplate("other", "models-as-a-service")
+		other.Spec.Callbacks["audit"] = rawRule(`{"http":{"url":"http://other.svc/events"}}`)
+		var rules authPolicyTemplateRules
+		if err := rules.add(newMaaSAuthPolicyTemplate("audit", "models-as-a-service"), models); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if err := rules.add(other, models); err == nil {
+			t.Error("expected a conflict for the audit callback")
+		}
+		// The same definition in two templates is fine, and applies to the models of both.
+		if err := rules.add(newMaaSAuthPolicyTemplate("copy", "models-as-a-service"), []string{"llm/model-b"}); err != nil {
+			t.Errorf("identical rules should merge: %v", err)
+		}
+		if got := rules.Callbacks["audit"].models; !reflect.DeepEqual(got, []string{"llm/model-a", "llm/model-b"}) {
+			t.Errorf("audit callback models = %v, want both templates' models", got)
+		}
+	})
+}
+
+func TestReconcile_AuthPolicyTemplate(t *testing.T) {
+	const (
+		namespace = "models-as-a-service"
+		gatewayNS = "gateway-ns"
+	)
+	ctx := context.Background()
+	model := maasv1alpha1.ModelRef{Name: "model-a", Namespace: namespace}
+
+	newReconciler := func(objs ...client.Object) (*MaaSAuthPolicyReconciler, client.Client) {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testRESTMapper()).
+			WithObjects(objs...).
+			WithStatusSubresource(&maasv1alpha1.MaaSAuthPolicy{}).
+			Build()
+		return &MaaSAuthPolicyReconciler{
+			Client:           c,
+			Scheme:           scheme,
+			MaaSAPINamespace: "maas-system",
+			GatewayNamespace: gatewayNS,
+			GatewayName:      "maas-default-gateway",
+		}, c
+	}
+	getGatewayPolicy := func(c client.Client) (*unstructured.Unstructured, error) {
+		gw := newGatewayAuthPolicy("", "")
+		return gw, c.Get(ctx, types.NamespacedName{Name: maasGatewayAuthPolicyName, Namespace: gatewayNS}, gw)
+	}
+
+	t.Run("merges the template into the gateway AuthPolicy", func(t *testing.T) {
+		policy := newMaaSAuthPolicy("team-a", namespace, "team-a", model)
+		policy.Spec.TemplateRef = &maasv1alpha1.AuthPolicyTemplateReference{Name: "audit"}
+		r, c := newReconciler(newMaaSModelRef("model-a", namespace, "ExternalModel", "model-a"), newHTTPRoute("model-a", namespace),
+			policy, newMaaSAuthPolicyTemplate("audit", namespace))
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		gw, err := getGatewayPolicy(c)
+		if err != nil {
+			t.Fatalf("get gateway AuthPolicy: %v", err)
+		}
+		euOnly := nestedMapRequired(t, gw, "spec", "defaults", "rules", "authorization", "eu-only")
+		nestedMapRequired(t, gw, "spec", "defaults", "rules", "callbacks", "audit")
+		if want := []any{map[string]any{"predicate": celRequestedModelIn([]string{namespace + "/model-a"})}}; !reflect.DeepEqual(euOnly["when"], want) {
+			t.Errorf("eu-only when = %#v, want %#v", euOnly["when"], want)
+		}
+
+		// A second reconcile leaves the merged policy as it is.
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("second Reconcile: %v", err)
+		}
+		again, err := getGatewayPolicy(c)
+		if err != nil {
+			t.Fatalf("get gateway AuthPolicy: %v", err)
+		}
+		if again.GetResourceVersion() != gw.GetResourceVersion() {
+			t.Errorf("gateway AuthPolicy updated again (resourceVersion %s -> %s)", gw.GetResourceVersion(), again.GetResourceVersion())
+		}
+	})
+
+	t.Run("missing template keeps the gateway AuthPolicy", func(t *testing.T) {
+		policy := newMaaSAuthPolicy("team-a", namespace, "team-a", model)
+		policy.Spec.TemplateRef = &maasv1alpha1.AuthPolicyTemplateReference{Name: "missing"}
+		r, c := newReconciler(policy)
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		if _, err := getGatewayPolicy(c); !apierrors.IsNotFound(err) {
+			t.Errorf("gateway AuthPolicy should not be written without the template, got err=%v", err)
+		}
+		got := &maasv1alpha1.MaaSAuthPolicy{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(policy), got); err != nil {
+			t.Fatal(err)
+		}
+		ready := apimeta.FindStatusCondition(got.Status.Conditions, "Ready")
+		if got.Status.Phase != maasv1alpha1.PhaseFailed || ready == nil || ready.Reason != string(maasv1alpha1.ReasonTemplateNotFound) {
+			t.Errorf("phase = %q, Ready = %+v, want Failed with reason TemplateNotFound", got.Status.Phase, ready)
+		}
+	})
+}
+
+func TestAggregateAuthPolicyTemplates_NotFound(t *testing.T) {
+	policy := newMaaSAuthPolicy("team-a", "default", "team-a", maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"})
+	policy.Spec.TemplateRef = &maasv1alpha1.AuthPolicyTemplateReference{Name: "gone"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy).Build()
+	r := &MaaSAuthPolicyReconciler{Client: c, Scheme: scheme}
+
+	_, err := r.aggregateAuthPolicyTemplates(context.Background(), "default")
+	var notFound *authPolicyTemplateNotFoundError
+	if !errors.As(err, &notFound) || notFound.template != "gone" || notFound.policy != "team-a" {
+		t.Errorf("err = %v, want authPolicyTemplateNotFoundError for gone referenced by team-a", err)
+	}
+}
+
+func TestMapAuthPolicyTemplateToMaaSAuthPolicies(t *testing.T) {
+	referencing := newMaaSAuthPolicy("referencing", "default", "team-a")
+	referencing.Spec.TemplateRef = &maasv1alpha1.AuthPolicyTemplateReference{Name: "audit"}
+	other := newMaaSAuthPolicy("other", "default", "team-a")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(referencing, other).Build()
+	r := &MaaSAuthPolicyReconciler{Client: c, Scheme: scheme}
+
+	requests := r.mapAuthPolicyTemplateToMaaSAuthPolicies(context.Background(), newMaaSAuthPolicyTemplate("audit", "default"))
+	if len(requests) != 1 || requests[0].Name != "referencing" {
+		t.Errorf("requests = %v, want only the referencing policy", requests)
+	}
+}
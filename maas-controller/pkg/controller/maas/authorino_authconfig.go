@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// AuthProvider selects which API the controller writes the gateway auth rules to.
+type AuthProvider string
+
+const (
+	// AuthProviderKuadrant emits a Kuadrant AuthPolicy attached to the Gateway (default).
+	AuthProviderKuadrant AuthProvider = "kuadrant"
+	// AuthProviderAuthorino emits an Authorino AuthConfig for the Gateway's hostnames,
+	// for clusters running standalone Authorino without the Kuadrant operator.
+	AuthProviderAuthorino AuthProvider = "authorino"
+)
+
+// ConditionRateLimitsEnforced is set False on MaaSSubscriptions in AuthProviderAuthorino
+// mode, where no Kuadrant TokenRateLimitPolicy or RateLimitPolicy enforces their limits.
+const ConditionRateLimitsEnforced = "RateLimitsEnforced"
+
+// authConfigGVK is the Authorino AuthConfig written in AuthProviderAuthorino mode.
+var authConfigGVK = schema.GroupVersionKind{Group: "authorino.kuadrant.io", Version: "v1beta3", Kind: "AuthConfig"}
+
+// authorinoAuthConfigs reports whether the gateway auth rules are written as an AuthConfig.
+func authorinoAuthConfigs(provider AuthProvider) bool {
+	return provider == AuthProviderAuthorino
+}
+
+// gatewayAuthGVK returns the kind of the generated gateway auth resource.
+func gatewayAuthGVK(provider AuthProvider) schema.GroupVersionKind {
+	if authorinoAuthConfigs(provider) {
+		return authConfigGVK
+	}
+	return kuadrantv1.AuthPolicyGVK
+}
+
+// gatewayAuthPolicyNameFor returns the name of the generated gateway auth resource of a
+// Gateway. The default gateway keeps the legacy name for backward compatibility; tenant
+// gateways use a name derived from the Gateway.
+func gatewayAuthPolicyNameFor(defaultNamespace, defaultName, gatewayNamespace, gatewayName string) string {
+	if gatewayNamespace != defaultNamespace || gatewayName != defaultName {
+		return fmt.Sprintf("%s-maas-auth", gatewayName)
+	}
+	return maasGatewayAuthPolicyName
+}
+
+// gatewayHosts returns the hostnames Authorino matches the AuthConfig of a Gateway
+// against: those of its listeners, or "*" when a listener accepts any hostname.
+func gatewayHosts(gateway *gatewayapiv1.Gateway) []string {
+	var hosts []string
+	for _, l := range gateway.Spec.Listeners {
+		if l.Hostname == nil || *l.Hostname == "" {
+			return []string{"*"}
+		}
+		hosts = append(hosts, string(*l.Hostname))
+	}
+	if len(hosts) == 0 {
+		return []string{"*"}
+	}
+	return deduplicateAndSort(hosts)
+}
+
+// authConfigSpec converts the spec of a gateway AuthPolicy to the spec of the equivalent
+// AuthConfig. The AuthConfig holds the rules of the AuthPolicy defaults at its top level,
+// with the hosts Kuadrant would otherwise derive from the target Gateway.
+func authConfigSpec(authPolicySpec map[string]any, hosts []string) (map[string]any, error) {
+	defaults, _, err := unstructured.NestedMap(authPolicySpec, "defaults")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AuthPolicy defaults: %w", err)
+	}
+	hostList := make([]any, 0, len(hosts))
+	for _, h := range hosts {
+		hostList = append(hostList, h)
+	}
+	spec := map[string]any{"hosts": hostList}
+	if when, ok := defaults["when"]; ok {
+		spec["when"] = when
+	}
+	rules, _, err := unstructured.NestedMap(defaults, "rules")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AuthPolicy rules: %w", err)
+	}
+	for _, section := range []string{"authentication", "metadata", "authorization", "response", "callbacks"} {
+		if v, ok := rules[section]; ok {
+			spec[section] = v
+		}
+	}
+	return spec, nil
+}
+
+// gatewayAuthConfigSpec converts the spec of the gateway AuthPolicy of a Gateway to an
+// AuthConfig spec. A Gateway that does not exist matches any host; the caller does not
+// apply the AuthConfig of a missing tenant Gateway.
+func (r *MaaSAuthPolicyReconciler) gatewayAuthConfigSpec(ctx context.Context, authPolicySpec map[string]any, gatewayNamespace, gatewayName string) (map[string]any, error) {
+	hosts := []string{"*"}
+	gateway := &gatewayapiv1.Gateway{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: gatewayNamespace, Name: gatewayName}, gateway)
+	switch {
+	case err == nil:
+		hosts = gatewayHosts(gateway)
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("failed to get Gateway %s/%s for the AuthConfig hosts: %w", gatewayNamespace, gatewayName, err)
+	}
+	return authConfigSpec(authPolicySpec, hosts)
+}
+
+// getAuthConfigReadyState checks if Authorino has linked the AuthConfig to all of its hosts.
+func getAuthConfigReadyState(authConfig *unstructured.Unstructured) (ready bool, reason maasv1alpha1.ConditionReason, message string) {
+	conditions, found, err := unstructured.NestedSlice(authConfig.Object, "status", "conditions")
+	if err != nil || !found || len(conditions) == 0 {
+		return false, maasv1alpha1.ReasonConditionsNotFound, "status conditions not available"
+	}
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		if cond["status"] == "True" {
+			return true, maasv1alpha1.ReasonAcceptedEnforced, ""
+		}
+		msg, _ := cond["message"].(string)
+		if msg == "" {
+			msg, _ = cond["reason"].(string)
+		}
+		return false, maasv1alpha1.ReasonNotEnforced, msg
+	}
+	return false, maasv1alpha1.ReasonConditionsNotFound, "Ready condition not available"
+}
+
+// findEnforcedAuthConfig looks for the ready gateway AuthConfig of the Gateway the model's
+// route is attached to.
+func (r *MaaSModelRefReconciler) findEnforcedAuthConfig(ctx context.Context, model *maasv1alpha1.MaaSModelRef) (found string, reason string, err error) {
+	gwName, gwNS := model.Status.HTTPRouteGatewayName, model.Status.HTTPRouteGatewayNamespace
+	if gwName == "" {
+		return "", "model route is not attached to a Gateway yet", nil
+	}
+	name := gatewayAuthPolicyNameFor(r.gatewayNamespace(), r.gatewayName(), gwNS, gwName)
+	authConfig := &unstructured.Unstructured{}
+	authConfig.SetGroupVersionKind(authConfigGVK)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: gwNS, Name: name}, authConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Sprintf("no AuthConfig %s/%s for Gateway %s/%s", gwNS, name, gwNS, gwName), nil
+		}
+		return "", "", fmt.Errorf("failed to get AuthConfig %s/%s: %w", gwNS, name, err)
+	}
+	ready, acReason, msg := getAuthConfigReadyState(authConfig)
+	if ready {
+		return gwNS + "/" + name, "", nil
+	}
+	reason = fmt.Sprintf("AuthConfig %s/%s is not ready (%s)", gwNS, name, acReason)
+	if msg != "" {
+		reason += ": " + msg
+	}
+	return "", reason, nil
+}
+
+// setRateLimitsEnforcedCondition sets RateLimitsEnforced=False while the controller runs
+// with standalone Authorino and removes it otherwise.
+func setRateLimitsEnforcedCondition(conditions *[]metav1.Condition, generation int64, provider AuthProvider) {
+	if !authorinoAuthConfigs(provider) {
+		apimeta.RemoveStatusCondition(conditions, ConditionRateLimitsEnforced)
+		return
+	}
+	apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionRateLimitsEnforced,
+		Status:             metav1.ConditionFalse,
+		Reason:             "AuthProviderAuthorino",
+		Message:            "rate limits, spec.suspended and limit groups are not enforced: with --auth-provider=authorino no Kuadrant TokenRateLimitPolicy or RateLimitPolicy is generated",
+		ObservedGeneration: generation,
+	})
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+func TestGatewayHosts(t *testing.T) {
+	gateway := newGatewayWithHostname("maas-default-gateway", "gateway-ns", "maas.example.com")
+	other := gatewayapiv1.Hostname("api.example.com")
+	gateway.Spec.Listeners = append(gateway.Spec.Listeners,
+		gatewayapiv1.Listener{Name: "api", Hostname: &other},
+		gatewayapiv1.Listener{Name: "https-2", Hostname: &other})
+	if got := gatewayHosts(gateway); !reflect.DeepEqual(got, []string{"api.example.com", "maas.example.com"}) {
+		t.Errorf("hosts = %v, want the listener hostnames deduplicated and sorted", got)
+	}
+
+	gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayapiv1.Listener{Name: "http"})
+	if got := gatewayHosts(gateway); !reflect.DeepEqual(got, []string{"*"}) {
+		t.Errorf("hosts = %v, want * when a listener accepts any hostname", got)
+	}
+}
+
+func TestAuthConfigSpec(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	authPolicySpec, err := kuadrantv1.ToUnstructured(r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway"))
+	if err != nil {
+		t.Fatalf("ToUnstructured: %v", err)
+	}
+
+	spec, err := authConfigSpec(authPolicySpec, []string{"maas.example.com"})
+	if err != nil {
+		t.Fatalf("authConfigSpec: %v", err)
+	}
+	if hosts, _, _ := unstructured.NestedStringSlice(spec, "hosts"); !reflect.DeepEqual(hosts, []string{"maas.example.com"}) {
+		t.Errorf("hosts = %v, want maas.example.com", hosts)
+	}
+	for _, field := range []string{"targetRef", "defaults"} {
+		if _, ok := spec[field]; ok {
+			t.Errorf("AuthConfig spec should not have the AuthPolicy field %s", field)
+		}
+	}
+	for _, section := range []string{"authentication", "metadata", "authorization", "response"} {
+		want, _, _ := unstructured.NestedFieldNoCopy(authPolicySpec, "defaults", "rules", section)
+		if !reflect.DeepEqual(spec[section], want) {
+			t.Errorf("AuthConfig %s should be the AuthPolicy defaults.rules.%s", section, section)
+		}
+	}
+	want, _, _ := unstructured.NestedFieldNoCopy(authPolicySpec, "defaults", "when")
+	if !reflect.DeepEqual(spec["when"], want) {
+		t.Errorf("AuthConfig when = %v, want the AuthPolicy defaults.when %v", spec["when"], want)
+	}
+}
+
+// TestMaaSAuthPolicyReconciler_AuthorinoAuthConfig verifies that with the authorino
+// provider the gateway rules are written as an AuthConfig for the Gateway's hostnames
+// and no AuthPolicy is created.
+func TestMaaSAuthPolicyReconciler_AuthorinoAuthConfig(t *testing.T) {
+	const (
+		namespace   = "default"
+		gatewayNS   = "gateway-ns"
+		gatewayName = "maas-default-gateway"
+	)
+	model := newMaaSModelRef("llm", namespace, "ExternalModel", "llm")
+	route := newHTTPRoute("maas-llm", namespace)
+	maasPolicy := newMaaSAuthPolicy("policy-a", namespace, "team-a", maasv1alpha1.ModelRef{Name: "llm", Namespace: namespace})
+	gateway := newGatewayWithHostname(gatewayName, gatewayNS, "maas.example.com")
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, maasPolicy, gateway).
+		WithStatusSubresource(&maasv1alpha1.MaaSAuthPolicy{}).
+		Build()
+	r := &MaaSAuthPolicyReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		MaaSAPINamespace: "maas-system",
+		GatewayNamespace: gatewayNS,
+		GatewayName:      gatewayName,
+		AuthProvider:     AuthProviderAuthorino,
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "policy-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	key := types.NamespacedName{Name: maasGatewayAuthPolicyName, Namespace: gatewayNS}
+	authConfig := &unstructured.Unstructured{}
+	authConfig.SetGroupVersionKind(authConfigGVK)
+	if err := c.Get(ctx, key, authConfig); err != nil {
+		t.Fatalf("Get gateway AuthConfig: %v", err)
+	}
+	if hosts, _, _ := unstructured.NestedStringSlice(authConfig.Object, "spec", "hosts"); !reflect.DeepEqual(hosts, []string{"maas.example.com"}) {
+		t.Errorf("AuthConfig hosts = %v, want the Gateway hostname", hosts)
+	}
+	rego, _, _ := unstructured.NestedString(authConfig.Object, "spec", "authorization", "require-group-membership", "opa", "rego")
+	if !strings.Contains(rego, "llm") {
+		t.Errorf("AuthConfig should carry the model access rules, got rego:\n%s", rego)
+	}
+
+	authPolicy := &unstructured.Unstructured{}
+	authPolicy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	if err := c.Get(ctx, key, authPolicy); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no gateway AuthPolicy with the authorino provider, got err=%v", err)
+	}
+}
+
+func TestGetAuthConfigReadyState(t *testing.T) {
+	authConfig := &unstructured.Unstructured{Object: map[string]any{}}
+	if ready, reason, _ := getAuthConfigReadyState(authConfig); ready || reason != maasv1alpha1.ReasonConditionsNotFound {
+		t.Errorf("AuthConfig without status: ready=%v reason=%s, want not ready with %s", ready, reason, maasv1alpha1.ReasonConditionsNotFound)
+	}
+
+	_ = unstructured.SetNestedSlice(authConfig.Object, []any{
+		map[string]any{"type": "Available", "status": "True"},
+		map[string]any{"type": "Ready", "status": "False", "reason": "HostsNotLinked"},
+	}, "status", "conditions")
+	if ready, reason, msg := getAuthConfigReadyState(authConfig); ready || reason != maasv1alpha1.ReasonNotEnforced || msg != "HostsNotLinked" {
+		t.Errorf("ready=%v reason=%s message=%q, want not enforced with HostsNotLinked", ready, reason, msg)
+	}
+
+	_ = unstructured.SetNestedSlice(authConfig.Object, []any{
+		map[string]any{"type": "Ready", "status": "True"},
+	}, "status", "conditions")
+	if ready, _, _ := getAuthConfigReadyState(authConfig); !ready {
+		t.Error("expected an AuthConfig with Ready=True to be ready")
+	}
+}
+
+// TestMaaSSubscriptionReconciler_AuthorinoRateLimitsNotEnforced verifies that with standalone
+// Authorino no TokenRateLimitPolicy is generated, the subscription reports that its limits
+// are not enforced, and deletion needs no policy cleanup.
+func TestMaaSSubscriptionReconciler_AuthorinoRateLimitsNotEnforced(t *testing.T) {
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute("maas-"+modelName, namespace)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, AuthProvider: AuthProviderAuthorino}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	key := types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}
+	if err := c.Get(ctx, key, trlp); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no TokenRateLimitPolicy with standalone Authorino, got err=%v", err)
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if got.Status.Phase != maasv1alpha1.PhaseActive {
+		t.Errorf("Phase = %q, want %q", got.Status.Phase, maasv1alpha1.PhaseActive)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, ConditionRateLimitsEnforced)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "AuthProviderAuthorino" {
+		t.Errorf("expected %s=False with reason AuthProviderAuthorino, got %+v", ConditionRateLimitsEnforced, cond)
+	}
+
+	if err := c.Delete(ctx, got); err != nil {
+		t.Fatalf("Delete MaaSSubscription: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile deletion: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the finalizer to be removed, got err=%v", err)
+	}
+}
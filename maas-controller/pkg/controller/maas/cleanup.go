@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// modelCleanup is the finalizer cleanup of one model's generated policies.
+type modelCleanup struct {
+	model string
+	run   func() error
+}
+
+// finalizerCleanup runs the cleanups of a finalizer best-effort: a failed model does not
+// stop the others, and its error is kept for the status of the resource being deleted.
+type finalizerCleanup struct {
+	log logr.Logger
+	// retry limits the model cleanups to the models that failed on the previous attempt.
+	// Nil runs them all.
+	retry    map[string]bool
+	failures []maasv1alpha1.CleanupFailure
+}
+
+// newFinalizerCleanup starts a cleanup attempt. When the previous attempt failed, as
+// recorded by a CleanupFailed Ready condition, only its failed models are retried; the
+// models that were cleaned up are not touched again.
+func newFinalizerCleanup(log logr.Logger, conditions []metav1.Condition, previous []maasv1alpha1.CleanupFailure) *finalizerCleanup {
+	c := &finalizerCleanup{log: log}
+	ready := apimeta.FindStatusCondition(conditions, "Ready")
+	if ready == nil || ready.Reason != string(maasv1alpha1.ReasonCleanupFailed) {
+		return c
+	}
+	c.retry = make(map[string]bool, len(previous))
+	for _, f := range previous {
+		if f.Model != "" {
+			c.retry[f.Model] = true
+		}
+	}
+	return c
+}
+
+// runModels runs the cleanup of each model once, skipping models that are not retried.
+func (c *finalizerCleanup) runModels(cleanups []modelCleanup) {
+	seen := make(map[string]bool, len(cleanups))
+	for _, mc := range cleanups {
+		if seen[mc.model] || (c.retry != nil && !c.retry[mc.model]) {
+			continue
+		}
+		seen[mc.model] = true
+		if err := mc.run(); err != nil {
+			c.log.Error(err, "failed to clean up generated policies, will retry", "model", mc.model)
+			c.failures = append(c.failures, maasv1alpha1.CleanupFailure{Model: mc.model, Message: err.Error()})
+		}
+	}
+}
+
+// fail records an error of cleanup that is not tied to a model. Such cleanup runs on
+// every attempt.
+func (c *finalizerCleanup) fail(err error) {
+	c.log.Error(err, "failed to clean up, will retry")
+	c.failures = append(c.failures, maasv1alpha1.CleanupFailure{Message: err.Error()})
+}
+
+// result returns the failures sorted by model, and an error joining them, or nil when
+// the cleanup succeeded.
+func (c *finalizerCleanup) result() ([]maasv1alpha1.CleanupFailure, error) {
+	if len(c.failures) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(c.failures, func(i, j int) bool { return c.failures[i].Model < c.failures[j].Model })
+	errs := make([]error, 0, len(c.failures))
+	for _, f := range c.failures {
+		errs = append(errs, errors.New(cleanupFailureString(f)))
+	}
+	return c.failures, errors.Join(errs...)
+}
+
+// cleanupFailuresMessage summarizes failures for the Ready condition and Events.
+func cleanupFailuresMessage(failures []maasv1alpha1.CleanupFailure) string {
+	parts := make([]string, 0, len(failures))
+	for _, f := range failures {
+		parts = append(parts, cleanupFailureString(f))
+	}
+	return fmt.Sprintf("cleanup failed for %d item(s), will retry: %s", len(failures), strings.Join(parts, "; "))
+}
+
+func cleanupFailureString(f maasv1alpha1.CleanupFailure) string {
+	if f.Model == "" {
+		return f.Message
+	}
+	return "model " + f.Model + ": " + f.Message
+}
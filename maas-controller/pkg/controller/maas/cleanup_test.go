@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// TestMaaSSubscriptionReconciler_DeletionPartialFailure verifies that a failed cleanup of
+// one model does not stop the others, is recorded in status and an Event, and keeps the
+// finalizer until a retry succeeds.
+func TestMaaSSubscriptionReconciler_DeletionPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	const namespace = "default"
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", "llm-a", 100)
+	sub.Spec.ModelRefs = append(sub.Spec.ModelRefs, maasv1alpha1.ModelSubscriptionRef{
+		Name:            "llm-b",
+		Namespace:       namespace,
+		TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 100, Window: "1m"}},
+	})
+
+	failing := tokenRateLimitPolicyName(namespace, "llm-a", "")
+	failDelete := true
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(
+			newMaaSModelRef("llm-a", namespace, "ExternalModel", "llm-a"),
+			newMaaSModelRef("llm-b", namespace, "ExternalModel", "llm-b"),
+			newHTTPRoute("maas-llm-a", namespace),
+			newHTTPRoute("maas-llm-b", namespace),
+			sub,
+		).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*unstructured.Unstructured); ok && obj.GetName() == failing && failDelete {
+					return errors.New("simulated API server error")
+				}
+				return cl.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if err := c.Delete(ctx, got); err != nil {
+		t.Fatalf("Delete MaaSSubscription: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatal("expected the deletion reconcile to fail while a TokenRateLimitPolicy cannot be deleted")
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	if err := c.Get(ctx, client.ObjectKey{Name: tokenRateLimitPolicyName(namespace, "llm-b", ""), Namespace: namespace}, trlp); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the other model's TokenRateLimitPolicy to be deleted despite the failure, got %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("expected the subscription to be kept by its finalizer: %v", err)
+	}
+	wantFailures := []string{namespace + "/llm-a"}
+	var gotFailures []string
+	for _, f := range got.Status.CleanupFailures {
+		gotFailures = append(gotFailures, f.Model)
+	}
+	if !reflect.DeepEqual(gotFailures, wantFailures) {
+		t.Errorf("cleanupFailures = %v, want %v", gotFailures, wantFailures)
+	}
+	if ready := apimeta.FindStatusCondition(got.Status.Conditions, "Ready"); ready == nil || ready.Reason != string(maasv1alpha1.ReasonCleanupFailed) {
+		t.Errorf("expected Ready reason %s, got %+v", maasv1alpha1.ReasonCleanupFailed, ready)
+	}
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, "CleanupFailed") || !strings.Contains(e, "llm-a") {
+			t.Errorf("unexpected event %q", e)
+		}
+	default:
+		t.Error("expected a CleanupFailed event")
+	}
+
+	failDelete = false
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile retry: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: failing, Namespace: namespace}, trlp); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the failed TokenRateLimitPolicy to be deleted on retry, got %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the finalizer to be removed after a successful retry, got %v", err)
+	}
+}
+
+// TestFinalizerCleanup_RetriesFailedModels verifies that after a failed attempt only the
+// failed models are cleaned up again.
+func TestFinalizerCleanup_RetriesFailedModels(t *testing.T) {
+	failed := []maasv1alpha1.CleanupFailure{{Model: "ns/b", Message: "boom"}, {Message: "gateway"}}
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		want       []string
+	}{
+		{name: "first attempt", want: []string{"ns/a", "ns/b"}},
+		{
+			name:       "retry after a failed cleanup",
+			conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: string(maasv1alpha1.ReasonCleanupFailed)}},
+			want:       []string{"ns/b"},
+		},
+		{
+			name:       "stale failures without a CleanupFailed condition",
+			conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: string(maasv1alpha1.ReasonReconciled)}},
+			want:       []string{"ns/a", "ns/b"},
+		},
+	}
+	for _, tt := range tests {
+		var ran []string
+		cleanup := newFinalizerCleanup(ctrl.Log.WithName("test"), tt.conditions, failed)
+		run := func(model string) modelCleanup {
+			return modelCleanup{model: model, run: func() error { ran = append(ran, model); return nil }}
+		}
+		cleanup.runModels([]modelCleanup{run("ns/a"), run("ns/b"), run("ns/b")})
+		if !reflect.DeepEqual(ran, tt.want) {
+			t.Errorf("%s: cleaned up %v, want %v", tt.name, ran, tt.want)
+		}
+		if failures, err := cleanup.result(); failures != nil || err != nil {
+			t.Errorf("%s: expected no failures, got %v, %v", tt.name, failures, err)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	// concurrencyRouteComponent labels the HTTPRoutes, Services, and DestinationRules
+	// created for spec.modelRefs[].maxConcurrentRequests.
+	concurrencyRouteComponent = "concurrency-route"
+
+	// subscriptionHeader carries the name of the subscription a request was authorized
+	// for. The gateway AuthPolicy sets it, replacing any value sent by the client.
+	subscriptionHeader = "X-MaaS-Subscription"
+)
+
+// concurrencyLimit is a subscription that caps its in-flight requests to a model.
+type concurrencyLimit struct {
+	sub *maasv1alpha1.MaaSSubscription
+	max int32
+}
+
+// subscriptionHash returns a short, stable hash of a subscription for generated names.
+func subscriptionHash(subNamespace, subName string) string {
+	sum := sha256.Sum256([]byte(subNamespace + "/" + subName))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// concurrencyRouteName returns the name of the HTTPRoute that sends one subscription's
+// requests for a model through its capped backends.
+func concurrencyRouteName(modelName, subNamespace, subName string) string {
+	return fmt.Sprintf("%s-concurrency-%s", modelName, subscriptionHash(subNamespace, subName))
+}
+
+// concurrencyServiceName returns the name of the copy of a backend Service that carries
+// one subscription's cap. Service names are DNS-1035 labels, so the backend name is
+// trimmed to keep the result within 63 characters.
+func concurrencyServiceName(service, subNamespace, subName string) string {
+	suffix := "-cc-" + subscriptionHash(subNamespace, subName)
+	if budget := 63 - len(suffix); len(service) > budget {
+		service = strings.TrimRight(service[:budget], "-")
+	}
+	return service + suffix
+}
+
+// concurrencyLimits returns the subscriptions that set maxConcurrentRequests for the
+// model, sorted by namespace and name.
+func concurrencyLimits(allSubs []maasv1alpha1.MaaSSubscription, modelNamespace, modelName string) []concurrencyLimit {
+	var out []concurrencyLimit
+	for i := range allSubs {
+		for _, mRef := range allSubs[i].Spec.ModelRefs {
+			if mRef.Namespace != modelNamespace || mRef.Name != modelName {
+				continue
+			}
+			if mRef.MaxConcurrentRequests != nil {
+				out = append(out, concurrencyLimit{sub: &allSubs[i], max: *mRef.MaxConcurrentRequests})
+			}
+			break
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return qualifiedName(out[i].sub.Namespace, out[i].sub.Name) < qualifiedName(out[j].sub.Namespace, out[j].sub.Name)
+	})
+	return out
+}
+
+// concurrencyRules copies the source route's rules for one subscription: every match
+// additionally requires the subscription header, which makes it more specific than the
+// source's own match, and every backend Service is replaced by its capped copy. It
+// returns false if a rule sends traffic to anything but a Service in the route's
+// namespace, since such a backend cannot be capped.
+func concurrencyRules(source *gatewayapiv1.HTTPRoute, subName string, serviceName func(string) string) ([]gatewayapiv1.HTTPRouteRule, bool) {
+	header := gatewayapiv1.HTTPHeaderMatch{Name: subscriptionHeader, Value: subName}
+	rules := make([]gatewayapiv1.HTTPRouteRule, 0, len(source.Spec.Rules))
+	for _, rule := range source.Spec.Rules {
+		out := *rule.DeepCopy()
+		for i, ref := range out.BackendRefs {
+			if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Service") ||
+				(ref.Namespace != nil && string(*ref.Namespace) != source.Namespace) {
+				return nil, false
+			}
+			out.BackendRefs[i].Name = gatewayapiv1.ObjectName(serviceName(string(ref.Name)))
+		}
+		if len(out.Matches) == 0 {
+			out.Matches = []gatewayapiv1.HTTPRouteMatch{{}}
+		}
+		for i := range out.Matches {
+			out.Matches[i].Headers = append(out.Matches[i].Headers, header)
+		}
+		rules = append(rules, out)
+	}
+	return rules, len(rules) > 0
+}
+
+// concurrencySourceRoute returns the route whose rules the concurrency routes copy: the
+// routing route if spec.routing adds one, so its timeouts and retries still apply, and
+// the model's primary route otherwise. It returns nil while a failover route takes over
+// the model's traffic, whose backup backends are not capped.
+func (r *MaaSSubscriptionReconciler) concurrencySourceRoute(ctx context.Context, modelName string, primary *gatewayapiv1.HTTPRoute) (*gatewayapiv1.HTTPRoute, error) {
+	for _, c := range []struct{ name, component string }{
+		{failoverRouteName(modelName), failoverRouteComponent},
+		{routingRouteName(modelName), routingRouteComponent},
+	} {
+		route := &gatewayapiv1.HTTPRoute{}
+		err := r.Get(ctx, types.NamespacedName{Name: c.name, Namespace: primary.Namespace}, route)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s HTTPRoute for model %s: %w", c.component, modelName, err)
+		}
+		if route.Labels["app.kubernetes.io/component"] != c.component {
+			continue
+		}
+		if c.component == failoverRouteComponent {
+			return nil, nil
+		}
+		return route, nil
+	}
+	return primary, nil
+}
+
+// reconcileConcurrencyRoutes enforces spec.modelRefs[].maxConcurrentRequests. Envoy caps
+// in-flight requests per upstream cluster, so each capped subscription gets its own
+// copies of the model's backend Services, each with a DestinationRule whose connection
+// pool allows the cap in requests, and an HTTPRoute that sends the subscription's
+// requests to those copies. The gateway selects the route again once the AuthPolicy has
+// set the subscription header. The model's TokenRateLimitPolicy and RateLimitPolicy are
+// mirrored onto each route. Generated resources are owned by the primary route and
+// removed once their subscription no longer sets a cap.
+func (r *MaaSSubscriptionReconciler) reconcileConcurrencyRoutes(ctx context.Context, log logr.Logger, modelNamespace, modelName string, primary *gatewayapiv1.HTTPRoute, allSubs []maasv1alpha1.MaaSSubscription) error {
+	limits := concurrencyLimits(allSubs, modelNamespace, modelName)
+	keep := map[string]bool{}
+	if len(limits) == 0 {
+		return r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, keep)
+	}
+
+	source, err := r.concurrencySourceRoute(ctx, modelName, primary)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		r.recordConcurrencyNotApplied(limits, modelNamespace, modelName, "the model is failed over to its backup")
+		return r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, keep)
+	}
+	backends := map[string]*corev1.Service{}
+	for _, name := range routeServiceBackends(source) {
+		svc := &corev1.Service{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: source.Namespace}, svc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get backend Service %s/%s: %w", source.Namespace, name, err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			r.recordConcurrencyNotApplied(limits, modelNamespace, modelName, fmt.Sprintf("backend Service %s has no selector", name))
+			return r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, keep)
+		}
+		backends[name] = svc
+	}
+
+	for _, l := range limits {
+		serviceName := func(s string) string { return concurrencyServiceName(s, l.sub.Namespace, l.sub.Name) }
+		rules, ok := concurrencyRules(source, l.sub.Name, serviceName)
+		if !ok {
+			r.recordConcurrencyNotApplied(limits, modelNamespace, modelName, fmt.Sprintf("HTTPRoute %s/%s does not route to Services in its namespace", source.Namespace, source.Name))
+			return r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, keep)
+		}
+		for name, backend := range backends {
+			copyName := serviceName(name)
+			if err := r.applyConcurrencyBackend(ctx, log, modelNamespace, modelName, primary, backend, copyName, l.max); err != nil {
+				if apimeta.IsNoMatchError(err) {
+					r.recordConcurrencyNotApplied(limits, modelNamespace, modelName, "the Istio DestinationRule API is not installed")
+					return r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, map[string]bool{})
+				}
+				return err
+			}
+			keep[copyName] = true
+		}
+		routeName := concurrencyRouteName(modelName, l.sub.Namespace, l.sub.Name)
+		if err := r.applyConcurrencyRoute(ctx, log, modelNamespace, modelName, primary, source, routeName, rules); err != nil {
+			return err
+		}
+		keep[routeName] = true
+		if err := r.reconcileMirroredTRLP(ctx, log, modelNamespace, modelName, primary.Namespace, routeName, concurrencyRouteComponent, allSubs); err != nil {
+			return err
+		}
+	}
+	return r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, keep)
+}
+
+// concurrencyLabels labels the resources generated for a model's concurrency limits.
+func concurrencyLabels(labels map[string]string, modelNamespace, modelName string) map[string]string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["maas.opendatahub.io/model"] = modelName
+	labels["maas.opendatahub.io/model-namespace"] = modelNamespace
+	labels["app.kubernetes.io/managed-by"] = "maas-controller"
+	labels["app.kubernetes.io/part-of"] = "maas-subscription"
+	labels["app.kubernetes.io/component"] = concurrencyRouteComponent
+	return labels
+}
+
+// applyConcurrencyBackend applies the capped copy of a backend Service and the
+// DestinationRule that caps it.
+func (r *MaaSSubscriptionReconciler) applyConcurrencyBackend(ctx context.Context, log logr.Logger, modelNamespace, modelName string, primary *gatewayapiv1.HTTPRoute, backend *corev1.Service, name string, maxRequests int32) error {
+	dr := &unstructured.Unstructured{}
+	dr.SetGroupVersionKind(istioDestinationRuleGVK)
+	dr.SetName(name)
+	dr.SetNamespace(backend.Namespace)
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, dr, func() error {
+		dr.SetLabels(concurrencyLabels(dr.GetLabels(), modelNamespace, modelName))
+		if err := controllerutil.SetControllerReference(primary, dr, r.Scheme); err != nil {
+			return err
+		}
+		return unstructured.SetNestedMap(dr.Object, map[string]any{
+			"host": fmt.Sprintf("%s.%s.svc.cluster.local", name, backend.Namespace),
+			"trafficPolicy": map[string]any{
+				"connectionPool": map[string]any{
+					// Istio maps http2MaxRequests to Envoy's max_requests circuit breaker,
+					// which bounds active requests over HTTP/1.1 and HTTP/2 alike.
+					"http": map[string]any{"http2MaxRequests": int64(maxRequests)},
+				},
+			},
+		}, "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply DestinationRule %s/%s: %w", dr.GetNamespace(), dr.GetName(), err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Concurrency limit DestinationRule applied", "name", name, "model", modelNamespace+"/"+modelName, "maxConcurrentRequests", maxRequests, "operation", op)
+	}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: backend.Namespace}}
+	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		svc.Labels = concurrencyLabels(svc.Labels, modelNamespace, modelName)
+		if err := controllerutil.SetControllerReference(primary, svc, r.Scheme); err != nil {
+			return err
+		}
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Spec.Selector = backend.Spec.Selector
+		ports := make([]corev1.ServicePort, len(backend.Spec.Ports))
+		for i, p := range backend.Spec.Ports {
+			p.NodePort = 0
+			ports[i] = p
+		}
+		svc.Spec.Ports = ports
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply Service %s/%s: %w", svc.Namespace, svc.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Concurrency limit Service applied", "name", name, "backend", backend.Name, "model", modelNamespace+"/"+modelName, "operation", op)
+	}
+	return nil
+}
+
+// applyConcurrencyRoute applies the HTTPRoute that carries one subscription's capped rules.
+func (r *MaaSSubscriptionReconciler) applyConcurrencyRoute(ctx context.Context, log logr.Logger, modelNamespace, modelName string, primary, source *gatewayapiv1.HTTPRoute, name string, rules []gatewayapiv1.HTTPRouteRule) error {
+	route := &gatewayapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: primary.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		route.Labels = concurrencyLabels(route.Labels, modelNamespace, modelName)
+		if err := controllerutil.SetControllerReference(primary, route, r.Scheme); err != nil {
+			return err
+		}
+		route.Spec.ParentRefs = source.Spec.ParentRefs
+		route.Spec.Hostnames = source.Spec.Hostnames
+		route.Spec.Rules = rules
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply concurrency HTTPRoute %s/%s: %w", route.Namespace, route.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Concurrency limit HTTPRoute applied", "name", name, "model", modelNamespace+"/"+modelName, "operation", op)
+	}
+	return nil
+}
+
+// pruneConcurrencyRoutes deletes the model's generated concurrency resources not in keep.
+// Routes go first so no request is routed to a deleted backend.
+func (r *MaaSSubscriptionReconciler) pruneConcurrencyRoutes(ctx context.Context, log logr.Logger, modelNamespace, modelName string, keep map[string]bool) error {
+	selector := client.MatchingLabels(concurrencyLabels(nil, modelNamespace, modelName))
+	dr := &unstructured.UnstructuredList{}
+	dr.SetGroupVersionKind(istioDestinationRuleGVK.GroupVersion().WithKind("DestinationRuleList"))
+	for _, list := range []client.ObjectList{&gatewayapiv1.HTTPRouteList{}, &corev1.ServiceList{}, dr} {
+		if err := r.List(ctx, list, selector); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to list concurrency limit resources for model %s/%s: %w", modelNamespace, modelName, err)
+		}
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || keep[obj.GetName()] {
+				continue
+			}
+			if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %T %s/%s: %w", obj, obj.GetNamespace(), obj.GetName(), err)
+			}
+			log.Info("Concurrency limit resource deleted", "name", obj.GetName(), "namespace", obj.GetNamespace(), "model", modelNamespace+"/"+modelName)
+		}
+	}
+	return nil
+}
+
+// recordConcurrencyNotApplied warns the capped subscriptions of a model that their cap is
+// not enforced.
+func (r *MaaSSubscriptionReconciler) recordConcurrencyNotApplied(limits []concurrencyLimit, modelNamespace, modelName, reason string) {
+	if r.Recorder == nil {
+		return
+	}
+	for _, l := range limits {
+		r.Recorder.Eventf(l.sub, corev1.EventTypeWarning, "ConcurrencyLimitNotApplied",
+			"maxConcurrentRequests for model %s/%s is not enforced: %s", modelNamespace, modelName, reason)
+	}
+}
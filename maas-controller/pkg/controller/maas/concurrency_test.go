@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// newServiceRoute returns an HTTPRoute that sends /llm to the given backend Service.
+func newServiceRoute(name, namespace, service string) *gatewayapiv1.HTTPRoute {
+	route := newHTTPRoute(name, namespace)
+	pathType := gatewayapiv1.PathMatchPathPrefix
+	path := "/llm"
+	route.Spec.Rules = []gatewayapiv1.HTTPRouteRule{{
+		Matches: []gatewayapiv1.HTTPRouteMatch{{Path: &gatewayapiv1.HTTPPathMatch{Type: &pathType, Value: &path}}},
+		BackendRefs: []gatewayapiv1.HTTPBackendRef{{BackendRef: gatewayapiv1.BackendRef{
+			BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: gatewayapiv1.ObjectName(service)},
+		}}},
+	}}
+	return route
+}
+
+func TestConcurrencyRules(t *testing.T) {
+	route := newServiceRoute("llm-route", "default", "llm-svc")
+	rules, ok := concurrencyRules(route, "team-a", func(s string) string { return s + "-capped" })
+	if !ok || len(rules) != 1 {
+		t.Fatalf("concurrencyRules = %v, %v; want one rule", rules, ok)
+	}
+	match := rules[0].Matches[0]
+	if match.Path == nil || *match.Path.Value != "/llm" {
+		t.Errorf("path match = %v, want the source's /llm prefix", match.Path)
+	}
+	if len(match.Headers) != 1 || match.Headers[0].Name != subscriptionHeader || match.Headers[0].Value != "team-a" {
+		t.Errorf("header matches = %v, want %s: team-a", match.Headers, subscriptionHeader)
+	}
+	if got := rules[0].BackendRefs[0].Name; got != "llm-svc-capped" {
+		t.Errorf("backend = %q, want llm-svc-capped", got)
+	}
+	if len(route.Spec.Rules[0].Matches[0].Headers) != 0 || route.Spec.Rules[0].BackendRefs[0].Name != "llm-svc" {
+		t.Error("concurrencyRules modified the source route")
+	}
+
+	group := gatewayapiv1.Group("inference.networking.k8s.io")
+	kind := gatewayapiv1.Kind("InferencePool")
+	route.Spec.Rules[0].BackendRefs[0].Group = &group
+	route.Spec.Rules[0].BackendRefs[0].Kind = &kind
+	if _, ok := concurrencyRules(route, "team-a", func(s string) string { return s }); ok {
+		t.Error("expected an InferencePool backend to be rejected")
+	}
+}
+
+func TestConcurrencyServiceName(t *testing.T) {
+	long := strings.Repeat("a", 70)
+	if name := concurrencyServiceName(long, "default", "sub-a"); len(name) > 63 {
+		t.Errorf("name %q is longer than 63 characters", name)
+	}
+	if concurrencyServiceName("svc", "default", "sub-a") == concurrencyServiceName("svc", "default", "sub-b") {
+		t.Error("expected distinct names for distinct subscriptions")
+	}
+}
+
+// TestMaaSSubscriptionReconciler_ConcurrencyLimits verifies that maxConcurrentRequests
+// produces a capped backend and an HTTPRoute for the subscription and that removing the
+// cap deletes them.
+func TestMaaSSubscriptionReconciler_ConcurrencyLimits(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newServiceRoute("maas-"+modelName, namespace, "llm-svc")
+	backend := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm-svc", Namespace: namespace},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "llm"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 8000}},
+		},
+	}
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+	limit := int32(4)
+	sub.Spec.ModelRefs[0].MaxConcurrentRequests = &limit
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, backend, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	copyName := concurrencyServiceName("llm-svc", namespace, "sub-a")
+	capped := &corev1.Service{}
+	if err := c.Get(ctx, types.NamespacedName{Name: copyName, Namespace: namespace}, capped); err != nil {
+		t.Fatalf("expected capped Service copy: %v", err)
+	}
+	if capped.Spec.Selector["app"] != "llm" || len(capped.Spec.Ports) != 1 {
+		t.Errorf("capped Service = %v, want the backend's selector and ports", capped.Spec)
+	}
+	dr := &unstructured.Unstructured{}
+	dr.SetGroupVersionKind(istioDestinationRuleGVK)
+	if err := c.Get(ctx, types.NamespacedName{Name: copyName, Namespace: namespace}, dr); err != nil {
+		t.Fatalf("expected DestinationRule: %v", err)
+	}
+	if got, _, _ := unstructured.NestedInt64(dr.Object, "spec", "trafficPolicy", "connectionPool", "http", "http2MaxRequests"); got != 4 {
+		t.Errorf("http2MaxRequests = %d, want 4", got)
+	}
+
+	routeName := concurrencyRouteName(modelName, namespace, "sub-a")
+	capRoute := &gatewayapiv1.HTTPRoute{}
+	routeKey := types.NamespacedName{Name: routeName, Namespace: namespace}
+	if err := c.Get(ctx, routeKey, capRoute); err != nil {
+		t.Fatalf("expected concurrency HTTPRoute: %v", err)
+	}
+	if got := capRoute.Spec.Rules[0].BackendRefs[0].Name; string(got) != copyName {
+		t.Errorf("concurrency route backend = %q, want %q", got, copyName)
+	}
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	if err := c.Get(ctx, types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, modelName, routeName), Namespace: namespace}, trlp); err != nil {
+		t.Errorf("expected TokenRateLimitPolicy mirrored onto the concurrency route: %v", err)
+	}
+
+	current := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	current.Spec.ModelRefs[0].MaxConcurrentRequests = nil
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after removing the cap: %v", err)
+	}
+	if err := c.Get(ctx, routeKey, capRoute); !apierrors.IsNotFound(err) {
+		t.Errorf("expected concurrency HTTPRoute to be deleted, got err=%v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: copyName, Namespace: namespace}, capped); !apierrors.IsNotFound(err) {
+		t.Errorf("expected capped Service to be deleted, got err=%v", err)
+	}
+}
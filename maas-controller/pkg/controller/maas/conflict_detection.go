@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -31,11 +32,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
 )
 
-const ConditionConflictingAuthPolicy = "ConflictingAuthPolicy"
+const (
+	ConditionConflictingAuthPolicy      = "ConflictingAuthPolicy"
+	ConditionConflictingRateLimitPolicy = "ConflictingRateLimitPolicy"
+)
 
 type conflictingPolicyInfo struct {
+	// Kind is set for rate limit policies, where a route can carry either kind.
+	Kind          string
 	Name          string
 	Namespace     string
 	HTTPRouteName string
@@ -43,8 +50,15 @@ type conflictingPolicyInfo struct {
 	ModelNS       string
 }
 
+func (c conflictingPolicyInfo) key() string {
+	if c.Kind != "" {
+		return c.Kind + " " + c.Namespace + "/" + c.Name
+	}
+	return c.Namespace + "/" + c.Name
+}
+
 func (c conflictingPolicyInfo) String() string {
-	return fmt.Sprintf("%s/%s (targets HTTPRoute %s, model %s/%s)", c.Namespace, c.Name, c.HTTPRouteName, c.ModelNS, c.Model)
+	return fmt.Sprintf("%s (targets HTTPRoute %s, model %s/%s)", c.key(), c.HTTPRouteName, c.ModelNS, c.Model)
 }
 
 // detectConflictingAuthPolicies finds non-MaaS Kuadrant AuthPolicies that target
@@ -71,9 +85,14 @@ func (r *MaaSAuthPolicyReconciler) detectConflictingAuthPolicies(ctx context.Con
 
 	// Deduplicate: one rogue AuthPolicy can appear multiple times when
 	// multiple modelRefs resolve to the same HTTPRoute.
+	return uniqueConflicts(conflicts), nil
+}
+
+// uniqueConflicts drops repeated policies and sorts the rest by name.
+func uniqueConflicts(conflicts []conflictingPolicyInfo) []conflictingPolicyInfo {
 	uniq := make(map[string]conflictingPolicyInfo, len(conflicts))
 	for _, c := range conflicts {
-		uniq[c.Namespace+"/"+c.Name] = c
+		uniq[c.key()] = c
 	}
 	conflicts = conflicts[:0]
 	for _, c := range uniq {
@@ -81,51 +100,60 @@ func (r *MaaSAuthPolicyReconciler) detectConflictingAuthPolicies(ctx context.Con
 	}
 
 	sort.Slice(conflicts, func(i, j int) bool {
-		ki := conflicts[i].Namespace + "/" + conflicts[i].Name
-		kj := conflicts[j].Namespace + "/" + conflicts[j].Name
-		return ki < kj
+		return conflicts[i].key() < conflicts[j].key()
 	})
-	return conflicts, nil
+	return conflicts
 }
 
 // findConflictsForHTTPRoute lists all AuthPolicies in the given namespace and returns
 // any that target the specified HTTPRoute but are not managed by MaaS.
 func (r *MaaSAuthPolicyReconciler) findConflictsForHTTPRoute(ctx context.Context, log logr.Logger, httpRouteName, httpRouteNS, modelName, modelNS string) ([]conflictingPolicyInfo, error) {
-	allAPs := &unstructured.UnstructuredList{}
-	allAPs.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicyList"})
-	if err := r.List(ctx, allAPs, client.InNamespace(httpRouteNS)); err != nil {
+	conflicts, err := findUnmanagedPoliciesForHTTPRoute(ctx, r.Client, schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"},
+		httpRouteName, httpRouteNS, modelName, modelNS)
+	for _, c := range conflicts {
+		log.Info("detected conflicting AuthPolicy on MaaS auth surface",
+			"conflictingPolicy", c.Namespace+"/"+c.Name,
+			"httpRoute", httpRouteNS+"/"+httpRouteName,
+			"model", modelNS+"/"+modelName)
+	}
+	return conflicts, err
+}
+
+// findUnmanagedPoliciesForHTTPRoute lists the policies of the given kind in the
+// HTTPRoute's namespace and returns those that target the HTTPRoute but were not
+// created by maas-controller. A kind whose CRD is not installed has no policies.
+func findUnmanagedPoliciesForHTTPRoute(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, httpRouteName, httpRouteNS, modelName, modelNS string) ([]conflictingPolicyInfo, error) {
+	policies := &unstructured.UnstructuredList{}
+	policies.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.List(ctx, policies, client.InNamespace(httpRouteNS)); err != nil {
 		if apimeta.IsNoMatchError(err) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("list AuthPolicies in namespace %s: %w", httpRouteNS, err)
+		return nil, fmt.Errorf("list %s resources in namespace %s: %w", gvk.Kind, httpRouteNS, err)
 	}
 
 	var conflicts []conflictingPolicyInfo
-	for i := range allAPs.Items {
-		ap := &allAPs.Items[i]
+	for i := range policies.Items {
+		p := &policies.Items[i]
 
-		if ap.GetLabels()["app.kubernetes.io/managed-by"] == "maas-controller" {
+		if p.GetLabels()["app.kubernetes.io/managed-by"] == "maas-controller" {
 			continue
 		}
 
-		targetRefName, _, _ := unstructured.NestedString(ap.Object, "spec", "targetRef", "name")
-		targetRefKind, _, _ := unstructured.NestedString(ap.Object, "spec", "targetRef", "kind")
-		targetRefGroup, _, _ := unstructured.NestedString(ap.Object, "spec", "targetRef", "group")
+		targetRefName, _, _ := unstructured.NestedString(p.Object, "spec", "targetRef", "name")
+		targetRefKind, _, _ := unstructured.NestedString(p.Object, "spec", "targetRef", "kind")
+		targetRefGroup, _, _ := unstructured.NestedString(p.Object, "spec", "targetRef", "group")
 
 		if targetRefName == httpRouteName &&
 			targetRefKind == "HTTPRoute" &&
 			targetRefGroup == "gateway.networking.k8s.io" {
 			conflicts = append(conflicts, conflictingPolicyInfo{
-				Name:          ap.GetName(),
-				Namespace:     ap.GetNamespace(),
+				Name:          p.GetName(),
+				Namespace:     p.GetNamespace(),
 				HTTPRouteName: httpRouteName,
 				Model:         modelName,
 				ModelNS:       modelNS,
 			})
-			log.Info("detected conflicting AuthPolicy on MaaS auth surface",
-				"conflictingPolicy", ap.GetNamespace()+"/"+ap.GetName(),
-				"httpRoute", httpRouteNS+"/"+httpRouteName,
-				"model", modelNS+"/"+modelName)
 		}
 	}
 	return conflicts, nil
@@ -167,6 +195,167 @@ func setConflictingAuthPolicyCondition(policy *maasv1alpha1.MaaSAuthPolicy, conf
 	})
 }
 
+// detectConflictingRateLimitPolicies finds TokenRateLimitPolicies and RateLimitPolicies
+// not created by maas-controller that target the HTTPRoutes of the subscription's models.
+// Kuadrant gives no defined precedence between them and the generated policies, so user
+// limits may replace or add to the subscription's limits. A policy is reported once for
+// each model whose route it targets.
+func (r *MaaSSubscriptionReconciler) detectConflictingRateLimitPolicies(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) ([]conflictingPolicyInfo, error) {
+	var conflicts []conflictingPolicyInfo
+	seen := make(map[string]struct{}, len(subscription.Spec.ModelRefs))
+	for _, ref := range subscription.Spec.ModelRefs {
+		k := ref.Namespace + "/" + ref.Name
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+
+		httpRouteName, httpRouteNS, err := findHTTPRouteForModel(ctx, r.Client, ref.Namespace, ref.Name)
+		if err != nil {
+			if errors.Is(err, ErrModelNotFound) || errors.Is(err, ErrHTTPRouteNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("resolve HTTPRoute for model %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+
+		modelConflicts, err := r.findConflictingRateLimitPolicies(ctx, log, httpRouteName, httpRouteNS, ref.Name, ref.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, modelConflicts...)
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return conflicts[i].key() < conflicts[j].key()
+	})
+	return conflicts, nil
+}
+
+// findConflictingRateLimitPolicies returns the TokenRateLimitPolicies and RateLimitPolicies
+// targeting the HTTPRoute that maas-controller did not create.
+func (r *MaaSSubscriptionReconciler) findConflictingRateLimitPolicies(ctx context.Context, log logr.Logger, httpRouteName, httpRouteNS, modelName, modelNS string) ([]conflictingPolicyInfo, error) {
+	var conflicts []conflictingPolicyInfo
+	for _, gvk := range []schema.GroupVersionKind{r.trlpGVK(), kuadrantv1.RateLimitPolicyGVK} {
+		found, err := findUnmanagedPoliciesForHTTPRoute(ctx, r.Client, gvk, httpRouteName, httpRouteNS, modelName, modelNS)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range found {
+			c.Kind = gvk.Kind
+			log.Info("detected conflicting rate limit policy on MaaS model route",
+				"kind", gvk.Kind,
+				"conflictingPolicy", c.Namespace+"/"+c.Name,
+				"httpRoute", httpRouteNS+"/"+httpRouteName,
+				"model", modelNS+"/"+modelName)
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts, nil
+}
+
+// setConflictingRateLimitPolicyCondition updates the ConflictingRateLimitPolicy condition
+// on a MaaSSubscription based on detected conflicts. refused reports whether the
+// controller stopped enforcing the generated policies on the affected routes.
+func setConflictingRateLimitPolicyCondition(subscription *maasv1alpha1.MaaSSubscription, conflicts []conflictingPolicyInfo, refused bool) {
+	if len(conflicts) == 0 {
+		apimeta.SetStatusCondition(&subscription.Status.Conditions, metav1.Condition{
+			Type:               ConditionConflictingRateLimitPolicy,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoConflict",
+			Message:            "No conflicting rate limit policies detected on MaaS-managed HTTPRoutes",
+			ObservedGeneration: subscription.GetGeneration(),
+		})
+		return
+	}
+
+	var names []string
+	for _, c := range uniqueConflicts(slices.Clone(conflicts)) {
+		names = append(names, c.key())
+	}
+	effect := "These policies may override or add to the subscription's rate limits."
+	reason := "ConflictDetected"
+	if refused {
+		effect = "MaaS rate limits are not enforced on the affected HTTPRoutes until these policies are removed."
+		reason = "EnforcementRefused"
+	}
+	msg := fmt.Sprintf("Detected %d non-MaaS rate limit polic%s targeting MaaS-managed HTTPRoutes: %s. %s "+
+		"See MaaS troubleshooting documentation for remediation.",
+		len(names), pluralY(len(names)), strings.Join(names, ", "), effect)
+
+	if len(msg) > 1024 {
+		msg = msg[:1021] + "..."
+	}
+
+	apimeta.SetStatusCondition(&subscription.Status.Conditions, metav1.Condition{
+		Type:               ConditionConflictingRateLimitPolicy,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: subscription.GetGeneration(),
+	})
+}
+
+// reportConflictingRateLimitPolicies sets the ConflictingRateLimitPolicy condition on the
+// subscription and emits an event when conflicts appear, change, or are resolved. It
+// returns the detected conflicts.
+func (r *MaaSSubscriptionReconciler) reportConflictingRateLimitPolicies(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) []conflictingPolicyInfo {
+	prevConflict := apimeta.FindStatusCondition(subscription.Status.Conditions, ConditionConflictingRateLimitPolicy).DeepCopy()
+	conflicts, detectErr := r.detectConflictingRateLimitPolicies(ctx, log, subscription)
+	if detectErr != nil {
+		apimeta.SetStatusCondition(&subscription.Status.Conditions, metav1.Condition{
+			Type:               ConditionConflictingRateLimitPolicy,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ConflictCheckFailed",
+			Message:            detectErr.Error(),
+			ObservedGeneration: subscription.GetGeneration(),
+		})
+	} else {
+		setConflictingRateLimitPolicyCondition(subscription, conflicts, r.RefuseConflictingPolicies)
+	}
+	currConflict := apimeta.FindStatusCondition(subscription.Status.Conditions, ConditionConflictingRateLimitPolicy)
+	shouldEmitConflictEvent := currConflict != nil &&
+		currConflict.Status == metav1.ConditionTrue &&
+		(prevConflict == nil ||
+			prevConflict.Status != currConflict.Status ||
+			prevConflict.Message != currConflict.Message)
+	if shouldEmitConflictEvent && r.Recorder != nil {
+		var names []string
+		for _, c := range conflicts {
+			names = append(names, c.String())
+		}
+		r.Recorder.Eventf(subscription, "Warning", "ConflictingRateLimitPolicy",
+			"Detected non-MaaS rate limit policies on MaaS model routes: %s", strings.Join(names, "; "))
+	}
+	shouldEmitResolvedEvent := currConflict != nil &&
+		currConflict.Status == metav1.ConditionFalse &&
+		prevConflict != nil &&
+		prevConflict.Status == metav1.ConditionTrue
+	if shouldEmitResolvedEvent && r.Recorder != nil {
+		r.Recorder.Event(subscription, "Normal", "ConflictingRateLimitPolicyResolved",
+			"All conflicting rate limit policies on MaaS model routes have been resolved")
+	}
+	return conflicts
+}
+
+// markRefusedTokenRateLimits reports the TokenRateLimitPolicies that were not applied
+// because of conflicting policies, so the subscription is Degraded rather than waiting
+// for policies that will not be created.
+func markRefusedTokenRateLimits(statuses []maasv1alpha1.TokenRateLimitStatus, conflicts []conflictingPolicyInfo) {
+	refused := make(map[string][]string, len(conflicts))
+	for _, c := range conflicts {
+		k := c.Namespace + "/" + c.Model
+		refused[k] = append(refused[k], c.key())
+	}
+	for i := range statuses {
+		names, ok := refused[statuses[i].Namespace+"/"+statuses[i].Model]
+		if !ok {
+			continue
+		}
+		statuses[i].Ready = false
+		statuses[i].Reason = maasv1alpha1.ReasonConflictingPolicy
+		statuses[i].Message = fmt.Sprintf("not applied while non-MaaS rate limit policies target the model's HTTPRoute: %s", strings.Join(names, ", "))
+	}
+}
+
 func pluralY(n int) string {
 	if n == 1 {
 		return "y"
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -30,6 +31,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
 )
 
 // newRogueAuthPolicy creates a non-MaaS AuthPolicy targeting a specific HTTPRoute.
@@ -551,3 +554,154 @@ func TestPluralY(t *testing.T) {
 		t.Errorf("pluralY(0) = %q, want \"ies\"", pluralY(0))
 	}
 }
+
+// newRogueRateLimitPolicy creates a non-MaaS policy of the given kind targeting a specific HTTPRoute.
+func newRogueRateLimitPolicy(gvk schema.GroupVersionKind, name, namespace, httpRouteName string) *unstructured.Unstructured {
+	p := newRogueAuthPolicy(name, namespace, httpRouteName)
+	p.SetGroupVersionKind(gvk)
+	return p
+}
+
+// TestMaaSSubscriptionReconciler_ConflictingRateLimitPolicies verifies that user-created
+// TokenRateLimitPolicies and RateLimitPolicies on a model's HTTPRoute are reported, and
+// that in refuse mode the generated policy is not applied until they are removed.
+func TestMaaSSubscriptionReconciler_ConflictingRateLimitPolicies(t *testing.T) {
+	const (
+		modelName     = "llm"
+		namespace     = "default"
+		httpRouteName = "maas-" + modelName
+		subName       = "sub-a"
+	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
+
+	tests := []struct {
+		name       string
+		refuse     bool
+		rogue      *unstructured.Unstructured
+		wantReason string
+		wantTRLP   bool
+	}{
+		{
+			name:       "user TokenRateLimitPolicy is reported",
+			rogue:      newRogueRateLimitPolicy(kuadrantv1alpha1.TokenRateLimitPolicyGVK, "user-trlp", namespace, httpRouteName),
+			wantReason: "ConflictDetected",
+			wantTRLP:   true,
+		},
+		{
+			name:       "user RateLimitPolicy is reported",
+			rogue:      newRogueRateLimitPolicy(kuadrantv1.RateLimitPolicyGVK, "user-rlp", namespace, httpRouteName),
+			wantReason: "ConflictDetected",
+			wantTRLP:   true,
+		},
+		{
+			name:       "refuse mode does not apply the generated policy",
+			refuse:     true,
+			rogue:      newRogueRateLimitPolicy(kuadrantv1alpha1.TokenRateLimitPolicyGVK, "user-trlp", namespace, httpRouteName),
+			wantReason: "EnforcementRefused",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testRESTMapper()).
+				WithObjects(
+					newMaaSModelRef(modelName, namespace, "ExternalModel", modelName),
+					newHTTPRoute(httpRouteName, namespace),
+					newMaaSSubscription(subName, namespace, "team-a", modelName, 100),
+					tc.rogue,
+				).
+				WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+				WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+				Build()
+			r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, RefuseConflictingPolicies: tc.refuse}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: subName, Namespace: namespace}}
+
+			trlpExists := func() bool {
+				trlp := &unstructured.Unstructured{}
+				trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+				err := c.Get(ctx, types.NamespacedName{Name: trlpName, Namespace: namespace}, trlp)
+				if err != nil && !apierrors.IsNotFound(err) {
+					t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+				}
+				return err == nil
+			}
+			condition := func() (*metav1.Condition, *maasv1alpha1.MaaSSubscription) {
+				if _, err := r.Reconcile(ctx, req); err != nil {
+					t.Fatalf("Reconcile: %v", err)
+				}
+				sub := &maasv1alpha1.MaaSSubscription{}
+				if err := c.Get(ctx, req.NamespacedName, sub); err != nil {
+					t.Fatalf("Get MaaSSubscription: %v", err)
+				}
+				cond := apimeta.FindStatusCondition(sub.Status.Conditions, ConditionConflictingRateLimitPolicy)
+				if cond == nil {
+					t.Fatal("ConflictingRateLimitPolicy condition not found")
+				}
+				return cond, sub
+			}
+
+			cond, sub := condition()
+			if cond.Status != metav1.ConditionTrue || cond.Reason != tc.wantReason {
+				t.Errorf("condition = %s/%s, want True/%s", cond.Status, cond.Reason, tc.wantReason)
+			}
+			if want := tc.rogue.GetKind() + " " + namespace + "/" + tc.rogue.GetName(); !strings.Contains(cond.Message, want) {
+				t.Errorf("condition message %q does not name %q", cond.Message, want)
+			}
+			if got := trlpExists(); got != tc.wantTRLP {
+				t.Errorf("generated TokenRateLimitPolicy exists = %v, want %v", got, tc.wantTRLP)
+			}
+			if tc.refuse {
+				if len(sub.Status.TokenRateLimitStatuses) != 1 || sub.Status.TokenRateLimitStatuses[0].Reason != maasv1alpha1.ReasonConflictingPolicy {
+					t.Errorf("tokenRateLimitStatuses = %+v, want one with reason %s", sub.Status.TokenRateLimitStatuses, maasv1alpha1.ReasonConflictingPolicy)
+				}
+				if sub.Status.Phase != maasv1alpha1.PhaseDegraded {
+					t.Errorf("phase = %s, want %s", sub.Status.Phase, maasv1alpha1.PhaseDegraded)
+				}
+			}
+
+			if err := c.Delete(ctx, tc.rogue); err != nil {
+				t.Fatalf("Delete conflicting policy: %v", err)
+			}
+			cond, _ = condition()
+			if cond.Status != metav1.ConditionFalse {
+				t.Errorf("condition after removing the conflicting policy = %s, want False", cond.Status)
+			}
+			if !trlpExists() {
+				t.Error("generated TokenRateLimitPolicy not applied after removing the conflicting policy")
+			}
+		})
+	}
+}
+
+// TestMapRateLimitPolicyToMaaSSubscriptions verifies that a user-created policy maps to the
+// subscriptions of the models on its target route, so conflicts are re-evaluated.
+func TestMapRateLimitPolicyToMaaSSubscriptions(t *testing.T) {
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(
+			newMaaSModelRef(modelName, namespace, "ExternalModel", modelName),
+			newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100),
+		).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+
+	rogue := newRogueRateLimitPolicy(kuadrantv1.RateLimitPolicyGVK, "user-rlp", namespace, "maas-"+modelName)
+	requests := r.mapRateLimitPolicyToMaaSSubscriptions(context.Background(), rogue)
+	if len(requests) != 1 || requests[0].Name != "sub-a" {
+		t.Errorf("requests = %v, want sub-a", requests)
+	}
+
+	gatewayTarget := newRogueRateLimitPolicy(kuadrantv1.RateLimitPolicyGVK, "gateway-rlp", namespace, "gw")
+	_ = unstructured.SetNestedField(gatewayTarget.Object, "Gateway", "spec", "targetRef", "kind")
+	if requests := r.mapRateLimitPolicyToMaaSSubscriptions(context.Background(), gatewayTarget); len(requests) != 0 {
+		t.Errorf("requests for a Gateway-targeted policy = %v, want none", requests)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/tracing"
+)
+
+// DefaultPolicyHealthInterval is how often the generated policies are checked by default.
+const DefaultPolicyHealthInterval = time.Minute
+
+// Outcomes of a reconcile.
+const (
+	reconcileSuccess  = "success"
+	reconcileError    = "error"
+	reconcileConflict = "conflict"
+)
+
+var (
+	// reconcileTotal counts the reconciles of each MaaS resource kind by outcome.
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "maas_controller_reconcile_total",
+			Help: "Number of reconciles of a MaaS resource kind, by outcome: success, error, or conflict when the error was a write conflict.",
+		},
+		[]string{"kind", "outcome"},
+	)
+
+	// policiesNotEnforced is the number of generated Kuadrant policies whose Enforced
+	// condition is not True.
+	policiesNotEnforced = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "maas_controller_policies_not_enforced",
+			Help: "Number of Kuadrant policies generated by maas-controller whose Enforced condition is not True.",
+		},
+		[]string{"kind", "namespace"},
+	)
+
+	// orphanedResources is the number of generated resources whose MaaSModelRef no
+	// longer exists.
+	orphanedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "maas_controller_orphaned_resources",
+			Help: "Number of resources generated by maas-controller for a MaaSModelRef that no longer exists.",
+		},
+		[]string{"kind", "namespace"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileTotal, policiesNotEnforced, orphanedResources)
+}
+
+// observedReconciler counts the outcomes of the reconciles of a kind in
+// maas_controller_reconcile_total and traces each reconcile in a span.
+type observedReconciler struct {
+	kind string
+	reconcile.Reconciler
+}
+
+// observeReconcile wraps the reconciler of a kind to count its outcomes and trace it.
+func observeReconcile(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	for _, outcome := range []string{reconcileSuccess, reconcileError, reconcileConflict} {
+		reconcileTotal.WithLabelValues(kind, outcome)
+	}
+	return &observedReconciler{kind: kind, Reconciler: r}
+}
+
+func (o *observedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Reconcile "+o.kind, trace.WithAttributes(
+		attribute.String("k8s.resource.kind", o.kind),
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8s.resource.name", req.Name),
+		attribute.String("maas.reconcile.id", string(controller.ReconcileIDFromContext(ctx))),
+	))
+	defer span.End()
+
+	result, err := o.Reconciler.Reconcile(ctx, req)
+	outcome := reconcileOutcome(err)
+	reconcileTotal.WithLabelValues(o.kind, outcome).Inc()
+
+	span.SetAttributes(attribute.String("maas.reconcile.outcome", outcome))
+	if result.RequeueAfter > 0 {
+		span.SetAttributes(attribute.String("maas.reconcile.requeue_after", result.RequeueAfter.String()))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, outcome)
+	}
+	return result, err
+}
+
+// reconcileOutcome classifies the error of a reconcile. A conflict is a write that lost
+// the race against another writer of the resource; it is retried like any error but
+// is expected in small numbers.
+func reconcileOutcome(err error) string {
+	switch {
+	case err == nil:
+		return reconcileSuccess
+	case apierrors.IsConflict(err):
+		return reconcileConflict
+	default:
+		return reconcileError
+	}
+}
+
+// PolicyHealthCollector periodically sets maas_controller_policies_not_enforced and
+// maas_controller_orphaned_resources from the policies labeled as managed by
+// maas-controller. Only the leader collects, so that the gauges are not exported twice.
+type PolicyHealthCollector struct {
+	Client client.Reader
+	// PolicyGVKs are the kinds of the generated policies. Kinds whose CRD is not
+	// installed are skipped.
+	PolicyGVKs []schema.GroupVersionKind
+	Interval   time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (c *PolicyHealthCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start collects every Interval until ctx is done.
+func (c *PolicyHealthCollector) Start(ctx context.Context) error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("policy health interval must be positive, got %v", c.Interval)
+	}
+	log := ctrl.Log.WithName("policy-health-collector")
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		if err := c.collect(ctx); err != nil {
+			log.Error(err, "failed to collect generated policy health")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type kindNamespace struct{ kind, namespace string }
+
+// collect counts the generated policies that are not enforced and those whose model
+// is gone. Policies being deleted are left out of both.
+func (c *PolicyHealthCollector) collect(ctx context.Context) error {
+	models := &maasv1alpha1.MaaSModelRefList{}
+	if err := c.Client.List(ctx, models); err != nil {
+		return fmt.Errorf("failed to list MaaSModelRefs: %w", err)
+	}
+	existing := make(map[string]bool, len(models.Items))
+	for _, m := range models.Items {
+		existing[m.Namespace+"/"+m.Name] = true
+	}
+
+	notEnforced := map[kindNamespace]int{}
+	orphaned := map[kindNamespace]int{}
+	for _, gvk := range c.PolicyGVKs {
+		policies := &unstructured.UnstructuredList{}
+		policies.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.Client.List(ctx, policies, client.MatchingLabels{"app.kubernetes.io/managed-by": "maas-controller"}); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to list %s resources: %w", gvk.Kind, err)
+		}
+		for i := range policies.Items {
+			p := &policies.Items[i]
+			if !p.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+			key := kindNamespace{gvk.Kind, p.GetNamespace()}
+			if _, enforced := policyEnforcedAt(p); !enforced {
+				notEnforced[key]++
+			}
+			if model, ok := generatedPolicyModel(p); ok && !existing[model] {
+				orphaned[key]++
+			}
+		}
+	}
+
+	policiesNotEnforced.Reset()
+	for key, n := range notEnforced {
+		policiesNotEnforced.WithLabelValues(key.kind, key.namespace).Set(float64(n))
+	}
+	orphanedResources.Reset()
+	for key, n := range orphaned {
+		orphanedResources.WithLabelValues(key.kind, key.namespace).Set(float64(n))
+	}
+	return nil
+}
+
+// generatedPolicyModel returns the model, as "namespace/name", a generated policy was
+// created for, from its maas.opendatahub.io/model labels. Policies generated for a
+// gateway rather than a model have none.
+func generatedPolicyModel(policy *unstructured.Unstructured) (string, bool) {
+	labels := policy.GetLabels()
+	name := labels["maas.opendatahub.io/model"]
+	if name == "" {
+		return "", false
+	}
+	namespace := labels["maas.opendatahub.io/model-namespace"]
+	if namespace == "" {
+		namespace = policy.GetNamespace()
+	}
+	return namespace + "/" + name, true
+}
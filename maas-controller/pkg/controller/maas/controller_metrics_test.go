@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+func TestReconcileOutcome(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "maassubscriptions"}, "sub-a", errors.New("object was modified"))
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{nil, reconcileSuccess},
+		{errors.New("boom"), reconcileError},
+		{conflict, reconcileConflict},
+		{fmt.Errorf("failed to update status: %w", conflict), reconcileConflict},
+		{errors.Join(errors.New("model a"), conflict), reconcileConflict},
+	}
+	for _, tc := range cases {
+		if got := reconcileOutcome(tc.err); got != tc.want {
+			t.Errorf("reconcileOutcome(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestObserveReconcile(t *testing.T) {
+	var err error
+	r := observeReconcile("TestKind", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, err
+	}))
+	success := reconcileTotal.WithLabelValues("TestKind", reconcileSuccess)
+	failure := reconcileTotal.WithLabelValues("TestKind", reconcileError)
+	beforeSuccess, beforeFailure := testutil.ToFloat64(success), testutil.ToFloat64(failure)
+
+	_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+	err = errors.New("boom")
+	if _, got := r.Reconcile(context.Background(), reconcile.Request{}); !errors.Is(got, err) {
+		t.Errorf("Reconcile error = %v, want the reconciler's", got)
+	}
+
+	if got := testutil.ToFloat64(success) - beforeSuccess; got != 1 {
+		t.Errorf("success count increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(failure) - beforeFailure; got != 1 {
+		t.Errorf("error count increased by %v, want 1", got)
+	}
+}
+
+func TestObserveReconcileSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "maassubscriptions"}, "premium", errors.New("modified"))
+	r := observeReconcile("MaaSSubscription", reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			t.Error("the reconcile context carries no span")
+		}
+		return reconcile.Result{RequeueAfter: time.Minute}, conflict
+	}))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "models-as-a-service", Name: "premium"}}
+	_, _ = r.Reconcile(context.Background(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "Reconcile MaaSSubscription" {
+		t.Errorf("span name = %q, want %q", span.Name(), "Reconcile MaaSSubscription")
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want Error", span.Status().Code)
+	}
+	attrs := map[attribute.Key]string{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	for key, want := range map[attribute.Key]string{
+		"k8s.resource.kind":            "MaaSSubscription",
+		"k8s.namespace.name":           "models-as-a-service",
+		"k8s.resource.name":            "premium",
+		"maas.reconcile.outcome":       reconcileConflict,
+		"maas.reconcile.requeue_after": "1m0s",
+	} {
+		if attrs[key] != want {
+			t.Errorf("attribute %s = %q, want %q", key, attrs[key], want)
+		}
+	}
+}
+
+// generatedPolicy returns a policy labeled as generated for a model, Enforced or not.
+func generatedPolicy(gvk schema.GroupVersionKind, name, namespace, model string, enforced bool) *unstructured.Unstructured {
+	p := &unstructured.Unstructured{}
+	p.SetGroupVersionKind(gvk)
+	p.SetName(name)
+	p.SetNamespace(namespace)
+	labels := map[string]string{"app.kubernetes.io/managed-by": "maas-controller"}
+	if model != "" {
+		labels["maas.opendatahub.io/model"] = model
+	}
+	p.SetLabels(labels)
+	status := "False"
+	if enforced {
+		status = "True"
+	}
+	_ = unstructured.SetNestedSlice(p.Object, []any{map[string]any{
+		"type": "Enforced", "status": status, "lastTransitionTime": metav1.Now().UTC().Format(time.RFC3339),
+	}}, "status", "conditions")
+	return p
+}
+
+func TestPolicyHealthCollector(t *testing.T) {
+	model := newMaaSModelRef("granite", "llm", "ExternalModel", "granite")
+	unmanaged := generatedPolicy(kuadrantv1.AuthPolicyGVK, "hand-written", "llm", "", false)
+	unmanaged.SetLabels(nil)
+	objects := []client.Object{
+		model,
+		generatedPolicy(kuadrantv1.AuthPolicyGVK, "granite-auth", "llm", "granite", true),
+		generatedPolicy(kuadrantv1alpha1.TokenRateLimitPolicyGVK, "granite-trlp", "llm", "granite", false),
+		generatedPolicy(kuadrantv1.AuthPolicyGVK, "gone-auth", "llm", "gone", true),
+		generatedPolicy(kuadrantv1alpha1.TokenRateLimitPolicyGVK, "gone-trlp", "llm", "gone", false),
+		generatedPolicy(kuadrantv1.AuthPolicyGVK, "gateway-auth", "gateways", "", false),
+		unmanaged,
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(objects...).
+		Build()
+	collector := &PolicyHealthCollector{
+		Client:     c,
+		PolicyGVKs: []schema.GroupVersionKind{kuadrantv1.AuthPolicyGVK, kuadrantv1alpha1.TokenRateLimitPolicyGVK},
+	}
+
+	if err := collector.collect(context.Background()); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	gauges := []struct {
+		name        string
+		value, want float64
+	}{
+		{"not enforced TokenRateLimitPolicies", testutil.ToFloat64(policiesNotEnforced.WithLabelValues("TokenRateLimitPolicy", "llm")), 2},
+		{"not enforced gateway AuthPolicies", testutil.ToFloat64(policiesNotEnforced.WithLabelValues("AuthPolicy", "gateways")), 1},
+		{"not enforced model AuthPolicies", testutil.ToFloat64(policiesNotEnforced.WithLabelValues("AuthPolicy", "llm")), 0},
+		{"orphaned AuthPolicies", testutil.ToFloat64(orphanedResources.WithLabelValues("AuthPolicy", "llm")), 1},
+		{"orphaned TokenRateLimitPolicies", testutil.ToFloat64(orphanedResources.WithLabelValues("TokenRateLimitPolicy", "llm")), 1},
+	}
+	for _, g := range gauges {
+		if g.value != g.want {
+			t.Errorf("%s = %v, want %v", g.name, g.value, g.want)
+		}
+	}
+
+	// Once the model's resources are cleaned up, the gauges drop back.
+	for _, name := range []string{"gone-auth", "gone-trlp"} {
+		for _, o := range objects {
+			if o.GetName() == name {
+				if err := c.Delete(context.Background(), o); err != nil {
+					t.Fatalf("delete %s: %v", name, err)
+				}
+			}
+		}
+	}
+	if err := collector.collect(context.Background()); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if got := testutil.CollectAndCount(orphanedResources); got != 0 {
+		t.Errorf("orphaned series = %d, want 0", got)
+	}
+	if got := testutil.ToFloat64(policiesNotEnforced.WithLabelValues("TokenRateLimitPolicy", "llm")); got != 1 {
+		t.Errorf("not enforced TokenRateLimitPolicies = %v, want 1", got)
+	}
+}
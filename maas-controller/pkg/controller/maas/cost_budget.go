@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// PricePerTokenAnnotation on a MaaSModelRef is the price of one token as a decimal
+	// number, e.g. "0.000002". Subscriptions use it to convert cost budgets into tokens.
+	PricePerTokenAnnotation = "maas.opendatahub.io/price-per-token"
+
+	// PriceCurrencyAnnotation on a MaaSModelRef is the ISO 4217 currency of its price.
+	// Defaults to USD.
+	PriceCurrencyAnnotation = "maas.opendatahub.io/price-currency"
+
+	defaultCurrency = "USD"
+)
+
+var decimalPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// modelPrice is the price of one token of a model.
+type modelPrice struct {
+	perToken *big.Rat
+	currency string
+}
+
+// parsePositiveDecimal parses a plain decimal number such as "0.000002" exactly.
+func parsePositiveDecimal(s string) (*big.Rat, error) {
+	if !decimalPattern.MatchString(s) {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	v, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	if v.Sign() <= 0 {
+		return nil, fmt.Errorf("%q must be greater than zero", s)
+	}
+	return v, nil
+}
+
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return defaultCurrency
+	}
+	return currency
+}
+
+// modelPricing returns the per-token price set on a model, or nil if it has none.
+func modelPricing(model *maasv1alpha1.MaaSModelRef) (*modelPrice, error) {
+	raw, ok := model.GetAnnotations()[PricePerTokenAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	perToken, err := parsePositiveDecimal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on MaaSModelRef %s/%s: %w", PricePerTokenAnnotation, model.Namespace, model.Name, err)
+	}
+	return &modelPrice{perToken: perToken, currency: currencyOrDefault(model.GetAnnotations()[PriceCurrencyAnnotation])}, nil
+}
+
+// getModelPricing fetches a model and returns its price. A missing model has no price.
+// An invalid price is returned as nil: it only affects subscriptions with a cost budget,
+// which validateModelRefs reports as invalid.
+func getModelPricing(ctx context.Context, c client.Reader, modelNamespace, modelName string) (*modelPrice, error) {
+	model := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, types.NamespacedName{Name: modelName, Namespace: modelNamespace}, model); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get MaaSModelRef %s/%s: %w", modelNamespace, modelName, err)
+	}
+	price, err := modelPricing(model)
+	if err != nil {
+		return nil, nil
+	}
+	return price, nil
+}
+
+// validateCostBudget checks a cost budget independently of any model price.
+func validateCostBudget(budget *maasv1alpha1.CostBudget) error {
+	if _, err := parsePositiveDecimal(budget.Amount); err != nil {
+		return fmt.Errorf("invalid costBudget amount: %w", err)
+	}
+	if err := validateTokenRateLimit(1, budget.Window); err != nil {
+		return fmt.Errorf("invalid costBudget window: %w", err)
+	}
+	return nil
+}
+
+// costBudgetTokenRateLimit converts a cost budget into the number of tokens it buys at
+// the model's price, rounded down and capped at the maximum token limit.
+func costBudgetTokenRateLimit(budget *maasv1alpha1.CostBudget, price *modelPrice) (maasv1alpha1.TokenRateLimit, error) {
+	if err := validateCostBudget(budget); err != nil {
+		return maasv1alpha1.TokenRateLimit{}, err
+	}
+	if price == nil {
+		return maasv1alpha1.TokenRateLimit{}, errors.New("costBudget requires the model to set the " + PricePerTokenAnnotation + " annotation")
+	}
+	if currency := currencyOrDefault(budget.Currency); currency != price.currency {
+		return maasv1alpha1.TokenRateLimit{}, fmt.Errorf("costBudget currency %s does not match the model price currency %s", currency, price.currency)
+	}
+	amount, _ := parsePositiveDecimal(budget.Amount)
+	tokens := new(big.Rat).Quo(amount, price.perToken)
+	limit := new(big.Int).Quo(tokens.Num(), tokens.Denom())
+	if limit.Sign() == 0 {
+		return maasv1alpha1.TokenRateLimit{}, fmt.Errorf("costBudget %s %s buys less than one token", budget.Amount, currencyOrDefault(budget.Currency))
+	}
+	if !limit.IsInt64() || limit.Int64() > maxTokenRateLimit {
+		return maasv1alpha1.TokenRateLimit{Limit: maxTokenRateLimit, Window: budget.Window}, nil
+	}
+	return maasv1alpha1.TokenRateLimit{Limit: limit.Int64(), Window: budget.Window}, nil
+}
+
+// withCostBudget returns the token rates enforced for a modelRef: its token rates plus
+// the rate derived from its cost budget. The model price is per token of either
+// direction, so the derived rate counts Total tokens; when it shares a window with a
+// Total rate, the lower limit is kept.
+func withCostBudget(limits []maasv1alpha1.TokenRateLimit, budget *maasv1alpha1.CostBudget, price *modelPrice) ([]maasv1alpha1.TokenRateLimit, error) {
+	if budget == nil {
+		return limits, nil
+	}
+	derived, err := costBudgetTokenRateLimit(budget, price)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]maasv1alpha1.TokenRateLimit, 0, len(limits)+1)
+	merged := false
+	for _, trl := range limits {
+		if tokenDirection(trl) == maasv1alpha1.TokenDirectionTotal && sameWindow(trl.Window, derived.Window) {
+			trl.Limit = min(trl.Limit, derived.Limit)
+			merged = true
+		}
+		out = append(out, trl)
+	}
+	if !merged {
+		out = append(out, derived)
+	}
+	return out, nil
+}
+
+// sameWindow reports whether two windows have the same duration, e.g. "60m" and "1h".
+func sameWindow(a, b string) bool {
+	as, errA := windowSeconds(a)
+	bs, errB := windowSeconds(b)
+	return errA == nil && errB == nil && as == bs
+}
+
+// validateCostBudgetWindow checks that the rate derived from a cost budget can be
+// anchored to the reset schedule together with the modelRef's token rates. A budget
+// sharing a window with a Total token rate folds into that rate.
+func validateCostBudgetWindow(ref maasv1alpha1.ModelSubscriptionRef, schedule *maasv1alpha1.ResetSchedule) error {
+	for _, trl := range ref.TokenRateLimits {
+		if tokenDirection(trl) == maasv1alpha1.TokenDirectionTotal && sameWindow(trl.Window, ref.CostBudget.Window) {
+			return nil
+		}
+	}
+	limits := append([]maasv1alpha1.TokenRateLimit{{Limit: 1, Window: ref.CostBudget.Window}}, ref.TokenRateLimits...)
+	return validateAnchoredRates(limits, schedule)
+}
+
+// validateModelRefCostBudget checks that the cost budget of a modelRef can be converted
+// with the price of the referenced model.
+func validateModelRefCostBudget(ref maasv1alpha1.ModelSubscriptionRef, model *maasv1alpha1.MaaSModelRef) error {
+	if ref.CostBudget == nil {
+		return nil
+	}
+	price, err := modelPricing(model)
+	if err != nil {
+		return err
+	}
+	_, err = costBudgetTokenRateLimit(ref.CostBudget, price)
+	return err
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+func TestCostBudgetTokenRateLimit(t *testing.T) {
+	usd := &modelPrice{perToken: big.NewRat(2, 1000000), currency: "USD"}
+	tests := []struct {
+		name        string
+		budget      maasv1alpha1.CostBudget
+		price       *modelPrice
+		want        int64
+		errContains string
+	}{
+		{name: "whole tokens", budget: maasv1alpha1.CostBudget{Amount: "10", Window: "24h"}, price: usd, want: 5000000},
+		{name: "rounded down", budget: maasv1alpha1.CostBudget{Amount: "0.0000051", Currency: "USD", Window: "1h"}, price: usd, want: 2},
+		{name: "capped", budget: maasv1alpha1.CostBudget{Amount: "1000000", Window: "24h"}, price: usd, want: maxTokenRateLimit},
+		{name: "no price", budget: maasv1alpha1.CostBudget{Amount: "10", Window: "24h"}, errContains: PricePerTokenAnnotation},
+		{name: "currency mismatch", budget: maasv1alpha1.CostBudget{Amount: "10", Currency: "EUR", Window: "24h"}, price: usd, errContains: "does not match"},
+		{name: "less than one token", budget: maasv1alpha1.CostBudget{Amount: "0.000001", Window: "1h"}, price: usd, errContains: "less than one token"},
+		{name: "invalid amount", budget: maasv1alpha1.CostBudget{Amount: "1e3", Window: "1h"}, price: usd, errContains: "invalid costBudget amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := costBudgetTokenRateLimit(&tt.budget, tt.price)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("costBudgetTokenRateLimit: %v", err)
+			}
+			if got.Limit != tt.want || got.Window != tt.budget.Window {
+				t.Errorf("got %d/%s, want %d/%s", got.Limit, got.Window, tt.want, tt.budget.Window)
+			}
+		})
+	}
+}
+
+func TestWithCostBudget(t *testing.T) {
+	price := &modelPrice{perToken: big.NewRat(1, 1000), currency: "USD"}
+	limits := []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}, {Limit: 50000, Window: "1h"}}
+
+	got, err := withCostBudget(limits, &maasv1alpha1.CostBudget{Amount: "20", Window: "60m"}, price)
+	if err != nil {
+		t.Fatalf("withCostBudget: %v", err)
+	}
+	want := []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}, {Limit: 20000, Window: "1h"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("budget sharing a window = %v, want %v", got, want)
+	}
+
+	got, err = withCostBudget(limits, &maasv1alpha1.CostBudget{Amount: "500", Window: "24h"}, price)
+	if err != nil {
+		t.Fatalf("withCostBudget: %v", err)
+	}
+	want = append(append([]maasv1alpha1.TokenRateLimit{}, limits...), maasv1alpha1.TokenRateLimit{Limit: 500000, Window: "24h"})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("budget with its own window = %v, want %v", got, want)
+	}
+	if limits[1].Limit != 50000 {
+		t.Errorf("withCostBudget modified its input: %v", limits)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_CostBudget verifies that a cost budget is enforced as a
+// token rate derived from the model price, and that the modelRef is invalid without a price.
+func TestMaaSSubscriptionReconciler_CostBudget(t *testing.T) {
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	tests := []struct {
+		name            string
+		modelAnnotation map[string]string
+		wantRates       []kuadrantv1.Rate
+		wantReason      maasv1alpha1.ConditionReason
+	}{
+		{
+			name:            "priced model",
+			modelAnnotation: map[string]string{PricePerTokenAnnotation: "0.00001"},
+			wantRates:       []kuadrantv1.Rate{{Limit: 100, Window: "1m"}, {Limit: 2500000, Window: "24h"}},
+			wantReason:      maasv1alpha1.ReasonValid,
+		},
+		{
+			name:       "model without a price",
+			wantReason: maasv1alpha1.ReasonInvalidRateLimits,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+			model.Annotations = tc.modelAnnotation
+			sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+			sub.Spec.ModelRefs[0].CostBudget = &maasv1alpha1.CostBudget{Amount: "25", Currency: "USD", Window: "24h"}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testRESTMapper()).
+				WithObjects(model, newHTTPRoute("maas-"+modelName, namespace), sub).
+				WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+				WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+				Build()
+			r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+
+			got := &maasv1alpha1.MaaSSubscription{}
+			if err := c.Get(context.Background(), req.NamespacedName, got); err != nil {
+				t.Fatalf("Get MaaSSubscription: %v", err)
+			}
+			if len(got.Status.ModelRefStatuses) != 1 || got.Status.ModelRefStatuses[0].Reason != tc.wantReason {
+				t.Fatalf("modelRefStatuses = %+v, want reason %s", got.Status.ModelRefStatuses, tc.wantReason)
+			}
+
+			trlp := &unstructured.Unstructured{}
+			trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+			err := c.Get(context.Background(), types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}, trlp)
+			if tc.wantRates == nil {
+				if err == nil {
+					t.Errorf("expected no TokenRateLimitPolicy for a subscription whose budget cannot be converted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+			}
+			spec := &kuadrantv1alpha1.TokenRateLimitPolicySpec{}
+			specMap, _, _ := unstructured.NestedMap(trlp.Object, "spec")
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, spec); err != nil {
+				t.Fatalf("decode TokenRateLimitPolicy spec: %v", err)
+			}
+			rates := spec.Limits[subscriptionLimitKey(namespace, "sub-a", modelName, "tokens")].Rates
+			if !reflect.DeepEqual(rates, tc.wantRates) {
+				t.Errorf("rates = %v, want %v", rates, tc.wantRates)
+			}
+		})
+	}
+}
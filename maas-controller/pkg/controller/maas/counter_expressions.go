@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"regexp"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// counterExpressionPattern accepts the expressions a subscription may key its counters
+// on: an attribute of the identity that the AuthPolicy passes on, such as
+// auth.identity.keyId, or a request header lookup such as request.headers["x-org-id"].
+// Anything else could read unrelated request data or fail at evaluation.
+var counterExpressionPattern = regexp.MustCompile(
+	`^(auth\.identity(\.[A-Za-z_][A-Za-z0-9_]*)+|request\.headers\["[a-z0-9][a-z0-9-]*"\])$`)
+
+// ValidateCounterExpressions validates spec.counterExpressions. The MaaSSubscription
+// webhook runs the same checks at admission.
+func ValidateCounterExpressions(expressions []string) error {
+	seen := make(map[string]struct{}, len(expressions))
+	for _, expr := range expressions {
+		if !counterExpressionPattern.MatchString(expr) {
+			return fmt.Errorf(`counter expression %q must be an auth.identity attribute or a request.headers["<name>"] lookup`, expr)
+		}
+		if _, dup := seen[expr]; dup {
+			return fmt.Errorf("counter expression %q is listed twice", expr)
+		}
+		seen[expr] = struct{}{}
+	}
+	return nil
+}
+
+// subscriptionCounters returns the counters of a subscription's limits: its counter
+// expressions when set, else those of its counter scope.
+func subscriptionCounters(sub *maasv1alpha1.MaaSSubscription) []kuadrantv1.Counter {
+	if len(sub.Spec.CounterExpressions) == 0 {
+		return trlpCounters(sub.Spec.CounterScope)
+	}
+	counters := make([]kuadrantv1.Counter, 0, len(sub.Spec.CounterExpressions))
+	for _, expr := range sub.Spec.CounterExpressions {
+		counters = append(counters, kuadrantv1.Counter{Expression: expr})
+	}
+	return counters
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"reflect"
+	"testing"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestValidateCounterExpressions(t *testing.T) {
+	tests := []struct {
+		expressions []string
+		wantErr     bool
+	}{
+		{expressions: nil},
+		{expressions: []string{"auth.identity.keyId", "auth.identity.subscription_info.organizationId"}},
+		{expressions: []string{`request.headers["x-org-id"]`}},
+		{expressions: []string{"auth.identity"}, wantErr: true},
+		{expressions: []string{"request.body"}, wantErr: true},
+		{expressions: []string{`request.headers["X-Org"]`}, wantErr: true},
+		{expressions: []string{`auth.identity.userid + request.path`}, wantErr: true},
+		{expressions: []string{"auth.identity.keyId", "auth.identity.keyId"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidateCounterExpressions(tt.expressions); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateCounterExpressions(%q) error = %v, wantErr %v", tt.expressions, err, tt.wantErr)
+		}
+	}
+}
+
+// TestBuildTRLPSpec_CounterExpressions verifies that counter expressions replace the
+// counter scope, including on anchored limits.
+func TestBuildTRLPSpec_CounterExpressions(t *testing.T) {
+	sub := newMaaSSubscription("sub", "default", "team-a", "llm", 0)
+	sub.Spec.CounterScope = maasv1alpha1.CounterScopeGroup
+	sub.Spec.CounterExpressions = []string{"auth.identity.keyId"}
+	sub.Spec.ResetSchedule = &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily}
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}, {Limit: 100000, Window: "24h"}}
+
+	spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route")
+	if spec == nil {
+		t.Fatal("expected a TRLP spec")
+	}
+	if got, want := spec.Limits["default-sub-llm-tokens"].Counters, []kuadrantv1.Counter{{Expression: "auth.identity.keyId"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rolling counters = %v, want %v", got, want)
+	}
+	want := []kuadrantv1.Counter{{Expression: "auth.identity.keyId"}, {Expression: resetPeriodExpression(sub.Spec.ResetSchedule)}}
+	if got := spec.Limits["default-sub-llm-tokens-daily"].Counters; !reflect.DeepEqual(got, want) {
+		t.Errorf("anchored counters = %v, want %v", got, want)
+	}
+
+	sub.Spec.CounterExpressions = []string{"request.body"}
+	if spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route"); spec != nil {
+		t.Errorf("expected a subscription with an invalid counter expression to be skipped, got %v", spec)
+	}
+}
@@ -343,9 +343,11 @@ func TestMaaSSubscriptionReconciler_CrossNamespace(t *testing.T) {
 		modelNamespaceB = "model-ns-b"
 		modelName       = "test-model"
 		httpRouteName   = "maas-" + modelName
-		trlpName        = "maas-trlp-" + modelName
 		subName         = "cross-ns-subscription"
 	)
+	// Generated names hash the model's namespace, so the two TRLPs differ.
+	trlpNameA := tokenRateLimitPolicyName(modelNamespaceA, modelName, "")
+	trlpNameB := tokenRateLimitPolicyName(modelNamespaceB, modelName, "")
 
 	// Model and HTTPRoute in namespace-a
 	modelA := &maasv1alpha1.MaaSModelRef{
@@ -418,21 +420,21 @@ func TestMaaSSubscriptionReconciler_CrossNamespace(t *testing.T) {
 	// Verify TokenRateLimitPolicy created in modelNamespaceA
 	trlpA := &unstructured.Unstructured{}
 	trlpA.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
-	if err := c.Get(context.Background(), types.NamespacedName{Name: trlpName, Namespace: modelNamespaceA}, trlpA); err != nil {
+	if err := c.Get(context.Background(), types.NamespacedName{Name: trlpNameA, Namespace: modelNamespaceA}, trlpA); err != nil {
 		t.Errorf("TokenRateLimitPolicy in namespace %q not found: %v", modelNamespaceA, err)
 	}
 
 	// Verify TokenRateLimitPolicy created in modelNamespaceB
 	trlpB := &unstructured.Unstructured{}
 	trlpB.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
-	if err := c.Get(context.Background(), types.NamespacedName{Name: trlpName, Namespace: modelNamespaceB}, trlpB); err != nil {
+	if err := c.Get(context.Background(), types.NamespacedName{Name: trlpNameB, Namespace: modelNamespaceB}, trlpB); err != nil {
 		t.Errorf("TokenRateLimitPolicy in namespace %q not found: %v", modelNamespaceB, err)
 	}
 
 	// Verify NO TokenRateLimitPolicy created in the subscription namespace
 	wrongNsTRLP := &unstructured.Unstructured{}
 	wrongNsTRLP.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
-	err := c.Get(context.Background(), types.NamespacedName{Name: trlpName, Namespace: subNamespace}, wrongNsTRLP)
+	err := c.Get(context.Background(), types.NamespacedName{Name: trlpNameA, Namespace: subNamespace}, wrongNsTRLP)
 	if err == nil {
 		t.Errorf("TokenRateLimitPolicy should NOT be created in subscription namespace %q, but it exists", subNamespace)
 	} else if !apierrors.IsNotFound(err) {
@@ -455,11 +457,11 @@ func TestMaaSSubscriptionReconciler_DuplicateNameIsolation(t *testing.T) {
 		modelName        = "llm"
 		modelNamespace   = "models"
 		httpRouteName    = "maas-" + modelName
-		trlpName         = "maas-trlp-" + modelName
 		subscriptionName = "gold" // SAME name in both namespaces
 		namespaceA       = "tenant-a"
 		namespaceB       = "tenant-b"
 	)
+	trlpName := tokenRateLimitPolicyName(modelNamespace, modelName, "")
 
 	// Model and HTTPRoute (shared by both subscriptions)
 	model := &maasv1alpha1.MaaSModelRef{
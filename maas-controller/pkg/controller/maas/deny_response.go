@@ -0,0 +1,324 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// celUnauthorizedReason and celUnauthorizedMessage describe why a request was
+	// refused, from the subscription selection of maas-api when it failed.
+	celUnauthorizedReason  = `has(auth.metadata["subscription-info"].error) ? auth.metadata["subscription-info"].error : "unauthorized"`
+	celUnauthorizedMessage = `has(auth.metadata["subscription-info"].message) ? auth.metadata["subscription-info"].message : "Access denied"`
+
+	unauthenticatedMessage = "Authentication required"
+)
+
+// denyResponsePlaceholder matches the placeholders of a deny response body template.
+var denyResponsePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z]*)\s*\}\}`)
+
+// denyResponseKind is a kind of denial with the CEL expressions of its placeholders.
+type denyResponseKind struct {
+	Name         string
+	Get          func(*maasv1alpha1.DenyResponses) *maasv1alpha1.DenyResponse
+	Placeholders map[string]string
+}
+
+var (
+	celRequestedModel = `(` + celModelIdentityAvailable + ` ? ` + celModelIdentity + ` : "")`
+
+	unauthenticatedDenial = denyResponseKind{
+		Name: "unauthenticated",
+		Get:  func(r *maasv1alpha1.DenyResponses) *maasv1alpha1.DenyResponse { return r.Unauthenticated },
+		Placeholders: map[string]string{
+			"model":   celRequestedModel,
+			"reason":  `"unauthenticated"`,
+			"message": strconv.Quote(unauthenticatedMessage),
+		},
+	}
+	unauthorizedDenial = denyResponseKind{
+		Name: "unauthorized",
+		Get:  func(r *maasv1alpha1.DenyResponses) *maasv1alpha1.DenyResponse { return r.Unauthorized },
+		Placeholders: map[string]string{
+			"model":   celRequestedModel,
+			"reason":  `(` + celUnauthorizedReason + `)`,
+			"message": `(` + celUnauthorizedMessage + `)`,
+		},
+	}
+)
+
+// denyResponsePolicy is the spec.denyResponse of a MaaSAuthPolicy with the models, as
+// "namespace/name", it applies to.
+type denyResponsePolicy struct {
+	PolicyName string
+	Priority   int32
+	Models     []string
+	Responses  *maasv1alpha1.DenyResponses
+}
+
+// ValidateDenyResponses checks the parts of deny responses the CRD schema cannot.
+func ValidateDenyResponses(responses *maasv1alpha1.DenyResponses) error {
+	if responses == nil {
+		return nil
+	}
+	for _, kind := range []denyResponseKind{unauthenticatedDenial, unauthorizedDenial} {
+		if err := validateDenyResponse(kind.Get(responses)); err != nil {
+			return fmt.Errorf("%s: %w", kind.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateDenyResponse(r *maasv1alpha1.DenyResponse) error {
+	if r == nil {
+		return nil
+	}
+	if r.Body != "" {
+		for _, m := range denyResponsePlaceholder.FindAllStringSubmatch(r.Body, -1) {
+			if _, ok := unauthorizedDenial.Placeholders[m[1]]; !ok && m[1] != "docsUrl" {
+				return fmt.Errorf("body: unknown placeholder %s, want {{model}}, {{reason}}, {{message}} or {{docsUrl}}", m[0])
+			}
+		}
+		if !json.Valid([]byte(denyResponsePlaceholder.ReplaceAllString(r.Body, "x"))) {
+			return fmt.Errorf("body is not a JSON template")
+		}
+	}
+	seen := make(map[string]bool, len(r.Headers))
+	for i, h := range r.Headers {
+		name := strings.ToLower(h.Name)
+		if seen[name] || (name == "link" && r.DocsURL != "") {
+			return fmt.Errorf("headers[%d]: duplicate header %q", i, h.Name)
+		}
+		seen[name] = true
+		if strings.ContainsAny(h.Value, "\r\n") {
+			return fmt.Errorf("headers[%d]: value of %q must not contain line breaks", i, h.Name)
+		}
+	}
+	return nil
+}
+
+// aggregateDenyResponses returns the deny responses of the policies, by priority, highest
+// first, then by policy name.
+func aggregateDenyResponses(policies []maasv1alpha1.MaaSAuthPolicy) ([]denyResponsePolicy, error) {
+	var out []denyResponsePolicy
+	for _, p := range policies {
+		if p.Spec.DenyResponse == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := ValidateDenyResponses(p.Spec.DenyResponse); err != nil {
+			return nil, fmt.Errorf("invalid MaaSAuthPolicy %s/%s: denyResponse.%w", p.Namespace, p.Name, err)
+		}
+		entry := denyResponsePolicy{PolicyName: p.Name, Priority: p.Spec.Priority, Responses: p.Spec.DenyResponse}
+		for _, ref := range p.Spec.ModelRefs {
+			entry.Models = append(entry.Models, ref.Namespace+"/"+ref.Name)
+		}
+		entry.Models = deduplicateAndSort(entry.Models)
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Priority != out[j].Priority {
+			return out[i].Priority > out[j].Priority
+		}
+		return out[i].PolicyName < out[j].PolicyName
+	})
+	return out, nil
+}
+
+// celJSONStringEscape escapes the string expression for a JSON string.
+func celJSONStringEscape(expr string) string {
+	return expr + `.replace("\\", "\\\\").replace("\"", "\\\"").replace("\n", "\\n")`
+}
+
+// jsonStringEscape escapes s for a JSON string.
+func jsonStringEscape(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b[1 : len(b)-1])
+}
+
+// celDenyBody compiles a body template into a CEL string expression.
+func celDenyBody(r *maasv1alpha1.DenyResponse, kind denyResponseKind) string {
+	var parts []string
+	literal := func(s string) {
+		if s != "" {
+			parts = append(parts, strconv.Quote(s))
+		}
+	}
+	last := 0
+	for _, m := range denyResponsePlaceholder.FindAllStringSubmatchIndex(r.Body, -1) {
+		literal(r.Body[last:m[0]])
+		name := r.Body[m[2]:m[3]]
+		if name == "docsUrl" {
+			literal(jsonStringEscape(r.DocsURL))
+		} else {
+			parts = append(parts, celJSONStringEscape(kind.Placeholders[name]))
+		}
+		last = m[1]
+	}
+	literal(r.Body[last:])
+	if len(parts) == 0 {
+		return `""`
+	}
+	return strings.Join(parts, " + ")
+}
+
+// celValueFrom is the CEL expression of a response value.
+func celValueFrom(v *kuadrantv1.ValueFrom) string {
+	if v == nil {
+		return `""`
+	}
+	if v.Expression != "" {
+		return "(" + v.Expression + ")"
+	}
+	return strconv.Quote(v.Value)
+}
+
+// modelDenyResponse is a deny response with the models it is sent for.
+type modelDenyResponse struct {
+	Models   []string
+	Response *maasv1alpha1.DenyResponse
+}
+
+// resolveDenyResponses picks, for each model, the response of the kind of its policy
+// with the highest priority setting one.
+func resolveDenyResponses(policies []denyResponsePolicy, kind denyResponseKind) []modelDenyResponse {
+	var out []modelDenyResponse
+	claimed := map[string]bool{}
+	for _, p := range policies {
+		r := kind.Get(p.Responses)
+		if r == nil {
+			continue
+		}
+		var models []string
+		for _, m := range p.Models {
+			if !claimed[m] {
+				claimed[m] = true
+				models = append(models, m)
+			}
+		}
+		if len(models) > 0 {
+			out = append(out, modelDenyResponse{Models: models, Response: r})
+		}
+	}
+	return out
+}
+
+// celDenyResponseChoice is the value of the first response whose models include the
+// requested model, or def.
+func celDenyResponseChoice(responses []modelDenyResponse, value func(*maasv1alpha1.DenyResponse) (string, bool), def string) string {
+	expr := def
+	for i := len(responses) - 1; i >= 0; i-- {
+		if v, ok := value(responses[i].Response); ok {
+			expr = "(" + celRequestedModelIn(responses[i].Models) + " ? " + v + " : " + expr + ")"
+		}
+	}
+	return expr
+}
+
+// applyDenyResponse replaces the parts of a gateway AuthPolicy denial that the policies
+// customize with expressions choosing each model's response.
+func applyDenyResponse(deny *kuadrantv1.DenyWith, policies []denyResponsePolicy, kind denyResponseKind) {
+	responses := resolveDenyResponses(policies, kind)
+	if len(responses) == 0 {
+		return
+	}
+	for _, p := range policies {
+		if r := kind.Get(p.Responses); r != nil && r.StatusCode != 0 {
+			deny.Code = int64(r.StatusCode)
+			break
+		}
+	}
+
+	headers := map[string]bool{}
+	customBody := false
+	for _, r := range responses {
+		for _, h := range r.Response.Headers {
+			headers[strings.ToLower(h.Name)] = true
+		}
+		if r.Response.DocsURL != "" {
+			headers["link"] = true
+		}
+		if r.Response.Body != "" {
+			customBody = true
+			headers["content-type"] = true
+		}
+	}
+	if customBody {
+		defaultBody := deny.Body
+		if defaultBody == nil {
+			defaultBody = deny.Message
+		}
+		body := func(r *maasv1alpha1.DenyResponse) (string, bool) { return celDenyBody(r, kind), r.Body != "" }
+		deny.Body = &kuadrantv1.ValueFrom{Expression: celDenyResponseChoice(responses, body, celValueFrom(defaultBody))}
+	}
+
+	if deny.Headers == nil {
+		deny.Headers = map[string]kuadrantv1.ValueFrom{}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := func(r *maasv1alpha1.DenyResponse) (string, bool) {
+			for _, h := range r.Headers {
+				if strings.EqualFold(h.Name, name) {
+					return strconv.Quote(h.Value), true
+				}
+			}
+			switch {
+			case name == "link" && r.DocsURL != "":
+				return strconv.Quote("<" + r.DocsURL + `>; rel="help"`), true
+			case name == "content-type" && r.Body != "":
+				return `"application/json"`, true
+			}
+			return "", false
+		}
+		def := `""`
+		if existing, ok := deny.Headers[name]; ok {
+			def = celValueFrom(&existing)
+		} else if name == "content-type" {
+			def = `"text/plain"`
+		}
+		deny.Headers[name] = kuadrantv1.ValueFrom{Expression: celDenyResponseChoice(responses, value, def)}
+	}
+}
+
+// applyDenyResponses applies the spec.denyResponse of the policies to the denials of the
+// gateway AuthPolicy.
+func (a gatewayAuthorization) applyDenyResponses(response *kuadrantv1.ResponseRules) {
+	if len(a.DenyResponses) == 0 {
+		return
+	}
+	if response.Unauthenticated == nil {
+		response.Unauthenticated = &kuadrantv1.DenyWith{Code: 401}
+	}
+	if response.Unauthorized == nil {
+		response.Unauthorized = &kuadrantv1.DenyWith{Code: 403}
+	}
+	applyDenyResponse(response.Unauthenticated, a.DenyResponses, unauthenticatedDenial)
+	applyDenyResponse(response.Unauthorized, a.DenyResponses, unauthorizedDenial)
+}
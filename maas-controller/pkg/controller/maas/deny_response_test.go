@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestValidateDenyResponses(t *testing.T) {
+	valid := &maasv1alpha1.DenyResponses{
+		Unauthenticated: &maasv1alpha1.DenyResponse{
+			Headers: []maasv1alpha1.DenyResponseHeader{{Name: "WWW-Authenticate", Value: `Bearer realm="maas"`}},
+		},
+		Unauthorized: &maasv1alpha1.DenyResponse{
+			StatusCode: 404,
+			Body:       `{"error": {"code": "{{ reason }}", "model": "{{model}}", "docs": "{{docsUrl}}"}}`,
+			DocsURL:    "https://docs.example.com/access",
+		},
+	}
+	if err := ValidateDenyResponses(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for name, r := range map[string]*maasv1alpha1.DenyResponse{
+		"unknown placeholder": {Body: `{"user": "{{user}}"}`},
+		"not JSON":            {Body: `error: {{message}}`},
+		"duplicate header":    {Headers: []maasv1alpha1.DenyResponseHeader{{Name: "X-Reason", Value: "a"}, {Name: "x-reason", Value: "b"}}},
+		"link and docsUrl":    {DocsURL: "https://docs.example.com", Headers: []maasv1alpha1.DenyResponseHeader{{Name: "Link", Value: "<https://other>"}}},
+		"line break":          {Headers: []maasv1alpha1.DenyResponseHeader{{Name: "X-Reason", Value: "a\r\nSet-Cookie: b"}}},
+	} {
+		if err := ValidateDenyResponses(&maasv1alpha1.DenyResponses{Unauthorized: r}); err == nil || !strings.HasPrefix(err.Error(), "unauthorized: ") {
+			t.Errorf("%s: err = %v, want the unauthorized response rejected", name, err)
+		}
+	}
+}
+
+func TestCelDenyBody(t *testing.T) {
+	r := &maasv1alpha1.DenyResponse{Body: `{"reason": "{{reason}}", "docs": "{{docsUrl}}"}`, DocsURL: `https://docs.example.com/a"b`}
+	got := celDenyBody(r, unauthenticatedDenial)
+	want := `"{\"reason\": \"" + "unauthenticated".replace("\\", "\\\\").replace("\"", "\\\"").replace("\n", "\\n") + "\", \"docs\": \"" + "https://docs.example.com/a\\\"b" + "\"}"`
+	if got != want {
+		t.Errorf("celDenyBody =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAggregateDenyResponses_Priority(t *testing.T) {
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	other := maasv1alpha1.ModelRef{Name: "other", Namespace: "default"}
+	team := newMaaSAuthPolicy("team", "default", "team-a", llm, other)
+	team.Spec.DenyResponse = &maasv1alpha1.DenyResponses{Unauthorized: &maasv1alpha1.DenyResponse{StatusCode: 404, Body: `{"error": "team"}`}}
+	platform := newMaaSAuthPolicy("platform", "default", "team-b", llm)
+	platform.Spec.Priority = 10
+	platform.Spec.DenyResponse = &maasv1alpha1.DenyResponses{Unauthorized: &maasv1alpha1.DenyResponse{Body: `{"error": "platform"}`}}
+
+	policies, err := aggregateDenyResponses([]maasv1alpha1.MaaSAuthPolicy{*team, *platform})
+	if err != nil {
+		t.Fatalf("aggregateDenyResponses: %v", err)
+	}
+	if len(policies) != 2 || policies[0].PolicyName != "platform" {
+		t.Fatalf("got %+v, want the higher priority policy first", policies)
+	}
+	resolved := resolveDenyResponses(policies, unauthorizedDenial)
+	if len(resolved) != 2 || strings.Join(resolved[0].Models, ",") != "default/llm" || strings.Join(resolved[1].Models, ",") != "default/other" {
+		t.Errorf("resolved = %+v, want llm answered by platform and other by team", resolved)
+	}
+	if got := resolveDenyResponses(policies, unauthenticatedDenial); len(got) != 0 {
+		t.Errorf("unauthenticated = %+v, want none", got)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_DenyResponse(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	unchanged := spec.Defaults.Rules.Response
+	if got := unchanged.Unauthorized.Body.Expression; got != celUnauthorizedMessage {
+		t.Errorf("default unauthorized body = %s, want the subscription message", got)
+	}
+
+	authz := gatewayAuthorization{DenyResponses: []denyResponsePolicy{{
+		PolicyName: "llm-access",
+		Models:     []string{"llm/granite"},
+		Responses: &maasv1alpha1.DenyResponses{
+			Unauthenticated: &maasv1alpha1.DenyResponse{
+				Headers: []maasv1alpha1.DenyResponseHeader{{Name: "WWW-Authenticate", Value: `Bearer realm="maas"`}},
+			},
+			Unauthorized: &maasv1alpha1.DenyResponse{
+				StatusCode: 404,
+				Body:       `{"error": "{{message}}"}`,
+				DocsURL:    "https://docs.example.com/access",
+			},
+		},
+	}}}
+	spec = r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, authz, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	granite := celRequestedModelIn([]string{"llm/granite"})
+
+	unauthenticated := spec.Defaults.Rules.Response.Unauthenticated
+	if unauthenticated.Code != 401 || unauthenticated.Body != nil {
+		t.Errorf("unauthenticated = %+v, want the default code and message", unauthenticated)
+	}
+	if got, want := unauthenticated.Headers["www-authenticate"].Expression, `(`+granite+` ? "Bearer realm=\"maas\"" : "")`; got != want {
+		t.Errorf("www-authenticate = %s, want %s", got, want)
+	}
+
+	unauthorized := spec.Defaults.Rules.Response.Unauthorized
+	if unauthorized.Code != 404 {
+		t.Errorf("unauthorized code = %d, want 404", unauthorized.Code)
+	}
+	wantBody := `(` + granite + ` ? ` + celDenyBody(authz.DenyResponses[0].Responses.Unauthorized, unauthorizedDenial) + ` : (` + celUnauthorizedMessage + `))`
+	if got := unauthorized.Body.Expression; got != wantBody {
+		t.Errorf("unauthorized body =\n%s\nwant\n%s", got, wantBody)
+	}
+	if got, want := unauthorized.Headers["content-type"].Expression, `(`+granite+` ? "application/json" : "text/plain")`; got != want {
+		t.Errorf("content-type = %s, want %s", got, want)
+	}
+	if got, want := unauthorized.Headers["link"].Expression, `(`+granite+` ? "<https://docs.example.com/access>; rel=\"help\"" : "")`; got != want {
+		t.Errorf("link = %s, want %s", got, want)
+	}
+	if got := unauthorized.Headers["x-ext-auth-reason"].Expression; got != celUnauthorizedReason {
+		t.Errorf("x-ext-auth-reason = %s, want it kept", got)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// generatedSpecHashAnnotation records a hash of the spec the controller last wrote
+	// to a generated AuthPolicy or TokenRateLimitPolicy. It lets the controller tell
+	// its own spec changes apart from edits made by someone else.
+	generatedSpecHashAnnotation = "maas.opendatahub.io/generated-spec-hash"
+
+	// reasonGeneratedPolicyDrift is the event reason emitted when a managed generated
+	// policy was modified outside the controller and is about to be overwritten.
+	reasonGeneratedPolicyDrift = "GeneratedPolicyDrift"
+)
+
+// generatedPolicyDriftTotal counts how often a managed generated policy was found to
+// have been modified outside the controller.
+var generatedPolicyDriftTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "maas_controller_generated_policy_drift_total",
+		Help: "Number of times a generated Kuadrant policy was found modified outside the controller and reverted.",
+	},
+	[]string{"kind", "namespace", "name"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(generatedPolicyDriftTotal)
+}
+
+// generatedSpecHash returns a stable hash of a generated policy spec.
+// encoding/json sorts map keys, so equal specs always hash the same.
+func generatedSpecHash(spec map[string]any) (string, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec for hashing: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// generatedPolicyDrifted reports whether the live policy spec was changed by someone
+// other than the controller. Drift is only reported when the controller's desired
+// spec is the one it last wrote (the hash annotation matches) and the live spec
+// differs from it; a legitimate spec change from the controller itself is not drift.
+func generatedPolicyDrifted(live *unstructured.Unstructured, desiredHash string) bool {
+	if live.GetAnnotations()[generatedSpecHashAnnotation] != desiredHash {
+		return false
+	}
+	liveSpec, _, err := unstructured.NestedMap(live.Object, "spec")
+	if err != nil {
+		return true
+	}
+	liveHash, err := generatedSpecHash(liveSpec)
+	if err != nil {
+		return true
+	}
+	return liveHash != desiredHash
+}
+
+// recordGeneratedPolicyDrift increments the drift counter and emits a Warning event
+// on the drifted policy so platform teams can spot tampering or misbehaving automation.
+func recordGeneratedPolicyDrift(log logr.Logger, recorder record.EventRecorder, policy *unstructured.Unstructured) {
+	kind := policy.GetKind()
+	generatedPolicyDriftTotal.WithLabelValues(kind, policy.GetNamespace(), policy.GetName()).Inc()
+	log.Info("generated policy was modified outside the controller, reverting",
+		"kind", kind, "name", policy.GetName(), "namespace", policy.GetNamespace())
+	if recorder != nil {
+		recorder.Eventf(policy, "Warning", reasonGeneratedPolicyDrift,
+			"%s %s/%s was modified outside maas-controller; the change is being reverted. Set annotation %s=false to manage it manually.",
+			kind, policy.GetNamespace(), policy.GetName(), ManagedByODHOperator)
+	}
+}
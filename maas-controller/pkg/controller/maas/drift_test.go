@@ -0,0 +1,253 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// drainEvents returns all events currently buffered in the fake recorder.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestGeneratedPolicyDrifted(t *testing.T) {
+	spec := map[string]any{"targetRef": map[string]any{"kind": "HTTPRoute", "name": "maas-llm"}}
+	hash, err := generatedSpecHash(spec)
+	if err != nil {
+		t.Fatalf("generatedSpecHash: %v", err)
+	}
+
+	newPolicy := func(liveSpec map[string]any, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{}}
+		u.SetAnnotations(annotations)
+		_ = unstructured.SetNestedMap(u.Object, liveSpec, "spec")
+		return u
+	}
+	tampered := map[string]any{"targetRef": map[string]any{"kind": "HTTPRoute", "name": "other"}}
+
+	tests := []struct {
+		name string
+		live *unstructured.Unstructured
+		want bool
+	}{
+		{"unchanged", newPolicy(spec, map[string]string{generatedSpecHashAnnotation: hash}), false},
+		{"modified_externally", newPolicy(tampered, map[string]string{generatedSpecHashAnnotation: hash}), true},
+		{"missing_hash_annotation", newPolicy(tampered, nil), false},
+		{"controller_spec_changed", newPolicy(tampered, map[string]string{generatedSpecHashAnnotation: "stale"}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generatedPolicyDrifted(tt.live, hash); got != tt.want {
+				t.Errorf("generatedPolicyDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMaaSSubscriptionReconciler_TRLPDrift verifies that an external edit to a managed
+// TokenRateLimitPolicy is reverted, counted, and reported with a Warning event, while
+// a spec change driven by the controller itself is not reported as drift.
+func TestMaaSSubscriptionReconciler_TRLPDrift(t *testing.T) {
+	const (
+		modelName     = "llm"
+		namespace     = "default"
+		httpRouteName = "maas-" + modelName
+	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
+
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute(httpRouteName, namespace)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	counter := generatedPolicyDriftTotal.WithLabelValues("TokenRateLimitPolicy", namespace, trlpName)
+	before := testutil.ToFloat64(counter)
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	key := types.NamespacedName{Name: trlpName, Namespace: namespace}
+	if err := c.Get(ctx, key, trlp); err != nil {
+		t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+	}
+	if trlp.GetAnnotations()[generatedSpecHashAnnotation] == "" {
+		t.Fatalf("expected %s annotation on generated TokenRateLimitPolicy", generatedSpecHashAnnotation)
+	}
+
+	// Controller-driven change: raising the subscription limit is not drift.
+	if err := c.Get(ctx, req.NamespacedName, sub); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	sub.Spec.ModelRefs[0].TokenRateLimits[0].Limit = 500
+	if err := c.Update(ctx, sub); err != nil {
+		t.Fatalf("Update MaaSSubscription: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after subscription change: %v", err)
+	}
+	if got := testutil.ToFloat64(counter) - before; got != 0 {
+		t.Errorf("drift counter incremented by %v after controller-driven change, want 0", got)
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("expected no events after controller-driven change, got %v", events)
+	}
+
+	// External change: tamper with the limits directly.
+	if err := c.Get(ctx, key, trlp); err != nil {
+		t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+	}
+	if err := unstructured.SetNestedMap(trlp.Object, map[string]any{}, "spec", "limits"); err != nil {
+		t.Fatalf("SetNestedMap: %v", err)
+	}
+	if err := c.Update(ctx, trlp); err != nil {
+		t.Fatalf("Update TokenRateLimitPolicy: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after tampering: %v", err)
+	}
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("drift counter incremented by %v after external edit, want 1", got)
+	}
+	events := drainEvents(recorder)
+	if len(events) != 1 || !strings.Contains(events[0], "Warning "+reasonGeneratedPolicyDrift) {
+		t.Errorf("expected one %s Warning event, got %v", reasonGeneratedPolicyDrift, events)
+	}
+
+	// The tampered spec was reverted.
+	if err := c.Get(ctx, key, trlp); err != nil {
+		t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+	}
+	limits, _, _ := unstructured.NestedMap(trlp.Object, "spec", "limits")
+	if len(limits) == 0 {
+		t.Error("expected tampered TokenRateLimitPolicy limits to be restored")
+	}
+}
+
+// TestMaaSAuthPolicyReconciler_GatewayAuthPolicyDrift verifies that an external edit to
+// the managed gateway AuthPolicy is counted and reported with a Warning event.
+func TestMaaSAuthPolicyReconciler_GatewayAuthPolicyDrift(t *testing.T) {
+	const (
+		modelName      = "llm"
+		namespace      = "default"
+		gatewayNS      = "gateway-ns"
+		gatewayName    = "maas-default-gateway"
+		httpRouteName  = "maas-" + modelName
+		maasPolicyName = "policy-a"
+	)
+
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute(httpRouteName, namespace)
+	maasPolicy := newMaaSAuthPolicy(maasPolicyName, namespace, "team-a",
+		maasv1alpha1.ModelRef{Name: modelName, Namespace: namespace})
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, maasPolicy).
+		WithStatusSubresource(&maasv1alpha1.MaaSAuthPolicy{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &MaaSAuthPolicyReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		MaaSAPINamespace: "maas-system",
+		GatewayNamespace: gatewayNS,
+		GatewayName:      gatewayName,
+		Recorder:         recorder,
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: maasPolicyName, Namespace: namespace}}
+	counter := generatedPolicyDriftTotal.WithLabelValues("AuthPolicy", gatewayNS, maasGatewayAuthPolicyName)
+	before := testutil.ToFloat64(counter)
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	ap := &unstructured.Unstructured{}
+	ap.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	key := types.NamespacedName{Name: maasGatewayAuthPolicyName, Namespace: gatewayNS}
+	if err := c.Get(ctx, key, ap); err != nil {
+		t.Fatalf("Get gateway AuthPolicy: %v", err)
+	}
+	if err := unstructured.SetNestedField(ap.Object, "tampered", "spec", "targetRef", "name"); err != nil {
+		t.Fatalf("SetNestedField: %v", err)
+	}
+	if err := c.Update(ctx, ap); err != nil {
+		t.Fatalf("Update gateway AuthPolicy: %v", err)
+	}
+	drainEvents(recorder)
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after tampering: %v", err)
+	}
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("drift counter incremented by %v after external edit, want 1", got)
+	}
+	var driftEvents int
+	for _, e := range drainEvents(recorder) {
+		if strings.Contains(e, "Warning "+reasonGeneratedPolicyDrift) {
+			driftEvents++
+		}
+	}
+	if driftEvents != 1 {
+		t.Errorf("expected one %s Warning event, got %d", reasonGeneratedPolicyDrift, driftEvents)
+	}
+
+	if err := c.Get(ctx, key, ap); err != nil {
+		t.Fatalf("Get gateway AuthPolicy: %v", err)
+	}
+	if name, _, _ := unstructured.NestedString(ap.Object, "spec", "targetRef", "name"); name != gatewayName {
+		t.Errorf("expected targetRef.name to be restored to %q, got %q", gatewayName, name)
+	}
+}
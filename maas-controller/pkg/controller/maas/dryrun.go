@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// DryRunAnnotation, when set to "true" on a MaaSSubscription or MaaSAuthPolicy,
+	// makes the controller render the resources it would generate into
+	// status.dryRunPreview instead of applying them.
+	DryRunAnnotation = "maas.opendatahub.io/dry-run"
+
+	// ConditionDryRun is set True while a MaaSSubscription or MaaSAuthPolicy is in dry-run mode.
+	ConditionDryRun = "DryRun"
+)
+
+// isDryRun reports whether obj has opted into dry-run mode.
+func isDryRun(obj metav1.Object) bool {
+	return obj.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+// dryRunAnnotationChanged triggers reconciliation when the dry-run annotation is
+// toggled; annotation changes do not bump metadata.generation.
+var dryRunAnnotationChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		return isDryRun(e.ObjectOld) != isDryRun(e.ObjectNew)
+	},
+}
+
+// renderPreview builds a GeneratedResourcePreview with content rendered as JSON.
+func renderPreview(kind, name, namespace, model string, content any) (maasv1alpha1.GeneratedResourcePreview, error) {
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return maasv1alpha1.GeneratedResourcePreview{}, fmt.Errorf("failed to render %s %s/%s preview: %w", kind, namespace, name, err)
+	}
+	return maasv1alpha1.GeneratedResourcePreview{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Model:     model,
+		Rendered:  string(raw),
+	}, nil
+}
+
+// setDryRunCondition sets the DryRun condition while dry-run is enabled and removes
+// it once the annotation is cleared.
+func setDryRunCondition(conditions *[]metav1.Condition, generation int64, enabled bool, previews int) {
+	if !enabled {
+		apimeta.RemoveStatusCondition(conditions, ConditionDryRun)
+		return
+	}
+	apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DryRunEnabled",
+		Message:            fmt.Sprintf("%d generated resource(s) rendered to status.dryRunPreview and not applied; remove the %s annotation to enforce", previews, DryRunAnnotation),
+		ObservedGeneration: generation,
+	})
+}
+
+// excludeDryRunSubscriptions drops dry-run subscriptions so they never contribute
+// limits to an applied TokenRateLimitPolicy.
+func excludeDryRunSubscriptions(subs []maasv1alpha1.MaaSSubscription) []maasv1alpha1.MaaSSubscription {
+	out := subs[:0:0]
+	for _, s := range subs {
+		if !isDryRun(&s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// excludeDryRunAuthPolicies drops dry-run auth policies so they never contribute
+// subjects to an applied AuthPolicy.
+func excludeDryRunAuthPolicies(policies []maasv1alpha1.MaaSAuthPolicy) []maasv1alpha1.MaaSAuthPolicy {
+	out := policies[:0:0]
+	for _, p := range policies {
+		if !isDryRun(&p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestDryRunAnnotationChanged(t *testing.T) {
+	plain := &maasv1alpha1.MaaSSubscription{ObjectMeta: metav1.ObjectMeta{Name: "s"}}
+	dryRun := plain.DeepCopy()
+	dryRun.Annotations = map[string]string{DryRunAnnotation: "true"}
+	other := plain.DeepCopy()
+	other.Annotations = map[string]string{"example.com/unrelated": "x"}
+
+	tests := []struct {
+		name     string
+		old, new *maasv1alpha1.MaaSSubscription
+		want     bool
+	}{
+		{"enabled", plain, dryRun, true},
+		{"disabled", dryRun, plain, true},
+		{"unchanged", dryRun, dryRun, false},
+		{"unrelated_annotation", plain, other, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dryRunAnnotationChanged.Update(event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}); got != tt.want {
+				t.Errorf("dryRunAnnotationChanged.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMaaSSubscriptionReconciler_DryRun verifies that a dry-run subscription renders its
+// TokenRateLimitPolicy into status without creating it, and that clearing the annotation
+// applies the policy and removes the preview.
+func TestMaaSSubscriptionReconciler_DryRun(t *testing.T) {
+	const (
+		modelName     = "llm"
+		namespace     = "default"
+		httpRouteName = "maas-" + modelName
+	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
+
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute(httpRouteName, namespace)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+	sub.Annotations = map[string]string{DryRunAnnotation: "true"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	key := types.NamespacedName{Name: trlpName, Namespace: namespace}
+	if err := c.Get(ctx, key, trlp); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no TokenRateLimitPolicy in dry-run mode, got err=%v", err)
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if got.Status.Phase != maasv1alpha1.PhasePending {
+		t.Errorf("Phase = %q, want %q", got.Status.Phase, maasv1alpha1.PhasePending)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, ConditionDryRun)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected %s condition True, got %+v", ConditionDryRun, cond)
+	}
+	if len(got.Status.DryRunPreview) != 1 {
+		t.Fatalf("expected 1 dry-run preview, got %d", len(got.Status.DryRunPreview))
+	}
+	preview := got.Status.DryRunPreview[0]
+	if preview.Kind != "TokenRateLimitPolicy" || preview.Name != trlpName || preview.Namespace != namespace {
+		t.Errorf("unexpected preview target %s %s/%s", preview.Kind, preview.Namespace, preview.Name)
+	}
+	if !strings.Contains(preview.Rendered, "default-sub-a-llm-tokens") {
+		t.Errorf("expected preview to contain the subscription limit key, got %s", preview.Rendered)
+	}
+
+	// Clearing the annotation enforces the subscription.
+	got.Annotations = nil
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("Update MaaSSubscription: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after clearing dry-run: %v", err)
+	}
+	if err := c.Get(ctx, key, trlp); err != nil {
+		t.Fatalf("expected TokenRateLimitPolicy after clearing dry-run: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if len(got.Status.DryRunPreview) != 0 {
+		t.Errorf("expected dry-run preview to be cleared, got %d entries", len(got.Status.DryRunPreview))
+	}
+	if apimeta.FindStatusCondition(got.Status.Conditions, ConditionDryRun) != nil {
+		t.Errorf("expected %s condition to be removed", ConditionDryRun)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_DryRunExcludedFromAggregation verifies that a dry-run
+// subscription never contributes limits to the TRLP built for an enforced subscription.
+func TestMaaSSubscriptionReconciler_DryRunExcludedFromAggregation(t *testing.T) {
+	const (
+		modelName     = "llm"
+		namespace     = "default"
+		httpRouteName = "maas-" + modelName
+	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
+
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute(httpRouteName, namespace)
+	live := newMaaSSubscription("sub-live", namespace, "team-a", modelName, 100)
+	dryRun := newMaaSSubscription("sub-dry", namespace, "team-b", modelName, 200)
+	dryRun.Annotations = map[string]string{DryRunAnnotation: "true"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, live, dryRun).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	for _, name := range []string{"sub-live", "sub-dry"} {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}); err != nil {
+			t.Fatalf("Reconcile %s: %v", name, err)
+		}
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	if err := c.Get(ctx, types.NamespacedName{Name: trlpName, Namespace: namespace}, trlp); err != nil {
+		t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+	}
+	limits, _, _ := unstructured.NestedMap(trlp.Object, "spec", "limits")
+	if _, ok := limits["default-sub-live-llm-tokens"]; !ok {
+		t.Errorf("expected enforced subscription limit in TRLP, got keys %v", getKeys(limits))
+	}
+	if _, ok := limits["default-sub-dry-llm-tokens"]; ok {
+		t.Errorf("dry-run subscription must not contribute to the applied TRLP, got keys %v", getKeys(limits))
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "sub-dry", Namespace: namespace}, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if len(got.Status.DryRunPreview) != 1 {
+		t.Fatalf("expected 1 dry-run preview, got %d", len(got.Status.DryRunPreview))
+	}
+	rendered := got.Status.DryRunPreview[0].Rendered
+	if !strings.Contains(rendered, "default-sub-live-llm-tokens") || !strings.Contains(rendered, "default-sub-dry-llm-tokens") {
+		t.Errorf("expected preview to aggregate enforced and dry-run limits, got %s", rendered)
+	}
+}
+
+// TestMaaSAuthPolicyReconciler_DryRun verifies that a dry-run MaaSAuthPolicy is excluded
+// from the applied access rules and renders its would-be access rules into status.
+func TestMaaSAuthPolicyReconciler_DryRun(t *testing.T) {
+	const (
+		modelName      = "llm"
+		namespace      = "default"
+		gatewayNS      = "gateway-ns"
+		gatewayName    = "maas-default-gateway"
+		httpRouteName  = "maas-" + modelName
+		maasPolicyName = "policy-a"
+	)
+
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute(httpRouteName, namespace)
+	maasPolicy := newMaaSAuthPolicy(maasPolicyName, namespace, "team-dry",
+		maasv1alpha1.ModelRef{Name: modelName, Namespace: namespace})
+	maasPolicy.Annotations = map[string]string{DryRunAnnotation: "true"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, maasPolicy).
+		WithStatusSubresource(&maasv1alpha1.MaaSAuthPolicy{}).
+		Build()
+
+	r := &MaaSAuthPolicyReconciler{
+		Client:           c,
+		Scheme:           scheme,
+		MaaSAPINamespace: "maas-system",
+		GatewayNamespace: gatewayNS,
+		GatewayName:      gatewayName,
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: maasPolicyName, Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	allowlists, err := r.aggregateModelSubjectAllowlists(ctx, namespace)
+	if err != nil {
+		t.Fatalf("aggregateModelSubjectAllowlists: %v", err)
+	}
+	if _, ok := allowlists[namespace+"/"+modelName]; ok {
+		t.Errorf("dry-run MaaSAuthPolicy must not contribute to applied access rules, got %v", allowlists)
+	}
+
+	got := &maasv1alpha1.MaaSAuthPolicy{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSAuthPolicy: %v", err)
+	}
+	if got.Status.Phase != maasv1alpha1.PhasePending {
+		t.Errorf("Phase = %q, want %q", got.Status.Phase, maasv1alpha1.PhasePending)
+	}
+	if len(got.Status.DryRunPreview) != 1 {
+		t.Fatalf("expected 1 dry-run preview, got %d", len(got.Status.DryRunPreview))
+	}
+	preview := got.Status.DryRunPreview[0]
+	if preview.Kind != "AuthPolicy" || preview.Name != maasGatewayAuthPolicyName || preview.Namespace != gatewayNS {
+		t.Errorf("unexpected preview target %s %s/%s", preview.Kind, preview.Namespace, preview.Name)
+	}
+	if preview.Model != namespace+"/"+modelName || !strings.Contains(preview.Rendered, "team-dry") {
+		t.Errorf("expected preview for %s/%s containing group team-dry, got %+v", namespace, modelName, preview)
+	}
+}
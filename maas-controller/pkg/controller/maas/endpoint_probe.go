@@ -0,0 +1,300 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// DefaultEndpointProbeTimeout bounds a single endpoint probe request.
+const DefaultEndpointProbeTimeout = 5 * time.Second
+
+const (
+	// reasonEndpointHealthy is the Degraded=False reason after a successful probe.
+	reasonEndpointHealthy = "EndpointHealthy"
+	// reasonEndpointProbeFailed is the Degraded=True reason when the probe failed.
+	reasonEndpointProbeFailed = "EndpointProbeFailed"
+)
+
+// EndpointProber checks whether a model endpoint is serving. statusCode is 0 when
+// no HTTP response was received; err is then set.
+type EndpointProber interface {
+	Probe(ctx context.Context, endpoint string) (statusCode int, err error)
+}
+
+// HTTPEndpointProber probes <endpoint>/v1/models with a GET request.
+type HTTPEndpointProber struct {
+	Client *http.Client
+	// TokenFile, when set, is read on every probe and sent as a Bearer token so the
+	// probe passes gateway authentication. Without it the gateway answers 401 and the
+	// probe only verifies that the route is served.
+	TokenFile string
+}
+
+// NewHTTPEndpointProber returns an HTTPEndpointProber with the given request timeout.
+func NewHTTPEndpointProber(timeout time.Duration, tokenFile string) *HTTPEndpointProber {
+	if timeout <= 0 {
+		timeout = DefaultEndpointProbeTimeout
+	}
+	return &HTTPEndpointProber{
+		Client: &http.Client{
+			Timeout: timeout,
+			// A redirect would carry the probe to a host other than the gateway.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		TokenFile: tokenFile,
+	}
+}
+
+// Probe sends GET <endpoint>/v1/models and returns the response status code.
+func (p *HTTPEndpointProber) Probe(ctx context.Context, endpoint string) (int, error) {
+	target, err := url.JoinPath(endpoint, "v1", "models")
+	if err != nil {
+		return 0, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	if p.TokenFile != "" {
+		token, err := os.ReadFile(p.TokenFile)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read probe token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// probeFailed reports whether a probe result means the endpoint is broken. 404 means
+// the gateway has no route for the model and 5xx means the route or backend failed;
+// 401/403/429 come from gateway policies in front of a served route and are not failures.
+func probeFailed(statusCode int, err error) bool {
+	return err != nil || statusCode == http.StatusNotFound || statusCode >= http.StatusInternalServerError
+}
+
+// endpointProbeResult is the outcome of the last probe of a tracked endpoint.
+type endpointProbeResult struct {
+	statusCode int
+	err        error
+}
+
+// endpointProbeTarget is a model endpoint the monitor probes.
+type endpointProbeTarget struct {
+	endpoint string
+	result   *endpointProbeResult
+}
+
+// EndpointProbeMonitor probes the endpoints of Ready MaaSModelRefs every Interval,
+// outside the reconcile loop, and requeues a model when its result changes. The
+// reconciler only tracks endpoints it derived from the gateway, never
+// spec.endpointOverride, so the probe token is only ever sent to the gateway.
+type EndpointProbeMonitor struct {
+	Prober   EndpointProber
+	Interval time.Duration
+
+	mu      sync.Mutex
+	targets map[types.NamespacedName]*endpointProbeTarget
+	// wake is signaled when a new endpoint is tracked, so it is probed without
+	// waiting for the next interval.
+	wake chan struct{}
+	// events requeues the models whose probe result changed.
+	events chan event.GenericEvent
+}
+
+// NewEndpointProbeMonitor returns a monitor probing with prober every interval.
+func NewEndpointProbeMonitor(prober EndpointProber, interval time.Duration) *EndpointProbeMonitor {
+	return &EndpointProbeMonitor{
+		Prober:   prober,
+		Interval: interval,
+		targets:  map[types.NamespacedName]*endpointProbeTarget{},
+		wake:     make(chan struct{}, 1),
+		events:   make(chan event.GenericEvent, 64),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The monitor runs
+// alongside the MaaSModelRef controller, which only runs on the leader.
+func (m *EndpointProbeMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start probes the tracked endpoints every Interval until ctx is done.
+func (m *EndpointProbeMonitor) Start(ctx context.Context) error {
+	if m.Interval <= 0 {
+		return fmt.Errorf("endpoint probe interval must be positive, got %v", m.Interval)
+	}
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.probe(ctx, false)
+		case <-m.wake:
+			m.probe(ctx, true)
+		}
+	}
+}
+
+// probe probes the tracked endpoints, only those never probed when pendingOnly is set,
+// and requeues the models whose result changed.
+func (m *EndpointProbeMonitor) probe(ctx context.Context, pendingOnly bool) {
+	m.mu.Lock()
+	pending := make(map[types.NamespacedName]string, len(m.targets))
+	for key, target := range m.targets {
+		if !pendingOnly || target.result == nil {
+			pending[key] = target.endpoint
+		}
+	}
+	m.mu.Unlock()
+
+	for key, endpoint := range pending {
+		statusCode, err := m.Prober.Probe(ctx, endpoint)
+		if ctx.Err() != nil {
+			return
+		}
+		result := &endpointProbeResult{statusCode: statusCode, err: err}
+
+		m.mu.Lock()
+		target, ok := m.targets[key]
+		changed := ok && target.endpoint == endpoint && !sameProbeResult(target.result, result)
+		if changed {
+			target.result = result
+		}
+		m.mu.Unlock()
+		if !changed {
+			continue
+		}
+		select {
+		case m.events <- event.GenericEvent{Object: &maasv1alpha1.MaaSModelRef{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sameProbeResult reports whether two probe results yield the same Degraded condition.
+func sameProbeResult(a, b *endpointProbeResult) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.statusCode != b.statusCode || (a.err == nil) != (b.err == nil) {
+		return false
+	}
+	return a.err == nil || a.err.Error() == b.err.Error()
+}
+
+// track starts probing endpoint for the model. The last result is dropped when the
+// endpoint changed.
+func (m *EndpointProbeMonitor) track(key types.NamespacedName, endpoint string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if target, ok := m.targets[key]; ok && target.endpoint == endpoint {
+		return
+	}
+	m.targets[key] = &endpointProbeTarget{endpoint: endpoint}
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// forget stops probing the model's endpoint.
+func (m *EndpointProbeMonitor) forget(key types.NamespacedName) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.targets, key)
+}
+
+// result returns the last probe result of endpoint for the model, if it was probed.
+func (m *EndpointProbeMonitor) result(key types.NamespacedName, endpoint string) (endpointProbeResult, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	target, ok := m.targets[key]
+	if !ok || target.endpoint != endpoint || target.result == nil {
+		return endpointProbeResult{}, false
+	}
+	return *target.result, true
+}
+
+// reportEndpointHealth tracks the endpoint of a Ready model in the probe monitor and
+// records its last probe result in the Degraded condition. The phase is left alone: a
+// degraded model is still deployed, and the condition lets the catalog tell it apart
+// from a healthy one. spec.endpointOverride is user-controlled and can point anywhere,
+// so overridden endpoints are never probed.
+func (r *MaaSModelRefReconciler) reportEndpointHealth(model *maasv1alpha1.MaaSModelRef) {
+	key := client.ObjectKeyFromObject(model)
+	if r.EndpointProbes == nil || model.Status.Phase != "Ready" || model.Status.Endpoint == "" || model.Spec.EndpointOverride != "" {
+		r.EndpointProbes.forget(key)
+		// Degraded only describes a Ready model's probed endpoint.
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionDegraded)
+		return
+	}
+	r.EndpointProbes.track(key, model.Status.Endpoint)
+	result, ok := r.EndpointProbes.result(key, model.Status.Endpoint)
+	if !ok {
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionDegraded)
+		return
+	}
+
+	endpoint := strings.TrimSuffix(model.Status.Endpoint, "/")
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonEndpointHealthy,
+		Message:            fmt.Sprintf("GET %s/v1/models returned HTTP %d", endpoint, result.statusCode),
+		ObservedGeneration: model.GetGeneration(),
+	}
+	if probeFailed(result.statusCode, result.err) {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = reasonEndpointProbeFailed
+		if result.err != nil {
+			cond.Message = fmt.Sprintf("GET %s/v1/models failed: %v", endpoint, result.err)
+		}
+	}
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+}
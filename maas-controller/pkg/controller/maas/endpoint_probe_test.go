@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// fakeProber returns a fixed probe result and records the probed endpoints.
+type fakeProber struct {
+	statusCode int
+	err        error
+	probed     []string
+}
+
+func (f *fakeProber) Probe(_ context.Context, endpoint string) (int, error) {
+	f.probed = append(f.probed, endpoint)
+	return f.statusCode, f.err
+}
+
+func TestHTTPEndpointProber_Probe(t *testing.T) {
+	var gotPath, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("probe-token\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	p := NewHTTPEndpointProber(time.Second, tokenFile)
+
+	code, err := p.Probe(context.Background(), srv.URL+"/llm/my-model")
+	if err != nil {
+		t.Fatalf("Probe: %v", err)
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("status code = %d, want 503", code)
+	}
+	if gotPath != "/llm/my-model/v1/models" {
+		t.Errorf("probed path = %q, want /llm/my-model/v1/models", gotPath)
+	}
+	if gotAuth != "Bearer probe-token" {
+		t.Errorf("Authorization = %q, want Bearer probe-token", gotAuth)
+	}
+}
+
+func TestProbeFailed(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{statusCode: http.StatusOK, want: false},
+		{statusCode: http.StatusUnauthorized, want: false},
+		{statusCode: http.StatusForbidden, want: false},
+		{statusCode: http.StatusTooManyRequests, want: false},
+		{statusCode: http.StatusNotFound, want: true},
+		{statusCode: http.StatusInternalServerError, want: true},
+		{statusCode: http.StatusServiceUnavailable, want: true},
+		{err: errors.New("connection refused"), want: true},
+	}
+	for _, tt := range tests {
+		if got := probeFailed(tt.statusCode, tt.err); got != tt.want {
+			t.Errorf("probeFailed(%d, %v) = %v, want %v", tt.statusCode, tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestMaaSModelRefReconciler_EndpointProbe verifies that the reconcile does not probe
+// itself, that a Ready model whose endpoint fails the monitor's probe stays Ready but
+// reports Degraded=True with the status code and recovers to Degraded=False, and that an
+// endpoint override is never probed.
+func TestMaaSModelRefReconciler_EndpointProbe(t *testing.T) {
+	const testKind = "_test_endpoint_probe_kind"
+	backendHandlerFactories[testKind] = func(_ *MaaSModelRefReconciler) BackendHandler {
+		return &fakeHandler{endpoint: "https://maas.example.com/default/probed", ready: true}
+	}
+	defer delete(backendHandlerFactories, testKind)
+
+	ctx := context.Background()
+	model := newMaaSModelRef("probed", "default", testKind, "backend")
+	sub := newMaaSSubscription("sub1", "admin-ns", "team-a", "probed", 100)
+	sub.Spec.ModelRefs[0].Namespace = "default"
+	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a", maasv1alpha1.ModelRef{Name: "probed", Namespace: "default"})
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, sub, auth).
+		WithStatusSubresource(&maasv1alpha1.MaaSModelRef{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, modelRefIndexKey, subscriptionModelRefIndexer).
+		Build()
+	prober := &fakeProber{statusCode: http.StatusServiceUnavailable}
+	monitor := NewEndpointProbeMonitor(prober, time.Minute)
+	r := &MaaSModelRefReconciler{
+		Client: c, Scheme: scheme, GatewayName: testGatewayName, GatewayNamespace: testGatewayNamespace,
+		EndpointProbes: monitor,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "probed", Namespace: "default"}}
+	degraded := func() *metav1.Condition {
+		t.Helper()
+		got := &maasv1alpha1.MaaSModelRef{}
+		if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.Status.Phase != "Ready" {
+			t.Errorf("Phase = %q, want Ready (degraded models stay deployed)", got.Status.Phase)
+		}
+		return apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionDegraded)
+	}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(prober.probed) != 0 {
+		t.Errorf("probed endpoints = %v, want none during the reconcile", prober.probed)
+	}
+	if cond := degraded(); cond != nil {
+		t.Errorf("expected no Degraded condition before the first probe, got %+v", cond)
+	}
+
+	monitor.probe(ctx, true)
+	if len(prober.probed) != 1 || prober.probed[0] != "https://maas.example.com/default/probed" {
+		t.Errorf("probed endpoints = %v, want the model endpoint", prober.probed)
+	}
+	select {
+	case e := <-monitor.events:
+		if e.Object.GetName() != "probed" || e.Object.GetNamespace() != "default" {
+			t.Errorf("requeued %s/%s, want default/probed", e.Object.GetNamespace(), e.Object.GetName())
+		}
+	default:
+		t.Fatal("expected the changed probe result to requeue the model")
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if cond := degraded(); cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reasonEndpointProbeFailed || !strings.Contains(cond.Message, "503") {
+		t.Errorf("expected Degraded=True with status code 503, got %+v", cond)
+	}
+
+	monitor.probe(ctx, false)
+	if len(monitor.events) != 0 {
+		t.Errorf("an unchanged probe result must not requeue the model")
+	}
+
+	prober.statusCode = http.StatusOK
+	monitor.probe(ctx, false)
+	<-monitor.events
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after recovery: %v", err)
+	}
+	if cond := degraded(); cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != reasonEndpointHealthy {
+		t.Errorf("expected Degraded=False after a successful probe, got %+v", cond)
+	}
+
+	// An endpoint override is user-controlled: it is never probed and the condition is removed.
+	current := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	current.Spec.EndpointOverride = "https://attacker.example.com"
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile with endpoint override: %v", err)
+	}
+	if cond := degraded(); cond != nil {
+		t.Errorf("expected Degraded to be removed with an endpoint override, got %+v", cond)
+	}
+	prober.probed = nil
+	monitor.probe(ctx, false)
+	if len(prober.probed) != 0 {
+		t.Errorf("probed endpoints = %v, want none with an endpoint override", prober.probed)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// policyEnforcementSeconds records how long generated policies take to be enforced.
+var policyEnforcementSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "maas_controller_policy_enforcement_duration_seconds",
+		Help:    "Time from the creation of a generated Kuadrant policy until Kuadrant first reports it Enforced.",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 14),
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(policyEnforcementSeconds)
+}
+
+// enforcementObserver records the time to enforcement of each generated policy once.
+// Only policies that became enforced after the observer was created are recorded, so a
+// restart does not record the policies that were already enforced again.
+type enforcementObserver struct {
+	since time.Time
+
+	mu       sync.Mutex
+	observed map[types.UID]struct{}
+}
+
+var policyEnforcement = newEnforcementObserver(time.Now())
+
+func newEnforcementObserver(since time.Time) *enforcementObserver {
+	return &enforcementObserver{since: since, observed: make(map[types.UID]struct{})}
+}
+
+// observe records the policy's time to enforcement if Kuadrant reports it Enforced and
+// it has not been recorded yet. It reports whether a duration was recorded.
+func (o *enforcementObserver) observe(policy *unstructured.Unstructured) bool {
+	enforcedAt, ok := policyEnforcedAt(policy)
+	if !ok || enforcedAt.Before(o.since) {
+		return false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, done := o.observed[policy.GetUID()]; done {
+		return false
+	}
+	o.observed[policy.GetUID()] = struct{}{}
+	latency := max(enforcedAt.Sub(policy.GetCreationTimestamp().Time), 0)
+	policyEnforcementSeconds.WithLabelValues(policy.GetKind()).Observe(latency.Seconds())
+	return true
+}
+
+// policyEnforcedAt returns the lastTransitionTime of the policy's Enforced condition when
+// the condition is True.
+func policyEnforcedAt(policy *unstructured.Unstructured) (time.Time, bool) {
+	conditions, _, _ := unstructured.NestedSlice(policy.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok || cond["type"] != "Enforced" {
+			continue
+		}
+		if cond["status"] != "True" {
+			return time.Time{}, false
+		}
+		raw, _ := cond["lastTransitionTime"].(string)
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			// Without a transition time, the policy is known to be enforced as of now.
+			return time.Now(), true
+		}
+		return at, true
+	}
+	return time.Time{}, false
+}
+
+// awaitingEnforcement reports whether a generated policy has not been enforced yet
+// but is expected to be, as opposed to being blocked on a missing backend or a conflict.
+func awaitingEnforcement(ready bool, reason maasv1alpha1.ConditionReason) bool {
+	if ready {
+		return false
+	}
+	switch reason {
+	case maasv1alpha1.ReasonNotFound, maasv1alpha1.ReasonConditionsNotFound,
+		maasv1alpha1.ReasonNotAccepted, maasv1alpha1.ReasonNotEnforced:
+		return true
+	}
+	return false
+}
+
+// requeueUntilEnforced returns the result that polls again while generated policies are
+// awaiting enforcement. Policy status updates trigger reconciliation through the policy
+// watches; the requeue is a safety net. The wait is measured from the last transition of
+// the Ready condition, so the backoff survives controller restarts.
+func requeueUntilEnforced(backoff RequeueBackoff, conditions []metav1.Condition, awaiting bool) ctrl.Result {
+	if !awaiting {
+		return ctrl.Result{}
+	}
+	var waited time.Duration
+	if ready := apimeta.FindStatusCondition(conditions, "Ready"); ready != nil && ready.Status != metav1.ConditionTrue {
+		waited = time.Since(ready.LastTransitionTime.Time)
+	}
+	delay, ok := backoff.Next(waited)
+	if !ok {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: delay}
+}
+
+// minRequeue returns the result that requeues soonest.
+func minRequeue(a, b ctrl.Result) ctrl.Result {
+	if a.RequeueAfter == 0 || (b.RequeueAfter != 0 && b.RequeueAfter < a.RequeueAfter) {
+		return b
+	}
+	return a
+}
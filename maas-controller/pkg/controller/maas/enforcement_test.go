@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// setEnforced sets Accepted=True and an Enforced condition with the given status and
+// transition time on a policy.
+func setEnforced(t *testing.T, policy *unstructured.Unstructured, status string, at time.Time) {
+	t.Helper()
+	if err := unstructured.SetNestedSlice(policy.Object, []any{
+		map[string]any{"type": "Accepted", "status": "True"},
+		map[string]any{"type": "Enforced", "status": status, "lastTransitionTime": at.UTC().Format(time.RFC3339)},
+	}, "status", "conditions"); err != nil {
+		t.Fatalf("SetNestedSlice status.conditions: %v", err)
+	}
+}
+
+func TestEnforcementObserver(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	created := start.Add(time.Minute)
+
+	newPolicy := func(uid string, status string, at time.Time) *unstructured.Unstructured {
+		p := newPreexistingTRLP("maas-trlp-"+uid, "default", "llm", nil)
+		p.SetUID(types.UID(uid))
+		p.SetCreationTimestamp(metav1.NewTime(created))
+		setEnforced(t, p, status, at)
+		return p
+	}
+
+	o := newEnforcementObserver(start)
+	enforced := newPolicy("a", "True", created.Add(30*time.Second))
+	if !o.observe(enforced) {
+		t.Error("expected an enforced policy to be recorded")
+	}
+	if o.observe(enforced) {
+		t.Error("expected a policy to be recorded only once")
+	}
+	if o.observe(newPolicy("b", "False", created.Add(30*time.Second))) {
+		t.Error("expected a policy that is not enforced not to be recorded")
+	}
+	if o.observe(newPolicy("c", "True", start.Add(-time.Second))) {
+		t.Error("expected a policy enforced before the observer started not to be recorded")
+	}
+}
+
+func TestRequeueUntilEnforced(t *testing.T) {
+	backoff := RequeueBackoff{InitialDelay: 5 * time.Second, MaxDelay: time.Minute, MaxWait: 10 * time.Minute}
+	notReadySince := func(d time.Duration) []metav1.Condition {
+		return []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-d))}}
+	}
+
+	if got := requeueUntilEnforced(backoff, notReadySince(0), false); got.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %v, want 0 when no policy is awaiting enforcement", got.RequeueAfter)
+	}
+	if got := requeueUntilEnforced(backoff, notReadySince(0), true); got.RequeueAfter != 5*time.Second {
+		t.Errorf("RequeueAfter = %v, want the initial delay", got.RequeueAfter)
+	}
+	if got := requeueUntilEnforced(backoff, notReadySince(2*time.Minute), true); got.RequeueAfter != time.Minute {
+		t.Errorf("RequeueAfter = %v, want the max delay", got.RequeueAfter)
+	}
+	if got := requeueUntilEnforced(backoff, notReadySince(time.Hour), true); got.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %v, want 0 after the max wait", got.RequeueAfter)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_RequeueUntilEnforced verifies that a subscription is
+// requeued while its generated TokenRateLimitPolicy is not enforced, and no longer once it is.
+func TestMaaSSubscriptionReconciler_RequeueUntilEnforced(t *testing.T) {
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	tests := []struct {
+		name        string
+		enforced    string
+		wantRequeue time.Duration
+	}{
+		{name: "not enforced", enforced: "False", wantRequeue: DefaultRequeueInitialDelay},
+		{name: "enforced", enforced: "True"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trlp := newPreexistingTRLP(tokenRateLimitPolicyName(namespace, modelName, ""), namespace, modelName, nil)
+			setEnforced(t, trlp, tc.enforced, time.Now())
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testRESTMapper()).
+				WithObjects(
+					newMaaSModelRef(modelName, namespace, "ExternalModel", modelName),
+					newHTTPRoute("maas-"+modelName, namespace),
+					newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100),
+					trlp,
+				).
+				WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+				WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+				Build()
+			r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			if result.RequeueAfter != tc.wantRequeue {
+				t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, tc.wantRequeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kservev1alpha1 "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// reasonPrimaryReady is the FailoverActive=False reason while the primary backend serves traffic.
+	reasonPrimaryReady = "PrimaryReady"
+	// reasonFailoverRouting is the FailoverActive=True reason.
+	reasonFailoverRouting = "FailoverRouting"
+	// reasonFailoverUnavailable is used when the primary is not Ready and the backup cannot take over.
+	reasonFailoverUnavailable = "FailoverUnavailable"
+
+	// failoverRouteComponent labels the HTTPRoutes created for spec.failover.
+	failoverRouteComponent = "failover-route"
+)
+
+// failoverRouteName returns the name of the HTTPRoute that sends a model's traffic to its backup.
+func failoverRouteName(modelName string) string {
+	return modelName + "-failover"
+}
+
+// llmisvcPathPrefix returns the path prefix KServe routes an LLMInferenceService under.
+func llmisvcPathPrefix(namespace, name string) string {
+	return "/" + namespace + "/" + name
+}
+
+// reconcileFailover routes the model's traffic to spec.failover while the primary
+// LLMInferenceService is not Ready, and returns the primary endpoint when it did so.
+// The KServe-owned primary route is left alone: a controller-owned HTTPRoute copies the
+// backup route's rules under the primary's path prefix with one match per HTTP method,
+// since a method match outranks the primary's rules in Gateway API route precedence. The
+// route is deleted again as soon as the primary is Ready.
+func (r *MaaSModelRefReconciler) reconcileFailover(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef, primaryReady bool) (string, error) {
+	if model.Spec.Failover == nil {
+		if apimeta.FindStatusCondition(model.Status.Conditions, maasv1alpha1.ConditionFailoverActive) != nil {
+			apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionFailoverActive)
+			return "", r.deleteFailoverRoute(ctx, log, model)
+		}
+		return "", nil
+	}
+
+	backupName := model.Spec.Failover.ModelRef.Name
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionFailoverActive,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: model.GetGeneration(),
+	}
+	setInactive := func(reason, message string) (string, error) {
+		cond.Reason, cond.Message = reason, message
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return "", r.deleteFailoverRoute(ctx, log, model)
+	}
+
+	if primaryReady {
+		return setInactive(reasonPrimaryReady, "Primary backend is Ready")
+	}
+	if model.Status.HTTPRouteName == "" {
+		return setInactive(reasonFailoverUnavailable, "Primary HTTPRoute is not known yet")
+	}
+
+	backup := &kservev1alpha1.LLMInferenceService{}
+	if err := r.Get(ctx, types.NamespacedName{Name: backupName, Namespace: model.Namespace}, backup); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get backup LLMInferenceService %s/%s: %w", model.Namespace, backupName, err)
+		}
+		return setInactive(reasonFailoverUnavailable, fmt.Sprintf("Backup LLMInferenceService %s not found", backupName))
+	}
+	if !llmisvcReady(backup) {
+		return setInactive(reasonFailoverUnavailable, fmt.Sprintf("Primary and backup LLMInferenceService %s are both not Ready", backupName))
+	}
+
+	backupRouteName, _, err := llmisvcRouteResolver{}.HTTPRouteForModel(ctx, r.Client, &maasv1alpha1.MaaSModelRef{
+		ObjectMeta: metav1.ObjectMeta{Namespace: model.Namespace},
+		Spec:       maasv1alpha1.MaaSModelSpec{ModelRef: model.Spec.Failover.ModelRef},
+	})
+	if err != nil {
+		return setInactive(reasonFailoverUnavailable, fmt.Sprintf("Backup LLMInferenceService %s has no HTTPRoute: %v", backupName, err))
+	}
+	backupRoute := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: backupRouteName, Namespace: model.Namespace}, backupRoute); err != nil {
+		return "", fmt.Errorf("failed to get backup HTTPRoute %s/%s: %w", model.Namespace, backupRouteName, err)
+	}
+	primaryRoute := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: model.Status.HTTPRouteName, Namespace: model.Status.HTTPRouteNamespace}, primaryRoute); err != nil {
+		return "", fmt.Errorf("failed to get HTTPRoute %s/%s: %w", model.Status.HTTPRouteNamespace, model.Status.HTTPRouteName, err)
+	}
+
+	rules := failoverRules(backupRoute.Spec.Rules,
+		llmisvcPathPrefix(model.Namespace, backupName),
+		llmisvcPathPrefix(model.Namespace, model.Spec.ModelRef.Name))
+	if len(rules) == 0 {
+		return setInactive(reasonFailoverUnavailable, fmt.Sprintf("Backup HTTPRoute %s has no rules under %s",
+			backupRouteName, llmisvcPathPrefix(model.Namespace, backupName)))
+	}
+	applyRoutingPolicy(rules, model.Spec.Routing)
+
+	route := &gatewayapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: failoverRouteName(model.Name), Namespace: model.Namespace},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["app.kubernetes.io/managed-by"] = "maas-controller"
+		labels["app.kubernetes.io/component"] = failoverRouteComponent
+		labels["maas.opendatahub.io/model"] = model.Name
+		route.SetLabels(labels)
+		route.Spec.ParentRefs = primaryRoute.Spec.ParentRefs
+		route.Spec.Hostnames = primaryRoute.Spec.Hostnames
+		route.Spec.Rules = rules
+		return controllerutil.SetControllerReference(model, route, r.Scheme)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to apply failover HTTPRoute %s/%s: %w", route.Namespace, route.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Failover HTTPRoute applied", "name", route.Name, "backup", backupName, "operation", op)
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = reasonFailoverRouting
+	cond.Message = fmt.Sprintf("Primary backend is not Ready; routing to backup LLMInferenceService %s via HTTPRoute %s", backupName, route.Name)
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+	return r.primaryEndpoint(ctx, log, model)
+}
+
+// failoverMethods are the methods a failover match is repeated for. Every request method
+// must go to the backup, not only inference POSTs, so each method gets its own match.
+var failoverMethods = []gatewayapiv1.HTTPMethod{
+	gatewayapiv1.HTTPMethodGet,
+	gatewayapiv1.HTTPMethodHead,
+	gatewayapiv1.HTTPMethodPost,
+	gatewayapiv1.HTTPMethodPut,
+	gatewayapiv1.HTTPMethodDelete,
+	gatewayapiv1.HTTPMethodConnect,
+	gatewayapiv1.HTTPMethodOptions,
+	gatewayapiv1.HTTPMethodTrace,
+	gatewayapiv1.HTTPMethodPatch,
+}
+
+// failoverRules copies the backup route's rules whose path matches lie under backupPrefix,
+// moving those matches to primaryPrefix. A match without a method is repeated for each of
+// failoverMethods, so that it takes precedence over the primary route's otherwise identical
+// match for any request method. Filters such as URLRewrite replace the matched prefix, so
+// they keep working unchanged.
+func failoverRules(backupRules []gatewayapiv1.HTTPRouteRule, backupPrefix, primaryPrefix string) []gatewayapiv1.HTTPRouteRule {
+	var rules []gatewayapiv1.HTTPRouteRule
+	for _, rule := range backupRules {
+		var matches []gatewayapiv1.HTTPRouteMatch
+		for _, m := range rule.Matches {
+			if m.Path == nil || m.Path.Value == nil {
+				continue
+			}
+			value := *m.Path.Value
+			if value != backupPrefix && !strings.HasPrefix(value, backupPrefix+"/") {
+				continue
+			}
+			m = *m.DeepCopy()
+			moved := primaryPrefix + strings.TrimPrefix(value, backupPrefix)
+			m.Path.Value = &moved
+			if m.Method != nil {
+				matches = append(matches, m)
+				continue
+			}
+			for _, method := range failoverMethods {
+				withMethod := *m.DeepCopy()
+				withMethod.Method = &method
+				matches = append(matches, withMethod)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		out := *rule.DeepCopy()
+		out.Matches = matches
+		rules = append(rules, out)
+	}
+	return rules
+}
+
+// primaryEndpoint returns the endpoint of the (not Ready) primary LLMInferenceService,
+// preferring the addresses it still reports over the gateway-derived endpoint.
+func (r *MaaSModelRefReconciler) primaryEndpoint(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) (string, error) {
+	h := &llmisvcHandler{r: r}
+	primary := &kservev1alpha1.LLMInferenceService{}
+	if err := r.Get(ctx, types.NamespacedName{Name: model.Spec.ModelRef.Name, Namespace: model.Namespace}, primary); err == nil {
+		if endpoint := h.getEndpointFromLLMISvc(primary, model.Status.HTTPRouteHostnames); endpoint != "" {
+			return endpoint, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	return h.GetModelEndpoint(ctx, log, model)
+}
+
+// deleteFailoverRoute removes the model's failover HTTPRoute, if any.
+func (r *MaaSModelRefReconciler) deleteFailoverRoute(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: failoverRouteName(model.Name), Namespace: model.Namespace}, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get failover HTTPRoute: %w", err)
+	}
+	if !metav1.IsControlledBy(route, model) {
+		return nil
+	}
+	if err := r.Delete(ctx, route); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete failover HTTPRoute %s/%s: %w", route.Namespace, route.Name, err)
+	}
+	log.Info("Failover HTTPRoute deleted", "name", route.Name)
+	return nil
+}
+
+// llmisvcReady reports whether the LLMInferenceService has Ready=True.
+func llmisvcReady(llmisvc *kservev1alpha1.LLMInferenceService) bool {
+	for _, c := range llmisvc.Status.Conditions {
+		if c.Type == "Ready" && c.Status == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileMirroredTRLP mirrors the model's aggregated TokenRateLimitPolicy, and its
+// RateLimitPolicy if any, onto one of the controller-owned HTTPRoutes that take over its
+// traffic (failover or routing) while that route exists, so requests served through it
+// are rate limited too. The copies are owned by the route and garbage collected with it.
+func (r *MaaSSubscriptionReconciler) reconcileMirroredTRLP(ctx context.Context, log logr.Logger, modelNamespace, modelName, routeNamespace, routeName, component string, allSubs []maasv1alpha1.MaaSSubscription) error {
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: routeName, Namespace: routeNamespace}, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get %s HTTPRoute for model %s/%s: %w", component, modelNamespace, modelName, err)
+	}
+	if route.Labels["app.kubernetes.io/component"] != component {
+		return nil
+	}
+	globalLimits, err := modelGlobalTokenRateLimits(ctx, r.Client, modelNamespace, modelName)
+	if err != nil {
+		return err
+	}
+	price, err := getModelPricing(ctx, r.Client, modelNamespace, modelName)
+	if err != nil {
+		return err
+	}
+	spec, subNames := buildTRLPSpec(log, allSubs, modelNamespace, modelName, globalLimits, price, route.Name)
+	if spec == nil {
+		return nil
+	}
+	specMap, err := kuadrantv1.ToUnstructured(spec)
+	if err != nil {
+		return err
+	}
+
+	legacy, err := r.getLegacyPolicy(ctx, r.trlpGVK(),
+		types.NamespacedName{Name: legacyPolicyName(tokenRateLimitPolicyPrefix, modelName, route.Name), Namespace: routeNamespace}, modelNamespace, modelName)
+	if err != nil {
+		return err
+	}
+	if legacy != nil && !isManaged(legacy) {
+		return nil
+	}
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(r.trlpGVK())
+	policy.SetName(tokenRateLimitPolicyName(modelNamespace, modelName, route.Name))
+	policy.SetNamespace(routeNamespace)
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if !isManaged(policy) {
+			return nil
+		}
+		labels := policy.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["maas.opendatahub.io/model"] = modelName
+		labels["maas.opendatahub.io/model-namespace"] = modelNamespace
+		labels["app.kubernetes.io/managed-by"] = "maas-controller"
+		labels["app.kubernetes.io/part-of"] = "maas-subscription"
+		labels["app.kubernetes.io/component"] = "token-rate-limit-policy"
+		policy.SetLabels(labels)
+		annotations := policy.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations["maas.opendatahub.io/subscriptions"] = strings.Join(subNames, ",")
+		policy.SetAnnotations(annotations)
+		if err := controllerutil.SetControllerReference(route, policy, r.Scheme); err != nil {
+			return err
+		}
+		return unstructured.SetNestedMap(policy.Object, specMap, "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply TokenRateLimitPolicy for %s HTTPRoute of model %s/%s: %w", component, modelNamespace, modelName, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Mirrored TokenRateLimitPolicy applied", "name", policy.GetName(), "route", route.Name, "model", modelNamespace+"/"+modelName, "operation", op)
+	}
+	if err := r.deleteLegacyPolicy(ctx, log, legacy); err != nil {
+		return err
+	}
+	return r.reconcileRLP(ctx, log, modelNamespace, modelName, route.Name, route, allSubs)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// withKServeRules adds the KServe-style completions rule for llmisvcName to route.
+func withKServeRules(route *gatewayapiv1.HTTPRoute, llmisvcName string) *gatewayapiv1.HTTPRoute {
+	pathType := gatewayapiv1.PathMatchPathPrefix
+	path := llmisvcPathPrefix(route.Namespace, llmisvcName) + "/v1/completions"
+	rewrite := "/v1/completions"
+	route.Spec.Rules = []gatewayapiv1.HTTPRouteRule{{
+		Matches: []gatewayapiv1.HTTPRouteMatch{{Path: &gatewayapiv1.HTTPPathMatch{Type: &pathType, Value: &path}}},
+		Filters: []gatewayapiv1.HTTPRouteFilter{{
+			Type: gatewayapiv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayapiv1.HTTPURLRewriteFilter{Path: &gatewayapiv1.HTTPPathModifier{
+				Type: gatewayapiv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: &rewrite,
+			}},
+		}},
+		BackendRefs: []gatewayapiv1.HTTPBackendRef{{BackendRef: gatewayapiv1.BackendRef{
+			BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: gatewayapiv1.ObjectName(llmisvcName + "-workload")},
+		}}},
+	}}
+	return route
+}
+
+func TestFailoverRules(t *testing.T) {
+	backup := withKServeRules(newLLMISvcRoute("backup", "default"), "backup")
+	modelHeader := "X-Gateway-Model-Name"
+	backup.Spec.Rules = append(backup.Spec.Rules, gatewayapiv1.HTTPRouteRule{
+		Matches: []gatewayapiv1.HTTPRouteMatch{{Headers: []gatewayapiv1.HTTPHeaderMatch{{Name: gatewayapiv1.HTTPHeaderName(modelHeader), Value: "backup"}}}},
+	})
+
+	rules := failoverRules(backup.Spec.Rules, "/default/backup", "/default/primary")
+	if len(rules) != 1 {
+		t.Fatalf("expected only the path rule to be copied, got %d rules", len(rules))
+	}
+	if len(rules[0].Matches) != len(failoverMethods) {
+		t.Fatalf("expected one match per method, got %d", len(rules[0].Matches))
+	}
+	methods := map[gatewayapiv1.HTTPMethod]bool{}
+	for _, m := range rules[0].Matches {
+		if got := *m.Path.Value; got != "/default/primary/v1/completions" {
+			t.Errorf("path = %q, want /default/primary/v1/completions", got)
+		}
+		if m.Method == nil {
+			t.Fatal("expected a method match to outrank the primary route")
+		}
+		methods[*m.Method] = true
+	}
+	if !methods[gatewayapiv1.HTTPMethodGet] || !methods[gatewayapiv1.HTTPMethodPost] {
+		t.Errorf("expected GET and POST requests to go to the backup, got %v", methods)
+	}
+	if got := string(rules[0].BackendRefs[0].Name); got != "backup-workload" {
+		t.Errorf("backendRef = %q, want backup-workload", got)
+	}
+	if *backup.Spec.Rules[0].Matches[0].Path.Value != "/default/backup/v1/completions" {
+		t.Error("failoverRules must not modify the backup route")
+	}
+}
+
+// TestMaaSModelRefReconciler_Failover verifies that a model whose primary LLMInferenceService
+// is NotReady stays Ready through a failover HTTPRoute to the Ready backup, and that the
+// route is removed once the primary recovers.
+func TestMaaSModelRefReconciler_Failover(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("primary", "default", "LLMInferenceService", "primary")
+	model.Spec.Failover = &maasv1alpha1.FailoverSpec{
+		ModelRef: maasv1alpha1.ModelReference{Kind: "LLMInferenceService", Name: "backup"},
+	}
+	sub := newMaaSSubscription("sub1", "admin-ns", "team-a", "primary", 100)
+	sub.Spec.ModelRefs[0].Namespace = "default"
+	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a", maasv1alpha1.ModelRef{Name: "primary", Namespace: "default"})
+
+	r, c := newTestReconciler(
+		model, sub, auth,
+		newMaaSGateway(testGatewayName, testGatewayNamespace),
+		newLLMISvc("primary", "default", corev1.ConditionFalse),
+		newLLMISvc("backup", "default", corev1.ConditionTrue),
+		withKServeRules(newLLMISvcRoute("primary", "default"), "primary"),
+		withKServeRules(newLLMISvcRoute("backup", "default"), "backup"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "primary", Namespace: "default"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != "Ready" || got.Status.Endpoint == "" {
+		t.Errorf("expected Ready with an endpoint during failover, got phase=%q endpoint=%q", got.Status.Phase, got.Status.Endpoint)
+	}
+	assertCondition(t, got.Status.Conditions, maasv1alpha1.ConditionFailoverActive, metav1.ConditionTrue, reasonFailoverRouting)
+
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "primary-failover", Namespace: "default"}, route); err != nil {
+		t.Fatalf("expected failover HTTPRoute: %v", err)
+	}
+	if !metav1.IsControlledBy(route, got) {
+		t.Error("failover HTTPRoute should be controlled by the MaaSModelRef")
+	}
+	if len(route.Spec.ParentRefs) != 1 || string(route.Spec.ParentRefs[0].Name) != testGatewayName {
+		t.Errorf("failover HTTPRoute parentRefs = %+v, want the primary route's gateway", route.Spec.ParentRefs)
+	}
+	if got := string(route.Spec.Rules[0].BackendRefs[0].Name); got != "backup-workload" {
+		t.Errorf("failover backendRef = %q, want backup-workload", got)
+	}
+
+	// The primary recovers: traffic returns to it and the failover route is deleted.
+	current := newLLMISvc("primary", "default")
+	if err := c.Get(ctx, types.NamespacedName{Name: "primary", Namespace: "default"}, current); err != nil {
+		t.Fatalf("Get LLMInferenceService: %v", err)
+	}
+	current.Status = newLLMISvc("primary", "default", corev1.ConditionTrue).Status
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatalf("Update LLMInferenceService: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after recovery: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertCondition(t, got.Status.Conditions, maasv1alpha1.ConditionFailoverActive, metav1.ConditionFalse, reasonPrimaryReady)
+	if err := c.Get(ctx, types.NamespacedName{Name: "primary-failover", Namespace: "default"}, route); !apierrors.IsNotFound(err) {
+		t.Errorf("expected failover HTTPRoute to be deleted, got err=%v", err)
+	}
+}
+
+func TestMaaSModelRefReconciler_FailoverBackupNotReady(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("primary", "default", "LLMInferenceService", "primary")
+	model.Spec.Failover = &maasv1alpha1.FailoverSpec{
+		ModelRef: maasv1alpha1.ModelReference{Kind: "LLMInferenceService", Name: "backup"},
+	}
+	r, c := newTestReconciler(
+		model,
+		newMaaSGateway(testGatewayName, testGatewayNamespace),
+		newLLMISvc("primary", "default", corev1.ConditionFalse),
+		newLLMISvc("backup", "default", corev1.ConditionFalse),
+		withKServeRules(newLLMISvcRoute("primary", "default"), "primary"),
+		withKServeRules(newLLMISvcRoute("backup", "default"), "backup"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "primary", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertCondition(t, got.Status.Conditions, maasv1alpha1.ConditionFailoverActive, metav1.ConditionFalse, reasonFailoverUnavailable)
+	if got.Status.Endpoint != "" {
+		t.Errorf("expected no endpoint when neither backend is Ready, got %q", got.Status.Endpoint)
+	}
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "primary-failover", Namespace: "default"}, route); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no failover HTTPRoute, got err=%v", err)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_FailoverTRLP verifies that the aggregated TokenRateLimitPolicy
+// is mirrored onto the failover HTTPRoute so requests served by the backup stay rate limited.
+func TestMaaSSubscriptionReconciler_FailoverTRLP(t *testing.T) {
+	const namespace = "default"
+	model := newMaaSModelRef("llm", namespace, "LLMInferenceService", "llm")
+	failover := newHTTPRoute(failoverRouteName("llm"), namespace)
+	failover.Labels = map[string]string{"app.kubernetes.io/component": failoverRouteComponent}
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", "llm", 100)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, newLLMISvcRoute("llm", namespace), failover, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	if err := c.Get(context.Background(), types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, "llm", "llm-failover"), Namespace: namespace}, trlp); err != nil {
+		t.Fatalf("expected failover TokenRateLimitPolicy: %v", err)
+	}
+	if target, _, _ := unstructured.NestedString(trlp.Object, "spec", "targetRef", "name"); target != "llm-failover" {
+		t.Errorf("targetRef.name = %q, want llm-failover", target)
+	}
+	if trlp.GetLabels()["maas.opendatahub.io/model"] != "llm" {
+		t.Errorf("expected model labels for label-based cleanup, got %v", trlp.GetLabels())
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// gatewayAuthentication holds the spec.authentication of the enforced MaaSAuthPolicies
+// of a tenant, merged for its gateway AuthPolicy.
+type gatewayAuthentication struct {
+	JWTIssuers []jwtIssuer
+	// TokenReviewAudiences are the audiences the policies add to the Kubernetes TokenReview.
+	TokenReviewAudiences []string
+	// X509CASecrets are the CA Secrets of the policies accepting client certificates.
+	X509CASecrets []x509CASecret
+	// CacheTTL is the shortest spec.authentication.cacheTTL of the policies, in seconds;
+	// nil when none sets it.
+	CacheTTL *int64
+	// APIKeysRestricted is set when a policy disables API keys for its models.
+	APIKeysRestricted bool
+	// APIKeysDisabled is set when every policy disables API keys, so the gateway does not
+	// validate them with maas-api at all.
+	APIKeysDisabled bool
+}
+
+// aggregateGatewayAuthentication merges the spec.authentication of the policies.
+func aggregateGatewayAuthentication(policies []maasv1alpha1.MaaSAuthPolicy) (gatewayAuthentication, error) {
+	issuers, err := aggregateJWTIssuers(policies)
+	if err != nil {
+		return gatewayAuthentication{}, err
+	}
+	var audiences []string
+	var cacheTTL *int64
+	var live, apiKeyPolicies int
+	for _, p := range policies {
+		if p.GetDeletionTimestamp().IsZero() {
+			live++
+			if policyAPIKeysEnabled(&p) {
+				apiKeyPolicies++
+			}
+		}
+		if p.Spec.Authentication != nil && p.Spec.Authentication.CacheTTL != nil && p.GetDeletionTimestamp().IsZero() {
+			ttl := int64(p.Spec.Authentication.CacheTTL.Seconds())
+			if cacheTTL == nil || ttl < *cacheTTL {
+				cacheTTL = &ttl
+			}
+		}
+		if p.Spec.Authentication == nil || p.Spec.Authentication.TokenReview == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		for _, aud := range p.Spec.Authentication.TokenReview.Audiences {
+			if err := validateCELValue(aud, "TokenReview audience"); err != nil {
+				return gatewayAuthentication{}, fmt.Errorf("invalid TokenReview authentication in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+			}
+			audiences = append(audiences, aud)
+		}
+	}
+	return gatewayAuthentication{
+		JWTIssuers:           issuers,
+		TokenReviewAudiences: deduplicateAndSort(audiences),
+		X509CASecrets:        aggregateX509CASecrets(policies),
+		CacheTTL:             cacheTTL,
+		APIKeysRestricted:    apiKeyPolicies < live,
+		APIKeysDisabled:      live > 0 && apiKeyPolicies == 0,
+	}, nil
+}
+
+// aggregateTenantAuthentication returns the merged spec.authentication of the enforced
+// MaaSAuthPolicies in a namespace.
+func (r *MaaSAuthPolicyReconciler) aggregateTenantAuthentication(ctx context.Context, policyNamespace string) (gatewayAuthentication, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policyNamespace)
+	if err != nil {
+		return gatewayAuthentication{}, err
+	}
+	return aggregateGatewayAuthentication(policies)
+}
+
+// capCacheTTL caps a cache TTL of the gateway AuthPolicy at the policies' cacheTTL.
+func (a gatewayAuthentication) capCacheTTL(ttl int64) int64 {
+	if a.CacheTTL != nil && *a.CacheTTL < ttl {
+		return max(*a.CacheTTL, 0)
+	}
+	return ttl
+}
+
+// tokenReviewCache caches the TokenReview of a Kubernetes token for the policies'
+// cacheTTL. Without it every request is reviewed.
+func (a gatewayAuthentication) tokenReviewCache() *kuadrantv1.RuleCache {
+	if a.CacheTTL == nil || *a.CacheTTL <= 0 {
+		return nil
+	}
+	return &kuadrantv1.RuleCache{
+		Key: kuadrantv1.ValueFrom{Selector: "request.headers.authorization"},
+		TTL: *a.CacheTTL,
+	}
+}
+
+// tokenReviewAudiences returns the audiences of the gateway's Kubernetes TokenReview: the
+// cluster audience first, then the ones configured for the controller and the policies.
+func (r *MaaSAuthPolicyReconciler) tokenReviewAudiences(declared []string) []string {
+	audiences := []string{r.ClusterAudience}
+	seen := map[string]bool{r.ClusterAudience: true}
+	for _, aud := range append(append([]string{}, r.TokenReviewAudiences...), declared...) {
+		if aud == "" || seen[aud] {
+			continue
+		}
+		seen[aud] = true
+		audiences = append(audiences, aud)
+	}
+	return audiences
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// newTokenReviewAuthPolicy returns a MaaSAuthPolicy adding TokenReview audiences for a model.
+func newTokenReviewAuthPolicy(name string, audiences ...string) maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-"+name, maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"})
+	p.Spec.Authentication = &maasv1alpha1.AuthenticationSpec{
+		TokenReview: &maasv1alpha1.TokenReviewAuthentication{Audiences: audiences},
+	}
+	return *p
+}
+
+func TestAggregateGatewayAuthentication_TokenReviewAudiences(t *testing.T) {
+	authn, err := aggregateGatewayAuthentication([]maasv1alpha1.MaaSAuthPolicy{
+		newTokenReviewAuthPolicy("a", "custom-gateway-sa", "https://api.example.com"),
+		newTokenReviewAuthPolicy("b", "custom-gateway-sa"),
+		*newMaaSAuthPolicy("c", "default", "team-c", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}),
+	})
+	if err != nil {
+		t.Fatalf("aggregateGatewayAuthentication: %v", err)
+	}
+	if want := []string{"custom-gateway-sa", "https://api.example.com"}; !reflect.DeepEqual(authn.TokenReviewAudiences, want) {
+		t.Errorf("TokenReviewAudiences = %v, want %v", authn.TokenReviewAudiences, want)
+	}
+
+	if _, err := aggregateGatewayAuthentication([]maasv1alpha1.MaaSAuthPolicy{newTokenReviewAuthPolicy("bad", `x" || true`)}); err == nil {
+		t.Error("expected an audience that is not a valid CEL string value to be rejected")
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_TokenReviewAudiences(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{
+		MaaSAPINamespace:     "maas-system",
+		ClusterAudience:      "https://kubernetes.default.svc",
+		TokenReviewAudiences: []string{"custom-gateway-sa", "https://kubernetes.default.svc"},
+		MetadataCacheTTL:     60,
+		AuthzCacheTTL:        60,
+	}
+	authn := gatewayAuthentication{TokenReviewAudiences: []string{"custom-gateway-sa", "https://api.example.com"}}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, authn, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "custom-gateway")
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+
+	got, found, err := unstructured.NestedStringSlice(obj.Object, "spec", "defaults", "rules", "authentication", "openshift-identities", "kubernetesTokenReview", "audiences")
+	if err != nil || !found {
+		t.Fatalf("openshift-identities audiences not found: %v", err)
+	}
+	want := []string{"https://kubernetes.default.svc", "custom-gateway-sa", "https://api.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TokenReview audiences = %v, want %v", got, want)
+	}
+}
+
+// newCacheTTLAuthPolicy returns a MaaSAuthPolicy setting spec.authentication.cacheTTL.
+func newCacheTTLAuthPolicy(name string, ttl time.Duration) maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-"+name, maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"})
+	p.Spec.Authentication = &maasv1alpha1.AuthenticationSpec{CacheTTL: &metav1.Duration{Duration: ttl}}
+	return *p
+}
+
+func TestAggregateGatewayAuthentication_CacheTTL(t *testing.T) {
+	deleting := newCacheTTLAuthPolicy("deleting", 0)
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	deleting.Finalizers = []string{maasAuthPolicyFinalizer}
+
+	authn, err := aggregateGatewayAuthentication([]maasv1alpha1.MaaSAuthPolicy{
+		newCacheTTLAuthPolicy("a", 5*time.Minute),
+		newCacheTTLAuthPolicy("b", 30*time.Second),
+		*newMaaSAuthPolicy("c", "default", "team-c", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}),
+		deleting,
+	})
+	if err != nil {
+		t.Fatalf("aggregateGatewayAuthentication: %v", err)
+	}
+	if authn.CacheTTL == nil || *authn.CacheTTL != 30 {
+		t.Errorf("CacheTTL = %v, want the shortest of the policies (30)", authn.CacheTTL)
+	}
+
+	authn, err = aggregateGatewayAuthentication([]maasv1alpha1.MaaSAuthPolicy{
+		*newMaaSAuthPolicy("c", "default", "team-c", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}),
+	})
+	if err != nil {
+		t.Fatalf("aggregateGatewayAuthentication: %v", err)
+	}
+	if authn.CacheTTL != nil {
+		t.Errorf("CacheTTL = %d, want unset when no policy sets it", *authn.CacheTTL)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_CacheTTL(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{
+		MaaSAPINamespace: "maas-system",
+		ClusterAudience:  "https://kubernetes.default.svc",
+		MetadataCacheTTL: 120,
+		AuthzCacheTTL:    60,
+	}
+	ttlOf := func(t *testing.T, obj *unstructured.Unstructured, fields ...string) int64 {
+		t.Helper()
+		ttl, found, err := unstructured.NestedInt64(obj.Object, append(fields, "cache", "ttl")...)
+		if err != nil || !found {
+			t.Fatalf("cache ttl of %v not found: %v", fields, err)
+		}
+		return ttl
+	}
+	rules := []string{"spec", "defaults", "rules"}
+	build := func(authn gatewayAuthentication) *unstructured.Unstructured {
+		spec := r.buildGatewayAuthPolicySpec("{}", nil, authn, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+		return &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+	}
+
+	obj := build(gatewayAuthentication{})
+	if _, found, _ := unstructured.NestedMap(obj.Object, append(rules, "authentication", "openshift-identities", "cache")...); found {
+		t.Error("TokenReview should not be cached without cacheTTL")
+	}
+	if got := ttlOf(t, obj, append(rules, "metadata", "apiKeyValidation")...); got != 120 {
+		t.Errorf("apiKeyValidation ttl = %d, want --metadata-cache-ttl (120)", got)
+	}
+
+	ttl := int64(30)
+	obj = build(gatewayAuthentication{CacheTTL: &ttl})
+	if got := ttlOf(t, obj, append(rules, "authentication", "openshift-identities")...); got != 30 {
+		t.Errorf("openshift-identities ttl = %d, want 30", got)
+	}
+	if key := nestedStringRequired(t, obj, append(rules, "authentication", "openshift-identities", "cache", "key", "selector")...); key != "request.headers.authorization" {
+		t.Errorf("openshift-identities cache key = %s, want the token", key)
+	}
+	for _, rule := range [][]string{
+		{"metadata", "apiKeyValidation"},
+		{"metadata", "subscription-info"},
+		{"authorization", "auth-valid"},
+		{"authorization", "subscription-valid"},
+		{"authorization", "require-group-membership"},
+	} {
+		if got := ttlOf(t, obj, append(rules, rule...)...); got != 30 {
+			t.Errorf("%v ttl = %d, want it capped at cacheTTL (30)", rule, got)
+		}
+	}
+
+	ttl = 600
+	obj = build(gatewayAuthentication{CacheTTL: &ttl})
+	if got := ttlOf(t, obj, append(rules, "authorization", "auth-valid")...); got != 60 {
+		t.Errorf("auth-valid ttl = %d, a longer cacheTTL should not raise --authz-cache-ttl (60)", got)
+	}
+}
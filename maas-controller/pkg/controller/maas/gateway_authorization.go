@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// gatewayAuthorization holds the spec.authorization of the enforced MaaSAuthPolicies of a
+// tenant, merged for its gateway AuthPolicy.
+type gatewayAuthorization struct {
+	OPAPolicies          []opaPolicy
+	SubjectAccessReviews []subjectAccessReview
+	// RequiredScopes are the spec.requiredScopes of the policies.
+	RequiredScopes []requiredScopesPolicy
+	// AccessWindows are the spec.accessWindows of the policies.
+	AccessWindows []accessWindowPolicy
+	// AllowedCIDRs are the spec.allowedCIDRs of the policies and of their modelRefs.
+	AllowedCIDRs []allowedCIDRsRule
+	// DenyResponses are the spec.denyResponse of the policies.
+	DenyResponses []denyResponsePolicy
+}
+
+// aggregateGatewayAuthorization merges the access checks of the policies, from
+// spec.authorization, spec.requiredScopes, spec.accessWindows and allowedCIDRs, and
+// their spec.denyResponse.
+func aggregateGatewayAuthorization(policies []maasv1alpha1.MaaSAuthPolicy) (gatewayAuthorization, error) {
+	scopes, err := aggregateRequiredScopes(policies)
+	if err != nil {
+		return gatewayAuthorization{}, err
+	}
+	windows, err := aggregateAccessWindows(policies)
+	if err != nil {
+		return gatewayAuthorization{}, err
+	}
+	cidrs, err := aggregateAllowedCIDRs(policies)
+	if err != nil {
+		return gatewayAuthorization{}, err
+	}
+	denyResponses, err := aggregateDenyResponses(policies)
+	if err != nil {
+		return gatewayAuthorization{}, err
+	}
+	return gatewayAuthorization{
+		OPAPolicies:          aggregateOPAPolicies(policies),
+		SubjectAccessReviews: aggregateSubjectAccessReviews(policies),
+		RequiredScopes:       scopes,
+		AccessWindows:        windows,
+		AllowedCIDRs:         cidrs,
+		DenyResponses:        denyResponses,
+	}, nil
+}
+
+// aggregateTenantAuthorization returns the merged access checks and deny responses of the
+// enforced MaaSAuthPolicies in a namespace.
+func (r *MaaSAuthPolicyReconciler) aggregateTenantAuthorization(ctx context.Context, policyNamespace string) (gatewayAuthorization, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policyNamespace)
+	if err != nil {
+		return gatewayAuthorization{}, err
+	}
+	return aggregateGatewayAuthorization(policies)
+}
+
+// addAuthorizationRules adds the authorization rules of the policies.
+func (a gatewayAuthorization) addAuthorizationRules(authorization map[string]kuadrantv1.AuthorizationRule, cacheTTL int64, celIsNotAPIKey string) {
+	addRequiredScopesRules(authorization, a.RequiredScopes, celIsNotAPIKey)
+	addOPAAuthorizationRules(authorization, a.OPAPolicies)
+	addSubjectAccessReviewRules(authorization, a.SubjectAccessReviews, cacheTTL)
+	addAccessWindowsRules(authorization, a.AccessWindows)
+	addAllowedCIDRsRules(authorization, a.AllowedCIDRs)
+}
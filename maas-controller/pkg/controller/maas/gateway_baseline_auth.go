@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// buildGatewayDefaultAuthPolicy returns the deny-all gateway-default-auth AuthPolicy of
+// the gateway. Route-level AuthPolicies and maas-gateway-auth replace it.
+func buildGatewayDefaultAuthPolicy(gatewayNamespace, gatewayName string) (*unstructured.Unstructured, error) {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	policy.SetName(gatewayDefaultAuthPolicyName)
+	policy.SetNamespace(gatewayNamespace)
+	policy.SetLabels(map[string]string{
+		"app.kubernetes.io/managed-by": "maas-controller",
+		"app.kubernetes.io/part-of":    "maas-controller",
+		"app.kubernetes.io/component":  "default-policy",
+	})
+	spec, err := kuadrantv1.ToUnstructured(&kuadrantv1.AuthPolicySpec{
+		TargetRef: kuadrantv1.TargetRef{
+			Group: "gateway.networking.k8s.io",
+			Kind:  "Gateway",
+			Name:  gatewayName,
+		},
+		Defaults: &kuadrantv1.MergeableAuthPolicy{
+			Rules: kuadrantv1.AuthRules{
+				Authorization: map[string]kuadrantv1.AuthorizationRule{
+					"deny-unconfigured-models": {
+						PatternMatching: &kuadrantv1.PatternMatchingAuthz{
+							Patterns: []kuadrantv1.Pattern{{
+								Selector: "context.request.http.method",
+								Operator: "eq",
+								Value:    "__deny_unconfigured_models__",
+							}},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedMap(policy.Object, spec, "spec"); err != nil {
+		return nil, fmt.Errorf("failed to set gateway-default-auth spec: %w", err)
+	}
+	return policy, nil
+}
+
+// GatewayBaselineAuthReconciler keeps the deny-all gateway-default-auth AuthPolicy on the
+// MaaS gateway while there is no maas-gateway-auth AuthPolicy, so model routes are never
+// exposed unauthenticated before a MaaSAuthPolicy covers them. It recreates the policy
+// when it is deleted and reverts edits to its spec.
+type GatewayBaselineAuthReconciler struct {
+	client.Client
+	GatewayName      string
+	GatewayNamespace string
+}
+
+// baselineRequest is the single request the reconciler works on.
+func (r *GatewayBaselineAuthReconciler) baselineRequest() reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: r.GatewayNamespace, Name: gatewayDefaultAuthPolicyName}}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *GatewayBaselineAuthReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("AuthPolicy", r.baselineRequest().NamespacedName)
+
+	gatewayAuth := &unstructured.Unstructured{}
+	gatewayAuth.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: maasGatewayAuthPolicyName}, gatewayAuth)
+	if err == nil && gatewayAuth.GetDeletionTimestamp().IsZero() {
+		// maas-gateway-auth denies unconfigured models itself; the MaaSAuthPolicy
+		// reconciler removes gateway-default-auth when it creates it.
+		return ctrl.Result{}, nil
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get gateway AuthPolicy: %w", err)
+	}
+
+	desired, err := buildGatewayDefaultAuthPolicy(r.GatewayNamespace, r.GatewayName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, desired); err != nil && !apierrors.IsAlreadyExists(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to create gateway-default-auth: %w", err)
+		}
+		log.Info("gateway-default-auth created (no maas-gateway-auth on the gateway)")
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get gateway-default-auth: %w", err)
+	}
+	if !isManaged(existing) {
+		log.Info("gateway-default-auth opted out of management, skipping")
+		return ctrl.Result{}, nil
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return ctrl.Result{}, nil
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	if err := r.Update(ctx, existing); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update gateway-default-auth: %w", err)
+	}
+	log.Info("gateway-default-auth spec restored")
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GatewayBaselineAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	authPolicy := &unstructured.Unstructured{}
+	authPolicy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+
+	// The policy may not exist at startup, so reconcile once without an event.
+	startup := make(chan event.GenericEvent, 1)
+	startup <- event.GenericEvent{Object: authPolicy}
+
+	toBaseline := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{r.baselineRequest()}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("gateway-baseline-auth").
+		// React to gateway-default-auth being deleted or edited, and to maas-gateway-auth
+		// coming and going.
+		Watches(authPolicy, toBaseline, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.GatewayNamespace &&
+				(obj.GetName() == gatewayDefaultAuthPolicyName || obj.GetName() == maasGatewayAuthPolicyName)
+		}))).
+		WatchesRawSource(source.Channel(startup, toBaseline)).
+		Complete(r)
+}
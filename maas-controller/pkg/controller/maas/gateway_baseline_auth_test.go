@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func newGatewayAuthPolicy(name, namespace string) *unstructured.Unstructured {
+	p := &unstructured.Unstructured{}
+	p.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	p.SetName(name)
+	p.SetNamespace(namespace)
+	return p
+}
+
+func TestGatewayBaselineAuthReconciler(t *testing.T) {
+	const gatewayNS = "openshift-ingress"
+	ctx := context.Background()
+	baselineKey := types.NamespacedName{Namespace: gatewayNS, Name: gatewayDefaultAuthPolicyName}
+
+	reconcileWith := func(t *testing.T, objs ...client.Object) client.Client {
+		t.Helper()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(testRESTMapper()).WithObjects(objs...).Build()
+		r := &GatewayBaselineAuthReconciler{Client: c, GatewayName: "maas-default-gateway", GatewayNamespace: gatewayNS}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: baselineKey}); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		return c
+	}
+	getBaseline := func(t *testing.T, c client.Client) (*unstructured.Unstructured, error) {
+		t.Helper()
+		p := newGatewayAuthPolicy("", "")
+		return p, c.Get(ctx, baselineKey, p)
+	}
+
+	t.Run("creates the deny-all policy without maas-gateway-auth", func(t *testing.T) {
+		c := reconcileWith(t)
+		p, err := getBaseline(t, c)
+		if err != nil {
+			t.Fatalf("gateway-default-auth should be created: %v", err)
+		}
+		if name, _, _ := unstructured.NestedString(p.Object, "spec", "targetRef", "name"); name != "maas-default-gateway" {
+			t.Errorf("targetRef.name = %q, want maas-default-gateway", name)
+		}
+		if _, found, _ := unstructured.NestedMap(p.Object, "spec", "defaults", "rules", "authorization", "deny-unconfigured-models"); !found {
+			t.Error("gateway-default-auth should carry the deny-unconfigured-models rule")
+		}
+	})
+
+	t.Run("leaves the gateway to maas-gateway-auth", func(t *testing.T) {
+		c := reconcileWith(t, newGatewayAuthPolicy(maasGatewayAuthPolicyName, gatewayNS))
+		if _, err := getBaseline(t, c); !apierrors.IsNotFound(err) {
+			t.Errorf("gateway-default-auth should not be created next to maas-gateway-auth, got err=%v", err)
+		}
+	})
+
+	t.Run("reverts edits to the spec", func(t *testing.T) {
+		edited := newGatewayAuthPolicy(gatewayDefaultAuthPolicyName, gatewayNS)
+		if err := unstructured.SetNestedField(edited.Object, "other-gateway", "spec", "targetRef", "name"); err != nil {
+			t.Fatal(err)
+		}
+		c := reconcileWith(t, edited)
+		p, err := getBaseline(t, c)
+		if err != nil {
+			t.Fatalf("get gateway-default-auth: %v", err)
+		}
+		if name, _, _ := unstructured.NestedString(p.Object, "spec", "targetRef", "name"); name != "maas-default-gateway" {
+			t.Errorf("targetRef.name = %q, want the edit reverted to maas-default-gateway", name)
+		}
+		if _, found, _ := unstructured.NestedMap(p.Object, "spec", "defaults"); !found {
+			t.Error("the deny-all defaults should be restored")
+		}
+	})
+
+	t.Run("respects the opt-out annotation", func(t *testing.T) {
+		optedOut := newGatewayAuthPolicy(gatewayDefaultAuthPolicyName, gatewayNS)
+		optedOut.SetAnnotations(map[string]string{ManagedByODHOperator: "false"})
+		if err := unstructured.SetNestedField(optedOut.Object, "other-gateway", "spec", "targetRef", "name"); err != nil {
+			t.Fatal(err)
+		}
+		c := reconcileWith(t, optedOut)
+		p, err := getBaseline(t, c)
+		if err != nil {
+			t.Fatalf("get gateway-default-auth: %v", err)
+		}
+		if name, _, _ := unstructured.NestedString(p.Object, "spec", "targetRef", "name"); name != "other-gateway" {
+			t.Errorf("targetRef.name = %q, an opted-out policy should be left alone", name)
+		}
+	})
+}
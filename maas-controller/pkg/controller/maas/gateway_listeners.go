@@ -0,0 +1,270 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// reasonListenerCompatible is the GatewayListenerCompatible=True reason.
+	reasonListenerCompatible = "ListenerCompatible"
+	// reasonGatewayListenerIncompatible is used when no listener of the Gateway can accept the route.
+	reasonGatewayListenerIncompatible = "GatewayListenerIncompatible"
+	// reasonGatewayNotFound is used when the Gateway the route attaches to does not exist.
+	reasonGatewayNotFound = "GatewayNotFound"
+)
+
+// hostnamesIntersect reports whether a listener hostname and a route hostname can match
+// the same request host, following the Gateway API wildcard rules ("*.example.com"
+// matches any subdomain of example.com, but not example.com itself).
+func hostnamesIntersect(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
+		return true
+	}
+	return strings.HasPrefix(b, "*.") && strings.HasSuffix(a, b[1:])
+}
+
+// listenerRejectsRoute returns why listener l cannot accept an HTTPRoute in a namespace
+// with the given labels, or "" if it can. parentRef narrows the listeners by sectionName
+// and port, like the gateway does.
+func listenerRejectsRoute(l gatewayapiv1.Listener, gwNamespace string, parentRef gatewayapiv1.ParentReference, route *gatewayapiv1.HTTPRoute, nsLabels map[string]string) string {
+	if parentRef.SectionName != nil && *parentRef.SectionName != l.Name {
+		return fmt.Sprintf("listener %q is not the route's sectionName %q", l.Name, *parentRef.SectionName)
+	}
+	if parentRef.Port != nil && *parentRef.Port != l.Port {
+		return fmt.Sprintf("listener %q port %d is not the route's port %d", l.Name, l.Port, *parentRef.Port)
+	}
+	if l.Protocol != gatewayapiv1.HTTPProtocolType && l.Protocol != gatewayapiv1.HTTPSProtocolType {
+		return fmt.Sprintf("listener %q protocol %s does not accept HTTPRoutes", l.Name, l.Protocol)
+	}
+
+	if ar := l.AllowedRoutes; ar != nil {
+		if len(ar.Kinds) > 0 {
+			allowed := false
+			for _, k := range ar.Kinds {
+				if k.Kind == "HTTPRoute" && (k.Group == nil || *k.Group == gatewayapiv1.GroupName) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return fmt.Sprintf("listener %q allowedRoutes.kinds does not include HTTPRoute", l.Name)
+			}
+		}
+		from := gatewayapiv1.NamespacesFromSame
+		if ar.Namespaces != nil && ar.Namespaces.From != nil {
+			from = *ar.Namespaces.From
+		}
+		switch from {
+		case gatewayapiv1.NamespacesFromAll:
+		case gatewayapiv1.NamespacesFromSelector:
+			if ar.Namespaces.Selector == nil {
+				return fmt.Sprintf("listener %q allowedRoutes uses Selector without a selector", l.Name)
+			}
+			sel, err := metav1.LabelSelectorAsSelector(ar.Namespaces.Selector)
+			if err != nil {
+				return fmt.Sprintf("listener %q has an invalid allowedRoutes selector: %v", l.Name, err)
+			}
+			if !sel.Matches(labels.Set(nsLabels)) {
+				return fmt.Sprintf("listener %q allowedRoutes selector does not match namespace %s", l.Name, route.Namespace)
+			}
+		default:
+			// Same (also the default when allowedRoutes.namespaces is unset).
+			if route.Namespace != gwNamespace {
+				return fmt.Sprintf("listener %q only allows routes from namespace %s", l.Name, gwNamespace)
+			}
+		}
+	} else if route.Namespace != gwNamespace {
+		return fmt.Sprintf("listener %q only allows routes from namespace %s", l.Name, gwNamespace)
+	}
+
+	if l.Hostname != nil && len(route.Spec.Hostnames) > 0 {
+		for _, h := range route.Spec.Hostnames {
+			if hostnamesIntersect(string(*l.Hostname), string(h)) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("listener %q hostname %s matches none of the route hostnames", l.Name, *l.Hostname)
+	}
+	return ""
+}
+
+// listenersSelectNamespaces reports whether a listener of gw allows routes from the
+// namespaces matching a label selector.
+func listenersSelectNamespaces(gw *gatewayapiv1.Gateway) bool {
+	for _, l := range gw.Spec.Listeners {
+		if ar := l.AllowedRoutes; ar != nil && ar.Namespaces != nil && ar.Namespaces.From != nil &&
+			*ar.Namespaces.From == gatewayapiv1.NamespacesFromSelector {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGatewayListeners verifies that the Gateway the model's HTTPRoute attaches to has at
+// least one listener that accepts the route (protocol, allowedRoutes, hostname) and records
+// the hostnames of those listeners in status. Without this a route that can never attach
+// just leaves the model Pending with no explanation. It returns false with a message when no
+// listener is compatible. Models without an HTTPRoute yet are skipped.
+func (r *MaaSModelRefReconciler) checkGatewayListeners(ctx context.Context, model *maasv1alpha1.MaaSModelRef) (bool, string, error) {
+	if r.istioRouting() || model.Status.HTTPRouteName == "" {
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionGatewayListenerCompatible)
+		model.Status.GatewayListenerHostnames = nil
+		return true, "", nil
+	}
+
+	gwKey := types.NamespacedName{Name: model.Status.HTTPRouteGatewayName, Namespace: model.Status.HTTPRouteGatewayNamespace}
+	if gwKey.Name == "" {
+		// The ExternalModel handler only fills in the gateway once the route is accepted.
+		gwKey = types.NamespacedName{Name: r.gatewayName(), Namespace: r.gatewayNamespace()}
+	}
+
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: model.Status.HTTPRouteName, Namespace: model.Status.HTTPRouteNamespace}, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, "", nil
+		}
+		return false, "", fmt.Errorf("failed to get HTTPRoute %s/%s: %w", model.Status.HTTPRouteNamespace, model.Status.HTTPRouteName, err)
+	}
+	var parentRef *gatewayapiv1.ParentReference
+	for i, ref := range route.Spec.ParentRefs {
+		refNS := route.Namespace
+		if ref.Namespace != nil {
+			refNS = string(*ref.Namespace)
+		}
+		if string(ref.Name) == gwKey.Name && refNS == gwKey.Namespace {
+			parentRef = &route.Spec.ParentRefs[i]
+			break
+		}
+	}
+	if parentRef == nil {
+		// The backend handler reports routes that do not reference the gateway.
+		return true, "", nil
+	}
+
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionGatewayListenerCompatible,
+		ObservedGeneration: model.GetGeneration(),
+	}
+	gw := &gatewayapiv1.Gateway{}
+	if err := r.Get(ctx, gwKey, gw); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, "", fmt.Errorf("failed to get Gateway %s: %w", gwKey, err)
+		}
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonGatewayNotFound
+		cond.Message = fmt.Sprintf("Gateway %s referenced by HTTPRoute %s/%s does not exist", gwKey, route.Namespace, route.Name)
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		model.Status.GatewayListenerHostnames = nil
+		return false, cond.Message, nil
+	}
+
+	// Selectors are matched against the labels of the route namespace, whether or not it is
+	// the Gateway namespace.
+	var nsLabels map[string]string
+	if listenersSelectNamespaces(gw) {
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, types.NamespacedName{Name: route.Namespace}, ns); err != nil && !apierrors.IsNotFound(err) {
+			return false, "", fmt.Errorf("failed to get namespace %s: %w", route.Namespace, err)
+		}
+		nsLabels = ns.Labels
+	}
+
+	var reasons []string
+	seen := map[string]struct{}{}
+	var hostnames []string
+	compatible := false
+	for _, l := range gw.Spec.Listeners {
+		if why := listenerRejectsRoute(l, gw.Namespace, *parentRef, route, nsLabels); why != "" {
+			reasons = append(reasons, why)
+			continue
+		}
+		compatible = true
+		if l.Hostname != nil {
+			if _, ok := seen[string(*l.Hostname)]; !ok {
+				seen[string(*l.Hostname)] = struct{}{}
+				hostnames = append(hostnames, string(*l.Hostname))
+			}
+		}
+	}
+	sort.Strings(hostnames)
+	model.Status.GatewayListenerHostnames = hostnames
+
+	if !compatible {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonGatewayListenerIncompatible
+		cond.Message = fmt.Sprintf("no listener on Gateway %s accepts HTTPRoute %s/%s: %s", gwKey, route.Namespace, route.Name, strings.Join(reasons, "; "))
+		if len(gw.Spec.Listeners) == 0 {
+			cond.Message = fmt.Sprintf("Gateway %s has no listeners", gwKey)
+		}
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return false, cond.Message, nil
+	}
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = reasonListenerCompatible
+	cond.Message = fmt.Sprintf("Gateway %s has a listener that accepts HTTPRoute %s/%s", gwKey, route.Namespace, route.Name)
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+	return true, "", nil
+}
+
+// listenerFailureReason returns the Ready reason for a failed listener check, taken from
+// the GatewayListenerCompatible condition.
+func listenerFailureReason(model *maasv1alpha1.MaaSModelRef) string {
+	if c := apimeta.FindStatusCondition(model.Status.Conditions, maasv1alpha1.ConditionGatewayListenerCompatible); c != nil {
+		return c.Reason
+	}
+	return reasonGatewayListenerIncompatible
+}
+
+// mapGatewayToMaaSModelRefs returns reconcile requests for the MaaSModelRefs attached to the
+// Gateway (or waiting to attach to it), so listener changes are re-validated.
+func (r *MaaSModelRefReconciler) mapGatewayToMaaSModelRefs(ctx context.Context, obj client.Object) []reconcile.Request {
+	var models maasv1alpha1.MaaSModelRefList
+	if err := r.List(ctx, &models); err != nil {
+		return nil
+	}
+	isDefault := obj.GetName() == r.gatewayName() && obj.GetNamespace() == r.gatewayNamespace()
+	var requests []reconcile.Request
+	for _, m := range models.Items {
+		attached := m.Status.HTTPRouteGatewayName == obj.GetName() && m.Status.HTTPRouteGatewayNamespace == obj.GetNamespace()
+		if attached || (isDefault && m.Status.HTTPRouteGatewayName == "") {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: m.Name, Namespace: m.Namespace},
+			})
+		}
+	}
+	return requests
+}
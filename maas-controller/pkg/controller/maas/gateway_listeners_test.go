@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// newMaaSGateway returns a Gateway with one HTTPS listener that accepts HTTPRoutes from
+// all namespaces, like the default MaaS gateway.
+func newMaaSGateway(name, ns string, hostnames ...string) *gatewayapiv1.Gateway {
+	from := gatewayapiv1.NamespacesFromAll
+	gw := &gatewayapiv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+	}
+	listener := gatewayapiv1.Listener{
+		Name:          "https",
+		Port:          443,
+		Protocol:      gatewayapiv1.HTTPSProtocolType,
+		AllowedRoutes: &gatewayapiv1.AllowedRoutes{Namespaces: &gatewayapiv1.RouteNamespaces{From: &from}},
+	}
+	if len(hostnames) == 0 {
+		gw.Spec.Listeners = []gatewayapiv1.Listener{listener}
+	}
+	for i, h := range hostnames {
+		l := listener
+		l.Name = gatewayapiv1.SectionName("https-" + string(rune('a'+i)))
+		hostname := gatewayapiv1.Hostname(h)
+		l.Hostname = &hostname
+		gw.Spec.Listeners = append(gw.Spec.Listeners, l)
+	}
+	return gw
+}
+
+func TestHostnamesIntersect(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"maas.example.com", "maas.example.com", true},
+		{"*.example.com", "maas.example.com", true},
+		{"maas.example.com", "*.example.com", true},
+		{"*.example.com", "*.apps.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"maas.example.com", "other.example.com", false},
+		{"*.example.com", "maas.example.org", false},
+	}
+	for _, tt := range tests {
+		if got := hostnamesIntersect(tt.a, tt.b); got != tt.want {
+			t.Errorf("hostnamesIntersect(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestListenerRejectsRoute(t *testing.T) {
+	all := gatewayapiv1.NamespacesFromAll
+	selector := gatewayapiv1.NamespacesFromSelector
+	hostname := gatewayapiv1.Hostname("*.apps.example.com")
+	otherSection := gatewayapiv1.SectionName("http")
+	grpcKind := gatewayapiv1.RouteGroupKind{Kind: "GRPCRoute"}
+
+	route := newHTTPRoute("model-route", "llm")
+	route.Spec.Hostnames = []gatewayapiv1.Hostname{"maas.apps.example.com"}
+
+	base := gatewayapiv1.Listener{
+		Name:          "https",
+		Port:          443,
+		Protocol:      gatewayapiv1.HTTPSProtocolType,
+		AllowedRoutes: &gatewayapiv1.AllowedRoutes{Namespaces: &gatewayapiv1.RouteNamespaces{From: &all}},
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(l *gatewayapiv1.Listener, ref *gatewayapiv1.ParentReference)
+		nsLabels  map[string]string
+		wantMatch string // substring of the rejection; empty means accepted
+	}{
+		{name: "compatible", mutate: func(*gatewayapiv1.Listener, *gatewayapiv1.ParentReference) {}},
+		{name: "matching wildcard hostname", mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			l.Hostname = &hostname
+		}},
+		{name: "TCP protocol", wantMatch: "protocol TCP", mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			l.Protocol = gatewayapiv1.TCPProtocolType
+		}},
+		{name: "same-namespace only", wantMatch: "only allows routes from namespace openshift-ingress", mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			l.AllowedRoutes = nil
+		}},
+		{name: "selector does not match", wantMatch: "selector does not match", nsLabels: map[string]string{"team": "b"}, mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			l.AllowedRoutes.Namespaces = &gatewayapiv1.RouteNamespaces{From: &selector, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}
+		}},
+		{name: "selector matches", nsLabels: map[string]string{"team": "a"}, mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			l.AllowedRoutes.Namespaces = &gatewayapiv1.RouteNamespaces{From: &selector, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}
+		}},
+		{name: "kinds exclude HTTPRoute", wantMatch: "kinds does not include HTTPRoute", mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			l.AllowedRoutes.Kinds = []gatewayapiv1.RouteGroupKind{grpcKind}
+		}},
+		{name: "hostname mismatch", wantMatch: "matches none of the route hostnames", mutate: func(l *gatewayapiv1.Listener, _ *gatewayapiv1.ParentReference) {
+			other := gatewayapiv1.Hostname("api.other.com")
+			l.Hostname = &other
+		}},
+		{name: "sectionName selects another listener", wantMatch: "not the route's sectionName", mutate: func(_ *gatewayapiv1.Listener, ref *gatewayapiv1.ParentReference) {
+			ref.SectionName = &otherSection
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := base
+			ar := *base.AllowedRoutes
+			l.AllowedRoutes = &ar
+			ref := gatewayapiv1.ParentReference{Name: "maas-default-gateway"}
+			tt.mutate(&l, &ref)
+			got := listenerRejectsRoute(l, "openshift-ingress", ref, route, tt.nsLabels)
+			if tt.wantMatch == "" && got != "" {
+				t.Errorf("expected listener to accept route, got %q", got)
+			}
+			if tt.wantMatch != "" && !strings.Contains(got, tt.wantMatch) {
+				t.Errorf("rejection = %q, want it to contain %q", got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+// TestMaaSModelRefReconciler_GatewayListenerValidation verifies that a model whose route
+// cannot attach to any gateway listener is Failed with an explicit condition, and that the
+// compatible listeners' hostnames are surfaced in status once the gateway is fixed.
+func TestMaaSModelRefReconciler_GatewayListenerValidation(t *testing.T) {
+	const (
+		modelName = "llm"
+		ns        = "default"
+	)
+	model := newMaaSModelRef(modelName, ns, "LLMInferenceService", modelName)
+	route := newLLMISvcRoute(modelName, ns)
+	llmisvc := newLLMISvc(modelName, ns, corev1.ConditionTrue)
+	gw := newMaaSGateway(testGatewayName, testGatewayNamespace, "*.example.com")
+	// Listener only accepts routes from the gateway's own namespace.
+	gw.Spec.Listeners[0].AllowedRoutes = nil
+	nsObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}
+
+	r, c := newTestReconciler(model, route, llmisvc, gw, nsObj)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: modelName, Namespace: ns}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != "Failed" {
+		t.Errorf("Phase = %q, want Failed for a route that cannot attach", got.Status.Phase)
+	}
+	assertReadyCondition(t, got.Status.Conditions, metav1.ConditionFalse, reasonGatewayListenerIncompatible)
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionGatewayListenerCompatible)
+	if cond == nil || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, "only allows routes from namespace") {
+		t.Errorf("expected GatewayListenerCompatible=False explaining allowedRoutes, got %+v", cond)
+	}
+
+	if reqs := r.mapGatewayToMaaSModelRefs(ctx, gw); len(reqs) != 1 || reqs[0].NamespacedName != req.NamespacedName {
+		t.Errorf("mapGatewayToMaaSModelRefs = %v, want [%s]", reqs, req.NamespacedName)
+	}
+
+	// The admin opens the listener to all namespaces.
+	if err := c.Get(ctx, types.NamespacedName{Name: testGatewayName, Namespace: testGatewayNamespace}, gw); err != nil {
+		t.Fatalf("Get Gateway: %v", err)
+	}
+	from := gatewayapiv1.NamespacesFromAll
+	gw.Spec.Listeners[0].AllowedRoutes = &gatewayapiv1.AllowedRoutes{Namespaces: &gatewayapiv1.RouteNamespaces{From: &from}}
+	if err := c.Update(ctx, gw); err != nil {
+		t.Fatalf("Update Gateway: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after gateway fix: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cond = apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionGatewayListenerCompatible)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected GatewayListenerCompatible=True, got %+v", cond)
+	}
+	if len(got.Status.GatewayListenerHostnames) != 1 || got.Status.GatewayListenerHostnames[0] != "*.example.com" {
+		t.Errorf("GatewayListenerHostnames = %v, want [*.example.com]", got.Status.GatewayListenerHostnames)
+	}
+	if got.Status.Phase == "Failed" {
+		t.Errorf("Phase = Failed after the gateway was fixed")
+	}
+}
+
+// TestMaaSModelRefReconciler_GatewayListenerSelectorSameNamespace verifies that a Selector
+// listener is matched against the labels of the route namespace when the route lives in
+// the Gateway namespace.
+func TestMaaSModelRefReconciler_GatewayListenerSelectorSameNamespace(t *testing.T) {
+	const modelName = "llm"
+	model := newMaaSModelRef(modelName, testGatewayNamespace, "LLMInferenceService", modelName)
+	route := newLLMISvcRoute(modelName, testGatewayNamespace)
+	llmisvc := newLLMISvc(modelName, testGatewayNamespace, corev1.ConditionTrue)
+	gw := newMaaSGateway(testGatewayName, testGatewayNamespace, "*.example.com")
+	from := gatewayapiv1.NamespacesFromSelector
+	gw.Spec.Listeners[0].AllowedRoutes = &gatewayapiv1.AllowedRoutes{Namespaces: &gatewayapiv1.RouteNamespaces{
+		From:     &from,
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"maas-routes": "true"}},
+	}}
+	nsObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testGatewayNamespace, Labels: map[string]string{"maas-routes": "true"}}}
+
+	r, c := newTestReconciler(model, route, llmisvc, gw, nsObj)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: modelName, Namespace: testGatewayNamespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionGatewayListenerCompatible)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected GatewayListenerCompatible=True for a selected same-namespace route, got %+v", cond)
+	}
+}
+
+// TestMaaSModelRefReconciler_GatewayNotFound verifies that a route attached to a Gateway that
+// does not exist is reported instead of leaving the model silently Pending.
+func TestMaaSModelRefReconciler_GatewayNotFound(t *testing.T) {
+	model := newMaaSModelRef("llm", "default", "LLMInferenceService", "llm")
+	route := newLLMISvcRoute("llm", "default")
+	llmisvc := newLLMISvc("llm", "default", corev1.ConditionTrue)
+
+	r, c := newTestReconciler(model, route, llmisvc)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llm", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertReadyCondition(t, got.Status.Conditions, metav1.ConditionFalse, reasonGatewayNotFound)
+	assertCondition(t, got.Status.Conditions, maasv1alpha1.ConditionGatewayListenerCompatible, metav1.ConditionFalse, reasonGatewayNotFound)
+}
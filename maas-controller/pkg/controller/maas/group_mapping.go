@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// GroupMappingConfigMapName is the ConfigMap of a subscription namespace that maps
+	// identity provider groups to the role names its MaaSAuthPolicies and MaaSSubscriptions
+	// refer to. maas-api reads the same ConfigMap of its MAAS_SUBSCRIPTION_NAMESPACE for
+	// subscription selection.
+	GroupMappingConfigMapName = "maas-group-mapping"
+	// groupMappingKey is the data key of the mapping: a YAML map from an identity
+	// provider group to the list of roles its members have.
+	groupMappingKey = "mapping.yaml"
+)
+
+// groupMapping maps an identity provider group to the roles of its members.
+type groupMapping map[string][]string
+
+// parseGroupMapping parses the mapping.yaml of the group mapping ConfigMap. Group and role
+// names are embedded in the generated expressions like the group names of subjects.
+func parseGroupMapping(data string) (groupMapping, error) {
+	mapping := groupMapping{}
+	if err := yaml.UnmarshalStrict([]byte(data), &mapping); err != nil {
+		return nil, fmt.Errorf("%s must map group names to lists of roles: %w", groupMappingKey, err)
+	}
+	for group, roles := range mapping {
+		if group == "" {
+			return nil, errors.New("group names must not be empty")
+		}
+		if err := validateCELValue(group, "group name"); err != nil {
+			return nil, err
+		}
+		if len(roles) == 0 {
+			return nil, fmt.Errorf("group %q maps to no roles", group)
+		}
+		for _, role := range roles {
+			if role == "" {
+				return nil, fmt.Errorf("group %q maps to an empty role", group)
+			}
+			if err := validateCELValue(role, "role"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// groupsWithRoles returns the identity provider groups mapped to any of the roles.
+func (m groupMapping) groupsWithRoles(roles []string) []string {
+	wanted := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		wanted[role] = true
+	}
+	var groups []string
+	for group, groupRoles := range m {
+		for _, role := range groupRoles {
+			if wanted[role] {
+				groups = append(groups, group)
+				break
+			}
+		}
+	}
+	return groups
+}
+
+// applyGroupMapping grants each model to the identity provider groups mapped to the
+// groups of its allowlist, so that the gateway matches the caller's own groups.
+func applyGroupMapping(aggregate map[string]modelSubjectAllowlist, mapping groupMapping) {
+	if len(mapping) == 0 {
+		return
+	}
+	for model, entry := range aggregate {
+		if mapped := mapping.groupsWithRoles(entry.Groups); len(mapped) > 0 {
+			entry.Groups = deduplicateAndSort(append(entry.Groups, mapped...))
+			aggregate[model] = entry
+		}
+	}
+}
+
+// groupMappingNamespace returns the namespace of the group mapping ConfigMap applying to
+// the MaaSAuthPolicies of policyNamespace: the subscription namespace that the maas-api
+// serving them reads the mapping from. That is the Tenant's namespace with tenant namespace
+// discovery, where the tenant reconciler sets MAAS_SUBSCRIPTION_NAMESPACE to it, and the
+// --maas-subscription-namespace otherwise.
+func (r *MaaSAuthPolicyReconciler) groupMappingNamespace(policyNamespace string) string {
+	if r.TenantNamespaceDiscoveryEnabled || r.TenantNamespace == "" {
+		return policyNamespace
+	}
+	return r.TenantNamespace
+}
+
+// groupMapping reads the group mapping ConfigMap applying to the MaaSAuthPolicies of
+// policyNamespace. Without the ConfigMap there is no mapping.
+func (r *MaaSAuthPolicyReconciler) groupMapping(ctx context.Context, policyNamespace string) (groupMapping, error) {
+	namespace := r.groupMappingNamespace(policyNamespace)
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: GroupMappingConfigMapName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, GroupMappingConfigMapName, err)
+	}
+	mapping, err := parseGroupMapping(cm.Data[groupMappingKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ConfigMap %s/%s: %w", namespace, GroupMappingConfigMapName, err)
+	}
+	return mapping, nil
+}
+
+// mapGroupMappingToAuthPolicies returns reconcile requests for the MaaSAuthPolicies that a
+// group mapping ConfigMap applies to.
+func (r *MaaSAuthPolicyReconciler) mapGroupMappingToAuthPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != GroupMappingConfigMapName || obj.GetNamespace() != r.groupMappingNamespace(obj.GetNamespace()) {
+		return nil
+	}
+	var policies maasv1alpha1.MaaSAuthPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.Name, Namespace: p.Namespace}})
+	}
+	return requests
+}
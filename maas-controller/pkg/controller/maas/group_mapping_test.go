@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestParseGroupMapping(t *testing.T) {
+	mapping, err := parseGroupMapping("ldap-premium: [maas-premium]\nldap-staff:\n  - maas-premium\n  - maas-standard\n")
+	if err != nil {
+		t.Fatalf("parseGroupMapping: %v", err)
+	}
+	want := groupMapping{"ldap-premium": {"maas-premium"}, "ldap-staff": {"maas-premium", "maas-standard"}}
+	if !reflect.DeepEqual(mapping, want) {
+		t.Errorf("mapping = %v, want %v", mapping, want)
+	}
+	if got := deduplicateAndSort(mapping.groupsWithRoles([]string{"maas-premium"})); !reflect.DeepEqual(got, []string{"ldap-premium", "ldap-staff"}) {
+		t.Errorf("groups with maas-premium = %v, want both groups", got)
+	}
+
+	if mapping, err := parseGroupMapping(""); err != nil || len(mapping) != 0 {
+		t.Errorf("empty mapping = %v, %v; want no mapping", mapping, err)
+	}
+	for _, data := range []string{
+		"ldap-premium: maas-premium",
+		"ldap-premium: []",
+		`ldap-premium: ["maas\"premium"]`,
+		`"": [maas-premium]`,
+		"- ldap-premium",
+	} {
+		if _, err := parseGroupMapping(data); err == nil {
+			t.Errorf("parseGroupMapping(%q) expected an error", data)
+		}
+	}
+}
+
+func TestApplyGroupMapping(t *testing.T) {
+	aggregate := map[string]modelSubjectAllowlist{
+		"llm/granite": {Groups: []string{"maas-premium"}, Users: []string{"alice"}},
+		"llm/small":   {Groups: []string{"data-science"}},
+	}
+	applyGroupMapping(aggregate, groupMapping{"ldap-premium": {"maas-premium"}, "ldap-staff": {"maas-standard"}})
+	if got := aggregate["llm/granite"].Groups; !reflect.DeepEqual(got, []string{"ldap-premium", "maas-premium"}) {
+		t.Errorf("granite groups = %v, want the mapped ldap-premium added", got)
+	}
+	if got := aggregate["llm/small"].Groups; !reflect.DeepEqual(got, []string{"data-science"}) {
+		t.Errorf("small groups = %v, want unchanged", got)
+	}
+}
+
+// TestMaaSAuthPolicyReconciler_GroupMapping verifies that the gateway AuthPolicy grants a
+// model to the identity provider groups mapped to the roles in the policy's subjects.
+func TestMaaSAuthPolicyReconciler_GroupMapping(t *testing.T) {
+	const namespace = "default"
+	model := newMaaSModelRef("llm", namespace, "ExternalModel", "llm")
+	route := newHTTPRoute("maas-llm", namespace)
+	policy := newMaaSAuthPolicy("premium", namespace, "maas-premium", maasv1alpha1.ModelRef{Name: "llm", Namespace: namespace})
+	mapping := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: GroupMappingConfigMapName, Namespace: namespace},
+		Data:       map[string]string{groupMappingKey: "ldap-premium: [maas-premium]\n"},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, policy, mapping).
+		WithStatusSubresource(&maasv1alpha1.MaaSAuthPolicy{}).
+		Build()
+	r := &MaaSAuthPolicyReconciler{Client: c, Scheme: scheme, MaaSAPINamespace: "maas-system", GatewayNamespace: "gateway-ns", GatewayName: "maas-default-gateway"}
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "premium", Namespace: namespace}}); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	authPolicy := &unstructured.Unstructured{}
+	authPolicy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	if err := c.Get(ctx, types.NamespacedName{Name: maasGatewayAuthPolicyName, Namespace: "gateway-ns"}, authPolicy); err != nil {
+		t.Fatalf("Get gateway AuthPolicy: %v", err)
+	}
+	rego, _, _ := unstructured.NestedString(authPolicy.Object, "spec", "defaults", "rules", "authorization", "require-group-membership", "opa", "rego")
+	if !strings.Contains(rego, `"ldap-premium"`) {
+		t.Errorf("gateway AuthPolicy should grant llm to the mapped ldap-premium group, got rego:\n%s", rego)
+	}
+
+	mapping.Data[groupMappingKey] = "ldap-premium: maas-premium"
+	if err := c.Update(ctx, mapping); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "premium", Namespace: namespace}}); err == nil {
+		t.Error("expected an invalid group mapping to fail the reconcile")
+	}
+	got := &maasv1alpha1.MaaSAuthPolicy{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "premium", Namespace: namespace}, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != maasv1alpha1.PhaseFailed {
+		t.Errorf("phase = %s, want Failed for an invalid group mapping", got.Status.Phase)
+	}
+}
+
+func TestMapGroupMappingToAuthPolicies(t *testing.T) {
+	ref := maasv1alpha1.ModelRef{Name: "llm", Namespace: "llm"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newMaaSAuthPolicy("a", "models-as-a-service", "team-a", ref),
+		newMaaSAuthPolicy("b", "other", "team-b", ref),
+	).Build()
+	r := &MaaSAuthPolicyReconciler{Client: c}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: GroupMappingConfigMapName, Namespace: "models-as-a-service"}}
+	want := []ctrl.Request{{NamespacedName: types.NamespacedName{Name: "a", Namespace: "models-as-a-service"}}}
+	if got := r.mapGroupMappingToAuthPolicies(context.Background(), cm); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+	cm.Name = "unrelated"
+	if got := r.mapGroupMappingToAuthPolicies(context.Background(), cm); len(got) != 0 {
+		t.Errorf("requests = %v, want none for another ConfigMap", got)
+	}
+
+	r.TenantNamespace = "models-as-a-service"
+	cm.Name, cm.Namespace = GroupMappingConfigMapName, "other"
+	if got := r.mapGroupMappingToAuthPolicies(context.Background(), cm); len(got) != 0 {
+		t.Errorf("requests = %v, want none for a mapping outside the subscription namespace", got)
+	}
+}
+
+// TestGroupMappingNamespace verifies that the controller reads the mapping from the
+// subscription namespace that maas-api reads it from.
+func TestGroupMappingNamespace(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{TenantNamespace: "models-as-a-service"}
+	if got := r.groupMappingNamespace("models-as-a-service"); got != "models-as-a-service" {
+		t.Errorf("groupMappingNamespace = %q, want models-as-a-service", got)
+	}
+	if got := r.groupMappingNamespace("other"); got != "models-as-a-service" {
+		t.Errorf("groupMappingNamespace = %q, want the --maas-subscription-namespace", got)
+	}
+	r.TenantNamespaceDiscoveryEnabled = true
+	if got := r.groupMappingNamespace("tenant-a"); got != "tenant-a" {
+		t.Errorf("groupMappingNamespace = %q, want the tenant namespace with discovery", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -53,7 +54,21 @@ func findAllSubscriptionsForModel(ctx context.Context, c client.Reader, modelNam
 		}
 		result = append(result, s)
 	}
-	return result, nil
+
+	// Subscriptions selecting the model through a wildcard modelRef or by label get it
+	// as a modelRef with the selecting entry's limits, so callers handle all alike.
+	model := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: modelNamespace, Name: modelName}, model); err != nil {
+		if apierrors.IsNotFound(err) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to get MaaSModelRef %s: %w", modelKey, err)
+	}
+	selecting, err := findSubscriptionsSelectingModel(ctx, c, model)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, selecting...), nil
 }
 
 // findAllAuthPoliciesForModel returns all MaaSAuthPolicies that reference the given model,
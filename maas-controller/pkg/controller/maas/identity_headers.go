@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	userIdentityHeader         = "X-MaaS-User"
+	groupsIdentityHeader       = "X-MaaS-Groups"
+	subscriptionIdentityHeader = "X-MaaS-Subscription"
+
+	// celSelectedSubscriptionAvailable is true once maas-api selected a subscription.
+	celSelectedSubscriptionAvailable = `has(auth.metadata["subscription-info"].name)`
+)
+
+// identityHeaderModels are the models, as "namespace/name", whose upstream requests get
+// each spec.identityHeaders header.
+type identityHeaderModels struct {
+	User         []string
+	Groups       []string
+	Subscription []string
+	// Metering is the spec.meteringMetadata reported for the models.
+	Metering []meteringModels
+}
+
+// aggregateIdentityHeaderModels merges the spec.identityHeaders of the policies: a model
+// gets a header when any of its policies enables it.
+func aggregateIdentityHeaderModels(policies []maasv1alpha1.MaaSAuthPolicy) identityHeaderModels {
+	var models identityHeaderModels
+	for _, p := range policies {
+		headers := p.Spec.IdentityHeaders
+		if headers == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		for _, ref := range p.Spec.ModelRefs {
+			key := ref.Namespace + "/" + ref.Name
+			if headers.User {
+				models.User = append(models.User, key)
+			}
+			if headers.Groups {
+				models.Groups = append(models.Groups, key)
+			}
+			if headers.Subscription {
+				models.Subscription = append(models.Subscription, key)
+			}
+		}
+	}
+	models.User = deduplicateAndSort(models.User)
+	models.Groups = deduplicateAndSort(models.Groups)
+	models.Subscription = deduplicateAndSort(models.Subscription)
+	models.Metering = aggregateMeteringModels(policies)
+	return models
+}
+
+// celRequestedModelIn matches requests to one of the models.
+func celRequestedModelIn(models []string) string {
+	return celModelIdentityAvailable + ` && ` + celModelIdentity + ` in ` + celStringList(models)
+}
+
+// addIdentityHeaders adds the spec.identityHeaders headers of the models to the success
+// headers of the gateway AuthPolicy. X-MaaS-Subscription is otherwise set to the
+// requested subscription; for the models enabling it, it is set to the selected
+// subscription instead.
+func addIdentityHeaders(headers map[string]kuadrantv1.HeaderResponse, models identityHeaderModels) {
+	if len(models.User) > 0 {
+		headers[userIdentityHeader] = kuadrantv1.HeaderResponse{ResponseItem: kuadrantv1.ResponseItem{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{Predicate: celRequestedModelIn(models.User)}},
+			},
+			Plain: &kuadrantv1.ValueFrom{Expression: celUsername},
+		}}
+	}
+	if len(models.Groups) > 0 {
+		headers[groupsIdentityHeader] = kuadrantv1.HeaderResponse{ResponseItem: kuadrantv1.ResponseItem{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{Predicate: celRequestedModelIn(models.Groups)}},
+			},
+			// Groups that could split the list or break the header are left out.
+			Plain: &kuadrantv1.ValueFrom{Expression: `(` + celGroups + `).filter(g, g.matches('` + safeGroupNamePattern + `')).join(",")`},
+		}}
+	}
+	if len(models.Subscription) > 0 {
+		enabled := celRequestedModelIn(models.Subscription)
+		requested := headers[subscriptionIdentityHeader]
+		requested.When = []kuadrantv1.WhenCondition{{Predicate: `!(` + enabled + `) && (` + celRequestedSubscriptionAvailable + `)`}}
+		headers[subscriptionIdentityHeader] = requested
+		headers[subscriptionIdentityHeader+"-Selected"] = kuadrantv1.HeaderResponse{
+			ResponseItem: kuadrantv1.ResponseItem{
+				CommonRule: kuadrantv1.CommonRule{
+					When: []kuadrantv1.WhenCondition{{Predicate: enabled + ` && ` + celSelectedSubscriptionAvailable}},
+				},
+				Plain: &kuadrantv1.ValueFrom{Expression: `auth.metadata["subscription-info"].name`},
+			},
+			Key: subscriptionIdentityHeader,
+		}
+	}
+}
+
+// aggregateTenantIdentityHeaders returns the merged spec.identityHeaders of the enforced
+// MaaSAuthPolicies in a namespace.
+func (r *MaaSAuthPolicyReconciler) aggregateTenantIdentityHeaders(ctx context.Context, policyNamespace string) (identityHeaderModels, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policyNamespace)
+	if err != nil {
+		return identityHeaderModels{}, err
+	}
+	return aggregateIdentityHeaderModels(policies), nil
+}
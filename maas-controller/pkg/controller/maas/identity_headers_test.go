@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestAggregateIdentityHeaderModels(t *testing.T) {
+	modelA := maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"}
+	modelB := maasv1alpha1.ModelRef{Name: "model-b", Namespace: "llm"}
+
+	userOnly := newMaaSAuthPolicy("user-only", "default", "team-a", modelA, modelB)
+	userOnly.Spec.IdentityHeaders = &maasv1alpha1.IdentityHeaders{User: true}
+	all := newMaaSAuthPolicy("all", "default", "team-b", modelA)
+	all.Spec.IdentityHeaders = &maasv1alpha1.IdentityHeaders{User: true, Groups: true, Subscription: true}
+	deleting := newMaaSAuthPolicy("deleting", "default", "team-c", modelB)
+	deleting.Spec.IdentityHeaders = &maasv1alpha1.IdentityHeaders{Groups: true}
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	none := newMaaSAuthPolicy("none", "default", "team-d", modelB)
+
+	models := aggregateIdentityHeaderModels([]maasv1alpha1.MaaSAuthPolicy{*userOnly, *all, *deleting, *none})
+	if got, want := strings.Join(models.User, ","), "llm/model-a,llm/model-b"; got != want {
+		t.Errorf("User = %q, want %q", got, want)
+	}
+	if got, want := strings.Join(models.Groups, ","), "llm/model-a"; got != want {
+		t.Errorf("Groups = %q, want %q (deleting policies do not contribute)", got, want)
+	}
+	if got, want := strings.Join(models.Subscription, ","), "llm/model-a"; got != want {
+		t.Errorf("Subscription = %q, want %q", got, want)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_IdentityHeaders(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	headers := spec.Defaults.Rules.Response.Success.Headers
+	for _, name := range []string{userIdentityHeader, groupsIdentityHeader, subscriptionIdentityHeader + "-Selected"} {
+		if _, ok := headers[name]; ok {
+			t.Errorf("%s should not be set without spec.identityHeaders", name)
+		}
+	}
+	if got := headers[subscriptionIdentityHeader].When[0].Predicate; got != celRequestedSubscriptionAvailable {
+		t.Errorf("X-MaaS-Subscription predicate = %q, want the requested subscription check only", got)
+	}
+
+	spec = r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{
+		User:         []string{"llm/model-a", "llm/model-b"},
+		Groups:       []string{"llm/model-a"},
+		Subscription: []string{"llm/model-b"},
+	}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	headers = spec.Defaults.Rules.Response.Success.Headers
+
+	user, ok := headers[userIdentityHeader]
+	if !ok {
+		t.Fatalf("%s header missing", userIdentityHeader)
+	}
+	if !strings.Contains(user.When[0].Predicate, `in ["llm/model-a", "llm/model-b"]`) {
+		t.Errorf("X-MaaS-User should be limited to its models, got %q", user.When[0].Predicate)
+	}
+	if user.Plain.Expression != celUsername {
+		t.Errorf("X-MaaS-User value = %q, want the username", user.Plain.Expression)
+	}
+
+	groups, ok := headers[groupsIdentityHeader]
+	if !ok {
+		t.Fatalf("%s header missing", groupsIdentityHeader)
+	}
+	if !strings.Contains(groups.When[0].Predicate, `in ["llm/model-a"]`) {
+		t.Errorf("X-MaaS-Groups should be limited to its models, got %q", groups.When[0].Predicate)
+	}
+	if !strings.Contains(groups.Plain.Expression, safeGroupNamePattern) || !strings.HasSuffix(groups.Plain.Expression, `.join(",")`) {
+		t.Errorf("X-MaaS-Groups should join the safe groups, got %q", groups.Plain.Expression)
+	}
+
+	selected, ok := headers[subscriptionIdentityHeader+"-Selected"]
+	if !ok {
+		t.Fatal("selected subscription header missing")
+	}
+	if selected.Key != subscriptionIdentityHeader || !strings.Contains(selected.When[0].Predicate, `in ["llm/model-b"]`) {
+		t.Errorf("selected subscription header = %+v, want X-MaaS-Subscription for llm/model-b", selected)
+	}
+	requested := headers[subscriptionIdentityHeader].When[0].Predicate
+	if !strings.HasPrefix(requested, `!(`) || !strings.Contains(requested, `in ["llm/model-b"]`) {
+		t.Errorf("the requested subscription header should skip llm/model-b, got %q", requested)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// defaultJWKSRefreshSeconds is how often an issuer's JWKS is refetched when no policy
+// sets spec.authentication.jwt.jwksRefreshSeconds.
+const defaultJWKSRefreshSeconds = 300
+
+// jwtIssuer is an issuer of spec.authentication.jwt, merged over the MaaSAuthPolicies
+// declaring it.
+type jwtIssuer struct {
+	IssuerURL string
+	// Audiences is empty when a policy declaring the issuer does not restrict audiences.
+	Audiences          []string
+	JWKSRefreshSeconds int64
+}
+
+// aggregateJWTIssuers returns the JWT issuers the policies declare, sorted by URL. The
+// audiences of an issuer declared by several policies are combined, and its JWKS is
+// refreshed as often as the most frequent of them asks.
+func aggregateJWTIssuers(policies []maasv1alpha1.MaaSAuthPolicy) ([]jwtIssuer, error) {
+	byURL := map[string]*jwtIssuer{}
+	unrestricted := map[string]bool{}
+	for _, p := range policies {
+		jwt := policyJWT(&p)
+		if jwt == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := validateCELValue(jwt.IssuerURL, "JWT issuer URL"); err != nil {
+			return nil, fmt.Errorf("invalid JWT authentication in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+		}
+		refresh := jwt.JWKSRefreshSeconds
+		if refresh <= 0 {
+			refresh = defaultJWKSRefreshSeconds
+		}
+		issuer, ok := byURL[jwt.IssuerURL]
+		if !ok {
+			issuer = &jwtIssuer{IssuerURL: jwt.IssuerURL, JWKSRefreshSeconds: refresh}
+			byURL[jwt.IssuerURL] = issuer
+		}
+		issuer.JWKSRefreshSeconds = min(issuer.JWKSRefreshSeconds, refresh)
+		if len(jwt.Audiences) == 0 {
+			unrestricted[jwt.IssuerURL] = true
+		}
+		issuer.Audiences = append(issuer.Audiences, jwt.Audiences...)
+	}
+
+	issuers := make([]jwtIssuer, 0, len(byURL))
+	for url, issuer := range byURL {
+		if unrestricted[url] {
+			issuer.Audiences = nil
+		} else {
+			issuer.Audiences = deduplicateAndSort(issuer.Audiences)
+		}
+		issuers = append(issuers, *issuer)
+	}
+	sort.Slice(issuers, func(i, j int) bool { return issuers[i].IssuerURL < issuers[j].IssuerURL })
+	return issuers, nil
+}
+
+func policyJWT(p *maasv1alpha1.MaaSAuthPolicy) *maasv1alpha1.JWTAuthentication {
+	if p.Spec.Authentication == nil {
+		return nil
+	}
+	return p.Spec.Authentication.JWT
+}
+
+// jwtRuleSuffix names the rules of an issuer by a hash of its URL, which is not a valid
+// rule name itself.
+func jwtRuleSuffix(issuerURL string) string {
+	sum := sha256.Sum256([]byte(issuerURL))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// addJWTAuthenticationRules adds an authentication rule per issuer, tried alongside the
+// tenant's OIDC provider before Kubernetes TokenReview, and an authorization rule
+// checking the audiences of issuers that restrict them.
+func addJWTAuthenticationRules(authn map[string]kuadrantv1.AuthenticationRule, authz map[string]kuadrantv1.AuthorizationRule, issuers []jwtIssuer, celIsNotAPIKey string) {
+	for _, issuer := range issuers {
+		suffix := jwtRuleSuffix(issuer.IssuerURL)
+		authn["jwt-"+suffix] = kuadrantv1.AuthenticationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celIsNotAPIKey + ` && request.headers.authorization.matches("^Bearer [^.]+\\.[^.]+\\.[^.]+$")`,
+				}},
+				Priority: 1,
+			},
+			JWT: &kuadrantv1.JWTAuth{IssuerURL: issuer.IssuerURL, TTL: issuer.JWKSRefreshSeconds},
+		}
+		if len(issuer.Audiences) == 0 {
+			continue
+		}
+		authz["jwt-audiences-"+suffix] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celIsNotAPIKey + ` && has(auth.identity.iss) && auth.identity.iss == "` + issuer.IssuerURL + `"`,
+				}},
+			},
+			OPA: &kuadrantv1.OPAAuthorization{
+				Rego: `audiences := ` + celStringList(issuer.Audiences) + `
+
+aud := object.get(input.auth.identity, "aud", [])
+
+allow {
+	is_string(aud)
+	audiences[_] == aud
+}
+
+allow {
+	is_array(aud)
+	audiences[_] == aud[_]
+}`,
+			},
+		}
+	}
+}
+
+// jwtIssuerCacheKeySuffix extends the cache key of the model access check with the
+// token's issuer when issuers are scoped to models, so that a token of one issuer does
+// not reuse the decision cached for the same user and groups from another.
+func jwtIssuerCacheKeySuffix(issuers []jwtIssuer, celIsNotAPIKey string) string {
+	if len(issuers) == 0 {
+		return ""
+	}
+	return ` + "|" + ((` + celIsNotAPIKey + `) && has(auth.identity.iss) ? auth.identity.iss : "")`
+}
+
+// jwtIssuerURLs returns the URLs of the issuers.
+func jwtIssuerURLs(issuers []jwtIssuer) []string {
+	urls := make([]string, 0, len(issuers))
+	for _, issuer := range issuers {
+		urls = append(urls, issuer.IssuerURL)
+	}
+	return urls
+}
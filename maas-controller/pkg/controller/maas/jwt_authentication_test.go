@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const keycloakIssuer = "https://keycloak.example.com/realms/maas"
+
+// newJWTAuthPolicy returns a MaaSAuthPolicy accepting the issuer's tokens for a model.
+func newJWTAuthPolicy(name, model string, jwt maasv1alpha1.JWTAuthentication) maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-"+name, maasv1alpha1.ModelRef{Name: model, Namespace: "default"})
+	p.Spec.Authentication = &maasv1alpha1.AuthenticationSpec{JWT: &jwt}
+	return *p
+}
+
+func TestAggregateJWTIssuers(t *testing.T) {
+	deleting := newJWTAuthPolicy("deleting", "llm", maasv1alpha1.JWTAuthentication{IssuerURL: "https://gone.example.com"})
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	deleting.Finalizers = []string{maasAuthPolicyFinalizer}
+	policies := []maasv1alpha1.MaaSAuthPolicy{
+		newJWTAuthPolicy("a", "llm", maasv1alpha1.JWTAuthentication{IssuerURL: keycloakIssuer, Audiences: []string{"maas", "chat"}}),
+		newJWTAuthPolicy("b", "llm", maasv1alpha1.JWTAuthentication{IssuerURL: keycloakIssuer, Audiences: []string{"maas"}, JWKSRefreshSeconds: 60}),
+		newJWTAuthPolicy("c", "llm", maasv1alpha1.JWTAuthentication{IssuerURL: "https://auth.example.com"}),
+		*newMaaSAuthPolicy("d", "default", "team-d", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}),
+		deleting,
+	}
+
+	got, err := aggregateJWTIssuers(policies)
+	if err != nil {
+		t.Fatalf("aggregateJWTIssuers: %v", err)
+	}
+	want := []jwtIssuer{
+		{IssuerURL: "https://auth.example.com", JWKSRefreshSeconds: defaultJWKSRefreshSeconds},
+		{IssuerURL: keycloakIssuer, Audiences: []string{"chat", "maas"}, JWKSRefreshSeconds: 60},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issuers = %+v, want %+v", got, want)
+	}
+
+	policies = append(policies, newJWTAuthPolicy("e", "llm", maasv1alpha1.JWTAuthentication{IssuerURL: keycloakIssuer}))
+	got, err = aggregateJWTIssuers(policies)
+	if err != nil {
+		t.Fatalf("aggregateJWTIssuers: %v", err)
+	}
+	if got[1].Audiences != nil {
+		t.Errorf("expected no audience restriction once a policy declares the issuer without audiences, got %v", got[1].Audiences)
+	}
+}
+
+func TestAggregateSubjectAllowlists_JWTIssuers(t *testing.T) {
+	allowlists, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{
+		newJWTAuthPolicy("a", "llm-a", maasv1alpha1.JWTAuthentication{IssuerURL: keycloakIssuer}),
+		*newMaaSAuthPolicy("b", "default", "team-b", maasv1alpha1.ModelRef{Name: "llm-b", Namespace: "default"}),
+	})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists: %v", err)
+	}
+	if got := allowlists["default/llm-a"].Issuers; !reflect.DeepEqual(got, []string{keycloakIssuer}) {
+		t.Errorf("llm-a issuers = %v, want [%s]", got, keycloakIssuer)
+	}
+	if got := allowlists["default/llm-b"].Issuers; got != nil {
+		t.Errorf("expected no issuers for llm-b, got %v", got)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_JWTIssuers(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{
+		MaaSAPINamespace: "maas-system",
+		ClusterAudience:  "https://kubernetes.default.svc",
+		MetadataCacheTTL: 60,
+		AuthzCacheTTL:    60,
+	}
+	issuers := []jwtIssuer{
+		{IssuerURL: "https://auth.example.com", JWKSRefreshSeconds: 300},
+		{IssuerURL: keycloakIssuer, Audiences: []string{"maas"}, JWKSRefreshSeconds: 60},
+	}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{JWTIssuers: issuers}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+
+	suffix := jwtRuleSuffix(keycloakIssuer)
+	issuer := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authentication", "jwt-"+suffix, "jwt", "issuerUrl")
+	if issuer != keycloakIssuer {
+		t.Errorf("jwt issuerUrl = %s, want %s", issuer, keycloakIssuer)
+	}
+	if ttl, _, _ := unstructured.NestedInt64(obj.Object, "spec", "defaults", "rules", "authentication", "jwt-"+suffix, "jwt", "ttl"); ttl != 60 {
+		t.Errorf("jwt ttl = %d, want 60", ttl)
+	}
+	if _, exists := nestedMapRequired(t, obj, "spec", "defaults", "rules", "authentication")["openshift-identities"]; !exists {
+		t.Error("openshift-identities should stay present alongside JWT issuers")
+	}
+
+	authz := nestedMapRequired(t, obj, "spec", "defaults", "rules", "authorization")
+	if _, exists := authz["jwt-audiences-"+jwtRuleSuffix("https://auth.example.com")]; exists {
+		t.Error("an issuer without audiences should get no audience check")
+	}
+	rego := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "jwt-audiences-"+suffix, "opa", "rego")
+	if !contains(rego, `audiences := ["maas"]`) {
+		t.Errorf("audience check should list the issuer's audiences, got: %s", rego)
+	}
+	predicate := nestedWhenPredicateRequired(t, obj, "spec", "defaults", "rules", "authorization", "jwt-audiences-"+suffix, "when")
+	if !contains(predicate, `auth.identity.iss == "`+keycloakIssuer+`"`) {
+		t.Errorf("audience check should only run for the issuer's tokens, got: %s", predicate)
+	}
+	if _, exists := authz["oidc-groups-safe"]; !exists {
+		t.Error("oidc-groups-safe should be present for JWT issuers")
+	}
+
+	cacheKey := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "cache", "key", "selector")
+	if !contains(cacheKey, "auth.identity.iss") {
+		t.Errorf("require-group-membership cache key should include the token issuer, got: %s", cacheKey)
+	}
+
+	membership := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "opa", "rego")
+	if !contains(membership, `jwt_issuers := ["https://auth.example.com", "`+keycloakIssuer+`"]`) || !contains(membership, "issuer_allowed") {
+		t.Errorf("require-group-membership should scope JWT issuers to their models, got: %s", membership)
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	// DefaultKeycloakAdminTimeout bounds a single Keycloak admin API request.
+	DefaultKeycloakAdminTimeout = 10 * time.Second
+
+	// keycloakAudienceMapperName and keycloakGroupsMapperName are the protocol mappers
+	// added to provisioned clients.
+	keycloakAudienceMapperName = "maas-audience"
+	keycloakGroupsMapperName   = "groups"
+
+	// keycloakOwnerAttribute is the client attribute recording the tenant namespace a
+	// client was created for. The secrets of clients without it, or created for another
+	// tenant, are never read.
+	keycloakOwnerAttribute = "maas.opendatahub.io/tenant"
+)
+
+var (
+	// errKeycloakIssuerNotAllowed is returned for issuer URLs outside the Keycloak server
+	// of the admin credentials, which are only ever sent to that server.
+	errKeycloakIssuerNotAllowed = errors.New("issuer URL is not on the Keycloak server of the admin credentials")
+	// errKeycloakClientConflict is returned when the client ID is taken by a client that
+	// maas-controller did not create for the tenant.
+	errKeycloakClientConflict = errors.New("keycloak client exists and was not created by maas-controller for this tenant")
+)
+
+// KeycloakAdminCredentials authenticate against the Keycloak admin API, either as a user
+// with a password or as a client with a secret.
+type KeycloakAdminCredentials struct {
+	// ServerURL is the Keycloak server the credentials are sent to, https://<host>[/<path>].
+	// Clients are only provisioned for issuers on this server.
+	ServerURL string
+	// Realm is the realm the credentials belong to, "master" when empty.
+	Realm string
+	// ClientID is the client requesting the token, "admin-cli" when empty.
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+}
+
+// KeycloakAdmin provisions OIDC clients through the Keycloak admin REST API.
+type KeycloakAdmin struct {
+	Client *http.Client
+}
+
+// NewKeycloakAdmin returns a KeycloakAdmin with the given request timeout.
+func NewKeycloakAdmin(timeout time.Duration) *KeycloakAdmin {
+	if timeout <= 0 {
+		timeout = DefaultKeycloakAdminTimeout
+	}
+	return &KeycloakAdmin{Client: &http.Client{Timeout: timeout}}
+}
+
+// keycloakRealmURL splits a Keycloak issuer URL, https://<host>[/<path>]/realms/<realm>,
+// into the server URL and the realm.
+func keycloakRealmURL(issuerURL string) (serverURL, realm string, err error) {
+	u, err := url.Parse(issuerURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid issuer URL %q: %w", issuerURL, err)
+	}
+	prefix, realm, ok := strings.Cut(strings.TrimSuffix(u.Path, "/"), "/realms/")
+	if !ok || realm == "" || strings.Contains(realm, "/") {
+		return "", "", fmt.Errorf("issuer URL %q is not a Keycloak realm URL (https://<host>/realms/<realm>)", issuerURL)
+	}
+	u.Path, u.RawQuery, u.Fragment = prefix, "", ""
+	return u.String(), realm, nil
+}
+
+// sameKeycloakServer reports whether the server URLs a and b have the same scheme, host
+// and path.
+func sameKeycloakServer(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return ua.Scheme != "" && ua.Host != "" &&
+		strings.EqualFold(ua.Scheme, ub.Scheme) &&
+		strings.EqualFold(ua.Host, ub.Host) &&
+		strings.TrimSuffix(ua.Path, "/") == strings.TrimSuffix(ub.Path, "/")
+}
+
+type keycloakClient struct {
+	ID         string            `json:"id,omitempty"`
+	ClientID   string            `json:"clientId"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+type keycloakProtocolMapper struct {
+	Name           string            `json:"name"`
+	Protocol       string            `json:"protocol"`
+	ProtocolMapper string            `json:"protocolMapper"`
+	Config         map[string]string `json:"config"`
+}
+
+// keycloakClientMappers are the protocol mappers of a provisioned client: the audience
+// mapper puts the client ID in the aud claim of access tokens, the groups mapper the
+// user's groups in the groups claim the gateway matches subjects against.
+func keycloakClientMappers(clientID string) []keycloakProtocolMapper {
+	return []keycloakProtocolMapper{
+		{
+			Name:           keycloakAudienceMapperName,
+			Protocol:       "openid-connect",
+			ProtocolMapper: "oidc-audience-mapper",
+			Config: map[string]string{
+				"included.client.audience": clientID,
+				"access.token.claim":       "true",
+				"id.token.claim":           "false",
+			},
+		},
+		{
+			Name:           keycloakGroupsMapperName,
+			Protocol:       "openid-connect",
+			ProtocolMapper: "oidc-group-membership-mapper",
+			Config: map[string]string{
+				"claim.name":           "groups",
+				"full.path":            "false",
+				"access.token.claim":   "true",
+				"id.token.claim":       "true",
+				"userinfo.token.claim": "true",
+			},
+		},
+	}
+}
+
+// EnsureClient creates clientID as a confidential client in the realm of the issuer URL
+// for the tenant of namespace owner unless it exists, adds the audience and groups
+// mappers it lacks, and returns its secret. Other settings of an existing client are left
+// as they are. The issuer must be on the server of creds, and an existing client must
+// have been created by EnsureClient for owner.
+func (k *KeycloakAdmin) EnsureClient(ctx context.Context, creds KeycloakAdminCredentials, issuerURL, clientID, owner string) (string, error) {
+	serverURL, realm, err := keycloakRealmURL(issuerURL)
+	if err != nil {
+		return "", err
+	}
+	if !sameKeycloakServer(creds.ServerURL, serverURL) {
+		return "", fmt.Errorf("%w: %s is not %s", errKeycloakIssuerNotAllowed, serverURL, creds.ServerURL)
+	}
+	serverURL = strings.TrimSuffix(creds.ServerURL, "/")
+	token, err := k.token(ctx, serverURL, creds)
+	if err != nil {
+		return "", err
+	}
+	clientsURL := serverURL + "/admin/realms/" + url.PathEscape(realm) + "/clients"
+
+	existing, err := k.findClient(ctx, token, clientsURL, clientID)
+	if err != nil {
+		return "", err
+	}
+	var id string
+	if existing == nil {
+		client := map[string]any{
+			"clientId":                  clientID,
+			"name":                      clientID,
+			"description":               "Provisioned by maas-controller",
+			"enabled":                   true,
+			"protocol":                  "openid-connect",
+			"publicClient":              false,
+			"clientAuthenticatorType":   "client-secret",
+			"standardFlowEnabled":       false,
+			"directAccessGrantsEnabled": true,
+			"protocolMappers":           keycloakClientMappers(clientID),
+			"attributes":                map[string]string{keycloakOwnerAttribute: owner},
+		}
+		if err := k.do(ctx, token, http.MethodPost, clientsURL, client, nil); err != nil {
+			return "", fmt.Errorf("failed to create Keycloak client %q: %w", clientID, err)
+		}
+		created, err := k.findClient(ctx, token, clientsURL, clientID)
+		if err != nil {
+			return "", err
+		}
+		if created == nil {
+			return "", fmt.Errorf("keycloak client %q not found after creating it", clientID)
+		}
+		id = created.ID
+	} else {
+		if existing.Attributes[keycloakOwnerAttribute] != owner {
+			return "", fmt.Errorf("%w: %q", errKeycloakClientConflict, clientID)
+		}
+		id = existing.ID
+		mappersURL := clientsURL + "/" + url.PathEscape(id) + "/protocol-mappers/models"
+		var mappers []keycloakProtocolMapper
+		if err := k.do(ctx, token, http.MethodGet, mappersURL, nil, &mappers); err != nil {
+			return "", fmt.Errorf("failed to list protocol mappers of Keycloak client %q: %w", clientID, err)
+		}
+		names := make(map[string]bool, len(mappers))
+		for _, m := range mappers {
+			names[m.Name] = true
+		}
+		for _, m := range keycloakClientMappers(clientID) {
+			if names[m.Name] {
+				continue
+			}
+			if err := k.do(ctx, token, http.MethodPost, mappersURL, m, nil); err != nil {
+				return "", fmt.Errorf("failed to add protocol mapper %q to Keycloak client %q: %w", m.Name, clientID, err)
+			}
+		}
+	}
+
+	var secret struct {
+		Value string `json:"value"`
+	}
+	if err := k.do(ctx, token, http.MethodGet, clientsURL+"/"+url.PathEscape(id)+"/client-secret", nil, &secret); err != nil {
+		return "", fmt.Errorf("failed to get the secret of Keycloak client %q: %w", clientID, err)
+	}
+	if secret.Value == "" {
+		return "", fmt.Errorf("keycloak client %q has no secret; it must be a confidential client", clientID)
+	}
+	return secret.Value, nil
+}
+
+// findClient returns the client with clientID, or nil if there is none.
+func (k *KeycloakAdmin) findClient(ctx context.Context, token, clientsURL, clientID string) (*keycloakClient, error) {
+	var clients []keycloakClient
+	query := url.Values{"clientId": {clientID}}
+	if err := k.do(ctx, token, http.MethodGet, clientsURL+"?"+query.Encode(), nil, &clients); err != nil {
+		return nil, fmt.Errorf("failed to look up Keycloak client %q: %w", clientID, err)
+	}
+	for i := range clients {
+		if clients[i].ClientID == clientID {
+			return &clients[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// token obtains an admin API access token for the credentials.
+func (k *KeycloakAdmin) token(ctx context.Context, serverURL string, creds KeycloakAdminCredentials) (string, error) {
+	realm := creds.Realm
+	if realm == "" {
+		realm = "master"
+	}
+	form := url.Values{"client_id": {creds.ClientID}}
+	if creds.ClientID == "" {
+		form.Set("client_id", "admin-cli")
+	}
+	switch {
+	case creds.Username != "":
+		form.Set("grant_type", "password")
+		form.Set("username", creds.Username)
+		form.Set("password", creds.Password)
+		if creds.ClientSecret != "" {
+			form.Set("client_secret", creds.ClientSecret)
+		}
+	case creds.ClientSecret != "":
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_secret", creds.ClientSecret)
+	default:
+		return "", errors.New("keycloak admin credentials need a username and password or a client secret")
+	}
+
+	tokenURL := serverURL + "/realms/" + url.PathEscape(realm) + "/protocol/openid-connect/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	setReconcileRequestID(req)
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return "", fmt.Errorf("keycloak admin login at %s returned HTTP %d", tokenURL, resp.StatusCode)
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tok); err != nil {
+		return "", fmt.Errorf("failed to decode Keycloak token response: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return "", errors.New("keycloak token response has no access_token")
+	}
+	return tok.AccessToken, nil
+}
+
+// do sends an admin API request with a JSON body and decodes the JSON response into out.
+func (k *KeycloakAdmin) do(ctx context.Context, token, method, target string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	setReconcileRequestID(req)
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("%s %s returned HTTP %d", method, target, resp.StatusCode)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, target, err)
+	}
+	return nil
+}
+
+// setReconcileRequestID sends the reconcileID of the controller's log lines as the
+// X-Request-ID of a Keycloak request, so that Keycloak's logs can be matched with them.
+func setReconcileRequestID(req *http.Request) {
+	if id := controller.ReconcileIDFromContext(req.Context()); id != "" {
+		req.Header.Set("X-Request-ID", string(id))
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
+)
+
+// fakeKeycloak serves the parts of the Keycloak admin API used to provision clients.
+type fakeKeycloak struct {
+	mu         sync.Mutex
+	clients    map[string][]keycloakProtocolMapper
+	attributes map[string]map[string]string
+	logins     []string
+}
+
+func newFakeKeycloak(t *testing.T) (*fakeKeycloak, *httptest.Server) {
+	t.Helper()
+	kc := &fakeKeycloak{clients: map[string][]keycloakProtocolMapper{}, attributes: map[string]map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("password") != "admin-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		kc.mu.Lock()
+		kc.logins = append(kc.logins, r.PostForm.Get("grant_type"))
+		kc.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "admin-token"})
+	})
+	authorized := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer admin-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			kc.mu.Lock()
+			defer kc.mu.Unlock()
+			h(w, r)
+		}
+	}
+	mux.HandleFunc("GET /admin/realms/maas/clients", authorized(func(w http.ResponseWriter, r *http.Request) {
+		out := []keycloakClient{}
+		clientID := r.URL.Query().Get("clientId")
+		if _, ok := kc.clients[clientID]; ok {
+			out = append(out, keycloakClient{ID: "id-" + clientID, ClientID: clientID, Attributes: kc.attributes[clientID]})
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+	mux.HandleFunc("POST /admin/realms/maas/clients", authorized(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ClientID        string                   `json:"clientId"`
+			PublicClient    bool                     `json:"publicClient"`
+			ProtocolMappers []keycloakProtocolMapper `json:"protocolMappers"`
+			Attributes      map[string]string        `json:"attributes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.PublicClient {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		kc.clients[body.ClientID] = body.ProtocolMappers
+		kc.attributes[body.ClientID] = body.Attributes
+		w.WriteHeader(http.StatusCreated)
+	}))
+	mux.HandleFunc("GET /admin/realms/maas/clients/{id}/protocol-mappers/models", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(kc.clients[r.PathValue("id")[len("id-"):]])
+	}))
+	mux.HandleFunc("POST /admin/realms/maas/clients/{id}/protocol-mappers/models", authorized(func(w http.ResponseWriter, r *http.Request) {
+		var m keycloakProtocolMapper
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id := r.PathValue("id")[len("id-"):]
+		kc.clients[id] = append(kc.clients[id], m)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	mux.HandleFunc("GET /admin/realms/maas/clients/{id}/client-secret", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"type": "secret", "value": "secret-of-" + r.PathValue("id")})
+	}))
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return kc, server
+}
+
+func TestKeycloakRealmURL(t *testing.T) {
+	for issuer, want := range map[string][2]string{
+		"https://keycloak.example.com/realms/maas":       {"https://keycloak.example.com", "maas"},
+		"https://keycloak.example.com/auth/realms/maas/": {"https://keycloak.example.com/auth", "maas"},
+	} {
+		server, realm, err := keycloakRealmURL(issuer)
+		if err != nil || server != want[0] || realm != want[1] {
+			t.Errorf("keycloakRealmURL(%q) = %q, %q, %v; want %q, %q", issuer, server, realm, err, want[0], want[1])
+		}
+	}
+	for _, issuer := range []string{"https://login.example.com/oauth2", "https://keycloak.example.com/realms/"} {
+		if _, _, err := keycloakRealmURL(issuer); err == nil {
+			t.Errorf("keycloakRealmURL(%q) expected an error", issuer)
+		}
+	}
+}
+
+func TestKeycloakAdmin_EnsureClient(t *testing.T) {
+	kc, server := newFakeKeycloak(t)
+	admin := &KeycloakAdmin{Client: server.Client()}
+	creds := KeycloakAdminCredentials{ServerURL: server.URL, Username: "admin", Password: "admin-password"}
+	ctx := context.Background()
+
+	secret, err := admin.EnsureClient(ctx, creds, server.URL+"/realms/maas", "maas", "tenant-a")
+	if err != nil {
+		t.Fatalf("EnsureClient: %v", err)
+	}
+	if secret != "secret-of-id-maas" {
+		t.Errorf("secret = %q, want the client's secret", secret)
+	}
+	if mappers := kc.clients["maas"]; len(mappers) != 2 || mappers[0].Config["included.client.audience"] != "maas" {
+		t.Errorf("mappers = %+v, want the audience and groups mappers", mappers)
+	}
+	if len(kc.logins) != 1 || kc.logins[0] != "password" {
+		t.Errorf("logins = %v, want one password grant", kc.logins)
+	}
+
+	if got := kc.attributes["maas"][keycloakOwnerAttribute]; got != "tenant-a" {
+		t.Errorf("owner attribute = %q, want the tenant namespace", got)
+	}
+
+	// An existing client of the tenant without the mappers gets them added, once.
+	kc.clients["legacy"] = []keycloakProtocolMapper{{Name: keycloakGroupsMapperName}}
+	kc.attributes["legacy"] = map[string]string{keycloakOwnerAttribute: "tenant-a"}
+	for range 2 {
+		if _, err := admin.EnsureClient(ctx, creds, server.URL+"/realms/maas", "legacy", "tenant-a"); err != nil {
+			t.Fatalf("EnsureClient: %v", err)
+		}
+	}
+	if mappers := kc.clients["legacy"]; len(mappers) != 2 || mappers[1].Name != keycloakAudienceMapperName {
+		t.Errorf("mappers = %+v, want only the audience mapper added", mappers)
+	}
+
+	if _, err := admin.EnsureClient(ctx, KeycloakAdminCredentials{ServerURL: server.URL, Username: "admin", Password: "wrong"}, server.URL+"/realms/maas", "maas", "tenant-a"); err == nil {
+		t.Error("expected rejected admin credentials to fail")
+	}
+	if _, err := admin.EnsureClient(ctx, KeycloakAdminCredentials{ServerURL: server.URL}, server.URL+"/realms/maas", "maas", "tenant-a"); err == nil {
+		t.Error("expected missing admin credentials to fail")
+	}
+}
+
+func TestKeycloakAdmin_EnsureClientRefusesForeignClients(t *testing.T) {
+	kc, server := newFakeKeycloak(t)
+	admin := &KeycloakAdmin{Client: server.Client()}
+	creds := KeycloakAdminCredentials{ServerURL: server.URL, Username: "admin", Password: "admin-password"}
+	ctx := context.Background()
+
+	kc.clients["unrelated"] = nil
+	if _, err := admin.EnsureClient(ctx, creds, server.URL+"/realms/maas", "maas", "tenant-a"); err != nil {
+		t.Fatalf("EnsureClient: %v", err)
+	}
+	for clientID, owner := range map[string]string{
+		"unrelated": "tenant-a", // not created by maas-controller
+		"maas":      "tenant-b", // created for another tenant
+	} {
+		_, err := admin.EnsureClient(ctx, creds, server.URL+"/realms/maas", clientID, owner)
+		if !errors.Is(err, errKeycloakClientConflict) {
+			t.Errorf("EnsureClient(%q, %q) error = %v, want a client conflict", clientID, owner, err)
+		}
+	}
+}
+
+func TestKeycloakAdmin_EnsureClientRefusesOtherServers(t *testing.T) {
+	kc, server := newFakeKeycloak(t)
+	admin := &KeycloakAdmin{Client: server.Client()}
+	creds := KeycloakAdminCredentials{ServerURL: "https://keycloak.example.com", Username: "admin", Password: "admin-password"}
+
+	_, err := admin.EnsureClient(context.Background(), creds, server.URL+"/realms/maas", "maas", "tenant-a")
+	if !errors.Is(err, errKeycloakIssuerNotAllowed) {
+		t.Fatalf("error = %v, want the issuer refused", err)
+	}
+	if len(kc.logins) != 0 {
+		t.Errorf("logins = %v, want the admin credentials never sent", kc.logins)
+	}
+}
+
+func TestSameKeycloakServer(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{a: "https://keycloak.example.com", b: "https://keycloak.example.com", want: true},
+		{a: "https://Keycloak.example.com/auth/", b: "https://keycloak.example.com/auth", want: true},
+		{a: "https://keycloak.example.com", b: "https://attacker.example.com"},
+		{a: "https://keycloak.example.com", b: "http://keycloak.example.com"},
+		{a: "https://keycloak.example.com/auth", b: "https://keycloak.example.com"},
+		{a: "", b: ""},
+	} {
+		if got := sameKeycloakServer(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameKeycloakServer(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestTenantReconciler_ReconcileOIDCClient(t *testing.T) {
+	_, server := newFakeKeycloak(t)
+	tenant := &maasv1alpha1.Tenant{ObjectMeta: metav1.ObjectMeta{Name: maasv1alpha1.TenantInstanceName, Namespace: "models-as-a-service", UID: "tenant-uid"}}
+	adminSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "maas-keycloak-initial-admin", Namespace: "keycloak-system"},
+		Data:       map[string][]byte{"url": []byte(server.URL), "username": []byte("admin"), "password": []byte("admin-password")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant, adminSecret).Build()
+	r := &TenantReconciler{Client: c, Scheme: scheme}
+	platformContext := tenantreconcile.PlatformContext{ExternalOIDC: &maasv1alpha1.TenantExternalOIDCConfig{
+		IssuerURL: server.URL + "/realms/maas", ClientID: "maas", ProvisionClient: true,
+	}}
+	ctx := context.Background()
+
+	r.reconcileOIDCClient(ctx, logr.Discard(), tenant, platformContext)
+	if cond := apimeta.FindStatusCondition(tenant.Status.Conditions, ConditionOIDCClientProvisioned); cond == nil || cond.Reason != "KeycloakAdminNotConfigured" {
+		t.Errorf("condition = %+v, want KeycloakAdminNotConfigured without --keycloak-admin-secret", cond)
+	}
+
+	r.KeycloakAdminSecret = types.NamespacedName{Namespace: "keycloak-system", Name: "maas-keycloak-initial-admin"}
+	r.KeycloakAdmin = &KeycloakAdmin{Client: server.Client()}
+	r.reconcileOIDCClient(ctx, logr.Discard(), tenant, platformContext)
+	if cond := apimeta.FindStatusCondition(tenant.Status.Conditions, ConditionOIDCClientProvisioned); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("condition = %+v, want True", cond)
+	}
+	if got := tenant.Status.OIDCClient; got == nil || got.ClientID != "maas" || got.SecretName != TenantOIDCClientSecretName {
+		t.Errorf("status.oidcClient = %+v, want the maas client", got)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "models-as-a-service", Name: TenantOIDCClientSecretName}, secret); err != nil {
+		t.Fatalf("Get client Secret: %v", err)
+	}
+	if string(secret.Data["client-id"]) != "maas" || string(secret.Data["client-secret"]) != "secret-of-id-maas" {
+		t.Errorf("client Secret data = %v, want the client ID and secret", secret.Data)
+	}
+
+	// A tenant pointing its issuer at another server never gets the admin credentials.
+	platformContext.ExternalOIDC.IssuerURL = "https://attacker.example.com/realms/maas"
+	r.reconcileOIDCClient(ctx, logr.Discard(), tenant, platformContext)
+	if cond := apimeta.FindStatusCondition(tenant.Status.Conditions, ConditionOIDCClientProvisioned); cond == nil || cond.Reason != "IssuerNotAllowed" {
+		t.Errorf("condition = %+v, want IssuerNotAllowed", cond)
+	}
+
+	platformContext.ExternalOIDC.ProvisionClient = false
+	r.reconcileOIDCClient(ctx, logr.Discard(), tenant, platformContext)
+	if tenant.Status.OIDCClient != nil || apimeta.FindStatusCondition(tenant.Status.Conditions, ConditionOIDCClientProvisioned) != nil {
+		t.Errorf("status = %+v, want the OIDC client cleared once provisionClient is unset", tenant.Status)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// DefaultLifecycleSinkTimeout bounds a single request to the lifecycle event sink.
+const DefaultLifecycleSinkTimeout = 5 * time.Second
+
+// Kubernetes Event reasons of the lifecycle of a MaaSModelRef in the catalog.
+const (
+	ReasonModelRegistered     = "ModelRegistered"
+	ReasonModelReady          = "ModelReady"
+	ReasonModelPolicyEnforced = "ModelPolicyEnforced"
+	ReasonModelRemoved        = "ModelRemoved"
+)
+
+// lifecycleEventTypes are the CloudEvents types of the lifecycle reasons.
+var lifecycleEventTypes = map[string]string{
+	ReasonModelRegistered:     "io.opendatahub.maas.model.registered",
+	ReasonModelReady:          "io.opendatahub.maas.model.ready",
+	ReasonModelPolicyEnforced: "io.opendatahub.maas.model.policy_enforced",
+	ReasonModelRemoved:        "io.opendatahub.maas.model.removed",
+}
+
+// lifecycleEventsTotal counts the lifecycle events sent to the sink by type and result.
+var lifecycleEventsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "maas_controller_lifecycle_events_total",
+		Help: "Number of MaaSModelRef lifecycle events sent to the lifecycle event sink, by type and result.",
+	},
+	[]string{"type", "result"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(lifecycleEventsTotal)
+}
+
+// ModelLifecycleEvent is a structured-mode CloudEvent of the lifecycle of a MaaSModelRef.
+type ModelLifecycleEvent struct {
+	SpecVersion string `json:"specversion"`
+	// ID is derived from the UID and resourceVersion of the model and the reason, so
+	// that sinks can drop duplicates.
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	// Subject is the MaaSModelRef as "namespace/name".
+	Subject         string             `json:"subject"`
+	Time            time.Time          `json:"time"`
+	DataContentType string             `json:"datacontenttype"`
+	Data            ModelLifecycleData `json:"data"`
+}
+
+// ModelLifecycleData is the data of a ModelLifecycleEvent.
+type ModelLifecycleData struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	// Kind is spec.modelRef.kind, e.g. LLMInferenceService or ExternalModel.
+	Kind       string `json:"kind"`
+	Phase      string `json:"phase,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	Visibility string `json:"visibility"`
+	Message    string `json:"message"`
+}
+
+// newModelLifecycleEvent returns the CloudEvent of a lifecycle reason of a model.
+func newModelLifecycleEvent(model *maasv1alpha1.MaaSModelRef, reason, message string, now time.Time) ModelLifecycleEvent {
+	return ModelLifecycleEvent{
+		SpecVersion:     "1.0",
+		ID:              fmt.Sprintf("%s-%s-%s", model.UID, reason, model.ResourceVersion),
+		Source:          "maas-controller",
+		Type:            lifecycleEventTypes[reason],
+		Subject:         model.Namespace + "/" + model.Name,
+		Time:            now.UTC(),
+		DataContentType: "application/json",
+		Data: ModelLifecycleData{
+			Name:       model.Name,
+			Namespace:  model.Namespace,
+			UID:        string(model.UID),
+			Kind:       model.Spec.ModelRef.Kind,
+			Phase:      model.Status.Phase,
+			Endpoint:   model.Status.Endpoint,
+			Visibility: string(effectiveVisibility(model)),
+			Message:    message,
+		},
+	}
+}
+
+// LifecycleSink delivers the lifecycle events of the models, e.g. to a portal that
+// mirrors the catalog.
+type LifecycleSink interface {
+	Publish(ctx context.Context, event ModelLifecycleEvent) error
+}
+
+// WebhookLifecycleSink posts lifecycle events to a URL as structured-mode CloudEvents.
+type WebhookLifecycleSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookLifecycleSink returns a WebhookLifecycleSink with the given request timeout.
+func NewWebhookLifecycleSink(url string, timeout time.Duration) *WebhookLifecycleSink {
+	if timeout <= 0 {
+		timeout = DefaultLifecycleSinkTimeout
+	}
+	return &WebhookLifecycleSink{URL: url, Client: &http.Client{Timeout: timeout}}
+}
+
+// Publish posts the event and fails unless the sink answers with a 2xx status.
+func (s *WebhookLifecycleSink) Publish(ctx context.Context, event ModelLifecycleEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json; charset=UTF-8")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("POST %s returned HTTP %d", s.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// publishLifecycle records a lifecycle event of the model as a Kubernetes Event and
+// sends it to the lifecycle sink. Delivery to the sink is best effort: a failure is
+// logged and counted, and does not fail the reconcile.
+func (r *MaaSModelRefReconciler) publishLifecycle(ctx context.Context, model *maasv1alpha1.MaaSModelRef, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(model, corev1.EventTypeNormal, reason, message)
+	}
+	if r.LifecycleSink == nil {
+		return
+	}
+	event := newModelLifecycleEvent(model, reason, message, time.Now())
+	if err := r.LifecycleSink.Publish(ctx, event); err != nil {
+		lifecycleEventsTotal.WithLabelValues(event.Type, "error").Inc()
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to publish lifecycle event", "type", event.Type)
+		return
+	}
+	lifecycleEventsTotal.WithLabelValues(event.Type, "success").Inc()
+}
+
+// publishStatusTransitions publishes the lifecycle events of a status update: the
+// model became Ready, or the AuthPolicy protecting it became enforced.
+func (r *MaaSModelRefReconciler) publishStatusTransitions(ctx context.Context, model *maasv1alpha1.MaaSModelRef, previous *maasv1alpha1.MaaSModelStatus) {
+	if model.Status.Phase == "Ready" && previous.Phase != "Ready" {
+		r.publishLifecycle(ctx, model, ReasonModelReady, "Model is Ready at "+model.Status.Endpoint)
+	}
+	enforced := apimeta.FindStatusCondition(model.Status.Conditions, maasv1alpha1.ConditionPoliciesEnforced)
+	if enforced != nil && enforced.Status == metav1.ConditionTrue &&
+		!apimeta.IsStatusConditionTrue(previous.Conditions, maasv1alpha1.ConditionPoliciesEnforced) {
+		r.publishLifecycle(ctx, model, ReasonModelPolicyEnforced, enforced.Message)
+	}
+}
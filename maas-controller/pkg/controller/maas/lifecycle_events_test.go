@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// fakeLifecycleSink records the published events and fails while err is set.
+type fakeLifecycleSink struct {
+	events []ModelLifecycleEvent
+	err    error
+}
+
+func (f *fakeLifecycleSink) Publish(_ context.Context, event ModelLifecycleEvent) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeLifecycleSink) types() []string {
+	var got []string
+	for _, e := range f.events {
+		got = append(got, e.Type)
+	}
+	return got
+}
+
+func TestWebhookLifecycleSink_Publish(t *testing.T) {
+	var contentType string
+	var got ModelLifecycleEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	model := newMaaSModelRef("granite", "llm", "LLMInferenceService", "granite")
+	model.UID, model.ResourceVersion = "uid-1", "42"
+	model.Status.Phase, model.Status.Endpoint = "Ready", "https://maas.example.com/llm/granite"
+	event := newModelLifecycleEvent(model, ReasonModelReady, "Model is Ready", time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+
+	if err := NewWebhookLifecycleSink(srv.URL+"/ok", time.Second).Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if !strings.HasPrefix(contentType, "application/cloudevents+json") {
+		t.Errorf("Content-Type = %q, want application/cloudevents+json", contentType)
+	}
+	if !reflect.DeepEqual(got, event) {
+		t.Errorf("sink received %+v, want %+v", got, event)
+	}
+	if got.SpecVersion != "1.0" || got.Type != "io.opendatahub.maas.model.ready" || got.Subject != "llm/granite" || got.ID != "uid-1-ModelReady-42" {
+		t.Errorf("CloudEvent attributes = %+v", got)
+	}
+	if got.Data.Endpoint != model.Status.Endpoint || got.Data.Visibility != "public" {
+		t.Errorf("CloudEvent data = %+v", got.Data)
+	}
+	if err := NewWebhookLifecycleSink(srv.URL+"/fail", time.Second).Publish(context.Background(), event); err == nil {
+		t.Error("expected an error for a non-2xx response")
+	}
+}
+
+func TestModelLifecycleEvents(t *testing.T) {
+	const testKind = "_test_lifecycle"
+	backendHandlerFactories[testKind] = func(_ *MaaSModelRefReconciler) BackendHandler {
+		return &fakeHandler{endpoint: "https://model.example.com", ready: true}
+	}
+	defer delete(backendHandlerFactories, testKind)
+
+	ctx := context.Background()
+	model := newMaaSModelRef("catalog-model", "default", testKind, "backend")
+	sub := newMaaSSubscription("sub1", "admin-ns", "team-a", "catalog-model", 100)
+	sub.Spec.ModelRefs[0].Namespace = "default"
+	authPolicy := newMaaSAuthPolicy("auth1", "admin-ns", "team-a",
+		maasv1alpha1.ModelRef{Name: "catalog-model", Namespace: "default"})
+
+	r, c := newTestReconciler(model, sub, authPolicy)
+	recorder := record.NewFakeRecorder(10)
+	sink := &fakeLifecycleSink{}
+	r.Recorder, r.LifecycleSink = recorder, sink
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "catalog-model", Namespace: "default"}}
+
+	for range 2 {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+	}
+	want := []string{"io.opendatahub.maas.model.registered", "io.opendatahub.maas.model.ready"}
+	if got := sink.types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("events after two reconciles = %v, want %v", got, want)
+	}
+	if ready := sink.events[1]; ready.Data.Endpoint != "https://model.example.com" || ready.Data.Phase != "Ready" {
+		t.Errorf("ready event data = %+v", ready.Data)
+	}
+
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := c.Delete(ctx, got); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile deletion: %v", err)
+	}
+	want = append(want, "io.opendatahub.maas.model.removed")
+	if got := sink.types(); !reflect.DeepEqual(got, want) {
+		t.Errorf("events after deletion = %v, want %v", got, want)
+	}
+
+	close(recorder.Events)
+	var reasons []string
+	for e := range recorder.Events {
+		reasons = append(reasons, strings.Fields(e)[1])
+	}
+	if wantReasons := []string{ReasonModelRegistered, ReasonModelReady, ReasonModelRemoved}; !reflect.DeepEqual(reasons, wantReasons) {
+		t.Errorf("Kubernetes event reasons = %v, want %v", reasons, wantReasons)
+	}
+}
+
+func TestPublishStatusTransitions_PolicyEnforced(t *testing.T) {
+	sink := &fakeLifecycleSink{}
+	r := &MaaSModelRefReconciler{LifecycleSink: sink}
+	model := newMaaSModelRef("granite", "llm", "LLMInferenceService", "granite")
+	previous := model.Status.DeepCopy()
+	model.Status.Phase = "Pending"
+	model.Status.Conditions = []metav1.Condition{{
+		Type:    maasv1alpha1.ConditionPoliciesEnforced,
+		Status:  metav1.ConditionTrue,
+		Reason:  string(maasv1alpha1.ReasonAcceptedEnforced),
+		Message: "AuthPolicy openshift-ingress/gateway-auth is accepted and enforced",
+	}}
+
+	r.publishStatusTransitions(context.Background(), model, previous)
+	if got := sink.types(); !reflect.DeepEqual(got, []string{"io.opendatahub.maas.model.policy_enforced"}) {
+		t.Fatalf("events = %v, want the policy enforced event", got)
+	}
+	if sink.events[0].Data.Message != model.Status.Conditions[0].Message {
+		t.Errorf("message = %q, want the condition message", sink.events[0].Data.Message)
+	}
+
+	// An enforced policy that stays enforced is not published again.
+	r.publishStatusTransitions(context.Background(), model, model.Status.DeepCopy())
+	if len(sink.events) != 1 {
+		t.Errorf("events = %d after an unchanged condition, want 1", len(sink.events))
+	}
+
+	// A failed delivery is counted.
+	failed := lifecycleEventsTotal.WithLabelValues("io.opendatahub.maas.model.removed", "error")
+	before := testutil.ToFloat64(failed)
+	sink.err = errors.New("sink unavailable")
+	r.publishLifecycle(context.Background(), model, ReasonModelRemoved, "Model is removed from the catalog")
+	if got := testutil.ToFloat64(failed) - before; got != 1 {
+		t.Errorf("error count increased by %v, want 1", got)
+	}
+}
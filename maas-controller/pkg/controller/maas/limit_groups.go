@@ -0,0 +1,298 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// limitGroupsComponent is the app.kubernetes.io/component label of the Gateway-level
+// TokenRateLimitPolicy carrying spec.limitGroups.
+const limitGroupsComponent = "limit-groups"
+
+// limitGroupsPolicyName returns the name of the Gateway-level TokenRateLimitPolicy
+// carrying the limitGroups of the subscriptions of a Gateway's tenants.
+func limitGroupsPolicyName(gatewayName string) string {
+	return gatewayName + "-maas-limit-groups"
+}
+
+// limitGroupLimitKey returns the TRLP limit key of a subscription's limit group, e.g.
+// "<namespace>-<subscription>-<group>-group-tokens".
+func limitGroupLimitKey(subNamespace, subName, groupName string) string {
+	return subscriptionLimitKey(subNamespace, subName, groupName, "group-tokens")
+}
+
+// ValidateLimitGroups validates spec.limitGroups: every model of a group must be listed
+// by name in spec.modelRefs, and the group's rates follow the rules of a modelRef's.
+// The MaaSSubscription webhook runs the same checks at admission.
+func ValidateLimitGroups(sub *maasv1alpha1.MaaSSubscription) error {
+	modelRefs := make(map[string]bool, len(sub.Spec.ModelRefs))
+	for _, ref := range sub.Spec.ModelRefs {
+		if !isWildcardModelRef(ref) {
+			modelRefs[ref.Namespace+"/"+ref.Name] = true
+		}
+	}
+	for _, group := range sub.Spec.LimitGroups {
+		for _, ref := range group.ModelRefs {
+			if !modelRefs[ref.Namespace+"/"+ref.Name] {
+				return fmt.Errorf("limit group %q: model %s/%s is not listed by name in spec.modelRefs", group.Name, ref.Namespace, ref.Name)
+			}
+		}
+		if err := validateTokenRateLimits(group.TokenRateLimits); err != nil {
+			return fmt.Errorf("limit group %q: invalid tokenRateLimits: %w", group.Name, err)
+		}
+		if err := validateAnchoredRates(group.TokenRateLimits, sub.Spec.ResetSchedule); err != nil {
+			return fmt.Errorf("limit group %q: invalid tokenRateLimits: %w", group.Name, err)
+		}
+	}
+	return nil
+}
+
+// limitGroupPredicate returns the predicate selecting the requests of a subscription to
+// any model of a limit group. The selected subscription key carries the model, so one
+// predicate on the Gateway covers every model's route.
+func limitGroupPredicate(sub *maasv1alpha1.MaaSSubscription, group maasv1alpha1.LimitGroup) string {
+	keys := make([]string, 0, len(group.ModelRefs))
+	for _, ref := range group.ModelRefs {
+		keys = append(keys, fmt.Sprintf("%s/%s@%s/%s", sub.Namespace, sub.Name, ref.Namespace, ref.Name))
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf(`auth.identity.selected_subscription_key in %s && !request.path.endsWith("/v1/models")`, celStringList(keys))
+}
+
+// buildLimitGroupsSpec builds the Gateway-level TokenRateLimitPolicy spec for the limit
+// groups of the given subscriptions and returns it with the sorted, namespace-qualified
+// names of the contributing subscriptions. Suspended subscriptions are skipped, as their
+// models' limits already deny every request, and so are subscriptions whose groups are
+// invalid. A nil spec means no subscription has a limit group.
+//
+// The limits are defaults with the merge strategy, so that Kuadrant adds them to the
+// TokenRateLimitPolicy of each model's HTTPRoute instead of the route policies
+// replacing them.
+func buildLimitGroupsSpec(log logr.Logger, subs []maasv1alpha1.MaaSSubscription, gatewayName string) (*kuadrantv1alpha1.TokenRateLimitPolicySpec, []string) {
+	limitsMap := map[string]kuadrantv1.Limit{}
+	var subNames []string
+	for i := range subs {
+		sub := &subs[i]
+		if len(sub.Spec.LimitGroups) == 0 || sub.Spec.Suspended {
+			continue
+		}
+		if err := ValidateResetSchedule(sub.Spec.ResetSchedule); err != nil {
+			log.Error(err, "Skipping limit groups of subscription with invalid reset schedule", "subscription", qualifiedName(sub.Namespace, sub.Name))
+			continue
+		}
+		if err := ValidateCounterExpressions(sub.Spec.CounterExpressions); err != nil {
+			log.Error(err, "Skipping limit groups of subscription with invalid counter expressions", "subscription", qualifiedName(sub.Namespace, sub.Name))
+			continue
+		}
+		if err := ValidateLimitGroups(sub); err != nil {
+			log.Error(err, "Skipping invalid limit groups — fix the spec to include them in the TRLP", "subscription", qualifiedName(sub.Namespace, sub.Name))
+			continue
+		}
+		for _, group := range sub.Spec.LimitGroups {
+			addSubscriptionLimits(limitsMap, limitGroupLimitKey(sub.Namespace, sub.Name, group.Name), limitGroupPredicate(sub, group), sub, group.TokenRateLimits)
+		}
+		subNames = append(subNames, qualifiedName(sub.Namespace, sub.Name))
+	}
+	if len(subNames) == 0 {
+		return nil, nil
+	}
+	sort.Strings(subNames)
+	return &kuadrantv1alpha1.TokenRateLimitPolicySpec{
+		TargetRef: kuadrantv1.TargetRef{
+			Group: "gateway.networking.k8s.io",
+			Kind:  "Gateway",
+			Name:  gatewayName,
+		},
+		Defaults: &kuadrantv1alpha1.MergeableTokenRateLimitPolicy{
+			Strategy: kuadrantv1alpha1.MergeStrategy,
+			Limits:   limitsMap,
+		},
+	}, subNames
+}
+
+// subscriptionGateway returns the Gateway of the subscription's tenant, or an empty ref
+// when none is configured.
+func (r *MaaSSubscriptionReconciler) subscriptionGateway(ctx context.Context, namespace string) (maasv1alpha1.TenantGatewayRef, error) {
+	return tenantGatewayRefForNamespace(ctx, r.Client, namespace, r.DefaultTenantNamespace, r.GatewayName, r.GatewayNamespace, r.TenantNamespaceDiscoveryEnabled)
+}
+
+// reconcileLimitGroups rebuilds the Gateway-level TokenRateLimitPolicy of the tenant
+// Gateway of the subscription from the limit groups of every subscription on that
+// Gateway, deleting it when none has one. It runs for every subscription so that
+// removing a subscription's last group also removes its limits.
+func (r *MaaSSubscriptionReconciler) reconcileLimitGroups(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) error {
+	gatewayRef, err := r.subscriptionGateway(ctx, subscription.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to resolve tenant gateway for limit groups: %w", err)
+	}
+	if gatewayRef.Name == "" || gatewayRef.Namespace == "" {
+		return nil
+	}
+	if !r.WatchNamespaces.Contains(gatewayRef.Namespace) {
+		log.Info("gateway namespace is not watched, skipping limit groups", "gateway", gatewayRef.Namespace+"/"+gatewayRef.Name)
+		return nil
+	}
+
+	var all maasv1alpha1.MaaSSubscriptionList
+	if err := r.List(ctx, &all); err != nil {
+		return fmt.Errorf("failed to list MaaSSubscriptions for limit groups: %w", err)
+	}
+	subs := filterSubscriptionsByTenantNamespace(ctx, r.Client, all.Items, r.DefaultTenantNamespace, r.TenantNamespaceDiscoveryEnabled)
+	subs = excludeDryRunSubscriptions(subs)
+	gateways := map[string]maasv1alpha1.TenantGatewayRef{}
+	var onGateway []maasv1alpha1.MaaSSubscription
+	for _, sub := range subs {
+		if !sub.GetDeletionTimestamp().IsZero() || len(sub.Spec.LimitGroups) == 0 {
+			continue
+		}
+		ref, ok := gateways[sub.Namespace]
+		if !ok {
+			if ref, err = r.subscriptionGateway(ctx, sub.Namespace); err != nil {
+				return fmt.Errorf("failed to resolve tenant gateway of subscription %s: %w", qualifiedName(sub.Namespace, sub.Name), err)
+			}
+			gateways[sub.Namespace] = ref
+		}
+		if ref == gatewayRef {
+			onGateway = append(onGateway, sub)
+		}
+	}
+
+	policyName := limitGroupsPolicyName(gatewayRef.Name)
+	spec, subNames := buildLimitGroupsSpec(log, onGateway, gatewayRef.Name)
+	if spec == nil {
+		return r.deleteLimitGroupsPolicy(ctx, log, gatewayRef.Namespace, policyName)
+	}
+
+	gateway := &gatewayapiv1.Gateway{}
+	if err := r.Get(ctx, types.NamespacedName{Name: gatewayRef.Name, Namespace: gatewayRef.Namespace}, gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("tenant gateway not found, skipping limit groups", "gateway", gatewayRef.Namespace+"/"+gatewayRef.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to fetch Gateway %s/%s: %w", gatewayRef.Namespace, gatewayRef.Name, err)
+	}
+
+	specMap, err := kuadrantv1.ToUnstructured(spec)
+	if err != nil {
+		return err
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(r.trlpGVK())
+	policy.SetName(policyName)
+	policy.SetNamespace(gatewayRef.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(policy), policy); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get TokenRateLimitPolicy %s/%s: %w", gatewayRef.Namespace, policyName, err)
+	}
+	if policy.GetResourceVersion() != "" && !isManaged(policy) {
+		log.Info("TokenRateLimitPolicy opted out, skipping reconciliation", "name", policyName, "namespace", gatewayRef.Namespace)
+		return nil
+	}
+	snapshot := policy.DeepCopy()
+
+	labels := policy.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["app.kubernetes.io/managed-by"] = "maas-controller"
+	labels["app.kubernetes.io/part-of"] = "maas-subscription"
+	labels["app.kubernetes.io/component"] = limitGroupsComponent
+	policy.SetLabels(labels)
+	annotations := policy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["maas.opendatahub.io/subscriptions"] = strings.Join(subNames, ",")
+	policy.SetAnnotations(annotations)
+	// The Gateway owns the policy so that it is garbage collected with it.
+	if err := controllerutil.SetControllerReference(gateway, policy, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on TokenRateLimitPolicy %s/%s: %w", gatewayRef.Namespace, policyName, err)
+	}
+	if err := unstructured.SetNestedMap(policy.Object, specMap, "spec"); err != nil {
+		return fmt.Errorf("failed to set spec: %w", err)
+	}
+
+	if snapshot.GetResourceVersion() == "" {
+		if err := r.Create(ctx, policy); err != nil {
+			return fmt.Errorf("failed to create TokenRateLimitPolicy %s/%s: %w", gatewayRef.Namespace, policyName, err)
+		}
+		log.Info("Limit groups TokenRateLimitPolicy created", "name", policyName, "namespace", gatewayRef.Namespace, "subscriptions", subNames)
+		return nil
+	}
+	if equality.Semantic.DeepEqual(snapshot.Object, policy.Object) {
+		return nil
+	}
+	if err := r.Update(ctx, policy); err != nil {
+		return fmt.Errorf("failed to update TokenRateLimitPolicy %s/%s: %w", gatewayRef.Namespace, policyName, err)
+	}
+	log.Info("Limit groups TokenRateLimitPolicy updated", "name", policyName, "namespace", gatewayRef.Namespace, "subscriptions", subNames)
+	return nil
+}
+
+// limitGroupsPolicySubscriptions maps the limit groups TokenRateLimitPolicy to the
+// subscriptions listed in its annotation, so that edits to it are reverted.
+func limitGroupsPolicySubscriptions(obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for item := range strings.SplitSeq(obj.GetAnnotations()["maas.opendatahub.io/subscriptions"], ",") {
+		namespace, name, ok := strings.Cut(strings.TrimSpace(item), "/")
+		if !ok || namespace == "" || name == "" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}})
+	}
+	return requests
+}
+
+// deleteLimitGroupsPolicy deletes the Gateway-level limit groups TokenRateLimitPolicy,
+// unless it is opted out.
+func (r *MaaSSubscriptionReconciler) deleteLimitGroupsPolicy(ctx context.Context, log logr.Logger, namespace, name string) error {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(r.trlpGVK())
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, policy); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get TokenRateLimitPolicy %s/%s: %w", namespace, name, err)
+	}
+	if !isManaged(policy) {
+		log.Info("TokenRateLimitPolicy opted out, skipping deletion", "name", name, "namespace", namespace)
+		return nil
+	}
+	log.Info("Deleting limit groups TokenRateLimitPolicy (no remaining limit groups)", "name", name, "namespace", namespace)
+	if err := r.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete TokenRateLimitPolicy %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
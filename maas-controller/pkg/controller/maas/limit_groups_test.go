@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// newGroupedSubscription returns a subscription to two models that share a limit group.
+func newGroupedSubscription(name, namespace string) *maasv1alpha1.MaaSSubscription {
+	sub := newMaaSSubscription(name, namespace, "team-a", "small-a", 100000)
+	sub.Spec.ModelRefs = append(sub.Spec.ModelRefs, maasv1alpha1.ModelSubscriptionRef{
+		Name:            "small-b",
+		Namespace:       namespace,
+		TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 100000, Window: "1m"}},
+	})
+	sub.Spec.LimitGroups = []maasv1alpha1.LimitGroup{{
+		Name:            "small",
+		ModelRefs:       []maasv1alpha1.ModelRef{{Name: "small-b", Namespace: namespace}, {Name: "small-a", Namespace: namespace}},
+		TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000000, Window: "24h"}},
+	}}
+	return sub
+}
+
+func TestBuildLimitGroupsSpec(t *testing.T) {
+	sub := newGroupedSubscription("sub-a", "default")
+	sub.Spec.ResetSchedule = &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily}
+	ungrouped := newMaaSSubscription("sub-b", "default", "team-b", "small-a", 100)
+	suspended := newGroupedSubscription("sub-c", "default")
+	suspended.Spec.Suspended = true
+
+	spec, subNames := buildLimitGroupsSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub, *ungrouped, *suspended}, "maas-gateway")
+	if spec == nil {
+		t.Fatal("expected a limit groups spec")
+	}
+	if want := []string{"default/sub-a"}; !reflect.DeepEqual(subNames, want) {
+		t.Errorf("subscriptions = %v, want %v", subNames, want)
+	}
+	if spec.TargetRef.Kind != "Gateway" || spec.TargetRef.Name != "maas-gateway" {
+		t.Errorf("expected the Gateway as target, got %+v", spec.TargetRef)
+	}
+	if len(spec.Limits) != 0 || spec.Defaults == nil || spec.Defaults.Strategy != kuadrantv1alpha1.MergeStrategy {
+		t.Fatalf("expected the limits as merged defaults, got %+v", spec)
+	}
+	want := map[string]kuadrantv1.Limit{
+		"default-sub-a-small-group-tokens-daily": {
+			Rates: []kuadrantv1.Rate{{Limit: 1000000, Window: anchoredDailyWindow}},
+			When: []kuadrantv1.Predicate{{
+				Predicate: `auth.identity.selected_subscription_key in ["default/sub-a@default/small-a", "default/sub-a@default/small-b"] && !request.path.endsWith("/v1/models")`,
+			}},
+			Counters: []kuadrantv1.Counter{{Expression: "auth.identity.userid"}, {Expression: resetPeriodExpression(sub.Spec.ResetSchedule)}},
+		},
+	}
+	if !reflect.DeepEqual(spec.Defaults.Limits, want) {
+		t.Errorf("limits = %+v, want %+v", spec.Defaults.Limits, want)
+	}
+
+	if spec, _ := buildLimitGroupsSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*ungrouped}, "maas-gateway"); spec != nil {
+		t.Errorf("expected no spec without limit groups, got %+v", spec)
+	}
+}
+
+func TestValidateLimitGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*maasv1alpha1.MaaSSubscription)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(*maasv1alpha1.MaaSSubscription) {}},
+		{
+			name: "model not in modelRefs",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.LimitGroups[0].ModelRefs = append(s.Spec.LimitGroups[0].ModelRefs, maasv1alpha1.ModelRef{Name: "large", Namespace: "default"})
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid rates",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.LimitGroups[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 200, Window: "1m"}, {Limit: 100, Window: "1h"}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		sub := newGroupedSubscription("sub-a", "default")
+		tt.mutate(sub)
+		if err := ValidateLimitGroups(sub); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestMaaSSubscriptionReconciler_LimitGroups verifies that a limit group becomes a
+// TokenRateLimitPolicy on the tenant Gateway, and that it is deleted with the last group.
+func TestMaaSSubscriptionReconciler_LimitGroups(t *testing.T) {
+	ctx := context.Background()
+	const (
+		namespace   = "default"
+		gatewayName = "maas-default-gateway"
+		gatewayNS   = "openshift-ingress"
+	)
+	gateway := &gatewayapiv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: gatewayNS}}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(
+			gateway,
+			newMaaSModelRef("small-a", namespace, "ExternalModel", "small-a"),
+			newMaaSModelRef("small-b", namespace, "ExternalModel", "small-b"),
+			newHTTPRouteWithGateway("maas-small-a", namespace, gatewayName, gatewayNS),
+			newHTTPRouteWithGateway("maas-small-b", namespace, gatewayName, gatewayNS),
+			newGroupedSubscription("sub-a", namespace),
+		).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, GatewayName: gatewayName, GatewayNamespace: gatewayNS}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	key := client.ObjectKey{Name: limitGroupsPolicyName(gatewayName), Namespace: gatewayNS}
+	if err := c.Get(ctx, key, policy); err != nil {
+		t.Fatalf("expected the limit groups TokenRateLimitPolicy on the Gateway: %v", err)
+	}
+	if kind, _, _ := unstructured.NestedString(policy.Object, "spec", "targetRef", "kind"); kind != "Gateway" {
+		t.Errorf("expected the policy to target the Gateway, got %q", kind)
+	}
+	limits, _, _ := unstructured.NestedMap(policy.Object, "spec", "defaults", "limits")
+	if _, ok := limits[limitGroupLimitKey(namespace, "sub-a", "small")]; !ok {
+		t.Errorf("expected the group's limit in the policy defaults, got %v", limits)
+	}
+	if owners := policy.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != gatewayName {
+		t.Errorf("expected the Gateway to own the policy, got %+v", owners)
+	}
+
+	sub := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, sub); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	sub.Spec.LimitGroups = nil
+	if err := c.Update(ctx, sub); err != nil {
+		t.Fatalf("Update MaaSSubscription: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, key, policy); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the limit groups TokenRateLimitPolicy to be deleted with the last group, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kservev1alpha1 "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// ExposeLabel, set to "true" on an LLMInferenceService, asks the onboarding
+	// controller to create a MaaSModelRef for it.
+	ExposeLabel = "maas.opendatahub.io/expose"
+
+	// autoOnboardedLabel marks MaaSModelRefs created by the onboarding controller.
+	// Only MaaSModelRefs carrying it are updated or deleted; hand-written ones are left alone.
+	autoOnboardedLabel = "maas.opendatahub.io/auto-onboarded"
+)
+
+//+kubebuilder:rbac:groups=serving.kserve.io,resources=llminferenceservices/finalizers,verbs=update
+
+// LLMISvcOnboardingReconciler creates a MaaSModelRef for every LLMInferenceService
+// labeled maas.opendatahub.io/expose=true. The MaaSModelRef has the same name and
+// namespace as the LLMInferenceService and is owned by it, so it is garbage-collected
+// with the service; removing the label deletes it.
+type LLMISvcOnboardingReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// isExposed reports whether the LLMInferenceService opted into auto-onboarding.
+func isExposed(obj metav1.Object) bool {
+	return obj.GetLabels()[ExposeLabel] == "true"
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *LLMISvcOnboardingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("LLMInferenceService", req.NamespacedName)
+
+	llmisvc := &kservev1alpha1.LLMInferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, llmisvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The owner reference takes care of the generated MaaSModelRef.
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch LLMInferenceService")
+		return ctrl.Result{}, err
+	}
+
+	existing := &maasv1alpha1.MaaSModelRef{}
+	err := r.Get(ctx, req.NamespacedName, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get MaaSModelRef %s: %w", req.NamespacedName, err)
+	}
+	found := err == nil
+
+	if !isExposed(llmisvc) || !llmisvc.GetDeletionTimestamp().IsZero() {
+		if found {
+			return ctrl.Result{}, r.deleteOnboardedModel(ctx, log, existing)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	desired := &maasv1alpha1.MaaSModelRef{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      llmisvc.Name,
+			Namespace: llmisvc.Namespace,
+			Labels: map[string]string{
+				autoOnboardedLabel:             "true",
+				"app.kubernetes.io/managed-by": "maas-controller",
+			},
+		},
+		Spec: maasv1alpha1.MaaSModelSpec{
+			ModelRef: maasv1alpha1.ModelReference{Kind: "LLMInferenceService", Name: llmisvc.Name},
+		},
+	}
+	if err := controllerutil.SetControllerReference(llmisvc, desired, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner reference on MaaSModelRef: %w", err)
+	}
+
+	if !found {
+		if err := r.Create(ctx, desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create MaaSModelRef %s: %w", req.NamespacedName, err)
+		}
+		log.Info("MaaSModelRef created for exposed LLMInferenceService")
+		return ctrl.Result{}, nil
+	}
+
+	if existing.Labels[autoOnboardedLabel] != "true" {
+		log.Info("MaaSModelRef with the same name already exists and was not auto-onboarded, leaving it unchanged")
+		return ctrl.Result{}, nil
+	}
+	if !isManaged(existing) {
+		log.Info("Auto-onboarded MaaSModelRef opted out, skipping update")
+		return ctrl.Result{}, nil
+	}
+	if existing.Spec.ModelRef == desired.Spec.ModelRef && metav1.IsControlledBy(existing, llmisvc) {
+		return ctrl.Result{}, nil
+	}
+	existing.Spec.ModelRef = desired.Spec.ModelRef
+	existing.OwnerReferences = desired.OwnerReferences
+	if err := r.Update(ctx, existing); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update MaaSModelRef %s: %w", req.NamespacedName, err)
+	}
+	log.Info("MaaSModelRef updated for exposed LLMInferenceService")
+	return ctrl.Result{}, nil
+}
+
+// deleteOnboardedModel deletes model if it was created by the onboarding controller.
+func (r *LLMISvcOnboardingReconciler) deleteOnboardedModel(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	if model.Labels[autoOnboardedLabel] != "true" {
+		return nil
+	}
+	if !isManaged(model) {
+		log.Info("Auto-onboarded MaaSModelRef opted out, skipping deletion")
+		return nil
+	}
+	log.Info("Deleting auto-onboarded MaaSModelRef, LLMInferenceService is no longer exposed")
+	if err := r.Delete(ctx, model); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete MaaSModelRef %s/%s: %w", model.Namespace, model.Name, err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LLMISvcOnboardingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("llmisvc-onboarding").
+		// The expose label lives in metadata, so label changes must trigger reconciliation too.
+		For(&kservev1alpha1.LLMInferenceService{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.Funcs{UpdateFunc: deletionTimestampSet},
+		))).
+		// Recreate or repair the generated MaaSModelRef if it is deleted or edited.
+		Owns(&maasv1alpha1.MaaSModelRef{}).
+		Complete(r)
+}
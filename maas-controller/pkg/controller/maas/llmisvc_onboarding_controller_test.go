@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func newOnboardingReconciler(objects ...client.Object) (*LLMISvcOnboardingReconciler, client.Client) {
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return &LLMISvcOnboardingReconciler{Client: c, Scheme: scheme}, c
+}
+
+// TestLLMISvcOnboarding_CreatesAndDeletesModel verifies that an exposed LLMInferenceService
+// gets a matching MaaSModelRef owned by it, and that removing the label deletes it.
+func TestLLMISvcOnboarding_CreatesAndDeletesModel(t *testing.T) {
+	ctx := context.Background()
+	llmisvc := newLLMISvc("granite", "models")
+	llmisvc.Labels = map[string]string{ExposeLabel: "true"}
+	r, c := newOnboardingReconciler(llmisvc)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "granite", Namespace: "models"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	model := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, model); err != nil {
+		t.Fatalf("expected MaaSModelRef to be created: %v", err)
+	}
+	if model.Spec.ModelRef.Kind != "LLMInferenceService" || model.Spec.ModelRef.Name != "granite" {
+		t.Errorf("unexpected modelRef: %+v", model.Spec.ModelRef)
+	}
+	if model.Labels[autoOnboardedLabel] != "true" {
+		t.Errorf("expected %s label, got %v", autoOnboardedLabel, model.Labels)
+	}
+	if !metav1.IsControlledBy(model, llmisvc) {
+		t.Errorf("expected MaaSModelRef to be controlled by the LLMInferenceService, got owners %+v", model.OwnerReferences)
+	}
+
+	// Repair: a manual edit of the generated modelRef is reverted.
+	model.Spec.ModelRef.Name = "other"
+	if err := c.Update(ctx, model); err != nil {
+		t.Fatalf("Update MaaSModelRef: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after edit: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, model); err != nil {
+		t.Fatalf("Get MaaSModelRef: %v", err)
+	}
+	if model.Spec.ModelRef.Name != "granite" {
+		t.Errorf("modelRef.name = %q, want granite", model.Spec.ModelRef.Name)
+	}
+
+	// Removing the label garbage-collects the MaaSModelRef.
+	if err := c.Get(ctx, req.NamespacedName, llmisvc); err != nil {
+		t.Fatalf("Get LLMInferenceService: %v", err)
+	}
+	delete(llmisvc.Labels, ExposeLabel)
+	if err := c.Update(ctx, llmisvc); err != nil {
+		t.Fatalf("Update LLMInferenceService: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after label removal: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, model); !apierrors.IsNotFound(err) {
+		t.Errorf("expected MaaSModelRef to be deleted after label removal, got err=%v", err)
+	}
+}
+
+// TestLLMISvcOnboarding_LeavesUserModelAlone verifies that a hand-written MaaSModelRef with
+// the same name is neither modified nor deleted by the onboarding controller.
+func TestLLMISvcOnboarding_LeavesUserModelAlone(t *testing.T) {
+	ctx := context.Background()
+	llmisvc := newLLMISvc("granite", "models")
+	llmisvc.Labels = map[string]string{ExposeLabel: "true"}
+	userModel := newMaaSModelRef("granite", "models", "LLMInferenceService", "granite")
+	userModel.Spec.EndpointOverride = "https://granite.example.com"
+	r, c := newOnboardingReconciler(llmisvc, userModel)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "granite", Namespace: "models"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSModelRef: %v", err)
+	}
+	if len(got.OwnerReferences) != 0 || got.Labels[autoOnboardedLabel] != "" {
+		t.Errorf("user MaaSModelRef was adopted: labels=%v owners=%+v", got.Labels, got.OwnerReferences)
+	}
+
+	delete(llmisvc.Labels, ExposeLabel)
+	if err := c.Update(ctx, llmisvc); err != nil {
+		t.Fatalf("Update LLMInferenceService: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after label removal: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Errorf("expected user MaaSModelRef to be kept, got err=%v", err)
+	}
+}
@@ -46,7 +46,9 @@ import (
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/reconciler/externalmodel"
 )
 
 // MaaSAuthPolicyReconciler reconciles a MaaSAuthPolicy object
@@ -69,10 +71,17 @@ type MaaSAuthPolicyReconciler struct {
 	// TenantNamespaceDiscoveryEnabled enables AITenant-labeled tenant namespaces.
 	TenantNamespaceDiscoveryEnabled bool
 
+	// WatchNamespaces limits the controller to these namespaces in namespace-scoped mode.
+	WatchNamespaces WatchNamespaces
+
 	// ClusterAudience is the OIDC audience of the cluster (configurable via flags).
 	// Standard clusters use "https://kubernetes.default.svc"; HyperShift/ROSA use a custom OIDC provider URL.
 	ClusterAudience string
 
+	// TokenReviewAudiences are accepted by the gateway's Kubernetes TokenReview in addition
+	// to ClusterAudience (configurable via flags), e.g. "<gateway-name>-sa".
+	TokenReviewAudiences []string
+
 	// MetadataCacheTTL is the TTL in seconds for Authorino metadata HTTP caching.
 	// Applies to apiKeyValidation and subscription-info metadata evaluators.
 	MetadataCacheTTL int64
@@ -83,12 +92,27 @@ type MaaSAuthPolicyReconciler struct {
 
 	// Recorder emits Kubernetes events for conflict detection warnings.
 	Recorder record.EventRecorder
+
+	// EnforcementRequeue controls polling while generated AuthPolicies are not enforced
+	// yet (zero fields use defaults).
+	EnforcementRequeue RequeueBackoff
+
+	// AuthProvider selects whether the gateway auth rules are a Kuadrant AuthPolicy
+	// (default) or an Authorino AuthConfig.
+	AuthProvider AuthProvider
+
+	// RoutingProvider selects whether ExternalModel routes are HTTPRoutes (default) or
+	// Istio VirtualServices. HTTPRoutes are not watched with the istio provider, which
+	// runs without the Gateway API CRDs.
+	RoutingProvider externalmodel.RoutingProvider
 }
 
 // oidcConfig holds OIDC configuration from Tenant CR
 type oidcConfig struct {
 	IssuerURL string
 	ClientID  string
+	// StepUp is set when the administrative routes require a recent token of the issuer.
+	StepUp *maasv1alpha1.TenantStepUpAuthConfig
 }
 
 // authzCacheTTL returns the safe TTL for authorization caches that depend on metadata.
@@ -241,6 +265,7 @@ func (r *MaaSAuthPolicyReconciler) fetchOIDCConfig(ctx context.Context, log logr
 	return &oidcConfig{
 		IssuerURL: oidc.IssuerURL,
 		ClientID:  oidc.ClientID,
+		StepUp:    oidc.StepUp,
 	}
 }
 
@@ -282,6 +307,10 @@ const (
 		`: (has(auth.identity.preferred_username) ? auth.identity.preferred_username ` +
 		`: (has(auth.identity.sub) ? auth.identity.sub : auth.identity.user.username))`
 
+	// celSubscriptionPriority is spec.priority of the selected subscription. maas-api omits
+	// a priority of 0 from the selection response.
+	celSubscriptionPriority = `has(auth.metadata["subscription-info"].priority) ? auth.metadata["subscription-info"].priority : 0`
+
 	// celGroups extracts groups from API key, OIDC, or K8s token
 	// API key: uses apiKeyValidation.groups (snapshot at key creation)
 	// OIDC: uses groups claim (no .user. prefix)
@@ -305,6 +334,10 @@ const (
 		`'["system:authenticated","' + auth.identity.user.groups.join('","') + '"]' : ` +
 		`'["system:authenticated"]')`
 
+	// celRequestedSubscriptionAvailable is true when the API key or the
+	// X-MaaS-Subscription request header names a subscription.
+	celRequestedSubscriptionAvailable = `(has(auth.metadata) && has(auth.metadata.apiKeyValidation) && auth.metadata.apiKeyValidation.subscription != "") || "x-maas-subscription" in request.headers`
+
 	celSubscription = `(has(auth.metadata) && has(auth.metadata.apiKeyValidation)) ` +
 		`? auth.metadata.apiKeyValidation.subscription : ` +
 		`("x-maas-subscription" in request.headers ? request.headers["x-maas-subscription"] : "")`
@@ -364,13 +397,17 @@ func subscriptionGatewayCacheKeySelector() string {
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maasauthpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maasauthpolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maasauthpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maasauthpolicytemplates,verbs=get;list;watch
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maasmodelrefs,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kuadrant.io,resources=authpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=authorino.kuadrant.io,resources=authconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
 //+kubebuilder:rbac:groups=config.openshift.io,resources=authentications,verbs=get
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=tenants,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 //+kubebuilder:rbac:groups=inference.opendatahub.io,resources=externalmodels,verbs=list
 
 // Reconcile is part of the main kubernetes reconciliation loop
@@ -438,6 +475,40 @@ func (r *MaaSAuthPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	authn, err := r.aggregateTenantAuthentication(ctx, policy.Namespace)
+	if err != nil {
+		log.Error(err, "failed to aggregate authentication for gateway AuthPolicy")
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to aggregate authentication: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+
+	identityHeaders, err := r.aggregateTenantIdentityHeaders(ctx, policy.Namespace)
+	if err != nil {
+		log.Error(err, "failed to aggregate identity headers for gateway AuthPolicy")
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to aggregate identity headers: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+
+	authz, err := r.aggregateTenantAuthorization(ctx, policy.Namespace)
+	if err != nil {
+		log.Error(err, "failed to aggregate authorization for gateway AuthPolicy")
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to aggregate authorization: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+
+	templates, err := r.aggregateAuthPolicyTemplates(ctx, policy.Namespace)
+	var templateNotFound *authPolicyTemplateNotFoundError
+	if errors.As(err, &templateNotFound) {
+		// The template's watch reconciles the policy once it is created.
+		r.updateStatusWithReason(ctx, policy, maasv1alpha1.PhaseFailed, maasv1alpha1.ReasonTemplateNotFound, templateNotFound.Error(), statusSnapshot)
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "failed to aggregate MaaSAuthPolicyTemplates for gateway AuthPolicy")
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to aggregate MaaSAuthPolicyTemplates: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+
 	oidc := r.fetchOIDCConfig(ctx, log, req.Namespace)
 	tenantID, err := r.fetchTenantIdentifier(ctx, log, req.Namespace)
 	if err != nil {
@@ -452,6 +523,12 @@ func (r *MaaSAuthPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to fetch gateway info: %v", err), statusSnapshot)
 		return ctrl.Result{}, err
 	}
+	// The gateway AuthPolicy is placed in the gateway's namespace. Retrying cannot help
+	// until the namespace is added to the watched set, which restarts the controller.
+	if !r.WatchNamespaces.Contains(gatewayNs) {
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, notWatchedMessage("Gateway", gatewayNs), statusSnapshot)
+		return ctrl.Result{}, nil
+	}
 
 	// Reconcile the gateway-level AuthPolicy for this tenant's gateway.
 	// In single-tenant mode: creates AuthPolicy for the default gateway.
@@ -474,7 +551,13 @@ func (r *MaaSAuthPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
-	if err := r.reconcileGatewayAuthPolicy(ctx, log, string(modelAllowlistsJSON), oidc, xAPIKeyEnabled, tenantID, gatewayNs, gatewayName); err != nil {
+	if err := r.reconcileClientCABundle(ctx, log, authn.X509CASecrets, gatewayNs, gatewayName); err != nil {
+		log.Error(err, "failed to reconcile client CA bundle")
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to reconcile client CA bundle: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileGatewayAuthPolicy(ctx, log, string(modelAllowlistsJSON), oidc, authn, identityHeaders, authz, templates, xAPIKeyEnabled, tenantID, gatewayNs, gatewayName); err != nil {
 		log.Error(err, "failed to reconcile gateway AuthPolicy")
 		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to reconcile gateway AuthPolicy: %v", err), statusSnapshot)
 		return ctrl.Result{}, err
@@ -529,10 +612,49 @@ func (r *MaaSAuthPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			"All conflicting AuthPolicies on MaaS auth surfaces have been resolved")
 	}
 
+	// Report rules contradicting another MaaSAuthPolicy of the same priority.
+	prevPolicyConflict := apimeta.FindStatusCondition(policy.Status.Conditions, ConditionPolicyConflict)
+	policyConflicts, err := r.detectPolicyConflicts(ctx, policy)
+	if err != nil {
+		log.Error(err, "failed to detect conflicts with other MaaSAuthPolicies")
+		r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to detect policy conflicts: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	setPolicyConflictCondition(policy, policyConflicts)
+	currPolicyConflict := apimeta.FindStatusCondition(policy.Status.Conditions, ConditionPolicyConflict)
+	if currPolicyConflict.Status == metav1.ConditionTrue && r.Recorder != nil &&
+		(prevPolicyConflict == nil || prevPolicyConflict.Message != currPolicyConflict.Message) {
+		r.Recorder.Event(policy, "Warning", "PolicyConflict", currPolicyConflict.Message)
+	}
+
+	// In dry-run mode the policy never contributes to the applied gateway AuthPolicy,
+	// so render the access rules it would produce instead.
+	dryRun := isDryRun(policy)
+	policy.Status.DryRunPreview = nil
+	if dryRun {
+		previews, err := r.previewGatewayAuthPolicy(ctx, policy, gatewayNs, gatewayName)
+		if err != nil {
+			log.Error(err, "failed to render dry-run AuthPolicy preview")
+			r.updateStatus(ctx, policy, maasv1alpha1.PhaseFailed, fmt.Sprintf("Failed to render dry-run preview: %v", err), statusSnapshot)
+			return ctrl.Result{}, err
+		}
+		policy.Status.DryRunPreview = previews
+	}
+	setDryRunCondition(&policy.Status.Conditions, policy.GetGeneration(), dryRun, len(policy.Status.DryRunPreview))
+
 	// Derive final phase based on model and AuthPolicy health
 	phase, message := r.deriveAuthPolicyPhase(policy, missingModels)
+	if dryRun && phase != maasv1alpha1.PhaseFailed {
+		phase = maasv1alpha1.PhasePending
+		message = "dry-run: generated AuthPolicy access rules rendered to status.dryRunPreview and not applied"
+	}
 	r.updateStatus(ctx, policy, phase, message, statusSnapshot)
-	return ctrl.Result{}, nil
+
+	awaiting := false
+	for _, ap := range policy.Status.AuthPolicies {
+		awaiting = awaiting || (!dryRun && awaitingEnforcement(ap.Ready, ap.Reason))
+	}
+	return requeueUntilEnforced(r.EnforcementRequeue, policy.Status.Conditions, awaiting), nil
 }
 
 // findMissingModelRefs returns a list of model refs that don't exist or couldn't be fetched.
@@ -600,12 +722,26 @@ type authPolicyRef struct {
 type modelSubjectAllowlist struct {
 	Users  []string `json:"users"`
 	Groups []string `json:"groups"`
+	// Issuers lists the spec.authentication.jwt issuers whose tokens are accepted for
+	// the model.
+	Issuers []string `json:"issuers,omitempty"`
+	// DeniedUsers are refused access to the model, whatever grants it to them.
+	DeniedUsers []string `json:"deniedUsers,omitempty"`
+	// SubjectAccessReview is set when a policy of the model authorizes with a Kubernetes
+	// SubjectAccessReview, which then decides access instead of Users and Groups.
+	SubjectAccessReview bool `json:"subjectAccessReview,omitempty"`
+	// PathRules are the spec.rules of the model's policies.
+	PathRules []maasv1alpha1.PathRule `json:"pathRules,omitempty"`
+	// X509 is set when a policy of the model accepts client certificates.
+	X509 bool `json:"x509,omitempty"`
+	// APIKeysDisabled is set when every policy of the model disables API keys.
+	APIKeysDisabled bool `json:"apiKeysDisabled,omitempty"`
 }
 
 // buildGatewayAuthPolicySpec returns the Authorino AuthPolicy spec for the singleton
 // Gateway-level policy. Model identity is resolved dynamically via CEL on every request
 // rather than being baked in per-model, so this spec is the same for all MaaSAuthPolicy CRs.
-func (r *MaaSAuthPolicyReconciler) buildGatewayAuthPolicySpec(modelAccessJSON string, oidc *oidcConfig, xAPIKeyEnabled bool, tenantID, tenantName, gatewayNamespace, gatewayName string) map[string]any {
+func (r *MaaSAuthPolicyReconciler) buildGatewayAuthPolicySpec(modelAccessJSON string, oidc *oidcConfig, authn gatewayAuthentication, headers identityHeaderModels, authz gatewayAuthorization, xAPIKeyEnabled bool, tenantID, tenantName, gatewayNamespace, gatewayName string) *kuadrantv1.AuthPolicySpec {
 	// Construct tenant-specific maas-api service name using TenantIdentifier
 	// Default tenant (tenantID="") uses "maas-api", others use "maas-api-{tenantID}"
 	maasAPIServiceName := "maas-api"
@@ -624,78 +760,72 @@ func (r *MaaSAuthPolicyReconciler) buildGatewayAuthPolicySpec(modelAccessJSON st
   "requestedModel": %s
 }`, celGroups, celUsername, celModelIdentity)
 
+	// Without a policy accepting API keys, the api-keys rules and the apiKeyValidation
+	// callout are left out, so API keys match no authentication rule.
+	if authn.APIKeysDisabled {
+		xAPIKeyEnabled = false
+	}
 	celIsAPIKey, celIsNotAPIKey, celExtractKey := apiKeyCELPredicates(xAPIKeyEnabled)
-
-	authenticationRules := map[string]any{
-		"api-keys": map[string]any{
-			"plain": map[string]any{
-				"selector": "request.headers.authorization",
-			},
-			"when": []any{
-				map[string]any{
-					"selector": "request.headers.authorization",
-					"operator": "matches",
-					"value":    "^Bearer sk-oai-.*",
-				},
+	metadataCacheTTL := authn.capCacheTTL(r.MetadataCacheTTL)
+	authzCacheTTL := authn.capCacheTTL(r.authzCacheTTL())
+
+	authenticationRules := map[string]kuadrantv1.AuthenticationRule{
+		"api-keys": {
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Selector: "request.headers.authorization",
+					Operator: "matches",
+					Value:    "^Bearer sk-oai-.*",
+				}},
 			},
-			"metrics":  false,
-			"priority": int64(0),
+			Plain: &kuadrantv1.ValueFrom{Selector: "request.headers.authorization"},
 		},
-		"openshift-identities": map[string]any{
-			"kubernetesTokenReview": map[string]any{
-				"audiences": []any{r.ClusterAudience},
+		"openshift-identities": {
+			CommonRule: kuadrantv1.CommonRule{
+				When:     []kuadrantv1.WhenCondition{{Predicate: celIsNotAPIKey}},
+				Cache:    authn.tokenReviewCache(),
+				Priority: 2,
 			},
-			"when": []any{
-				map[string]any{
-					"predicate": celIsNotAPIKey,
-				},
-			},
-			"metrics":  false,
-			"priority": int64(2),
+			KubernetesTokenReview: &kuadrantv1.KubernetesTokenReviewAuth{Audiences: r.tokenReviewAudiences(authn.TokenReviewAudiences)},
 		},
 	}
 
 	if xAPIKeyEnabled {
-		authenticationRules["api-keys-x-api-key"] = map[string]any{
-			"plain": map[string]any{
-				"expression": `"Bearer " + request.headers["x-api-key"]`,
-			},
-			"when": []any{
-				map[string]any{
-					"predicate": `"x-api-key" in request.headers && request.headers["x-api-key"].matches("^sk-oai-.*") && !request.headers.authorization.matches("^Bearer sk-oai-.*")`,
-				},
+		authenticationRules["api-keys-x-api-key"] = kuadrantv1.AuthenticationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: `"x-api-key" in request.headers && request.headers["x-api-key"].matches("^sk-oai-.*") && !request.headers.authorization.matches("^Bearer sk-oai-.*")`,
+				}},
+				Priority: 1,
 			},
-			"metrics":  false,
-			"priority": int64(1),
+			Plain: &kuadrantv1.ValueFrom{Expression: `"Bearer " + request.headers["x-api-key"]`},
 		}
 	}
 
 	if oidc != nil {
-		authenticationRules["oidc-identities"] = map[string]any{
-			"jwt": map[string]any{
-				"issuerUrl": oidc.IssuerURL,
-				"ttl":       int64(300),
-			},
-			"when": []any{
-				map[string]any{
-					"predicate": celIsNotAPIKey + ` && request.headers.authorization.matches("^Bearer [^.]+\\.[^.]+\\.[^.]+$")`,
-				},
+		authenticationRules["oidc-identities"] = kuadrantv1.AuthenticationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celIsNotAPIKey + ` && request.headers.authorization.matches("^Bearer [^.]+\\.[^.]+\\.[^.]+$")`,
+				}},
+				Priority: 1,
 			},
-			"metrics":  false,
-			"priority": int64(1),
+			JWT: &kuadrantv1.JWTAuth{IssuerURL: oidc.IssuerURL, TTL: 300},
 		}
 	}
 
+	if authn.APIKeysDisabled {
+		delete(authenticationRules, "api-keys")
+	}
+
 	authValidCacheKey := `"api-key|" + (` + celExtractKey + `) + "|" + ` + celModelIdentity
 
 	// tenantGatewayIsolationRule is a stub that always allows. It will be replaced with a real
 	// maas-api call to verify the API key's tenant matches the gateway hostname when multi-tenant
 	// hostname routing is productised (prevents a Coke key from working on a Pepsi gateway).
-	tenantGatewayIsolationRule := map[string]any{
-		"priority": int64(0),
-		"metrics":  false,
-		"opa": map[string]any{
-			"rego": `# Tenant hostname isolation stub.
+	tenantGatewayIsolationRule := kuadrantv1.AuthorizationRule{
+		OPA: &kuadrantv1.OPAAuthorization{
+			Rego: `# Tenant hostname isolation stub.
 # Replace with a real maas-api call to validate that the API key's tenant
 # matches the gateway hostname (prevents Coke key on Pepsi gateway).
 allow { true }`,
@@ -748,33 +878,96 @@ else := []
 
 model_rules := object.get(model_access, model_identity, null)
 
+# Tokens of an issuer from spec.authentication.jwt are only accepted for the models of
+# the MaaSAuthPolicies declaring it, so that one policy's issuer cannot claim the
+# subjects of another's.
+jwt_issuers := %s
+
+token_issuer := object.get(input.auth.identity, "iss", "")
+	{ is_object(input.auth.identity) }
+else := ""
+
+declared_issuer {
+	jwt_issuers[_] == token_issuer
+}
+
+issuer_allowed {
+	not declared_issuer
+}
+
+issuer_allowed {
+	model_rules.issuers[_] == token_issuer
+}
+
+# Client certificates of spec.authentication.x509 are only accepted for the models of
+# the MaaSAuthPolicies declaring it.
+%s
+
+# API keys are only accepted for the models of the MaaSAuthPolicies that do not disable
+# spec.authentication.apiKey.
+%s
+
 # Management endpoints (e.g. /v1/models, /maas-api/v1/api-keys) carry no model context.
 # Allow them here; subscription and rate-limit checks are gated by model-route conditions.
 allow {
 	model_identity == ""
 }
 
+# spec.rules restrict the API paths of the model that may be called.
+%s
+
+# A user in subjects.deniedUsers of any policy of the model is refused, whatever grants
+# them access.
+denied {
+	model_rules.deniedUsers[_] == username
+}
+
 # Inference path: deny by default when no MaaSAuthPolicy covers this model.
 # Allow only when the caller's username or a group is explicitly listed.
 allow {
 	model_rules != null
+	issuer_allowed
+	certificate_allowed
+	api_key_allowed
+	not denied
+	path_allowed
 	model_rules.users[_] == username
 }
 
 allow {
 	model_rules != null
+	issuer_allowed
+	certificate_allowed
+	api_key_allowed
+	not denied
+	path_allowed
 	g := groups[_]
 	model_rules.groups[_] == g
 }
-`, modelAccessJSON)
 
-	authorizationRules := map[string]any{
+# Access to the models of policies with authorization.subjectAccessReview is decided by
+# the subject-access-review rules.
+allow {
+	model_rules.subjectAccessReview == true
+	issuer_allowed
+	certificate_allowed
+	api_key_allowed
+	not denied
+	path_allowed
+}
+`, modelAccessJSON, celStringList(jwtIssuerURLs(authn.JWTIssuers)), x509IdentityRego, apiKeyIdentityRego, pathRulesRego)
+
+	authorizationRules := map[string]kuadrantv1.AuthorizationRule{
 		"tenant-gateway-isolation": tenantGatewayIsolationRule,
-		"auth-valid": map[string]any{
-			"metrics":  false,
-			"priority": int64(0),
-			"opa": map[string]any{
-				"rego": `allow {
+		"auth-valid": {
+			CommonRule: kuadrantv1.CommonRule{
+				Cache: &kuadrantv1.RuleCache{
+					Key: kuadrantv1.ValueFrom{Selector: authValidCacheKey},
+					TTL: authzCacheTTL,
+				},
+			},
+			OPA: &kuadrantv1.OPAAuthorization{
+				Rego: `allow {
   object.get(input.auth.metadata, "apiKeyValidation", {})
   input.auth.metadata.apiKeyValidation.valid == true
 }
@@ -782,23 +975,17 @@ allow {
   not input.auth.metadata.apiKeyValidation
 }`,
 			},
-			"cache": map[string]any{
-				"key": map[string]any{
-					"selector": authValidCacheKey,
-				},
-				"ttl": r.authzCacheTTL(),
-			},
 		},
-		"subscription-valid": map[string]any{
-			"when": []any{
-				map[string]any{
-					"predicate": celModelIdentityAvailable,
+		"subscription-valid": {
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{Predicate: celModelIdentityAvailable}},
+				Cache: &kuadrantv1.RuleCache{
+					Key: kuadrantv1.ValueFrom{Selector: subscriptionGatewayCacheKeySelector()},
+					TTL: authzCacheTTL,
 				},
 			},
-			"metrics":  false,
-			"priority": int64(0),
-			"opa": map[string]any{
-				"rego": `allow {
+			OPA: &kuadrantv1.OPAAuthorization{
+				Rego: `allow {
 	object.get(input.auth.metadata["subscription-info"], "name", "") != ""
 	object.get(input.auth.metadata["subscription-info"], "error", "") == ""
 	phase := object.get(input.auth.metadata["subscription-info"], "phase", "")
@@ -806,38 +993,32 @@ allow {
 	object.get(input.auth.metadata["subscription-info"], "deletionTimestamp", "") == ""
 }`,
 			},
-			"cache": map[string]any{
-				"key": map[string]any{
-					"selector": subscriptionGatewayCacheKeySelector(),
-				},
-				"ttl": r.authzCacheTTL(),
-			},
 		},
-		"require-group-membership": map[string]any{
-			"metrics":  false,
-			"priority": int64(0),
-			"opa": map[string]any{
-				"rego": requireGroupMembershipRego,
-			},
-			"cache": map[string]any{
-				"key": map[string]any{
-					"selector": gatewayAuthzCacheKeySelector(),
+		"require-group-membership": {
+			CommonRule: kuadrantv1.CommonRule{
+				Cache: &kuadrantv1.RuleCache{
+					Key: kuadrantv1.ValueFrom{Selector: gatewayAuthzCacheKeySelector() + pathRuleCacheKeySuffix + jwtIssuerCacheKeySuffix(authn.JWTIssuers, celIsNotAPIKey) + x509IdentityCacheKeySuffix(len(authn.X509CASecrets) > 0) + apiKeyIdentityCacheKeySuffix(authn.APIKeysRestricted, celIsAPIKey)},
+					TTL: authzCacheTTL,
 				},
-				"ttl": r.authzCacheTTL(),
 			},
+			OPA: &kuadrantv1.OPAAuthorization{Rego: requireGroupMembershipRego},
 		},
 	}
-	if oidc != nil {
-		authorizationRules["oidc-groups-safe"] = map[string]any{
-			"when": []any{
-				map[string]any{
-					"predicate": celIsNotAPIKey + ` && has(auth.identity.groups) && size(auth.identity.groups) > 0`,
-				},
+	addJWTAuthenticationRules(authenticationRules, authorizationRules, authn.JWTIssuers, celIsNotAPIKey)
+	addStepUpAuthorizationRule(authorizationRules, oidc)
+	authz.addAuthorizationRules(authorizationRules, authzCacheTTL, celIsNotAPIKey)
+	if len(authn.X509CASecrets) > 0 {
+		addX509AuthenticationRule(authenticationRules, xAPIKeyEnabled, gatewayNamespace, gatewayName)
+	}
+	if oidc != nil || len(authn.JWTIssuers) > 0 {
+		authorizationRules["oidc-groups-safe"] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celIsNotAPIKey + ` && has(auth.identity.groups) && size(auth.identity.groups) > 0`,
+				}},
 			},
-			"metrics":  false,
-			"priority": int64(0),
-			"opa": map[string]any{
-				"rego": `unsafe_group[g] {
+			OPA: &kuadrantv1.OPAAuthorization{
+				Rego: `unsafe_group[g] {
 	g := input.auth.identity.groups[_]
 	not regex.match("` + safeGroupNamePattern + `", g)
 }
@@ -849,153 +1030,119 @@ allow {
 		}
 	}
 
-	defaultsRules := map[string]any{
-		"metadata": map[string]any{
-			"apiKeyValidation": map[string]any{
-				"when": []any{
-					map[string]any{
-						"predicate": celIsAPIKey,
+	defaultsRules := kuadrantv1.AuthRules{
+		Metadata: map[string]kuadrantv1.MetadataRule{
+			"apiKeyValidation": {
+				CommonRule: kuadrantv1.CommonRule{
+					When: []kuadrantv1.WhenCondition{{Predicate: celIsAPIKey}},
+					Cache: &kuadrantv1.RuleCache{
+						Key: kuadrantv1.ValueFrom{Selector: celExtractKey},
+						TTL: metadataCacheTTL,
 					},
 				},
-				"http": map[string]any{
-					"url":         apiKeyValidationURL,
-					"contentType": "application/json",
-					"method":      "POST",
-					"body": map[string]any{
-						"expression": `{"key": ` + celExtractKey + `}`,
-					},
-				},
-				"cache": map[string]any{
-					"key": map[string]any{
-						"selector": celExtractKey,
-					},
-					"ttl": r.MetadataCacheTTL,
+				HTTP: &kuadrantv1.HTTPMetadata{
+					URL:         apiKeyValidationURL,
+					ContentType: "application/json",
+					Method:      "POST",
+					Body:        &kuadrantv1.ValueFrom{Expression: `{"key": ` + celExtractKey + `}`},
 				},
-				"metrics":  false,
-				"priority": int64(0),
 			},
-			"subscription-info": map[string]any{
-				"when": []any{
-					map[string]any{
-						"predicate": celModelIdentityAvailable,
-					},
-				},
-				"http": map[string]any{
-					"url":         subscriptionSelectorURL,
-					"contentType": "application/json",
-					"method":      "POST",
-					"body": map[string]any{
-						"expression": subscriptionInfoBody,
+			"subscription-info": {
+				CommonRule: kuadrantv1.CommonRule{
+					When: []kuadrantv1.WhenCondition{{Predicate: celModelIdentityAvailable}},
+					Cache: &kuadrantv1.RuleCache{
+						Key: kuadrantv1.ValueFrom{Selector: subscriptionGatewayCacheKeySelector()},
+						TTL: metadataCacheTTL,
 					},
+					Priority: 1,
 				},
-				"cache": map[string]any{
-					"key": map[string]any{
-						"selector": subscriptionGatewayCacheKeySelector(),
-					},
-					"ttl": r.MetadataCacheTTL,
+				HTTP: &kuadrantv1.HTTPMetadata{
+					URL:         subscriptionSelectorURL,
+					ContentType: "application/json",
+					Method:      "POST",
+					Body:        &kuadrantv1.ValueFrom{Expression: subscriptionInfoBody},
 				},
-				"metrics":  false,
-				"priority": int64(1),
 			},
 		},
-		"authentication": authenticationRules,
-		"authorization":  authorizationRules,
-		"response": map[string]any{
-			"success": map[string]any{
-				"headers": map[string]any{
-					"X-MaaS-Username": map[string]any{
-						"when": []any{
-							map[string]any{
-								"predicate": celIsAPIKey,
-							},
-						},
-						"plain": map[string]any{
-							"selector": "auth.metadata.apiKeyValidation.username",
+		Authentication: authenticationRules,
+		Authorization:  authorizationRules,
+		Response: &kuadrantv1.ResponseRules{
+			Success: &kuadrantv1.SuccessResponse{
+				Headers: map[string]kuadrantv1.HeaderResponse{
+					"X-MaaS-Username": {ResponseItem: kuadrantv1.ResponseItem{
+						CommonRule: kuadrantv1.CommonRule{
+							When: []kuadrantv1.WhenCondition{{Predicate: celIsAPIKey}},
 						},
-						"metrics":  false,
-						"priority": int64(0),
-					},
-					"X-MaaS-Username-Token": map[string]any{
-						"when": []any{
-							map[string]any{
-								"predicate": celIsNotAPIKey,
+						Plain: &kuadrantv1.ValueFrom{Selector: "auth.metadata.apiKeyValidation.username"},
+					}},
+					"X-MaaS-Username-Token": {
+						ResponseItem: kuadrantv1.ResponseItem{
+							CommonRule: kuadrantv1.CommonRule{
+								When:     []kuadrantv1.WhenCondition{{Predicate: celIsNotAPIKey}},
+								Priority: 1,
+							},
+							Plain: &kuadrantv1.ValueFrom{
+								Expression: `has(auth.identity.preferred_username) ? auth.identity.preferred_username : (has(auth.identity.sub) ? auth.identity.sub : auth.identity.user.username)`,
 							},
 						},
-						"plain": map[string]any{
-							"expression": `has(auth.identity.preferred_username) ? auth.identity.preferred_username : (has(auth.identity.sub) ? auth.identity.sub : auth.identity.user.username)`,
-						},
-						"key":      "X-MaaS-Username",
-						"metrics":  false,
-						"priority": int64(1),
+						Key: "X-MaaS-Username",
 					},
-					"X-MaaS-Group": map[string]any{
-						"when": []any{
-							map[string]any{
-								"predicate": celIsAPIKey,
-							},
+					"X-MaaS-Group": {ResponseItem: kuadrantv1.ResponseItem{
+						CommonRule: kuadrantv1.CommonRule{
+							When: []kuadrantv1.WhenCondition{{Predicate: celIsAPIKey}},
 						},
-						"plain": map[string]any{
+						Plain: &kuadrantv1.ValueFrom{
 							// NOTE: Manual JSON construction without escaping (CEL lacks JSON escape functions).
 							// Group names are validated on API key creation to reject quotes/backslashes.
 							// Kubernetes group names follow DNS rules (no special chars).
-							"expression": `size(auth.metadata.apiKeyValidation.groups) > 0 ? '["' + auth.metadata.apiKeyValidation.groups.join('","') + '"]' : '[]'`,
+							Expression: `size(auth.metadata.apiKeyValidation.groups) > 0 ? '["' + auth.metadata.apiKeyValidation.groups.join('","') + '"]' : '[]'`,
 						},
-						"metrics":  false,
-						"priority": int64(0),
-					},
-					"X-MaaS-Group-Token": map[string]any{
-						"when": []any{
-							map[string]any{
-								"predicate": celIsNotAPIKey,
+					}},
+					"X-MaaS-Group-Token": {
+						ResponseItem: kuadrantv1.ResponseItem{
+							CommonRule: kuadrantv1.CommonRule{
+								When:     []kuadrantv1.WhenCondition{{Predicate: celIsNotAPIKey}},
+								Priority: 1,
 							},
+							Plain: &kuadrantv1.ValueFrom{Expression: celTokenGroupsHeaderJSON},
 						},
-						"plain": map[string]any{
-							"expression": celTokenGroupsHeaderJSON,
-						},
-						"key":      "X-MaaS-Group",
-						"metrics":  false,
-						"priority": int64(1),
+						Key: "X-MaaS-Group",
 					},
 					// Only inject X-MaaS-Subscription when there is a real value to inject.
 					// An empty string injected for K8s tokens without a subscription header
 					// causes maas-api to filter by an empty subscription name and return 0 models.
 					// The old maas-api-auth-policy never injected this header for K8s tokens —
 					// only for API keys with a non-empty subscription field.
-					"X-MaaS-Subscription": map[string]any{
-						"when": []any{
-							map[string]any{
-								"predicate": `(has(auth.metadata) && has(auth.metadata.apiKeyValidation) && auth.metadata.apiKeyValidation.subscription != "") || "x-maas-subscription" in request.headers`,
-							},
-						},
-						"plain": map[string]any{
-							"expression": celSubscription,
+					"X-MaaS-Subscription": {ResponseItem: kuadrantv1.ResponseItem{
+						CommonRule: kuadrantv1.CommonRule{
+							When: []kuadrantv1.WhenCondition{{
+								Predicate: celRequestedSubscriptionAvailable,
+							}},
 						},
-						"metrics":  false,
-						"priority": int64(0),
-					},
+						Plain: &kuadrantv1.ValueFrom{Expression: celSubscription},
+					}},
 				},
-				"filters": map[string]any{
-					"identity": map[string]any{
-						"json": map[string]any{
-							"properties": map[string]any{
-								"groups":     map[string]any{"expression": celGroups},
-								"groups_str": map[string]any{"expression": fmt.Sprintf(`(%s).join(",")`, celGroups)},
-								"userid": map[string]any{
-									"expression": celUsername,
-								},
-								"keyId": map[string]any{
-									"expression": `(has(auth.metadata) && has(auth.metadata.apiKeyValidation)) ? auth.metadata.apiKeyValidation.keyId : ""`,
+				Filters: map[string]kuadrantv1.ResponseItem{
+					"identity": {
+						CommonRule: kuadrantv1.CommonRule{Metrics: true},
+						JSON: &kuadrantv1.JSONResponse{
+							Properties: map[string]kuadrantv1.ValueFrom{
+								"groups":     {Expression: celGroups},
+								"groups_str": {Expression: fmt.Sprintf(`(%s).join(",")`, celGroups)},
+								"userid":     {Expression: celUsername},
+								"keyId": {
+									Expression: `(has(auth.metadata) && has(auth.metadata.apiKeyValidation)) ? auth.metadata.apiKeyValidation.keyId : ""`,
 								},
-								"keyName": map[string]any{
-									"expression": `(has(auth.metadata) && has(auth.metadata.apiKeyValidation)) ? auth.metadata.apiKeyValidation.keyName : ""`,
+								"keyName": {
+									Expression: `(has(auth.metadata) && has(auth.metadata.apiKeyValidation)) ? auth.metadata.apiKeyValidation.keyName : ""`,
 								},
-								"selected_subscription": map[string]any{
-									"expression": `has(auth.metadata["subscription-info"].name) ? auth.metadata["subscription-info"].name : ""`,
+								"selected_subscription": {
+									Expression: `has(auth.metadata["subscription-info"].name) ? auth.metadata["subscription-info"].name : ""`,
 								},
 								// Model-scoped subscription key: namespace/name@modelIdentity
 								// modelIdentity is dynamic (header or path), so this is always current
-								"selected_subscription_key": map[string]any{
-									"expression": fmt.Sprintf(
+								"selected_subscription_key": {
+									Expression: fmt.Sprintf(
 										`(has(auth.metadata["subscription-info"].namespace) && `+
 											`has(auth.metadata["subscription-info"].name)) `+
 											`? auth.metadata["subscription-info"].namespace + "/" `+
@@ -1003,70 +1150,117 @@ allow {
 										celModelIdentity,
 									),
 								},
-								"subscription_info": map[string]any{
-									"expression": `has(auth.metadata["subscription-info"].name) ? auth.metadata["subscription-info"] : {}`,
+								"subscription_info": {
+									Expression: `has(auth.metadata["subscription-info"].name) ? auth.metadata["subscription-info"] : {}`,
 								},
-								"subscription_error": map[string]any{
-									"expression": `has(auth.metadata["subscription-info"].error) ? auth.metadata["subscription-info"].error : ""`,
+								"subscription_error": {
+									Expression: `has(auth.metadata["subscription-info"].error) ? auth.metadata["subscription-info"].error : ""`,
 								},
-								"subscription_error_message": map[string]any{
-									"expression": `has(auth.metadata["subscription-info"].message) ? auth.metadata["subscription-info"].message : ""`,
+								"subscription_error_message": {
+									Expression: `has(auth.metadata["subscription-info"].message) ? auth.metadata["subscription-info"].message : ""`,
+								},
+							},
+						},
+					},
+					// Emitted as Envoy dynamic metadata (envoy.filters.http.ext_authz/qos), so
+					// route-level configuration can queue or shed requests by subscription
+					// priority during saturation instead of only enforcing quotas.
+					"qos": {
+						JSON: &kuadrantv1.JSONResponse{
+							Properties: map[string]kuadrantv1.ValueFrom{
+								"priority": {Expression: celSubscriptionPriority},
+								"subscription": {
+									Expression: `has(auth.metadata["subscription-info"].name) ? auth.metadata["subscription-info"].namespace + "/" + auth.metadata["subscription-info"].name : ""`,
 								},
 							},
 						},
-						"metrics": true, "priority": int64(0),
 					},
 				},
 			},
-			"unauthenticated": map[string]any{
-				"code": int64(401),
-				"message": map[string]any{
-					"value": "Authentication required",
-				},
+			Unauthenticated: &kuadrantv1.DenyWith{
+				Code:    401,
+				Message: &kuadrantv1.ValueFrom{Value: unauthenticatedMessage},
 			},
-			"unauthorized": map[string]any{
-				"code": int64(403),
-				"body": map[string]any{
-					"expression": `has(auth.metadata["subscription-info"].message) ? auth.metadata["subscription-info"].message : "Access denied"`,
-				},
-				"headers": map[string]any{
-					"x-ext-auth-reason": map[string]any{
-						"expression": `has(auth.metadata["subscription-info"].error) ? auth.metadata["subscription-info"].error : "unauthorized"`,
-					},
-					"content-type": map[string]any{
-						"value": "text/plain",
-					},
+			Unauthorized: &kuadrantv1.DenyWith{
+				Code: 403,
+				Body: &kuadrantv1.ValueFrom{Expression: celUnauthorizedMessage},
+				Headers: map[string]kuadrantv1.ValueFrom{
+					"x-ext-auth-reason": {Expression: celUnauthorizedReason},
+					"content-type":      {Value: "text/plain"},
 				},
 			},
 		},
 	}
+	if authn.APIKeysDisabled {
+		delete(defaultsRules.Metadata, "apiKeyValidation")
+	}
+	addIdentityHeaders(defaultsRules.Response.Success.Headers, headers)
+	addMetering(defaultsRules.Response.Success, headers.Metering)
+	authz.applyDenyResponses(defaultsRules.Response)
 
-	return map[string]any{
-		"targetRef": map[string]any{
-			"group":     "gateway.networking.k8s.io",
-			"kind":      "Gateway",
-			"name":      gatewayName,
-			"namespace": gatewayNamespace,
+	return &kuadrantv1.AuthPolicySpec{
+		TargetRef: kuadrantv1.TargetRef{
+			Group:     "gateway.networking.k8s.io",
+			Kind:      "Gateway",
+			Name:      gatewayName,
+			Namespace: gatewayNamespace,
 		},
-		// "when" must live inside "defaults" (not at spec level) because Kuadrant treats
-		// top-level "when" as implicit defaults, which conflicts with explicit "defaults".
-		"defaults": map[string]any{
+		Defaults: &kuadrantv1.MergeableAuthPolicy{
 			// Skip auth for the health readiness probe so unauthenticated GET /maas-api/health
 			// returns 200 without triggering Authorino. Previously handled by maas-api-auth-policy;
 			// now that the route-level policy is removed this condition lives at the gateway level.
-			"when": []any{
-				map[string]any{
-					"predicate": `request.path != "/maas-api/health" || request.method != "GET"`,
-				},
+			// CORS preflights of maas-api skip it too, since browsers send them without the
+			// Authorization header; maas-api answers them without serving any data.
+			When: []kuadrantv1.Predicate{
+				{Predicate: `request.path != "/maas-api/health" || request.method != "GET"`},
+				{Predicate: `request.method != "OPTIONS" || !request.path.startsWith("/maas-api/")`},
 			},
-			"rules": defaultsRules,
+			Rules: defaultsRules,
 		},
 	}
 }
 
+// gatewayAuthPolicyName returns the name of the gateway-level AuthPolicy for a Gateway.
+// The default gateway keeps the legacy name for backward compatibility; tenant gateways
+// use a name derived from the Gateway.
+func (r *MaaSAuthPolicyReconciler) gatewayAuthPolicyName(gatewayNamespace, gatewayName string) string {
+	return gatewayAuthPolicyNameFor(r.GatewayNamespace, r.GatewayName, gatewayNamespace, gatewayName)
+}
+
+// previewGatewayAuthPolicy renders the gateway AuthPolicy access rules each model
+// referenced by this dry-run policy would get if it were applied alongside the
+// MaaSAuthPolicies that are currently enforced.
+func (r *MaaSAuthPolicyReconciler) previewGatewayAuthPolicy(ctx context.Context, policy *maasv1alpha1.MaaSAuthPolicy, gatewayNamespace, gatewayName string) ([]maasv1alpha1.GeneratedResourcePreview, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policy.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	allowlists, err := r.aggregateMappedAllowlists(ctx, policy.Namespace, append(policies, *policy))
+	if err != nil {
+		return nil, err
+	}
+
+	authPolicyName := r.gatewayAuthPolicyName(gatewayNamespace, gatewayName)
+	var previews []maasv1alpha1.GeneratedResourcePreview
+	seen := make(map[string]struct{}, len(policy.Spec.ModelRefs))
+	for _, ref := range policy.Spec.ModelRefs {
+		key := ref.Namespace + "/" + ref.Name
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		preview, err := renderPreview("AuthPolicy", authPolicyName, gatewayNamespace, key, allowlists[key])
+		if err != nil {
+			return nil, err
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
 // reconcileGatewayAuthPolicy creates or updates the singleton Gateway-level AuthPolicy in
 // the gateway namespace. All MaaSAuthPolicy reconciliations converge on this one resource.
-func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Context, log logr.Logger, modelAccessJSON string, oidc *oidcConfig, xAPIKeyEnabled bool, tenantID, gatewayNamespace, gatewayName string) error {
+func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Context, log logr.Logger, modelAccessJSON string, oidc *oidcConfig, authn gatewayAuthentication, headers identityHeaderModels, authz gatewayAuthorization, templates authPolicyTemplateRules, xAPIKeyEnabled bool, tenantID, gatewayNamespace, gatewayName string) error {
 	log.Info("reconcileGatewayAuthPolicy entered", "gatewayNamespace", gatewayNamespace, "gatewayName", gatewayName, "tenantID", tenantID, "xAPIKeyEnabled", xAPIKeyEnabled)
 
 	// Calculate tenantName from tenantID
@@ -1076,18 +1270,26 @@ func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Contex
 		tenantName = tenantID
 	}
 
-	spec := r.buildGatewayAuthPolicySpec(modelAccessJSON, oidc, xAPIKeyEnabled, tenantID, tenantName, gatewayNamespace, gatewayName)
+	spec, err := kuadrantv1.ToUnstructured(r.buildGatewayAuthPolicySpec(modelAccessJSON, oidc, authn, headers, authz, xAPIKeyEnabled, tenantID, tenantName, gatewayNamespace, gatewayName))
+	if err != nil {
+		return err
+	}
+	if err := templates.apply(spec); err != nil {
+		return err
+	}
+	if authorinoAuthConfigs(r.AuthProvider) {
+		// Standalone Authorino reads the same rules from an AuthConfig for the Gateway's hosts.
+		spec, err = r.gatewayAuthConfigSpec(ctx, spec, gatewayNamespace, gatewayName)
+		if err != nil {
+			return err
+		}
+	}
 
-	// Use legacy name for default gateway (backward compatibility), dynamic name for tenant gateways
-	authPolicyName := maasGatewayAuthPolicyName
+	authPolicyName := r.gatewayAuthPolicyName(gatewayNamespace, gatewayName)
 	isTenantGateway := gatewayNamespace != r.GatewayNamespace || gatewayName != r.GatewayName
-	if isTenantGateway {
-		// This is a tenant-specific gateway, use dynamic naming
-		authPolicyName = fmt.Sprintf("%s-maas-auth", gatewayName)
-	}
 
 	gwPolicy := &unstructured.Unstructured{}
-	gwPolicy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	gwPolicy.SetGroupVersionKind(gatewayAuthGVK(r.AuthProvider))
 	gwPolicy.SetName(authPolicyName)
 	gwPolicy.SetNamespace(gatewayNamespace)
 	gwPolicy.SetLabels(map[string]string{
@@ -1095,6 +1297,11 @@ func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Contex
 		"app.kubernetes.io/part-of":    "maas-gateway-auth",
 		"app.kubernetes.io/component":  "gateway-auth",
 	})
+	specHash, err := generatedSpecHash(spec)
+	if err != nil {
+		return err
+	}
+	gwPolicy.SetAnnotations(map[string]string{generatedSpecHashAnnotation: specHash})
 
 	// Load the existing AuthPolicy first, before fetching the Gateway.
 	// This ordering is important: if a pre-upgrade tenant AuthPolicy exists
@@ -1103,7 +1310,7 @@ func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Contex
 	// the Gateway lookup.
 	existing := &unstructured.Unstructured{}
 	existing.SetGroupVersionKind(gwPolicy.GroupVersionKind())
-	err := r.Get(ctx, client.ObjectKeyFromObject(gwPolicy), existing)
+	err = r.Get(ctx, client.ObjectKeyFromObject(gwPolicy), existing)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("failed to get gateway AuthPolicy: %w", err)
 	}
@@ -1155,10 +1362,19 @@ func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Contex
 		return nil
 	}
 
+	if generatedPolicyDrifted(existing, specHash) {
+		recordGeneratedPolicyDrift(log, r.Recorder, existing)
+	}
 	snapshot := existing.DeepCopy()
 	if err := unstructured.SetNestedMap(existing.Object, spec, "spec"); err != nil {
 		return fmt.Errorf("failed to set gateway AuthPolicy spec for update: %w", err)
 	}
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[generatedSpecHashAnnotation] = specHash
+	existing.SetAnnotations(annotations)
 	// Ensure OwnerReferences are set on existing tenant gateway AuthPolicies
 	// (handles upgrade from pre-ownerref versions).
 	if isTenantGateway {
@@ -1190,6 +1406,10 @@ func (r *MaaSAuthPolicyReconciler) reconcileGatewayAuthPolicy(ctx context.Contex
 func (r *MaaSAuthPolicyReconciler) reconcileModelAuthPolicies(ctx context.Context, log logr.Logger, policy *maasv1alpha1.MaaSAuthPolicy) ([]authPolicyRef, error) {
 	var refs []authPolicyRef
 	for _, ref := range policy.Spec.ModelRefs {
+		if !r.WatchNamespaces.Contains(ref.Namespace) {
+			log.Info("model namespace is not watched, skipping", "model", ref.Namespace+"/"+ref.Name)
+			continue
+		}
 		httpRouteName, httpRouteNS, err := findHTTPRouteForModel(ctx, r.Client, ref.Namespace, ref.Name)
 		if err != nil {
 			if errors.Is(err, ErrModelNotFound) {
@@ -1231,47 +1451,117 @@ func (r *MaaSAuthPolicyReconciler) reconcileModelAuthPolicies(ctx context.Contex
 }
 
 func (r *MaaSAuthPolicyReconciler) aggregateModelSubjectAllowlists(ctx context.Context, policyNamespace string) (map[string]modelSubjectAllowlist, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policyNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return r.aggregateMappedAllowlists(ctx, policyNamespace, policies)
+}
+
+// aggregateMappedAllowlists aggregates the allowlists of the policies of a namespace and
+// grants each model to the identity provider groups the namespace's group mapping maps to
+// its groups.
+func (r *MaaSAuthPolicyReconciler) aggregateMappedAllowlists(ctx context.Context, policyNamespace string, policies []maasv1alpha1.MaaSAuthPolicy) (map[string]modelSubjectAllowlist, error) {
+	mapping, err := r.groupMapping(ctx, policyNamespace)
+	if err != nil {
+		return nil, err
+	}
+	allowlists, err := aggregateSubjectAllowlists(policies)
+	if err != nil {
+		return nil, err
+	}
+	applyGroupMapping(allowlists, mapping)
+	return allowlists, nil
+}
+
+// listEnforcedAuthPolicies lists the MaaSAuthPolicies in a namespace that contribute to
+// the gateway AuthPolicy. Dry-run policies are excluded.
+func (r *MaaSAuthPolicyReconciler) listEnforcedAuthPolicies(ctx context.Context, policyNamespace string) ([]maasv1alpha1.MaaSAuthPolicy, error) {
 	var policies maasv1alpha1.MaaSAuthPolicyList
 	if err := r.List(ctx, &policies, client.InNamespace(policyNamespace)); err != nil {
 		return nil, fmt.Errorf("failed to list MaaSAuthPolicies for gateway aggregation: %w", err)
 	}
+	return excludeDryRunAuthPolicies(policies.Items), nil
+}
+
+// ValidateAuthPolicySubjects rejects group and user names that cannot be embedded in the
+// CEL and Rego expressions of the gateway AuthPolicy.
+func ValidateAuthPolicySubjects(subjects maasv1alpha1.SubjectSpec) error {
+	for _, group := range subjects.Groups {
+		if err := validateCELValue(group.Name, "group name"); err != nil {
+			return err
+		}
+	}
+	for _, user := range subjects.Users {
+		if err := validateCELValue(user, "username"); err != nil {
+			return err
+		}
+	}
+	for _, user := range subjects.DeniedUsers {
+		if err := validateCELValue(user, "denied username"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// aggregateSubjectAllowlists merges the subjects of the given policies into a
+// per-model allowlist keyed by "namespace/name". A denied user or Deny path rule that a
+// policy of higher spec.priority allows is dropped.
+func aggregateSubjectAllowlists(policies []maasv1alpha1.MaaSAuthPolicy) (map[string]modelSubjectAllowlist, error) {
 	aggregate := make(map[string]modelSubjectAllowlist)
-	for _, p := range policies.Items {
+	apiKeyModels := make(map[string]bool)
+	for _, p := range policies {
 		if !p.GetDeletionTimestamp().IsZero() {
 			continue
 		}
 		for _, ref := range p.Spec.ModelRefs {
 			key := ref.Namespace + "/" + ref.Name
 			entry := aggregate[key]
+			if policyAPIKeysEnabled(&p) {
+				apiKeyModels[key] = true
+			}
+			entry.APIKeysDisabled = !apiKeyModels[key]
+			if err := ValidateAuthPolicySubjects(p.Spec.Subjects); err != nil {
+				return nil, fmt.Errorf("invalid subject in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+			}
 			for _, group := range p.Spec.Subjects.Groups {
-				if err := validateCELValue(group.Name, "group name"); err != nil {
-					return nil, fmt.Errorf("invalid subject in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
-				}
 				entry.Groups = append(entry.Groups, group.Name)
 			}
-			for _, user := range p.Spec.Subjects.Users {
-				if err := validateCELValue(user, "username"); err != nil {
-					return nil, fmt.Errorf("invalid subject in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
-				}
-				entry.Users = append(entry.Users, user)
+			entry.Users = append(entry.Users, p.Spec.Subjects.Users...)
+			entry.DeniedUsers = append(entry.DeniedUsers, p.Spec.Subjects.DeniedUsers...)
+			rules, err := mergePathRules(entry.PathRules, p.Spec.Rules)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rules in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+			}
+			entry.PathRules = rules
+			if jwt := policyJWT(&p); jwt != nil {
+				entry.Issuers = deduplicateAndSort(append(entry.Issuers, jwt.IssuerURL))
+			}
+			if policyX509(&p) != nil {
+				entry.X509 = true
+			}
+			if policySubjectAccessReview(&p) != nil {
+				entry.SubjectAccessReview = true
 			}
 			entry.Groups = deduplicateAndSort(entry.Groups)
 			entry.Users = deduplicateAndSort(entry.Users)
+			entry.DeniedUsers = deduplicateAndSort(entry.DeniedUsers)
 			aggregate[key] = entry
 		}
 	}
+	applyPolicyPrecedence(aggregate, policies)
 
 	return aggregate, nil
 }
 
 func (r *MaaSAuthPolicyReconciler) modelAuthPolicyExists(ctx context.Context, modelNamespace, modelName string) (bool, error) {
 	authPolicy := &unstructured.Unstructured{}
-	authPolicy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	authPolicy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
 	authPolicyName := fmt.Sprintf("maas-auth-%s", modelName)
 
 	err := r.Get(ctx, types.NamespacedName{Name: authPolicyName, Namespace: modelNamespace}, authPolicy)
-	if apierrors.IsNotFound(err) {
+	if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
 		return false, nil
 	}
 	if err != nil {
@@ -1282,9 +1572,26 @@ func (r *MaaSAuthPolicyReconciler) modelAuthPolicyExists(ctx context.Context, mo
 
 // cleanupStaleAuthPolicies deletes aggregated AuthPolicies for models that this
 // policy previously contributed to but no longer references in spec.modelRefs.
-// Generated AuthPolicies track contributing policies in the
-// "maas.opendatahub.io/auth-policies" annotation (namespace-qualified: "ns/name").
 func (r *MaaSAuthPolicyReconciler) cleanupStaleAuthPolicies(ctx context.Context, log logr.Logger, policy *maasv1alpha1.MaaSAuthPolicy) error {
+	stale, err := r.staleAuthPolicyModels(ctx, policy)
+	if err != nil {
+		return err
+	}
+	for _, ref := range stale {
+		modelKey := ref.Namespace + "/" + ref.Name
+		log.Info("Cleaning up stale AuthPolicy for removed modelRef", "model", modelKey)
+		if err := r.deleteModelAuthPolicy(ctx, log, ref.Namespace, ref.Name); err != nil {
+			return fmt.Errorf("failed to clean up stale AuthPolicy for removed model %s: %w", modelKey, err)
+		}
+	}
+	return nil
+}
+
+// staleAuthPolicyModels returns the models whose aggregated AuthPolicies this policy
+// contributed to but that it no longer references in spec.modelRefs. Generated
+// AuthPolicies track contributing policies in the "maas.opendatahub.io/auth-policies"
+// annotation (namespace-qualified: "ns/name").
+func (r *MaaSAuthPolicyReconciler) staleAuthPolicyModels(ctx context.Context, policy *maasv1alpha1.MaaSAuthPolicy) ([]maasv1alpha1.ModelRef, error) {
 	currentModels := make(map[string]bool, len(policy.Spec.ModelRefs))
 	for _, ref := range policy.Spec.ModelRefs {
 		currentModels[ref.Namespace+"/"+ref.Name] = true
@@ -1297,11 +1604,13 @@ func (r *MaaSAuthPolicyReconciler) cleanupStaleAuthPolicies(ctx context.Context,
 		"app.kubernetes.io/part-of":    "maas-auth-policy",
 	}); err != nil {
 		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("failed to list managed AuthPolicies for stale cleanup: %w", err)
+		return nil, fmt.Errorf("failed to list managed AuthPolicies for stale cleanup: %w", err)
 	}
 
+	var stale []maasv1alpha1.ModelRef
+	seen := map[string]bool{}
 	for i := range allManaged.Items {
 		ap := &allManaged.Items[i]
 		modelName := ap.GetLabels()["maas.opendatahub.io/model"]
@@ -1313,7 +1622,7 @@ func (r *MaaSAuthPolicyReconciler) cleanupStaleAuthPolicies(ctx context.Context,
 			modelNamespace = ap.GetNamespace()
 		}
 		modelKey := modelNamespace + "/" + modelName
-		if currentModels[modelKey] {
+		if currentModels[modelKey] || seen[modelKey] {
 			continue
 		}
 		owners := ap.GetAnnotations()["maas.opendatahub.io/auth-policies"]
@@ -1321,12 +1630,10 @@ func (r *MaaSAuthPolicyReconciler) cleanupStaleAuthPolicies(ctx context.Context,
 			!annotationListContains(owners, policy.Name) {
 			continue
 		}
-		log.Info("Cleaning up stale AuthPolicy for removed modelRef", "model", modelKey, "authPolicy", ap.GetName())
-		if err := r.deleteModelAuthPolicy(ctx, log, modelNamespace, modelName); err != nil {
-			return fmt.Errorf("failed to clean up stale AuthPolicy for removed model %s: %w", modelKey, err)
-		}
+		seen[modelKey] = true
+		stale = append(stale, maasv1alpha1.ModelRef{Name: modelName, Namespace: modelNamespace})
 	}
-	return nil
+	return stale, nil
 }
 
 // deleteModelAuthPolicy deletes the aggregated AuthPolicy for a model in the given namespace.
@@ -1347,6 +1654,8 @@ func (r *MaaSAuthPolicyReconciler) deleteModelAuthPolicy(ctx context.Context, lo
 		}
 		return fmt.Errorf("failed to list AuthPolicies for cleanup: %w", err)
 	}
+	// Keep going past a failed Delete so one stuck policy does not orphan the others.
+	var errs []error
 	for i := range policyList.Items {
 		p := &policyList.Items[i]
 		if labeledModelNamespace := p.GetLabels()["maas.opendatahub.io/model-namespace"]; labeledModelNamespace != "" && labeledModelNamespace != modelNamespace {
@@ -1358,54 +1667,54 @@ func (r *MaaSAuthPolicyReconciler) deleteModelAuthPolicy(ctx context.Context, lo
 		}
 		log.Info("Deleting AuthPolicy (no remaining parent policies)", "name", p.GetName(), "namespace", p.GetNamespace(), "model", modelNamespace+"/"+modelName)
 		if err := r.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
-			return fmt.Errorf("failed to delete AuthPolicy %s/%s: %w", p.GetNamespace(), p.GetName(), err)
+			errs = append(errs, fmt.Errorf("failed to delete AuthPolicy %s/%s: %w", p.GetNamespace(), p.GetName(), err))
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (r *MaaSAuthPolicyReconciler) handleDeletion(ctx context.Context, log logr.Logger, policy *maasv1alpha1.MaaSAuthPolicy) (ctrl.Result, error) {
 	if controllerutil.ContainsFinalizer(policy, maasAuthPolicyFinalizer) {
+		statusSnapshot := policy.Status.DeepCopy()
+		cleanup := newFinalizerCleanup(log, policy.Status.Conditions, policy.Status.CleanupFailures)
+
+		var cleanups []modelCleanup
 		for _, ref := range policy.Spec.ModelRefs {
-			log.Info("Deleting model group AuthPolicy so remaining policies can rebuild it", "model", ref.Namespace+"/"+ref.Name)
-			if err := r.deleteModelAuthPolicy(ctx, log, ref.Namespace, ref.Name); err != nil {
-				log.Error(err, "failed to clean up group AuthPolicy, will retry", "model", ref.Namespace+"/"+ref.Name)
-				return ctrl.Result{}, err
-			}
+			cleanups = append(cleanups, modelCleanup{model: ref.Namespace + "/" + ref.Name, run: func() error {
+				log.Info("Deleting model group AuthPolicy so remaining policies can rebuild it", "model", ref.Namespace+"/"+ref.Name)
+				return r.deleteModelAuthPolicy(ctx, log, ref.Namespace, ref.Name)
+			}})
 		}
 		// Also clean up stale group AuthPolicies from modelRefs that were removed
 		// before the CR was deleted (edge case: edit + delete before reconcile).
-		if err := r.cleanupStaleAuthPolicies(ctx, log, policy); err != nil {
-			return ctrl.Result{}, err
+		stale, err := r.staleAuthPolicyModels(ctx, policy)
+		if err != nil {
+			cleanup.fail(err)
+		}
+		for _, ref := range stale {
+			cleanups = append(cleanups, modelCleanup{model: ref.Namespace + "/" + ref.Name, run: func() error {
+				log.Info("Cleaning up stale AuthPolicy for removed modelRef", "model", ref.Namespace+"/"+ref.Name)
+				return r.deleteModelAuthPolicy(ctx, log, ref.Namespace, ref.Name)
+			}})
 		}
+		cleanup.runModels(cleanups)
 
 		// If this is the last MaaSAuthPolicy, also delete the singleton gateway-level AuthPolicy.
-		remaining := &maasv1alpha1.MaaSAuthPolicyList{}
-		if err := r.List(ctx, remaining); err != nil {
-			log.Error(err, "failed to list remaining MaaSAuthPolicies for gateway cleanup check")
-			return ctrl.Result{}, err
+		// This is not tied to a model, so it runs on every attempt.
+		if err := r.cleanupGatewayAuthPolicy(ctx, log, policy); err != nil {
+			cleanup.fail(err)
 		}
-		// Count policies not being deleted and not the current one
-		liveCount := 0
-		for _, p := range remaining.Items {
-			if p.Name == policy.Name && p.Namespace == policy.Namespace {
-				continue
-			}
-			if p.GetDeletionTimestamp().IsZero() {
-				liveCount++
-			}
-		}
-		if liveCount == 0 {
-			if err := r.deleteGatewayAuthPolicy(ctx, log, policy.Namespace); err != nil {
-				log.Error(err, "failed to delete gateway AuthPolicy")
-				return ctrl.Result{}, err
-			}
-			if err := r.ensureGatewayDefaultAuthPolicy(ctx, log); err != nil {
-				log.Error(err, "failed to restore gateway-default-auth")
-				return ctrl.Result{}, err
+
+		failures, err := cleanup.result()
+		if err != nil {
+			message := cleanupFailuresMessage(failures)
+			policy.Status.CleanupFailures = failures
+			r.updateStatusWithReason(ctx, policy, maasv1alpha1.PhaseFailed, maasv1alpha1.ReasonCleanupFailed, message, statusSnapshot)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(policy, corev1.EventTypeWarning, string(maasv1alpha1.ReasonCleanupFailed), "%s", message)
 			}
+			return ctrl.Result{}, err
 		}
-
 		controllerutil.RemoveFinalizer(policy, maasAuthPolicyFinalizer)
 		if err := r.Update(ctx, policy); err != nil {
 			return ctrl.Result{}, err
@@ -1414,6 +1723,35 @@ func (r *MaaSAuthPolicyReconciler) handleDeletion(ctx context.Context, log logr.
 	return ctrl.Result{}, nil
 }
 
+// cleanupGatewayAuthPolicy deletes the gateway-level AuthPolicy and restores
+// gateway-default-auth when no other live MaaSAuthPolicy remains.
+func (r *MaaSAuthPolicyReconciler) cleanupGatewayAuthPolicy(ctx context.Context, log logr.Logger, policy *maasv1alpha1.MaaSAuthPolicy) error {
+	remaining := &maasv1alpha1.MaaSAuthPolicyList{}
+	if err := r.List(ctx, remaining); err != nil {
+		return fmt.Errorf("failed to list remaining MaaSAuthPolicies for gateway cleanup check: %w", err)
+	}
+	// Count policies not being deleted and not the current one
+	liveCount := 0
+	for _, p := range remaining.Items {
+		if p.Name == policy.Name && p.Namespace == policy.Namespace {
+			continue
+		}
+		if p.GetDeletionTimestamp().IsZero() {
+			liveCount++
+		}
+	}
+	if liveCount > 0 {
+		return nil
+	}
+	if err := r.deleteGatewayAuthPolicy(ctx, log, policy.Namespace); err != nil {
+		return fmt.Errorf("failed to delete gateway AuthPolicy: %w", err)
+	}
+	if err := r.ensureGatewayDefaultAuthPolicy(ctx, log); err != nil {
+		return fmt.Errorf("failed to restore gateway-default-auth: %w", err)
+	}
+	return nil
+}
+
 // deleteGatewayAuthPolicy removes the tenant's Gateway-level AuthPolicy when no
 // MaaSAuthPolicy CRs remain in that tenant namespace.
 func (r *MaaSAuthPolicyReconciler) deleteGatewayAuthPolicy(ctx context.Context, log logr.Logger, tenantNamespace string) error {
@@ -1431,25 +1769,28 @@ func (r *MaaSAuthPolicyReconciler) deleteGatewayAuthPolicy(ctx context.Context,
 	}
 
 	gwPolicy := &unstructured.Unstructured{}
-	gwPolicy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	gwPolicy.SetGroupVersionKind(gatewayAuthGVK(r.AuthProvider))
 	gwPolicy.SetName(authPolicyName)
 	gwPolicy.SetNamespace(gatewayNs)
 
 	if err := r.Delete(ctx, gwPolicy); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete gateway AuthPolicy %s/%s: %w", gatewayNs, authPolicyName, err)
 		}
-		return fmt.Errorf("failed to delete gateway AuthPolicy %s/%s: %w", gatewayNs, authPolicyName, err)
+	} else {
+		log.Info("gateway AuthPolicy deleted (no remaining MaaSAuthPolicies)", "name", authPolicyName, "namespace", gatewayNs, "tenantNamespace", tenantNamespace)
 	}
-	log.Info("gateway AuthPolicy deleted (no remaining MaaSAuthPolicies)", "name", authPolicyName, "namespace", gatewayNs, "tenantNamespace", tenantNamespace)
-	return nil
+	return r.deleteClientCABundle(ctx, log, gatewayNs, authPolicyName)
 }
 
 // deleteGatewayDefaultAuthPolicy removes the static deny-all gateway-default-auth policy
 // so it does not conflict with the dynamic maas-gateway-auth policy on the same Gateway.
 func (r *MaaSAuthPolicyReconciler) deleteGatewayDefaultAuthPolicy(ctx context.Context, log logr.Logger) {
+	if authorinoAuthConfigs(r.AuthProvider) {
+		return
+	}
 	policy := &unstructured.Unstructured{}
-	policy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	policy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
 	policy.SetName(gatewayDefaultAuthPolicyName)
 	policy.SetNamespace(r.GatewayNamespace)
 
@@ -1464,53 +1805,21 @@ func (r *MaaSAuthPolicyReconciler) deleteGatewayDefaultAuthPolicy(ctx context.Co
 
 // ensureGatewayDefaultAuthPolicy recreates the static deny-all gateway-default-auth policy
 // after the last MaaSAuthPolicy is removed, so unconfigured model routes remain denied.
+// Standalone Authorino denies requests to hosts without an AuthConfig by itself.
 func (r *MaaSAuthPolicyReconciler) ensureGatewayDefaultAuthPolicy(ctx context.Context, log logr.Logger) error {
-	policy := &unstructured.Unstructured{}
-	policy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
-	policy.SetName(gatewayDefaultAuthPolicyName)
-	policy.SetNamespace(r.GatewayNamespace)
-
+	if authorinoAuthConfigs(r.AuthProvider) {
+		return nil
+	}
 	existing := &unstructured.Unstructured{}
-	existing.SetGroupVersionKind(policy.GroupVersionKind())
-	if err := r.Get(ctx, client.ObjectKeyFromObject(policy), existing); err == nil {
+	existing.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: gatewayDefaultAuthPolicyName}, existing); err == nil {
 		log.V(1).Info("gateway-default-auth already exists, skipping recreation", "name", gatewayDefaultAuthPolicyName)
 		return nil
 	}
 
-	policy.SetLabels(map[string]string{
-		"app.kubernetes.io/managed-by": "maas-controller",
-		"app.kubernetes.io/part-of":    "maas-controller",
-		"app.kubernetes.io/component":  "default-policy",
-	})
-	spec := map[string]any{
-		"targetRef": map[string]any{
-			"group": "gateway.networking.k8s.io",
-			"kind":  "Gateway",
-			"name":  r.GatewayName,
-		},
-		"defaults": map[string]any{
-			"rules": map[string]any{
-				"authentication": map[string]any{},
-				"authorization": map[string]any{
-					"deny-unconfigured-models": map[string]any{
-						"metrics":  false,
-						"priority": int64(0),
-						"patternMatching": map[string]any{
-							"patterns": []any{
-								map[string]any{
-									"operator": "eq",
-									"selector": "context.request.http.method",
-									"value":    "__deny_unconfigured_models__",
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-	if err := unstructured.SetNestedMap(policy.Object, spec, "spec"); err != nil {
-		return fmt.Errorf("failed to set gateway-default-auth spec: %w", err)
+	policy, err := buildGatewayDefaultAuthPolicy(r.GatewayNamespace, r.GatewayName)
+	if err != nil {
+		return err
 	}
 	if err := r.Create(ctx, policy); err != nil {
 		if apierrors.IsAlreadyExists(err) {
@@ -1584,7 +1893,7 @@ func (r *MaaSAuthPolicyReconciler) updateAuthPolicyRefStatus(ctx context.Context
 	policy.Status.AuthPolicies = make([]maasv1alpha1.AuthPolicyRefStatus, 0, len(refs))
 	for _, ref := range refs {
 		ap := &unstructured.Unstructured{}
-		ap.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+		ap.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
 		ap.SetNamespace(ref.Namespace)
 		ap.SetName(ref.Name)
 
@@ -1611,7 +1920,8 @@ func (r *MaaSAuthPolicyReconciler) updateAuthPolicyRefStatus(ctx context.Context
 			continue
 		}
 
-		ready, reason, message := getAuthPolicyReadyState(ap)
+		ready, reason, message := getPolicyReadyState(ap)
+		policyEnforcement.observe(ap)
 		status.Ready = ready
 		status.Reason = reason
 		status.Message = message
@@ -1619,10 +1929,10 @@ func (r *MaaSAuthPolicyReconciler) updateAuthPolicyRefStatus(ctx context.Context
 	}
 }
 
-// getAuthPolicyReadyState checks if an AuthPolicy is accepted and enforced.
+// getPolicyReadyState checks if a Kuadrant policy is accepted and enforced.
 // Returns ready=true only if both Accepted and Enforced conditions are True.
-func getAuthPolicyReadyState(ap *unstructured.Unstructured) (ready bool, reason maasv1alpha1.ConditionReason, message string) {
-	conditions, found, err := unstructured.NestedSlice(ap.Object, "status", "conditions")
+func getPolicyReadyState(policy *unstructured.Unstructured) (ready bool, reason maasv1alpha1.ConditionReason, message string) {
+	conditions, found, err := unstructured.NestedSlice(policy.Object, "status", "conditions")
 	if err != nil || !found || len(conditions) == 0 {
 		return false, maasv1alpha1.ReasonConditionsNotFound, "status conditions not available"
 	}
@@ -1663,6 +1973,12 @@ func getAuthPolicyReadyState(ap *unstructured.Unstructured) (ready bool, reason
 }
 
 func (r *MaaSAuthPolicyReconciler) updateStatus(ctx context.Context, policy *maasv1alpha1.MaaSAuthPolicy, phase maasv1alpha1.Phase, message string, statusSnapshot *maasv1alpha1.MaaSAuthPolicyStatus) {
+	r.updateStatusWithReason(ctx, policy, phase, "", message, statusSnapshot)
+}
+
+// updateStatusWithReason is updateStatus with a Ready condition reason that replaces the
+// one the phase implies, unless it is empty.
+func (r *MaaSAuthPolicyReconciler) updateStatusWithReason(ctx context.Context, policy *maasv1alpha1.MaaSAuthPolicy, phase maasv1alpha1.Phase, readyReason maasv1alpha1.ConditionReason, message string, statusSnapshot *maasv1alpha1.MaaSAuthPolicyStatus) {
 	policy.Status.Phase = phase
 
 	var status metav1.ConditionStatus
@@ -1671,6 +1987,9 @@ func (r *MaaSAuthPolicyReconciler) updateStatus(ctx context.Context, policy *maa
 	case maasv1alpha1.PhaseActive:
 		status = metav1.ConditionTrue
 		reason = maasv1alpha1.ReasonReconciled
+	case maasv1alpha1.PhasePending:
+		status = metav1.ConditionFalse
+		reason = maasv1alpha1.ReasonNotEnforced
 	case maasv1alpha1.PhaseDegraded:
 		status = metav1.ConditionFalse
 		reason = maasv1alpha1.ReasonPartialFailure
@@ -1684,6 +2003,9 @@ func (r *MaaSAuthPolicyReconciler) updateStatus(ctx context.Context, policy *maa
 		status = metav1.ConditionUnknown
 		reason = maasv1alpha1.ReasonUnknown
 	}
+	if readyReason != "" {
+		reason = readyReason
+	}
 
 	apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
 		Type:               "Ready",
@@ -1736,9 +2058,10 @@ func (r *MaaSAuthPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			"effectiveAuthzTTL", r.authzCacheTTL())
 	}
 
-	// Watch generated AuthPolicies so we re-reconcile when someone manually edits them.
+	// Watch generated AuthPolicies (AuthConfigs with standalone Authorino) so we
+	// re-reconcile when someone manually edits them.
 	generatedAuthPolicy := &unstructured.Unstructured{}
-	generatedAuthPolicy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	generatedAuthPolicy.SetGroupVersionKind(gatewayAuthGVK(r.AuthProvider))
 
 	// Watch Tenant so we re-reconcile when OIDC configuration changes.
 	tenant := &unstructured.Unstructured{}
@@ -1752,16 +2075,17 @@ func (r *MaaSAuthPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&maasv1alpha1.MaaSAuthPolicy{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.Funcs{UpdateFunc: deletionTimestampSet},
+			dryRunAnnotationChanged,
 		))).
-		// Watch HTTPRoutes so we re-reconcile when KServe creates/updates a route
-		// (fixes race condition where MaaSAuthPolicy is created before HTTPRoute exists).
-		Watches(&gatewayapiv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(
-			r.mapHTTPRouteToMaaSAuthPolicies,
-		)).
 		// Watch MaaSModelRefs so we re-reconcile when a model is created or deleted.
 		Watches(&maasv1alpha1.MaaSModelRef{}, handler.EnqueueRequestsFromMapFunc(
 			r.mapMaaSModelRefToMaaSAuthPolicies,
 		)).
+		// Watch the other MaaSAuthPolicies of a model so their Conflict conditions follow
+		// changes to its policies.
+		Watches(&maasv1alpha1.MaaSAuthPolicy{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapMaaSAuthPolicyToPeers,
+		), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		// Watch generated AuthPolicies so manual edits get overwritten by the controller.
 		Watches(generatedAuthPolicy, handler.EnqueueRequestsFromMapFunc(
 			r.mapGeneratedAuthPolicyToParent,
@@ -1774,7 +2098,27 @@ func (r *MaaSAuthPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// reconciles for policies in the affected tenant namespace.
 		Watches(&maasv1alpha1.AITenant{}, handler.EnqueueRequestsFromMapFunc(
 			r.mapAITenantToMaaSAuthPolicies,
+		)).
+		// Watch MaaSAuthPolicyTemplates so template changes reach the gateway AuthPolicy.
+		Watches(&maasv1alpha1.MaaSAuthPolicyTemplate{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapAuthPolicyTemplateToMaaSAuthPolicies,
+		)).
+		// Watch Secrets so rotated CA certificates of spec.authentication.x509 reach the
+		// gateway's client CA bundle.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapCASecretToMaaSAuthPolicies,
+		)).
+		// Watch the group mapping ConfigMaps so remapped groups reach the gateway AuthPolicy.
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapGroupMappingToAuthPolicies,
+		))
+	if r.RoutingProvider != externalmodel.RoutingProviderIstio {
+		// Watch HTTPRoutes so we re-reconcile when KServe creates/updates a route
+		// (fixes race condition where MaaSAuthPolicy is created before HTTPRoute exists).
+		b = b.Watches(&gatewayapiv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapHTTPRouteToMaaSAuthPolicies,
 		))
+	}
 	if r.TenantNamespaceDiscoveryEnabled {
 		// Watch Namespaces so that policies in newly labeled tenant
 		// namespaces are discovered without a controller restart.
@@ -1782,7 +2126,7 @@ func (r *MaaSAuthPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.mapNamespaceToMaaSAuthPolicies,
 		), builder.WithPredicates(predicate.LabelChangedPredicate{}))
 	}
-	return b.Complete(r)
+	return b.Complete(observeReconcile("MaaSAuthPolicy", r))
 }
 
 func (r *MaaSAuthPolicyReconciler) mapAITenantToMaaSAuthPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
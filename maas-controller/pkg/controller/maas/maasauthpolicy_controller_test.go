@@ -18,6 +18,7 @@ package maas
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
@@ -33,6 +34,7 @@ import (
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
 )
 
 // newPreexistingAuthPolicy builds a Kuadrant AuthPolicy as an unstructured object
@@ -1202,6 +1204,20 @@ func TestMaaSAuthPolicyReconciler_IdentityHeadersUpstream(t *testing.T) {
 		}
 	})
 
+	t.Run("subscription priority exposed as dynamic metadata", func(t *testing.T) {
+		qos, found, err := unstructured.NestedMap(gwPolicy.Object, "spec", "defaults", "rules", "response", "success", "filters", "qos", "json", "properties")
+		if err != nil || !found {
+			t.Fatalf("filters.qos.json.properties missing: found=%v err=%v", found, err)
+		}
+		priority, _, _ := unstructured.NestedString(qos, "priority", "expression")
+		if priority != celSubscriptionPriority {
+			t.Errorf("filters.qos priority expression = %q, want %q", priority, celSubscriptionPriority)
+		}
+		if _, exists := qos["subscription"]; !exists {
+			t.Error("filters.qos should include the selected subscription")
+		}
+	})
+
 	// Test 3: Verify metrics are enabled on identity filter
 	t.Run("identity filter has metrics enabled", func(t *testing.T) {
 		metricsEnabled, found, err := unstructured.NestedBool(gwPolicy.Object, "spec", "defaults", "rules", "response", "success", "filters", "identity", "metrics")
@@ -1224,8 +1240,18 @@ func gatewayAuthPolicySpecTestObject(t *testing.T, oidc *oidcConfig) *unstructur
 		MetadataCacheTTL: 60,
 		AuthzCacheTTL:    60,
 	}
-	spec := r.buildGatewayAuthPolicySpec("{}", oidc, false, "", "models-as-a-service", "test-gateway-ns", "test-gateway")
-	return &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	spec := r.buildGatewayAuthPolicySpec("{}", oidc, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "test-gateway-ns", "test-gateway")
+	return &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+}
+
+// specToUnstructured converts a typed Kuadrant spec to the form written to the cluster.
+func specToUnstructured(t *testing.T, spec any) map[string]any {
+	t.Helper()
+	out, err := kuadrantv1.ToUnstructured(spec)
+	if err != nil {
+		t.Fatalf("ToUnstructured: %v", err)
+	}
+	return out
 }
 
 func nestedMapRequired(t *testing.T, obj *unstructured.Unstructured, fields ...string) map[string]any {
@@ -1286,6 +1312,24 @@ func TestBuildGatewayAuthPolicySpec_K8sAuth(t *testing.T) {
 	}
 }
 
+func TestBuildGatewayAuthPolicySpec_SkipsHealthAndMaaSAPIPreflights(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+
+	var predicates []string
+	for _, p := range spec.Defaults.When {
+		predicates = append(predicates, p.Predicate)
+	}
+	want := []string{
+		`request.path != "/maas-api/health" || request.method != "GET"`,
+		`request.method != "OPTIONS" || !request.path.startsWith("/maas-api/")`,
+	}
+	if !slices.Equal(predicates, want) {
+		t.Errorf("when = %q, want %q: preflights of model routes must still be authenticated", predicates, want)
+	}
+}
+
 func TestBuildGatewayAuthPolicySpec_OIDCAuth(t *testing.T) {
 	oidc := &oidcConfig{
 		IssuerURL: "https://keycloak.example.com/realms/test",
@@ -1352,6 +1396,39 @@ func TestBuildGatewayAuthPolicySpec_RouteScopedRules(t *testing.T) {
 	})
 }
 
+// TestBuildGatewayAuthPolicySpec_APIKeyIdentity verifies that sk-oai-* API keys are
+// validated through the tenant's maas-api and that the validated username and groups
+// become the request identity, without setting spec.authentication.apiKey.
+func TestBuildGatewayAuthPolicySpec_APIKeyIdentity(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{
+		MaaSAPINamespace: "maas-system",
+		ClusterAudience:  "https://kubernetes.default.svc",
+		MetadataCacheTTL: 60,
+		AuthzCacheTTL:    60,
+	}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "acme", "acme", "gateway-ns", "maas-acme-gateway")
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+
+	url := nestedStringRequired(t, obj, "spec", "defaults", "rules", "metadata", "apiKeyValidation", "http", "url")
+	if want := "https://maas-api-acme.maas-system.svc.cluster.local:8443/internal/v1/api-keys/validate"; url != want {
+		t.Errorf("apiKeyValidation url = %s, want %s", url, want)
+	}
+	when, _, _ := unstructured.NestedSlice(obj.Object, "spec", "defaults", "rules", "authentication", "api-keys", "when")
+	if len(when) != 1 || when[0].(map[string]any)["value"] != "^Bearer sk-oai-.*" {
+		t.Errorf("api-keys should authenticate sk-oai-* bearer tokens, got %v", when)
+	}
+
+	for property, want := range map[string]string{
+		"userid": "auth.metadata.apiKeyValidation.username",
+		"groups": "auth.metadata.apiKeyValidation.groups",
+	} {
+		expr := nestedStringRequired(t, obj, "spec", "defaults", "rules", "response", "success", "filters", "identity", "json", "properties", property, "expression")
+		if !contains(expr, want) {
+			t.Errorf("identity %s should come from %s for API keys, got: %s", property, want, expr)
+		}
+	}
+}
+
 func TestBuildGatewayAuthPolicySpec_XAPIKeyEnabled(t *testing.T) {
 	r := &MaaSAuthPolicyReconciler{
 		MaaSAPINamespace: "maas-system",
@@ -1362,8 +1439,8 @@ func TestBuildGatewayAuthPolicySpec_XAPIKeyEnabled(t *testing.T) {
 		AuthzCacheTTL:    60,
 	}
 
-	spec := r.buildGatewayAuthPolicySpec("{}", nil, true, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
-	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, true, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
 
 	auth, found, err := unstructured.NestedMap(obj.Object, "spec", "defaults", "rules", "authentication")
 	if err != nil || !found {
@@ -83,36 +83,48 @@ func TestAggregateModelSubjectAllowlistsAndGatewaySpec(t *testing.T) {
 		t.Fatalf("json.Marshal(allowlists) returned error: %v", err)
 	}
 
-	spec := r.buildGatewayAuthPolicySpec(string(allowlistsJSON), nil, false, "", "models-as-a-service", "test-gateway-ns", "test-gateway")
-	defaults, ok := spec["defaults"].(map[string]any)
-	if !ok {
-		t.Fatalf("gateway spec missing defaults block")
+	spec := r.buildGatewayAuthPolicySpec(string(allowlistsJSON), nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "test-gateway-ns", "test-gateway")
+	requireGroupMembership, ok := spec.Defaults.Rules.Authorization["require-group-membership"]
+	if !ok || requireGroupMembership.OPA == nil {
+		t.Fatalf("gateway spec missing require-group-membership OPA rule")
 	}
-	rules, ok := defaults["rules"].(map[string]any)
-	if !ok {
-		t.Fatalf("gateway spec missing defaults.rules block")
+	rego := requireGroupMembership.OPA.Rego
+
+	if !strings.Contains(rego, `"llm/model-a":{"users":["user-a","user-b"],"groups":["group-a","group-b"]}`) {
+		t.Fatalf("rego does not include aggregated model-a allowlist: %s", rego)
 	}
-	authorization, ok := rules["authorization"].(map[string]any)
-	if !ok {
-		t.Fatalf("gateway spec missing defaults.rules.authorization block")
+	if !strings.Contains(rego, `"llm/model-b":{"users":["user-a"],"groups":["group-a"]}`) {
+		t.Fatalf("rego does not include aggregated model-b allowlist: %s", rego)
 	}
-	requireGroupMembership, ok := authorization["require-group-membership"].(map[string]any)
-	if !ok {
-		t.Fatalf("gateway spec missing require-group-membership rule")
+}
+
+func TestAggregateModelSubjectAllowlists_DeniedUsers(t *testing.T) {
+	grant := newMaaSAuthPolicy("grant", "default", "team-a", maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"})
+	lockout := newMaaSAuthPolicy("lockout", "default", "team-a", maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"})
+	lockout.Spec.Subjects.Groups = nil
+	lockout.Spec.Subjects.DeniedUsers = []string{"mallory", "eve", "mallory"}
+
+	allowlists, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*grant, *lockout})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists returned error: %v", err)
 	}
-	opa, ok := requireGroupMembership["opa"].(map[string]any)
-	if !ok {
-		t.Fatalf("gateway spec missing require-group-membership.opa block")
+	modelA := allowlists["llm/model-a"]
+	if got, want := strings.Join(modelA.DeniedUsers, ","), "eve,mallory"; got != want {
+		t.Fatalf("model-a deniedUsers = %q, want %q", got, want)
 	}
-	rego, ok := opa["rego"].(string)
-	if !ok {
-		t.Fatalf("gateway spec missing require-group-membership.opa.rego string")
+	if got, want := strings.Join(modelA.Groups, ","), "team-a"; got != want {
+		t.Fatalf("model-a groups = %q, want %q", got, want)
 	}
 
-	if !strings.Contains(rego, `"llm/model-a":{"users":["user-a","user-b"],"groups":["group-a","group-b"]}`) {
-		t.Fatalf("rego does not include aggregated model-a allowlist: %s", rego)
+	lockout.Spec.Subjects.DeniedUsers = []string{`eve" || true`}
+	if _, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*lockout}); err == nil {
+		t.Fatal("expected a denied username that is not a valid CEL string value to be rejected")
 	}
-	if !strings.Contains(rego, `"llm/model-b":{"users":["user-a"],"groups":["group-a"]}`) {
-		t.Fatalf("rego does not include aggregated model-b allowlist: %s", rego)
+
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub"}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "test-gateway-ns", "test-gateway")
+	rego := spec.Defaults.Rules.Authorization["require-group-membership"].OPA.Rego
+	if !strings.Contains(rego, "model_rules.deniedUsers[_] == username") || strings.Count(rego, "not denied") != 3 {
+		t.Fatalf("rego should refuse denied users in every model allow rule: %s", rego)
 	}
 }
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -41,9 +42,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/reconciler/externalmodel"
 )
 
 // MaaSModelRefReconciler reconciles a MaaSModelRef object
@@ -59,6 +62,33 @@ type MaaSModelRefReconciler struct {
 	DefaultTenantNamespace string
 	// TenantNamespaceDiscoveryEnabled enables AITenant-labeled tenant namespaces.
 	TenantNamespaceDiscoveryEnabled bool
+
+	// RouteRequeue controls polling while a model's HTTPRoute does not exist yet (zero fields use defaults).
+	RouteRequeue RequeueBackoff
+
+	// RequirePoliciesDefault applies to MaaSModelRefs that leave spec.requirePolicies unset.
+	RequirePoliciesDefault bool
+
+	// EndpointProbes enables periodic probing of Ready models' endpoints; the result is
+	// reported in the Degraded condition. Probing is off when unset.
+	EndpointProbes *EndpointProbeMonitor
+
+	// RoutingProvider selects whether ExternalModel routes are HTTPRoutes (default) or
+	// Istio VirtualServices; it must match the ExternalModel reconciler's setting.
+	RoutingProvider externalmodel.RoutingProvider
+
+	// AuthProvider selects whether the gateway auth rules are a Kuadrant AuthPolicy
+	// (default) or an Authorino AuthConfig; it must match the MaaSAuthPolicy reconciler's setting.
+	AuthProvider AuthProvider
+
+	// TokenRateLimitPolicyGVK is the TokenRateLimitPolicy version the installed Kuadrant
+	// serves, used to clean up a deleted model's policies. Defaults to kuadrant.io/v1alpha1.
+	TokenRateLimitPolicyGVK schema.GroupVersionKind
+
+	// Recorder emits the lifecycle events of the models as Kubernetes events.
+	Recorder record.EventRecorder
+	// LifecycleSink, when set, also receives the lifecycle events as CloudEvents.
+	LifecycleSink LifecycleSink
 }
 
 func (r *MaaSModelRefReconciler) gatewayName() string {
@@ -77,6 +107,7 @@ func (r *MaaSModelRefReconciler) gatewayNamespace() string {
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kuadrant.io,resources=authpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=authorino.kuadrant.io,resources=authconfigs,verbs=get;list;watch
 //+kubebuilder:rbac:groups=serving.kserve.io,resources=llminferenceservices,verbs=get;list;watch
 
 const maasModelFinalizer = "maas.opendatahub.io/model-cleanup"
@@ -84,12 +115,16 @@ const maasModelFinalizer = "maas.opendatahub.io/model-cleanup"
 // Field index for efficiently finding MaaSModelRefs by their modelRef.name
 const modelRefNameIndex = "spec.modelRef.name"
 
-// modelRefNameIndexer returns the modelRef.name for indexing
+// modelRefNameIndexer returns the modelRef.name (and the failover modelRef.name, so backup
+// readiness changes reach the model) for indexing
 func modelRefNameIndexer(obj client.Object) []string {
 	model, ok := obj.(*maasv1alpha1.MaaSModelRef)
 	if !ok || model.Spec.ModelRef.Name == "" {
 		return nil
 	}
+	if model.Spec.Failover != nil && model.Spec.Failover.ModelRef.Name != "" {
+		return []string{model.Spec.ModelRef.Name, model.Spec.Failover.ModelRef.Name}
+	}
 	return []string{model.Spec.ModelRef.Name}
 }
 
@@ -100,6 +135,7 @@ func (r *MaaSModelRefReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	model := &maasv1alpha1.MaaSModelRef{}
 	if err := r.Get(ctx, req.NamespacedName, model); err != nil {
 		if apierrors.IsNotFound(err) {
+			r.EndpointProbes.forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "unable to fetch MaaSModelRef")
@@ -108,6 +144,7 @@ func (r *MaaSModelRefReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Handle deletion
 	if !model.GetDeletionTimestamp().IsZero() {
+		r.EndpointProbes.forget(req.NamespacedName)
 		return r.handleDeletion(ctx, log, model)
 	}
 
@@ -119,13 +156,16 @@ func (r *MaaSModelRefReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
-	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(model, maasModelFinalizer) {
-		controllerutil.AddFinalizer(model, maasModelFinalizer)
+	// Add finalizer if not present, and mirror spec.visibility to the label maas-api filters on.
+	addedFinalizer := controllerutil.AddFinalizer(model, maasModelFinalizer)
+	if syncVisibilityLabel(model) || addedFinalizer {
 		if err := r.Update(ctx, model); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
+	if addedFinalizer {
+		r.publishLifecycle(ctx, model, ReasonModelRegistered, "Model is registered in the catalog")
+	}
 
 	statusSnapshot := model.Status.DeepCopy()
 
@@ -143,16 +183,71 @@ func (r *MaaSModelRefReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			return ctrl.Result{}, nil
 		}
 		if errors.Is(err, ErrHTTPRouteNotFound) {
-			// HTTPRoute doesn't exist yet - this is normal during startup.
-			// Set status to Pending (not Failed). The HTTPRoute watch will trigger reconciliation when the route is created.
+			// HTTPRoute doesn't exist yet - this is normal during startup (e.g. racing KServe route creation).
+			// Stay Pending and requeue with backoff; the HTTPRoute watch still triggers reconciliation
+			// as soon as the route is created. After MaxWait the model is reported Failed.
 			model.Status.Endpoint = ""
-			r.updateStatus(ctx, model, "Pending", "Waiting for HTTPRoute to be created", statusSnapshot)
-			return ctrl.Result{}, nil
+			result, phase, message := r.waitForRoute(model, err)
+			r.updateStatusWithReason(ctx, model, phase, message, waitForRouteReadyReason(phase), statusSnapshot)
+			return result, nil
 		}
 		log.Error(err, "failed to reconcile HTTPRoute")
 		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to reconcile HTTPRoute: %v", err), statusSnapshot)
 		return ctrl.Result{}, err
 	}
+	apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionWaitingForRoute)
+
+	compatible, listenerMessage, err := r.checkGatewayListeners(ctx, model)
+	if err != nil {
+		log.Error(err, "failed to validate gateway listeners")
+		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to validate gateway listeners: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	if !compatible {
+		// The route can never attach; say so instead of waiting on it.
+		model.Status.Endpoint = ""
+		r.updateStatusWithReason(ctx, model, "Failed", listenerMessage, listenerFailureReason(model), statusSnapshot)
+		return ctrl.Result{}, nil
+	}
+
+	if unsupported := r.maintenanceUnsupported(); model.Spec.Maintenance && unsupported != "" {
+		// Keep the model out of the catalog, but say that its requests are not refused.
+		model.Status.Endpoint = ""
+		r.updateStatusWithReason(ctx, model, phaseMaintenance,
+			"Model is in maintenance but requests still reach it: "+unsupported,
+			reasonMaintenanceUnsupported, statusSnapshot)
+		return ctrl.Result{}, nil
+	}
+	if model.Spec.Maintenance {
+		// Drain the model: the gateway answers 503 and the catalog stops listing it.
+		if err := r.reconcileMaintenance(ctx, log, model); err != nil {
+			log.Error(err, "failed to reconcile maintenance AuthPolicy")
+			r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to reconcile maintenance: %v", err), statusSnapshot)
+			return ctrl.Result{}, err
+		}
+		// The routing HTTPRoute would bypass the maintenance AuthPolicy on the primary route.
+		if err := r.deleteRoutingRoute(ctx, log, model); err != nil {
+			log.Error(err, "failed to remove routing HTTPRoute")
+			r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to reconcile maintenance: %v", err), statusSnapshot)
+			return ctrl.Result{}, err
+		}
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionRoutingPolicyApplied)
+		model.Status.Endpoint = ""
+		r.updateStatusWithReason(ctx, model, phaseMaintenance,
+			fmt.Sprintf("Model is in maintenance; requests receive 503 with Retry-After: %d", maintenanceRetryAfter(model)),
+			reasonMaintenance, statusSnapshot)
+		return ctrl.Result{}, nil
+	}
+	if err := r.deleteMaintenancePolicy(ctx, log, model); err != nil {
+		log.Error(err, "failed to remove maintenance AuthPolicy")
+		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to leave maintenance: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileSessionAffinity(ctx, log, model); err != nil {
+		log.Error(err, "failed to reconcile session affinity")
+		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to reconcile session affinity: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
 
 	endpoint, runtimeReady, err := handler.Status(ctx, log, model)
 	if err != nil {
@@ -168,6 +263,21 @@ func (r *MaaSModelRefReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to update model status: %v", err), statusSnapshot)
 		return ctrl.Result{}, err
 	}
+	failoverEndpoint, err := r.reconcileFailover(ctx, log, model, runtimeReady)
+	if err != nil {
+		log.Error(err, "failed to reconcile failover")
+		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to reconcile failover: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileRoutingPolicy(ctx, log, model, failoverEndpoint != ""); err != nil {
+		log.Error(err, "failed to reconcile routing timeouts and retries")
+		r.updateStatus(ctx, model, "Failed", fmt.Sprintf("Failed to reconcile routing policy: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	if failoverEndpoint != "" {
+		// The backup serves the primary's endpoint, so the model stays usable.
+		endpoint, runtimeReady = failoverEndpoint, true
+	}
 	if model.Spec.EndpointOverride != "" {
 		model.Status.Endpoint = model.Spec.EndpointOverride
 	} else {
@@ -179,6 +289,20 @@ func (r *MaaSModelRefReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	r.setRuntimeReadyCondition(model, runtimeReady)
 
 	phase, message := deriveModelPhase(governed, runtimeReady)
+	required := r.requirePolicies(model)
+	var enforcedPolicy, notEnforcedReason string
+	if required {
+		enforcedPolicy, notEnforcedReason, err = r.findEnforcedAuthPolicy(ctx, model)
+		if err != nil {
+			log.Error(err, "failed to check AuthPolicy enforcement")
+			notEnforcedReason = err.Error()
+		}
+		if enforcedPolicy == "" && phase == "Ready" {
+			// Never advertise a model that is reachable without authentication.
+			phase, message = "Pending", "Awaiting an enforced AuthPolicy: "+notEnforcedReason
+		}
+	}
+	r.setPoliciesEnforcedCondition(model, required, enforcedPolicy, notEnforcedReason)
 	if phase != "Ready" {
 		model.Status.Endpoint = ""
 	}
@@ -262,6 +386,28 @@ func (r *MaaSModelRefReconciler) setRuntimeReadyCondition(model *maasv1alpha1.Ma
 	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
 }
 
+// effectiveVisibility returns the model's spec.visibility, defaulting to public.
+func effectiveVisibility(model *maasv1alpha1.MaaSModelRef) maasv1alpha1.ModelVisibility {
+	if model.Spec.Visibility == "" {
+		return maasv1alpha1.VisibilityPublic
+	}
+	return model.Spec.Visibility
+}
+
+// syncVisibilityLabel sets the visibility label to the effective spec.visibility.
+// Returns true if the label changed.
+func syncVisibilityLabel(model *maasv1alpha1.MaaSModelRef) bool {
+	want := string(effectiveVisibility(model))
+	if model.Labels[maasv1alpha1.LabelVisibility] == want {
+		return false
+	}
+	if model.Labels == nil {
+		model.Labels = make(map[string]string)
+	}
+	model.Labels[maasv1alpha1.LabelVisibility] = want
+	return true
+}
+
 func deriveModelPhase(governed, runtimeReady bool) (phase, message string) {
 	switch {
 	case governed && runtimeReady:
@@ -277,29 +423,49 @@ func deriveModelPhase(governed, runtimeReady bool) (phase, message string) {
 
 func (r *MaaSModelRefReconciler) handleDeletion(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) (ctrl.Result, error) {
 	if controllerutil.ContainsFinalizer(model, maasModelFinalizer) {
+		// Every step runs even when an earlier one fails, so a single stuck resource does
+		// not orphan the others; the finalizer is kept until all of them succeed.
+		var errs []error
+
 		// Clean up generated AuthPolicies for this model
 		if err := r.deleteGeneratedPoliciesByLabel(ctx, log, model.Namespace, model.Name, "AuthPolicy", "kuadrant.io", "v1"); err != nil {
-			return ctrl.Result{}, err
+			errs = append(errs, err)
 		}
 
 		// Clean up generated TokenRateLimitPolicies for this model
-		if err := r.deleteGeneratedPoliciesByLabel(ctx, log, model.Namespace, model.Name, "TokenRateLimitPolicy", "kuadrant.io", "v1alpha1"); err != nil {
-			return ctrl.Result{}, err
+		trlpGVK := tokenRateLimitPolicyGVK(r.TokenRateLimitPolicyGVK)
+		if err := r.deleteGeneratedPoliciesByLabel(ctx, log, model.Namespace, model.Name, trlpGVK.Kind, trlpGVK.Group, trlpGVK.Version); err != nil {
+			errs = append(errs, err)
+		}
+
+		// Clean up generated RateLimitPolicies (request rate limits) for this model
+		if err := r.deleteGeneratedPoliciesByLabel(ctx, log, model.Namespace, model.Name, "RateLimitPolicy", "kuadrant.io", "v1"); err != nil {
+			errs = append(errs, err)
+		}
+
+		// The routing HTTPRoute may live in another namespace and then has no owner reference
+		if err := r.deleteRoutingRoute(ctx, log, model); err != nil {
+			errs = append(errs, err)
 		}
 
 		// Kind-specific cleanup (e.g. delete HTTPRoute for ExternalModel; no-op for llmisvc)
 		if handler := GetBackendHandler(model.Spec.ModelRef.Kind, r); handler != nil {
 			if err := handler.CleanupOnDelete(ctx, log, model); err != nil {
-				log.Error(err, "failed to clean up backend resources")
-				return ctrl.Result{}, err
+				errs = append(errs, err)
 			}
 		}
 
+		if err := errors.Join(errs...); err != nil {
+			log.Error(err, "failed to clean up resources of deleted MaaSModelRef, will retry")
+			return ctrl.Result{}, err
+		}
+
 		// Remove finalizer so the MaaSModelRef can be deleted
 		controllerutil.RemoveFinalizer(model, maasModelFinalizer)
 		if err := r.Update(ctx, model); err != nil {
 			return ctrl.Result{}, err
 		}
+		r.publishLifecycle(ctx, model, ReasonModelRemoved, "Model is removed from the catalog")
 	}
 
 	return ctrl.Result{}, nil
@@ -323,6 +489,7 @@ func (r *MaaSModelRefReconciler) deleteGeneratedPoliciesByLabel(ctx context.Cont
 		return fmt.Errorf("failed to list %s resources for model %s: %w", kind, modelName, err)
 	}
 
+	var errs []error
 	for i := range policyList.Items {
 		p := &policyList.Items[i]
 		if !isManaged(p) {
@@ -334,11 +501,11 @@ func (r *MaaSModelRefReconciler) deleteGeneratedPoliciesByLabel(ctx context.Cont
 		log.Info(fmt.Sprintf("Deleting generated %s on MaaSModelRef deletion", kind),
 			"name", p.GetName(), "namespace", p.GetNamespace(), "model", modelName)
 		if err := r.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
-			return fmt.Errorf("failed to delete %s %s/%s: %w", kind, p.GetNamespace(), p.GetName(), err)
+			errs = append(errs, fmt.Errorf("failed to delete %s %s/%s: %w", kind, p.GetNamespace(), p.GetName(), err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func (r *MaaSModelRefReconciler) updateStatus(ctx context.Context, model *maasv1alpha1.MaaSModelRef, phase, message string, statusSnapshot *maasv1alpha1.MaaSModelStatus) {
@@ -348,6 +515,7 @@ func (r *MaaSModelRefReconciler) updateStatus(ctx context.Context, model *maasv1
 // updateStatusWithReason sets Phase and Ready condition; when phase is "Failed", reason overrides the default "ReconcileFailed" (e.g. "Unsupported" for unimplemented kinds).
 func (r *MaaSModelRefReconciler) updateStatusWithReason(ctx context.Context, model *maasv1alpha1.MaaSModelRef, phase, message, reason string, statusSnapshot *maasv1alpha1.MaaSModelStatus) {
 	model.Status.Phase = phase
+	r.reportEndpointHealth(model)
 
 	status := metav1.ConditionTrue
 	condReason := "Reconciled"
@@ -380,7 +548,9 @@ func (r *MaaSModelRefReconciler) updateStatusWithReason(ctx context.Context, mod
 		log := logr.FromContextOrDiscard(ctx)
 		log.Error(err, "failed to update MaaSModelRef status", "name", model.Name)
 		// Intentionally do not return the error so we do not re-queue on status update conflict/failure.
+		return
 	}
+	r.publishStatusTransitions(ctx, model, statusSnapshot)
 }
 
 // llmisvcReadyChangedPredicate passes Create/Delete events and Update events
@@ -412,21 +582,48 @@ func llmisvcReadyStatus(obj *kservev1alpha1.LLMInferenceService) string {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MaaSModelRefReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("maas-modelref-controller")
+	}
 	ctx := context.Background()
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &maasv1alpha1.MaaSModelRef{}, modelRefNameIndex, modelRefNameIndexer); err != nil {
 		return fmt.Errorf("failed to create field index %s: %w", modelRefNameIndex, err)
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Standalone Authorino has no AuthPolicy CRD; the gateway AuthConfig is watched instead.
+	gatewayAuthPolicy := &unstructured.Unstructured{}
+	gatewayAuthPolicy.SetGroupVersionKind(gatewayAuthGVK(r.AuthProvider))
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&maasv1alpha1.MaaSModelRef{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.Funcs{UpdateFunc: deletionTimestampSet},
-		))).
+		)))
+	if r.istioRouting() {
+		// Routes are VirtualServices and the Gateway API CRDs may not be installed.
+		virtualService := &unstructured.Unstructured{}
+		virtualService.SetGroupVersionKind(istioVirtualServiceGVK)
+		b = b.Watches(virtualService, handler.EnqueueRequestsFromMapFunc(
+			r.mapVirtualServiceToMaaSModelRefs,
+		))
+	} else {
 		// Watch HTTPRoutes so we re-reconcile when KServe creates/updates a route
 		// (fixes race condition where MaaSModelRef is created before HTTPRoute exists).
-		Watches(&gatewayapiv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(
+		b = b.Watches(&gatewayapiv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(
 			r.mapHTTPRouteToMaaSModelRefs,
 		)).
+			// Watch Gateways so listener changes re-validate the models attached to them.
+			Watches(&gatewayapiv1.Gateway{}, handler.EnqueueRequestsFromMapFunc(
+				r.mapGatewayToMaaSModelRefs,
+			), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+
+	if r.EndpointProbes != nil {
+		// Requeue models whose endpoint probe result changed.
+		b = b.WatchesRawSource(source.Channel(r.EndpointProbes.events, &handler.EnqueueRequestForObject{}))
+	}
+
+	return b.
 		// Watch LLMInferenceServices so we re-reconcile when the backing service's Ready status changes
 		// (automatically updates MaaSModelRef status from Pending -> Ready and vice versa).
 		Watches(&kservev1alpha1.LLMInferenceService{},
@@ -443,7 +640,12 @@ func (r *MaaSModelRefReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&maasv1alpha1.MaaSAuthPolicy{}, handler.EnqueueRequestsFromMapFunc(
 			r.mapMaaSAuthPolicyToMaaSModelRefs,
 		)).
-		Complete(r)
+		// Watch Kuadrant AuthPolicies so models that require policies become Ready
+		// once the policy protecting their route is enforced (and drop out if it is not).
+		Watches(gatewayAuthPolicy, handler.EnqueueRequestsFromMapFunc(
+			r.mapAuthPolicyToMaaSModelRefs,
+		)).
+		Complete(observeReconcile("MaaSModelRef", r))
 }
 
 // mapHTTPRouteToMaaSModelRefs returns reconcile requests for all MaaSModelRefs in the HTTPRoute's namespace.
@@ -477,7 +679,8 @@ func (r *MaaSModelRefReconciler) mapMaaSSubscriptionToMaaSModelRefs(ctx context.
 	}
 	seen := make(map[types.NamespacedName]struct{})
 	var requests []reconcile.Request
-	for _, ref := range sub.Spec.ModelRefs {
+	// Selected models as last resolved by the subscription controller.
+	for _, ref := range namedModelRefs(sub) {
 		key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
 		if _, exists := seen[key]; exists {
 			continue
@@ -25,6 +25,7 @@ import (
 	kservev1alpha1 "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -290,7 +291,7 @@ func TestMaaSModelReconciler_LLMISvcReadyTransition_ModelBecomesReady(t *testing
 	sub.Spec.ModelRefs[0].Namespace = ns
 	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a",
 		maasv1alpha1.ModelRef{Name: modelName, Namespace: ns})
-	r, c := newTestReconciler(model, route, llmisvc, sub, auth)
+	r, c := newTestReconciler(model, route, llmisvc, sub, auth, newMaaSGateway(testGatewayName, testGatewayNamespace))
 	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: modelName, Namespace: ns}}
 
 	// --- Phase 1: reconcile while llmisvc is not-ready -> model enters Unhealthy (governed but runtime not ready) ---
@@ -356,7 +357,7 @@ func TestMaaSModelReconciler_LLMISvcReadyToNotReady_ModelBecomesUnhealthy(t *tes
 	sub.Spec.ModelRefs[0].Namespace = ns
 	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a",
 		maasv1alpha1.ModelRef{Name: modelName, Namespace: ns})
-	r, c := newTestReconciler(model, route, llmisvc, sub, auth)
+	r, c := newTestReconciler(model, route, llmisvc, sub, auth, newMaaSGateway(testGatewayName, testGatewayNamespace))
 	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: modelName, Namespace: ns}}
 
 	// --- Phase 1: reconcile while llmisvc is ready -> model enters Ready ---
@@ -563,7 +564,7 @@ func TestMaaSModelRefReconciler_HTTPRouteRaceCondition(t *testing.T) {
 	sub.Spec.ModelRefs[0].Namespace = ns
 	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a",
 		maasv1alpha1.ModelRef{Name: modelName, Namespace: ns})
-	r, c := newTestReconciler(model, llmisvc, sub, auth)
+	r, c := newTestReconciler(model, llmisvc, sub, auth, newMaaSGateway(testGatewayName, testGatewayNamespace))
 	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: modelName, Namespace: ns}}
 
 	// --- Phase 1: Reconcile without HTTPRoute -> should enter Pending ---
@@ -572,8 +573,8 @@ func TestMaaSModelRefReconciler_HTTPRouteRaceCondition(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Reconcile (no HTTPRoute): %v", err)
 	}
-	if result.RequeueAfter != 0 {
-		t.Errorf("expected no requeue when HTTPRoute not found (watch handles it), got: %v", result)
+	if result.RequeueAfter != DefaultRequeueInitialDelay {
+		t.Errorf("expected requeue after %s when HTTPRoute not found, got: %v", DefaultRequeueInitialDelay, result)
 	}
 
 	got := &maasv1alpha1.MaaSModelRef{}
@@ -605,6 +606,9 @@ func TestMaaSModelRefReconciler_HTTPRouteRaceCondition(t *testing.T) {
 		t.Errorf("Phase after HTTPRoute created = %q, want Ready", final.Status.Phase)
 	}
 	assertReadyCondition(t, final.Status.Conditions, metav1.ConditionTrue, "Reconciled")
+	if apimeta.FindStatusCondition(final.Status.Conditions, maasv1alpha1.ConditionWaitingForRoute) != nil {
+		t.Errorf("expected %s condition to be removed once the HTTPRoute exists", maasv1alpha1.ConditionWaitingForRoute)
+	}
 }
 
 // TestMaaSModelRefReconciler_DuplicateReconciliation verifies that reconciling the same
@@ -1260,3 +1264,43 @@ func TestGovernance_MapAuthPolicyToModels(t *testing.T) {
 		t.Errorf("expected ns-b/model-b in requests")
 	}
 }
+
+// TestMaaSModelRefReconciler_VisibilityLabel verifies that spec.visibility is mirrored to
+// the visibility label maas-api filters on, defaulting to public.
+func TestMaaSModelRefReconciler_VisibilityLabel(t *testing.T) {
+	const testKind = "_test_visibility_kind"
+	backendHandlerFactories[testKind] = func(_ *MaaSModelRefReconciler) BackendHandler {
+		return &fakeHandler{endpoint: "https://model.example.com", ready: true}
+	}
+	defer delete(backendHandlerFactories, testKind)
+
+	ctx := context.Background()
+	model := newMaaSModelRef("vis-model", "default", testKind, "backend")
+	r, c := newTestReconciler(model)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "vis-model", Namespace: "default"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if v := got.Labels[maasv1alpha1.LabelVisibility]; v != string(maasv1alpha1.VisibilityPublic) {
+		t.Errorf("visibility label = %q, want %q", v, maasv1alpha1.VisibilityPublic)
+	}
+
+	got.Spec.Visibility = maasv1alpha1.VisibilityHidden
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if v := got.Labels[maasv1alpha1.LabelVisibility]; v != string(maasv1alpha1.VisibilityHidden) {
+		t.Errorf("visibility label = %q, want %q", v, maasv1alpha1.VisibilityHidden)
+	}
+}
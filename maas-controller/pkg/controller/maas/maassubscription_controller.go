@@ -25,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -48,7 +50,10 @@ import (
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/reconciler/externalmodel"
 )
 
 // MaaSSubscriptionReconciler reconciles a MaaSSubscription object
@@ -66,16 +71,75 @@ type MaaSSubscriptionReconciler struct {
 	// Tenant does not yet carry spec.gatewayRef.
 	GatewayName      string
 	GatewayNamespace string
+	// WatchNamespaces limits the controller to these namespaces in namespace-scoped mode.
+	// Models in other namespaces are reported as not watched and get no policies.
+	WatchNamespaces WatchNamespaces
+
+	// Recorder emits Kubernetes events for generated policy drift warnings.
+	Recorder record.EventRecorder
+
+	// UsageCollector and UsageCollectionInterval enable periodic collection of Limitador
+	// counters into status.usage. Collection is disabled when either is unset.
+	UsageCollector          UsageCollector
+	UsageCollectionInterval time.Duration
+	// UsageNearLimitRatio is the share of a rate at which a counter is near its limit;
+	// DefaultUsageNearLimitRatio when unset.
+	UsageNearLimitRatio float64
+	// UsageHistoryLength is the number of hourly usage snapshots kept per model in
+	// status.usage; DefaultUsageHistoryLength when zero, none when negative.
+	UsageHistoryLength int
+	// UsageNotifier delivers spec.notifications as collected usage crosses their
+	// thresholds. Notifications are disabled when unset.
+	UsageNotifier UsageNotifier
+
+	// AuthProvider selects whether the gateway auth rules are a Kuadrant AuthPolicy
+	// (default) or an Authorino AuthConfig. With standalone Authorino there is no Kuadrant
+	// to enforce rate limits, so no rate limit policies are generated or watched.
+	AuthProvider AuthProvider
+
+	// RoutingProvider selects whether ExternalModel routes are HTTPRoutes (default) or
+	// Istio VirtualServices. HTTPRoutes are not watched with the istio provider, which
+	// runs without the Gateway API CRDs.
+	RoutingProvider externalmodel.RoutingProvider
+
+	// RefuseConflictingPolicies stops applying the generated policies of a model while a
+	// TokenRateLimitPolicy or RateLimitPolicy not created by maas-controller targets its
+	// HTTPRoute. Conflicts are reported in the ConflictingRateLimitPolicy condition either way.
+	RefuseConflictingPolicies bool
+
+	// EnforcementRequeue controls polling while generated policies are not enforced yet
+	// (zero fields use defaults).
+	EnforcementRequeue RequeueBackoff
+
+	// TokenRateLimitPolicyGVK is the TokenRateLimitPolicy version the installed Kuadrant
+	// serves, detected at startup. Defaults to kuadrant.io/v1alpha1 when unset.
+	TokenRateLimitPolicyGVK schema.GroupVersionKind
+}
+
+// trlpGVK returns the GVK of the TokenRateLimitPolicies the controller generates.
+func (r *MaaSSubscriptionReconciler) trlpGVK() schema.GroupVersionKind {
+	return tokenRateLimitPolicyGVK(r.TokenRateLimitPolicyGVK)
+}
+
+// tokenRateLimitPolicyGVK returns the detected TokenRateLimitPolicy GVK, or
+// kuadrant.io/v1alpha1 when none was detected.
+func tokenRateLimitPolicyGVK(served schema.GroupVersionKind) schema.GroupVersionKind {
+	if served.Empty() {
+		return kuadrantv1alpha1.TokenRateLimitPolicyGVK
+	}
+	return served
 }
 
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maassubscriptions,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maassubscriptions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maassubscriptions/finalizers,verbs=update
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maasmodelrefs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=maas.opendatahub.io,resources=maassubscriptiontemplates,verbs=get;list;watch
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=aitenants,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kuadrant.io,resources=tokenratelimitpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/finalizers,verbs=update
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/finalizers,verbs=update
 
 const (
 	maasSubscriptionFinalizer = "maas.opendatahub.io/subscription-cleanup"
@@ -105,9 +169,22 @@ func validateTokenRateLimit(limit int64, window string) error {
 		return fmt.Errorf("token limit %d exceeds maximum allowed value %d", limit, maxTokenRateLimit)
 	}
 
+	seconds, err := windowSeconds(window)
+	if err != nil {
+		return err
+	}
+	if seconds > maxWindowSeconds {
+		return fmt.Errorf("window %q (%d seconds) exceeds maximum allowed duration (%d seconds)", window, seconds, maxWindowSeconds)
+	}
+
+	return nil
+}
+
+// windowSeconds returns the length of a rate limit window such as "30s", "5m" or "24h".
+func windowSeconds(window string) (int64, error) {
 	matches := windowPattern.FindStringSubmatch(window)
 	if len(matches) != 2 {
-		return fmt.Errorf("invalid window format %q: expected a positive number followed by s, m, or h (e.g. \"1h\", \"30m\")", window)
+		return 0, fmt.Errorf("invalid window format %q: expected a positive number followed by s, m, or h (e.g. \"1h\", \"30m\")", window)
 	}
 
 	// Extract numeric part (everything except the last character).
@@ -115,26 +192,105 @@ func validateTokenRateLimit(limit int64, window string) error {
 	numStr := window[:len(window)-len(unit)]
 	value, err := strconv.ParseInt(numStr, 10, 64)
 	if err != nil {
-		return fmt.Errorf("invalid window numeric value %q: %w", numStr, err)
+		return 0, fmt.Errorf("invalid window numeric value %q: %w", numStr, err)
 	}
 
-	var seconds int64
 	switch unit {
-	case "s":
-		seconds = value
 	case "m":
-		seconds = value * 60
+		return value * 60, nil
 	case "h":
-		seconds = value * 3600
+		return value * 3600, nil
+	default:
+		return value, nil
 	}
+}
 
-	if seconds > maxWindowSeconds {
-		return fmt.Errorf("window %q (%d seconds) exceeds maximum allowed duration (%d seconds)", window, seconds, maxWindowSeconds)
+// validateTokenRateLimits checks a modelRef's set of rates, e.g. a burst limit over 1m
+// next to a sustained limit over 1h. Besides validating each rate, windows must be
+// distinct durations and a longer window must allow more tokens than a shorter one;
+// otherwise the shorter rate could never be reached. Rates of each direction are
+// counted separately, so these rules apply within a direction.
+func validateTokenRateLimits(limits []maasv1alpha1.TokenRateLimit) error {
+	byDirection := ratesByDirection(limits)
+	for _, d := range tokenDirections {
+		sorted, err := sortedTokenRateLimits(byDirection[d])
+		if err != nil {
+			return err
+		}
+		for i := 1; i < len(sorted); i++ {
+			shorter, longer := sorted[i-1], sorted[i]
+			if shorter.seconds == longer.seconds {
+				return fmt.Errorf("%swindows %q and %q have the same duration", directionPrefix(d), shorter.Window, longer.Window)
+			}
+			if longer.Limit <= shorter.Limit {
+				return fmt.Errorf("%slimit %d/%s must be larger than the shorter-window limit %d/%s", directionPrefix(d), longer.Limit, longer.Window, shorter.Limit, shorter.Window)
+			}
+		}
 	}
+	return nil
+}
+
+type windowedRateLimit struct {
+	maasv1alpha1.TokenRateLimit
+	seconds int64
+}
+
+// sortedTokenRateLimits validates each rate and returns them ordered by window length.
+func sortedTokenRateLimits(limits []maasv1alpha1.TokenRateLimit) ([]windowedRateLimit, error) {
+	out := make([]windowedRateLimit, 0, len(limits))
+	for _, trl := range limits {
+		if err := validateTokenRateLimit(trl.Limit, trl.Window); err != nil {
+			return nil, err
+		}
+		seconds, _ := windowSeconds(trl.Window)
+		out = append(out, windowedRateLimit{TokenRateLimit: trl, seconds: seconds})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].seconds < out[j].seconds })
+	return out, nil
+}
 
+// ValidateModelRefRateLimits validates the token and request rate limits of a modelRef
+// against each other and against the subscription's reset schedule. The MaaSSubscription
+// webhook runs the same checks at admission.
+func ValidateModelRefRateLimits(ref maasv1alpha1.ModelSubscriptionRef, schedule *maasv1alpha1.ResetSchedule) error {
+	if err := validateTokenRateLimits(ref.TokenRateLimits); err != nil {
+		return fmt.Errorf("invalid tokenRateLimits: %w", err)
+	}
+	if err := validateAnchoredRates(ref.TokenRateLimits, schedule); err != nil {
+		return fmt.Errorf("invalid tokenRateLimits: %w", err)
+	}
+	if ref.CostBudget != nil {
+		if err := validateCostBudget(ref.CostBudget); err != nil {
+			return err
+		}
+		if err := validateCostBudgetWindow(ref, schedule); err != nil {
+			return fmt.Errorf("invalid costBudget: %w", err)
+		}
+	}
+	limits := make([]maasv1alpha1.TokenRateLimit, 0, len(ref.RequestRateLimits))
+	for _, rrl := range ref.RequestRateLimits {
+		limits = append(limits, maasv1alpha1.TokenRateLimit{Limit: rrl.Limit, Window: rrl.Window})
+	}
+	if err := validateTokenRateLimits(limits); err != nil {
+		return fmt.Errorf("invalid requestRateLimits: %w", err)
+	}
+	if err := validateAnchoredRates(limits, schedule); err != nil {
+		return fmt.Errorf("invalid requestRateLimits: %w", err)
+	}
 	return nil
 }
 
+// rateLimitRates renders validated rates as TRLP/RLP rates, shortest window first, so
+// the generated policy is stable regardless of the order in the subscription.
+func rateLimitRates(limits []maasv1alpha1.TokenRateLimit) []kuadrantv1.Rate {
+	sorted, _ := sortedTokenRateLimits(limits)
+	rates := make([]kuadrantv1.Rate, 0, len(sorted))
+	for _, trl := range sorted {
+		rates = append(rates, kuadrantv1.Rate{Limit: trl.Limit, Window: trl.Window})
+	}
+	return rates
+}
+
 // ConditionSpecPriorityDuplicate is set True when another MaaSSubscription in the same namespace shares the same spec.priority
 // (API key mint and selector use deterministic tie-break; admins should set distinct priorities).
 const ConditionSpecPriorityDuplicate = "SpecPriorityDuplicate"
@@ -159,7 +315,11 @@ func (r *MaaSSubscriptionReconciler) validateModelRefs(ctx context.Context, subs
 		}
 
 		model := &maasv1alpha1.MaaSModelRef{}
-		if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, model); err != nil {
+		if !r.WatchNamespaces.Contains(ref.Namespace) {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonNamespaceNotWatched
+			status.Message = notWatchedMessage("MaaSModelRef", ref.Namespace)
+		} else if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, model); err != nil {
 			if apierrors.IsNotFound(err) {
 				status.Ready = false
 				status.Reason = maasv1alpha1.ReasonNotFound
@@ -169,6 +329,30 @@ func (r *MaaSSubscriptionReconciler) validateModelRefs(ctx context.Context, subs
 				status.Reason = maasv1alpha1.ReasonGetFailed
 				status.Message = fmt.Sprintf("failed to get MaaSModelRef: %v", err)
 			}
+		} else if err := ValidateResetSchedule(subscription.Spec.ResetSchedule); err != nil {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonInvalidRateLimits
+			status.Message = err.Error()
+		} else if err := ValidateCounterExpressions(subscription.Spec.CounterExpressions); err != nil {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonInvalidRateLimits
+			status.Message = err.Error()
+		} else if err := validateSubscriptionOwners(subscription); err != nil {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonInvalidRateLimits
+			status.Message = err.Error()
+		} else if err := ValidateLimitGroups(subscription); err != nil {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonInvalidRateLimits
+			status.Message = err.Error()
+		} else if err := ValidateModelRefRateLimits(ref, subscription.Spec.ResetSchedule); err != nil {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonInvalidRateLimits
+			status.Message = err.Error()
+		} else if err := validateModelRefCostBudget(ref, model); err != nil {
+			status.Ready = false
+			status.Reason = maasv1alpha1.ReasonInvalidRateLimits
+			status.Message = err.Error()
 		} else {
 			status.Ready = true
 			status.Reason = maasv1alpha1.ReasonValid
@@ -178,7 +362,11 @@ func (r *MaaSSubscriptionReconciler) validateModelRefs(ctx context.Context, subs
 	return statuses
 }
 
-// checkTokenRateLimitHealth checks the health of generated TokenRateLimitPolicies.
+// checkTokenRateLimitHealth checks the health of the policies generated for the
+// subscription's models. A model's TokenRateLimitPolicy is always reported, while its
+// other generated policies are reported when they exist. A policy is ready only when
+// Kuadrant reports it Accepted and Enforced: generated limits that are not enforced leave
+// usage unlimited.
 func (r *MaaSSubscriptionReconciler) checkTokenRateLimitHealth(ctx context.Context, subscription *maasv1alpha1.MaaSSubscription) []maasv1alpha1.TokenRateLimitStatus {
 	statuses := make([]maasv1alpha1.TokenRateLimitStatus, 0, len(subscription.Spec.ModelRefs))
 	seen := make(map[string]struct{})
@@ -190,17 +378,24 @@ func (r *MaaSSubscriptionReconciler) checkTokenRateLimitHealth(ctx context.Conte
 		}
 		seen[key] = struct{}{}
 
-		policyName := fmt.Sprintf("maas-trlp-%s", ref.Name)
+		policyName := tokenRateLimitPolicyName(ref.Namespace, ref.Name, "")
 		status := maasv1alpha1.TokenRateLimitStatus{
 			ResourceRefStatus: maasv1alpha1.ResourceRefStatus{
 				Name:      policyName,
 				Namespace: ref.Namespace,
 			},
+			Kind:  kuadrantv1alpha1.TokenRateLimitPolicyGVK.Kind,
 			Model: ref.Name,
 		}
+		if !r.WatchNamespaces.Contains(ref.Namespace) {
+			status.Reason = maasv1alpha1.ReasonNamespaceNotWatched
+			status.Message = notWatchedMessage("MaaSModelRef", ref.Namespace)
+			statuses = append(statuses, status)
+			continue
+		}
 
 		// Find the TRLP for this model (TRLP lives in HTTPRoute namespace)
-		_, httpRouteNS, err := findHTTPRouteForModel(ctx, r.Client, ref.Namespace, ref.Name)
+		httpRouteName, httpRouteNS, err := findHTTPRouteForModel(ctx, r.Client, ref.Namespace, ref.Name)
 		if err != nil {
 			// Record status even when HTTPRoute not found - makes diagnosing issues easier
 			status.Ready = false
@@ -215,9 +410,11 @@ func (r *MaaSSubscriptionReconciler) checkTokenRateLimitHealth(ctx context.Conte
 			continue
 		}
 		status.Namespace = httpRouteNS
+		policyName = r.modelTokenRateLimitPolicyName(ctx, httpRouteName, httpRouteNS, ref.Namespace, ref.Name)
+		status.Name = policyName
 
 		trlp := &unstructured.Unstructured{}
-		trlp.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+		trlp.SetGroupVersionKind(r.trlpGVK())
 
 		if err := r.Get(ctx, types.NamespacedName{Name: policyName, Namespace: httpRouteNS}, trlp); err != nil {
 			if apierrors.IsNotFound(err) {
@@ -230,55 +427,55 @@ func (r *MaaSSubscriptionReconciler) checkTokenRateLimitHealth(ctx context.Conte
 				status.Message = fmt.Sprintf("failed to get TokenRateLimitPolicy: %v", err)
 			}
 		} else {
-			// Check Accepted condition from TRLP status
-			accepted, message := getTRLPAcceptedCondition(trlp)
-			status.Ready = accepted
-			if accepted {
-				status.Reason = maasv1alpha1.ReasonAccepted
-			} else {
-				status.Reason = maasv1alpha1.ReasonNotAccepted
-				status.Message = message
-			}
+			status.Ready, status.Reason, status.Message = getPolicyReadyState(trlp)
+			policyEnforcement.observe(trlp)
 		}
 		statuses = append(statuses, status)
+		statuses = append(statuses, r.generatedPolicyStatuses(ctx, ref.Namespace, ref.Name, httpRouteNS, policyName)...)
 	}
 	return statuses
 }
 
-// getTRLPAcceptedCondition extracts the Accepted condition from a TokenRateLimitPolicy.
-func getTRLPAcceptedCondition(trlp *unstructured.Unstructured) (accepted bool, message string) {
-	status, found, err := unstructured.NestedMap(trlp.Object, "status")
-	if err != nil || !found {
-		return false, "status not available"
-	}
-
-	conditions, found, err := unstructured.NestedSlice(status, "conditions")
-	if err != nil || !found {
-		return false, "conditions not found"
-	}
-
-	for _, c := range conditions {
-		cond, ok := c.(map[string]any)
-		if !ok {
+// generatedPolicyStatuses reports the policies generated for the model other than its
+// TokenRateLimitPolicy, sorted by kind and name.
+func (r *MaaSSubscriptionReconciler) generatedPolicyStatuses(ctx context.Context, modelNamespace, modelName, namespace, trlpName string) []maasv1alpha1.TokenRateLimitStatus {
+	var statuses []maasv1alpha1.TokenRateLimitStatus
+	for _, gvk := range []schema.GroupVersionKind{r.trlpGVK(), kuadrantv1.RateLimitPolicyGVK} {
+		policies := &unstructured.UnstructuredList{}
+		policies.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := r.List(ctx, policies, client.InNamespace(namespace), client.MatchingLabels{
+			"maas.opendatahub.io/model":           modelName,
+			"maas.opendatahub.io/model-namespace": modelNamespace,
+			"app.kubernetes.io/managed-by":        "maas-controller",
+			"app.kubernetes.io/part-of":           "maas-subscription",
+		}); err != nil {
+			// The model's TokenRateLimitPolicy status already reports lookup failures.
 			continue
 		}
-		if cond["type"] == "Accepted" {
-			if cond["status"] == "True" {
-				return true, ""
+		sort.Slice(policies.Items, func(i, j int) bool { return policies.Items[i].GetName() < policies.Items[j].GetName() })
+		for i := range policies.Items {
+			p := &policies.Items[i]
+			if gvk == r.trlpGVK() && p.GetName() == trlpName {
+				continue
 			}
-			if msg, ok := cond["message"].(string); ok {
-				return false, msg
+			status := maasv1alpha1.TokenRateLimitStatus{
+				ResourceRefStatus: maasv1alpha1.ResourceRefStatus{Name: p.GetName(), Namespace: p.GetNamespace()},
+				Kind:              gvk.Kind,
+				Model:             modelName,
 			}
-			return false, "Accepted condition is False"
+			status.Ready, status.Reason, status.Message = getPolicyReadyState(p)
+			policyEnforcement.observe(p)
+			statuses = append(statuses, status)
 		}
 	}
-	return false, "Accepted condition not found"
+	return statuses
 }
 
 // deriveFinalPhase determines the subscription phase based on model and TRLP statuses.
-func deriveFinalPhase(modelStatuses []maasv1alpha1.ModelRefStatus, trlpStatuses []maasv1alpha1.TokenRateLimitStatus) (phase maasv1alpha1.Phase, message string) {
+// reason overrides the Ready condition reason the phase implies when it is set.
+func deriveFinalPhase(modelStatuses []maasv1alpha1.ModelRefStatus, trlpStatuses []maasv1alpha1.TokenRateLimitStatus) (phase maasv1alpha1.Phase, reason maasv1alpha1.ConditionReason, message string) {
 	if len(modelStatuses) == 0 {
-		return maasv1alpha1.PhaseFailed, "no model references specified"
+		return maasv1alpha1.PhaseFailed, "", "no model references specified"
 	}
 
 	// Build a set of models that validateModelRefs reported as valid
@@ -318,21 +515,21 @@ func deriveFinalPhase(modelStatuses []maasv1alpha1.ModelRefStatus, trlpStatuses
 
 	// All models invalid -> Failed
 	if effectiveValidModels <= 0 {
-		return maasv1alpha1.PhaseFailed, fmt.Sprintf("all %d model references are invalid or unavailable", len(modelStatuses))
+		return maasv1alpha1.PhaseFailed, "", fmt.Sprintf("all %d model references are invalid or unavailable", len(modelStatuses))
 	}
 
 	// Partial model failure -> Degraded
 	if effectiveInvalidModels > 0 {
-		return maasv1alpha1.PhaseDegraded, fmt.Sprintf("%d of %d model references are invalid or unavailable", effectiveInvalidModels, len(modelStatuses))
+		return maasv1alpha1.PhaseDegraded, "", fmt.Sprintf("%d of %d model references are invalid or unavailable", effectiveInvalidModels, len(modelStatuses))
 	}
 
-	// All models valid but some TRLPs unhealthy (not due to backend issues) -> Degraded
+	// All models valid but some policies not accepted or enforced (not due to backend issues) -> Degraded
 	trlpOnlyIssues := unhealthyTRLPs - modelsWithBackendIssues
 	if trlpOnlyIssues > 0 {
-		return maasv1alpha1.PhaseDegraded, fmt.Sprintf("%d of %d TokenRateLimitPolicies not accepted", trlpOnlyIssues, len(trlpStatuses))
+		return maasv1alpha1.PhaseDegraded, maasv1alpha1.ReasonPolicyNotEnforced, fmt.Sprintf("%d of %d rate limit policies not enforced", trlpOnlyIssues, len(trlpStatuses))
 	}
 
-	return maasv1alpha1.PhaseActive, "successfully reconciled"
+	return maasv1alpha1.PhaseActive, "", "successfully reconciled"
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop
@@ -382,10 +579,46 @@ func (r *MaaSSubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	statusSnapshot := subscription.Status.DeepCopy()
 
+	// A templateRef is materialized into the spec before anything reads the plan.
+	found, err := r.reconcileSubscriptionTemplate(ctx, log, subscription)
+	if err != nil {
+		log.Error(err, "failed to apply MaaSSubscriptionTemplate")
+		r.updateStatus(ctx, subscription, maasv1alpha1.PhaseFailed, err.Error(), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	if !found {
+		r.updateStatusWithReason(ctx, subscription, maasv1alpha1.PhaseFailed, maasv1alpha1.ReasonTemplateNotFound,
+			fmt.Sprintf("MaaSSubscriptionTemplate %s not found", subscription.Spec.TemplateRef.Name), statusSnapshot)
+		return ctrl.Result{}, nil
+	}
+
+	// From here on the subscription is only written through its status, so the models
+	// selected by wildcard modelRefs and spec.modelSelector can replace the wildcards in
+	// its modelRefs in memory.
+	selected, err := resolveSelectedModelRefs(ctx, r.Client, subscription)
+	if err != nil {
+		log.Error(err, "failed to resolve selected models")
+		r.updateStatus(ctx, subscription, maasv1alpha1.PhaseFailed, fmt.Sprintf("failed to resolve selected models: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+	subscription.Status.SelectedModelRefs = selected
+	expandModelRefs(subscription, selected)
+
 	// Validate model references and populate per-model status
 	modelStatuses := r.validateModelRefs(ctx, subscription)
 	subscription.Status.ModelRefStatuses = modelStatuses
 
+	setRateLimitsEnforcedCondition(&subscription.Status.Conditions, subscription.GetGeneration(), r.AuthProvider)
+	if authorinoAuthConfigs(r.AuthProvider) {
+		// The subscription still grants access to its models; nothing limits it.
+		subscription.Status.DryRunPreview = nil
+		subscription.Status.TokenRateLimitStatuses = nil
+		clearUsage(subscription)
+		phase, reason, message := deriveFinalPhase(modelStatuses, nil)
+		r.updateStatusWithReason(ctx, subscription, phase, reason, message, statusSnapshot)
+		return ctrl.Result{}, nil
+	}
+
 	// Check if we have any valid models to proceed with TRLP reconciliation
 	hasValidModels := false
 	for _, s := range modelStatuses {
@@ -414,8 +647,38 @@ func (r *MaaSSubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
-	// Check TRLP health and populate status
-	trlpStatuses := r.checkTokenRateLimitHealth(ctx, subscription)
+	// Limit groups span models, so they live on the tenant Gateway and are rebuilt from
+	// every subscription on it, whether or not this one still has valid models.
+	if err := r.reconcileLimitGroups(ctx, log, subscription); err != nil {
+		log.Error(err, "failed to reconcile limit groups")
+		r.updateStatus(ctx, subscription, maasv1alpha1.PhaseFailed, fmt.Sprintf("failed to reconcile limit groups: %v", err), statusSnapshot)
+		return ctrl.Result{}, err
+	}
+
+	// Detect rate limit policies not created by MaaS on the routes of the subscription's models
+	conflicts := r.reportConflictingRateLimitPolicies(ctx, log, subscription)
+
+	// In dry-run mode the subscription never contributes to an applied TRLP, so
+	// render what it would produce instead of checking TRLP health.
+	dryRun := isDryRun(subscription)
+	subscription.Status.DryRunPreview = nil
+	var trlpStatuses []maasv1alpha1.TokenRateLimitStatus
+	if dryRun {
+		previews, err := r.previewTokenRateLimitPolicies(ctx, log, subscription)
+		if err != nil {
+			log.Error(err, "failed to render dry-run TokenRateLimitPolicy preview")
+			r.updateStatus(ctx, subscription, maasv1alpha1.PhaseFailed, fmt.Sprintf("failed to render dry-run preview: %v", err), statusSnapshot)
+			return ctrl.Result{}, err
+		}
+		subscription.Status.DryRunPreview = previews
+	} else {
+		trlpStatuses = r.checkTokenRateLimitHealth(ctx, subscription)
+		if r.RefuseConflictingPolicies {
+			markRefusedTokenRateLimits(trlpStatuses, conflicts)
+		}
+	}
+	setDryRunCondition(&subscription.Status.Conditions, subscription.GetGeneration(), dryRun, len(subscription.Status.DryRunPreview))
+	setSuspendedCondition(&subscription.Status.Conditions, subscription.GetGeneration(), subscription.Spec.Suspended)
 	subscription.Status.TokenRateLimitStatuses = trlpStatuses
 
 	// Correct stale modelRefStatuses: validateModelRefs may have reported a model
@@ -442,10 +705,41 @@ func (r *MaaSSubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	subscription.Status.ModelRefStatuses = modelStatuses
 
 	// Derive final phase based on model and TRLP health
-	phase, message := deriveFinalPhase(modelStatuses, trlpStatuses)
-	r.updateStatus(ctx, subscription, phase, message, statusSnapshot)
+	phase, reason, message := deriveFinalPhase(modelStatuses, trlpStatuses)
+	if dryRun && phase != maasv1alpha1.PhaseFailed {
+		// Keep dry-run subscriptions out of Active/Degraded so maas-api never
+		// selects a subscription that has no enforced rate limits.
+		phase = maasv1alpha1.PhasePending
+		reason = ""
+		message = "dry-run: generated TokenRateLimitPolicies rendered to status.dryRunPreview and not applied"
+	}
+
+	var result ctrl.Result
+	if r.usageCollectionEnabled() && !dryRun {
+		r.collectUsage(ctx, subscription)
+		result.RequeueAfter = r.UsageCollectionInterval
+	} else {
+		clearUsage(subscription)
+	}
+
+	// Anchored counters reset on their own when the period id changes; requeue at the
+	// boundary only to advance status.nextResetTime.
+	subscription.Status.NextResetTime = nil
+	if schedule := subscription.Spec.ResetSchedule; schedule != nil && ValidateResetSchedule(schedule) == nil {
+		now := time.Now()
+		next := nextResetTime(schedule, now)
+		subscription.Status.NextResetTime = &metav1.Time{Time: next}
+		if untilReset := next.Sub(now); result.RequeueAfter == 0 || untilReset < result.RequeueAfter {
+			result.RequeueAfter = untilReset
+		}
+	}
+	r.updateStatusWithReason(ctx, subscription, phase, reason, message, statusSnapshot)
 
-	return ctrl.Result{}, nil
+	awaiting := false
+	for _, ts := range trlpStatuses {
+		awaiting = awaiting || awaitingEnforcement(ts.Ready, ts.Reason)
+	}
+	return minRequeue(result, requeueUntilEnforced(r.EnforcementRequeue, subscription.Status.Conditions, awaiting)), nil
 }
 
 func (r *MaaSSubscriptionReconciler) reconcileTokenRateLimitPolicies(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) error {
@@ -474,12 +768,17 @@ func (r *MaaSSubscriptionReconciler) reconcileTokenRateLimitPolicies(ctx context
 // reconcileTRLPForModel builds or updates the aggregated TokenRateLimitPolicy for a specific model.
 // It finds all active subscriptions for the model and creates a single TRLP covering all of them.
 func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context, log logr.Logger, modelNamespace, modelName string) error {
+	if !r.WatchNamespaces.Contains(modelNamespace) {
+		log.Info("model namespace is not watched, skipping TokenRateLimitPolicy", "model", modelNamespace+"/"+modelName)
+		return nil
+	}
 	// Find ALL subscriptions for this model (not just the current one)
 	allSubs, err := findAllSubscriptionsForModel(ctx, r.Client, modelNamespace, modelName)
 	if err != nil {
 		return fmt.Errorf("failed to list subscriptions for model %s/%s: %w", modelNamespace, modelName, err)
 	}
 	allSubs = filterSubscriptionsByTenantNamespace(ctx, r.Client, allSubs, r.DefaultTenantNamespace, r.TenantNamespaceDiscoveryEnabled)
+	allSubs = excludeDryRunSubscriptions(allSubs)
 
 	// Resolve HTTPRoute early to check if model/route exist
 	httpRouteName, httpRouteNS, err := findHTTPRouteForModel(ctx, r.Client, modelNamespace, modelName)
@@ -503,11 +802,38 @@ func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context,
 	if err := r.validateSubscriptionTenantGatewaysForRoute(ctx, allSubs, httpRouteName, httpRouteNS, modelNamespace, modelName); err != nil {
 		return err
 	}
+	// Policies are placed next to the HTTPRoute, which the controller can only write to
+	// in a watched namespace.
+	if !r.WatchNamespaces.Contains(httpRouteNS) {
+		log.Info("HTTPRoute namespace is not watched, skipping TokenRateLimitPolicy", "httpRoute", httpRouteNS+"/"+httpRouteName, "model", modelNamespace+"/"+modelName)
+		return nil
+	}
 
-	// Check if existing TRLP is opted-out before doing any expensive work
-	policyName := fmt.Sprintf("maas-trlp-%s", modelName)
+	// Check if existing TRLP is opted-out before doing any expensive work. A policy still
+	// under its legacy name stays authoritative while it is opted out.
+	policyName := tokenRateLimitPolicyName(modelNamespace, modelName, "")
+	legacy, err := r.getLegacyPolicy(ctx, r.trlpGVK(),
+		types.NamespacedName{Name: legacyPolicyName(tokenRateLimitPolicyPrefix, modelName, ""), Namespace: httpRouteNS}, modelNamespace, modelName)
+	if err != nil {
+		return err
+	}
+	if legacy != nil && !isManaged(legacy) {
+		log.Info("TokenRateLimitPolicy opted out, skipping reconciliation", "name", legacy.GetName(), "namespace", httpRouteNS, "model", modelNamespace+"/"+modelName)
+		return nil
+	}
+	// A pre-existing policy annotated for adoption becomes the model's policy. Adoption
+	// waits for a subscription so that the policy is not deleted right away.
+	if len(allSubs) > 0 {
+		adopted, err := r.adoptedTokenRateLimitPolicy(ctx, log, httpRouteName, httpRouteNS, modelNamespace, modelName)
+		if err != nil {
+			return err
+		}
+		if adopted != nil {
+			policyName = adopted.GetName()
+		}
+	}
 	existingCheck := &unstructured.Unstructured{}
-	existingCheck.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	existingCheck.SetGroupVersionKind(r.trlpGVK())
 	existingCheck.SetName(policyName)
 	existingCheck.SetNamespace(httpRouteNS)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(existingCheck), existingCheck); err == nil {
@@ -528,105 +854,48 @@ func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context,
 		return nil
 	}
 
+	// Layering generated policies over user policies leaves precedence undefined, so in
+	// refuse mode the model is not enforced until the user policies are removed.
+	if r.RefuseConflictingPolicies {
+		conflicts, err := r.findConflictingRateLimitPolicies(ctx, log, httpRouteName, httpRouteNS, modelName, modelNamespace)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			log.Info("conflicting rate limit policies target HTTPRoute, removing generated policies",
+				"httpRoute", httpRouteNS+"/"+httpRouteName, "model", modelNamespace+"/"+modelName, "conflicts", len(conflicts))
+			return r.deleteModelTRLP(ctx, log, modelNamespace, modelName)
+		}
+	}
+
 	// Fetch the HTTPRoute to set as owner for garbage collection
 	route := &gatewayapiv1.HTTPRoute{}
 	if err := r.Get(ctx, types.NamespacedName{Name: httpRouteName, Namespace: httpRouteNS}, route); err != nil {
 		return fmt.Errorf("failed to fetch HTTPRoute %s/%s: %w", httpRouteNS, httpRouteName, err)
 	}
-
-	limitsMap := map[string]any{}
-	var subNames []string
-
-	type subInfo struct {
-		sub   maasv1alpha1.MaaSSubscription
-		mRef  maasv1alpha1.ModelSubscriptionRef
-		rates []any
+	globalLimits, err := modelGlobalTokenRateLimits(ctx, r.Client, modelNamespace, modelName)
+	if err != nil {
+		return err
 	}
-	var subs []subInfo
-	for _, sub := range allSubs {
-		for _, mRef := range sub.Spec.ModelRefs {
-			if mRef.Namespace != modelNamespace || mRef.Name != modelName {
-				continue
-			}
-			var rates []any
-			var hasInvalidLimits bool
-			if len(mRef.TokenRateLimits) > 0 {
-				for _, trl := range mRef.TokenRateLimits {
-					if err := validateTokenRateLimit(trl.Limit, trl.Window); err != nil {
-						log.Error(err, "Skipping subscription with invalid token rate limit — fix the spec to include it in TRLP",
-							"subscription", sub.Name, "model", modelNamespace+"/"+modelName,
-							"limit", trl.Limit, "window", trl.Window)
-						hasInvalidLimits = true
-						break
-					}
-					rates = append(rates, map[string]any{"limit": trl.Limit, "window": trl.Window})
-				}
-			} else {
-				rates = append(rates, map[string]any{"limit": int64(100), "window": "1m"})
-			}
-			if hasInvalidLimits {
-				// Skip this subscription to prevent poisoning the aggregated TRLP.
-				// The subscription is already marked Degraded/Failed by validateModelRefs(),
-				// and maas-api's subscription selector rejects non-Active subscriptions,
-				// so the invalid subscription cannot be used for API key minting.
-				continue
-			}
-			subs = append(subs, subInfo{sub: sub, mRef: mRef, rates: rates})
-			break
-		}
+	price, err := getModelPricing(ctx, r.Client, modelNamespace, modelName)
+	if err != nil {
+		return err
 	}
 
+	spec, subNames := buildTRLPSpec(log, allSubs, modelNamespace, modelName, globalLimits, price, httpRouteName)
+
 	// If all subscriptions were skipped due to invalid limits, treat as no effective
 	// subscriptions — delete the TRLP instead of writing one with empty limits.
-	if len(subs) == 0 && len(allSubs) > 0 {
+	if spec == nil {
 		log.Info("All subscriptions for model have invalid rate limits — deleting TRLP",
 			"model", modelNamespace+"/"+modelName, "invalidCount", len(allSubs))
 		return r.deleteModelTRLP(ctx, log, modelNamespace, modelName)
 	}
 
-	// Trust auth.identity.selected_subscription_key from AuthPolicy.
-	// AuthPolicy has already validated subscription selection via /v1/subscriptions/select,
-	// which handles:
-	//  - Validating subscription exists and user has access (groups/users match)
-	//  - Auto-selecting if user has exactly one subscription
-	//  - Returning 403 Forbidden for invalid scenarios (wrong header, no access, multiple without header)
-	// TokenRateLimitPolicy simply applies the rate limit for the validated subscription.
-	//
-	// The selected_subscription_key format is: {subNamespace}/{subName}@{modelNamespace}/{modelName}
-	// This ensures proper isolation between subscriptions in different namespaces and across models.
-	for _, si := range subs {
-		subNames = append(subNames, qualifiedName(si.sub.Namespace, si.sub.Name))
-
-		// Build subscription reference: namespace/name
-		subRef := fmt.Sprintf("%s/%s", si.sub.Namespace, si.sub.Name)
-		// Build model-scoped reference: subscription@model
-		modelScopedRef := fmt.Sprintf("%s@%s/%s", subRef, si.mRef.Namespace, si.mRef.Name)
-
-		// TRLP limit key must be safe for YAML (no slashes)
-		safeKey := strings.ReplaceAll(subRef, "/", "-")
-		limitsMap[fmt.Sprintf("%s-%s-tokens", safeKey, si.mRef.Name)] = map[string]any{
-			"rates": si.rates,
-			"when": []any{
-				map[string]any{
-					// Exempt /v1/models endpoint from token rate limiting.
-					// This endpoint is used for model discovery/metadata and does not consume inference tokens.
-					// Users should be able to query model capabilities even when their token quota is exhausted.
-					"predicate": fmt.Sprintf(`auth.identity.selected_subscription_key == "%s" && !request.path.endsWith("/v1/models")`, modelScopedRef),
-				},
-			},
-			"counters": []any{
-				map[string]any{"expression": "auth.identity.userid"},
-			},
-		}
-	}
-
-	// Sort subscription names for stable annotation value across reconciles
-	sort.Strings(subNames)
-
 	// Build the aggregated TokenRateLimitPolicy (one per model, covering all subscriptions)
 	// policyName already declared during early opt-out check
 	policy := &unstructured.Unstructured{}
-	policy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	policy.SetGroupVersionKind(r.trlpGVK())
 	policy.SetName(policyName)
 	policy.SetNamespace(httpRouteNS)
 	policy.SetLabels(map[string]string{
@@ -645,17 +914,20 @@ func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context,
 		return fmt.Errorf("failed to set owner reference on TokenRateLimitPolicy %s/%s: %w", policy.GetNamespace(), policy.GetName(), err)
 	}
 
-	spec := map[string]any{
-		"targetRef": map[string]any{
-			"group": "gateway.networking.k8s.io",
-			"kind":  "HTTPRoute",
-			"name":  httpRouteName,
-		},
-		"limits": limitsMap,
+	specMap, err := kuadrantv1.ToUnstructured(spec)
+	if err != nil {
+		return err
 	}
-	if err := unstructured.SetNestedMap(policy.Object, spec, "spec"); err != nil {
+	if err := unstructured.SetNestedMap(policy.Object, specMap, "spec"); err != nil {
 		return fmt.Errorf("failed to set spec: %w", err)
 	}
+	specHash, err := generatedSpecHash(specMap)
+	if err != nil {
+		return err
+	}
+	policyAnnotations := policy.GetAnnotations()
+	policyAnnotations[generatedSpecHashAnnotation] = specHash
+	policy.SetAnnotations(policyAnnotations)
 
 	// Create or update TokenRateLimitPolicy
 	existing := &unstructured.Unstructured{}
@@ -678,6 +950,9 @@ func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context,
 			if err := controllerutil.SetControllerReference(route, existing, r.Scheme); err != nil {
 				return fmt.Errorf("failed to set owner reference on existing TokenRateLimitPolicy %s/%s: %w", existing.GetNamespace(), existing.GetName(), err)
 			}
+			if generatedPolicyDrifted(existing, specHash) {
+				recordGeneratedPolicyDrift(log, r.Recorder, existing)
+			}
 			// Snapshot the existing object before modifications so we can detect
 			// no-op updates.
 			snapshot := existing.DeepCopy()
@@ -699,7 +974,7 @@ func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context,
 				mergedLabels[k] = v
 			}
 			existing.SetLabels(mergedLabels)
-			if err := unstructured.SetNestedMap(existing.Object, spec, "spec"); err != nil {
+			if err := unstructured.SetNestedMap(existing.Object, specMap, "spec"); err != nil {
 				return fmt.Errorf("failed to update spec: %w", err)
 			}
 
@@ -713,7 +988,238 @@ func (r *MaaSSubscriptionReconciler) reconcileTRLPForModel(ctx context.Context,
 			}
 		}
 	}
-	return nil
+	if err := r.deleteLegacyPolicy(ctx, log, legacy); err != nil {
+		return err
+	}
+	if err := r.reconcileRLP(ctx, log, modelNamespace, modelName, "", route, allSubs); err != nil {
+		return err
+	}
+	if err := r.reconcileConcurrencyRoutes(ctx, log, modelNamespace, modelName, route, allSubs); err != nil {
+		return err
+	}
+	if err := r.reconcileMirroredTRLP(ctx, log, modelNamespace, modelName, httpRouteNS, failoverRouteName(modelName), failoverRouteComponent, allSubs); err != nil {
+		return err
+	}
+	return r.reconcileMirroredTRLP(ctx, log, modelNamespace, modelName, httpRouteNS, routingRouteName(modelName), routingRouteComponent, allSubs)
+}
+
+// previewTokenRateLimitPolicies renders the aggregated TokenRateLimitPolicy each
+// referenced model would get if this dry-run subscription were applied alongside the
+// subscriptions that are currently enforced. Models without a resolvable HTTPRoute
+// are omitted; their problem is already reported in status.modelRefStatuses.
+func (r *MaaSSubscriptionReconciler) previewTokenRateLimitPolicies(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) ([]maasv1alpha1.GeneratedResourcePreview, error) {
+	var previews []maasv1alpha1.GeneratedResourcePreview
+	seen := make(map[string]struct{}, len(subscription.Spec.ModelRefs))
+	for _, modelRef := range subscription.Spec.ModelRefs {
+		k := modelRef.Namespace + "/" + modelRef.Name
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+
+		httpRouteName, httpRouteNS, err := findHTTPRouteForModel(ctx, r.Client, modelRef.Namespace, modelRef.Name)
+		if err != nil {
+			if errors.Is(err, ErrModelNotFound) || errors.Is(err, ErrHTTPRouteNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to resolve HTTPRoute for model %s: %w", k, err)
+		}
+
+		allSubs, err := findAllSubscriptionsForModel(ctx, r.Client, modelRef.Namespace, modelRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions for model %s: %w", k, err)
+		}
+		allSubs = filterSubscriptionsByTenantNamespace(ctx, r.Client, allSubs, r.DefaultTenantNamespace, r.TenantNamespaceDiscoveryEnabled)
+		allSubs = append(excludeDryRunSubscriptions(allSubs), *subscription)
+		globalLimits, err := modelGlobalTokenRateLimits(ctx, r.Client, modelRef.Namespace, modelRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		price, err := getModelPricing(ctx, r.Client, modelRef.Namespace, modelRef.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		spec, _ := buildTRLPSpec(log, allSubs, modelRef.Namespace, modelRef.Name, globalLimits, price, httpRouteName)
+		if spec == nil {
+			continue
+		}
+		preview, err := renderPreview("TokenRateLimitPolicy", r.modelTokenRateLimitPolicyName(ctx, httpRouteName, httpRouteNS, modelRef.Namespace, modelRef.Name), httpRouteNS, k, spec)
+		if err != nil {
+			return nil, err
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// buildTRLPSpec builds the aggregated TokenRateLimitPolicy spec for a model from the
+// given subscriptions and returns it with the sorted, namespace-qualified names of the
+// contributing subscriptions. Subscriptions with invalid token rate limits are skipped;
+// a nil spec means no subscription contributed a limit. globalLimits, the model's
+// spec.globalTokenRateLimits, become one extra limit without per-user counters.
+func buildTRLPSpec(log logr.Logger, allSubs []maasv1alpha1.MaaSSubscription, modelNamespace, modelName string, globalLimits []maasv1alpha1.TokenRateLimit, price *modelPrice, httpRouteName string) (*kuadrantv1alpha1.TokenRateLimitPolicySpec, []string) {
+	limitsMap := map[string]kuadrantv1.Limit{}
+	var subNames []string
+
+	type subInfo struct {
+		sub    maasv1alpha1.MaaSSubscription
+		mRef   maasv1alpha1.ModelSubscriptionRef
+		limits []maasv1alpha1.TokenRateLimit
+		owners []ownerLimits
+	}
+	var subs []subInfo
+	for _, sub := range allSubs {
+		if err := ValidateResetSchedule(sub.Spec.ResetSchedule); err != nil && !sub.Spec.Suspended {
+			log.Error(err, "Skipping subscription with invalid reset schedule — fix the spec to include it in TRLP",
+				"subscription", sub.Name, "model", modelNamespace+"/"+modelName)
+			continue
+		}
+		if err := ValidateCounterExpressions(sub.Spec.CounterExpressions); err != nil && !sub.Spec.Suspended {
+			log.Error(err, "Skipping subscription with invalid counter expressions — fix the spec to include it in TRLP",
+				"subscription", sub.Name, "model", modelNamespace+"/"+modelName)
+			continue
+		}
+		for _, mRef := range sub.Spec.ModelRefs {
+			if mRef.Namespace != modelNamespace || mRef.Name != modelName {
+				continue
+			}
+			if sub.Spec.Suspended {
+				// Every request is denied, so the subscription's own rates do not matter.
+				subs = append(subs, subInfo{sub: sub, mRef: mRef})
+				break
+			}
+			limits := mRef.TokenRateLimits
+			if len(limits) > 0 {
+				if err := ValidateModelRefRateLimits(mRef, sub.Spec.ResetSchedule); err != nil {
+					log.Error(err, "Skipping subscription with invalid token rate limits — fix the spec to include it in TRLP",
+						"subscription", sub.Name, "model", modelNamespace+"/"+modelName)
+					// Skip this subscription to prevent poisoning the aggregated TRLP.
+					// The subscription is already marked Degraded/Failed by validateModelRefs(),
+					// and maas-api's subscription selector rejects non-Active subscriptions,
+					// so the invalid subscription cannot be used for API key minting.
+					continue
+				}
+			} else {
+				// Subscriptions created before tokenRateLimits was required.
+				limits = []maasv1alpha1.TokenRateLimit{{Limit: 100, Window: "1m"}}
+			}
+			limits, err := withCostBudget(limits, mRef.CostBudget, price)
+			if err != nil {
+				// Reported on the subscription by validateModelRefs.
+				log.Error(err, "Skipping subscription whose cost budget cannot be converted to tokens — fix the spec or the model price to include it in TRLP",
+					"subscription", sub.Name, "model", modelNamespace+"/"+modelName)
+				continue
+			}
+			owners, err := subscriptionOwnerLimits(&sub, mRef, price)
+			if err != nil {
+				log.Error(err, "Skipping subscription with invalid owners — fix the spec to include it in TRLP",
+					"subscription", sub.Name, "model", modelNamespace+"/"+modelName)
+				continue
+			}
+			subs = append(subs, subInfo{sub: sub, mRef: mRef, limits: limits, owners: owners})
+			break
+		}
+	}
+
+	if len(subs) == 0 {
+		return nil, nil
+	}
+
+	// Trust auth.identity.selected_subscription_key from AuthPolicy.
+	// AuthPolicy has already validated subscription selection via /v1/subscriptions/select,
+	// which handles:
+	//  - Validating subscription exists and user has access (groups/users match)
+	//  - Auto-selecting if user has exactly one subscription
+	//  - Returning 403 Forbidden for invalid scenarios (wrong header, no access, multiple without header)
+	// TokenRateLimitPolicy simply applies the rate limit for the validated subscription.
+	//
+	// The selected_subscription_key format is: {subNamespace}/{subName}@{modelNamespace}/{modelName}
+	// This ensures proper isolation between subscriptions in different namespaces and across models.
+	for i := range subs {
+		si := &subs[i]
+		subNames = append(subNames, qualifiedName(si.sub.Namespace, si.sub.Name))
+
+		// Build subscription reference: namespace/name
+		subRef := fmt.Sprintf("%s/%s", si.sub.Namespace, si.sub.Name)
+		// Build model-scoped reference: subscription@model
+		modelScopedRef := fmt.Sprintf("%s@%s/%s", subRef, si.mRef.Namespace, si.mRef.Name)
+
+		// Exempt /v1/models endpoint from token rate limiting.
+		// This endpoint is used for model discovery/metadata and does not consume inference tokens.
+		// Users should be able to query model capabilities even when their token quota is exhausted.
+		predicate := fmt.Sprintf(`auth.identity.selected_subscription_key == "%s" && !request.path.endsWith("/v1/models")`, modelScopedRef)
+		key := subscriptionLimitKey(si.sub.Namespace, si.sub.Name, si.mRef.Name, "tokens")
+		if si.sub.Spec.Suspended {
+			limitsMap[key] = suspendedLimit(predicate)
+			continue
+		}
+		addOwnerLimits(limitsMap, predicate, &si.sub, si.mRef.Name, si.limits, si.owners)
+	}
+
+	// The global cap has no counters, so Limitador keeps a single counter per model
+	// that every subscriber's requests are charged against.
+	if err := validateTokenRateLimits(globalLimits); err != nil {
+		log.Error(err, "Skipping invalid global token rate limits", "model", modelNamespace+"/"+modelName)
+	} else {
+		for d, rates := range ratesByDirection(globalLimits) {
+			limitsMap[directionLimitKey(globalTokenLimitKey(modelName), d)] = kuadrantv1.Limit{
+				Rates: rateLimitRates(rates),
+				When:  []kuadrantv1.Predicate{{Predicate: `!request.path.endsWith("/v1/models")`}},
+			}
+		}
+	}
+
+	// Sort subscription names for stable annotation value across reconciles
+	sort.Strings(subNames)
+
+	spec := &kuadrantv1alpha1.TokenRateLimitPolicySpec{
+		TargetRef: kuadrantv1.TargetRef{
+			Group: "gateway.networking.k8s.io",
+			Kind:  "HTTPRoute",
+			Name:  httpRouteName,
+		},
+		Limits: limitsMap,
+	}
+	return spec, subNames
+}
+
+// trlpCounters returns the TRLP counters for a subscription's counter scope. The
+// limit's predicate already selects one subscription, so the Subscription scope needs
+// no counter at all.
+func trlpCounters(scope maasv1alpha1.CounterScope) []kuadrantv1.Counter {
+	switch scope {
+	case maasv1alpha1.CounterScopeSubscription:
+		return nil
+	case maasv1alpha1.CounterScopeGroup:
+		return []kuadrantv1.Counter{{Expression: "auth.identity.groups_str"}}
+	default:
+		return []kuadrantv1.Counter{{Expression: "auth.identity.userid"}}
+	}
+}
+
+// subscriptionLimitKey returns the TRLP/RLP limit key of a subscription for a model,
+// e.g. "<namespace>-<subscription>-<model>-tokens". Keys must be safe for YAML (no slashes).
+func subscriptionLimitKey(subNamespace, subName, modelName, kind string) string {
+	return fmt.Sprintf("%s-%s-%s-%s", subNamespace, subName, modelName, kind)
+}
+
+// globalTokenLimitKey returns the TRLP limit key of a model's global token cap.
+func globalTokenLimitKey(modelName string) string {
+	return modelName + "-global-tokens"
+}
+
+// modelGlobalTokenRateLimits returns spec.globalTokenRateLimits of the MaaSModelRef,
+// or nil when the model does not exist.
+func modelGlobalTokenRateLimits(ctx context.Context, c client.Reader, modelNamespace, modelName string) ([]maasv1alpha1.TokenRateLimit, error) {
+	model := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, types.NamespacedName{Name: modelName, Namespace: modelNamespace}, model); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get MaaSModelRef %s/%s: %w", modelNamespace, modelName, err)
+	}
+	return model.Spec.GlobalTokenRateLimits, nil
 }
 
 func (r *MaaSSubscriptionReconciler) validateSubscriptionTenantGatewaysForRoute(
@@ -764,26 +1270,45 @@ func (r *MaaSSubscriptionReconciler) validateSubscriptionTenantGatewaysForRoute(
 
 // cleanupStaleTRLPs deletes aggregated TokenRateLimitPolicies for models that this
 // subscription previously contributed to but no longer references in spec.modelRefs.
+func (r *MaaSSubscriptionReconciler) cleanupStaleTRLPs(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) error {
+	stale, err := r.staleTRLPModels(ctx, subscription)
+	if err != nil {
+		return err
+	}
+	for _, ref := range stale {
+		modelKey := ref.Namespace + "/" + ref.Name
+		log.Info("Cleaning up stale TokenRateLimitPolicy for removed modelRef", "model", modelKey)
+		if err := r.deleteModelTRLP(ctx, log, ref.Namespace, ref.Name); err != nil {
+			return fmt.Errorf("failed to clean up stale TokenRateLimitPolicy for removed model %s: %w", modelKey, err)
+		}
+	}
+	return nil
+}
+
+// staleTRLPModels returns the models whose aggregated TokenRateLimitPolicies this
+// subscription contributed to but that it no longer references in spec.modelRefs.
 // Generated TRLPs track contributing subscriptions in the
 // "maas.opendatahub.io/subscriptions" annotation.
-func (r *MaaSSubscriptionReconciler) cleanupStaleTRLPs(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) error {
+func (r *MaaSSubscriptionReconciler) staleTRLPModels(ctx context.Context, subscription *maasv1alpha1.MaaSSubscription) ([]maasv1alpha1.ModelRef, error) {
 	currentModels := make(map[string]bool, len(subscription.Spec.ModelRefs))
 	for _, ref := range subscription.Spec.ModelRefs {
 		currentModels[ref.Namespace+"/"+ref.Name] = true
 	}
 
 	allManaged := &unstructured.UnstructuredList{}
-	allManaged.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicyList"})
+	allManaged.SetGroupVersionKind(r.trlpGVK().GroupVersion().WithKind("TokenRateLimitPolicyList"))
 	if err := r.List(ctx, allManaged, client.MatchingLabels{
 		"app.kubernetes.io/managed-by": "maas-controller",
 		"app.kubernetes.io/part-of":    "maas-subscription",
 	}); err != nil {
 		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("failed to list managed TokenRateLimitPolicies for stale cleanup: %w", err)
+		return nil, fmt.Errorf("failed to list managed TokenRateLimitPolicies for stale cleanup: %w", err)
 	}
 
+	var stale []maasv1alpha1.ModelRef
+	seen := map[string]bool{}
 	for i := range allManaged.Items {
 		trlp := &allManaged.Items[i]
 		modelName := trlp.GetLabels()["maas.opendatahub.io/model"]
@@ -795,7 +1320,7 @@ func (r *MaaSSubscriptionReconciler) cleanupStaleTRLPs(ctx context.Context, log
 			modelNamespace = trlp.GetNamespace()
 		}
 		modelKey := modelNamespace + "/" + modelName
-		if currentModels[modelKey] {
+		if currentModels[modelKey] || seen[modelKey] {
 			continue
 		}
 		owners := trlp.GetAnnotations()["maas.opendatahub.io/subscriptions"]
@@ -803,15 +1328,13 @@ func (r *MaaSSubscriptionReconciler) cleanupStaleTRLPs(ctx context.Context, log
 			!annotationListContains(owners, subscription.Name) {
 			continue
 		}
-		log.Info("Cleaning up stale TokenRateLimitPolicy for removed modelRef", "model", modelKey, "trlp", trlp.GetName())
-		if err := r.deleteModelTRLP(ctx, log, modelNamespace, modelName); err != nil {
-			return fmt.Errorf("failed to clean up stale TokenRateLimitPolicy for removed model %s: %w", modelKey, err)
-		}
+		seen[modelKey] = true
+		stale = append(stale, maasv1alpha1.ModelRef{Name: modelName, Namespace: modelNamespace})
 	}
-	return nil
+	return stale, nil
 }
 
-// deleteModelTRLP deletes the aggregated TokenRateLimitPolicy for a model in the given namespace.
+// deleteModelTRLP deletes the aggregated TokenRateLimitPolicy and RateLimitPolicy for a model in the given namespace.
 func (r *MaaSSubscriptionReconciler) deleteModelTRLP(ctx context.Context, log logr.Logger, modelNamespace, modelName string) error {
 	// Always delete the aggregated TokenRateLimitPolicy so remaining MaaSSubscriptions rebuild it
 	// without the rate limits from the deleted subscription. If we skip deletion, the aggregated
@@ -820,7 +1343,7 @@ func (r *MaaSSubscriptionReconciler) deleteModelTRLP(ctx context.Context, log lo
 	// Search across all namespaces using model labels since TRLP is created in HTTPRoute namespace
 	// (not model namespace). This allows cleanup even when HTTPRoute is already deleted.
 	policyList := &unstructured.UnstructuredList{}
-	policyList.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicyList"})
+	policyList.SetGroupVersionKind(r.trlpGVK().GroupVersion().WithKind("TokenRateLimitPolicyList"))
 	labelSelector := client.MatchingLabels{
 		"maas.opendatahub.io/model":           modelName,
 		"maas.opendatahub.io/model-namespace": modelNamespace,
@@ -833,6 +1356,8 @@ func (r *MaaSSubscriptionReconciler) deleteModelTRLP(ctx context.Context, log lo
 		}
 		return fmt.Errorf("failed to list TokenRateLimitPolicy for cleanup: %w", err)
 	}
+	// Keep going past a failed Delete so one stuck policy does not orphan the others.
+	var errs []error
 	for i := range policyList.Items {
 		p := &policyList.Items[i]
 		if !isManaged(p) {
@@ -841,33 +1366,69 @@ func (r *MaaSSubscriptionReconciler) deleteModelTRLP(ctx context.Context, log lo
 		}
 		log.Info("Deleting TokenRateLimitPolicy (no remaining parent subscriptions)", "name", p.GetName(), "namespace", p.GetNamespace(), "model", modelNamespace+"/"+modelName)
 		if err := r.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
-			return fmt.Errorf("failed to delete TokenRateLimitPolicy %s/%s: %w", p.GetNamespace(), p.GetName(), err)
+			errs = append(errs, fmt.Errorf("failed to delete TokenRateLimitPolicy %s/%s: %w", p.GetNamespace(), p.GetName(), err))
 		}
 	}
-	return nil
+	// The RateLimitPolicy for request rate limits is rebuilt together with the TRLP, and
+	// the concurrency routes must not outlive the policies that limit them.
+	if err := r.deleteModelRLPs(ctx, log, modelNamespace, modelName); err != nil {
+		errs = append(errs, err)
+	}
+	if err := r.pruneConcurrencyRoutes(ctx, log, modelNamespace, modelName, nil); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
 }
 
 func (r *MaaSSubscriptionReconciler) handleDeletion(ctx context.Context, log logr.Logger, subscription *maasv1alpha1.MaaSSubscription) (ctrl.Result, error) {
+	if authorinoAuthConfigs(r.AuthProvider) && controllerutil.ContainsFinalizer(subscription, maasSubscriptionFinalizer) {
+		// No rate limit policies were generated, so there is nothing to clean up.
+		controllerutil.RemoveFinalizer(subscription, maasSubscriptionFinalizer)
+		return ctrl.Result{}, r.Update(ctx, subscription)
+	}
 	if controllerutil.ContainsFinalizer(subscription, maasSubscriptionFinalizer) {
+		statusSnapshot := subscription.Status.DeepCopy()
+		cleanup := newFinalizerCleanup(log, subscription.Status.Conditions, subscription.Status.CleanupFailures)
+
 		// For each model referenced by this subscription, rebuild the aggregated TokenRateLimitPolicy
 		// without the deleted subscription's limits. If no other subscriptions reference the model,
 		// the TRLP will be deleted. This ensures zero-downtime rate limiting during subscription removal.
-		seen := make(map[string]struct{}, len(subscription.Spec.ModelRefs))
-		for _, modelRef := range subscription.Spec.ModelRefs {
-			k := modelRef.Namespace + "/" + modelRef.Name
-			if _, ok := seen[k]; ok {
-				continue
-			}
-			seen[k] = struct{}{}
-			log.Info("Rebuilding TokenRateLimitPolicy without deleted subscription", "model", modelRef.Namespace+"/"+modelRef.Name, "subscription", subscription.Name)
-			if err := r.reconcileTRLPForModel(ctx, log, modelRef.Namespace, modelRef.Name); err != nil {
-				log.Error(err, "failed to reconcile TokenRateLimitPolicy during deletion, will retry", "model", modelRef.Namespace+"/"+modelRef.Name)
-				return ctrl.Result{}, err
-			}
+		// Models last selected by wildcard modelRefs or spec.modelSelector are rebuilt too;
+		// the spec itself is written back below, so they are not added to it.
+		var cleanups []modelCleanup
+		for _, modelRef := range namedModelRefs(subscription) {
+			cleanups = append(cleanups, modelCleanup{model: modelRef.Namespace + "/" + modelRef.Name, run: func() error {
+				log.Info("Rebuilding TokenRateLimitPolicy without deleted subscription", "model", modelRef.Namespace+"/"+modelRef.Name, "subscription", subscription.Name)
+				return r.reconcileTRLPForModel(ctx, log, modelRef.Namespace, modelRef.Name)
+			}})
 		}
 		// Also clean up stale TRLPs from modelRefs that were removed
 		// before the CR was deleted (edge case: edit + delete before reconcile).
-		if err := r.cleanupStaleTRLPs(ctx, log, subscription); err != nil {
+		stale, err := r.staleTRLPModels(ctx, subscription)
+		if err != nil {
+			cleanup.fail(err)
+		}
+		for _, modelRef := range stale {
+			cleanups = append(cleanups, modelCleanup{model: modelRef.Namespace + "/" + modelRef.Name, run: func() error {
+				log.Info("Cleaning up stale TokenRateLimitPolicy for removed modelRef", "model", modelRef.Namespace+"/"+modelRef.Name)
+				return r.deleteModelTRLP(ctx, log, modelRef.Namespace, modelRef.Name)
+			}})
+		}
+		cleanup.runModels(cleanups)
+		// The Gateway-level limit groups are not tied to a model, so they are rebuilt on
+		// every attempt.
+		if err := r.reconcileLimitGroups(ctx, log, subscription); err != nil {
+			cleanup.fail(err)
+		}
+
+		failures, err := cleanup.result()
+		if err != nil {
+			message := cleanupFailuresMessage(failures)
+			subscription.Status.CleanupFailures = failures
+			r.updateStatusWithReason(ctx, subscription, maasv1alpha1.PhaseFailed, maasv1alpha1.ReasonCleanupFailed, message, statusSnapshot)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(subscription, corev1.EventTypeWarning, string(maasv1alpha1.ReasonCleanupFailed), "%s", message)
+			}
 			return ctrl.Result{}, err
 		}
 		controllerutil.RemoveFinalizer(subscription, maasSubscriptionFinalizer)
@@ -880,6 +1441,12 @@ func (r *MaaSSubscriptionReconciler) handleDeletion(ctx context.Context, log log
 }
 
 func (r *MaaSSubscriptionReconciler) updateStatus(ctx context.Context, subscription *maasv1alpha1.MaaSSubscription, phase maasv1alpha1.Phase, message string, statusSnapshot *maasv1alpha1.MaaSSubscriptionStatus) {
+	r.updateStatusWithReason(ctx, subscription, phase, "", message, statusSnapshot)
+}
+
+// updateStatusWithReason is updateStatus with a Ready condition reason that replaces the
+// one the phase implies, unless it is empty.
+func (r *MaaSSubscriptionReconciler) updateStatusWithReason(ctx context.Context, subscription *maasv1alpha1.MaaSSubscription, phase maasv1alpha1.Phase, readyReason maasv1alpha1.ConditionReason, message string, statusSnapshot *maasv1alpha1.MaaSSubscriptionStatus) {
 	// Status-only updates do not bump metadata.generation, so this reconcile may not re-queue.
 	// Merge SpecPriorityDuplicate from the API server so we do not clobber the async duplicate-priority scan.
 	statusTarget := subscription
@@ -901,6 +1468,9 @@ func (r *MaaSSubscriptionReconciler) updateStatus(ctx context.Context, subscript
 	case maasv1alpha1.PhaseActive:
 		status = metav1.ConditionTrue
 		reason = maasv1alpha1.ReasonReconciled
+	case maasv1alpha1.PhasePending:
+		status = metav1.ConditionFalse
+		reason = maasv1alpha1.ReasonNotEnforced
 	case maasv1alpha1.PhaseDegraded:
 		status = metav1.ConditionFalse
 		reason = maasv1alpha1.ReasonPartialFailure
@@ -914,6 +1484,9 @@ func (r *MaaSSubscriptionReconciler) updateStatus(ctx context.Context, subscript
 		status = metav1.ConditionUnknown
 		reason = maasv1alpha1.ReasonUnknown
 	}
+	if readyReason != "" {
+		reason = readyReason
+	}
 
 	apimeta.SetStatusCondition(&subscription.Status.Conditions, metav1.Condition{
 		Type:               "Ready",
@@ -1057,6 +1630,9 @@ func (r *MaaSSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}
 			var refs []string
 			for _, modelRef := range sub.Spec.ModelRefs {
+				if isWildcardModelRef(modelRef) {
+					continue
+				}
 				// Index value format: "namespace/name"
 				refs = append(refs, modelRef.Namespace+"/"+modelRef.Name)
 			}
@@ -1066,14 +1642,15 @@ func (r *MaaSSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to setup field indexer for MaaSSubscription: %w", err)
 	}
 
-	// Watch generated TokenRateLimitPolicies so we re-reconcile when someone manually edits them.
-	generatedTRLP := &unstructured.Unstructured{}
-	generatedTRLP.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("maas-subscription-controller")
+	}
 
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&maasv1alpha1.MaaSSubscription{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.Funcs{UpdateFunc: deletionTimestampSet},
+			dryRunAnnotationChanged,
 		))).
 		// Full scan of duplicate spec.priority on create, delete, or priority-only spec update.
 		// Does not enqueue reconciles; only patches status conditions on all subscriptions.
@@ -1082,18 +1659,15 @@ func (r *MaaSSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			duplicatePriorityScanHandler(r),
 			builder.WithPredicates(duplicatePriorityScanPredicate()),
 		).
-		// Watch HTTPRoutes so we re-reconcile when KServe creates/updates a route
-		// (fixes race condition where MaaSSubscription is created before HTTPRoute exists).
-		Watches(&gatewayapiv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(
-			r.mapHTTPRouteToMaaSSubscriptions,
-		)).
-		// Watch MaaSModelRefs so we re-reconcile when a model is created or deleted.
+		// Watch MaaSModelRefs so we re-reconcile when a model is created, deleted, or its
+		// global token rate limits or price change.
 		Watches(&maasv1alpha1.MaaSModelRef{}, handler.EnqueueRequestsFromMapFunc(
 			r.mapMaaSModelRefToMaaSSubscriptions,
 		)).
-		// Watch generated TokenRateLimitPolicies so manual edits get overwritten by the controller.
-		Watches(generatedTRLP, handler.EnqueueRequestsFromMapFunc(
-			r.mapGeneratedTRLPToParent,
+		// Watch MaaSSubscriptionTemplates so plan changes reach the subscriptions
+		// referencing them.
+		Watches(&maasv1alpha1.MaaSSubscriptionTemplate{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapSubscriptionTemplateToMaaSSubscriptions,
 		)).
 		// Watch AITenants so gateway/OIDC platform-context changes refresh subscription
 		// gateway validation for the affected tenant namespace.
@@ -1101,6 +1675,28 @@ func (r *MaaSSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.mapAITenantToMaaSSubscriptions,
 		))
 
+	if !authorinoAuthConfigs(r.AuthProvider) {
+		// Watch generated TokenRateLimitPolicies so manual edits get overwritten by the controller.
+		generatedTRLP := &unstructured.Unstructured{}
+		generatedTRLP.SetGroupVersionKind(r.trlpGVK())
+		// Same for the RateLimitPolicies generated from request rate limits. Policies not
+		// created by the controller are mapped through their target HTTPRoute for conflict
+		// detection.
+		generatedRLP := &unstructured.Unstructured{}
+		generatedRLP.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK)
+		b = b.Watches(generatedTRLP, handler.EnqueueRequestsFromMapFunc(
+			r.mapRateLimitPolicyToMaaSSubscriptions,
+		)).Watches(generatedRLP, handler.EnqueueRequestsFromMapFunc(
+			r.mapRateLimitPolicyToMaaSSubscriptions,
+		))
+	}
+	if r.RoutingProvider != externalmodel.RoutingProviderIstio {
+		// Watch HTTPRoutes so we re-reconcile when KServe creates/updates a route
+		// (fixes race condition where MaaSSubscription is created before HTTPRoute exists).
+		b = b.Watches(&gatewayapiv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(
+			r.mapHTTPRouteToMaaSSubscriptions,
+		))
+	}
 	if r.TenantNamespaceDiscoveryEnabled {
 		// Watch Namespaces so that subscriptions in newly labeled tenant
 		// namespaces are discovered without a controller restart.
@@ -1109,7 +1705,7 @@ func (r *MaaSSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		), builder.WithPredicates(predicate.LabelChangedPredicate{}))
 	}
 
-	return b.Complete(r)
+	return b.Complete(observeReconcile("MaaSSubscription", r))
 }
 
 func (r *MaaSSubscriptionReconciler) mapAITenantToMaaSSubscriptions(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -1192,6 +1788,9 @@ func (r *MaaSSubscriptionReconciler) mapGeneratedTRLPToParent(ctx context.Contex
 	if labels["app.kubernetes.io/managed-by"] != "maas-controller" {
 		return nil
 	}
+	if labels["app.kubernetes.io/component"] == limitGroupsComponent {
+		return limitGroupsPolicySubscriptions(obj)
+	}
 	modelName := labels["maas.opendatahub.io/model"]
 	if modelName == "" {
 		return nil
@@ -1223,6 +1822,26 @@ func (r *MaaSSubscriptionReconciler) mapGeneratedTRLPToParent(ctx context.Contex
 	return requests
 }
 
+// mapRateLimitPolicyToMaaSSubscriptions maps a generated TokenRateLimitPolicy or
+// RateLimitPolicy to its parent subscriptions, and any other policy targeting an
+// HTTPRoute to the subscriptions of the models in the route's namespace.
+func (r *MaaSSubscriptionReconciler) mapRateLimitPolicyToMaaSSubscriptions(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetLabels()["app.kubernetes.io/managed-by"] == "maas-controller" {
+		return r.mapGeneratedTRLPToParent(ctx, obj)
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	kind, _, _ := unstructured.NestedString(u.Object, "spec", "targetRef", "kind")
+	name, _, _ := unstructured.NestedString(u.Object, "spec", "targetRef", "name")
+	if kind != "HTTPRoute" || name == "" {
+		return nil
+	}
+	route := &gatewayapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: u.GetNamespace()}}
+	return r.mapHTTPRouteToMaaSSubscriptions(ctx, route)
+}
+
 // mapMaaSModelRefToMaaSSubscriptions returns reconcile requests for all MaaSSubscriptions
 // that reference the given MaaSModelRef.
 func (r *MaaSSubscriptionReconciler) mapMaaSModelRefToMaaSSubscriptions(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -1236,6 +1855,13 @@ func (r *MaaSSubscriptionReconciler) mapMaaSModelRefToMaaSSubscriptions(ctx cont
 	if err := r.List(ctx, &subscriptions, client.MatchingFields{modelRefIndexKey: modelKey}); err != nil {
 		return nil
 	}
+	// Subscriptions selecting the model through a wildcard or by label; on relabeling,
+	// the mapping also runs for the old object, which reaches subscriptions that no
+	// longer select it.
+	selecting, err := findSubscriptionsSelectingModel(ctx, r.Client, model)
+	if err == nil {
+		subscriptions.Items = append(subscriptions.Items, selecting...)
+	}
 	subscriptions.Items = filterSubscriptionsByTenantNamespace(ctx, r.Client, subscriptions.Items, r.DefaultTenantNamespace, r.TenantNamespaceDiscoveryEnabled)
 	// Deduplicate requests (same subscription shouldn't be queued multiple times)
 	seen := make(map[types.NamespacedName]struct{}, len(subscriptions.Items))
@@ -1275,6 +1901,9 @@ func (r *MaaSSubscriptionReconciler) mapHTTPRouteToMaaSSubscriptions(ctx context
 		if err := r.List(ctx, &subscriptions, client.MatchingFields{modelRefIndexKey: modelKey}); err != nil {
 			continue // skip this model on error, don't fail entire mapping
 		}
+		if selecting, err := findSubscriptionsSelectingModel(ctx, r.Client, &m); err == nil {
+			subscriptions.Items = append(subscriptions.Items, selecting...)
+		}
 		subscriptions.Items = filterSubscriptionsByTenantNamespace(ctx, r.Client, subscriptions.Items, r.DefaultTenantNamespace, r.TenantNamespaceDiscoveryEnabled)
 		for _, s := range subscriptions.Items {
 			key := types.NamespacedName{Name: s.Name, Namespace: s.Namespace}
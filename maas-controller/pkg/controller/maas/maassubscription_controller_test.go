@@ -19,6 +19,7 @@ package maas
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -34,6 +35,7 @@ import (
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
 )
 
 // subscriptionModelRefIndexer is the field indexer function for MaaSSubscription.
@@ -45,6 +47,9 @@ func subscriptionModelRefIndexer(obj client.Object) []string {
 	}
 	var refs []string
 	for _, modelRef := range sub.Spec.ModelRefs {
+		if isWildcardModelRef(modelRef) {
+			continue
+		}
 		refs = append(refs, modelRef.Namespace+"/"+modelRef.Name)
 	}
 	return refs
@@ -80,10 +85,10 @@ func TestMaaSSubscriptionReconciler_ManagedAnnotation(t *testing.T) {
 	const (
 		modelName     = "llm"
 		namespace     = "default"
-		httpRouteName = "maas-" + modelName      // ExternalModel naming convention
-		trlpName      = "maas-trlp-" + modelName // generated by the controller
+		httpRouteName = "maas-" + modelName // ExternalModel naming convention
 		maasSubName   = "sub-a"
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	tests := []struct {
 		name            string
@@ -168,8 +173,8 @@ func TestMaaSSubscriptionReconciler_DuplicateReconciliation(t *testing.T) {
 		modelName     = "llm"
 		namespace     = "default"
 		httpRouteName = "maas-" + modelName
-		trlpName      = "maas-trlp-" + modelName
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
 	route := newHTTPRoute(httpRouteName, namespace)
@@ -225,8 +230,8 @@ func TestMaaSSubscriptionReconciler_MapGeneratedTRLPToParent_AllModelSubscriptio
 		modelName  = "llm"
 		otherModel = "other-model"
 		namespace  = "default"
-		trlpName   = "maas-trlp-" + modelName
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	subA := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
 	subB := newMaaSSubscription("sub-b", namespace, "team-b", modelName, 200)
@@ -395,9 +400,9 @@ func TestMaaSSubscriptionReconciler_DeleteAnnotation(t *testing.T) {
 	const (
 		modelName   = "llm"
 		namespace   = "default"
-		trlpName    = "maas-trlp-" + modelName
 		maasSubName = "sub-a"
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	tests := []struct {
 		name        string
@@ -475,10 +480,10 @@ func TestMaaSSubscriptionReconciler_RemoveModelRef(t *testing.T) {
 		namespace  = "default"
 		httpRouteA = "maas-" + modelA
 		httpRouteB = "maas-" + modelB
-		trlpA      = "maas-trlp-" + modelA
-		trlpB      = "maas-trlp-" + modelB
 		subName    = "sub-1"
 	)
+	trlpA := tokenRateLimitPolicyName(namespace, modelA, "")
+	trlpB := tokenRateLimitPolicyName(namespace, modelB, "")
 
 	modelRefA := newMaaSModelRef(modelA, namespace, "ExternalModel", modelA)
 	modelRefB := newMaaSModelRef(modelB, namespace, "ExternalModel", modelB)
@@ -563,8 +568,8 @@ func TestMaaSSubscriptionReconciler_RemoveModelRef_Aggregation(t *testing.T) {
 		namespace  = "default"
 		httpRouteA = "maas-" + modelA
 		httpRouteB = "maas-" + modelB
-		trlpB      = "maas-trlp-" + modelB
 	)
+	trlpB := tokenRateLimitPolicyName(namespace, modelB, "")
 
 	modelRefA := newMaaSModelRef(modelA, namespace, "ExternalModel", modelA)
 	modelRefB := newMaaSModelRef(modelB, namespace, "ExternalModel", modelB)
@@ -669,11 +674,11 @@ func TestMaaSSubscriptionReconciler_MultipleSubscriptionsDeletion(t *testing.T)
 		modelName      = "shared-model"
 		modelNamespace = "llm"
 		httpRouteName  = "maas-" + modelName
-		trlpName       = "maas-trlp-" + modelName
 		sub1Name       = "subscription-1"
 		sub2Name       = "subscription-2"
 		subNS          = "opendatahub"
 	)
+	trlpName := tokenRateLimitPolicyName(modelNamespace, modelName, "")
 
 	// Create model and HTTPRoute
 	model := &maasv1alpha1.MaaSModelRef{
@@ -811,9 +816,9 @@ func TestMaaSSubscriptionReconciler_SimplifiedTRLP(t *testing.T) {
 		modelName     = "llm"
 		namespace     = "default"
 		httpRouteName = "maas-" + modelName
-		trlpName      = "maas-trlp-" + modelName
 		maasSubName   = "sub-a"
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
 	route := newHTTPRoute(httpRouteName, namespace)
@@ -906,8 +911,8 @@ func TestMaaSSubscriptionReconciler_MultipleSubscriptionsSimplified(t *testing.T
 		modelName     = "llm"
 		namespace     = "default"
 		httpRouteName = "maas-" + modelName
-		trlpName      = "maas-trlp-" + modelName
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
 	route := newHTTPRoute(httpRouteName, namespace)
@@ -1254,21 +1259,21 @@ func TestMaaSSubscriptionReconciler_PartialModelRefs_DegradedPhase(t *testing.T)
 }
 
 // TestMaaSSubscriptionReconciler_AllValidModelRefs_ActivePhase verifies that a subscription
-// with all valid model refs and accepted TRLP gets Active phase.
+// with all valid model refs and an accepted and enforced TRLP gets Active phase.
 func TestMaaSSubscriptionReconciler_AllValidModelRefs_ActivePhase(t *testing.T) {
 	const (
 		namespace     = "default"
 		maasSubName   = "sub-valid"
 		modelName     = "valid-model"
 		httpRouteName = "maas-" + modelName
-		trlpName      = "maas-trlp-" + modelName
 	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
 	route := newHTTPRoute(httpRouteName, namespace)
 	maasSub := newMaaSSubscription(maasSubName, namespace, "team-a", modelName, 100)
 
-	// Pre-create TRLP with Accepted=True status (simulates Kuadrant accepting the policy)
+	// Pre-create TRLP with Accepted=True and Enforced=True status (simulates Kuadrant enforcing the policy)
 	existingTRLP := newPreexistingTRLP(trlpName, namespace, modelName, map[string]string{
 		"maas.opendatahub.io/subscriptions": maasSubName,
 	})
@@ -1277,6 +1282,10 @@ func TestMaaSSubscriptionReconciler_AllValidModelRefs_ActivePhase(t *testing.T)
 			"type":   "Accepted",
 			"status": "True",
 		},
+		map[string]any{
+			"type":   "Enforced",
+			"status": "True",
+		},
 	}, "status", "conditions"); err != nil {
 		t.Fatalf("SetNestedSlice status.conditions: %v", err)
 	}
@@ -1329,6 +1338,72 @@ func TestMaaSSubscriptionReconciler_AllValidModelRefs_ActivePhase(t *testing.T)
 	if !sub.Status.TokenRateLimitStatuses[0].Ready {
 		t.Error("expected tokenRateLimitStatus.Ready=true")
 	}
+	if got := sub.Status.TokenRateLimitStatuses[0].Reason; got != maasv1alpha1.ReasonAcceptedEnforced {
+		t.Errorf("expected tokenRateLimitStatus.Reason=%s, got %s", maasv1alpha1.ReasonAcceptedEnforced, got)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_PolicyNotEnforced verifies that generated policies that are
+// accepted but not enforced are reported per policy and keep the subscription out of Ready.
+func TestMaaSSubscriptionReconciler_PolicyNotEnforced(t *testing.T) {
+	const (
+		namespace   = "default"
+		maasSubName = "sub-a"
+		modelName   = "llm"
+	)
+	policyConditions := func(enforced string) []any {
+		return []any{
+			map[string]any{"type": "Accepted", "status": "True"},
+			map[string]any{"type": "Enforced", "status": enforced, "message": "limitador not ready"},
+		}
+	}
+	trlp := newPreexistingTRLP(tokenRateLimitPolicyName(namespace, modelName, ""), namespace, modelName, nil)
+	if err := unstructured.SetNestedSlice(trlp.Object, policyConditions("True"), "status", "conditions"); err != nil {
+		t.Fatalf("SetNestedSlice status.conditions: %v", err)
+	}
+	rlp := newPreexistingTRLP(rateLimitPolicyName(namespace, modelName, ""), namespace, modelName, nil)
+	rlp.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK)
+	if err := unstructured.SetNestedSlice(rlp.Object, policyConditions("False"), "status", "conditions"); err != nil {
+		t.Fatalf("SetNestedSlice status.conditions: %v", err)
+	}
+	maasSub := newMaaSSubscription(maasSubName, namespace, "team-a", modelName, 100)
+	maasSub.Spec.ModelRefs[0].RequestRateLimits = []maasv1alpha1.RequestRateLimit{{Limit: 10, Window: "1m"}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(newMaaSModelRef(modelName, namespace, "ExternalModel", modelName), newHTTPRoute("maas-"+modelName, namespace), maasSub, trlp, rlp).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: maasSubName, Namespace: namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile: unexpected error: %v", err)
+	}
+
+	var sub maasv1alpha1.MaaSSubscription
+	if err := c.Get(context.Background(), req.NamespacedName, &sub); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if len(sub.Status.TokenRateLimitStatuses) != 2 {
+		t.Fatalf("expected statuses for the TokenRateLimitPolicy and the RateLimitPolicy, got %+v", sub.Status.TokenRateLimitStatuses)
+	}
+	trlpStatus, rlpStatus := sub.Status.TokenRateLimitStatuses[0], sub.Status.TokenRateLimitStatuses[1]
+	if trlpStatus.Kind != "TokenRateLimitPolicy" || !trlpStatus.Ready {
+		t.Errorf("TokenRateLimitPolicy status = %+v, want ready", trlpStatus)
+	}
+	if rlpStatus.Kind != "RateLimitPolicy" || rlpStatus.Ready || rlpStatus.Reason != maasv1alpha1.ReasonNotEnforced || rlpStatus.Message != "limitador not ready" {
+		t.Errorf("RateLimitPolicy status = %+v, want not ready with reason %s", rlpStatus, maasv1alpha1.ReasonNotEnforced)
+	}
+	if sub.Status.Phase != maasv1alpha1.PhaseDegraded {
+		t.Errorf("expected phase Degraded, got %q", sub.Status.Phase)
+	}
+	readyCond := apimeta.FindStatusCondition(sub.Status.Conditions, "Ready")
+	if readyCond == nil || readyCond.Status != metav1.ConditionFalse || readyCond.Reason != string(maasv1alpha1.ReasonPolicyNotEnforced) {
+		t.Errorf("Ready condition = %+v, want False with reason %s", readyCond, maasv1alpha1.ReasonPolicyNotEnforced)
+	}
 }
 
 // TestMaaSSubscriptionReconciler_WindowValuesInTRLP verifies that valid window values
@@ -1359,9 +1434,9 @@ func TestMaaSSubscriptionReconciler_WindowValuesInTRLP(t *testing.T) {
 				modelName     = "llm"
 				namespace     = "default"
 				httpRouteName = "maas-" + modelName
-				trlpName      = "maas-trlp-" + modelName
 				maasSubName   = "sub-window"
 			)
+			trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
 
 			// Set up the minimum objects the reconciler needs: a MaaSModelRef (so the
 			// model lookup succeeds) and an HTTPRoute (so the TRLP has a valid target).
@@ -1449,6 +1524,138 @@ func TestMaaSSubscriptionReconciler_WindowValuesInTRLP(t *testing.T) {
 	}
 }
 
+func TestValidateTokenRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  []maasv1alpha1.TokenRateLimit
+		wantErr string
+	}{
+		{name: "single", limits: []maasv1alpha1.TokenRateLimit{{Limit: 100, Window: "1m"}}},
+		{name: "burst and sustained", limits: []maasv1alpha1.TokenRateLimit{{Limit: 200000, Window: "1h"}, {Limit: 10000, Window: "1m"}}},
+		{name: "same duration", limits: []maasv1alpha1.TokenRateLimit{{Limit: 100, Window: "60s"}, {Limit: 200, Window: "1m"}}, wantErr: "same duration"},
+		{name: "sustained not larger", limits: []maasv1alpha1.TokenRateLimit{{Limit: 10000, Window: "1m"}, {Limit: 10000, Window: "1h"}}, wantErr: "must be larger"},
+		{name: "invalid rate", limits: []maasv1alpha1.TokenRateLimit{{Limit: 100, Window: "1d"}}, wantErr: "invalid window format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTokenRateLimits(tt.limits)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestBuildTRLPSpec_BurstAndSustained verifies that all rates of a modelRef reach the
+// TRLP, shortest window first, and that an unenforceable set skips the subscription.
+func TestBuildTRLPSpec_BurstAndSustained(t *testing.T) {
+	sub := newMaaSSubscription("sub", "default", "team-a", "llm", 0)
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 200000, Window: "1h"}, {Limit: 10000, Window: "1m"}}
+	spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route")
+	rates := spec.Limits["default-sub-llm-tokens"].Rates
+	want := []kuadrantv1.Rate{{Limit: 10000, Window: "1m"}, {Limit: 200000, Window: "1h"}}
+	if !reflect.DeepEqual(rates, want) {
+		t.Errorf("rates = %v, want %v", rates, want)
+	}
+
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 10000, Window: "1m"}, {Limit: 5000, Window: "1h"}}
+	if spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route"); spec != nil {
+		t.Errorf("expected the subscription to be skipped, got %v", spec)
+	}
+}
+
+// TestBuildTRLPSpec_CounterScope verifies that spec.counterScope selects the counters of
+// the subscription's TRLP limit.
+func TestBuildTRLPSpec_CounterScope(t *testing.T) {
+	tests := []struct {
+		scope maasv1alpha1.CounterScope
+		want  []kuadrantv1.Counter
+	}{
+		{"", []kuadrantv1.Counter{{Expression: "auth.identity.userid"}}},
+		{maasv1alpha1.CounterScopeUser, []kuadrantv1.Counter{{Expression: "auth.identity.userid"}}},
+		{maasv1alpha1.CounterScopeGroup, []kuadrantv1.Counter{{Expression: "auth.identity.groups_str"}}},
+		{maasv1alpha1.CounterScopeSubscription, nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.scope), func(t *testing.T) {
+			sub := newMaaSSubscription("sub", "default", "team-a", "llm", 100)
+			sub.Spec.CounterScope = tt.scope
+			spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route")
+			if spec == nil {
+				t.Fatal("expected a TRLP spec")
+			}
+			limit, found := spec.Limits["default-sub-llm-tokens"]
+			if !found {
+				t.Fatalf("limit default-sub-llm-tokens not found in %v", spec.Limits)
+			}
+			if !reflect.DeepEqual(limit.Counters, tt.want) {
+				t.Errorf("counters = %v, want %v", limit.Counters, tt.want)
+			}
+		})
+	}
+}
+
+// TestMaaSSubscriptionReconciler_GlobalTokenRateLimits verifies that a model's
+// spec.globalTokenRateLimits become one extra TRLP limit shared by all subscribers.
+func TestMaaSSubscriptionReconciler_GlobalTokenRateLimits(t *testing.T) {
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	trlpName := tokenRateLimitPolicyName(namespace, modelName, "")
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	model.Spec.GlobalTokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 1000000, Window: "1h"}}
+	route := newHTTPRoute("maas-"+modelName, namespace)
+	subA := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+	subB := newMaaSSubscription("sub-b", namespace, "team-b", modelName, 200)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, subA, subB).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile: unexpected error: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	if err := c.Get(context.Background(), types.NamespacedName{Name: trlpName, Namespace: namespace}, trlp); err != nil {
+		t.Fatalf("Get TokenRateLimitPolicy %q: %v", trlpName, err)
+	}
+	limits, _, _ := unstructured.NestedMap(trlp.Object, "spec", "limits")
+	if len(limits) != 3 {
+		t.Errorf("expected 2 subscription limits and 1 global limit, got %d: %v", len(limits), limits)
+	}
+
+	global, found, _ := unstructured.NestedMap(trlp.Object, "spec", "limits", globalTokenLimitKey(modelName))
+	if !found {
+		t.Fatalf("global limit %q not found in %v", globalTokenLimitKey(modelName), limits)
+	}
+	if _, hasCounters := global["counters"]; hasCounters {
+		t.Errorf("global limit must not have per-user counters, got %v", global["counters"])
+	}
+	rates, _, _ := unstructured.NestedSlice(global, "rates")
+	if len(rates) != 1 {
+		t.Fatalf("expected 1 global rate, got %d", len(rates))
+	}
+	rate := rates[0].(map[string]any)
+	if rate["limit"] != int64(1000000) || rate["window"] != "1h" {
+		t.Errorf("global rate = %v, want limit 1000000 window 1h", rate)
+	}
+}
+
 // TestMaaSSubscriptionReconciler_NoSpec verifies that a legacy subscription created
 // without a spec field is marked Failed without adding a finalizer.
 func TestMaaSSubscriptionReconciler_NoSpec(t *testing.T) {
@@ -1499,3 +1706,64 @@ func TestMaaSSubscriptionReconciler_NoSpec(t *testing.T) {
 		t.Errorf("Ready.Message = %q, expected it to contain %q", ready.Message, "spec is required")
 	}
 }
+
+// TestMaaSSubscriptionReconciler_TokenRateLimitPolicyV1 verifies that the TokenRateLimitPolicy
+// is generated as kuadrant.io/v1 when Kuadrant serves that version, and that it is
+// reported and deleted under it.
+func TestMaaSSubscriptionReconciler_TokenRateLimitPolicyV1(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName     = "llm"
+		namespace     = "default"
+		httpRouteName = "maas-" + modelName
+	)
+	mapper := testRESTMapper().(*apimeta.DefaultRESTMapper)
+	mapper.Add(kuadrantv1.TokenRateLimitPolicyGVK, apimeta.RESTScopeNamespace)
+	mapper.Add(kuadrantv1.TokenRateLimitPolicyGVK.GroupVersion().WithKind("TokenRateLimitPolicyList"), apimeta.RESTScopeNamespace)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(mapper).
+		WithObjects(
+			newMaaSModelRef(modelName, namespace, "ExternalModel", modelName),
+			newHTTPRoute(httpRouteName, namespace),
+			newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100),
+		).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, TokenRateLimitPolicyGVK: kuadrantv1.TokenRateLimitPolicyGVK}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	key := client.ObjectKey{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.TokenRateLimitPolicyGVK)
+	if err := c.Get(ctx, key, policy); err != nil {
+		t.Fatalf("Get v1 TokenRateLimitPolicy: %v", err)
+	}
+	v1alpha1Policy := &unstructured.Unstructured{}
+	v1alpha1Policy.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1alpha1", Kind: "TokenRateLimitPolicy"})
+	if err := c.Get(ctx, key, v1alpha1Policy); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no v1alpha1 TokenRateLimitPolicy, got %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if len(got.Status.TokenRateLimitStatuses) == 0 || got.Status.TokenRateLimitStatuses[0].Name != key.Name {
+		t.Errorf("expected status to report the v1 TokenRateLimitPolicy, got %+v", got.Status.TokenRateLimitStatuses)
+	}
+
+	if err := c.Delete(ctx, got); err != nil {
+		t.Fatalf("Delete MaaSSubscription: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, key, policy); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the v1 TokenRateLimitPolicy to be deleted with the last subscription, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// phaseMaintenance is the MaaSModelRef phase while spec.maintenance is set.
+	phaseMaintenance = "Maintenance"
+	// reasonMaintenance is the Ready=False reason while spec.maintenance is set.
+	reasonMaintenance = "Maintenance"
+	// reasonMaintenanceUnsupported is the Ready=False reason while spec.maintenance is set
+	// but the gateway cannot answer 503 for the model.
+	reasonMaintenanceUnsupported = "MaintenanceUnsupported"
+	// defaultMaintenanceRetryAfterSeconds is the Retry-After value when spec.maintenanceRetryAfterSeconds is unset.
+	defaultMaintenanceRetryAfterSeconds = 300
+)
+
+// maintenanceAuthPolicyName returns the name of the AuthPolicy that drains a model in maintenance.
+func maintenanceAuthPolicyName(modelName string) string {
+	return fmt.Sprintf("maas-maintenance-%s", modelName)
+}
+
+// maintenanceRetryAfter returns the Retry-After seconds for the model.
+func maintenanceRetryAfter(model *maasv1alpha1.MaaSModelRef) int32 {
+	if model.Spec.MaintenanceRetryAfterSeconds > 0 {
+		return model.Spec.MaintenanceRetryAfterSeconds
+	}
+	return defaultMaintenanceRetryAfterSeconds
+}
+
+// buildMaintenanceAuthPolicySpec returns an AuthPolicy spec for the model HTTPRoute that
+// denies every request with 503 and a Retry-After header. Route-level defaults replace the
+// gateway AuthPolicy for the route, so nothing reaches the backend while it is drained.
+func buildMaintenanceAuthPolicySpec(routeName string, retryAfter int32) *kuadrantv1.AuthPolicySpec {
+	return &kuadrantv1.AuthPolicySpec{
+		TargetRef: kuadrantv1.TargetRef{
+			Group: "gateway.networking.k8s.io",
+			Kind:  "HTTPRoute",
+			Name:  routeName,
+		},
+		Defaults: &kuadrantv1.MergeableAuthPolicy{
+			Rules: kuadrantv1.AuthRules{
+				Authorization: map[string]kuadrantv1.AuthorizationRule{
+					"maintenance": {
+						PatternMatching: &kuadrantv1.PatternMatchingAuthz{
+							Patterns: []kuadrantv1.Pattern{{
+								Selector: "context.request.http.method",
+								Operator: "eq",
+								Value:    "__maintenance__",
+							}},
+						},
+					},
+				},
+				Response: &kuadrantv1.ResponseRules{
+					Unauthorized: &kuadrantv1.DenyWith{
+						Code: 503,
+						Body: &kuadrantv1.ValueFrom{
+							Value: `{"error":{"message":"The model is under maintenance, retry later","type":"service_unavailable","code":"model_maintenance"}}`,
+						},
+						Headers: map[string]kuadrantv1.ValueFrom{
+							"retry-after":  {Value: strconv.Itoa(int(retryAfter))},
+							"content-type": {Value: "application/json"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// maintenanceUnsupported returns why spec.maintenance cannot be enforced at the gateway, or
+// "" if it can. The maintenance AuthPolicy needs Kuadrant and an HTTPRoute to target.
+func (r *MaaSModelRefReconciler) maintenanceUnsupported() string {
+	switch {
+	case r.istioRouting():
+		return "the istio routing provider is in use and there is no HTTPRoute for the maintenance AuthPolicy"
+	case authorinoAuthConfigs(r.AuthProvider):
+		return "--auth-provider=authorino is in use and the maintenance AuthPolicy needs Kuadrant"
+	}
+	return ""
+}
+
+// reconcileMaintenance creates or updates the maintenance AuthPolicy on the model's HTTPRoute.
+func (r *MaaSModelRefReconciler) reconcileMaintenance(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	policy.SetName(maintenanceAuthPolicyName(model.Name))
+	policy.SetNamespace(model.Status.HTTPRouteNamespace)
+
+	existing := policy.DeepCopy()
+	if err := r.Get(ctx, types.NamespacedName{Name: policy.GetName(), Namespace: policy.GetNamespace()}, existing); err == nil {
+		if !isManaged(existing) {
+			log.Info("Maintenance AuthPolicy opted out, skipping reconciliation", "name", policy.GetName())
+			return nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get maintenance AuthPolicy: %w", err)
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		labels := policy.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["maas.opendatahub.io/model"] = model.Name
+		labels["maas.opendatahub.io/model-namespace"] = model.Namespace
+		labels["app.kubernetes.io/managed-by"] = "maas-controller"
+		labels["app.kubernetes.io/part-of"] = "maas-model-ref"
+		labels["app.kubernetes.io/component"] = "maintenance-auth-policy"
+		policy.SetLabels(labels)
+		if policy.GetNamespace() == model.Namespace {
+			if err := controllerutil.SetControllerReference(model, policy, r.Scheme); err != nil {
+				return err
+			}
+		}
+		spec, err := kuadrantv1.ToUnstructured(buildMaintenanceAuthPolicySpec(model.Status.HTTPRouteName, maintenanceRetryAfter(model)))
+		if err != nil {
+			return err
+		}
+		return unstructured.SetNestedMap(policy.Object, spec, "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply maintenance AuthPolicy %s/%s: %w", policy.GetNamespace(), policy.GetName(), err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Maintenance AuthPolicy applied", "name", policy.GetName(), "route", model.Status.HTTPRouteName, "operation", op)
+	}
+	return nil
+}
+
+// deleteMaintenancePolicy removes the maintenance AuthPolicy once spec.maintenance is cleared.
+func (r *MaaSModelRefReconciler) deleteMaintenancePolicy(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	namespace := model.Status.HTTPRouteNamespace
+	if namespace == "" {
+		namespace = model.Namespace
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: maintenanceAuthPolicyName(model.Name), Namespace: namespace}, policy); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get maintenance AuthPolicy: %w", err)
+	}
+	if !isManaged(policy) {
+		return nil
+	}
+	if err := r.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete maintenance AuthPolicy %s/%s: %w", policy.GetNamespace(), policy.GetName(), err)
+	}
+	log.Info("Maintenance AuthPolicy deleted", "name", policy.GetName())
+	return nil
+}
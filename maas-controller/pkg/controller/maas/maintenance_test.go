@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestBuildMaintenanceAuthPolicySpec(t *testing.T) {
+	spec := buildMaintenanceAuthPolicySpec("llm-route", 120)
+
+	if spec.TargetRef.Name != "llm-route" {
+		t.Errorf("targetRef.name = %q, want llm-route", spec.TargetRef.Name)
+	}
+	if spec.TargetRef.Kind != "HTTPRoute" {
+		t.Errorf("targetRef.kind = %q, want HTTPRoute", spec.TargetRef.Kind)
+	}
+	denial := spec.Defaults.Rules.Response.Unauthorized
+	if denial.Code != 503 {
+		t.Errorf("denial code = %d, want 503", denial.Code)
+	}
+	if retryAfter := denial.Headers["retry-after"].Value; retryAfter != "120" {
+		t.Errorf("Retry-After = %q, want 120", retryAfter)
+	}
+}
+
+// TestMaaSModelRefReconciler_Maintenance verifies that spec.maintenance puts the model in
+// phase Maintenance behind a 503 AuthPolicy on its route, and that clearing it removes the policy.
+func TestMaaSModelRefReconciler_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("llm", "default", "LLMInferenceService", "llm")
+	model.Spec.Maintenance = true
+	model.Spec.MaintenanceRetryAfterSeconds = 120
+
+	r, c := newTestReconcilerWithMapper(
+		model,
+		newMaaSGateway(testGatewayName, testGatewayNamespace),
+		newLLMISvc("llm", "default", corev1.ConditionTrue),
+		newLLMISvcRoute("llm", "default"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llm", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != phaseMaintenance {
+		t.Errorf("Phase = %q, want %q", got.Status.Phase, phaseMaintenance)
+	}
+	if got.Status.Endpoint != "" {
+		t.Errorf("expected no endpoint in maintenance, got %q", got.Status.Endpoint)
+	}
+	assertReadyCondition(t, got.Status.Conditions, metav1.ConditionFalse, reasonMaintenance)
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	key := types.NamespacedName{Name: maintenanceAuthPolicyName("llm"), Namespace: "default"}
+	if err := c.Get(ctx, key, policy); err != nil {
+		t.Fatalf("expected maintenance AuthPolicy: %v", err)
+	}
+	if target, _, _ := unstructured.NestedString(policy.Object, "spec", "targetRef", "name"); target != "llm-route" {
+		t.Errorf("maintenance AuthPolicy targets %q, want llm-route", target)
+	}
+	if !metav1.IsControlledBy(policy, got) {
+		t.Error("maintenance AuthPolicy should be controlled by the MaaSModelRef")
+	}
+
+	got.Spec.Maintenance = false
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after maintenance: %v", err)
+	}
+	if err := c.Get(ctx, key, policy); !apierrors.IsNotFound(err) {
+		t.Errorf("expected maintenance AuthPolicy to be deleted, got err=%v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase == phaseMaintenance {
+		t.Error("model should leave the Maintenance phase once spec.maintenance is cleared")
+	}
+}
+
+// TestMaaSModelRefReconciler_MaintenanceUnsupported verifies that spec.maintenance is
+// reported as not enforced, instead of ignored, when there is no Kuadrant AuthPolicy to
+// answer 503.
+func TestMaaSModelRefReconciler_MaintenanceUnsupported(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("llm", "default", "LLMInferenceService", "llm")
+	model.Spec.Maintenance = true
+
+	r, c := newTestReconcilerWithMapper(
+		model,
+		newMaaSGateway(testGatewayName, testGatewayNamespace),
+		newLLMISvc("llm", "default", corev1.ConditionTrue),
+		newLLMISvcRoute("llm", "default"),
+	)
+	r.AuthProvider = AuthProviderAuthorino
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llm", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != phaseMaintenance || got.Status.Endpoint != "" {
+		t.Errorf("Phase=%q Endpoint=%q, want Maintenance with no endpoint", got.Status.Phase, got.Status.Endpoint)
+	}
+	assertReadyCondition(t, got.Status.Conditions, metav1.ConditionFalse, reasonMaintenanceUnsupported)
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.AuthPolicyGVK)
+	if err := c.Get(ctx, types.NamespacedName{Name: maintenanceAuthPolicyName("llm"), Namespace: "default"}, policy); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no maintenance AuthPolicy without Kuadrant, got err=%v", err)
+	}
+}
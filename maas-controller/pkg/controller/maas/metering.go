@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// meteringFilter is the dynamic metadata entry of the gateway AuthPolicy holding the
+	// spec.meteringMetadata of the requested model.
+	meteringFilter = "metering"
+
+	organizationIDHeader = "X-MaaS-Organization-ID"
+	costCenterHeader     = "X-MaaS-Cost-Center"
+)
+
+// meteringModels is the spec.meteringMetadata of a MaaSAuthPolicy with the models, as
+// "namespace/name", it is reported for.
+type meteringModels struct {
+	PolicyName string
+	Models     []string
+	Metadata   *maasv1alpha1.MeteringMetadata
+}
+
+// ValidateMeteringMetadata checks the parts of meteringMetadata the CRD schema cannot.
+func ValidateMeteringMetadata(m *maasv1alpha1.MeteringMetadata) error {
+	if m == nil {
+		return nil
+	}
+	for field, value := range map[string]string{"organizationId": m.OrganizationID, "costCenter": m.CostCenter} {
+		if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return fmt.Errorf("%s must not contain control characters", field)
+		}
+	}
+	return nil
+}
+
+// aggregateMeteringModels returns the spec.meteringMetadata reported for each model: that
+// of its policy with the highest priority setting one, by policy name on a tie.
+func aggregateMeteringModels(policies []maasv1alpha1.MaaSAuthPolicy) []meteringModels {
+	var candidates []maasv1alpha1.MaaSAuthPolicy
+	for _, p := range policies {
+		m := p.Spec.MeteringMetadata
+		if m == nil || (m.OrganizationID == "" && m.CostCenter == "" && len(m.Labels) == 0) || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if ValidateMeteringMetadata(m) != nil {
+			// Rejected by the webhook; never put into a header.
+			continue
+		}
+		candidates = append(candidates, p)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Spec.Priority != candidates[j].Spec.Priority {
+			return candidates[i].Spec.Priority > candidates[j].Spec.Priority
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	var out []meteringModels
+	claimed := map[string]bool{}
+	for _, p := range candidates {
+		var models []string
+		for _, ref := range p.Spec.ModelRefs {
+			key := ref.Namespace + "/" + ref.Name
+			if !claimed[key] {
+				claimed[key] = true
+				models = append(models, key)
+			}
+		}
+		if len(models) > 0 {
+			out = append(out, meteringModels{PolicyName: p.Name, Models: deduplicateAndSort(models), Metadata: p.Spec.MeteringMetadata})
+		}
+	}
+	return out
+}
+
+// celMeteringChoice is the value of the metering of the requested model, or def.
+func celMeteringChoice(metering []meteringModels, value func(meteringModels) string, def string) string {
+	expr := def
+	for i := len(metering) - 1; i >= 0; i-- {
+		expr = "(" + celRequestedModelIn(metering[i].Models) + " ? " + value(metering[i]) + " : " + expr + ")"
+	}
+	return expr
+}
+
+// celStringMap is a CEL map literal of the labels.
+func celStringMap(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, strconv.Quote(k)+": "+strconv.Quote(labels[k]))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// addMetering adds the spec.meteringMetadata of the models to the dynamic metadata of the
+// allowed requests, for access logs and usage attribution, and sets the organization and
+// cost center headers on the upstream requests. The headers are set on every request so
+// that values sent by clients do not reach the models.
+func addMetering(success *kuadrantv1.SuccessResponse, metering []meteringModels) {
+	if len(metering) == 0 {
+		return
+	}
+	organization := func(m meteringModels) string { return strconv.Quote(m.Metadata.OrganizationID) }
+	costCenter := func(m meteringModels) string { return strconv.Quote(m.Metadata.CostCenter) }
+
+	success.Filters[meteringFilter] = kuadrantv1.ResponseItem{
+		JSON: &kuadrantv1.JSONResponse{
+			Properties: map[string]kuadrantv1.ValueFrom{
+				"organization_id": {Expression: celMeteringChoice(metering, organization, `""`)},
+				"cost_center":     {Expression: celMeteringChoice(metering, costCenter, `""`)},
+				"labels":          {Expression: celMeteringChoice(metering, func(m meteringModels) string { return celStringMap(m.Metadata.Labels) }, `{}`)},
+				"policy":          {Expression: celMeteringChoice(metering, func(m meteringModels) string { return strconv.Quote(m.PolicyName) }, `""`)},
+			},
+		},
+	}
+	success.Headers[organizationIDHeader] = kuadrantv1.HeaderResponse{ResponseItem: kuadrantv1.ResponseItem{
+		Plain: &kuadrantv1.ValueFrom{Expression: celMeteringChoice(metering, organization, `""`)},
+	}}
+	success.Headers[costCenterHeader] = kuadrantv1.HeaderResponse{ResponseItem: kuadrantv1.ResponseItem{
+		Plain: &kuadrantv1.ValueFrom{Expression: celMeteringChoice(metering, costCenter, `""`)},
+	}}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestAggregateMeteringModels(t *testing.T) {
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	other := maasv1alpha1.ModelRef{Name: "other", Namespace: "default"}
+	team := newMaaSAuthPolicy("team", "default", "team-a", llm, other)
+	team.Spec.MeteringMetadata = &maasv1alpha1.MeteringMetadata{CostCenter: "cc-team"}
+	platform := newMaaSAuthPolicy("platform", "default", "team-b", llm)
+	platform.Spec.Priority = 10
+	platform.Spec.MeteringMetadata = &maasv1alpha1.MeteringMetadata{OrganizationID: "acme", CostCenter: "cc-platform"}
+	empty := newMaaSAuthPolicy("empty", "default", "team-c", other)
+	empty.Spec.Priority = 20
+	empty.Spec.MeteringMetadata = &maasv1alpha1.MeteringMetadata{}
+
+	got := aggregateMeteringModels([]maasv1alpha1.MaaSAuthPolicy{*team, *platform, *empty})
+	if len(got) != 2 {
+		t.Fatalf("got %+v, want the platform and team policies", got)
+	}
+	if got[0].PolicyName != "platform" || strings.Join(got[0].Models, ",") != "default/llm" {
+		t.Errorf("got[0] = %+v, want platform for default/llm", got[0])
+	}
+	if got[1].PolicyName != "team" || strings.Join(got[1].Models, ",") != "default/other" {
+		t.Errorf("got[1] = %+v, want team for default/other only", got[1])
+	}
+}
+
+func TestValidateMeteringMetadata(t *testing.T) {
+	if err := ValidateMeteringMetadata(&maasv1alpha1.MeteringMetadata{OrganizationID: "acme", CostCenter: "CC 42/eu"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateMeteringMetadata(&maasv1alpha1.MeteringMetadata{OrganizationID: "acme\nX-Forged: 1"}); err == nil {
+		t.Error("expected a line break to be rejected")
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_Metering(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	if _, ok := spec.Defaults.Rules.Response.Success.Filters[meteringFilter]; ok {
+		t.Error("metering filter should only be generated when a policy sets meteringMetadata")
+	}
+
+	headers := identityHeaderModels{Metering: []meteringModels{{
+		PolicyName: "llm-access",
+		Models:     []string{"llm/granite"},
+		Metadata:   &maasv1alpha1.MeteringMetadata{OrganizationID: "acme", CostCenter: "cc-42", Labels: map[string]string{"team": "a", "env": "prod"}},
+	}}}
+	spec = r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, headers, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	granite := celRequestedModelIn([]string{"llm/granite"})
+
+	filter, ok := spec.Defaults.Rules.Response.Success.Filters[meteringFilter]
+	if !ok || filter.JSON == nil {
+		t.Fatalf("metering filter missing: %+v", spec.Defaults.Rules.Response.Success.Filters)
+	}
+	for property, want := range map[string]string{
+		"organization_id": `(` + granite + ` ? "acme" : "")`,
+		"cost_center":     `(` + granite + ` ? "cc-42" : "")`,
+		"labels":          `(` + granite + ` ? {"env": "prod", "team": "a"} : {})`,
+		"policy":          `(` + granite + ` ? "llm-access" : "")`,
+	} {
+		if got := filter.JSON.Properties[property].Expression; got != want {
+			t.Errorf("%s = %s, want %s", property, got, want)
+		}
+	}
+
+	header, ok := spec.Defaults.Rules.Response.Success.Headers[costCenterHeader]
+	if !ok || header.Plain == nil || header.Plain.Expression != `(`+granite+` ? "cc-42" : "")` {
+		t.Errorf("%s = %+v, want the cost center of granite", costCenterHeader, header)
+	}
+	if len(header.When) != 0 {
+		t.Errorf("%s when = %+v, want it set on every request", costCenterHeader, header.When)
+	}
+}
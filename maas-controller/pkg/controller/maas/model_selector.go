@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// modelRefWildcard as a modelRef name covers every model in the entry's namespace, and
+// as its namespace as well every model in every namespace.
+const modelRefWildcard = "*"
+
+// isWildcardModelRef reports whether a modelRefs entry is a wildcard rather than a
+// reference to a single model.
+func isWildcardModelRef(ref maasv1alpha1.ModelSubscriptionRef) bool {
+	return ref.Name == modelRefWildcard
+}
+
+// selectedModelRef returns the modelRef spec.modelSelector gives a selected model.
+func selectedModelRef(sel *maasv1alpha1.ModelSelector, namespace, name string) maasv1alpha1.ModelSubscriptionRef {
+	return maasv1alpha1.ModelSubscriptionRef{
+		Name:                  name,
+		Namespace:             namespace,
+		TokenRateLimits:       sel.TokenRateLimits,
+		RequestRateLimits:     sel.RequestRateLimits,
+		MaxConcurrentRequests: sel.MaxConcurrentRequests,
+	}
+}
+
+// listsModelRef reports whether spec.modelRefs lists the model by name.
+func listsModelRef(sub *maasv1alpha1.MaaSSubscription, namespace, name string) bool {
+	for _, ref := range sub.Spec.ModelRefs {
+		if !isWildcardModelRef(ref) && ref.Namespace == namespace && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardModelRef returns the most specific wildcard entry covering the namespace:
+// "<namespace>/*" before "*/*".
+func wildcardModelRef(sub *maasv1alpha1.MaaSSubscription, namespace string) *maasv1alpha1.ModelSubscriptionRef {
+	var global *maasv1alpha1.ModelSubscriptionRef
+	for i := range sub.Spec.ModelRefs {
+		ref := &sub.Spec.ModelRefs[i]
+		if !isWildcardModelRef(*ref) {
+			continue
+		}
+		if ref.Namespace == namespace {
+			return ref
+		}
+		if ref.Namespace == modelRefWildcard && global == nil {
+			global = ref
+		}
+	}
+	return global
+}
+
+// selectedModelRefFor returns the modelRef a wildcard entry or spec.modelSelector gives
+// the model, or nil if neither selects it. Models listed by name are not selected, so
+// they keep their own limits; a wildcard entry takes precedence over the selector.
+func selectedModelRefFor(sub *maasv1alpha1.MaaSSubscription, model *maasv1alpha1.MaaSModelRef) *maasv1alpha1.ModelSubscriptionRef {
+	if !model.GetDeletionTimestamp().IsZero() || listsModelRef(sub, model.Namespace, model.Name) {
+		return nil
+	}
+	if wildcard := wildcardModelRef(sub, model.Namespace); wildcard != nil {
+		ref := *wildcard
+		ref.Name, ref.Namespace = model.Name, model.Namespace
+		return &ref
+	}
+	if modelSelectorMatches(sub.Spec.ModelSelector, model) {
+		ref := selectedModelRef(sub.Spec.ModelSelector, model.Namespace, model.Name)
+		return &ref
+	}
+	return nil
+}
+
+// modelSelectorMatches reports whether the selector matches the model's namespace and
+// labels. An invalid selector selects nothing.
+func modelSelectorMatches(sel *maasv1alpha1.ModelSelector, model *maasv1alpha1.MaaSModelRef) bool {
+	if sel == nil {
+		return false
+	}
+	if len(sel.Namespaces) > 0 && !slices.Contains(sel.Namespaces, model.Namespace) {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&sel.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(model.Labels))
+}
+
+// selectsModels reports whether the subscription has wildcard modelRefs or a modelSelector.
+func selectsModels(sub *maasv1alpha1.MaaSSubscription) bool {
+	return sub.Spec.ModelSelector != nil || slices.ContainsFunc(sub.Spec.ModelRefs, isWildcardModelRef)
+}
+
+// resolveSelectedModelRefs returns the models the subscription's wildcard modelRefs and
+// modelSelector select, sorted by namespace and name.
+func resolveSelectedModelRefs(ctx context.Context, c client.Reader, sub *maasv1alpha1.MaaSSubscription) ([]maasv1alpha1.ModelRef, error) {
+	if !selectsModels(sub) {
+		return nil, nil
+	}
+	if sel := sub.Spec.ModelSelector; sel != nil {
+		if _, err := metav1.LabelSelectorAsSelector(&sel.Selector); err != nil {
+			return nil, fmt.Errorf("invalid spec.modelSelector.selector: %w", err)
+		}
+	}
+	var models maasv1alpha1.MaaSModelRefList
+	if err := c.List(ctx, &models); err != nil {
+		return nil, fmt.Errorf("failed to list MaaSModelRefs for selection: %w", err)
+	}
+	var out []maasv1alpha1.ModelRef
+	for i := range models.Items {
+		if selectedModelRefFor(sub, &models.Items[i]) != nil {
+			out = append(out, maasv1alpha1.ModelRef{Name: models.Items[i].Name, Namespace: models.Items[i].Namespace})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return qualifiedName(out[i].Namespace, out[i].Name) < qualifiedName(out[j].Namespace, out[j].Name)
+	})
+	return out, nil
+}
+
+// expandModelRefs replaces the subscription's wildcard modelRefs with the selected
+// models, so policy generation treats them like listed models. Callers must not write
+// the subscription's spec back afterwards.
+func expandModelRefs(sub *maasv1alpha1.MaaSSubscription, selected []maasv1alpha1.ModelRef) {
+	refs := make([]maasv1alpha1.ModelSubscriptionRef, 0, len(sub.Spec.ModelRefs)+len(selected))
+	for _, ref := range sub.Spec.ModelRefs {
+		if !isWildcardModelRef(ref) {
+			refs = append(refs, ref)
+		}
+	}
+	for _, sel := range selected {
+		if wildcard := wildcardModelRef(sub, sel.Namespace); wildcard != nil {
+			ref := *wildcard
+			ref.Name, ref.Namespace = sel.Name, sel.Namespace
+			refs = append(refs, ref)
+		} else if sub.Spec.ModelSelector != nil {
+			refs = append(refs, selectedModelRef(sub.Spec.ModelSelector, sel.Namespace, sel.Name))
+		}
+	}
+	sub.Spec.ModelRefs = refs
+}
+
+// namedModelRefs returns the subscription's modelRefs that name a single model,
+// followed by the models it last selected.
+func namedModelRefs(sub *maasv1alpha1.MaaSSubscription) []maasv1alpha1.ModelRef {
+	refs := make([]maasv1alpha1.ModelRef, 0, len(sub.Spec.ModelRefs)+len(sub.Status.SelectedModelRefs))
+	for _, ref := range sub.Spec.ModelRefs {
+		if !isWildcardModelRef(ref) {
+			refs = append(refs, maasv1alpha1.ModelRef{Name: ref.Name, Namespace: ref.Namespace})
+		}
+	}
+	return append(refs, sub.Status.SelectedModelRefs...)
+}
+
+// findSubscriptionsSelectingModel returns the subscriptions whose wildcard modelRefs or
+// modelSelector select the model, with the model expanded into their modelRefs, and
+// excluding subscriptions that are being deleted. Selections cannot be indexed by the
+// model they match, so every subscription is checked.
+func findSubscriptionsSelectingModel(ctx context.Context, c client.Reader, model *maasv1alpha1.MaaSModelRef) ([]maasv1alpha1.MaaSSubscription, error) {
+	var all maasv1alpha1.MaaSSubscriptionList
+	if err := c.List(ctx, &all); err != nil {
+		return nil, fmt.Errorf("failed to list MaaSSubscriptions selecting models: %w", err)
+	}
+	var out []maasv1alpha1.MaaSSubscription
+	for i := range all.Items {
+		sub := &all.Items[i]
+		if !sub.GetDeletionTimestamp().IsZero() || selectedModelRefFor(sub, model) == nil {
+			continue
+		}
+		expandModelRefs(sub, []maasv1alpha1.ModelRef{{Name: model.Name, Namespace: model.Namespace}})
+		out = append(out, *sub)
+	}
+	return out, nil
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// newSelectorSubscription returns a subscription that selects models labelled
+// family=granite instead of listing them.
+func newSelectorSubscription(name, namespace, group string, limit int64) *maasv1alpha1.MaaSSubscription {
+	sub := newMaaSSubscription(name, namespace, group, "unused", limit)
+	sub.Spec.ModelSelector = &maasv1alpha1.ModelSelector{
+		Selector:        metav1.LabelSelector{MatchLabels: map[string]string{"family": "granite"}},
+		TokenRateLimits: sub.Spec.ModelRefs[0].TokenRateLimits,
+	}
+	sub.Spec.ModelRefs = nil
+	return sub
+}
+
+func TestSelectedModelRefFor(t *testing.T) {
+	model := newMaaSModelRef("llm", "models", "ExternalModel", "llm")
+	model.Labels = map[string]string{"family": "granite"}
+	sub := newSelectorSubscription("sub-a", "default", "team-a", 100)
+
+	if selectedModelRefFor(sub, model) == nil {
+		t.Error("expected the labelled model to be selected")
+	}
+	sub.Spec.ModelSelector.Namespaces = []string{"other"}
+	if selectedModelRefFor(sub, model) != nil {
+		t.Error("expected a model outside spec.modelSelector.namespaces not to be selected")
+	}
+	sub.Spec.ModelSelector.Namespaces = []string{"models"}
+	sub.Spec.ModelRefs = []maasv1alpha1.ModelSubscriptionRef{{Name: "llm", Namespace: "models"}}
+	if selectedModelRefFor(sub, model) != nil {
+		t.Error("expected a model listed in modelRefs not to be selected")
+	}
+	sub.Spec.ModelRefs = nil
+	model.Labels = map[string]string{"family": "llama"}
+	if selectedModelRefFor(sub, model) != nil {
+		t.Error("expected a model with other labels not to be selected")
+	}
+}
+
+func TestSelectedModelRefFor_Wildcards(t *testing.T) {
+	model := newMaaSModelRef("llm", "models", "ExternalModel", "llm")
+	sub := newMaaSSubscription("default", "default", "system:authenticated", "llm", 100)
+	rates := func(limit int64) []maasv1alpha1.TokenRateLimit {
+		return []maasv1alpha1.TokenRateLimit{{Limit: limit, Window: "1m"}}
+	}
+	sub.Spec.ModelRefs = []maasv1alpha1.ModelSubscriptionRef{
+		{Name: "*", Namespace: "*", TokenRateLimits: rates(10)},
+		{Name: "*", Namespace: "models", TokenRateLimits: rates(20)},
+	}
+
+	ref := selectedModelRefFor(sub, model)
+	if ref == nil || ref.Name != "llm" || ref.Namespace != "models" {
+		t.Fatalf("selectedModelRefFor = %v, want models/llm", ref)
+	}
+	if ref.TokenRateLimits[0].Limit != 20 {
+		t.Errorf("limit = %d, want the namespace wildcard's 20", ref.TokenRateLimits[0].Limit)
+	}
+
+	other := newMaaSModelRef("llm", "elsewhere", "ExternalModel", "llm")
+	if ref := selectedModelRefFor(sub, other); ref == nil || ref.TokenRateLimits[0].Limit != 10 {
+		t.Errorf("selectedModelRefFor = %v, want the global wildcard's limits", ref)
+	}
+
+	sub.Spec.ModelRefs = append(sub.Spec.ModelRefs, maasv1alpha1.ModelSubscriptionRef{Name: "llm", Namespace: "models", TokenRateLimits: rates(30)})
+	if ref := selectedModelRefFor(sub, model); ref != nil {
+		t.Errorf("expected a model listed by name not to be selected, got %v", ref)
+	}
+
+	expandModelRefs(sub, []maasv1alpha1.ModelRef{{Name: "llm", Namespace: "elsewhere"}})
+	if len(sub.Spec.ModelRefs) != 2 {
+		t.Fatalf("expanded modelRefs = %v, want the listed and the selected model", sub.Spec.ModelRefs)
+	}
+	for _, ref := range sub.Spec.ModelRefs {
+		if isWildcardModelRef(ref) {
+			t.Errorf("wildcard %s/%s left in the expanded modelRefs", ref.Namespace, ref.Name)
+		}
+	}
+}
+
+// TestMaaSSubscriptionReconciler_ModelSelector verifies that a subscription with only a
+// modelSelector gets limits on the models it selects and that relabelling a model
+// removes them.
+func TestMaaSSubscriptionReconciler_ModelSelector(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	model.Labels = map[string]string{"family": "granite"}
+	other := newMaaSModelRef("other", namespace, "ExternalModel", "other")
+	route := newHTTPRoute("maas-"+modelName, namespace)
+	sub := newSelectorSubscription("sub-a", namespace, "team-a", 100)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, other, route, newHTTPRoute("maas-other", namespace), sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	trlpKey := types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}
+	if err := c.Get(ctx, trlpKey, trlp); err != nil {
+		t.Fatalf("expected TokenRateLimitPolicy for the selected model: %v", err)
+	}
+	if _, found, _ := unstructured.NestedMap(trlp.Object, "spec", "limits", "default-sub-a-llm-tokens"); !found {
+		t.Errorf("expected limit default-sub-a-llm-tokens, got %v", trlp.Object["spec"])
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, "other", ""), Namespace: namespace}, trlp); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no TokenRateLimitPolicy for the unselected model, got err=%v", err)
+	}
+
+	current := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := current.Status.SelectedModelRefs; len(got) != 1 || got[0].Name != modelName {
+		t.Errorf("selectedModelRefs = %v, want [%s/%s]", got, namespace, modelName)
+	}
+	if len(current.Spec.ModelRefs) != 0 {
+		t.Errorf("selected models were written to spec.modelRefs: %v", current.Spec.ModelRefs)
+	}
+
+	relabelled := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, types.NamespacedName{Name: modelName, Namespace: namespace}, relabelled); err != nil {
+		t.Fatalf("Get model: %v", err)
+	}
+	relabelled.Labels = map[string]string{"family": "llama"}
+	if err := c.Update(ctx, relabelled); err != nil {
+		t.Fatalf("Update model: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after relabelling: %v", err)
+	}
+	if err := c.Get(ctx, trlpKey, trlp); !apierrors.IsNotFound(err) {
+		t.Errorf("expected TokenRateLimitPolicy to be deleted once the model is no longer selected, got err=%v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(current.Status.SelectedModelRefs) != 0 {
+		t.Errorf("selectedModelRefs = %v, want none", current.Status.SelectedModelRefs)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_WildcardModelRefs verifies that a "*" modelRef covers
+// every model, including one created later, and that an entry listing a model by name
+// keeps its own limits.
+func TestMaaSSubscriptionReconciler_WildcardModelRefs(t *testing.T) {
+	ctx := context.Background()
+	const namespace = "default"
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", "llm", 100)
+	sub.Spec.ModelRefs = append(sub.Spec.ModelRefs, maasv1alpha1.ModelSubscriptionRef{
+		Name:            modelRefWildcard,
+		Namespace:       modelRefWildcard,
+		TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 10, Window: "1m"}},
+	})
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(
+			newMaaSModelRef("llm", namespace, "ExternalModel", "llm"), newHTTPRoute("maas-llm", namespace),
+			newHTTPRoute("maas-other", namespace), sub,
+		).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	rate := func(model string) any {
+		trlp := &unstructured.Unstructured{}
+		trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+		if err := c.Get(ctx, types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, model, ""), Namespace: namespace}, trlp); err != nil {
+			t.Fatalf("expected TokenRateLimitPolicy for %s: %v", model, err)
+		}
+		rates, _, _ := unstructured.NestedSlice(trlp.Object, "spec", "limits", "default-sub-a-"+model+"-tokens", "rates")
+		if len(rates) != 1 {
+			t.Fatalf("%s rates = %v, want one rate", model, rates)
+		}
+		return rates[0].(map[string]any)["limit"]
+	}
+	if got := rate("llm"); got != int64(100) {
+		t.Errorf("llm limit = %v, want the listed entry's 100", got)
+	}
+
+	if err := c.Create(ctx, newMaaSModelRef("other", namespace, "ExternalModel", "other")); err != nil {
+		t.Fatalf("Create model: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after adding a model: %v", err)
+	}
+	if got := rate("other"); got != int64(10) {
+		t.Errorf("other limit = %v, want the wildcard's 10", got)
+	}
+	current := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := current.Status.SelectedModelRefs; len(got) != 1 || got[0].Name != "other" {
+		t.Errorf("selectedModelRefs = %v, want [%s/other]", got, namespace)
+	}
+}
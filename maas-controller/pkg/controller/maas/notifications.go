@@ -0,0 +1,346 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// DefaultNotificationTimeout bounds a single notification webhook request.
+const DefaultNotificationTimeout = 5 * time.Second
+
+// maxNotificationBody bounds the rendered body of a payload template.
+const maxNotificationBody = 64 << 10
+
+// defaultNotificationThresholds are the thresholds of spec.notifications when none are set.
+var defaultNotificationThresholds = []int32{80, 90, 100}
+
+// usageNotificationsTotal counts usage notifications by result.
+var usageNotificationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "maas_controller_usage_notifications_total",
+		Help: "Number of usage threshold notifications sent to MaaSSubscription webhooks, by result.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(usageNotificationsTotal)
+}
+
+// UsageNotification is the JSON body posted to a notification webhook.
+type UsageNotification struct {
+	// Subscription and Model are namespace/name references.
+	Subscription string `json:"subscription"`
+	Model        string `json:"model"`
+	// Threshold is the percentage of the limit that was crossed.
+	Threshold int32  `json:"threshold"`
+	Limit     int64  `json:"limit"`
+	Window    string `json:"window"`
+	Consumed  int64  `json:"consumed"`
+	Remaining int64  `json:"remaining"`
+	// Counter identifies the counter that crossed the threshold, e.g. the user ID.
+	Counter string    `json:"counter,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// UsageNotifier delivers usage notifications to a webhook.
+type UsageNotifier interface {
+	Notify(ctx context.Context, webhook maasv1alpha1.NotificationWebhook, notification UsageNotification) error
+}
+
+// WebhookUsageNotifier posts usage notifications over HTTP, as JSON or rendered with
+// the webhook's payload template.
+type WebhookUsageNotifier struct {
+	Client *http.Client
+}
+
+// NewWebhookUsageNotifier returns a WebhookUsageNotifier with the given request timeout.
+func NewWebhookUsageNotifier(timeout time.Duration) *WebhookUsageNotifier {
+	if timeout <= 0 {
+		timeout = DefaultNotificationTimeout
+	}
+	return &WebhookUsageNotifier{Client: &http.Client{Timeout: timeout}}
+}
+
+// Notify posts the notification and fails unless the webhook answers with a 2xx status.
+func (n *WebhookUsageNotifier) Notify(ctx context.Context, webhook maasv1alpha1.NotificationWebhook, notification UsageNotification) error {
+	body, err := RenderNotification(webhook, notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	contentType := webhook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("POST %s returned HTTP %d", webhook.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// RenderNotification returns the body posted to the webhook: the notification as JSON,
+// or rendered with the webhook's payload template.
+func RenderNotification(webhook maasv1alpha1.NotificationWebhook, notification UsageNotification) ([]byte, error) {
+	if webhook.PayloadTemplate == "" {
+		return json.Marshal(notification)
+	}
+	tmpl, err := parsePayloadTemplate(webhook.PayloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+	out := &limitedBuffer{max: maxNotificationBody}
+	if err := tmpl.Execute(out, notification); err != nil {
+		return nil, fmt.Errorf("failed to render payload template: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// payloadTemplateFuncs are the functions available to payload templates.
+var payloadTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// parsePayloadTemplate parses a payload template. Templates are written by subscription
+// owners and rendered by the controller, so loops and template definitions, which could
+// make a small template render indefinitely, are rejected.
+func parsePayloadTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(payloadTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload template: %w", err)
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, errors.New("invalid payload template: define and block are not allowed")
+	}
+	if err := checkTemplateNode(tmpl.Root); err != nil {
+		return nil, fmt.Errorf("invalid payload template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func checkTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkTemplateNode(child); err != nil {
+				return err
+			}
+		}
+	case *parse.RangeNode:
+		return errors.New("range is not allowed")
+	case *parse.TemplateNode:
+		return errors.New("template is not allowed")
+	case *parse.IfNode:
+		if err := checkTemplateNode(n.List); err != nil {
+			return err
+		}
+		return checkTemplateNode(n.ElseList)
+	case *parse.WithNode:
+		if err := checkTemplateNode(n.List); err != nil {
+			return err
+		}
+		return checkTemplateNode(n.ElseList)
+	}
+	return nil
+}
+
+// limitedBuffer is a buffer that fails writes beyond max bytes.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		return 0, fmt.Errorf("rendered payload exceeds %d bytes", b.max)
+	}
+	return b.Buffer.Write(p)
+}
+
+// ValidateNotifications validates spec.notifications. The MaaSSubscription webhook runs
+// the same checks at admission.
+func ValidateNotifications(spec *maasv1alpha1.NotificationSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if len(spec.Webhooks) == 0 {
+		return errors.New("at least one webhook is required")
+	}
+	for _, w := range spec.Webhooks {
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook URL %q must be an absolute http or https URL", w.URL)
+		}
+		if w.PayloadTemplate != "" {
+			if _, err := parsePayloadTemplate(w.PayloadTemplate); err != nil {
+				return fmt.Errorf("webhook %s: %w", w.URL, err)
+			}
+		}
+	}
+	seen := make(map[int32]struct{}, len(spec.Thresholds))
+	for _, t := range spec.Thresholds {
+		if t < 1 || t > 100 {
+			return fmt.Errorf("threshold %d must be between 1 and 100", t)
+		}
+		if _, dup := seen[t]; dup {
+			return fmt.Errorf("threshold %d is listed twice", t)
+		}
+		seen[t] = struct{}{}
+	}
+	return nil
+}
+
+// crossedThreshold returns the highest threshold the usage has reached, or 0 if none.
+func crossedThreshold(thresholds []int32, usage maasv1alpha1.ModelUsageStatus) int32 {
+	if usage.Limit <= 0 {
+		return 0
+	}
+	var crossed int32
+	for _, t := range thresholds {
+		if usage.Consumed*100 >= int64(t)*usage.Limit && t > crossed {
+			crossed = t
+		}
+	}
+	return crossed
+}
+
+// notifyUsage sends spec.notifications for the models whose usage crossed a higher
+// threshold than last notified, and records the notified thresholds in
+// status.notifications. A threshold whose delivery failed is retried at the next
+// collection. Models without collected usage keep their state.
+func (r *MaaSSubscriptionReconciler) notifyUsage(ctx context.Context, subscription *maasv1alpha1.MaaSSubscription) {
+	spec := subscription.Spec.Notifications
+	if spec == nil || r.UsageNotifier == nil || ValidateNotifications(spec) != nil {
+		subscription.Status.Notifications = nil
+		return
+	}
+	thresholds := spec.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = defaultNotificationThresholds
+	}
+	previous := map[string]maasv1alpha1.NotificationStatus{}
+	for _, ns := range subscription.Status.Notifications {
+		previous[ns.Namespace+"/"+ns.Name] = ns
+	}
+	collected := map[string]struct{}{}
+
+	var statuses []maasv1alpha1.NotificationStatus
+	for _, u := range subscription.Status.Usage {
+		model := u.Namespace + "/" + u.Name
+		collected[model] = struct{}{}
+		prev, notified := previous[model]
+		crossed := crossedThreshold(thresholds, u)
+		switch {
+		case crossed == 0:
+			continue
+		case notified && crossed <= prev.Threshold:
+			prev.Threshold = crossed
+			statuses = append(statuses, prev)
+			continue
+		}
+		now := time.Now()
+		notification := UsageNotification{
+			Subscription: subscription.Namespace + "/" + subscription.Name,
+			Model:        model,
+			Threshold:    crossed,
+			Limit:        u.Limit,
+			Window:       u.Window,
+			Consumed:     u.Consumed,
+			Remaining:    u.Remaining,
+			Counter:      u.Counter,
+			Time:         now.UTC(),
+		}
+		if failures := r.sendNotification(ctx, spec.Webhooks, notification); len(failures) > 0 {
+			if r.Recorder != nil {
+				r.Recorder.Eventf(subscription, corev1.EventTypeWarning, "UsageNotificationFailed",
+					"Failed to notify %d%% usage of model %s: %s", crossed, model, strings.Join(failures, "; "))
+			}
+			if notified {
+				statuses = append(statuses, prev)
+			}
+			continue
+		}
+		statuses = append(statuses, maasv1alpha1.NotificationStatus{
+			Name:             u.Name,
+			Namespace:        u.Namespace,
+			Threshold:        crossed,
+			LastNotifiedTime: &metav1.Time{Time: now},
+		})
+	}
+	for _, ref := range subscription.Spec.ModelRefs {
+		model := ref.Namespace + "/" + ref.Name
+		if _, done := collected[model]; done {
+			continue
+		}
+		if prev, notified := previous[model]; notified {
+			collected[model] = struct{}{}
+			statuses = append(statuses, prev)
+		}
+	}
+	subscription.Status.Notifications = statuses
+}
+
+// sendNotification posts a notification to every webhook and returns the failures.
+func (r *MaaSSubscriptionReconciler) sendNotification(ctx context.Context, webhooks []maasv1alpha1.NotificationWebhook, notification UsageNotification) []string {
+	var failures []string
+	for _, w := range webhooks {
+		if err := r.UsageNotifier.Notify(ctx, w, notification); err != nil {
+			usageNotificationsTotal.WithLabelValues("error").Inc()
+			failures = append(failures, err.Error())
+			continue
+		}
+		usageNotificationsTotal.WithLabelValues("success").Inc()
+	}
+	return failures
+}
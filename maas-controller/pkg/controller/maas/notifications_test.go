@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// fakeUsageNotifier records notifications and fails while err is set.
+type fakeUsageNotifier struct {
+	sent []UsageNotification
+	err  error
+}
+
+func (f *fakeUsageNotifier) Notify(_ context.Context, _ maasv1alpha1.NotificationWebhook, n UsageNotification) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func TestWebhookUsageNotifier_Notify(t *testing.T) {
+	var got UsageNotification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n := UsageNotification{Subscription: "default/sub-a", Model: "default/llm", Threshold: 90, Limit: 1000, Consumed: 910}
+	notifier := NewWebhookUsageNotifier(time.Second)
+	if err := notifier.Notify(context.Background(), maasv1alpha1.NotificationWebhook{URL: srv.URL + "/ok"}, n); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.Subscription != n.Subscription || got.Threshold != 90 || got.Consumed != 910 {
+		t.Errorf("webhook received %+v, want %+v", got, n)
+	}
+	if err := notifier.Notify(context.Background(), maasv1alpha1.NotificationWebhook{URL: srv.URL + "/fail"}, n); err == nil {
+		t.Error("expected an error for a non-2xx response")
+	}
+}
+
+func TestWebhookUsageNotifier_NotifyTemplate(t *testing.T) {
+	var contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	webhook := maasv1alpha1.NotificationWebhook{
+		URL:             srv.URL,
+		PayloadTemplate: `{"text": {{json (printf "%s reached %d%% of %d tokens on %s" .Subscription .Threshold .Limit .Model)}}}`,
+	}
+	n := UsageNotification{Subscription: "default/sub-a", Model: "default/llm", Threshold: 80, Limit: 1000, Consumed: 800}
+	if err := NewWebhookUsageNotifier(time.Second).Notify(context.Background(), webhook, n); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if want := `{"text": "default/sub-a reached 80% of 1000 tokens on default/llm"}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	webhook.PayloadTemplate = "{{.Model}}: {{.Consumed}}/{{.Limit}}"
+	webhook.ContentType = "text/plain"
+	if err := NewWebhookUsageNotifier(time.Second).Notify(context.Background(), webhook, n); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if body != "default/llm: 800/1000" || contentType != "text/plain" {
+		t.Errorf("got %q with Content-Type %q", body, contentType)
+	}
+}
+
+func TestRenderNotification_Limits(t *testing.T) {
+	n := UsageNotification{Subscription: "default/sub-a"}
+	webhook := maasv1alpha1.NotificationWebhook{PayloadTemplate: "{{.Unknown}}"}
+	if _, err := RenderNotification(webhook, n); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+	webhook.PayloadTemplate = `{{printf "%070000d" 1}}`
+	if _, err := RenderNotification(webhook, n); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("error = %v, want the body size limit", err)
+	}
+}
+
+func TestValidateNotifications(t *testing.T) {
+	webhooks := []maasv1alpha1.NotificationWebhook{{URL: "https://hooks.example.com/maas"}}
+	tests := []struct {
+		name    string
+		spec    *maasv1alpha1.NotificationSpec
+		wantErr bool
+	}{
+		{name: "unset"},
+		{name: "default thresholds", spec: &maasv1alpha1.NotificationSpec{Webhooks: webhooks}},
+		{name: "thresholds", spec: &maasv1alpha1.NotificationSpec{Webhooks: webhooks, Thresholds: []int32{50, 100}}},
+		{name: "no webhooks", spec: &maasv1alpha1.NotificationSpec{}, wantErr: true},
+		{name: "relative URL", spec: &maasv1alpha1.NotificationSpec{Webhooks: []maasv1alpha1.NotificationWebhook{{URL: "/hook"}}}, wantErr: true},
+		{name: "unsupported scheme", spec: &maasv1alpha1.NotificationSpec{Webhooks: []maasv1alpha1.NotificationWebhook{{URL: "ftp://example.com"}}}, wantErr: true},
+		{name: "threshold above 100", spec: &maasv1alpha1.NotificationSpec{Webhooks: webhooks, Thresholds: []int32{120}}, wantErr: true},
+		{name: "duplicate threshold", spec: &maasv1alpha1.NotificationSpec{Webhooks: webhooks, Thresholds: []int32{80, 80}}, wantErr: true},
+		{name: "payload template", spec: &maasv1alpha1.NotificationSpec{Webhooks: templated(`{{if ge .Threshold 100}}limit reached{{else}}{{.Threshold}}%{{end}}`)}},
+		{name: "unparsable template", spec: &maasv1alpha1.NotificationSpec{Webhooks: templated("{{.Threshold")}, wantErr: true},
+		{name: "unknown template function", spec: &maasv1alpha1.NotificationSpec{Webhooks: templated("{{env .Model}}")}, wantErr: true},
+		{name: "range in template", spec: &maasv1alpha1.NotificationSpec{Webhooks: templated(`{{with .Model}}{{range .}}x{{end}}{{end}}`)}, wantErr: true},
+		{name: "template definition", spec: &maasv1alpha1.NotificationSpec{Webhooks: templated(`{{define "a"}}{{template "a"}}{{end}}`)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidateNotifications(tt.spec); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func templated(payloadTemplate string) []maasv1alpha1.NotificationWebhook {
+	return []maasv1alpha1.NotificationWebhook{{URL: "https://hooks.example.com/maas", PayloadTemplate: payloadTemplate}}
+}
+
+func TestCrossedThreshold(t *testing.T) {
+	tests := []struct {
+		consumed, limit int64
+		want            int32
+	}{
+		{consumed: 790, limit: 1000, want: 0},
+		{consumed: 800, limit: 1000, want: 80},
+		{consumed: 999, limit: 1000, want: 90},
+		{consumed: 1000, limit: 1000, want: 100},
+		{consumed: 10, limit: 0, want: 0},
+	}
+	for _, tt := range tests {
+		usage := maasv1alpha1.ModelUsageStatus{Consumed: tt.consumed, Limit: tt.limit}
+		if got := crossedThreshold(defaultNotificationThresholds, usage); got != tt.want {
+			t.Errorf("crossedThreshold(%d/%d) = %d, want %d", tt.consumed, tt.limit, got, tt.want)
+		}
+	}
+}
+
+// TestNotifyUsage verifies that each threshold is notified once as usage climbs, that
+// failed deliveries are retried, and that falling usage re-arms the thresholds.
+func TestNotifyUsage(t *testing.T) {
+	ctx := context.Background()
+	sub := newMaaSSubscription("sub-a", "default", "team-a", "llm", 1000)
+	sub.Spec.Notifications = &maasv1alpha1.NotificationSpec{
+		Webhooks: []maasv1alpha1.NotificationWebhook{{URL: "https://hooks.example.com/maas"}},
+	}
+	notifier := &fakeUsageNotifier{}
+	r := &MaaSSubscriptionReconciler{UsageNotifier: notifier}
+
+	collect := func(consumed int64) {
+		t.Helper()
+		sub.Status.Usage = []maasv1alpha1.ModelUsageStatus{{
+			Name: "llm", Namespace: "default", Limit: 1000, Window: "1m",
+			Consumed: consumed, Remaining: 1000 - consumed, Counter: "alice",
+		}}
+		r.notifyUsage(ctx, sub)
+	}
+	notifiedThreshold := func() int32 {
+		if len(sub.Status.Notifications) == 0 {
+			return 0
+		}
+		return sub.Status.Notifications[0].Threshold
+	}
+
+	steps := []struct {
+		name          string
+		consumed      int64
+		err           error
+		wantSent      int
+		wantThreshold int32
+	}{
+		{name: "below thresholds", consumed: 500, wantSent: 0, wantThreshold: 0},
+		{name: "crosses 80%", consumed: 850, wantSent: 1, wantThreshold: 80},
+		{name: "still at 80%", consumed: 870, wantSent: 1, wantThreshold: 80},
+		{name: "skips to 100%", consumed: 1000, wantSent: 2, wantThreshold: 100},
+		{name: "window reset", consumed: 100, wantSent: 2, wantThreshold: 0},
+		{name: "delivery fails", consumed: 900, err: errors.New("connection refused"), wantSent: 2, wantThreshold: 0},
+		{name: "retried", consumed: 900, wantSent: 3, wantThreshold: 90},
+	}
+	for _, step := range steps {
+		notifier.err = step.err
+		collect(step.consumed)
+		if len(notifier.sent) != step.wantSent || notifiedThreshold() != step.wantThreshold {
+			t.Fatalf("%s: sent %d notifications with threshold %d, want %d and %d",
+				step.name, len(notifier.sent), notifiedThreshold(), step.wantSent, step.wantThreshold)
+		}
+	}
+	if last := notifier.sent[len(notifier.sent)-1]; last.Subscription != "default/sub-a" || last.Model != "default/llm" || last.Counter != "alice" || last.Threshold != 90 {
+		t.Errorf("unexpected notification: %+v", last)
+	}
+
+	// A model whose counters could not be read keeps its state.
+	sub.Status.Usage = nil
+	r.notifyUsage(ctx, sub)
+	if notifiedThreshold() != 90 {
+		t.Errorf("expected the notified threshold to be kept without usage, got %+v", sub.Status.Notifications)
+	}
+
+	sub.Spec.Notifications = nil
+	r.notifyUsage(ctx, sub)
+	if sub.Status.Notifications != nil {
+		t.Errorf("expected notification state to be cleared without spec.notifications, got %+v", sub.Status.Notifications)
+	}
+}
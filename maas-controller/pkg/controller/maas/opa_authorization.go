@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"sort"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// defaultOPARefreshSeconds is how often an external Rego policy is fetched again when
+// refreshSeconds is not set.
+const defaultOPARefreshSeconds = 300
+
+// opaPolicy is the spec.authorization.opa of a MaaSAuthPolicy with the models, as
+// "namespace/name", it applies to.
+type opaPolicy struct {
+	PolicyName string
+	Models     []string
+	OPA        maasv1alpha1.OPAPolicy
+}
+
+// policyOPA returns the spec.authorization.opa of a policy, or nil.
+func policyOPA(p *maasv1alpha1.MaaSAuthPolicy) *maasv1alpha1.OPAPolicy {
+	if p.Spec.Authorization == nil {
+		return nil
+	}
+	return p.Spec.Authorization.OPA
+}
+
+// aggregateOPAPolicies returns the OPA policies of the policies, sorted by policy name.
+func aggregateOPAPolicies(policies []maasv1alpha1.MaaSAuthPolicy) []opaPolicy {
+	var out []opaPolicy
+	for _, p := range policies {
+		opa := policyOPA(&p)
+		if opa == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		var models []string
+		for _, ref := range p.Spec.ModelRefs {
+			models = append(models, ref.Namespace+"/"+ref.Name)
+		}
+		out = append(out, opaPolicy{PolicyName: p.Name, Models: deduplicateAndSort(models), OPA: *opa.DeepCopy()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PolicyName < out[j].PolicyName })
+	return out
+}
+
+// opaAuthorizationRuleName is the gateway AuthPolicy rule of a MaaSAuthPolicy's OPA policy.
+func opaAuthorizationRuleName(policyName string) string {
+	return "opa-" + policyName
+}
+
+// addOPAAuthorizationRules adds an authorization rule per OPA policy, evaluated for the
+// requests to its models only. A model with several OPA policies requires all of them to
+// allow a request. They run after the generated rules, so an external policy is only
+// evaluated for requests the subjects already allow.
+func addOPAAuthorizationRules(authorization map[string]kuadrantv1.AuthorizationRule, policies []opaPolicy) {
+	for _, p := range policies {
+		opa := &kuadrantv1.OPAAuthorization{Rego: p.OPA.Rego}
+		if p.OPA.ExternalPolicyURL != "" {
+			ttl := p.OPA.RefreshSeconds
+			if ttl == 0 {
+				ttl = defaultOPARefreshSeconds
+			}
+			opa = &kuadrantv1.OPAAuthorization{ExternalPolicy: &kuadrantv1.ExternalOPAPolicy{URL: p.OPA.ExternalPolicyURL, TTL: ttl}}
+		}
+		authorization[opaAuthorizationRuleName(p.PolicyName)] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When:     []kuadrantv1.WhenCondition{{Predicate: celRequestedModelIn(p.Models)}},
+				Priority: 1,
+			},
+			OPA: opa,
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const exportControlRego = `allow {
+	input.auth.identity.user.username != "blocked"
+}`
+
+func newOPAAuthPolicy(name string, opa maasv1alpha1.OPAPolicy, refs ...maasv1alpha1.ModelRef) *maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-a", refs...)
+	p.Spec.Authorization = &maasv1alpha1.AuthorizationSpec{OPA: &opa}
+	return p
+}
+
+func TestAggregateOPAPolicies(t *testing.T) {
+	modelA := maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"}
+	modelB := maasv1alpha1.ModelRef{Name: "model-b", Namespace: "llm"}
+
+	inline := newOPAAuthPolicy("residency", maasv1alpha1.OPAPolicy{Rego: exportControlRego}, modelB, modelA, modelA)
+	external := newOPAAuthPolicy("export-control", maasv1alpha1.OPAPolicy{ExternalPolicyURL: "https://policies.example.com/export.rego"}, modelA)
+	plain := newMaaSAuthPolicy("plain", "default", "team-b", modelA)
+
+	got := aggregateOPAPolicies([]maasv1alpha1.MaaSAuthPolicy{*inline, *plain, *external})
+	if len(got) != 2 {
+		t.Fatalf("got %d OPA policies, want 2: %+v", len(got), got)
+	}
+	if got[0].PolicyName != "export-control" || got[1].PolicyName != "residency" {
+		t.Errorf("policies = %s, %s, want them sorted by name", got[0].PolicyName, got[1].PolicyName)
+	}
+	if models := strings.Join(got[1].Models, ","); models != "llm/model-a,llm/model-b" {
+		t.Errorf("residency models = %q, want llm/model-a,llm/model-b", models)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_OPA(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	opa := []opaPolicy{
+		{PolicyName: "residency", Models: []string{"llm/model-a"}, OPA: maasv1alpha1.OPAPolicy{Rego: exportControlRego}},
+		{PolicyName: "export-control", Models: []string{"llm/model-b"}, OPA: maasv1alpha1.OPAPolicy{ExternalPolicyURL: "https://policies.example.com/export.rego"}},
+		{PolicyName: "audited", Models: []string{"llm/model-b"}, OPA: maasv1alpha1.OPAPolicy{ExternalPolicyURL: "https://policies.example.com/audit.rego", RefreshSeconds: 60}},
+	}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{OPAPolicies: opa}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	rules := spec.Defaults.Rules.Authorization
+
+	residency, ok := rules["opa-residency"]
+	if !ok || residency.OPA == nil {
+		t.Fatalf("opa-residency rule missing: %+v", rules)
+	}
+	if residency.OPA.Rego != exportControlRego || residency.OPA.ExternalPolicy != nil {
+		t.Errorf("opa-residency should carry the inline Rego, got %+v", residency.OPA)
+	}
+	if !strings.Contains(residency.When[0].Predicate, `in ["llm/model-a"]`) {
+		t.Errorf("opa-residency should only apply to llm/model-a, got %q", residency.When[0].Predicate)
+	}
+	if residency.Priority != 1 {
+		t.Errorf("opa-residency priority = %d, want 1 so it runs after the generated rules", residency.Priority)
+	}
+
+	external := rules["opa-export-control"]
+	if external.OPA == nil || external.OPA.ExternalPolicy == nil || external.OPA.Rego != "" {
+		t.Fatalf("opa-export-control should reference the external policy, got %+v", external.OPA)
+	}
+	if external.OPA.ExternalPolicy.URL != "https://policies.example.com/export.rego" || external.OPA.ExternalPolicy.TTL != defaultOPARefreshSeconds {
+		t.Errorf("opa-export-control external policy = %+v, want the URL with the default refresh", external.OPA.ExternalPolicy)
+	}
+	if ttl := rules["opa-audited"].OPA.ExternalPolicy.TTL; ttl != 60 {
+		t.Errorf("opa-audited TTL = %d, want refreshSeconds 60", ttl)
+	}
+	if _, ok := rules["require-group-membership"]; !ok {
+		t.Error("the generated rules should be kept")
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// ownerLimits are the token rates of one entry of spec.owners for a model.
+type ownerLimits struct {
+	name   string
+	owner  maasv1alpha1.OwnerSpec
+	limits []maasv1alpha1.TokenRateLimit
+}
+
+// ValidateSubscriptionOwner validates an entry of spec.owners: it needs a group or user,
+// and its token rate limits follow the rules of a modelRef's. The MaaSSubscription
+// webhook runs the same checks at admission.
+func ValidateSubscriptionOwner(owner maasv1alpha1.SubscriptionOwner, schedule *maasv1alpha1.ResetSchedule) error {
+	if !hasPrincipals(owner.OwnerSpec) {
+		return errors.New("at least one group, user or service account is required")
+	}
+	if err := validateTokenRateLimits(owner.TokenRateLimits); err != nil {
+		return fmt.Errorf("invalid tokenRateLimits: %w", err)
+	}
+	if err := validateAnchoredRates(owner.TokenRateLimits, schedule); err != nil {
+		return fmt.Errorf("invalid tokenRateLimits: %w", err)
+	}
+	return nil
+}
+
+// validateSubscriptionOwners validates every entry of spec.owners.
+func validateSubscriptionOwners(sub *maasv1alpha1.MaaSSubscription) error {
+	for _, owner := range sub.Spec.Owners {
+		if err := ValidateSubscriptionOwner(owner, sub.Spec.ResetSchedule); err != nil {
+			return fmt.Errorf("owner %q: %w", owner.Name, err)
+		}
+	}
+	return nil
+}
+
+// subscriptionOwnerLimits returns the token rates of each entry of spec.owners for a
+// modelRef, with the modelRef's cost budget applied to them.
+func subscriptionOwnerLimits(sub *maasv1alpha1.MaaSSubscription, mRef maasv1alpha1.ModelSubscriptionRef, price *modelPrice) ([]ownerLimits, error) {
+	if err := validateSubscriptionOwners(sub); err != nil {
+		return nil, err
+	}
+	out := make([]ownerLimits, 0, len(sub.Spec.Owners))
+	for _, owner := range sub.Spec.Owners {
+		limits, err := withCostBudget(owner.TokenRateLimits, mRef.CostBudget, price)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ownerLimits{name: owner.Name, owner: owner.OwnerSpec, limits: limits})
+	}
+	return out, nil
+}
+
+// hasPrincipals reports whether an owner lists any group, user or service account.
+func hasPrincipals(owner maasv1alpha1.OwnerSpec) bool {
+	return len(owner.Groups) > 0 || len(owner.Users) > 0 || len(owner.ServiceAccounts) > 0
+}
+
+// serviceAccountUsername returns the username Kubernetes authenticates a service account
+// token as, e.g. "system:serviceaccount:batch:pipeline".
+func serviceAccountUsername(namespace, name string) string {
+	return "system:serviceaccount:" + namespace + ":" + name
+}
+
+// serviceAccountGroup returns the group of every service account in a namespace, e.g.
+// "system:serviceaccounts:batch".
+func serviceAccountGroup(namespace string) string {
+	return "system:serviceaccounts:" + namespace
+}
+
+// ownerPrincipals returns the usernames and groups of an owner, with its service
+// accounts in their Kubernetes username or namespace group form.
+func ownerPrincipals(owner maasv1alpha1.OwnerSpec) (users, groups []string) {
+	users = append(users, owner.Users...)
+	for _, g := range owner.Groups {
+		groups = append(groups, g.Name)
+	}
+	for _, sa := range owner.ServiceAccounts {
+		if sa.Name == "" {
+			groups = append(groups, serviceAccountGroup(sa.Namespace))
+		} else {
+			users = append(users, serviceAccountUsername(sa.Namespace, sa.Name))
+		}
+	}
+	return users, groups
+}
+
+// ownerMatchExpression returns a CEL expression that is true when the request's identity
+// is one of the owner's users or service accounts, or a member of one of its groups.
+// auth.identity.userid carries the TokenReview username of Kubernetes tokens, which is
+// system:serviceaccount:<namespace>:<name> for service accounts.
+func ownerMatchExpression(owner maasv1alpha1.OwnerSpec) string {
+	users, groups := ownerPrincipals(owner)
+	var terms []string
+	if len(users) > 0 {
+		terms = append(terms, "auth.identity.userid in "+celStringList(users))
+	}
+	if len(groups) > 0 {
+		terms = append(terms, "auth.identity.groups.exists(g, g in "+celStringList(groups)+")")
+	}
+	return "(" + strings.Join(terms, " || ") + ")"
+}
+
+func celStringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// ownerLimitKey returns the TRLP limit key of an entry of spec.owners for a model, e.g.
+// "<namespace>-<subscription>-<model>-owner-<owner>-tokens".
+func ownerLimitKey(subNamespace, subName, modelName, ownerName string) string {
+	return subscriptionLimitKey(subNamespace, subName, modelName, "owner-"+ownerName+"-tokens")
+}
+
+// addOwnerLimits adds a subscription's token limits for a model. Each entry of
+// spec.owners gets its own limit, matched in list order so that a user in several
+// entries is limited by the first only. The members of spec.owner that match no entry
+// get the modelRef's limits under the subscription's own key.
+func addOwnerLimits(limitsMap map[string]kuadrantv1.Limit, predicate string, sub *maasv1alpha1.MaaSSubscription, modelName string, limits []maasv1alpha1.TokenRateLimit, owners []ownerLimits) {
+	key := subscriptionLimitKey(sub.Namespace, sub.Name, modelName, "tokens")
+	if len(owners) == 0 {
+		addSubscriptionLimits(limitsMap, key, predicate, sub, limits)
+		return
+	}
+	var earlier []string
+	for _, o := range owners {
+		match := ownerMatchExpression(o.owner)
+		ownerPredicate := predicate + " && " + match
+		if len(earlier) > 0 {
+			ownerPredicate += " && !(" + strings.Join(earlier, " || ") + ")"
+		}
+		addSubscriptionLimits(limitsMap, ownerLimitKey(sub.Namespace, sub.Name, modelName, o.name), ownerPredicate, sub, o.limits)
+		earlier = append(earlier, match)
+	}
+	if hasPrincipals(sub.Spec.Owner) {
+		addSubscriptionLimits(limitsMap, key, predicate+" && !("+strings.Join(earlier, " || ")+")", sub, limits)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestOwnerMatchExpression(t *testing.T) {
+	got := ownerMatchExpression(maasv1alpha1.OwnerSpec{
+		Groups: []maasv1alpha1.GroupReference{{Name: "gold"}, {Name: "platinum"}},
+		Users:  []string{"alice"},
+	})
+	want := `(auth.identity.userid in ["alice"] || auth.identity.groups.exists(g, g in ["gold", "platinum"]))`
+	if got != want {
+		t.Errorf("ownerMatchExpression =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestOwnerMatchExpression_ServiceAccounts(t *testing.T) {
+	got := ownerMatchExpression(maasv1alpha1.OwnerSpec{
+		ServiceAccounts: []maasv1alpha1.ServiceAccountReference{
+			{Namespace: "batch", Name: "pipeline"},
+			{Namespace: "etl"},
+		},
+	})
+	want := `(auth.identity.userid in ["system:serviceaccount:batch:pipeline"] || auth.identity.groups.exists(g, g in ["system:serviceaccounts:etl"]))`
+	if got != want {
+		t.Errorf("ownerMatchExpression =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestBuildTRLPSpec_Owners verifies that each entry of spec.owners gets its own limit,
+// matched in order, and that the members of spec.owner keep the modelRef's limits.
+func TestBuildTRLPSpec_Owners(t *testing.T) {
+	sub := newMaaSSubscription("tiers", "default", "everyone", "llm", 100)
+	sub.Spec.Owners = []maasv1alpha1.SubscriptionOwner{
+		{
+			Name:            "gold",
+			OwnerSpec:       maasv1alpha1.OwnerSpec{Groups: []maasv1alpha1.GroupReference{{Name: "gold"}}},
+			TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 5000, Window: "1m"}},
+		},
+		{
+			Name:            "silver",
+			OwnerSpec:       maasv1alpha1.OwnerSpec{Users: []string{"bob"}},
+			TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}},
+		},
+	}
+
+	spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route")
+	if spec == nil {
+		t.Fatal("expected a TRLP spec")
+	}
+	if len(spec.Limits) != 3 {
+		t.Fatalf("expected limits for two owners and the subscription, got %v", spec.Limits)
+	}
+	gold := ownerMatchExpression(sub.Spec.Owners[0].OwnerSpec)
+	silver := ownerMatchExpression(sub.Spec.Owners[1].OwnerSpec)
+	tests := []struct {
+		key         string
+		rate        int64
+		contains    []string
+		notContains []string
+	}{
+		{key: "default-tiers-llm-owner-gold-tokens", rate: 5000, contains: []string{"&& " + gold}, notContains: []string{"!("}},
+		{key: "default-tiers-llm-owner-silver-tokens", rate: 1000, contains: []string{"&& " + silver, "!(" + gold + ")"}},
+		{key: "default-tiers-llm-tokens", rate: 100, contains: []string{"!(" + gold + " || " + silver + ")"}},
+	}
+	for _, tt := range tests {
+		limit, found := spec.Limits[tt.key]
+		if !found {
+			t.Errorf("missing limit %s in %v", tt.key, spec.Limits)
+			continue
+		}
+		if want := []kuadrantv1.Rate{{Limit: tt.rate, Window: "1m"}}; !reflect.DeepEqual(limit.Rates, want) {
+			t.Errorf("%s rates = %v, want %v", tt.key, limit.Rates, want)
+		}
+		predicate := limit.When[0].Predicate
+		if !strings.Contains(predicate, `auth.identity.selected_subscription_key == "default/tiers@default/llm"`) {
+			t.Errorf("%s predicate %q does not select the subscription", tt.key, predicate)
+		}
+		for _, s := range tt.contains {
+			if !strings.Contains(predicate, s) {
+				t.Errorf("%s predicate %q does not contain %q", tt.key, predicate, s)
+			}
+		}
+		for _, s := range tt.notContains {
+			if strings.Contains(predicate, s) {
+				t.Errorf("%s predicate %q contains %q", tt.key, predicate, s)
+			}
+		}
+	}
+
+	// Without spec.owner, only the owners' limits are generated.
+	sub.Spec.Owner = maasv1alpha1.OwnerSpec{}
+	spec, _ = buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route")
+	if _, found := spec.Limits["default-tiers-llm-tokens"]; found || len(spec.Limits) != 2 {
+		t.Errorf("expected only the owners' limits without spec.owner, got %v", spec.Limits)
+	}
+
+	sub.Spec.Owners[1].TokenRateLimits = append(sub.Spec.Owners[1].TokenRateLimits, maasv1alpha1.TokenRateLimit{Limit: 500, Window: "1h"})
+	if spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route"); spec != nil {
+		t.Errorf("expected a subscription with invalid owner limits to be skipped, got %v", spec)
+	}
+}
+
+func TestSubscriptionTokenLimitKeys_Owners(t *testing.T) {
+	sub := newMaaSSubscription("tiers", "default", "everyone", "llm", 100)
+	sub.Spec.Owners = []maasv1alpha1.SubscriptionOwner{{Name: "gold"}}
+	sub.Spec.ResetSchedule = &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily}
+	want := []string{
+		"default-tiers-llm-tokens",
+		"default-tiers-llm-owner-gold-tokens",
+		"default-tiers-llm-tokens-daily",
+		"default-tiers-llm-owner-gold-tokens-daily",
+	}
+	if got := subscriptionTokenLimitKeys(sub, "llm"); !reflect.DeepEqual(got, want) {
+		t.Errorf("subscriptionTokenLimitKeys = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// pathRuleCacheKeySuffix extends the cache key of the model access check with the
+// request's method and path, on which spec.rules make the decision depend.
+const pathRuleCacheKeySuffix = ` + "|" + request.method + " " + request.url_path`
+
+// pathRulesRego evaluates the spec.rules of the requested model's policies. model_path
+// is the request path without the /<namespace>/<name> prefix of model routes and
+// without the query string.
+const pathRulesRego = `url_parts := [p | p := split(split(request_path, "?")[0], "/")[_]; p != ""]
+
+model_path := concat("/", array.concat([""], array.slice(url_parts, 2, count(url_parts)))) {
+	path_model_identity != ""
+} else := concat("/", array.concat([""], url_parts))
+
+request_method := object.get(input.context.request.http, "method", "")
+
+path_rules := object.get(model_rules, "pathRules", []) {
+	model_rules != null
+} else := []
+
+path_matches(p) {
+	endswith(p, "*")
+	startswith(model_path, trim_suffix(p, "*"))
+}
+
+path_matches(p) {
+	not endswith(p, "*")
+	model_path == p
+}
+
+method_matches(rule) {
+	count(object.get(rule, "methods", [])) == 0
+}
+
+method_matches(rule) {
+	rule.methods[_] == request_method
+}
+
+path_rule_matches(rule) {
+	path_matches(rule.paths[_])
+	method_matches(rule)
+}
+
+path_denied {
+	rule := path_rules[_]
+	rule.action == "Deny"
+	path_rule_matches(rule)
+}
+
+path_allow_rules := [rule | rule := path_rules[_]; rule.action == "Allow"]
+
+path_allowed {
+	not path_denied
+	count(path_allow_rules) == 0
+}
+
+path_allowed {
+	not path_denied
+	path_rule_matches(path_allow_rules[_])
+}`
+
+// mergePathRules adds the rules of a policy to those of a model, dropping duplicates and
+// sorting them so that the generated AuthPolicy does not depend on the policy order.
+func mergePathRules(rules, add []maasv1alpha1.PathRule) ([]maasv1alpha1.PathRule, error) {
+	byKey := make(map[string]maasv1alpha1.PathRule, len(rules)+len(add))
+	for _, rule := range append(append([]maasv1alpha1.PathRule{}, rules...), add...) {
+		key, err := json.Marshal(rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal path rule: %w", err)
+		}
+		byKey[string(key)] = rule
+	}
+	if len(byKey) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	merged := make([]maasv1alpha1.PathRule, 0, len(keys))
+	for _, key := range keys {
+		merged = append(merged, byKey[key])
+	}
+	return merged, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestAggregateSubjectAllowlists_PathRules(t *testing.T) {
+	denyFiles := maasv1alpha1.PathRule{Paths: []string{"/v1/files*"}, Methods: []maasv1alpha1.HTTPMethod{"POST"}, Action: maasv1alpha1.PathRuleDeny}
+	allowChat := maasv1alpha1.PathRule{Paths: []string{"/v1/chat/completions", "/v1/models"}, Action: maasv1alpha1.PathRuleAllow}
+
+	a := newMaaSAuthPolicy("a", "default", "team-a", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"})
+	a.Spec.Rules = []maasv1alpha1.PathRule{denyFiles, allowChat}
+	b := newMaaSAuthPolicy("b", "default", "team-b", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}, maasv1alpha1.ModelRef{Name: "other", Namespace: "default"})
+	b.Spec.Rules = []maasv1alpha1.PathRule{denyFiles}
+
+	forward, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*a, *b})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists: %v", err)
+	}
+	reverse, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*b, *a})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists: %v", err)
+	}
+	if got := forward["default/llm"].PathRules; len(got) != 2 || !reflect.DeepEqual(got, reverse["default/llm"].PathRules) {
+		t.Errorf("expected the two distinct rules in a stable order, got %+v and %+v", got, reverse["default/llm"].PathRules)
+	}
+	if got := forward["default/other"].PathRules; !reflect.DeepEqual(got, []maasv1alpha1.PathRule{denyFiles}) {
+		t.Errorf("other rules = %+v, want only the deny rule", got)
+	}
+
+	allowlistsJSON, err := json.Marshal(forward)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if !strings.Contains(string(allowlistsJSON), `"pathRules":[{"paths":["/v1/files*"],"methods":["POST"],"action":"Deny"}]`) {
+		t.Errorf("expected the rules in the model access JSON, got %s", allowlistsJSON)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_PathRules(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	rule := spec.Defaults.Rules.Authorization["require-group-membership"]
+
+	if strings.Count(rule.OPA.Rego, "\tpath_allowed\n") != 3 {
+		t.Errorf("every model allow rule should require path_allowed, got: %s", rule.OPA.Rego)
+	}
+	if !strings.Contains(rule.OPA.Rego, `path_rules := object.get(model_rules, "pathRules", [])`) {
+		t.Errorf("rego should read the model's pathRules, got: %s", rule.OPA.Rego)
+	}
+	if !strings.Contains(rule.Cache.Key.Selector, "request.method") || !strings.Contains(rule.Cache.Key.Selector, "request.url_path") {
+		t.Errorf("cache key should include the request method and path, got: %s", rule.Cache.Key.Selector)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	tokenRateLimitPolicyPrefix = "maas-trlp"
+	rateLimitPolicyPrefix      = "maas-rlp"
+
+	// maxGeneratedPolicyName keeps generated policy names usable as label values.
+	maxGeneratedPolicyName = 63
+)
+
+// generatedPolicyName returns the name of a policy generated for a model, or for one of
+// the controller-owned HTTPRoutes that mirror the model's policies when route is set.
+// The readable part (the route, else the model name) is trimmed so the name fits in 63
+// characters, and a hash of the model's namespace and name and the route keeps names
+// distinct when readable parts collide, such as model "llm-failover" and the failover
+// route of model "llm". The model labels on the policy give the reverse lookup.
+func generatedPolicyName(prefix, modelNamespace, modelName, route string) string {
+	sum := sha256.Sum256([]byte(modelNamespace + "/" + modelName + "/" + route))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8]
+	readable := modelName
+	if route != "" {
+		readable = route
+	}
+	if budget := maxGeneratedPolicyName - len(prefix) - 1 - len(suffix); len(readable) > budget {
+		readable = strings.TrimRight(readable[:budget], "-.")
+	}
+	return prefix + "-" + readable + suffix
+}
+
+// tokenRateLimitPolicyName returns the name of the TokenRateLimitPolicy generated for a
+// model, or mirrored onto one of its routes when route is set.
+func tokenRateLimitPolicyName(modelNamespace, modelName, route string) string {
+	return generatedPolicyName(tokenRateLimitPolicyPrefix, modelNamespace, modelName, route)
+}
+
+// rateLimitPolicyName returns the name of the RateLimitPolicy generated for a model, or
+// mirrored onto one of its routes when route is set.
+func rateLimitPolicyName(modelNamespace, modelName, route string) string {
+	return generatedPolicyName(rateLimitPolicyPrefix, modelNamespace, modelName, route)
+}
+
+// legacyPolicyName returns the name a policy had before names were hashed:
+// <prefix>-<model>, or <prefix>-<route> for mirrored policies.
+func legacyPolicyName(prefix, modelName, route string) string {
+	if route != "" {
+		return prefix + "-" + route
+	}
+	return prefix + "-" + modelName
+}
+
+// getLegacyPolicy returns the policy the controller generated under its legacy name, or
+// nil if there is none. Objects under that name that maas-controller did not create, or
+// that belong to another model, are ignored.
+func (r *MaaSSubscriptionReconciler) getLegacyPolicy(ctx context.Context, gvk schema.GroupVersionKind, key types.NamespacedName, modelNamespace, modelName string) (*unstructured.Unstructured, error) {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, key, policy); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s: %w", gvk.Kind, key, err)
+	}
+	labels := policy.GetLabels()
+	if labels["app.kubernetes.io/managed-by"] != "maas-controller" || labels["maas.opendatahub.io/model"] != modelName {
+		return nil, nil
+	}
+	if ns := labels["maas.opendatahub.io/model-namespace"]; ns != "" && ns != modelNamespace {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// deleteLegacyPolicy deletes a policy returned by getLegacyPolicy once the policy under
+// the hashed name has replaced it. Policies opted out of management are kept.
+func (r *MaaSSubscriptionReconciler) deleteLegacyPolicy(ctx context.Context, log logr.Logger, legacy *unstructured.Unstructured) error {
+	if legacy == nil || !isManaged(legacy) {
+		return nil
+	}
+	if err := r.Delete(ctx, legacy); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s/%s: %w", legacy.GetKind(), legacy.GetNamespace(), legacy.GetName(), err)
+	}
+	log.Info("Deleted policy replaced by its hashed name", "kind", legacy.GetKind(), "name", legacy.GetName(), "namespace", legacy.GetNamespace())
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+func TestGeneratedPolicyName(t *testing.T) {
+	name := tokenRateLimitPolicyName("llm", "granite", "")
+	if name != tokenRateLimitPolicyName("llm", "granite", "") {
+		t.Error("expected the name to be deterministic")
+	}
+	if !strings.HasPrefix(name, "maas-trlp-granite-") {
+		t.Errorf("name %q does not start with the readable model name", name)
+	}
+	if tokenRateLimitPolicyName("other", "granite", "") == name {
+		t.Error("expected distinct names for models in distinct namespaces")
+	}
+	if tokenRateLimitPolicyName("llm", "granite-failover", "") == tokenRateLimitPolicyName("llm", "granite", "granite-failover") {
+		t.Error("expected a model named like another model's failover route to get a distinct name")
+	}
+
+	long := strings.Repeat("a", 63)
+	for _, name := range []string{
+		tokenRateLimitPolicyName("llm", long, ""),
+		rateLimitPolicyName("llm", long, long+"-concurrency-12345678"),
+	} {
+		if len(name) > maxGeneratedPolicyName {
+			t.Errorf("name %q is longer than %d characters", name, maxGeneratedPolicyName)
+		}
+	}
+}
+
+// TestMaaSSubscriptionReconciler_LegacyPolicyNames verifies that a TokenRateLimitPolicy
+// generated under its unhashed name is replaced by the hashed one, and that an opted-out
+// legacy policy is left in charge.
+func TestMaaSSubscriptionReconciler_LegacyPolicyNames(t *testing.T) {
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	legacyName := legacyPolicyName(tokenRateLimitPolicyPrefix, modelName, "")
+
+	tests := []struct {
+		name       string
+		optedOut   bool
+		wantLegacy bool
+		wantHashed bool
+	}{
+		{name: "managed legacy policy is replaced", wantHashed: true},
+		{name: "opted-out legacy policy is kept", optedOut: true, wantLegacy: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var annotations map[string]string
+			if tc.optedOut {
+				annotations = map[string]string{ManagedByODHOperator: "false"}
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testRESTMapper()).
+				WithObjects(
+					newMaaSModelRef(modelName, namespace, "ExternalModel", modelName),
+					newHTTPRoute("maas-"+modelName, namespace),
+					newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100),
+					newPreexistingTRLP(legacyName, namespace, modelName, annotations),
+				).
+				WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+				WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+				Build()
+			r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+
+			exists := func(name string) bool {
+				trlp := &unstructured.Unstructured{}
+				trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+				err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, trlp)
+				if err != nil && !apierrors.IsNotFound(err) {
+					t.Fatalf("Get %s: %v", name, err)
+				}
+				return err == nil
+			}
+			if got := exists(legacyName); got != tc.wantLegacy {
+				t.Errorf("legacy TokenRateLimitPolicy exists = %v, want %v", got, tc.wantLegacy)
+			}
+			if got := exists(tokenRateLimitPolicyName(namespace, modelName, "")); got != tc.wantHashed {
+				t.Errorf("hashed TokenRateLimitPolicy exists = %v, want %v", got, tc.wantHashed)
+			}
+		})
+	}
+}
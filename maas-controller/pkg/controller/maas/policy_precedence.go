@@ -0,0 +1,314 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// ConditionPolicyConflict is set True on a MaaSAuthPolicy whose rules for a model
+// contradict those of another policy of the model with the same spec.priority.
+const ConditionPolicyConflict = "Conflict"
+
+// policyConflict is a rule that policies of the same priority set in opposite ways for a
+// model, which spec.priority cannot resolve.
+type policyConflict struct {
+	Model string
+	// Rule describes the contradicting rule, e.g. `user "alice"`.
+	Rule string
+	// Policies are the "namespace/name" of the policies allowing and denying the rule.
+	Policies []string
+}
+
+func (c policyConflict) String() string {
+	return fmt.Sprintf("%s on model %s (%s)", c.Rule, c.Model, strings.Join(c.Policies, ", "))
+}
+
+// rulePrecedence tracks the highest priority among the policies setting a rule, and the
+// policies with that priority.
+type rulePrecedence struct {
+	priority int32
+	policies []string
+}
+
+func (p *rulePrecedence) add(priority int32, policy string) {
+	switch {
+	case len(p.policies) == 0 || priority > p.priority:
+		p.priority = priority
+		p.policies = []string{policy}
+	case priority == p.priority:
+		p.policies = append(p.policies, policy)
+	}
+}
+
+// modelPrecedence holds who allows and denies each user and path rule of a model.
+type modelPrecedence struct {
+	allowedUsers map[string]*rulePrecedence
+	deniedUsers  map[string]*rulePrecedence
+	allowedPaths map[string]*rulePrecedence
+	deniedPaths  map[string]*rulePrecedence
+}
+
+func addRulePrecedence(rules map[string]*rulePrecedence, key string, priority int32, policy string) {
+	if rules[key] == nil {
+		rules[key] = &rulePrecedence{}
+	}
+	rules[key].add(priority, policy)
+}
+
+// pathRuleKey identifies the requests a path rule matches, whatever its action.
+func pathRuleKey(rule maasv1alpha1.PathRule) string {
+	paths := deduplicateAndSort(append([]string(nil), rule.Paths...))
+	methods := make([]string, 0, len(rule.Methods))
+	for _, m := range rule.Methods {
+		methods = append(methods, string(m))
+	}
+	methods = deduplicateAndSort(methods)
+	return fmt.Sprintf("path rule %v %v", paths, methods)
+}
+
+// collectModelPrecedence records the priorities of the policies allowing and denying the
+// users and path rules of each model, keyed by "namespace/name".
+func collectModelPrecedence(policies []maasv1alpha1.MaaSAuthPolicy) map[string]*modelPrecedence {
+	out := map[string]*modelPrecedence{}
+	for _, p := range policies {
+		if !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		name := qualifiedName(p.Namespace, p.Name)
+		seen := map[string]bool{}
+		for _, ref := range p.Spec.ModelRefs {
+			key := ref.Namespace + "/" + ref.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			mp := out[key]
+			if mp == nil {
+				mp = &modelPrecedence{
+					allowedUsers: map[string]*rulePrecedence{},
+					deniedUsers:  map[string]*rulePrecedence{},
+					allowedPaths: map[string]*rulePrecedence{},
+					deniedPaths:  map[string]*rulePrecedence{},
+				}
+				out[key] = mp
+			}
+			for _, user := range deduplicateAndSort(append([]string(nil), p.Spec.Subjects.Users...)) {
+				addRulePrecedence(mp.allowedUsers, user, p.Spec.Priority, name)
+			}
+			for _, user := range deduplicateAndSort(append([]string(nil), p.Spec.Subjects.DeniedUsers...)) {
+				addRulePrecedence(mp.deniedUsers, user, p.Spec.Priority, name)
+			}
+			rules := map[string]map[maasv1alpha1.PathRuleAction]bool{}
+			for _, rule := range p.Spec.Rules {
+				k := pathRuleKey(rule)
+				if rules[k] == nil {
+					rules[k] = map[maasv1alpha1.PathRuleAction]bool{}
+				}
+				rules[k][rule.Action] = true
+			}
+			for k, actions := range rules {
+				if actions[maasv1alpha1.PathRuleAllow] {
+					addRulePrecedence(mp.allowedPaths, k, p.Spec.Priority, name)
+				}
+				if actions[maasv1alpha1.PathRuleDeny] {
+					addRulePrecedence(mp.deniedPaths, k, p.Spec.Priority, name)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// outranked returns the denials allowed by a policy of higher priority, and the conflicts
+// between an allow and a denial of the same priority, whose denial is kept.
+func outranked(allowed, denied map[string]*rulePrecedence, model, rule string) (overridden map[string]bool, conflicts []policyConflict) {
+	overridden = map[string]bool{}
+	for key, deny := range denied {
+		allow, ok := allowed[key]
+		if !ok {
+			continue
+		}
+		switch {
+		case allow.priority > deny.priority:
+			overridden[key] = true
+		case allow.priority == deny.priority:
+			conflicts = append(conflicts, policyConflict{
+				Model:    model,
+				Rule:     fmt.Sprintf(rule, key),
+				Policies: deduplicateAndSort(append(append([]string(nil), allow.policies...), deny.policies...)),
+			})
+		}
+	}
+	return overridden, conflicts
+}
+
+// modelOverrides are the denied users and Deny path rules of a model that a policy of
+// higher priority allows.
+type modelOverrides struct {
+	users map[string]bool
+	paths map[string]bool
+}
+
+// resolvePolicyPrecedence returns the overridden denials of each model, and the
+// contradictions priority could not resolve, sorted by model and rule.
+func resolvePolicyPrecedence(policies []maasv1alpha1.MaaSAuthPolicy) (map[string]modelOverrides, []policyConflict) {
+	overrides := map[string]modelOverrides{}
+	var conflicts []policyConflict
+	for model, mp := range collectModelPrecedence(policies) {
+		users, userConflicts := outranked(mp.allowedUsers, mp.deniedUsers, model, "user %q")
+		paths, pathConflicts := outranked(mp.allowedPaths, mp.deniedPaths, model, "%s")
+		conflicts = append(append(conflicts, userConflicts...), pathConflicts...)
+		if len(users) > 0 || len(paths) > 0 {
+			overrides[model] = modelOverrides{users: users, paths: paths}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Model != conflicts[j].Model {
+			return conflicts[i].Model < conflicts[j].Model
+		}
+		return conflicts[i].Rule < conflicts[j].Rule
+	})
+	return overrides, conflicts
+}
+
+// applyPolicyPrecedence drops from the aggregated allowlists the denied users and Deny
+// path rules that a policy of higher priority allows.
+func applyPolicyPrecedence(aggregate map[string]modelSubjectAllowlist, policies []maasv1alpha1.MaaSAuthPolicy) {
+	overrides, _ := resolvePolicyPrecedence(policies)
+	for model, o := range overrides {
+		entry, ok := aggregate[model]
+		if !ok {
+			continue
+		}
+		var denied []string
+		for _, u := range entry.DeniedUsers {
+			if !o.users[u] {
+				denied = append(denied, u)
+			}
+		}
+		entry.DeniedUsers = denied
+		var rules []maasv1alpha1.PathRule
+		for _, rule := range entry.PathRules {
+			if rule.Action == maasv1alpha1.PathRuleDeny && o.paths[pathRuleKey(rule)] {
+				continue
+			}
+			rules = append(rules, rule)
+		}
+		entry.PathRules = rules
+		aggregate[model] = entry
+	}
+}
+
+// detectPolicyConflicts returns the contradictions between the policy and the other
+// enforced MaaSAuthPolicies of its namespace that priority could not resolve.
+func (r *MaaSAuthPolicyReconciler) detectPolicyConflicts(ctx context.Context, policy *maasv1alpha1.MaaSAuthPolicy) ([]policyConflict, error) {
+	policies, err := r.listEnforcedAuthPolicies(ctx, policy.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	_, conflicts := resolvePolicyPrecedence(policies)
+	return policyConflictsOf(conflicts, policy), nil
+}
+
+// policyConflictsOf returns the conflicts the policy is part of.
+func policyConflictsOf(conflicts []policyConflict, policy *maasv1alpha1.MaaSAuthPolicy) []policyConflict {
+	name := qualifiedName(policy.Namespace, policy.Name)
+	var out []policyConflict
+	for _, c := range conflicts {
+		for _, p := range c.Policies {
+			if p == name {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// setPolicyConflictCondition records the contradictions with other policies that
+// spec.priority could not resolve.
+func setPolicyConflictCondition(policy *maasv1alpha1.MaaSAuthPolicy, conflicts []policyConflict) {
+	if len(conflicts) == 0 {
+		apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               ConditionPolicyConflict,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoConflict",
+			Message:            "Rules merge with the other MaaSAuthPolicies of the models",
+			ObservedGeneration: policy.GetGeneration(),
+		})
+		return
+	}
+	names := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		names = append(names, c.String())
+	}
+	msg := fmt.Sprintf("Rules contradicting a MaaSAuthPolicy with the same priority are denied: %s. "+
+		"Set distinct spec.priority values to choose the rule that applies.", strings.Join(names, "; "))
+	if len(msg) > 1024 {
+		msg = msg[:1021] + "..."
+	}
+	apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               ConditionPolicyConflict,
+		Status:             metav1.ConditionTrue,
+		Reason:             "UnresolvedConflict",
+		Message:            msg,
+		ObservedGeneration: policy.GetGeneration(),
+	})
+}
+
+// mapMaaSAuthPolicyToPeers returns reconcile requests for the other MaaSAuthPolicies in
+// the namespace that share a model with the given policy, so their Conflict conditions
+// follow its changes.
+func (r *MaaSAuthPolicyReconciler) mapMaaSAuthPolicyToPeers(ctx context.Context, obj client.Object) []reconcile.Request {
+	policy, ok := obj.(*maasv1alpha1.MaaSAuthPolicy)
+	if !ok {
+		return nil
+	}
+	models := map[string]bool{}
+	for _, ref := range policy.Spec.ModelRefs {
+		models[ref.Namespace+"/"+ref.Name] = true
+	}
+	var policies maasv1alpha1.MaaSAuthPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(policy.Namespace)); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range policies.Items {
+		if p.Name == policy.Name {
+			continue
+		}
+		for _, ref := range p.Spec.ModelRefs {
+			if models[ref.Namespace+"/"+ref.Name] {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.Name, Namespace: p.Namespace}})
+				break
+			}
+		}
+	}
+	return requests
+}
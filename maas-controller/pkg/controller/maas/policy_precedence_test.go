@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestAggregateSubjectAllowlists_Priority(t *testing.T) {
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	denyFiles := maasv1alpha1.PathRule{Paths: []string{"/v1/files*"}, Methods: []maasv1alpha1.HTTPMethod{"POST"}, Action: maasv1alpha1.PathRuleDeny}
+	allowFiles := maasv1alpha1.PathRule{Paths: []string{"/v1/files*"}, Methods: []maasv1alpha1.HTTPMethod{"POST"}, Action: maasv1alpha1.PathRuleAllow}
+
+	lockout := newMaaSAuthPolicy("lockout", "default", "team-a", llm)
+	lockout.Spec.Subjects.DeniedUsers = []string{"alice", "bob"}
+	lockout.Spec.Rules = []maasv1alpha1.PathRule{denyFiles}
+	grant := newMaaSAuthPolicy("grant", "default", "team-b", llm)
+	grant.Spec.Subjects.Users = []string{"alice"}
+	grant.Spec.Rules = []maasv1alpha1.PathRule{allowFiles}
+
+	t.Run("higher priority allow wins", func(t *testing.T) {
+		grant.Spec.Priority = 10
+		allowlists, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*lockout, *grant})
+		if err != nil {
+			t.Fatalf("aggregateSubjectAllowlists: %v", err)
+		}
+		entry := allowlists["default/llm"]
+		if !reflect.DeepEqual(entry.DeniedUsers, []string{"bob"}) {
+			t.Errorf("denied users = %v, want alice allowed by the higher priority policy", entry.DeniedUsers)
+		}
+		if !reflect.DeepEqual(entry.Users, []string{"alice"}) {
+			t.Errorf("users = %v, want the union of the policies' users", entry.Users)
+		}
+		if !reflect.DeepEqual(entry.PathRules, []maasv1alpha1.PathRule{allowFiles}) {
+			t.Errorf("path rules = %+v, want the deny rule dropped", entry.PathRules)
+		}
+		if _, conflicts := resolvePolicyPrecedence([]maasv1alpha1.MaaSAuthPolicy{*lockout, *grant}); len(conflicts) != 0 {
+			t.Errorf("expected distinct priorities to resolve the contradictions, got %v", conflicts)
+		}
+	})
+
+	t.Run("higher priority deny wins", func(t *testing.T) {
+		grant.Spec.Priority = 0
+		lockout.Spec.Priority = 5
+		allowlists, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*lockout, *grant})
+		if err != nil {
+			t.Fatalf("aggregateSubjectAllowlists: %v", err)
+		}
+		if got := allowlists["default/llm"].DeniedUsers; !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+			t.Errorf("denied users = %v, want both kept", got)
+		}
+		if got := allowlists["default/llm"].PathRules; len(got) != 2 {
+			t.Errorf("path rules = %+v, want the deny rule kept", got)
+		}
+	})
+
+	t.Run("equal priority keeps the denial and reports a conflict", func(t *testing.T) {
+		lockout.Spec.Priority = 0
+		policies := []maasv1alpha1.MaaSAuthPolicy{*lockout, *grant}
+		allowlists, err := aggregateSubjectAllowlists(policies)
+		if err != nil {
+			t.Fatalf("aggregateSubjectAllowlists: %v", err)
+		}
+		if got := allowlists["default/llm"].DeniedUsers; !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+			t.Errorf("denied users = %v, want the denial kept on a tie", got)
+		}
+		_, conflicts := resolvePolicyPrecedence(policies)
+		if len(conflicts) != 2 {
+			t.Fatalf("conflicts = %v, want the user and the path rule", conflicts)
+		}
+		if c := conflicts[1]; c.Rule != `user "alice"` || !reflect.DeepEqual(c.Policies, []string{"default/grant", "default/lockout"}) {
+			t.Errorf("conflict = %+v, want alice between grant and lockout", c)
+		}
+		if !strings.Contains(conflicts[0].Rule, "/v1/files*") {
+			t.Errorf("conflict = %+v, want the /v1/files* path rule", conflicts[0])
+		}
+	})
+}
+
+// TestMaaSAuthPolicyReconciler_PolicyConflictCondition verifies that a policy whose rule
+// contradicts a policy of the same priority reports Conflict, and stops once the
+// priorities differ.
+func TestMaaSAuthPolicyReconciler_PolicyConflictCondition(t *testing.T) {
+	const namespace = "default"
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: namespace}
+	lockout := newMaaSAuthPolicy("lockout", namespace, "team-a", llm)
+	lockout.Spec.Subjects.DeniedUsers = []string{"alice"}
+	grant := newMaaSAuthPolicy("grant", namespace, "team-b", llm)
+	grant.Spec.Subjects.Users = []string{"alice"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(newMaaSModelRef("llm", namespace, "ExternalModel", "llm"), newHTTPRoute("maas-llm", namespace), lockout, grant).
+		WithStatusSubresource(&maasv1alpha1.MaaSAuthPolicy{}).
+		Build()
+	r := &MaaSAuthPolicyReconciler{Client: c, Scheme: scheme, MaaSAPINamespace: "maas-system", GatewayNamespace: "gateway-ns", GatewayName: "maas-default-gateway"}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "grant", Namespace: namespace}
+
+	conflict := func() *metav1.Condition {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		got := &maasv1alpha1.MaaSAuthPolicy{}
+		if err := c.Get(ctx, key, got); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		return apimeta.FindStatusCondition(got.Status.Conditions, ConditionPolicyConflict)
+	}
+
+	cond := conflict()
+	if cond == nil || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, `user "alice" on model default/llm`) {
+		t.Fatalf("Conflict condition = %+v, want True naming alice", cond)
+	}
+
+	current := &maasv1alpha1.MaaSAuthPolicy{}
+	if err := c.Get(ctx, key, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	current.Spec.Priority = 1
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if cond := conflict(); cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Errorf("Conflict condition = %+v, want False once the priorities differ", cond)
+	}
+}
+
+func TestMapMaaSAuthPolicyToPeers(t *testing.T) {
+	llm := maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}
+	other := maasv1alpha1.ModelRef{Name: "other", Namespace: "default"}
+	a := newMaaSAuthPolicy("a", "models-as-a-service", "team-a", llm)
+	b := newMaaSAuthPolicy("b", "models-as-a-service", "team-b", other, llm)
+	unrelated := newMaaSAuthPolicy("c", "models-as-a-service", "team-c", other)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(a, b, unrelated).Build()
+	r := &MaaSAuthPolicyReconciler{Client: c}
+
+	got := r.mapMaaSAuthPolicyToPeers(context.Background(), a)
+	want := []ctrl.Request{{NamespacedName: types.NamespacedName{Name: "b", Namespace: "models-as-a-service"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("peers = %v, want %v", got, want)
+	}
+}
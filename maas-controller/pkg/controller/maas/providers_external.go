@@ -111,6 +111,10 @@ func (h *externalModelHandler) ReconcileRoute(ctx context.Context, log logr.Logg
 	}
 	routeNS := model.Namespace
 
+	if h.r.istioRouting() {
+		return h.reconcileVirtualService(ctx, log, model, routeName)
+	}
+
 	route := &gatewayapiv1.HTTPRoute{}
 	key := client.ObjectKey{Name: routeName, Namespace: routeNS}
 	if err := h.r.Get(ctx, key, route); err != nil {
@@ -129,6 +133,10 @@ func (h *externalModelHandler) ReconcileRoute(ctx context.Context, log logr.Logg
 		return fmt.Errorf("failed to get HTTPRoute %s/%s: %w", routeNS, routeName, err)
 	}
 
+	if err := h.r.reconcileBackendReferenceGrants(ctx, log, model, route); err != nil {
+		return err
+	}
+
 	expectedGatewayName := h.r.gatewayName()
 	expectedGatewayNamespace := h.r.gatewayNamespace()
 	gatewayFound := false
@@ -238,6 +246,14 @@ func (h *externalModelHandler) GetModelEndpoint(ctx context.Context, log logr.Lo
 		return fmt.Sprintf("https://%s/%s/%s", hostname, model.Namespace, extModelName), nil
 	}
 
+	if h.r.istioRouting() {
+		host, err := h.istioGatewayHost(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("https://%s/%s/%s", host, model.Namespace, extModelName), nil
+	}
+
 	gatewayName := h.r.gatewayName()
 	gatewayNS := h.r.gatewayNamespace()
 	gateway := &gatewayapiv1.Gateway{}
@@ -267,9 +283,11 @@ func (h *externalModelHandler) GetModelEndpoint(ctx context.Context, log logr.Lo
 }
 
 // CleanupOnDelete is called when the MaaSModelRef is deleted.
-// ExternalModel: the ExternalModel reconciler handles cleanup of all resources via finalizer.
+// ExternalModel: the ExternalModel reconciler handles cleanup of the route and backend
+// resources via finalizer; only the ReferenceGrants created for cross-namespace
+// backends are removed here.
 func (h *externalModelHandler) CleanupOnDelete(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
-	return nil
+	return h.r.deleteReferenceGrants(ctx, log, model, nil)
 }
 
 // externalModelRouteResolver returns the HTTPRoute name/namespace for ExternalModel.
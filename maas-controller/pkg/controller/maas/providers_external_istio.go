@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/reconciler/externalmodel"
+)
+
+var (
+	istioVirtualServiceGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "VirtualService"}
+	istioGatewayGVK        = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "Gateway"}
+)
+
+//+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch
+
+// istioRouting reports whether model routes are Istio VirtualServices instead of HTTPRoutes.
+func (r *MaaSModelRefReconciler) istioRouting() bool {
+	return r.RoutingProvider == externalmodel.RoutingProviderIstio
+}
+
+// routedByVirtualService reports whether model is routed by a VirtualService. Kuadrant
+// policies attach only to Gateway API resources, so nothing authenticates its requests.
+func (r *MaaSModelRefReconciler) routedByVirtualService(model *maasv1alpha1.MaaSModelRef) bool {
+	return r.istioRouting() && model.Spec.ModelRef.Kind == inferenceExternalModelGVK.Kind
+}
+
+// virtualServiceGateways returns the namespace/name of every Istio Gateway the
+// VirtualService binds to. Unqualified names are relative to the VirtualService namespace.
+func virtualServiceGateways(vs *unstructured.Unstructured) []types.NamespacedName {
+	refs, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	out := make([]types.NamespacedName, 0, len(refs))
+	for _, ref := range refs {
+		ns, name, found := strings.Cut(ref, "/")
+		if !found {
+			ns, name = vs.GetNamespace(), ref
+		}
+		out = append(out, types.NamespacedName{Name: name, Namespace: ns})
+	}
+	return out
+}
+
+// reconcileVirtualService validates the VirtualService created for an external model
+// when the controller runs with the istio routing provider, and populates status the
+// same way the HTTPRoute path does. VirtualServices have no acceptance status, so a
+// VirtualService bound to the configured gateway is treated as programmed.
+func (h *externalModelHandler) reconcileVirtualService(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef, name string) error {
+	ns := model.Namespace
+	vs := &unstructured.Unstructured{}
+	vs.SetGroupVersionKind(istioVirtualServiceGVK)
+	if err := h.r.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, vs); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("VirtualService not found for ExternalModel, waiting for ExternalModel reconciler to create it",
+				"name", name, "namespace", ns, "model", model.Name)
+			model.Status.Endpoint = ""
+			model.Status.HTTPRouteName = ""
+			model.Status.HTTPRouteNamespace = ""
+			model.Status.HTTPRouteGatewayName = ""
+			model.Status.HTTPRouteGatewayNamespace = ""
+			model.Status.HTTPRouteHostnames = nil
+			return nil
+		}
+		return fmt.Errorf("failed to get VirtualService %s/%s: %w", ns, name, err)
+	}
+
+	expected := types.NamespacedName{Name: h.r.gatewayName(), Namespace: h.r.gatewayNamespace()}
+	gateways := virtualServiceGateways(vs)
+	bound := false
+	for _, gw := range gateways {
+		if gw == expected {
+			bound = true
+			break
+		}
+	}
+	if !bound {
+		return fmt.Errorf("VirtualService %s/%s does not bind gateway %s (found: %v)", ns, name, expected, gateways)
+	}
+
+	var hostnames []string
+	hosts, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "hosts")
+	for _, host := range hosts {
+		if host != "*" {
+			hostnames = append(hostnames, host)
+		}
+	}
+
+	// The status fields keep their HTTPRoute names; they describe whichever route object is in use.
+	model.Status.HTTPRouteName = name
+	model.Status.HTTPRouteNamespace = ns
+	model.Status.HTTPRouteGatewayName = expected.Name
+	model.Status.HTTPRouteGatewayNamespace = expected.Namespace
+	model.Status.HTTPRouteHostnames = hostnames
+
+	log.Info("VirtualService validated for ExternalModel",
+		"name", name, "namespace", ns, "model", model.Name,
+		"gateway", expected.String(), "hostnames", hostnames)
+	return nil
+}
+
+// istioGatewayHost returns the first concrete host served by the configured Istio Gateway.
+// Server hosts may be "namespace/host" qualified; wildcards are skipped.
+func (h *externalModelHandler) istioGatewayHost(ctx context.Context) (string, error) {
+	gwName, gwNS := h.r.gatewayName(), h.r.gatewayNamespace()
+	gw := &unstructured.Unstructured{}
+	gw.SetGroupVersionKind(istioGatewayGVK)
+	if err := h.r.Get(ctx, client.ObjectKey{Name: gwName, Namespace: gwNS}, gw); err != nil {
+		return "", fmt.Errorf("failed to get Istio Gateway %s/%s: %w", gwNS, gwName, err)
+	}
+	servers, _, _ := unstructured.NestedSlice(gw.Object, "spec", "servers")
+	for _, s := range servers {
+		server, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		hosts, _, _ := unstructured.NestedStringSlice(server, "hosts")
+		for _, host := range hosts {
+			if _, after, found := strings.Cut(host, "/"); found {
+				host = after
+			}
+			if host != "" && !strings.HasPrefix(host, "*") {
+				return host, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unable to determine endpoint: Istio Gateway %s/%s has no concrete server host", gwNS, gwName)
+}
+
+// mapVirtualServiceToMaaSModelRefs returns reconcile requests for all MaaSModelRefs in the
+// VirtualService's namespace (the istio-provider counterpart of mapHTTPRouteToMaaSModelRefs).
+func (r *MaaSModelRefReconciler) mapVirtualServiceToMaaSModelRefs(ctx context.Context, obj client.Object) []reconcile.Request {
+	var models maasv1alpha1.MaaSModelRefList
+	if err := r.List(ctx, &models, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, m := range models.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: m.Name, Namespace: m.Namespace},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/modelnaming"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/reconciler/externalmodel"
+)
+
+// newVirtualService returns a VirtualService bound to the given Istio Gateway refs.
+func newVirtualService(name, ns string, gateways ...string) *unstructured.Unstructured {
+	gws := make([]any, 0, len(gateways))
+	for _, gw := range gateways {
+		gws = append(gws, gw)
+	}
+	vs := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"hosts": []any{"*"}, "gateways": gws},
+	}}
+	vs.SetGroupVersionKind(istioVirtualServiceGVK)
+	vs.SetName(name)
+	vs.SetNamespace(ns)
+	return vs
+}
+
+// newIstioGateway returns an Istio Gateway serving the given hosts.
+func newIstioGateway(name, ns string, hosts ...string) *unstructured.Unstructured {
+	hs := make([]any, 0, len(hosts))
+	for _, h := range hosts {
+		hs = append(hs, h)
+	}
+	gw := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"servers": []any{map[string]any{"hosts": hs}},
+		},
+	}}
+	gw.SetGroupVersionKind(istioGatewayGVK)
+	gw.SetName(name)
+	gw.SetNamespace(ns)
+	return gw
+}
+
+// TestExternalModel_ReconcileRoute_VirtualService verifies that with the istio routing
+// provider the handler validates the VirtualService instead of an HTTPRoute and builds
+// the endpoint from the Istio Gateway's server hosts.
+func TestExternalModel_ReconcileRoute_VirtualService(t *testing.T) {
+	model := newExternalModel("gpt-4o", "default", "openai", "api.openai.com")
+	externalModelCR := newExternalModelCR("gpt-4o", "default", "openai", "api.openai.com")
+	vs := newVirtualService(modelnaming.ExternalModelResourceName("gpt-4o"), "default", "openshift-ingress/maas-default-gateway")
+	gw := newIstioGateway("maas-default-gateway", "openshift-ingress", "*/*.wildcard.example.com", "openshift-ingress/maas.example.com")
+
+	r, _ := newTestReconcilerWithMapper(model, externalModelCR, vs, gw)
+	r.RoutingProvider = externalmodel.RoutingProviderIstio
+	handler := &externalModelHandler{r: r}
+	ctx := context.Background()
+
+	if err := handler.ReconcileRoute(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("ReconcileRoute: %v", err)
+	}
+	if model.Status.HTTPRouteName != "maas-gpt-4o" || model.Status.HTTPRouteGatewayName != "maas-default-gateway" ||
+		model.Status.HTTPRouteGatewayNamespace != "openshift-ingress" {
+		t.Errorf("unexpected route status: name=%q gateway=%s/%s", model.Status.HTTPRouteName,
+			model.Status.HTTPRouteGatewayNamespace, model.Status.HTTPRouteGatewayName)
+	}
+
+	endpoint, ready, err := handler.Status(ctx, logr.Discard(), model)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if !ready || endpoint != "https://maas.example.com/default/gpt-4o" {
+		t.Errorf("Status = (%q, %v), want (https://maas.example.com/default/gpt-4o, true)", endpoint, ready)
+	}
+}
+
+// TestMaaSModelRefReconciler_VirtualServiceNotReady verifies that a model routed by a
+// VirtualService stays Pending even with spec.requirePolicies=false and an enforced gateway
+// AuthPolicy, since no AuthPolicy attaches to VirtualService routes.
+func TestMaaSModelRefReconciler_VirtualServiceNotReady(t *testing.T) {
+	ctx := context.Background()
+	model := newExternalModel("gpt-4o", "default", "openai", "api.openai.com")
+	notRequired := false
+	model.Spec.RequirePolicies = &notRequired
+	externalModelCR := newExternalModelCR("gpt-4o", "default", "openai", "api.openai.com")
+	vs := newVirtualService(modelnaming.ExternalModelResourceName("gpt-4o"), "default", "openshift-ingress/maas-default-gateway")
+	gw := newIstioGateway("maas-default-gateway", "openshift-ingress", "maas.example.com")
+	sub := newMaaSSubscription("sub1", "admin-ns", "team-a", "gpt-4o", 100)
+	sub.Spec.ModelRefs[0].Namespace = "default"
+	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a", maasv1alpha1.ModelRef{Name: "gpt-4o", Namespace: "default"})
+	gwPolicy := newKuadrantAuthPolicy(maasGatewayAuthPolicyName, "openshift-ingress", "Gateway", "maas-default-gateway", true)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, externalModelCR, vs, gw, sub, auth, gwPolicy).
+		WithStatusSubresource(&maasv1alpha1.MaaSModelRef{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, modelRefIndexKey, subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSModelRefReconciler{
+		Client: c, Scheme: scheme,
+		GatewayName: "maas-default-gateway", GatewayNamespace: "openshift-ingress",
+		RoutingProvider: externalmodel.RoutingProviderIstio,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "gpt-4o", Namespace: "default"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != "Pending" || got.Status.Endpoint != "" {
+		t.Errorf("Phase=%q Endpoint=%q, want Pending with no endpoint", got.Status.Phase, got.Status.Endpoint)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionPoliciesEnforced)
+	if cond == nil || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, "VirtualService") {
+		t.Errorf("expected PoliciesEnforced=False naming VirtualService routes, got %+v", cond)
+	}
+}
+
+func TestExternalModel_ReconcileRoute_VirtualServiceWrongGateway(t *testing.T) {
+	model := newExternalModel("gpt-4o", "default", "openai", "api.openai.com")
+	externalModelCR := newExternalModelCR("gpt-4o", "default", "openai", "api.openai.com")
+	vs := newVirtualService(modelnaming.ExternalModelResourceName("gpt-4o"), "default", "other-gateway")
+
+	r, _ := newTestReconcilerWithMapper(model, externalModelCR, vs)
+	r.RoutingProvider = externalmodel.RoutingProviderIstio
+	handler := &externalModelHandler{r: r}
+
+	err := handler.ReconcileRoute(context.Background(), logr.Discard(), model)
+	if err == nil || !strings.Contains(err.Error(), "does not bind gateway") {
+		t.Fatalf("expected gateway binding error, got %v", err)
+	}
+	if model.Status.HTTPRouteGatewayName != "" {
+		t.Errorf("gateway status must stay empty for an unbound VirtualService, got %q", model.Status.HTTPRouteGatewayName)
+	}
+}
+
+func TestVirtualServiceGateways(t *testing.T) {
+	vs := newVirtualService("vs", "llm", "istio-system/public", "local-gw")
+	got := virtualServiceGateways(vs)
+	if len(got) != 2 || got[0].String() != "istio-system/public" || got[1].String() != "llm/local-gw" {
+		t.Errorf("virtualServiceGateways = %v, want [istio-system/public llm/local-gw]", got)
+	}
+}
@@ -133,12 +133,7 @@ func (h *llmisvcHandler) Status(ctx context.Context, log logr.Logger, model *maa
 		}
 		return "", false, err
 	}
-	for _, c := range llmisvc.Status.Conditions {
-		if c.Type == "Ready" && c.Status == "True" {
-			ready = true
-			break
-		}
-	}
+	ready = llmisvcReady(llmisvc)
 	if !ready {
 		return "", false, nil
 	}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch;create;update;patch;delete
+
+// maxReferenceGrantName keeps generated ReferenceGrant names within the label value limit.
+const maxReferenceGrantName = 63
+
+// referenceGrantName returns the name of the ReferenceGrant generated for a model in a
+// backend namespace, where the grants of models from many namespaces meet. The name ends
+// with a hash of the model's namespace and name, so that e.g. "team-a"/"llm" and
+// "team"/"a-llm" get different grants; the labels of referenceGrantLabels record both.
+func referenceGrantName(modelNamespace, modelName string) string {
+	sum := sha256.Sum256([]byte(modelNamespace + "/" + modelName))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8]
+	readable := modelName
+	if budget := maxReferenceGrantName - len("maas-") - len(suffix); len(readable) > budget {
+		readable = strings.TrimRight(readable[:budget], "-.")
+	}
+	return "maas-" + readable + suffix
+}
+
+// referenceGrantLabels returns the labels that tie a generated ReferenceGrant to its
+// MaaSModelRef. ReferenceGrants live in the backend namespace, so an OwnerReference to
+// the model is not possible; cleanup finds them by these labels instead.
+func referenceGrantLabels(model *maasv1alpha1.MaaSModelRef) map[string]string {
+	return map[string]string{
+		"maas.opendatahub.io/model":           model.Name,
+		"maas.opendatahub.io/model-namespace": model.Namespace,
+		"app.kubernetes.io/managed-by":        "maas-controller",
+		"app.kubernetes.io/part-of":           "maas-model-ref",
+		"app.kubernetes.io/component":         "reference-grant",
+	}
+}
+
+// crossNamespaceBackendServices returns, per namespace, the sorted names of the Services
+// the route sends traffic to outside its own namespace.
+func crossNamespaceBackendServices(route *gatewayapiv1.HTTPRoute) map[string][]string {
+	seen := make(map[string]map[string]struct{})
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if ref.Namespace == nil || string(*ref.Namespace) == route.Namespace {
+				continue
+			}
+			if ref.Group != nil && *ref.Group != "" {
+				continue
+			}
+			if ref.Kind != nil && *ref.Kind != "Service" {
+				continue
+			}
+			ns := string(*ref.Namespace)
+			if seen[ns] == nil {
+				seen[ns] = make(map[string]struct{})
+			}
+			seen[ns][string(ref.Name)] = struct{}{}
+		}
+	}
+
+	out := make(map[string][]string, len(seen))
+	for ns, names := range seen {
+		for name := range names {
+			out[ns] = append(out[ns], name)
+		}
+		sort.Strings(out[ns])
+	}
+	return out
+}
+
+// buildReferenceGrant returns the ReferenceGrant that lets the model's HTTPRoute
+// reference the given Services in namespace ns.
+func buildReferenceGrant(model *maasv1alpha1.MaaSModelRef, route *gatewayapiv1.HTTPRoute, ns string, services []string) *gatewayapiv1beta1.ReferenceGrant {
+	to := make([]gatewayapiv1beta1.ReferenceGrantTo, 0, len(services))
+	for _, svc := range services {
+		name := gatewayapiv1.ObjectName(svc)
+		to = append(to, gatewayapiv1beta1.ReferenceGrantTo{Group: "", Kind: "Service", Name: &name})
+	}
+	return &gatewayapiv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      referenceGrantName(model.Namespace, model.Name),
+			Namespace: ns,
+			Labels:    referenceGrantLabels(model),
+		},
+		Spec: gatewayapiv1beta1.ReferenceGrantSpec{
+			From: []gatewayapiv1beta1.ReferenceGrantFrom{{
+				Group:     gatewayapiv1.GroupName,
+				Kind:      "HTTPRoute",
+				Namespace: gatewayapiv1.Namespace(route.Namespace),
+			}},
+			To: to,
+		},
+	}
+}
+
+// reconcileBackendReferenceGrants creates or updates a ReferenceGrant in every namespace
+// the model's HTTPRoute references a backend Service in, and deletes grants for
+// namespaces the route no longer references. Without a grant the gateway rejects
+// cross-namespace backendRefs and the route silently never serves traffic.
+func (r *MaaSModelRefReconciler) reconcileBackendReferenceGrants(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef, route *gatewayapiv1.HTTPRoute) error {
+	desired := crossNamespaceBackendServices(route)
+
+	for ns, services := range desired {
+		grant := buildReferenceGrant(model, route, ns, services)
+		existing := &gatewayapiv1beta1.ReferenceGrant{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(grant), existing)
+		if apierrors.IsNotFound(err) {
+			if err := r.Create(ctx, grant); err != nil {
+				return fmt.Errorf("failed to create ReferenceGrant %s/%s: %w", ns, grant.Name, err)
+			}
+			log.Info("ReferenceGrant created for cross-namespace backend", "name", grant.Name, "namespace", ns, "services", services)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get ReferenceGrant %s/%s: %w", ns, grant.Name, err)
+		}
+		if !isManaged(existing) {
+			log.Info("ReferenceGrant opted out, skipping update", "name", existing.Name, "namespace", ns)
+			continue
+		}
+		snapshot := existing.DeepCopy()
+		if existing.Labels == nil {
+			existing.Labels = make(map[string]string)
+		}
+		for k, v := range grant.Labels {
+			existing.Labels[k] = v
+		}
+		existing.Spec = grant.Spec
+		if equality.Semantic.DeepEqual(snapshot, existing) {
+			continue
+		}
+		if err := r.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update ReferenceGrant %s/%s: %w", ns, existing.Name, err)
+		}
+		log.Info("ReferenceGrant updated for cross-namespace backend", "name", existing.Name, "namespace", ns, "services", services)
+	}
+
+	return r.deleteReferenceGrants(ctx, log, model, desired)
+}
+
+// deleteReferenceGrants deletes the model's managed ReferenceGrants, except those in
+// namespaces listed in keep.
+func (r *MaaSModelRefReconciler) deleteReferenceGrants(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef, keep map[string][]string) error {
+	grants := &gatewayapiv1beta1.ReferenceGrantList{}
+	if err := r.List(ctx, grants, client.MatchingLabels(referenceGrantLabels(model))); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list ReferenceGrants for model %s/%s: %w", model.Namespace, model.Name, err)
+	}
+	for i := range grants.Items {
+		g := &grants.Items[i]
+		if _, ok := keep[g.Namespace]; ok {
+			continue
+		}
+		if !isManaged(g) {
+			log.Info("ReferenceGrant opted out, skipping deletion", "name", g.Name, "namespace", g.Namespace)
+			continue
+		}
+		log.Info("Deleting ReferenceGrant no longer needed by model", "name", g.Name, "namespace", g.Namespace, "model", model.Namespace+"/"+model.Name)
+		if err := r.Delete(ctx, g); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ReferenceGrant %s/%s: %w", g.Namespace, g.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/modelnaming"
+)
+
+// withBackendRef appends a rule sending traffic to the named Service. An empty ns
+// leaves the backendRef namespace unset (same namespace as the route).
+func withBackendRef(route *gatewayapiv1.HTTPRoute, ns, service string) *gatewayapiv1.HTTPRoute {
+	port := gatewayapiv1.PortNumber(443)
+	ref := gatewayapiv1.HTTPBackendRef{BackendRef: gatewayapiv1.BackendRef{
+		BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: gatewayapiv1.ObjectName(service), Port: &port},
+	}}
+	if ns != "" {
+		backendNS := gatewayapiv1.Namespace(ns)
+		ref.Namespace = &backendNS
+	}
+	route.Spec.Rules = append(route.Spec.Rules, gatewayapiv1.HTTPRouteRule{BackendRefs: []gatewayapiv1.HTTPBackendRef{ref}})
+	return route
+}
+
+func TestCrossNamespaceBackendServices(t *testing.T) {
+	route := newHTTPRoute("maas-gpt-4o", "models")
+	withBackendRef(route, "", "local-svc")
+	withBackendRef(route, "models", "explicit-local-svc")
+	withBackendRef(route, "backends", "svc-b")
+	withBackendRef(route, "backends", "svc-a")
+	withBackendRef(route, "backends", "svc-a")
+	withBackendRef(route, "other", "svc-c")
+
+	got := crossNamespaceBackendServices(route)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 backend namespaces, got %v", got)
+	}
+	if want := []string{"svc-a", "svc-b"}; len(got["backends"]) != 2 || got["backends"][0] != want[0] || got["backends"][1] != want[1] {
+		t.Errorf("backends services = %v, want %v", got["backends"], want)
+	}
+	if len(got["other"]) != 1 || got["other"][0] != "svc-c" {
+		t.Errorf("other services = %v, want [svc-c]", got["other"])
+	}
+}
+
+func TestReferenceGrantName(t *testing.T) {
+	if a, b := referenceGrantName("team-a", "llm"), referenceGrantName("team", "a-llm"); a == b {
+		t.Errorf("models team-a/llm and team/a-llm share the ReferenceGrant name %q", a)
+	}
+	if got := referenceGrantName("team-a", "llm"); !strings.HasPrefix(got, "maas-llm-") {
+		t.Errorf("name = %q, want the model name after the maas- prefix", got)
+	}
+	long := referenceGrantName("models", strings.Repeat("m", 80))
+	if len(long) > maxReferenceGrantName {
+		t.Errorf("name %q is longer than %d characters", long, maxReferenceGrantName)
+	}
+}
+
+// TestExternalModel_ReconcileRoute_ReferenceGrant verifies that a ReferenceGrant is created
+// in the backend namespace for a cross-namespace backendRef, removed once the route no
+// longer needs it, and cleaned up when the model is deleted.
+func TestExternalModel_ReconcileRoute_ReferenceGrant(t *testing.T) {
+	const (
+		modelNS   = "default"
+		backendNS = "backends"
+	)
+	model := newExternalModel("gpt-4o", modelNS, "openai", "api.openai.com")
+	externalModelCR := newExternalModelCR("gpt-4o", modelNS, "openai", "api.openai.com")
+	route := newHTTPRouteWithGateway(modelnaming.ExternalModelResourceName("gpt-4o"), modelNS, "maas-default-gateway", "openshift-ingress")
+	withBackendRef(route, backendNS, "openai-proxy")
+
+	r, c := newTestReconciler(model, externalModelCR, route)
+	r.GatewayName = "maas-default-gateway"
+	r.GatewayNamespace = "openshift-ingress"
+	handler := &externalModelHandler{r: r}
+	ctx := context.Background()
+
+	if err := handler.ReconcileRoute(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("ReconcileRoute: %v", err)
+	}
+
+	grantKey := types.NamespacedName{Name: referenceGrantName(modelNS, "gpt-4o"), Namespace: backendNS}
+	grant := &gatewayapiv1beta1.ReferenceGrant{}
+	if err := c.Get(ctx, grantKey, grant); err != nil {
+		t.Fatalf("expected ReferenceGrant %s: %v", grantKey, err)
+	}
+	if grant.Labels["maas.opendatahub.io/model"] != "gpt-4o" || grant.Labels["maas.opendatahub.io/model-namespace"] != modelNS {
+		t.Errorf("ReferenceGrant missing model owner labels: %v", grant.Labels)
+	}
+	if len(grant.Spec.From) != 1 || grant.Spec.From[0].Kind != "HTTPRoute" || string(grant.Spec.From[0].Namespace) != modelNS {
+		t.Errorf("unexpected ReferenceGrant from: %+v", grant.Spec.From)
+	}
+	if len(grant.Spec.To) != 1 || grant.Spec.To[0].Kind != "Service" || grant.Spec.To[0].Name == nil || *grant.Spec.To[0].Name != "openai-proxy" {
+		t.Errorf("unexpected ReferenceGrant to: %+v", grant.Spec.To)
+	}
+
+	// Route moves back to a same-namespace backend: the grant is no longer needed.
+	if err := c.Get(ctx, types.NamespacedName{Name: route.Name, Namespace: modelNS}, route); err != nil {
+		t.Fatalf("Get HTTPRoute: %v", err)
+	}
+	route.Spec.Rules = nil
+	withBackendRef(route, "", "openai-proxy")
+	if err := c.Update(ctx, route); err != nil {
+		t.Fatalf("Update HTTPRoute: %v", err)
+	}
+	if err := handler.ReconcileRoute(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("ReconcileRoute after route change: %v", err)
+	}
+	if err := c.Get(ctx, grantKey, grant); !apierrors.IsNotFound(err) {
+		t.Errorf("expected stale ReferenceGrant to be deleted, got err=%v", err)
+	}
+
+	// Deleting the model removes any grant still present.
+	withBackendRef(route, backendNS, "openai-proxy")
+	if err := c.Update(ctx, route); err != nil {
+		t.Fatalf("Update HTTPRoute: %v", err)
+	}
+	if err := handler.ReconcileRoute(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("ReconcileRoute: %v", err)
+	}
+	if err := c.Get(ctx, grantKey, grant); err != nil {
+		t.Fatalf("expected ReferenceGrant to be recreated: %v", err)
+	}
+	if err := handler.CleanupOnDelete(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("CleanupOnDelete: %v", err)
+	}
+	if err := c.Get(ctx, grantKey, grant); !apierrors.IsNotFound(err) {
+		t.Errorf("expected ReferenceGrant to be deleted on model deletion, got err=%v", err)
+	}
+}
+
+// TestExternalModel_ReconcileRoute_ReferenceGrantOptOut verifies that an opted-out
+// ReferenceGrant is neither updated nor deleted.
+func TestExternalModel_ReconcileRoute_ReferenceGrantOptOut(t *testing.T) {
+	const (
+		modelNS   = "default"
+		backendNS = "backends"
+	)
+	model := newExternalModel("gpt-4o", modelNS, "openai", "api.openai.com")
+	externalModelCR := newExternalModelCR("gpt-4o", modelNS, "openai", "api.openai.com")
+	route := newHTTPRouteWithGateway(modelnaming.ExternalModelResourceName("gpt-4o"), modelNS, "maas-default-gateway", "openshift-ingress")
+	withBackendRef(route, backendNS, "openai-proxy")
+
+	grant := buildReferenceGrant(model, route, backendNS, []string{"hand-edited"})
+	grant.Annotations = map[string]string{ManagedByODHOperator: "false"}
+
+	r, c := newTestReconciler(model, externalModelCR, route, grant)
+	r.GatewayName = "maas-default-gateway"
+	r.GatewayNamespace = "openshift-ingress"
+	handler := &externalModelHandler{r: r}
+	ctx := context.Background()
+
+	if err := handler.ReconcileRoute(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("ReconcileRoute: %v", err)
+	}
+	if err := handler.CleanupOnDelete(ctx, logr.Discard(), model); err != nil {
+		t.Fatalf("CleanupOnDelete: %v", err)
+	}
+
+	got := &gatewayapiv1beta1.ReferenceGrant{}
+	if err := c.Get(ctx, types.NamespacedName{Name: grant.Name, Namespace: backendNS}, got); err != nil {
+		t.Fatalf("expected opted-out ReferenceGrant to be kept: %v", err)
+	}
+	if len(got.Spec.To) != 1 || *got.Spec.To[0].Name != "hand-edited" {
+		t.Errorf("opted-out ReferenceGrant was modified: %+v", got.Spec.To)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+//+kubebuilder:rbac:groups=kuadrant.io,resources=ratelimitpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// buildRLPSpec builds the aggregated RateLimitPolicy spec for a model from the request
+// rate limits of the given subscriptions, mirroring buildTRLPSpec: one limit per
+// subscription, selected by the subscription key and counted per spec.counterScope.
+// Suspended subscriptions get a zero rate that denies all their requests. A nil spec
+// means no subscription sets request rate limits for the model and none is suspended.
+func buildRLPSpec(log logr.Logger, allSubs []maasv1alpha1.MaaSSubscription, modelNamespace, modelName, httpRouteName string) (*kuadrantv1.RateLimitPolicySpec, []string) {
+	limitsMap := map[string]kuadrantv1.Limit{}
+	var subNames []string
+	for _, sub := range allSubs {
+		for _, mRef := range sub.Spec.ModelRefs {
+			if mRef.Namespace != modelNamespace || mRef.Name != modelName {
+				continue
+			}
+			subRef := fmt.Sprintf("%s/%s", sub.Namespace, sub.Name)
+			modelScopedRef := fmt.Sprintf("%s@%s/%s", subRef, mRef.Namespace, mRef.Name)
+			// Model discovery does not count against the request quota, as for tokens.
+			predicate := fmt.Sprintf(`auth.identity.selected_subscription_key == "%s" && !request.path.endsWith("/v1/models")`, modelScopedRef)
+			key := subscriptionLimitKey(sub.Namespace, sub.Name, mRef.Name, "requests")
+			if sub.Spec.Suspended {
+				// Token limits are only checked against tokens already used, so a zero
+				// token rate alone would let requests through; the deny happens here.
+				limitsMap[key] = suspendedLimit(predicate)
+				subNames = append(subNames, qualifiedName(sub.Namespace, sub.Name))
+				break
+			}
+			if len(mRef.RequestRateLimits) == 0 {
+				break
+			}
+			// Request rates follow the same rules as token rates, so reuse their validation.
+			limits := make([]maasv1alpha1.TokenRateLimit, 0, len(mRef.RequestRateLimits))
+			for _, rrl := range mRef.RequestRateLimits {
+				limits = append(limits, maasv1alpha1.TokenRateLimit{Limit: rrl.Limit, Window: rrl.Window})
+			}
+			err := ValidateResetSchedule(sub.Spec.ResetSchedule)
+			if err == nil {
+				err = ValidateCounterExpressions(sub.Spec.CounterExpressions)
+			}
+			if err == nil {
+				err = ValidateModelRefRateLimits(mRef, sub.Spec.ResetSchedule)
+			}
+			if err != nil {
+				log.Error(err, "Skipping subscription with invalid request rate limits — fix the spec to include it in RateLimitPolicy",
+					"subscription", sub.Name, "model", modelNamespace+"/"+modelName)
+				break
+			}
+
+			addSubscriptionLimits(limitsMap, key, predicate, &sub, limits)
+			subNames = append(subNames, qualifiedName(sub.Namespace, sub.Name))
+			break
+		}
+	}
+	if len(limitsMap) == 0 {
+		return nil, nil
+	}
+	sort.Strings(subNames)
+	return &kuadrantv1.RateLimitPolicySpec{
+		TargetRef: kuadrantv1.TargetRef{
+			Group: "gateway.networking.k8s.io",
+			Kind:  "HTTPRoute",
+			Name:  httpRouteName,
+		},
+		Limits: limitsMap,
+	}, subNames
+}
+
+// reconcileRLP creates, updates, or deletes the RateLimitPolicy for the model's request
+// rate limits on route, which is the model's own HTTPRoute unless mirrorRoute names it.
+// The policy is owned by the route, like the TokenRateLimitPolicy next to it.
+func (r *MaaSSubscriptionReconciler) reconcileRLP(ctx context.Context, log logr.Logger, modelNamespace, modelName, mirrorRoute string, route *gatewayapiv1.HTTPRoute, allSubs []maasv1alpha1.MaaSSubscription) error {
+	policyName := rateLimitPolicyName(modelNamespace, modelName, mirrorRoute)
+	legacy, err := r.getLegacyPolicy(ctx, kuadrantv1.RateLimitPolicyGVK,
+		types.NamespacedName{Name: legacyPolicyName(rateLimitPolicyPrefix, modelName, mirrorRoute), Namespace: route.Namespace}, modelNamespace, modelName)
+	if err != nil {
+		return err
+	}
+	if legacy != nil && !isManaged(legacy) {
+		return nil
+	}
+	spec, subNames := buildRLPSpec(log, allSubs, modelNamespace, modelName, route.Name)
+	if spec == nil {
+		if err := r.deleteLegacyPolicy(ctx, log, legacy); err != nil {
+			return err
+		}
+		return r.deleteRLP(ctx, log, types.NamespacedName{Name: policyName, Namespace: route.Namespace})
+	}
+	specMap, err := kuadrantv1.ToUnstructured(spec)
+	if err != nil {
+		return err
+	}
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK)
+	policy.SetName(policyName)
+	policy.SetNamespace(route.Namespace)
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if !isManaged(policy) {
+			return nil
+		}
+		labels := policy.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["maas.opendatahub.io/model"] = modelName
+		labels["maas.opendatahub.io/model-namespace"] = modelNamespace
+		labels["app.kubernetes.io/managed-by"] = "maas-controller"
+		labels["app.kubernetes.io/part-of"] = "maas-subscription"
+		labels["app.kubernetes.io/component"] = "rate-limit-policy"
+		policy.SetLabels(labels)
+		annotations := policy.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations["maas.opendatahub.io/subscriptions"] = strings.Join(subNames, ",")
+		policy.SetAnnotations(annotations)
+		if err := controllerutil.SetControllerReference(route, policy, r.Scheme); err != nil {
+			return err
+		}
+		return unstructured.SetNestedMap(policy.Object, specMap, "spec")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply RateLimitPolicy for model %s/%s: %w", modelNamespace, modelName, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("RateLimitPolicy applied", "name", policyName, "model", modelNamespace+"/"+modelName, "subscriptions", subNames, "operation", op)
+	}
+	return r.deleteLegacyPolicy(ctx, log, legacy)
+}
+
+// deleteRLP deletes a generated RateLimitPolicy unless it is opted out of management.
+func (r *MaaSSubscriptionReconciler) deleteRLP(ctx context.Context, log logr.Logger, key types.NamespacedName) error {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK)
+	if err := r.Get(ctx, key, policy); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get RateLimitPolicy %s: %w", key, err)
+	}
+	if !isManaged(policy) || policy.GetLabels()["app.kubernetes.io/managed-by"] != "maas-controller" {
+		return nil
+	}
+	if err := r.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete RateLimitPolicy %s: %w", key, err)
+	}
+	log.Info("RateLimitPolicy deleted (no request rate limits remain)", "name", key.Name, "namespace", key.Namespace)
+	return nil
+}
+
+// deleteModelRLPs deletes every generated RateLimitPolicy of a model, found by label
+// across namespaces like the TokenRateLimitPolicies.
+func (r *MaaSSubscriptionReconciler) deleteModelRLPs(ctx context.Context, log logr.Logger, modelNamespace, modelName string) error {
+	policyList := &unstructured.UnstructuredList{}
+	policyList.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK.GroupVersion().WithKind("RateLimitPolicyList"))
+	if err := r.List(ctx, policyList, client.MatchingLabels{
+		"maas.opendatahub.io/model":           modelName,
+		"maas.opendatahub.io/model-namespace": modelNamespace,
+		"app.kubernetes.io/managed-by":        "maas-controller",
+		"app.kubernetes.io/part-of":           "maas-subscription",
+	}); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list RateLimitPolicy for cleanup: %w", err)
+	}
+	for i := range policyList.Items {
+		p := &policyList.Items[i]
+		if !isManaged(p) {
+			log.Info("RateLimitPolicy opted out, skipping deletion", "name", p.GetName(), "namespace", p.GetNamespace(), "model", modelNamespace+"/"+modelName)
+			continue
+		}
+		log.Info("Deleting RateLimitPolicy (no remaining parent subscriptions)", "name", p.GetName(), "namespace", p.GetNamespace(), "model", modelNamespace+"/"+modelName)
+		if err := r.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete RateLimitPolicy %s/%s: %w", p.GetNamespace(), p.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestBuildRLPSpec(t *testing.T) {
+	withRequests := newMaaSSubscription("sub-a", "default", "team-a", "llm", 100)
+	withRequests.Spec.ModelRefs[0].RequestRateLimits = []maasv1alpha1.RequestRateLimit{{Limit: 60, Window: "1m"}}
+	tokensOnly := newMaaSSubscription("sub-b", "default", "team-b", "llm", 100)
+
+	spec, subNames := buildRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*withRequests, *tokensOnly}, "default", "llm", "llm-route")
+	if spec == nil {
+		t.Fatal("expected a RateLimitPolicy spec")
+	}
+	if len(subNames) != 1 || subNames[0] != "default/sub-a" {
+		t.Errorf("subNames = %v, want [default/sub-a]", subNames)
+	}
+	if len(spec.Limits) != 1 {
+		t.Fatalf("expected 1 limit, got %v", spec.Limits)
+	}
+	rates := spec.Limits["default-sub-a-llm-requests"].Rates
+	if len(rates) != 1 {
+		t.Fatalf("expected 1 rate for default-sub-a-llm-requests, got %v", spec.Limits)
+	}
+	if rates[0] != (kuadrantv1.Rate{Limit: 60, Window: "1m"}) {
+		t.Errorf("rate = %v, want limit 60 window 1m", rates[0])
+	}
+
+	if spec, _ := buildRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*tokensOnly}, "default", "llm", "llm-route"); spec != nil {
+		t.Errorf("expected no spec without request rate limits, got %v", spec)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_RequestRateLimits verifies that request rate limits produce
+// a RateLimitPolicy next to the TRLP and that removing them deletes it.
+func TestMaaSSubscriptionReconciler_RequestRateLimits(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute("maas-"+modelName, namespace)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+	sub.Spec.ModelRefs[0].RequestRateLimits = []maasv1alpha1.RequestRateLimit{{Limit: 60, Window: "1m"}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	rlp := &unstructured.Unstructured{}
+	rlp.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK)
+	key := types.NamespacedName{Name: rateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}
+	if err := c.Get(ctx, key, rlp); err != nil {
+		t.Fatalf("expected RateLimitPolicy: %v", err)
+	}
+	if target, _, _ := unstructured.NestedString(rlp.Object, "spec", "targetRef", "name"); target != route.Name {
+		t.Errorf("RateLimitPolicy targets %q, want %q", target, route.Name)
+	}
+	if counter, _, _ := unstructured.NestedSlice(rlp.Object, "spec", "limits", "default-sub-a-llm-requests", "counters"); len(counter) != 1 {
+		t.Errorf("expected a per-user counter, got %v", counter)
+	}
+
+	current := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	current.Spec.ModelRefs[0].RequestRateLimits = nil
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after removing request rate limits: %v", err)
+	}
+	if err := c.Get(ctx, key, rlp); !apierrors.IsNotFound(err) {
+		t.Errorf("expected RateLimitPolicy to be deleted, got err=%v", err)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// Defaults used when a RequeueBackoff field is left zero.
+const (
+	DefaultRequeueInitialDelay = 5 * time.Second
+	DefaultRequeueMaxDelay     = 5 * time.Minute
+	DefaultRequeueMaxWait      = 30 * time.Minute
+)
+
+// RequeueBackoff controls how a reconciler polls for a dependency that does not
+// exist yet (e.g. the HTTPRoute KServe creates for an LLMInferenceService).
+// Watches normally trigger reconciliation as soon as the dependency shows up;
+// the requeue is a safety net for missed or filtered events.
+type RequeueBackoff struct {
+	// InitialDelay is the first requeue delay. Subsequent delays double up to MaxDelay.
+	InitialDelay time.Duration
+	// MaxDelay caps a single requeue delay.
+	MaxDelay time.Duration
+	// MaxWait is how long to keep requeueing before reporting the dependency as
+	// missing; after that only watch events trigger reconciliation.
+	MaxWait time.Duration
+}
+
+func (b RequeueBackoff) withDefaults() RequeueBackoff {
+	if b.InitialDelay <= 0 {
+		b.InitialDelay = DefaultRequeueInitialDelay
+	}
+	if b.MaxDelay <= 0 {
+		b.MaxDelay = DefaultRequeueMaxDelay
+	}
+	if b.MaxDelay < b.InitialDelay {
+		b.MaxDelay = b.InitialDelay
+	}
+	if b.MaxWait <= 0 {
+		b.MaxWait = DefaultRequeueMaxWait
+	}
+	return b
+}
+
+// Next returns the delay before the next check, given how long the dependency has
+// been missing. Each delay equals the time already waited, so delays double from
+// InitialDelay without tracking an attempt counter; the last delay is shortened to
+// land on the MaxWait deadline. ok is false once MaxWait has elapsed.
+func (b RequeueBackoff) Next(waited time.Duration) (delay time.Duration, ok bool) {
+	b = b.withDefaults()
+	if waited >= b.MaxWait {
+		return 0, false
+	}
+	delay = max(waited, b.InitialDelay)
+	delay = min(delay, b.MaxDelay, b.MaxWait-waited)
+	return delay, true
+}
+
+const (
+	// reasonHTTPRouteNotFound is the WaitingForRoute reason while the controller is still requeueing.
+	reasonHTTPRouteNotFound = "HTTPRouteNotFound"
+	// reasonRouteWaitExceeded is used once MaxWait has elapsed without the HTTPRoute appearing.
+	reasonRouteWaitExceeded = "RouteWaitExceeded"
+)
+
+// waitForRoute records that the model's HTTPRoute is missing and decides when to look
+// again. The WaitingForRoute condition's lastTransitionTime marks when the wait began,
+// so the backoff survives controller restarts. Returns the phase and message to report.
+func (r *MaaSModelRefReconciler) waitForRoute(model *maasv1alpha1.MaaSModelRef, cause error) (ctrl.Result, string, string) {
+	backoff := r.RouteRequeue.withDefaults()
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionWaitingForRoute,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonHTTPRouteNotFound,
+		Message:            cause.Error(),
+		ObservedGeneration: model.GetGeneration(),
+	}
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+	since := apimeta.FindStatusCondition(model.Status.Conditions, maasv1alpha1.ConditionWaitingForRoute).LastTransitionTime
+
+	delay, ok := backoff.Next(time.Since(since.Time))
+	if !ok {
+		cond.Reason = reasonRouteWaitExceeded
+		cond.Message = fmt.Sprintf("HTTPRoute still missing after %s; no longer requeueing, will reconcile when it is created: %v", backoff.MaxWait, cause)
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return ctrl.Result{}, "Failed", fmt.Sprintf("HTTPRoute not found after waiting %s", backoff.MaxWait)
+	}
+	return ctrl.Result{RequeueAfter: delay}, "Pending", "Waiting for HTTPRoute to be created"
+}
+
+// waitForRouteReadyReason returns the Ready condition reason for the phase returned by waitForRoute.
+func waitForRouteReadyReason(phase string) string {
+	if phase == "Failed" {
+		return reasonRouteWaitExceeded
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestRequeueBackoff_Next(t *testing.T) {
+	b := RequeueBackoff{InitialDelay: time.Second, MaxDelay: 8 * time.Second, MaxWait: 20 * time.Second}
+
+	tests := []struct {
+		name      string
+		waited    time.Duration
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{"first attempt uses initial delay", 0, time.Second, true},
+		{"doubles with time waited", 2 * time.Second, 2 * time.Second, true},
+		{"capped at max delay", 10 * time.Second, 8 * time.Second, true},
+		{"shortened to land on max wait", 15 * time.Second, 5 * time.Second, true},
+		{"stops at max wait", 20 * time.Second, 0, false},
+		{"stops after max wait", time.Hour, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := b.Next(tt.waited)
+			if delay != tt.wantDelay || ok != tt.wantOK {
+				t.Errorf("Next(%s) = (%s, %v), want (%s, %v)", tt.waited, delay, ok, tt.wantDelay, tt.wantOK)
+			}
+		})
+	}
+
+	if delay, ok := (RequeueBackoff{}).Next(0); delay != DefaultRequeueInitialDelay || !ok {
+		t.Errorf("zero-value Next(0) = (%s, %v), want (%s, true)", delay, ok, DefaultRequeueInitialDelay)
+	}
+}
+
+// TestMaaSModelRefReconciler_WaitingForRouteMaxWait verifies that a model whose HTTPRoute
+// never appears is marked Failed once MaxWait has elapsed and stops requeueing.
+func TestMaaSModelRefReconciler_WaitingForRouteMaxWait(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName   = "test-model"
+		llmisvcName = "test-llmisvc"
+		ns          = "default"
+	)
+
+	llmisvc := newLLMISvc(llmisvcName, ns, corev1.ConditionTrue)
+	model := newMaaSModelRef(modelName, ns, "LLMInferenceService", llmisvcName)
+	r, c := newTestReconciler(model, llmisvc, newMaaSGateway(testGatewayName, testGatewayNamespace))
+	r.RouteRequeue = RequeueBackoff{InitialDelay: time.Second, MaxDelay: time.Minute, MaxWait: 10 * time.Minute}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: modelName, Namespace: ns}}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if result.RequeueAfter != time.Second {
+		t.Errorf("RequeueAfter = %s, want %s", result.RequeueAfter, time.Second)
+	}
+
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionWaitingForRoute)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reasonHTTPRouteNotFound {
+		t.Fatalf("expected %s=True/%s, got %+v", maasv1alpha1.ConditionWaitingForRoute, reasonHTTPRouteNotFound, cond)
+	}
+
+	// Pretend the wait started longer ago than MaxWait.
+	cond.LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Hour))
+	if err := c.Status().Update(ctx, got); err != nil {
+		t.Fatalf("Status().Update: %v", err)
+	}
+
+	result, err = r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile after MaxWait: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue after MaxWait, got RequeueAfter=%s", result.RequeueAfter)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != "Failed" {
+		t.Errorf("Phase = %q, want Failed", got.Status.Phase)
+	}
+	assertReadyCondition(t, got.Status.Conditions, metav1.ConditionFalse, reasonRouteWaitExceeded)
+	cond = apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionWaitingForRoute)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reasonRouteWaitExceeded {
+		t.Errorf("expected %s=True/%s, got %+v", maasv1alpha1.ConditionWaitingForRoute, reasonRouteWaitExceeded, cond)
+	}
+
+	// The route finally shows up: the watch-triggered reconcile recovers the model.
+	if err := c.Create(ctx, newLLMISvcRoute(llmisvcName, ns)); err != nil {
+		t.Fatalf("Create HTTPRoute: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile with HTTPRoute: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase == "Failed" {
+		t.Errorf("Phase = Failed after HTTPRoute was created")
+	}
+	if apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionWaitingForRoute) != nil {
+		t.Errorf("expected %s condition to be removed", maasv1alpha1.ConditionWaitingForRoute)
+	}
+}
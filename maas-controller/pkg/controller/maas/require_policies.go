@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// requirePolicies reports whether model must have an enforced AuthPolicy before it is Ready.
+// Models routed by a VirtualService always do, whatever spec.requirePolicies says.
+func (r *MaaSModelRefReconciler) requirePolicies(model *maasv1alpha1.MaaSModelRef) bool {
+	if r.routedByVirtualService(model) {
+		return true
+	}
+	if model.Spec.RequirePolicies != nil {
+		return *model.Spec.RequirePolicies
+	}
+	return r.RequirePoliciesDefault
+}
+
+// authPolicyTargets reports whether the AuthPolicy's targetRef is the given object.
+func authPolicyTargets(ap *unstructured.Unstructured, kind, namespace, name string) bool {
+	if ap.GetNamespace() != namespace {
+		return false
+	}
+	targetKind, _, _ := unstructured.NestedString(ap.Object, "spec", "targetRef", "kind")
+	targetName, _, _ := unstructured.NestedString(ap.Object, "spec", "targetRef", "name")
+	return targetKind == kind && targetName == name
+}
+
+// authPolicyProtects reports whether the generated AuthPolicy, or gateway AuthConfig with
+// standalone Authorino, applies to the model's route.
+func (r *MaaSModelRefReconciler) authPolicyProtects(ap *unstructured.Unstructured, model *maasv1alpha1.MaaSModelRef) bool {
+	gwNS, gwName := model.Status.HTTPRouteGatewayNamespace, model.Status.HTTPRouteGatewayName
+	if authorinoAuthConfigs(r.AuthProvider) {
+		return gwName != "" && ap.GetNamespace() == gwNS &&
+			ap.GetName() == gatewayAuthPolicyNameFor(r.gatewayNamespace(), r.gatewayName(), gwNS, gwName)
+	}
+	return authPolicyTargets(ap, "HTTPRoute", model.Status.HTTPRouteNamespace, model.Status.HTTPRouteName) ||
+		authPolicyTargets(ap, "Gateway", gwNS, gwName)
+}
+
+// findEnforcedAuthPolicy looks for an AuthPolicy that is Accepted and Enforced and targets
+// either the model's HTTPRoute or the Gateway the route is attached to (the gateway-level
+// AuthPolicy protects every route on it). It returns the policy's namespace/name, or the
+// most relevant reason none qualifies.
+func (r *MaaSModelRefReconciler) findEnforcedAuthPolicy(ctx context.Context, model *maasv1alpha1.MaaSModelRef) (found string, reason string, err error) {
+	if r.routedByVirtualService(model) {
+		return "", "authentication is not enforced on VirtualService routes: no AuthPolicy attaches to them", nil
+	}
+	if authorinoAuthConfigs(r.AuthProvider) {
+		return r.findEnforcedAuthConfig(ctx, model)
+	}
+	routeName, routeNS := model.Status.HTTPRouteName, model.Status.HTTPRouteNamespace
+	gwName, gwNS := model.Status.HTTPRouteGatewayName, model.Status.HTTPRouteGatewayNamespace
+	if routeName == "" {
+		return "", "model has no HTTPRoute yet", nil
+	}
+
+	namespaces := []string{routeNS}
+	if gwNS != "" && gwNS != routeNS {
+		namespaces = append(namespaces, gwNS)
+	}
+
+	reason = fmt.Sprintf("no AuthPolicy targets HTTPRoute %s/%s or its Gateway", routeNS, routeName)
+	for _, ns := range namespaces {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicyList"})
+		if err := r.List(ctx, list, client.InNamespace(ns)); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				return "", "AuthPolicy CRD (kuadrant.io/v1) is not installed", nil
+			}
+			return "", "", fmt.Errorf("failed to list AuthPolicies in namespace %s: %w", ns, err)
+		}
+		for i := range list.Items {
+			ap := &list.Items[i]
+			if !authPolicyTargets(ap, "HTTPRoute", routeNS, routeName) && (gwName == "" || !authPolicyTargets(ap, "Gateway", gwNS, gwName)) {
+				continue
+			}
+			ready, apReason, msg := getPolicyReadyState(ap)
+			if ready {
+				return ap.GetNamespace() + "/" + ap.GetName(), "", nil
+			}
+			reason = fmt.Sprintf("AuthPolicy %s/%s is not ready (%s)", ap.GetNamespace(), ap.GetName(), apReason)
+			if msg != "" {
+				reason += ": " + msg
+			}
+		}
+	}
+	return "", reason, nil
+}
+
+// setPoliciesEnforcedCondition records whether the model's route is protected; the
+// condition is removed when policies are not required.
+func (r *MaaSModelRefReconciler) setPoliciesEnforcedCondition(model *maasv1alpha1.MaaSModelRef, required bool, policy, reason string) {
+	if !required {
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionPoliciesEnforced)
+		return
+	}
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionPoliciesEnforced,
+		ObservedGeneration: model.GetGeneration(),
+	}
+	if policy != "" {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = string(maasv1alpha1.ReasonAcceptedEnforced)
+		cond.Message = fmt.Sprintf("AuthPolicy %s is accepted and enforced", policy)
+	} else {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = string(maasv1alpha1.ReasonNotEnforced)
+		cond.Message = reason
+	}
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+}
+
+// mapAuthPolicyToMaaSModelRefs returns reconcile requests for the MaaSModelRefs whose
+// HTTPRoute or Gateway is targeted by the AuthPolicy, so models waiting on enforcement
+// become Ready as soon as Kuadrant enforces the policy.
+func (r *MaaSModelRefReconciler) mapAuthPolicyToMaaSModelRefs(ctx context.Context, obj client.Object) []reconcile.Request {
+	ap, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	var models maasv1alpha1.MaaSModelRefList
+	if err := r.List(ctx, &models); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, m := range models.Items {
+		if !r.requirePolicies(&m) {
+			continue
+		}
+		if r.authPolicyProtects(ap, &m) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: m.Name, Namespace: m.Namespace},
+			})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// routedFakeHandler is a fakeHandler that also records route and gateway info in status,
+// like the real handlers do.
+type routedFakeHandler struct {
+	fakeHandler
+}
+
+func (f *routedFakeHandler) ReconcileRoute(_ context.Context, _ logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	model.Status.HTTPRouteName = "model-route"
+	model.Status.HTTPRouteNamespace = model.Namespace
+	model.Status.HTTPRouteGatewayName = testGatewayName
+	model.Status.HTTPRouteGatewayNamespace = testGatewayNamespace
+	return nil
+}
+
+// newKuadrantAuthPolicy returns an AuthPolicy targeting the given object, with Accepted
+// and Enforced set to enforced.
+func newKuadrantAuthPolicy(name, ns, targetKind, targetName string, enforced bool) *unstructured.Unstructured {
+	status := "False"
+	if enforced {
+		status = "True"
+	}
+	ap := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"targetRef": map[string]any{"group": "gateway.networking.k8s.io", "kind": targetKind, "name": targetName},
+		},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Accepted", "status": "True"},
+				map[string]any{"type": "Enforced", "status": status, "message": "waiting for Authorino"},
+			},
+		},
+	}}
+	ap.SetGroupVersionKind(schema.GroupVersionKind{Group: "kuadrant.io", Version: "v1", Kind: "AuthPolicy"})
+	ap.SetName(name)
+	ap.SetNamespace(ns)
+	return ap
+}
+
+// TestMaaSModelRefReconciler_RequirePolicies verifies that a governed, healthy model that
+// requires policies stays Pending until an AuthPolicy on its Gateway is enforced.
+func TestMaaSModelRefReconciler_RequirePolicies(t *testing.T) {
+	const testKind = "_test_require_policies_kind"
+	backendHandlerFactories[testKind] = func(_ *MaaSModelRefReconciler) BackendHandler {
+		return &routedFakeHandler{fakeHandler{endpoint: "https://model.example.com", ready: true}}
+	}
+	defer delete(backendHandlerFactories, testKind)
+
+	ctx := context.Background()
+	required := true
+	model := newMaaSModelRef("guarded", "default", testKind, "backend")
+	model.Spec.RequirePolicies = &required
+	sub := newMaaSSubscription("sub1", "admin-ns", "team-a", "guarded", 100)
+	sub.Spec.ModelRefs[0].Namespace = "default"
+	auth := newMaaSAuthPolicy("auth1", "admin-ns", "team-a", maasv1alpha1.ModelRef{Name: "guarded", Namespace: "default"})
+	gwPolicy := newKuadrantAuthPolicy(maasGatewayAuthPolicyName, testGatewayNamespace, "Gateway", testGatewayName, false)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, sub, auth, gwPolicy).
+		WithStatusSubresource(&maasv1alpha1.MaaSModelRef{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, modelRefIndexKey, subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSModelRefReconciler{Client: c, Scheme: scheme, GatewayName: testGatewayName, GatewayNamespace: testGatewayNamespace}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "guarded", Namespace: "default"}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != "Pending" || got.Status.Endpoint != "" {
+		t.Errorf("Phase=%q Endpoint=%q, want Pending with no endpoint while AuthPolicy is not enforced", got.Status.Phase, got.Status.Endpoint)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionPoliciesEnforced)
+	if cond == nil || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, maasGatewayAuthPolicyName) {
+		t.Errorf("expected PoliciesEnforced=False naming %s, got %+v", maasGatewayAuthPolicyName, cond)
+	}
+
+	if reqs := r.mapAuthPolicyToMaaSModelRefs(ctx, gwPolicy); len(reqs) != 1 || reqs[0].NamespacedName != req.NamespacedName {
+		t.Errorf("mapAuthPolicyToMaaSModelRefs = %v, want [%s]", reqs, req.NamespacedName)
+	}
+
+	// Kuadrant enforces the gateway policy.
+	if err := c.Get(ctx, types.NamespacedName{Name: maasGatewayAuthPolicyName, Namespace: testGatewayNamespace}, gwPolicy); err != nil {
+		t.Fatalf("Get AuthPolicy: %v", err)
+	}
+	enforced := newKuadrantAuthPolicy(maasGatewayAuthPolicyName, testGatewayNamespace, "Gateway", testGatewayName, true)
+	gwPolicy.Object["status"] = enforced.Object["status"]
+	if err := c.Update(ctx, gwPolicy); err != nil {
+		t.Fatalf("Update AuthPolicy: %v", err)
+	}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after enforcement: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want Ready once AuthPolicy is enforced", got.Status.Phase)
+	}
+	cond = apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionPoliciesEnforced)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected PoliciesEnforced=True, got %+v", cond)
+	}
+}
+
+// TestMaaSModelRefReconciler_RequirePoliciesDefault verifies the controller default and
+// that an explicit spec.requirePolicies=false overrides it.
+func TestMaaSModelRefReconciler_RequirePoliciesDefault(t *testing.T) {
+	r := &MaaSModelRefReconciler{RequirePoliciesDefault: true}
+	model := newMaaSModelRef("m", "default", "LLMInferenceService", "m")
+	if !r.requirePolicies(model) {
+		t.Error("expected controller default to apply when spec.requirePolicies is unset")
+	}
+	disabled := false
+	model.Spec.RequirePolicies = &disabled
+	if r.requirePolicies(model) {
+		t.Error("expected spec.requirePolicies=false to override the controller default")
+	}
+}
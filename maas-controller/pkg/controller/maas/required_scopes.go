@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// requiredScopesPolicy is the spec.requiredScopes of a MaaSAuthPolicy with the models, as
+// "namespace/name", it applies to.
+type requiredScopesPolicy struct {
+	PolicyName string
+	Models     []string
+	Scopes     []string
+	// ClientRoles maps client IDs to the roles granting access.
+	ClientRoles map[string][]string
+}
+
+// aggregateRequiredScopes returns the required scopes of the policies, sorted by policy name.
+func aggregateRequiredScopes(policies []maasv1alpha1.MaaSAuthPolicy) ([]requiredScopesPolicy, error) {
+	var out []requiredScopesPolicy
+	for _, p := range policies {
+		required := p.Spec.RequiredScopes
+		if required == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		entry := requiredScopesPolicy{PolicyName: p.Name, ClientRoles: map[string][]string{}}
+		for _, scope := range required.Scopes {
+			if err := validateCELValue(scope, "scope"); err != nil {
+				return nil, fmt.Errorf("invalid requiredScopes in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+			}
+			entry.Scopes = append(entry.Scopes, scope)
+		}
+		for _, cr := range required.ClientRoles {
+			if err := validateCELValue(cr.Client, "client"); err != nil {
+				return nil, fmt.Errorf("invalid requiredScopes in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+			}
+			if err := validateCELValue(cr.Role, "client role"); err != nil {
+				return nil, fmt.Errorf("invalid requiredScopes in MaaSAuthPolicy %s/%s: %w", p.Namespace, p.Name, err)
+			}
+			entry.ClientRoles[cr.Client] = append(entry.ClientRoles[cr.Client], cr.Role)
+		}
+		for client, roles := range entry.ClientRoles {
+			entry.ClientRoles[client] = deduplicateAndSort(roles)
+		}
+		entry.Scopes = deduplicateAndSort(entry.Scopes)
+		for _, ref := range p.Spec.ModelRefs {
+			entry.Models = append(entry.Models, ref.Namespace+"/"+ref.Name)
+		}
+		entry.Models = deduplicateAndSort(entry.Models)
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PolicyName < out[j].PolicyName })
+	return out, nil
+}
+
+// requiredScopesRuleName is the gateway AuthPolicy rule of a MaaSAuthPolicy's required scopes.
+func requiredScopesRuleName(policyName string) string {
+	return "required-scopes-" + policyName
+}
+
+// requiredScopesRego allows a token carrying all the scopes, or one of the client roles.
+func requiredScopesRego(p requiredScopesPolicy) string {
+	// encoding/json sorts map keys, so the Rego is stable.
+	clientRoles, _ := json.Marshal(p.ClientRoles)
+	return `required_scopes := ` + celStringList(p.Scopes) + `
+client_roles := ` + string(clientRoles) + `
+
+token_scope[s] {
+	s := split(object.get(input.auth.identity, "scope", ""), " ")[_]
+}
+
+token_scope[s] {
+	scp := object.get(input.auth.identity, "scp", [])
+	is_array(scp)
+	s := scp[_]
+}
+
+missing_scope[s] {
+	s := required_scopes[_]
+	not token_scope[s]
+}
+
+allow {
+	count(required_scopes) > 0
+	count(missing_scope) == 0
+}
+
+allow {
+	roles := client_roles[client]
+	object.get(object.get(object.get(input.auth.identity, "resource_access", {}), client, {}), "roles", [])[_] == roles[_]
+}`
+}
+
+// addRequiredScopesRules adds an authorization rule per policy with spec.requiredScopes,
+// evaluated for JWTs presented to its models. A model whose policies set several of them
+// requires the token to satisfy each.
+func addRequiredScopesRules(authorization map[string]kuadrantv1.AuthorizationRule, policies []requiredScopesPolicy, celIsNotAPIKey string) {
+	for _, p := range policies {
+		authorization[requiredScopesRuleName(p.PolicyName)] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{
+					Predicate: celIsNotAPIKey + ` && has(auth.identity.iss) && ` + celRequestedModelIn(p.Models),
+				}},
+			},
+			OPA: &kuadrantv1.OPAAuthorization{Rego: requiredScopesRego(p)},
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestAggregateRequiredScopes(t *testing.T) {
+	modelA := maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"}
+	modelB := maasv1alpha1.ModelRef{Name: "model-b", Namespace: "llm"}
+
+	granite := newMaaSAuthPolicy("granite", "default", "team-a", modelB, modelA)
+	granite.Spec.RequiredScopes = &maasv1alpha1.RequiredScopes{
+		Scopes: []string{"models:granite", "models:read", "models:granite"},
+		ClientRoles: []maasv1alpha1.ClientRole{
+			{Client: "maas", Role: "granite-user"},
+			{Client: "maas", Role: "admin"},
+		},
+	}
+	llama := newMaaSAuthPolicy("llama", "default", "team-b", modelA)
+	llama.Spec.RequiredScopes = &maasv1alpha1.RequiredScopes{Scopes: []string{"models:llama"}}
+	plain := newMaaSAuthPolicy("plain", "default", "team-c", modelA)
+
+	got, err := aggregateRequiredScopes([]maasv1alpha1.MaaSAuthPolicy{*llama, *plain, *granite})
+	if err != nil {
+		t.Fatalf("aggregateRequiredScopes: %v", err)
+	}
+	if len(got) != 2 || got[0].PolicyName != "granite" || got[1].PolicyName != "llama" {
+		t.Fatalf("got %+v, want the granite and llama policies sorted by name", got)
+	}
+	if scopes := strings.Join(got[0].Scopes, ","); scopes != "models:granite,models:read" {
+		t.Errorf("granite scopes = %q, want them deduplicated and sorted", scopes)
+	}
+	if !reflect.DeepEqual(got[0].ClientRoles, map[string][]string{"maas": {"admin", "granite-user"}}) {
+		t.Errorf("granite client roles = %v, want the maas roles sorted", got[0].ClientRoles)
+	}
+	if models := strings.Join(got[0].Models, ","); models != "llm/model-a,llm/model-b" {
+		t.Errorf("granite models = %q, want llm/model-a,llm/model-b", models)
+	}
+
+	llama.Spec.RequiredScopes.ClientRoles = []maasv1alpha1.ClientRole{{Client: `maas"`, Role: "user"}}
+	if _, err := aggregateRequiredScopes([]maasv1alpha1.MaaSAuthPolicy{*llama}); err == nil {
+		t.Error("expected a client ID that is not a valid Rego string value to be rejected")
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_RequiredScopes(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	authz := gatewayAuthorization{RequiredScopes: []requiredScopesPolicy{{
+		PolicyName:  "granite",
+		Models:      []string{"llm/granite"},
+		Scopes:      []string{"models:granite"},
+		ClientRoles: map[string][]string{"maas": {"granite-user"}},
+	}}}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, authz, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+
+	rule, ok := spec.Defaults.Rules.Authorization["required-scopes-granite"]
+	if !ok || rule.OPA == nil {
+		t.Fatalf("required-scopes-granite rule missing: %+v", spec.Defaults.Rules.Authorization)
+	}
+	predicate := rule.When[0].Predicate
+	if !strings.Contains(predicate, "has(auth.identity.iss)") || !strings.Contains(predicate, `in ["llm/granite"]`) {
+		t.Errorf("rule should only apply to JWTs presented to llm/granite, got %q", predicate)
+	}
+	for _, want := range []string{
+		`required_scopes := ["models:granite"]`,
+		`client_roles := {"maas":["granite-user"]}`,
+		`object.get(input.auth.identity, "scp", [])`,
+		`"resource_access"`,
+	} {
+		if !strings.Contains(rule.OPA.Rego, want) {
+			t.Errorf("rego should contain %s, got:\n%s", want, rule.OPA.Rego)
+		}
+	}
+}
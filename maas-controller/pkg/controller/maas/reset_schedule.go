@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// Limitador windows are fixed durations that start at a counter's first hit, so they
+// cannot be aligned to calendar boundaries. Anchored rates instead count per period:
+// a counter expression evaluates to the current period's id (derived from request.time),
+// so a new period starts a fresh counter. The window only has to outlive the longest
+// period so that a counter never expires inside it.
+const (
+	// anchoredDailyWindow covers a day, including a 25-hour daylight saving day.
+	anchoredDailyWindow = "25h"
+	// anchoredMonthlyWindow covers a 31-day month, including a daylight saving hour.
+	anchoredMonthlyWindow = "745h"
+)
+
+// resetScheduleLocation returns the time zone of a schedule, UTC by default.
+func resetScheduleLocation(schedule *maasv1alpha1.ResetSchedule) (*time.Location, error) {
+	if schedule.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resetSchedule timeZone %q: %w", schedule.TimeZone, err)
+	}
+	return loc, nil
+}
+
+// ValidateResetSchedule checks the parts of a schedule the CRD schema cannot.
+func ValidateResetSchedule(schedule *maasv1alpha1.ResetSchedule) error {
+	if schedule == nil {
+		return nil
+	}
+	_, err := resetScheduleLocation(schedule)
+	return err
+}
+
+// resetDayOfMonth returns the day a Monthly period starts on.
+func resetDayOfMonth(schedule *maasv1alpha1.ResetSchedule) int {
+	if schedule.DayOfMonth == nil {
+		return 1
+	}
+	return int(*schedule.DayOfMonth)
+}
+
+// minPeriodSeconds is the length of the shortest period of a schedule. Rates with a
+// window at least this long are anchored to the schedule.
+func minPeriodSeconds(period maasv1alpha1.ResetPeriod) int64 {
+	if period == maasv1alpha1.ResetPeriodMonthly {
+		return 28 * 24 * 3600
+	}
+	return 24 * 3600
+}
+
+// splitAnchoredRates separates validated rates into those that keep rolling and the
+// one anchored to the schedule, if any.
+func splitAnchoredRates(limits []maasv1alpha1.TokenRateLimit, schedule *maasv1alpha1.ResetSchedule) ([]maasv1alpha1.TokenRateLimit, *maasv1alpha1.TokenRateLimit) {
+	if schedule == nil {
+		return limits, nil
+	}
+	var rolling []maasv1alpha1.TokenRateLimit
+	var anchored *maasv1alpha1.TokenRateLimit
+	for _, trl := range limits {
+		seconds, _ := windowSeconds(trl.Window)
+		if seconds >= minPeriodSeconds(schedule.Period) && anchored == nil {
+			anchored = &trl
+			continue
+		}
+		rolling = append(rolling, trl)
+	}
+	return rolling, anchored
+}
+
+// validateAnchoredRates rejects more than one rate per modelRef and direction reaching
+// the reset period, since every such rate would reset at the same boundary.
+func validateAnchoredRates(limits []maasv1alpha1.TokenRateLimit, schedule *maasv1alpha1.ResetSchedule) error {
+	if schedule == nil {
+		return nil
+	}
+	byDirection := ratesByDirection(limits)
+	for _, d := range tokenDirections {
+		count := 0
+		for _, trl := range byDirection[d] {
+			if seconds, _ := windowSeconds(trl.Window); seconds >= minPeriodSeconds(schedule.Period) {
+				count++
+			}
+		}
+		if count > 1 {
+			return fmt.Errorf("%s%d rates have a window of at least one %s reset period; at most one can be anchored", directionPrefix(d), count, strings.ToLower(string(schedule.Period)))
+		}
+	}
+	return nil
+}
+
+// resetPeriodExpression returns the CEL counter expression that identifies the current
+// period of a schedule, e.g. the day of the year for Daily.
+func resetPeriodExpression(schedule *maasv1alpha1.ResetSchedule) string {
+	tz := schedule.TimeZone
+	if tz == "" {
+		tz = "UTC"
+	}
+	if schedule.Period == maasv1alpha1.ResetPeriodDaily {
+		return fmt.Sprintf(`string(request.time.getFullYear(%[1]q)) + "-" + string(request.time.getDayOfYear(%[1]q))`, tz)
+	}
+	// Months since year 0. A period starting on a later day belongs to the previous
+	// month until that day is reached.
+	months := fmt.Sprintf(`request.time.getFullYear(%[1]q) * 12 + request.time.getMonth(%[1]q)`, tz)
+	if day := resetDayOfMonth(schedule); day > 1 {
+		months += fmt.Sprintf(` - (request.time.getDate(%q) < %d ? 1 : 0)`, tz, day)
+	}
+	return fmt.Sprintf("string(%s)", months)
+}
+
+// anchoredWindow returns the Limitador window of an anchored rate.
+func anchoredWindow(period maasv1alpha1.ResetPeriod) string {
+	if period == maasv1alpha1.ResetPeriodMonthly {
+		return anchoredMonthlyWindow
+	}
+	return anchoredDailyWindow
+}
+
+// nextResetTime returns the first period boundary of a valid schedule after now.
+func nextResetTime(schedule *maasv1alpha1.ResetSchedule, now time.Time) time.Time {
+	loc, err := resetScheduleLocation(schedule)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	if schedule.Period == maasv1alpha1.ResetPeriodDaily {
+		return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+	}
+	day := resetDayOfMonth(schedule)
+	next := time.Date(local.Year(), local.Month(), day, 0, 0, 0, 0, loc)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month()+1, day, 0, 0, 0, 0, loc)
+	}
+	return next
+}
+
+// addSubscriptionLimits adds a subscription's TRLP or RLP limits under key. Rolling
+// rates share one limit; the rate anchored to spec.resetSchedule gets its own limit
+// (key suffixed with the period) whose counters include the period id. Input and
+// Output token rates get limits of their own under directionLimitKey.
+func addSubscriptionLimits(limitsMap map[string]kuadrantv1.Limit, key, predicate string, sub *maasv1alpha1.MaaSSubscription, limits []maasv1alpha1.TokenRateLimit) {
+	for d, rates := range ratesByDirection(limits) {
+		addScheduledLimits(limitsMap, directionLimitKey(key, d), predicate, sub, rates)
+	}
+}
+
+// addScheduledLimits adds the limits of rates of a single direction under key.
+func addScheduledLimits(limitsMap map[string]kuadrantv1.Limit, key, predicate string, sub *maasv1alpha1.MaaSSubscription, limits []maasv1alpha1.TokenRateLimit) {
+	schedule := sub.Spec.ResetSchedule
+	rolling, anchored := splitAnchoredRates(limits, schedule)
+	when := []kuadrantv1.Predicate{{Predicate: predicate}}
+	if len(rolling) > 0 {
+		limitsMap[key] = kuadrantv1.Limit{
+			Rates:    rateLimitRates(rolling),
+			When:     when,
+			Counters: subscriptionCounters(sub),
+		}
+	}
+	if anchored != nil {
+		limitsMap[key+"-"+strings.ToLower(string(schedule.Period))] = kuadrantv1.Limit{
+			Rates:    []kuadrantv1.Rate{{Limit: anchored.Limit, Window: anchoredWindow(schedule.Period)}},
+			When:     when,
+			Counters: append(subscriptionCounters(sub), kuadrantv1.Counter{Expression: resetPeriodExpression(schedule)}),
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestNextResetTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	day15 := int32(15)
+	now := time.Date(2026, time.January, 31, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		schedule maasv1alpha1.ResetSchedule
+		want     time.Time
+	}{
+		{"daily UTC", maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily}, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"daily in time zone", maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily, TimeZone: "Europe/Berlin"}, time.Date(2026, time.February, 1, 0, 0, 0, 0, berlin)},
+		{"monthly on the 1st", maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodMonthly}, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"monthly on the 15th", maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodMonthly, DayOfMonth: &day15}, time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextResetTime(&tt.schedule, now); !got.Equal(tt.want) {
+				t.Errorf("nextResetTime = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResetPeriodExpression(t *testing.T) {
+	day15 := int32(15)
+	tests := []struct {
+		schedule maasv1alpha1.ResetSchedule
+		want     string
+	}{
+		{
+			maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily},
+			`string(request.time.getFullYear("UTC")) + "-" + string(request.time.getDayOfYear("UTC"))`,
+		},
+		{
+			maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodMonthly, TimeZone: "Europe/Berlin"},
+			`string(request.time.getFullYear("Europe/Berlin") * 12 + request.time.getMonth("Europe/Berlin"))`,
+		},
+		{
+			maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodMonthly, DayOfMonth: &day15},
+			`string(request.time.getFullYear("UTC") * 12 + request.time.getMonth("UTC") - (request.time.getDate("UTC") < 15 ? 1 : 0))`,
+		},
+	}
+	for _, tt := range tests {
+		if got := resetPeriodExpression(&tt.schedule); got != tt.want {
+			t.Errorf("resetPeriodExpression(%+v) =\n%s\nwant\n%s", tt.schedule, got, tt.want)
+		}
+	}
+}
+
+// TestBuildTRLPSpec_ResetSchedule verifies that a rate reaching the reset period moves to
+// its own limit counted per period, while shorter rates keep rolling.
+func TestBuildTRLPSpec_ResetSchedule(t *testing.T) {
+	sub := newMaaSSubscription("sub", "default", "team-a", "llm", 0)
+	sub.Spec.ResetSchedule = &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodMonthly}
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 10000, Window: "1m"}, {Limit: 5000000, Window: "720h"}}
+
+	spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route")
+	if spec == nil {
+		t.Fatal("expected a TRLP spec")
+	}
+	rates := spec.Limits["default-sub-llm-tokens"].Rates
+	if want := []kuadrantv1.Rate{{Limit: 10000, Window: "1m"}}; !reflect.DeepEqual(rates, want) {
+		t.Errorf("rolling rates = %v, want %v", rates, want)
+	}
+	anchored, found := spec.Limits["default-sub-llm-tokens-monthly"]
+	if !found {
+		t.Fatalf("expected an anchored monthly limit, got %v", spec.Limits)
+	}
+	if want := []kuadrantv1.Rate{{Limit: 5000000, Window: anchoredMonthlyWindow}}; !reflect.DeepEqual(anchored.Rates, want) {
+		t.Errorf("anchored rates = %v, want %v", anchored.Rates, want)
+	}
+	wantCounters := []kuadrantv1.Counter{
+		{Expression: "auth.identity.userid"},
+		{Expression: resetPeriodExpression(sub.Spec.ResetSchedule)},
+	}
+	if !reflect.DeepEqual(anchored.Counters, wantCounters) {
+		t.Errorf("anchored counters = %v, want %v", anchored.Counters, wantCounters)
+	}
+
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 5000000, Window: "720h"}, {Limit: 9000000, Window: "1440h"}}
+	if spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route"); spec != nil {
+		t.Errorf("expected a subscription with two anchored rates to be skipped, got %v", spec)
+	}
+
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{{Limit: 5000000, Window: "720h"}}
+	sub.Spec.ResetSchedule.TimeZone = "Not/AZone"
+	if spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", nil, nil, "llm-route"); spec != nil {
+		t.Errorf("expected a subscription with an invalid time zone to be skipped, got %v", spec)
+	}
+}
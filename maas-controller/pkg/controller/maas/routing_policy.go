@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// reasonRouteRulesApplied is the RoutingPolicyApplied=True reason.
+	reasonRouteRulesApplied = "RouteRulesApplied"
+	// reasonNoRoutableRules is used when the model's HTTPRoute has no rules to copy.
+	reasonNoRoutableRules = "NoRoutableRules"
+	// reasonRoutingPolicyUnsupported is used when the model is not routed by an HTTPRoute
+	// (istio routing provider), so there are no HTTPRoute rules to carry the policy.
+	reasonRoutingPolicyUnsupported = "RoutingPolicyUnsupported"
+
+	// routingRouteComponent labels the HTTPRoutes created for spec.routing timeouts and retries.
+	routingRouteComponent = "routing-route"
+)
+
+// defaultRetryCodes are retried when spec.routing.retries.codes is empty.
+var defaultRetryCodes = []gatewayapiv1.HTTPRouteRetryStatusCode{502, 503, 504}
+
+// routingRouteName returns the name of the HTTPRoute that carries a model's timeouts and retries.
+func routingRouteName(modelName string) string {
+	return modelName + "-routing"
+}
+
+// hasRoutingPolicy reports whether the model configures timeouts or retries.
+func hasRoutingPolicy(model *maasv1alpha1.MaaSModelRef) bool {
+	return model.Spec.Routing != nil && (model.Spec.Routing.Timeouts != nil || model.Spec.Routing.Retries != nil)
+}
+
+// gatewayDuration formats d in the Gateway API duration format (e.g. "1h30m", "250ms"),
+// which unlike Go's format allows no fractions and no zero-valued trailing units.
+func gatewayDuration(d time.Duration) gatewayapiv1.Duration {
+	if d <= 0 {
+		return "0s"
+	}
+	var out string
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"h", time.Hour}, {"m", time.Minute}, {"s", time.Second}, {"ms", time.Millisecond}} {
+		if n := d / unit.size; n > 0 {
+			out += strconv.FormatInt(int64(n), 10) + unit.suffix
+			d -= n * unit.size
+		}
+	}
+	if out == "" {
+		// Below the Gateway API resolution of one millisecond.
+		return "1ms"
+	}
+	return gatewayapiv1.Duration(out)
+}
+
+// applyRoutingPolicy sets the spec.routing timeouts and retries on every rule.
+// Rules keep their own values for whatever the spec leaves unset.
+func applyRoutingPolicy(rules []gatewayapiv1.HTTPRouteRule, routing *maasv1alpha1.RoutingSpec) {
+	if routing == nil {
+		return
+	}
+	for i := range rules {
+		rule := &rules[i]
+		if t := routing.Timeouts; t != nil {
+			if rule.Timeouts == nil {
+				rule.Timeouts = &gatewayapiv1.HTTPRouteTimeouts{}
+			}
+			if t.Request != nil {
+				d := gatewayDuration(t.Request.Duration)
+				rule.Timeouts.Request = &d
+			}
+			if t.BackendRequest != nil {
+				d := gatewayDuration(t.BackendRequest.Duration)
+				rule.Timeouts.BackendRequest = &d
+			}
+		}
+		if rt := routing.Retries; rt != nil {
+			attempts := int(rt.Attempts)
+			retry := &gatewayapiv1.HTTPRouteRetry{Attempts: &attempts, Codes: defaultRetryCodes}
+			if len(rt.Codes) > 0 {
+				retry.Codes = make([]gatewayapiv1.HTTPRouteRetryStatusCode, 0, len(rt.Codes))
+				for _, c := range rt.Codes {
+					retry.Codes = append(retry.Codes, gatewayapiv1.HTTPRouteRetryStatusCode(c))
+				}
+			}
+			if rt.Backoff != nil {
+				d := gatewayDuration(rt.Backoff.Duration)
+				retry.Backoff = &d
+			}
+			rule.Retry = retry
+		}
+	}
+}
+
+// routingRules copies the primary route's rules, adding a POST method match to every
+// match so that the copies take precedence over the originals. Non-POST requests such
+// as model listing keep going through the primary route unchanged.
+func routingRules(primaryRules []gatewayapiv1.HTTPRouteRule, routing *maasv1alpha1.RoutingSpec) []gatewayapiv1.HTTPRouteRule {
+	post := gatewayapiv1.HTTPMethodPost
+	var rules []gatewayapiv1.HTTPRouteRule
+	for _, rule := range primaryRules {
+		out := *rule.DeepCopy()
+		if len(out.Matches) == 0 {
+			// No matches means every request; match them all again, restricted to POST.
+			out.Matches = []gatewayapiv1.HTTPRouteMatch{{}}
+		}
+		for i := range out.Matches {
+			if out.Matches[i].Method == nil {
+				out.Matches[i].Method = &post
+			}
+		}
+		rules = append(rules, out)
+	}
+	applyRoutingPolicy(rules, routing)
+	return rules
+}
+
+// reconcileRoutingPolicy applies spec.routing timeouts and retries. The model's HTTPRoute
+// belongs to KServe or the ExternalModel reconciler, so, as with failover, a controller-owned
+// HTTPRoute copies its rules with the policy applied and wins through route precedence.
+// While failover is active the failover route carries the policy instead.
+func (r *MaaSModelRefReconciler) reconcileRoutingPolicy(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef, failoverActive bool) error {
+	if !hasRoutingPolicy(model) {
+		if apimeta.FindStatusCondition(model.Status.Conditions, maasv1alpha1.ConditionRoutingPolicyApplied) == nil {
+			return nil
+		}
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionRoutingPolicyApplied)
+		return r.deleteRoutingRoute(ctx, log, model)
+	}
+
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionRoutingPolicyApplied,
+		ObservedGeneration: model.GetGeneration(),
+	}
+	if r.istioRouting() {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonRoutingPolicyUnsupported
+		cond.Message = "Timeouts and retries require Gateway API routing; the istio routing provider is in use"
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return r.deleteRoutingRoute(ctx, log, model)
+	}
+	if failoverActive {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = reasonRouteRulesApplied
+		cond.Message = fmt.Sprintf("Timeouts and retries applied to failover HTTPRoute %s", failoverRouteName(model.Name))
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return r.deleteRoutingRoute(ctx, log, model)
+	}
+	if model.Status.HTTPRouteName == "" {
+		return nil
+	}
+
+	primaryRoute := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: model.Status.HTTPRouteName, Namespace: model.Status.HTTPRouteNamespace}, primaryRoute); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get HTTPRoute %s/%s: %w", model.Status.HTTPRouteNamespace, model.Status.HTTPRouteName, err)
+	}
+	rules := routingRules(primaryRoute.Spec.Rules, model.Spec.Routing)
+	if len(rules) == 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonNoRoutableRules
+		cond.Message = fmt.Sprintf("HTTPRoute %s/%s has no rules to apply timeouts and retries to", primaryRoute.Namespace, primaryRoute.Name)
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return r.deleteRoutingRoute(ctx, log, model)
+	}
+
+	// Backend references without a namespace are relative to the route, so the copy
+	// lives next to the primary route.
+	route := &gatewayapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: routingRouteName(model.Name), Namespace: primaryRoute.Namespace},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["app.kubernetes.io/managed-by"] = "maas-controller"
+		labels["app.kubernetes.io/component"] = routingRouteComponent
+		labels["maas.opendatahub.io/model"] = model.Name
+		labels["maas.opendatahub.io/model-namespace"] = model.Namespace
+		route.SetLabels(labels)
+		route.Spec.ParentRefs = primaryRoute.Spec.ParentRefs
+		route.Spec.Hostnames = primaryRoute.Spec.Hostnames
+		route.Spec.Rules = rules
+		if route.Namespace == model.Namespace {
+			return controllerutil.SetControllerReference(model, route, r.Scheme)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply routing HTTPRoute %s/%s: %w", route.Namespace, route.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Routing HTTPRoute applied", "name", route.Name, "operation", op)
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = reasonRouteRulesApplied
+	cond.Message = fmt.Sprintf("Timeouts and retries applied via HTTPRoute %s", route.Name)
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+	return nil
+}
+
+// deleteRoutingRoute removes the model's routing HTTPRoute, if any.
+func (r *MaaSModelRefReconciler) deleteRoutingRoute(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	namespace := model.Status.HTTPRouteNamespace
+	if namespace == "" {
+		namespace = model.Namespace
+	}
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: routingRouteName(model.Name), Namespace: namespace}, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get routing HTTPRoute: %w", err)
+	}
+	if route.Labels["app.kubernetes.io/component"] != routingRouteComponent ||
+		route.Labels["maas.opendatahub.io/model-namespace"] != model.Namespace {
+		return nil
+	}
+	if err := r.Delete(ctx, route); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete routing HTTPRoute %s/%s: %w", route.Namespace, route.Name, err)
+	}
+	log.Info("Routing HTTPRoute deleted", "name", route.Name)
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestGatewayDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want gatewayapiv1.Duration
+	}{
+		{0, "0s"},
+		{10 * time.Minute, "10m"},
+		{90 * time.Minute, "1h30m"},
+		{1500 * time.Millisecond, "1s500ms"},
+		{time.Microsecond, "1ms"},
+	}
+	for _, tt := range tests {
+		if got := gatewayDuration(tt.in); got != tt.want {
+			t.Errorf("gatewayDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRoutingRules(t *testing.T) {
+	get := gatewayapiv1.HTTPMethodGet
+	prefix := "/default/llm"
+	primary := []gatewayapiv1.HTTPRouteRule{
+		{Matches: []gatewayapiv1.HTTPRouteMatch{
+			{Path: &gatewayapiv1.HTTPPathMatch{Value: &prefix}},
+			{Path: &gatewayapiv1.HTTPPathMatch{Value: &prefix}, Method: &get},
+		}},
+		{},
+	}
+	routing := &maasv1alpha1.RoutingSpec{
+		Timeouts: &maasv1alpha1.RouteTimeouts{Request: &metav1.Duration{Duration: 10 * time.Minute}},
+		Retries:  &maasv1alpha1.RouteRetries{Attempts: 2},
+	}
+
+	rules := routingRules(primary, routing)
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if m := rules[0].Matches[0].Method; m == nil || *m != gatewayapiv1.HTTPMethodPost {
+		t.Errorf("expected a POST method match to be added, got %v", m)
+	}
+	if m := rules[0].Matches[1].Method; m == nil || *m != gatewayapiv1.HTTPMethodGet {
+		t.Errorf("expected an existing method match to be kept, got %v", m)
+	}
+	if len(rules[1].Matches) != 1 || rules[1].Matches[0].Method == nil {
+		t.Errorf("expected a rule without matches to get a POST match, got %+v", rules[1].Matches)
+	}
+	if primary[0].Matches[0].Method != nil {
+		t.Error("primary rules must not be modified")
+	}
+	for i, rule := range rules {
+		if rule.Timeouts == nil || rule.Timeouts.Request == nil || *rule.Timeouts.Request != "10m" {
+			t.Errorf("rule %d: timeouts = %+v, want request 10m", i, rule.Timeouts)
+		}
+		if rule.Retry == nil || rule.Retry.Attempts == nil || *rule.Retry.Attempts != 2 {
+			t.Errorf("rule %d: retry = %+v, want 2 attempts", i, rule.Retry)
+			continue
+		}
+		if !reflect.DeepEqual(rule.Retry.Codes, defaultRetryCodes) {
+			t.Errorf("rule %d: retry codes = %v, want %v", i, rule.Retry.Codes, defaultRetryCodes)
+		}
+	}
+}
+
+// TestMaaSModelRefReconciler_RoutingPolicy verifies that spec.routing timeouts and retries
+// create a routing HTTPRoute copying the primary rules, and that unsetting them removes it.
+func TestMaaSModelRefReconciler_RoutingPolicy(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("llm", "default", "LLMInferenceService", "llm")
+	model.Spec.Routing = &maasv1alpha1.RoutingSpec{
+		Timeouts: &maasv1alpha1.RouteTimeouts{
+			Request:        &metav1.Duration{Duration: 15 * time.Minute},
+			BackendRequest: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		Retries: &maasv1alpha1.RouteRetries{Attempts: 3, Codes: []int32{503}, Backoff: &metav1.Duration{Duration: 250 * time.Millisecond}},
+	}
+	r, c := newTestReconcilerWithMapper(
+		model,
+		newMaaSGateway(testGatewayName, testGatewayNamespace),
+		newLLMISvc("llm", "default", corev1.ConditionTrue),
+		withKServeRules(newLLMISvcRoute("llm", "default"), "llm"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llm", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertCondition(t, got.Status.Conditions, maasv1alpha1.ConditionRoutingPolicyApplied, metav1.ConditionTrue, reasonRouteRulesApplied)
+
+	route := &gatewayapiv1.HTTPRoute{}
+	key := types.NamespacedName{Name: routingRouteName("llm"), Namespace: "default"}
+	if err := c.Get(ctx, key, route); err != nil {
+		t.Fatalf("expected routing HTTPRoute: %v", err)
+	}
+	if !metav1.IsControlledBy(route, got) {
+		t.Error("routing HTTPRoute should be controlled by the MaaSModelRef")
+	}
+	if len(route.Spec.ParentRefs) == 0 || len(route.Spec.Rules) == 0 {
+		t.Fatalf("routing HTTPRoute should copy parentRefs and rules, got %+v", route.Spec)
+	}
+	rule := route.Spec.Rules[0]
+	if rule.Timeouts == nil || rule.Timeouts.Request == nil || *rule.Timeouts.Request != "15m" ||
+		rule.Timeouts.BackendRequest == nil || *rule.Timeouts.BackendRequest != "5m" {
+		t.Errorf("timeouts = %+v, want request 15m and backendRequest 5m", rule.Timeouts)
+	}
+	if rule.Retry == nil || *rule.Retry.Attempts != 3 || rule.Retry.Backoff == nil || *rule.Retry.Backoff != "250ms" ||
+		!reflect.DeepEqual(rule.Retry.Codes, []gatewayapiv1.HTTPRouteRetryStatusCode{503}) {
+		t.Errorf("retry = %+v, want 3 attempts on 503 with 250ms backoff", rule.Retry)
+	}
+
+	got.Spec.Routing = nil
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after unsetting routing: %v", err)
+	}
+	if err := c.Get(ctx, key, route); !apierrors.IsNotFound(err) {
+		t.Errorf("expected routing HTTPRoute to be deleted, got err=%v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionRoutingPolicyApplied); cond != nil {
+		t.Errorf("expected RoutingPolicyApplied to be removed, got %+v", cond)
+	}
+}
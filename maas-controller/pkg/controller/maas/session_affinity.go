@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+var istioDestinationRuleGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "DestinationRule"}
+
+//+kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	// reasonDestinationRuleApplied is the SessionAffinityConfigured=True reason.
+	reasonDestinationRuleApplied = "DestinationRuleApplied"
+	// reasonNoServiceBackends is used when the model's HTTPRoute has no Service backends
+	// in its namespace (e.g. it routes to an InferencePool, whose endpoint picker chooses the replica).
+	reasonNoServiceBackends = "NoServiceBackends"
+	// reasonDestinationRuleUnsupported is used when the Istio DestinationRule API is not installed.
+	reasonDestinationRuleUnsupported = "DestinationRuleUnsupported"
+
+	defaultSessionCookieName = "maas-session"
+	defaultSessionHeaderName = "x-session-id"
+	defaultSessionCookieTTL  = time.Hour
+
+	sessionAffinityComponent = "session-affinity"
+)
+
+// sessionAffinityLoadBalancer translates the session affinity spec into an Istio
+// consistent-hash load balancer. Istio issues the cookie itself when the request has none.
+func sessionAffinityLoadBalancer(a *maasv1alpha1.SessionAffinity) map[string]any {
+	if a.Type == maasv1alpha1.SessionAffinityHeader {
+		name := a.Name
+		if name == "" {
+			name = defaultSessionHeaderName
+		}
+		return map[string]any{"consistentHash": map[string]any{"httpHeaderName": name}}
+	}
+	name := a.Name
+	if name == "" {
+		name = defaultSessionCookieName
+	}
+	ttl := defaultSessionCookieTTL
+	if a.CookieTTL != nil && a.CookieTTL.Duration > 0 {
+		ttl = a.CookieTTL.Duration
+	}
+	return map[string]any{"consistentHash": map[string]any{"httpCookie": map[string]any{
+		"name": name,
+		"path": "/",
+		"ttl":  fmt.Sprintf("%ds", int64(ttl.Seconds())),
+	}}}
+}
+
+// routeServiceBackends returns the sorted names of the Services in the route's own
+// namespace that the route sends traffic to.
+func routeServiceBackends(route *gatewayapiv1.HTTPRoute) []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if ref.Group != nil && *ref.Group != "" {
+				continue
+			}
+			if ref.Kind != nil && *ref.Kind != "Service" {
+				continue
+			}
+			if ref.Namespace != nil && string(*ref.Namespace) != route.Namespace {
+				continue
+			}
+			if _, ok := seen[string(ref.Name)]; ok {
+				continue
+			}
+			seen[string(ref.Name)] = struct{}{}
+			out = append(out, string(ref.Name))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// destinationRuleName returns the name of the DestinationRule for a model backend Service.
+func destinationRuleName(modelName, service string) string {
+	return fmt.Sprintf("%s-%s-affinity", modelName, service)
+}
+
+// reconcileSessionAffinity applies spec.routing.sessionAffinity as one Istio DestinationRule
+// per backend Service of the model's HTTPRoute. The route itself belongs to KServe, so the
+// stickiness lives on the destination instead. DestinationRules are owned by the model and
+// removed when session affinity is unset.
+func (r *MaaSModelRefReconciler) reconcileSessionAffinity(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef) error {
+	var affinity *maasv1alpha1.SessionAffinity
+	if model.Spec.Routing != nil {
+		affinity = model.Spec.Routing.SessionAffinity
+	}
+	if affinity == nil {
+		if apimeta.FindStatusCondition(model.Status.Conditions, maasv1alpha1.ConditionSessionAffinityConfigured) == nil {
+			return nil
+		}
+		apimeta.RemoveStatusCondition(&model.Status.Conditions, maasv1alpha1.ConditionSessionAffinityConfigured)
+		return r.pruneDestinationRules(ctx, log, model, nil)
+	}
+	if model.Status.HTTPRouteName == "" {
+		return nil
+	}
+
+	cond := metav1.Condition{
+		Type:               maasv1alpha1.ConditionSessionAffinityConfigured,
+		ObservedGeneration: model.GetGeneration(),
+	}
+	route := &gatewayapiv1.HTTPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Name: model.Status.HTTPRouteName, Namespace: model.Status.HTTPRouteNamespace}, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get HTTPRoute %s/%s: %w", model.Status.HTTPRouteNamespace, model.Status.HTTPRouteName, err)
+	}
+	services := routeServiceBackends(route)
+	if len(services) == 0 || route.Namespace != model.Namespace {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonNoServiceBackends
+		cond.Message = fmt.Sprintf("HTTPRoute %s/%s has no Service backends in namespace %s to apply session affinity to", route.Namespace, route.Name, model.Namespace)
+		apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+		return r.pruneDestinationRules(ctx, log, model, nil)
+	}
+
+	keep := make(map[string]bool, len(services))
+	for _, svc := range services {
+		dr := &unstructured.Unstructured{}
+		dr.SetGroupVersionKind(istioDestinationRuleGVK)
+		dr.SetName(destinationRuleName(model.Name, svc))
+		dr.SetNamespace(model.Namespace)
+		keep[dr.GetName()] = true
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, dr, func() error {
+			labels := dr.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels["maas.opendatahub.io/model"] = model.Name
+			labels["app.kubernetes.io/managed-by"] = "maas-controller"
+			labels["app.kubernetes.io/part-of"] = "maas-model-ref"
+			labels["app.kubernetes.io/component"] = sessionAffinityComponent
+			dr.SetLabels(labels)
+			if err := controllerutil.SetControllerReference(model, dr, r.Scheme); err != nil {
+				return err
+			}
+			return unstructured.SetNestedMap(dr.Object, map[string]any{
+				"host": fmt.Sprintf("%s.%s.svc.cluster.local", svc, model.Namespace),
+				"trafficPolicy": map[string]any{
+					"loadBalancer": sessionAffinityLoadBalancer(affinity),
+				},
+			}, "spec")
+		})
+		if err != nil {
+			if apimeta.IsNoMatchError(err) {
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = reasonDestinationRuleUnsupported
+				cond.Message = "Istio DestinationRule API is not installed; session affinity cannot be applied"
+				apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+				return nil
+			}
+			return fmt.Errorf("failed to apply DestinationRule %s/%s: %w", dr.GetNamespace(), dr.GetName(), err)
+		}
+		if op != controllerutil.OperationResultNone {
+			log.Info("Session affinity DestinationRule applied", "name", dr.GetName(), "service", svc, "operation", op)
+		}
+	}
+	if err := r.pruneDestinationRules(ctx, log, model, keep); err != nil {
+		return err
+	}
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = reasonDestinationRuleApplied
+	cond.Message = fmt.Sprintf("%s session affinity applied to Service(s) %s", sessionAffinityType(affinity), strings.Join(services, ", "))
+	apimeta.SetStatusCondition(&model.Status.Conditions, cond)
+	return nil
+}
+
+// sessionAffinityType returns the effective session affinity type.
+func sessionAffinityType(a *maasv1alpha1.SessionAffinity) maasv1alpha1.SessionAffinityType {
+	if a.Type == "" {
+		return maasv1alpha1.SessionAffinityCookie
+	}
+	return a.Type
+}
+
+// pruneDestinationRules deletes the model's session affinity DestinationRules not in keep.
+func (r *MaaSModelRefReconciler) pruneDestinationRules(ctx context.Context, log logr.Logger, model *maasv1alpha1.MaaSModelRef, keep map[string]bool) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(istioDestinationRuleGVK.GroupVersion().WithKind("DestinationRuleList"))
+	if err := r.List(ctx, list, client.InNamespace(model.Namespace), client.MatchingLabels{
+		"maas.opendatahub.io/model":   model.Name,
+		"app.kubernetes.io/component": sessionAffinityComponent,
+	}); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list session affinity DestinationRules: %w", err)
+	}
+	for i := range list.Items {
+		dr := &list.Items[i]
+		if keep[dr.GetName()] || !metav1.IsControlledBy(dr, model) {
+			continue
+		}
+		if err := r.Delete(ctx, dr); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete DestinationRule %s/%s: %w", dr.GetNamespace(), dr.GetName(), err)
+		}
+		log.Info("Session affinity DestinationRule deleted", "name", dr.GetName())
+	}
+	return nil
+}
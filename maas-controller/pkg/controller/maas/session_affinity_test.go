@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestSessionAffinityLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name     string
+		affinity maasv1alpha1.SessionAffinity
+		want     map[string]any
+	}{
+		{
+			name:     "cookie defaults",
+			affinity: maasv1alpha1.SessionAffinity{},
+			want: map[string]any{"consistentHash": map[string]any{"httpCookie": map[string]any{
+				"name": "maas-session", "path": "/", "ttl": "3600s",
+			}}},
+		},
+		{
+			name: "cookie with name and ttl",
+			affinity: maasv1alpha1.SessionAffinity{
+				Type: maasv1alpha1.SessionAffinityCookie, Name: "kv", CookieTTL: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			want: map[string]any{"consistentHash": map[string]any{"httpCookie": map[string]any{
+				"name": "kv", "path": "/", "ttl": "600s",
+			}}},
+		},
+		{
+			name:     "header default",
+			affinity: maasv1alpha1.SessionAffinity{Type: maasv1alpha1.SessionAffinityHeader},
+			want:     map[string]any{"consistentHash": map[string]any{"httpHeaderName": "x-session-id"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionAffinityLoadBalancer(&tt.affinity); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sessionAffinityLoadBalancer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteServiceBackends(t *testing.T) {
+	poolGroup := gatewayapiv1.Group("inference.networking.k8s.io")
+	poolKind := gatewayapiv1.Kind("InferencePool")
+	otherNS := gatewayapiv1.Namespace("other")
+	route := newHTTPRoute("llm-route", "default")
+	route.Spec.Rules = []gatewayapiv1.HTTPRouteRule{
+		{BackendRefs: []gatewayapiv1.HTTPBackendRef{
+			{BackendRef: gatewayapiv1.BackendRef{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "svc-b"}}},
+			{BackendRef: gatewayapiv1.BackendRef{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "svc-a"}}},
+		}},
+		{BackendRefs: []gatewayapiv1.HTTPBackendRef{
+			{BackendRef: gatewayapiv1.BackendRef{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "svc-a"}}},
+			{BackendRef: gatewayapiv1.BackendRef{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "pool", Group: &poolGroup, Kind: &poolKind}}},
+			{BackendRef: gatewayapiv1.BackendRef{BackendObjectReference: gatewayapiv1.BackendObjectReference{Name: "remote", Namespace: &otherNS}}},
+		}},
+	}
+	if got := routeServiceBackends(route); !reflect.DeepEqual(got, []string{"svc-a", "svc-b"}) {
+		t.Errorf("routeServiceBackends() = %v, want [svc-a svc-b]", got)
+	}
+}
+
+// TestMaaSModelRefReconciler_SessionAffinity verifies that spec.routing.sessionAffinity
+// creates a DestinationRule for the route's backend Service and that unsetting it removes it.
+func TestMaaSModelRefReconciler_SessionAffinity(t *testing.T) {
+	ctx := context.Background()
+	model := newMaaSModelRef("llm", "default", "LLMInferenceService", "llm")
+	model.Spec.Routing = &maasv1alpha1.RoutingSpec{
+		SessionAffinity: &maasv1alpha1.SessionAffinity{Type: maasv1alpha1.SessionAffinityHeader, Name: "x-conversation"},
+	}
+	r, c := newTestReconcilerWithMapper(
+		model,
+		newMaaSGateway(testGatewayName, testGatewayNamespace),
+		newLLMISvc("llm", "default", corev1.ConditionTrue),
+		withKServeRules(newLLMISvcRoute("llm", "default"), "llm"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llm", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSModelRef{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertCondition(t, got.Status.Conditions, maasv1alpha1.ConditionSessionAffinityConfigured, metav1.ConditionTrue, reasonDestinationRuleApplied)
+
+	dr := &unstructured.Unstructured{}
+	dr.SetGroupVersionKind(istioDestinationRuleGVK)
+	key := types.NamespacedName{Name: destinationRuleName("llm", "llm-workload"), Namespace: "default"}
+	if err := c.Get(ctx, key, dr); err != nil {
+		t.Fatalf("expected DestinationRule: %v", err)
+	}
+	if host, _, _ := unstructured.NestedString(dr.Object, "spec", "host"); host != "llm-workload.default.svc.cluster.local" {
+		t.Errorf("DestinationRule host = %q, want llm-workload.default.svc.cluster.local", host)
+	}
+	if header, _, _ := unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "loadBalancer", "consistentHash", "httpHeaderName"); header != "x-conversation" {
+		t.Errorf("consistentHash.httpHeaderName = %q, want x-conversation", header)
+	}
+
+	got.Spec.Routing = nil
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after unsetting session affinity: %v", err)
+	}
+	if err := c.Get(ctx, key, dr); !apierrors.IsNotFound(err) {
+		t.Errorf("expected DestinationRule to be deleted, got err=%v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, maasv1alpha1.ConditionSessionAffinityConfigured); cond != nil {
+		t.Errorf("expected SessionAffinityConfigured to be removed, got %+v", cond)
+	}
+}
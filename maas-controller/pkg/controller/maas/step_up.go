@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// stepUpRuleName is the gateway AuthPolicy rule enforcing the tenant's OIDC stepUp.
+const stepUpRuleName = "step-up-authentication"
+
+// defaultStepUpPaths are the maas-api key-management and admin routes, used when
+// stepUp.paths is empty.
+var defaultStepUpPaths = []string{"/maas-api/v1/api-keys*", "/maas-api/v1/admin*"}
+
+// celStepUpPath is true when the request path is one of the step-up paths.
+func celStepUpPath(paths []string) string {
+	if len(paths) == 0 {
+		paths = defaultStepUpPaths
+	}
+	matches := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			matches = append(matches, `request.url_path.startsWith(`+strconv.Quote(prefix)+`)`)
+		} else {
+			matches = append(matches, `request.url_path == `+strconv.Quote(p))
+		}
+	}
+	return "(" + strings.Join(matches, " || ") + ")"
+}
+
+// stepUpRego allows tokens of the issuer that are recent enough and carry an accepted acr
+// claim. API keys and Kubernetes tokens carry no iss claim and are refused.
+func stepUpRego(issuerURL string, stepUp *maasv1alpha1.TenantStepUpAuthConfig) string {
+	return fmt.Sprintf(`issuer := %s
+max_token_age := %d
+acr_values := %s
+
+identity := input.auth.identity { is_object(input.auth.identity) } else := {}
+
+authenticated_at := identity.auth_time { is_number(object.get(identity, "auth_time", null)) } else := object.get(identity, "iat", 0)
+
+fresh {
+	max_token_age == 0
+}
+
+fresh {
+	time.now_ns() / 1000000000 - authenticated_at <= max_token_age
+}
+
+acr_allowed {
+	count(acr_values) == 0
+}
+
+acr_allowed {
+	acr_values[_] == object.get(identity, "acr", "")
+}
+
+allow {
+	object.get(identity, "iss", "") == issuer
+	fresh
+	acr_allowed
+}`, strconv.Quote(issuerURL), stepUp.MaxTokenAgeSeconds, celStringList(stepUp.ACRValues))
+}
+
+// addStepUpAuthorizationRule adds the rule requiring a recent token of the tenant's OIDC
+// issuer on the step-up paths. It applies to every credential, so that long-lived API keys
+// stay limited to inference.
+func addStepUpAuthorizationRule(authorization map[string]kuadrantv1.AuthorizationRule, oidc *oidcConfig) {
+	if oidc == nil || oidc.StepUp == nil {
+		return
+	}
+	authorization[stepUpRuleName] = kuadrantv1.AuthorizationRule{
+		CommonRule: kuadrantv1.CommonRule{
+			When: []kuadrantv1.WhenCondition{{Predicate: celStepUpPath(oidc.StepUp.Paths)}},
+		},
+		OPA: &kuadrantv1.OPAAuthorization{Rego: stepUpRego(oidc.IssuerURL, oidc.StepUp)},
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestCelStepUpPath(t *testing.T) {
+	if got, want := celStepUpPath(nil), `(request.url_path.startsWith("/maas-api/v1/api-keys") || request.url_path.startsWith("/maas-api/v1/admin"))`; got != want {
+		t.Errorf("default paths = %s, want %s", got, want)
+	}
+	got := celStepUpPath([]string{"/maas-api/v1/api-keys/bulk-revoke", "/maas-api/v1/admin*"})
+	want := `(request.url_path == "/maas-api/v1/api-keys/bulk-revoke" || request.url_path.startsWith("/maas-api/v1/admin"))`
+	if got != want {
+		t.Errorf("paths = %s, want %s", got, want)
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_StepUp(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+	oidc := &oidcConfig{IssuerURL: "https://keycloak.example.com/realms/maas", ClientID: "maas"}
+
+	spec := r.buildGatewayAuthPolicySpec("{}", oidc, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	if _, ok := spec.Defaults.Rules.Authorization[stepUpRuleName]; ok {
+		t.Error("step-up rule should only be generated when stepUp is configured")
+	}
+
+	oidc.StepUp = &maasv1alpha1.TenantStepUpAuthConfig{MaxTokenAgeSeconds: 300, ACRValues: []string{"mfa"}}
+	spec = r.buildGatewayAuthPolicySpec("{}", oidc, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	rule, ok := spec.Defaults.Rules.Authorization[stepUpRuleName]
+	if !ok || rule.OPA == nil {
+		t.Fatalf("step-up rule missing: %+v", spec.Defaults.Rules.Authorization)
+	}
+	if len(rule.When) != 1 || rule.When[0].Predicate != celStepUpPath(nil) {
+		t.Errorf("when = %+v, want only the step-up paths so that API keys are refused there", rule.When)
+	}
+	for _, want := range []string{
+		`issuer := "https://keycloak.example.com/realms/maas"`,
+		`max_token_age := 300`,
+		`acr_values := ["mfa"]`,
+		`object.get(identity, "iss", "") == issuer`,
+	} {
+		if !strings.Contains(rule.OPA.Rego, want) {
+			t.Errorf("rego missing %q:\n%s", want, rule.OPA.Rego)
+		}
+	}
+}
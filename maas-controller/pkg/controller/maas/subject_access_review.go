@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// modelAccessReviewGroup and modelAccessReviewResource name the virtual resource
+	// RBAC grants access to models on. No such API is served; the resource only exists
+	// in Roles and SubjectAccessReviews.
+	modelAccessReviewGroup    = "maas.opendatahub.io"
+	modelAccessReviewResource = "models"
+
+	defaultModelAccessReviewVerb = "get"
+)
+
+// subjectAccessReview is the verb callers need on the models, as "namespace/name", of
+// the policies setting spec.authorization.subjectAccessReview with it.
+type subjectAccessReview struct {
+	Verb   string
+	Models []string
+}
+
+// policySubjectAccessReview returns the spec.authorization.subjectAccessReview of a
+// policy, or nil.
+func policySubjectAccessReview(p *maasv1alpha1.MaaSAuthPolicy) *maasv1alpha1.SubjectAccessReviewAuthorization {
+	if p.Spec.Authorization == nil {
+		return nil
+	}
+	return p.Spec.Authorization.SubjectAccessReview
+}
+
+// aggregateSubjectAccessReviews groups the models of the policies setting
+// spec.authorization.subjectAccessReview by verb, sorted by verb.
+func aggregateSubjectAccessReviews(policies []maasv1alpha1.MaaSAuthPolicy) []subjectAccessReview {
+	modelsByVerb := map[string][]string{}
+	for _, p := range policies {
+		sar := policySubjectAccessReview(&p)
+		if sar == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		verb := sar.Verb
+		if verb == "" {
+			verb = defaultModelAccessReviewVerb
+		}
+		for _, ref := range p.Spec.ModelRefs {
+			modelsByVerb[verb] = append(modelsByVerb[verb], ref.Namespace+"/"+ref.Name)
+		}
+	}
+	var out []subjectAccessReview
+	for _, verb := range sortedKeys(modelsByVerb) {
+		out = append(out, subjectAccessReview{Verb: verb, Models: deduplicateAndSort(modelsByVerb[verb])})
+	}
+	return out
+}
+
+// subjectAccessReviewRuleName is the gateway AuthPolicy rule checking a verb on models.
+func subjectAccessReviewRuleName(verb string) string {
+	return "subject-access-review-" + verb
+}
+
+// addSubjectAccessReviewRules adds an authorization rule per verb, evaluated for the
+// requests to its models only, asking the Kubernetes API whether the caller may use the
+// verb on the model in the model's namespace. The require-group-membership rule lets
+// these models through, so RBAC decides who may call them.
+func addSubjectAccessReviewRules(authorization map[string]kuadrantv1.AuthorizationRule, reviews []subjectAccessReview, cacheTTL int64) {
+	for _, review := range reviews {
+		authorization[subjectAccessReviewRuleName(review.Verb)] = kuadrantv1.AuthorizationRule{
+			CommonRule: kuadrantv1.CommonRule{
+				When: []kuadrantv1.WhenCondition{{Predicate: celRequestedModelIn(review.Models)}},
+				Cache: &kuadrantv1.RuleCache{
+					Key: kuadrantv1.ValueFrom{Selector: gatewayAuthzCacheKeySelector()},
+					TTL: cacheTTL,
+				},
+				Priority: 1,
+			},
+			KubernetesSubjectAccessReview: &kuadrantv1.KubernetesSubjectAccessReviewAuthz{
+				User:                kuadrantv1.ValueFrom{Expression: celUsername},
+				AuthorizationGroups: &kuadrantv1.ValueFrom{Expression: celGroups},
+				ResourceAttributes: &kuadrantv1.SubjectAccessReviewResourceAttributes{
+					Namespace: kuadrantv1.ValueFrom{Expression: celModelIdentity + `.split("/")[0]`},
+					Group:     kuadrantv1.ValueFrom{Value: modelAccessReviewGroup},
+					Resource:  kuadrantv1.ValueFrom{Value: modelAccessReviewResource},
+					Name:      kuadrantv1.ValueFrom{Expression: celModelIdentity + `.split("/")[1]`},
+					Verb:      kuadrantv1.ValueFrom{Value: review.Verb},
+				},
+			},
+		}
+	}
+}
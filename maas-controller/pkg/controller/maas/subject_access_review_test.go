@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func newSubjectAccessReviewAuthPolicy(name, verb string, refs ...maasv1alpha1.ModelRef) *maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-a", refs...)
+	p.Spec.Subjects = maasv1alpha1.SubjectSpec{}
+	p.Spec.Authorization = &maasv1alpha1.AuthorizationSpec{
+		SubjectAccessReview: &maasv1alpha1.SubjectAccessReviewAuthorization{Verb: verb},
+	}
+	return p
+}
+
+func TestAggregateSubjectAccessReviews(t *testing.T) {
+	modelA := maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"}
+	modelB := maasv1alpha1.ModelRef{Name: "model-b", Namespace: "llm"}
+
+	defaulted := newSubjectAccessReviewAuthPolicy("rbac", "", modelB, modelA)
+	get := newSubjectAccessReviewAuthPolicy("rbac-get", "get", modelA)
+	use := newSubjectAccessReviewAuthPolicy("rbac-use", "use", modelB)
+	deleting := newSubjectAccessReviewAuthPolicy("deleting", "use", modelA)
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	plain := newMaaSAuthPolicy("plain", "default", "team-b", modelA)
+
+	got := aggregateSubjectAccessReviews([]maasv1alpha1.MaaSAuthPolicy{*use, *defaulted, *plain, *get, *deleting})
+	if len(got) != 2 {
+		t.Fatalf("got %d reviews, want one per verb: %+v", len(got), got)
+	}
+	if got[0].Verb != "get" || strings.Join(got[0].Models, ",") != "llm/model-a,llm/model-b" {
+		t.Errorf("get review = %+v, want llm/model-a and llm/model-b with the default verb", got[0])
+	}
+	if got[1].Verb != "use" || strings.Join(got[1].Models, ",") != "llm/model-b" {
+		t.Errorf("use review = %+v, want llm/model-b only (deleting policies do not contribute)", got[1])
+	}
+}
+
+func TestAggregateSubjectAllowlists_SubjectAccessReview(t *testing.T) {
+	modelA := maasv1alpha1.ModelRef{Name: "model-a", Namespace: "llm"}
+	modelB := maasv1alpha1.ModelRef{Name: "model-b", Namespace: "llm"}
+
+	rbac := newSubjectAccessReviewAuthPolicy("rbac", "", modelA)
+	rbac.Spec.Subjects.DeniedUsers = []string{"mallory"}
+	plain := newMaaSAuthPolicy("plain", "default", "team-b", modelA, modelB)
+
+	allowlists, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{*rbac, *plain})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists: %v", err)
+	}
+	if a := allowlists["llm/model-a"]; !a.SubjectAccessReview || strings.Join(a.DeniedUsers, ",") != "mallory" {
+		t.Errorf("llm/model-a = %+v, want SubjectAccessReview with the denied users kept", a)
+	}
+	if allowlists["llm/model-b"].SubjectAccessReview {
+		t.Error("llm/model-b has no policy with subjectAccessReview")
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_SubjectAccessReview(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{MaaSAPINamespace: "opendatahub", AuthzCacheTTL: 60, MetadataCacheTTL: 60}
+
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	for name, rule := range spec.Defaults.Rules.Authorization {
+		if rule.KubernetesSubjectAccessReview != nil {
+			t.Errorf("%s should not review access without spec.authorization.subjectAccessReview", name)
+		}
+	}
+
+	authz := gatewayAuthorization{SubjectAccessReviews: []subjectAccessReview{
+		{Verb: "get", Models: []string{"llm/model-a"}},
+		{Verb: "use", Models: []string{"llm/model-b"}},
+	}}
+	spec = r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, authz, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	rules := spec.Defaults.Rules.Authorization
+
+	get, ok := rules["subject-access-review-get"]
+	if !ok || get.KubernetesSubjectAccessReview == nil {
+		t.Fatalf("subject-access-review-get rule missing: %+v", rules)
+	}
+	if !strings.Contains(get.When[0].Predicate, `in ["llm/model-a"]`) {
+		t.Errorf("subject-access-review-get should only apply to llm/model-a, got %q", get.When[0].Predicate)
+	}
+	if get.Priority != 1 {
+		t.Errorf("subject-access-review-get priority = %d, want 1 so it runs after the generated rules", get.Priority)
+	}
+	if get.Cache == nil || get.Cache.TTL != 60 {
+		t.Errorf("subject-access-review-get cache = %+v, want the authorization cache TTL", get.Cache)
+	}
+	sar := get.KubernetesSubjectAccessReview
+	if sar.User.Expression != celUsername || sar.AuthorizationGroups == nil || sar.AuthorizationGroups.Expression != celGroups {
+		t.Errorf("review should be for the caller's username and groups, got %+v", sar)
+	}
+	attrs := sar.ResourceAttributes
+	if attrs.Group.Value != "maas.opendatahub.io" || attrs.Resource.Value != "models" || attrs.Verb.Value != "get" {
+		t.Errorf("resource attributes = %+v, want get on models.maas.opendatahub.io", attrs)
+	}
+	if !strings.HasSuffix(attrs.Namespace.Expression, `.split("/")[0]`) || !strings.HasSuffix(attrs.Name.Expression, `.split("/")[1]`) {
+		t.Errorf("resource attributes should name the requested model, got namespace %q and name %q", attrs.Namespace.Expression, attrs.Name.Expression)
+	}
+
+	if use := rules["subject-access-review-use"]; use.KubernetesSubjectAccessReview == nil || use.KubernetesSubjectAccessReview.ResourceAttributes.Verb.Value != "use" {
+		t.Errorf("subject-access-review-use rule = %+v, want the use verb", use)
+	}
+	if !strings.Contains(rules["require-group-membership"].OPA.Rego, "model_rules.subjectAccessReview == true") {
+		t.Error("require-group-membership should let models reviewed with RBAC through")
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// TemplateFieldsAnnotation lists the spec fields of a MaaSSubscription that the
+// controller copied from its MaaSSubscriptionTemplate. These follow the template; the
+// fields the subscription sets itself are its own and take precedence over the template.
+const TemplateFieldsAnnotation = "maas.opendatahub.io/template-fields"
+
+// templateField is a spec field a MaaSSubscriptionTemplate can define.
+type templateField struct {
+	name string
+	// isSet reports whether the field has a value other than its default.
+	isSet func(spec *maasv1alpha1.MaaSSubscriptionSpec) bool
+	copy  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec)
+}
+
+var templateFields = []templateField{
+	{
+		name:  "modelRefs",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return len(s.ModelRefs) > 0 },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.ModelRefs = src.ModelRefs },
+	},
+	{
+		name:  "modelSelector",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return s.ModelSelector != nil },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.ModelSelector = src.ModelSelector },
+	},
+	{
+		name:  "tokenMetadata",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return s.TokenMetadata != nil },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.TokenMetadata = src.TokenMetadata },
+	},
+	{
+		name:  "priority",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return s.Priority != 0 },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.Priority = src.Priority },
+	},
+	{
+		name: "counterScope",
+		// User is defaulted by the CRD, so it does not hide the template's scope.
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool {
+			return s.CounterScope != "" && s.CounterScope != maasv1alpha1.CounterScopeUser
+		},
+		copy: func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.CounterScope = src.CounterScope },
+	},
+	{
+		name:  "counterExpressions",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return len(s.CounterExpressions) > 0 },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.CounterExpressions = src.CounterExpressions },
+	},
+	{
+		name:  "resetSchedule",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return s.ResetSchedule != nil },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.ResetSchedule = src.ResetSchedule },
+	},
+	{
+		name:  "limitGroups",
+		isSet: func(s *maasv1alpha1.MaaSSubscriptionSpec) bool { return len(s.LimitGroups) > 0 },
+		copy:  func(dst, src *maasv1alpha1.MaaSSubscriptionSpec) { dst.LimitGroups = src.LimitGroups },
+	},
+}
+
+// templateSubscriptionSpec returns the template's plan as a subscription spec.
+func templateSubscriptionSpec(tmpl *maasv1alpha1.MaaSSubscriptionTemplate) maasv1alpha1.MaaSSubscriptionSpec {
+	t := tmpl.Spec.DeepCopy()
+	return maasv1alpha1.MaaSSubscriptionSpec{
+		ModelRefs:          t.ModelRefs,
+		ModelSelector:      t.ModelSelector,
+		TokenMetadata:      t.TokenMetadata,
+		Priority:           t.Priority,
+		CounterScope:       t.CounterScope,
+		CounterExpressions: t.CounterExpressions,
+		ResetSchedule:      t.ResetSchedule,
+		LimitGroups:        t.LimitGroups,
+	}
+}
+
+// applySubscriptionTemplate merges the template into the subscription's spec and
+// records the fields it copied in TemplateFieldsAnnotation. A field the subscription
+// sets itself is kept; any other field the template defines is copied; a field copied
+// before that the template no longer defines is cleared. It reports whether the
+// subscription changed.
+func applySubscriptionTemplate(sub *maasv1alpha1.MaaSSubscription, tmpl *maasv1alpha1.MaaSSubscriptionTemplate) bool {
+	copied := templateFieldsOf(sub)
+	src := templateSubscriptionSpec(tmpl)
+	var zero maasv1alpha1.MaaSSubscriptionSpec
+	spec := sub.Spec.DeepCopy()
+	var fields []string
+	for _, f := range templateFields {
+		switch {
+		case f.isSet(spec) && !copied[f.name]:
+			// The subscription's own value.
+		case f.isSet(&src):
+			f.copy(spec, &src)
+			fields = append(fields, f.name)
+		case copied[f.name]:
+			f.copy(spec, &zero)
+		}
+	}
+
+	annotation := strings.Join(fields, ",")
+	if reflect.DeepEqual(*spec, sub.Spec) && annotation == sub.GetAnnotations()[TemplateFieldsAnnotation] {
+		return false
+	}
+	sub.Spec = *spec
+	setTemplateFieldsAnnotation(sub, annotation)
+	return true
+}
+
+// templateFieldsOf returns the fields recorded as copied from the template.
+func templateFieldsOf(sub *maasv1alpha1.MaaSSubscription) map[string]bool {
+	fields := map[string]bool{}
+	for _, name := range strings.Split(sub.GetAnnotations()[TemplateFieldsAnnotation], ",") {
+		if name != "" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+func setTemplateFieldsAnnotation(sub *maasv1alpha1.MaaSSubscription, value string) {
+	annotations := sub.GetAnnotations()
+	if value == "" {
+		delete(annotations, TemplateFieldsAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[TemplateFieldsAnnotation] = value
+	}
+	sub.SetAnnotations(annotations)
+}
+
+// reconcileSubscriptionTemplate materializes the subscription's MaaSSubscriptionTemplate
+// into its spec, so that policy generation and maas-api read the plan like any other
+// subscription's. The merged spec is written back, and admission validates it there.
+// It returns false when the template does not exist. Once templateRef is removed, the
+// fields copied last become the subscription's own.
+func (r *MaaSSubscriptionReconciler) reconcileSubscriptionTemplate(ctx context.Context, log logr.Logger, sub *maasv1alpha1.MaaSSubscription) (bool, error) {
+	ref := sub.Spec.TemplateRef
+	if ref == nil {
+		if _, ok := sub.GetAnnotations()[TemplateFieldsAnnotation]; !ok {
+			return true, nil
+		}
+		setTemplateFieldsAnnotation(sub, "")
+		if err := r.Update(ctx, sub); err != nil {
+			return false, fmt.Errorf("failed to detach MaaSSubscriptionTemplate: %w", err)
+		}
+		return true, nil
+	}
+
+	tmpl := &maasv1alpha1.MaaSSubscriptionTemplate{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: sub.Namespace}, tmpl); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get MaaSSubscriptionTemplate %s/%s: %w", sub.Namespace, ref.Name, err)
+	}
+	if !applySubscriptionTemplate(sub, tmpl) {
+		return true, nil
+	}
+	if err := r.Update(ctx, sub); err != nil {
+		return false, fmt.Errorf("failed to apply MaaSSubscriptionTemplate %s/%s: %w", sub.Namespace, ref.Name, err)
+	}
+	log.Info("Applied MaaSSubscriptionTemplate", "template", ref.Name, "fields", sub.GetAnnotations()[TemplateFieldsAnnotation])
+	return true, nil
+}
+
+// mapSubscriptionTemplateToMaaSSubscriptions returns the subscriptions referencing the
+// template, so that template changes propagate to them.
+func (r *MaaSSubscriptionReconciler) mapSubscriptionTemplateToMaaSSubscriptions(ctx context.Context, obj client.Object) []reconcile.Request {
+	subList := &maasv1alpha1.MaaSSubscriptionList{}
+	if err := r.List(ctx, subList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list MaaSSubscription resources for MaaSSubscriptionTemplate change",
+			"template", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, sub := range subList.Items {
+		if ref := sub.Spec.TemplateRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: sub.Name, Namespace: sub.Namespace}})
+		}
+	}
+	return requests
+}
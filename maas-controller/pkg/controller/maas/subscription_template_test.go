@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+// newSubscriptionTemplate returns a plan with one model and a group counter scope.
+func newSubscriptionTemplate(name, namespace, modelName string, limit int64) *maasv1alpha1.MaaSSubscriptionTemplate {
+	return &maasv1alpha1.MaaSSubscriptionTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: maasv1alpha1.MaaSSubscriptionTemplateSpec{
+			ModelRefs: []maasv1alpha1.ModelSubscriptionRef{
+				{Name: modelName, Namespace: namespace, TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: limit, Window: "1m"}}},
+			},
+			Priority:     10,
+			CounterScope: maasv1alpha1.CounterScopeGroup,
+		},
+	}
+}
+
+// newTemplatedSubscription returns a subscription that only names its template and owner.
+func newTemplatedSubscription(name, namespace, group, template string) *maasv1alpha1.MaaSSubscription {
+	return &maasv1alpha1.MaaSSubscription{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: maasv1alpha1.MaaSSubscriptionSpec{
+			Owner:        maasv1alpha1.OwnerSpec{Groups: []maasv1alpha1.GroupReference{{Name: group}}},
+			TemplateRef:  &maasv1alpha1.SubscriptionTemplateReference{Name: template},
+			CounterScope: maasv1alpha1.CounterScopeUser,
+		},
+	}
+}
+
+func TestApplySubscriptionTemplate(t *testing.T) {
+	tmpl := newSubscriptionTemplate("pro", "default", "llm", 1000)
+	sub := newTemplatedSubscription("sub-a", "default", "team-a", "pro")
+	sub.Spec.Priority = 5
+
+	if !applySubscriptionTemplate(sub, tmpl) {
+		t.Fatal("expected the first apply to change the subscription")
+	}
+	if sub.Spec.Priority != 5 {
+		t.Errorf("expected the subscription's own priority to be kept, got %d", sub.Spec.Priority)
+	}
+	if !reflect.DeepEqual(sub.Spec.ModelRefs, tmpl.Spec.ModelRefs) || sub.Spec.CounterScope != maasv1alpha1.CounterScopeGroup {
+		t.Errorf("expected the template's modelRefs and counterScope, got %+v", sub.Spec)
+	}
+	if got := sub.Annotations[TemplateFieldsAnnotation]; got != "modelRefs,counterScope" {
+		t.Errorf("%s = %q, want %q", TemplateFieldsAnnotation, got, "modelRefs,counterScope")
+	}
+	if applySubscriptionTemplate(sub, tmpl) {
+		t.Error("expected applying the same template again to change nothing")
+	}
+
+	tmpl.Spec.ModelRefs[0].TokenRateLimits[0].Limit = 2000
+	tmpl.Spec.CounterScope = ""
+	if !applySubscriptionTemplate(sub, tmpl) {
+		t.Fatal("expected a template change to change the subscription")
+	}
+	if got := sub.Spec.ModelRefs[0].TokenRateLimits[0].Limit; got != 2000 {
+		t.Errorf("expected the updated template limit, got %d", got)
+	}
+	if sub.Spec.CounterScope != "" {
+		t.Errorf("expected the counterScope dropped from the template to be cleared, got %q", sub.Spec.CounterScope)
+	}
+	if got := sub.Annotations[TemplateFieldsAnnotation]; got != "modelRefs" {
+		t.Errorf("%s = %q, want %q", TemplateFieldsAnnotation, got, "modelRefs")
+	}
+}
+
+// TestMaaSSubscriptionReconciler_SubscriptionTemplate verifies that a subscription
+// referencing a template gets its plan materialized and policies generated, and that a
+// missing template is reported.
+func TestMaaSSubscriptionReconciler_SubscriptionTemplate(t *testing.T) {
+	ctx := context.Background()
+	const namespace = "default"
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(
+			newMaaSModelRef("llm", namespace, "ExternalModel", "llm"),
+			newHTTPRoute("maas-llm", namespace),
+			newSubscriptionTemplate("pro", namespace, "llm", 1000),
+			newTemplatedSubscription("sub-a", namespace, "team-a", "pro"),
+			newTemplatedSubscription("sub-b", namespace, "team-b", "missing"),
+		).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	sub := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, sub); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if len(sub.Spec.ModelRefs) != 1 || sub.Spec.ModelRefs[0].Name != "llm" || sub.Spec.Priority != 10 {
+		t.Errorf("expected the template's plan in the subscription's spec, got %+v", sub.Spec)
+	}
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	if err := c.Get(ctx, client.ObjectKey{Name: tokenRateLimitPolicyName(namespace, "llm", ""), Namespace: namespace}, trlp); err != nil {
+		t.Errorf("expected a TokenRateLimitPolicy for the template's model: %v", err)
+	}
+
+	if got := r.mapSubscriptionTemplateToMaaSSubscriptions(ctx, newSubscriptionTemplate("pro", namespace, "llm", 1000)); len(got) != 1 || got[0].Name != "sub-a" {
+		t.Errorf("expected the template to map to sub-a, got %v", got)
+	}
+
+	req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-b", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, sub); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if ready := apimeta.FindStatusCondition(sub.Status.Conditions, "Ready"); ready == nil || ready.Reason != string(maasv1alpha1.ReasonTemplateNotFound) {
+		t.Errorf("expected Ready reason %s, got %+v", maasv1alpha1.ReasonTemplateNotFound, ready)
+	}
+}
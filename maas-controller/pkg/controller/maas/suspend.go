@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// ConditionSuspended is set True while a MaaSSubscription has spec.suspended set.
+	ConditionSuspended = "Suspended"
+
+	// suspendedWindow is the window of the zero rate that denies a suspended subscription.
+	suspendedWindow = "1m"
+)
+
+// suspendedLimit returns the limit that replaces a suspended subscription's rates: a
+// single zero rate, so every request matching the predicate is over the limit. It has no
+// counters since nothing is counted.
+func suspendedLimit(predicate string) kuadrantv1.Limit {
+	return kuadrantv1.Limit{
+		Rates: []kuadrantv1.Rate{{Limit: 0, Window: suspendedWindow}},
+		When:  []kuadrantv1.Predicate{{Predicate: predicate}},
+	}
+}
+
+// setSuspendedCondition sets the Suspended condition while the subscription is
+// suspended and removes it once spec.suspended is cleared.
+func setSuspendedCondition(conditions *[]metav1.Condition, generation int64, suspended bool) {
+	if !suspended {
+		apimeta.RemoveStatusCondition(conditions, ConditionSuspended)
+		return
+	}
+	apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionSuspended,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Suspended",
+		Message:            "spec.suspended is set: generated rate limits deny every request of this subscription",
+		ObservedGeneration: generation,
+	})
+}
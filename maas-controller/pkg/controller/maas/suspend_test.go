@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"testing"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+func TestBuildSpecs_Suspended(t *testing.T) {
+	suspended := newMaaSSubscription("sub-a", "default", "team-a", "llm", 100)
+	suspended.Spec.Suspended = true
+	active := newMaaSSubscription("sub-b", "default", "team-b", "llm", 100)
+	subs := []maasv1alpha1.MaaSSubscription{*suspended, *active}
+	zero := []kuadrantv1.Rate{{Limit: 0, Window: suspendedWindow}}
+
+	trlp, subNames := buildTRLPSpec(ctrl.Log.WithName("test"), subs, "default", "llm", nil, nil, "llm-route")
+	if trlp == nil {
+		t.Fatal("expected a TokenRateLimitPolicy spec")
+	}
+	if len(subNames) != 2 {
+		t.Errorf("subNames = %v, want both subscriptions", subNames)
+	}
+	limit := trlp.Limits["default-sub-a-llm-tokens"]
+	if len(limit.Rates) != 1 || limit.Rates[0] != zero[0] {
+		t.Errorf("suspended token rates = %v, want %v", limit.Rates, zero)
+	}
+	if len(limit.Counters) != 0 {
+		t.Errorf("suspended token limit has counters %v, want none", limit.Counters)
+	}
+	if rates := trlp.Limits["default-sub-b-llm-tokens"].Rates; len(rates) != 1 || rates[0].Limit != 100 {
+		t.Errorf("active token rates = %v, want the subscription's limit", rates)
+	}
+
+	// The subscriptions set no request rate limits; suspension alone must produce
+	// the RateLimitPolicy that denies requests.
+	rlp, subNames := buildRLPSpec(ctrl.Log.WithName("test"), subs, "default", "llm", "llm-route")
+	if rlp == nil {
+		t.Fatal("expected a RateLimitPolicy spec for the suspended subscription")
+	}
+	if len(subNames) != 1 || subNames[0] != "default/sub-a" {
+		t.Errorf("subNames = %v, want [default/sub-a]", subNames)
+	}
+	if len(rlp.Limits) != 1 {
+		t.Fatalf("expected 1 limit, got %v", rlp.Limits)
+	}
+	if rates := rlp.Limits["default-sub-a-llm-requests"].Rates; len(rates) != 1 || rates[0] != zero[0] {
+		t.Errorf("suspended request rates = %v, want %v", rates, zero)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_Suspended verifies that suspending a subscription keeps
+// its policies with deny-all limits and that resuming restores the configured limits.
+func TestMaaSSubscriptionReconciler_Suspended(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute("maas-"+modelName, namespace)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+	sub.Spec.Suspended = true
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	trlpKey := types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}
+	if err := c.Get(ctx, trlpKey, trlp); err != nil {
+		t.Fatalf("expected TokenRateLimitPolicy for suspended subscription: %v", err)
+	}
+	if rates, _, _ := unstructured.NestedSlice(trlp.Object, "spec", "limits", "default-sub-a-llm-tokens", "rates"); len(rates) != 1 ||
+		rates[0].(map[string]any)["limit"] != int64(0) {
+		t.Errorf("suspended token rates = %v, want a single zero rate", rates)
+	}
+	rlp := &unstructured.Unstructured{}
+	rlp.SetGroupVersionKind(kuadrantv1.RateLimitPolicyGVK)
+	rlpKey := types.NamespacedName{Name: rateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}
+	if err := c.Get(ctx, rlpKey, rlp); err != nil {
+		t.Fatalf("expected RateLimitPolicy for suspended subscription: %v", err)
+	}
+
+	current := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cond := apimeta.FindStatusCondition(current.Status.Conditions, ConditionSuspended)
+	if cond == nil || cond.Status != "True" {
+		t.Fatalf("expected Suspended=True, got %v", cond)
+	}
+	if len(current.Status.ModelRefStatuses) != 1 {
+		t.Errorf("expected model ref statuses to be kept, got %v", current.Status.ModelRefStatuses)
+	}
+
+	current.Spec.Suspended = false
+	if err := c.Update(ctx, current); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile after resuming: %v", err)
+	}
+	if err := c.Get(ctx, trlpKey, trlp); err != nil {
+		t.Fatalf("Get TokenRateLimitPolicy: %v", err)
+	}
+	if rates, _, _ := unstructured.NestedSlice(trlp.Object, "spec", "limits", "default-sub-a-llm-tokens", "rates"); len(rates) != 1 ||
+		rates[0].(map[string]any)["limit"] != int64(100) {
+		t.Errorf("resumed token rates = %v, want the subscription's limit", rates)
+	}
+	if err := c.Get(ctx, rlpKey, rlp); err == nil {
+		t.Error("expected RateLimitPolicy to be deleted after resuming")
+	}
+	if err := c.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cond := apimeta.FindStatusCondition(current.Status.Conditions, ConditionSuspended); cond != nil {
+		t.Errorf("expected Suspended condition to be removed, got %v", cond)
+	}
+}
@@ -71,6 +71,14 @@ type TenantReconciler struct {
 	// MetadataCacheTTL is the TTL in seconds for Authorino metadata HTTP caching.
 	// Applies to apiKeyValidation and subscription-info metadata evaluators.
 	MetadataCacheTTL int64
+	// KeycloakAdminSecret is the Secret with the Keycloak admin credentials used to
+	// provision the OIDC clients of tenants with provisionClient (--keycloak-admin-secret).
+	// An empty name disables provisioning.
+	KeycloakAdminSecret types.NamespacedName
+	// KeycloakAdmin provisions OIDC clients through the Keycloak admin API.
+	KeycloakAdmin *KeycloakAdmin
+	// APIReader reads the Keycloak admin Secret, which may live outside the cached namespaces.
+	APIReader client.Reader
 }
 
 // Tenant platform pipeline — resources the TenantReconciler creates and manages on behalf of maas-api.
@@ -88,6 +96,7 @@ type TenantReconciler struct {
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=operator.authorino.kuadrant.io,resources=authorinos,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kuadrant.io,resources=ratelimitpolicies,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups=limitador.kuadrant.io,resources=limitadors,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=extensions.kuadrant.io,resources=telemetrypolicies,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=envoyfilters,verbs=get;list;watch;create;patch;delete
@@ -101,6 +110,8 @@ type TenantReconciler struct {
 // grants unrestricted get on secrets; Kubernetes also does not support resourceNames on list/watch).
 // The client-side predicate secretNamedMaaSDB() filters informer events to maas-db-config only.
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// secrets create/update: the maas-oidc-client Secret of tenants with a provisioned OIDC client.
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;update
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;patch;delete
 
@@ -225,5 +236,5 @@ func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.enqueueDefaultTenant),
 			builder.WithPredicates(authenticationClusterSingleton()),
 		).
-		Complete(r)
+		Complete(observeReconcile("Tenant", r))
 }
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
+)
+
+const (
+	// ConditionOIDCClientProvisioned reports whether the OIDC client of a tenant with
+	// provisionClient exists in Keycloak.
+	ConditionOIDCClientProvisioned = "OIDCClientProvisioned"
+
+	// TenantOIDCClientSecretName is the Secret of the tenant namespace holding the
+	// client-id and client-secret of the provisioned client.
+	TenantOIDCClientSecretName = "maas-oidc-client"
+)
+
+// keycloakAdminCredentials reads the Keycloak admin credentials from the
+// --keycloak-admin-secret Secret: the url of the Keycloak server, username and password,
+// or client-id and client-secret, with an optional realm. The url pins the server the
+// credentials are sent to, whatever the issuer URL of the tenant.
+func (r *TenantReconciler) keycloakAdminCredentials(ctx context.Context) (KeycloakAdminCredentials, error) {
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, r.KeycloakAdminSecret, secret); err != nil {
+		return KeycloakAdminCredentials{}, fmt.Errorf("failed to get Keycloak admin Secret %s: %w", r.KeycloakAdminSecret, err)
+	}
+	if len(secret.Data["url"]) == 0 {
+		return KeycloakAdminCredentials{}, fmt.Errorf("keycloak admin Secret %s has no url of the Keycloak server", r.KeycloakAdminSecret)
+	}
+	return KeycloakAdminCredentials{
+		ServerURL:    string(secret.Data["url"]),
+		Realm:        string(secret.Data["realm"]),
+		ClientID:     string(secret.Data["client-id"]),
+		ClientSecret: string(secret.Data["client-secret"]),
+		Username:     string(secret.Data["username"]),
+		Password:     string(secret.Data["password"]),
+	}, nil
+}
+
+// reconcileOIDCClient provisions the OIDC client of a tenant whose OIDC configuration sets
+// provisionClient and records it in the status. Failures are reported in the
+// OIDCClientProvisioned condition without holding up the platform reconcile.
+func (r *TenantReconciler) reconcileOIDCClient(ctx context.Context, log logr.Logger, tenant *maasv1alpha1.Tenant, platformContext tenantreconcile.PlatformContext) {
+	oidc := platformContext.ExternalOIDC
+	if oidc == nil || !oidc.ProvisionClient {
+		tenant.Status.OIDCClient = nil
+		apimeta.RemoveStatusCondition(&tenant.Status.Conditions, ConditionOIDCClientProvisioned)
+		return
+	}
+	fail := func(reason, message string) {
+		log.Info("OIDC client not provisioned", "clientId", oidc.ClientID, "reason", reason, "message", message)
+		setTenantCondition(tenant, ConditionOIDCClientProvisioned, metav1.ConditionFalse, reason, message)
+	}
+	if r.KeycloakAdminSecret.Name == "" || r.KeycloakAdmin == nil {
+		fail("KeycloakAdminNotConfigured", "provisionClient requires maas-controller to run with --keycloak-admin-secret")
+		return
+	}
+
+	creds, err := r.keycloakAdminCredentials(ctx)
+	if err != nil {
+		fail("KeycloakAdminCredentialsUnavailable", err.Error())
+		return
+	}
+	clientSecret, err := r.KeycloakAdmin.EnsureClient(ctx, creds, oidc.IssuerURL, oidc.ClientID, tenant.Namespace)
+	switch {
+	case errors.Is(err, errKeycloakIssuerNotAllowed):
+		fail("IssuerNotAllowed", err.Error())
+		return
+	case errors.Is(err, errKeycloakClientConflict):
+		fail("ClientConflict", err.Error())
+		return
+	case err != nil:
+		fail("ProvisioningFailed", err.Error())
+		return
+	}
+
+	secret := &corev1.Secret{}
+	secret.Name = TenantOIDCClientSecretName
+	secret.Namespace = tenant.Namespace
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["app.kubernetes.io/managed-by"] = "maas-controller"
+		secret.Labels["app.kubernetes.io/component"] = "oidc-client"
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			"client-id":     []byte(oidc.ClientID),
+			"client-secret": []byte(clientSecret),
+		}
+		return controllerutil.SetControllerReference(tenant, secret, r.Scheme)
+	}); err != nil {
+		fail("SecretUpdateFailed", fmt.Sprintf("failed to write Secret %s/%s: %v", tenant.Namespace, TenantOIDCClientSecretName, err))
+		return
+	}
+
+	tenant.Status.OIDCClient = &maasv1alpha1.TenantOIDCClientStatus{ClientID: oidc.ClientID, SecretName: TenantOIDCClientSecretName}
+	setTenantCondition(tenant, ConditionOIDCClientProvisioned, metav1.ConditionTrue, "Provisioned",
+		fmt.Sprintf("Keycloak client %q exists with audience and groups mappers", oidc.ClientID))
+}
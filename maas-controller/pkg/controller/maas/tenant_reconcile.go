@@ -151,6 +151,8 @@ func (r *TenantReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	r.reconcileOIDCClient(ctx, log, &tenant, platformContext)
+
 	// Check dependencies and prerequisites
 	if result, err := r.checkDependenciesAndPrerequisites(ctx, &tenant); result != nil {
 		return *result, err
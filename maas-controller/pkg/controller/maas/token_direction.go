@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"strings"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// tokenDirections lists the directions of token rates in the order their limits are
+// generated.
+var tokenDirections = []maasv1alpha1.TokenDirection{
+	maasv1alpha1.TokenDirectionTotal,
+	maasv1alpha1.TokenDirectionInput,
+	maasv1alpha1.TokenDirectionOutput,
+}
+
+// tokenDirection returns the direction of a token rate, Total when unset.
+func tokenDirection(trl maasv1alpha1.TokenRateLimit) maasv1alpha1.TokenDirection {
+	if trl.Direction == "" {
+		return maasv1alpha1.TokenDirectionTotal
+	}
+	return trl.Direction
+}
+
+// ratesByDirection groups rates by direction, keeping their order within a direction.
+// Rates of an unknown direction are dropped; the CRD rejects them at admission.
+func ratesByDirection(limits []maasv1alpha1.TokenRateLimit) map[maasv1alpha1.TokenDirection][]maasv1alpha1.TokenRateLimit {
+	out := map[maasv1alpha1.TokenDirection][]maasv1alpha1.TokenRateLimit{}
+	for _, trl := range limits {
+		d := tokenDirection(trl)
+		switch d {
+		case maasv1alpha1.TokenDirectionTotal, maasv1alpha1.TokenDirectionInput, maasv1alpha1.TokenDirectionOutput:
+			out[d] = append(out[d], trl)
+		}
+	}
+	return out
+}
+
+// subscriptionTokenDirections returns the directions of a subscription's token rates,
+// in tokenDirections order. Total is always included: it is the direction of rates
+// without one, of cost budgets and of the default limit.
+func subscriptionTokenDirections(sub *maasv1alpha1.MaaSSubscription) []maasv1alpha1.TokenDirection {
+	var limits []maasv1alpha1.TokenRateLimit
+	for _, ref := range sub.Spec.ModelRefs {
+		limits = append(limits, ref.TokenRateLimits...)
+	}
+	for _, owner := range sub.Spec.Owners {
+		limits = append(limits, owner.TokenRateLimits...)
+	}
+	if sel := sub.Spec.ModelSelector; sel != nil {
+		limits = append(limits, sel.TokenRateLimits...)
+	}
+	used := ratesByDirection(limits)
+	out := []maasv1alpha1.TokenDirection{maasv1alpha1.TokenDirectionTotal}
+	for _, d := range tokenDirections[1:] {
+		if len(used[d]) > 0 {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// directionLimitKey returns the TRLP limit key of a direction's rates. Total rates keep
+// the "-tokens" key; Input and Output rates get their own limit, and so their own
+// counters, e.g. "<namespace>-<subscription>-<model>-output-tokens".
+func directionLimitKey(key string, direction maasv1alpha1.TokenDirection) string {
+	if direction == maasv1alpha1.TokenDirectionTotal {
+		return key
+	}
+	return strings.TrimSuffix(key, "-tokens") + "-" + strings.ToLower(string(direction)) + "-tokens"
+}
+
+// directionPrefix prefixes a validation message with the rates' direction, unless it
+// is Total.
+func directionPrefix(direction maasv1alpha1.TokenDirection) string {
+	if direction == maasv1alpha1.TokenDirectionTotal {
+		return ""
+	}
+	return strings.ToLower(string(direction)) + " rates: "
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// TestBuildTRLPSpec_TokenDirections verifies that Input and Output rates become limits
+// of their own next to the Total limit, including the anchored ones.
+func TestBuildTRLPSpec_TokenDirections(t *testing.T) {
+	sub := newMaaSSubscription("split", "default", "team-a", "llm", 100)
+	sub.Spec.ResetSchedule = &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily}
+	sub.Spec.ModelRefs[0].TokenRateLimits = []maasv1alpha1.TokenRateLimit{
+		{Limit: 20000, Window: "1m"},
+		{Limit: 10000, Window: "1m", Direction: maasv1alpha1.TokenDirectionInput},
+		{Limit: 2000, Window: "1m", Direction: maasv1alpha1.TokenDirectionOutput},
+		{Limit: 100000, Window: "24h", Direction: maasv1alpha1.TokenDirectionOutput},
+	}
+	globalLimits := []maasv1alpha1.TokenRateLimit{{Limit: 500000, Window: "1m", Direction: maasv1alpha1.TokenDirectionOutput}}
+
+	spec, _ := buildTRLPSpec(ctrl.Log.WithName("test"), []maasv1alpha1.MaaSSubscription{*sub}, "default", "llm", globalLimits, nil, "llm-route")
+	if spec == nil {
+		t.Fatal("expected a TRLP spec")
+	}
+	want := map[string][]kuadrantv1.Rate{
+		"default-split-llm-tokens":              {{Limit: 20000, Window: "1m"}},
+		"default-split-llm-input-tokens":        {{Limit: 10000, Window: "1m"}},
+		"default-split-llm-output-tokens":       {{Limit: 2000, Window: "1m"}},
+		"default-split-llm-output-tokens-daily": {{Limit: 100000, Window: anchoredDailyWindow}},
+		"llm-global-output-tokens":              {{Limit: 500000, Window: "1m"}},
+	}
+	if len(spec.Limits) != len(want) {
+		t.Errorf("expected limits %v, got %v", want, spec.Limits)
+	}
+	for key, rates := range want {
+		if got := spec.Limits[key].Rates; !reflect.DeepEqual(got, rates) {
+			t.Errorf("%s rates = %v, want %v", key, got, rates)
+		}
+	}
+
+	wantKeys := []string{
+		"default-split-llm-tokens",
+		"default-split-llm-input-tokens",
+		"default-split-llm-output-tokens",
+		"default-split-llm-tokens-daily",
+		"default-split-llm-input-tokens-daily",
+		"default-split-llm-output-tokens-daily",
+	}
+	if got := subscriptionTokenLimitKeys(sub, "llm"); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("subscriptionTokenLimitKeys = %v, want %v", got, wantKeys)
+	}
+}
+
+func TestValidateTokenRateLimits_Directions(t *testing.T) {
+	daily := &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily}
+	tests := []struct {
+		name     string
+		limits   []maasv1alpha1.TokenRateLimit
+		schedule *maasv1alpha1.ResetSchedule
+		wantErr  bool
+	}{
+		{
+			name: "same window in each direction",
+			limits: []maasv1alpha1.TokenRateLimit{
+				{Limit: 1000, Window: "1m"},
+				{Limit: 1000, Window: "1m", Direction: maasv1alpha1.TokenDirectionInput},
+				{Limit: 200, Window: "1m", Direction: maasv1alpha1.TokenDirectionOutput},
+			},
+		},
+		{
+			name: "output rates checked against each other",
+			limits: []maasv1alpha1.TokenRateLimit{
+				{Limit: 200, Window: "1m", Direction: maasv1alpha1.TokenDirectionOutput},
+				{Limit: 100, Window: "1h", Direction: maasv1alpha1.TokenDirectionOutput},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate input window",
+			limits: []maasv1alpha1.TokenRateLimit{
+				{Limit: 200, Window: "60m", Direction: maasv1alpha1.TokenDirectionInput},
+				{Limit: 300, Window: "1h", Direction: maasv1alpha1.TokenDirectionInput},
+			},
+			wantErr: true,
+		},
+		{
+			name: "one anchored rate per direction",
+			limits: []maasv1alpha1.TokenRateLimit{
+				{Limit: 100000, Window: "24h"},
+				{Limit: 20000, Window: "24h", Direction: maasv1alpha1.TokenDirectionOutput},
+			},
+			schedule: daily,
+		},
+		{
+			name: "two anchored output rates",
+			limits: []maasv1alpha1.TokenRateLimit{
+				{Limit: 20000, Window: "24h", Direction: maasv1alpha1.TokenDirectionOutput},
+				{Limit: 30000, Window: "48h", Direction: maasv1alpha1.TokenDirectionOutput},
+			},
+			schedule: daily,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		err := ValidateModelRefRateLimits(maasv1alpha1.ModelSubscriptionRef{Name: "llm", TokenRateLimits: tt.limits}, tt.schedule)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestWithCostBudget_Directions verifies that a cost budget only folds into a Total rate
+// of the same window.
+func TestWithCostBudget_Directions(t *testing.T) {
+	price := &modelPrice{perToken: big.NewRat(1, 1000), currency: "USD"}
+	limits := []maasv1alpha1.TokenRateLimit{{Limit: 100, Window: "1h", Direction: maasv1alpha1.TokenDirectionOutput}}
+	got, err := withCostBudget(limits, &maasv1alpha1.CostBudget{Amount: "1", Window: "1h"}, price)
+	if err != nil {
+		t.Fatalf("withCostBudget: %v", err)
+	}
+	want := []maasv1alpha1.TokenRateLimit{
+		{Limit: 100, Window: "1h", Direction: maasv1alpha1.TokenDirectionOutput},
+		{Limit: 1000, Window: "1h"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withCostBudget = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// DefaultUsageCollectionTimeout bounds a single Limitador counters request.
+	DefaultUsageCollectionTimeout = 5 * time.Second
+	// DefaultUsageNearLimitRatio is the share of a rate a counter must have used to be near its limit.
+	DefaultUsageNearLimitRatio = 0.9
+	// DefaultUsageHistoryLength is the number of hourly usage snapshots kept per model.
+	DefaultUsageHistoryLength = 24
+	// MaxUsageHistoryLength matches the MaxItems of ModelUsageStatus.History.
+	MaxUsageHistoryLength = 744
+
+	// ConditionNearLimit is True while a counter of the subscription has used the
+	// near-limit share of one of its token rate limits.
+	ConditionNearLimit = "NearLimit"
+
+	// reasonUsageNearLimit is the NearLimit=True reason.
+	reasonUsageNearLimit = "UsageNearLimit"
+	// reasonUsageBelowLimit is the NearLimit=False reason.
+	reasonUsageBelowLimit = "UsageBelowLimit"
+	// reasonUsageUnavailable is used when no model's counters could be read from Limitador.
+	reasonUsageUnavailable = "UsageUnavailable"
+
+	// maxCounterIdentityLength matches the MaxLength of ModelUsageStatus.Counter.
+	maxCounterIdentityLength = 256
+)
+
+// LimitadorLimit is the limit a Limitador counter belongs to.
+type LimitadorLimit struct {
+	Namespace  string   `json:"namespace"`
+	MaxValue   int64    `json:"max_value"`
+	Seconds    int64    `json:"seconds"`
+	Name       string   `json:"name,omitempty"`
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// LimitadorCounter is a counter as returned by Limitador's GET /counters/{namespace}.
+type LimitadorCounter struct {
+	Limit            LimitadorLimit    `json:"limit"`
+	SetVariables     map[string]string `json:"set_variables,omitempty"`
+	Remaining        int64             `json:"remaining"`
+	ExpiresInSeconds int64             `json:"expires_in_seconds"`
+}
+
+// UsageCollector reads the active rate limit counters of a Limitador namespace.
+type UsageCollector interface {
+	Counters(ctx context.Context, namespace string) ([]LimitadorCounter, error)
+}
+
+// LimitadorUsageCollector reads counters from Limitador's HTTP API.
+type LimitadorUsageCollector struct {
+	Client *http.Client
+	// URL is the base URL of the Limitador HTTP API, e.g.
+	// http://limitador-limitador.kuadrant-system.svc:8080.
+	URL string
+}
+
+// NewLimitadorUsageCollector returns a LimitadorUsageCollector with the given request timeout.
+func NewLimitadorUsageCollector(limitadorURL string, timeout time.Duration) *LimitadorUsageCollector {
+	if timeout <= 0 {
+		timeout = DefaultUsageCollectionTimeout
+	}
+	return &LimitadorUsageCollector{
+		Client: &http.Client{Timeout: timeout},
+		URL:    limitadorURL,
+	}
+}
+
+// Counters sends GET <url>/counters/<namespace> and decodes the counters.
+func (c *LimitadorUsageCollector) Counters(ctx context.Context, namespace string) ([]LimitadorCounter, error) {
+	target, err := url.JoinPath(c.URL, "counters", url.PathEscape(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Limitador URL %q: %w", c.URL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil, fmt.Errorf("GET %s returned HTTP %d", target, resp.StatusCode)
+	}
+	var counters []LimitadorCounter
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(&counters); err != nil {
+		return nil, fmt.Errorf("failed to decode Limitador counters: %w", err)
+	}
+	return counters, nil
+}
+
+// limitadorNamespace returns the Limitador namespace Kuadrant configures for the
+// limits of policies targeting an HTTPRoute.
+func limitadorNamespace(routeNamespace, routeName string) string {
+	return routeNamespace + "/" + routeName
+}
+
+// counterMatchesLimit reports whether a counter belongs to the policy limit key. Kuadrant
+// identifies a policy limit in Limitador as "limit.<key>__<hash>", which appears in the
+// Limitador limit name or its conditions depending on the Kuadrant version.
+func counterMatchesLimit(counter LimitadorCounter, key string) bool {
+	id := "limit." + key + "__"
+	if strings.Contains(counter.Limit.Name, id) {
+		return true
+	}
+	for _, cond := range counter.Limit.Conditions {
+		if strings.Contains(cond, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// counterIdentity renders the counter values of a counter, e.g. the user ID. The period
+// id of an anchored rate is left out since it does not identify who is counted.
+func counterIdentity(counter LimitadorCounter) string {
+	keys := make([]string, 0, len(counter.SetVariables))
+	for k := range counter.SetVariables {
+		if !strings.Contains(k, "request.time") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, counter.SetVariables[k])
+	}
+	identity := strings.Join(values, ",")
+	if len(identity) > maxCounterIdentityLength {
+		identity = identity[:maxCounterIdentityLength]
+	}
+	return identity
+}
+
+// windowString formats a Limitador window length like a TokenRateLimit window.
+func windowString(seconds int64) string {
+	switch {
+	case seconds > 0 && seconds%3600 == 0:
+		return fmt.Sprintf("%dh", seconds/3600)
+	case seconds > 0 && seconds%60 == 0:
+		return fmt.Sprintf("%dm", seconds/60)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// summarizeUsage reduces the counters of a model's limit keys to the usage of the
+// counter closest to its limit, counting the counters at or above nearLimitRatio.
+func summarizeUsage(counters []LimitadorCounter, keys []string, nearLimitRatio float64) maasv1alpha1.ModelUsageStatus {
+	var usage maasv1alpha1.ModelUsageStatus
+	topRatio := -1.0
+	for _, counter := range counters {
+		matched := false
+		for _, key := range keys {
+			if counterMatchesLimit(counter, key) {
+				matched = true
+				break
+			}
+		}
+		if !matched || counter.Limit.MaxValue <= 0 {
+			continue
+		}
+		consumed := max(counter.Limit.MaxValue-counter.Remaining, 0)
+		ratio := float64(consumed) / float64(counter.Limit.MaxValue)
+		usage.ActiveCounters++
+		if ratio >= nearLimitRatio {
+			usage.NearLimitCounters++
+		}
+		if ratio > topRatio || (ratio == topRatio && consumed > usage.Consumed) {
+			topRatio = ratio
+			usage.Limit = counter.Limit.MaxValue
+			usage.Window = windowString(counter.Limit.Seconds)
+			usage.Consumed = consumed
+			usage.Remaining = max(counter.Remaining, 0)
+			usage.Counter = counterIdentity(counter)
+		}
+	}
+	return usage
+}
+
+// subscriptionTokenLimitKeys returns the TRLP limit keys of a subscription for a model:
+// the rolling limit and, with a reset schedule, the anchored one, of each token
+// direction the subscription uses, for the subscription and each entry of spec.owners.
+func subscriptionTokenLimitKeys(sub *maasv1alpha1.MaaSSubscription, modelName string) []string {
+	owners := []string{subscriptionLimitKey(sub.Namespace, sub.Name, modelName, "tokens")}
+	for _, owner := range sub.Spec.Owners {
+		owners = append(owners, ownerLimitKey(sub.Namespace, sub.Name, modelName, owner.Name))
+	}
+	directions := subscriptionTokenDirections(sub)
+	var base []string
+	for _, key := range owners {
+		for _, d := range directions {
+			base = append(base, directionLimitKey(key, d))
+		}
+	}
+	keys := base
+	if schedule := sub.Spec.ResetSchedule; schedule != nil {
+		for _, key := range base {
+			keys = append(keys, key+"-"+strings.ToLower(string(schedule.Period)))
+		}
+	}
+	return keys
+}
+
+// appendUsageSnapshot adds a snapshot of usage to history unless one was already taken
+// in the hour of now, and drops the oldest snapshots beyond length.
+func appendUsageSnapshot(history []maasv1alpha1.UsageSnapshot, usage maasv1alpha1.ModelUsageStatus, now time.Time, length int) []maasv1alpha1.UsageSnapshot {
+	if length <= 0 {
+		return nil
+	}
+	hour := now.UTC().Truncate(time.Hour)
+	if n := len(history); n > 0 && !history[n-1].Time.UTC().Before(hour) {
+		return history
+	}
+	history = append(history, maasv1alpha1.UsageSnapshot{
+		Time:           metav1.NewTime(now.UTC().Truncate(time.Second)),
+		Consumed:       usage.Consumed,
+		Limit:          usage.Limit,
+		Window:         usage.Window,
+		ActiveCounters: usage.ActiveCounters,
+	})
+	if len(history) > length {
+		history = history[len(history)-length:]
+	}
+	return history
+}
+
+// usageHistoryLength returns how many hourly snapshots are kept per model.
+func (r *MaaSSubscriptionReconciler) usageHistoryLength() int {
+	switch {
+	case r.UsageHistoryLength == 0:
+		return DefaultUsageHistoryLength
+	case r.UsageHistoryLength > MaxUsageHistoryLength:
+		return MaxUsageHistoryLength
+	}
+	return r.UsageHistoryLength
+}
+
+// collectUsage reads the Limitador counters of the subscription's Ready models into
+// status.usage, adds the hourly snapshots, sets the NearLimit condition and sends
+// spec.notifications. Models whose counters cannot be read keep their previous usage and
+// history; the condition is Unknown only when no model could be read.
+func (r *MaaSSubscriptionReconciler) collectUsage(ctx context.Context, subscription *maasv1alpha1.MaaSSubscription) {
+	ratio := r.UsageNearLimitRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = DefaultUsageNearLimitRatio
+	}
+	keys := map[string][]string{}
+	for _, ref := range subscription.Spec.ModelRefs {
+		keys[ref.Namespace+"/"+ref.Name] = subscriptionTokenLimitKeys(subscription, ref.Name)
+	}
+
+	previous := map[string]maasv1alpha1.ModelUsageStatus{}
+	for _, u := range subscription.Status.Usage {
+		previous[u.Namespace+"/"+u.Name] = u
+	}
+	now := time.Now()
+
+	var usage []maasv1alpha1.ModelUsageStatus
+	var read int
+	var nearLimit, failures []string
+	for _, ms := range subscription.Status.ModelRefStatuses {
+		if !ms.Ready {
+			continue
+		}
+		model := ms.Namespace + "/" + ms.Name
+		routeName, routeNS, err := findHTTPRouteForModel(ctx, r.Client, ms.Namespace, ms.Name)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", model, err))
+			continue
+		}
+		counters, err := r.UsageCollector.Counters(ctx, limitadorNamespace(routeNS, routeName))
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", model, err))
+			if u, ok := previous[model]; ok {
+				usage = append(usage, u)
+			}
+			continue
+		}
+		read++
+		u := summarizeUsage(counters, keys[model], ratio)
+		u.Name = ms.Name
+		u.Namespace = ms.Namespace
+		u.History = appendUsageSnapshot(previous[model].History, u, now, r.usageHistoryLength())
+		usage = append(usage, u)
+		if u.NearLimitCounters > 0 {
+			nearLimit = append(nearLimit, model)
+		}
+	}
+	subscription.Status.Usage = usage
+
+	cond := metav1.Condition{
+		Type:               ConditionNearLimit,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonUsageBelowLimit,
+		Message:            fmt.Sprintf("No counter has used %d%% of a token rate limit", int(ratio*100)),
+		ObservedGeneration: subscription.GetGeneration(),
+	}
+	switch {
+	case len(nearLimit) > 0:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = reasonUsageNearLimit
+		cond.Message = fmt.Sprintf("Counters have used %d%% of a token rate limit for: %s", int(ratio*100), strings.Join(nearLimit, ", "))
+	case read == 0 && len(failures) > 0:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = reasonUsageUnavailable
+		cond.Message = "Failed to read Limitador counters: " + strings.Join(failures, "; ")
+	}
+	apimeta.SetStatusCondition(&subscription.Status.Conditions, cond)
+	r.notifyUsage(ctx, subscription)
+}
+
+// clearUsage removes collected usage, e.g. when collection is disabled.
+func clearUsage(subscription *maasv1alpha1.MaaSSubscription) {
+	subscription.Status.Usage = nil
+	subscription.Status.Notifications = nil
+	apimeta.RemoveStatusCondition(&subscription.Status.Conditions, ConditionNearLimit)
+}
+
+// usageCollectionEnabled reports whether subscriptions collect Limitador usage periodically.
+func (r *MaaSSubscriptionReconciler) usageCollectionEnabled() bool {
+	return r.UsageCollector != nil && r.UsageCollectionInterval > 0
+}
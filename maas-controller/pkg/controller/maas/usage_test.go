@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// fakeUsageCollector returns fixed counters per Limitador namespace.
+type fakeUsageCollector struct {
+	counters map[string][]LimitadorCounter
+	err      error
+}
+
+func (f *fakeUsageCollector) Counters(_ context.Context, namespace string) ([]LimitadorCounter, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.counters[namespace], nil
+}
+
+func limitadorCounter(key string, maxValue, seconds, remaining int64, user string) LimitadorCounter {
+	return LimitadorCounter{
+		Limit:        LimitadorLimit{MaxValue: maxValue, Seconds: seconds, Name: "limit." + key + "__1a2b3c4d"},
+		SetVariables: map[string]string{"auth.identity.userid": user},
+		Remaining:    remaining,
+	}
+}
+
+func TestLimitadorUsageCollector_Counters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/counters/default%2Fmaas-llm" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"limit":{"namespace":"default/maas-llm","max_value":100,"seconds":60,"name":"limit.default-sub-llm-tokens__abc"},"set_variables":{"auth.identity.userid":"alice"},"remaining":5,"expires_in_seconds":30}]`))
+	}))
+	defer srv.Close()
+
+	counters, err := NewLimitadorUsageCollector(srv.URL, time.Second).Counters(context.Background(), "default/maas-llm")
+	if err != nil {
+		t.Fatalf("Counters: %v", err)
+	}
+	if len(counters) != 1 || counters[0].Limit.MaxValue != 100 || counters[0].Remaining != 5 || counters[0].SetVariables["auth.identity.userid"] != "alice" {
+		t.Errorf("unexpected counters: %+v", counters)
+	}
+
+	if _, err := NewLimitadorUsageCollector(srv.URL, time.Second).Counters(context.Background(), "other/route"); err == nil {
+		t.Error("expected an error for a non-200 response")
+	}
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	keys := []string{"default-sub-llm-tokens"}
+	counters := []LimitadorCounter{
+		limitadorCounter("default-sub-llm-tokens", 1000, 60, 600, "alice"),
+		limitadorCounter("default-sub-llm-tokens", 1000, 60, 50, "bob"),
+		limitadorCounter("default-sub-llm-tokens", 100000, 3600, 20000, "bob"),
+		// Another subscription's limit on the same route.
+		limitadorCounter("default-other-llm-tokens", 10, 60, 0, "carol"),
+	}
+	usage := summarizeUsage(counters, keys, DefaultUsageNearLimitRatio)
+	if usage.ActiveCounters != 3 || usage.NearLimitCounters != 1 {
+		t.Errorf("activeCounters=%d nearLimitCounters=%d, want 3 and 1", usage.ActiveCounters, usage.NearLimitCounters)
+	}
+	if usage.Counter != "bob" || usage.Limit != 1000 || usage.Window != "1m" || usage.Consumed != 950 || usage.Remaining != 50 {
+		t.Errorf("unexpected top counter: %+v", usage)
+	}
+}
+
+func TestAppendUsageSnapshot(t *testing.T) {
+	usage := maasv1alpha1.ModelUsageStatus{Limit: 1000, Window: "1h", Consumed: 400, ActiveCounters: 2}
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	var history []maasv1alpha1.UsageSnapshot
+	history = appendUsageSnapshot(history, usage, start.Add(5*time.Minute), 3)
+	history = appendUsageSnapshot(history, usage, start.Add(55*time.Minute), 3)
+	if len(history) != 1 {
+		t.Fatalf("expected one snapshot within the hour, got %+v", history)
+	}
+	if s := history[0]; !s.Time.Time.Equal(start.Add(5*time.Minute)) || s.Consumed != 400 || s.Limit != 1000 || s.Window != "1h" || s.ActiveCounters != 2 {
+		t.Errorf("unexpected snapshot: %+v", s)
+	}
+
+	for hour := 1; hour <= 4; hour++ {
+		usage.Consumed = int64(400 + hour)
+		history = appendUsageSnapshot(history, usage, start.Add(time.Duration(hour)*time.Hour), 3)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected the history bounded to 3 snapshots, got %d", len(history))
+	}
+	if history[0].Consumed != 402 || history[2].Consumed != 404 {
+		t.Errorf("expected the oldest snapshots dropped, got %+v", history)
+	}
+
+	if got := appendUsageSnapshot(history, usage, start.Add(5*time.Hour), -1); got != nil {
+		t.Errorf("expected no history with a negative length, got %+v", got)
+	}
+}
+
+// TestMaaSSubscriptionReconciler_Usage verifies that collected counters land in
+// status.usage and drive the NearLimit condition.
+func TestMaaSSubscriptionReconciler_Usage(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	model := newMaaSModelRef(modelName, namespace, "ExternalModel", modelName)
+	route := newHTTPRoute("maas-"+modelName, namespace)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 1000)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(model, route, sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	collector := &fakeUsageCollector{counters: map[string][]LimitadorCounter{
+		limitadorNamespace(namespace, route.Name): {limitadorCounter("default-sub-a-llm-tokens", 1000, 60, 40, "alice")},
+	}}
+	// The fake client never reports the generated policy Enforced, so keep the
+	// enforcement requeue longer than the collection interval.
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, UsageCollector: collector, UsageCollectionInterval: time.Minute,
+		EnforcementRequeue: RequeueBackoff{InitialDelay: time.Hour}}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if result.RequeueAfter != time.Minute {
+		t.Errorf("RequeueAfter = %v, want the collection interval", result.RequeueAfter)
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(got.Status.Usage) != 1 {
+		t.Fatalf("expected usage for one model, got %+v", got.Status.Usage)
+	}
+	if u := got.Status.Usage[0]; u.Name != modelName || u.Consumed != 960 || u.Remaining != 40 || u.Counter != "alice" {
+		t.Errorf("unexpected usage: %+v", u)
+	}
+	if h := got.Status.Usage[0].History; len(h) != 1 || h[0].Consumed != 960 || h[0].Limit != 1000 {
+		t.Errorf("expected one usage snapshot, got %+v", h)
+	}
+	cond := apimeta.FindStatusCondition(got.Status.Conditions, ConditionNearLimit)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != reasonUsageNearLimit {
+		t.Errorf("expected NearLimit=True, got %+v", cond)
+	}
+
+	collector.err = errors.New("connection refused")
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	cond = apimeta.FindStatusCondition(got.Status.Conditions, ConditionNearLimit)
+	if cond == nil || cond.Status != metav1.ConditionUnknown || cond.Reason != reasonUsageUnavailable {
+		t.Errorf("expected NearLimit=Unknown when Limitador is unreachable, got %+v", cond)
+	}
+	if len(got.Status.Usage) != 1 || len(got.Status.Usage[0].History) != 1 {
+		t.Errorf("expected the previous usage and history kept when Limitador is unreachable, got %+v", got.Status.Usage)
+	}
+
+	r.UsageCollector = nil
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status.Usage != nil || apimeta.FindStatusCondition(got.Status.Conditions, ConditionNearLimit) != nil {
+		t.Errorf("expected usage to be cleared when collection is disabled, got %+v", got.Status)
+	}
+}
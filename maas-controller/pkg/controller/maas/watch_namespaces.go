@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WatchNamespaces is the set of namespaces the controller is limited to in
+// namespace-scoped mode. A nil set watches every namespace.
+type WatchNamespaces map[string]struct{}
+
+// ParseWatchNamespaces parses a comma-separated list of namespaces, such as the value of
+// WATCH_NAMESPACES. An empty list returns nil, i.e. cluster-wide mode.
+func ParseWatchNamespaces(value string) WatchNamespaces {
+	var w WatchNamespaces
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			if w == nil {
+				w = WatchNamespaces{}
+			}
+			w[ns] = struct{}{}
+		}
+	}
+	return w
+}
+
+// Add adds namespaces to a namespace-scoped set. It does nothing in cluster-wide mode.
+func (w WatchNamespaces) Add(namespaces ...string) {
+	if w == nil {
+		return
+	}
+	for _, ns := range namespaces {
+		if ns != "" {
+			w[ns] = struct{}{}
+		}
+	}
+}
+
+// Contains reports whether the controller watches the namespace.
+func (w WatchNamespaces) Contains(namespace string) bool {
+	if w == nil {
+		return true
+	}
+	_, ok := w[namespace]
+	return ok
+}
+
+// List returns the namespaces in sorted order.
+func (w WatchNamespaces) List() []string {
+	out := make([]string, 0, len(w))
+	for ns := range w {
+		out = append(out, ns)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// notWatchedMessage explains why the controller does not act on an object in an
+// unwatched namespace.
+func notWatchedMessage(kind, namespace string) string {
+	return fmt.Sprintf("%s namespace %q is not watched by the controller; add it to WATCH_NAMESPACES", kind, namespace)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
+)
+
+func TestParseWatchNamespaces(t *testing.T) {
+	if w := ParseWatchNamespaces(" , "); w != nil || !w.Contains("anything") {
+		t.Errorf("expected an empty list to watch every namespace, got %v", w)
+	}
+	w := ParseWatchNamespaces("team-a, team-b,,team-a")
+	w.Add("models-as-a-service", "")
+	if got, want := w.List(), []string{"models-as-a-service", "team-a", "team-b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+	if !w.Contains("team-b") || w.Contains("team-c") || w.Contains("") {
+		t.Errorf("unexpected Contains results for %v", w.List())
+	}
+}
+
+// TestMaaSSubscriptionReconciler_NamespaceNotWatched verifies that in namespace-scoped
+// mode a model outside the watched namespaces is reported and gets no policy.
+func TestMaaSSubscriptionReconciler_NamespaceNotWatched(t *testing.T) {
+	ctx := context.Background()
+	const (
+		modelName = "llm"
+		namespace = "default"
+	)
+	sub := newMaaSSubscription("sub-a", namespace, "team-a", modelName, 100)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testRESTMapper()).
+		WithObjects(newMaaSModelRef(modelName, namespace, "ExternalModel", modelName), newHTTPRoute("maas-"+modelName, namespace), sub).
+		WithStatusSubresource(&maasv1alpha1.MaaSSubscription{}).
+		WithIndex(&maasv1alpha1.MaaSSubscription{}, "spec.modelRef", subscriptionModelRefIndexer).
+		Build()
+	r := &MaaSSubscriptionReconciler{Client: c, Scheme: scheme, WatchNamespaces: ParseWatchNamespaces("team-a")}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sub-a", Namespace: namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	got := &maasv1alpha1.MaaSSubscription{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("Get MaaSSubscription: %v", err)
+	}
+	if len(got.Status.ModelRefStatuses) != 1 || got.Status.ModelRefStatuses[0].Reason != maasv1alpha1.ReasonNamespaceNotWatched {
+		t.Errorf("modelRefStatuses = %+v, want reason %s", got.Status.ModelRefStatuses, maasv1alpha1.ReasonNamespaceNotWatched)
+	}
+	if len(got.Status.TokenRateLimitStatuses) != 1 || got.Status.TokenRateLimitStatuses[0].Reason != maasv1alpha1.ReasonNamespaceNotWatched {
+		t.Errorf("tokenRateLimitStatuses = %+v, want reason %s", got.Status.TokenRateLimitStatuses, maasv1alpha1.ReasonNamespaceNotWatched)
+	}
+	trlp := &unstructured.Unstructured{}
+	trlp.SetGroupVersionKind(kuadrantv1alpha1.TokenRateLimitPolicyGVK)
+	err := c.Get(ctx, types.NamespacedName{Name: tokenRateLimitPolicyName(namespace, modelName, ""), Namespace: namespace}, trlp)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected no TokenRateLimitPolicy in an unwatched namespace, got %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+const (
+	// defaultCASecretKey is the data key of spec.authentication.x509.caSecretRef when
+	// none is set.
+	defaultCASecretKey = "ca.crt"
+
+	// clientCALabel selects the client CA bundle of a gateway in its x509 rule. Authorino
+	// reads the trusted CA certificates from the tls.crt of the matching Secrets.
+	clientCALabel = "maas.opendatahub.io/client-ca"
+
+	// identitySourceProperty marks the identities of client certificates, so that the
+	// model access check can tell them from tokens carrying the same username.
+	identitySourceProperty = "maas_identity_source"
+	x509IdentitySource     = "x509"
+)
+
+// x509CASecret is a spec.authentication.x509.caSecretRef, resolved to its namespace and key.
+type x509CASecret struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// aggregateX509CASecrets returns the CA Secrets the policies trust client certificates
+// of, sorted and without duplicates.
+func aggregateX509CASecrets(policies []maasv1alpha1.MaaSAuthPolicy) []x509CASecret {
+	seen := map[x509CASecret]bool{}
+	var secrets []x509CASecret
+	for _, p := range policies {
+		x509Authn := policyX509(&p)
+		if x509Authn == nil || !p.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		secret := x509CASecret{Namespace: p.Namespace, Name: x509Authn.CASecretRef.Name, Key: x509Authn.CASecretRef.Key}
+		if secret.Key == "" {
+			secret.Key = defaultCASecretKey
+		}
+		if seen[secret] {
+			continue
+		}
+		seen[secret] = true
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		a, b := secrets[i], secrets[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Key < b.Key
+	})
+	return secrets
+}
+
+func policyX509(p *maasv1alpha1.MaaSAuthPolicy) *maasv1alpha1.X509Authentication {
+	if p.Spec.Authentication == nil {
+		return nil
+	}
+	return p.Spec.Authentication.X509
+}
+
+// clientCABundleName is the name of the Secret holding the trusted client CA certificates
+// of a gateway AuthPolicy, in the gateway namespace.
+func clientCABundleName(authPolicyName string) string {
+	return authPolicyName + "-client-ca"
+}
+
+// clientCALabelValue identifies the client CA bundle of a gateway. Gateway names may be
+// longer than a label value, so they are hashed.
+func clientCALabelValue(gatewayNamespace, gatewayName string) string {
+	sum := sha256.Sum256([]byte(gatewayNamespace + "/" + gatewayName))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// addX509AuthenticationRule adds the rule authenticating client certificates of the CAs
+// in the gateway's client CA bundle. It only applies to requests without other
+// credentials; the certificate's common name becomes the username and its organizations
+// the groups, dropping those that are unsafe in the X-MaaS-Group header.
+func addX509AuthenticationRule(authn map[string]kuadrantv1.AuthenticationRule, xAPIKeyEnabled bool, gatewayNamespace, gatewayName string) {
+	predicate := `!("authorization" in request.headers)`
+	if xAPIKeyEnabled {
+		predicate += ` && !("x-api-key" in request.headers)`
+	}
+	authn["x509-client-certs"] = kuadrantv1.AuthenticationRule{
+		CommonRule: kuadrantv1.CommonRule{
+			When:     []kuadrantv1.WhenCondition{{Predicate: predicate}},
+			Priority: 1,
+		},
+		// Kuadrant places the AuthConfig outside the gateway namespace, so the bundle is
+		// selected across namespaces by a label unique to the gateway.
+		X509: &kuadrantv1.X509Auth{
+			Selector:      kuadrantv1.LabelSelector{MatchLabels: map[string]string{clientCALabel: clientCALabelValue(gatewayNamespace, gatewayName)}},
+			AllNamespaces: true,
+		},
+		Overrides: map[string]kuadrantv1.ValueFrom{
+			"preferred_username": {Selector: "auth.identity.CommonName"},
+			"groups": {
+				Expression: `has(auth.identity.Organization) && auth.identity.Organization != null ? ` +
+					`auth.identity.Organization.filter(o, o.matches('` + safeGroupNamePattern + `')) : []`,
+			},
+			identitySourceProperty: {Value: x509IdentitySource},
+		},
+	}
+}
+
+// x509IdentityCacheKeySuffix extends the cache key of the model access check with the
+// identity's source when client certificates are accepted, so that a certificate does
+// not reuse the decision cached for a token of the same username and groups.
+func x509IdentityCacheKeySuffix(enabled bool) string {
+	if !enabled {
+		return ""
+	}
+	return ` + "|" + (has(auth.identity.` + identitySourceProperty + `) ? auth.identity.` + identitySourceProperty + ` : "")`
+}
+
+// x509IdentityRego only accepts client certificate identities for the models of the
+// MaaSAuthPolicies declaring spec.authentication.x509.
+const x509IdentityRego = `certificate_identity {
+	is_object(input.auth.identity)
+	object.get(input.auth.identity, "` + identitySourceProperty + `", "") == "` + x509IdentitySource + `"
+}
+
+certificate_allowed {
+	not certificate_identity
+}
+
+certificate_allowed {
+	model_rules.x509 == true
+}`
+
+// readClientCABundle concatenates the PEM-encoded CA certificates of the Secrets.
+func (r *MaaSAuthPolicyReconciler) readClientCABundle(ctx context.Context, secrets []x509CASecret) ([]byte, error) {
+	var bundle bytes.Buffer
+	for _, ref := range secrets {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get CA Secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("CA Secret %s/%s has no key %q", ref.Namespace, ref.Name, ref.Key)
+		}
+		certs, err := pemCertificates(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA Secret %s/%s key %q: %w", ref.Namespace, ref.Name, ref.Key, err)
+		}
+		bundle.Write(certs)
+	}
+	return bundle.Bytes(), nil
+}
+
+// pemCertificates returns the certificates of PEM data, re-encoded, skipping other blocks.
+func pemCertificates(data []byte) ([]byte, error) {
+	var certs bytes.Buffer
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		if err := pem.Encode(&certs, &pem.Block{Type: "CERTIFICATE", Bytes: block.Bytes}); err != nil {
+			return nil, err
+		}
+	}
+	if certs.Len() == 0 {
+		return nil, fmt.Errorf("no PEM-encoded certificate found")
+	}
+	return certs.Bytes(), nil
+}
+
+// reconcileClientCABundle creates or updates the client CA bundle of the gateway from
+// the referenced CA Secrets, and deletes it when no policy accepts client certificates.
+func (r *MaaSAuthPolicyReconciler) reconcileClientCABundle(ctx context.Context, log logr.Logger, secrets []x509CASecret, gatewayNamespace, gatewayName string) error {
+	authPolicyName := r.gatewayAuthPolicyName(gatewayNamespace, gatewayName)
+	if len(secrets) == 0 {
+		return r.deleteClientCABundle(ctx, log, gatewayNamespace, authPolicyName)
+	}
+	bundle, err := r.readClientCABundle(ctx, secrets)
+	if err != nil {
+		return err
+	}
+
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clientCABundleName(authPolicyName),
+			Namespace: gatewayNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "maas-controller",
+				"app.kubernetes.io/part-of":    "maas-gateway-auth",
+				"app.kubernetes.io/component":  "client-ca",
+				clientCALabel:                  clientCALabelValue(gatewayNamespace, gatewayName),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{corev1.TLSCertKey: bundle},
+	}
+	existing := &corev1.Secret{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create client CA bundle %s/%s: %w", gatewayNamespace, desired.Name, err)
+		}
+		log.Info("client CA bundle created", "name", desired.Name, "namespace", gatewayNamespace)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get client CA bundle %s/%s: %w", gatewayNamespace, desired.Name, err)
+	}
+	if !isManaged(existing) {
+		log.Info("client CA bundle opted out of management, skipping", "name", existing.Name, "namespace", gatewayNamespace)
+		return nil
+	}
+	if bytes.Equal(existing.Data[corev1.TLSCertKey], bundle) && existing.Labels[clientCALabel] == desired.Labels[clientCALabel] {
+		return nil
+	}
+	if existing.Labels == nil {
+		existing.Labels = make(map[string]string)
+	}
+	for k, v := range desired.Labels {
+		existing.Labels[k] = v
+	}
+	existing.Data = desired.Data
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update client CA bundle %s/%s: %w", gatewayNamespace, existing.Name, err)
+	}
+	log.Info("client CA bundle updated", "name", existing.Name, "namespace", gatewayNamespace)
+	return nil
+}
+
+// deleteClientCABundle deletes the client CA bundle of a gateway AuthPolicy, if the
+// controller manages it.
+func (r *MaaSAuthPolicyReconciler) deleteClientCABundle(ctx context.Context, log logr.Logger, gatewayNamespace, authPolicyName string) error {
+	existing := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: gatewayNamespace, Name: clientCABundleName(authPolicyName)}, existing)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get client CA bundle %s/%s: %w", gatewayNamespace, clientCABundleName(authPolicyName), err)
+	}
+	if !isManaged(existing) || existing.Labels["app.kubernetes.io/managed-by"] != "maas-controller" {
+		return nil
+	}
+	if err := r.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete client CA bundle %s/%s: %w", gatewayNamespace, existing.Name, err)
+	}
+	log.Info("client CA bundle deleted", "name", existing.Name, "namespace", gatewayNamespace)
+	return nil
+}
+
+// mapCASecretToMaaSAuthPolicies enqueues the MaaSAuthPolicies referencing a Secret in
+// spec.authentication.x509.caSecretRef, so that rotated CA certificates are picked up.
+func (r *MaaSAuthPolicyReconciler) mapCASecretToMaaSAuthPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	policyList := &maasv1alpha1.MaaSAuthPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list MaaSAuthPolicy resources for CA Secret change",
+			"secret", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range policyList.Items {
+		if x509Authn := policyX509(&p); x509Authn != nil && x509Authn.CASecretRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.Name, Namespace: p.Namespace}})
+		}
+	}
+	return requests
+}
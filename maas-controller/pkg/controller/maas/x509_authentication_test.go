@@ -0,0 +1,260 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maas
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// newX509AuthPolicy returns a MaaSAuthPolicy accepting client certificates of the CA
+// Secret for a model.
+func newX509AuthPolicy(name, model string, ref maasv1alpha1.CASecretReference) maasv1alpha1.MaaSAuthPolicy {
+	p := newMaaSAuthPolicy(name, "default", "team-"+name, maasv1alpha1.ModelRef{Name: model, Namespace: "default"})
+	p.Spec.Authentication = &maasv1alpha1.AuthenticationSpec{X509: &maasv1alpha1.X509Authentication{CASecretRef: ref}}
+	return *p
+}
+
+// newTestCACertificate returns a PEM-encoded self-signed CA certificate.
+func newTestCACertificate(t *testing.T, commonName string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestAggregateX509CASecrets(t *testing.T) {
+	deleting := newX509AuthPolicy("deleting", "llm", maasv1alpha1.CASecretReference{Name: "gone-ca"})
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	deleting.Finalizers = []string{maasAuthPolicyFinalizer}
+
+	got := aggregateX509CASecrets([]maasv1alpha1.MaaSAuthPolicy{
+		newX509AuthPolicy("a", "llm", maasv1alpha1.CASecretReference{Name: "partner-ca"}),
+		newX509AuthPolicy("b", "llm", maasv1alpha1.CASecretReference{Name: "partner-ca", Key: defaultCASecretKey}),
+		newX509AuthPolicy("c", "llm", maasv1alpha1.CASecretReference{Name: "corp-ca", Key: "bundle.pem"}),
+		*newMaaSAuthPolicy("d", "default", "team-d", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"}),
+		deleting,
+	})
+	want := []x509CASecret{
+		{Namespace: "default", Name: "corp-ca", Key: "bundle.pem"},
+		{Namespace: "default", Name: "partner-ca", Key: defaultCASecretKey},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CA Secrets = %+v, want %+v", got, want)
+	}
+}
+
+func TestAggregateSubjectAllowlists_X509(t *testing.T) {
+	allowlists, err := aggregateSubjectAllowlists([]maasv1alpha1.MaaSAuthPolicy{
+		newX509AuthPolicy("a", "llm-a", maasv1alpha1.CASecretReference{Name: "partner-ca"}),
+		*newMaaSAuthPolicy("b", "default", "team-b", maasv1alpha1.ModelRef{Name: "llm-b", Namespace: "default"}),
+	})
+	if err != nil {
+		t.Fatalf("aggregateSubjectAllowlists: %v", err)
+	}
+	if !allowlists["default/llm-a"].X509 {
+		t.Error("llm-a should accept client certificates")
+	}
+	if allowlists["default/llm-b"].X509 {
+		t.Error("llm-b should not accept client certificates")
+	}
+}
+
+func TestBuildGatewayAuthPolicySpec_X509(t *testing.T) {
+	r := &MaaSAuthPolicyReconciler{
+		MaaSAPINamespace: "maas-system",
+		ClusterAudience:  "https://kubernetes.default.svc",
+		MetadataCacheTTL: 60,
+		AuthzCacheTTL:    60,
+	}
+	authn := gatewayAuthentication{X509CASecrets: []x509CASecret{{Namespace: "default", Name: "partner-ca", Key: defaultCASecretKey}}}
+	spec := r.buildGatewayAuthPolicySpec("{}", nil, authn, identityHeaderModels{}, gatewayAuthorization{}, true, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+
+	rule := []string{"spec", "defaults", "rules", "authentication", "x509-client-certs"}
+	label := nestedStringRequired(t, obj, append(rule, "x509", "selector", "matchLabels", clientCALabel)...)
+	if label != clientCALabelValue("gateway-ns", "maas-default-gateway") {
+		t.Errorf("x509 selector label = %s, want the gateway's client CA label", label)
+	}
+	if all, _, _ := unstructured.NestedBool(obj.Object, append(rule, "x509", "allNamespaces")...); !all {
+		t.Error("x509 rule should select the client CA bundle across namespaces")
+	}
+	predicate := nestedWhenPredicateRequired(t, obj, append(rule, "when")...)
+	if !contains(predicate, `!("authorization" in request.headers)`) || !contains(predicate, `!("x-api-key" in request.headers)`) {
+		t.Errorf("x509 rule should only apply to requests without other credentials, got: %s", predicate)
+	}
+	if got := nestedStringRequired(t, obj, append(rule, "overrides", "preferred_username", "selector")...); got != "auth.identity.CommonName" {
+		t.Errorf("username override = %s, want auth.identity.CommonName", got)
+	}
+	if got := nestedStringRequired(t, obj, append(rule, "overrides", "groups", "expression")...); !contains(got, "auth.identity.Organization") {
+		t.Errorf("groups override should use the certificate organizations, got: %s", got)
+	}
+	if got := nestedStringRequired(t, obj, append(rule, "overrides", identitySourceProperty, "value")...); got != x509IdentitySource {
+		t.Errorf("identity source override = %s, want %s", got, x509IdentitySource)
+	}
+
+	cacheKey := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "cache", "key", "selector")
+	if !contains(cacheKey, "auth.identity."+identitySourceProperty) {
+		t.Errorf("require-group-membership cache key should include the identity source, got: %s", cacheKey)
+	}
+	membership := nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "opa", "rego")
+	if !contains(membership, "certificate_allowed") || !contains(membership, "model_rules.x509 == true") {
+		t.Errorf("require-group-membership should scope client certificates to their models, got: %s", membership)
+	}
+
+	spec = r.buildGatewayAuthPolicySpec("{}", nil, gatewayAuthentication{}, identityHeaderModels{}, gatewayAuthorization{}, false, "", "models-as-a-service", "gateway-ns", "maas-default-gateway")
+	obj = &unstructured.Unstructured{Object: map[string]any{"spec": specToUnstructured(t, spec)}}
+	if _, exists := nestedMapRequired(t, obj, "spec", "defaults", "rules", "authentication")["x509-client-certs"]; exists {
+		t.Error("x509-client-certs should only be present when a policy accepts client certificates")
+	}
+	cacheKey = nestedStringRequired(t, obj, "spec", "defaults", "rules", "authorization", "require-group-membership", "cache", "key", "selector")
+	if contains(cacheKey, identitySourceProperty) {
+		t.Errorf("cache key should not include the identity source without client certificates, got: %s", cacheKey)
+	}
+}
+
+func TestPEMCertificates(t *testing.T) {
+	ca := newTestCACertificate(t, "partner-ca")
+	key := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("not a key")})
+
+	got, err := pemCertificates(append(append([]byte("comment\n"), key...), ca...))
+	if err != nil {
+		t.Fatalf("pemCertificates: %v", err)
+	}
+	if !bytes.Equal(got, ca) {
+		t.Errorf("pemCertificates should keep only the certificate, got:\n%s", got)
+	}
+	if _, err := pemCertificates(key); err == nil {
+		t.Error("expected an error for data without certificates")
+	}
+	if _, err := pemCertificates(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})); err == nil {
+		t.Error("expected an error for an unparsable certificate")
+	}
+}
+
+func TestReconcileClientCABundle(t *testing.T) {
+	const gatewayNS = "gateway-ns"
+	partnerCA := newTestCACertificate(t, "partner-ca")
+	corpCA := newTestCACertificate(t, "corp-ca")
+	partner := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "partner-ca", Namespace: "default"},
+		Data:       map[string][]byte{defaultCASecretKey: partnerCA},
+	}
+	corp := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "corp-ca", Namespace: "default"},
+		Data:       map[string][]byte{"bundle.pem": corpCA},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(partner, corp).Build()
+	r := &MaaSAuthPolicyReconciler{Client: c, Scheme: scheme, GatewayNamespace: gatewayNS, GatewayName: "maas-default-gateway"}
+	ctx := context.Background()
+	bundleKey := types.NamespacedName{Namespace: gatewayNS, Name: clientCABundleName(maasGatewayAuthPolicyName)}
+
+	secrets := []x509CASecret{
+		{Namespace: "default", Name: "corp-ca", Key: "bundle.pem"},
+		{Namespace: "default", Name: "partner-ca", Key: defaultCASecretKey},
+	}
+	if err := r.reconcileClientCABundle(ctx, logr.Discard(), secrets, gatewayNS, "maas-default-gateway"); err != nil {
+		t.Fatalf("reconcileClientCABundle: %v", err)
+	}
+	bundle := &corev1.Secret{}
+	if err := c.Get(ctx, bundleKey, bundle); err != nil {
+		t.Fatalf("get client CA bundle: %v", err)
+	}
+	if got := bundle.Labels[clientCALabel]; got != clientCALabelValue(gatewayNS, "maas-default-gateway") {
+		t.Errorf("bundle label = %s, want the gateway's client CA label", got)
+	}
+	if got := bundle.Data[corev1.TLSCertKey]; !bytes.Equal(got, append(append([]byte{}, corpCA...), partnerCA...)) {
+		t.Errorf("bundle tls.crt should hold both CA certificates, got:\n%s", got)
+	}
+
+	// A rotated CA certificate replaces the bundle's content.
+	rotated := newTestCACertificate(t, "partner-ca-2")
+	partner.Data[defaultCASecretKey] = rotated
+	if err := c.Update(ctx, partner); err != nil {
+		t.Fatalf("update CA Secret: %v", err)
+	}
+	if err := r.reconcileClientCABundle(ctx, logr.Discard(), secrets[1:], gatewayNS, "maas-default-gateway"); err != nil {
+		t.Fatalf("reconcileClientCABundle: %v", err)
+	}
+	if err := c.Get(ctx, bundleKey, bundle); err != nil {
+		t.Fatalf("get client CA bundle: %v", err)
+	}
+	if !bytes.Equal(bundle.Data[corev1.TLSCertKey], rotated) {
+		t.Errorf("bundle should hold the rotated certificate, got:\n%s", bundle.Data[corev1.TLSCertKey])
+	}
+
+	missing := []x509CASecret{{Namespace: "default", Name: "partner-ca", Key: "missing.crt"}}
+	if err := r.reconcileClientCABundle(ctx, logr.Discard(), missing, gatewayNS, "maas-default-gateway"); err == nil {
+		t.Error("expected an error for a missing CA Secret key")
+	}
+
+	if err := r.reconcileClientCABundle(ctx, logr.Discard(), nil, gatewayNS, "maas-default-gateway"); err != nil {
+		t.Fatalf("reconcileClientCABundle: %v", err)
+	}
+	if err := c.Get(ctx, bundleKey, bundle); !apierrors.IsNotFound(err) {
+		t.Errorf("client CA bundle should be deleted once no policy accepts client certificates, got err=%v", err)
+	}
+}
+
+func TestMapCASecretToMaaSAuthPolicies(t *testing.T) {
+	referencing := newX509AuthPolicy("a", "llm", maasv1alpha1.CASecretReference{Name: "partner-ca"})
+	other := newMaaSAuthPolicy("b", "default", "team-b", maasv1alpha1.ModelRef{Name: "llm", Namespace: "default"})
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&referencing, other).Build()
+	r := &MaaSAuthPolicyReconciler{Client: c, Scheme: scheme}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "partner-ca", Namespace: "default"}}
+	got := r.mapCASecretToMaaSAuthPolicies(context.Background(), secret)
+	if len(got) != 1 || got[0].Name != "a" {
+		t.Errorf("requests = %v, want only the policy referencing the Secret", got)
+	}
+}
@@ -0,0 +1,21 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1 contains typed specs for the kuadrant.io/v1 policies maas-controller
+// generates (AuthPolicy and RateLimitPolicy). They cover the subset of the Kuadrant
+// schema the controller uses and are converted to unstructured objects when applied,
+// so the controller does not depend on the Kuadrant operator module.
+package v1
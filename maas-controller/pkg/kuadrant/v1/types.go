@@ -0,0 +1,277 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupVersion is the group version of the Kuadrant v1 policies.
+var GroupVersion = schema.GroupVersion{Group: "kuadrant.io", Version: "v1"}
+
+var (
+	AuthPolicyGVK      = GroupVersion.WithKind("AuthPolicy")
+	RateLimitPolicyGVK = GroupVersion.WithKind("RateLimitPolicy")
+	// TokenRateLimitPolicyGVK is the TokenRateLimitPolicy once Kuadrant serves it as v1.
+	// Its spec has the schema of the v1alpha1 TokenRateLimitPolicySpec.
+	TokenRateLimitPolicyGVK = GroupVersion.WithKind("TokenRateLimitPolicy")
+)
+
+// TargetRef references the Gateway API resource a policy attaches to.
+type TargetRef struct {
+	Group string `json:"group"`
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	// Namespace of the target. Kuadrant resolves targets in the policy's namespace, so
+	// it must match it when set.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Predicate is a CEL condition; a policy section applies only when all of its predicates hold.
+type Predicate struct {
+	Predicate string `json:"predicate"`
+}
+
+// Counter is a CEL expression whose value partitions a limit's counters, e.g. per user.
+type Counter struct {
+	Expression string `json:"expression"`
+}
+
+// Rate allows Limit hits per Window, e.g. 100 per "1m".
+type Rate struct {
+	Limit  int64  `json:"limit"`
+	Window string `json:"window"`
+}
+
+// Limit is a named rate limit of a RateLimitPolicy.
+type Limit struct {
+	Rates    []Rate      `json:"rates,omitempty"`
+	When     []Predicate `json:"when,omitempty"`
+	Counters []Counter   `json:"counters,omitempty"`
+}
+
+// RateLimitPolicySpec is the spec of a kuadrant.io/v1 RateLimitPolicy.
+type RateLimitPolicySpec struct {
+	TargetRef TargetRef        `json:"targetRef"`
+	Limits    map[string]Limit `json:"limits,omitempty"`
+}
+
+// AuthPolicySpec is the spec of a kuadrant.io/v1 AuthPolicy. The controller only
+// generates explicit defaults.
+type AuthPolicySpec struct {
+	TargetRef TargetRef            `json:"targetRef"`
+	Defaults  *MergeableAuthPolicy `json:"defaults,omitempty"`
+}
+
+// MergeableAuthPolicy holds the rules of an AuthPolicy defaults or overrides section.
+type MergeableAuthPolicy struct {
+	// When must live inside defaults rather than at spec level: Kuadrant treats
+	// spec-level rules and conditions as implicit defaults, which conflict with explicit ones.
+	When  []Predicate `json:"when,omitempty"`
+	Rules AuthRules   `json:"rules"`
+}
+
+// AuthRules are the Authorino rules of an AuthPolicy, keyed by rule name.
+type AuthRules struct {
+	Authentication map[string]AuthenticationRule `json:"authentication,omitempty"`
+	Metadata       map[string]MetadataRule       `json:"metadata,omitempty"`
+	Authorization  map[string]AuthorizationRule  `json:"authorization,omitempty"`
+	Response       *ResponseRules                `json:"response,omitempty"`
+}
+
+// WhenCondition is a condition of a rule: either a CEL Predicate or an Authorino
+// Selector/Operator/Value pattern.
+type WhenCondition struct {
+	Predicate string `json:"predicate,omitempty"`
+	Selector  string `json:"selector,omitempty"`
+	Operator  string `json:"operator,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+// ValueFrom is a static Value, an Authorization JSON Selector, or a CEL Expression.
+type ValueFrom struct {
+	Value      string `json:"value,omitempty"`
+	Selector   string `json:"selector,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+// RuleCache caches a rule's result under Key for TTL seconds.
+type RuleCache struct {
+	Key ValueFrom `json:"key"`
+	TTL int64     `json:"ttl"`
+}
+
+// CommonRule holds the fields every Authorino rule has. Priority and Metrics are
+// always written, matching the CRD defaults, so a generated policy compares equal to
+// its stored copy.
+type CommonRule struct {
+	When     []WhenCondition `json:"when,omitempty"`
+	Cache    *RuleCache      `json:"cache,omitempty"`
+	Priority int64           `json:"priority"`
+	Metrics  bool            `json:"metrics"`
+}
+
+// AuthenticationRule verifies the caller's identity with one of its methods.
+type AuthenticationRule struct {
+	CommonRule            `json:",inline"`
+	Plain                 *ValueFrom                 `json:"plain,omitempty"`
+	KubernetesTokenReview *KubernetesTokenReviewAuth `json:"kubernetesTokenReview,omitempty"`
+	JWT                   *JWTAuth                   `json:"jwt,omitempty"`
+	X509                  *X509Auth                  `json:"x509,omitempty"`
+	// Overrides set properties of the resolved identity, replacing those it has.
+	Overrides map[string]ValueFrom `json:"overrides,omitempty"`
+}
+
+// KubernetesTokenReviewAuth authenticates Kubernetes tokens for the given audiences.
+type KubernetesTokenReviewAuth struct {
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// JWTAuth authenticates OIDC JWTs of an issuer, refreshing its discovery every TTL seconds.
+type JWTAuth struct {
+	IssuerURL string `json:"issuerUrl"`
+	TTL       int64  `json:"ttl,omitempty"`
+}
+
+// X509Auth authenticates TLS client certificates signed by the CA certificates in the
+// tls.crt of the Secrets matching Selector.
+type X509Auth struct {
+	Selector      LabelSelector `json:"selector"`
+	AllNamespaces bool          `json:"allNamespaces,omitempty"`
+}
+
+// LabelSelector selects resources by their labels.
+type LabelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// MetadataRule fetches additional data for authorization.
+type MetadataRule struct {
+	CommonRule `json:",inline"`
+	HTTP       *HTTPMetadata `json:"http,omitempty"`
+}
+
+// HTTPMetadata fetches metadata from an HTTP endpoint.
+type HTTPMetadata struct {
+	URL         string     `json:"url"`
+	Method      string     `json:"method,omitempty"`
+	ContentType string     `json:"contentType,omitempty"`
+	Body        *ValueFrom `json:"body,omitempty"`
+}
+
+// AuthorizationRule grants or denies access with one of its methods.
+type AuthorizationRule struct {
+	CommonRule      `json:",inline"`
+	OPA             *OPAAuthorization     `json:"opa,omitempty"`
+	PatternMatching *PatternMatchingAuthz `json:"patternMatching,omitempty"`
+
+	KubernetesSubjectAccessReview *KubernetesSubjectAccessReviewAuthz `json:"kubernetesSubjectAccessReview,omitempty"`
+}
+
+// KubernetesSubjectAccessReviewAuthz allows a request when Kubernetes RBAC grants User, as
+// a member of AuthorizationGroups, the ResourceAttributes.
+type KubernetesSubjectAccessReviewAuthz struct {
+	User                ValueFrom                              `json:"user"`
+	AuthorizationGroups *ValueFrom                             `json:"authorizationGroups,omitempty"`
+	ResourceAttributes  *SubjectAccessReviewResourceAttributes `json:"resourceAttributes,omitempty"`
+}
+
+// SubjectAccessReviewResourceAttributes is the resource a SubjectAccessReview checks.
+type SubjectAccessReviewResourceAttributes struct {
+	Namespace ValueFrom `json:"namespace"`
+	Group     ValueFrom `json:"group"`
+	Resource  ValueFrom `json:"resource"`
+	Name      ValueFrom `json:"name"`
+	Verb      ValueFrom `json:"verb"`
+}
+
+// OPAAuthorization evaluates an inline Rego policy, or one fetched from ExternalPolicy.
+type OPAAuthorization struct {
+	Rego           string             `json:"rego,omitempty"`
+	ExternalPolicy *ExternalOPAPolicy `json:"externalPolicy,omitempty"`
+}
+
+// ExternalOPAPolicy is a Rego policy served at URL, fetched again every TTL seconds.
+type ExternalOPAPolicy struct {
+	URL string `json:"url"`
+	TTL int64  `json:"ttl,omitempty"`
+}
+
+// PatternMatchingAuthz allows a request when all patterns match.
+type PatternMatchingAuthz struct {
+	Patterns []Pattern `json:"patterns"`
+}
+
+// Pattern compares the value at Selector with Value using Operator.
+type Pattern struct {
+	Selector string `json:"selector"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// ResponseRules customize denials and enrich successful requests.
+type ResponseRules struct {
+	Unauthenticated *DenyWith        `json:"unauthenticated,omitempty"`
+	Unauthorized    *DenyWith        `json:"unauthorized,omitempty"`
+	Success         *SuccessResponse `json:"success,omitempty"`
+}
+
+// DenyWith is the response returned when a request is denied.
+type DenyWith struct {
+	Code    int64                `json:"code,omitempty"`
+	Message *ValueFrom           `json:"message,omitempty"`
+	Headers map[string]ValueFrom `json:"headers,omitempty"`
+	Body    *ValueFrom           `json:"body,omitempty"`
+}
+
+// SuccessResponse adds request headers and dynamic metadata (filters) to allowed requests.
+type SuccessResponse struct {
+	Headers map[string]HeaderResponse `json:"headers,omitempty"`
+	Filters map[string]ResponseItem   `json:"filters,omitempty"`
+}
+
+// ResponseItem is a value injected into a successful request.
+type ResponseItem struct {
+	CommonRule `json:",inline"`
+	Plain      *ValueFrom    `json:"plain,omitempty"`
+	JSON       *JSONResponse `json:"json,omitempty"`
+}
+
+// HeaderResponse injects a header; Key overrides the header name, which defaults to
+// the entry name, so several conditional entries can set one header.
+type HeaderResponse struct {
+	ResponseItem `json:",inline"`
+	Key          string `json:"key,omitempty"`
+}
+
+// JSONResponse renders properties as a JSON object.
+type JSONResponse struct {
+	Properties map[string]ValueFrom `json:"properties"`
+}
+
+// ToUnstructured converts a pointer to a typed spec to the map form set on
+// unstructured policies.
+func ToUnstructured(spec any) (map[string]any, error) {
+	out, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T to unstructured: %w", spec, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// TestToUnstructured verifies that embedded rule fields are flattened and that priority
+// and metrics are written even when zero.
+func TestToUnstructured(t *testing.T) {
+	spec := &AuthPolicySpec{
+		TargetRef: TargetRef{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "llm"},
+		Defaults: &MergeableAuthPolicy{
+			Rules: AuthRules{
+				Authentication: map[string]AuthenticationRule{
+					"api-keys": {
+						CommonRule: CommonRule{When: []WhenCondition{{Predicate: "true"}}},
+						Plain:      &ValueFrom{Selector: "request.headers.authorization"},
+					},
+				},
+			},
+		},
+	}
+	obj, err := ToUnstructured(spec)
+	if err != nil {
+		t.Fatalf("ToUnstructured: %v", err)
+	}
+	rule, found, err := unstructured.NestedMap(obj, "defaults", "rules", "authentication", "api-keys")
+	if err != nil || !found {
+		t.Fatalf("authentication rule missing: found=%v err=%v", found, err)
+	}
+	if rule["priority"] != int64(0) || rule["metrics"] != false {
+		t.Errorf("priority=%v metrics=%v, want 0 and false", rule["priority"], rule["metrics"])
+	}
+	if _, ok := rule["when"].([]any); !ok {
+		t.Errorf("when should be flattened into the rule, got %v", rule)
+	}
+	if _, found := rule["cache"]; found {
+		t.Errorf("unset cache should be omitted, got %v", rule["cache"])
+	}
+	if _, found := obj["targetRef"].(map[string]any)["namespace"]; found {
+		t.Error("unset targetRef.namespace should be omitted")
+	}
+
+	limits, err := ToUnstructured(&RateLimitPolicySpec{Limits: map[string]Limit{"l": {Rates: []Rate{{Limit: 10, Window: "1m"}}}}})
+	if err != nil {
+		t.Fatalf("ToUnstructured: %v", err)
+	}
+	rates, _, _ := unstructured.NestedSlice(limits, "limits", "l", "rates")
+	if len(rates) != 1 || rates[0].(map[string]any)["limit"] != int64(10) {
+		t.Errorf("rates = %v, want one rate with limit 10", rates)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains typed specs for the kuadrant.io/v1alpha1 policies
+// maas-controller generates (TokenRateLimitPolicy), built on the kuadrant.io/v1 types.
+// The same spec is written when Kuadrant serves TokenRateLimitPolicy as v1.
+package v1alpha1
+
+import (
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+// GroupVersion is the group version of the Kuadrant v1alpha1 policies.
+var GroupVersion = schema.GroupVersion{Group: "kuadrant.io", Version: "v1alpha1"}
+
+var TokenRateLimitPolicyGVK = GroupVersion.WithKind("TokenRateLimitPolicy")
+
+// ServedTokenRateLimitPolicyGVK returns the TokenRateLimitPolicy version the installed
+// Kuadrant operator serves, preferring kuadrant.io/v1 over v1alpha1. It returns the
+// v1alpha1 GVK with the mapper's error when neither is served, e.g. before Kuadrant is
+// installed.
+func ServedTokenRateLimitPolicyGVK(mapper apimeta.RESTMapper) (schema.GroupVersionKind, error) {
+	mapping, err := mapper.RESTMapping(TokenRateLimitPolicyGVK.GroupKind(), kuadrantv1.TokenRateLimitPolicyGVK.Version, TokenRateLimitPolicyGVK.Version)
+	if err != nil {
+		return TokenRateLimitPolicyGVK, err
+	}
+	return mapping.GroupVersionKind, nil
+}
+
+// TokenRateLimitPolicySpec is the spec of a kuadrant.io/v1alpha1 TokenRateLimitPolicy.
+// Token limits have the schema of RateLimitPolicy limits; their rates count tokens
+// instead of requests.
+type TokenRateLimitPolicySpec struct {
+	TargetRef kuadrantv1.TargetRef        `json:"targetRef"`
+	Limits    map[string]kuadrantv1.Limit `json:"limits,omitempty"`
+	// Defaults holds limits a Gateway-level policy hands down to the routes below it.
+	// Spec-level limits are atomic defaults that a route-level policy replaces entirely.
+	Defaults *MergeableTokenRateLimitPolicy `json:"defaults,omitempty"`
+}
+
+// MergeStrategy merges a defaults section into the route-level policies key by key
+// instead of being replaced by them.
+const MergeStrategy = "merge"
+
+// MergeableTokenRateLimitPolicy holds the limits of a defaults or overrides section.
+type MergeableTokenRateLimitPolicy struct {
+	Strategy string                      `json:"strategy,omitempty"`
+	Limits   map[string]kuadrantv1.Limit `json:"limits"`
+}
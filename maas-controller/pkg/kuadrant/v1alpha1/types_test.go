@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kuadrantv1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1"
+)
+
+func TestServedTokenRateLimitPolicyGVK(t *testing.T) {
+	tests := []struct {
+		name    string
+		served  []schema.GroupVersionKind
+		want    schema.GroupVersionKind
+		wantErr bool
+	}{
+		{name: "v1alpha1 only", served: []schema.GroupVersionKind{TokenRateLimitPolicyGVK}, want: TokenRateLimitPolicyGVK},
+		{name: "v1 only", served: []schema.GroupVersionKind{kuadrantv1.TokenRateLimitPolicyGVK}, want: kuadrantv1.TokenRateLimitPolicyGVK},
+		{name: "both prefer v1", served: []schema.GroupVersionKind{TokenRateLimitPolicyGVK, kuadrantv1.TokenRateLimitPolicyGVK}, want: kuadrantv1.TokenRateLimitPolicyGVK},
+		{name: "not installed", want: TokenRateLimitPolicyGVK, wantErr: true},
+	}
+	for _, tt := range tests {
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		for _, gvk := range tt.served {
+			mapper.Add(gvk, apimeta.RESTScopeNamespace)
+		}
+		got, err := ServedTokenRateLimitPolicyGVK(mapper)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
 )
@@ -31,6 +32,10 @@ type PlatformParams struct {
 	MaaSAPIKeyCleanupImage string
 
 	APIKeyMaxExpirationDays string
+
+	// TokenRateLimitPolicyGVK is the TokenRateLimitPolicy version the installed Kuadrant
+	// serves. Rendered TokenRateLimitPolicies keep their manifest version when unset.
+	TokenRateLimitPolicyGVK schema.GroupVersionKind
 }
 
 // BuildPlatformParams resolves all runtime parameters from the Tenant CR,
@@ -131,8 +136,34 @@ func patchResource(log logr.Logger, r *unstructured.Unstructured, params Platfor
 		r.SetNamespace(params.GatewayNamespace)
 	case gvk == GVKConfigMap && name == PayloadProcessingPluginsConfigMapName:
 		r.SetNamespace(params.GatewayNamespace)
+	case gvk == GVKDeployment && name == TokenCounterName:
+		return patchTokenCounterDeployment(log, r, params)
+	case gvk == GVKService && name == TokenCounterName:
+		r.SetNamespace(params.GatewayNamespace)
+	case gvk == GVKEnvoyFilter && name == TokenCounterName:
+		return patchTokenCounterEnvoyFilter(log, r, params)
+	case gvk == GVKDeployment && name == CredentialInjectorName:
+		return patchCredentialInjectorDeployment(log, r, params)
+	case gvk == GVKService && name == CredentialInjectorName:
+		r.SetNamespace(params.GatewayNamespace)
+	case gvk == GVKServiceAccount && name == CredentialInjectorName:
+		r.SetNamespace(params.GatewayNamespace)
+	case gvk == GVKEnvoyFilter && name == CredentialInjectorName:
+		return patchExtProcEnvoyFilter(log, r, params, CredentialInjectorName)
 	case gvk == GVKClusterRoleBinding && name == PayloadProcessingReaderClusterRoleBindingName:
 		return patchClusterRoleBindingSubjectNS(r, params.GatewayNamespace)
+	case gvk == GVKClusterRoleBinding && name == CredentialInjectorName:
+		return patchClusterRoleBindingSubjectNS(r, params.GatewayNamespace)
+	}
+	return nil
+}
+
+func patchCredentialInjectorDeployment(log logr.Logger, r *unstructured.Unstructured, params PlatformParams) error {
+	r.SetNamespace(params.GatewayNamespace)
+	// The credential injector is a binary of the maas-api image.
+	log.V(4).Info("Patching credential-injector image", "image", params.MaaSAPIImage)
+	if err := setContainerImage(r, CredentialInjectorName, params.MaaSAPIImage); err != nil {
+		return fmt.Errorf("patch credential-injector image: %w", err)
 	}
 	return nil
 }
@@ -374,7 +405,8 @@ func grpcClusterName(service, namespace string, port int) string {
 	return fmt.Sprintf("outbound|%d||%s.%s.svc.cluster.local", port, service, namespace)
 }
 
-func patchPayloadProcessingEnvoyFilter(log logr.Logger, r *unstructured.Unstructured, params PlatformParams) error {
+// patchEnvoyFilterGateway moves an EnvoyFilter to the gateway namespace and targets the gateway.
+func patchEnvoyFilterGateway(r *unstructured.Unstructured, params PlatformParams) error {
 	r.SetNamespace(params.GatewayNamespace)
 
 	targetRefs, found, err := unstructured.NestedSlice(r.Object, "spec", "targetRefs")
@@ -393,6 +425,65 @@ func patchPayloadProcessingEnvoyFilter(log logr.Logger, r *unstructured.Unstruct
 	if err := unstructured.SetNestedSlice(r.Object, targetRefs, "spec", "targetRefs"); err != nil {
 		return fmt.Errorf("write EnvoyFilter targetRefs: %w", err)
 	}
+	return nil
+}
+
+// patchExtProcEnvoyFilter patches the EnvoyFilter of an ext_proc service inserted after
+// the Kuadrant WasmPlugin, like the token counter: configPatches[0] inserts the filter
+// calling the service on port 9004, and patches 1 and 2 disable it on the maas-api routes.
+func patchExtProcEnvoyFilter(log logr.Logger, r *unstructured.Unstructured, params PlatformParams, service string) error {
+	if err := patchEnvoyFilterGateway(r, params); err != nil {
+		return err
+	}
+
+	configPatches, found, err := unstructured.NestedSlice(r.Object, "spec", "configPatches")
+	if err != nil {
+		return fmt.Errorf("read EnvoyFilter configPatches: %w", err)
+	}
+	if !found || len(configPatches) < 3 {
+		return fmt.Errorf("EnvoyFilter configPatches: expected at least 3 entries, got %d", len(configPatches))
+	}
+
+	filter, ok := configPatches[0].(map[string]any)
+	if !ok {
+		return errors.New("EnvoyFilter configPatches[0] is not an object")
+	}
+	if err := unstructured.SetNestedField(filter, wasmpluginAnchorName(params.GatewayNamespace, params.GatewayName),
+		"match", "listener", "filterChain", "filter", "subFilter", "name"); err != nil {
+		return fmt.Errorf("write configPatches[0] subFilter.name: %w", err)
+	}
+	clusterName := grpcClusterName(service, params.GatewayNamespace, 9004)
+	log.V(4).Info("Patching ext_proc EnvoyFilter", "name", service, "cluster", clusterName)
+	if err := unstructured.SetNestedField(filter, clusterName,
+		"patch", "value", "typed_config", "grpc_service", "envoy_grpc", "cluster_name"); err != nil {
+		return fmt.Errorf("write configPatches[0] grpc cluster_name: %w", err)
+	}
+	configPatches[0] = filter
+
+	// Patches 1 and 2 disable the filter on the maas-api routes.
+	for i := 1; i < 3; i++ {
+		patch, ok := configPatches[i].(map[string]any)
+		if !ok {
+			return fmt.Errorf("EnvoyFilter configPatches[%d] is not an object", i)
+		}
+		if err := unstructured.SetNestedField(patch,
+			fmt.Sprintf("%s.%s.%d", params.AppNamespace, MaaSAPIRouteName(params.TenantIdentifier), i-1),
+			"match", "routeConfiguration", "vhost", "route", "name"); err != nil {
+			return fmt.Errorf("write configPatches[%d] route name: %w", i, err)
+		}
+		configPatches[i] = patch
+	}
+
+	if err := unstructured.SetNestedSlice(r.Object, configPatches, "spec", "configPatches"); err != nil {
+		return fmt.Errorf("write EnvoyFilter configPatches: %w", err)
+	}
+	return nil
+}
+
+func patchPayloadProcessingEnvoyFilter(log logr.Logger, r *unstructured.Unstructured, params PlatformParams) error {
+	if err := patchEnvoyFilterGateway(r, params); err != nil {
+		return err
+	}
 
 	anchorName := wasmpluginAnchorName(params.GatewayNamespace, params.GatewayName)
 	beforeCluster := grpcClusterName(PayloadPreProcessingName, params.GatewayNamespace, 9004)
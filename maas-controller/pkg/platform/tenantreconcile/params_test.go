@@ -236,6 +236,52 @@ func TestApplyPlatformParamsWithRenderedOverlay(t *testing.T) {
 	assert.Equal(t, params.GatewayNamespace, firstSubject["namespace"])
 }
 
+func TestApplyPlatformParamsCredentialInjector(t *testing.T) {
+	resources := renderOverlayResources(t, "tenant-ns")
+	params := PlatformParams{
+		AppNamespace:     "tenant-ns",
+		GatewayNamespace: "gateway-ns",
+		GatewayName:      "custom-gateway",
+		TenantIdentifier: "redteam",
+		MaaSAPIImage:     "quay.io/example/maas-api:test",
+	}
+
+	require.NoError(t, applyPlatformParams(logr.Discard(), resources, params))
+
+	deployment := requireResource(t, resources, GVKDeployment, CredentialInjectorName)
+	assert.Equal(t, params.GatewayNamespace, deployment.GetNamespace())
+	assert.Equal(t, params.MaaSAPIImage, requireContainerImage(t, deployment, "spec", "template", "spec", "containers"))
+	for _, gvk := range []schema.GroupVersionKind{GVKService, GVKServiceAccount} {
+		assert.Equal(t, params.GatewayNamespace, requireResource(t, resources, gvk, CredentialInjectorName).GetNamespace(), gvk.Kind)
+	}
+
+	clusterRoleBinding := requireResource(t, resources, GVKClusterRoleBinding, CredentialInjectorName)
+	subjects, _, err := unstructured.NestedSlice(clusterRoleBinding.Object, "subjects")
+	require.NoError(t, err)
+	require.NotEmpty(t, subjects)
+	assert.Equal(t, params.GatewayNamespace, subjects[0].(map[string]any)["namespace"])
+
+	envoyFilter := requireResource(t, resources, GVKEnvoyFilter, CredentialInjectorName)
+	assert.Equal(t, params.GatewayNamespace, envoyFilter.GetNamespace())
+	configPatches, _, err := unstructured.NestedSlice(envoyFilter.Object, "spec", "configPatches")
+	require.NoError(t, err)
+	require.Len(t, configPatches, 3, "expected the ext_proc filter and 2x MERGE on maas-api-route rules")
+
+	filter, ok := configPatches[0].(map[string]any)
+	require.True(t, ok)
+	anchor, _, _ := unstructured.NestedString(filter, "match", "listener", "filterChain", "filter", "subFilter", "name")
+	assert.Equal(t, wasmpluginAnchorName(params.GatewayNamespace, params.GatewayName), anchor)
+	cluster, _, _ := unstructured.NestedString(filter, "patch", "value", "typed_config", "grpc_service", "envoy_grpc", "cluster_name")
+	assert.Equal(t, grpcClusterName(CredentialInjectorName, params.GatewayNamespace, 9004), cluster)
+	failOpen, _, _ := unstructured.NestedBool(filter, "patch", "value", "typed_config", "failure_mode_allow")
+	assert.False(t, failOpen, "requests must not reach a provider without the injector")
+
+	for i := 1; i < 3; i++ {
+		routeName, _, _ := unstructured.NestedString(configPatches[i].(map[string]any), "match", "routeConfiguration", "vhost", "route", "name")
+		assert.Equal(t, fmt.Sprintf("tenant-ns.%s.%d", MaaSAPIRouteName(params.TenantIdentifier), i-1), routeName)
+	}
+}
+
 func renderOverlayResources(t *testing.T, appNamespace string) []unstructured.Unstructured {
 	t.Helper()
 
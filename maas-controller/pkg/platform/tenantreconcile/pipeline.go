@@ -16,6 +16,7 @@ import (
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	kuadrantv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/kuadrant/v1alpha1"
 )
 
 // RunResult is returned from Run for reconcile pacing.
@@ -72,6 +73,10 @@ func RunPlatform(
 	if err != nil {
 		return nil, fmt.Errorf("build params: %w", err)
 	}
+	// Render the TokenRateLimitPolicy version Kuadrant serves; the manifests carry v1alpha1.
+	if gvk, err := kuadrantv1alpha1.ServedTokenRateLimitPolicyGVK(c.RESTMapper()); err == nil {
+		params.TokenRateLimitPolicyGVK = gvk
+	}
 
 	rendered, err := RenderKustomize(manifestPath, appNs)
 	if err != nil {
@@ -86,6 +91,12 @@ func RunPlatform(
 	if err := ApplyRendered(ctx, c, scheme, tenant, appNs, mcfg, resources); err != nil {
 		return nil, fmt.Errorf("apply: %w", err)
 	}
+	if err := PruneTokenCounter(ctx, log, c, tenant, params.GatewayNamespace); err != nil {
+		return nil, fmt.Errorf("prune token counter: %w", err)
+	}
+	if err := ReconcileRateLimitHeaders(ctx, log, c, tenant); err != nil {
+		return nil, fmt.Errorf("rate limit headers: %w", err)
+	}
 
 	tenantID, err := TenantIdentifierFor(tenant)
 	if err != nil {
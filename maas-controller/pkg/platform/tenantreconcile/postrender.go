@@ -30,9 +30,17 @@ func PostRender(ctx context.Context, log logr.Logger, tenant *maasv1alpha1.Tenan
 			continue
 		}
 
+		if isTokenCounterResource(resource) && !isTokenCountingEnabled(tenant.Spec.TokenCounting) {
+			log.V(2).Info("Skipping token-counter resource, token counting is disabled", "kind", resource.GetKind())
+			continue
+		}
+
 		gvk := resource.GroupVersionKind()
 		switch {
-		case gvk == GVKTokenRateLimitPolicy && resource.GetName() == baseGatewayTokenRateLimitDefaultDenyPolicyName:
+		case gvk.GroupKind() == GVKTokenRateLimitPolicy.GroupKind() && resource.GetName() == baseGatewayTokenRateLimitDefaultDenyPolicyName:
+			if !params.TokenRateLimitPolicyGVK.Empty() {
+				resource.SetAPIVersion(params.TokenRateLimitPolicyGVK.GroupVersion().String())
+			}
 			if err := configureTokenRateLimitPolicy(log, resource, gatewayNamespace, gatewayName, tenantID); err != nil {
 				return nil, err
 			}
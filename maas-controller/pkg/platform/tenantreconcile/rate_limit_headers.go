@@ -0,0 +1,96 @@
+package tenantreconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+const (
+	// RateLimitHeadersDraft03 is the Limitador spec.rateLimitHeaders value returning the
+	// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers of
+	// draft-polli-ratelimit-headers-03.
+	RateLimitHeadersDraft03 = "DRAFT_VERSION_03"
+
+	// AnnotationRateLimitHeadersTenant records on a Limitador the Tenant, as
+	// "namespace/name", that set its spec.rateLimitHeaders, so that only that Tenant
+	// unsets it again.
+	AnnotationRateLimitHeadersTenant = "maas.opendatahub.io/rate-limit-headers-tenant"
+)
+
+func isRateLimitHeadersEnabled(r *maasv1alpha1.TenantRateLimitHeadersConfig) bool {
+	if r == nil || r.Enabled == nil {
+		return false
+	}
+	return *r.Enabled
+}
+
+// ReconcileRateLimitHeaders sets spec.rateLimitHeaders on the Limitador instances while
+// the Tenant enables spec.rateLimitHeaders, and unsets the value it set once disabled.
+// Limitador then returns the headers with each rate limit decision, and the Kuadrant
+// Wasm plugin adds them to the responses of the gateway. Limitador instances that
+// already return headers, or are marked opendatahub.io/managed=false, are left alone.
+func ReconcileRateLimitHeaders(ctx context.Context, log logr.Logger, c client.Client, tenant *maasv1alpha1.Tenant) error {
+	enabled := isRateLimitHeadersEnabled(tenant.Spec.RateLimitHeaders)
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvkListKind(GVKLimitador))
+	if err := c.List(ctx, list); err != nil {
+		// Without Limitador, or permission to read it, there is nothing this Tenant set.
+		if !enabled && (meta.IsNoMatchError(err) || apierrors.IsForbidden(err)) {
+			return nil
+		}
+		return fmt.Errorf("list Limitador instances: %w", err)
+	}
+	if enabled && len(list.Items) == 0 {
+		return errors.New("no Limitador instance found to return rate limit headers")
+	}
+
+	owner := tenant.Namespace + "/" + tenant.Name
+	for i := range list.Items {
+		limitador := &list.Items[i]
+		if limitador.GetAnnotations()[AnnotationManaged] == "false" {
+			continue
+		}
+		current, _, err := unstructured.NestedString(limitador.Object, "spec", "rateLimitHeaders")
+		if err != nil {
+			return fmt.Errorf("read spec.rateLimitHeaders of Limitador %s/%s: %w", limitador.GetNamespace(), limitador.GetName(), err)
+		}
+		setByTenant := limitador.GetAnnotations()[AnnotationRateLimitHeadersTenant] == owner
+
+		var patch map[string]any
+		switch {
+		case enabled && current == "":
+			log.Info("Enabling rate limit headers on Limitador", "namespace", limitador.GetNamespace(), "name", limitador.GetName())
+			patch = map[string]any{
+				"metadata": map[string]any{"annotations": map[string]any{AnnotationRateLimitHeadersTenant: owner}},
+				"spec":     map[string]any{"rateLimitHeaders": RateLimitHeadersDraft03},
+			}
+		case !enabled && setByTenant:
+			log.Info("Disabling rate limit headers on Limitador", "namespace", limitador.GetNamespace(), "name", limitador.GetName())
+			patch = map[string]any{
+				"metadata": map[string]any{"annotations": map[string]any{AnnotationRateLimitHeadersTenant: nil}},
+				"spec":     map[string]any{"rateLimitHeaders": nil},
+			}
+		default:
+			continue
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return fmt.Errorf("marshal Limitador patch: %w", err)
+		}
+		if err := c.Patch(ctx, limitador, client.RawPatch(types.MergePatchType, data)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("patch Limitador %s/%s: %w", limitador.GetNamespace(), limitador.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package tenantreconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestReconcileRateLimitHeaders(t *testing.T) {
+	tenant := &maasv1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: maasv1alpha1.TenantInstanceName, Namespace: "tenant-ns"},
+	}
+	enabled := tenant.DeepCopy()
+	enabled.Spec.RateLimitHeaders = &maasv1alpha1.TenantRateLimitHeadersConfig{Enabled: ptr.To(true)}
+	owner := tenant.Namespace + "/" + tenant.Name
+
+	limitador := func(headers string, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{}}}
+		u.SetGroupVersionKind(GVKLimitador)
+		u.SetNamespace("kuadrant-system")
+		u.SetName("limitador")
+		u.SetAnnotations(annotations)
+		if headers != "" {
+			require.NoError(t, unstructured.SetNestedField(u.Object, headers, "spec", "rateLimitHeaders"))
+		}
+		return u
+	}
+	get := func(t *testing.T, c client.Client) *unstructured.Unstructured {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(GVKLimitador)
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "kuadrant-system", Name: "limitador"}, live))
+		return live
+	}
+	headers := func(u *unstructured.Unstructured) string {
+		value, _, _ := unstructured.NestedString(u.Object, "spec", "rateLimitHeaders")
+		return value
+	}
+
+	t.Run("enables and disables the headers set for the tenant", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(limitador("", nil)).Build()
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, enabled))
+		live := get(t, c)
+		assert.Equal(t, RateLimitHeadersDraft03, headers(live))
+		assert.Equal(t, owner, live.GetAnnotations()[AnnotationRateLimitHeadersTenant])
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, tenant))
+		live = get(t, c)
+		assert.Empty(t, headers(live))
+		assert.NotContains(t, live.GetAnnotations(), AnnotationRateLimitHeadersTenant)
+	})
+
+	t.Run("keeps headers set by an administrator", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(limitador(RateLimitHeadersDraft03, nil)).Build()
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, enabled))
+		assert.NotContains(t, get(t, c).GetAnnotations(), AnnotationRateLimitHeadersTenant)
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, tenant))
+		assert.Equal(t, RateLimitHeadersDraft03, headers(get(t, c)))
+	})
+
+	t.Run("keeps headers set for another tenant", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(limitador(RateLimitHeadersDraft03,
+			map[string]string{AnnotationRateLimitHeadersTenant: "other-ns/" + maasv1alpha1.TenantInstanceName})).Build()
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, tenant))
+		assert.Equal(t, RateLimitHeadersDraft03, headers(get(t, c)))
+	})
+
+	t.Run("skips unmanaged Limitador instances", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(limitador("", map[string]string{AnnotationManaged: "false"})).Build()
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, enabled))
+		assert.Empty(t, headers(get(t, c)))
+	})
+
+	t.Run("fails without a Limitador instance while enabled", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+
+		require.NoError(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, tenant))
+		require.Error(t, ReconcileRateLimitHeaders(context.Background(), logr.Discard(), c, enabled))
+	})
+}
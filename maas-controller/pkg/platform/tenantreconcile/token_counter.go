@@ -0,0 +1,85 @@
+package tenantreconcile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+// tokenCounterGVKs are the kinds of the token counter resources rendered from
+// deployment/base/token-counter, all named TokenCounterName in the gateway namespace.
+var tokenCounterGVKs = []schema.GroupVersionKind{GVKDeployment, GVKService, GVKEnvoyFilter}
+
+func isTokenCountingEnabled(t *maasv1alpha1.TenantTokenCountingConfig) bool {
+	if t == nil || t.Enabled == nil {
+		return false
+	}
+	return *t.Enabled
+}
+
+func isTokenCounterResource(r *unstructured.Unstructured) bool {
+	if r.GetName() != TokenCounterName {
+		return false
+	}
+	gvk := r.GroupVersionKind()
+	for _, tokenCounterGVK := range tokenCounterGVKs {
+		if gvk == tokenCounterGVK {
+			return true
+		}
+	}
+	return false
+}
+
+func patchTokenCounterDeployment(log logr.Logger, r *unstructured.Unstructured, params PlatformParams) error {
+	r.SetNamespace(params.GatewayNamespace)
+	// The token counter is a binary of the maas-api image.
+	log.V(4).Info("Patching token-counter image", "image", params.MaaSAPIImage)
+	if err := setContainerImage(r, TokenCounterName, params.MaaSAPIImage); err != nil {
+		return fmt.Errorf("patch token-counter image: %w", err)
+	}
+	return nil
+}
+
+func patchTokenCounterEnvoyFilter(log logr.Logger, r *unstructured.Unstructured, params PlatformParams) error {
+	return patchExtProcEnvoyFilter(log, r, params, TokenCounterName)
+}
+
+// PruneTokenCounter deletes the token counter resources a Tenant applied in the gateway
+// namespace once it disables spec.tokenCounting. Resources last applied for another
+// Tenant sharing the gateway, or marked opendatahub.io/managed=false, are kept.
+func PruneTokenCounter(ctx context.Context, log logr.Logger, c client.Client, tenant *maasv1alpha1.Tenant, gatewayNamespace string) error {
+	if isTokenCountingEnabled(tenant.Spec.TokenCounting) {
+		return nil
+	}
+	for _, gvk := range tokenCounterGVKs {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(gvk)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: gatewayNamespace, Name: TokenCounterName}, live); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("get token-counter %s: %w", gvk.Kind, err)
+		}
+		labels := live.GetLabels()
+		if labels[LabelTenantName] != tenant.Name || labels[LabelTenantNamespace] != tenant.Namespace {
+			continue
+		}
+		if live.GetAnnotations()[AnnotationManaged] == "false" {
+			continue
+		}
+		log.Info("Deleting token-counter resource, token counting is disabled",
+			"kind", gvk.Kind, "namespace", gatewayNamespace)
+		if err := c.Delete(ctx, live); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete token-counter %s: %w", gvk.Kind, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package tenantreconcile
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+)
+
+func TestApplyPlatformParamsTokenCounter(t *testing.T) {
+	resources := renderOverlayResources(t, "tenant-ns")
+	params := PlatformParams{
+		AppNamespace:     "tenant-ns",
+		GatewayNamespace: "gateway-ns",
+		GatewayName:      "custom-gateway",
+		TenantIdentifier: "redteam",
+		MaaSAPIImage:     "quay.io/example/maas-api:test",
+	}
+
+	require.NoError(t, applyPlatformParams(logr.Discard(), resources, params))
+
+	deployment := requireResource(t, resources, GVKDeployment, TokenCounterName)
+	assert.Equal(t, params.GatewayNamespace, deployment.GetNamespace())
+	assert.Equal(t, params.MaaSAPIImage, requireContainerImage(t, deployment, "spec", "template", "spec", "containers"))
+
+	service := requireResource(t, resources, GVKService, TokenCounterName)
+	assert.Equal(t, params.GatewayNamespace, service.GetNamespace())
+
+	envoyFilter := requireResource(t, resources, GVKEnvoyFilter, TokenCounterName)
+	assert.Equal(t, params.GatewayNamespace, envoyFilter.GetNamespace())
+	targetRefs, _, err := unstructured.NestedSlice(envoyFilter.Object, "spec", "targetRefs")
+	require.NoError(t, err)
+	require.NotEmpty(t, targetRefs)
+	assert.Equal(t, params.GatewayName, targetRefs[0].(map[string]any)["name"])
+
+	configPatches, _, err := unstructured.NestedSlice(envoyFilter.Object, "spec", "configPatches")
+	require.NoError(t, err)
+	require.Len(t, configPatches, 3, "expected the ext_proc filter and 2x MERGE on maas-api-route rules")
+
+	filter, ok := configPatches[0].(map[string]any)
+	require.True(t, ok)
+	op, _, _ := unstructured.NestedString(filter, "patch", "operation")
+	assert.Equal(t, "INSERT_AFTER", op)
+	anchor, _, _ := unstructured.NestedString(filter, "match", "listener", "filterChain", "filter", "subFilter", "name")
+	assert.Equal(t, wasmpluginAnchorName(params.GatewayNamespace, params.GatewayName), anchor)
+	cluster, _, _ := unstructured.NestedString(filter, "patch", "value", "typed_config", "grpc_service", "envoy_grpc", "cluster_name")
+	assert.Equal(t, grpcClusterName(TokenCounterName, params.GatewayNamespace, 9004), cluster)
+
+	for i := 1; i < 3; i++ {
+		cp, ok := configPatches[i].(map[string]any)
+		require.True(t, ok, "configPatches[%d] should be a map", i)
+
+		routeName, _, _ := unstructured.NestedString(cp, "match", "routeConfiguration", "vhost", "route", "name")
+		assert.Equal(t, fmt.Sprintf("tenant-ns.%s.%d", MaaSAPIRouteName(params.TenantIdentifier), i-1), routeName)
+
+		disabled, found, err := unstructured.NestedBool(cp, "patch", "value", "typed_per_filter_config", "envoy.filters.http.ext_proc.token-counter", "disabled")
+		require.NoError(t, err)
+		require.True(t, found, "configPatches[%d] token-counter disabled field should exist", i)
+		assert.True(t, disabled)
+	}
+}
+
+func TestPostRenderTokenCounter(t *testing.T) {
+	params := PlatformParams{
+		AppNamespace:     "tenant-ns",
+		GatewayNamespace: "gateway-ns",
+		GatewayName:      "custom-gateway",
+		MaaSAPIImage:     "quay.io/example/maas-api:test",
+	}
+
+	tests := []struct {
+		name          string
+		tokenCounting *maasv1alpha1.TenantTokenCountingConfig
+		wantRendered  bool
+	}{
+		{name: "unset", tokenCounting: nil, wantRendered: false},
+		{name: "disabled", tokenCounting: &maasv1alpha1.TenantTokenCountingConfig{Enabled: ptr.To(false)}, wantRendered: false},
+		{name: "enabled", tokenCounting: &maasv1alpha1.TenantTokenCountingConfig{Enabled: ptr.To(true)}, wantRendered: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := &maasv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{Name: maasv1alpha1.TenantInstanceName, Namespace: "tenant-ns"},
+				Spec:       maasv1alpha1.TenantSpec{TokenCounting: tt.tokenCounting},
+			}
+
+			resources, err := PostRender(context.Background(), logr.Discard(), tenant, renderOverlayResources(t, "tenant-ns"), params)
+			require.NoError(t, err)
+
+			for _, gvk := range tokenCounterGVKs {
+				rendered := findResource(resources, gvk, TokenCounterName) != nil
+				assert.Equal(t, tt.wantRendered, rendered, "token-counter %s rendered", gvk.Kind)
+			}
+			assert.NotNil(t, findResource(resources, GVKEnvoyFilter, PayloadProcessingName), "payload-processing is always rendered")
+		})
+	}
+}
+
+func TestPruneTokenCounter(t *testing.T) {
+	tenant := &maasv1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: maasv1alpha1.TenantInstanceName, Namespace: "tenant-ns"},
+	}
+	tokenCounterResource := func(gvkIndex int, tenantNamespace string, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(tokenCounterGVKs[gvkIndex])
+		u.SetNamespace("gateway-ns")
+		u.SetName(TokenCounterName)
+		u.SetLabels(map[string]string{LabelTenantName: maasv1alpha1.TenantInstanceName, LabelTenantNamespace: tenantNamespace})
+		u.SetAnnotations(annotations)
+		return u
+	}
+
+	t.Run("deletes the resources applied for the tenant", func(t *testing.T) {
+		deployment := tokenCounterResource(0, "tenant-ns", nil)
+		service := tokenCounterResource(1, "tenant-ns", nil)
+		envoyFilter := tokenCounterResource(2, "tenant-ns", nil)
+		c := fake.NewClientBuilder().WithObjects(deployment, service, envoyFilter).Build()
+
+		require.NoError(t, PruneTokenCounter(context.Background(), logr.Discard(), c, tenant, "gateway-ns"))
+
+		for _, obj := range []*unstructured.Unstructured{deployment, service, envoyFilter} {
+			err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj.DeepCopy())
+			assert.True(t, apierrors.IsNotFound(err), "%s should be deleted, got %v", obj.GetKind(), err)
+		}
+	})
+
+	t.Run("keeps resources of another tenant and unmanaged resources", func(t *testing.T) {
+		deployment := tokenCounterResource(0, "other-tenant-ns", nil)
+		service := tokenCounterResource(1, "tenant-ns", map[string]string{AnnotationManaged: "false"})
+		c := fake.NewClientBuilder().WithObjects(deployment, service).Build()
+
+		require.NoError(t, PruneTokenCounter(context.Background(), logr.Discard(), c, tenant, "gateway-ns"))
+
+		for _, obj := range []*unstructured.Unstructured{deployment, service} {
+			assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj.DeepCopy()), "%s should be kept", obj.GetKind())
+		}
+	})
+
+	t.Run("keeps the resources while token counting is enabled", func(t *testing.T) {
+		enabled := tenant.DeepCopy()
+		enabled.Spec.TokenCounting = &maasv1alpha1.TenantTokenCountingConfig{Enabled: ptr.To(true)}
+		deployment := tokenCounterResource(0, "tenant-ns", nil)
+		c := fake.NewClientBuilder().WithObjects(deployment).Build()
+
+		require.NoError(t, PruneTokenCounter(context.Background(), logr.Discard(), c, enabled, "gateway-ns"))
+
+		assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deployment), deployment.DeepCopy()))
+	})
+}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +35,23 @@ const (
 	annotationTLS = "maas.opendatahub.io/tls"
 )
 
+// RoutingProvider selects which API the reconciler uses to route gateway traffic
+// to the external provider.
+type RoutingProvider string
+
+const (
+	// RoutingProviderGatewayAPI emits a Gateway API HTTPRoute (default).
+	RoutingProviderGatewayAPI RoutingProvider = "gateway-api"
+	// RoutingProviderIstio emits an Istio VirtualService bound to an Istio Gateway,
+	// for clusters without the Gateway API CRDs.
+	RoutingProviderIstio RoutingProvider = "istio"
+)
+
+var (
+	httpRouteGVK      = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	virtualServiceGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "VirtualService"}
+)
+
 // Reconciler watches ExternalModel CRs and creates the Istio resources
 // needed to route to the external provider.
 //
@@ -45,6 +64,8 @@ type Reconciler struct {
 	Log              logr.Logger
 	GatewayName      string
 	GatewayNamespace string
+	// RoutingProvider selects HTTPRoute (default) or VirtualService routing.
+	RoutingProvider RoutingProvider
 }
 
 func (r *Reconciler) gatewayName() string {
@@ -96,12 +117,14 @@ func getTLSInfo(extModel *maasv1alpha1.ExternalModel) (tls bool, port int32, err
 	return
 }
 
-//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=externalmodels,verbs=get;list;watch
 //+kubebuilder:rbac:groups=maas.opendatahub.io,resources=externalmodels/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=networking.istio.io,resources=serviceentries,verbs=get;list;watch;create;update
 //+kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;delete
 
 // Reconcile handles create/update/delete of ExternalModel CRs.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -174,14 +197,59 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
-	// 4. HTTPRoute (routes requests to external provider via gateway)
-	hr := buildHTTPRoute(extModel.Spec.Endpoint, resourceName, resourceName, name, extModel.Spec.TargetModel, ns, port, gwName, gwNamespace, labels)
+	// 4. Role and RoleBinding (let the credential injector get the credentialRef Secret)
+	credentialName := modelnaming.ExternalModelResourceName(name + "-credential")
+	role := buildCredentialRole(credentialName, ns, extModel.Spec.CredentialRef.Name, labels)
+	if err := controllerutil.SetControllerReference(extModel, role, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner on Role: %w", err)
+	}
+	if err := r.applyRole(ctx, logger, role); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create Role: %w", err)
+	}
+	binding := buildCredentialRoleBinding(credentialName, ns, gwNamespace, labels)
+	if err := controllerutil.SetControllerReference(extModel, binding, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner on RoleBinding: %w", err)
+	}
+	if err := r.applyRoleBinding(ctx, logger, binding); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create RoleBinding: %w", err)
+	}
+
+	// 5. HTTPRoute or VirtualService (routes requests to external provider via gateway)
+	removeHeaders := consumerCredentialHeaders(extModel.Spec.Provider)
+	annotations := credentialAnnotations(extModel.Spec.Provider, extModel.Spec.CredentialRef.Name)
+	if r.RoutingProvider == RoutingProviderIstio {
+		vs := buildVirtualService(extModel.Spec.Endpoint, resourceName, name, extModel.Spec.TargetModel, ns, port, gwName, gwNamespace, removeHeaders, labels)
+		if err := r.setUnstructuredOwner(extModel, vs); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set owner on VirtualService: %w", err)
+		}
+		if err := r.applyUnstructured(ctx, logger, vs); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create VirtualService: %w", err)
+		}
+		// Remove the HTTPRoute left over from running with the gateway-api provider.
+		if err := r.deleteIfExists(ctx, logger, "HTTPRoute", resourceName, ns, httpRouteGVK); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete stale HTTPRoute: %w", err)
+		}
+		logger.Info("ExternalModel resources reconciled successfully",
+			"service", svc.Name,
+			"serviceEntry", se.GetName(),
+			"virtualService", vs.GetName(),
+			"namespace", ns,
+		)
+		return ctrl.Result{}, nil
+	}
+
+	hr := buildHTTPRoute(extModel.Spec.Endpoint, resourceName, resourceName, name, extModel.Spec.TargetModel, ns, port, gwName, gwNamespace, removeHeaders, labels)
+	hr.Annotations = annotations
 	if err := controllerutil.SetControllerReference(extModel, hr, r.Scheme); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to set owner on HTTPRoute: %w", err)
 	}
 	if err := r.applyHTTPRoute(ctx, logger, hr); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to create HTTPRoute: %w", err)
 	}
+	// Remove the VirtualService left over from running with the istio provider.
+	if err := r.deleteIfExists(ctx, logger, "VirtualService", resourceName, ns, virtualServiceGVK); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete stale VirtualService: %w", err)
+	}
 
 	logger.Info("ExternalModel resources reconciled successfully",
 		"service", svc.Name,
@@ -215,7 +283,8 @@ func (r *Reconciler) deleteIfExists(ctx context.Context, log logr.Logger, kind,
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(gvk)
 	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj); err != nil {
-		if apierrors.IsNotFound(err) {
+		// A missing CRD (e.g. no Gateway API on an istio-routed cluster) means nothing to delete.
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
 			return nil
 		}
 		return fmt.Errorf("failed to get %s %s/%s: %w", kind, namespace, name, err)
@@ -270,6 +339,60 @@ func (r *Reconciler) applyService(ctx context.Context, log logr.Logger, desired
 	return nil
 }
 
+// applyRole creates or updates a Role.
+func (r *Reconciler) applyRole(ctx context.Context, log logr.Logger, desired *rbacv1.Role) error {
+	existing := &rbacv1.Role{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating Role", "name", desired.Name)
+		return r.Create(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+	if !isManaged(existing) {
+		log.Info("Role opted out of management, skipping update", "name", existing.Name, "namespace", existing.Namespace)
+		return nil
+	}
+	if !equality.Semantic.DeepEqual(existing.Rules, desired.Rules) ||
+		!equality.Semantic.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) ||
+		!equality.Semantic.DeepEqual(existing.Labels, desired.Labels) {
+		existing.Rules = desired.Rules
+		existing.Labels = desired.Labels
+		existing.OwnerReferences = desired.OwnerReferences
+		log.Info("Updating Role", "name", desired.Name)
+		return r.Update(ctx, existing)
+	}
+	return nil
+}
+
+// applyRoleBinding creates or updates a RoleBinding. Its roleRef never changes.
+func (r *Reconciler) applyRoleBinding(ctx context.Context, log logr.Logger, desired *rbacv1.RoleBinding) error {
+	existing := &rbacv1.RoleBinding{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating RoleBinding", "name", desired.Name)
+		return r.Create(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+	if !isManaged(existing) {
+		log.Info("RoleBinding opted out of management, skipping update", "name", existing.Name, "namespace", existing.Namespace)
+		return nil
+	}
+	if !equality.Semantic.DeepEqual(existing.Subjects, desired.Subjects) ||
+		!equality.Semantic.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) ||
+		!equality.Semantic.DeepEqual(existing.Labels, desired.Labels) {
+		existing.Subjects = desired.Subjects
+		existing.Labels = desired.Labels
+		existing.OwnerReferences = desired.OwnerReferences
+		log.Info("Updating RoleBinding", "name", desired.Name)
+		return r.Update(ctx, existing)
+	}
+	return nil
+}
+
 // applyUnstructured creates or updates an unstructured resource (ServiceEntry, DestinationRule).
 func (r *Reconciler) applyUnstructured(ctx context.Context, log logr.Logger, desired *unstructured.Unstructured) error {
 	existing := &unstructured.Unstructured{}
@@ -308,6 +431,12 @@ func (r *Reconciler) applyHTTPRoute(ctx context.Context, log logr.Logger, desire
 	}
 	existing.Spec = desired.Spec
 	existing.Labels = desired.Labels
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	for k, v := range desired.Annotations {
+		existing.Annotations[k] = v
+	}
 	existing.OwnerReferences = desired.OwnerReferences
 	log.Info("Updating HTTPRoute", "name", desired.Name)
 	return r.Update(ctx, existing)
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -350,3 +351,126 @@ func TestIsManaged(t *testing.T) {
 		})
 	}
 }
+
+// TestReconcile_StripsConsumerCredentials verifies that the HTTPRoute never carries the
+// provider key, names its Secret and header for the credential injector, and removes the
+// consumer's MaaS key for providers that do not use it.
+func TestReconcile_StripsConsumerCredentials(t *testing.T) {
+	const (
+		name = "claude"
+		ns   = "llm"
+	)
+	resourceName := modelnaming.ExternalModelResourceName(name)
+
+	tests := []struct {
+		provider   string
+		wantHeader string
+		wantRemove []string
+	}{
+		{provider: "openai", wantHeader: "Authorization"},
+		{provider: "anthropic", wantHeader: "x-api-key", wantRemove: []string{"Authorization"}},
+		{provider: "azure-openai", wantHeader: "api-key", wantRemove: []string{"Authorization"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.provider, func(t *testing.T) {
+			em := newTestExternalModel(name, ns, "api.example.com", nil)
+			em.Spec.Provider = tc.provider
+			em.Spec.CredentialRef.Name = "provider-key"
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(em).Build()
+			r := &Reconciler{Client: c, Scheme: testScheme, Log: ctrl.Log, GatewayName: "maas-default-gateway", GatewayNamespace: "openshift-ingress"}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: ns}})
+			require.NoError(t, err)
+
+			hr := &gatewayapiv1.HTTPRoute{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: resourceName, Namespace: ns}, hr))
+			assert.Equal(t, map[string]string{
+				annotationCredentialSecret: "provider-key",
+				annotationCredentialHeader: tc.wantHeader,
+			}, hr.Annotations)
+			for i, rule := range hr.Spec.Rules {
+				modifier := rule.Filters[0].RequestHeaderModifier
+				require.Len(t, modifier.Set, 1, "rule %d: expected only the Host header", i)
+				assert.Equal(t, "Host", string(modifier.Set[0].Name))
+				assert.Equal(t, tc.wantRemove, modifier.Remove, "rule %d", i)
+			}
+		})
+	}
+}
+
+// TestReconcile_GrantsCredentialInjectorTheSecret verifies that the credential injector is
+// granted get on the credentialRef Secret, and on no other Secret.
+func TestReconcile_GrantsCredentialInjectorTheSecret(t *testing.T) {
+	const (
+		name = "gpt-4o"
+		ns   = "llm"
+	)
+	em := newTestExternalModel(name, ns, "api.openai.com", nil)
+	em.Spec.CredentialRef.Name = "openai-key"
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(em).Build()
+	r := &Reconciler{Client: c, Scheme: testScheme, Log: ctrl.Log, GatewayName: "maas-default-gateway", GatewayNamespace: "openshift-ingress"}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: ns}})
+	require.NoError(t, err)
+
+	key := types.NamespacedName{Name: "maas-gpt-4o-credential", Namespace: ns}
+	role := &rbacv1.Role{}
+	require.NoError(t, c.Get(context.Background(), key, role))
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []string{"secrets"}, role.Rules[0].Resources)
+	assert.Equal(t, []string{"openai-key"}, role.Rules[0].ResourceNames)
+	assert.Equal(t, []string{"get"}, role.Rules[0].Verbs)
+	require.Len(t, role.OwnerReferences, 1)
+	assert.Equal(t, name, role.OwnerReferences[0].Name)
+
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, c.Get(context.Background(), key, binding))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: key.Name}, binding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "credential-injector", Namespace: "openshift-ingress"}}, binding.Subjects)
+
+	// A changed credentialRef moves the grant to the new Secret.
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: ns}, em))
+	em.Spec.CredentialRef.Name = "openai-key-rotated"
+	require.NoError(t, c.Update(context.Background(), em))
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: ns}})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(context.Background(), key, role))
+	assert.Equal(t, []string{"openai-key-rotated"}, role.Rules[0].ResourceNames)
+}
+
+// TestReconcile_IstioRoutingProvider verifies that the istio routing provider creates a
+// VirtualService instead of an HTTPRoute and removes an HTTPRoute left from the
+// gateway-api provider.
+func TestReconcile_IstioRoutingProvider(t *testing.T) {
+	const (
+		name     = "gpt-4o"
+		ns       = "llm"
+		endpoint = "api.openai.com"
+	)
+	resourceName := modelnaming.ExternalModelResourceName(name)
+	staleHR := &gatewayapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: ns}}
+
+	em := newTestExternalModel(name, ns, endpoint, nil)
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(em, staleHR).Build()
+	r := &Reconciler{
+		Client: c, Scheme: testScheme, Log: ctrl.Log,
+		GatewayName: "maas-default-gateway", GatewayNamespace: "openshift-ingress",
+		RoutingProvider: RoutingProviderIstio,
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: ns}})
+	require.NoError(t, err)
+
+	vs := &unstructured.Unstructured{}
+	vs.SetGroupVersionKind(virtualServiceGVK)
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: resourceName, Namespace: ns}, vs))
+	require.Len(t, vs.GetOwnerReferences(), 1)
+	assert.Equal(t, name, vs.GetOwnerReferences()[0].Name)
+	assert.NotContains(t, vs.GetAnnotations(), annotationCredentialSecret,
+		"the credential injector does not serve VirtualServices")
+
+	hr := &gatewayapiv1.HTTPRoute{}
+	assert.True(t, apierrors.IsNotFound(c.Get(context.Background(), types.NamespacedName{Name: resourceName, Namespace: ns}, hr)),
+		"expected stale HTTPRoute to be deleted")
+}
@@ -2,10 +2,13 @@ package externalmodel
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/platform/tenantreconcile"
 )
 
 // buildService creates a Kubernetes ExternalName Service that maps an in-cluster
@@ -83,11 +86,98 @@ func buildDestinationRule(endpoint, name, namespace string, labels map[string]st
 	return dr
 }
 
+// Annotations naming the provider key of an ExternalModel on its HTTPRoute. The
+// credential injector of the gateway reads them, and the key from
+// the credentialRef Secret, at request time, so the key is never copied into a route.
+const (
+	annotationCredentialSecret = "maas.opendatahub.io/credential-secret"
+	annotationCredentialHeader = "maas.opendatahub.io/credential-header"
+)
+
+// credentialHeader returns the request header the provider reads its API key from.
+// The credential injector sends the Authorization header as a bearer token.
+func credentialHeader(provider string) string {
+	switch provider {
+	case "anthropic":
+		return "x-api-key"
+	case "azure-openai":
+		return "api-key"
+	default:
+		return "Authorization"
+	}
+}
+
+// credentialAnnotations returns the annotations of the routes of an ExternalModel of
+// provider whose key is in the Secret secretName.
+func credentialAnnotations(provider, secretName string) map[string]string {
+	return map[string]string{
+		annotationCredentialSecret: secretName,
+		annotationCredentialHeader: credentialHeader(provider),
+	}
+}
+
+// buildCredentialRole creates the Role allowing the credential injector to get the
+// credentialRef Secret secretName, and no other Secret of the namespace.
+func buildCredentialRole(name, namespace, secretName string, labels map[string]string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{secretName},
+				Verbs:         []string{"get"},
+			},
+		},
+	}
+}
+
+// buildCredentialRoleBinding binds the Role of buildCredentialRole to the ServiceAccount
+// of the credential injector, which the tenant reconciler deploys in the gateway
+// namespace.
+func buildCredentialRoleBinding(name, namespace, gatewayNamespace string, labels map[string]string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      tenantreconcile.CredentialInjectorName,
+				Namespace: gatewayNamespace,
+			},
+		},
+	}
+}
+
+// consumerCredentialHeaders returns the request headers carrying the consumer's MaaS
+// key that the route removes before the request leaves for the provider. The credential
+// injector already removes them; Authorization is only removed for providers that do
+// not read their key from it, since route header modifiers apply after the injector.
+func consumerCredentialHeaders(provider string) []string {
+	if credentialHeader(provider) == "Authorization" {
+		return nil
+	}
+	return []string{"Authorization"}
+}
+
 // buildHTTPRoute creates the HTTPRoute in the model's namespace.
 // Path prefix is /<namespace>/<name> for namespace isolation.
-// Only a Host header filter is set (required for TLS SNI).
-// IPP ext-proc handles path rewriting and provider-specific headers.
-func buildHTTPRoute(endpoint, routeName, serviceName, modelName, targetModel, namespace string, port int32, gatewayName, gatewayNamespace string, labels map[string]string) *gatewayapiv1.HTTPRoute {
+// A Host header filter is always set (required for TLS SNI), and removeHeaders are
+// removed from the upstream request. The credential injector sets the provider key from
+// the credentialRef Secret, and IPP ext-proc handles path rewriting.
+func buildHTTPRoute(endpoint, routeName, serviceName, modelName, targetModel, namespace string, port int32, gatewayName, gatewayNamespace string, removeHeaders []string, labels map[string]string) *gatewayapiv1.HTTPRoute {
 	gwNamespace := gatewayapiv1.Namespace(gatewayNamespace)
 	pathType := gatewayapiv1.PathMatchPathPrefix
 	pathPrefix := "/" + namespace + "/" + modelName
@@ -108,16 +198,21 @@ func buildHTTPRoute(endpoint, routeName, serviceName, modelName, targetModel, na
 
 	// Host header is required for TLS SNI — must be set before TLS handshake,
 	// which happens before IPP ext-proc runs.
+	setHeaders := []gatewayapiv1.HTTPHeader{
+		{
+			Name:  "Host",
+			Value: endpoint,
+		},
+	}
+	// Route-level header modifiers are applied by the Envoy router, after the
+	// Kuadrant auth filters have validated the consumer's MaaS key, so removing
+	// it only affects the upstream request.
 	filters := []gatewayapiv1.HTTPRouteFilter{
 		{
 			Type: gatewayapiv1.HTTPRouteFilterRequestHeaderModifier,
 			RequestHeaderModifier: &gatewayapiv1.HTTPHeaderFilter{
-				Set: []gatewayapiv1.HTTPHeader{
-					{
-						Name:  "Host",
-						Value: endpoint,
-					},
-				},
+				Set:    setHeaders,
+				Remove: removeHeaders,
 			},
 		},
 	}
@@ -173,3 +268,61 @@ func buildHTTPRoute(endpoint, routeName, serviceName, modelName, targetModel, na
 		},
 	}
 }
+
+// buildVirtualService creates the Istio VirtualService used instead of the HTTPRoute when
+// the controller runs with the istio routing provider. It mirrors buildHTTPRoute: the same
+// path-prefix and model-header matches, Host header, removed headers and timeout,
+// bound to the Istio Gateway <gatewayNamespace>/<gatewayName>. The destination is the
+// external host registered by the ServiceEntry. The credential injector does not serve
+// VirtualServices, so the provider key is not set on requests routed by them.
+func buildVirtualService(endpoint, name, modelName, targetModel, namespace string, port int32, gatewayName, gatewayNamespace string, removeHeaders []string, labels map[string]string) *unstructured.Unstructured {
+	requestHeaders := map[string]any{"set": map[string]any{"Host": endpoint}}
+	if len(removeHeaders) > 0 {
+		remove := make([]any, len(removeHeaders))
+		for i, h := range removeHeaders {
+			remove[i] = h
+		}
+		requestHeaders["remove"] = remove
+	}
+	route := func(routeName string, match map[string]any) map[string]any {
+		return map[string]any{
+			"name":  routeName,
+			"match": []any{match},
+			"headers": map[string]any{
+				"request": requestHeaders,
+			},
+			"route": []any{
+				map[string]any{
+					"destination": map[string]any{
+						"host": endpoint,
+						"port": map[string]any{"number": int64(port)},
+					},
+				},
+			},
+			"timeout": "300s",
+		}
+	}
+
+	vs := &unstructured.Unstructured{}
+	vs.SetAPIVersion("networking.istio.io/v1")
+	vs.SetKind("VirtualService")
+	vs.SetName(name)
+	vs.SetNamespace(namespace)
+	vs.SetLabels(labels)
+
+	vs.Object["spec"] = map[string]any{
+		"hosts":    []any{"*"},
+		"gateways": []any{gatewayNamespace + "/" + gatewayName},
+		"http": []any{
+			route("path", map[string]any{
+				"uri": map[string]any{"prefix": "/" + namespace + "/" + modelName},
+			}),
+			route("model-header", map[string]any{
+				"headers": map[string]any{
+					"X-Gateway-Model-Name": map[string]any{"exact": targetModel},
+				},
+			}),
+		},
+	}
+	return vs
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/modelnaming"
@@ -67,7 +68,7 @@ func TestBuildDestinationRule(t *testing.T) {
 
 func TestBuildHTTPRoute(t *testing.T) {
 	resourceName := modelnaming.ExternalModelResourceName("gpt-4o")
-	hr := buildHTTPRoute("api.openai.com", resourceName, resourceName, "gpt-4o", "gpt-4o", "llm", 443, "maas-default-gateway", "openshift-ingress", commonLabels("gpt-4o"))
+	hr := buildHTTPRoute("api.openai.com", resourceName, resourceName, "gpt-4o", "gpt-4o", "llm", 443, "maas-default-gateway", "openshift-ingress", nil, commonLabels("gpt-4o"))
 
 	assert.Equal(t, "maas-gpt-4o", hr.Name)
 	assert.Equal(t, "llm", hr.Namespace)
@@ -100,7 +101,7 @@ func TestBuildHTTPRoute(t *testing.T) {
 
 func TestBuildHTTPRoute_TargetModelDiffersFromName(t *testing.T) {
 	resourceName := modelnaming.ExternalModelResourceName("my-bedrock")
-	hr := buildHTTPRoute("bedrock-mantle.us-east-2.api.aws", resourceName, resourceName, "my-bedrock", "openai.gpt-oss-20b", "llm", 443, "maas-default-gateway", "openshift-ingress", commonLabels("my-bedrock"))
+	hr := buildHTTPRoute("bedrock-mantle.us-east-2.api.aws", resourceName, resourceName, "my-bedrock", "openai.gpt-oss-20b", "llm", 443, "maas-default-gateway", "openshift-ingress", nil, commonLabels("my-bedrock"))
 
 	// Resource name is MaaS-owned, while the public path uses ExternalModel name.
 	assert.Equal(t, "maas-my-bedrock", hr.Name)
@@ -112,3 +113,97 @@ func TestBuildHTTPRoute_TargetModelDiffersFromName(t *testing.T) {
 	// BackendRef uses the MaaS-owned Service name.
 	assert.Equal(t, "maas-my-bedrock", string(hr.Spec.Rules[0].BackendRefs[0].Name))
 }
+
+func TestConsumerCredentialHeaders(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{provider: "openai"},
+		{provider: "anthropic", want: []string{"Authorization"}},
+		{provider: "azure-openai", want: []string{"Authorization"}},
+		{provider: "bedrock-openai"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.provider, func(t *testing.T) {
+			assert.Equal(t, tc.want, consumerCredentialHeaders(tc.provider))
+		})
+	}
+}
+
+func TestCredentialAnnotations(t *testing.T) {
+	tests := []struct {
+		provider   string
+		wantHeader string
+	}{
+		{provider: "openai", wantHeader: "Authorization"},
+		{provider: "anthropic", wantHeader: "x-api-key"},
+		{provider: "azure-openai", wantHeader: "api-key"},
+		{provider: "bedrock-openai", wantHeader: "Authorization"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.provider, func(t *testing.T) {
+			assert.Equal(t, map[string]string{
+				annotationCredentialSecret: "provider-key",
+				annotationCredentialHeader: tc.wantHeader,
+			}, credentialAnnotations(tc.provider, "provider-key"))
+		})
+	}
+}
+
+func TestBuildHTTPRoute_RemovesConsumerCredential(t *testing.T) {
+	resourceName := modelnaming.ExternalModelResourceName("claude")
+	hr := buildHTTPRoute("api.anthropic.com", resourceName, resourceName, "claude", "claude-sonnet-4-20250514", "llm", 443, "maas-default-gateway", "openshift-ingress", consumerCredentialHeaders("anthropic"), commonLabels("claude"))
+
+	for i, rule := range hr.Spec.Rules {
+		require.Len(t, rule.Filters, 1, "rule %d", i)
+		modifier := rule.Filters[0].RequestHeaderModifier
+		require.Len(t, modifier.Set, 1, "rule %d: provider keys must never be written into the route", i)
+		assert.Equal(t, "Host", string(modifier.Set[0].Name))
+		assert.Equal(t, []string{"Authorization"}, modifier.Remove, "rule %d", i)
+	}
+}
+
+func TestBuildVirtualService(t *testing.T) {
+	resourceName := modelnaming.ExternalModelResourceName("my-bedrock")
+	vs := buildVirtualService("bedrock-mantle.us-east-2.api.aws", resourceName, "my-bedrock", "openai.gpt-oss-20b", "llm", 443, "maas-default-gateway", "openshift-ingress", nil, commonLabels("my-bedrock"))
+
+	assert.Equal(t, "VirtualService", vs.GetKind())
+	assert.Equal(t, "maas-my-bedrock", vs.GetName())
+	assert.Equal(t, "llm", vs.GetNamespace())
+
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	assert.Equal(t, []string{"openshift-ingress/maas-default-gateway"}, gateways)
+
+	http, _, _ := unstructured.NestedSlice(vs.Object, "spec", "http")
+	require.Len(t, http, 2, "must have path-based and header-based routes")
+
+	pathRoute, ok := http[0].(map[string]any)
+	require.True(t, ok)
+	pathMatches, _, _ := unstructured.NestedSlice(pathRoute, "match")
+	require.Len(t, pathMatches, 1)
+	prefix, _, _ := unstructured.NestedString(pathMatches[0].(map[string]any), "uri", "prefix")
+	assert.Equal(t, "/llm/my-bedrock", prefix)
+
+	headerRoute, ok := http[1].(map[string]any)
+	require.True(t, ok)
+	matches, _, _ := unstructured.NestedSlice(headerRoute, "match")
+	require.Len(t, matches, 1)
+	exact, _, _ := unstructured.NestedString(matches[0].(map[string]any), "headers", "X-Gateway-Model-Name", "exact")
+	assert.Equal(t, "openai.gpt-oss-20b", exact)
+
+	for i, r := range http {
+		route := r.(map[string]any)
+		set, _, _ := unstructured.NestedStringMap(route, "headers", "request", "set")
+		assert.Equal(t, map[string]string{"Host": "bedrock-mantle.us-east-2.api.aws"}, set, "route %d", i)
+		_, found, _ := unstructured.NestedSlice(route, "headers", "request", "remove")
+		assert.False(t, found, "route %d: openai-compatible providers keep the Authorization header", i)
+		dests, _, _ := unstructured.NestedSlice(route, "route")
+		require.Len(t, dests, 1)
+		host, _, _ := unstructured.NestedString(dests[0].(map[string]any), "destination", "host")
+		assert.Equal(t, "bedrock-mantle.us-east-2.api.aws", host, "route %d", i)
+		assert.Equal(t, "300s", route["timeout"], "route %d", i)
+	}
+}
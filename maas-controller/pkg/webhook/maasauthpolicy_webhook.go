@@ -21,16 +21,22 @@ import (
 	"errors"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/controller/maas"
 )
 
 // MaaSAuthPolicyValidator validates MaaSAuthPolicy resources.
-// +kubebuilder:webhook:path=/validate-maas-opendatahub-io-v1alpha1-maasauthpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=maas.opendatahub.io,resources=maasauthpolicies,verbs=create,versions=v1alpha1,name=vmaasauthpolicy.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-maas-opendatahub-io-v1alpha1-maasauthpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=maas.opendatahub.io,resources=maasauthpolicies,verbs=create;update,versions=v1alpha1,name=vmaasauthpolicy.kb.io,admissionReviewVersions=v1
 
 type MaaSAuthPolicyValidator struct {
 	Client    client.Reader
@@ -61,14 +67,32 @@ func (v *MaaSAuthPolicyValidator) ValidateCreate(ctx context.Context, obj runtim
 		return nil, fmt.Errorf("%s", message)
 	}
 
-	return nil, nil
+	if err := validateAuthPolicySpec(policy); err != nil {
+		return nil, err
+	}
+	return v.missingModelWarnings(ctx, policy), nil
 }
 
 // ValidateUpdate validates MaaSAuthPolicy on update.
-// Namespace cannot be changed on update (Kubernetes enforces this), so we don't need to validate.
+// Namespace cannot be changed on update (Kubernetes enforces this), so only the spec is
+// validated, and only when it changes: policies stored before this validation existed
+// must stay updatable, e.g. to remove a finalizer.
 func (v *MaaSAuthPolicyValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	// No validation needed - namespace is immutable
-	return nil, nil
+	oldPolicy, ok := oldObj.(*maasv1alpha1.MaaSAuthPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected MaaSAuthPolicy object for old, got %T", oldObj)
+	}
+	newPolicy, ok := newObj.(*maasv1alpha1.MaaSAuthPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected MaaSAuthPolicy object for new, got %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(oldPolicy.Spec, newPolicy.Spec) {
+		return nil, nil
+	}
+	if err := validateAuthPolicySpec(newPolicy); err != nil {
+		return nil, err
+	}
+	return v.missingModelWarnings(ctx, newPolicy), nil
 }
 
 // ValidateDelete validates MaaSAuthPolicy on deletion.
@@ -77,3 +101,91 @@ func (v *MaaSAuthPolicyValidator) ValidateDelete(ctx context.Context, obj runtim
 	// No validation needed for deletion
 	return nil, nil
 }
+
+// missingModelWarnings warns about modelRefs whose MaaSModelRef does not exist. They are
+// not rejected, so that a policy can be applied before its models; the policy is reported
+// Degraded, or Failed when none of its models exist, until they are created.
+func (v *MaaSAuthPolicyValidator) missingModelWarnings(ctx context.Context, policy *maasv1alpha1.MaaSAuthPolicy) admission.Warnings {
+	if v.Client == nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, ref := range policy.Spec.ModelRefs {
+		model := &maasv1alpha1.MaaSModelRef{}
+		err := v.Client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, model)
+		if apierrors.IsNotFound(err) {
+			warnings = append(warnings, fmt.Sprintf("MaaSModelRef %s/%s does not exist yet; the policy applies to it once it is created", ref.Namespace, ref.Name))
+		}
+	}
+	return warnings
+}
+
+// validateAuthPolicySpec rejects policies the controller could not turn into a gateway
+// AuthPolicy: malformed or duplicate modelRefs, no subjects unless
+// authorization.subjectAccessReview grants access, subject names that are unsafe in the
+// generated expressions, access windows in unknown time zones, allowedCIDRs that are not
+// IPv4 networks, deny response bodies that are not JSON templates, and meteringMetadata
+// with control characters or labels that are not valid annotation keys.
+func validateAuthPolicySpec(policy *maasv1alpha1.MaaSAuthPolicy) error {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+
+	if len(policy.Spec.ModelRefs) == 0 {
+		errs = append(errs, field.Required(spec.Child("modelRefs"), "at least one model must be referenced"))
+	}
+	seen := make(map[string]struct{}, len(policy.Spec.ModelRefs))
+	for i, ref := range policy.Spec.ModelRefs {
+		path := spec.Child("modelRefs").Index(i)
+		key := ref.Namespace + "/" + ref.Name
+		if _, ok := seen[key]; ok {
+			errs = append(errs, field.Duplicate(path, key))
+			continue
+		}
+		seen[key] = struct{}{}
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			errs = append(errs, field.Invalid(path.Child("name"), ref.Name, msg))
+		}
+		for _, msg := range validation.IsDNS1123Label(ref.Namespace) {
+			errs = append(errs, field.Invalid(path.Child("namespace"), ref.Namespace, msg))
+		}
+		if err := maas.ValidateAllowedCIDRs(ref.AllowedCIDRs); err != nil {
+			errs = append(errs, field.Invalid(path.Child("allowedCIDRs"), ref.AllowedCIDRs, err.Error()))
+		}
+	}
+
+	subjects := policy.Spec.Subjects
+	sar := policy.Spec.Authorization != nil && policy.Spec.Authorization.SubjectAccessReview != nil
+	if len(subjects.Groups) == 0 && len(subjects.Users) == 0 && len(subjects.DeniedUsers) == 0 && !sar {
+		errs = append(errs, field.Required(spec.Child("subjects"), "at least one group, user or denied user must be specified unless authorization.subjectAccessReview is set"))
+	}
+	for i, group := range subjects.Groups {
+		if group.Name == "" {
+			errs = append(errs, field.Required(spec.Child("subjects", "groups").Index(i).Child("name"), "group name must not be empty"))
+		}
+	}
+	if err := maas.ValidateAuthPolicySubjects(subjects); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("subjects"), subjects, err.Error()))
+	}
+
+	if err := maas.ValidateAccessWindows(policy.Spec.AccessWindows); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("accessWindows"), policy.Spec.AccessWindows, err.Error()))
+	}
+	if err := maas.ValidateAllowedCIDRs(policy.Spec.AllowedCIDRs); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("allowedCIDRs"), policy.Spec.AllowedCIDRs, err.Error()))
+	}
+	if err := maas.ValidateDenyResponses(policy.Spec.DenyResponse); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("denyResponse"), policy.Spec.DenyResponse, err.Error()))
+	}
+
+	if m := policy.Spec.MeteringMetadata; m != nil {
+		if err := maas.ValidateMeteringMetadata(m); err != nil {
+			errs = append(errs, field.Invalid(spec.Child("meteringMetadata"), m, err.Error()))
+		}
+		errs = append(errs, apivalidation.ValidateAnnotations(m.Labels, spec.Child("meteringMetadata", "labels"))...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(maasv1alpha1.GroupVersion.WithKind("MaaSAuthPolicy").GroupKind(), policy.Name, errs)
+}
@@ -42,13 +42,8 @@ func TestMaaSAuthPolicyValidator_ValidateCreate(t *testing.T) {
 		errContains string
 	}{
 		{
-			name: "allow policy in namespace with Tenant CR",
-			policy: &maasv1alpha1.MaaSAuthPolicy{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-policy",
-					Namespace: "ai-tenant-blueteam",
-				},
-			},
+			name:   "allow policy in namespace with Tenant CR",
+			policy: validAuthPolicy("ai-tenant-blueteam"),
 			tenant: &maasv1alpha1.Tenant{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "default-tenant",
@@ -68,6 +63,22 @@ func TestMaaSAuthPolicyValidator_ValidateCreate(t *testing.T) {
 			wantErr:     true,
 			errContains: "not enabled for MaaS tenant resources",
 		},
+		{
+			name: "reject policy without subjects",
+			policy: func() *maasv1alpha1.MaaSAuthPolicy {
+				p := validAuthPolicy("ai-tenant-blueteam")
+				p.Spec.Subjects = maasv1alpha1.SubjectSpec{}
+				return p
+			}(),
+			tenant: &maasv1alpha1.Tenant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "default-tenant",
+					Namespace: "ai-tenant-blueteam",
+				},
+			},
+			wantErr:     true,
+			errContains: "spec.subjects",
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,25 +126,28 @@ func TestMaaSAuthPolicyValidator_ValidateUpdate(t *testing.T) {
 		},
 	}
 
-	oldPolicy := &maasv1alpha1.MaaSAuthPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-policy",
-			Namespace: "ai-tenant-test",
-		},
-	}
-
-	newPolicy := &maasv1alpha1.MaaSAuthPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-policy",
-			Namespace: "ai-tenant-test",
-		},
-	}
+	oldPolicy := validAuthPolicy("ai-tenant-test")
+	newPolicy := validAuthPolicy("ai-tenant-test")
 
 	// Update should not validate namespace (it's immutable)
 	_, err := validator.ValidateUpdate(context.Background(), oldPolicy, newPolicy)
 	if err != nil {
 		t.Errorf("ValidateUpdate() unexpected error: %v", err)
 	}
+
+	// A spec change is validated.
+	newPolicy.Spec.ModelRefs = append(newPolicy.Spec.ModelRefs, newPolicy.Spec.ModelRefs[0])
+	if _, err := validator.ValidateUpdate(context.Background(), oldPolicy, newPolicy); err == nil {
+		t.Error("ValidateUpdate() expected an error for a duplicate modelRef")
+	}
+
+	// An unchanged invalid spec, e.g. stored before the webhook, stays updatable.
+	oldPolicy.Spec.Subjects = maasv1alpha1.SubjectSpec{}
+	legacy := oldPolicy.DeepCopy()
+	legacy.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), oldPolicy, legacy); err != nil {
+		t.Errorf("ValidateUpdate() unexpected error for a metadata-only update: %v", err)
+	}
 }
 
 func TestMaaSAuthPolicyValidator_ValidateDelete(t *testing.T) {
@@ -162,3 +176,180 @@ func TestMaaSAuthPolicyValidator_ValidateDelete(t *testing.T) {
 		t.Errorf("ValidateDelete() unexpected error: %v", err)
 	}
 }
+
+// validAuthPolicy returns a MaaSAuthPolicy that passes spec validation.
+func validAuthPolicy(namespace string) *maasv1alpha1.MaaSAuthPolicy {
+	return &maasv1alpha1.MaaSAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-policy",
+			Namespace:  namespace,
+			Finalizers: []string{"maas.opendatahub.io/authpolicy-cleanup"},
+		},
+		Spec: maasv1alpha1.MaaSAuthPolicySpec{
+			ModelRefs: []maasv1alpha1.ModelRef{{Name: "llm", Namespace: "models"}},
+			Subjects:  maasv1alpha1.SubjectSpec{Groups: []maasv1alpha1.GroupReference{{Name: "team-a"}}},
+		},
+	}
+}
+
+func TestValidateAuthPolicySpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(*maasv1alpha1.MaaSAuthPolicy)
+		errContains string
+	}{
+		{name: "valid", mutate: func(*maasv1alpha1.MaaSAuthPolicy) {}},
+		{
+			name:        "no modelRefs",
+			mutate:      func(p *maasv1alpha1.MaaSAuthPolicy) { p.Spec.ModelRefs = nil },
+			errContains: "spec.modelRefs: Required value",
+		},
+		{
+			name: "duplicate modelRef",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.ModelRefs = append(p.Spec.ModelRefs, p.Spec.ModelRefs[0])
+			},
+			errContains: "spec.modelRefs[1]: Duplicate value",
+		},
+		{
+			name:        "malformed model name",
+			mutate:      func(p *maasv1alpha1.MaaSAuthPolicy) { p.Spec.ModelRefs[0].Name = "Granite_3B" },
+			errContains: "spec.modelRefs[0].name",
+		},
+		{
+			name:        "malformed model namespace",
+			mutate:      func(p *maasv1alpha1.MaaSAuthPolicy) { p.Spec.ModelRefs[0].Namespace = "llm.models" },
+			errContains: "spec.modelRefs[0].namespace",
+		},
+		{
+			name:        "no subjects",
+			mutate:      func(p *maasv1alpha1.MaaSAuthPolicy) { p.Spec.Subjects = maasv1alpha1.SubjectSpec{} },
+			errContains: "spec.subjects: Required value",
+		},
+		{
+			name: "no subjects with subjectAccessReview",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.Subjects = maasv1alpha1.SubjectSpec{}
+				p.Spec.Authorization = &maasv1alpha1.AuthorizationSpec{SubjectAccessReview: &maasv1alpha1.SubjectAccessReviewAuthorization{}}
+			},
+		},
+		{
+			name: "only denied users",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.Subjects = maasv1alpha1.SubjectSpec{DeniedUsers: []string{"mallory"}}
+			},
+		},
+		{
+			name: "empty group name",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.Subjects.Groups = append(p.Spec.Subjects.Groups, maasv1alpha1.GroupReference{})
+			},
+			errContains: "spec.subjects.groups[1].name",
+		},
+		{
+			name:        "user unsafe in expressions",
+			mutate:      func(p *maasv1alpha1.MaaSAuthPolicy) { p.Spec.Subjects.Users = []string{`alice"`} },
+			errContains: "unsafe for CEL expressions",
+		},
+		{
+			name: "access window in an unknown time zone",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.AccessWindows = []maasv1alpha1.AccessWindow{{Start: "09:00", End: "17:00", TimeZone: "Mars/Olympus"}}
+			},
+			errContains: "spec.accessWindows",
+		},
+		{
+			name: "valid allowed CIDRs",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.AllowedCIDRs = []string{"10.0.0.0/8"}
+				p.Spec.ModelRefs[0].AllowedCIDRs = []string{"10.20.0.0/16"}
+			},
+		},
+		{
+			name: "IPv6 model allowed CIDR",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.ModelRefs[0].AllowedCIDRs = []string{"fd00::/8"}
+			},
+			errContains: "spec.modelRefs[0].allowedCIDRs",
+		},
+		{
+			name: "invalid allowed CIDR",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.AllowedCIDRs = []string{"10.0.0.0/33"}
+			},
+			errContains: "spec.allowedCIDRs",
+		},
+		{
+			name: "valid deny response",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.DenyResponse = &maasv1alpha1.DenyResponses{Unauthorized: &maasv1alpha1.DenyResponse{
+					Body:    `{"error": {"message": "{{message}}", "docs": "{{docsUrl}}"}}`,
+					DocsURL: "https://docs.example.com/access",
+				}}
+			},
+		},
+		{
+			name: "deny response body not JSON",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.DenyResponse = &maasv1alpha1.DenyResponses{Unauthenticated: &maasv1alpha1.DenyResponse{Body: `{"error": {{reason}}`}}
+			},
+			errContains: "spec.denyResponse",
+		},
+		{
+			name: "valid metering labels",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.MeteringMetadata = &maasv1alpha1.MeteringMetadata{Labels: map[string]string{"billing.example.com/project": "llm-eval", "team": "a"}}
+			},
+		},
+		{
+			name: "invalid metering label key",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.MeteringMetadata = &maasv1alpha1.MeteringMetadata{Labels: map[string]string{"cost center": "42"}}
+			},
+			errContains: "spec.meteringMetadata.labels",
+		},
+		{
+			name: "metering cost center with a line break",
+			mutate: func(p *maasv1alpha1.MaaSAuthPolicy) {
+				p.Spec.MeteringMetadata = &maasv1alpha1.MeteringMetadata{CostCenter: "cc-42\r\nX-Injected: 1"}
+			},
+			errContains: "spec.meteringMetadata",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := validAuthPolicy("ai-tenant-test")
+			tt.mutate(policy)
+			err := validateAuthPolicySpec(policy)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateAuthPolicySpec() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.errContains) {
+				t.Errorf("validateAuthPolicySpec() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestMaaSAuthPolicyValidator_MissingModelWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = maasv1alpha1.AddToScheme(scheme)
+
+	policy := validAuthPolicy("ai-tenant-test")
+	policy.Spec.ModelRefs = append(policy.Spec.ModelRefs, maasv1alpha1.ModelRef{Name: "granite", Namespace: "models"})
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&maasv1alpha1.MaaSModelRef{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "models"},
+	}).Build()
+	validator := &MaaSAuthPolicyValidator{Client: client, Validator: &TenantNamespaceValidator{Client: client}}
+
+	warnings, err := validator.ValidateUpdate(context.Background(), validAuthPolicy("ai-tenant-test"), policy)
+	if err != nil {
+		t.Fatalf("ValidateUpdate() unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || !contains(warnings[0], "models/granite") {
+		t.Errorf("warnings = %v, want one for the missing models/granite", warnings)
+	}
+}
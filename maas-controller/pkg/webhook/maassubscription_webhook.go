@@ -20,17 +20,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	maasv1alpha1 "github.com/opendatahub-io/models-as-a-service/maas-controller/api/maas/v1alpha1"
+	"github.com/opendatahub-io/models-as-a-service/maas-controller/pkg/controller/maas"
 )
 
 // MaaSSubscriptionValidator validates MaaSSubscription resources.
-// +kubebuilder:webhook:path=/validate-maas-opendatahub-io-v1alpha1-maassubscription,mutating=false,failurePolicy=fail,sideEffects=None,groups=maas.opendatahub.io,resources=maassubscriptions,verbs=create,versions=v1alpha1,name=vmaassubscription.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-maas-opendatahub-io-v1alpha1-maassubscription,mutating=false,failurePolicy=fail,sideEffects=None,groups=maas.opendatahub.io,resources=maassubscriptions,verbs=create;update,versions=v1alpha1,name=vmaassubscription.kb.io,admissionReviewVersions=v1
 
 type MaaSSubscriptionValidator struct {
 	Client    client.Reader
@@ -61,14 +67,26 @@ func (v *MaaSSubscriptionValidator) ValidateCreate(ctx context.Context, obj runt
 		return nil, fmt.Errorf("%s", message)
 	}
 
-	return nil, nil
+	return nil, validateSubscriptionSpec(sub)
 }
 
 // ValidateUpdate validates MaaSSubscription on update.
-// Namespace cannot be changed on update (Kubernetes enforces this), so we don't need to validate.
+// Namespace cannot be changed on update (Kubernetes enforces this), so only the spec is
+// validated, and only when it changes: subscriptions stored before this validation
+// existed must stay updatable, e.g. to remove a finalizer.
 func (v *MaaSSubscriptionValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	// No validation needed - namespace is immutable
-	return nil, nil
+	oldSub, ok := oldObj.(*maasv1alpha1.MaaSSubscription)
+	if !ok {
+		return nil, fmt.Errorf("expected MaaSSubscription object for old, got %T", oldObj)
+	}
+	newSub, ok := newObj.(*maasv1alpha1.MaaSSubscription)
+	if !ok {
+		return nil, fmt.Errorf("expected MaaSSubscription object for new, got %T", newObj)
+	}
+	if equality.Semantic.DeepEqual(oldSub.Spec, newSub.Spec) {
+		return nil, nil
+	}
+	return nil, validateSubscriptionSpec(newSub)
 }
 
 // ValidateDelete validates MaaSSubscription on deletion.
@@ -77,3 +95,94 @@ func (v *MaaSSubscriptionValidator) ValidateDelete(ctx context.Context, obj runt
 	// No validation needed for deletion
 	return nil, nil
 }
+
+// validateModelRefName rejects partial wildcards: "*" must be the whole name, and a "*"
+// namespace is only allowed together with a "*" name.
+func validateModelRefName(ref maasv1alpha1.ModelSubscriptionRef) error {
+	if ref.Name != "*" && strings.Contains(ref.Name, "*") {
+		return errors.New(`name must be a model name or "*"`)
+	}
+	if ref.Namespace == "*" && ref.Name != "*" {
+		return errors.New(`namespace "*" requires name "*"`)
+	}
+	if ref.Namespace != "*" && strings.Contains(ref.Namespace, "*") {
+		return errors.New(`namespace must be a namespace name or "*"`)
+	}
+	return nil
+}
+
+// validateSubscriptionSpec rejects subscriptions the controller would refuse to turn into
+// rate limit policies: no owner with groups, users or service accounts, a model
+// referenced twice, and rate limits that are malformed or that Kuadrant could not enforce
+// together, checked with the same rules the controller applies. A modelSelector's limits
+// follow the rules of a modelRef. Notification webhooks must be http or https URLs.
+func validateSubscriptionSpec(sub *maasv1alpha1.MaaSSubscription) error {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+
+	owner := sub.Spec.Owner
+	if len(owner.Groups) == 0 && len(owner.Users) == 0 && len(owner.ServiceAccounts) == 0 && len(sub.Spec.Owners) == 0 {
+		errs = append(errs, field.Required(spec.Child("owner"), "at least one group, user or service account must own the subscription"))
+	}
+	for i, owner := range sub.Spec.Owners {
+		if err := maas.ValidateSubscriptionOwner(owner, sub.Spec.ResetSchedule); err != nil {
+			errs = append(errs, field.Invalid(spec.Child("owners").Index(i), owner.Name, err.Error()))
+		}
+	}
+	if err := maas.ValidateResetSchedule(sub.Spec.ResetSchedule); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("resetSchedule", "timeZone"), sub.Spec.ResetSchedule.TimeZone, err.Error()))
+	}
+	if err := maas.ValidateCounterExpressions(sub.Spec.CounterExpressions); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("counterExpressions"), sub.Spec.CounterExpressions, err.Error()))
+	}
+	if err := maas.ValidateNotifications(sub.Spec.Notifications); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("notifications"), sub.Spec.Notifications, err.Error()))
+	}
+	if err := maas.ValidateLimitGroups(sub); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("limitGroups"), len(sub.Spec.LimitGroups), err.Error()))
+	}
+
+	seen := make(map[string]struct{}, len(sub.Spec.ModelRefs))
+	for i, ref := range sub.Spec.ModelRefs {
+		path := spec.Child("modelRefs").Index(i)
+		key := ref.Namespace + "/" + ref.Name
+		if _, ok := seen[key]; ok {
+			errs = append(errs, field.Duplicate(path, key))
+			continue
+		}
+		seen[key] = struct{}{}
+		if err := validateModelRefName(ref); err != nil {
+			errs = append(errs, field.Invalid(path, key, err.Error()))
+			continue
+		}
+		if len(ref.TokenRateLimits) == 0 {
+			errs = append(errs, field.Required(path.Child("tokenRateLimits"), "at least one token rate limit is required"))
+			continue
+		}
+		if err := maas.ValidateModelRefRateLimits(ref, sub.Spec.ResetSchedule); err != nil {
+			errs = append(errs, field.Invalid(path, key, err.Error()))
+		}
+	}
+
+	if sel := sub.Spec.ModelSelector; sel != nil {
+		path := spec.Child("modelSelector")
+		if _, err := metav1.LabelSelectorAsSelector(&sel.Selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), sel.Selector, err.Error()))
+		}
+		if len(sel.TokenRateLimits) == 0 {
+			errs = append(errs, field.Required(path.Child("tokenRateLimits"), "at least one token rate limit is required"))
+		} else {
+			ref := maasv1alpha1.ModelSubscriptionRef{TokenRateLimits: sel.TokenRateLimits, RequestRateLimits: sel.RequestRateLimits}
+			if err := maas.ValidateModelRefRateLimits(ref, sub.Spec.ResetSchedule); err != nil {
+				errs = append(errs, field.Invalid(path, metav1.FormatLabelSelector(&sel.Selector), err.Error()))
+			}
+		}
+	} else if len(sub.Spec.ModelRefs) == 0 && sub.Spec.TemplateRef == nil {
+		errs = append(errs, field.Required(spec.Child("modelRefs"), "modelRefs, modelSelector or templateRef is required"))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(maasv1alpha1.GroupVersion.WithKind("MaaSSubscription").GroupKind(), sub.Name, errs)
+}
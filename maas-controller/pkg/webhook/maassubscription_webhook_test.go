@@ -42,13 +42,8 @@ func TestMaaSSubscriptionValidator_ValidateCreate(t *testing.T) {
 		errContains  string
 	}{
 		{
-			name: "allow subscription in namespace with Tenant CR",
-			subscription: &maasv1alpha1.MaaSSubscription{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-sub",
-					Namespace: "ai-tenant-redteam",
-				},
-			},
+			name:         "allow subscription in namespace with Tenant CR",
+			subscription: validSubscription("ai-tenant-redteam"),
 			tenant: &maasv1alpha1.Tenant{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "default-tenant",
@@ -115,25 +110,28 @@ func TestMaaSSubscriptionValidator_ValidateUpdate(t *testing.T) {
 		},
 	}
 
-	oldSub := &maasv1alpha1.MaaSSubscription{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-sub",
-			Namespace: "ai-tenant-test",
-		},
-	}
-
-	newSub := &maasv1alpha1.MaaSSubscription{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-sub",
-			Namespace: "ai-tenant-test",
-		},
-	}
+	oldSub := validSubscription("ai-tenant-test")
+	newSub := validSubscription("ai-tenant-test")
 
 	// Update should not validate namespace (it's immutable)
 	_, err := validator.ValidateUpdate(context.Background(), oldSub, newSub)
 	if err != nil {
 		t.Errorf("ValidateUpdate() unexpected error: %v", err)
 	}
+
+	// A spec change is validated.
+	newSub.Spec.ModelRefs = append(newSub.Spec.ModelRefs, newSub.Spec.ModelRefs[0])
+	if _, err := validator.ValidateUpdate(context.Background(), oldSub, newSub); err == nil {
+		t.Error("ValidateUpdate() expected an error for a duplicate modelRef")
+	}
+
+	// An unchanged invalid spec, e.g. stored before the webhook, stays updatable.
+	oldSub.Spec.Owner = maasv1alpha1.OwnerSpec{}
+	legacy := oldSub.DeepCopy()
+	legacy.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), oldSub, legacy); err != nil {
+		t.Errorf("ValidateUpdate() unexpected error for a metadata-only update: %v", err)
+	}
 }
 
 func TestMaaSSubscriptionValidator_ValidateDelete(t *testing.T) {
@@ -162,3 +160,262 @@ func TestMaaSSubscriptionValidator_ValidateDelete(t *testing.T) {
 		t.Errorf("ValidateDelete() unexpected error: %v", err)
 	}
 }
+
+// validSubscription returns a MaaSSubscription that passes spec validation.
+func validSubscription(namespace string) *maasv1alpha1.MaaSSubscription {
+	return &maasv1alpha1.MaaSSubscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-sub",
+			Namespace:  namespace,
+			Finalizers: []string{"maas.opendatahub.io/subscription-cleanup"},
+		},
+		Spec: maasv1alpha1.MaaSSubscriptionSpec{
+			Owner: maasv1alpha1.OwnerSpec{Groups: []maasv1alpha1.GroupReference{{Name: "team-a"}}},
+			ModelRefs: []maasv1alpha1.ModelSubscriptionRef{{
+				Name:            "llm",
+				Namespace:       "models",
+				TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}},
+			}},
+		},
+	}
+}
+
+func TestValidateSubscriptionSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(*maasv1alpha1.MaaSSubscription)
+		errContains string
+	}{
+		{name: "valid", mutate: func(*maasv1alpha1.MaaSSubscription) {}},
+		{
+			name:        "empty owner",
+			mutate:      func(s *maasv1alpha1.MaaSSubscription) { s.Spec.Owner = maasv1alpha1.OwnerSpec{} },
+			errContains: "spec.owner",
+		},
+		{
+			name: "service account owner",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.Owner = maasv1alpha1.OwnerSpec{ServiceAccounts: []maasv1alpha1.ServiceAccountReference{{Namespace: "batch", Name: "pipeline"}}}
+			},
+		},
+		{
+			name: "owners instead of owner",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.Owner = maasv1alpha1.OwnerSpec{}
+				s.Spec.Owners = []maasv1alpha1.SubscriptionOwner{{
+					Name:            "gold",
+					OwnerSpec:       maasv1alpha1.OwnerSpec{Groups: []maasv1alpha1.GroupReference{{Name: "gold-team"}}},
+					TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 5000, Window: "1m"}},
+				}}
+			},
+		},
+		{
+			name: "owner without groups or users",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.Owners = []maasv1alpha1.SubscriptionOwner{{
+					Name:            "gold",
+					TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 5000, Window: "1m"}},
+				}}
+			},
+			errContains: "spec.owners[0]",
+		},
+		{
+			name: "duplicate modelRef",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs = append(s.Spec.ModelRefs, s.Spec.ModelRefs[0])
+			},
+			errContains: "spec.modelRefs[1]: Duplicate value",
+		},
+		{
+			name: "malformed window",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].TokenRateLimits[0].Window = "1d"
+			},
+			errContains: "invalid window format",
+		},
+		{
+			name: "non-positive limit",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].RequestRateLimits = []maasv1alpha1.RequestRateLimit{{Limit: 0, Window: "1m"}}
+			},
+			errContains: "must be positive",
+		},
+		{
+			name: "longer window with a smaller limit",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].TokenRateLimits = append(s.Spec.ModelRefs[0].TokenRateLimits, maasv1alpha1.TokenRateLimit{Limit: 500, Window: "1h"})
+			},
+			errContains: "must be larger",
+		},
+		{
+			name: "output limit over the same window",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].TokenRateLimits = append(s.Spec.ModelRefs[0].TokenRateLimits, maasv1alpha1.TokenRateLimit{Limit: 10, Window: "1m", Direction: maasv1alpha1.TokenDirectionOutput})
+			},
+		},
+		{
+			name: "output rates with a smaller longer-window limit",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].TokenRateLimits = append(s.Spec.ModelRefs[0].TokenRateLimits,
+					maasv1alpha1.TokenRateLimit{Limit: 500, Window: "1m", Direction: maasv1alpha1.TokenDirectionOutput},
+					maasv1alpha1.TokenRateLimit{Limit: 100, Window: "1h", Direction: maasv1alpha1.TokenDirectionOutput})
+			},
+			errContains: "output rates: limit 100/1h must be larger",
+		},
+		{
+			name: "missing token rate limits",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].TokenRateLimits = nil
+			},
+			errContains: "spec.modelRefs[0].tokenRateLimits",
+		},
+		{
+			name: "cost budget",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].CostBudget = &maasv1alpha1.CostBudget{Amount: "25.50", Currency: "USD", Window: "24h"}
+			},
+		},
+		{
+			name: "zero cost budget",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs[0].CostBudget = &maasv1alpha1.CostBudget{Amount: "0", Window: "24h"}
+			},
+			errContains: "invalid costBudget amount",
+		},
+		{
+			name: "counter expressions",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.CounterExpressions = []string{"auth.identity.keyId", `request.headers["x-org-id"]`}
+			},
+		},
+		{
+			name: "arbitrary counter expression",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.CounterExpressions = []string{"request.body"}
+			},
+			errContains: "spec.counterExpressions",
+		},
+		{
+			name: "notifications",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.Notifications = &maasv1alpha1.NotificationSpec{
+					Webhooks:   []maasv1alpha1.NotificationWebhook{{URL: "https://hooks.example.com/maas"}},
+					Thresholds: []int32{75, 100},
+				}
+			},
+		},
+		{
+			name: "duplicate notification threshold",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.Notifications = &maasv1alpha1.NotificationSpec{
+					Webhooks:   []maasv1alpha1.NotificationWebhook{{URL: "https://hooks.example.com/maas"}},
+					Thresholds: []int32{90, 90},
+				}
+			},
+			errContains: "spec.notifications",
+		},
+		{
+			name: "unknown time zone",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ResetSchedule = &maasv1alpha1.ResetSchedule{Period: maasv1alpha1.ResetPeriodDaily, TimeZone: "Not/AZone"}
+			},
+			errContains: "spec.resetSchedule.timeZone",
+		},
+		{
+			name: "wildcard modelRefs",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelRefs = append(s.Spec.ModelRefs,
+					maasv1alpha1.ModelSubscriptionRef{Name: "*", Namespace: "*", TokenRateLimits: s.Spec.ModelRefs[0].TokenRateLimits},
+					maasv1alpha1.ModelSubscriptionRef{Name: "*", Namespace: "llm", TokenRateLimits: s.Spec.ModelRefs[0].TokenRateLimits},
+				)
+			},
+		},
+		{
+			name:        "partial wildcard name",
+			mutate:      func(s *maasv1alpha1.MaaSSubscription) { s.Spec.ModelRefs[0].Name = "granite-*" },
+			errContains: `name must be a model name or "*"`,
+		},
+		{
+			name:        "wildcard namespace with a model name",
+			mutate:      func(s *maasv1alpha1.MaaSSubscription) { s.Spec.ModelRefs[0].Namespace = "*" },
+			errContains: `namespace "*" requires name "*"`,
+		},
+		{
+			name: "modelSelector instead of modelRefs",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelSelector = &maasv1alpha1.ModelSelector{
+					Selector:        metav1.LabelSelector{MatchLabels: map[string]string{"family": "granite"}},
+					TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}},
+				}
+				s.Spec.ModelRefs = nil
+			},
+		},
+		{
+			name:        "neither modelRefs nor modelSelector",
+			mutate:      func(s *maasv1alpha1.MaaSSubscription) { s.Spec.ModelRefs = nil },
+			errContains: "spec.modelRefs: Required value",
+		},
+		{
+			name: "templateRef instead of modelRefs",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.TemplateRef = &maasv1alpha1.SubscriptionTemplateReference{Name: "pro"}
+				s.Spec.ModelRefs = nil
+			},
+		},
+		{
+			name: "invalid modelSelector",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelSelector = &maasv1alpha1.ModelSelector{
+					Selector:        metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "family", Operator: "Near"}}},
+					TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000, Window: "1m"}},
+				}
+			},
+			errContains: "spec.modelSelector.selector",
+		},
+		{
+			name: "modelSelector without tokenRateLimits",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.ModelSelector = &maasv1alpha1.ModelSelector{}
+			},
+			errContains: "spec.modelSelector.tokenRateLimits",
+		},
+		{
+			name: "limit group of listed models",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				ref := s.Spec.ModelRefs[0]
+				s.Spec.LimitGroups = []maasv1alpha1.LimitGroup{{
+					Name:            "small",
+					ModelRefs:       []maasv1alpha1.ModelRef{{Name: ref.Name, Namespace: ref.Namespace}},
+					TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000000, Window: "24h"}},
+				}}
+			},
+		},
+		{
+			name: "limit group of an unlisted model",
+			mutate: func(s *maasv1alpha1.MaaSSubscription) {
+				s.Spec.LimitGroups = []maasv1alpha1.LimitGroup{{
+					Name:            "small",
+					ModelRefs:       []maasv1alpha1.ModelRef{{Name: "other", Namespace: "llm"}},
+					TokenRateLimits: []maasv1alpha1.TokenRateLimit{{Limit: 1000000, Window: "24h"}},
+				}}
+			},
+			errContains: "spec.limitGroups",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := validSubscription("ai-tenant-test")
+			tt.mutate(sub)
+			err := validateSubscriptionSpec(sub)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("validateSubscriptionSpec() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.errContains) {
+				t.Errorf("validateSubscriptionSpec() error = %v, want error containing %q", err, tt.errContains)
+			}
+		})
+	}
+}
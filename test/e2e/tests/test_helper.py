@@ -39,6 +39,7 @@ Environment variables (all optional unless noted):
 """
 
 import base64
+import hashlib
 import json
 import logging
 import os
@@ -655,6 +656,29 @@ def completions(prompt: str, model_v1: str, headers: dict, model_name: str):
     return requests.post(url, headers=headers, json=body, timeout=30, verify=TLS_VERIFY)
 
 
+# ---------------------------------------------------------------------------
+# Generated Resource Names
+# ---------------------------------------------------------------------------
+
+def _generated_policy_name(prefix, model_ref, model_namespace=MODEL_NAMESPACE, route=""):
+    """Name of a policy maas-controller generates for a model (see policy_names.go).
+
+    The readable part (the route for mirrored policies, else the model) is trimmed to
+    keep the name within 63 characters, followed by a hash of the model and route.
+    """
+    digest = hashlib.sha256(f"{model_namespace}/{model_ref}/{route}".encode()).hexdigest()[:8]
+    readable = route or model_ref
+    budget = 63 - len(prefix) - 1 - 9
+    if len(readable) > budget:
+        readable = readable[:budget].rstrip("-.")
+    return f"{prefix}-{readable}-{digest}"
+
+
+def _trlp_name(model_ref, model_namespace=MODEL_NAMESPACE):
+    """Name of the TokenRateLimitPolicy maas-controller generates for a model."""
+    return _generated_policy_name("maas-trlp", model_ref, model_namespace)
+
+
 # ---------------------------------------------------------------------------
 # Wait / Polling Helpers
 # ---------------------------------------------------------------------------
@@ -674,7 +698,7 @@ def _wait_for_token_rate_limit_policy(model_ref, model_namespace=MODEL_NAMESPACE
     Raises:
         TimeoutError: If TRLP isn't created and enforced within timeout
     """
-    trlp_name = f"maas-trlp-{model_ref}"
+    trlp_name = _trlp_name(model_ref, model_namespace)
     deadline = time.time() + timeout
     log.info(f"Waiting for TokenRateLimitPolicy {trlp_name} in {model_namespace} (timeout: {timeout}s)...")
 
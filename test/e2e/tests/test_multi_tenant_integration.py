@@ -51,7 +51,7 @@ from multitenancy_helpers import (
     wait_for_not_found,
     wait_for_status_phase,
 )
-from test_helper import _wait_reconcile
+from test_helper import _trlp_name, _wait_reconcile
 
 
 @pytest.fixture(scope="module", autouse=True)
@@ -156,7 +156,7 @@ class TestMultiTenantIntegration:
             expected_subs = [f"{case_a['tenant_ns']}/{shared_sub}", f"{case_b['tenant_ns']}/{shared_sub}"]
             wait_for_annotation_contains(
                 "tokenratelimitpolicy",
-                f"maas-trlp-{MODEL_REF}",
+                _trlp_name(MODEL_REF, MODEL_NAMESPACE),
                 MODEL_NAMESPACE,
                 "maas.opendatahub.io/subscriptions",
                 expected_subs,
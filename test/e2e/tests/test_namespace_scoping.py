@@ -41,6 +41,7 @@ from test_helper import (
     _maas_api_url,
     _ns,
     _revoke_api_key,
+    _trlp_name,
     _wait_for_maas_auth_policy_phase,
     _wait_for_maas_subscription_phase,
     _wait_reconcile,
@@ -305,7 +306,7 @@ class TestMaaSControllerWatchNamespace:
             _wait_for_maas_auth_policy_phase("e2e-watched-auth", timeout=90)
             _wait_for_maas_subscription_phase("e2e-watched-sub", namespace=ns, timeout=90)
 
-            trlp_name = f"maas-trlp-{MODEL_REF}"
+            trlp_name = _trlp_name(MODEL_REF)
             subscriptions = [x.strip() for x in (_get_cr_annotation("tokenratelimitpolicy", trlp_name, MODEL_NAMESPACE, "maas.opendatahub.io/subscriptions") or "").split(",") if x.strip()]
             expected_sub = f"{ns}/e2e-watched-sub"
             assert expected_sub in subscriptions, (
@@ -357,7 +358,7 @@ class TestMaaSControllerWatchNamespace:
                 f"but '{unwatched_auth}' found in {auth_policies}"
             )
 
-            trlp_name = f"maas-trlp-{MODEL_REF}"
+            trlp_name = _trlp_name(MODEL_REF)
             subscriptions = [x.strip() for x in (_get_cr_annotation("tokenratelimitpolicy", trlp_name, MODEL_NAMESPACE, "maas.opendatahub.io/subscriptions") or "").split(",") if x.strip()]
             unwatched_sub = f"{ns}/e2e-unwatched-sub"
             assert unwatched_sub not in subscriptions, (
@@ -498,8 +499,8 @@ class TestModelRef:
 
             _wait_reconcile(15)
 
-            trlp_name = f"maas-trlp-{MODEL_REF}"
-            trlp_name_other = f"maas-trlp-{other_model_ref}"
+            trlp_name = _trlp_name(MODEL_REF)
+            trlp_name_other = _trlp_name(other_model_ref)
 
             # Verify: subscription is reconciled into MODEL_REF's TRLP in MODEL_NAMESPACE
             subscriptions_in_model_ns = [x.strip() for x in (_get_cr_annotation("tokenratelimitpolicy", trlp_name, MODEL_NAMESPACE, "maas.opendatahub.io/subscriptions") or "").split(",") if x.strip()]
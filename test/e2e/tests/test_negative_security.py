@@ -46,6 +46,7 @@ from test_helper import (
     _create_test_auth_policy,
     _create_test_subscription,
     _delete_cr,
+    _trlp_name,
     _gateway_url,
     _get_cluster_token,
     _get_cr,
@@ -336,7 +337,7 @@ class TestMissingModelRef:
             _wait_for_maas_subscription_phase(sub_name, "Degraded", timeout=60)
 
             # No TRLP should exist for the ghost model
-            ghost_trlp_name = f"maas-trlp-{ghost_model}"
+            ghost_trlp_name = _trlp_name(ghost_model)
             ghost_trlp = _get_cr("tokenratelimitpolicy", ghost_trlp_name, namespace=MODEL_NAMESPACE)
             log.info("Ghost model TRLP exists: %s", ghost_trlp is not None)
             assert ghost_trlp is None, (
@@ -344,7 +345,7 @@ class TestMissingModelRef:
             )
 
             # TRLP should exist for the valid model
-            valid_trlp_name = f"maas-trlp-{MODEL_REF}"
+            valid_trlp_name = _trlp_name(MODEL_REF)
             valid_trlp = _get_cr("tokenratelimitpolicy", valid_trlp_name, namespace=MODEL_NAMESPACE)
             log.info("Valid model TRLP exists: %s", valid_trlp is not None)
             assert valid_trlp is not None, (
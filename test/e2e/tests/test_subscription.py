@@ -73,6 +73,7 @@ from test_helper import (
     _create_test_auth_policy,
     _create_test_subscription,
     _delete_cr,
+    _trlp_name,
     _delete_sa,
     _gateway_url,
     _get_auth_policies_for_model,
@@ -103,7 +104,7 @@ PREMIUM_MODEL_PATH = os.environ.get("E2E_PREMIUM_MODEL_PATH", "/llm/premium-simu
 
 # Generated resource names (for TestManagedAnnotation)
 AUTH_POLICY_NAME = f"maas-auth-{MODEL_REF}"
-TRLP_NAME = f"maas-trlp-{MODEL_REF}"
+TRLP_NAME = _trlp_name(MODEL_REF)
 MANAGED_ANNOTATION = "opendatahub.io/managed"
 GATEWAY_PROPAGATION_RETRIES = 6
 GATEWAY_PROPAGATION_DELAY = 5
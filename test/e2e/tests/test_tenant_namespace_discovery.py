@@ -62,7 +62,7 @@ from multitenancy_helpers import (
     _create_expect_failure,
     _oc_run,
 )
-from test_helper import MODEL_NAMESPACE, MODEL_REF, _wait_for_maas_auth_policy_phase, _wait_reconcile
+from test_helper import MODEL_NAMESPACE, MODEL_REF, _trlp_name, _wait_for_maas_auth_policy_phase, _wait_reconcile
 
 
 @pytest.fixture(scope="module", autouse=True)
@@ -237,7 +237,7 @@ class TestTenantNamespaceDiscovery:
             expected_b_sub = f"{case_b['tenant_ns']}/{shared_sub_name}"
             sub_contributors = wait_for_annotation_contains(
                 "tokenratelimitpolicy",
-                f"maas-trlp-{MODEL_REF}",
+                _trlp_name(MODEL_REF, MODEL_NAMESPACE),
                 MODEL_NAMESPACE,
                 "maas.opendatahub.io/subscriptions",
                 [expected_a_sub, expected_b_sub],